@@ -0,0 +1,58 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned by Get when no object exists for the requested key.
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore is a simple key/value store for large binary payloads (block bodies, sidecars, ...)
+// that should not be kept in the relational database.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type Config struct {
+	Provider  string
+	Path      string
+	Endpoint  string
+	Region    string
+	Bucket    string
+	Prefix    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool
+	AuthToken string
+}
+
+// NewBlobStore creates a blob store for the configured provider.
+// Returns nil (without error) if no provider is configured, which means payloads are kept in the database.
+func NewBlobStore(config *Config) (BlobStore, error) {
+	switch config.Provider {
+	case "", "db", "none":
+		return nil, nil
+	case "fs", "filesystem":
+		return NewFsStore(config.Path, config.Prefix)
+	case "s3":
+		return NewS3Store(config)
+	case "gcs":
+		// GCS is accessed via its S3 compatible XML API (requires HMAC keys)
+		gcsConfig := *config
+		if gcsConfig.Endpoint == "" {
+			gcsConfig.Endpoint = "https://storage.googleapis.com"
+		}
+		if gcsConfig.Region == "" {
+			gcsConfig.Region = "auto"
+		}
+		return NewS3Store(&gcsConfig)
+	case "http":
+		return NewHttpStore(config)
+	default:
+		return nil, fmt.Errorf("unknown blob store provider: %v", config.Provider)
+	}
+}
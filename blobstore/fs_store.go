@@ -0,0 +1,127 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type FsStore struct {
+	basePath string
+}
+
+func NewFsStore(basePath string, prefix string) (*FsStore, error) {
+	if basePath == "" {
+		return nil, fmt.Errorf("blob store path not set")
+	}
+
+	if prefix != "" {
+		basePath = filepath.Join(basePath, prefix)
+	}
+
+	err := os.MkdirAll(basePath, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating blob store directory: %w", err)
+	}
+
+	return &FsStore{
+		basePath: basePath,
+	}, nil
+}
+
+func (store *FsStore) getFilePath(key string) (string, error) {
+	if key == "" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid blob key: %v", key)
+	}
+
+	return filepath.Join(store.basePath, filepath.FromSlash(key)), nil
+}
+
+func (store *FsStore) Put(ctx context.Context, key string, data []byte) error {
+	filePath, err := store.getFilePath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so readers never see partially written blobs
+	tmpFile := fmt.Sprintf("%v.tmp", filePath)
+	err = os.WriteFile(tmpFile, data, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, filePath)
+}
+
+func (store *FsStore) Get(ctx context.Context, key string) ([]byte, error) {
+	filePath, err := store.getFilePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	return data, err
+}
+
+func (store *FsStore) Delete(ctx context.Context, key string) error {
+	filePath, err := store.getFilePath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// PruneBefore removes all blobs that have been written before the given time and returns the number of removed blobs.
+func (store *FsStore) PruneBefore(before time.Time) (uint64, error) {
+	pruned := uint64(0)
+	err := filepath.WalkDir(store.basePath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.ModTime().Before(before) {
+			return nil
+		}
+
+		err = os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		pruned++
+		return nil
+	})
+
+	return pruned, err
+}
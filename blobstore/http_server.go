@@ -0,0 +1,90 @@
+package blobstore
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxHttpBlobSize is the max size of a blob that is accepted by the http store handler
+const maxHttpBlobSize = 128 * 1024 * 1024
+
+// HttpStoreHandler serves a blob store via the protocol used by HttpStore, so multiple dora instances can share one body store.
+type HttpStoreHandler struct {
+	store         BlobStore
+	authToken     string
+	ignoreDeletes bool // deletes are acknowledged but ignored, blobs are expired by the service instead
+}
+
+func NewHttpStoreHandler(store BlobStore, authToken string, ignoreDeletes bool) *HttpStoreHandler {
+	return &HttpStoreHandler{
+		store:         store,
+		authToken:     authToken,
+		ignoreDeletes: ignoreDeletes,
+	}
+}
+
+func (handler *HttpStoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if handler.authToken != "" {
+		authToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(authToken), []byte(handler.authToken)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	if key == "" || strings.Contains(key, "..") {
+		http.Error(w, "invalid blob key", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		data, err := handler.store.Get(r.Context(), key)
+		if errors.Is(err, ErrNotFound) {
+			http.Error(w, "blob not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error reading blob: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(data)
+	case http.MethodPut:
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxHttpBlobSize+1))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error reading request body: %v", err), http.StatusBadRequest)
+			return
+		}
+		if len(data) > maxHttpBlobSize {
+			http.Error(w, "blob too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		err = handler.store.Put(r.Context(), key, data)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error writing blob: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if !handler.ignoreDeletes {
+			err := handler.store.Delete(r.Context(), key)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("error deleting blob: %v", err), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,122 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HttpStore stores blobs in a remote body store service (see the `body-store` subcommand).
+// The service exposes each blob as plain http resource: GET / PUT / DELETE {endpoint}/{prefix}/{key}.
+// Multiple dora instances can share one service, blob keys are derived from block roots and are the same on all instances.
+type HttpStore struct {
+	client    *http.Client
+	baseUrl   *url.URL
+	prefix    string
+	authToken string
+}
+
+func NewHttpStore(config *Config) (*HttpStore, error) {
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("blob store endpoint not set")
+	}
+
+	baseUrl, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob store endpoint: %w", err)
+	}
+
+	return &HttpStore{
+		client: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+		baseUrl:   baseUrl,
+		prefix:    strings.Trim(config.Prefix, "/"),
+		authToken: config.AuthToken,
+	}, nil
+}
+
+func (store *HttpStore) getObjectUrl(key string) string {
+	objectPath := key
+	if store.prefix != "" {
+		objectPath = store.prefix + "/" + key
+	}
+
+	objectUrl := *store.baseUrl
+	objectUrl.Path = strings.TrimRight(objectUrl.Path, "/") + "/" + objectPath
+	return objectUrl.String()
+}
+
+func (store *HttpStore) doRequest(ctx context.Context, method string, key string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, store.getObjectUrl(key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.ContentLength = int64(len(body))
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	if store.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+store.authToken)
+	}
+
+	return store.client.Do(req)
+}
+
+func (store *HttpStore) Put(ctx context.Context, key string, data []byte) error {
+	if data == nil {
+		data = []byte{}
+	}
+
+	rsp, err := store.doRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK && rsp.StatusCode != http.StatusCreated && rsp.StatusCode != http.StatusNoContent {
+		errBody, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return fmt.Errorf("body store put %v failed: %v %v", key, rsp.Status, string(errBody))
+	}
+
+	return nil
+}
+
+func (store *HttpStore) Get(ctx context.Context, key string) ([]byte, error) {
+	rsp, err := store.doRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if rsp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return nil, fmt.Errorf("body store get %v failed: %v %v", key, rsp.Status, string(errBody))
+	}
+
+	return io.ReadAll(rsp.Body)
+}
+
+func (store *HttpStore) Delete(ctx context.Context, key string) error {
+	rsp, err := store.doRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusNoContent && rsp.StatusCode != http.StatusOK && rsp.StatusCode != http.StatusNotFound {
+		errBody, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return fmt.Errorf("body store delete %v failed: %v %v", key, rsp.Status, string(errBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Store stores blobs in a S3 compatible object storage.
+// Requests are signed with AWS signature v4, which is also accepted by most S3 compatible services (minio, GCS interop, R2, ...).
+type S3Store struct {
+	client    *http.Client
+	baseUrl   *url.URL
+	region    string
+	bucket    string
+	prefix    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+}
+
+func NewS3Store(config *Config) (*S3Store, error) {
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("blob store bucket not set")
+	}
+
+	region := config.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%v.amazonaws.com", region)
+	}
+
+	baseUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob store endpoint: %w", err)
+	}
+
+	return &S3Store{
+		client: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+		baseUrl:   baseUrl,
+		region:    region,
+		bucket:    config.Bucket,
+		prefix:    strings.Trim(config.Prefix, "/"),
+		accessKey: config.AccessKey,
+		secretKey: config.SecretKey,
+		pathStyle: config.PathStyle,
+	}, nil
+}
+
+func (store *S3Store) getObjectUrl(key string) *url.URL {
+	objectPath := key
+	if store.prefix != "" {
+		objectPath = store.prefix + "/" + key
+	}
+
+	objectUrl := *store.baseUrl
+	if store.pathStyle {
+		objectUrl.Path = strings.TrimRight(objectUrl.Path, "/") + "/" + store.bucket + "/" + objectPath
+	} else {
+		objectUrl.Host = store.bucket + "." + objectUrl.Host
+		objectUrl.Path = strings.TrimRight(objectUrl.Path, "/") + "/" + objectPath
+	}
+
+	return &objectUrl
+}
+
+func (store *S3Store) doRequest(ctx context.Context, method string, key string, body []byte) (*http.Response, error) {
+	objectUrl := store.getObjectUrl(key)
+
+	req, err := http.NewRequestWithContext(ctx, method, objectUrl.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.ContentLength = int64(len(body))
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+
+	if store.accessKey != "" {
+		store.signRequest(req, objectUrl, body, time.Now().UTC())
+	}
+
+	return store.client.Do(req)
+}
+
+func (store *S3Store) signRequest(req *http.Request, objectUrl *url.URL, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+
+	payloadHash := sha256.Sum256(body)
+	payloadHashHex := hex.EncodeToString(payloadHash[:])
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHashHex)
+
+	canonicalHeaders := fmt.Sprintf("host:%v\nx-amz-content-sha256:%v\nx-amz-date:%v\n", objectUrl.Host, payloadHashHex, amzDate)
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(objectUrl.Path),
+		"",
+		canonicalHeaders,
+		signedHeaders,
+		payloadHashHex,
+	}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	credentialScope := fmt.Sprintf("%v/%v/s3/aws4_request", shortDate, store.region)
+	stringToSign := fmt.Sprintf("AWS4-HMAC-SHA256\n%v\n%v\n%v", amzDate, credentialScope, hex.EncodeToString(canonicalRequestHash[:]))
+
+	signingKey := s3HmacSha256([]byte("AWS4"+store.secretKey), shortDate)
+	signingKey = s3HmacSha256(signingKey, store.region)
+	signingKey = s3HmacSha256(signingKey, "s3")
+	signingKey = s3HmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(s3HmacSha256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%v/%v, SignedHeaders=%v, Signature=%v", store.accessKey, credentialScope, signedHeaders, signature))
+}
+
+func s3HmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapePath escapes a path as required by the canonical request format (RFC 3986, slashes preserved)
+func s3EscapePath(path string) string {
+	var res strings.Builder
+	for _, c := range []byte(path) {
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			res.WriteByte(c)
+		} else {
+			fmt.Fprintf(&res, "%%%02X", c)
+		}
+	}
+	return res.String()
+}
+
+func (store *S3Store) Put(ctx context.Context, key string, data []byte) error {
+	if data == nil {
+		data = []byte{}
+	}
+
+	rsp, err := store.doRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return fmt.Errorf("s3 put %v failed: %v %v", key, rsp.Status, string(errBody))
+	}
+
+	return nil
+}
+
+func (store *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	rsp, err := store.doRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if rsp.StatusCode != http.StatusOK {
+		errBody, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return nil, fmt.Errorf("s3 get %v failed: %v %v", key, rsp.Status, string(errBody))
+	}
+
+	return io.ReadAll(rsp.Body)
+}
+
+func (store *S3Store) Delete(ctx context.Context, key string) error {
+	rsp, err := store.doRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusNoContent && rsp.StatusCode != http.StatusOK && rsp.StatusCode != http.StatusNotFound {
+		errBody, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return fmt.Errorf("s3 delete %v failed: %v %v", key, rsp.Status, string(errBody))
+	}
+
+	return nil
+}
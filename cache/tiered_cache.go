@@ -40,6 +40,8 @@ type RemoteCache interface {
 	GetBool(ctx context.Context, key string) (bool, error)
 }
 
+// NewTieredCache creates a new tiered cache.
+// a cacheSize of 0 disables the local cache, so all values are stored in the remote cache only.
 func NewTieredCache(cacheSize int, redisAddress string, redisPrefix string) (*TieredCache, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
@@ -54,9 +56,16 @@ func NewTieredCache(cacheSize int, redisAddress string, redisPrefix string) (*Ti
 		}
 	}
 
+	var localGoCache *freecache.Cache
+	if cacheSize > 0 {
+		localGoCache = freecache.NewCache(cacheSize * 1024 * 1024) // 100 MB
+	} else if remoteCache == nil {
+		return nil, errors.New("tiered cache requires either a local or a remote cache")
+	}
+
 	return &TieredCache{
 		remoteCache:  remoteCache,
-		localGoCache: freecache.NewCache(cacheSize * 1024 * 1024), // 100 MB
+		localGoCache: localGoCache,
 	}, nil
 }
 
@@ -75,7 +84,9 @@ func (cache *TieredCache) Set(key string, value interface{}, expiration time.Dur
 	if err != nil {
 		return err
 	}
-	cache.localGoCache.Set([]byte(key), valueMarshal, int(expiration.Seconds()))
+	if cache.localGoCache != nil {
+		cache.localGoCache.Set([]byte(key), valueMarshal, int(expiration.Seconds()))
+	}
 	if cache.remoteCache != nil {
 		return cache.remoteCache.SetBytes(ctx, key, valueMarshal, expiration)
 	}
@@ -88,15 +99,17 @@ func (cache *TieredCache) Get(key string, returnValue interface{}) (interface{},
 	}
 
 	// try to retrieve the key from the local cache
-	wanted, err := cache.localGoCache.Get([]byte(key))
-	if err == nil {
-		err = json.Unmarshal([]byte(wanted), cacheValue)
-		if err != nil {
-			utils.LogError(err, "error unmarshalling data for key", 0, map[string]interface{}{"key": key})
-			return nil, err
+	if cache.localGoCache != nil {
+		wanted, err := cache.localGoCache.Get([]byte(key))
+		if err == nil {
+			err = json.Unmarshal([]byte(wanted), cacheValue)
+			if err != nil {
+				utils.LogError(err, "error unmarshalling data for key", 0, map[string]interface{}{"key": key})
+				return nil, err
+			}
+
+			return returnValue, nil
 		}
-
-		return returnValue, nil
 	}
 
 	if cache.remoteCache == nil {
@@ -107,12 +120,12 @@ func (cache *TieredCache) Get(key string, returnValue interface{}) (interface{},
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 
-	_, err = cache.remoteCache.Get(ctx, key, cacheValue)
+	_, err := cache.remoteCache.Get(ctx, key, cacheValue)
 	if err != nil {
 		return nil, err
 	}
 
-	if cacheValue.Timeout == 0 || cacheValue.Timeout > uint64(time.Now().Add(2*time.Second).Unix()) {
+	if cache.localGoCache != nil && (cacheValue.Timeout == 0 || cacheValue.Timeout > uint64(time.Now().Add(2*time.Second).Unix())) {
 		valueMarshal, err := json.Marshal(cacheValue)
 		if err != nil {
 			return nil, err
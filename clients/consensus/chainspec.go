@@ -36,12 +36,15 @@ type ChainSpec struct {
 	ElectraForkEpoch                      *uint64           `yaml:"ELECTRA_FORK_EPOCH"   check-if-fork:"ElectraForkEpoch"`
 	Eip7594ForkVersion                    phase0.Version    `yaml:"EIP7594_FORK_VERSION" check-if-fork:"Eip7594ForkEpoch"`
 	Eip7594ForkEpoch                      *uint64           `yaml:"EIP7594_FORK_EPOCH"   check-if-fork:"Eip7594ForkEpoch"`
+	FuluForkVersion                       phase0.Version    `yaml:"FULU_FORK_VERSION"    check-if-fork:"FuluForkEpoch"`
+	FuluForkEpoch                         *uint64           `yaml:"FULU_FORK_EPOCH"      check-if-fork:"FuluForkEpoch"`
 	SecondsPerSlot                        time.Duration     `yaml:"SECONDS_PER_SLOT"`
 	SlotsPerEpoch                         uint64            `yaml:"SLOTS_PER_EPOCH"`
 	EpochsPerHistoricalVector             uint64            `yaml:"EPOCHS_PER_HISTORICAL_VECTOR"`
 	EpochsPerSlashingVector               uint64            `yaml:"EPOCHS_PER_SLASHINGS_VECTOR"`
 	EpochsPerSyncCommitteePeriod          uint64            `yaml:"EPOCHS_PER_SYNC_COMMITTEE_PERIOD"`
 	MinSeedLookahead                      uint64            `yaml:"MIN_SEED_LOOKAHEAD"`
+	MaxSeedLookahead                      uint64            `yaml:"MAX_SEED_LOOKAHEAD"`
 	ShuffleRoundCount                     uint64            `yaml:"SHUFFLE_ROUND_COUNT"`
 	MaxEffectiveBalance                   uint64            `yaml:"MAX_EFFECTIVE_BALANCE"`
 	MaxEffectiveBalanceElectra            uint64            `yaml:"MAX_EFFECTIVE_BALANCE_ELECTRA" check-if-fork:"ElectraForkEpoch"`
@@ -49,6 +52,7 @@ type ChainSpec struct {
 	MaxCommitteesPerSlot                  uint64            `yaml:"MAX_COMMITTEES_PER_SLOT"`
 	MinPerEpochChurnLimit                 uint64            `yaml:"MIN_PER_EPOCH_CHURN_LIMIT"`
 	ChurnLimitQuotient                    uint64            `yaml:"CHURN_LIMIT_QUOTIENT"`
+	MaxPerEpochActivationChurnLimit       uint64            `yaml:"MAX_PER_EPOCH_ACTIVATION_CHURN_LIMIT" check-if-fork:"DenebForkEpoch"`
 	DomainBeaconProposer                  phase0.DomainType `yaml:"DOMAIN_BEACON_PROPOSER"`
 	DomainBeaconAttester                  phase0.DomainType `yaml:"DOMAIN_BEACON_ATTESTER"`
 	DomainSyncCommittee                   phase0.DomainType `yaml:"DOMAIN_SYNC_COMMITTEE"`
@@ -70,6 +74,7 @@ type ChainSpec struct {
 	NumberOfColumns              *uint64 `yaml:"NUMBER_OF_COLUMNS"                check-if-fork:"Eip7594ForkEpoch"`
 	DataColumnSidecarSubnetCount *uint64 `yaml:"DATA_COLUMN_SIDECAR_SUBNET_COUNT" check-if-fork:"Eip7594ForkEpoch"`
 	CustodyRequirement           *uint64 `yaml:"CUSTODY_REQUIREMENT"              check-if-fork:"Eip7594ForkEpoch"`
+	NumberOfCustodyGroups        *uint64 `yaml:"NUMBER_OF_CUSTODY_GROUPS"`
 
 	// additional dora specific specs
 	WhiskForkEpoch *uint64
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/ethwallclock"
 	"github.com/mashingan/smapping"
@@ -265,3 +266,121 @@ func (cs *ChainState) GetValidatorChurnLimit(validatorCount uint64) uint64 {
 
 	return adaptable
 }
+
+// GetActivationChurnLimit returns the max number of validators that get activated per epoch (pre-electra).
+// deneb caps the activation churn (EIP-7514), while the exit churn remains uncapped.
+func (cs *ChainState) GetActivationChurnLimit(validatorCount uint64, epoch phase0.Epoch) uint64 {
+	churnLimit := cs.GetValidatorChurnLimit(validatorCount)
+	if cs.specs == nil || cs.specs.DenebForkEpoch == nil || epoch < phase0.Epoch(*cs.specs.DenebForkEpoch) {
+		return churnLimit
+	}
+
+	if cs.specs.MaxPerEpochActivationChurnLimit > 0 && churnLimit > cs.specs.MaxPerEpochActivationChurnLimit {
+		return cs.specs.MaxPerEpochActivationChurnLimit
+	}
+
+	return churnLimit
+}
+
+// GetBalanceChurnLimit returns the balance churn limit per epoch in gwei (electra).
+func (cs *ChainState) GetBalanceChurnLimit(totalActiveBalance phase0.Gwei) phase0.Gwei {
+	if cs.specs == nil || cs.specs.ChurnLimitQuotient == 0 {
+		return 0
+	}
+
+	churn := uint64(totalActiveBalance) / cs.specs.ChurnLimitQuotient
+	if churn < cs.specs.MinPerEpochChurnLimitElectra {
+		churn = cs.specs.MinPerEpochChurnLimitElectra
+	}
+
+	if cs.specs.EffectiveBalanceIncrement > 0 {
+		churn -= churn % cs.specs.EffectiveBalanceIncrement
+	}
+
+	return phase0.Gwei(churn)
+}
+
+// GetActivationExitChurnLimit returns the balance churn limit for deposits & exits per epoch in gwei (electra).
+func (cs *ChainState) GetActivationExitChurnLimit(totalActiveBalance phase0.Gwei) phase0.Gwei {
+	churn := cs.GetBalanceChurnLimit(totalActiveBalance)
+	if cs.specs != nil && cs.specs.MaxPerEpochActivationExitChurnLimit > 0 && uint64(churn) > cs.specs.MaxPerEpochActivationExitChurnLimit {
+		return phase0.Gwei(cs.specs.MaxPerEpochActivationExitChurnLimit)
+	}
+
+	return churn
+}
+
+// ComputeActivationExitEpoch returns the epoch at which activations & exits initiated in the given epoch take effect.
+func (cs *ChainState) ComputeActivationExitEpoch(epoch phase0.Epoch) phase0.Epoch {
+	if cs.specs == nil {
+		return epoch + 1
+	}
+
+	return epoch + 1 + phase0.Epoch(cs.specs.MaxSeedLookahead)
+}
+
+// GetForkVersionAtEpoch returns the fork version that is active at the given epoch.
+func (cs *ChainState) GetForkVersionAtEpoch(epoch phase0.Epoch) phase0.Version {
+	if cs.specs == nil {
+		return phase0.Version{}
+	}
+
+	forkVersion := cs.specs.GenesisForkVersion
+	forkEpochs := []struct {
+		epoch   *uint64
+		version phase0.Version
+	}{
+		{cs.specs.AltairForkEpoch, cs.specs.AltairForkVersion},
+		{cs.specs.BellatrixForkEpoch, cs.specs.BellatrixForkVersion},
+		{cs.specs.CapellaForkEpoch, cs.specs.CapellaForkVersion},
+		{cs.specs.DenebForkEpoch, cs.specs.DenebForkVersion},
+		{cs.specs.ElectraForkEpoch, cs.specs.ElectraForkVersion},
+		{cs.specs.FuluForkEpoch, cs.specs.FuluForkVersion},
+	}
+	for _, fork := range forkEpochs {
+		if fork.epoch != nil && uint64(epoch) >= *fork.epoch {
+			forkVersion = fork.version
+		}
+	}
+
+	return forkVersion
+}
+
+// GetPeerDASForkEpoch returns the epoch PeerDAS gets activated at (fulu, or the standalone eip7594 fork on early devnets).
+// returns nil if PeerDAS is not scheduled for the network.
+func (cs *ChainState) GetPeerDASForkEpoch() *phase0.Epoch {
+	if cs.specs == nil {
+		return nil
+	}
+
+	for _, forkEpoch := range []*uint64{cs.specs.FuluForkEpoch, cs.specs.Eip7594ForkEpoch} {
+		if forkEpoch != nil && *forkEpoch < uint64(18446744073709551615) {
+			epoch := phase0.Epoch(*forkEpoch)
+			return &epoch
+		}
+	}
+
+	return nil
+}
+
+// GetDataVersionAtEpoch returns the data version (fork) that is active at the given epoch.
+func (cs *ChainState) GetDataVersionAtEpoch(epoch phase0.Epoch) spec.DataVersion {
+	if cs.specs == nil {
+		return spec.DataVersionPhase0
+	}
+
+	switch {
+	case cs.specs.ElectraForkEpoch != nil && uint64(epoch) >= *cs.specs.ElectraForkEpoch:
+		return spec.DataVersionElectra
+	case cs.specs.DenebForkEpoch != nil && uint64(epoch) >= *cs.specs.DenebForkEpoch:
+		return spec.DataVersionDeneb
+	case cs.specs.CapellaForkEpoch != nil && uint64(epoch) >= *cs.specs.CapellaForkEpoch:
+		return spec.DataVersionCapella
+	case cs.specs.BellatrixForkEpoch != nil && uint64(epoch) >= *cs.specs.BellatrixForkEpoch:
+		return spec.DataVersionBellatrix
+	case cs.specs.AltairForkEpoch != nil && uint64(epoch) >= *cs.specs.AltairForkEpoch:
+		return spec.DataVersionAltair
+	default:
+		return spec.DataVersionPhase0
+	}
+}
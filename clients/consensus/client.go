@@ -3,27 +3,34 @@ package consensus
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/dora/clients/consensus/rpc"
+	"github.com/ethpandaops/dora/clients/selection"
 	"github.com/ethpandaops/dora/clients/sshtunnel"
 )
 
 type ClientConfig struct {
-	URL        string
-	Name       string
-	Headers    map[string]string
-	SshConfig  *sshtunnel.SshConfig
-	DisableSSZ bool
+	URL               string
+	Name              string
+	Headers           map[string]string
+	SshConfig         *sshtunnel.SshConfig
+	DisableSSZ        bool
+	ExperimentalForks map[string]spec.DataVersion // consensus version of experimental forks -> base fork
+	EventTopics       uint16                      // subscribed event stream topics (rpc.Stream* flags), 0 for all topics
+	Selection         selection.Settings          // request routing settings (priority, weight & archive / full designation)
 }
 
 type Client struct {
 	pool                    *Pool
 	clientIdx               uint16
+	clientId                atomic.Uint64 // stable client id, persisted across restarts & renames
 	endpointConfig          *ClientConfig
 	clientCtx               context.Context
 	clientCtxCancel         context.CancelFunc
@@ -49,6 +56,8 @@ type Client struct {
 	lastMetadataUpdate      time.Time
 	lastSyncUpdateEpoch     phase0.Epoch
 	peers                   []*v1.Peer
+	blockStream             *rpc.BeaconStream
+	recentBlockRoots        []phase0.Root // roots of the last dispatched block events, used to deduplicate events from different topics
 	blockDispatcher         Dispatcher[*v1.BlockEvent]
 	headDispatcher          Dispatcher[*v1.HeadEvent]
 	checkpointDispatcher    Dispatcher[*v1.Finality]
@@ -57,7 +66,7 @@ type Client struct {
 func (pool *Pool) newPoolClient(clientIdx uint16, endpoint *ClientConfig) (*Client, error) {
 	logger := pool.logger.WithField("client", endpoint.Name)
 
-	rpcClient, err := rpc.NewBeaconClient(endpoint.Name, endpoint.URL, endpoint.Headers, endpoint.SshConfig, endpoint.DisableSSZ, logger)
+	rpcClient, err := rpc.NewBeaconClient(endpoint.Name, endpoint.URL, endpoint.Headers, endpoint.SshConfig, endpoint.DisableSSZ, endpoint.ExperimentalForks, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -104,10 +113,25 @@ func (client *Client) GetIndex() uint16 {
 	return client.clientIdx
 }
 
+// GetClientId returns the stable id of the client, or 0 if no id has been assigned yet.
+func (client *Client) GetClientId() uint64 {
+	return client.clientId.Load()
+}
+
+// SetClientId sets the stable id of the client.
+func (client *Client) SetClientId(clientId uint64) {
+	client.clientId.Store(clientId)
+}
+
 func (client *Client) GetName() string {
 	return client.endpointConfig.Name
 }
 
+// GetSelection returns the request routing settings of the client.
+func (client *Client) GetSelection() *selection.Settings {
+	return &client.endpointConfig.Selection
+}
+
 func (client *Client) GetVersion() string {
 	return client.versionStr
 }
@@ -147,6 +171,17 @@ func (client *Client) GetLastClientError() error {
 	return client.lastError
 }
 
+// GetEventStreamStats returns the event queue statistics of the current event stream (nil if not connected).
+func (client *Client) GetEventStreamStats() *rpc.BeaconStreamStats {
+	blockStream := client.blockStream
+	if blockStream == nil {
+		return nil
+	}
+
+	stats := blockStream.GetStats()
+	return &stats
+}
+
 func (client *Client) GetFinalityCheckpoint() (finalitedEpoch phase0.Epoch, finalizedRoot phase0.Root, justifiedEpoch phase0.Epoch, justifiedRoot phase0.Root) {
 	client.headMutex.RLock()
 	defer client.headMutex.RUnlock()
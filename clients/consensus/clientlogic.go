@@ -124,18 +124,32 @@ func (client *Client) runClientLogic() error {
 	}
 
 	// start event stream
-	blockStream := client.rpcClient.NewBlockStream(client.clientCtx, client.logger, rpc.StreamBlockEvent|rpc.StreamHeadEvent|rpc.StreamFinalizedEvent)
-	defer blockStream.Close()
+	eventTopics := client.getEventTopics()
+	blockStream := client.rpcClient.NewBlockStream(client.clientCtx, client.logger, eventTopics)
+	client.blockStream = blockStream
+	defer func() {
+		client.blockStream = nil
+		blockStream.Close()
+	}()
+
+	// poll the chain head if there was no head event for 30 secs, or every slot if head events are not subscribed
+	headPollInterval := 30 * time.Second
+	if eventTopics&rpc.StreamHeadEvent == 0 {
+		if specs := client.pool.chainState.GetSpecs(); specs != nil && specs.SecondsPerSlot > 0 {
+			headPollInterval = specs.SecondsPerSlot
+		}
+	}
 
 	// process events
 	client.lastEvent = time.Now()
+	lastHeadUpdate := time.Now()
 
 	for {
-		eventTimeout := time.Since(client.lastEvent)
-		if eventTimeout > 30*time.Second {
+		eventTimeout := time.Since(lastHeadUpdate)
+		if eventTimeout > headPollInterval {
 			eventTimeout = 0
 		} else {
-			eventTimeout = 30*time.Second - eventTimeout
+			eventTimeout = headPollInterval - eventTimeout
 		}
 
 		select {
@@ -152,11 +166,13 @@ func (client *Client) runClientLogic() error {
 				}
 
 			case rpc.StreamHeadEvent:
-				err := client.processHeadEvent(evt.Data.(*v1.HeadEvent))
+				err := client.processHeadEvent(evt.Data.(*v1.HeadEvent), eventTopics&rpc.StreamBlockEvent == 0)
 				if err != nil {
 					client.logger.Warnf("failed processing head event: %v", err)
 				}
 
+				lastHeadUpdate = time.Now()
+
 			case rpc.StreamFinalizedEvent:
 				err := client.processFinalizedEvent(evt.Data.(*v1.FinalizedCheckpointEvent))
 				if err != nil {
@@ -164,7 +180,7 @@ func (client *Client) runClientLogic() error {
 				}
 			}
 
-			client.logger.Tracef("event (%v) processing time: %v ms", evt.Event, time.Since(now).Milliseconds())
+			client.logger.Tracef("event (%v) processing time: %v ms, queue lag: %v ms", evt.Event, time.Since(now).Milliseconds(), now.Sub(evt.Received).Milliseconds())
 			client.lastEvent = time.Now()
 		case streamStatus := <-blockStream.ReadyChan:
 			if client.isOnline != streamStatus.Ready {
@@ -178,7 +194,7 @@ func (client *Client) runClientLogic() error {
 				}
 			}
 		case <-time.After(eventTimeout):
-			client.logger.Debug("no head event since 30 secs, polling chain head")
+			client.logger.Debugf("no head event since %v, polling chain head", headPollInterval)
 
 			err := client.pollClientHead()
 			if err != nil {
@@ -187,6 +203,7 @@ func (client *Client) runClientLogic() error {
 			}
 
 			client.lastEvent = time.Now()
+			lastHeadUpdate = time.Now()
 		}
 
 		currentEpoch := client.pool.chainState.CurrentEpoch()
@@ -311,20 +328,62 @@ func (client *Client) updateFinalityCheckpoints(ctx context.Context) (phase0.Roo
 	return finalizedCheckpoints.Finalized.Root, nil
 }
 
-func (client *Client) processBlockEvent(evt *v1.BlockEvent) error {
+// getEventTopics returns the event stream topics to subscribe, defaults to all topics if not configured.
+func (client *Client) getEventTopics() uint16 {
+	if client.endpointConfig.EventTopics == 0 {
+		return rpc.StreamAllEvents
+	}
+	return client.endpointConfig.EventTopics
+}
+
+// GetEventTopics returns the names of the subscribed event stream topics.
+func (client *Client) GetEventTopics() []string {
+	return rpc.GetStreamEventTopics(client.getEventTopics())
+}
+
+// fireBlockEvent dispatches a block event to the subscribers.
+// block events are received via the block topic, derived from head events or from head polling, so events for recently dispatched roots are skipped.
+func (client *Client) fireBlockEvent(evt *v1.BlockEvent) {
+	client.headMutex.Lock()
+	for _, root := range client.recentBlockRoots {
+		if bytes.Equal(root[:], evt.Block[:]) {
+			client.headMutex.Unlock()
+			return
+		}
+	}
+
+	client.recentBlockRoots = append(client.recentBlockRoots, evt.Block)
+	if len(client.recentBlockRoots) > 16 {
+		client.recentBlockRoots = client.recentBlockRoots[1:]
+	}
+	client.headMutex.Unlock()
+
 	client.blockDispatcher.Fire(evt)
+}
+
+func (client *Client) processBlockEvent(evt *v1.BlockEvent) error {
+	client.fireBlockEvent(evt)
 
 	//client.logger.Infof("BLOCK: %v %v", evt.Slot, evt.Block.String())
 
 	return nil
 }
 
-func (client *Client) processHeadEvent(evt *v1.HeadEvent) error {
+func (client *Client) processHeadEvent(evt *v1.HeadEvent, deriveBlockEvent bool) error {
 	client.headMutex.Lock()
 	client.headSlot = evt.Slot
 	client.headRoot = evt.Block
 	client.headMutex.Unlock()
 
+	if deriveBlockEvent {
+		// block events are not subscribed, the head block is the only block we get to know about
+		client.fireBlockEvent(&v1.BlockEvent{
+			Slot:                evt.Slot,
+			Block:               evt.Block,
+			ExecutionOptimistic: client.isOptimistic, // head events do not carry the optimistic flag
+		})
+	}
+
 	client.headDispatcher.Fire(evt)
 
 	//client.logger.Infof("HEAD: %v %v %v", evt.Slot, evt.Block.String(), evt.EpochTransition)
@@ -375,7 +434,7 @@ func (client *Client) pollClientHead() error {
 	client.headRoot = latestHeader.Root
 	client.headMutex.Unlock()
 
-	client.blockDispatcher.Fire(&v1.BlockEvent{
+	client.fireBlockEvent(&v1.BlockEvent{
 		Slot:  latestHeader.Header.Message.Slot,
 		Block: latestHeader.Root,
 	})
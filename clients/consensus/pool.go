@@ -7,7 +7,8 @@ import (
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/ethpandaops/ethwallclock"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/exp/rand"
+
+	"github.com/ethpandaops/dora/clients/selection"
 )
 
 type Pool struct {
@@ -76,9 +77,12 @@ func (pool *Pool) GetReadyEndpoint(clientType ClientType) *Client {
 		readyClients = append(readyClients, client)
 	}
 
-	rand.Shuffle(len(readyClients), func(i, j int) {
-		readyClients[i], readyClients[j] = readyClients[j], readyClients[i]
-	})
+	if len(readyClients) == 0 {
+		return nil
+	}
+
+	// light request, load-balanced by client weight
+	selection.SortClients(readyClients, (*Client).GetSelection, false)
 
 	return readyClients[0]
 }
@@ -0,0 +1,16 @@
+package rpc
+
+// AttestationRewards is the response of the attestation rewards api.
+// the values are parsed as signed integers as penalties are returned as negative numbers (incl. inactivity).
+type AttestationRewards struct {
+	TotalRewards []ValidatorAttestationRewards `json:"total_rewards"`
+}
+
+type ValidatorAttestationRewards struct {
+	ValidatorIndex uint64 `json:"validator_index,string"`
+	Head           int64  `json:"head,string"`
+	Target         int64  `json:"target,string"`
+	Source         int64  `json:"source,string"`
+	InclusionDelay int64  `json:"inclusion_delay,string"` // phase0 only
+	Inactivity     int64  `json:"inactivity,string"`
+}
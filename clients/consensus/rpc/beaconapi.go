@@ -17,8 +17,11 @@ import (
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/http"
 	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/rs/zerolog"
 	"github.com/sirupsen/logrus"
@@ -28,23 +31,26 @@ import (
 )
 
 type BeaconClient struct {
-	name       string
-	endpoint   string
-	headers    map[string]string
-	sshtunnel  *sshtunnel.SSHTunnel
-	disableSSZ bool
-	clientSvc  eth2client.Service
-	logger     logrus.FieldLogger
+	name              string
+	endpoint          string
+	headers           map[string]string
+	sshtunnel         *sshtunnel.SSHTunnel
+	disableSSZ        bool
+	experimentalForks map[string]spec.DataVersion
+	clientSvc         eth2client.Service
+	logger            logrus.FieldLogger
 }
 
 // NewBeaconClient is used to create a new beacon client
-func NewBeaconClient(name, endpoint string, headers map[string]string, sshcfg *sshtunnel.SshConfig, disableSSZ bool, logger logrus.FieldLogger) (*BeaconClient, error) {
+// experimentalForks maps the consensus versions of experimental devnet forks to the fork their blocks are decoded as (nil = strict decoding).
+func NewBeaconClient(name, endpoint string, headers map[string]string, sshcfg *sshtunnel.SshConfig, disableSSZ bool, experimentalForks map[string]spec.DataVersion, logger logrus.FieldLogger) (*BeaconClient, error) {
 	client := &BeaconClient{
-		name:       name,
-		endpoint:   endpoint,
-		headers:    headers,
-		disableSSZ: disableSSZ,
-		logger:     logger,
+		name:              name,
+		endpoint:          endpoint,
+		headers:           headers,
+		disableSSZ:        disableSSZ,
+		experimentalForks: experimentalForks,
+		logger:            logger,
 	}
 
 	if sshcfg != nil {
@@ -171,6 +177,10 @@ func (bc *BeaconClient) getJSON(ctx context.Context, requrl string, returnValue
 }
 
 func (bc *BeaconClient) postJSON(ctx context.Context, requrl string, postData, returnValue interface{}) error {
+	return bc.postJSONWithHeaders(ctx, requrl, nil, postData, returnValue)
+}
+
+func (bc *BeaconClient) postJSONWithHeaders(ctx context.Context, requrl string, headers map[string]string, postData, returnValue interface{}) error {
 	logurl := getRedactedURL(requrl)
 
 	postDataBytes, err := json.Marshal(postData)
@@ -190,6 +200,9 @@ func (bc *BeaconClient) postJSON(ctx context.Context, requrl string, postData, r
 	for headerKey, headerVal := range bc.headers {
 		req.Header.Set(headerKey, headerVal)
 	}
+	for headerKey, headerVal := range headers {
+		req.Header.Set(headerKey, headerVal)
+	}
 
 	client := &nethttp.Client{Timeout: time.Second * 300}
 
@@ -400,12 +413,76 @@ func (bc *BeaconClient) GetBlockBodyByBlockroot(ctx context.Context, blockroot p
 			return nil, nil
 		}
 
+		if len(bc.experimentalForks) > 0 && ctx.Err() == nil {
+			// the block might be of an experimental fork or contain unknown body extensions, retry with tolerant decoding
+			block, err2 := bc.getTolerantBlockBody(ctx, blockroot)
+			if err2 == nil {
+				return block, nil
+			}
+
+			bc.logger.Debugf("tolerant block decoding failed for 0x%x: %v", blockroot, err2)
+		}
+
 		return nil, err
 	}
 
 	return result.Data, nil
 }
 
+// getTolerantBlockBody loads a block via the json api and decodes it as the fork mapped to the reported consensus version.
+// experimental block body extensions of feature devnets are skipped, as unknown json fields are ignored by the decoder.
+func (bc *BeaconClient) getTolerantBlockBody(ctx context.Context, blockroot phase0.Root) (*spec.VersionedSignedBeaconBlock, error) {
+	var response struct {
+		Version string          `json:"version"`
+		Data    json.RawMessage `json:"data"`
+	}
+
+	err := bc.getJSON(ctx, fmt.Sprintf("%s/eth/v2/beacon/blocks/0x%x", bc.endpoint, blockroot), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	version, found := bc.experimentalForks[strings.ToLower(response.Version)]
+	if !found {
+		// known forks with unknown body extensions
+		if err := version.UnmarshalJSON([]byte(fmt.Sprintf("%q", response.Version))); err != nil {
+			return nil, fmt.Errorf("unknown block version %v", response.Version)
+		}
+	}
+
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: version,
+	}
+
+	switch version {
+	case spec.DataVersionPhase0:
+		block.Phase0 = &phase0.SignedBeaconBlock{}
+		err = json.Unmarshal(response.Data, block.Phase0)
+	case spec.DataVersionAltair:
+		block.Altair = &altair.SignedBeaconBlock{}
+		err = json.Unmarshal(response.Data, block.Altair)
+	case spec.DataVersionBellatrix:
+		block.Bellatrix = &bellatrix.SignedBeaconBlock{}
+		err = json.Unmarshal(response.Data, block.Bellatrix)
+	case spec.DataVersionCapella:
+		block.Capella = &capella.SignedBeaconBlock{}
+		err = json.Unmarshal(response.Data, block.Capella)
+	case spec.DataVersionDeneb:
+		block.Deneb = &deneb.SignedBeaconBlock{}
+		err = json.Unmarshal(response.Data, block.Deneb)
+	case spec.DataVersionElectra:
+		block.Electra = &electra.SignedBeaconBlock{}
+		err = json.Unmarshal(response.Data, block.Electra)
+	default:
+		return nil, fmt.Errorf("unsupported base fork %v for block version %v", version, response.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding %v block as %v: %v", response.Version, version, err)
+	}
+
+	return block, nil
+}
+
 func (bc *BeaconClient) GetState(ctx context.Context, stateRef string) (*spec.VersionedBeaconState, error) {
 	provider, isProvider := bc.clientSvc.(eth2client.BeaconStateProvider)
 	if !isProvider {
@@ -425,6 +502,45 @@ func (bc *BeaconClient) GetState(ctx context.Context, stateRef string) (*spec.Ve
 	return result.Data, nil
 }
 
+// GetStateSSZ opens a stream of the ssz encoded beacon state from the debug api.
+// returns the response body along with the consensus version of the state, the caller needs to close the body.
+func (bc *BeaconClient) GetStateSSZ(ctx context.Context, stateRef string) (io.ReadCloser, string, error) {
+	requrl := fmt.Sprintf("%s/eth/v2/debug/beacon/states/%s", bc.endpoint, stateRef)
+	logurl := getRedactedURL(requrl)
+
+	req, err := nethttp.NewRequestWithContext(ctx, "GET", requrl, nethttp.NoBody)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for headerKey, headerVal := range bc.headers {
+		req.Header.Set(headerKey, headerVal)
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+
+	resp, err := nethttp.DefaultClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode != nethttp.StatusOK {
+		defer resp.Body.Close()
+		if resp.StatusCode == nethttp.StatusNotFound {
+			return nil, "", fmt.Errorf("not found")
+		}
+
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, "", fmt.Errorf("url: %v, error-response: %s", logurl, data)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "application/octet-stream") {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("url: %v, unexpected content type: %v", logurl, contentType)
+	}
+
+	return resp.Body, resp.Header.Get("Eth-Consensus-Version"), nil
+}
+
 func (bc *BeaconClient) GetBlobSidecarsByBlockroot(ctx context.Context, blockroot []byte) ([]*deneb.BlobSidecar, error) {
 	provider, isProvider := bc.clientSvc.(eth2client.BlobSidecarsProvider)
 	if !isProvider {
@@ -439,6 +555,104 @@ func (bc *BeaconClient) GetBlobSidecarsByBlockroot(ctx context.Context, blockroo
 	return result.Data, nil
 }
 
+// GetBlobSidecarIndexes returns the indexes of the blob sidecars the client has available for a block.
+// only the blob indexes are decoded, as the blob data itself is not needed.
+func (bc *BeaconClient) GetBlobSidecarIndexes(ctx context.Context, blockroot phase0.Root) ([]uint64, error) {
+	response := struct {
+		Data []struct {
+			Index string `json:"index"`
+		} `json:"data"`
+	}{}
+
+	err := bc.getJSON(ctx, fmt.Sprintf("%s/eth/v1/beacon/blob_sidecars/0x%x", bc.endpoint, blockroot[:]), &response)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving blob sidecars: %v", err)
+	}
+
+	indexes := make([]uint64, 0, len(response.Data))
+	for _, sidecar := range response.Data {
+		index, err := strconv.ParseUint(sidecar.Index, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blob sidecar index %v: %v", sidecar.Index, err)
+		}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+// GetDataColumnSidecarIndexes returns the indexes of the data column sidecars the client has available for a block.
+// only the column indexes are decoded, as the column data itself is not needed by the explorer.
+func (bc *BeaconClient) GetDataColumnSidecarIndexes(ctx context.Context, blockroot phase0.Root) ([]uint64, error) {
+	response := struct {
+		Data []struct {
+			Index string `json:"index"`
+		} `json:"data"`
+	}{}
+
+	err := bc.getJSON(ctx, fmt.Sprintf("%s/eth/v1/debug/beacon/data_column_sidecars/0x%x", bc.endpoint, blockroot[:]), &response)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving data column sidecars: %v", err)
+	}
+
+	indexes := make([]uint64, 0, len(response.Data))
+	for _, sidecar := range response.Data {
+		index, err := strconv.ParseUint(sidecar.Index, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data column sidecar index %v: %v", sidecar.Index, err)
+		}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+// GetLightClientUpdates returns the best light client updates of the sync committee periods in the requested range.
+// clients only return updates of periods they have data for, so the result may contain less updates than requested.
+func (bc *BeaconClient) GetLightClientUpdates(ctx context.Context, startPeriod uint64, count uint64) ([]*VersionedLightClientUpdate, error) {
+	response := []*lightClientResponse{}
+
+	err := bc.getJSON(ctx, fmt.Sprintf("%s/eth/v1/beacon/light_client/updates?start_period=%d&count=%d", bc.endpoint, startPeriod, count), &response)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving light client updates: %v", err)
+	}
+
+	updates := make([]*VersionedLightClientUpdate, 0, len(response))
+	for _, updateResponse := range response {
+		update, err := updateResponse.decode()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding light client update: %v", err)
+		}
+		updates = append(updates, update)
+	}
+
+	return updates, nil
+}
+
+// GetLightClientFinalityUpdate returns the latest light client finality update known to the client.
+func (bc *BeaconClient) GetLightClientFinalityUpdate(ctx context.Context) (*VersionedLightClientUpdate, error) {
+	response := &lightClientResponse{}
+
+	err := bc.getJSON(ctx, fmt.Sprintf("%s/eth/v1/beacon/light_client/finality_update", bc.endpoint), response)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving light client finality update: %v", err)
+	}
+
+	return response.decode()
+}
+
+// GetLightClientOptimisticUpdate returns the latest light client optimistic update known to the client.
+func (bc *BeaconClient) GetLightClientOptimisticUpdate(ctx context.Context) (*VersionedLightClientUpdate, error) {
+	response := &lightClientResponse{}
+
+	err := bc.getJSON(ctx, fmt.Sprintf("%s/eth/v1/beacon/light_client/optimistic_update", bc.endpoint), response)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving light client optimistic update: %v", err)
+	}
+
+	return response.decode()
+}
+
 func (bc *BeaconClient) GetForkState(ctx context.Context, stateRef string) (*phase0.Fork, error) {
 	provider, isProvider := bc.clientSvc.(eth2client.ForkProvider)
 	if !isProvider {
@@ -458,6 +672,95 @@ func (bc *BeaconClient) GetForkState(ctx context.Context, stateRef string) (*pha
 	return result.Data, nil
 }
 
+func (bc *BeaconClient) GetAttestationRewards(ctx context.Context, epoch phase0.Epoch) (*AttestationRewards, error) {
+	response := struct {
+		Data *AttestationRewards `json:"data"`
+	}{}
+
+	err := bc.postJSON(ctx, fmt.Sprintf("%s/eth/v1/beacon/rewards/attestations/%d", bc.endpoint, epoch), []string{}, &response)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving attestation rewards: %v", err)
+	}
+	return response.Data, nil
+}
+
+func (bc *BeaconClient) GetBlockRewards(ctx context.Context, blockroot phase0.Root) (*v1.BlockRewards, error) {
+	provider, isProvider := bc.clientSvc.(eth2client.BlockRewardsProvider)
+	if !isProvider {
+		return nil, fmt.Errorf("get block rewards not supported")
+	}
+
+	result, err := provider.BlockRewards(ctx, &api.BlockRewardsOpts{
+		Block: fmt.Sprintf("0x%x", blockroot),
+		Common: api.CommonOpts{
+			Timeout: 0,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+func (bc *BeaconClient) GetSyncCommitteeRewards(ctx context.Context, blockroot phase0.Root) ([]*v1.SyncCommitteeReward, error) {
+	provider, isProvider := bc.clientSvc.(eth2client.SyncCommitteeRewardsProvider)
+	if !isProvider {
+		return nil, fmt.Errorf("get sync committee rewards not supported")
+	}
+
+	result, err := provider.SyncCommitteeRewards(ctx, &api.SyncCommitteeRewardsOpts{
+		Block: fmt.Sprintf("0x%x", blockroot),
+		Common: api.CommonOpts{
+			Timeout: 0,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+func (bc *BeaconClient) GetProposerDuties(ctx context.Context, epoch phase0.Epoch) ([]*v1.ProposerDuty, error) {
+	provider, isProvider := bc.clientSvc.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return nil, fmt.Errorf("get proposer duties not supported")
+	}
+
+	result, err := provider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch: epoch,
+		Common: api.CommonOpts{
+			Timeout: 0,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+func (bc *BeaconClient) GetBeaconCommittees(ctx context.Context, stateRef string, epoch *phase0.Epoch) ([]*v1.BeaconCommittee, error) {
+	provider, isProvider := bc.clientSvc.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return nil, fmt.Errorf("get beacon committees not supported")
+	}
+
+	result, err := provider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+		State: stateRef,
+		Epoch: epoch,
+		Common: api.CommonOpts{
+			Timeout: 0,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
 func (bc *BeaconClient) GetNodePeers(ctx context.Context) ([]*v1.Peer, error) {
 	provider, isProvider := bc.clientSvc.(eth2client.NodePeersProvider)
 	if !isProvider {
@@ -528,6 +831,18 @@ func (bc *BeaconClient) SubmitAttesterSlashing(ctx context.Context, slashing *ph
 	return nil
 }
 
+func (bc *BeaconClient) SubmitElectraAttesterSlashing(ctx context.Context, slashing *electra.AttesterSlashing) error {
+	headers := map[string]string{
+		"Eth-Consensus-Version": spec.DataVersionElectra.String(),
+	}
+	err := bc.postJSONWithHeaders(ctx, fmt.Sprintf("%s/eth/v2/beacon/pool/attester_slashings", bc.endpoint), headers, slashing, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (bc *BeaconClient) SubmitProposerSlashing(ctx context.Context, slashing *phase0.ProposerSlashing) error {
 	err := bc.postJSON(ctx, fmt.Sprintf("%s/eth/v1/beacon/pool/proposer_slashings", bc.endpoint), slashing, nil)
 	if err != nil {
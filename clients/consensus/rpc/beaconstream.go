@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -20,11 +21,52 @@ const (
 	StreamBlockEvent     uint16 = 0x01
 	StreamHeadEvent      uint16 = 0x02
 	StreamFinalizedEvent uint16 = 0x04
+
+	StreamAllEvents = StreamBlockEvent | StreamHeadEvent | StreamFinalizedEvent
 )
 
-type BeaconStreamEvent struct {
+// StreamEventTopics maps the beacon event stream topics to their event flags
+var StreamEventTopics = []struct {
+	Topic string
 	Event uint16
-	Data  interface{}
+}{
+	{"block", StreamBlockEvent},
+	{"head", StreamHeadEvent},
+	{"finalized_checkpoint", StreamFinalizedEvent},
+}
+
+// GetStreamEventTopics returns the names of the topics included in the event flags
+func GetStreamEventTopics(events uint16) []string {
+	topics := []string{}
+	for _, topic := range StreamEventTopics {
+		if events&topic.Event > 0 {
+			topics = append(topics, topic.Topic)
+		}
+	}
+	return topics
+}
+
+const (
+	// beaconStreamQueueSoftLimit is the queue size above which queued non-critical events are replaced by newer events of the same topic.
+	beaconStreamQueueSoftLimit = 16
+	// beaconStreamQueueLimit is the max queue size, the stream reader is blocked when the queue is full of critical events.
+	beaconStreamQueueLimit = 256
+)
+
+type BeaconStreamEvent struct {
+	Event    uint16
+	Data     interface{}
+	Received time.Time
+}
+
+// BeaconStreamStats holds the event queue statistics of a beacon event stream.
+type BeaconStreamStats struct {
+	Received    uint64        // number of received events
+	Dropped     uint64        // number of non-critical events dropped due to a slow consumer
+	QueueLength int           // number of events currently waiting for the consumer
+	MaxQueue    int           // highest number of queued events
+	LastLag     time.Duration // time the last delivered event spent in the queue
+	MaxLag      time.Duration // highest time an event spent in the queue
 }
 
 type BeaconStreamStatus struct {
@@ -42,28 +84,158 @@ type BeaconStream struct {
 	ReadyChan    chan *BeaconStreamStatus
 	EventChan    chan *BeaconStreamEvent
 	lastHeadSeen time.Time
+
+	queueMutex  sync.Mutex
+	queueCond   *sync.Cond
+	queue       []*BeaconStreamEvent
+	queueNotify chan bool
+	stats       BeaconStreamStats
 }
 
 func (bc *BeaconClient) NewBlockStream(ctx context.Context, logger logrus.FieldLogger, events uint16) *BeaconStream {
 	streamCtx, ctxCancel := context.WithCancel(ctx)
 
-	blockStream := BeaconStream{
-		ctx:       streamCtx,
-		ctxCancel: ctxCancel,
-		logger:    logger,
-		running:   true,
-		events:    events,
-		client:    bc,
-		ReadyChan: make(chan *BeaconStreamStatus, 10),
-		EventChan: make(chan *BeaconStreamEvent, 10),
+	blockStream := &BeaconStream{
+		ctx:         streamCtx,
+		ctxCancel:   ctxCancel,
+		logger:      logger,
+		running:     true,
+		events:      events,
+		client:      bc,
+		ReadyChan:   make(chan *BeaconStreamStatus, 10),
+		EventChan:   make(chan *BeaconStreamEvent, 10),
+		queueNotify: make(chan bool, 1),
 	}
+	blockStream.queueCond = sync.NewCond(&blockStream.queueMutex)
+
 	go blockStream.startStream()
+	go blockStream.runEventDispatcher()
 
-	return &blockStream
+	return blockStream
 }
 
 func (bs *BeaconStream) Close() {
 	bs.ctxCancel()
+
+	// wake up a blocked stream reader
+	bs.queueMutex.Lock()
+	bs.queueCond.Broadcast()
+	bs.queueMutex.Unlock()
+}
+
+// GetStats returns the event queue statistics of the stream.
+func (bs *BeaconStream) GetStats() BeaconStreamStats {
+	bs.queueMutex.Lock()
+	defer bs.queueMutex.Unlock()
+
+	stats := bs.stats
+	stats.QueueLength = len(bs.queue)
+
+	return stats
+}
+
+// isCriticalEvent returns true if the event must not be dropped.
+// head & finalized_checkpoint events are superseded by newer events of the same topic, block events are not.
+func isCriticalEvent(event uint16) bool {
+	return event == StreamBlockEvent
+}
+
+// enqueueEvent adds an event to the queue for the consumer.
+// the stream reader is never blocked by a slow consumer unless the queue is full of critical events.
+func (bs *BeaconStream) enqueueEvent(evt *BeaconStreamEvent) {
+	evt.Received = time.Now()
+
+	bs.queueMutex.Lock()
+	defer bs.queueMutex.Unlock()
+
+	bs.stats.Received++
+
+	if len(bs.queue) >= beaconStreamQueueSoftLimit && !isCriticalEvent(evt.Event) {
+		// drop the oldest queued event of the same topic
+		bs.dropQueuedEvent(func(queued *BeaconStreamEvent) bool {
+			return queued.Event == evt.Event
+		})
+	}
+
+	for len(bs.queue) >= beaconStreamQueueLimit {
+		// drop the oldest non-critical event or wait for the consumer
+		if bs.dropQueuedEvent(func(queued *BeaconStreamEvent) bool {
+			return !isCriticalEvent(queued.Event)
+		}) {
+			continue
+		}
+
+		if bs.ctx.Err() != nil {
+			return
+		}
+
+		bs.queueCond.Wait()
+	}
+
+	bs.queue = append(bs.queue, evt)
+	if len(bs.queue) > bs.stats.MaxQueue {
+		bs.stats.MaxQueue = len(bs.queue)
+	}
+
+	select {
+	case bs.queueNotify <- true:
+	default:
+	}
+}
+
+// dropQueuedEvent removes the oldest queued event that matches the filter, must be called with queueMutex held.
+func (bs *BeaconStream) dropQueuedEvent(filter func(queued *BeaconStreamEvent) bool) bool {
+	for idx, queued := range bs.queue {
+		if !filter(queued) {
+			continue
+		}
+
+		bs.queue = append(bs.queue[:idx], bs.queue[idx+1:]...)
+		bs.stats.Dropped++
+
+		return true
+	}
+
+	return false
+}
+
+// runEventDispatcher forwards queued events to the consumer.
+func (bs *BeaconStream) runEventDispatcher() {
+	for {
+		bs.queueMutex.Lock()
+		var evt *BeaconStreamEvent
+		if len(bs.queue) > 0 {
+			evt = bs.queue[0]
+			bs.queue = bs.queue[1:]
+			bs.queueCond.Broadcast()
+		}
+		bs.queueMutex.Unlock()
+
+		if evt == nil {
+			select {
+			case <-bs.ctx.Done():
+				return
+			case <-bs.queueNotify:
+			}
+
+			continue
+		}
+
+		select {
+		case <-bs.ctx.Done():
+			return
+		case bs.EventChan <- evt:
+		}
+
+		lag := time.Since(evt.Received)
+
+		bs.queueMutex.Lock()
+		bs.stats.LastLag = lag
+		if lag > bs.stats.MaxLag {
+			bs.stats.MaxLag = lag
+		}
+		bs.queueMutex.Unlock()
+	}
 }
 
 func (bs *BeaconStream) startStream() {
@@ -114,48 +286,15 @@ func (bs *BeaconStream) startStream() {
 }
 
 func (bs *BeaconStream) subscribeStream(endpoint string, events uint16) *eventstream.Stream {
-	var topics strings.Builder
-
-	topicsCount := 0
-
-	if events&StreamBlockEvent > 0 {
-		if topicsCount > 0 {
-			fmt.Fprintf(&topics, ",")
-		}
-
-		fmt.Fprintf(&topics, "block")
-
-		topicsCount++
-	}
-
-	if events&StreamHeadEvent > 0 {
-		if topicsCount > 0 {
-			fmt.Fprintf(&topics, ",")
-		}
-
-		fmt.Fprintf(&topics, "head")
-
-		topicsCount++
-	}
-
-	if events&StreamFinalizedEvent > 0 {
-		if topicsCount > 0 {
-			fmt.Fprintf(&topics, ",")
-		}
-
-		fmt.Fprintf(&topics, "finalized_checkpoint")
-
-		topicsCount++
-	}
-
-	if topicsCount == 0 {
+	topics := GetStreamEventTopics(events)
+	if len(topics) == 0 {
 		return nil
 	}
 
 	for {
 		var stream *eventstream.Stream
 
-		streamURL := fmt.Sprintf("%s/eth/v1/events?topics=%v", endpoint, topics.String())
+		streamURL := fmt.Sprintf("%s/eth/v1/events?topics=%v", endpoint, strings.Join(topics, ","))
 		req, err := http.NewRequestWithContext(bs.ctx, "GET", streamURL, http.NoBody)
 
 		if err == nil {
@@ -188,10 +327,10 @@ func (bs *BeaconStream) processBlockEvent(evt eventsource.Event) {
 		bs.logger.Warnf("beacon block stream failed to decode block event: %v", err)
 		return
 	}
-	bs.EventChan <- &BeaconStreamEvent{
+	bs.enqueueEvent(&BeaconStreamEvent{
 		Event: StreamBlockEvent,
 		Data:  &parsed,
-	}
+	})
 }
 
 func (bs *BeaconStream) processHeadEvent(evt eventsource.Event) {
@@ -204,10 +343,10 @@ func (bs *BeaconStream) processHeadEvent(evt eventsource.Event) {
 	}
 
 	bs.lastHeadSeen = time.Now()
-	bs.EventChan <- &BeaconStreamEvent{
+	bs.enqueueEvent(&BeaconStreamEvent{
 		Event: StreamHeadEvent,
 		Data:  &parsed,
-	}
+	})
 }
 
 func (bs *BeaconStream) processFinalizedEvent(evt eventsource.Event) {
@@ -219,10 +358,10 @@ func (bs *BeaconStream) processFinalizedEvent(evt eventsource.Event) {
 		return
 	}
 
-	bs.EventChan <- &BeaconStreamEvent{
+	bs.enqueueEvent(&BeaconStreamEvent{
 		Event: StreamFinalizedEvent,
 		Data:  &parsed,
-	}
+	})
 }
 
 func getRedactedURL(requrl string) string {
@@ -13,6 +13,9 @@ import (
 	"github.com/donovanhide/eventsource"
 )
 
+// streamEventBuffer is the number of received events that are buffered before the stream reader blocks.
+const streamEventBuffer = 64
+
 // Stream handles a connection for receiving Server Sent Events.
 // It will try and reconnect if the connection is lost, respecting both
 // received retry delays and event id's.
@@ -21,13 +24,15 @@ type Stream struct {
 	req         *http.Request
 	lastEventID string
 	retry       time.Duration
-	// Events emits the events received by the stream
+	// Events emits the events received by the stream.
+	// It's buffered, but the consumer should drain it quickly as the stream reader blocks when the buffer is full.
 	Events chan StreamEvent
 	Ready  chan bool
 	// Errors emits any errors encountered while reading events from the stream.
 	// It's mainly for informative purposes - the client isn't required to take any
 	// action when an error is encountered. The stream will always attempt to continue,
 	// even if that involves reconnecting to the server.
+	// Errors are dropped if the channel buffer is full, so a slow consumer never blocks the stream.
 	Errors chan error
 	// Logger is a logger that, when set, will be used for logging debug messages
 	Logger *log.Logger
@@ -85,7 +90,7 @@ func SubscribeWith(lastEventID string, client *http.Client, request *http.Reques
 		req:         request,
 		lastEventID: lastEventID,
 		retry:       time.Millisecond * 3000,
-		Events:      make(chan StreamEvent),
+		Events:      make(chan StreamEvent, streamEventBuffer),
 		Errors:      make(chan error, 10),
 		Ready:       make(chan bool),
 	}
@@ -192,7 +197,7 @@ func (stream *Stream) receiveEvents(r io.ReadCloser) {
 		}
 
 		if err != nil {
-			stream.Errors <- err
+			stream.sendError(err)
 			stream.closeMutex.Unlock()
 
 			return
@@ -249,9 +254,21 @@ func (stream *Stream) retryRestartStream() {
 			stream.closeMutex.Unlock()
 			return
 		}
-		stream.Errors <- err
+		stream.sendError(err)
 		stream.closeMutex.Unlock()
 
 		backoff = 10 * time.Second
 	}
 }
+
+// sendError emits an error without blocking, the error is dropped if the errors buffer is full.
+// must be called with closeMutex held.
+func (stream *Stream) sendError(err error) {
+	select {
+	case stream.Errors <- err:
+	default:
+		if stream.Logger != nil {
+			stream.Logger.Printf("Dropped stream error: %v\n", err)
+		}
+	}
+}
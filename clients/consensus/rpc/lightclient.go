@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// LightClientHeader is the header of a light client update.
+// only the beacon block header is decoded, the execution payload header & branch (capella+) are not needed by the explorer.
+type LightClientHeader struct {
+	Beacon *phase0.BeaconBlockHeader `json:"beacon"`
+}
+
+// LightClientUpdate is a light client update as served by the beacon light client apis.
+// the same type is used for sync committee updates, finality updates (no next sync committee) and optimistic updates (attested header & sync aggregate only).
+type LightClientUpdate struct {
+	AttestedHeader          *LightClientHeader    `json:"attested_header"`
+	NextSyncCommittee       *altair.SyncCommittee `json:"next_sync_committee"`
+	NextSyncCommitteeBranch []phase0.Root         `json:"next_sync_committee_branch"`
+	FinalizedHeader         *LightClientHeader    `json:"finalized_header"`
+	FinalityBranch          []phase0.Root         `json:"finality_branch"`
+	SyncAggregate           *altair.SyncAggregate `json:"sync_aggregate"`
+	SignatureSlot           phase0.Slot           `json:"signature_slot,string"`
+}
+
+// VersionedLightClientUpdate is a decoded light client update with its fork version and the raw json data returned by the client.
+type VersionedLightClientUpdate struct {
+	Version string
+	Update  *LightClientUpdate
+	Data    json.RawMessage
+}
+
+type lightClientResponse struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (response *lightClientResponse) decode() (*VersionedLightClientUpdate, error) {
+	update := &LightClientUpdate{}
+	if err := json.Unmarshal(response.Data, update); err != nil {
+		return nil, err
+	}
+
+	return &VersionedLightClientUpdate{
+		Version: response.Version,
+		Update:  update,
+		Data:    response.Data,
+	}, nil
+}
@@ -6,7 +6,9 @@ type NodeIdentity struct {
 	P2PAddresses       []string `json:"p2p_addresses"`
 	DiscoveryAddresses []string `json:"discovery_addresses"`
 	Metadata           struct {
-		Attnets string `json:"attnets"`
+		Attnets           string `json:"attnets"`
+		Syncnets          string `json:"syncnets"`
+		CustodyGroupCount string `json:"custody_group_count"` // PeerDAS (fulu) only
 		//SeqNumber string `json:"seq_number"` // BUG: Teku and Grandine have an int type for this field
 	} `json:"metadata"`
 }
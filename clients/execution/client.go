@@ -3,6 +3,7 @@ package execution
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -10,6 +11,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/ethpandaops/dora/clients/execution/rpc"
+	"github.com/ethpandaops/dora/clients/selection"
 	"github.com/ethpandaops/dora/clients/sshtunnel"
 )
 
@@ -18,11 +20,13 @@ type ClientConfig struct {
 	Name      string
 	Headers   map[string]string
 	SshConfig *sshtunnel.SshConfig
+	Selection selection.Settings // request routing settings (priority, weight & archive / full designation)
 }
 
 type Client struct {
 	pool               *Pool
 	clientIdx          uint16
+	clientId           atomic.Uint64 // stable client id, persisted across restarts & renames
 	endpointConfig     *ClientConfig
 	clientCtx          context.Context
 	clientCtxCancel    context.CancelFunc
@@ -80,10 +84,25 @@ func (client *Client) GetIndex() uint16 {
 	return client.clientIdx
 }
 
+// GetClientId returns the stable id of the client, or 0 if no id has been assigned yet.
+func (client *Client) GetClientId() uint64 {
+	return client.clientId.Load()
+}
+
+// SetClientId sets the stable id of the client.
+func (client *Client) SetClientId(clientId uint64) {
+	client.clientId.Store(clientId)
+}
+
 func (client *Client) GetName() string {
 	return client.endpointConfig.Name
 }
 
+// GetSelection returns the request routing settings of the client.
+func (client *Client) GetSelection() *selection.Settings {
+	return &client.endpointConfig.Selection
+}
+
 func (client *Client) GetVersion() string {
 	return client.versionStr
 }
@@ -2,10 +2,11 @@ package execution
 
 import (
 	"context"
-	"math/rand/v2"
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/selection"
 )
 
 type Pool struct {
@@ -62,9 +63,8 @@ func (pool *Pool) GetReadyEndpoints(clientType ClientType) []*Client {
 		readyClients = append(readyClients, client)
 	}
 
-	rand.Shuffle(len(readyClients), func(i, j int) {
-		readyClients[i], readyClients[j] = readyClients[j], readyClients[i]
-	})
+	// ordered for light requests, load-balanced by client weight
+	selection.SortClients(readyClients, (*Client).GetSelection, false)
 
 	return readyClients
 }
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -231,6 +232,10 @@ func (ec *ExecutionClient) GetBlockByHash(ctx context.Context, hash common.Hash)
 	return block, nil
 }
 
+func (ec *ExecutionClient) GetBlockReceipts(ctx context.Context, hash common.Hash) ([]*types.Receipt, error) {
+	return ec.ethClient.BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(hash, false))
+}
+
 func (ec *ExecutionClient) GetNonceAt(ctx context.Context, wallet common.Address, blockNumber *big.Int) (uint64, error) {
 	return ec.ethClient.NonceAt(ctx, wallet, blockNumber)
 }
@@ -243,6 +248,10 @@ func (ec *ExecutionClient) GetTransactionReceipt(ctx context.Context, txHash com
 	return ec.ethClient.TransactionReceipt(ctx, txHash)
 }
 
+func (ec *ExecutionClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return ec.ethClient.CallContract(ctx, msg, blockNumber)
+}
+
 func (ec *ExecutionClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
 	return ec.ethClient.SendTransaction(ctx, tx)
 }
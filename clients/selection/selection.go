@@ -0,0 +1,69 @@
+package selection
+
+import (
+	"math/rand/v2"
+	"sort"
+)
+
+// Settings holds the request routing settings of a client endpoint
+type Settings struct {
+	Priority int  // clients with higher priority are preferred
+	Weight   int  // share of requests among clients with the same priority (<= 0 counts as 1)
+	Archive  bool // designated for heavy requests (state queries, historic log crawls)
+	Full     bool // designated for light requests, used for heavy requests only if no other client is available
+}
+
+// getClass returns the preference class of the client for a request (lower is preferred).
+// designated clients come first, untagged clients next and clients designated for the other request kind last.
+func (settings *Settings) getClass(heavy bool) int {
+	switch {
+	case settings.Archive && heavy, settings.Full && !heavy:
+		return 0
+	case settings.Archive, settings.Full:
+		return 2
+	default:
+		return 1
+	}
+}
+
+type sortEntry[T any] struct {
+	client   T
+	class    int
+	priority int
+	key      float64
+}
+
+// SortClients orders the clients for a request by class & priority.
+// clients with the same class & priority are shuffled by weight, so requests are load-balanced across them.
+func SortClients[T any](clients []T, getSettings func(client T) *Settings, heavy bool) {
+	entries := make([]*sortEntry[T], len(clients))
+	for idx, client := range clients {
+		settings := getSettings(client)
+		weight := settings.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+
+		entries[idx] = &sortEntry[T]{
+			client:   client,
+			class:    settings.getClass(heavy),
+			priority: settings.Priority,
+			// weighted random order: the client with the lowest key is picked with a probability of weight / total weight
+			key: rand.ExpFloat64() / float64(weight),
+		}
+	}
+
+	sort.Slice(entries, func(a, b int) bool {
+		if entries[a].class != entries[b].class {
+			return entries[a].class < entries[b].class
+		}
+		if entries[a].priority != entries[b].priority {
+			return entries[a].priority > entries[b].priority
+		}
+		return entries[a].key < entries[b].key
+	})
+
+	for idx, entry := range entries {
+		clients[idx] = entry.client
+	}
+}
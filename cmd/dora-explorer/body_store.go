@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/blobstore"
+)
+
+// runBodyStore is the entrypoint for the `body-store` subcommand.
+// it serves a filesystem backed blob store via http, so multiple dora replicas can share one store for block payloads (blobStore.provider: http).
+func runBodyStore(args []string) {
+	flags := flag.NewFlagSet("body-store", flag.ExitOnError)
+	listenAddr := flags.String("listen", "0.0.0.0:8090", "Address to listen on")
+	storePath := flags.String("path", "", "Directory to store the block payloads in")
+	authToken := flags.String("token", "", "Bearer token required for all requests (optional)")
+	retention := flags.Duration("retention", 0, "Remove payloads after the given duration and ignore deletes from the replicas (0 = keep payloads until they are deleted)")
+	flags.Usage = func() {
+		flags.Output().Write([]byte("Usage: dora-explorer body-store [options]\n\nServes a shared block payload store for multiple dora replicas.\nThe replicas connect to it via blobStore.provider: http and blobStore.endpoint.\nWhen replicas use separate databases, set a retention so payloads are not deleted while other replicas still reference them.\n\nOptions:\n"))
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *storePath == "" {
+		logrus.Fatalf("no store path given")
+	}
+
+	store, err := blobstore.NewFsStore(*storePath, "")
+	if err != nil {
+		logrus.Fatalf("error initializing body store: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if *retention > 0 {
+		go func() {
+			for {
+				pruned, err := store.PruneBefore(time.Now().Add(-*retention))
+				if err != nil {
+					logrus.Warnf("error pruning body store: %v", err)
+				} else if pruned > 0 {
+					logrus.Infof("pruned %v payloads from body store", pruned)
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(10 * time.Minute):
+				}
+			}
+		}()
+	}
+
+	server := &http.Server{
+		Addr:              *listenAddr,
+		Handler:           blobstore.NewHttpStoreHandler(store, *authToken, *retention > 0),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	logrus.WithFields(logrus.Fields{
+		"listen":    *listenAddr,
+		"path":      *storePath,
+		"retention": *retention,
+	}).Infof("starting body store")
+
+	err = server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logrus.Fatalf("error serving body store: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// runImportEra is the entrypoint for the `import-era` subcommand.
+// it imports finalized chain history from era files into the database and exits.
+func runImportEra(args []string) {
+	flags := flag.NewFlagSet("import-era", flag.ExitOnError)
+	configPath := flags.String("config", "", "Path to the config file, if empty string defaults will be used")
+	forceUpdate := flags.Bool("force", false, "Re-import epochs that are already present in the database")
+	flags.Usage = func() {
+		flags.Output().Write([]byte("Usage: dora-explorer import-era [options] <file.era>...\n\nImports finalized chain history from era files.\nA beacon node is still required to load the chain specs, but it doesn't need to be an archive node.\n\nOptions:\n"))
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	cfg := &types.Config{}
+	err := utils.ReadConfig(cfg, *configPath)
+	if err != nil {
+		logrus.Fatalf("error reading config file: %v", err)
+	}
+	utils.Config = cfg
+	logWriter, logger := utils.InitLogger()
+	defer logWriter.Dispose()
+
+	logger.WithFields(logrus.Fields{
+		"config":  *configPath,
+		"version": utils.BuildVersion,
+		"release": utils.BuildRelease,
+		"files":   flags.NArg(),
+	}).Printf("starting era import")
+
+	db.MustInitDB()
+	err = db.ApplyEmbeddedDbSchema(-2)
+	if err != nil {
+		logger.Fatalf("error initializing db schema: %v", err)
+	}
+
+	services.InitChainService(ctx, logger)
+
+	err = services.GlobalBeaconService.ImportEraFiles(ctx, flags.Args(), *forceUpdate)
+	db.MustCloseDB()
+	if err != nil {
+		logger.Fatalf("era import failed: %v", err)
+	}
+}
@@ -7,14 +7,17 @@ import (
 	"net"
 	"net/http"
 	_ "net/http/pprof"
+	"os"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/negroni"
 
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/handlers"
+	"github.com/ethpandaops/dora/handlers/api"
 	"github.com/ethpandaops/dora/services"
 	"github.com/ethpandaops/dora/static"
 	"github.com/ethpandaops/dora/types"
@@ -23,6 +26,19 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import-era" {
+		runImportEra(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate-db" {
+		runMigrateDb(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "body-store" {
+		runBodyStore(os.Args[2:])
+		return
+	}
+
 	configPath := flag.String("config", "", "Path to the config file, if empty string defaults will be used")
 	flag.Parse()
 
@@ -42,12 +58,20 @@ func main() {
 		"config":  *configPath,
 		"version": utils.BuildVersion,
 		"release": utils.BuildRelease,
+		"mode":    cfg.Indexer.Mode,
 	}).Printf("starting")
 
 	db.MustInitDB()
-	err = db.ApplyEmbeddedDbSchema(-2)
-	if err != nil {
-		logger.Fatalf("error initializing db schema: %v", err)
+	if cfg.Indexer.Mode != types.IndexerModeFrontend {
+		// frontend-only instances rely on the writer instance to apply schema upgrades
+		err = db.ApplyEmbeddedDbSchema(-2)
+		if err != nil {
+			logger.Fatalf("error initializing db schema: %v", err)
+		}
+
+		services.StartOnlineMigrations(logger.WithField("service", "online-migrations"))
+	} else if err := db.CheckEmbeddedDbSchema(); err != nil {
+		logger.Fatalf("error checking db schema: %v", err)
 	}
 
 	services.InitChainService(ctx, logger)
@@ -83,6 +107,31 @@ func main() {
 		}
 	}
 
+	if cfg.Api.Quotas.Enabled {
+		err = services.StartApiQuotaManager(logger.WithField("service", "api-quotas"))
+		if err != nil {
+			logger.Fatalf("error starting api quota manager: %v", err)
+		}
+	}
+
+	if cfg.BeaconApiProxy.Enabled {
+		services.StartBeaconApiProxy()
+	}
+
+	if cfg.Api.AdminToken != "" || cfg.Diagnostics.HeapDumpRssThreshold > 0 {
+		err = services.StartHeapDumper(logger.WithField("service", "heap-dumper"))
+		if err != nil {
+			logger.Fatalf("error starting heap dumper: %v", err)
+		}
+	}
+
+	if cfg.Integrations.Assertoor.Enabled {
+		err = services.StartAssertoorService(logger.WithField("service", "assertoor"))
+		if err != nil {
+			logger.Fatalf("error starting assertoor service: %v", err)
+		}
+	}
+
 	if webserver != nil {
 		startFrontend(webserver)
 	}
@@ -147,38 +196,89 @@ func startFrontend(webserver *http.Server) {
 	router.HandleFunc("/index/data", handlers.IndexData).Methods("GET")
 	router.HandleFunc("/clients/consensus", handlers.ClientsCL).Methods("GET")
 	router.HandleFunc("/clients/execution", handlers.ClientsEl).Methods("GET")
+	router.HandleFunc("/clients/diversity", handlers.ClientDiversity).Methods("GET")
+	router.HandleFunc("/clients/propagation", handlers.BlockPropagation).Methods("GET")
 	router.HandleFunc("/forks", handlers.Forks).Methods("GET")
+	router.HandleFunc("/assertoor", handlers.Assertoor).Methods("GET")
 	router.HandleFunc("/epochs", handlers.Epochs).Methods("GET")
 	router.HandleFunc("/epoch/{epoch}", handlers.Epoch).Methods("GET")
+	router.HandleFunc("/epoch/{epoch}/rewards", handlers.EpochRewards).Methods("GET")
+	router.HandleFunc("/epoch/{epoch}/state/ssz", handlers.EpochStateSSZ).Methods("GET")
 	router.HandleFunc("/slots", handlers.Slots).Methods("GET")
 	router.HandleFunc("/slots/filtered", handlers.SlotsFiltered).Methods("GET")
+	router.HandleFunc("/slots/missed", handlers.MissedSlots).Methods("GET")
+	router.HandleFunc("/slots/tags", handlers.BlockTags).Methods("GET")
 	router.HandleFunc("/slot/{slotOrHash}", handlers.Slot).Methods("GET")
 	router.HandleFunc("/slot/{root}/blob/{commitment}", handlers.SlotBlob).Methods("GET")
+	router.HandleFunc("/slot/{root}/download/ssz", handlers.SlotDownloadSSZ).Methods("GET")
+	router.HandleFunc("/block/{numberOrHash}", handlers.ExecutionBlock).Methods("GET")
+	router.HandleFunc("/address/{address}", handlers.Address).Methods("GET")
+	router.HandleFunc("/graffiti", handlers.Graffiti).Methods("GET")
+	router.HandleFunc("/custom/{name}", handlers.CustomPage).Methods("GET")
 	router.HandleFunc("/mev/blocks", handlers.MevBlocks).Methods("GET")
+	router.HandleFunc("/blobs/rollups", handlers.BlobRollups).Methods("GET")
+	router.HandleFunc("/blobs/columns", handlers.BlobColumns).Methods("GET")
+	router.HandleFunc("/blobs/availability", handlers.BlobAvailability).Methods("GET")
+	router.HandleFunc("/lightclient", handlers.LightClient).Methods("GET")
+	router.HandleFunc("/tools/validate_object", handlers.ValidateObject).Methods("GET", "POST")
 
 	router.HandleFunc("/search", handlers.Search).Methods("GET")
 	router.HandleFunc("/search/{type}", handlers.SearchAhead).Methods("GET")
 	router.HandleFunc("/validators", handlers.Validators).Methods("GET")
 	router.HandleFunc("/validators/activity", handlers.ValidatorsActivity).Methods("GET")
+	router.HandleFunc("/validators/watchlist", handlers.ValidatorsWatchlist).Methods("GET")
+	router.HandleFunc("/entities", handlers.Entities).Methods("GET")
+	router.HandleFunc("/entity/{id}", handlers.Entity).Methods("GET")
+	router.HandleFunc("/validators/staking_entities", handlers.StakingEntities).Methods("GET")
+	router.HandleFunc("/validators/genesis", handlers.GenesisValidators).Methods("GET")
+	router.HandleFunc("/validators/queues", handlers.ValidatorQueues).Methods("GET")
 	router.HandleFunc("/validators/deposits", handlers.Deposits).Methods("GET")
 	router.HandleFunc("/validators/deposits/submit", handlers.SubmitDeposit).Methods("GET", "POST")
 	router.HandleFunc("/validators/initiated_deposits", handlers.InitiatedDeposits).Methods("GET")
 	router.HandleFunc("/validators/included_deposits", handlers.IncludedDeposits).Methods("GET")
 	router.HandleFunc("/validators/voluntary_exits", handlers.VoluntaryExits).Methods("GET")
 	router.HandleFunc("/validators/slashings", handlers.Slashings).Methods("GET")
+	router.HandleFunc("/validators/fee_recipients", handlers.FeeRecipients).Methods("GET")
 	router.HandleFunc("/validators/el_withdrawals", handlers.ElWithdrawals).Methods("GET")
 	router.HandleFunc("/validators/el_consolidations", handlers.ElConsolidations).Methods("GET")
+	router.HandleFunc("/validators/request_fees", handlers.RequestFees).Methods("GET")
+	router.HandleFunc("/validators/effective_balances", handlers.EffectiveBalances).Methods("GET")
+	router.HandleFunc("/export/slots", handlers.SlotsExport).Methods("GET")
+	router.HandleFunc("/export/validators", handlers.ValidatorsExport).Methods("GET")
+	router.HandleFunc("/export/validators/{format}", handlers.ValidatorsToolingExport).Methods("GET")
+	router.HandleFunc("/export/deposits", handlers.DepositsExport).Methods("GET")
+	router.HandleFunc("/export/withdrawals", handlers.WithdrawalsExport).Methods("GET")
+	router.HandleFunc("/export/voluntary_exits", handlers.VoluntaryExitsExport).Methods("GET")
+	router.HandleFunc("/export/slashings", handlers.SlashingsExport).Methods("GET")
 	router.HandleFunc("/validators/submit_consolidations", handlers.SubmitConsolidation).Methods("GET")
 	router.HandleFunc("/validators/submit_withdrawals", handlers.SubmitWithdrawal).Methods("GET")
+	router.HandleFunc("/validators/broadcast", handlers.SubmitBroadcast).Methods("GET", "POST")
 	router.HandleFunc("/validator/{idxOrPubKey}", handlers.Validator).Methods("GET")
 	router.HandleFunc("/validator/{index}/slots", handlers.ValidatorSlots).Methods("GET")
 
+	// api endpoints
+	router.HandleFunc("/api/v1/openapi.json", api.ApiOpenApiSpec).Methods("GET")
+	router.HandleFunc("/api/swagger.json", api.ApiOpenApiSpec).Methods("GET")
+	for _, route := range api.ApiRoutes {
+		router.HandleFunc(route.Path, api.ApiRouteHandler(route)).Methods(route.Method)
+	}
+	if utils.Config.BeaconApiProxy.Enabled {
+		for _, route := range api.BeaconApiProxyRoutes {
+			router.HandleFunc(route.Path, api.ApiRouteHandler(route)).Methods(route.Method)
+		}
+	}
+
 	if utils.Config.Frontend.Pprof {
 		// add pprof handler
 		router.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux)
 		router.HandleFunc("/debug/cache", handlers.DebugCache).Methods("GET")
 	}
 
+	if utils.Config.Frontend.Metrics {
+		// expose prometheus metrics
+		router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+	}
+
 	if utils.Config.Frontend.Debug {
 		// serve files from local directory when debugging, instead of from go embed file
 		templatesHandler := http.FileServer(http.Dir("templates"))
@@ -204,10 +304,18 @@ func startFrontend(webserver *http.Server) {
 	fileSys := http.FS(static.Files)
 	router.PathPrefix("/").Handler(handlers.CustomFileServer(http.FileServer(fileSys), fileSys, handlers.NotFound))
 
+	// track page build & render timings
+	router.Use(handlers.PageMetricsMiddleware)
+
 	n := negroni.New()
 	n.Use(negroni.NewRecovery())
 	//n.Use(gzip.Gzip(gzip.DefaultCompression))
 	n.UseHandler(router)
 
-	webserver.Handler = n
+	// the event stream bypasses negroni, as its response writer does not allow lifting the write timeout for long lived streams
+	rootMux := http.NewServeMux()
+	rootMux.Handle("/api/v1/events", router)
+	rootMux.Handle("/", n)
+
+	webserver.Handler = rootMux
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// runMigrateDb is the entrypoint for the `migrate-db` subcommand.
+// it copies all tables from a sqlite database into the configured pgsql database and exits.
+func runMigrateDb(args []string) {
+	flags := flag.NewFlagSet("migrate-db", flag.ExitOnError)
+	configPath := flags.String("config", "", "Path to the config file, if empty string defaults will be used")
+	sqliteFile := flags.String("sqlite", "", "Path to the sqlite database to migrate, defaults to database.sqlite.file from the config")
+	batchSize := flags.Int("batch", 1000, "Number of rows to insert per batch")
+	truncate := flags.Bool("truncate", false, "Clear all target tables before copying (required if the pgsql database is not empty)")
+	skipVerify := flags.Bool("skip-verify", false, "Skip the row count verification after copying")
+	flags.Usage = func() {
+		flags.Output().Write([]byte("Usage: dora-explorer migrate-db [options]\n\nCopies all tables from a sqlite database into the pgsql database configured in database.pgsql.\nDora must not be running on either database while the migration is in progress.\n\nOptions:\n"))
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	cfg := &types.Config{}
+	err := utils.ReadConfig(cfg, *configPath)
+	if err != nil {
+		logrus.Fatalf("error reading config file: %v", err)
+	}
+	if *sqliteFile == "" {
+		*sqliteFile = cfg.Database.Sqlite.File
+	}
+
+	// the configured engine might still point to the sqlite database, the migration always targets pgsql
+	cfg.Database.Engine = "pgsql"
+	utils.Config = cfg
+	logWriter, logger := utils.InitLogger()
+	defer logWriter.Dispose()
+
+	if *sqliteFile == "" {
+		logger.Fatalf("no sqlite database given")
+	}
+	if cfg.Database.Pgsql.Host == "" {
+		logger.Fatalf("no pgsql database configured")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"config":  *configPath,
+		"version": utils.BuildVersion,
+		"release": utils.BuildRelease,
+		"sqlite":  *sqliteFile,
+	}).Printf("starting db migration")
+
+	db.MustInitDB()
+	err = db.ApplyEmbeddedDbSchema(-2)
+	if err != nil {
+		logger.Fatalf("error initializing db schema: %v", err)
+	}
+
+	err = db.MigrateFromSqlite(ctx, &db.SqliteMigrationOptions{
+		SqliteFile: *sqliteFile,
+		BatchSize:  *batchSize,
+		Truncate:   *truncate,
+		SkipVerify: *skipVerify,
+	})
+	db.MustCloseDB()
+	if err != nil {
+		logger.Fatalf("db migration failed: %v", err)
+	}
+
+	logger.Infof("db migration completed, switch database.engine to pgsql to use the migrated database")
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// GetAddressWatchEvents returns the deposit & system request contract interactions of the filtered addresses (newest first) and the total number of matches.
+// an interaction matches if one of the addresses is the transaction sender or the request source address.
+func GetAddressWatchEvents(offset uint64, limit uint32, filter *dbtypes.AddressWatchFilter) ([]*dbtypes.AddressWatchEvent, uint64, error) {
+	if len(filter.Addresses) == 0 {
+		return []*dbtypes.AddressWatchEvent{}, 0, nil
+	}
+
+	args := []interface{}{filter.MinBlock}
+	addressArgs := make([]string, len(filter.Addresses))
+	for i, address := range filter.Addresses {
+		args = append(args, address)
+		addressArgs[i] = fmt.Sprintf("$%v", len(args))
+	}
+	addressList := strings.Join(addressArgs, ", ")
+
+	queries := []string{}
+	if filter.Type == 0 || filter.Type == dbtypes.AddressWatchEventDeposit {
+		queries = append(queries, fmt.Sprintf(`
+			SELECT
+				%v AS type, block_number, block_time, fork_id, tx_hash, tx_sender, NULL AS source_address,
+				publickey AS validator_pubkey, NULL AS target_pubkey, amount
+			FROM deposit_txs
+			WHERE block_number >= $1 AND tx_sender IN (%v)`,
+			dbtypes.AddressWatchEventDeposit, addressList,
+		))
+	}
+	if filter.Type == 0 || filter.Type == dbtypes.AddressWatchEventWithdrawalRequest {
+		queries = append(queries, fmt.Sprintf(`
+			SELECT
+				%v AS type, block_number, block_time, fork_id, tx_hash, tx_sender, source_address,
+				validator_pubkey, NULL AS target_pubkey, amount
+			FROM withdrawal_request_txs
+			WHERE block_number >= $1 AND (tx_sender IN (%v) OR source_address IN (%v))`,
+			dbtypes.AddressWatchEventWithdrawalRequest, addressList, addressList,
+		))
+	}
+	if filter.Type == 0 || filter.Type == dbtypes.AddressWatchEventConsolidationRequest {
+		queries = append(queries, fmt.Sprintf(`
+			SELECT
+				%v AS type, block_number, block_time, fork_id, tx_hash, tx_sender, source_address,
+				source_pubkey AS validator_pubkey, target_pubkey, 0 AS amount
+			FROM consolidation_request_txs
+			WHERE block_number >= $1 AND (tx_sender IN (%v) OR source_address IN (%v))`,
+			dbtypes.AddressWatchEventConsolidationRequest, addressList, addressList,
+		))
+	}
+	unionQuery := strings.Join(queries, " UNION ALL ")
+
+	var totalCount uint64
+	err := ReaderDb.Get(&totalCount, fmt.Sprintf(`SELECT COUNT(*) FROM (%v) AS events`, unionQuery), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching address watch event count: %v", err)
+		return nil, 0, err
+	}
+
+	args = append(args, limit, offset)
+	events := []*dbtypes.AddressWatchEvent{}
+	err = ReaderDb.Select(&events, fmt.Sprintf(`
+		SELECT type, block_number, block_time, fork_id, tx_hash, tx_sender, source_address, validator_pubkey, target_pubkey, amount
+		FROM (%v) AS events
+		ORDER BY block_number DESC, type ASC
+		LIMIT $%v OFFSET $%v`, unionQuery, len(args)-1, len(args)), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching address watch events: %v", err)
+		return nil, 0, err
+	}
+
+	return events, totalCount, nil
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// ArchiveDatabase moves all tables of the explorer database to an archive and leaves an empty database behind.
+// pgsql: the tables are moved to a new schema with the given archive name.
+// sqlite: the database is copied to "<file>.<archive name>" before all tables are dropped.
+// the embedded schema needs to be re-applied afterwards.
+func ArchiveDatabase(archiveName string) error {
+	switch DbEngine {
+	case dbtypes.DBEnginePgsql:
+		return RunDBTransaction(func(tx *sqlx.Tx) error {
+			tables := []string{}
+			err := tx.Select(&tables, `SELECT tablename FROM pg_tables WHERE schemaname = 'public'`)
+			if err != nil {
+				return fmt.Errorf("error while fetching tables: %v", err)
+			}
+
+			_, err = tx.Exec(fmt.Sprintf(`CREATE SCHEMA %v`, pq.QuoteIdentifier(archiveName)))
+			if err != nil {
+				return fmt.Errorf("error while creating archive schema: %v", err)
+			}
+
+			for _, table := range tables {
+				_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE public.%v SET SCHEMA %v`, pq.QuoteIdentifier(table), pq.QuoteIdentifier(archiveName)))
+				if err != nil {
+					return fmt.Errorf("error while archiving table %v: %v", table, err)
+				}
+			}
+
+			return nil
+		})
+
+	case dbtypes.DBEngineSqlite:
+		// VACUUM INTO can't run inside a transaction
+		writerMutex.Lock()
+		_, err := writerDb.Exec(`VACUUM INTO $1`, fmt.Sprintf("%v.%v", utils.Config.Database.Sqlite.File, archiveName))
+		writerMutex.Unlock()
+		if err != nil {
+			return fmt.Errorf("error while copying database to archive: %v", err)
+		}
+
+		return RunDBTransaction(func(tx *sqlx.Tx) error {
+			tables := []string{}
+			err := tx.Select(&tables, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+			if err != nil {
+				return fmt.Errorf("error while fetching tables: %v", err)
+			}
+
+			for _, table := range tables {
+				_, err = tx.Exec(fmt.Sprintf(`DROP TABLE "%v"`, table))
+				if err != nil {
+					return fmt.Errorf("error while dropping table %v: %v", table, err)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	return fmt.Errorf("unknown database engine")
+}
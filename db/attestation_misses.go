@@ -0,0 +1,76 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertAttestationMisses inserts or replaces the attributed attestation misses of multiple entities in a batch
+func InsertAttestationMisses(misses []*dbtypes.AttestationMisses, tx *sqlx.Tx) error {
+	if len(misses) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(misses))
+	valueArgs := make([]interface{}, 0, len(misses)*6)
+	for i, miss := range misses {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v)", i*6+1, i*6+2, i*6+3, i*6+4, i*6+5, i*6+6)
+		valueArgs = append(valueArgs,
+			miss.Epoch,
+			miss.Entity,
+			miss.MissedLateBlock,
+			miss.MissedAttester,
+			miss.DelayedLateBlock,
+			miss.DelayedAttester)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO attestation_misses (
+				epoch, entity, missed_late_block, missed_attester, delayed_late_block, delayed_attester
+			) VALUES %s
+			ON CONFLICT (epoch, entity) DO UPDATE SET
+				missed_late_block = excluded.missed_late_block,
+				missed_attester = excluded.missed_attester,
+				delayed_late_block = excluded.delayed_late_block,
+				delayed_attester = excluded.delayed_attester`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO attestation_misses (
+				epoch, entity, missed_late_block, missed_attester, delayed_late_block, delayed_attester
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting attestation misses: %v", err)
+	}
+
+	return nil
+}
+
+// GetAttestationMissStats returns the attributed attestation misses since minEpoch aggregated per entity (most misses first)
+func GetAttestationMissStats(minEpoch uint64, limit uint64) ([]*dbtypes.AttestationMissStats, error) {
+	stats := []*dbtypes.AttestationMissStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT
+			entity,
+			COUNT(*) AS epochs,
+			SUM(missed_late_block) AS missed_late_block,
+			SUM(missed_attester) AS missed_attester,
+			SUM(delayed_late_block) AS delayed_late_block,
+			SUM(delayed_attester) AS delayed_attester
+		FROM attestation_misses
+		WHERE epoch >= $1
+		GROUP BY entity
+		ORDER BY SUM(missed_late_block + missed_attester + delayed_late_block + delayed_attester) DESC
+		LIMIT $2`, minEpoch, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching attestation miss stats: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertBlobTransactions(blobTxs []*dbtypes.BlobTransaction, tx *sqlx.Tx) error {
+	if len(blobTxs) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO blob_transactions ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO blob_transactions ",
+		}),
+		"(slot, tx_index, tx_hash, sender, to_address, blob_count)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 6
+
+	args := make([]any, len(blobTxs)*fieldCount)
+	for i, blobTx := range blobTxs {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = blobTx.Slot
+		args[argIdx+1] = blobTx.TxIndex
+		args[argIdx+2] = blobTx.TxHash
+		args[argIdx+3] = blobTx.Sender
+		args[argIdx+4] = blobTx.ToAddress
+		args[argIdx+5] = blobTx.BlobCount
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (slot, tx_index) DO UPDATE SET tx_hash = excluded.tx_hash, sender = excluded.sender, to_address = excluded.to_address, blob_count = excluded.blob_count",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetBlobTransactionStats returns the blob usage per sender / target address combination, grouped in buckets of slotsPerBucket slots.
+func GetBlobTransactionStats(minSlot uint64, slotsPerBucket uint64) ([]*dbtypes.BlobTransactionStats, error) {
+	if slotsPerBucket == 0 {
+		slotsPerBucket = 1
+	}
+
+	stats := []*dbtypes.BlobTransactionStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT slot / $1 AS bucket, sender, to_address, COUNT(*) AS tx_count, SUM(blob_count) AS blob_count, MAX(slot) AS last_slot
+		FROM blob_transactions
+		WHERE slot >= $2
+		GROUP BY bucket, sender, to_address
+		ORDER BY bucket ASC`, slotsPerBucket, minSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching blob transaction stats: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
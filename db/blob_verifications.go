@@ -0,0 +1,77 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertBlobVerifications(verifications []*dbtypes.BlobVerification, tx *sqlx.Tx) error {
+	if len(verifications) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO blob_verifications ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO blob_verifications ",
+		}),
+		"(block_root, client_name, slot, blob_count, valid_count, invalid_count, result)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 7
+
+	args := make([]any, len(verifications)*fieldCount)
+	for i, verification := range verifications {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = verification.BlockRoot
+		args[argIdx+1] = verification.ClientName
+		args[argIdx+2] = verification.Slot
+		args[argIdx+3] = verification.BlobCount
+		args[argIdx+4] = verification.ValidCount
+		args[argIdx+5] = verification.InvalidCount
+		args[argIdx+6] = verification.Result
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (block_root, client_name) DO UPDATE SET blob_count = excluded.blob_count, valid_count = excluded.valid_count, invalid_count = excluded.invalid_count, result = excluded.result",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetBlobVerificationsByRoot returns the kzg verification results of all clients for a block
+func GetBlobVerificationsByRoot(blockRoot []byte) []*dbtypes.BlobVerification {
+	verifications := []*dbtypes.BlobVerification{}
+	err := ReaderDb.Select(&verifications, `
+		SELECT block_root, client_name, slot, blob_count, valid_count, invalid_count, result
+		FROM blob_verifications
+		WHERE block_root = $1
+		ORDER BY client_name ASC`, blockRoot)
+	if err != nil {
+		logger.Errorf("Error while fetching blob verifications: %v", err)
+		return nil
+	}
+
+	return verifications
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/blobstore"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// PayloadStore is the optional external store for large block payloads.
+// If nil, all payloads are stored in the database.
+var PayloadStore blobstore.BlobStore
+
+const payloadStoreTimeout = 30 * time.Second
+
+func mustInitBlobStore() {
+	storeConfig := utils.Config.BlobStore
+	store, err := blobstore.NewBlobStore(&blobstore.Config{
+		Provider:  storeConfig.Provider,
+		Path:      storeConfig.Path,
+		Endpoint:  storeConfig.Endpoint,
+		Region:    storeConfig.Region,
+		Bucket:    storeConfig.Bucket,
+		Prefix:    storeConfig.Prefix,
+		AccessKey: storeConfig.AccessKey,
+		SecretKey: storeConfig.SecretKey,
+		PathStyle: storeConfig.PathStyle,
+		AuthToken: storeConfig.AuthToken,
+	})
+	if err != nil {
+		utils.LogFatal(err, "error initializing blob store", 0)
+	}
+
+	if store != nil {
+		logger.Infof("using %v blob store for block payloads", storeConfig.Provider)
+	}
+	PayloadStore = store
+}
+
+func getUnfinalizedBlockPayloadKey(root []byte) string {
+	return fmt.Sprintf("blocks/unfinalized/%x", root)
+}
+
+func getOrphanedBlockPayloadKey(root []byte) string {
+	return fmt.Sprintf("blocks/orphaned/%x", root)
+}
+
+func getBlobSidecarsPayloadKey(root []byte) string {
+	return fmt.Sprintf("blobs/%x", root)
+}
+
+// PutBlobSidecars stores the ssz encoded blob sidecars of a block in the blob store.
+// blob sidecars are not kept in the database, so nothing is stored if no blob store is configured.
+func PutBlobSidecars(root []byte, sidecarsSSZ []byte) error {
+	if PayloadStore == nil {
+		return nil
+	}
+
+	return putPayload(getBlobSidecarsPayloadKey(root), sidecarsSSZ)
+}
+
+// GetBlobSidecars returns the ssz encoded blob sidecars of a block from the blob store.
+// returns nil if no blob store is configured or no sidecars have been stored for the block.
+func GetBlobSidecars(root []byte) ([]byte, error) {
+	if PayloadStore == nil {
+		return nil, nil
+	}
+
+	data, err := getPayload(getBlobSidecarsPayloadKey(root))
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, nil
+	}
+	return data, err
+}
+
+func putPayload(key string, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+
+	err := PayloadStore.Put(ctx, key, data)
+	if err != nil {
+		return fmt.Errorf("error writing %v to blob store: %w", key, err)
+	}
+	return nil
+}
+
+// moveBlockPayload moves the inline payload of a committed block row to the blob store.
+// the payload stays in the database if the blob store write fails.
+func moveBlockPayload(table string, key string, root []byte, payload []byte) {
+	if err := putPayload(key, payload); err != nil {
+		logger.Warnf("error moving block 0x%x payload to blob store, keeping it in the db: %v", root, err)
+		return
+	}
+
+	var rowCount int64
+	err := RunDBTransaction(func(tx *sqlx.Tx) error {
+		res, err := tx.Exec(fmt.Sprintf(`UPDATE %v SET block_ssz = $1, block_ext = 1 WHERE root = $2 AND block_ext = 0`, table), []byte{}, root)
+		if err != nil {
+			return err
+		}
+
+		rowCount, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		logger.Warnf("error updating block 0x%x payload reference, keeping it in the db: %v", root, err)
+	}
+	if err != nil || rowCount == 0 {
+		// the row has been deleted or updated meanwhile, the payload isn't referenced
+		deletePayloads([]string{key})
+	}
+}
+
+func getPayload(key string) ([]byte, error) {
+	if PayloadStore == nil {
+		return nil, fmt.Errorf("payload %v is stored externally, but no blob store is configured", key)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+
+	data, err := PayloadStore.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %v from blob store: %w", key, err)
+	}
+	return data, nil
+}
+
+func deletePayloads(keys []string) {
+	if PayloadStore == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), payloadStoreTimeout)
+	defer cancel()
+
+	for _, key := range keys {
+		err := PayloadStore.Delete(ctx, key)
+		if err != nil {
+			logger.Warnf("error deleting %v from blob store: %v", key, err)
+		}
+	}
+}
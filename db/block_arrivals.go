@@ -0,0 +1,196 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertBlockArrivals inserts or replaces the arrival delays of multiple blocks and their per client delays in a batch
+func InsertBlockArrivals(arrivals []*dbtypes.BlockArrival, clientArrivals []*dbtypes.BlockArrivalClient, tx *sqlx.Tx) error {
+	if len(arrivals) > 0 {
+		valueStrings := make([]string, len(arrivals))
+		valueArgs := make([]interface{}, 0, len(arrivals)*6)
+		for i, arrival := range arrivals {
+			valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v)", i*6+1, i*6+2, i*6+3, i*6+4, i*6+5, i*6+6)
+			valueArgs = append(valueArgs,
+				arrival.Slot,
+				arrival.Proposer,
+				arrival.SeenBy,
+				arrival.MinDelay,
+				arrival.MedianDelay,
+				arrival.MaxDelay)
+		}
+
+		stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql: `
+				INSERT INTO block_arrivals (
+					slot, proposer, seen_by, min_delay, median_delay, max_delay
+				) VALUES %s
+				ON CONFLICT (slot) DO UPDATE SET
+					proposer = excluded.proposer,
+					seen_by = excluded.seen_by,
+					min_delay = excluded.min_delay,
+					median_delay = excluded.median_delay,
+					max_delay = excluded.max_delay`,
+			dbtypes.DBEngineSqlite: `
+				INSERT OR REPLACE INTO block_arrivals (
+					slot, proposer, seen_by, min_delay, median_delay, max_delay
+				) VALUES %s`,
+		}), strings.Join(valueStrings, ","))
+
+		_, err := tx.Exec(stmt, valueArgs...)
+		if err != nil {
+			return fmt.Errorf("error inserting block arrivals: %v", err)
+		}
+	}
+
+	if len(clientArrivals) > 0 {
+		valueStrings := make([]string, len(clientArrivals))
+		valueArgs := make([]interface{}, 0, len(clientArrivals)*4)
+		for i, clientArrival := range clientArrivals {
+			valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v)", i*4+1, i*4+2, i*4+3, i*4+4)
+			valueArgs = append(valueArgs,
+				clientArrival.Slot,
+				clientArrival.Client,
+				clientArrival.ClientId,
+				clientArrival.Delay)
+		}
+
+		stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql: `
+				INSERT INTO block_arrival_clients (
+					slot, client, client_id, delay
+				) VALUES %s
+				ON CONFLICT (slot, client) DO UPDATE SET
+					client_id = excluded.client_id,
+					delay = excluded.delay`,
+			dbtypes.DBEngineSqlite: `
+				INSERT OR REPLACE INTO block_arrival_clients (
+					slot, client, client_id, delay
+				) VALUES %s`,
+		}), strings.Join(valueStrings, ","))
+
+		_, err := tx.Exec(stmt, valueArgs...)
+		if err != nil {
+			return fmt.Errorf("error inserting block arrival clients: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// GetBlockArrivalHistory returns the average arrival delays of blocks since minSlot.
+// blocks are aggregated in buckets of slotsPerBucket slots (bucket = slot / slotsPerBucket).
+func GetBlockArrivalHistory(minSlot uint64, slotsPerBucket uint64) ([]*dbtypes.BlockArrivalStats, error) {
+	if slotsPerBucket == 0 {
+		slotsPerBucket = 1
+	}
+
+	stats := []*dbtypes.BlockArrivalStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT slot / $1 AS bucket, COUNT(*) AS blocks,
+			AVG(min_delay) AS min_delay, AVG(median_delay) AS median_delay, AVG(max_delay) AS max_delay
+		FROM block_arrivals
+		WHERE slot >= $2
+		GROUP BY bucket
+		ORDER BY bucket ASC`, slotsPerBucket, minSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching block arrival history: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetBlockArrivalProposerStats returns the average arrival delays of blocks since minSlot grouped by proposer (slowest proposers first)
+func GetBlockArrivalProposerStats(minSlot uint64, limit uint32) ([]*dbtypes.BlockArrivalStats, error) {
+	stats := []*dbtypes.BlockArrivalStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT proposer, COUNT(*) AS blocks,
+			AVG(min_delay) AS min_delay, AVG(median_delay) AS median_delay, AVG(max_delay) AS max_delay
+		FROM block_arrivals
+		WHERE slot >= $1
+		GROUP BY proposer
+		ORDER BY median_delay DESC, proposer ASC
+		LIMIT $2`, minSlot, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching block arrival proposer stats: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetBlockArrivalClientTypeStats returns the average arrival delays of blocks since minSlot grouped by the guessed proposing consensus client.
+// blocks that have not been classified yet are skipped.
+func GetBlockArrivalClientTypeStats(minSlot uint64) ([]*dbtypes.BlockArrivalStats, error) {
+	stats := []*dbtypes.BlockArrivalStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT slots.cl_client AS cl_client, COUNT(*) AS blocks,
+			AVG(block_arrivals.min_delay) AS min_delay, AVG(block_arrivals.median_delay) AS median_delay, AVG(block_arrivals.max_delay) AS max_delay
+		FROM block_arrivals
+		JOIN slots ON slots.slot = block_arrivals.slot AND slots.status = $2
+		WHERE block_arrivals.slot >= $1 AND slots.cl_client != 0
+		GROUP BY slots.cl_client
+		ORDER BY median_delay DESC`, minSlot, dbtypes.Canonical)
+	if err != nil {
+		logger.Errorf("Error while fetching block arrival client type stats: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetBlockArrivalClientStats returns the arrival delays of blocks since minSlot grouped by the announcing client.
+// arrivals with a stable client id are grouped by the current client name, so renamed clients are not split up.
+func GetBlockArrivalClientStats(minSlot uint64) ([]*dbtypes.BlockArrivalClientStats, error) {
+	stats := []*dbtypes.BlockArrivalClientStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT COALESCE(pool_clients.name, block_arrival_clients.client) AS client, COUNT(*) AS blocks, AVG(delay) AS avg_delay, MAX(delay) AS max_delay
+		FROM block_arrival_clients
+		LEFT JOIN pool_clients ON pool_clients.client_id = block_arrival_clients.client_id AND block_arrival_clients.client_id > 0
+		WHERE slot >= $1
+		GROUP BY COALESCE(pool_clients.name, block_arrival_clients.client)
+		ORDER BY avg_delay ASC, client ASC`, minSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching block arrival client stats: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetBlockArrivalsRange returns the arrival delays of the blocks between minSlot and maxSlot (inclusive), ordered by slot
+func GetBlockArrivalsRange(minSlot uint64, maxSlot uint64) ([]*dbtypes.BlockArrival, error) {
+	arrivals := []*dbtypes.BlockArrival{}
+	err := ReaderDb.Select(&arrivals, `
+		SELECT slot, proposer, seen_by, min_delay, median_delay, max_delay
+		FROM block_arrivals
+		WHERE slot >= $1 AND slot <= $2
+		ORDER BY slot ASC`, minSlot, maxSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching block arrivals: %v", err)
+		return nil, err
+	}
+
+	return arrivals, nil
+}
+
+// GetBlockArrivalClientsRange returns the per client arrival delays of the blocks between minSlot and maxSlot (inclusive), ordered by slot & delay
+func GetBlockArrivalClientsRange(minSlot uint64, maxSlot uint64) ([]*dbtypes.BlockArrivalClient, error) {
+	arrivals := []*dbtypes.BlockArrivalClient{}
+	err := ReaderDb.Select(&arrivals, `
+		SELECT slot, client, client_id, delay
+		FROM block_arrival_clients
+		WHERE slot >= $1 AND slot <= $2
+		ORDER BY slot ASC, delay ASC`, minSlot, maxSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching block arrival clients: %v", err)
+		return nil, err
+	}
+
+	return arrivals, nil
+}
@@ -0,0 +1,28 @@
+package db
+
+import (
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// GetBlockClientStats returns the canonical block counts grouped by the guessed proposing clients.
+// blocks are aggregated in buckets of slotsPerBucket slots (bucket = slot / slotsPerBucket), starting from minSlot.
+// blocks that have not been classified yet (indexed before the client columns were added) are skipped.
+func GetBlockClientStats(minSlot uint64, slotsPerBucket uint64) ([]*dbtypes.BlockClientStats, error) {
+	if slotsPerBucket == 0 {
+		slotsPerBucket = 1
+	}
+
+	stats := []*dbtypes.BlockClientStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT slot / $1 AS bucket, cl_client, el_client, COUNT(*) AS blocks
+		FROM slots
+		WHERE slot >= $2 AND status = $3 AND cl_client != 0
+		GROUP BY bucket, cl_client, el_client
+		ORDER BY bucket ASC`, slotsPerBucket, minSlot, dbtypes.Canonical)
+	if err != nil {
+		logger.Errorf("Error while fetching block client stats: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
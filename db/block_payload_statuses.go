@@ -0,0 +1,63 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertBlockPayloadStatuses inserts the payload verdicts of multiple blocks in a batch, existing verdicts are updated
+func InsertBlockPayloadStatuses(statuses []*dbtypes.BlockPayloadStatus, tx *sqlx.Tx) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(statuses))
+	valueArgs := make([]interface{}, 0, len(statuses)*5)
+	for i, status := range statuses {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5)
+		valueArgs = append(valueArgs,
+			status.Root,
+			status.Slot,
+			status.ClientId,
+			status.ClientName,
+			status.Status)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO block_payload_statuses (
+				root, slot, client_id, client_name, status
+			) VALUES %s
+			ON CONFLICT (root, client_id) DO UPDATE SET
+				client_name = excluded.client_name,
+				status = excluded.status`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO block_payload_statuses (
+				root, slot, client_id, client_name, status
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting block payload statuses: %v", err)
+	}
+	return nil
+}
+
+// GetBlockPayloadStatuses returns the persisted payload verdicts of a block ordered by client name
+func GetBlockPayloadStatuses(root []byte) []*dbtypes.BlockPayloadStatus {
+	statuses := []*dbtypes.BlockPayloadStatus{}
+	err := ReaderDb.Select(&statuses, `
+		SELECT root, slot, client_id, client_name, status
+		FROM block_payload_statuses
+		WHERE root = $1
+		ORDER BY client_name ASC`, root)
+	if err != nil {
+		logger.Errorf("Error while fetching block payload statuses: %v", err)
+		return nil
+	}
+	return statuses
+}
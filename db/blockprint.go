@@ -0,0 +1,92 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertBlockprintSlots(blockprints []*dbtypes.BlockprintSlot, tx *sqlx.Tx) error {
+	if len(blockprints) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO slot_blockprints ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO slot_blockprints ",
+		}),
+		"(slot, proposer, client, probability)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 4
+
+	args := make([]any, len(blockprints)*fieldCount)
+	for i, blockprint := range blockprints {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = blockprint.Slot
+		args[argIdx+1] = blockprint.Proposer
+		args[argIdx+2] = blockprint.Client
+		args[argIdx+3] = blockprint.Probability
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (slot) DO UPDATE SET proposer = excluded.proposer, client = excluded.client, probability = excluded.probability",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetBlockprintSlot(slot uint64) *dbtypes.BlockprintSlot {
+	blockprint := dbtypes.BlockprintSlot{}
+	err := ReaderDb.Get(&blockprint, `
+		SELECT slot, proposer, client, probability
+		FROM slot_blockprints
+		WHERE slot = $1
+	`, slot)
+	if err != nil {
+		return nil
+	}
+	return &blockprint
+}
+
+// GetBlockprintDayStats returns the number of blockprint classified blocks per client, aggregated per day (day = slot / slotsPerDay).
+func GetBlockprintDayStats(minSlot uint64, slotsPerDay uint64) ([]*dbtypes.BlockprintDayStats, error) {
+	if slotsPerDay == 0 {
+		slotsPerDay = 1
+	}
+
+	stats := []*dbtypes.BlockprintDayStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT slot / $1 AS day, client, COUNT(*) AS blocks
+		FROM slot_blockprints
+		WHERE slot >= $2
+		GROUP BY day, client
+		ORDER BY day ASC`, slotsPerDay, minSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching blockprint day stats: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
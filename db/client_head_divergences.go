@@ -0,0 +1,93 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertClientHeadDivergences inserts or updates multiple client head divergences in a batch
+func InsertClientHeadDivergences(divergences []*dbtypes.ClientHeadDivergence, tx *sqlx.Tx) error {
+	if len(divergences) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(divergences))
+	valueArgs := make([]interface{}, 0, len(divergences)*11)
+	for i, divergence := range divergences {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", i*11+1, i*11+2, i*11+3, i*11+4, i*11+5, i*11+6, i*11+7, i*11+8, i*11+9, i*11+10, i*11+11)
+		valueArgs = append(valueArgs,
+			divergence.ClientName,
+			divergence.StartSlot,
+			divergence.EndSlot,
+			divergence.Reason,
+			divergence.HeadSlot,
+			divergence.HeadRoot,
+			divergence.CanonicalSlot,
+			divergence.CanonicalRoot,
+			divergence.FinalizedEpoch,
+			divergence.MaxDistance,
+			divergence.Resolved)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO client_head_divergences (
+				client_name, start_slot, end_slot, reason, head_slot, head_root, canonical_slot, canonical_root, finalized_epoch, max_distance, resolved
+			) VALUES %s
+			ON CONFLICT (client_name, start_slot) DO UPDATE SET
+				end_slot = excluded.end_slot,
+				reason = excluded.reason,
+				head_slot = excluded.head_slot,
+				head_root = excluded.head_root,
+				canonical_slot = excluded.canonical_slot,
+				canonical_root = excluded.canonical_root,
+				finalized_epoch = excluded.finalized_epoch,
+				max_distance = excluded.max_distance,
+				resolved = excluded.resolved`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO client_head_divergences (
+				client_name, start_slot, end_slot, reason, head_slot, head_root, canonical_slot, canonical_root, finalized_epoch, max_distance, resolved
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting client head divergences: %v", err)
+	}
+
+	return nil
+}
+
+// GetClientHeadDivergences returns the most recent client head divergences (newest first)
+func GetClientHeadDivergences(limit uint32) ([]*dbtypes.ClientHeadDivergence, error) {
+	divergences := []*dbtypes.ClientHeadDivergence{}
+	err := ReaderDb.Select(&divergences, `
+		SELECT client_name, start_slot, end_slot, reason, head_slot, head_root, canonical_slot, canonical_root, finalized_epoch, max_distance, resolved
+		FROM client_head_divergences
+		ORDER BY start_slot DESC, client_name ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching client head divergences: %v", err)
+		return nil, err
+	}
+	return divergences, nil
+}
+
+// GetUnresolvedClientHeadDivergences returns all client head divergences that are not resolved yet
+func GetUnresolvedClientHeadDivergences() ([]*dbtypes.ClientHeadDivergence, error) {
+	divergences := []*dbtypes.ClientHeadDivergence{}
+	err := ReaderDb.Select(&divergences, `
+		SELECT client_name, start_slot, end_slot, reason, head_slot, head_root, canonical_slot, canonical_root, finalized_epoch, max_distance, resolved
+		FROM client_head_divergences
+		WHERE resolved = $1
+	`, false)
+	if err != nil {
+		logger.Errorf("Error while fetching unresolved client head divergences: %v", err)
+		return nil, err
+	}
+	return divergences, nil
+}
@@ -33,6 +33,10 @@ var DbEngine dbtypes.DBEngineType
 var ReaderDb *sqlx.DB
 var writerDb *sqlx.DB
 var writerMutex sync.Mutex
+var writeFence func(tx *sqlx.Tx) error
+var writeFenceMutex sync.RWMutex
+var commitHooks = map[*sqlx.Tx][]func(){}
+var commitHooksMutex sync.Mutex
 
 var logger = logrus.StandardLogger().WithField("module", "db")
 
@@ -145,6 +149,8 @@ func MustInitDB() {
 	} else {
 		logger.Fatalf("unknown database engine type: %s", utils.Config.Database.Engine)
 	}
+
+	mustInitBlobStore()
 }
 
 func MustCloseDB() {
@@ -158,7 +164,48 @@ func MustCloseDB() {
 	}
 }
 
+// SetWriteFence sets a check that runs at the start of every write transaction (nil to disable).
+// the transaction is aborted if the check fails, which fences off writes of a replica that lost the leader lease.
+func SetWriteFence(fence func(tx *sqlx.Tx) error) {
+	writeFenceMutex.Lock()
+	defer writeFenceMutex.Unlock()
+	writeFence = fence
+}
+
 func RunDBTransaction(handler func(tx *sqlx.Tx) error) error {
+	writeFenceMutex.RLock()
+	fence := writeFence
+	writeFenceMutex.RUnlock()
+
+	if fence == nil {
+		return RunUnfencedDBTransaction(handler)
+	}
+
+	return RunUnfencedDBTransaction(func(tx *sqlx.Tx) error {
+		if err := fence(tx); err != nil {
+			return fmt.Errorf("write fence check failed: %w", err)
+		}
+
+		return handler(tx)
+	})
+}
+
+// RunUnfencedDBTransaction runs a write transaction without the write fence check (used for the lease handling itself).
+func RunUnfencedDBTransaction(handler func(tx *sqlx.Tx) error) error {
+	hooks, err := runUnfencedDBTransaction(handler)
+	if err != nil {
+		return err
+	}
+
+	// run the post commit actions outside of the transaction, so slow actions don't block other writers
+	for _, hook := range hooks {
+		hook()
+	}
+
+	return nil
+}
+
+func runUnfencedDBTransaction(handler func(tx *sqlx.Tx) error) ([]func(), error) {
 	if DbEngine == dbtypes.DBEngineSqlite {
 		writerMutex.Lock()
 		defer writerMutex.Unlock()
@@ -166,22 +213,42 @@ func RunDBTransaction(handler func(tx *sqlx.Tx) error) error {
 
 	tx, err := writerDb.Beginx()
 	if err != nil {
-		return fmt.Errorf("error starting db transactions: %v", err)
+		return nil, fmt.Errorf("error starting db transactions: %v", err)
 	}
 
 	defer tx.Rollback()
+	defer takeCommitHooks(tx)
 
 	err = handler(tx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error committing db transaction: %v", err)
+		return nil, fmt.Errorf("error committing db transaction: %v", err)
 	}
 
-	return nil
+	return takeCommitHooks(tx), nil
+}
+
+// runAfterCommit queues an action that is run after the transaction has been committed.
+// queued actions are dropped if the transaction is rolled back.
+func runAfterCommit(tx *sqlx.Tx, hook func()) {
+	commitHooksMutex.Lock()
+	defer commitHooksMutex.Unlock()
+
+	commitHooks[tx] = append(commitHooks[tx], hook)
+}
+
+// takeCommitHooks removes and returns the queued post commit actions of the transaction.
+func takeCommitHooks(tx *sqlx.Tx) []func() {
+	commitHooksMutex.Lock()
+	defer commitHooksMutex.Unlock()
+
+	hooks := commitHooks[tx]
+	delete(commitHooks, tx)
+	return hooks
 }
 
 func ApplyEmbeddedDbSchema(version int64) error {
@@ -220,6 +287,51 @@ func ApplyEmbeddedDbSchema(version int64) error {
 	return nil
 }
 
+// CheckEmbeddedDbSchema checks if all schema migrations embedded in this binary have been applied to the database.
+// frontend-only instances don't upgrade the schema themselves, so they need to wait for the writer instance to do so.
+func CheckEmbeddedDbSchema() error {
+	var schemaDirectory string
+	switch DbEngine {
+	case dbtypes.DBEnginePgsql:
+		goose.SetBaseFS(EmbedPgsqlSchema)
+		schemaDirectory = "schema/pgsql"
+	case dbtypes.DBEngineSqlite:
+		goose.SetBaseFS(EmbedSqliteSchema)
+		schemaDirectory = "schema/sqlite"
+	default:
+		logger.Fatalf("unknown database engine")
+	}
+
+	migrations, err := goose.CollectMigrations(schemaDirectory, 0, goose.MaxVersion)
+	if err != nil {
+		return err
+	}
+
+	appliedVersions := []int64{}
+	err = ReaderDb.Select(&appliedVersions, fmt.Sprintf("SELECT version_id FROM %v WHERE is_applied", goose.TableName()))
+	if err != nil {
+		return fmt.Errorf("failed loading db schema version: %v", err)
+	}
+
+	appliedMap := make(map[int64]bool, len(appliedVersions))
+	for _, version := range appliedVersions {
+		appliedMap[version] = true
+	}
+
+	pendingCount := 0
+	for _, migration := range migrations {
+		if !appliedMap[migration.Version] {
+			pendingCount++
+		}
+	}
+
+	if pendingCount > 0 {
+		return fmt.Errorf("db schema is older than this binary (%v pending migrations), start the writer instance to upgrade it first", pendingCount)
+	}
+
+	return nil
+}
+
 func EngineQuery(queryMap map[dbtypes.DBEngineType]string) string {
 	if queryMap[DbEngine] != "" {
 		return queryMap[DbEngine]
@@ -15,11 +15,11 @@ func InsertConsolidationRequestTxs(consolidationTxs []*dbtypes.ConsolidationRequ
 			dbtypes.DBEnginePgsql:  "INSERT INTO consolidation_request_txs ",
 			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO consolidation_request_txs ",
 		}),
-		"(block_number, block_index, block_time, block_root, fork_id, source_address, source_pubkey, source_index, target_pubkey, target_index, tx_hash, tx_sender, tx_target, dequeue_block)",
+		"(block_number, block_index, block_time, block_root, fork_id, source_address, source_pubkey, source_index, target_pubkey, target_index, tx_hash, tx_sender, tx_target, dequeue_block, fee)",
 		" VALUES ",
 	)
 	argIdx := 0
-	fieldCount := 14
+	fieldCount := 15
 
 	args := make([]any, len(consolidationTxs)*fieldCount)
 	for i, consolidationTx := range consolidationTxs {
@@ -50,6 +50,7 @@ func InsertConsolidationRequestTxs(consolidationTxs []*dbtypes.ConsolidationRequ
 		args[argIdx+11] = consolidationTx.TxSender
 		args[argIdx+12] = consolidationTx.TxTarget
 		args[argIdx+13] = consolidationTx.DequeueBlock
+		args[argIdx+14] = consolidationTx.Fee
 		argIdx += fieldCount
 	}
 	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
@@ -114,7 +115,7 @@ func GetConsolidationRequestTxsFiltered(offset uint64, limit uint32, canonicalFo
 	fmt.Fprint(&sql, `
 	WITH cte AS (
 		SELECT
-			block_number, block_index, block_time, block_root, fork_id, source_address, source_pubkey, source_index, target_pubkey, target_index, tx_hash, tx_sender, tx_target, dequeue_block
+			block_number, block_index, block_time, block_root, fork_id, source_address, source_pubkey, source_index, target_pubkey, target_index, tx_hash, tx_sender, tx_target, dequeue_block, fee
 		FROM consolidation_request_txs
 	`)
 
@@ -223,7 +224,8 @@ func GetConsolidationRequestTxsFiltered(offset uint64, limit uint32, canonicalFo
 		null AS tx_hash,
 		null AS tx_sender,
 		null AS tx_target,
-		0 AS dequeue_block
+		0 AS dequeue_block,
+		0 AS fee
 	FROM cte
 	UNION ALL SELECT * FROM (
 	SELECT * FROM cte
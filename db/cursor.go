@@ -0,0 +1,27 @@
+package db
+
+import "github.com/ethpandaops/dora/dbtypes"
+
+// GetNextListCursor returns the keyset cursor of the page following a page of rows with the given keys (in listing order).
+// cursor is the cursor the page has been loaded with (or nil for the first page).
+func GetNextListCursor(cursor *dbtypes.ListCursor, keys []uint64) *dbtypes.ListCursor {
+	if len(keys) == 0 {
+		return cursor
+	}
+
+	lastKey := keys[len(keys)-1]
+	nextCursor := &dbtypes.ListCursor{
+		Key: lastKey,
+	}
+
+	for i := len(keys) - 1; i >= 0 && keys[i] == lastKey; i-- {
+		nextCursor.Skip++
+	}
+
+	if nextCursor.Skip == uint64(len(keys)) && cursor != nil && cursor.Key == lastKey {
+		// the whole page shares the key of the previous cursor
+		nextCursor.Skip += cursor.Skip
+	}
+
+	return nextCursor
+}
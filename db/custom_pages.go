@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// GetCustomPageRows runs an operator defined custom page query and returns the result columns and the requested range of rows.
+// the query runs in a transaction that is always rolled back (and read-only on pgsql), so custom pages can never modify the db.
+func GetCustomPageRows(ctx context.Context, query string, args []interface{}, offset uint64, limit uint64) ([]string, []map[string]interface{}, error) {
+	tx, err := ReaderDb.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error starting custom page transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if DbEngine == dbtypes.DBEnginePgsql {
+		if _, err := tx.Exec("SET TRANSACTION READ ONLY"); err != nil {
+			return nil, nil, fmt.Errorf("error setting custom page transaction read-only: %v", err)
+		}
+	}
+
+	args = append(args, limit, offset)
+	rows, err := tx.QueryxContext(ctx, fmt.Sprintf(`
+		SELECT * FROM (%v) AS custom_page_query
+		LIMIT $%v OFFSET $%v`, query, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error running custom page query: %v", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading custom page query columns: %v", err)
+	}
+
+	results := []map[string]interface{}{}
+	for rows.Next() {
+		row := map[string]interface{}{}
+		if err := rows.MapScan(row); err != nil {
+			return nil, nil, fmt.Errorf("error scanning custom page query row: %v", err)
+		}
+		results = append(results, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error reading custom page query rows: %v", err)
+	}
+
+	return columns, results, nil
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertDataColumnAvailability(entries []*dbtypes.DataColumnAvailability, tx *sqlx.Tx) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO data_column_availability ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO data_column_availability ",
+		}),
+		"(block_root, client_name, slot, blob_count, node_id, custody_columns, available_columns, fetch_error)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 8
+
+	args := make([]any, len(entries)*fieldCount)
+	for i, entry := range entries {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = entry.BlockRoot
+		args[argIdx+1] = entry.ClientName
+		args[argIdx+2] = entry.Slot
+		args[argIdx+3] = entry.BlobCount
+		args[argIdx+4] = entry.NodeId
+		args[argIdx+5] = entry.CustodyColumns
+		args[argIdx+6] = entry.AvailableColumns
+		args[argIdx+7] = entry.FetchError
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (block_root, client_name) DO UPDATE SET blob_count = excluded.blob_count, node_id = excluded.node_id, custody_columns = excluded.custody_columns, available_columns = excluded.available_columns, fetch_error = excluded.fetch_error",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDataColumnAvailabilityBySlots returns the data column availability of all blocks within the given slot range (inclusive).
+func GetDataColumnAvailabilityBySlots(firstSlot uint64, lastSlot uint64) ([]*dbtypes.DataColumnAvailability, error) {
+	entries := []*dbtypes.DataColumnAvailability{}
+	err := ReaderDb.Select(&entries, `
+		SELECT block_root, client_name, slot, blob_count, node_id, custody_columns, available_columns, fetch_error
+		FROM data_column_availability
+		WHERE slot >= $1 AND slot <= $2
+		ORDER BY slot DESC, block_root ASC, client_name ASC`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching data column availability: %v", err)
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/ethpandaops/dora/dbtypes"
@@ -16,11 +17,11 @@ func InsertDepositTxs(depositTxs []*dbtypes.DepositTx, tx *sqlx.Tx) error {
 			dbtypes.DBEnginePgsql:  "INSERT INTO deposit_txs ",
 			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO deposit_txs ",
 		}),
-		"(deposit_index, block_number, block_time, block_root, publickey, withdrawalcredentials, amount, signature, valid_signature, orphaned, tx_hash, tx_sender, tx_target, fork_id)",
+		"(deposit_index, block_number, block_time, block_root, publickey, withdrawalcredentials, amount, signature, valid_signature, orphaned, tx_hash, tx_sender, tx_target, fork_id, problem_flags)",
 		" VALUES ",
 	)
 	argIdx := 0
-	fieldCount := 14
+	fieldCount := 15
 
 	args := make([]any, len(depositTxs)*fieldCount)
 	for i, depositTx := range depositTxs {
@@ -51,10 +52,11 @@ func InsertDepositTxs(depositTxs []*dbtypes.DepositTx, tx *sqlx.Tx) error {
 		args[argIdx+11] = depositTx.TxSender
 		args[argIdx+12] = depositTx.TxTarget
 		args[argIdx+13] = depositTx.ForkId
+		args[argIdx+14] = depositTx.ProblemFlags
 		argIdx += fieldCount
 	}
 	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
-		dbtypes.DBEnginePgsql:  " ON CONFLICT (deposit_index, block_root) DO UPDATE SET orphaned = excluded.orphaned",
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (deposit_index, block_root) DO UPDATE SET orphaned = excluded.orphaned, problem_flags = excluded.problem_flags",
 		dbtypes.DBEngineSqlite: "",
 	}))
 
@@ -120,7 +122,7 @@ func GetDepositTxs(firstIndex uint64, limit uint32) []*dbtypes.DepositTx {
 	args := []any{}
 	fmt.Fprint(&sql, `
 	SELECT
-		deposit_index, block_number, block_time, block_root, publickey, withdrawalcredentials, amount, signature, valid_signature, orphaned, tx_hash, tx_sender, tx_target, fork_id
+		deposit_index, block_number, block_time, block_root, publickey, withdrawalcredentials, amount, signature, valid_signature, orphaned, tx_hash, tx_sender, tx_target, fork_id, problem_flags
 	FROM deposit_txs
 	`)
 	if firstIndex > 0 {
@@ -149,7 +151,7 @@ func GetDepositTxsFiltered(offset uint64, limit uint32, finalizedBlock uint64, f
 	fmt.Fprint(&sql, `
 	WITH cte AS (
 		SELECT
-			deposit_index, block_number, block_time, block_root, publickey, withdrawalcredentials, amount, signature, valid_signature, orphaned, tx_hash, tx_sender, tx_target, fork_id
+			deposit_index, block_number, block_time, block_root, publickey, withdrawalcredentials, amount, signature, valid_signature, orphaned, tx_hash, tx_sender, tx_target, fork_id, problem_flags
 		FROM deposit_txs
 	`)
 
@@ -217,8 +219,14 @@ func GetDepositTxsFiltered(offset uint64, limit uint32, finalizedBlock uint64, f
 		fmt.Fprintf(&sql, " %v valid_signature = false", filterOp)
 		filterOp = "AND"
 	}
+	if filter.WithProblems == 0 {
+		fmt.Fprintf(&sql, " %v problem_flags = 0", filterOp)
+		filterOp = "AND"
+	} else if filter.WithProblems == 2 {
+		fmt.Fprintf(&sql, " %v problem_flags != 0", filterOp)
+		filterOp = "AND"
+	}
 
-	args = append(args, limit)
 	fmt.Fprintf(&sql, `) 
 	SELECT 
 		count(*) AS deposit_index, 
@@ -234,11 +242,23 @@ func GetDepositTxsFiltered(offset uint64, limit uint32, finalizedBlock uint64, f
 		null AS tx_hash, 
 		null AS tx_sender, 
 		null AS tx_target,
-		0 AS fork_id
+		0 AS fork_id,
+		0 AS problem_flags
 	FROM cte
 	UNION ALL SELECT * FROM (
 	SELECT * FROM cte
-	ORDER BY deposit_index DESC 
+	`)
+
+	if filter.Cursor != nil {
+		// keyset pagination: continue after the last returned deposit index instead of skipping all previous rows
+		args = append(args, filter.Cursor.Key)
+		fmt.Fprintf(&sql, " WHERE deposit_index <= $%v ", len(args))
+		offset += filter.Cursor.Skip
+	}
+
+	args = append(args, limit)
+	fmt.Fprintf(&sql, `
+	ORDER BY deposit_index DESC, block_root DESC 
 	LIMIT $%v 
 	`, len(args))
 
@@ -258,6 +278,62 @@ func GetDepositTxsFiltered(offset uint64, limit uint32, finalizedBlock uint64, f
 	return depositTxs[1:], depositTxs[0].Index, nil
 }
 
+// GetDepositTxsByPublicKeys returns all known deposit txs for the given pubkeys ordered by deposit index.
+// the lookup runs in the supplied transaction, so it sees deposit txs that have not been committed yet.
+func GetDepositTxsByPublicKeys(publicKeys [][]byte, tx *sqlx.Tx) ([]*dbtypes.DepositTx, error) {
+	const batchSize = 1000
+
+	// Process in batches
+	depositTxs := []*dbtypes.DepositTx{}
+	for i := 0; i < len(publicKeys); i += batchSize {
+		end := i + batchSize
+		if end > len(publicKeys) {
+			end = len(publicKeys)
+		}
+
+		batchTxs, err := getDepositTxsByPublicKeys(publicKeys[i:end], tx)
+		if err != nil {
+			return nil, err
+		}
+		depositTxs = append(depositTxs, batchTxs...)
+	}
+
+	if len(publicKeys) > batchSize {
+		sort.Slice(depositTxs, func(a, b int) bool {
+			return depositTxs[a].Index < depositTxs[b].Index
+		})
+	}
+
+	return depositTxs, nil
+}
+
+func getDepositTxsByPublicKeys(publicKeys [][]byte, tx *sqlx.Tx) ([]*dbtypes.DepositTx, error) {
+	var sql strings.Builder
+	args := make([]any, len(publicKeys))
+	fmt.Fprint(&sql, `
+	SELECT
+		deposit_index, block_number, block_time, block_root, publickey, withdrawalcredentials, amount, signature, valid_signature, orphaned, tx_hash, tx_sender, tx_target, fork_id, problem_flags
+	FROM deposit_txs
+	WHERE orphaned = false AND publickey IN (`)
+	for i, pubKey := range publicKeys {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		args[i] = pubKey
+		fmt.Fprintf(&sql, "$%v", i+1)
+	}
+	fmt.Fprint(&sql, `)
+	ORDER BY deposit_index ASC
+	`)
+
+	depositTxs := []*dbtypes.DepositTx{}
+	err := tx.Select(&depositTxs, sql.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	return depositTxs, nil
+}
+
 func GetDepositsFiltered(offset uint64, limit uint32, finalizedBlock uint64, filter *dbtypes.DepositFilter) ([]*dbtypes.Deposit, uint64, error) {
 	var sql strings.Builder
 	args := []any{}
@@ -304,7 +380,6 @@ func GetDepositsFiltered(offset uint64, limit uint32, finalizedBlock uint64, fil
 		filterOp = "AND"
 	}
 
-	args = append(args, limit)
 	fmt.Fprintf(&sql, `) 
 	SELECT 
 		0 AS deposit_index, 
@@ -319,7 +394,18 @@ func GetDepositsFiltered(offset uint64, limit uint32, finalizedBlock uint64, fil
 	FROM cte
 	UNION ALL SELECT * FROM (
 	SELECT * FROM cte
-	ORDER BY slot_number DESC, deposit_index DESC 
+	`)
+
+	if filter.Cursor != nil {
+		// keyset pagination: continue after the last returned slot instead of skipping all previous rows
+		args = append(args, filter.Cursor.Key)
+		fmt.Fprintf(&sql, " WHERE slot_number <= $%v ", len(args))
+		offset += filter.Cursor.Skip
+	}
+
+	args = append(args, limit)
+	fmt.Fprintf(&sql, `
+	ORDER BY slot_number DESC, deposit_index DESC, slot_root DESC 
 	LIMIT $%v 
 	`, len(args))
 
@@ -338,3 +424,40 @@ func GetDepositsFiltered(offset uint64, limit uint32, finalizedBlock uint64, fil
 
 	return deposits[1:], deposits[0].SlotNumber, nil
 }
+
+// GetDepositTxSenders returns the sender of the first canonical deposit tx for each validator pubkey.
+func GetDepositTxSenders() ([]*dbtypes.DepositTxSender, error) {
+	senders := []*dbtypes.DepositTxSender{}
+	err := ReaderDb.Select(&senders, `
+	SELECT
+		deposit_txs.publickey, deposit_txs.tx_sender, deposit_txs.block_time
+	FROM deposit_txs
+	INNER JOIN (
+		SELECT publickey, MIN(deposit_index) AS deposit_index
+		FROM deposit_txs
+		WHERE orphaned = false
+		GROUP BY publickey
+	) AS first_deposits ON first_deposits.publickey = deposit_txs.publickey AND first_deposits.deposit_index = deposit_txs.deposit_index
+	WHERE deposit_txs.orphaned = false
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return senders, nil
+}
+
+// GetDepositSenderStats returns the number, amount & time range of canonical deposit txs per sender address.
+func GetDepositSenderStats() ([]*dbtypes.DepositSenderStats, error) {
+	stats := []*dbtypes.DepositSenderStats{}
+	err := ReaderDb.Select(&stats, `
+	SELECT
+		tx_sender, COUNT(*) AS deposit_count, SUM(amount) AS deposit_amount, MIN(block_time) AS first_block_time, MAX(block_time) AS last_block_time
+	FROM deposit_txs
+	WHERE orphaned = false
+	GROUP BY tx_sender
+	`)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
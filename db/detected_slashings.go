@@ -0,0 +1,96 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertDetectedSlashings inserts multiple detected slashable offences in a batch, existing offences are left unchanged
+func InsertDetectedSlashings(slashings []*dbtypes.DetectedSlashing, tx *sqlx.Tx) error {
+	if len(slashings) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(slashings))
+	valueArgs := make([]interface{}, 0, len(slashings)*10)
+	for i, slashing := range slashings {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", i*10+1, i*10+2, i*10+3, i*10+4, i*10+5, i*10+6, i*10+7, i*10+8, i*10+9, i*10+10)
+		valueArgs = append(valueArgs,
+			slashing.Validator,
+			slashing.Slot,
+			slashing.Type,
+			slashing.Att1Root,
+			slashing.Att1Source,
+			slashing.Att1Target,
+			slashing.Att2Root,
+			slashing.Att2Source,
+			slashing.Att2Target,
+			slashing.Broadcasted)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO detected_slashings (
+				validator, slot, type, att1_root, att1_source, att1_target, att2_root, att2_source, att2_target, broadcasted
+			) VALUES %s
+			ON CONFLICT (validator, att1_root, att2_root) DO NOTHING`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR IGNORE INTO detected_slashings (
+				validator, slot, type, att1_root, att1_source, att1_target, att2_root, att2_source, att2_target, broadcasted
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting detected slashings: %v", err)
+	}
+
+	return nil
+}
+
+// GetDetectedSlashingsFiltered returns a page of detected slashable offences matching the filter (newest first) and the total number of matches.
+// the slot of the on-chain slashing of the validator is attached, if it has been included already.
+func GetDetectedSlashingsFiltered(offset uint64, limit uint32, filter *dbtypes.DetectedSlashingFilter) ([]*dbtypes.DetectedSlashing, uint64, error) {
+	var filterSql strings.Builder
+	args := []interface{}{filter.MinSlot}
+
+	fmt.Fprint(&filterSql, ` WHERE slot >= $1 `)
+	if filter.Validator != nil {
+		args = append(args, *filter.Validator)
+		fmt.Fprintf(&filterSql, ` AND validator = $%v `, len(args))
+	}
+	if filter.Type > 0 {
+		args = append(args, filter.Type)
+		fmt.Fprintf(&filterSql, ` AND type = $%v `, len(args))
+	}
+
+	var totalCount uint64
+	err := ReaderDb.Get(&totalCount, fmt.Sprintf(`SELECT COUNT(*) FROM detected_slashings %v`, filterSql.String()), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching detected slashing count: %v", err)
+		return nil, 0, err
+	}
+
+	args = append(args, limit, offset)
+	slashings := []*dbtypes.DetectedSlashing{}
+	err = ReaderDb.Select(&slashings, fmt.Sprintf(`
+		SELECT
+			validator, slot, type, att1_root, att1_source, att1_target, att2_root, att2_source, att2_target, broadcasted,
+			(
+				SELECT MIN(slashings.slot_number) FROM slashings
+				WHERE slashings.validator = detected_slashings.validator AND slashings.orphaned = false
+			) AS included_slot
+		FROM detected_slashings
+		%v
+		ORDER BY slot DESC, validator ASC
+		LIMIT $%v OFFSET $%v`, filterSql.String(), len(args)-1, len(args)), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching detected slashings: %v", err)
+		return nil, 0, err
+	}
+
+	return slashings, totalCount, nil
+}
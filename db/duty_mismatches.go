@@ -0,0 +1,95 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertDutyMismatches inserts multiple duty mismatches in a batch, existing mismatches are updated
+func InsertDutyMismatches(mismatches []*dbtypes.DutyMismatch, tx *sqlx.Tx) error {
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(mismatches))
+	valueArgs := make([]interface{}, 0, len(mismatches)*10)
+	for i, mismatch := range mismatches {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", i*10+1, i*10+2, i*10+3, i*10+4, i*10+5, i*10+6, i*10+7, i*10+8, i*10+9, i*10+10)
+		valueArgs = append(valueArgs,
+			mismatch.Epoch,
+			mismatch.ClientId,
+			mismatch.DutyType,
+			mismatch.Slot,
+			mismatch.CommitteeIndex,
+			mismatch.ClientName,
+			mismatch.DependentRoot,
+			mismatch.Expected,
+			mismatch.Reported,
+			mismatch.DetectedAt)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO duty_mismatches (
+				epoch, client_id, duty_type, slot, committee_index, client_name, dependent_root, expected, reported, detected_at
+			) VALUES %s
+			ON CONFLICT (epoch, client_id, duty_type, slot, committee_index) DO UPDATE SET
+				client_name = excluded.client_name,
+				dependent_root = excluded.dependent_root,
+				expected = excluded.expected,
+				reported = excluded.reported`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO duty_mismatches (
+				epoch, client_id, duty_type, slot, committee_index, client_name, dependent_root, expected, reported, detected_at
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting duty mismatches: %v", err)
+	}
+
+	return nil
+}
+
+// GetDutyMismatchesFiltered returns a page of duty mismatches matching the filter (newest first) and the total number of matches.
+func GetDutyMismatchesFiltered(offset uint64, limit uint32, filter *dbtypes.DutyMismatchFilter) ([]*dbtypes.DutyMismatch, uint64, error) {
+	var filterSql strings.Builder
+	args := []interface{}{filter.MinEpoch}
+
+	fmt.Fprint(&filterSql, ` WHERE epoch >= $1 `)
+	if filter.ClientId != nil {
+		args = append(args, *filter.ClientId)
+		fmt.Fprintf(&filterSql, ` AND client_id = $%v `, len(args))
+	}
+	if filter.DutyType > 0 {
+		args = append(args, filter.DutyType)
+		fmt.Fprintf(&filterSql, ` AND duty_type = $%v `, len(args))
+	}
+
+	var totalCount uint64
+	err := ReaderDb.Get(&totalCount, fmt.Sprintf(`SELECT COUNT(*) FROM duty_mismatches %v`, filterSql.String()), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching duty mismatch count: %v", err)
+		return nil, 0, err
+	}
+
+	args = append(args, limit, offset)
+	mismatches := []*dbtypes.DutyMismatch{}
+	err = ReaderDb.Select(&mismatches, fmt.Sprintf(`
+		SELECT
+			epoch, client_id, duty_type, slot, committee_index, client_name, dependent_root, expected, reported, detected_at
+		FROM duty_mismatches
+		%v
+		ORDER BY epoch DESC, client_id ASC, duty_type ASC, slot ASC, committee_index ASC
+		LIMIT $%v OFFSET $%v`, filterSql.String(), len(args)-1, len(args)), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching duty mismatches: %v", err)
+		return nil, 0, err
+	}
+
+	return mismatches, totalCount, nil
+}
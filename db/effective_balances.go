@@ -0,0 +1,147 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertEffectiveBalanceChanges inserts effective balance changes in a batch, existing changes are updated
+func InsertEffectiveBalanceChanges(changes []*dbtypes.EffectiveBalanceChange, tx *sqlx.Tx) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(changes))
+	valueArgs := make([]interface{}, 0, len(changes)*5)
+	for i, change := range changes {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5)
+		valueArgs = append(valueArgs,
+			change.ValidatorIndex,
+			change.Epoch,
+			change.OldEffectiveBalance,
+			change.NewEffectiveBalance,
+			change.Compounding)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO effective_balance_changes (
+				validator_index, epoch, old_effective_balance, new_effective_balance, compounding
+			) VALUES %s
+			ON CONFLICT (validator_index, epoch) DO UPDATE SET
+				old_effective_balance = excluded.old_effective_balance,
+				new_effective_balance = excluded.new_effective_balance,
+				compounding = excluded.compounding`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO effective_balance_changes (
+				validator_index, epoch, old_effective_balance, new_effective_balance, compounding
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting effective balance changes: %v", err)
+	}
+	return nil
+}
+
+// InsertEffectiveBalanceStats replaces the effective balance distribution of an epoch
+func InsertEffectiveBalanceStats(epoch uint64, stats []*dbtypes.EffectiveBalanceStats, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`DELETE FROM effective_balance_stats WHERE epoch = $1`, epoch)
+	if err != nil {
+		return fmt.Errorf("error deleting effective balance stats: %v", err)
+	}
+
+	if len(stats) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(stats))
+	valueArgs := make([]interface{}, 0, len(stats)*5)
+	for i, bucket := range stats {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5)
+		valueArgs = append(valueArgs,
+			epoch,
+			bucket.Bucket,
+			bucket.Validators,
+			bucket.Compounding,
+			bucket.EffectiveBalance)
+	}
+
+	_, err = tx.Exec(fmt.Sprintf(`
+		INSERT INTO effective_balance_stats (
+			epoch, bucket, validators, compounding, effective_balance
+		) VALUES %s`, strings.Join(valueStrings, ",")), valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting effective balance stats: %v", err)
+	}
+	return nil
+}
+
+// GetEffectiveBalanceChangesByValidator returns the effective balance changes of a validator, most recent first
+func GetEffectiveBalanceChangesByValidator(validatorIndex uint64, limit uint64) []*dbtypes.EffectiveBalanceChange {
+	changes := []*dbtypes.EffectiveBalanceChange{}
+	err := ReaderDb.Select(&changes, `
+		SELECT validator_index, epoch, old_effective_balance, new_effective_balance, compounding
+		FROM effective_balance_changes
+		WHERE validator_index = $1
+		ORDER BY epoch DESC
+		LIMIT $2`, validatorIndex, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching effective balance changes: %v", err)
+		return nil
+	}
+	return changes
+}
+
+// GetRecentEffectiveBalanceChanges returns the most recent effective balance changes of all validators
+func GetRecentEffectiveBalanceChanges(limit uint64) []*dbtypes.EffectiveBalanceChange {
+	changes := []*dbtypes.EffectiveBalanceChange{}
+	err := ReaderDb.Select(&changes, `
+		SELECT validator_index, epoch, old_effective_balance, new_effective_balance, compounding
+		FROM effective_balance_changes
+		ORDER BY epoch DESC, validator_index ASC
+		LIMIT $1`, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching effective balance changes: %v", err)
+		return nil
+	}
+	return changes
+}
+
+// GetLatestEffectiveBalanceStats returns the effective balance distribution of the most recent recorded epoch ordered by bucket
+func GetLatestEffectiveBalanceStats() []*dbtypes.EffectiveBalanceStats {
+	stats := []*dbtypes.EffectiveBalanceStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT epoch, bucket, validators, compounding, effective_balance
+		FROM effective_balance_stats
+		WHERE epoch = (SELECT MAX(epoch) FROM effective_balance_stats)
+		ORDER BY bucket ASC`)
+	if err != nil {
+		logger.Errorf("Error while fetching effective balance stats: %v", err)
+		return nil
+	}
+	return stats
+}
+
+// GetEffectiveBalanceStatsHistory returns the effective balance distribution of every n-th epoch since minEpoch ordered by epoch & bucket
+func GetEffectiveBalanceStatsHistory(minEpoch uint64, epochInterval uint64) []*dbtypes.EffectiveBalanceStats {
+	if epochInterval == 0 {
+		epochInterval = 1
+	}
+
+	stats := []*dbtypes.EffectiveBalanceStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT epoch, bucket, validators, compounding, effective_balance
+		FROM effective_balance_stats
+		WHERE epoch >= $1 AND epoch % $2 = 0
+		ORDER BY epoch DESC, bucket ASC`, minEpoch, epochInterval)
+	if err != nil {
+		logger.Errorf("Error while fetching effective balance stats history: %v", err)
+		return nil
+	}
+	return stats
+}
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// GetEntities returns all entities ordered by name
+func GetEntities() ([]*dbtypes.Entity, error) {
+	entities := []*dbtypes.Entity{}
+	err := ReaderDb.Select(&entities, `
+		SELECT entity_id, name, description, links, created, updated
+		FROM entities
+		ORDER BY name ASC, entity_id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching entities: %v", err)
+	}
+
+	return entities, nil
+}
+
+// GetEntity returns the entity with the given id, or nil if it does not exist
+func GetEntity(entityId uint64) *dbtypes.Entity {
+	entity := dbtypes.Entity{}
+	err := ReaderDb.Get(&entity, `
+		SELECT entity_id, name, description, links, created, updated
+		FROM entities
+		WHERE entity_id = $1`, entityId)
+	if err != nil {
+		return nil
+	}
+
+	return &entity
+}
+
+// GetEntityValidatorRanges returns the validator ranges of an entity, or of all entities if entityId is nil
+func GetEntityValidatorRanges(entityId *uint64) ([]*dbtypes.EntityValidatorRange, error) {
+	var sql strings.Builder
+	args := []any{}
+	fmt.Fprint(&sql, `SELECT entity_id, min_index, max_index FROM entity_validators`)
+	if entityId != nil {
+		fmt.Fprint(&sql, ` WHERE entity_id = $1`)
+		args = append(args, *entityId)
+	}
+	fmt.Fprint(&sql, ` ORDER BY entity_id ASC, min_index ASC`)
+
+	ranges := []*dbtypes.EntityValidatorRange{}
+	err := ReaderDb.Select(&ranges, sql.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching entity validators: %v", err)
+	}
+
+	return ranges, nil
+}
+
+// GetEntityAddresses returns the addresses of an entity, or of all entities if entityId is nil
+func GetEntityAddresses(entityId *uint64) ([]*dbtypes.EntityAddress, error) {
+	var sql strings.Builder
+	args := []any{}
+	fmt.Fprint(&sql, `SELECT entity_id, address_type, address FROM entity_addresses`)
+	if entityId != nil {
+		fmt.Fprint(&sql, ` WHERE entity_id = $1`)
+		args = append(args, *entityId)
+	}
+	fmt.Fprint(&sql, ` ORDER BY entity_id ASC, address_type ASC, address ASC`)
+
+	addresses := []*dbtypes.EntityAddress{}
+	err := ReaderDb.Select(&addresses, sql.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching entity addresses: %v", err)
+	}
+
+	return addresses, nil
+}
+
+// GetNextEntityId returns the next free entity id
+func GetNextEntityId(tx *sqlx.Tx) (uint64, error) {
+	maxId := uint64(0)
+	err := tx.Get(&maxId, `SELECT COALESCE(MAX(entity_id), 0) FROM entities`)
+	if err != nil {
+		return 0, fmt.Errorf("error while fetching max entity id: %v", err)
+	}
+
+	return maxId + 1, nil
+}
+
+// InsertEntity inserts or updates an entity
+func InsertEntity(entity *dbtypes.Entity, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO entities (
+				entity_id, name, description, links, created, updated
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (entity_id) DO UPDATE SET
+				name = excluded.name,
+				description = excluded.description,
+				links = excluded.links,
+				updated = excluded.updated`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO entities (
+				entity_id, name, description, links, created, updated
+			) VALUES ($1, $2, $3, $4, $5, $6)`,
+	}), entity.EntityId, entity.Name, entity.Description, entity.Links, entity.Created, entity.Updated)
+	if err != nil {
+		return fmt.Errorf("error inserting entity: %v", err)
+	}
+
+	return nil
+}
+
+// SetEntityMembers replaces the validator ranges & addresses of an entity
+func SetEntityMembers(entityId uint64, ranges []*dbtypes.EntityValidatorRange, addresses []*dbtypes.EntityAddress, tx *sqlx.Tx) error {
+	if _, err := tx.Exec(`DELETE FROM entity_validators WHERE entity_id = $1`, entityId); err != nil {
+		return fmt.Errorf("error deleting entity validators: %v", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM entity_addresses WHERE entity_id = $1`, entityId); err != nil {
+		return fmt.Errorf("error deleting entity addresses: %v", err)
+	}
+
+	if len(ranges) > 0 {
+		valueStrings := make([]string, len(ranges))
+		valueArgs := make([]interface{}, 0, len(ranges)*3)
+		for i, validatorRange := range ranges {
+			valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v)", i*3+1, i*3+2, i*3+3)
+			valueArgs = append(valueArgs, entityId, validatorRange.MinIndex, validatorRange.MaxIndex)
+		}
+
+		_, err := tx.Exec(fmt.Sprintf(`INSERT INTO entity_validators (entity_id, min_index, max_index) VALUES %s`, strings.Join(valueStrings, ",")), valueArgs...)
+		if err != nil {
+			return fmt.Errorf("error inserting entity validators: %v", err)
+		}
+	}
+
+	if len(addresses) > 0 {
+		valueStrings := make([]string, len(addresses))
+		valueArgs := make([]interface{}, 0, len(addresses)*3)
+		for i, address := range addresses {
+			valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v)", i*3+1, i*3+2, i*3+3)
+			valueArgs = append(valueArgs, entityId, address.AddressType, address.Address)
+		}
+
+		_, err := tx.Exec(fmt.Sprintf(`INSERT INTO entity_addresses (entity_id, address_type, address) VALUES %s`, strings.Join(valueStrings, ",")), valueArgs...)
+		if err != nil {
+			return fmt.Errorf("error inserting entity addresses: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteEntity deletes an entity including its validator ranges & addresses
+func DeleteEntity(entityId uint64, tx *sqlx.Tx) error {
+	if err := SetEntityMembers(entityId, nil, nil, tx); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM entities WHERE entity_id = $1`, entityId); err != nil {
+		return fmt.Errorf("error deleting entity: %v", err)
+	}
+
+	return nil
+}
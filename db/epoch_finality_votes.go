@@ -0,0 +1,53 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertEpochFinalityVotes inserts or replaces the target vote map of a finalized epoch
+func InsertEpochFinalityVotes(votes *dbtypes.EpochFinalityVotes, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO epoch_finality_votes (
+				epoch, target_root, active_count, voted_count, active_balance, voted_balance, active_bits, voted_bits
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (epoch) DO UPDATE SET
+				target_root = excluded.target_root,
+				active_count = excluded.active_count,
+				voted_count = excluded.voted_count,
+				active_balance = excluded.active_balance,
+				voted_balance = excluded.voted_balance,
+				active_bits = excluded.active_bits,
+				voted_bits = excluded.voted_bits`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO epoch_finality_votes (
+				epoch, target_root, active_count, voted_count, active_balance, voted_balance, active_bits, voted_bits
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+	}),
+		votes.Epoch, votes.TargetRoot, votes.ActiveCount, votes.VotedCount, votes.ActiveBalance, votes.VotedBalance, votes.ActiveBits, votes.VotedBits)
+	if err != nil {
+		return fmt.Errorf("error inserting epoch finality votes: %v", err)
+	}
+
+	return nil
+}
+
+// GetEpochFinalityVotes returns the target vote map of a finalized epoch or nil if not recorded
+func GetEpochFinalityVotes(epoch uint64) (*dbtypes.EpochFinalityVotes, error) {
+	votes := []*dbtypes.EpochFinalityVotes{}
+	err := ReaderDb.Select(&votes, `
+		SELECT epoch, target_root, active_count, voted_count, active_balance, voted_balance, active_bits, voted_bits
+		FROM epoch_finality_votes
+		WHERE epoch = $1`, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching epoch finality votes: %v", err)
+	}
+	if len(votes) == 0 {
+		return nil, nil
+	}
+
+	return votes[0], nil
+}
@@ -31,7 +31,8 @@ func InsertEpoch(epoch *dbtypes.Epoch, tx *sqlx.Tx) error {
 				proposer_slashing_count = excluded.proposer_slashing_count, 
 				bls_change_count = excluded.bls_change_count, 
 				eth_transaction_count = excluded.eth_transaction_count, 
-				sync_participation = excluded.sync_participation`,
+				sync_participation = excluded.sync_participation,
+				imported = false`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR REPLACE INTO epochs (
 				epoch, validator_count, validator_balance, eligible, voted_target, voted_head, voted_total, block_count, orphaned_count,
@@ -57,6 +58,32 @@ func IsEpochSynchronized(epoch uint64) bool {
 	return count > 0
 }
 
+// SetEpochImported flags an epoch as imported from an external source, its duties & vote aggregations are missing.
+// the flag is cleared when the epoch is written again by the indexer.
+func SetEpochImported(epoch uint64, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`UPDATE epochs SET imported = true WHERE epoch = $1`, epoch)
+	return err
+}
+
+func IsEpochImported(epoch uint64) bool {
+	var count uint64
+	err := ReaderDb.Get(&count, `SELECT COUNT(*) FROM epochs WHERE epoch = $1 AND imported`, epoch)
+	if err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// GetFirstImportedEpoch returns the lowest epoch that has been imported from an external source and not been written by the indexer yet.
+func GetFirstImportedEpoch() (uint64, bool) {
+	var epoch uint64
+	err := ReaderDb.Get(&epoch, `SELECT epoch FROM epochs WHERE imported ORDER BY epoch ASC LIMIT 1`)
+	if err != nil {
+		return 0, false
+	}
+	return epoch, true
+}
+
 func GetEpochs(firstEpoch uint64, limit uint32) []*dbtypes.Epoch {
 	epochs := []*dbtypes.Epoch{}
 	err := ReaderDb.Select(&epochs, `
@@ -75,3 +102,64 @@ func GetEpochs(firstEpoch uint64, limit uint32) []*dbtypes.Epoch {
 	}
 	return epochs
 }
+
+// GetMissingEpochAggregates recomputes the aggregates of epochs that have slots in the db, but no entry in the epochs table.
+// only the block based fields are restored, validator & vote stats are not available from the slots table.
+func GetMissingEpochAggregates(slotsPerEpoch uint64, maxEpoch uint64, limit uint32) ([]*dbtypes.Epoch, error) {
+	epochs := []*dbtypes.Epoch{}
+	err := ReaderDb.Select(&epochs, `
+	SELECT
+		slots.slot / $1 AS epoch,
+		SUM(CASE WHEN slots.status = 1 THEN 1 ELSE 0 END) AS block_count,
+		SUM(CASE WHEN slots.status = 2 THEN 1 ELSE 0 END) AS orphaned_count,
+		SUM(CASE WHEN slots.status = 1 THEN slots.attestation_count ELSE 0 END) AS attestation_count,
+		SUM(CASE WHEN slots.status = 1 THEN slots.deposit_count ELSE 0 END) AS deposit_count,
+		SUM(CASE WHEN slots.status = 1 THEN slots.exit_count ELSE 0 END) AS exit_count,
+		SUM(CASE WHEN slots.status = 1 THEN slots.withdraw_count ELSE 0 END) AS withdraw_count,
+		SUM(CASE WHEN slots.status = 1 THEN slots.withdraw_amount ELSE 0 END) AS withdraw_amount,
+		SUM(CASE WHEN slots.status = 1 THEN slots.attester_slashing_count ELSE 0 END) AS attester_slashing_count,
+		SUM(CASE WHEN slots.status = 1 THEN slots.proposer_slashing_count ELSE 0 END) AS proposer_slashing_count,
+		SUM(CASE WHEN slots.status = 1 THEN slots.bls_change_count ELSE 0 END) AS bls_change_count,
+		SUM(CASE WHEN slots.status = 1 THEN slots.eth_transaction_count ELSE 0 END) AS eth_transaction_count,
+		COALESCE(AVG(CASE WHEN slots.status = 1 AND slots.slot > 0 THEN slots.sync_participation END), 0) AS sync_participation
+	FROM slots
+	WHERE slots.slot < $2 AND NOT EXISTS (
+		SELECT 1 FROM epochs WHERE epochs.epoch = slots.slot / $1
+	)
+	GROUP BY slots.slot / $1
+	ORDER BY epoch ASC
+	LIMIT $3
+	`, slotsPerEpoch, (maxEpoch+1)*slotsPerEpoch, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching missing epoch aggregates: %v", err)
+		return nil, err
+	}
+	return epochs, nil
+}
+
+// GetEpochRangeStats returns the aggregated stats of all indexed epochs in the given range (inclusive)
+func GetEpochRangeStats(firstEpoch uint64, lastEpoch uint64) (*dbtypes.EpochRangeStats, error) {
+	stats := dbtypes.EpochRangeStats{}
+	err := ReaderDb.Get(&stats, `
+	SELECT
+		COUNT(*) AS epochs,
+		COALESCE(MIN(epoch), 0) AS first_epoch,
+		COALESCE(MAX(epoch), 0) AS last_epoch,
+		COALESCE((SELECT validator_count FROM epochs WHERE epoch >= $1 AND epoch <= $2 ORDER BY epoch ASC LIMIT 1), 0) AS first_validator_count,
+		COALESCE((SELECT validator_count FROM epochs WHERE epoch >= $1 AND epoch <= $2 ORDER BY epoch DESC LIMIT 1), 0) AS last_validator_count,
+		COALESCE(SUM(eligible), 0) AS eligible,
+		COALESCE(SUM(voted_target), 0) AS voted_target,
+		COALESCE(SUM(voted_head), 0) AS voted_head,
+		COALESCE(SUM(voted_total), 0) AS voted_total,
+		COALESCE(SUM(block_count), 0) AS block_count,
+		COALESCE(SUM(orphaned_count), 0) AS orphaned_count,
+		COALESCE(AVG(sync_participation), 0) AS sync_participation
+	FROM epochs
+	WHERE epoch >= $1 AND epoch <= $2
+	`, firstEpoch, lastEpoch)
+	if err != nil {
+		logger.Errorf("Error while fetching epoch range stats: %v", err)
+		return nil, err
+	}
+	return &stats, nil
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertExecutionBlockSlot inserts or updates the execution block -> slot mapping of a beacon block
+func InsertExecutionBlockSlot(blockSlot *dbtypes.ExecutionBlockSlot, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO execution_block_slots (
+				root, slot, block_hash, block_number, fork_id, orphaned
+			) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (root) DO UPDATE SET
+				fork_id = excluded.fork_id,
+				orphaned = excluded.orphaned`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO execution_block_slots (
+				root, slot, block_hash, block_number, fork_id, orphaned
+			) VALUES ($1, $2, $3, $4, $5, $6)`,
+	}),
+		blockSlot.Root, blockSlot.Slot, blockSlot.BlockHash, blockSlot.BlockNumber, blockSlot.ForkId, blockSlot.Orphaned)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateExecutionBlockSlotForkId updates the fork id of the execution block -> slot mappings of the given beacon blocks
+func UpdateExecutionBlockSlotForkId(roots [][]byte, forkId uint64, tx *sqlx.Tx) error {
+	var sql strings.Builder
+	args := []any{}
+
+	fmt.Fprint(&sql, `UPDATE execution_block_slots SET fork_id = $1 WHERE root IN (`)
+	args = append(args, forkId)
+
+	for i, root := range roots {
+		if i > 0 {
+			fmt.Fprint(&sql, ",")
+		}
+
+		args = append(args, root)
+		fmt.Fprintf(&sql, "$%v", len(args))
+	}
+
+	fmt.Fprint(&sql, ")")
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetExecutionBlockSlotsByHash returns all beacon blocks that include the execution block with the given hash (canonical blocks first)
+func GetExecutionBlockSlotsByHash(blockHash []byte) []*dbtypes.ExecutionBlockSlot {
+	blockSlots := []*dbtypes.ExecutionBlockSlot{}
+	err := ReaderDb.Select(&blockSlots, `
+	SELECT root, slot, block_hash, block_number, fork_id, orphaned
+	FROM execution_block_slots
+	WHERE block_hash = $1
+	ORDER BY orphaned ASC, slot DESC
+	`, blockHash)
+	if err != nil {
+		logger.Errorf("Error while fetching execution block slots by hash: %v", err)
+		return nil
+	}
+	return blockSlots
+}
+
+// GetExecutionBlockSlotsByNumber returns all beacon blocks that include an execution block with the given number
+func GetExecutionBlockSlotsByNumber(blockNumber uint64, limit uint32) []*dbtypes.ExecutionBlockSlot {
+	blockSlots := []*dbtypes.ExecutionBlockSlot{}
+	err := ReaderDb.Select(&blockSlots, `
+	SELECT root, slot, block_hash, block_number, fork_id, orphaned
+	FROM execution_block_slots
+	WHERE block_number = $1
+	ORDER BY slot ASC
+	LIMIT $2
+	`, blockNumber, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching execution block slots by number: %v", err)
+		return nil
+	}
+	return blockSlots
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// GetFeeRecipientStats returns the fee recipients used by each proposer in canonical blocks since minSlot.
+// pre-merge blocks and blocks indexed before the fee recipient column was added are skipped.
+func GetFeeRecipientStats(minSlot uint64) ([]*dbtypes.FeeRecipientStats, error) {
+	stats := []*dbtypes.FeeRecipientStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT proposer, eth_fee_recipient, COUNT(*) AS blocks, MIN(slot) AS first_slot, MAX(slot) AS last_slot
+		FROM slots
+		WHERE slot >= $1 AND status = $2 AND eth_block_number IS NOT NULL AND eth_fee_recipient IS NOT NULL
+		GROUP BY proposer, eth_fee_recipient
+		ORDER BY proposer ASC`, minSlot, dbtypes.Canonical)
+	if err != nil {
+		logger.Errorf("Error while fetching fee recipient stats: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetFeeRecipientUsage returns the number of canonical blocks & distinct proposers that used the given fee recipient.
+func GetFeeRecipientUsage(feeRecipient []byte) (*dbtypes.FeeRecipientUsage, error) {
+	usage := &dbtypes.FeeRecipientUsage{}
+	err := ReaderDb.Get(usage, `
+		SELECT COUNT(*) AS blocks, COUNT(DISTINCT proposer) AS proposers, COALESCE(MIN(slot), 0) AS first_slot, COALESCE(MAX(slot), 0) AS last_slot
+		FROM slots
+		WHERE eth_fee_recipient = $1 AND status = $2`, feeRecipient, dbtypes.Canonical)
+	if err != nil {
+		logger.Errorf("Error while fetching fee recipient usage: %v", err)
+		return nil, err
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertGenesisValidatorBatch inserts multiple genesis validators in a batch
+func InsertGenesisValidatorBatch(validators []*dbtypes.GenesisValidator, tx *sqlx.Tx) error {
+	if len(validators) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(validators))
+	valueArgs := make([]interface{}, 0, len(validators)*5)
+	for i, val := range validators {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5)
+		valueArgs = append(valueArgs,
+			val.ValidatorIndex,
+			val.Pubkey,
+			val.WithdrawalCredentials,
+			val.Balance,
+			val.Entity)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO genesis_validators (
+				validator_index, pubkey, withdrawal_credentials, balance, entity
+			) VALUES %s
+			ON CONFLICT (validator_index) DO UPDATE SET
+				pubkey = excluded.pubkey,
+				withdrawal_credentials = excluded.withdrawal_credentials,
+				balance = excluded.balance,
+				entity = excluded.entity`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO genesis_validators (
+				validator_index, pubkey, withdrawal_credentials, balance, entity
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting genesis validator batch: %v", err)
+	}
+
+	return nil
+}
+
+// SetGenesisValidatorEntity attributes a range of genesis validators to the given entity
+func SetGenesisValidatorEntity(minIndex uint64, maxIndex uint64, entity string, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`UPDATE genesis_validators SET entity = $1 WHERE validator_index >= $2 AND validator_index <= $3`, entity, minIndex, maxIndex)
+	if err != nil {
+		return fmt.Errorf("error updating genesis validator entities: %v", err)
+	}
+	return nil
+}
+
+// ResetGenesisValidatorEntities removes all entity attributions from genesis validators
+func ResetGenesisValidatorEntities(tx *sqlx.Tx) error {
+	_, err := tx.Exec(`UPDATE genesis_validators SET entity = '' WHERE entity != ''`)
+	if err != nil {
+		return fmt.Errorf("error resetting genesis validator entities: %v", err)
+	}
+	return nil
+}
+
+// GetGenesisValidator returns the genesis validator with the given index or nil if the validator was not part of the genesis set
+func GetGenesisValidator(index uint64) *dbtypes.GenesisValidator {
+	validator := dbtypes.GenesisValidator{}
+	err := ReaderDb.Get(&validator, `
+		SELECT validator_index, pubkey, withdrawal_credentials, balance, entity
+		FROM genesis_validators
+		WHERE validator_index = $1
+	`, index)
+	if err != nil {
+		return nil
+	}
+	return &validator
+}
+
+// GetGenesisValidatorsFiltered returns a page of genesis validators matching the filter and the total number of matches
+func GetGenesisValidatorsFiltered(offset uint64, limit uint32, filter *dbtypes.GenesisValidatorFilter) ([]*dbtypes.GenesisValidator, uint64, error) {
+	var sql strings.Builder
+	args := []any{}
+	fmt.Fprint(&sql, `
+	WITH cte AS (
+		SELECT
+			validator_index, pubkey, withdrawal_credentials, balance, entity
+		FROM genesis_validators
+	`)
+
+	filterOp := "WHERE"
+	if filter.Entity != nil {
+		args = append(args, *filter.Entity)
+		fmt.Fprintf(&sql, " %v entity = $%v", filterOp, len(args))
+		filterOp = "AND"
+	}
+	if len(filter.PublicKey) > 0 {
+		args = append(args, filter.PublicKey)
+		fmt.Fprintf(&sql, " %v pubkey = $%v", filterOp, len(args))
+		filterOp = "AND"
+	}
+	if filter.MinIndex != nil {
+		args = append(args, *filter.MinIndex)
+		fmt.Fprintf(&sql, " %v validator_index >= $%v", filterOp, len(args))
+		filterOp = "AND"
+	}
+	if filter.MaxIndex != nil {
+		args = append(args, *filter.MaxIndex)
+		fmt.Fprintf(&sql, " %v validator_index <= $%v", filterOp, len(args))
+		filterOp = "AND"
+	}
+
+	args = append(args, limit)
+	fmt.Fprintf(&sql, `)
+	SELECT
+		count(*) AS validator_index,
+		null AS pubkey,
+		null AS withdrawal_credentials,
+		0 AS balance,
+		'' AS entity
+	FROM cte
+	UNION ALL SELECT * FROM (
+	SELECT * FROM cte
+	ORDER BY validator_index ASC
+	LIMIT $%v
+	`, len(args))
+
+	if offset > 0 {
+		args = append(args, offset)
+		fmt.Fprintf(&sql, " OFFSET $%v ", len(args))
+	}
+	fmt.Fprintf(&sql, ") AS t1")
+
+	validators := []*dbtypes.GenesisValidator{}
+	err := ReaderDb.Select(&validators, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching filtered genesis validators: %v", err)
+		return nil, 0, err
+	}
+
+	return validators[1:], validators[0].ValidatorIndex, nil
+}
+
+// GetGenesisEntityStats returns the number of genesis validators and total genesis balance per entity
+func GetGenesisEntityStats() ([]*dbtypes.GenesisEntityStats, error) {
+	stats := []*dbtypes.GenesisEntityStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT entity, count(*) AS validators, COALESCE(sum(balance), 0) AS balance
+		FROM genesis_validators
+		GROUP BY entity
+		ORDER BY validators DESC
+	`)
+	if err != nil {
+		logger.Errorf("Error while fetching genesis entity stats: %v", err)
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// GetGraffitiStats returns the canonical block counts grouped by graffiti, ordered by block count.
+// only blocks with a slot number >= minSlot are counted. the search string filters the graffiti by substring (case-insensitive).
+// returns the requested page of graffiti stats, the total number of distinct graffitis and the total number of blocks with graffiti.
+func GetGraffitiStats(minSlot uint64, search string, offset uint64, limit uint32) ([]*dbtypes.GraffitiStats, uint64, uint64, error) {
+	var filterSql strings.Builder
+	args := []interface{}{minSlot, dbtypes.Canonical}
+
+	fmt.Fprint(&filterSql, ` WHERE slot >= $1 AND status = $2 AND graffiti_text IS NOT NULL AND graffiti_text != '' `)
+	if search != "" {
+		args = append(args, "%"+search+"%")
+		fmt.Fprintf(&filterSql, EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  ` AND graffiti_text ILIKE $%v `,
+			dbtypes.DBEngineSqlite: ` AND graffiti_text LIKE $%v `,
+		}), len(args))
+	}
+
+	totals := struct {
+		Graffitis uint64 `db:"graffitis"`
+		Blocks    uint64 `db:"blocks"`
+	}{}
+	err := ReaderDb.Get(&totals, fmt.Sprintf(`
+		SELECT COUNT(DISTINCT graffiti_text) AS graffitis, COUNT(*) AS blocks
+		FROM slots
+		%v`, filterSql.String()), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching graffiti stats totals: %v", err)
+		return nil, 0, 0, err
+	}
+
+	args = append(args, limit, offset)
+	stats := []*dbtypes.GraffitiStats{}
+	err = ReaderDb.Select(&stats, fmt.Sprintf(`
+		SELECT graffiti_text, COUNT(*) AS blocks, COUNT(DISTINCT proposer) AS proposers, MAX(slot) AS last_slot
+		FROM slots
+		%v
+		GROUP BY graffiti_text
+		ORDER BY blocks DESC, last_slot DESC
+		LIMIT $%v OFFSET $%v`, filterSql.String(), len(args)-1, len(args)), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching graffiti stats: %v", err)
+		return nil, 0, 0, err
+	}
+
+	return stats, totals.Graffitis, totals.Blocks, nil
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// leaseNowExpr is the current unix time of the database server.
+// lease expiry is always compared against the db clock, so clock drift between the replicas does not affect the election.
+func leaseNowExpr() string {
+	return EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  "EXTRACT(EPOCH FROM NOW())::BIGINT",
+		dbtypes.DBEngineSqlite: "CAST(strftime('%s', 'now') AS BIGINT)",
+	})
+}
+
+// AcquireIndexerLease acquires or renews the named lease for the given holder.
+// returns true and the fencing token of the lease if the holder owns the lease afterwards, false if the lease is held by another holder and not yet expired.
+// the fencing token is incremented whenever the lease is taken over by another holder.
+func AcquireIndexerLease(name string, holder string, timeout time.Duration, tx *sqlx.Tx) (uint64, bool, error) {
+	nowExpr := leaseNowExpr()
+	token := uint64(0)
+	err := tx.Get(&token, fmt.Sprintf(`
+		INSERT INTO indexer_leases (name, holder, expires, token)
+		VALUES ($1, $2, %v + $3, 1)
+		ON CONFLICT (name) DO UPDATE SET
+			holder = excluded.holder,
+			expires = excluded.expires,
+			token = CASE WHEN indexer_leases.holder = excluded.holder THEN indexer_leases.token ELSE indexer_leases.token + 1 END
+		WHERE indexer_leases.holder = excluded.holder OR indexer_leases.expires < %v
+		RETURNING token`, nowExpr, nowExpr),
+		name, holder, int64(timeout.Seconds()))
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return token, true, nil
+}
+
+// CheckIndexerLease returns an error if the named lease is not held by the given holder with the given fencing token, or if it is expired.
+// on pgsql the lease row is locked until the transaction ends, so the lease cannot be taken over while the transaction is writing.
+func CheckIndexerLease(name string, holder string, token uint64, tx *sqlx.Tx) error {
+	nowExpr := leaseNowExpr()
+	lockExpr := EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  "FOR SHARE",
+		dbtypes.DBEngineSqlite: "",
+	})
+
+	leaseToken := uint64(0)
+	err := tx.Get(&leaseToken, fmt.Sprintf(`
+		SELECT token FROM indexer_leases
+		WHERE name = $1 AND holder = $2 AND token = $3 AND expires >= %v
+		%v`, nowExpr, lockExpr),
+		name, holder, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("lease %v is not held by %v (token %v) anymore", name, holder, token)
+	}
+
+	return err
+}
+
+// ReleaseIndexerLease releases the named lease if it is held by the given holder.
+func ReleaseIndexerLease(name string, holder string, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`DELETE FROM indexer_leases WHERE name = $1 AND holder = $2`, name, holder)
+	return err
+}
+
+// GetIndexerLeaseHolder returns the current holder of the named lease and its expiry time.
+func GetIndexerLeaseHolder(name string) (string, time.Time, error) {
+	lease := struct {
+		Holder  string `db:"holder"`
+		Expires int64  `db:"expires"`
+	}{}
+	err := ReaderDb.Get(&lease, `SELECT holder, expires FROM indexer_leases WHERE name = $1`, name)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return lease.Holder, time.Unix(lease.Expires, 0), nil
+}
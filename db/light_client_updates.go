@@ -0,0 +1,171 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertLightClientUpdates(updates []*dbtypes.LightClientUpdate, tx *sqlx.Tx) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO light_client_updates ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO light_client_updates ",
+		}),
+		"(period, client_name, version, attested_slot, attested_root, finalized_slot, finalized_root, signature_slot, participation, next_committee_root, status, result, data, updated_at)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 14
+
+	args := make([]any, len(updates)*fieldCount)
+	for i, update := range updates {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = update.Period
+		args[argIdx+1] = update.ClientName
+		args[argIdx+2] = update.Version
+		args[argIdx+3] = update.AttestedSlot
+		args[argIdx+4] = update.AttestedRoot
+		args[argIdx+5] = update.FinalizedSlot
+		args[argIdx+6] = update.FinalizedRoot
+		args[argIdx+7] = update.SignatureSlot
+		args[argIdx+8] = update.Participation
+		args[argIdx+9] = update.NextCommitteeRoot
+		args[argIdx+10] = update.Status
+		args[argIdx+11] = update.Result
+		args[argIdx+12] = update.Data
+		args[argIdx+13] = update.UpdatedAt
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (period, client_name) DO UPDATE SET version = excluded.version, attested_slot = excluded.attested_slot, attested_root = excluded.attested_root, finalized_slot = excluded.finalized_slot, finalized_root = excluded.finalized_root, signature_slot = excluded.signature_slot, participation = excluded.participation, next_committee_root = excluded.next_committee_root, status = excluded.status, result = excluded.result, data = excluded.data, updated_at = excluded.updated_at",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func InsertLightClientHeadUpdates(updates []*dbtypes.LightClientHeadUpdate, tx *sqlx.Tx) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO light_client_head_updates ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO light_client_head_updates ",
+		}),
+		"(period, client_name, update_type, version, attested_slot, attested_root, finalized_slot, finalized_root, signature_slot, participation, status, result, data, updated_at)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 14
+
+	args := make([]any, len(updates)*fieldCount)
+	for i, update := range updates {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = update.Period
+		args[argIdx+1] = update.ClientName
+		args[argIdx+2] = update.UpdateType
+		args[argIdx+3] = update.Version
+		args[argIdx+4] = update.AttestedSlot
+		args[argIdx+5] = update.AttestedRoot
+		args[argIdx+6] = update.FinalizedSlot
+		args[argIdx+7] = update.FinalizedRoot
+		args[argIdx+8] = update.SignatureSlot
+		args[argIdx+9] = update.Participation
+		args[argIdx+10] = update.Status
+		args[argIdx+11] = update.Result
+		args[argIdx+12] = update.Data
+		args[argIdx+13] = update.UpdatedAt
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (period, client_name, update_type) DO UPDATE SET version = excluded.version, attested_slot = excluded.attested_slot, attested_root = excluded.attested_root, finalized_slot = excluded.finalized_slot, finalized_root = excluded.finalized_root, signature_slot = excluded.signature_slot, participation = excluded.participation, status = excluded.status, result = excluded.result, data = excluded.data, updated_at = excluded.updated_at",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetLightClientUpdates returns the light client updates of all clients within the given period range (inclusive), newest first.
+// the raw update data is only loaded if withData is set.
+func GetLightClientUpdates(firstPeriod uint64, lastPeriod uint64, withData bool) ([]*dbtypes.LightClientUpdate, error) {
+	dataField := "''"
+	if withData {
+		dataField = "data"
+	}
+
+	updates := []*dbtypes.LightClientUpdate{}
+	err := ReaderDb.Select(&updates, fmt.Sprintf(`
+		SELECT period, client_name, version, attested_slot, attested_root, finalized_slot, finalized_root, signature_slot, participation, next_committee_root, status, result, %v AS data, updated_at
+		FROM light_client_updates
+		WHERE period >= $1 AND period <= $2
+		ORDER BY period DESC, client_name ASC`, dataField), firstPeriod, lastPeriod)
+	if err != nil {
+		logger.Errorf("Error while fetching light client updates: %v", err)
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// GetLightClientHeadUpdates returns the latest light client finality & optimistic updates of all clients within the given period range (inclusive), newest first.
+// the raw update data is only loaded if withData is set.
+func GetLightClientHeadUpdates(firstPeriod uint64, lastPeriod uint64, withData bool) ([]*dbtypes.LightClientHeadUpdate, error) {
+	dataField := "''"
+	if withData {
+		dataField = "data"
+	}
+
+	updates := []*dbtypes.LightClientHeadUpdate{}
+	err := ReaderDb.Select(&updates, fmt.Sprintf(`
+		SELECT period, client_name, update_type, version, attested_slot, attested_root, finalized_slot, finalized_root, signature_slot, participation, status, result, %v AS data, updated_at
+		FROM light_client_head_updates
+		WHERE period >= $1 AND period <= $2
+		ORDER BY period DESC, client_name ASC, update_type ASC`, dataField), firstPeriod, lastPeriod)
+	if err != nil {
+		logger.Errorf("Error while fetching light client head updates: %v", err)
+		return nil, err
+	}
+
+	return updates, nil
+}
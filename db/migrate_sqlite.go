@@ -0,0 +1,316 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// sqliteMigrationMaxParams is the maximum number of bind parameters per insert statement (pgsql limit).
+const sqliteMigrationMaxParams = 65535
+
+// sqliteMigrationProgressInterval is the interval for progress reports while copying a table.
+const sqliteMigrationProgressInterval = 10 * time.Second
+
+// SqliteMigrationOptions configures the migration of a sqlite database into the connected pgsql database.
+type SqliteMigrationOptions struct {
+	SqliteFile string
+	BatchSize  int
+	Truncate   bool
+	SkipVerify bool
+}
+
+// sqliteMigrationColumn is a column that is copied from the sqlite source to the pgsql target table.
+type sqliteMigrationColumn struct {
+	name     string
+	dataType string
+}
+
+// MigrateFromSqlite copies all tables of a sqlite database into the connected pgsql database.
+// the pgsql schema must be initialized & both databases must be on the same schema version.
+// target tables must be empty, unless the truncate option is set.
+func MigrateFromSqlite(ctx context.Context, options *SqliteMigrationOptions) error {
+	if DbEngine != dbtypes.DBEnginePgsql {
+		return fmt.Errorf("migration target must be a pgsql database")
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = 1000
+	}
+
+	// sqlite creates a new database if the file does not exist
+	if _, err := os.Stat(options.SqliteFile); err != nil {
+		return fmt.Errorf("error opening sqlite database: %v", err)
+	}
+
+	sourceDb, err := sqlx.Open("sqlite", fmt.Sprintf("%s?_pragma=query_only(1)", options.SqliteFile))
+	if err != nil {
+		return fmt.Errorf("error opening sqlite database: %v", err)
+	}
+	defer sourceDb.Close()
+
+	sourceVersion, err := getSchemaVersion(sourceDb)
+	if err != nil {
+		return fmt.Errorf("error loading sqlite schema version: %v", err)
+	}
+	targetVersion, err := getSchemaVersion(writerDb)
+	if err != nil {
+		return fmt.Errorf("error loading pgsql schema version: %v", err)
+	}
+	if sourceVersion != targetVersion {
+		return fmt.Errorf("schema version mismatch (sqlite: %v, pgsql: %v), run dora with the sqlite database once to upgrade its schema", sourceVersion, targetVersion)
+	}
+
+	tables := []string{}
+	err = sourceDb.SelectContext(ctx, &tables, `
+		SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'goose_db_version'
+		ORDER BY name`)
+	if err != nil {
+		return fmt.Errorf("error loading sqlite tables: %v", err)
+	}
+
+	tableColumns := make(map[string][]*sqliteMigrationColumn, len(tables))
+	for _, table := range tables {
+		columns, err := getSqliteMigrationColumns(ctx, sourceDb, table)
+		if err != nil {
+			return err
+		}
+		tableColumns[table] = columns
+	}
+
+	if options.Truncate {
+		quotedTables := make([]string, len(tables))
+		for i, table := range tables {
+			quotedTables[i] = fmt.Sprintf(`"%v"`, table)
+		}
+		logger.Infof("truncating %v pgsql tables", len(tables))
+		if _, err := writerDb.ExecContext(ctx, fmt.Sprintf("TRUNCATE TABLE %v", strings.Join(quotedTables, ", "))); err != nil {
+			return fmt.Errorf("error truncating pgsql tables: %v", err)
+		}
+	} else {
+		for _, table := range tables {
+			hasRows := false
+			if err := writerDb.GetContext(ctx, &hasRows, fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM "%v")`, table)); err != nil {
+				return fmt.Errorf("error checking pgsql table %v: %v", table, err)
+			}
+			if hasRows {
+				return fmt.Errorf("pgsql table %v is not empty, use the truncate option to clear the target tables", table)
+			}
+		}
+	}
+
+	startTime := time.Now()
+	totalRows := uint64(0)
+	for idx, table := range tables {
+		logger.Infof("copying table %v (%v/%v)", table, idx+1, len(tables))
+		copiedRows, err := copySqliteTable(ctx, sourceDb, table, tableColumns[table], options.BatchSize)
+		if err != nil {
+			return fmt.Errorf("error copying table %v: %v", table, err)
+		}
+		totalRows += copiedRows
+	}
+	logger.Infof("copied %v rows from %v tables in %v", totalRows, len(tables), time.Since(startTime).Round(time.Second))
+
+	if options.SkipVerify {
+		return nil
+	}
+
+	// verify row counts of all copied tables
+	mismatches := []string{}
+	for _, table := range tables {
+		sourceCount := uint64(0)
+		if err := sourceDb.GetContext(ctx, &sourceCount, fmt.Sprintf(`SELECT COUNT(*) FROM "%v"`, table)); err != nil {
+			return fmt.Errorf("error counting sqlite table %v: %v", table, err)
+		}
+		targetCount := uint64(0)
+		if err := writerDb.GetContext(ctx, &targetCount, fmt.Sprintf(`SELECT COUNT(*) FROM "%v"`, table)); err != nil {
+			return fmt.Errorf("error counting pgsql table %v: %v", table, err)
+		}
+
+		if sourceCount != targetCount {
+			mismatches = append(mismatches, fmt.Sprintf("%v (sqlite: %v, pgsql: %v)", table, sourceCount, targetCount))
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("verification failed, row count mismatch in %v", strings.Join(mismatches, ", "))
+	}
+	logger.Infof("verified row counts of %v tables", len(tables))
+
+	return nil
+}
+
+// getSchemaVersion returns the latest applied goose schema version of a database.
+func getSchemaVersion(dbConn *sqlx.DB) (int64, error) {
+	version := int64(0)
+	err := dbConn.Get(&version, `SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied`)
+	return version, err
+}
+
+// getSqliteMigrationColumns returns the columns of a sqlite table along with the data type of the matching pgsql column.
+func getSqliteMigrationColumns(ctx context.Context, sourceDb *sqlx.DB, table string) ([]*sqliteMigrationColumn, error) {
+	sourceColumns := []string{}
+	if err := sourceDb.SelectContext(ctx, &sourceColumns, `SELECT name FROM pragma_table_info(?) ORDER BY cid`, table); err != nil {
+		return nil, fmt.Errorf("error loading columns of sqlite table %v: %v", table, err)
+	}
+
+	targetColumns := []struct {
+		Name     string `db:"column_name"`
+		DataType string `db:"data_type"`
+	}{}
+	err := writerDb.SelectContext(ctx, &targetColumns, `
+		SELECT column_name, data_type FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1`, table)
+	if err != nil {
+		return nil, fmt.Errorf("error loading columns of pgsql table %v: %v", table, err)
+	}
+	if len(targetColumns) == 0 {
+		return nil, fmt.Errorf("table %v does not exist in pgsql schema", table)
+	}
+
+	targetTypes := make(map[string]string, len(targetColumns))
+	for _, column := range targetColumns {
+		targetTypes[column.Name] = column.DataType
+	}
+
+	columns := make([]*sqliteMigrationColumn, 0, len(sourceColumns))
+	for _, name := range sourceColumns {
+		dataType, found := targetTypes[name]
+		if !found {
+			return nil, fmt.Errorf("column %v.%v does not exist in pgsql schema", table, name)
+		}
+		columns = append(columns, &sqliteMigrationColumn{
+			name:     name,
+			dataType: dataType,
+		})
+	}
+
+	return columns, nil
+}
+
+// copySqliteTable streams all rows of a sqlite table into the pgsql table with batched inserts.
+func copySqliteTable(ctx context.Context, sourceDb *sqlx.DB, table string, columns []*sqliteMigrationColumn, batchSize int) (uint64, error) {
+	totalRows := uint64(0)
+	if err := sourceDb.GetContext(ctx, &totalRows, fmt.Sprintf(`SELECT COUNT(*) FROM "%v"`, table)); err != nil {
+		return 0, err
+	}
+	if totalRows == 0 {
+		return 0, nil
+	}
+
+	if batchSize*len(columns) > sqliteMigrationMaxParams {
+		batchSize = sqliteMigrationMaxParams / len(columns)
+	}
+
+	quotedColumns := make([]string, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = fmt.Sprintf(`"%v"`, column.name)
+	}
+	columnList := strings.Join(quotedColumns, ", ")
+
+	rows, err := sourceDb.QueryxContext(ctx, fmt.Sprintf(`SELECT %v FROM "%v"`, columnList, table))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	copiedRows := uint64(0)
+	lastProgress := time.Now()
+	batchArgs := make([]any, 0, batchSize*len(columns))
+	batchRows := 0
+
+	flushBatch := func() error {
+		if batchRows == 0 {
+			return nil
+		}
+
+		var sql strings.Builder
+		fmt.Fprintf(&sql, `INSERT INTO "%v" (%v) VALUES `, table, columnList)
+		argIdx := 1
+		for i := 0; i < batchRows; i++ {
+			if i > 0 {
+				fmt.Fprint(&sql, ", ")
+			}
+			fmt.Fprint(&sql, "(")
+			for j := range columns {
+				if j > 0 {
+					fmt.Fprint(&sql, ", ")
+				}
+				fmt.Fprintf(&sql, "$%v", argIdx)
+				argIdx++
+			}
+			fmt.Fprint(&sql, ")")
+		}
+
+		if _, err := writerDb.ExecContext(ctx, sql.String(), batchArgs...); err != nil {
+			return err
+		}
+
+		copiedRows += uint64(batchRows)
+		batchArgs = batchArgs[:0]
+		batchRows = 0
+
+		if time.Since(lastProgress) >= sqliteMigrationProgressInterval {
+			logger.Infof("copying table %v: %v/%v rows (%.1f%%)", table, copiedRows, totalRows, float64(copiedRows)*100/float64(totalRows))
+			lastProgress = time.Now()
+		}
+		return nil
+	}
+
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return copiedRows, err
+		}
+
+		for i, value := range values {
+			batchArgs = append(batchArgs, convertSqliteValue(value, columns[i].dataType))
+		}
+		batchRows++
+
+		if batchRows >= batchSize {
+			if err := flushBatch(); err != nil {
+				return copiedRows, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return copiedRows, err
+	}
+	if err := flushBatch(); err != nil {
+		return copiedRows, err
+	}
+
+	logger.Infof("copied table %v: %v rows", table, copiedRows)
+	return copiedRows, nil
+}
+
+// convertSqliteValue converts a value read from sqlite to the type expected by the pgsql column.
+// sqlite stores booleans as integers and does not strictly distinguish between text & blob values.
+func convertSqliteValue(value any, dataType string) any {
+	switch dataType {
+	case "boolean":
+		if intValue, ok := value.(int64); ok {
+			return intValue != 0
+		}
+	case "bytea":
+		if strValue, ok := value.(string); ok {
+			return []byte(strValue)
+		}
+	case "text", "character varying":
+		if bytesValue, ok := value.([]byte); ok {
+			return string(bytesValue)
+		}
+	case "real", "double precision":
+		if intValue, ok := value.(int64); ok {
+			return float64(intValue)
+		}
+	}
+
+	return value
+}
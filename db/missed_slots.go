@@ -0,0 +1,119 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertMissedSlots inserts or replaces the root cause analysis of multiple missed slots in a batch
+func InsertMissedSlots(missedSlots []*dbtypes.MissedSlot, tx *sqlx.Tx) error {
+	if len(missedSlots) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(missedSlots))
+	valueArgs := make([]interface{}, 0, len(missedSlots)*6)
+	for i, missedSlot := range missedSlots {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v)", i*6+1, i*6+2, i*6+3, i*6+4, i*6+5, i*6+6)
+		valueArgs = append(valueArgs,
+			missedSlot.Slot,
+			missedSlot.Proposer,
+			missedSlot.Reason,
+			missedSlot.BlockRoot,
+			missedSlot.SeenDelay,
+			missedSlot.SeenBy)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO missed_slots (
+				slot, proposer, reason, block_root, seen_delay, seen_by
+			) VALUES %s
+			ON CONFLICT (slot) DO UPDATE SET
+				proposer = excluded.proposer,
+				reason = excluded.reason,
+				block_root = excluded.block_root,
+				seen_delay = excluded.seen_delay,
+				seen_by = excluded.seen_by`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO missed_slots (
+				slot, proposer, reason, block_root, seen_delay, seen_by
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting missed slots: %v", err)
+	}
+
+	return nil
+}
+
+func getMissedSlotsFilterSql(filter *dbtypes.MissedSlotFilter, withReason bool) (string, []interface{}) {
+	var filterSql strings.Builder
+	args := []interface{}{filter.MinSlot}
+
+	fmt.Fprint(&filterSql, ` WHERE slot >= $1 `)
+	if filter.MaxSlot > 0 {
+		args = append(args, filter.MaxSlot)
+		fmt.Fprintf(&filterSql, ` AND slot <= $%v `, len(args))
+	}
+	if filter.Proposer != nil {
+		args = append(args, *filter.Proposer)
+		fmt.Fprintf(&filterSql, ` AND proposer = $%v `, len(args))
+	}
+	if withReason && filter.WithReason > 0 {
+		args = append(args, filter.WithReason)
+		fmt.Fprintf(&filterSql, ` AND reason = $%v `, len(args))
+	}
+
+	return filterSql.String(), args
+}
+
+// GetMissedSlotsFiltered returns a page of analyzed missed slots matching the filter (newest first) and the total number of matches
+func GetMissedSlotsFiltered(offset uint64, limit uint32, filter *dbtypes.MissedSlotFilter) ([]*dbtypes.MissedSlot, uint64, error) {
+	filterSql, args := getMissedSlotsFilterSql(filter, true)
+
+	var totalCount uint64
+	err := ReaderDb.Get(&totalCount, fmt.Sprintf(`SELECT COUNT(*) FROM missed_slots %v`, filterSql), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching missed slot count: %v", err)
+		return nil, 0, err
+	}
+
+	args = append(args, limit, offset)
+	missedSlots := []*dbtypes.MissedSlot{}
+	err = ReaderDb.Select(&missedSlots, fmt.Sprintf(`
+		SELECT slot, proposer, reason, block_root, seen_delay, seen_by
+		FROM missed_slots
+		%v
+		ORDER BY slot DESC
+		LIMIT $%v OFFSET $%v`, filterSql, len(args)-1, len(args)), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching missed slots: %v", err)
+		return nil, 0, err
+	}
+
+	return missedSlots, totalCount, nil
+}
+
+// GetMissedSlotReasonCounts returns the number of analyzed missed slots per reason matching the filter (the reason filter is ignored)
+func GetMissedSlotReasonCounts(filter *dbtypes.MissedSlotFilter) ([]*dbtypes.MissedSlotReasonCount, error) {
+	filterSql, args := getMissedSlotsFilterSql(filter, false)
+
+	counts := []*dbtypes.MissedSlotReasonCount{}
+	err := ReaderDb.Select(&counts, fmt.Sprintf(`
+		SELECT reason, COUNT(*) AS count
+		FROM missed_slots
+		%v
+		GROUP BY reason`, filterSql), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching missed slot reason counts: %v", err)
+		return nil, err
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// GetOnlineMigrations returns all registered online migrations ordered by name.
+func GetOnlineMigrations() ([]*dbtypes.OnlineMigration, error) {
+	migrations := []*dbtypes.OnlineMigration{}
+	err := ReaderDb.Select(&migrations, `
+		SELECT name, table_name, key_column, backfill_sql, finalize_sql, chunk_size, first_key, next_key, max_key, rows_done, started_at, finished_at, last_error
+		FROM online_migrations
+		ORDER BY name ASC`)
+	if err != nil {
+		return nil, err
+	}
+	return migrations, nil
+}
+
+// IsOnlineMigrationFinished returns true if the backfill of the named online migration has been completed.
+// code relying on a backfilled column needs to handle the incomplete state until then.
+func IsOnlineMigrationFinished(name string) bool {
+	finishedAt := uint64(0)
+	err := ReaderDb.Get(&finishedAt, `SELECT finished_at FROM online_migrations WHERE name = $1`, name)
+	return err == nil && finishedAt > 0
+}
+
+// StartOnlineMigration determines the key range of the table to backfill.
+// rows inserted after the start are expected to be written with the new schema and are not part of the backfill.
+func StartOnlineMigration(migration *dbtypes.OnlineMigration, tx *sqlx.Tx) error {
+	keyRange := struct {
+		MinKey *int64 `db:"min_key"`
+		MaxKey *int64 `db:"max_key"`
+	}{}
+	err := tx.Get(&keyRange, fmt.Sprintf(`SELECT MIN(%v) AS min_key, MAX(%v) AS max_key FROM %v`, migration.KeyColumn, migration.KeyColumn, migration.TableName))
+	if err != nil {
+		return fmt.Errorf("failed loading key range: %v", err)
+	}
+
+	nextKey := int64(0)
+	maxKey := int64(-1)
+	if keyRange.MinKey != nil && keyRange.MaxKey != nil {
+		nextKey = *keyRange.MinKey
+		maxKey = *keyRange.MaxKey
+	}
+
+	firstKey := nextKey
+	migration.FirstKey = &firstKey
+	migration.NextKey = &nextKey
+	migration.MaxKey = &maxKey
+	migration.StartedAt = uint64(time.Now().Unix())
+
+	_, err = tx.Exec(`UPDATE online_migrations SET first_key = $1, next_key = $1, max_key = $2, started_at = $3 WHERE name = $4`, nextKey, maxKey, migration.StartedAt, migration.Name)
+	return err
+}
+
+// RunOnlineMigrationChunk backfills the next key range chunk of the migration and updates the progress.
+// returns true if the backfill range has been completed.
+func RunOnlineMigrationChunk(migration *dbtypes.OnlineMigration, tx *sqlx.Tx) (bool, error) {
+	if migration.NextKey == nil || migration.MaxKey == nil {
+		return false, fmt.Errorf("online migration %v not started", migration.Name)
+	}
+
+	firstKey := *migration.NextKey
+	if firstKey > *migration.MaxKey {
+		return true, nil
+	}
+
+	chunkSize := int64(migration.ChunkSize)
+	if chunkSize <= 0 {
+		chunkSize = 10000
+	}
+	lastKey := firstKey + chunkSize - 1
+	if lastKey > *migration.MaxKey {
+		lastKey = *migration.MaxKey
+	}
+
+	res, err := tx.Exec(migration.BackfillSql, firstKey, lastKey)
+	if err != nil {
+		return false, err
+	}
+	affected, _ := res.RowsAffected()
+
+	nextKey := lastKey + 1
+	_, err = tx.Exec(`UPDATE online_migrations SET next_key = $1, rows_done = rows_done + $2, last_error = '' WHERE name = $3`, nextKey, affected, migration.Name)
+	if err != nil {
+		return false, err
+	}
+
+	migration.NextKey = &nextKey
+	migration.RowsDone += uint64(affected)
+	migration.LastError = ""
+	return nextKey > *migration.MaxKey, nil
+}
+
+// FinishOnlineMigration runs the finalize statement of the migration and marks it as finished.
+// the finalize statement is executed outside of a transaction, so it can create indexes concurrently.
+func FinishOnlineMigration(migration *dbtypes.OnlineMigration) error {
+	if migration.FinalizeSql != "" {
+		if DbEngine == dbtypes.DBEngineSqlite {
+			writerMutex.Lock()
+			_, err := writerDb.Exec(migration.FinalizeSql)
+			writerMutex.Unlock()
+			if err != nil {
+				return fmt.Errorf("finalize failed: %v", err)
+			}
+		} else if _, err := writerDb.Exec(migration.FinalizeSql); err != nil {
+			return fmt.Errorf("finalize failed: %v", err)
+		}
+	}
+
+	migration.FinishedAt = uint64(time.Now().Unix())
+	return RunDBTransaction(func(tx *sqlx.Tx) error {
+		_, err := tx.Exec(`UPDATE online_migrations SET finished_at = $1, last_error = '' WHERE name = $2`, migration.FinishedAt, migration.Name)
+		return err
+	})
+}
+
+// SetOnlineMigrationError records the last error of the migration for progress reporting.
+func SetOnlineMigrationError(name string, lastError string) error {
+	return RunDBTransaction(func(tx *sqlx.Tx) error {
+		_, err := tx.Exec(`UPDATE online_migrations SET last_error = $1 WHERE name = $2`, lastError, name)
+		return err
+	})
+}
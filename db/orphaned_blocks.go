@@ -6,18 +6,37 @@ import (
 )
 
 func InsertOrphanedBlock(block *dbtypes.OrphanedBlock, tx *sqlx.Tx) error {
+	blockSSZ := block.BlockSSZ
+	if PayloadStore != nil && len(blockSSZ) > 0 {
+		// don't replace the payload of an existing row, it might be encoded with a different block version / compression
+		var rowCount int
+		if err := tx.Get(&rowCount, `SELECT COUNT(*) FROM orphaned_blocks WHERE root = $1`, block.Root); err != nil {
+			return err
+		}
+		if rowCount > 0 {
+			return nil
+		}
+
+		// the row is written with the inline payload, which is moved to the blob store after the transaction has been committed.
+		// this way a rolled back transaction doesn't leave an unreferenced payload behind.
+		root := block.Root
+		runAfterCommit(tx, func() {
+			moveBlockPayload("orphaned_blocks", getOrphanedBlockPayloadKey(root), root, blockSSZ)
+		})
+	}
+
 	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO orphaned_blocks (
-				root, header_ver, header_ssz, block_ver, block_ssz
-			) VALUES ($1, $2, $3, $4, $5)
+				root, header_ver, header_ssz, block_ver, block_ssz, block_ext
+			) VALUES ($1, $2, $3, $4, $5, $6)
 			ON CONFLICT (root) DO NOTHING`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR IGNORE INTO orphaned_blocks (
-				root, header_ver, header_ssz, block_ver, block_ssz
-			) VALUES ($1, $2, $3, $4, $5)`,
+				root, header_ver, header_ssz, block_ver, block_ssz, block_ext
+			) VALUES ($1, $2, $3, $4, $5, $6)`,
 	}),
-		block.Root, block.HeaderVer, block.HeaderSSZ, block.BlockVer, block.BlockSSZ)
+		block.Root, block.HeaderVer, block.HeaderSSZ, block.BlockVer, blockSSZ, 0)
 	if err != nil {
 		return err
 	}
@@ -27,12 +46,19 @@ func InsertOrphanedBlock(block *dbtypes.OrphanedBlock, tx *sqlx.Tx) error {
 func GetOrphanedBlock(root []byte) *dbtypes.OrphanedBlock {
 	block := dbtypes.OrphanedBlock{}
 	err := ReaderDb.Get(&block, `
-	SELECT root, header_ver, header_ssz, block_ver, block_ssz
+	SELECT root, header_ver, header_ssz, block_ver, block_ssz, block_ext
 	FROM orphaned_blocks
 	WHERE root = $1
 	`, root)
 	if err != nil {
 		return nil
 	}
+	if block.BlockExt != 0 {
+		blockSSZ, err := getPayload(getOrphanedBlockPayloadKey(root))
+		if err != nil {
+			logger.Errorf("Error while loading orphaned block 0x%x payload: %v", root, err)
+		}
+		block.BlockSSZ = blockSSZ
+	}
 	return &block
 }
@@ -0,0 +1,119 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// GetPoolClients returns all known pool clients
+func GetPoolClients() ([]*dbtypes.PoolClient, error) {
+	clients := []*dbtypes.PoolClient{}
+	err := ReaderDb.Select(&clients, `
+		SELECT client_id, layer, name, client_type, fingerprint, first_seen, last_seen
+		FROM pool_clients
+		ORDER BY client_id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching pool clients: %v", err)
+	}
+
+	return clients, nil
+}
+
+// GetPoolClientVersions returns the version history of all pool clients
+func GetPoolClientVersions() ([]*dbtypes.PoolClientVersion, error) {
+	versions := []*dbtypes.PoolClientVersion{}
+	err := ReaderDb.Select(&versions, `
+		SELECT client_id, version, first_seen, last_seen
+		FROM pool_client_versions
+		ORDER BY client_id ASC, first_seen ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching pool client versions: %v", err)
+	}
+
+	return versions, nil
+}
+
+// InsertPoolClients inserts or replaces multiple pool clients in a batch
+func InsertPoolClients(clients []*dbtypes.PoolClient, tx *sqlx.Tx) error {
+	if len(clients) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(clients))
+	valueArgs := make([]interface{}, 0, len(clients)*7)
+	for i, client := range clients {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v, $%v)", i*7+1, i*7+2, i*7+3, i*7+4, i*7+5, i*7+6, i*7+7)
+		valueArgs = append(valueArgs,
+			client.ClientId,
+			client.Layer,
+			client.Name,
+			client.ClientType,
+			client.Fingerprint,
+			client.FirstSeen,
+			client.LastSeen)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO pool_clients (
+				client_id, layer, name, client_type, fingerprint, first_seen, last_seen
+			) VALUES %s
+			ON CONFLICT (client_id) DO UPDATE SET
+				name = excluded.name,
+				client_type = excluded.client_type,
+				fingerprint = excluded.fingerprint,
+				last_seen = excluded.last_seen`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO pool_clients (
+				client_id, layer, name, client_type, fingerprint, first_seen, last_seen
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting pool clients: %v", err)
+	}
+
+	return nil
+}
+
+// InsertPoolClientVersions inserts or replaces multiple pool client versions in a batch
+func InsertPoolClientVersions(versions []*dbtypes.PoolClientVersion, tx *sqlx.Tx) error {
+	if len(versions) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(versions))
+	valueArgs := make([]interface{}, 0, len(versions)*4)
+	for i, version := range versions {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v)", i*4+1, i*4+2, i*4+3, i*4+4)
+		valueArgs = append(valueArgs,
+			version.ClientId,
+			version.Version,
+			version.FirstSeen,
+			version.LastSeen)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO pool_client_versions (
+				client_id, version, first_seen, last_seen
+			) VALUES %s
+			ON CONFLICT (client_id, version) DO UPDATE SET
+				last_seen = excluded.last_seen`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO pool_client_versions (
+				client_id, version, first_seen, last_seen
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting pool client versions: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertProposerEquivocations inserts multiple proposer equivocations in a batch, existing equivocations are left unchanged
+func InsertProposerEquivocations(equivocations []*dbtypes.ProposerEquivocation, tx *sqlx.Tx) error {
+	if len(equivocations) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(equivocations))
+	valueArgs := make([]interface{}, 0, len(equivocations)*4)
+	for i, equivocation := range equivocations {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v)", i*4+1, i*4+2, i*4+3, i*4+4)
+		valueArgs = append(valueArgs,
+			equivocation.Slot,
+			equivocation.Proposer,
+			equivocation.BlockRoot1,
+			equivocation.BlockRoot2)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO proposer_equivocations (
+				slot, proposer, block_root_1, block_root_2
+			) VALUES %s
+			ON CONFLICT (slot, block_root_1, block_root_2) DO NOTHING`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR IGNORE INTO proposer_equivocations (
+				slot, proposer, block_root_1, block_root_2
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting proposer equivocations: %v", err)
+	}
+
+	return nil
+}
+
+// GetProposerEquivocationsBySlot returns the proposer equivocations of a slot
+func GetProposerEquivocationsBySlot(slot uint64) []*dbtypes.ProposerEquivocation {
+	equivocations := []*dbtypes.ProposerEquivocation{}
+	err := ReaderDb.Select(&equivocations, `
+		SELECT slot, proposer, block_root_1, block_root_2
+		FROM proposer_equivocations
+		WHERE slot = $1`, slot)
+	if err != nil {
+		logger.Errorf("Error while fetching proposer equivocations by slot: %v", err)
+		return nil
+	}
+
+	return equivocations
+}
+
+// GetProposerEquivocationsByProposer returns the most recent equivocations of a proposer (newest first)
+func GetProposerEquivocationsByProposer(proposer uint64, limit uint32) []*dbtypes.ProposerEquivocation {
+	equivocations := []*dbtypes.ProposerEquivocation{}
+	err := ReaderDb.Select(&equivocations, `
+		SELECT slot, proposer, block_root_1, block_root_2
+		FROM proposer_equivocations
+		WHERE proposer = $1
+		ORDER BY slot DESC
+		LIMIT $2`, proposer, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching proposer equivocations by proposer: %v", err)
+		return nil
+	}
+
+	return equivocations
+}
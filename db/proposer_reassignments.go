@@ -0,0 +1,65 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertProposerReassignments inserts multiple proposer reassignments in a batch, existing reassignments are left unchanged
+func InsertProposerReassignments(reassignments []*dbtypes.ProposerReassignment, tx *sqlx.Tx) error {
+	if len(reassignments) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(reassignments))
+	valueArgs := make([]interface{}, 0, len(reassignments)*7)
+	for i, reassignment := range reassignments {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v, $%v)", i*7+1, i*7+2, i*7+3, i*7+4, i*7+5, i*7+6, i*7+7)
+		valueArgs = append(valueArgs,
+			reassignment.Slot,
+			reassignment.Epoch,
+			reassignment.SwitchSlot,
+			reassignment.OldProposer,
+			reassignment.NewProposer,
+			reassignment.OldDependentRoot,
+			reassignment.NewDependentRoot)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO proposer_reassignments (
+				slot, epoch, switch_slot, old_proposer, new_proposer, old_dependent_root, new_dependent_root
+			) VALUES %s
+			ON CONFLICT (slot, old_dependent_root, new_dependent_root) DO NOTHING`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR IGNORE INTO proposer_reassignments (
+				slot, epoch, switch_slot, old_proposer, new_proposer, old_dependent_root, new_dependent_root
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting proposer reassignments: %v", err)
+	}
+
+	return nil
+}
+
+// GetProposerReassignmentsBySlot returns the proposer reassignments of a slot, oldest switch first
+func GetProposerReassignmentsBySlot(slot uint64) []*dbtypes.ProposerReassignment {
+	reassignments := []*dbtypes.ProposerReassignment{}
+	err := ReaderDb.Select(&reassignments, `
+		SELECT slot, epoch, switch_slot, old_proposer, new_proposer, old_dependent_root, new_dependent_root
+		FROM proposer_reassignments
+		WHERE slot = $1
+		ORDER BY switch_slot ASC`, slot)
+	if err != nil {
+		logger.Errorf("Error while fetching proposer reassignments by slot: %v", err)
+		return nil
+	}
+
+	return reassignments
+}
@@ -0,0 +1,40 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// GetWithdrawalRequestFeeStats returns the fees paid for withdrawal requests since minTime,
+// aggregated in buckets of bucketSize seconds (bucket = block_time / bucketSize).
+func GetWithdrawalRequestFeeStats(minTime uint64, bucketSize uint64) ([]*dbtypes.RequestFeeStats, error) {
+	return getRequestFeeStats("withdrawal_request_txs", minTime, bucketSize)
+}
+
+// GetConsolidationRequestFeeStats returns the fees paid for consolidation requests since minTime,
+// aggregated in buckets of bucketSize seconds (bucket = block_time / bucketSize).
+func GetConsolidationRequestFeeStats(minTime uint64, bucketSize uint64) ([]*dbtypes.RequestFeeStats, error) {
+	return getRequestFeeStats("consolidation_request_txs", minTime, bucketSize)
+}
+
+func getRequestFeeStats(table string, minTime uint64, bucketSize uint64) ([]*dbtypes.RequestFeeStats, error) {
+	if bucketSize == 0 {
+		bucketSize = 1
+	}
+
+	// requests with unknown fee (sent via an intermediate contract) are skipped
+	stats := []*dbtypes.RequestFeeStats{}
+	err := ReaderDb.Select(&stats, fmt.Sprintf(`
+		SELECT block_time / $1 AS bucket, COUNT(*) AS requests, MIN(fee) AS min_fee, MAX(fee) AS max_fee, CAST(AVG(fee) AS BIGINT) AS avg_fee
+		FROM %v
+		WHERE block_time >= $2 AND fee > 0
+		GROUP BY bucket
+		ORDER BY bucket ASC`, table), bucketSize, minTime)
+	if err != nil {
+		logger.Errorf("Error while fetching request fee stats from %v: %v", table, err)
+		return nil, err
+	}
+
+	return stats, nil
+}
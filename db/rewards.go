@@ -0,0 +1,252 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertEpochRewards inserts or updates the aggregated rewards of an epoch
+func InsertEpochRewards(rewards *dbtypes.EpochRewards, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO epoch_rewards (
+				epoch, validators, attestation_head, attestation_target, attestation_source, attestation_inclusion_delay,
+				attestation_inactivity, sync_committee, proposer
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (epoch) DO UPDATE SET
+				validators = excluded.validators,
+				attestation_head = excluded.attestation_head,
+				attestation_target = excluded.attestation_target,
+				attestation_source = excluded.attestation_source,
+				attestation_inclusion_delay = excluded.attestation_inclusion_delay,
+				attestation_inactivity = excluded.attestation_inactivity,
+				sync_committee = excluded.sync_committee,
+				proposer = excluded.proposer`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO epoch_rewards (
+				epoch, validators, attestation_head, attestation_target, attestation_source, attestation_inclusion_delay,
+				attestation_inactivity, sync_committee, proposer
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+	}),
+		rewards.Epoch, rewards.Validators, rewards.AttestationHead, rewards.AttestationTarget, rewards.AttestationSource,
+		rewards.AttestationInclusionDelay, rewards.AttestationInactivity, rewards.SyncCommittee, rewards.Proposer)
+	if err != nil {
+		return fmt.Errorf("error inserting epoch rewards: %v", err)
+	}
+	return nil
+}
+
+// InsertBlockRewards inserts or updates the proposer rewards of multiple blocks in a batch
+func InsertBlockRewards(rewards []*dbtypes.BlockRewards, tx *sqlx.Tx) error {
+	if len(rewards) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(rewards))
+	valueArgs := make([]interface{}, 0, len(rewards)*9)
+	for i, reward := range rewards {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", i*9+1, i*9+2, i*9+3, i*9+4, i*9+5, i*9+6, i*9+7, i*9+8, i*9+9)
+		valueArgs = append(valueArgs,
+			reward.Slot,
+			reward.Proposer,
+			reward.Total,
+			reward.Attestations,
+			reward.SyncAggregate,
+			reward.ProposerSlashings,
+			reward.AttesterSlashings,
+			reward.ElFees,
+			reward.MevPayment)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO block_rewards (
+				slot, proposer, total, attestations, sync_aggregate, proposer_slashings, attester_slashings, el_fees, mev_payment
+			) VALUES %s
+			ON CONFLICT (slot) DO UPDATE SET
+				proposer = excluded.proposer,
+				total = excluded.total,
+				attestations = excluded.attestations,
+				sync_aggregate = excluded.sync_aggregate,
+				proposer_slashings = excluded.proposer_slashings,
+				attester_slashings = excluded.attester_slashings,
+				el_fees = excluded.el_fees,
+				mev_payment = excluded.mev_payment`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO block_rewards (
+				slot, proposer, total, attestations, sync_aggregate, proposer_slashings, attester_slashings, el_fees, mev_payment
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting block rewards: %v", err)
+	}
+	return nil
+}
+
+// InsertValidatorRewardsBatch inserts or updates multiple daily validator rewards in a batch
+func InsertValidatorRewardsBatch(rewards []*dbtypes.ValidatorRewards, tx *sqlx.Tx) error {
+	if len(rewards) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(rewards))
+	valueArgs := make([]interface{}, 0, len(rewards)*6)
+	for i, reward := range rewards {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v)", i*6+1, i*6+2, i*6+3, i*6+4, i*6+5, i*6+6)
+		valueArgs = append(valueArgs,
+			reward.ValidatorIndex,
+			reward.Day,
+			reward.Epochs,
+			reward.Attestation,
+			reward.SyncCommittee,
+			reward.Proposer)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO validator_rewards (
+				validator_index, day, epochs, attestation, sync_committee, proposer
+			) VALUES %s
+			ON CONFLICT (validator_index, day) DO UPDATE SET
+				epochs = excluded.epochs,
+				attestation = excluded.attestation,
+				sync_committee = excluded.sync_committee,
+				proposer = excluded.proposer`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO validator_rewards (
+				validator_index, day, epochs, attestation, sync_committee, proposer
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting validator rewards batch: %v", err)
+	}
+	return nil
+}
+
+// DeleteValidatorRewardsBefore deletes all daily validator rewards before the given day
+func DeleteValidatorRewardsBefore(day uint64, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`DELETE FROM validator_rewards WHERE day < $1`, day)
+	if err != nil {
+		return fmt.Errorf("error deleting old validator rewards: %v", err)
+	}
+	return nil
+}
+
+// GetEpochRewards returns the aggregated rewards of an epoch or nil if the epoch has not been indexed
+func GetEpochRewards(epoch uint64) *dbtypes.EpochRewards {
+	rewards := dbtypes.EpochRewards{}
+	err := ReaderDb.Get(&rewards, `
+		SELECT
+			epoch, validators, attestation_head, attestation_target, attestation_source, attestation_inclusion_delay,
+			attestation_inactivity, sync_committee, proposer
+		FROM epoch_rewards
+		WHERE epoch = $1
+	`, epoch)
+	if err != nil {
+		return nil
+	}
+	return &rewards
+}
+
+// GetBlockRewardsRange returns the proposer rewards of all blocks in the given slot range (inclusive), ordered by slot
+func GetBlockRewardsRange(firstSlot uint64, lastSlot uint64) ([]*dbtypes.BlockRewards, error) {
+	rewards := []*dbtypes.BlockRewards{}
+	err := ReaderDb.Select(&rewards, `
+		SELECT slot, proposer, total, attestations, sync_aggregate, proposer_slashings, attester_slashings, el_fees, mev_payment
+		FROM block_rewards
+		WHERE slot >= $1 AND slot <= $2
+		ORDER BY slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching block rewards: %v", err)
+		return nil, err
+	}
+	return rewards, nil
+}
+
+// GetBlockRewards returns the proposer rewards of the block in the given slot or nil if the block has not been indexed
+func GetBlockRewards(slot uint64) *dbtypes.BlockRewards {
+	rewards := dbtypes.BlockRewards{}
+	err := ReaderDb.Get(&rewards, `
+		SELECT slot, proposer, total, attestations, sync_aggregate, proposer_slashings, attester_slashings, el_fees, mev_payment
+		FROM block_rewards
+		WHERE slot = $1
+	`, slot)
+	if err != nil {
+		return nil
+	}
+	return &rewards
+}
+
+// GetBlockRewardsBySlots returns the proposer rewards of the blocks in the given slots, blocks that have not been indexed are omitted
+func GetBlockRewardsBySlots(slots []uint64) ([]*dbtypes.BlockRewards, error) {
+	rewards := []*dbtypes.BlockRewards{}
+	if len(slots) == 0 {
+		return rewards, nil
+	}
+
+	var sql strings.Builder
+	args := make([]any, len(slots))
+
+	fmt.Fprint(&sql, `
+		SELECT slot, proposer, total, attestations, sync_aggregate, proposer_slashings, attester_slashings, el_fees, mev_payment
+		FROM block_rewards
+		WHERE slot IN (`)
+	for i, slot := range slots {
+		if i > 0 {
+			fmt.Fprint(&sql, ",")
+		}
+		args[i] = slot
+		fmt.Fprintf(&sql, "$%v", i+1)
+	}
+	fmt.Fprint(&sql, `)`)
+
+	err := ReaderDb.Select(&rewards, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching block rewards by slots: %v", err)
+		return nil, err
+	}
+	return rewards, nil
+}
+
+// GetValidatorRewardsHistory returns the most recent daily rewards of a validator, newest first
+func GetValidatorRewardsHistory(validatorIndex uint64, limit uint32) ([]*dbtypes.ValidatorRewards, error) {
+	rewards := []*dbtypes.ValidatorRewards{}
+	err := ReaderDb.Select(&rewards, `
+		SELECT validator_index, day, epochs, attestation, sync_committee, proposer
+		FROM validator_rewards
+		WHERE validator_index = $1
+		ORDER BY day DESC
+		LIMIT $2
+	`, validatorIndex, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching validator rewards history: %v", err)
+		return nil, err
+	}
+	return rewards, nil
+}
+
+// GetBlockValueStats returns the number of indexed blocks and their summed proposer value in the given slot range (inclusive).
+// the proposer value is the consensus reward plus the mev payment for relayed blocks or the priority fees for locally built blocks.
+func GetBlockValueStats(firstSlot uint64, lastSlot uint64) (*dbtypes.BlockValueStats, error) {
+	stats := dbtypes.BlockValueStats{}
+	err := ReaderDb.Get(&stats, `
+		SELECT
+			COUNT(*) AS blocks,
+			COALESCE(SUM(total + CASE WHEN mev_payment > 0 THEN mev_payment ELSE el_fees END), 0) AS total_value
+		FROM block_rewards
+		WHERE slot >= $1 AND slot <= $2
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching block value stats: %v", err)
+		return nil, err
+	}
+	return &stats, nil
+}
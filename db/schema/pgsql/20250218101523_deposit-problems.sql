@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE public."deposit_txs"
+    ADD "problem_flags" smallint NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS "deposit_txs_problem_flags_idx"
+    ON public."deposit_txs"
+    ("problem_flags" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
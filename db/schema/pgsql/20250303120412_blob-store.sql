@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE public."unfinalized_blocks"
+    ADD "block_ext" smallint NOT NULL DEFAULT 0;
+
+ALTER TABLE public."orphaned_blocks"
+    ADD "block_ext" smallint NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."genesis_validators" (
+    "validator_index" BIGINT NOT NULL,
+    "pubkey" bytea NOT NULL,
+    "withdrawal_credentials" bytea NOT NULL,
+    "balance" BIGINT NOT NULL,
+    "entity" VARCHAR(100) NOT NULL DEFAULT '',
+    CONSTRAINT "genesis_validators_pkey" PRIMARY KEY ("validator_index")
+);
+
+CREATE INDEX IF NOT EXISTS "genesis_validators_pubkey_idx"
+    ON public."genesis_validators"
+    ("pubkey" ASC NULLS LAST);
+
+CREATE INDEX IF NOT EXISTS "genesis_validators_entity_idx"
+    ON public."genesis_validators"
+    ("entity" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."execution_block_slots" (
+    "root" bytea NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "block_hash" bytea NOT NULL,
+    "block_number" BIGINT NOT NULL,
+    "fork_id" BIGINT NOT NULL DEFAULT 0,
+    "orphaned" bool NOT NULL DEFAULT FALSE,
+    CONSTRAINT "execution_block_slots_pkey" PRIMARY KEY ("root")
+);
+
+CREATE INDEX IF NOT EXISTS "execution_block_slots_block_hash_idx"
+    ON public."execution_block_slots"
+    ("block_hash" ASC NULLS LAST);
+
+CREATE INDEX IF NOT EXISTS "execution_block_slots_block_number_idx"
+    ON public."execution_block_slots"
+    ("block_number" ASC NULLS LAST);
+
+-- backfill mapping from already indexed blocks
+INSERT INTO public."execution_block_slots" (root, slot, block_hash, block_number, fork_id, orphaned)
+SELECT root, slot, eth_block_hash, eth_block_number, fork_id, status = 2
+FROM slots
+WHERE eth_block_hash IS NOT NULL AND eth_block_number IS NOT NULL AND status != 0
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."indexer_leases" (
+    "name" TEXT NOT NULL,
+    "holder" TEXT NOT NULL,
+    "expires" BIGINT NOT NULL,
+    CONSTRAINT "indexer_leases_pkey" PRIMARY KEY ("name")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
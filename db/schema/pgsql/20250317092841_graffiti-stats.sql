@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- btree index for graffiti aggregations (leaderboard), the gin trigram index only covers substring searches
+CREATE INDEX IF NOT EXISTS "slots_graffiti_text_slot_idx"
+    ON public."slots"
+    ("graffiti_text" ASC NULLS LAST, "slot" ASC NULLS LAST)
+    WHERE "status" = 1;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."slot_blockprints" (
+    "slot" BIGINT NOT NULL,
+    "proposer" BIGINT NOT NULL,
+    "client" SMALLINT NOT NULL,
+    "probability" real NOT NULL DEFAULT 0,
+    CONSTRAINT "slot_blockprints_pkey" PRIMARY KEY ("slot")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
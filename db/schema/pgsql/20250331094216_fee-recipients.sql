@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- fee recipient of the execution payload (NULL for pre-merge blocks)
+ALTER TABLE public."slots"
+    ADD "eth_fee_recipient" bytea NULL;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
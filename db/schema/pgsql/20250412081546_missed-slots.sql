@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- root cause analysis of missed slots in finalized epochs
+-- (reason: 1 = no block seen, 2 = reorged proposal, 3 = late block)
+CREATE TABLE IF NOT EXISTS public."missed_slots" (
+    "slot" BIGINT NOT NULL,
+    "proposer" BIGINT NOT NULL,
+    "reason" smallint NOT NULL,
+    "block_root" bytea NULL,
+    "seen_delay" BIGINT NULL,
+    "seen_by" INT NOT NULL DEFAULT 0,
+    CONSTRAINT "missed_slots_pkey" PRIMARY KEY ("slot")
+);
+
+CREATE INDEX IF NOT EXISTS "missed_slots_reason_idx"
+    ON public."missed_slots"
+    ("reason" ASC, "slot" DESC NULLS LAST);
+
+CREATE INDEX IF NOT EXISTS "missed_slots_proposer_idx"
+    ON public."missed_slots"
+    ("proposer" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- double & surround votes detected in unfinalized blocks (type: 1 = double vote, 2 = surround vote)
+-- att1 is the earlier seen vote, att2 the conflicting vote; roots are attestation data roots
+CREATE TABLE IF NOT EXISTS public."detected_slashings" (
+    "validator" BIGINT NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "type" smallint NOT NULL,
+    "att1_root" bytea NOT NULL,
+    "att1_source" BIGINT NOT NULL,
+    "att1_target" BIGINT NOT NULL,
+    "att2_root" bytea NOT NULL,
+    "att2_source" BIGINT NOT NULL,
+    "att2_target" BIGINT NOT NULL,
+    "broadcasted" bool NOT NULL DEFAULT FALSE,
+    CONSTRAINT "detected_slashings_pkey" PRIMARY KEY ("validator", "att1_root", "att2_root")
+);
+
+CREATE INDEX IF NOT EXISTS "detected_slashings_slot_idx"
+    ON public."detected_slashings"
+    ("slot" DESC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- lookup of system request contract interactions by transaction sender (address watch)
+CREATE INDEX IF NOT EXISTS "withdrawal_request_txs_tx_sender_idx"
+    ON public."withdrawal_request_txs"
+    ("tx_sender" ASC NULLS FIRST);
+
+CREATE INDEX IF NOT EXISTS "consolidation_request_txs_tx_sender_idx"
+    ON public."consolidation_request_txs"
+    ("tx_sender" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
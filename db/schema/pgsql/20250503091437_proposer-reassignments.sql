@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- proposer duties that changed because a reorg switched the dependent root of the epoch
+CREATE TABLE IF NOT EXISTS public."proposer_reassignments" (
+    "slot" BIGINT NOT NULL,
+    "epoch" BIGINT NOT NULL,
+    "switch_slot" BIGINT NOT NULL,
+    "old_proposer" BIGINT NOT NULL,
+    "new_proposer" BIGINT NOT NULL,
+    "old_dependent_root" bytea NOT NULL,
+    "new_dependent_root" bytea NOT NULL,
+    CONSTRAINT "proposer_reassignments_pkey" PRIMARY KEY ("slot", "old_dependent_root", "new_dependent_root")
+);
+
+CREATE INDEX IF NOT EXISTS "proposer_reassignments_epoch_idx"
+    ON public."proposer_reassignments"
+    ("epoch" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
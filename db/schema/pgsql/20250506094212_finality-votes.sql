@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- target vote map of finalized epochs, the bitfields are indexed by validator index and zlib compressed
+CREATE TABLE IF NOT EXISTS public."epoch_finality_votes" (
+    "epoch" BIGINT NOT NULL,
+    "target_root" bytea NOT NULL,
+    "active_count" INTEGER NOT NULL,
+    "voted_count" INTEGER NOT NULL,
+    "active_balance" BIGINT NOT NULL,
+    "voted_balance" BIGINT NOT NULL,
+    "active_bits" bytea NOT NULL,
+    "voted_bits" bytea NOT NULL,
+    CONSTRAINT "epoch_finality_votes_pkey" PRIMARY KEY ("epoch")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
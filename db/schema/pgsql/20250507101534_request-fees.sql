@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- fee paid to the system contract (in wei, 0 if unknown)
+ALTER TABLE public."withdrawal_request_txs"
+    ADD "fee" BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE public."consolidation_request_txs"
+    ADD "fee" BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS "withdrawal_request_txs_block_time_idx"
+    ON public."withdrawal_request_txs"
+    ("block_time" ASC NULLS LAST);
+
+CREATE INDEX IF NOT EXISTS "consolidation_request_txs_block_time_idx"
+    ON public."consolidation_request_txs"
+    ("block_time" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
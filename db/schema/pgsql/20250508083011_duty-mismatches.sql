@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- duties reported by the connected beacon nodes that differ from the duties computed by the explorer
+CREATE TABLE IF NOT EXISTS public."duty_mismatches" (
+    "epoch" BIGINT NOT NULL,
+    "client_id" BIGINT NOT NULL,
+    "duty_type" SMALLINT NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "committee_index" BIGINT NOT NULL,
+    "client_name" VARCHAR(100) NOT NULL,
+    "dependent_root" bytea NOT NULL,
+    "expected" TEXT NOT NULL,
+    "reported" TEXT NOT NULL,
+    "detected_at" BIGINT NOT NULL,
+    CONSTRAINT duty_mismatches_pkey PRIMARY KEY ("epoch", "client_id", "duty_type", "slot", "committee_index")
+);
+
+CREATE INDEX IF NOT EXISTS "duty_mismatches_client_idx"
+    ON public."duty_mismatches"
+    ("client_id" ASC NULLS LAST, "epoch" DESC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
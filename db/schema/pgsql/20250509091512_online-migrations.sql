@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- background backfills registered by schema migrations.
+-- a migration adds the new column (nullable or with constant default, no table rewrite) and registers the backfill here,
+-- the writer instance then processes the backfill in small key range chunks without locking the table.
+CREATE TABLE IF NOT EXISTS public."online_migrations" (
+    "name" VARCHAR(100) NOT NULL,
+    "table_name" VARCHAR(100) NOT NULL,
+    "key_column" VARCHAR(100) NOT NULL,
+    "backfill_sql" TEXT NOT NULL,
+    "finalize_sql" TEXT NOT NULL DEFAULT '',
+    "chunk_size" BIGINT NOT NULL DEFAULT 10000,
+    "first_key" BIGINT NULL,
+    "next_key" BIGINT NULL,
+    "max_key" BIGINT NULL,
+    "rows_done" BIGINT NOT NULL DEFAULT 0,
+    "started_at" BIGINT NOT NULL DEFAULT 0,
+    "finished_at" BIGINT NOT NULL DEFAULT 0,
+    "last_error" TEXT NOT NULL DEFAULT '',
+    CONSTRAINT online_migrations_pkey PRIMARY KEY ("name")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
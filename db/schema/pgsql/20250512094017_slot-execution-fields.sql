@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- gas usage & base fee of the execution payload (0 for pre-merge blocks)
+ALTER TABLE public."slots"
+    ADD "eth_gas_used" bigint NOT NULL DEFAULT 0,
+    ADD "eth_gas_limit" bigint NOT NULL DEFAULT 0,
+    ADD "eth_base_fee" bigint NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
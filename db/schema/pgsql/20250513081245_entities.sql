@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- operator managed entities (staking operators, client teams, ...) that label groups of validators
+CREATE TABLE IF NOT EXISTS public."entities" (
+    "entity_id" BIGINT NOT NULL,
+    "name" VARCHAR(100) NOT NULL,
+    "description" TEXT NOT NULL DEFAULT '',
+    "links" TEXT NOT NULL DEFAULT '', -- json encoded list of links
+    "created" BIGINT NOT NULL,
+    "updated" BIGINT NOT NULL,
+    CONSTRAINT "entities_pkey" PRIMARY KEY ("entity_id")
+);
+
+-- validator index ranges of the entities
+CREATE TABLE IF NOT EXISTS public."entity_validators" (
+    "entity_id" BIGINT NOT NULL,
+    "min_index" BIGINT NOT NULL,
+    "max_index" BIGINT NOT NULL,
+    CONSTRAINT "entity_validators_pkey" PRIMARY KEY ("entity_id", "min_index")
+);
+
+-- withdrawal & deposit addresses of the entities (validators are resolved via these addresses)
+CREATE TABLE IF NOT EXISTS public."entity_addresses" (
+    "entity_id" BIGINT NOT NULL,
+    "address_type" SMALLINT NOT NULL,
+    "address" bytea NOT NULL,
+    CONSTRAINT "entity_addresses_pkey" PRIMARY KEY ("entity_id", "address_type", "address")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
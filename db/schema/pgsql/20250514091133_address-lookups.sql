@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- lookup of blocks by fee recipient (address page)
+CREATE INDEX IF NOT EXISTS "slots_eth_fee_recipient_idx"
+    ON public."slots"
+    ("eth_fee_recipient" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."slot_fingerprints" (
+    "slot" BIGINT NOT NULL,
+    "proposer" BIGINT NOT NULL,
+    "cl_client" SMALLINT NOT NULL DEFAULT -1,
+    "cl_confidence" SMALLINT NOT NULL DEFAULT 0,
+    "el_client" SMALLINT NOT NULL DEFAULT -1,
+    "el_confidence" SMALLINT NOT NULL DEFAULT 0,
+    "builder_type" SMALLINT NOT NULL DEFAULT 0,
+    "builder_confidence" SMALLINT NOT NULL DEFAULT 0,
+    "builder_name" VARCHAR(100) NOT NULL DEFAULT '',
+    "builder_pubkey" bytea NULL,
+    "sources" INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT "slot_fingerprints_pkey" PRIMARY KEY ("slot")
+);
+
+CREATE INDEX IF NOT EXISTS "slot_fingerprints_cl_client_idx"
+    ON public."slot_fingerprints"
+    ("cl_client" ASC NULLS FIRST, "cl_confidence" ASC NULLS FIRST);
+
+CREATE INDEX IF NOT EXISTS "slot_fingerprints_el_client_idx"
+    ON public."slot_fingerprints"
+    ("el_client" ASC NULLS FIRST, "el_confidence" ASC NULLS FIRST);
+
+CREATE INDEX IF NOT EXISTS "slot_fingerprints_builder_type_idx"
+    ON public."slot_fingerprints"
+    ("builder_type" ASC NULLS FIRST, "builder_confidence" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
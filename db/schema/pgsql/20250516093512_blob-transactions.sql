@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."blob_transactions" (
+    "slot" BIGINT NOT NULL,
+    "tx_index" INTEGER NOT NULL,
+    "tx_hash" bytea NOT NULL,
+    "sender" bytea NOT NULL,
+    "to_address" bytea NOT NULL,
+    "blob_count" SMALLINT NOT NULL DEFAULT 0,
+    CONSTRAINT "blob_transactions_pkey" PRIMARY KEY ("slot", "tx_index")
+);
+
+CREATE INDEX IF NOT EXISTS "blob_transactions_sender_idx"
+    ON public."blob_transactions"
+    ("sender" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
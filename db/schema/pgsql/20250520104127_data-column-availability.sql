@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."data_column_availability" (
+    "block_root" bytea NOT NULL,
+    "client_name" VARCHAR(100) NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "blob_count" SMALLINT NOT NULL DEFAULT 0,
+    "node_id" bytea NULL,
+    "custody_columns" bytea NOT NULL,
+    "available_columns" bytea NOT NULL,
+    "fetch_error" TEXT NOT NULL DEFAULT '',
+    CONSTRAINT "data_column_availability_pkey" PRIMARY KEY ("block_root", "client_name")
+);
+
+CREATE INDEX IF NOT EXISTS "data_column_availability_slot_idx"
+    ON public."data_column_availability"
+    ("slot" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
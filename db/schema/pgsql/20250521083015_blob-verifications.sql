@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."blob_verifications" (
+    "block_root" bytea NOT NULL,
+    "client_name" VARCHAR(100) NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "blob_count" SMALLINT NOT NULL DEFAULT 0,
+    "valid_count" SMALLINT NOT NULL DEFAULT 0,
+    "invalid_count" SMALLINT NOT NULL DEFAULT 0,
+    "result" TEXT NOT NULL DEFAULT '',
+    CONSTRAINT "blob_verifications_pkey" PRIMARY KEY ("block_root", "client_name")
+);
+
+CREATE INDEX IF NOT EXISTS "blob_verifications_slot_idx"
+    ON public."blob_verifications"
+    ("slot" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
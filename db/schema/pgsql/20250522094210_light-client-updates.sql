@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- best light client update of each sync committee period, as served by the connected beacon nodes
+CREATE TABLE IF NOT EXISTS public."light_client_updates" (
+    "period" BIGINT NOT NULL,
+    "client_name" VARCHAR(100) NOT NULL,
+    "version" VARCHAR(20) NOT NULL,
+    "attested_slot" BIGINT NOT NULL,
+    "attested_root" bytea NOT NULL,
+    "finalized_slot" BIGINT NOT NULL,
+    "finalized_root" bytea NOT NULL,
+    "signature_slot" BIGINT NOT NULL,
+    "participation" INTEGER NOT NULL,
+    "next_committee_root" bytea NOT NULL,
+    "status" SMALLINT NOT NULL,
+    "result" TEXT NOT NULL DEFAULT '',
+    "data" bytea NOT NULL,
+    "updated_at" BIGINT NOT NULL,
+    CONSTRAINT "light_client_updates_pkey" PRIMARY KEY ("period", "client_name")
+);
+
+-- latest light client finality & optimistic update of each sync committee period
+CREATE TABLE IF NOT EXISTS public."light_client_head_updates" (
+    "period" BIGINT NOT NULL,
+    "client_name" VARCHAR(100) NOT NULL,
+    "update_type" SMALLINT NOT NULL,
+    "version" VARCHAR(20) NOT NULL,
+    "attested_slot" BIGINT NOT NULL,
+    "attested_root" bytea NOT NULL,
+    "finalized_slot" BIGINT NOT NULL,
+    "finalized_root" bytea NOT NULL,
+    "signature_slot" BIGINT NOT NULL,
+    "participation" INTEGER NOT NULL,
+    "status" SMALLINT NOT NULL,
+    "result" TEXT NOT NULL DEFAULT '',
+    "data" bytea NOT NULL,
+    "updated_at" BIGINT NOT NULL,
+    CONSTRAINT "light_client_head_updates_pkey" PRIMARY KEY ("period", "client_name", "update_type")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
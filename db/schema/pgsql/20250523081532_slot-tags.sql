@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- config defined block tags (graffiti / extra data rules), evaluated when the block gets indexed
+CREATE TABLE IF NOT EXISTS public."slot_tags" (
+    "root" bytea NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "tag" VARCHAR(100) NOT NULL,
+    CONSTRAINT "slot_tags_pkey" PRIMARY KEY ("root", "tag")
+);
+
+CREATE INDEX IF NOT EXISTS "slot_tags_tag_slot_idx"
+    ON public."slot_tags"
+    ("tag" ASC NULLS FIRST, "slot" ASC NULLS FIRST);
+
+CREATE INDEX IF NOT EXISTS "slot_tags_slot_idx"
+    ON public."slot_tags"
+    ("slot" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
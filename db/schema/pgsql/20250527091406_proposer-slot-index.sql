@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- blocks of a proposer are listed newest first, the combined index avoids scanning all blocks of the proposer for sorting
+CREATE INDEX IF NOT EXISTS "slots_proposer_slot_idx"
+    ON public."slots"
+    ("proposer" ASC NULLS LAST, "slot" DESC NULLS LAST);
+
+DROP INDEX IF EXISTS "slots_proposer_idx";
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
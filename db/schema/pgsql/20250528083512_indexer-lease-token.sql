@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- fencing token, incremented whenever the lease is taken over by another holder
+ALTER TABLE public."indexer_leases"
+    ADD "token" BIGINT NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- epochs imported from era files have no duties & vote aggregations, the synchronizer revisits them once a client can serve their states
+ALTER TABLE public."epochs"
+    ADD "imported" BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS "epochs_imported_idx"
+    ON public."epochs"
+    ("epoch" ASC NULLS FIRST)
+    WHERE "imported";
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "genesis_validators" (
+    "validator_index" BIGINT NOT NULL,
+    "pubkey" BLOB NOT NULL,
+    "withdrawal_credentials" BLOB NOT NULL,
+    "balance" BIGINT NOT NULL,
+    "entity" VARCHAR(100) NOT NULL DEFAULT '',
+    CONSTRAINT "genesis_validators_pkey" PRIMARY KEY ("validator_index")
+);
+
+CREATE INDEX IF NOT EXISTS "genesis_validators_pubkey_idx"
+    ON "genesis_validators"
+    ("pubkey" ASC);
+
+CREATE INDEX IF NOT EXISTS "genesis_validators_entity_idx"
+    ON "genesis_validators"
+    ("entity" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
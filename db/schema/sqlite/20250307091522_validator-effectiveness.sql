@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "validator_effectiveness" (
+    "day" INT NOT NULL,
+    "validator_index" BIGINT NOT NULL,
+    "effectiveness" INT NOT NULL,
+    "epochs" INT NOT NULL,
+    "top_percent" INT NOT NULL,
+    CONSTRAINT "validator_effectiveness_pkey" PRIMARY KEY ("validator_index", "day")
+);
+
+CREATE INDEX IF NOT EXISTS "validator_effectiveness_day_idx"
+    ON "validator_effectiveness"
+    ("day" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
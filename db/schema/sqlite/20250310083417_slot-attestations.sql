@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "slot_attestations" (
+    "slot_root" bytea NOT NULL,
+    "slot_index" INT NOT NULL,
+    "slot_number" BIGINT NOT NULL,
+    "orphaned" bool NOT NULL DEFAULT FALSE,
+    "att_slot" BIGINT NOT NULL,
+    "committee_index" BIGINT NOT NULL,
+    "committee_bits" bytea NULL,
+    "aggregation_bits" bytea NOT NULL,
+    "beacon_block_root" bytea NOT NULL,
+    "source_epoch" BIGINT NOT NULL,
+    "source_root" bytea NOT NULL,
+    "target_epoch" BIGINT NOT NULL,
+    "target_root" bytea NOT NULL,
+    "signature" bytea NOT NULL,
+    CONSTRAINT "slot_attestations_pkey" PRIMARY KEY ("slot_root", "slot_index")
+);
+
+CREATE INDEX IF NOT EXISTS "slot_attestations_slot_number_idx"
+    ON "slot_attestations"
+    ("slot_number" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- index for graffiti aggregations (leaderboard)
+CREATE INDEX IF NOT EXISTS "slots_graffiti_text_slot_idx"
+    ON "slots"
+    ("graffiti_text" ASC, "slot" ASC)
+    WHERE "status" = 1;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- guessed proposing clients (0 = not classified, -1 = unknown client)
+ALTER TABLE "slots"
+    ADD "cl_client" TINYINT NOT NULL DEFAULT 0;
+
+ALTER TABLE "slots"
+    ADD "el_client" TINYINT NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
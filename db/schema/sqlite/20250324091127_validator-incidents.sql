@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "validator_incidents" (
+    "entity" TEXT NOT NULL,
+    "start_epoch" BIGINT NOT NULL,
+    "end_epoch" BIGINT NOT NULL,
+    "validators" INT NOT NULL,
+    "missed_attestations" BIGINT NOT NULL,
+    "missed_proposals" BIGINT NOT NULL,
+    "estimated_loss" BIGINT NOT NULL,
+    "resolved" bool NOT NULL DEFAULT FALSE,
+    CONSTRAINT "validator_incidents_pkey" PRIMARY KEY ("entity", "start_epoch")
+);
+
+CREATE INDEX IF NOT EXISTS "validator_incidents_start_epoch_idx"
+    ON "validator_incidents"
+    ("start_epoch" DESC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
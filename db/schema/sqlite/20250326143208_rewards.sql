@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "epoch_rewards" (
+    "epoch" BIGINT NOT NULL,
+    "validators" INT NOT NULL,
+    "attestation_head" BIGINT NOT NULL,
+    "attestation_target" BIGINT NOT NULL,
+    "attestation_source" BIGINT NOT NULL,
+    "attestation_inclusion_delay" BIGINT NOT NULL,
+    "attestation_inactivity" BIGINT NOT NULL,
+    "sync_committee" BIGINT NOT NULL,
+    "proposer" BIGINT NOT NULL,
+    CONSTRAINT "epoch_rewards_pkey" PRIMARY KEY ("epoch")
+);
+
+CREATE TABLE IF NOT EXISTS "block_rewards" (
+    "slot" BIGINT NOT NULL,
+    "proposer" BIGINT NOT NULL,
+    "total" BIGINT NOT NULL,
+    "attestations" BIGINT NOT NULL,
+    "sync_aggregate" BIGINT NOT NULL,
+    "proposer_slashings" BIGINT NOT NULL,
+    "attester_slashings" BIGINT NOT NULL,
+    CONSTRAINT "block_rewards_pkey" PRIMARY KEY ("slot")
+);
+
+CREATE TABLE IF NOT EXISTS "validator_rewards" (
+    "validator_index" BIGINT NOT NULL,
+    "day" BIGINT NOT NULL,
+    "epochs" INT NOT NULL,
+    "attestation" BIGINT NOT NULL,
+    "sync_committee" BIGINT NOT NULL,
+    "proposer" BIGINT NOT NULL,
+    CONSTRAINT "validator_rewards_pkey" PRIMARY KEY ("validator_index", "day")
+);
+
+CREATE INDEX IF NOT EXISTS "validator_rewards_day_idx"
+    ON "validator_rewards"
+    ("day" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
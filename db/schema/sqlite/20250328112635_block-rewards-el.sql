@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "block_rewards"
+ADD "el_fees" BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE "block_rewards"
+ADD "mev_payment" BIGINT NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- unexpected changes of watchlisted validators (type: 1 = fee recipient, 2 = withdrawal credentials, 3 = graffiti)
+CREATE TABLE IF NOT EXISTS "validator_anomalies" (
+    "validator_index" BIGINT NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "type" smallint NOT NULL,
+    "before_value" BLOB NULL,
+    "after_value" BLOB NULL,
+    CONSTRAINT "validator_anomalies_pkey" PRIMARY KEY ("validator_index", "slot", "type")
+);
+
+CREATE INDEX IF NOT EXISTS "validator_anomalies_slot_idx"
+    ON "validator_anomalies"
+    ("slot" DESC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
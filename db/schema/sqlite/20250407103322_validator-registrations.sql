@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- latest validator registration per validator as reported by the configured relays
+-- (seenby_relays: bitmask of the relays that returned this registration)
+CREATE TABLE IF NOT EXISTS "validator_registrations" (
+    "validator_index" BIGINT NOT NULL,
+    "fee_recipient" BLOB NOT NULL,
+    "gas_limit" BIGINT NOT NULL,
+    "timestamp" BIGINT NOT NULL,
+    "seenby_relays" BIGINT NOT NULL,
+    CONSTRAINT "validator_registrations_pkey" PRIMARY KEY ("validator_index")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- arrival delays (ms after slot start) of finalized canonical blocks, as announced by the connected clients
+CREATE TABLE IF NOT EXISTS "block_arrivals" (
+    "slot" BIGINT NOT NULL,
+    "proposer" BIGINT NOT NULL,
+    "seen_by" INT NOT NULL,
+    "min_delay" BIGINT NOT NULL,
+    "median_delay" BIGINT NOT NULL,
+    "max_delay" BIGINT NOT NULL,
+    CONSTRAINT "block_arrivals_pkey" PRIMARY KEY ("slot")
+);
+
+CREATE INDEX IF NOT EXISTS "block_arrivals_proposer_idx"
+    ON "block_arrivals"
+    ("proposer" ASC, "slot" DESC);
+
+-- arrival delay of the finalized canonical blocks per client
+CREATE TABLE IF NOT EXISTS "block_arrival_clients" (
+    "slot" BIGINT NOT NULL,
+    "client" VARCHAR(100) NOT NULL,
+    "delay" BIGINT NOT NULL,
+    CONSTRAINT "block_arrival_clients_pkey" PRIMARY KEY ("slot", "client")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
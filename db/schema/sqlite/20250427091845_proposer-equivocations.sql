@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- conflicting blocks published by the same proposer for the same slot (block_root_1 < block_root_2)
+CREATE TABLE IF NOT EXISTS "proposer_equivocations" (
+    "slot" BIGINT NOT NULL,
+    "proposer" BIGINT NOT NULL,
+    "block_root_1" BLOB NOT NULL,
+    "block_root_2" BLOB NOT NULL,
+    CONSTRAINT "proposer_equivocations_pkey" PRIMARY KEY ("slot", "block_root_1", "block_root_2")
+);
+
+CREATE INDEX IF NOT EXISTS "proposer_equivocations_proposer_idx"
+    ON "proposer_equivocations"
+    ("proposer" ASC, "slot" DESC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- execution layer fee burn & consensus layer issuance per finalized epoch (in gwei)
+CREATE TABLE IF NOT EXISTS "supply_epochs" (
+    "epoch" BIGINT NOT NULL,
+    "day" BIGINT NOT NULL,
+    "blocks" INT NOT NULL,
+    "issued" BIGINT NOT NULL,
+    "burned" BIGINT NOT NULL,
+    CONSTRAINT "supply_epochs_pkey" PRIMARY KEY ("epoch")
+);
+
+CREATE INDEX IF NOT EXISTS "supply_epochs_day_idx"
+    ON "supply_epochs"
+    ("day" ASC);
+
+-- daily rollup of supply_epochs
+CREATE TABLE IF NOT EXISTS "supply_days" (
+    "day" BIGINT NOT NULL,
+    "epochs" INT NOT NULL,
+    "blocks" INT NOT NULL,
+    "issued" BIGINT NOT NULL,
+    "burned" BIGINT NOT NULL,
+    CONSTRAINT "supply_days_pkey" PRIMARY KEY ("day")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- missed & late attestations per entity and finalized epoch, attributed to late blocks or the attester itself
+CREATE TABLE IF NOT EXISTS "attestation_misses" (
+    "epoch" BIGINT NOT NULL,
+    "entity" TEXT NOT NULL,
+    "missed_late_block" INTEGER NOT NULL,
+    "missed_attester" INTEGER NOT NULL,
+    "delayed_late_block" INTEGER NOT NULL,
+    "delayed_attester" INTEGER NOT NULL,
+    CONSTRAINT "attestation_misses_pkey" PRIMARY KEY ("epoch", "entity")
+);
+
+CREATE INDEX IF NOT EXISTS "attestation_misses_entity_idx"
+    ON "attestation_misses"
+    ("entity" ASC, "epoch" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- configured consensus (layer 1) & execution (layer 2) clients with a stable id that survives restarts & renames
+CREATE TABLE IF NOT EXISTS "pool_clients" (
+    "client_id" BIGINT NOT NULL,
+    "layer" SMALLINT NOT NULL,
+    "name" VARCHAR(100) NOT NULL,
+    "client_type" SMALLINT NOT NULL,
+    "fingerprint" BLOB NOT NULL,
+    "first_seen" BIGINT NOT NULL,
+    "last_seen" BIGINT NOT NULL,
+    CONSTRAINT "pool_clients_pkey" PRIMARY KEY ("client_id")
+);
+
+CREATE INDEX IF NOT EXISTS "pool_clients_fingerprint_idx"
+    ON "pool_clients"
+    ("fingerprint");
+
+-- version history of the pool clients
+CREATE TABLE IF NOT EXISTS "pool_client_versions" (
+    "client_id" BIGINT NOT NULL,
+    "version" VARCHAR(200) NOT NULL,
+    "first_seen" BIGINT NOT NULL,
+    "last_seen" BIGINT NOT NULL,
+    CONSTRAINT "pool_client_versions_pkey" PRIMARY KEY ("client_id", "version")
+);
+
+-- stable id of the announcing client (0 for arrivals recorded before the client ids were introduced)
+ALTER TABLE "block_arrival_clients"
+ADD "client_id" BIGINT NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- execution payload verdicts of the connected clients for finalized blocks (only blocks with non-valid verdicts are stored)
+CREATE TABLE IF NOT EXISTS "block_payload_statuses" (
+    "root" BLOB NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "client_id" BIGINT NOT NULL,
+    "client_name" VARCHAR(100) NOT NULL,
+    "status" SMALLINT NOT NULL,
+    CONSTRAINT block_payload_statuses_pkey PRIMARY KEY ("root", "client_id")
+);
+
+CREATE INDEX IF NOT EXISTS "block_payload_statuses_slot_idx"
+    ON "block_payload_statuses"
+    ("slot" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- effective balance changes of the validators, recorded when the change is finalized
+CREATE TABLE IF NOT EXISTS "effective_balance_changes" (
+    "validator_index" BIGINT NOT NULL,
+    "epoch" BIGINT NOT NULL,
+    "old_effective_balance" BIGINT NOT NULL,
+    "new_effective_balance" BIGINT NOT NULL,
+    "compounding" BOOLEAN NOT NULL DEFAULT FALSE,
+    CONSTRAINT effective_balance_changes_pkey PRIMARY KEY ("validator_index", "epoch")
+);
+
+CREATE INDEX IF NOT EXISTS "effective_balance_changes_epoch_idx"
+    ON "effective_balance_changes"
+    ("epoch" ASC);
+
+-- effective balance distribution of the active validators per finalized epoch
+CREATE TABLE IF NOT EXISTS "effective_balance_stats" (
+    "epoch" BIGINT NOT NULL,
+    "bucket" BIGINT NOT NULL,
+    "validators" BIGINT NOT NULL,
+    "compounding" BIGINT NOT NULL,
+    "effective_balance" BIGINT NOT NULL,
+    CONSTRAINT effective_balance_stats_pkey PRIMARY KEY ("epoch", "bucket")
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
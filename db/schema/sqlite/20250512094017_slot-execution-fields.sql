@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- gas usage & base fee of the execution payload (0 for pre-merge blocks)
+ALTER TABLE "slots"
+    ADD "eth_gas_used" BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE "slots"
+    ADD "eth_gas_limit" BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE "slots"
+    ADD "eth_base_fee" BIGINT NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- config defined block tags (graffiti / extra data rules), evaluated when the block gets indexed
+CREATE TABLE IF NOT EXISTS "slot_tags" (
+    "root" BLOB NOT NULL,
+    "slot" BIGINT NOT NULL,
+    "tag" VARCHAR(100) NOT NULL,
+    CONSTRAINT "slot_tags_pkey" PRIMARY KEY ("root", "tag")
+);
+
+CREATE INDEX IF NOT EXISTS "slot_tags_tag_slot_idx"
+    ON "slot_tags"
+    ("tag" ASC, "slot" ASC);
+
+CREATE INDEX IF NOT EXISTS "slot_tags_slot_idx"
+    ON "slot_tags"
+    ("slot" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
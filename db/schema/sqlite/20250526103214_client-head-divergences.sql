@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "client_head_divergences" (
+    "client_name" TEXT NOT NULL,
+    "start_slot" BIGINT NOT NULL,
+    "end_slot" BIGINT NOT NULL,
+    "reason" TEXT NOT NULL,
+    "head_slot" BIGINT NOT NULL,
+    "head_root" BLOB NOT NULL,
+    "canonical_slot" BIGINT NOT NULL,
+    "canonical_root" BLOB NOT NULL,
+    "finalized_epoch" BIGINT NOT NULL,
+    "max_distance" BIGINT NOT NULL,
+    "resolved" bool NOT NULL DEFAULT FALSE,
+    CONSTRAINT "client_head_divergences_pkey" PRIMARY KEY ("client_name", "start_slot")
+);
+
+CREATE INDEX IF NOT EXISTS "client_head_divergences_start_slot_idx"
+    ON "client_head_divergences"
+    ("start_slot" DESC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,133 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertSlotAttestations inserts the attestations included in a block
+func InsertSlotAttestations(attestations []*dbtypes.SlotAttestation, tx *sqlx.Tx) error {
+	if len(attestations) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO slot_attestations ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO slot_attestations ",
+		}),
+		"(slot_root, slot_index, slot_number, orphaned, att_slot, committee_index, committee_bits, aggregation_bits, beacon_block_root, source_epoch, source_root, target_epoch, target_root, signature)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 14
+
+	args := make([]any, len(attestations)*fieldCount)
+	for i, attestation := range attestations {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = attestation.SlotRoot
+		args[argIdx+1] = attestation.SlotIndex
+		args[argIdx+2] = attestation.SlotNumber
+		args[argIdx+3] = attestation.Orphaned
+		args[argIdx+4] = attestation.AttSlot
+		args[argIdx+5] = attestation.CommitteeIndex
+		args[argIdx+6] = attestation.CommitteeBits
+		args[argIdx+7] = attestation.AggregationBits
+		args[argIdx+8] = attestation.BeaconBlockRoot
+		args[argIdx+9] = attestation.SourceEpoch
+		args[argIdx+10] = attestation.SourceRoot
+		args[argIdx+11] = attestation.TargetEpoch
+		args[argIdx+12] = attestation.TargetRoot
+		args[argIdx+13] = attestation.Signature
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (slot_root, slot_index) DO UPDATE SET orphaned = excluded.orphaned",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSlotAttestations returns a page of the attestations included in a block and the total number of stored attestations for the block
+func GetSlotAttestations(slotRoot []byte, offset uint64, limit uint32) ([]*dbtypes.SlotAttestation, uint64, error) {
+	var totalCount uint64
+	err := ReaderDb.Get(&totalCount, `SELECT COUNT(*) FROM slot_attestations WHERE slot_root = $1`, slotRoot)
+	if err != nil {
+		logger.Errorf("Error while counting slot attestations: %v", err)
+		return nil, 0, err
+	}
+	if totalCount == 0 {
+		return []*dbtypes.SlotAttestation{}, 0, nil
+	}
+
+	attestations := []*dbtypes.SlotAttestation{}
+	err = ReaderDb.Select(&attestations, `
+		SELECT
+			slot_root, slot_index, slot_number, orphaned, att_slot, committee_index, committee_bits, aggregation_bits,
+			beacon_block_root, source_epoch, source_root, target_epoch, target_root, signature
+		FROM slot_attestations
+		WHERE slot_root = $1
+		ORDER BY slot_index ASC
+		LIMIT $2 OFFSET $3
+	`, slotRoot, limit, offset)
+	if err != nil {
+		logger.Errorf("Error while fetching slot attestations: %v", err)
+		return nil, 0, err
+	}
+	return attestations, totalCount, nil
+}
+
+// GetCanonicalAttestationsByAttSlot returns the committee & aggregation bits of all canonical attestations voting for slots in the given range.
+// only attestations included up to maxInclusionSlot are returned.
+func GetCanonicalAttestationsByAttSlot(minAttSlot uint64, maxAttSlot uint64, maxInclusionSlot uint64) ([]*dbtypes.SlotAttestation, error) {
+	attestations := []*dbtypes.SlotAttestation{}
+	err := ReaderDb.Select(&attestations, `
+		SELECT att_slot, committee_index, committee_bits, aggregation_bits
+		FROM slot_attestations
+		WHERE slot_number > $1 AND slot_number <= $2 AND att_slot >= $1 AND att_slot <= $3 AND orphaned = false
+	`, minAttSlot, maxInclusionSlot, maxAttSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching attestations by att slot: %v", err)
+		return nil, err
+	}
+	return attestations, nil
+}
+
+// GetSlotAttestationsByAttSlot returns all stored attestations (including orphaned ones) voting for the given slot,
+// included in blocks up to maxInclusionSlot, ordered by inclusion.
+func GetSlotAttestationsByAttSlot(attSlot uint64, maxInclusionSlot uint64) ([]*dbtypes.SlotAttestation, error) {
+	attestations := []*dbtypes.SlotAttestation{}
+	err := ReaderDb.Select(&attestations, `
+		SELECT
+			slot_root, slot_index, slot_number, orphaned, att_slot, committee_index, committee_bits, aggregation_bits,
+			beacon_block_root, source_epoch, source_root, target_epoch, target_root, signature
+		FROM slot_attestations
+		WHERE slot_number > $1 AND slot_number <= $2 AND att_slot = $1
+		ORDER BY slot_number ASC, slot_index ASC
+	`, attSlot, maxInclusionSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching attestations by att slot: %v", err)
+		return nil, err
+	}
+	return attestations, nil
+}
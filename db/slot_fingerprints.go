@@ -0,0 +1,115 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertSlotFingerprints(fingerprints []*dbtypes.SlotFingerprint, tx *sqlx.Tx) error {
+	if len(fingerprints) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO slot_fingerprints ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO slot_fingerprints ",
+		}),
+		"(slot, proposer, cl_client, cl_confidence, el_client, el_confidence, builder_type, builder_confidence, builder_name, builder_pubkey, sources)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 11
+
+	args := make([]any, len(fingerprints)*fieldCount)
+	for i, fingerprint := range fingerprints {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = fingerprint.Slot
+		args[argIdx+1] = fingerprint.Proposer
+		args[argIdx+2] = fingerprint.ClClient
+		args[argIdx+3] = fingerprint.ClConfidence
+		args[argIdx+4] = fingerprint.ElClient
+		args[argIdx+5] = fingerprint.ElConfidence
+		args[argIdx+6] = fingerprint.BuilderType
+		args[argIdx+7] = fingerprint.BuilderConfidence
+		args[argIdx+8] = fingerprint.BuilderName
+		args[argIdx+9] = fingerprint.BuilderPubkey
+		args[argIdx+10] = fingerprint.Sources
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: " ON CONFLICT (slot) DO UPDATE SET proposer = excluded.proposer, cl_client = excluded.cl_client, cl_confidence = excluded.cl_confidence, " +
+			"el_client = excluded.el_client, el_confidence = excluded.el_confidence, builder_type = excluded.builder_type, builder_confidence = excluded.builder_confidence, " +
+			"builder_name = excluded.builder_name, builder_pubkey = excluded.builder_pubkey, sources = excluded.sources",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetSlotFingerprint(slot uint64) *dbtypes.SlotFingerprint {
+	fingerprint := dbtypes.SlotFingerprint{}
+	err := ReaderDb.Get(&fingerprint, `
+		SELECT slot, proposer, cl_client, cl_confidence, el_client, el_confidence, builder_type, builder_confidence, builder_name, builder_pubkey, sources
+		FROM slot_fingerprints
+		WHERE slot = $1
+	`, slot)
+	if err != nil {
+		return nil
+	}
+	return &fingerprint
+}
+
+// GetSlotFingerprintsBySlots returns the fingerprints of the given slots, slots without fingerprint are skipped.
+func GetSlotFingerprintsBySlots(slots []uint64) map[uint64]*dbtypes.SlotFingerprint {
+	fingerprintMap := map[uint64]*dbtypes.SlotFingerprint{}
+	if len(slots) == 0 {
+		return fingerprintMap
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql, `
+		SELECT slot, proposer, cl_client, cl_confidence, el_client, el_confidence, builder_type, builder_confidence, builder_name, builder_pubkey, sources
+		FROM slot_fingerprints
+		WHERE slot IN (`)
+	args := make([]any, len(slots))
+	for i, slot := range slots {
+		if i > 0 {
+			fmt.Fprint(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "$%v", i+1)
+		args[i] = slot
+	}
+	fmt.Fprint(&sql, ")")
+
+	fingerprints := []*dbtypes.SlotFingerprint{}
+	err := ReaderDb.Select(&fingerprints, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching slot fingerprints: %v", err)
+		return fingerprintMap
+	}
+
+	for _, fingerprint := range fingerprints {
+		fingerprintMap[fingerprint.Slot] = fingerprint
+	}
+	return fingerprintMap
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertSlotTags inserts the config defined tags of a block, existing tags are kept
+func InsertSlotTags(tags []*dbtypes.SlotTag, tx *sqlx.Tx) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO slot_tags ",
+			dbtypes.DBEngineSqlite: "INSERT OR IGNORE INTO slot_tags ",
+		}),
+		"(root, slot, tag)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 3
+
+	args := make([]any, len(tags)*fieldCount)
+	for i, tag := range tags {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = tag.Root
+		args[argIdx+1] = tag.Slot
+		args[argIdx+2] = tag.Tag
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (root, tag) DO NOTHING",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSlotTagsByRoots returns the tags of the given blocks
+func GetSlotTagsByRoots(roots [][]byte) []*dbtypes.SlotTag {
+	tags := []*dbtypes.SlotTag{}
+	if len(roots) == 0 {
+		return tags
+	}
+
+	var sql strings.Builder
+	args := make([]any, len(roots))
+
+	fmt.Fprint(&sql, `SELECT root, slot, tag FROM slot_tags WHERE root IN (`)
+	for i, root := range roots {
+		if i > 0 {
+			fmt.Fprint(&sql, ",")
+		}
+		args[i] = root
+		fmt.Fprintf(&sql, "$%v", i+1)
+	}
+	fmt.Fprint(&sql, `) ORDER BY slot DESC, tag ASC`)
+
+	err := ReaderDb.Select(&tags, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching slot tags: %v", err)
+		return nil
+	}
+
+	return tags
+}
+
+// GetSlotTagStats returns the canonical block counts grouped by tag.
+// blocks are aggregated in buckets of slotsPerBucket slots (bucket = slot / slotsPerBucket), starting from minSlot.
+// the total number of canonical blocks per bucket is returned with an empty tag.
+func GetSlotTagStats(minSlot uint64, slotsPerBucket uint64) ([]*dbtypes.SlotTagStats, error) {
+	if slotsPerBucket == 0 {
+		slotsPerBucket = 1
+	}
+
+	stats := []*dbtypes.SlotTagStats{}
+	err := ReaderDb.Select(&stats, `
+		SELECT slots.slot / $1 AS bucket, slot_tags.tag AS tag, COUNT(*) AS blocks
+		FROM slot_tags
+		JOIN slots ON slots.root = slot_tags.root
+		WHERE slot_tags.slot >= $2 AND slots.status = $3
+		GROUP BY bucket, slot_tags.tag
+		UNION ALL
+		SELECT slot / $1 AS bucket, '' AS tag, COUNT(*) AS blocks
+		FROM slots
+		WHERE slot >= $2 AND status = $3
+		GROUP BY bucket
+		ORDER BY bucket ASC`, slotsPerBucket, minSlot, dbtypes.Canonical)
+	if err != nil {
+		logger.Errorf("Error while fetching slot tag stats: %v", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetSlotTagNames returns all distinct tags that have been assigned to blocks
+func GetSlotTagNames() []string {
+	tags := []string{}
+	err := ReaderDb.Select(&tags, `SELECT DISTINCT tag FROM slot_tags ORDER BY tag ASC`)
+	if err != nil {
+		logger.Errorf("Error while fetching slot tag names: %v", err)
+		return nil
+	}
+
+	return tags
+}
@@ -19,25 +19,34 @@ func InsertSlot(slot *dbtypes.Slot, tx *sqlx.Tx) error {
 				slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 				proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, 
-				eth_block_extra, eth_block_extra_text, sync_participation, fork_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+				eth_block_extra, eth_block_extra_text, sync_participation, fork_id, cl_client, el_client, eth_fee_recipient,
+				eth_gas_used, eth_gas_limit, eth_base_fee
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29)
 			ON CONFLICT (slot, root) DO UPDATE SET
 				status = excluded.status,
 				eth_block_extra = excluded.eth_block_extra,
 				eth_block_extra_text = excluded.eth_block_extra_text,
-				fork_id = excluded.fork_id`,
+				fork_id = excluded.fork_id,
+				cl_client = excluded.cl_client,
+				el_client = excluded.el_client,
+				eth_fee_recipient = excluded.eth_fee_recipient,
+				eth_gas_used = excluded.eth_gas_used,
+				eth_gas_limit = excluded.eth_gas_limit,
+				eth_base_fee = excluded.eth_base_fee`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR REPLACE INTO slots (
 				slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 				proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, 
-				eth_block_extra, eth_block_extra_text, sync_participation, fork_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`,
+				eth_block_extra, eth_block_extra_text, sync_participation, fork_id, cl_client, el_client, eth_fee_recipient,
+				eth_gas_used, eth_gas_limit, eth_base_fee
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29)`,
 	}),
 		slot.Slot, slot.Proposer, slot.Status, slot.Root, slot.ParentRoot, slot.StateRoot, slot.Graffiti, slot.GraffitiText,
 		slot.AttestationCount, slot.DepositCount, slot.ExitCount, slot.WithdrawCount, slot.WithdrawAmount, slot.AttesterSlashingCount,
 		slot.ProposerSlashingCount, slot.BLSChangeCount, slot.EthTransactionCount, slot.EthBlockNumber, slot.EthBlockHash,
-		slot.EthBlockExtra, slot.EthBlockExtraText, slot.SyncParticipation, slot.ForkId)
+		slot.EthBlockExtra, slot.EthBlockExtraText, slot.SyncParticipation, slot.ForkId, slot.ClClient, slot.ElClient, slot.EthFeeRecipient,
+		slot.EthGasUsed, slot.EthGasLimit, slot.EthBaseFee)
 	if err != nil {
 		return err
 	}
@@ -92,7 +101,8 @@ func GetSlotsRange(firstSlot uint64, lastSlot uint64, withMissing bool, withOrph
 		"state_root", "root", "slot", "proposer", "status", "parent_root", "graffiti", "graffiti_text",
 		"attestation_count", "deposit_count", "exit_count", "withdraw_count", "withdraw_amount", "attester_slashing_count",
 		"proposer_slashing_count", "bls_change_count", "eth_transaction_count", "eth_block_number", "eth_block_hash",
-		"eth_block_extra", "eth_block_extra_text", "sync_participation", "fork_id",
+		"eth_block_extra", "eth_block_extra_text", "sync_participation", "fork_id", "cl_client", "el_client", "eth_fee_recipient",
+		"eth_gas_used", "eth_gas_limit", "eth_base_fee",
 	}
 	for _, blockField := range blockFields {
 		fmt.Fprintf(&sql, ", slots.%v AS \"block.%v\"", blockField, blockField)
@@ -124,7 +134,8 @@ func GetSlotsByParentRoot(parentRoot []byte) []*dbtypes.Slot {
 		slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, 
-		eth_block_extra, eth_block_extra_text, sync_participation, fork_id
+		eth_block_extra, eth_block_extra_text, sync_participation, fork_id, cl_client, el_client, eth_fee_recipient,
+		eth_gas_used, eth_gas_limit, eth_base_fee
 	FROM slots
 	WHERE parent_root = $1
 	ORDER BY slot DESC
@@ -143,7 +154,8 @@ func GetSlotByRoot(root []byte) *dbtypes.Slot {
 		root, slot, parent_root, state_root, status, proposer, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash,
-		eth_block_extra, eth_block_extra_text, sync_participation, fork_id
+		eth_block_extra, eth_block_extra_text, sync_participation, fork_id, cl_client, el_client, eth_fee_recipient,
+		eth_gas_used, eth_gas_limit, eth_base_fee
 	FROM slots
 	WHERE root = $1
 	`, root)
@@ -169,7 +181,8 @@ func GetSlotsByRoots(roots [][]byte) map[phase0.Root]*dbtypes.Slot {
 			root, slot, parent_root, state_root, status, proposer, graffiti, graffiti_text,
 			attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 			proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash,
-			eth_block_extra, eth_block_extra_text, sync_participation, fork_id
+			eth_block_extra, eth_block_extra_text, sync_participation, fork_id, cl_client, el_client, eth_fee_recipient,
+			eth_gas_used, eth_gas_limit, eth_base_fee
 		FROM slots
 		WHERE root IN (%v)
 		ORDER BY slot DESC`,
@@ -191,37 +204,51 @@ func GetSlotsByRoots(roots [][]byte) map[phase0.Root]*dbtypes.Slot {
 	return slotMap
 }
 
-func GetBlockHeadByRoot(root []byte) *dbtypes.BlockHead {
-	blockHead := dbtypes.BlockHead{}
-	err := ReaderDb.Get(&blockHead, `
+// GetSlotsByExecutionBlock returns all blocks that include the execution payload with the given block number or block hash.
+func GetSlotsByExecutionBlock(blockNumber *uint64, blockHash []byte) []*dbtypes.Slot {
+	var sql strings.Builder
+	args := []any{}
+	fmt.Fprint(&sql, `
 	SELECT
-		root, slot, parent_root, fork_id
+		root, slot, parent_root, state_root, status, proposer, graffiti, graffiti_text,
+		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
+		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash,
+		eth_block_extra, eth_block_extra_text, sync_participation, fork_id, cl_client, el_client, eth_fee_recipient,
+		eth_gas_used, eth_gas_limit, eth_base_fee
 	FROM slots
-	WHERE root = $1
-	`, root)
+	`)
+	if blockHash != nil {
+		args = append(args, blockHash)
+		fmt.Fprintf(&sql, " WHERE eth_block_hash = $%v", len(args))
+	} else if blockNumber != nil {
+		args = append(args, *blockNumber)
+		fmt.Fprintf(&sql, " WHERE eth_block_number = $%v", len(args))
+	} else {
+		return nil
+	}
+	fmt.Fprint(&sql, " ORDER BY status ASC, slot DESC")
+
+	slots := []*dbtypes.Slot{}
+	err := ReaderDb.Select(&slots, sql.String(), args...)
 	if err != nil {
+		logger.Errorf("Error while fetching slots by execution block: %v", err)
 		return nil
 	}
-	return &blockHead
+	return slots
 }
 
-func GetSlotsByBlockHash(blockHash []byte) []*dbtypes.Slot {
-	slots := []*dbtypes.Slot{}
-	err := ReaderDb.Select(&slots, `
+func GetBlockHeadByRoot(root []byte) *dbtypes.BlockHead {
+	blockHead := dbtypes.BlockHead{}
+	err := ReaderDb.Get(&blockHead, `
 	SELECT
-		slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
-		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
-		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, 
-		eth_block_extra, eth_block_extra_text, sync_participation, fork_id
+		root, slot, parent_root, fork_id
 	FROM slots
-	WHERE eth_block_hash = $1
-	ORDER BY slot DESC
-	`, blockHash)
+	WHERE root = $1
+	`, root)
 	if err != nil {
-		logger.Errorf("Error while fetching slots by block hash: %v", err)
 		return nil
 	}
-	return slots
+	return &blockHead
 }
 
 func parseAssignedSlots(rows *sql.Rows, fields []string, fieldsOffset int) []*dbtypes.AssignedSlot {
@@ -272,7 +299,8 @@ func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint
 		"state_root", "root", "slot", "proposer", "status", "parent_root", "graffiti", "graffiti_text",
 		"attestation_count", "deposit_count", "exit_count", "withdraw_count", "withdraw_amount", "attester_slashing_count",
 		"proposer_slashing_count", "bls_change_count", "eth_transaction_count", "eth_block_number", "eth_block_hash",
-		"eth_block_extra", "eth_block_extra_text", "sync_participation", "fork_id",
+		"eth_block_extra", "eth_block_extra_text", "sync_participation", "fork_id", "cl_client", "el_client", "eth_fee_recipient",
+		"eth_gas_used", "eth_gas_limit", "eth_base_fee",
 	}
 	for _, blockField := range blockFields {
 		fmt.Fprintf(&sql, ", slots.%v AS \"block.%v\"", blockField, blockField)
@@ -281,6 +309,11 @@ func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint
 	if filter.ProposerName != "" {
 		fmt.Fprintf(&sql, ` LEFT JOIN validator_names ON validator_names."index" = slots.proposer `)
 	}
+	withFingerprintFilter := filter.ClClient != 0 || filter.ElClient != 0 || filter.BuilderType != 0
+	if withFingerprintFilter {
+		// fingerprints are only available for canonical blocks
+		fmt.Fprintf(&sql, ` INNER JOIN slot_fingerprints ON slot_fingerprints.slot = slots.slot AND slots.status = 1 `)
+	}
 
 	argIdx := 0
 	args := make([]any, 0)
@@ -289,6 +322,22 @@ func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint
 	fmt.Fprintf(&sql, ` WHERE slots.slot < $%v `, argIdx)
 	args = append(args, firstSlot)
 
+	if filter.ClClient != 0 {
+		argIdx += 2
+		fmt.Fprintf(&sql, ` AND slot_fingerprints.cl_client = $%v AND slot_fingerprints.cl_confidence >= $%v `, argIdx-1, argIdx)
+		args = append(args, filter.ClClient, filter.MinConfidence)
+	}
+	if filter.ElClient != 0 {
+		argIdx += 2
+		fmt.Fprintf(&sql, ` AND slot_fingerprints.el_client = $%v AND slot_fingerprints.el_confidence >= $%v `, argIdx-1, argIdx)
+		args = append(args, filter.ElClient, filter.MinConfidence)
+	}
+	if filter.BuilderType != 0 {
+		argIdx += 2
+		fmt.Fprintf(&sql, ` AND slot_fingerprints.builder_type = $%v AND slot_fingerprints.builder_confidence >= $%v `, argIdx-1, argIdx)
+		args = append(args, filter.BuilderType, filter.MinConfidence)
+	}
+
 	if filter.WithMissing == 0 {
 		fmt.Fprintf(&sql, ` AND slots.status != 0 `)
 	} else if filter.WithMissing == 2 {
@@ -304,6 +353,11 @@ func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint
 		fmt.Fprintf(&sql, ` AND slots.proposer = $%v `, argIdx)
 		args = append(args, *filter.ProposerIndex)
 	}
+	if len(filter.FeeRecipient) > 0 {
+		argIdx++
+		fmt.Fprintf(&sql, ` AND slots.eth_fee_recipient = $%v `, argIdx)
+		args = append(args, filter.FeeRecipient)
+	}
 	if filter.Graffiti != "" {
 		argIdx++
 		fmt.Fprintf(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
@@ -328,8 +382,21 @@ func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint
 		}), argIdx)
 		args = append(args, "%"+filter.ProposerName+"%")
 	}
+	if filter.Tag != "" {
+		argIdx++
+		fmt.Fprintf(&sql, ` AND EXISTS (SELECT 1 FROM slot_tags WHERE slot_tags.root = slots.root AND slot_tags.tag = $%v) `, argIdx)
+		args = append(args, filter.Tag)
+	}
+
+	if filter.Cursor != nil {
+		// keyset pagination: continue after the last returned slot instead of skipping all previous rows
+		argIdx++
+		fmt.Fprintf(&sql, ` AND slots.slot <= $%v `, argIdx)
+		args = append(args, filter.Cursor.Key)
+		offset += filter.Cursor.Skip
+	}
 
-	fmt.Fprintf(&sql, `	ORDER BY slots.slot DESC `)
+	fmt.Fprintf(&sql, `	ORDER BY slots.slot DESC, slots.root DESC `)
 	fmt.Fprintf(&sql, ` LIMIT $%v OFFSET $%v `, argIdx+1, argIdx+2)
 	argIdx += 2
 	args = append(args, limit)
@@ -391,6 +458,27 @@ func GetHighestRootBeforeSlot(slot uint64, withOrphaned bool) []byte {
 	return result
 }
 
+// GetLastProposedSlot returns the last canonical block of a proposer before the given slot or nil if there is none
+func GetLastProposedSlot(proposer uint64, beforeSlot uint64) *dbtypes.Slot {
+	block := dbtypes.Slot{}
+	err := ReaderDb.Get(&block, `
+	SELECT
+		root, slot, parent_root, state_root, status, proposer, graffiti, graffiti_text,
+		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
+		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash,
+		eth_block_extra, eth_block_extra_text, sync_participation, fork_id, cl_client, el_client, eth_fee_recipient,
+		eth_gas_used, eth_gas_limit, eth_base_fee
+	FROM slots
+	WHERE proposer = $1 AND slot < $2 AND status = $3
+	ORDER BY slot DESC
+	LIMIT 1
+	`, proposer, beforeSlot, dbtypes.Canonical)
+	if err != nil {
+		return nil
+	}
+	return &block
+}
+
 func GetSlotAssignment(slot uint64) uint64 {
 	proposer := uint64(math.MaxInt64)
 	err := ReaderDb.Get(&proposer, `
@@ -404,3 +492,88 @@ func GetSlotAssignment(slot uint64) uint64 {
 	}
 	return proposer
 }
+
+// slotOperationTables are the tables holding block operations that are linked to a slot via slot_number & slot_root
+var slotOperationTables = []string{"deposits", "voluntary_exits", "slashings", "slot_attestations", "withdrawal_requests", "consolidation_requests"}
+
+// ReconcileSlots cleans up leftovers of a previous (partial) write of the slot range.
+// canonical slots & block operations that do not belong to the given canonical roots are marked as orphaned,
+// missed slot placeholders of slots with a canonical block are removed.
+// returns the number of reconciled slot rows.
+func ReconcileSlots(firstSlot uint64, lastSlot uint64, canonicalRoots [][]byte, tx *sqlx.Tx) (int64, error) {
+	args := []any{firstSlot, lastSlot}
+	rootArgs := make([]string, len(canonicalRoots))
+	for i, root := range canonicalRoots {
+		args = append(args, root)
+		rootArgs[i] = fmt.Sprintf("$%v", len(args))
+	}
+	getRootFilter := func(column string) string {
+		if len(rootArgs) == 0 {
+			return ""
+		}
+		return fmt.Sprintf(" AND %v NOT IN (%v)", column, strings.Join(rootArgs, ", "))
+	}
+
+	var reconciled int64
+
+	args = append(args, dbtypes.Orphaned, dbtypes.Canonical)
+	res, err := tx.Exec(fmt.Sprintf(`
+		UPDATE slots SET status = $%v
+		WHERE slot >= $1 AND slot <= $2 AND status = $%v %v`,
+		len(args)-1, len(args), getRootFilter("root"),
+	), args...)
+	if err != nil {
+		return 0, fmt.Errorf("error orphaning stale canonical slots: %v", err)
+	}
+	if rows, err := res.RowsAffected(); err == nil {
+		reconciled += rows
+	}
+	args = args[:len(args)-2]
+
+	for _, table := range slotOperationTables {
+		_, err := tx.Exec(fmt.Sprintf(`
+			UPDATE %v SET orphaned = true
+			WHERE slot_number >= $1 AND slot_number <= $2 AND orphaned = false %v`,
+			table, getRootFilter("slot_root"),
+		), args...)
+		if err != nil {
+			return 0, fmt.Errorf("error orphaning stale %v: %v", table, err)
+		}
+	}
+
+	res, err = tx.Exec(`
+		DELETE FROM slots
+		WHERE slot >= $1 AND slot <= $2 AND status = $3 AND slot IN (
+			SELECT slot FROM slots WHERE slot >= $1 AND slot <= $2 AND status = $4
+		)`, firstSlot, lastSlot, dbtypes.Missing, dbtypes.Canonical)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting stale missed slots: %v", err)
+	}
+	if rows, err := res.RowsAffected(); err == nil {
+		reconciled += rows
+	}
+
+	return reconciled, nil
+}
+
+// GetSlotStatusCounts returns the number of canonical & total rows per slot in the slot range.
+// the counts are loaded within the given transaction (or from the reader db if tx is nil), so uncommitted writes are included.
+func GetSlotStatusCounts(firstSlot uint64, lastSlot uint64, tx *sqlx.Tx) ([]*dbtypes.SlotStatusCount, error) {
+	var queryer sqlx.Queryer = ReaderDb
+	if tx != nil {
+		queryer = tx
+	}
+
+	counts := []*dbtypes.SlotStatusCount{}
+	err := sqlx.Select(queryer, &counts, `
+		SELECT slot, SUM(CASE WHEN status = $3 THEN 1 ELSE 0 END) AS canonical, COUNT(*) AS row_count
+		FROM slots
+		WHERE slot >= $1 AND slot <= $2
+		GROUP BY slot
+		ORDER BY slot ASC`, firstSlot, lastSlot, dbtypes.Canonical)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching slot status counts: %v", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertSupplyEpoch inserts or updates the supply stats of an epoch and refreshes the rollup of its day
+func InsertSupplyEpoch(supply *dbtypes.SupplyEpoch, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO supply_epochs (epoch, day, blocks, issued, burned)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (epoch) DO UPDATE SET
+				day = excluded.day,
+				blocks = excluded.blocks,
+				issued = excluded.issued,
+				burned = excluded.burned`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO supply_epochs (epoch, day, blocks, issued, burned)
+			VALUES ($1, $2, $3, $4, $5)`,
+	}), supply.Epoch, supply.Day, supply.Blocks, supply.Issued, supply.Burned)
+	if err != nil {
+		return fmt.Errorf("error inserting supply epoch: %v", err)
+	}
+
+	// the day rollup is rebuilt from the epoch stats, so retried epochs are not counted twice
+	_, err = tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO supply_days (day, epochs, blocks, issued, burned)
+			SELECT day, COUNT(*), SUM(blocks), SUM(issued), SUM(burned)
+			FROM supply_epochs
+			WHERE day = $1
+			GROUP BY day
+			ON CONFLICT (day) DO UPDATE SET
+				epochs = excluded.epochs,
+				blocks = excluded.blocks,
+				issued = excluded.issued,
+				burned = excluded.burned`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO supply_days (day, epochs, blocks, issued, burned)
+			SELECT day, COUNT(*), SUM(blocks), SUM(issued), SUM(burned)
+			FROM supply_epochs
+			WHERE day = $1
+			GROUP BY day`,
+	}), supply.Day)
+	if err != nil {
+		return fmt.Errorf("error updating supply day: %v", err)
+	}
+	return nil
+}
+
+// GetSupplyEpochs returns the supply stats of the most recent epochs, newest first
+func GetSupplyEpochs(limit uint32) ([]*dbtypes.SupplyEpoch, error) {
+	supply := []*dbtypes.SupplyEpoch{}
+	err := ReaderDb.Select(&supply, `
+		SELECT epoch, day, blocks, issued, burned
+		FROM supply_epochs
+		ORDER BY epoch DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching supply epochs: %v", err)
+		return nil, err
+	}
+	return supply, nil
+}
+
+// GetSupplyDays returns the daily supply rollups of the most recent days, newest first
+func GetSupplyDays(limit uint32) ([]*dbtypes.SupplyDay, error) {
+	supply := []*dbtypes.SupplyDay{}
+	err := ReaderDb.Select(&supply, `
+		SELECT day, epochs, blocks, issued, burned
+		FROM supply_days
+		ORDER BY day DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching supply days: %v", err)
+		return nil, err
+	}
+	return supply, nil
+}
+
+// GetSupplyTotals returns the total issuance & fee burn of all indexed epochs
+func GetSupplyTotals() (*dbtypes.SupplyDay, error) {
+	totals := dbtypes.SupplyDay{}
+	err := ReaderDb.Get(&totals, `
+		SELECT COALESCE(SUM(epochs), 0) AS epochs, COALESCE(SUM(blocks), 0) AS blocks, COALESCE(SUM(issued), 0) AS issued, COALESCE(SUM(burned), 0) AS burned
+		FROM supply_days
+	`)
+	if err != nil {
+		logger.Errorf("Error while fetching supply totals: %v", err)
+		return nil, err
+	}
+	return &totals, nil
+}
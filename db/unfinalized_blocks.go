@@ -9,18 +9,38 @@ import (
 )
 
 func InsertUnfinalizedBlock(block *dbtypes.UnfinalizedBlock, tx *sqlx.Tx) error {
+	blockSSZ := block.BlockSSZ
+	if PayloadStore != nil && len(blockSSZ) > 0 {
+		// the payload key is derived from the root, so an existing row must not get its payload replaced
+		// (the stored payload might be encoded with a different block version / compression)
+		var rowCount int
+		if err := tx.Get(&rowCount, `SELECT COUNT(*) FROM unfinalized_blocks WHERE root = $1`, block.Root); err != nil {
+			return err
+		}
+		if rowCount > 0 {
+			return nil
+		}
+
+		// the row is written with the inline payload, which is moved to the blob store after the transaction has been committed.
+		// this way a rolled back transaction doesn't leave an unreferenced payload behind.
+		root := block.Root
+		runAfterCommit(tx, func() {
+			moveBlockPayload("unfinalized_blocks", getUnfinalizedBlockPayloadKey(root), root, blockSSZ)
+		})
+	}
+
 	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO unfinalized_blocks (
-				root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+				root, slot, header_ver, header_ssz, block_ver, block_ssz, block_ext, status, fork_id
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 			ON CONFLICT (root) DO NOTHING`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR IGNORE INTO unfinalized_blocks (
-				root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+				root, slot, header_ver, header_ssz, block_ver, block_ssz, block_ext, status, fork_id
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
 	}),
-		block.Root, block.Slot, block.HeaderVer, block.HeaderSSZ, block.BlockVer, block.BlockSSZ, block.Status, block.ForkId)
+		block.Root, block.Slot, block.HeaderVer, block.HeaderSSZ, block.BlockVer, blockSSZ, 0, block.Status, block.ForkId)
 	if err != nil {
 		return err
 	}
@@ -86,7 +106,7 @@ func GetUnfinalizedBlocks(filter *dbtypes.UnfinalizedBlockFilter) []*dbtypes.Unf
 	fmt.Fprint(&sql, `SELECT root, slot, status, fork_id, header_ver, header_ssz`)
 
 	if filter == nil || filter.WithBody {
-		fmt.Fprint(&sql, `, block_ver, block_ssz`)
+		fmt.Fprint(&sql, `, block_ver, block_ssz, block_ext`)
 	}
 	fmt.Fprint(&sql, `
 	FROM unfinalized_blocks
@@ -113,14 +133,35 @@ func GetUnfinalizedBlocks(filter *dbtypes.UnfinalizedBlockFilter) []*dbtypes.Unf
 		logger.Errorf("Error while fetching unfinalized blocks: %v", err)
 		return nil
 	}
+
+	for _, block := range blockRefs {
+		if err := loadUnfinalizedBlockPayload(block); err != nil {
+			logger.Errorf("Error while loading unfinalized block payload: %v", err)
+		}
+	}
+
 	return blockRefs
 }
 
+func loadUnfinalizedBlockPayload(block *dbtypes.UnfinalizedBlock) error {
+	if block.BlockExt == 0 {
+		return nil
+	}
+
+	blockSSZ, err := getPayload(getUnfinalizedBlockPayloadKey(block.Root))
+	if err != nil {
+		return err
+	}
+
+	block.BlockSSZ = blockSSZ
+	return nil
+}
+
 func StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBlock)) error {
 	var sql strings.Builder
 	args := []any{slot}
 
-	fmt.Fprint(&sql, `SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id FROM unfinalized_blocks WHERE slot >= $1`)
+	fmt.Fprint(&sql, `SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz, block_ext, status, fork_id FROM unfinalized_blocks WHERE slot >= $1`)
 
 	rows, err := ReaderDb.Query(sql.String(), args...)
 	if err != nil {
@@ -130,11 +171,14 @@ func StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBloc
 
 	for rows.Next() {
 		block := dbtypes.UnfinalizedBlock{}
-		err := rows.Scan(&block.Root, &block.Slot, &block.HeaderVer, &block.HeaderSSZ, &block.BlockVer, &block.BlockSSZ, &block.Status, &block.ForkId)
+		err := rows.Scan(&block.Root, &block.Slot, &block.HeaderVer, &block.HeaderSSZ, &block.BlockVer, &block.BlockSSZ, &block.BlockExt, &block.Status, &block.ForkId)
 		if err != nil {
 			logger.Errorf("Error while scanning unfinalized block: %v", err)
 			return err
 		}
+		if err := loadUnfinalizedBlockPayload(&block); err != nil {
+			logger.Errorf("Error while loading unfinalized block payload: %v", err)
+		}
 		cb(&block)
 	}
 
@@ -144,7 +188,7 @@ func StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBloc
 func GetUnfinalizedBlock(root []byte) *dbtypes.UnfinalizedBlock {
 	block := dbtypes.UnfinalizedBlock{}
 	err := ReaderDb.Get(&block, `
-	SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id
+	SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz, block_ext, status, fork_id
 	FROM unfinalized_blocks
 	WHERE root = $1
 	`, root)
@@ -152,13 +196,64 @@ func GetUnfinalizedBlock(root []byte) *dbtypes.UnfinalizedBlock {
 		logger.Errorf("Error while fetching unfinalized block 0x%x: %v", root, err)
 		return nil
 	}
+	if err := loadUnfinalizedBlockPayload(&block); err != nil {
+		logger.Errorf("Error while loading unfinalized block 0x%x payload: %v", root, err)
+	}
 	return &block
 }
 
-func DeleteUnfinalizedBlocksBefore(slot uint64, tx *sqlx.Tx) error {
+// GetUnfinalizedBlockRoots returns the subset of the given block roots that are stored in the unfinalized blocks table.
+func GetUnfinalizedBlockRoots(roots [][]byte) [][]byte {
+	if len(roots) == 0 {
+		return [][]byte{}
+	}
+
+	plcList := make([]string, len(roots))
+	args := make([]any, len(roots))
+	for i, root := range roots {
+		plcList[i] = fmt.Sprintf("$%v", i+1)
+		args[i] = root
+	}
+
+	storedRoots := [][]byte{}
+	err := ReaderDb.Select(&storedRoots, fmt.Sprintf(`SELECT root FROM unfinalized_blocks WHERE root IN (%v)`, strings.Join(plcList, ", ")), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching unfinalized block roots: %v", err)
+		return nil
+	}
+
+	return storedRoots
+}
+
+// DeleteUnfinalizedBlocksBefore deletes all unfinalized blocks before the given slot.
+// returns the roots of the deleted blocks with payloads in the blob store. the payloads must only be deleted
+// via DeleteUnfinalizedBlockPayloads after the transaction has been committed, as a rollback would restore the rows.
+func DeleteUnfinalizedBlocksBefore(slot uint64, tx *sqlx.Tx) ([][]byte, error) {
+	externalRoots := [][]byte{}
+	if PayloadStore != nil {
+		err := tx.Select(&externalRoots, `SELECT root FROM unfinalized_blocks WHERE slot < $1 AND block_ext != 0`, slot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	_, err := tx.Exec(`DELETE FROM unfinalized_blocks WHERE slot < $1`, slot)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+
+	return externalRoots, nil
+}
+
+// DeleteUnfinalizedBlockPayloads deletes the blob store payloads of deleted unfinalized blocks.
+func DeleteUnfinalizedBlockPayloads(roots [][]byte) {
+	if len(roots) == 0 {
+		return
+	}
+
+	payloadKeys := make([]string, len(roots))
+	for i, root := range roots {
+		payloadKeys[i] = getUnfinalizedBlockPayloadKey(root)
+	}
+	deletePayloads(payloadKeys)
 }
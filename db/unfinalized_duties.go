@@ -65,6 +65,29 @@ func GetUnfinalizedDuty(epoch uint64, dependentRoot []byte) *dbtypes.Unfinalized
 	return &duty
 }
 
+// GetUnfinalizedDutyKeys returns the epoch & dependent root of all unfinalized duties from the given epoch on (without the duties).
+func GetUnfinalizedDutyKeys(epoch uint64) []*dbtypes.UnfinalizedDuty {
+	duties := []*dbtypes.UnfinalizedDuty{}
+	err := ReaderDb.Select(&duties, `SELECT epoch, dependent_root FROM unfinalized_duties WHERE epoch >= $1`, epoch)
+	if err != nil {
+		logger.Errorf("Error while fetching unfinalized duty keys: %v", err)
+		return nil
+	}
+	return duties
+}
+
+// HasUnfinalizedDuty returns true if the duties for the given epoch & dependent root are stored in the unfinalized duties table.
+func HasUnfinalizedDuty(epoch uint64, dependentRoot []byte) bool {
+	count := 0
+	err := ReaderDb.Get(&count, `SELECT COUNT(*) FROM unfinalized_duties WHERE epoch = $1 AND dependent_root = $2`, epoch, dependentRoot)
+	if err != nil {
+		logger.Errorf("Error while checking unfinalized duty: %v", err)
+		return false
+	}
+
+	return count > 0
+}
+
 func DeleteUnfinalizedDutiesBefore(epoch uint64, tx *sqlx.Tx) error {
 	_, err := tx.Exec(`DELETE FROM unfinalized_duties WHERE epoch < $1`, epoch)
 	if err != nil {
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertValidatorAnomalies inserts multiple validator anomalies in a batch, existing anomalies are left unchanged
+func InsertValidatorAnomalies(anomalies []*dbtypes.ValidatorAnomaly, tx *sqlx.Tx) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(anomalies))
+	valueArgs := make([]interface{}, 0, len(anomalies)*5)
+	for i, anomaly := range anomalies {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5)
+		valueArgs = append(valueArgs,
+			anomaly.ValidatorIndex,
+			anomaly.Slot,
+			anomaly.Type,
+			anomaly.BeforeValue,
+			anomaly.AfterValue)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO validator_anomalies (
+				validator_index, slot, type, before_value, after_value
+			) VALUES %s
+			ON CONFLICT (validator_index, slot, type) DO NOTHING`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR IGNORE INTO validator_anomalies (
+				validator_index, slot, type, before_value, after_value
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting validator anomalies: %v", err)
+	}
+
+	return nil
+}
+
+// GetValidatorAnomaliesFiltered returns a page of validator anomalies matching the filter (newest first) and the total number of matches
+func GetValidatorAnomaliesFiltered(offset uint64, limit uint32, filter *dbtypes.ValidatorAnomalyFilter) ([]*dbtypes.ValidatorAnomaly, uint64, error) {
+	var filterSql strings.Builder
+	args := []interface{}{filter.MinSlot}
+
+	fmt.Fprint(&filterSql, ` WHERE slot >= $1 `)
+	if filter.ValidatorIndex != nil {
+		args = append(args, *filter.ValidatorIndex)
+		fmt.Fprintf(&filterSql, ` AND validator_index = $%v `, len(args))
+	}
+	if filter.Type > 0 {
+		args = append(args, filter.Type)
+		fmt.Fprintf(&filterSql, ` AND type = $%v `, len(args))
+	}
+
+	var totalCount uint64
+	err := ReaderDb.Get(&totalCount, fmt.Sprintf(`SELECT COUNT(*) FROM validator_anomalies %v`, filterSql.String()), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching validator anomaly count: %v", err)
+		return nil, 0, err
+	}
+
+	args = append(args, limit, offset)
+	anomalies := []*dbtypes.ValidatorAnomaly{}
+	err = ReaderDb.Select(&anomalies, fmt.Sprintf(`
+		SELECT validator_index, slot, type, before_value, after_value
+		FROM validator_anomalies
+		%v
+		ORDER BY slot DESC, validator_index ASC, type ASC
+		LIMIT $%v OFFSET $%v`, filterSql.String(), len(args)-1, len(args)), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching validator anomalies: %v", err)
+		return nil, 0, err
+	}
+
+	return anomalies, totalCount, nil
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// GetValidatorProposalStats returns the number of proposed, orphaned & missed slots per validator since minSlot.
+// validators without any proposer duty in that range are not included.
+func GetValidatorProposalStats(validators []uint64, minSlot uint64) ([]*dbtypes.ValidatorProposalStats, error) {
+	stats := []*dbtypes.ValidatorProposalStats{}
+	if len(validators) == 0 {
+		return stats, nil
+	}
+
+	args := []interface{}{minSlot}
+	validatorArgs := make([]string, len(validators))
+	for i, validator := range validators {
+		args = append(args, validator)
+		validatorArgs[i] = fmt.Sprintf("$%v", len(args))
+	}
+
+	err := ReaderDb.Select(&stats, fmt.Sprintf(`
+		SELECT
+			proposer,
+			SUM(CASE WHEN status = 1 THEN 1 ELSE 0 END) AS proposed,
+			SUM(CASE WHEN status = 2 THEN 1 ELSE 0 END) AS orphaned,
+			SUM(CASE WHEN status = 0 THEN 1 ELSE 0 END) AS missed
+		FROM slots
+		WHERE slot >= $1 AND proposer IN (%v)
+		GROUP BY proposer
+	`, strings.Join(validatorArgs, ", ")), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching validator proposal stats: %v", err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetLatestValidatorEffectiveness returns the effectiveness scores of the most recent scored day for the given validators
+func GetLatestValidatorEffectiveness(validators []uint64) ([]*dbtypes.ValidatorEffectiveness, error) {
+	scores := []*dbtypes.ValidatorEffectiveness{}
+	if len(validators) == 0 {
+		return scores, nil
+	}
+
+	args := []interface{}{}
+	validatorArgs := make([]string, len(validators))
+	for i, validator := range validators {
+		args = append(args, validator)
+		validatorArgs[i] = fmt.Sprintf("$%v", len(args))
+	}
+
+	err := ReaderDb.Select(&scores, fmt.Sprintf(`
+		SELECT day, validator_index, effectiveness, epochs, top_percent
+		FROM validator_effectiveness
+		WHERE day = (SELECT MAX(day) FROM validator_effectiveness) AND validator_index IN (%v)
+	`, strings.Join(validatorArgs, ", ")), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching latest validator effectiveness: %v", err)
+		return nil, err
+	}
+	return scores, nil
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertValidatorEffectivenessBatch inserts multiple daily validator effectiveness scores in a batch
+func InsertValidatorEffectivenessBatch(scores []*dbtypes.ValidatorEffectiveness, tx *sqlx.Tx) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(scores))
+	valueArgs := make([]interface{}, 0, len(scores)*5)
+	for i, score := range scores {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5)
+		valueArgs = append(valueArgs,
+			score.Day,
+			score.ValidatorIndex,
+			score.Effectiveness,
+			score.Epochs,
+			score.TopPercent)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO validator_effectiveness (
+				day, validator_index, effectiveness, epochs, top_percent
+			) VALUES %s
+			ON CONFLICT (validator_index, day) DO UPDATE SET
+				effectiveness = excluded.effectiveness,
+				epochs = excluded.epochs,
+				top_percent = excluded.top_percent`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO validator_effectiveness (
+				day, validator_index, effectiveness, epochs, top_percent
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting validator effectiveness batch: %v", err)
+	}
+
+	return nil
+}
+
+// DeleteValidatorEffectivenessBefore deletes all daily validator effectiveness scores before the given day
+func DeleteValidatorEffectivenessBefore(day uint64, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`DELETE FROM validator_effectiveness WHERE day < $1`, day)
+	if err != nil {
+		return fmt.Errorf("error deleting old validator effectiveness scores: %v", err)
+	}
+	return nil
+}
+
+// GetValidatorEffectivenessHistory returns the most recent daily effectiveness scores of a validator, newest first
+func GetValidatorEffectivenessHistory(validatorIndex uint64, limit uint32) ([]*dbtypes.ValidatorEffectiveness, error) {
+	scores := []*dbtypes.ValidatorEffectiveness{}
+	err := ReaderDb.Select(&scores, `
+		SELECT day, validator_index, effectiveness, epochs, top_percent
+		FROM validator_effectiveness
+		WHERE validator_index = $1
+		ORDER BY day DESC
+		LIMIT $2
+	`, validatorIndex, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching validator effectiveness history: %v", err)
+		return nil, err
+	}
+	return scores, nil
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertValidatorIncidents inserts or updates multiple validator incidents in a batch
+func InsertValidatorIncidents(incidents []*dbtypes.ValidatorIncident, tx *sqlx.Tx) error {
+	if len(incidents) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(incidents))
+	valueArgs := make([]interface{}, 0, len(incidents)*8)
+	for i, incident := range incidents {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)", i*8+1, i*8+2, i*8+3, i*8+4, i*8+5, i*8+6, i*8+7, i*8+8)
+		valueArgs = append(valueArgs,
+			incident.Entity,
+			incident.StartEpoch,
+			incident.EndEpoch,
+			incident.Validators,
+			incident.MissedAttestations,
+			incident.MissedProposals,
+			incident.EstimatedLoss,
+			incident.Resolved)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO validator_incidents (
+				entity, start_epoch, end_epoch, validators, missed_attestations, missed_proposals, estimated_loss, resolved
+			) VALUES %s
+			ON CONFLICT (entity, start_epoch) DO UPDATE SET
+				end_epoch = excluded.end_epoch,
+				validators = excluded.validators,
+				missed_attestations = excluded.missed_attestations,
+				missed_proposals = excluded.missed_proposals,
+				estimated_loss = excluded.estimated_loss,
+				resolved = excluded.resolved`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO validator_incidents (
+				entity, start_epoch, end_epoch, validators, missed_attestations, missed_proposals, estimated_loss, resolved
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting validator incidents: %v", err)
+	}
+
+	return nil
+}
+
+// GetValidatorIncidentsFiltered returns a page of validator incidents matching the filter (newest first) and the total number of matches
+func GetValidatorIncidentsFiltered(offset uint64, limit uint32, filter *dbtypes.ValidatorIncidentFilter) ([]*dbtypes.ValidatorIncident, uint64, error) {
+	var filterSql strings.Builder
+	args := []interface{}{filter.MinEpoch}
+
+	fmt.Fprint(&filterSql, ` WHERE end_epoch >= $1 `)
+	if filter.Entity != "" {
+		args = append(args, filter.Entity)
+		fmt.Fprintf(&filterSql, ` AND entity = $%v `, len(args))
+	}
+	if filter.Resolved != nil {
+		args = append(args, *filter.Resolved)
+		fmt.Fprintf(&filterSql, ` AND resolved = $%v `, len(args))
+	}
+
+	var totalCount uint64
+	err := ReaderDb.Get(&totalCount, fmt.Sprintf(`SELECT COUNT(*) FROM validator_incidents %v`, filterSql.String()), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching validator incident count: %v", err)
+		return nil, 0, err
+	}
+
+	args = append(args, limit, offset)
+	incidents := []*dbtypes.ValidatorIncident{}
+	err = ReaderDb.Select(&incidents, fmt.Sprintf(`
+		SELECT entity, start_epoch, end_epoch, validators, missed_attestations, missed_proposals, estimated_loss, resolved
+		FROM validator_incidents
+		%v
+		ORDER BY start_epoch DESC, entity ASC
+		LIMIT $%v OFFSET $%v`, filterSql.String(), len(args)-1, len(args)), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching validator incidents: %v", err)
+		return nil, 0, err
+	}
+
+	return incidents, totalCount, nil
+}
+
+// GetUnresolvedValidatorIncidents returns all validator incidents that are not resolved yet
+func GetUnresolvedValidatorIncidents() ([]*dbtypes.ValidatorIncident, error) {
+	incidents := []*dbtypes.ValidatorIncident{}
+	err := ReaderDb.Select(&incidents, `
+		SELECT entity, start_epoch, end_epoch, validators, missed_attestations, missed_proposals, estimated_loss, resolved
+		FROM validator_incidents
+		WHERE resolved = $1
+	`, false)
+	if err != nil {
+		logger.Errorf("Error while fetching unresolved validator incidents: %v", err)
+		return nil, err
+	}
+	return incidents, nil
+}
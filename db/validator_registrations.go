@@ -0,0 +1,65 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// InsertValidatorRegistrations inserts or replaces the latest validator registrations in a batch
+func InsertValidatorRegistrations(registrations []*dbtypes.ValidatorRegistration, tx *sqlx.Tx) error {
+	if len(registrations) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, len(registrations))
+	valueArgs := make([]interface{}, 0, len(registrations)*5)
+	for i, registration := range registrations {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5)
+		valueArgs = append(valueArgs,
+			registration.ValidatorIndex,
+			registration.FeeRecipient,
+			registration.GasLimit,
+			registration.Timestamp,
+			registration.SeenbyRelays)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO validator_registrations (
+				validator_index, fee_recipient, gas_limit, timestamp, seenby_relays
+			) VALUES %s
+			ON CONFLICT (validator_index) DO UPDATE SET
+				fee_recipient = excluded.fee_recipient,
+				gas_limit = excluded.gas_limit,
+				timestamp = excluded.timestamp,
+				seenby_relays = excluded.seenby_relays`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO validator_registrations (
+				validator_index, fee_recipient, gas_limit, timestamp, seenby_relays
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting validator registrations: %v", err)
+	}
+
+	return nil
+}
+
+// GetValidatorRegistration returns the latest known validator registration of a validator or nil if none is known
+func GetValidatorRegistration(validatorIndex uint64) *dbtypes.ValidatorRegistration {
+	registration := &dbtypes.ValidatorRegistration{}
+	err := ReaderDb.Get(registration, `
+		SELECT validator_index, fee_recipient, gas_limit, timestamp, seenby_relays
+		FROM validator_registrations
+		WHERE validator_index = $1
+	`, validatorIndex)
+	if err != nil {
+		return nil
+	}
+	return registration
+}
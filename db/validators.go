@@ -153,8 +153,9 @@ func GetMaxValidatorIndex() (uint64, error) {
 	return maxIndex, nil
 }
 
-// GetValidatorIndexesByFilter returns validator indexes matching a filter
-func GetValidatorIndexesByFilter(filter dbtypes.ValidatorFilter, currentEpoch uint64) ([]uint64, error) {
+// GetValidatorIndexesByFilter returns validator indexes matching a filter.
+// limit restricts the number of returned indexes (0 for no limit), the filter offset is not applied.
+func GetValidatorIndexesByFilter(filter dbtypes.ValidatorFilter, currentEpoch uint64, limit uint64) ([]uint64, error) {
 	var sql strings.Builder
 	args := []interface{}{}
 	fmt.Fprint(&sql, `
@@ -175,21 +176,26 @@ func GetValidatorIndexesByFilter(filter dbtypes.ValidatorFilter, currentEpoch ui
 	case dbtypes.ValidatorOrderPubKeyDesc:
 		fmt.Fprintf(&sql, " ORDER BY pubkey DESC")
 	case dbtypes.ValidatorOrderBalanceAsc:
-		fmt.Fprintf(&sql, " ORDER BY effective_balance ASC")
+		fmt.Fprintf(&sql, " ORDER BY effective_balance ASC, validator_index ASC")
 	case dbtypes.ValidatorOrderBalanceDesc:
-		fmt.Fprintf(&sql, " ORDER BY effective_balance DESC")
+		fmt.Fprintf(&sql, " ORDER BY effective_balance DESC, validator_index ASC")
 	case dbtypes.ValidatorOrderActivationEpochAsc:
-		fmt.Fprintf(&sql, " ORDER BY activation_epoch ASC")
+		fmt.Fprintf(&sql, " ORDER BY activation_epoch ASC, validator_index ASC")
 	case dbtypes.ValidatorOrderActivationEpochDesc:
-		fmt.Fprintf(&sql, " ORDER BY activation_epoch DESC")
+		fmt.Fprintf(&sql, " ORDER BY activation_epoch DESC, validator_index ASC")
 	case dbtypes.ValidatorOrderExitEpochAsc:
-		fmt.Fprintf(&sql, " ORDER BY exit_epoch ASC")
+		fmt.Fprintf(&sql, " ORDER BY exit_epoch ASC, validator_index ASC")
 	case dbtypes.ValidatorOrderExitEpochDesc:
-		fmt.Fprintf(&sql, " ORDER BY exit_epoch DESC")
+		fmt.Fprintf(&sql, " ORDER BY exit_epoch DESC, validator_index ASC")
 	case dbtypes.ValidatorOrderWithdrawableEpochAsc:
-		fmt.Fprintf(&sql, " ORDER BY withdrawable_epoch ASC")
+		fmt.Fprintf(&sql, " ORDER BY withdrawable_epoch ASC, validator_index ASC")
 	case dbtypes.ValidatorOrderWithdrawableEpochDesc:
-		fmt.Fprintf(&sql, " ORDER BY withdrawable_epoch DESC")
+		fmt.Fprintf(&sql, " ORDER BY withdrawable_epoch DESC, validator_index ASC")
+	}
+
+	if limit > 0 {
+		args = append(args, limit)
+		fmt.Fprintf(&sql, " LIMIT $%v", len(args))
 	}
 
 	validatorIds := []uint64{}
@@ -202,6 +208,67 @@ func GetValidatorIndexesByFilter(filter dbtypes.ValidatorFilter, currentEpoch ui
 	return validatorIds, nil
 }
 
+// GetValidatorCountByFilter returns the number of validators matching a filter.
+// if indexes is not nil, only the given validator indexes are counted.
+func GetValidatorCountByFilter(filter dbtypes.ValidatorFilter, currentEpoch uint64, indexes []uint64) (uint64, error) {
+	if indexes == nil {
+		return countValidatorsByFilter(filter, currentEpoch, nil)
+	}
+
+	const batchSize = 1000
+
+	// Process in batches
+	totalCount := uint64(0)
+	for i := 0; i < len(indexes); i += batchSize {
+		end := i + batchSize
+		if end > len(indexes) {
+			end = len(indexes)
+		}
+
+		count, err := countValidatorsByFilter(filter, currentEpoch, indexes[i:end])
+		if err != nil {
+			return 0, err
+		}
+		totalCount += count
+	}
+
+	return totalCount, nil
+}
+
+func countValidatorsByFilter(filter dbtypes.ValidatorFilter, currentEpoch uint64, indexes []uint64) (uint64, error) {
+	var sql strings.Builder
+	fmt.Fprint(&sql, `
+	SELECT
+		COUNT(*)
+	FROM validators
+	`)
+
+	args := buildValidatorFilterSql(filter, currentEpoch, &sql, []interface{}{})
+
+	if indexes != nil {
+		filterOp := "WHERE"
+		if len(args) > 0 {
+			filterOp = "AND"
+		}
+
+		values := make([]string, len(indexes))
+		for i, index := range indexes {
+			args = append(args, index)
+			values[i] = fmt.Sprintf("$%v", len(args))
+		}
+		fmt.Fprintf(&sql, " %v validator_index IN (%v)", filterOp, strings.Join(values, ","))
+	}
+
+	var count uint64
+	err := ReaderDb.Get(&count, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while counting validators by filter: %v", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func buildValidatorFilterSql(filter dbtypes.ValidatorFilter, currentEpoch uint64, sql *strings.Builder, args []interface{}) []interface{} {
 	if filter.ValidatorName != "" {
 		fmt.Fprintf(sql, ` LEFT JOIN validator_names ON validator_names."index" = validators.validator_index `)
@@ -242,6 +309,34 @@ func buildValidatorFilterSql(filter dbtypes.ValidatorFilter, currentEpoch uint64
 		}), filterOp, len(args))
 		filterOp = "AND"
 	}
+	if filter.MinBalance != nil {
+		args = append(args, *filter.MinBalance)
+		fmt.Fprintf(sql, " %v effective_balance >= $%v", filterOp, len(args))
+		filterOp = "AND"
+	}
+	if filter.MaxBalance != nil {
+		args = append(args, *filter.MaxBalance)
+		fmt.Fprintf(sql, " %v effective_balance <= $%v", filterOp, len(args))
+		filterOp = "AND"
+	}
+	if filter.MinActivation != nil {
+		args = append(args, ConvertUint64ToInt64(*filter.MinActivation))
+		fmt.Fprintf(sql, " %v activation_epoch >= $%v", filterOp, len(args))
+		filterOp = "AND"
+	}
+	if filter.MaxActivation != nil {
+		args = append(args, ConvertUint64ToInt64(*filter.MaxActivation))
+		fmt.Fprintf(sql, " %v activation_epoch <= $%v", filterOp, len(args))
+		filterOp = "AND"
+	}
+	if len(filter.CredentialsPrefix) > 0 {
+		args = append(args, filter.CredentialsPrefix)
+		fmt.Fprintf(sql, EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  " %v substring(withdrawal_credentials from 1 for %v) = $%v",
+			dbtypes.DBEngineSqlite: " %v substr(withdrawal_credentials, 1, %v) = $%v",
+		}), filterOp, len(filter.CredentialsPrefix), len(args))
+		filterOp = "AND"
+	}
 	if len(filter.Status) > 0 {
 		values := []string{}
 		for _, status := range filter.Status {
@@ -15,11 +15,11 @@ func InsertWithdrawalRequestTxs(withdrawalTxs []*dbtypes.WithdrawalRequestTx, tx
 			dbtypes.DBEnginePgsql:  "INSERT INTO withdrawal_request_txs ",
 			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO withdrawal_request_txs ",
 		}),
-		"(block_number, block_index, block_time, block_root, fork_id, source_address, validator_pubkey, validator_index, amount, tx_hash, tx_sender, tx_target, dequeue_block)",
+		"(block_number, block_index, block_time, block_root, fork_id, source_address, validator_pubkey, validator_index, amount, tx_hash, tx_sender, tx_target, dequeue_block, fee)",
 		" VALUES ",
 	)
 	argIdx := 0
-	fieldCount := 13
+	fieldCount := 14
 
 	args := make([]any, len(withdrawalTxs)*fieldCount)
 	for i, withdrawalTx := range withdrawalTxs {
@@ -49,6 +49,7 @@ func InsertWithdrawalRequestTxs(withdrawalTxs []*dbtypes.WithdrawalRequestTx, tx
 		args[argIdx+10] = withdrawalTx.TxSender
 		args[argIdx+11] = withdrawalTx.TxTarget
 		args[argIdx+12] = withdrawalTx.DequeueBlock
+		args[argIdx+13] = withdrawalTx.Fee
 		argIdx += fieldCount
 	}
 	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
@@ -113,7 +114,7 @@ func GetWithdrawalRequestTxsFiltered(offset uint64, limit uint32, canonicalForkI
 	fmt.Fprint(&sql, `
 	WITH cte AS (
 		SELECT
-			block_number, block_index, block_time, block_root, fork_id, source_address, validator_pubkey, validator_index, CAST(amount AS BIGINT), tx_hash, tx_sender, tx_target, dequeue_block
+			block_number, block_index, block_time, block_root, fork_id, source_address, validator_pubkey, validator_index, CAST(amount AS BIGINT), tx_hash, tx_sender, tx_target, dequeue_block, fee
 		FROM withdrawal_request_txs
 	`)
 
@@ -207,7 +208,8 @@ func GetWithdrawalRequestTxsFiltered(offset uint64, limit uint32, canonicalForkI
 		null AS tx_hash,
 		null AS tx_sender,
 		null AS tx_target,
-		0 AS dequeue_block
+		0 AS dequeue_block,
+		0 AS fee
 	FROM cte
 	UNION ALL SELECT * FROM (
 	SELECT * FROM cte
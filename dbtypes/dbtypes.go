@@ -48,6 +48,12 @@ type Slot struct {
 	EthBlockExtraText     string     `db:"eth_block_extra_text"`
 	SyncParticipation     float32    `db:"sync_participation"`
 	ForkId                uint64     `db:"fork_id"`
+	ClClient              int8       `db:"cl_client"`
+	ElClient              int8       `db:"el_client"`
+	EthFeeRecipient       []byte     `db:"eth_fee_recipient"`
+	EthGasUsed            uint64     `db:"eth_gas_used"`
+	EthGasLimit           uint64     `db:"eth_gas_limit"`
+	EthBaseFee            uint64     `db:"eth_base_fee"`
 }
 
 type Epoch struct {
@@ -72,12 +78,19 @@ type Epoch struct {
 	SyncParticipation     float32 `db:"sync_participation"`
 }
 
+type SlotStatusCount struct {
+	Slot      uint64 `db:"slot"`
+	Canonical uint64 `db:"canonical"`
+	RowCount  uint64 `db:"row_count"`
+}
+
 type OrphanedBlock struct {
 	Root      []byte `db:"root"`
 	HeaderVer uint64 `db:"header_ver"`
 	HeaderSSZ []byte `db:"header_ssz"`
 	BlockVer  uint64 `db:"block_ver"`
 	BlockSSZ  []byte `db:"block_ssz"`
+	BlockExt  uint8  `db:"block_ext"`
 }
 
 type SlotAssignment struct {
@@ -106,6 +119,7 @@ type UnfinalizedBlock struct {
 	HeaderSSZ []byte                 `db:"header_ssz"`
 	BlockVer  uint64                 `db:"block_ver"`
 	BlockSSZ  []byte                 `db:"block_ssz"`
+	BlockExt  uint8                  `db:"block_ext"`
 	Status    UnfinalizedBlockStatus `db:"status"`
 	ForkId    uint64                 `db:"fork_id"`
 }
@@ -150,19 +164,6 @@ type UnfinalizedDuty struct {
 	DutiesSSZ     []byte `db:"duties"`
 }
 
-type Blob struct {
-	Commitment []byte  `db:"commitment"`
-	Proof      []byte  `db:"proof"`
-	Size       uint32  `db:"size"`
-	Blob       *[]byte `db:"blob"`
-}
-
-type BlobAssignment struct {
-	Root       []byte `db:"root"`
-	Commitment []byte `db:"commitment"`
-	Slot       uint64 `db:"slot"`
-}
-
 type TxFunctionSignature struct {
 	Signature string `db:"signature"`
 	Bytes     []byte `db:"bytes"`
@@ -209,6 +210,30 @@ type DepositTx struct {
 	TxSender              []byte `db:"tx_sender"`
 	TxTarget              []byte `db:"tx_target"`
 	ForkId                uint64 `db:"fork_id"`
+	ProblemFlags          uint8  `db:"problem_flags"`
+}
+
+const (
+	// deposit reuses an already known pubkey with different withdrawal credentials (credentials are ignored by the beacon chain)
+	DepositTxProblemCredentialsMismatch uint8 = 0x01
+	// deposit has an invalid signature and there is no prior valid deposit for the pubkey (deposit is ignored by the beacon chain)
+	DepositTxProblemInvalidSignature uint8 = 0x02
+)
+
+// DepositTxSender is the sender of the first canonical deposit tx of a validator pubkey
+type DepositTxSender struct {
+	PublicKey []byte `db:"publickey"`
+	TxSender  []byte `db:"tx_sender"`
+	BlockTime uint64 `db:"block_time"`
+}
+
+// DepositSenderStats aggregates the canonical deposit txs of a sender address
+type DepositSenderStats struct {
+	TxSender       []byte `db:"tx_sender"`
+	DepositCount   uint64 `db:"deposit_count"`
+	DepositAmount  uint64 `db:"deposit_amount"`
+	FirstBlockTime uint64 `db:"first_block_time"`
+	LastBlockTime  uint64 `db:"last_block_time"`
 }
 
 type Deposit struct {
@@ -306,6 +331,7 @@ type ConsolidationRequestTx struct {
 	TxSender      []byte  `db:"tx_sender"`
 	TxTarget      []byte  `db:"tx_target"`
 	DequeueBlock  uint64  `db:"dequeue_block"`
+	Fee           uint64  `db:"fee"`
 }
 
 const (
@@ -355,6 +381,7 @@ type WithdrawalRequestTx struct {
 	TxSender        []byte  `db:"tx_sender"`
 	TxTarget        []byte  `db:"tx_target"`
 	DequeueBlock    uint64  `db:"dequeue_block"`
+	Fee             uint64  `db:"fee"`
 }
 
 type Validator struct {
@@ -368,3 +395,652 @@ type Validator struct {
 	ExitEpoch                  int64  `db:"exit_epoch"`
 	WithdrawableEpoch          int64  `db:"withdrawable_epoch"`
 }
+
+type GenesisValidator struct {
+	ValidatorIndex        uint64 `db:"validator_index"`
+	Pubkey                []byte `db:"pubkey"`
+	WithdrawalCredentials []byte `db:"withdrawal_credentials"`
+	Balance               uint64 `db:"balance"`
+	Entity                string `db:"entity"`
+}
+
+type GenesisEntityStats struct {
+	Entity     string `db:"entity"`
+	Validators uint64 `db:"validators"`
+	Balance    uint64 `db:"balance"`
+}
+
+type ValidatorEffectiveness struct {
+	Day            uint64 `db:"day"`
+	ValidatorIndex uint64 `db:"validator_index"`
+	Effectiveness  uint32 `db:"effectiveness"` // basis points (0-10000)
+	Epochs         uint32 `db:"epochs"`
+	TopPercent     uint32 `db:"top_percent"` // basis points (0-10000)
+}
+
+type SlotAttestation struct {
+	SlotRoot        []byte `db:"slot_root"`
+	SlotIndex       uint64 `db:"slot_index"`
+	SlotNumber      uint64 `db:"slot_number"`
+	Orphaned        bool   `db:"orphaned"`
+	AttSlot         uint64 `db:"att_slot"`
+	CommitteeIndex  uint64 `db:"committee_index"` // pre-electra only
+	CommitteeBits   []byte `db:"committee_bits"`  // electra+ only (EIP-7549)
+	AggregationBits []byte `db:"aggregation_bits"`
+	BeaconBlockRoot []byte `db:"beacon_block_root"`
+	SourceEpoch     uint64 `db:"source_epoch"`
+	SourceRoot      []byte `db:"source_root"`
+	TargetEpoch     uint64 `db:"target_epoch"`
+	TargetRoot      []byte `db:"target_root"`
+	Signature       []byte `db:"signature"`
+}
+
+type ExecutionBlockSlot struct {
+	Root        []byte `db:"root"`
+	Slot        uint64 `db:"slot"`
+	BlockHash   []byte `db:"block_hash"`
+	BlockNumber uint64 `db:"block_number"`
+	ForkId      uint64 `db:"fork_id"`
+	Orphaned    bool   `db:"orphaned"`
+}
+
+type SlotTag struct {
+	Root []byte `db:"root"`
+	Slot uint64 `db:"slot"`
+	Tag  string `db:"tag"`
+}
+
+type SlotTagStats struct {
+	Bucket uint64 `db:"bucket"`
+	Tag    string `db:"tag"`
+	Blocks uint64 `db:"blocks"`
+}
+
+type GraffitiStats struct {
+	GraffitiText string `db:"graffiti_text"`
+	Blocks       uint64 `db:"blocks"`
+	Proposers    uint64 `db:"proposers"`
+	LastSlot     uint64 `db:"last_slot"`
+}
+
+type BlockClientStats struct {
+	Bucket   uint64 `db:"bucket"`
+	ClClient int8   `db:"cl_client"`
+	ElClient int8   `db:"el_client"`
+	Blocks   uint64 `db:"blocks"`
+}
+
+type BlockprintSlot struct {
+	Slot        uint64  `db:"slot"`
+	Proposer    uint64  `db:"proposer"`
+	Client      int8    `db:"client"`
+	Probability float32 `db:"probability"`
+}
+
+type BlockprintDayStats struct {
+	Day    uint64 `db:"day"`
+	Client int8   `db:"client"`
+	Blocks uint64 `db:"blocks"`
+}
+
+// BlockBuilderType is the guessed origin of the execution payload of a block
+type BlockBuilderType uint8
+
+const (
+	BlockBuilderUnknown  BlockBuilderType = iota
+	BlockBuilderLocal                     // built by the execution client of the proposer
+	BlockBuilderExternal                  // built by an external block builder (mev-boost)
+)
+
+// SlotFingerprintSource flags the signals that contributed to a slot fingerprint
+type SlotFingerprintSource uint16
+
+const (
+	FingerprintSourceGraffitiVersion SlotFingerprintSource = 1 << iota // client version suffix in the graffiti
+	FingerprintSourceGraffitiName                                      // client name in the graffiti
+	FingerprintSourceExtraData                                         // execution client name in the extra data
+	FingerprintSourceRelay                                             // payload delivered by a mev relay
+	FingerprintSourceFeeRecipient                                      // fee recipient shared by several proposers
+	FingerprintSourceEmptyBlock                                        // payload without transactions
+)
+
+type SlotFingerprint struct {
+	Slot              uint64 `db:"slot"`
+	Proposer          uint64 `db:"proposer"`
+	ClClient          int8   `db:"cl_client"`
+	ClConfidence      uint8  `db:"cl_confidence"` // 0-100
+	ElClient          int8   `db:"el_client"`
+	ElConfidence      uint8  `db:"el_confidence"` // 0-100
+	BuilderType       uint8  `db:"builder_type"`
+	BuilderConfidence uint8  `db:"builder_confidence"` // 0-100
+	BuilderName       string `db:"builder_name"`
+	BuilderPubkey     []byte `db:"builder_pubkey"`
+	Sources           uint16 `db:"sources"`
+}
+
+type ValidatorIncident struct {
+	Entity             string `db:"entity"`
+	StartEpoch         uint64 `db:"start_epoch"`
+	EndEpoch           uint64 `db:"end_epoch"`
+	Validators         uint64 `db:"validators"`
+	MissedAttestations uint64 `db:"missed_attestations"`
+	MissedProposals    uint64 `db:"missed_proposals"`
+	EstimatedLoss      uint64 `db:"estimated_loss"`
+	Resolved           bool   `db:"resolved"`
+}
+
+type ClientHeadDivergence struct {
+	ClientName     string `db:"client_name"`
+	StartSlot      uint64 `db:"start_slot"`
+	EndSlot        uint64 `db:"end_slot"`
+	Reason         string `db:"reason"` // "fork", "lagging" or "finality"
+	HeadSlot       uint64 `db:"head_slot"`
+	HeadRoot       []byte `db:"head_root"`
+	CanonicalSlot  uint64 `db:"canonical_slot"`
+	CanonicalRoot  []byte `db:"canonical_root"`
+	FinalizedEpoch uint64 `db:"finalized_epoch"`
+	MaxDistance    uint64 `db:"max_distance"`
+	Resolved       bool   `db:"resolved"`
+}
+
+type EpochRewards struct {
+	Epoch                     uint64 `db:"epoch"`
+	Validators                uint64 `db:"validators"`
+	AttestationHead           int64  `db:"attestation_head"`
+	AttestationTarget         int64  `db:"attestation_target"`
+	AttestationSource         int64  `db:"attestation_source"`
+	AttestationInclusionDelay int64  `db:"attestation_inclusion_delay"`
+	AttestationInactivity     int64  `db:"attestation_inactivity"`
+	SyncCommittee             int64  `db:"sync_committee"`
+	Proposer                  int64  `db:"proposer"`
+}
+
+type BlockRewards struct {
+	Slot              uint64 `db:"slot"`
+	Proposer          uint64 `db:"proposer"`
+	Total             int64  `db:"total"`
+	Attestations      int64  `db:"attestations"`
+	SyncAggregate     int64  `db:"sync_aggregate"`
+	ProposerSlashings int64  `db:"proposer_slashings"`
+	AttesterSlashings int64  `db:"attester_slashings"`
+	ElFees            int64  `db:"el_fees"`
+	MevPayment        int64  `db:"mev_payment"`
+}
+
+type ValidatorRewards struct {
+	ValidatorIndex uint64 `db:"validator_index"`
+	Day            uint64 `db:"day"`
+	Epochs         uint32 `db:"epochs"`
+	Attestation    int64  `db:"attestation"`
+	SyncCommittee  int64  `db:"sync_committee"`
+	Proposer       int64  `db:"proposer"`
+}
+
+type FeeRecipientStats struct {
+	Proposer     uint64 `db:"proposer"`
+	FeeRecipient []byte `db:"eth_fee_recipient"`
+	Blocks       uint64 `db:"blocks"`
+	FirstSlot    uint64 `db:"first_slot"`
+	LastSlot     uint64 `db:"last_slot"`
+}
+
+// FeeRecipientUsage aggregates the canonical blocks with a specific fee recipient
+type FeeRecipientUsage struct {
+	Blocks    uint64 `db:"blocks"`
+	Proposers uint64 `db:"proposers"`
+	FirstSlot uint64 `db:"first_slot"`
+	LastSlot  uint64 `db:"last_slot"`
+}
+
+type ValidatorAnomalyType uint8
+
+const (
+	ValidatorAnomalyFeeRecipient          ValidatorAnomalyType = 1
+	ValidatorAnomalyWithdrawalCredentials ValidatorAnomalyType = 2
+	ValidatorAnomalyGraffiti              ValidatorAnomalyType = 3
+)
+
+type ValidatorAnomaly struct {
+	ValidatorIndex uint64               `db:"validator_index"`
+	Slot           uint64               `db:"slot"`
+	Type           ValidatorAnomalyType `db:"type"`
+	BeforeValue    []byte               `db:"before_value"`
+	AfterValue     []byte               `db:"after_value"`
+}
+
+type ValidatorRegistration struct {
+	ValidatorIndex uint64 `db:"validator_index"`
+	FeeRecipient   []byte `db:"fee_recipient"`
+	GasLimit       uint64 `db:"gas_limit"`
+	Timestamp      uint64 `db:"timestamp"`
+	SeenbyRelays   uint64 `db:"seenby_relays"`
+}
+
+type MissedSlotReason uint8
+
+const (
+	MissedSlotReasonOffline MissedSlotReason = 1 // no block seen for the slot
+	MissedSlotReasonReorged MissedSlotReason = 2 // block seen in time, but reorged out
+	MissedSlotReasonLate    MissedSlotReason = 3 // block seen after the attestation deadline
+)
+
+type MissedSlot struct {
+	Slot      uint64           `db:"slot"`
+	Proposer  uint64           `db:"proposer"`
+	Reason    MissedSlotReason `db:"reason"`
+	BlockRoot []byte           `db:"block_root"`
+	SeenDelay *int64           `db:"seen_delay"` // ms after slot start
+	SeenBy    uint32           `db:"seen_by"`
+}
+
+type MissedSlotReasonCount struct {
+	Reason MissedSlotReason `db:"reason"`
+	Count  uint64           `db:"count"`
+}
+
+type BlockArrival struct {
+	Slot        uint64 `db:"slot"`
+	Proposer    uint64 `db:"proposer"`
+	SeenBy      uint32 `db:"seen_by"`
+	MinDelay    int64  `db:"min_delay"`    // ms after slot start
+	MedianDelay int64  `db:"median_delay"` // ms after slot start
+	MaxDelay    int64  `db:"max_delay"`    // ms after slot start
+}
+
+type BlockArrivalClient struct {
+	Slot     uint64 `db:"slot"`
+	Client   string `db:"client"`
+	ClientId uint64 `db:"client_id"` // stable pool client id, 0 if unknown
+	Delay    int64  `db:"delay"`     // ms after slot start
+}
+
+type BlockArrivalStats struct {
+	Bucket      uint64  `db:"bucket"`
+	Proposer    uint64  `db:"proposer"`
+	ClClient    int8    `db:"cl_client"`
+	Blocks      uint64  `db:"blocks"`
+	MinDelay    float64 `db:"min_delay"`    // average of the min delays
+	MedianDelay float64 `db:"median_delay"` // average of the median delays
+	MaxDelay    float64 `db:"max_delay"`    // average of the max delays
+}
+
+type BlockArrivalClientStats struct {
+	Client   string  `db:"client"`
+	Blocks   uint64  `db:"blocks"`
+	AvgDelay float64 `db:"avg_delay"`
+	MaxDelay int64   `db:"max_delay"`
+}
+
+type DetectedSlashingType uint8
+
+const (
+	DetectedSlashingDoubleVote   DetectedSlashingType = 1 // two different votes for the same target epoch
+	DetectedSlashingSurroundVote DetectedSlashingType = 2 // one vote surrounds the other
+)
+
+type DetectedSlashing struct {
+	Validator    uint64               `db:"validator"`
+	Slot         uint64               `db:"slot"` // inclusion slot of the conflicting vote
+	Type         DetectedSlashingType `db:"type"`
+	Att1Root     []byte               `db:"att1_root"`
+	Att1Source   uint64               `db:"att1_source"`
+	Att1Target   uint64               `db:"att1_target"`
+	Att2Root     []byte               `db:"att2_root"`
+	Att2Source   uint64               `db:"att2_source"`
+	Att2Target   uint64               `db:"att2_target"`
+	Broadcasted  bool                 `db:"broadcasted"`
+	IncludedSlot *uint64              `db:"included_slot"` // slot of the on-chain slashing, if included
+}
+
+type AddressWatchEventType uint8
+
+const (
+	AddressWatchEventDeposit              AddressWatchEventType = 1
+	AddressWatchEventWithdrawalRequest    AddressWatchEventType = 2
+	AddressWatchEventConsolidationRequest AddressWatchEventType = 3
+)
+
+// AddressWatchEvent is a deposit or system request contract interaction (from deposit_txs, withdrawal_request_txs or consolidation_request_txs)
+type AddressWatchEvent struct {
+	Type            AddressWatchEventType `db:"type"`
+	BlockNumber     uint64                `db:"block_number"`
+	BlockTime       uint64                `db:"block_time"`
+	ForkId          uint64                `db:"fork_id"`
+	TxHash          []byte                `db:"tx_hash"`
+	TxSender        []byte                `db:"tx_sender"`
+	SourceAddress   []byte                `db:"source_address"`   // request source address (nil for deposits)
+	ValidatorPubkey []byte                `db:"validator_pubkey"` // deposit / withdrawal pubkey or consolidation source pubkey
+	TargetPubkey    []byte                `db:"target_pubkey"`    // consolidation target pubkey (nil otherwise)
+	Amount          int64                 `db:"amount"`           // deposit / withdrawal amount in gwei
+}
+
+// ProposerEquivocation is a pair of conflicting blocks published by the same proposer for the same slot
+type ProposerEquivocation struct {
+	Slot       uint64 `db:"slot"`
+	Proposer   uint64 `db:"proposer"`
+	BlockRoot1 []byte `db:"block_root_1"`
+	BlockRoot2 []byte `db:"block_root_2"`
+}
+
+// ValidatorProposalStats holds the aggregated proposal results of a validator
+type ValidatorProposalStats struct {
+	Proposer uint64 `db:"proposer"`
+	Proposed uint64 `db:"proposed"`
+	Orphaned uint64 `db:"orphaned"`
+	Missed   uint64 `db:"missed"`
+}
+
+// SupplyEpoch holds the consensus layer issuance & execution layer fee burn of a finalized epoch (in gwei)
+type SupplyEpoch struct {
+	Epoch  uint64 `db:"epoch"`
+	Day    uint64 `db:"day"`
+	Blocks uint32 `db:"blocks"`
+	Issued int64  `db:"issued"`
+	Burned int64  `db:"burned"`
+}
+
+// SupplyDay is the daily rollup of SupplyEpoch
+type SupplyDay struct {
+	Day    uint64 `db:"day"`
+	Epochs uint32 `db:"epochs"`
+	Blocks uint32 `db:"blocks"`
+	Issued int64  `db:"issued"`
+	Burned int64  `db:"burned"`
+}
+
+// ProposerReassignment is a proposer duty that changed because a reorg switched the dependent root of the epoch
+type ProposerReassignment struct {
+	Slot             uint64 `db:"slot"`
+	Epoch            uint64 `db:"epoch"`
+	SwitchSlot       uint64 `db:"switch_slot"` // wallclock slot the new assignment became canonical
+	OldProposer      uint64 `db:"old_proposer"`
+	NewProposer      uint64 `db:"new_proposer"`
+	OldDependentRoot []byte `db:"old_dependent_root"`
+	NewDependentRoot []byte `db:"new_dependent_root"`
+}
+
+// AttestationMisses holds the missed & late (delayed inclusion) attestations of an entity in a finalized epoch by probable cause
+type AttestationMisses struct {
+	Epoch            uint64 `db:"epoch"`
+	Entity           string `db:"entity"`
+	MissedLateBlock  uint32 `db:"missed_late_block"`
+	MissedAttester   uint32 `db:"missed_attester"`
+	DelayedLateBlock uint32 `db:"delayed_late_block"`
+	DelayedAttester  uint32 `db:"delayed_attester"`
+}
+
+// AttestationMissStats is the aggregation of AttestationMisses per entity over a range of epochs
+type AttestationMissStats struct {
+	Entity           string `db:"entity"`
+	Epochs           uint64 `db:"epochs"`
+	MissedLateBlock  uint64 `db:"missed_late_block"`
+	MissedAttester   uint64 `db:"missed_attester"`
+	DelayedLateBlock uint64 `db:"delayed_late_block"`
+	DelayedAttester  uint64 `db:"delayed_attester"`
+}
+
+// PoolClient is a configured consensus or execution client with a stable id
+type PoolClient struct {
+	ClientId    uint64 `db:"client_id"`
+	Layer       uint8  `db:"layer"` // 1 = consensus, 2 = execution
+	Name        string `db:"name"`
+	ClientType  int8   `db:"client_type"`
+	Fingerprint []byte `db:"fingerprint"` // hash of the redacted endpoint url
+	FirstSeen   uint64 `db:"first_seen"`
+	LastSeen    uint64 `db:"last_seen"`
+}
+
+// PoolClientVersion is a version reported by a pool client
+type PoolClientVersion struct {
+	ClientId  uint64 `db:"client_id"`
+	Version   string `db:"version"`
+	FirstSeen uint64 `db:"first_seen"`
+	LastSeen  uint64 `db:"last_seen"`
+}
+
+// EpochFinalityVotes holds the target vote map of a finalized epoch.
+// the bitfields are indexed by validator index (bit i%8 of byte i/8) and stored compressed.
+type EpochFinalityVotes struct {
+	Epoch         uint64 `db:"epoch"`
+	TargetRoot    []byte `db:"target_root"`
+	ActiveCount   uint64 `db:"active_count"`
+	VotedCount    uint64 `db:"voted_count"`
+	ActiveBalance uint64 `db:"active_balance"`
+	VotedBalance  uint64 `db:"voted_balance"`
+	ActiveBits    []byte `db:"active_bits"`
+	VotedBits     []byte `db:"voted_bits"`
+}
+
+type RequestFeeStats struct {
+	Bucket   uint64 `db:"bucket"`
+	Requests uint64 `db:"requests"`
+	MinFee   uint64 `db:"min_fee"`
+	MaxFee   uint64 `db:"max_fee"`
+	AvgFee   uint64 `db:"avg_fee"`
+}
+
+type DutyMismatchType uint8
+
+const (
+	DutyMismatchProposer DutyMismatchType = 1 // proposer of a slot differs
+	DutyMismatchAttester DutyMismatchType = 2 // members of an attestation committee differ
+)
+
+type DutyMismatch struct {
+	Epoch          uint64           `db:"epoch"`
+	ClientId       uint64           `db:"client_id"`
+	DutyType       DutyMismatchType `db:"duty_type"`
+	Slot           uint64           `db:"slot"`
+	CommitteeIndex uint64           `db:"committee_index"` // 0 for proposer duties
+	ClientName     string           `db:"client_name"`
+	DependentRoot  []byte           `db:"dependent_root"`
+	Expected       string           `db:"expected"` // comma separated validator indices computed by the explorer
+	Reported       string           `db:"reported"` // comma separated validator indices reported by the client
+	DetectedAt     uint64           `db:"detected_at"`
+}
+
+type PayloadStatus uint8
+
+const (
+	PayloadStatusUnknown PayloadStatus = 0
+	PayloadStatusValid   PayloadStatus = 1 // payload verified by the clients execution engine
+	PayloadStatusSyncing PayloadStatus = 2 // block imported optimistically, payload not verified yet
+	PayloadStatusInvalid PayloadStatus = 3 // optimistically imported block dropped after the execution engine caught up
+)
+
+type BlockPayloadStatus struct {
+	Root       []byte        `db:"root"`
+	Slot       uint64        `db:"slot"`
+	ClientId   uint64        `db:"client_id"`
+	ClientName string        `db:"client_name"`
+	Status     PayloadStatus `db:"status"`
+}
+
+type OnlineMigration struct {
+	Name        string `db:"name"`
+	TableName   string `db:"table_name"`
+	KeyColumn   string `db:"key_column"`   // integer column the backfill is chunked by
+	BackfillSql string `db:"backfill_sql"` // statement to backfill a key range ($1: first key, $2: last key, inclusive)
+	FinalizeSql string `db:"finalize_sql"` // optional statement executed outside of a transaction after the backfill
+	ChunkSize   uint64 `db:"chunk_size"`
+	FirstKey    *int64 `db:"first_key"` // nil until the backfill is started
+	NextKey     *int64 `db:"next_key"`
+	MaxKey      *int64 `db:"max_key"` // highest key at backfill start, newer rows are written with the new schema
+	RowsDone    uint64 `db:"rows_done"`
+	StartedAt   uint64 `db:"started_at"`
+	FinishedAt  uint64 `db:"finished_at"`
+	LastError   string `db:"last_error"`
+}
+
+type EffectiveBalanceChange struct {
+	ValidatorIndex      uint64 `db:"validator_index"`
+	Epoch               uint64 `db:"epoch"` // first epoch with the new effective balance
+	OldEffectiveBalance uint64 `db:"old_effective_balance"`
+	NewEffectiveBalance uint64 `db:"new_effective_balance"`
+	Compounding         bool   `db:"compounding"` // validator has compounding (0x02) withdrawal credentials
+}
+
+type EffectiveBalanceStats struct {
+	Epoch            uint64 `db:"epoch"`
+	Bucket           uint64 `db:"bucket"` // lower bound of the effective balance bucket in ETH
+	Validators       uint64 `db:"validators"`
+	Compounding      uint64 `db:"compounding"`
+	EffectiveBalance uint64 `db:"effective_balance"`
+}
+
+// Entity is an operator managed label for a group of validators
+type Entity struct {
+	EntityId    uint64 `db:"entity_id"`
+	Name        string `db:"name"`
+	Description string `db:"description"`
+	Links       string `db:"links"` // json encoded list of EntityLink
+	Created     uint64 `db:"created"`
+	Updated     uint64 `db:"updated"`
+}
+
+// EntityLink is an external link shown on the entity page
+type EntityLink struct {
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+// EntityValidatorRange is a range of validator indexes (inclusive) that belongs to an entity
+type EntityValidatorRange struct {
+	EntityId uint64 `db:"entity_id"`
+	MinIndex uint64 `db:"min_index"`
+	MaxIndex uint64 `db:"max_index"`
+}
+
+type EntityAddressType uint8
+
+const (
+	EntityAddressWithdrawal    EntityAddressType = 1
+	EntityAddressDepositOrigin EntityAddressType = 2
+	EntityAddressDepositTarget EntityAddressType = 3
+)
+
+// EntityAddress is a withdrawal or deposit address that belongs to an entity
+type EntityAddress struct {
+	EntityId    uint64            `db:"entity_id"`
+	AddressType EntityAddressType `db:"address_type"`
+	Address     []byte            `db:"address"`
+}
+
+// BlobTransaction is a blob carrying transaction of a finalized canonical block
+type BlobTransaction struct {
+	Slot      uint64 `db:"slot"`
+	TxIndex   uint32 `db:"tx_index"`
+	TxHash    []byte `db:"tx_hash"`
+	Sender    []byte `db:"sender"`
+	ToAddress []byte `db:"to_address"`
+	BlobCount uint16 `db:"blob_count"`
+}
+
+// BlobTransactionStats holds the blob usage of a sender / target address combination within a bucket of slots
+type BlobTransactionStats struct {
+	Bucket    uint64 `db:"bucket"`
+	Sender    []byte `db:"sender"`
+	ToAddress []byte `db:"to_address"`
+	TxCount   uint64 `db:"tx_count"`
+	BlobCount uint64 `db:"blob_count"`
+	LastSlot  uint64 `db:"last_slot"`
+}
+
+// DataColumnAvailability holds the data column sidecars a consensus client had available for a block.
+// the custody & available columns are bitfields with one bit per column index.
+type DataColumnAvailability struct {
+	BlockRoot        []byte `db:"block_root"`
+	ClientName       string `db:"client_name"`
+	Slot             uint64 `db:"slot"`
+	BlobCount        uint16 `db:"blob_count"`
+	NodeId           []byte `db:"node_id"`
+	CustodyColumns   []byte `db:"custody_columns"`
+	AvailableColumns []byte `db:"available_columns"`
+	FetchError       string `db:"fetch_error"`
+}
+
+// BlobVerification holds the kzg verification result of the blob sidecars a consensus client served for a block.
+// the result describes the failed sidecars, it's empty if all served sidecars are valid.
+type BlobVerification struct {
+	BlockRoot    []byte `db:"block_root"`
+	ClientName   string `db:"client_name"`
+	Slot         uint64 `db:"slot"`
+	BlobCount    uint16 `db:"blob_count"`
+	ValidCount   uint16 `db:"valid_count"`
+	InvalidCount uint16 `db:"invalid_count"`
+	Result       string `db:"result"`
+}
+
+type LightClientUpdateStatus uint8
+
+const (
+	LightClientUpdateUnverified LightClientUpdateStatus = 0 // signing or next sync committee not known yet, only the branches have been checked
+	LightClientUpdateValid      LightClientUpdateStatus = 1 // all checks passed
+	LightClientUpdateInvalid    LightClientUpdateStatus = 2 // at least one check failed
+)
+
+// LightClientUpdate holds the best light client update of a sync committee period served by a consensus client.
+// the result describes the failed checks or the reason the update could not be fully verified.
+type LightClientUpdate struct {
+	Period            uint64                  `db:"period"`
+	ClientName        string                  `db:"client_name"`
+	Version           string                  `db:"version"`
+	AttestedSlot      uint64                  `db:"attested_slot"`
+	AttestedRoot      []byte                  `db:"attested_root"`
+	FinalizedSlot     uint64                  `db:"finalized_slot"`
+	FinalizedRoot     []byte                  `db:"finalized_root"`
+	SignatureSlot     uint64                  `db:"signature_slot"`
+	Participation     uint32                  `db:"participation"`
+	NextCommitteeRoot []byte                  `db:"next_committee_root"`
+	Status            LightClientUpdateStatus `db:"status"`
+	Result            string                  `db:"result"`
+	Data              []byte                  `db:"data"` // raw json update as served by the client
+	UpdatedAt         uint64                  `db:"updated_at"`
+}
+
+type LightClientHeadUpdateType uint8
+
+const (
+	LightClientFinalityUpdate   LightClientHeadUpdateType = 1
+	LightClientOptimisticUpdate LightClientHeadUpdateType = 2
+)
+
+// LightClientHeadUpdate holds the latest light client finality or optimistic update of a sync committee period served by a consensus client.
+type LightClientHeadUpdate struct {
+	Period        uint64                    `db:"period"`
+	ClientName    string                    `db:"client_name"`
+	UpdateType    LightClientHeadUpdateType `db:"update_type"`
+	Version       string                    `db:"version"`
+	AttestedSlot  uint64                    `db:"attested_slot"`
+	AttestedRoot  []byte                    `db:"attested_root"`
+	FinalizedSlot uint64                    `db:"finalized_slot"`
+	FinalizedRoot []byte                    `db:"finalized_root"`
+	SignatureSlot uint64                    `db:"signature_slot"`
+	Participation uint32                    `db:"participation"`
+	Status        LightClientUpdateStatus   `db:"status"`
+	Result        string                    `db:"result"`
+	Data          []byte                    `db:"data"` // raw json update as served by the client
+	UpdatedAt     uint64                    `db:"updated_at"`
+}
+
+// EpochRangeStats holds the aggregated epoch stats of a range of finalized epochs
+type EpochRangeStats struct {
+	Epochs              uint64  `db:"epochs"`
+	FirstEpoch          uint64  `db:"first_epoch"`
+	LastEpoch           uint64  `db:"last_epoch"`
+	FirstValidatorCount uint64  `db:"first_validator_count"`
+	LastValidatorCount  uint64  `db:"last_validator_count"`
+	Eligible            uint64  `db:"eligible"`
+	VotedTarget         uint64  `db:"voted_target"`
+	VotedHead           uint64  `db:"voted_head"`
+	VotedTotal          uint64  `db:"voted_total"`
+	BlockCount          uint64  `db:"block_count"`
+	OrphanedCount       uint64  `db:"orphaned_count"`
+	SyncParticipation   float64 `db:"sync_participation"`
+}
+
+// BlockValueStats holds the summed proposer value (consensus rewards + mev payment or priority fees) of a range of blocks
+type BlockValueStats struct {
+	Blocks     uint64 `db:"blocks"`
+	TotalValue int64  `db:"total_value"`
+}
@@ -20,11 +20,11 @@ type BlockHead struct {
 	ForkId     uint64 `db:"fork_id"`
 }
 
-type AssignedBlob struct {
-	Root       []byte `db:"root"`
-	Commitment []byte `db:"commitment"`
-	Slot       uint64 `db:"slot"`
-	Blob       *Blob  `db:"blob"`
+// ListCursor is a keyset pagination cursor for listings ordered descending by Key.
+// the next page starts with the rows having a key <= Key, skipping the first Skip rows with exactly that key (already returned).
+type ListCursor struct {
+	Key  uint64
+	Skip uint64
 }
 
 type UnfinalizedBlockFilter struct {
@@ -38,8 +38,15 @@ type BlockFilter struct {
 	ExtraData     string
 	ProposerIndex *uint64
 	ProposerName  string
+	FeeRecipient  []byte
+	ClClient      int8   // fingerprinted consensus client (0 = any)
+	ElClient      int8   // fingerprinted execution client (0 = any)
+	BuilderType   uint8  // fingerprinted block builder type (0 = any)
+	MinConfidence uint8  // min confidence of the fingerprint filters
+	Tag           string // config defined block tag
 	WithOrphaned  uint8
 	WithMissing   uint8
+	Cursor        *ListCursor
 }
 
 type MevBlockFilter struct {
@@ -65,6 +72,8 @@ type DepositTxFilter struct {
 	MaxAmount     uint64
 	WithOrphaned  uint8
 	WithValid     uint8
+	WithProblems  uint8
+	Cursor        *ListCursor
 }
 
 type DepositFilter struct {
@@ -75,6 +84,7 @@ type DepositFilter struct {
 	MinAmount     uint64
 	MaxAmount     uint64
 	WithOrphaned  uint8
+	Cursor        *ListCursor
 }
 
 type VoluntaryExitFilter struct {
@@ -175,8 +185,57 @@ type ValidatorFilter struct {
 	WithdrawalAddress []byte
 	ValidatorName     string
 	Status            []v1.ValidatorState
+	MinBalance        *uint64 // balance (gwei), effective balance in db queries
+	MaxBalance        *uint64 // balance (gwei), effective balance in db queries
+	MinActivation     *uint64
+	MaxActivation     *uint64
+	CredentialsPrefix []byte // leading bytes of the withdrawal credentials (e.g. 0x01 for execution withdrawal credentials)
 
 	OrderBy ValidatorOrder
 	Limit   uint64
 	Offset  uint64
 }
+
+type GenesisValidatorFilter struct {
+	Entity    *string
+	PublicKey []byte
+	MinIndex  *uint64
+	MaxIndex  *uint64
+}
+
+type ValidatorAnomalyFilter struct {
+	ValidatorIndex *uint64
+	Type           ValidatorAnomalyType
+	MinSlot        uint64
+}
+
+type DetectedSlashingFilter struct {
+	Validator *uint64
+	Type      DetectedSlashingType
+	MinSlot   uint64
+}
+
+type DutyMismatchFilter struct {
+	ClientId *uint64
+	DutyType DutyMismatchType
+	MinEpoch uint64
+}
+
+type AddressWatchFilter struct {
+	Addresses [][]byte // matches tx sender or request source address
+	Type      AddressWatchEventType
+	MinBlock  uint64
+}
+
+type MissedSlotFilter struct {
+	MinSlot    uint64
+	MaxSlot    uint64
+	Proposer   *uint64
+	WithReason MissedSlotReason
+}
+
+type ValidatorIncidentFilter struct {
+	Entity   string
+	MinEpoch uint64
+	Resolved *bool
+}
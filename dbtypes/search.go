@@ -24,14 +24,6 @@ type SearchAheadSlotsResult []struct {
 	Status SlotStatus `db:"status"`
 }
 
-type SearchAheadExecBlocksResult []struct {
-	Slot       uint64     `db:"slot"`
-	Root       []byte     `db:"root"`
-	ExecHash   []byte     `db:"eth_block_hash"`
-	ExecNumber uint64     `db:"eth_block_number"`
-	Status     SlotStatus `db:"status"`
-}
-
 type SearchAheadGraffitiResult []struct {
 	Graffiti string `db:"graffiti"`
 	Count    uint64 `db:"count"`
@@ -13,8 +13,20 @@ type IndexerForkState struct {
 	Finalized uint64 `json:"finalized"`
 }
 
+type IndexerNetworkState struct {
+	ConfigName            string `json:"config_name"`
+	GenesisTime           uint64 `json:"genesis_time"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	GenesisForkVersion    string `json:"genesis_fork_version"`
+}
+
 type DepositIndexerState struct {
 	FinalBlock   uint64 `json:"final_block"`
 	HeadBlock    uint64 `json:"head_block"`
 	DepositIndex uint64 `json:"deposit_index"`
 }
+
+type GenesisValidatorsState struct {
+	Loaded     bool   `json:"loaded"`
+	Validators uint64 `json:"validators"`
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+)
+
+// addressPageMaxValidators is the maximum number of withdrawal validators shown on the address page
+const addressPageMaxValidators = 100
+
+// Address will return the execution "address" page using a go template
+func Address(w http.ResponseWriter, r *http.Request) {
+	var addressTemplateFiles = append(layoutTemplateFiles,
+		"address/address.html",
+	)
+	var notfoundTemplateFiles = append(layoutTemplateFiles,
+		"address/notfound.html",
+	)
+
+	vars := mux.Vars(r)
+	if !common.IsHexAddress(vars["address"]) {
+		data := InitPageData(w, r, "blockchain", "/slots", "Address not found", notfoundTemplateFiles)
+		w.Header().Set("Content-Type", "text/html")
+		if handleTemplateError(w, r, "address.go", "Address", "notFound", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+			return // an error has occurred and was processed
+		}
+		return
+	}
+	address := common.HexToAddress(vars["address"])
+
+	var pageTemplate = templates.GetTemplate(addressTemplateFiles...)
+	data := InitPageData(w, r, "blockchain", "/slots", fmt.Sprintf("Address %v", address.String()), addressTemplateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getAddressPageData(address.Bytes())
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "address.go", "Address", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getAddressPageData(address []byte) (*models.AddressPageData, error) {
+	pageData := &models.AddressPageData{}
+	pageCacheKey := fmt.Sprintf("address:%x", address)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildAddressPageData(address)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.AddressPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildAddressPageData(address []byte) (*models.AddressPageData, time.Duration) {
+	logrus.Debugf("address page called: 0x%x", address)
+	chainState := services.GlobalBeaconService.GetChainState()
+	pageData := &models.AddressPageData{
+		Address:      address,
+		Label:        services.GlobalBeaconService.GetAddressLabel(address),
+		ShowDeposits: services.IsDepositSenderSearchAllowed(),
+	}
+
+	// validators withdrawing to the address
+	validators, validatorTotal := services.GlobalBeaconService.GetFilteredValidatorSet(&dbtypes.ValidatorFilter{
+		WithdrawalAddress: address,
+		OrderBy:           dbtypes.ValidatorOrderIndexAsc,
+		Limit:             addressPageMaxValidators,
+	}, true)
+	pageData.WithdrawalValidators = make([]*models.AddressPageDataValidator, 0, len(validators))
+	for _, validator := range validators {
+		pageData.WithdrawalValidators = append(pageData.WithdrawalValidators, &models.AddressPageDataValidator{
+			Index:   uint64(validator.Index),
+			Name:    services.GlobalBeaconService.GetValidatorName(uint64(validator.Index)),
+			State:   validator.Status.String(),
+			Balance: uint64(validator.Balance),
+		})
+	}
+	pageData.WithdrawalValidatorCount = uint64(len(pageData.WithdrawalValidators))
+	pageData.WithdrawalValidatorTotal = validatorTotal
+
+	// fee recipient usage
+	feeRecipientUsage, err := db.GetFeeRecipientUsage(address)
+	if err == nil {
+		pageData.FeeRecipientBlocks = feeRecipientUsage.Blocks
+		pageData.FeeRecipientProposers = feeRecipientUsage.Proposers
+		pageData.FeeRecipientFirstSlot = feeRecipientUsage.FirstSlot
+		pageData.FeeRecipientLastSlot = feeRecipientUsage.LastSlot
+		pageData.FeeRecipientFirstTime = chainState.SlotToTime(phase0.Slot(feeRecipientUsage.FirstSlot))
+		pageData.FeeRecipientLastTime = chainState.SlotToTime(phase0.Slot(feeRecipientUsage.LastSlot))
+	}
+
+	pageData.RecentBlocks = make([]*models.AddressPageDataBlock, 0)
+	blocksData := services.GlobalBeaconService.GetDbBlocksByFilter(&dbtypes.BlockFilter{
+		FeeRecipient: address,
+		WithOrphaned: 1,
+	}, 0, 10, chainState.GetSpecs().SlotsPerEpoch)
+	for _, blockData := range blocksData {
+		if blockData.Block == nil {
+			continue
+		}
+		blockEntry := &models.AddressPageDataBlock{
+			Epoch:        uint64(chainState.EpochOfSlot(phase0.Slot(blockData.Slot))),
+			Slot:         blockData.Slot,
+			Ts:           chainState.SlotToTime(phase0.Slot(blockData.Slot)),
+			Orphaned:     blockData.Block.Status == dbtypes.Orphaned,
+			Proposer:     blockData.Proposer,
+			ProposerName: services.GlobalBeaconService.GetValidatorName(blockData.Proposer),
+			BlockRoot:    blockData.Block.Root,
+		}
+		if blockData.Block.EthBlockNumber != nil {
+			blockEntry.WithEthBlock = true
+			blockEntry.EthBlock = *blockData.Block.EthBlockNumber
+		}
+		pageData.RecentBlocks = append(pageData.RecentBlocks, blockEntry)
+	}
+	pageData.RecentBlockCount = uint64(len(pageData.RecentBlocks))
+
+	// deposit & system request contract interactions
+	canonicalForkIds := services.GlobalBeaconService.GetCanonicalForkIds()
+	loadRequests := func(eventType dbtypes.AddressWatchEventType) ([]*models.AddressPageDataRequest, uint64) {
+		events, total, err := db.GetAddressWatchEvents(0, 10, &dbtypes.AddressWatchFilter{
+			Addresses: [][]byte{address},
+			Type:      eventType,
+		})
+		requests := make([]*models.AddressPageDataRequest, 0, len(events))
+		if err != nil {
+			return requests, 0
+		}
+
+		for _, event := range events {
+			request := &models.AddressPageDataRequest{
+				BlockNumber:     event.BlockNumber,
+				Time:            time.Unix(int64(event.BlockTime), 0),
+				Orphaned:        !slices.Contains(canonicalForkIds, event.ForkId),
+				TxHash:          event.TxHash,
+				TxSender:        event.TxSender,
+				SourceAddress:   event.SourceAddress,
+				ValidatorPubkey: event.ValidatorPubkey,
+				TargetPubkey:    event.TargetPubkey,
+			}
+			if event.Amount > 0 {
+				request.Amount = uint64(event.Amount)
+			}
+			requests = append(requests, request)
+		}
+		return requests, total
+	}
+
+	if pageData.ShowDeposits {
+		pageData.Deposits, pageData.DepositTotal = loadRequests(dbtypes.AddressWatchEventDeposit)
+		pageData.DepositCount = uint64(len(pageData.Deposits))
+	}
+	pageData.WithdrawalRequests, pageData.WithdrawalRequestTotal = loadRequests(dbtypes.AddressWatchEventWithdrawalRequest)
+	pageData.WithdrawalRequestCount = uint64(len(pageData.WithdrawalRequests))
+	pageData.ConsolidationRequests, pageData.ConsolidationRequestTotal = loadRequests(dbtypes.AddressWatchEventConsolidationRequest)
+	pageData.ConsolidationRequestCount = uint64(len(pageData.ConsolidationRequests))
+
+	return pageData, 1 * time.Minute
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// ApiAddressWatch returns the deposit & system request contract interactions of the watched addresses, newest first.
+// supported filters: address (must be a watched address), type (deposit / withdrawal_request / consolidation_request)
+func ApiAddressWatch(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	if !utils.Config.AddressWatch.Enabled {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "address watch is not enabled")
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	paging, err := parseApiPaging(urlArgs, 50, 100)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	watchFilter := &dbtypes.AddressWatchFilter{}
+	for _, address := range services.GetWatchedAddresses(nil) {
+		watchFilter.Addresses = append(watchFilter.Addresses, address.Bytes())
+	}
+	if urlArgs.Has("address") {
+		addressStr := urlArgs.Get("address")
+		if !common.IsHexAddress(addressStr) {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid address")
+			return
+		}
+
+		address := common.HexToAddress(addressStr).Bytes()
+		isWatched := false
+		for _, watchedAddress := range watchFilter.Addresses {
+			if bytes.Equal(watchedAddress, address) {
+				isWatched = true
+				break
+			}
+		}
+		if !isWatched {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "address is not watched")
+			return
+		}
+
+		watchFilter.Addresses = [][]byte{address}
+	}
+	switch urlArgs.Get("type") {
+	case "":
+	case "deposit":
+		watchFilter.Type = dbtypes.AddressWatchEventDeposit
+	case "withdrawal_request":
+		watchFilter.Type = dbtypes.AddressWatchEventWithdrawalRequest
+	case "consolidation_request":
+		watchFilter.Type = dbtypes.AddressWatchEventConsolidationRequest
+	default:
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid type filter (expected: deposit, withdrawal_request or consolidation_request)")
+		return
+	}
+
+	events, totalRows, err := db.GetAddressWatchEvents(paging.offset(), uint32(paging.limit), watchFilter)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load address activity")
+		return
+	}
+
+	response := &apitypes.ApiAddressWatchResponse{
+		Events:     make([]*apitypes.ApiAddressWatchEvent, 0, len(events)),
+		Pagination: paging.getPagination(&totalRows, false),
+	}
+
+	canonicalForkIds := map[uint64]bool{}
+	for _, forkId := range services.GlobalBeaconService.GetCanonicalForkIds() {
+		canonicalForkIds[forkId] = true
+	}
+
+	for _, event := range events {
+		apiEvent := &apitypes.ApiAddressWatchEvent{
+			Type:        services.GetAddressWatchEventTypeKey(event.Type),
+			BlockNumber: event.BlockNumber,
+			BlockTime:   time.Unix(int64(event.BlockTime), 0),
+			TxHash:      fmt.Sprintf("0x%x", event.TxHash),
+			TxSender:    common.BytesToAddress(event.TxSender).Hex(),
+			Amount:      event.Amount,
+			Orphaned:    !canonicalForkIds[event.ForkId],
+		}
+		if len(event.SourceAddress) > 0 {
+			apiEvent.SourceAddress = common.BytesToAddress(event.SourceAddress).Hex()
+		}
+		if len(event.ValidatorPubkey) > 0 {
+			apiEvent.ValidatorPubkey = fmt.Sprintf("0x%x", event.ValidatorPubkey)
+		}
+		if len(event.TargetPubkey) > 0 {
+			apiEvent.TargetPubkey = fmt.Sprintf("0x%x", event.TargetPubkey)
+		}
+
+		response.Events = append(response.Events, apiEvent)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
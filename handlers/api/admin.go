@@ -0,0 +1,36 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/ethpandaops/dora/utils"
+)
+
+// checkAdminAuth validates the admin bearer token and writes an error response if the request is not authorized
+func checkAdminAuth(w http.ResponseWriter, r *http.Request, route string) bool {
+	adminToken := utils.Config.Api.AdminToken
+	if adminToken == "" {
+		sendErrorResponse(w, route, http.StatusNotFound, "admin api disabled")
+		return false
+	}
+
+	if !isAdminRequest(r) {
+		sendErrorResponse(w, route, http.StatusUnauthorized, "unauthorized")
+		return false
+	}
+
+	return true
+}
+
+// isAdminRequest returns true if the request carries a valid admin bearer token
+func isAdminRequest(r *http.Request) bool {
+	adminToken := utils.Config.Api.AdminToken
+	if adminToken == "" {
+		return false
+	}
+
+	authToken, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return found && subtle.ConstantTimeCompare([]byte(authToken), []byte(adminToken)) == 1
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiAdminBlockCache returns (GET) or updates (POST) the block cache retention settings
+func ApiAdminBlockCache(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/blockcache"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	indexer := services.GlobalBeaconService.GetBeaconIndexer()
+	settings := indexer.GetBlockCacheSettings()
+
+	if r.Method == http.MethodPost {
+		update := &apitypes.ApiAdminBlockCacheUpdate{}
+		err := json.NewDecoder(r.Body).Decode(update)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusBadRequest, "invalid request body")
+			return
+		}
+
+		if update.InMemoryEpochs != nil {
+			settings.InMemoryEpochs = *update.InMemoryEpochs
+		}
+		if update.BodyEpochs != nil {
+			settings.BodyEpochs = *update.BodyEpochs
+		}
+		if update.MaxForkBlockBodies != nil {
+			settings.MaxForkBlockBodies = *update.MaxForkBlockBodies
+		}
+
+		err = indexer.SetBlockCacheSettings(settings)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	sendOKResponse(w, route, &apitypes.ApiAdminBlockCacheResponse{
+		Settings: settings,
+		Stats:    indexer.GetBlockCacheStats(),
+	})
+}
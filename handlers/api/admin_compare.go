@@ -0,0 +1,229 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// maxCompareEpochRange is the max number of epochs compared per request
+const maxCompareEpochRange = 1000
+
+// ApiAdminCompare compares the finalized epoch aggregates (epoch totals, block counts, deposit counts, ...) against another dora instance.
+// only epochs that are finalized on both instances are compared. the remote instance is queried via its public epoch aggregates api.
+func ApiAdminCompare(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/compare"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	remoteUrl, err := url.Parse(urlArgs.Get("remote"))
+	if err != nil || (remoteUrl.Scheme != "http" && remoteUrl.Scheme != "https") || remoteUrl.Host == "" {
+		sendErrorResponse(w, route, http.StatusBadRequest, "invalid remote url")
+		return
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	remoteFinalized, _, err := loadRemoteEpochAggregates(client, remoteUrl, nil, nil)
+	if err != nil {
+		sendErrorResponse(w, route, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	// compare epochs that are finalized on both instances
+	localFinalized, _ := services.GlobalBeaconService.GetChainState().GetFinalizedCheckpoint()
+	finalizedEpoch := uint64(localFinalized)
+	if remoteFinalized < finalizedEpoch {
+		finalizedEpoch = remoteFinalized
+	}
+	if finalizedEpoch == 0 {
+		sendErrorResponse(w, route, http.StatusBadRequest, "no finalized epochs to compare")
+		return
+	}
+
+	maxEpoch := finalizedEpoch - 1
+	if urlArgs.Has("max_epoch") {
+		maxEpoch, err = strconv.ParseUint(urlArgs.Get("max_epoch"), 10, 64)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusBadRequest, "invalid max_epoch")
+			return
+		}
+		if maxEpoch >= finalizedEpoch {
+			maxEpoch = finalizedEpoch - 1
+		}
+	}
+
+	minEpoch := uint64(0)
+	if maxEpoch >= maxEpochAggregatesRange {
+		minEpoch = maxEpoch - maxEpochAggregatesRange + 1
+	}
+	if urlArgs.Has("min_epoch") {
+		minEpoch, err = strconv.ParseUint(urlArgs.Get("min_epoch"), 10, 64)
+		if err != nil || minEpoch > maxEpoch {
+			sendErrorResponse(w, route, http.StatusBadRequest, "invalid min_epoch")
+			return
+		}
+		if !urlArgs.Has("max_epoch") && maxEpoch-minEpoch >= maxCompareEpochRange {
+			maxEpoch = minEpoch + maxCompareEpochRange - 1
+		}
+		if maxEpoch-minEpoch >= maxCompareEpochRange {
+			sendErrorResponse(w, route, http.StatusBadRequest, fmt.Sprintf("epoch range too large (max %v epochs)", maxCompareEpochRange))
+			return
+		}
+	}
+
+	response := &apitypes.ApiAdminCompareResponse{
+		Remote:        utils.GetRedactedUrl(remoteUrl.String()),
+		MinEpoch:      minEpoch,
+		MaxEpoch:      maxEpoch,
+		MissingLocal:  []uint64{},
+		MissingRemote: []uint64{},
+		Divergences:   []*apitypes.ApiAdminCompareDivergence{},
+	}
+
+	// load the aggregates from both instances in chunks (newest first)
+	for chunkMax := maxEpoch + 1; chunkMax > minEpoch; {
+		chunkMin := minEpoch
+		if chunkMax-chunkMin > maxEpochAggregatesRange {
+			chunkMin = chunkMax - maxEpochAggregatesRange
+		}
+
+		rangeMin, rangeMax := chunkMin, chunkMax-1
+		_, remoteEpochs, err := loadRemoteEpochAggregates(client, remoteUrl, &rangeMin, &rangeMax)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusBadGateway, err.Error())
+			return
+		}
+
+		remoteMap := map[uint64]*apitypes.ApiEpochAggregate{}
+		for _, epoch := range remoteEpochs {
+			remoteMap[epoch.Epoch] = epoch
+		}
+
+		localMap := map[uint64]*apitypes.ApiEpochAggregate{}
+		for _, epoch := range db.GetEpochs(rangeMax, uint32(rangeMax-rangeMin+1)) {
+			if epoch.Epoch < rangeMin {
+				break
+			}
+			localMap[epoch.Epoch] = buildApiEpochAggregate(epoch)
+		}
+
+		for epoch := rangeMax + 1; epoch > rangeMin; epoch-- {
+			localEpoch := localMap[epoch-1]
+			remoteEpoch := remoteMap[epoch-1]
+			switch {
+			case localEpoch == nil && remoteEpoch == nil:
+				continue
+			case localEpoch == nil:
+				response.MissingLocal = append(response.MissingLocal, epoch-1)
+				continue
+			case remoteEpoch == nil:
+				response.MissingRemote = append(response.MissingRemote, epoch-1)
+				continue
+			}
+
+			response.ComparedEpochs++
+			remoteFields := getEpochAggregateFields(remoteEpoch)
+			divergent := false
+			for i, localField := range getEpochAggregateFields(localEpoch) {
+				if localField.value == remoteFields[i].value {
+					continue
+				}
+
+				divergent = true
+				response.Divergences = append(response.Divergences, &apitypes.ApiAdminCompareDivergence{
+					Epoch:  epoch - 1,
+					Field:  localField.name,
+					Local:  localField.value,
+					Remote: remoteFields[i].value,
+				})
+			}
+			if divergent {
+				response.DivergentEpochs++
+			}
+		}
+
+		chunkMax = chunkMin
+	}
+
+	sendOKResponse(w, route, response)
+}
+
+// loadRemoteEpochAggregates loads the finalized epoch aggregates from the public api of another dora instance.
+// returns the finalized epoch of the remote instance and the aggregates in the requested range.
+func loadRemoteEpochAggregates(client *http.Client, remoteUrl *url.URL, minEpoch *uint64, maxEpoch *uint64) (uint64, []*apitypes.ApiEpochAggregate, error) {
+	apiUrl := *remoteUrl
+	apiUrl.Path = path.Join(apiUrl.Path, "/api/v1/epochs")
+	query := url.Values{}
+	if minEpoch != nil {
+		query.Set("min_epoch", strconv.FormatUint(*minEpoch, 10))
+	}
+	if maxEpoch != nil {
+		query.Set("max_epoch", strconv.FormatUint(*maxEpoch, 10))
+	}
+	apiUrl.RawQuery = query.Encode()
+
+	resp, err := client.Get(apiUrl.String())
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not fetch remote epoch aggregates (%v): %v", utils.GetRedactedUrl(apiUrl.String()), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return 0, nil, fmt.Errorf("url: %v, error-response (%v): %s", utils.GetRedactedUrl(apiUrl.String()), resp.StatusCode, data)
+	}
+
+	response := &struct {
+		Status string                               `json:"status"`
+		Data   *apitypes.ApiEpochAggregatesResponse `json:"data"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(response)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error parsing remote epoch aggregates response: %v", err)
+	}
+	if response.Status != "OK" || response.Data == nil {
+		return 0, nil, fmt.Errorf("remote epoch aggregates error: %v", response.Status)
+	}
+
+	return response.Data.FinalizedEpoch, response.Data.Epochs, nil
+}
+
+// getEpochAggregateFields returns the compared values of the epoch aggregate by json field name
+func getEpochAggregateFields(epoch *apitypes.ApiEpochAggregate) []apiEpochAggregateField {
+	return []apiEpochAggregateField{
+		{"validator_count", epoch.ValidatorCount},
+		{"validator_balance", epoch.ValidatorBalance},
+		{"eligible", epoch.Eligible},
+		{"voted_target", epoch.VotedTarget},
+		{"voted_head", epoch.VotedHead},
+		{"voted_total", epoch.VotedTotal},
+		{"block_count", epoch.BlockCount},
+		{"orphaned_count", epoch.OrphanedCount},
+		{"attestation_count", epoch.AttestationCount},
+		{"deposit_count", epoch.DepositCount},
+		{"exit_count", epoch.ExitCount},
+		{"withdraw_count", epoch.WithdrawCount},
+		{"withdraw_amount", epoch.WithdrawAmount},
+		{"attester_slashing_count", epoch.AttesterSlashingCount},
+		{"proposer_slashing_count", epoch.ProposerSlashingCount},
+		{"bls_change_count", epoch.BLSChangeCount},
+		{"eth_transaction_count", epoch.EthTransactionCount},
+	}
+}
+
+type apiEpochAggregateField struct {
+	name  string
+	value uint64
+}
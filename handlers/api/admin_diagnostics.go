@@ -0,0 +1,114 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// adminPprofProfiles are the runtime profiles served via the admin pprof endpoint
+var adminPprofProfiles = []string{"allocs", "block", "cmdline", "goroutine", "heap", "mutex", "profile", "symbol", "threadcreate", "trace"}
+
+// ApiAdminPprof serves the go runtime profiles to admin requests, the query parameters are the same as for net/http/pprof
+func ApiAdminPprof(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/pprof/{profile}"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	switch profile := mux.Vars(r)["profile"]; profile {
+	case "cmdline":
+		pprof.Cmdline(w, r)
+	case "profile":
+		pprof.Profile(w, r)
+	case "symbol":
+		pprof.Symbol(w, r)
+	case "trace":
+		pprof.Trace(w, r)
+	case "allocs", "block", "goroutine", "heap", "mutex", "threadcreate":
+		pprof.Handler(profile).ServeHTTP(w, r)
+	default:
+		sendErrorResponse(w, route, http.StatusNotFound, fmt.Sprintf("unknown profile, available profiles: %v", adminPprofProfiles))
+	}
+}
+
+// ApiAdminHeapDumps lists (GET) or captures (POST) heap dumps
+func ApiAdminHeapDumps(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/heapdumps"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	if services.GlobalHeapDumper == nil {
+		sendErrorResponse(w, route, http.StatusServiceUnavailable, "heap dumper not running")
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		dump, err := services.GlobalHeapDumper.CaptureHeapDump("manual")
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		sendOKResponse(w, route, buildApiHeapDump(dump))
+		return
+	}
+
+	dumps, err := services.GlobalHeapDumper.GetHeapDumps()
+	if err != nil {
+		sendErrorResponse(w, route, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := &apitypes.ApiAdminHeapDumpsResponse{
+		ProcessRss:   services.GetProcessRss(),
+		RssThreshold: utils.Config.Diagnostics.HeapDumpRssThreshold * 1024 * 1024,
+		Dumps:        make([]*apitypes.ApiAdminHeapDump, 0, len(dumps)),
+	}
+	for _, dump := range dumps {
+		response.Dumps = append(response.Dumps, buildApiHeapDump(dump))
+	}
+
+	sendOKResponse(w, route, response)
+}
+
+// ApiAdminHeapDumpDownload downloads a captured heap dump
+func ApiAdminHeapDumpDownload(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/heapdumps/{name}"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	if services.GlobalHeapDumper == nil {
+		sendErrorResponse(w, route, http.StatusServiceUnavailable, "heap dumper not running")
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+	path, err := services.GlobalHeapDumper.GetHeapDumpPath(name)
+	if err != nil {
+		sendErrorResponse(w, route, http.StatusNotFound, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%v\"", name))
+	http.ServeFile(w, r, path)
+}
+
+func buildApiHeapDump(dump *services.HeapDump) *apitypes.ApiAdminHeapDump {
+	return &apitypes.ApiAdminHeapDump{
+		Name:   dump.Name,
+		Size:   dump.Size,
+		Time:   dump.Time,
+		Reason: dump.Reason,
+		Rss:    dump.Rss,
+	}
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+var entityIdParams = []ApiRouteParam{
+	{Name: "id", In: "path", Type: "integer", Description: "Entity id", Required: true},
+}
+
+// ApiAdminEntities returns all entities (GET) or creates a new entity (POST)
+func ApiAdminEntities(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/entities"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		details, err := parseApiEntityUpdate(r, 0)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		err = services.SaveEntity(details)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		sendOKResponse(w, route, buildApiEntity(details))
+		return
+	}
+
+	entities, err := services.GetEntities()
+	if err != nil {
+		sendErrorResponse(w, route, http.StatusInternalServerError, "could not load entities")
+		return
+	}
+
+	response := &apitypes.ApiEntitiesResponse{
+		Entities: make([]*apitypes.ApiEntity, 0, len(entities)),
+	}
+	for _, details := range entities {
+		response.Entities = append(response.Entities, buildApiEntity(details))
+	}
+
+	sendOKResponse(w, route, response)
+}
+
+// ApiAdminEntity returns (GET), replaces (PUT) or deletes (DELETE) a single entity
+func ApiAdminEntity(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/entities/{id}"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	entityId, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil || entityId == 0 {
+		sendErrorResponse(w, route, http.StatusBadRequest, "invalid entity id")
+		return
+	}
+
+	existing, err := services.GetEntity(entityId)
+	if err != nil {
+		sendErrorResponse(w, route, http.StatusInternalServerError, "could not load entity")
+		return
+	}
+	if existing == nil {
+		sendErrorResponse(w, route, http.StatusNotFound, "entity not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		details, err := parseApiEntityUpdate(r, entityId)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusBadRequest, err.Error())
+			return
+		}
+		details.Entity.Created = existing.Entity.Created
+
+		err = services.SaveEntity(details)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		sendOKResponse(w, route, buildApiEntity(details))
+	case http.MethodDelete:
+		err = services.DeleteEntity(entityId)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		sendOKResponse(w, route, buildApiEntity(existing))
+	default:
+		sendOKResponse(w, route, buildApiEntity(existing))
+	}
+}
+
+// parseApiEntityUpdate decodes & validates the entity from the request body
+func parseApiEntityUpdate(r *http.Request, entityId uint64) (*services.EntityDetails, error) {
+	update := &apitypes.ApiEntityUpdate{}
+	err := json.NewDecoder(r.Body).Decode(update)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request body")
+	}
+
+	details := &services.EntityDetails{
+		Entity: &dbtypes.Entity{
+			EntityId:    entityId,
+			Name:        update.Name,
+			Description: update.Description,
+		},
+		Links:           make([]*dbtypes.EntityLink, 0, len(update.Links)),
+		ValidatorRanges: make([]*dbtypes.EntityValidatorRange, 0, len(update.Validators)),
+		Addresses:       make([]*dbtypes.EntityAddress, 0, len(update.Addresses)),
+	}
+
+	for _, link := range update.Links {
+		details.Links = append(details.Links, &dbtypes.EntityLink{
+			Name: link.Name,
+			Url:  link.Url,
+		})
+	}
+
+	for _, validators := range update.Validators {
+		maxIndex := validators.MinIndex
+		if validators.MaxIndex != nil {
+			maxIndex = *validators.MaxIndex
+		}
+		details.ValidatorRanges = append(details.ValidatorRanges, &dbtypes.EntityValidatorRange{
+			EntityId: entityId,
+			MinIndex: validators.MinIndex,
+			MaxIndex: maxIndex,
+		})
+	}
+
+	for _, address := range update.Addresses {
+		addressType, ok := services.ParseEntityAddressType(address.Type)
+		if !ok {
+			return nil, fmt.Errorf("invalid address type %v (expected: withdrawal, deposit_origin or deposit_target)", address.Type)
+		}
+		if !common.IsHexAddress(address.Address) {
+			return nil, fmt.Errorf("invalid address %v", address.Address)
+		}
+		details.Addresses = append(details.Addresses, &dbtypes.EntityAddress{
+			EntityId:    entityId,
+			AddressType: addressType,
+			Address:     common.HexToAddress(address.Address).Bytes(),
+		})
+	}
+
+	if err := services.ValidateEntity(details); err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+func buildApiEntity(details *services.EntityDetails) *apitypes.ApiEntity {
+	apiEntity := &apitypes.ApiEntity{
+		Id:          details.Entity.EntityId,
+		Name:        details.Entity.Name,
+		Description: details.Entity.Description,
+		Links:       make([]*apitypes.ApiEntityLink, 0, len(details.Links)),
+		Validators:  make([]*apitypes.ApiEntityValidators, 0, len(details.ValidatorRanges)),
+		Addresses:   make([]*apitypes.ApiEntityAddress, 0, len(details.Addresses)),
+		Created:     time.Unix(int64(details.Entity.Created), 0),
+		Updated:     time.Unix(int64(details.Entity.Updated), 0),
+	}
+
+	for _, link := range details.Links {
+		apiEntity.Links = append(apiEntity.Links, &apitypes.ApiEntityLink{
+			Name: link.Name,
+			Url:  link.Url,
+		})
+	}
+
+	for _, validatorRange := range details.ValidatorRanges {
+		maxIndex := validatorRange.MaxIndex
+		apiEntity.Validators = append(apiEntity.Validators, &apitypes.ApiEntityValidators{
+			MinIndex: validatorRange.MinIndex,
+			MaxIndex: &maxIndex,
+		})
+	}
+
+	for _, address := range details.Addresses {
+		apiEntity.Addresses = append(apiEntity.Addresses, &apitypes.ApiEntityAddress{
+			Type:    services.GetEntityAddressTypeKey(address.AddressType),
+			Address: common.BytesToAddress(address.Address).Hex(),
+		})
+	}
+
+	return apiEntity
+}
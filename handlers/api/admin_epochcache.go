@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/dora/services"
+)
+
+// ApiAdminEpochCache returns the current epoch cache memory usage
+func ApiAdminEpochCache(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/epochcache"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	sendOKResponse(w, route, services.GlobalBeaconService.GetBeaconIndexer().GetEpochCacheStats())
+}
@@ -0,0 +1,416 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// maxIncidentBundleSlotRange is the max number of slots packaged per incident bundle
+const maxIncidentBundleSlotRange = 1024
+
+// incidentBundleLogMargin is the time added before & after the bundled slot range when collecting log lines
+const incidentBundleLogMargin = 2 * time.Minute
+
+// ApiAdminIncidentBundle packages the data usually attached to devnet incident reports for a slot range into a zip archive.
+// the range is given as slot range (start_slot / end_slot) or epoch range (start_epoch / end_epoch).
+func ApiAdminIncidentBundle(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/incident_bundle"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	if specs == nil {
+		sendErrorResponse(w, route, http.StatusServiceUnavailable, "chain specs not loaded")
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	var startSlot, endSlot uint64
+	var err error
+	switch {
+	case urlArgs.Has("start_slot"):
+		startSlot, err = strconv.ParseUint(urlArgs.Get("start_slot"), 10, 64)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusBadRequest, "invalid start_slot")
+			return
+		}
+
+		endSlot = startSlot
+		if urlArgs.Has("end_slot") {
+			endSlot, err = strconv.ParseUint(urlArgs.Get("end_slot"), 10, 64)
+			if err != nil {
+				sendErrorResponse(w, route, http.StatusBadRequest, "invalid end_slot")
+				return
+			}
+		}
+	case urlArgs.Has("start_epoch"):
+		startEpoch, err := strconv.ParseUint(urlArgs.Get("start_epoch"), 10, 64)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusBadRequest, "invalid start_epoch")
+			return
+		}
+
+		endEpoch := startEpoch
+		if urlArgs.Has("end_epoch") {
+			endEpoch, err = strconv.ParseUint(urlArgs.Get("end_epoch"), 10, 64)
+			if err != nil {
+				sendErrorResponse(w, route, http.StatusBadRequest, "invalid end_epoch")
+				return
+			}
+		}
+
+		startSlot = uint64(chainState.EpochToSlot(phase0.Epoch(startEpoch)))
+		endSlot = uint64(chainState.EpochToSlot(phase0.Epoch(endEpoch+1))) - 1
+	default:
+		sendErrorResponse(w, route, http.StatusBadRequest, "missing start_slot or start_epoch")
+		return
+	}
+
+	if currentSlot := uint64(chainState.CurrentSlot()); endSlot > currentSlot {
+		endSlot = currentSlot
+	}
+	if startSlot > endSlot {
+		sendErrorResponse(w, route, http.StatusBadRequest, "invalid slot range")
+		return
+	}
+	if endSlot-startSlot >= maxIncidentBundleSlotRange {
+		sendErrorResponse(w, route, http.StatusBadRequest, fmt.Sprintf("slot range too large (max %v slots)", maxIncidentBundleSlotRange))
+		return
+	}
+
+	bundleFiles := []struct {
+		name string
+		data interface{}
+	}{
+		{"fork_tree.json", buildIncidentBundleForkTree(startSlot, endSlot)},
+		{"client_heads.json", buildIncidentBundleClientHeads()},
+		{"propagation.json", buildIncidentBundlePropagation(startSlot, endSlot)},
+		{"participation.json", buildIncidentBundleParticipation(startSlot, endSlot)},
+	}
+
+	summary := &apitypes.ApiIncidentBundleSummary{
+		Network:         utils.Config.Chain.DisplayName,
+		ExplorerVersion: utils.GetExplorerVersion(),
+		GeneratedAt:     time.Now().UTC(),
+		StartSlot:       startSlot,
+		EndSlot:         endSlot,
+		StartEpoch:      uint64(chainState.EpochOfSlot(phase0.Slot(startSlot))),
+		EndEpoch:        uint64(chainState.EpochOfSlot(phase0.Slot(endSlot))),
+		StartTime:       chainState.SlotToTime(phase0.Slot(startSlot)),
+		EndTime:         chainState.SlotToTime(phase0.Slot(endSlot + 1)),
+		Files:           []string{"summary.json"},
+	}
+	if summary.Network == "" {
+		summary.Network = specs.ConfigName
+	}
+	if headBlock := services.GlobalBeaconService.GetBeaconIndexer().GetCanonicalHead(nil); headBlock != nil {
+		summary.HeadSlot = uint64(headBlock.Slot)
+		summary.HeadRoot = headBlock.Root.String()
+	}
+	finalizedEpoch, finalizedRoot := chainState.GetFinalizedCheckpoint()
+	summary.FinalizedEpoch = uint64(finalizedEpoch)
+	summary.FinalizedRoot = finalizedRoot.String()
+	for _, bundleFile := range bundleFiles {
+		summary.Files = append(summary.Files, bundleFile.name)
+	}
+
+	logLines := utils.GetBufferedLogs(summary.StartTime.Add(-incidentBundleLogMargin), summary.EndTime.Add(incidentBundleLogMargin))
+	if logLines != nil {
+		summary.Files = append(summary.Files, "logs.txt")
+	}
+
+	archive := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(archive)
+	err = writeIncidentBundleJson(zipWriter, "summary.json", summary)
+	for _, bundleFile := range bundleFiles {
+		if err != nil {
+			break
+		}
+		err = writeIncidentBundleJson(zipWriter, bundleFile.name, bundleFile.data)
+	}
+	if err == nil && logLines != nil {
+		var logFile strings.Builder
+		for _, logLine := range logLines {
+			logFile.WriteString(logLine.Line)
+		}
+		err = writeIncidentBundleFile(zipWriter, "logs.txt", []byte(logFile.String()))
+	}
+	if err == nil {
+		err = zipWriter.Close()
+	}
+	if err != nil {
+		sendErrorResponse(w, route, http.StatusInternalServerError, fmt.Sprintf("could not build incident bundle: %v", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"incident-%v-%v-%v.zip\"", strings.ReplaceAll(summary.Network, " ", "_"), startSlot, endSlot))
+	w.Write(archive.Bytes())
+}
+
+func writeIncidentBundleJson(zipWriter *zip.Writer, name string, data interface{}) error {
+	fileData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding %v: %w", name, err)
+	}
+
+	return writeIncidentBundleFile(zipWriter, name, fileData)
+}
+
+func writeIncidentBundleFile(zipWriter *zip.Writer, name string, data []byte) error {
+	fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("error adding %v: %w", name, err)
+	}
+
+	_, err = fileWriter.Write(data)
+	if err != nil {
+		return fmt.Errorf("error writing %v: %w", name, err)
+	}
+	return nil
+}
+
+// buildIncidentBundleForkTree collects all canonical, orphaned & missed slots of the range along with the current client head forks
+func buildIncidentBundleForkTree(startSlot uint64, endSlot uint64) *apitypes.ApiIncidentBundleForkTree {
+	chainState := services.GlobalBeaconService.GetChainState()
+	forkTree := &apitypes.ApiIncidentBundleForkTree{
+		Blocks:    []*apitypes.ApiIncidentBundleBlock{},
+		HeadForks: []*apitypes.ApiIncidentBundleHeadFork{},
+	}
+
+	dbBlocks := services.GlobalBeaconService.GetDbBlocksForSlots(endSlot, uint32(endSlot-startSlot), true, true)
+	for idx := len(dbBlocks) - 1; idx >= 0; idx-- {
+		dbBlock := dbBlocks[idx]
+		if dbBlock.Slot < startSlot || dbBlock.Slot > endSlot {
+			continue
+		}
+
+		bundleBlock := &apitypes.ApiIncidentBundleBlock{
+			Slot:     dbBlock.Slot,
+			Epoch:    uint64(chainState.EpochOfSlot(phase0.Slot(dbBlock.Slot))),
+			Proposer: dbBlock.Proposer,
+		}
+		if dbBlock.Proposer != math.MaxInt64 {
+			bundleBlock.ProposerName = services.GlobalBeaconService.GetValidatorName(dbBlock.Proposer)
+		}
+
+		switch dbBlock.Status {
+		case dbtypes.Missing:
+			bundleBlock.Status = "missing"
+		case dbtypes.Canonical:
+			bundleBlock.Status = "canonical"
+		case dbtypes.Orphaned:
+			bundleBlock.Status = "orphaned"
+		}
+
+		if dbBlock.Status != dbtypes.Missing {
+			bundleBlock.Root = fmt.Sprintf("0x%x", dbBlock.Root)
+			bundleBlock.ParentRoot = fmt.Sprintf("0x%x", dbBlock.ParentRoot)
+			bundleBlock.ForkId = dbBlock.ForkId
+			bundleBlock.Graffiti = dbBlock.GraffitiText
+			bundleBlock.AttestationCount = dbBlock.AttestationCount
+			bundleBlock.SyncParticipation = dbBlock.SyncParticipation
+			if dbBlock.EthBlockNumber != nil {
+				bundleBlock.ElBlockNumber = dbBlock.EthBlockNumber
+				bundleBlock.ElBlockHash = fmt.Sprintf("0x%x", dbBlock.EthBlockHash)
+				bundleBlock.ElExtraData = dbBlock.EthBlockExtraText
+			}
+		}
+
+		forkTree.Blocks = append(forkTree.Blocks, bundleBlock)
+	}
+
+	for _, fork := range services.GlobalBeaconService.GetConsensusClientForks() {
+		headFork := &apitypes.ApiIncidentBundleHeadFork{
+			HeadSlot: uint64(fork.Slot),
+			HeadRoot: fork.Root.String(),
+			Clients:  make([]string, 0, len(fork.AllClients)),
+		}
+		for _, client := range fork.AllClients {
+			headFork.Clients = append(headFork.Clients, services.RedactClientName(client.GetClient().GetName()))
+		}
+		forkTree.HeadForks = append(forkTree.HeadForks, headFork)
+	}
+
+	return forkTree
+}
+
+// buildIncidentBundleClientHeads collects the current head & finality view of all connected clients
+func buildIncidentBundleClientHeads() *apitypes.ApiIncidentBundleClientHeads {
+	clientHeads := &apitypes.ApiIncidentBundleClientHeads{
+		Consensus: []*apitypes.ApiIncidentBundleConsensusClient{},
+		Execution: []*apitypes.ApiIncidentBundleExecutionClient{},
+	}
+
+	for _, client := range services.GlobalBeaconService.GetConsensusClients() {
+		headSlot, headRoot := client.GetLastHead()
+		finalizedEpoch, finalizedRoot, justifiedEpoch, justifiedRoot := client.GetFinalityCheckpoint()
+		clientHead := &apitypes.ApiIncidentBundleConsensusClient{
+			Name:           services.RedactClientName(client.GetName()),
+			Version:        client.GetVersion(),
+			Status:         client.GetStatus().String(),
+			HeadSlot:       uint64(headSlot),
+			HeadRoot:       headRoot.String(),
+			JustifiedEpoch: uint64(justifiedEpoch),
+			JustifiedRoot:  justifiedRoot.String(),
+			FinalizedEpoch: uint64(finalizedEpoch),
+			FinalizedRoot:  finalizedRoot.String(),
+			LastRefresh:    client.GetLastEventTime(),
+		}
+		if lastErr := client.GetLastClientError(); lastErr != nil {
+			clientHead.LastError = lastErr.Error()
+		}
+		clientHeads.Consensus = append(clientHeads.Consensus, clientHead)
+	}
+
+	for _, client := range services.GlobalBeaconService.GetExecutionClients() {
+		headNumber, headHash := client.GetLastHead()
+		clientHead := &apitypes.ApiIncidentBundleExecutionClient{
+			Name:        services.RedactClientName(client.GetName()),
+			Version:     client.GetVersion(),
+			Status:      client.GetStatus().String(),
+			HeadNumber:  headNumber,
+			HeadHash:    headHash.String(),
+			LastRefresh: client.GetLastEventTime(),
+		}
+		if lastErr := client.GetLastClientError(); lastErr != nil {
+			clientHead.LastError = lastErr.Error()
+		}
+		clientHeads.Execution = append(clientHeads.Execution, clientHead)
+	}
+
+	return clientHeads
+}
+
+// buildIncidentBundlePropagation collects the block arrival delays of the range.
+// finalized arrivals are loaded from the db, unfinalized arrivals (including orphaned blocks) from the block cache.
+func buildIncidentBundlePropagation(startSlot uint64, endSlot uint64) *apitypes.ApiIncidentBundlePropagation {
+	chainState := services.GlobalBeaconService.GetChainState()
+	beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
+	propagation := &apitypes.ApiIncidentBundlePropagation{
+		Blocks: []*apitypes.ApiIncidentBundleBlockArrival{},
+	}
+
+	_, prunedEpoch := beaconIndexer.GetBlockCacheState()
+	cacheStartSlot := uint64(chainState.EpochToSlot(prunedEpoch))
+
+	if startSlot < cacheStartSlot {
+		dbEndSlot := endSlot
+		if dbEndSlot >= cacheStartSlot {
+			dbEndSlot = cacheStartSlot - 1
+		}
+
+		clientArrivals := map[uint64][]*apitypes.ApiIncidentBundleClientBlockArrival{}
+		dbClientArrivals, _ := db.GetBlockArrivalClientsRange(startSlot, dbEndSlot)
+		for _, clientArrival := range dbClientArrivals {
+			clientArrivals[clientArrival.Slot] = append(clientArrivals[clientArrival.Slot], &apitypes.ApiIncidentBundleClientBlockArrival{
+				Client: services.RedactClientName(clientArrival.Client),
+				Delay:  clientArrival.Delay,
+			})
+		}
+
+		dbArrivals, _ := db.GetBlockArrivalsRange(startSlot, dbEndSlot)
+		for _, arrival := range dbArrivals {
+			propagation.Blocks = append(propagation.Blocks, buildIncidentBundleBlockArrival(arrival, clientArrivals[arrival.Slot]))
+		}
+	}
+
+	for slot := max(startSlot, cacheStartSlot); slot <= endSlot; slot++ {
+		for _, block := range beaconIndexer.GetBlocksBySlot(phase0.Slot(slot)) {
+			arrival, dbClientArrivals := block.GetDbArrivals(chainState)
+			if arrival == nil {
+				continue
+			}
+
+			clientArrivals := make([]*apitypes.ApiIncidentBundleClientBlockArrival, len(dbClientArrivals))
+			for i, clientArrival := range dbClientArrivals {
+				clientArrivals[i] = &apitypes.ApiIncidentBundleClientBlockArrival{
+					Client: services.RedactClientName(clientArrival.Client),
+					Delay:  clientArrival.Delay,
+				}
+			}
+
+			blockArrival := buildIncidentBundleBlockArrival(arrival, clientArrivals)
+			blockArrival.Root = block.Root.String()
+			propagation.Blocks = append(propagation.Blocks, blockArrival)
+		}
+	}
+
+	return propagation
+}
+
+func buildIncidentBundleBlockArrival(arrival *dbtypes.BlockArrival, clientArrivals []*apitypes.ApiIncidentBundleClientBlockArrival) *apitypes.ApiIncidentBundleBlockArrival {
+	if clientArrivals == nil {
+		clientArrivals = []*apitypes.ApiIncidentBundleClientBlockArrival{}
+	}
+
+	return &apitypes.ApiIncidentBundleBlockArrival{
+		Slot:        arrival.Slot,
+		Proposer:    arrival.Proposer,
+		SeenBy:      arrival.SeenBy,
+		MinDelay:    arrival.MinDelay,
+		MedianDelay: arrival.MedianDelay,
+		MaxDelay:    arrival.MaxDelay,
+		Clients:     clientArrivals,
+	}
+}
+
+// buildIncidentBundleParticipation collects the voting participation of the epochs covered by the range
+func buildIncidentBundleParticipation(startSlot uint64, endSlot uint64) *apitypes.ApiIncidentBundleParticipation {
+	chainState := services.GlobalBeaconService.GetChainState()
+	participation := &apitypes.ApiIncidentBundleParticipation{
+		Epochs: []*apitypes.ApiIncidentBundleEpoch{},
+	}
+
+	startEpoch := uint64(chainState.EpochOfSlot(phase0.Slot(startSlot)))
+	endEpoch := uint64(chainState.EpochOfSlot(phase0.Slot(endSlot)))
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+
+	dbEpochs := services.GlobalBeaconService.GetDbEpochs(endEpoch, uint32(endEpoch-startEpoch+1))
+	for idx := len(dbEpochs) - 1; idx >= 0; idx-- {
+		dbEpoch := dbEpochs[idx]
+		if dbEpoch == nil || dbEpoch.Epoch < startEpoch || dbEpoch.Epoch > endEpoch {
+			continue
+		}
+
+		epoch := &apitypes.ApiIncidentBundleEpoch{
+			Epoch:             dbEpoch.Epoch,
+			Finalized:         dbEpoch.Epoch < uint64(finalizedEpoch),
+			Eligible:          dbEpoch.Eligible,
+			VotedTarget:       dbEpoch.VotedTarget,
+			VotedHead:         dbEpoch.VotedHead,
+			VotedTotal:        dbEpoch.VotedTotal,
+			BlockCount:        dbEpoch.BlockCount,
+			OrphanedCount:     dbEpoch.OrphanedCount,
+			SyncParticipation: dbEpoch.SyncParticipation,
+		}
+		if dbEpoch.Eligible > 0 {
+			epoch.TargetPercent = float64(dbEpoch.VotedTarget) * 100 / float64(dbEpoch.Eligible)
+			epoch.HeadPercent = float64(dbEpoch.VotedHead) * 100 / float64(dbEpoch.Eligible)
+			epoch.TotalPercent = float64(dbEpoch.VotedTotal) * 100 / float64(dbEpoch.Eligible)
+		}
+		participation.Epochs = append(participation.Epochs, epoch)
+	}
+
+	return participation
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ethpandaops/dora/db"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiAdminOnlineMigrations returns the backfill progress of the online schema migrations
+func ApiAdminOnlineMigrations(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/online_migrations"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	migrations, err := db.GetOnlineMigrations()
+	if err != nil {
+		sendErrorResponse(w, route, http.StatusInternalServerError, "could not load online migrations")
+		return
+	}
+
+	response := &apitypes.ApiOnlineMigrationsResponse{
+		Migrations: make([]*apitypes.ApiOnlineMigration, 0, len(migrations)),
+	}
+	for _, migration := range migrations {
+		apiMigration := &apitypes.ApiOnlineMigration{
+			Name:      migration.Name,
+			Table:     migration.TableName,
+			Status:    "pending",
+			NextKey:   migration.NextKey,
+			MaxKey:    migration.MaxKey,
+			RowsDone:  migration.RowsDone,
+			LastError: migration.LastError,
+		}
+
+		if migration.StartedAt > 0 {
+			startedAt := time.Unix(int64(migration.StartedAt), 0)
+			apiMigration.Status = "running"
+			apiMigration.StartedAt = &startedAt
+		}
+		if migration.FinishedAt > 0 {
+			finishedAt := time.Unix(int64(migration.FinishedAt), 0)
+			apiMigration.Status = "finished"
+			apiMigration.FinishedAt = &finishedAt
+			apiMigration.Progress = 100
+		} else if migration.FirstKey != nil && migration.NextKey != nil && migration.MaxKey != nil {
+			if keyRange := *migration.MaxKey - *migration.FirstKey + 1; keyRange > 0 {
+				apiMigration.Progress = float64(*migration.NextKey-*migration.FirstKey) * 100 / float64(keyRange)
+			}
+		}
+
+		response.Migrations = append(response.Migrations, apiMigration)
+	}
+
+	sendOKResponse(w, route, response)
+}
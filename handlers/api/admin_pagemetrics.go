@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/dora/services"
+)
+
+// ApiAdminPageMetrics returns the page build & render timings per route and the page model build stats per page type
+func ApiAdminPageMetrics(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/page_metrics"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	sendOKResponse(w, route, services.GlobalPageMetrics.GetStats())
+}
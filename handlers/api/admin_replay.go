@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiAdminReplay rewrites a range of already finalized epochs, eg. after a partially failed finalization write.
+// the epochs are replayed asynchronously by the synchronizer. existing rows are overwritten and stale rows are reconciled.
+func ApiAdminReplay(w http.ResponseWriter, r *http.Request) {
+	route := "/api/v1/admin/replay"
+	if !checkAdminAuth(w, r, route) {
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	startEpoch, err := strconv.ParseUint(urlArgs.Get("start_epoch"), 10, 64)
+	if err != nil {
+		sendErrorResponse(w, route, http.StatusBadRequest, "invalid start_epoch")
+		return
+	}
+
+	endEpoch := startEpoch
+	if urlArgs.Has("end_epoch") {
+		endEpoch, err = strconv.ParseUint(urlArgs.Get("end_epoch"), 10, 64)
+		if err != nil {
+			sendErrorResponse(w, route, http.StatusBadRequest, "invalid end_epoch")
+			return
+		}
+	}
+
+	err = services.GlobalBeaconService.GetBeaconIndexer().ReplayEpochs(phase0.Epoch(startEpoch), phase0.Epoch(endEpoch))
+	if err != nil {
+		sendErrorResponse(w, route, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sendOKResponse(w, route, &apitypes.ApiAdminReplayResponse{
+		StartEpoch: startEpoch,
+		EndEpoch:   endEpoch,
+	})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiValidatorAnomalies returns the detected anomalies of watchlisted validators, newest first.
+// supported filters: validator (index), type (fee_recipient / withdrawal_credentials / graffiti), min_epoch
+func ApiValidatorAnomalies(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	paging, err := parseApiPaging(urlArgs, 50, 100)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	anomalyFilter := &dbtypes.ValidatorAnomalyFilter{}
+	if urlArgs.Has("validator") {
+		validatorIndex, err := strconv.ParseUint(urlArgs.Get("validator"), 10, 64)
+		if err != nil {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid validator index")
+			return
+		}
+		anomalyFilter.ValidatorIndex = &validatorIndex
+	}
+	if urlArgs.Has("min_epoch") {
+		minEpoch, _ := strconv.ParseUint(urlArgs.Get("min_epoch"), 10, 64)
+		anomalyFilter.MinSlot = uint64(chainState.EpochToSlot(phase0.Epoch(minEpoch)))
+	}
+	switch urlArgs.Get("type") {
+	case "":
+	case "fee_recipient":
+		anomalyFilter.Type = dbtypes.ValidatorAnomalyFeeRecipient
+	case "withdrawal_credentials":
+		anomalyFilter.Type = dbtypes.ValidatorAnomalyWithdrawalCredentials
+	case "graffiti":
+		anomalyFilter.Type = dbtypes.ValidatorAnomalyGraffiti
+	default:
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid type filter (expected: fee_recipient, withdrawal_credentials or graffiti)")
+		return
+	}
+
+	anomalies, totalRows, err := db.GetValidatorAnomaliesFiltered(paging.offset(), uint32(paging.limit), anomalyFilter)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load anomalies")
+		return
+	}
+
+	response := &apitypes.ApiValidatorAnomaliesResponse{
+		Anomalies:  make([]*apitypes.ApiValidatorAnomaly, 0, len(anomalies)),
+		Pagination: paging.getPagination(&totalRows, false),
+	}
+
+	for _, anomaly := range anomalies {
+		response.Anomalies = append(response.Anomalies, &apitypes.ApiValidatorAnomaly{
+			Validator:     anomaly.ValidatorIndex,
+			ValidatorName: getValidatorName(r, anomaly.ValidatorIndex),
+			Slot:          anomaly.Slot,
+			Epoch:         uint64(chainState.EpochOfSlot(phase0.Slot(anomaly.Slot))),
+			Time:          chainState.SlotToTime(phase0.Slot(anomaly.Slot)),
+			Type:          services.GetAnomalyTypeKey(anomaly.Type),
+			Before:        services.FormatAnomalyValue(anomaly.Type, anomaly.BeforeValue),
+			After:         services.FormatAnomalyValue(anomaly.Type, anomaly.AfterValue),
+		})
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
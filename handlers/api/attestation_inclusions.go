@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/gorilla/mux"
+)
+
+// ApiAttestationInclusions returns the aggregates & blocks that include the vote of a validator for its attestation duty in an epoch
+func ApiAttestationInclusions(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	var validatorIndex phase0.ValidatorIndex
+	validatorFound := false
+	validatorPubKey, err := hex.DecodeString(strings.Replace(vars["idxOrPubKey"], "0x", "", -1))
+	if err != nil || len(validatorPubKey) != 48 {
+		index, err := strconv.ParseUint(vars["idxOrPubKey"], 10, 64)
+		if err == nil {
+			validatorIndex = phase0.ValidatorIndex(index)
+			validatorFound = true
+		}
+	} else {
+		validatorIndex, validatorFound = services.GlobalBeaconService.GetValidatorIndexByPubkey(phase0.BLSPubKey(validatorPubKey))
+	}
+	if !validatorFound {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "validator not found")
+		return
+	}
+
+	epoch, err := strconv.ParseUint(vars["epoch"], 10, 64)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid epoch")
+		return
+	}
+	if epoch > uint64(services.GlobalBeaconService.GetChainState().CurrentEpoch()) {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "epoch is in the future")
+		return
+	}
+
+	duty, err := services.GlobalBeaconService.GetAttestationDutyInclusions(validatorIndex, phase0.Epoch(epoch))
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	response := &apitypes.ApiAttestationInclusionsResponse{
+		ValidatorIndex: uint64(validatorIndex),
+		Epoch:          epoch,
+		Inclusions:     []*apitypes.ApiAttestationInclusion{},
+	}
+	if duty != nil {
+		response.HasDuty = true
+		response.Slot = duty.Slot
+		response.CommitteeIndex = duty.CommitteeIndex
+		response.CommitteePosition = duty.CommitteePosition
+		response.CommitteeSize = duty.CommitteeSize
+
+		for _, inclusion := range duty.Inclusions {
+			if !inclusion.Orphaned && !response.Included {
+				response.Included = true
+				response.InclusionDelay = inclusion.InclusionDelay
+			}
+
+			response.Inclusions = append(response.Inclusions, &apitypes.ApiAttestationInclusion{
+				Slot:             inclusion.Slot,
+				BlockRoot:        fmt.Sprintf("0x%x", inclusion.BlockRoot),
+				Orphaned:         inclusion.Orphaned,
+				AttestationIndex: inclusion.AttestationIndex,
+				InclusionDelay:   inclusion.InclusionDelay,
+				AggregateSize:    inclusion.AggregateSize,
+				BeaconBlockRoot:  fmt.Sprintf("0x%x", inclusion.BeaconBlockRoot),
+				SourceEpoch:      inclusion.SourceEpoch,
+				SourceRoot:       fmt.Sprintf("0x%x", inclusion.SourceRoot),
+				TargetEpoch:      inclusion.TargetEpoch,
+				TargetRoot:       fmt.Sprintf("0x%x", inclusion.TargetRoot),
+			})
+		}
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
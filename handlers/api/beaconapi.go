@@ -0,0 +1,209 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/services"
+)
+
+// BeaconApiProxyRoutes holds the beacon api endpoints served by the beacon api proxy
+var BeaconApiProxyRoutes = []*ApiRoute{
+	{Path: "/eth/v1/beacon/headers", Method: http.MethodGet, Handler: BeaconApiHeaders},
+	{Path: "/eth/v1/beacon/headers/{block_id}", Method: http.MethodGet, Handler: BeaconApiHeader},
+	{Path: "/eth/v2/beacon/blocks/{block_id}", Method: http.MethodGet, Handler: BeaconApiBlock},
+	{Path: "/eth/v1/beacon/blob_sidecars/{block_id}", Method: http.MethodGet, Handler: BeaconApiBlobSidecars},
+}
+
+// beaconApiResponse is the response envelope of the beacon api
+type beaconApiResponse struct {
+	Version             string      `json:"version,omitempty"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+	Finalized           bool        `json:"finalized"`
+	Data                interface{} `json:"data"`
+}
+
+type beaconApiHeader struct {
+	Root      phase0.Root                     `json:"root"`
+	Canonical bool                            `json:"canonical"`
+	Header    *phase0.SignedBeaconBlockHeader `json:"header"`
+}
+
+// BeaconApiHeaders serves /eth/v1/beacon/headers, only the slot filter is supported (defaults to the head)
+func BeaconApiHeaders(w http.ResponseWriter, r *http.Request) {
+	blockId := "head"
+	if r.URL.Query().Has("parent_root") {
+		sendBeaconApiError(w, r, http.StatusBadRequest, "parent_root filter not supported")
+		return
+	}
+	if r.URL.Query().Has("slot") {
+		blockId = r.URL.Query().Get("slot")
+		if _, err := strconv.ParseUint(blockId, 10, 64); err != nil {
+			sendBeaconApiError(w, r, http.StatusBadRequest, "invalid slot")
+			return
+		}
+	}
+
+	block := loadBeaconApiBlock(w, r, blockId)
+	if block == nil {
+		return
+	}
+
+	sendBeaconApiResponse(w, r, &beaconApiResponse{
+		Finalized: block.Finalized,
+		Data: []*beaconApiHeader{{
+			Root:      block.Root,
+			Canonical: !block.Orphaned,
+			Header:    block.Header,
+		}},
+	})
+}
+
+// BeaconApiHeader serves /eth/v1/beacon/headers/{block_id}
+func BeaconApiHeader(w http.ResponseWriter, r *http.Request) {
+	block := loadBeaconApiBlock(w, r, mux.Vars(r)["block_id"])
+	if block == nil {
+		return
+	}
+
+	sendBeaconApiResponse(w, r, &beaconApiResponse{
+		Finalized: block.Finalized,
+		Data: &beaconApiHeader{
+			Root:      block.Root,
+			Canonical: !block.Orphaned,
+			Header:    block.Header,
+		},
+	})
+}
+
+// BeaconApiBlock serves /eth/v2/beacon/blocks/{block_id} as json or ssz (Accept: application/octet-stream)
+func BeaconApiBlock(w http.ResponseWriter, r *http.Request) {
+	block := loadBeaconApiBlock(w, r, mux.Vars(r)["block_id"])
+	if block == nil {
+		return
+	}
+	if block.Block == nil {
+		sendBeaconApiError(w, r, http.StatusNotFound, "block body not available")
+		return
+	}
+
+	w.Header().Set("Eth-Consensus-Version", block.Block.Version.String())
+
+	if strings.Contains(r.Header.Get("Accept"), "application/octet-stream") {
+		_, blockSSZ, err := beacon.MarshalVersionedSignedBeaconBlockSSZ(services.GlobalBeaconService.GetBeaconIndexer().GetDynSSZ(), block.Block, nil, true)
+		if err != nil {
+			sendBeaconApiError(w, r, http.StatusInternalServerError, "could not encode block")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(blockSSZ)
+		return
+	}
+
+	_, blockJson, err := beacon.MarshalVersionedSignedBeaconBlockJson(block.Block)
+	if err != nil {
+		sendBeaconApiError(w, r, http.StatusInternalServerError, "could not encode block")
+		return
+	}
+
+	sendBeaconApiResponse(w, r, &beaconApiResponse{
+		Version:   block.Block.Version.String(),
+		Finalized: block.Finalized,
+		Data:      json.RawMessage(blockJson),
+	})
+}
+
+// BeaconApiBlobSidecars serves /eth/v1/beacon/blob_sidecars/{block_id}, optionally filtered by the indices query parameter
+func BeaconApiBlobSidecars(w http.ResponseWriter, r *http.Request) {
+	block := loadBeaconApiBlock(w, r, mux.Vars(r)["block_id"])
+	if block == nil {
+		return
+	}
+
+	blobs, err := services.GlobalBeaconApiProxy.GetBlobSidecars(r.Context(), block)
+	if err != nil {
+		logrus.WithError(err).Warnf("beacon api proxy: failed loading blob sidecars for block %v", block.Root.String())
+		sendBeaconApiError(w, r, http.StatusServiceUnavailable, "could not load blob sidecars")
+		return
+	}
+
+	if indices := r.URL.Query()["indices"]; len(indices) > 0 {
+		blobIndices := []deneb.BlobIndex{}
+		for _, indexList := range indices {
+			for _, indexStr := range strings.Split(indexList, ",") {
+				index, err := strconv.ParseUint(indexStr, 10, 64)
+				if err != nil {
+					sendBeaconApiError(w, r, http.StatusBadRequest, "invalid indices")
+					return
+				}
+				blobIndices = append(blobIndices, deneb.BlobIndex(index))
+			}
+		}
+
+		filteredBlobs := make([]*deneb.BlobSidecar, 0, len(blobIndices))
+		for _, blob := range blobs {
+			if slices.Contains(blobIndices, blob.Index) {
+				filteredBlobs = append(filteredBlobs, blob)
+			}
+		}
+		blobs = filteredBlobs
+	}
+
+	sendBeaconApiResponse(w, r, &beaconApiResponse{
+		Finalized: block.Finalized,
+		Data:      blobs,
+	})
+}
+
+// loadBeaconApiBlock resolves the block id via the beacon api proxy, sends an error response and returns nil if the block could not be loaded
+func loadBeaconApiBlock(w http.ResponseWriter, r *http.Request, blockId string) *services.BeaconApiProxyBlock {
+	block, err := services.GlobalBeaconApiProxy.GetBlock(r.Context(), blockId)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidBlockId) {
+			sendBeaconApiError(w, r, http.StatusBadRequest, err.Error())
+		} else {
+			logrus.WithError(err).Warnf("beacon api proxy: failed loading block %v", blockId)
+			sendBeaconApiError(w, r, http.StatusServiceUnavailable, "could not load block")
+		}
+		return nil
+	}
+	if block == nil {
+		sendBeaconApiError(w, r, http.StatusNotFound, "block not found")
+		return nil
+	}
+
+	return block
+}
+
+func sendBeaconApiResponse(w http.ResponseWriter, r *http.Request, response *beaconApiResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		logrus.WithError(err).WithField("route", r.URL.String()).Error("error encoding beacon api response")
+	}
+}
+
+// sendBeaconApiError sends an error in the beacon api error format
+func sendBeaconApiError(w http.ResponseWriter, r *http.Request, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    statusCode,
+		"message": message,
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("route", r.URL.String()).Error("error encoding beacon api error response")
+	}
+}
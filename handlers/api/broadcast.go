@@ -0,0 +1,71 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// maxBroadcastBodySize is the maximum size of the request body for the operation broadcast
+const maxBroadcastBodySize = 1024 * 1024
+
+// ApiBroadcast validates signed voluntary exits & bls to execution changes against the current state and broadcasts them to all online clients.
+func ApiBroadcast(w http.ResponseWriter, r *http.Request) {
+	if !utils.Config.Frontend.ShowSubmitBroadcast {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "broadcasting operations is not enabled")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBroadcastBodySize))
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	operations, parseErr := services.ParseBroadcastOperations(body)
+
+	err = services.GlobalCallRateLimiter.CheckCallLimit(r, services.GetBroadcastCallCost(len(operations)))
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	if parseErr != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, parseErr.Error())
+		return
+	}
+
+	response := &apitypes.ApiBroadcastResponse{
+		Results: make([]*apitypes.ApiBroadcastResult, 0, len(operations)),
+	}
+	for _, result := range services.GlobalBeaconService.BroadcastOperations(r.Context(), operations) {
+		resultData := &apitypes.ApiBroadcastResult{
+			Type:           result.Operation.Type.String(),
+			ValidatorIndex: uint64(result.Operation.ValidatorIndex),
+			Valid:          result.ValidationError == nil,
+			Clients:        make([]*apitypes.ApiBroadcastClientResult, 0, len(result.Clients)),
+		}
+		if result.ValidationError != nil {
+			resultData.ValidationError = result.ValidationError.Error()
+		}
+
+		for _, clientResult := range result.Clients {
+			resultData.Clients = append(resultData.Clients, &apitypes.ApiBroadcastClientResult{
+				Client:   clientResult.ClientName,
+				Accepted: clientResult.Accepted,
+				Error:    clientResult.Error,
+			})
+			if clientResult.Accepted {
+				resultData.AcceptedCount++
+			}
+		}
+
+		response.Results = append(response.Results, resultData)
+	}
+	response.Count = uint64(len(response.Results))
+
+	sendOKResponse(w, r.URL.String(), response)
+}
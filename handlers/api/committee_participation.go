@@ -0,0 +1,95 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/gorilla/mux"
+)
+
+// ApiEpochCommittees returns the attestation participation per committee index across all slots of an epoch.
+// committee indexes with a notably lower participation hint to gossip problems on the related attestation subnets.
+func ApiEpochCommittees(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	epoch, err := strconv.ParseUint(vars["epoch"], 10, 64)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid epoch")
+		return
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	if epoch >= uint64(chainState.CurrentEpoch()) {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "epoch not completed yet")
+		return
+	}
+
+	response := &apitypes.ApiEpochCommitteesResponse{}
+	pageCacheKey := fmt.Sprintf("api/epoch_committees:%v", epoch)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, response, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		response, cacheTimeout := buildEpochCommitteesResponse(phase0.Epoch(epoch))
+		pageCall.CacheTimeout = cacheTimeout
+		return response
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*apitypes.ApiEpochCommitteesResponse)
+		if !resOk {
+			pageErr = fmt.Errorf("invalid response model")
+		}
+		response = resData
+	}
+	if pageErr != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, pageErr.Error())
+		return
+	}
+	if response == nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "committee assignments for epoch not available")
+		return
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
+
+func buildEpochCommitteesResponse(epoch phase0.Epoch) (*apitypes.ApiEpochCommitteesResponse, time.Duration) {
+	participation, err := services.GlobalBeaconService.GetEpochCommitteeParticipation(epoch)
+	if err != nil {
+		// the duties might become available later, do not cache the result
+		return nil, -1
+	}
+
+	finalizedEpoch, _ := services.GlobalBeaconService.GetChainState().GetFinalizedCheckpoint()
+	response := &apitypes.ApiEpochCommitteesResponse{
+		Epoch:      uint64(epoch),
+		Finalized:  epoch < finalizedEpoch,
+		Committees: make([]*apitypes.ApiEpochCommitteeParticipation, 0, len(participation)),
+	}
+
+	for _, committee := range participation {
+		committeeParticipation := &apitypes.ApiEpochCommitteeParticipation{
+			CommitteeIndex: committee.CommitteeIndex,
+			Assigned:       committee.Assigned,
+			Attested:       committee.Attested,
+		}
+		if committee.Assigned > 0 {
+			committeeParticipation.Participation = float64(committee.Attested) * 100 / float64(committee.Assigned)
+		}
+
+		response.Committees = append(response.Committees, committeeParticipation)
+	}
+
+	// attestations of unfinalized epochs may still be included or orphaned
+	if response.Finalized {
+		return response, 30 * time.Minute
+	}
+	return response, 12 * time.Second
+}
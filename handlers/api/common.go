@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/sirupsen/logrus"
+)
+
+// sendOKResponse writes a successful api response with the given data
+func sendOKResponse(w http.ResponseWriter, route string, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(&apitypes.ApiResponse{
+		Status: "OK",
+		Data:   data,
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("route", route).Error("error encoding api response")
+	}
+}
+
+// sendErrorResponse writes a failed api response with the given status code and error message
+func sendErrorResponse(w http.ResponseWriter, route string, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	err := json.NewEncoder(w).Encode(&apitypes.ApiResponse{
+		Status: "ERROR: " + message,
+	})
+	if err != nil {
+		logrus.WithError(err).WithField("route", route).Error("error encoding api error response")
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// ApiCustomPage returns the raw result rows of an operator defined custom page query
+func ApiCustomPage(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	customPage := services.GetCustomPage(vars["name"])
+	if customPage == nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "custom page not found")
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+		if pageIdx < 1 {
+			pageIdx = 1
+		}
+	}
+	if cursor := urlArgs.Get("cursor"); cursor != "" {
+		offset, keyset, err := decodePageCursor(cursor)
+		if err == nil && keyset != nil {
+			err = errInvalidCursor
+		}
+		if err != nil {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+			return
+		}
+		pageIdx = offset/customPage.PageSize + 1
+	}
+
+	args, _, err := services.GetCustomPageParams(customPage, urlArgs)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := services.LoadCustomPageResult(customPage, args, pageIdx)
+	if err != nil {
+		logrus.Warnf("custom page %v query failed: %v", customPage.Name, err)
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "custom page query failed")
+		return
+	}
+
+	// custom pages use 1 based page numbers & the configured page size
+	paging := &apiPaging{
+		limit:   customPage.PageSize,
+		pageIdx: pageIdx - 1,
+	}
+
+	// binary values are returned hex encoded
+	for _, row := range result.Rows {
+		for column, value := range row {
+			switch v := value.(type) {
+			case []byte:
+				row[column] = fmt.Sprintf("0x%x", v)
+			case time.Time:
+				row[column] = v.Unix()
+			}
+		}
+	}
+
+	sendOKResponse(w, r.URL.String(), &apitypes.ApiCustomPageResponse{
+		Name:       customPage.Name,
+		Columns:    result.Columns,
+		Rows:       result.Rows,
+		Pagination: paging.getPagination(nil, result.HasMore),
+	})
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiProblematicDeposits returns deposits that probably resulted in lost funds.
+// these are deposits reusing an already deposited pubkey with different withdrawal credentials,
+// or deposits with an invalid signature for pubkeys without a prior valid deposit.
+func ApiProblematicDeposits(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	paging, err := parseApiPaging(urlArgs, 50, 100)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	depositSyncState := dbtypes.DepositIndexerState{}
+	db.GetExplorerState("indexer.depositstate", &depositSyncState)
+
+	depositFilter := &dbtypes.DepositTxFilter{
+		WithOrphaned: 0,
+		WithValid:    1,
+		WithProblems: 2,
+		Cursor:       paging.keyset,
+	}
+
+	offset := paging.offset()
+	if paging.keyset != nil {
+		offset = 0
+	}
+
+	depositTxs, totalRows, err := db.GetDepositTxsFiltered(offset, uint32(paging.limit), depositSyncState.FinalBlock, depositFilter)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load deposits")
+		return
+	}
+
+	depositKeys := make([]uint64, len(depositTxs))
+	for idx, depositTx := range depositTxs {
+		depositKeys[idx] = depositTx.Index
+	}
+
+	response := &apitypes.ApiProblematicDepositsResponse{
+		Deposits:   make([]*apitypes.ApiProblematicDeposit, 0, len(depositTxs)),
+		Pagination: paging.getKeysetPagination(&totalRows, false, db.GetNextListCursor(paging.keyset, depositKeys)),
+	}
+
+	for _, depositTx := range depositTxs {
+		deposit := &apitypes.ApiProblematicDeposit{
+			Index:                 depositTx.Index,
+			PublicKey:             fmt.Sprintf("0x%x", depositTx.PublicKey),
+			WithdrawalCredentials: fmt.Sprintf("0x%x", depositTx.WithdrawalCredentials),
+			Amount:                depositTx.Amount,
+			ValidSignature:        depositTx.ValidSignature,
+			CredentialsMismatch:   depositTx.ProblemFlags&dbtypes.DepositTxProblemCredentialsMismatch != 0,
+			InvalidSignature:      depositTx.ProblemFlags&dbtypes.DepositTxProblemInvalidSignature != 0,
+			BlockNumber:           depositTx.BlockNumber,
+			BlockTime:             time.Unix(int64(depositTx.BlockTime), 0),
+			TxHash:                fmt.Sprintf("0x%x", depositTx.TxHash),
+		}
+		if txSender := getDepositSender(r, depositTx.TxSender); txSender != nil {
+			deposit.TxSender = fmt.Sprintf("0x%x", txSender)
+		}
+
+		response.Deposits = append(response.Deposits, deposit)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiDetectedSlashings returns the double & surround votes detected in unfinalized blocks, newest first.
+// supported filters: validator (index), type (double_vote / surround_vote), min_epoch
+func ApiDetectedSlashings(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	paging, err := parseApiPaging(urlArgs, 50, 100)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	slashingFilter := &dbtypes.DetectedSlashingFilter{}
+	if urlArgs.Has("validator") {
+		validatorIndex, err := strconv.ParseUint(urlArgs.Get("validator"), 10, 64)
+		if err != nil {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid validator index")
+			return
+		}
+		slashingFilter.Validator = &validatorIndex
+	}
+	if urlArgs.Has("min_epoch") {
+		minEpoch, _ := strconv.ParseUint(urlArgs.Get("min_epoch"), 10, 64)
+		slashingFilter.MinSlot = uint64(chainState.EpochToSlot(phase0.Epoch(minEpoch)))
+	}
+	switch urlArgs.Get("type") {
+	case "":
+	case "double_vote":
+		slashingFilter.Type = dbtypes.DetectedSlashingDoubleVote
+	case "surround_vote":
+		slashingFilter.Type = dbtypes.DetectedSlashingSurroundVote
+	default:
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid type filter (expected: double_vote or surround_vote)")
+		return
+	}
+
+	slashings, totalRows, err := db.GetDetectedSlashingsFiltered(paging.offset(), uint32(paging.limit), slashingFilter)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load detected slashings")
+		return
+	}
+
+	response := &apitypes.ApiDetectedSlashingsResponse{
+		Slashings:  make([]*apitypes.ApiDetectedSlashing, 0, len(slashings)),
+		Pagination: paging.getPagination(&totalRows, false),
+	}
+
+	for _, slashing := range slashings {
+		response.Slashings = append(response.Slashings, &apitypes.ApiDetectedSlashing{
+			Validator:     slashing.Validator,
+			ValidatorName: getValidatorName(r, slashing.Validator),
+			Slot:          slashing.Slot,
+			Epoch:         uint64(chainState.EpochOfSlot(phase0.Slot(slashing.Slot))),
+			Time:          chainState.SlotToTime(phase0.Slot(slashing.Slot)),
+			Type:          getDetectedSlashingTypeKey(slashing.Type),
+			Attestation1: &apitypes.ApiDetectedSlashingVote{
+				DataRoot:    fmt.Sprintf("0x%x", slashing.Att1Root),
+				SourceEpoch: slashing.Att1Source,
+				TargetEpoch: slashing.Att1Target,
+			},
+			Attestation2: &apitypes.ApiDetectedSlashingVote{
+				DataRoot:    fmt.Sprintf("0x%x", slashing.Att2Root),
+				SourceEpoch: slashing.Att2Source,
+				TargetEpoch: slashing.Att2Target,
+			},
+			Broadcasted:  slashing.Broadcasted,
+			Included:     slashing.IncludedSlot != nil,
+			IncludedSlot: slashing.IncludedSlot,
+		})
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
+
+func getDetectedSlashingTypeKey(slashingType dbtypes.DetectedSlashingType) string {
+	switch slashingType {
+	case dbtypes.DetectedSlashingDoubleVote:
+		return "double_vote"
+	case dbtypes.DetectedSlashingSurroundVote:
+		return "surround_vote"
+	default:
+		return "unknown"
+	}
+}
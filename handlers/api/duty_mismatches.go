@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiDutyMismatches returns the duties reported by the connected beacon nodes that differ from the duties computed by the explorer, newest first.
+// supported filters: client_id, type (proposer / attester), min_epoch
+func ApiDutyMismatches(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	paging, err := parseApiPaging(urlArgs, 50, 100)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	mismatchFilter := &dbtypes.DutyMismatchFilter{}
+	if urlArgs.Has("client_id") {
+		clientId, err := strconv.ParseUint(urlArgs.Get("client_id"), 10, 64)
+		if err != nil {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid client id")
+			return
+		}
+		mismatchFilter.ClientId = &clientId
+	}
+	if urlArgs.Has("min_epoch") {
+		mismatchFilter.MinEpoch, _ = strconv.ParseUint(urlArgs.Get("min_epoch"), 10, 64)
+	}
+	switch urlArgs.Get("type") {
+	case "":
+	case "proposer":
+		mismatchFilter.DutyType = dbtypes.DutyMismatchProposer
+	case "attester":
+		mismatchFilter.DutyType = dbtypes.DutyMismatchAttester
+	default:
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid type filter (expected: proposer or attester)")
+		return
+	}
+
+	mismatches, totalRows, err := db.GetDutyMismatchesFiltered(paging.offset(), uint32(paging.limit), mismatchFilter)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load duty mismatches")
+		return
+	}
+
+	response := &apitypes.ApiDutyMismatchesResponse{
+		Mismatches: make([]*apitypes.ApiDutyMismatch, 0, len(mismatches)),
+		Pagination: paging.getPagination(&totalRows, false),
+	}
+
+	for _, mismatch := range mismatches {
+		apiMismatch := &apitypes.ApiDutyMismatch{
+			Epoch:         mismatch.Epoch,
+			ClientId:      mismatch.ClientId,
+			ClientName:    getClientName(r, mismatch.ClientName),
+			Type:          getDutyMismatchTypeKey(mismatch.DutyType),
+			Slot:          mismatch.Slot,
+			DependentRoot: fmt.Sprintf("0x%x", mismatch.DependentRoot),
+			Expected:      parseDutyIndices(mismatch.Expected),
+			Reported:      parseDutyIndices(mismatch.Reported),
+			DetectedAt:    time.Unix(int64(mismatch.DetectedAt), 0),
+		}
+		if mismatch.DutyType == dbtypes.DutyMismatchAttester {
+			apiMismatch.CommitteeIndex = &mismatch.CommitteeIndex
+		}
+
+		response.Mismatches = append(response.Mismatches, apiMismatch)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
+
+func getDutyMismatchTypeKey(dutyType dbtypes.DutyMismatchType) string {
+	switch dutyType {
+	case dbtypes.DutyMismatchProposer:
+		return "proposer"
+	case dbtypes.DutyMismatchAttester:
+		return "attester"
+	default:
+		return "unknown"
+	}
+}
+
+// parseDutyIndices parses the comma separated validator indices stored with a duty mismatch
+func parseDutyIndices(indices string) []uint64 {
+	result := []uint64{}
+	if indices == "" {
+		return result
+	}
+
+	for _, indexStr := range strings.Split(indices, ",") {
+		index, err := strconv.ParseUint(indexStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		result = append(result, index)
+	}
+
+	return result
+}
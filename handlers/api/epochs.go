@@ -0,0 +1,132 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// maxEpochAggregatesRange is the max number of epochs returned per epoch aggregates request
+const maxEpochAggregatesRange = 100
+
+// ApiEpochAggregates returns the indexed aggregates of finalized epochs in the requested range (newest first).
+// unfinalized epochs are not returned, as their aggregates may still change.
+func ApiEpochAggregates(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	paging, err := parseApiPaging(urlArgs, maxEpochAggregatesRange, maxEpochAggregatesRange)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var maxEpoch, minEpoch *uint64
+	if urlArgs.Has("max_epoch") {
+		epoch, err := strconv.ParseUint(urlArgs.Get("max_epoch"), 10, 64)
+		if err != nil {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid max_epoch")
+			return
+		}
+		maxEpoch = &epoch
+	}
+	if urlArgs.Has("min_epoch") {
+		epoch, err := strconv.ParseUint(urlArgs.Get("min_epoch"), 10, 64)
+		if err != nil {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid min_epoch")
+			return
+		}
+		minEpoch = &epoch
+	}
+
+	finalizedEpoch, _ := services.GlobalBeaconService.GetChainState().GetFinalizedCheckpoint()
+	response := &apitypes.ApiEpochAggregatesResponse{
+		FinalizedEpoch: uint64(finalizedEpoch),
+		Epochs:         []*apitypes.ApiEpochAggregate{},
+	}
+
+	// the range is limited to the finalized epochs, page 1 starts at the highest epoch of the range
+	totalCount := uint64(0)
+	firstEpoch := uint64(0)
+	if finalizedEpoch > 0 {
+		firstEpoch = uint64(finalizedEpoch) - 1
+		if maxEpoch != nil && *maxEpoch < firstEpoch {
+			firstEpoch = *maxEpoch
+		}
+
+		lastEpoch := uint64(0)
+		if minEpoch != nil {
+			lastEpoch = *minEpoch
+		}
+		if lastEpoch <= firstEpoch {
+			totalCount = firstEpoch - lastEpoch + 1
+		}
+	}
+	response.Pagination = paging.getPagination(&totalCount, false)
+
+	if paging.offset() >= totalCount {
+		sendOKResponse(w, r.URL.String(), response)
+		return
+	}
+
+	firstEpoch -= paging.offset()
+	epochLimit := paging.limit
+	if remaining := totalCount - paging.offset(); remaining < epochLimit {
+		epochLimit = remaining
+	}
+
+	formatter := getApiFormatter(r)
+	for _, epoch := range db.GetEpochs(firstEpoch, uint32(epochLimit)) {
+		if minEpoch != nil && epoch.Epoch < *minEpoch {
+			break
+		}
+
+		aggregate := buildApiEpochAggregate(epoch)
+		if formatter != nil {
+			formatApiEpochAggregate(aggregate, formatter)
+		}
+		response.Epochs = append(response.Epochs, aggregate)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
+
+func buildApiEpochAggregate(epoch *dbtypes.Epoch) *apitypes.ApiEpochAggregate {
+	return &apitypes.ApiEpochAggregate{
+		Epoch:                 epoch.Epoch,
+		ValidatorCount:        epoch.ValidatorCount,
+		ValidatorBalance:      epoch.ValidatorBalance,
+		Eligible:              epoch.Eligible,
+		VotedTarget:           epoch.VotedTarget,
+		VotedHead:             epoch.VotedHead,
+		VotedTotal:            epoch.VotedTotal,
+		BlockCount:            uint64(epoch.BlockCount),
+		OrphanedCount:         uint64(epoch.OrphanedCount),
+		AttestationCount:      epoch.AttestationCount,
+		DepositCount:          epoch.DepositCount,
+		ExitCount:             epoch.ExitCount,
+		WithdrawCount:         epoch.WithdrawCount,
+		WithdrawAmount:        epoch.WithdrawAmount,
+		AttesterSlashingCount: epoch.AttesterSlashingCount,
+		ProposerSlashingCount: epoch.ProposerSlashingCount,
+		BLSChangeCount:        epoch.BLSChangeCount,
+		EthTransactionCount:   epoch.EthTransactionCount,
+	}
+}
+
+// formatApiEpochAggregate sets the human formatted fields of the epoch aggregate
+func formatApiEpochAggregate(aggregate *apitypes.ApiEpochAggregate, formatter *utils.LocaleFormatter) {
+	aggregate.ValidatorCountFormatted = formatter.FormatNumber(aggregate.ValidatorCount)
+	aggregate.ValidatorBalanceFormatted = formatter.FormatEthFromGwei(aggregate.ValidatorBalance)
+	aggregate.EligibleFormatted = formatter.FormatEthFromGwei(aggregate.Eligible)
+	aggregate.WithdrawAmountFormatted = formatter.FormatEthFromGwei(aggregate.WithdrawAmount)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/dora/services"
+)
+
+// eventStreamKeepaliveInterval is the interval of keepalive comments sent to idle stream clients
+const eventStreamKeepaliveInterval = 15 * time.Second
+
+// ApiEvents streams the deduplicated indexer events of the subscribed topics as server-sent-events
+func ApiEvents(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	if services.GlobalEventStream == nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusServiceUnavailable, "event stream not enabled")
+		return
+	}
+
+	topics := []string{}
+	for _, topic := range strings.Split(r.URL.Query().Get("topics"), ",") {
+		topic = strings.TrimSpace(topic)
+		if topic == "" {
+			continue
+		}
+		if !slices.Contains(services.EventStreamTopics, topic) {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, fmt.Sprintf("invalid topic: %v", topic))
+			return
+		}
+		topics = append(topics, topic)
+	}
+	if len(topics) == 0 {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "missing topics")
+		return
+	}
+
+	subscriber, err := services.GlobalEventStream.Subscribe(topics)
+	if err != nil {
+		if errors.Is(err, services.ErrEventStreamFull) {
+			sendErrorResponse(w, r.URL.String(), http.StatusServiceUnavailable, err.Error())
+		} else {
+			sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+	defer services.GlobalEventStream.Unsubscribe(subscriber)
+
+	// lift the server write timeout for the long lived stream
+	responseController := http.NewResponseController(w)
+	responseController.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	responseController.Flush()
+
+	keepaliveTicker := time.NewTicker(eventStreamKeepaliveInterval)
+	defer keepaliveTicker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case message := <-subscriber.Messages:
+			_, err = fmt.Fprintf(w, "event: %v\ndata: %s\n\n", message.Event, message.Data)
+		case <-keepaliveTicker.C:
+			_, err = fmt.Fprint(w, ": keepalive\n\n")
+		}
+		if err != nil {
+			return
+		}
+		if err := responseController.Flush(); err != nil {
+			return
+		}
+	}
+}
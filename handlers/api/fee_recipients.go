@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiFeeRecipients returns the fee recipients used by the proposers of canonical blocks, mismatches first.
+// supported filters: min_epoch (defaults to 24h ago), mismatches (true = only unexpected fee recipients)
+func ApiFeeRecipients(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	paging, err := parseApiPaging(urlArgs, 50, 100)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	minSlot := uint64(chainState.TimeToSlot(time.Now().Add(-24 * time.Hour)))
+	if urlArgs.Has("min_epoch") {
+		minEpoch, _ := strconv.ParseUint(urlArgs.Get("min_epoch"), 10, 64)
+		minSlot = uint64(chainState.EpochToSlot(phase0.Epoch(minEpoch)))
+	}
+	mismatchesOnly := urlArgs.Get("mismatches") == "true"
+
+	summaries, err := services.GlobalBeaconService.GetFeeRecipientSummaries(minSlot)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load fee recipients")
+		return
+	}
+
+	recipients := make([]*apitypes.ApiFeeRecipient, 0, len(summaries))
+	var mismatches uint64
+	for _, summary := range summaries {
+		if summary.Mismatch {
+			mismatches += summary.Blocks
+		} else if mismatchesOnly {
+			continue
+		}
+
+		recipient := &apitypes.ApiFeeRecipient{
+			Validator:     summary.Validator,
+			ValidatorName: summary.ValidatorName,
+			FeeRecipient:  summary.FeeRecipient.String(),
+			Mismatch:      summary.Mismatch,
+			Blocks:        summary.Blocks,
+			FirstSlot:     summary.FirstSlot,
+			LastSlot:      summary.LastSlot,
+		}
+		if summary.Expected != nil {
+			recipient.Expected = summary.Expected.String()
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	response := &apitypes.ApiFeeRecipientsResponse{
+		Mismatches: mismatches,
+	}
+
+	totalCount := uint64(len(recipients))
+	response.Pagination = paging.getPagination(&totalCount, false)
+
+	if firstIdx := paging.offset(); firstIdx < totalCount {
+		lastIdx := firstIdx + paging.limit
+		if lastIdx > totalCount {
+			lastIdx = totalCount
+		}
+		response.Recipients = recipients[firstIdx:lastIdx]
+	} else {
+		response.Recipients = []*apitypes.ApiFeeRecipient{}
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
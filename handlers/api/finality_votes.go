@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// ApiEpochFinalityVotes returns the target vote map of a finalized epoch.
+// it lists the active validators whose target votes have not been included, which helps to analyse delayed justification.
+func ApiEpochFinalityVotes(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	epoch, err := strconv.ParseUint(vars["epoch"], 10, 64)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid epoch")
+		return
+	}
+
+	voteMap, err := services.GlobalBeaconService.GetBeaconIndexer().GetFinalityVoteMap(phase0.Epoch(epoch))
+	if err != nil {
+		logrus.Warnf("failed loading finality votes for epoch %v: %v", epoch, err)
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load finality votes")
+		return
+	}
+	if voteMap == nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "finality votes for epoch not recorded (epoch not finalized yet?)")
+		return
+	}
+
+	response := &apitypes.ApiEpochFinalityVotesResponse{
+		Epoch:             epoch,
+		TargetRoot:        voteMap.TargetRoot.String(),
+		ActiveCount:       voteMap.ActiveCount,
+		VotedCount:        voteMap.VotedCount,
+		MissingCount:      voteMap.ActiveCount - voteMap.VotedCount,
+		ActiveBalance:     uint64(voteMap.ActiveBalance),
+		VotedBalance:      uint64(voteMap.VotedBalance),
+		MissingValidators: make([]uint64, 0, voteMap.ActiveCount-voteMap.VotedCount),
+	}
+	if voteMap.ActiveBalance > 0 {
+		response.VotedPercent = float64(voteMap.VotedBalance) * 100 / float64(voteMap.ActiveBalance)
+	}
+
+	for validatorIndex := uint64(0); validatorIndex < uint64(len(voteMap.ActiveBits))*8; validatorIndex++ {
+		if voteMap.IsActive(phase0.ValidatorIndex(validatorIndex)) && !voteMap.HasVoted(phase0.ValidatorIndex(validatorIndex)) {
+			response.MissingValidators = append(response.MissingValidators, validatorIndex)
+		}
+	}
+
+	if r.URL.Query().Get("bitfield") == "true" {
+		response.ActiveBitfield = fmt.Sprintf("0x%x", voteMap.ActiveBits)
+		response.VotedBitfield = fmt.Sprintf("0x%x", voteMap.VotedBits)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/dora/utils"
+)
+
+// localeParam is the query parameter of endpoints that return human formatted values next to the raw values
+var localeParam = ApiRouteParam{Name: "locale", In: "query", Type: "string", Description: "Locale for the formatted fields (e.g. en-US, de-DE), the formatted fields are omitted if not set"}
+
+// getApiFormatter returns the formatter for the requested locale, or nil if no formatted fields are requested
+func getApiFormatter(r *http.Request) *utils.LocaleFormatter {
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		return nil
+	}
+	return utils.GetLocaleFormatter(locale)
+}
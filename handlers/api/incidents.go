@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiValidatorIncidents returns the missed duty incidents grouped by entity, newest first.
+// supported filters: entity (exact name), status (open / resolved), min_epoch
+func ApiValidatorIncidents(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	paging, err := parseApiPaging(urlArgs, 50, 100)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	incidentFilter := &dbtypes.ValidatorIncidentFilter{
+		Entity: urlArgs.Get("entity"),
+	}
+	if urlArgs.Has("min_epoch") {
+		incidentFilter.MinEpoch, _ = strconv.ParseUint(urlArgs.Get("min_epoch"), 10, 64)
+	}
+	switch urlArgs.Get("status") {
+	case "":
+	case "open":
+		resolved := false
+		incidentFilter.Resolved = &resolved
+	case "resolved":
+		resolved := true
+		incidentFilter.Resolved = &resolved
+	default:
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid status filter (expected: open or resolved)")
+		return
+	}
+
+	incidents, totalRows, err := db.GetValidatorIncidentsFiltered(paging.offset(), uint32(paging.limit), incidentFilter)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load incidents")
+		return
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	response := &apitypes.ApiValidatorIncidentsResponse{
+		Incidents:  make([]*apitypes.ApiValidatorIncident, 0, len(incidents)),
+		Pagination: paging.getPagination(&totalRows, false),
+	}
+
+	for _, incident := range incidents {
+		response.Incidents = append(response.Incidents, &apitypes.ApiValidatorIncident{
+			Entity:             incident.Entity,
+			StartEpoch:         incident.StartEpoch,
+			StartTime:          chainState.EpochToTime(phase0.Epoch(incident.StartEpoch)),
+			EndEpoch:           incident.EndEpoch,
+			EndTime:            chainState.EpochToTime(phase0.Epoch(incident.EndEpoch + 1)),
+			Validators:         incident.Validators,
+			MissedAttestations: incident.MissedAttestations,
+			MissedProposals:    incident.MissedProposals,
+			EstimatedLoss:      incident.EstimatedLoss,
+			Resolved:           incident.Resolved,
+		})
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
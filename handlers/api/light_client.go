@@ -0,0 +1,195 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// lightClientMaxPeriods is the max number of sync committee periods returned by the light client updates endpoint
+const lightClientMaxPeriods = 16
+
+// ApiLightClientUpdates returns the best light client updates served by the connected clients for a range of sync committee periods.
+// supported filters: start_period (defaults to the current period), count, data
+func ApiLightClientUpdates(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	startPeriod, ok := getLightClientPeriodArg(w, r, "start_period")
+	if !ok {
+		return
+	}
+
+	count := uint64(1)
+	if urlArgs.Has("count") {
+		count, err = strconv.ParseUint(urlArgs.Get("count"), 10, 64)
+		if err != nil || count == 0 {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid count")
+			return
+		}
+		if count > lightClientMaxPeriods {
+			count = lightClientMaxPeriods
+		}
+	}
+	withData := urlArgs.Get("data") == "true"
+
+	updates, err := db.GetLightClientUpdates(startPeriod, startPeriod+count-1, withData)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load light client updates")
+		return
+	}
+
+	response := &apitypes.ApiLightClientUpdatesResponse{
+		StartPeriod: startPeriod,
+		EndPeriod:   startPeriod + count - 1,
+		Updates:     make([]*apitypes.ApiLightClientUpdate, 0, len(updates)),
+	}
+
+	for _, update := range updates {
+		apiUpdate := &apitypes.ApiLightClientUpdate{
+			Period:            update.Period,
+			ClientName:        getClientName(r, update.ClientName),
+			Type:              "update",
+			Version:           update.Version,
+			AttestedSlot:      update.AttestedSlot,
+			AttestedRoot:      fmt.Sprintf("0x%x", update.AttestedRoot),
+			FinalizedSlot:     update.FinalizedSlot,
+			FinalizedRoot:     fmt.Sprintf("0x%x", update.FinalizedRoot),
+			SignatureSlot:     update.SignatureSlot,
+			Participation:     update.Participation,
+			NextCommitteeRoot: fmt.Sprintf("0x%x", update.NextCommitteeRoot),
+			Status:            getLightClientStatusKey(update.Status),
+			Result:            update.Result,
+			UpdatedAt:         time.Unix(int64(update.UpdatedAt), 0),
+		}
+		if withData && len(update.Data) > 0 {
+			apiUpdate.Data = json.RawMessage(update.Data)
+		}
+
+		response.Updates = append(response.Updates, apiUpdate)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
+
+// ApiLightClientHeadUpdates returns the latest light client finality & optimistic updates served by the connected clients in a sync committee period.
+// supported filters: period (defaults to the current period), type (finality / optimistic), data
+func ApiLightClientHeadUpdates(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	period, ok := getLightClientPeriodArg(w, r, "period")
+	if !ok {
+		return
+	}
+
+	var updateType dbtypes.LightClientHeadUpdateType
+	switch urlArgs.Get("type") {
+	case "":
+	case "finality":
+		updateType = dbtypes.LightClientFinalityUpdate
+	case "optimistic":
+		updateType = dbtypes.LightClientOptimisticUpdate
+	default:
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid type filter (expected: finality or optimistic)")
+		return
+	}
+	withData := urlArgs.Get("data") == "true"
+
+	updates, err := db.GetLightClientHeadUpdates(period, period, withData)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load light client updates")
+		return
+	}
+
+	response := &apitypes.ApiLightClientHeadUpdatesResponse{
+		Period:  period,
+		Updates: make([]*apitypes.ApiLightClientUpdate, 0, len(updates)),
+	}
+
+	for _, update := range updates {
+		if updateType > 0 && update.UpdateType != updateType {
+			continue
+		}
+
+		apiUpdate := &apitypes.ApiLightClientUpdate{
+			Period:        update.Period,
+			ClientName:    getClientName(r, update.ClientName),
+			Type:          getLightClientHeadUpdateTypeKey(update.UpdateType),
+			Version:       update.Version,
+			AttestedSlot:  update.AttestedSlot,
+			AttestedRoot:  fmt.Sprintf("0x%x", update.AttestedRoot),
+			FinalizedSlot: update.FinalizedSlot,
+			FinalizedRoot: fmt.Sprintf("0x%x", update.FinalizedRoot),
+			SignatureSlot: update.SignatureSlot,
+			Participation: update.Participation,
+			Status:        getLightClientStatusKey(update.Status),
+			Result:        update.Result,
+			UpdatedAt:     time.Unix(int64(update.UpdatedAt), 0),
+		}
+		if withData && len(update.Data) > 0 {
+			apiUpdate.Data = json.RawMessage(update.Data)
+		}
+
+		response.Updates = append(response.Updates, apiUpdate)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
+
+// getLightClientPeriodArg parses a sync committee period query argument, defaults to the current period
+func getLightClientPeriodArg(w http.ResponseWriter, r *http.Request, name string) (uint64, bool) {
+	urlArgs := r.URL.Query()
+	if urlArgs.Has(name) {
+		period, err := strconv.ParseUint(urlArgs.Get(name), 10, 64)
+		if err != nil {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, fmt.Sprintf("invalid %v", name))
+			return 0, false
+		}
+		return period, true
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	epochsPerPeriod := chainState.GetSpecs().EpochsPerSyncCommitteePeriod
+	if epochsPerPeriod == 0 {
+		return 0, true
+	}
+	return uint64(chainState.CurrentEpoch()) / epochsPerPeriod, true
+}
+
+func getLightClientStatusKey(status dbtypes.LightClientUpdateStatus) string {
+	switch status {
+	case dbtypes.LightClientUpdateValid:
+		return "valid"
+	case dbtypes.LightClientUpdateInvalid:
+		return "invalid"
+	default:
+		return "unverified"
+	}
+}
+
+func getLightClientHeadUpdateTypeKey(updateType dbtypes.LightClientHeadUpdateType) string {
+	switch updateType {
+	case dbtypes.LightClientFinalityUpdate:
+		return "finality"
+	case dbtypes.LightClientOptimisticUpdate:
+		return "optimistic"
+	default:
+		return "unknown"
+	}
+}
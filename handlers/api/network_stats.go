@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiNetworkStats returns the rolling 1d / 7d / 31d network stats (participation, missed slots, reorgs, validator growth & block value).
+// the stats are aggregated from the finalized epochs and refreshed once per epoch.
+func ApiNetworkStats(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	stats, err := services.GlobalBeaconService.GetNetworkStats()
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load network stats")
+		return
+	}
+
+	response := &apitypes.ApiNetworkStatsResponse{
+		CurrentEpoch:   stats.CurrentEpoch,
+		FinalizedEpoch: stats.FinalizedEpoch,
+		UpdatedAt:      stats.UpdatedAt,
+		Windows:        make([]*apitypes.ApiNetworkStatsWindow, 0, len(stats.Windows)),
+	}
+
+	for _, window := range stats.Windows {
+		apiWindow := &apitypes.ApiNetworkStatsWindow{
+			Window:              window.Name,
+			FirstEpoch:          window.FirstEpoch,
+			LastEpoch:           window.LastEpoch,
+			Epochs:              window.Epochs,
+			Slots:               window.Slots,
+			Blocks:              window.Blocks,
+			MissedSlots:         window.MissedSlots,
+			MissedSlotRate:      window.MissedSlotRate,
+			ReorgCount:          window.ReorgCount,
+			Participation:       window.Participation,
+			HeadParticipation:   window.HeadParticipation,
+			SyncParticipation:   window.SyncParticipation,
+			ValidatorCountStart: window.ValidatorCountStart,
+			ValidatorCountEnd:   window.ValidatorCountEnd,
+			ValidatorGrowth:     window.ValidatorGrowth,
+			BlockValueBlocks:    window.BlockValueBlocks,
+		}
+		if window.HasBlockValue {
+			avgBlockValue := window.AvgBlockValue
+			apiWindow.AvgBlockValue = &avgBlockValue
+		}
+
+		response.Windows = append(response.Windows, apiWindow)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
@@ -0,0 +1,316 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+var openApiSpecMutex sync.Mutex
+var openApiSpecCache []byte
+
+// ApiOpenApiSpec returns the openapi 3 specification of the json api
+func ApiOpenApiSpec(w http.ResponseWriter, r *http.Request) {
+	openApiSpecMutex.Lock()
+	if openApiSpecCache == nil {
+		specJson, err := json.Marshal(BuildOpenApiSpec())
+		if err != nil {
+			openApiSpecMutex.Unlock()
+			sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not build openapi specification")
+			return
+		}
+		openApiSpecCache = specJson
+	}
+	specJson := openApiSpecCache
+	openApiSpecMutex.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if _, err := w.Write(specJson); err != nil {
+		logrus.WithError(err).WithField("route", r.URL.String()).Error("error writing openapi specification")
+	}
+}
+
+// BuildOpenApiSpec generates the openapi 3 specification from the api route definitions
+func BuildOpenApiSpec() map[string]interface{} {
+	generator := &openApiGenerator{
+		schemas: map[string]interface{}{},
+	}
+
+	generator.schemas["ApiResponse"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"status": map[string]interface{}{
+				"type":        "string",
+				"description": "\"OK\" for successful requests, \"ERROR: <message>\" otherwise",
+			},
+		},
+		"required": []string{"status"},
+	}
+
+	paths := map[string]interface{}{}
+	for _, route := range ApiRoutes {
+		pathItem, ok := paths[route.Path].(map[string]interface{})
+		if !ok {
+			pathItem = map[string]interface{}{}
+			paths[route.Path] = pathItem
+		}
+		pathItem[strings.ToLower(route.Method)] = generator.buildOperation(route)
+	}
+
+	title := utils.Config.Frontend.SiteName
+	if title == "" {
+		title = "Dora the Explorer"
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   title + " API",
+			"version": utils.GetExplorerVersion(),
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": generator.schemas,
+			"securitySchemes": map[string]interface{}{
+				"adminToken": map[string]interface{}{
+					"type":   "http",
+					"scheme": "bearer",
+				},
+				"apiKey": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": "X-Api-Key",
+				},
+			},
+		},
+	}
+}
+
+type openApiGenerator struct {
+	schemas map[string]interface{}
+}
+
+func (generator *openApiGenerator) buildOperation(route *ApiRoute) map[string]interface{} {
+	errorResponse := map[string]interface{}{
+		"description": "Error",
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": "#/components/schemas/ApiResponse"},
+			},
+		},
+	}
+
+	successSchema := map[string]interface{}{
+		"allOf": []interface{}{
+			map[string]interface{}{"$ref": "#/components/schemas/ApiResponse"},
+			map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"data": generator.getSchema(reflect.TypeOf(route.Response)),
+				},
+			},
+		},
+	}
+
+	successContent := map[string]interface{}{
+		"application/json": map[string]interface{}{
+			"schema": successSchema,
+		},
+	}
+	if route.Stream {
+		successContent = map[string]interface{}{
+			"text/event-stream": map[string]interface{}{
+				"schema": map[string]interface{}{"type": "string"},
+			},
+		}
+	}
+	if route.Download != "" {
+		successContent = map[string]interface{}{
+			route.Download: map[string]interface{}{
+				"schema": map[string]interface{}{"type": "string", "format": "binary"},
+			},
+		}
+	}
+
+	operation := map[string]interface{}{
+		"summary":     route.Summary,
+		"description": route.Description,
+		"operationId": getOpenApiOperationId(route),
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "OK",
+				"content":     successContent,
+			},
+			"default": errorResponse,
+		},
+	}
+
+	if route.Tag != "" {
+		operation["tags"] = []string{route.Tag}
+	}
+
+	if route.Admin {
+		operation["security"] = []interface{}{
+			map[string]interface{}{"adminToken": []string{}},
+		}
+	} else if utils.Config.Api.Quotas.Enabled && utils.Config.Api.Quotas.RequireKey {
+		operation["security"] = []interface{}{
+			map[string]interface{}{"apiKey": []string{}},
+		}
+	} else {
+		// api keys are optional, requests without key are subject to the anonymous quota
+		operation["security"] = []interface{}{
+			map[string]interface{}{"apiKey": []string{}},
+			map[string]interface{}{},
+		}
+	}
+
+	if len(route.Params) > 0 {
+		params := make([]interface{}, 0, len(route.Params))
+		for _, param := range route.Params {
+			paramSchema := map[string]interface{}{"type": param.Type}
+			if len(param.Enum) > 0 {
+				paramSchema["enum"] = param.Enum
+			}
+			params = append(params, map[string]interface{}{
+				"name":        param.Name,
+				"in":          param.In,
+				"description": param.Description,
+				"required":    param.Required,
+				"schema":      paramSchema,
+			})
+		}
+		operation["parameters"] = params
+	}
+
+	if route.Request != nil {
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": generator.getSchema(reflect.TypeOf(route.Request)),
+				},
+			},
+		}
+	}
+
+	return operation
+}
+
+// getOpenApiOperationId returns a unique operation id for the route, e.g. "getAdminBlockcache"
+func getOpenApiOperationId(route *ApiRoute) string {
+	operationId := strings.ToLower(route.Method)
+	for _, part := range strings.Split(strings.TrimPrefix(route.Path, "/api/v1/"), "/") {
+		part = strings.Trim(part, "{}")
+		for _, word := range strings.FieldsFunc(part, func(r rune) bool { return r == '_' || r == '-' }) {
+			operationId += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return operationId
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// getSchema returns the json schema of the given type.
+// named struct types are added to the component schemas and referenced.
+func (generator *openApiGenerator) getSchema(t reflect.Type) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "int32", "minimum": 0}
+	case reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// byte slices are hex encoded
+			return map[string]interface{}{"type": "string"}
+		}
+		return map[string]interface{}{"type": "array", "items": generator.getSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": generator.getSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return generator.getStructSchema(t)
+		}
+
+		if _, exists := generator.schemas[t.Name()]; !exists {
+			generator.schemas[t.Name()] = map[string]interface{}{} // placeholder for recursive types
+			generator.schemas[t.Name()] = generator.getStructSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	// interface{} & other types can hold any value
+	return map[string]interface{}{}
+}
+
+func (generator *openApiGenerator) getStructSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	generator.addStructProperties(t, properties)
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+func (generator *openApiGenerator) addStructProperties(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(jsonTag, ",")
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				generator.addStructProperties(fieldType, properties)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = generator.getSchema(field.Type)
+	}
+}
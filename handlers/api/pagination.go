@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+var pagingParams = []ApiRouteParam{
+	{Name: "limit", In: "query", Type: "integer", Description: "Number of items per page (max 100)"},
+	{Name: "page", In: "query", Type: "integer", Description: "Page index (1 based, like the page numbers of the explorer pages)"},
+	{Name: "cursor", In: "query", Type: "string", Description: "Page cursor (next_cursor / prev_cursor of a previous response), overrides page"},
+}
+
+// apiPaging holds the parsed paging parameters of a list request
+type apiPaging struct {
+	limit   uint64
+	pageIdx uint64 // 0 based, the page query parameter & response page index are 1 based
+	keyset  *dbtypes.ListCursor
+}
+
+// parseApiPaging parses the limit, page & cursor query parameters of a list request.
+// the limit defaults to defaultLimit and is capped at maxLimit.
+func parseApiPaging(urlArgs url.Values, defaultLimit uint64, maxLimit uint64) (*apiPaging, error) {
+	paging := &apiPaging{
+		limit: defaultLimit,
+	}
+
+	if urlArgs.Has("limit") {
+		paging.limit, _ = strconv.ParseUint(urlArgs.Get("limit"), 10, 64)
+	}
+	if paging.limit > maxLimit || paging.limit == 0 {
+		paging.limit = maxLimit
+	}
+
+	if urlArgs.Has("page") {
+		pageNum, _ := strconv.ParseUint(urlArgs.Get("page"), 10, 64)
+		if pageNum > 1 {
+			paging.pageIdx = pageNum - 1
+		}
+	}
+
+	if cursor := urlArgs.Get("cursor"); cursor != "" {
+		offset, keyset, err := decodePageCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if keyset != nil {
+			paging.pageIdx = offset
+			paging.keyset = keyset
+		} else {
+			paging.pageIdx = offset / paging.limit
+		}
+	}
+
+	return paging, nil
+}
+
+// offset returns the offset of the first item of the requested page
+func (paging *apiPaging) offset() uint64 {
+	return paging.pageIdx * paging.limit
+}
+
+// getPagination returns the pagination envelope for the requested page.
+// totalCount is the total number of items, or nil if unknown (hasMore is used to determine the next page then).
+func (paging *apiPaging) getPagination(totalCount *uint64, hasMore bool) *apitypes.ApiPagination {
+	pagination := &apitypes.ApiPagination{
+		Limit:      paging.limit,
+		PageIndex:  paging.pageIdx + 1,
+		TotalCount: totalCount,
+	}
+
+	nextOffset := paging.offset() + paging.limit
+	if totalCount != nil {
+		hasMore = nextOffset < *totalCount
+	}
+	if hasMore {
+		pagination.NextCursor = encodePageCursor(nextOffset)
+	}
+	if paging.pageIdx > 0 {
+		pagination.PrevCursor = encodePageCursor(paging.offset() - paging.limit)
+	}
+
+	return pagination
+}
+
+// getKeysetPagination returns the pagination envelope for a keyset paginated list.
+// the next cursor continues after nextKeyset (the keyset cursor of the last returned row), while the previous cursor stays offset based.
+func (paging *apiPaging) getKeysetPagination(totalCount *uint64, hasMore bool, nextKeyset *dbtypes.ListCursor) *apitypes.ApiPagination {
+	pagination := paging.getPagination(totalCount, hasMore)
+	if pagination.NextCursor != "" && nextKeyset != nil {
+		pagination.NextCursor = encodeKeysetCursor(paging.pageIdx+1, nextKeyset)
+	}
+
+	return pagination
+}
+
+// encodePageCursor returns the opaque cursor of the page starting at the given offset
+func encodePageCursor(offset uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("o:%d", offset)))
+}
+
+// encodeKeysetCursor returns the opaque cursor of the page with the given index starting at the keyset cursor
+func encodeKeysetCursor(pageIdx uint64, keyset *dbtypes.ListCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("k:%d:%d:%d", pageIdx, keyset.Key, keyset.Skip)))
+}
+
+// decodePageCursor decodes an opaque page cursor.
+// returns the offset for offset based cursors, or the page index & keyset cursor for keyset cursors.
+func decodePageCursor(cursor string) (uint64, *dbtypes.ListCursor, error) {
+	cursorBytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, nil, errInvalidCursor
+	}
+
+	var offset uint64
+	if len(cursorBytes) > 2 && cursorBytes[0] == 'k' {
+		keyset := &dbtypes.ListCursor{}
+		if _, err := fmt.Sscanf(string(cursorBytes), "k:%d:%d:%d", &offset, &keyset.Key, &keyset.Skip); err != nil {
+			return 0, nil, errInvalidCursor
+		}
+		return offset, keyset, nil
+	}
+
+	if _, err := fmt.Sscanf(string(cursorBytes), "o:%d", &offset); err != nil {
+		return 0, nil, errInvalidCursor
+	}
+
+	return offset, nil, nil
+}
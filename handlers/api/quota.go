@@ -0,0 +1,102 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiRouteHandler returns the handler of an api route, wrapped with the quota check if api quotas are enabled.
+// the quota state of the caller is returned in the X-RateLimit-* response headers.
+func ApiRouteHandler(route *ApiRoute) http.HandlerFunc {
+	if route.Admin || route.SkipQuota {
+		return route.Handler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		usage, err := services.GlobalApiQuotaManager.CheckQuota(r)
+		if usage != nil {
+			setQuotaHeaders(w, usage)
+		}
+		if err != nil {
+			if errors.Is(err, services.ErrApiQuotaExceeded) {
+				sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+			} else if errors.Is(err, services.ErrInvalidApiKey) || errors.Is(err, services.ErrApiKeyRequired) {
+				sendErrorResponse(w, r.URL.String(), http.StatusUnauthorized, err.Error())
+			} else {
+				sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+			}
+			return
+		}
+
+		route.Handler(w, r)
+	}
+}
+
+func setQuotaHeaders(w http.ResponseWriter, usage *services.ApiQuotaUsage) {
+	if usage.Limit == 0 {
+		return
+	}
+
+	remaining := uint64(0)
+	if usage.Used < usage.Limit {
+		remaining = usage.Limit - usage.Used
+	}
+
+	w.Header().Set("X-RateLimit-Limit", strconv.FormatUint(usage.Limit, 10))
+	w.Header().Set("X-RateLimit-Remaining", strconv.FormatUint(remaining, 10))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(usage.ResetTime.Unix(), 10))
+}
+
+// ApiUsage returns the api usage of the calling key / ip within the current quota window (the call itself is not counted)
+func ApiUsage(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	if services.GlobalApiQuotaManager == nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "api quotas are not enabled")
+		return
+	}
+
+	usage, err := services.GlobalApiQuotaManager.GetUsage(r)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidApiKey) || errors.Is(err, services.ErrApiKeyRequired) {
+			sendErrorResponse(w, r.URL.String(), http.StatusUnauthorized, err.Error())
+		} else {
+			sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	setQuotaHeaders(w, usage)
+
+	response := &apitypes.ApiUsageResponse{
+		Identity:   usage.Identity,
+		Tier:       usage.Tier,
+		Limit:      usage.Limit,
+		Used:       usage.Used,
+		Window:     uint64(usage.Window.Seconds()),
+		ResetTime:  usage.ResetTime,
+		BucketSize: uint64(usage.BucketSize.Seconds()),
+		Usage:      make([]*apitypes.ApiUsageBucket, 0, len(usage.History)),
+	}
+	if usage.Used < usage.Limit {
+		response.Remaining = usage.Limit - usage.Used
+	}
+
+	bucketTime := usage.HistoryStart
+	for _, calls := range usage.History {
+		response.Usage = append(response.Usage, &apitypes.ApiUsageBucket{
+			Time:  bucketTime,
+			Calls: calls,
+		})
+		bucketTime = bucketTime.Add(usage.BucketSize)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
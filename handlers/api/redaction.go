@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/dora/services"
+)
+
+// the redaction helpers return the unredacted values for requests authenticated with the admin token.
+
+func getValidatorName(r *http.Request, index uint64) string {
+	if isAdminRequest(r) {
+		return services.GlobalBeaconService.GetUnredactedValidatorName(index)
+	}
+	return services.GlobalBeaconService.GetValidatorName(index)
+}
+
+func getClientName(r *http.Request, name string) string {
+	if isAdminRequest(r) {
+		return name
+	}
+	return services.RedactClientName(name)
+}
+
+func getDepositSender(r *http.Request, sender []byte) []byte {
+	if isAdminRequest(r) {
+		return sender
+	}
+	return services.RedactDepositSender(sender)
+}
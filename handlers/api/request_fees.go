@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiRequestFees returns the current fees of the withdrawal & consolidation request contracts and the fees paid by past requests.
+// supported filters: min_time (unix timestamp, defaults to 7 days ago), bucket (history bucket size in seconds, defaults to 1 hour)
+func ApiRequestFees(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	minTime := uint64(time.Now().Add(-7 * 24 * time.Hour).Unix())
+	if urlArgs.Has("min_time") {
+		minTime, _ = strconv.ParseUint(urlArgs.Get("min_time"), 10, 64)
+	}
+	bucketSize := uint64(3600)
+	if urlArgs.Has("bucket") {
+		bucketSize, _ = strconv.ParseUint(urlArgs.Get("bucket"), 10, 64)
+		if bucketSize < 60 {
+			bucketSize = 60
+		}
+	}
+
+	response := &apitypes.ApiRequestFeesResponse{
+		Withdrawals:    getApiRequestFees(services.GlobalBeaconService.GetWithdrawalRequestFees(minTime, bucketSize), bucketSize),
+		Consolidations: getApiRequestFees(services.GlobalBeaconService.GetConsolidationRequestFees(minTime, bucketSize), bucketSize),
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
+
+func getApiRequestFees(requestFees *services.RequestFees, bucketSize uint64) *apitypes.ApiRequestFees {
+	apiFees := &apitypes.ApiRequestFees{
+		Contract: requestFees.ContractAddress.String(),
+		History:  make([]*apitypes.ApiRequestFeesBucket, 0, len(requestFees.History)),
+	}
+
+	if requestFees.CurrentFee != nil {
+		apiFees.CurrentFee = requestFees.CurrentFee.String()
+	}
+
+	for _, stats := range requestFees.History {
+		apiFees.History = append(apiFees.History, &apitypes.ApiRequestFeesBucket{
+			Time:     time.Unix(int64(stats.Bucket*bucketSize), 0),
+			Requests: stats.Requests,
+			MinFee:   stats.MinFee,
+			MaxFee:   stats.MaxFee,
+			AvgFee:   stats.AvgFee,
+		})
+	}
+
+	return apiFees
+}
@@ -0,0 +1,568 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiRoute describes a json api endpoint.
+// the route definitions are used to register the handlers and to generate the openapi specification.
+type ApiRoute struct {
+	Path        string
+	Method      string
+	Handler     http.HandlerFunc
+	Summary     string
+	Description string
+	Tag         string
+	Admin       bool            // requires the admin bearer token
+	SkipQuota   bool            // not counted against the api quotas (admin endpoints are never counted)
+	Stream      bool            // server-sent-events stream instead of a json response
+	Download    string          // content type of a file download instead of a json response
+	Params      []ApiRouteParam // path & query parameters
+	Request     interface{}     // request body type (nil = no body)
+	Response    interface{}     // type of the response data field
+}
+
+// ApiRouteParam describes a path or query parameter of an api endpoint
+type ApiRouteParam struct {
+	Name        string
+	In          string // "path" or "query"
+	Type        string // "string", "integer" or "boolean"
+	Description string
+	Required    bool
+	Enum        []string
+}
+
+// ApiRoutes holds all json api endpoints
+var ApiRoutes = []*ApiRoute{
+	{
+		Path:        "/api/v1/deposits/problematic",
+		Method:      http.MethodGet,
+		Handler:     ApiProblematicDeposits,
+		Summary:     "Get problematic deposits",
+		Description: "Returns deposits that probably resulted in lost funds (reused pubkeys with different withdrawal credentials or invalid signatures).",
+		Tag:         "deposits",
+		Params:      pagingParams,
+		Response:    &apitypes.ApiProblematicDepositsResponse{},
+	},
+	{
+		Path:        "/api/v1/address_watch",
+		Method:      http.MethodGet,
+		Handler:     ApiAddressWatch,
+		Summary:     "Get watched address activity",
+		Description: "Returns the deposit, withdrawal request and consolidation request contract interactions of the configured watched addresses, newest first. Addresses are matched against the transaction sender and the request source address.",
+		Tag:         "deposits",
+		Params: append([]ApiRouteParam{
+			{Name: "address", In: "query", Type: "string", Description: "Watched address to filter for"},
+			{Name: "type", In: "query", Type: "string", Description: "Interaction type", Enum: []string{"deposit", "withdrawal_request", "consolidation_request"}},
+		}, pagingParams...),
+		Response: &apitypes.ApiAddressWatchResponse{},
+	},
+	{
+		Path:        "/api/v1/usage",
+		Method:      http.MethodGet,
+		Handler:     ApiUsage,
+		Summary:     "Get api usage",
+		Description: "Returns the api quota usage of the calling api key (X-Api-Key header or apikey query parameter) or ip address within the rolling quota window. This endpoint is not counted against the quota.",
+		Tag:         "api",
+		SkipQuota:   true,
+		Response:    &apitypes.ApiUsageResponse{},
+	},
+	{
+		Path:        "/api/v1/validators/metadata",
+		Method:      http.MethodGet,
+		Handler:     ApiValidatorMetadata,
+		Summary:     "Get validator metadata by indices",
+		Description: "Returns the pubkey, entity name & current status of a batch of validators. Validators are resolved with a single lookup, so this endpoint is suited for rendering committees with hundreds of validators. Unknown validators are omitted from the response.",
+		Tag:         "validators",
+		Params: []ApiRouteParam{
+			{Name: "indices", In: "query", Type: "string", Description: "Comma separated list of validator indices or index ranges (e.g. 1,5,100-200), max 2048 validators", Required: true},
+		},
+		Response: &apitypes.ApiValidatorMetadataResponse{},
+	},
+	{
+		Path:        "/api/v1/validator/{idxOrPubKey}/effectiveness",
+		Method:      http.MethodGet,
+		Handler:     ApiValidatorEffectiveness,
+		Summary:     "Get validator effectiveness",
+		Description: "Returns the daily effectiveness scores & network-wide percentile rankings of a validator.",
+		Tag:         "validators",
+		Params: []ApiRouteParam{
+			{Name: "idxOrPubKey", In: "path", Type: "string", Description: "Validator index or hex encoded pubkey", Required: true},
+			{Name: "limit", In: "query", Type: "integer", Description: "Number of days (max 100)"},
+		},
+		Response: &apitypes.ApiValidatorEffectivenessResponse{},
+	},
+	{
+		Path:        "/api/v1/validator/{idxOrPubKey}/timeline",
+		Method:      http.MethodGet,
+		Handler:     ApiValidatorTimeline,
+		Summary:     "Get validator lifecycle timeline",
+		Description: "Returns the lifecycle events of a validator (deposit transactions, deposits, activation, credential changes, withdrawal & consolidation requests, slashings and exits) in chronological order. Bls credential changes are derived from the credential prefixes and have no slot.",
+		Tag:         "validators",
+		Params: []ApiRouteParam{
+			{Name: "idxOrPubKey", In: "path", Type: "string", Description: "Validator index or hex encoded pubkey", Required: true},
+		},
+		Response: &apitypes.ApiValidatorTimelineResponse{},
+	},
+	{
+		Path:        "/api/v1/validator/{idxOrPubKey}/proposals",
+		Method:      http.MethodGet,
+		Handler:     ApiValidatorProposals,
+		Summary:     "Get validator block proposals",
+		Description: "Returns the block proposals of a validator (canonical, orphaned & missing blocks) newest first, including the proposer rewards of canonical blocks when the rewards indexer is enabled. Rewards are in gwei.",
+		Tag:         "validators",
+		Params: append([]ApiRouteParam{
+			{Name: "idxOrPubKey", In: "path", Type: "string", Description: "Validator index or hex encoded pubkey", Required: true},
+			{Name: "status", In: "query", Type: "string", Description: "Proposal status", Enum: []string{"canonical", "orphaned", "missing"}},
+		}, pagingParams...),
+		Response: &apitypes.ApiValidatorProposalsResponse{},
+	},
+	{
+		Path:        "/api/v1/validator/{idxOrPubKey}/attestation/{epoch}",
+		Method:      http.MethodGet,
+		Handler:     ApiAttestationInclusions,
+		Summary:     "Get attestation inclusion path",
+		Description: "Returns the attestation duty of a validator in an epoch and every aggregate & block that includes its vote, including late and orphaned inclusions. Votes can be included until the end of the next epoch.",
+		Tag:         "validators",
+		Params: []ApiRouteParam{
+			{Name: "idxOrPubKey", In: "path", Type: "string", Description: "Validator index or hex encoded pubkey", Required: true},
+			{Name: "epoch", In: "path", Type: "integer", Description: "Epoch of the attestation duty", Required: true},
+		},
+		Response: &apitypes.ApiAttestationInclusionsResponse{},
+	},
+	{
+		Path:        "/api/v1/epochs",
+		Method:      http.MethodGet,
+		Handler:     ApiEpochAggregates,
+		Summary:     "Get finalized epoch aggregates",
+		Description: "Returns the indexed aggregates (validator & vote totals, block, deposit, exit & withdrawal counts) of finalized epochs, newest first.",
+		Tag:         "epochs",
+		Params: append([]ApiRouteParam{
+			{Name: "min_epoch", In: "query", Type: "integer", Description: "First epoch of the range"},
+			{Name: "max_epoch", In: "query", Type: "integer", Description: "Last epoch of the range"},
+			localeParam,
+		}, pagingParams...),
+		Response: &apitypes.ApiEpochAggregatesResponse{},
+	},
+	{
+		Path:        "/api/v1/epoch/{epoch}/committees",
+		Method:      http.MethodGet,
+		Handler:     ApiEpochCommittees,
+		Summary:     "Get epoch participation by committee index",
+		Description: "Returns the attestation participation per committee index across all slots of an epoch, computed from the aggregation bits of the included canonical attestations. A low participation of single committee indexes hints to gossip problems on the related attestation subnets.",
+		Tag:         "epochs",
+		Params: []ApiRouteParam{
+			{Name: "epoch", In: "path", Type: "integer", Description: "Epoch number", Required: true},
+		},
+		Response: &apitypes.ApiEpochCommitteesResponse{},
+	},
+	{
+		Path:        "/api/v1/epoch/{epoch}/finality_votes",
+		Method:      http.MethodGet,
+		Handler:     ApiEpochFinalityVotes,
+		Summary:     "Get the target vote map of a finalized epoch",
+		Description: "Returns which active validators had a correct target vote included in the canonical chain (the votes counted towards justification) and lists the validators with missing target votes. Only available for finalized epochs.",
+		Tag:         "epochs",
+		Params: []ApiRouteParam{
+			{Name: "epoch", In: "path", Type: "integer", Description: "Epoch number", Required: true},
+			{Name: "bitfield", In: "query", Type: "boolean", Description: "Include the hex encoded active & voted bitfields (indexed by validator index)"},
+		},
+		Response: &apitypes.ApiEpochFinalityVotesResponse{},
+	},
+	{
+		Path:        "/api/v1/custom/{name}",
+		Method:      http.MethodGet,
+		Handler:     ApiCustomPage,
+		Summary:     "Get custom page results",
+		Description: "Returns the query results of an operator defined custom page. Additional query parameters are passed to the page query.",
+		Tag:         "custom",
+		Params: []ApiRouteParam{
+			{Name: "name", In: "path", Type: "string", Description: "Custom page name", Required: true},
+			{Name: "p", In: "query", Type: "integer", Description: "Page number (1 based)"},
+			{Name: "cursor", In: "query", Type: "string", Description: "Page cursor (next_cursor / prev_cursor of a previous response), overrides p"},
+		},
+		Response: &apitypes.ApiCustomPageResponse{},
+	},
+	{
+		Path:        "/api/v1/incidents",
+		Method:      http.MethodGet,
+		Handler:     ApiValidatorIncidents,
+		Summary:     "Get validator incidents",
+		Description: "Returns the missed duty incidents grouped by entity, newest first.",
+		Tag:         "validators",
+		Params: append([]ApiRouteParam{
+			{Name: "entity", In: "query", Type: "string", Description: "Entity name (exact match)"},
+			{Name: "status", In: "query", Type: "string", Description: "Incident status", Enum: []string{"open", "resolved"}},
+			{Name: "min_epoch", In: "query", Type: "integer", Description: "Only incidents ending at or after this epoch"},
+		}, pagingParams...),
+		Response: &apitypes.ApiValidatorIncidentsResponse{},
+	},
+	{
+		Path:        "/api/v1/anomalies",
+		Method:      http.MethodGet,
+		Handler:     ApiValidatorAnomalies,
+		Summary:     "Get validator anomalies",
+		Description: "Returns the unexpected fee recipient, withdrawal credential and graffiti changes of watchlisted validators, newest first.",
+		Tag:         "validators",
+		Params: append([]ApiRouteParam{
+			{Name: "validator", In: "query", Type: "integer", Description: "Validator index"},
+			{Name: "type", In: "query", Type: "string", Description: "Anomaly type", Enum: []string{"fee_recipient", "withdrawal_credentials", "graffiti"}},
+			{Name: "min_epoch", In: "query", Type: "integer", Description: "Only anomalies at or after this epoch"},
+		}, pagingParams...),
+		Response: &apitypes.ApiValidatorAnomaliesResponse{},
+	},
+	{
+		Path:        "/api/v1/slashings/detected",
+		Method:      http.MethodGet,
+		Handler:     ApiDetectedSlashings,
+		Summary:     "Get detected slashable offences",
+		Description: "Returns the double and surround votes detected by the slasher in unfinalized blocks, newest first. Offences are usually detected before the slashing is included on chain.",
+		Tag:         "validators",
+		Params: append([]ApiRouteParam{
+			{Name: "validator", In: "query", Type: "integer", Description: "Validator index"},
+			{Name: "type", In: "query", Type: "string", Description: "Offence type", Enum: []string{"double_vote", "surround_vote"}},
+			{Name: "min_epoch", In: "query", Type: "integer", Description: "Only offences detected at or after this epoch"},
+		}, pagingParams...),
+		Response: &apitypes.ApiDetectedSlashingsResponse{},
+	},
+	{
+		Path:        "/api/v1/duty_mismatches",
+		Method:      http.MethodGet,
+		Handler:     ApiDutyMismatches,
+		Summary:     "Get duty mismatches",
+		Description: "Returns the proposer and attester duties reported by the connected beacon nodes that differ from the duties computed by the explorer, newest first. Requires the duty check (dutyCheck.enabled).",
+		Tag:         "epochs",
+		Params: append([]ApiRouteParam{
+			{Name: "client_id", In: "query", Type: "integer", Description: "Stable id of the consensus client"},
+			{Name: "type", In: "query", Type: "string", Description: "Duty type", Enum: []string{"proposer", "attester"}},
+			{Name: "min_epoch", In: "query", Type: "integer", Description: "Only mismatches at or after this epoch"},
+		}, pagingParams...),
+		Response: &apitypes.ApiDutyMismatchesResponse{},
+	},
+	{
+		Path:        "/api/v1/light_client/updates",
+		Method:      http.MethodGet,
+		Handler:     ApiLightClientUpdates,
+		Summary:     "Get light client updates",
+		Description: "Returns the best light client updates served by the connected beacon nodes for a range of sync committee periods and their verification result (merkle branches, sync committee signature and next sync committee checked against the canonical chain). Requires the light client indexer (lightClient.enabled).",
+		Tag:         "epochs",
+		Params: []ApiRouteParam{
+			{Name: "start_period", In: "query", Type: "integer", Description: "First sync committee period (defaults to the current period)"},
+			{Name: "count", In: "query", Type: "integer", Description: "Number of periods (defaults to 1, max 16)"},
+			{Name: "data", In: "query", Type: "boolean", Description: "Include the updates as served by the clients"},
+		},
+		Response: &apitypes.ApiLightClientUpdatesResponse{},
+	},
+	{
+		Path:        "/api/v1/light_client/head_updates",
+		Method:      http.MethodGet,
+		Handler:     ApiLightClientHeadUpdates,
+		Summary:     "Get light client finality & optimistic updates",
+		Description: "Returns the latest light client finality and optimistic updates served by the connected beacon nodes in a sync committee period and their verification result. Requires the light client indexer (lightClient.enabled).",
+		Tag:         "epochs",
+		Params: []ApiRouteParam{
+			{Name: "period", In: "query", Type: "integer", Description: "Sync committee period (defaults to the current period)"},
+			{Name: "type", In: "query", Type: "string", Description: "Update type", Enum: []string{"finality", "optimistic"}},
+			{Name: "data", In: "query", Type: "boolean", Description: "Include the updates as served by the clients"},
+		},
+		Response: &apitypes.ApiLightClientHeadUpdatesResponse{},
+	},
+	{
+		Path:        "/api/v1/fee_recipients",
+		Method:      http.MethodGet,
+		Handler:     ApiFeeRecipients,
+		Summary:     "Get proposer fee recipients",
+		Description: "Returns the fee recipients used by the proposers of canonical blocks, matched against the configured expected fee recipients. Mismatches are listed first.",
+		Tag:         "validators",
+		Params: append([]ApiRouteParam{
+			{Name: "min_epoch", In: "query", Type: "integer", Description: "Only blocks at or after this epoch (defaults to the last 24 hours)"},
+			{Name: "mismatches", In: "query", Type: "boolean", Description: "Only return unexpected fee recipients"},
+		}, pagingParams...),
+		Response: &apitypes.ApiFeeRecipientsResponse{},
+	},
+	{
+		Path:        "/api/v1/request_fees",
+		Method:      http.MethodGet,
+		Handler:     ApiRequestFees,
+		Summary:     "Get withdrawal & consolidation request fees",
+		Description: "Returns the current dynamic fees (in wei) of the EIP-7002 withdrawal request and EIP-7251 consolidation request contracts, and the fees paid by past requests aggregated in time buckets. Fees are only known for transactions calling the system contracts directly.",
+		Tag:         "validators",
+		Params: []ApiRouteParam{
+			{Name: "min_time", In: "query", Type: "integer", Description: "Only requests at or after this unix timestamp (defaults to the last 7 days)"},
+			{Name: "bucket", In: "query", Type: "integer", Description: "History bucket size in seconds (defaults to 3600, min 60)"},
+		},
+		Response: &apitypes.ApiRequestFeesResponse{},
+	},
+	{
+		Path:        "/api/v1/queues",
+		Method:      http.MethodGet,
+		Handler:     ApiValidatorQueues,
+		Summary:     "Get activation & exit queue estimates",
+		Description: "Returns the current activation & exit queue lengths, the churn limits and the estimated activation epoch of a new deposit and exit epoch of a new 32 ETH exit. Before electra the queues are limited by a validator count churn, with electra by a balance churn (balances in gwei). Estimates assume timely finalization.",
+		Tag:         "validators",
+		Params: []ApiRouteParam{
+			{Name: "validator", In: "query", Type: "string", Description: "Validator index or hex encoded pubkey to include the queue position & estimated activation or exit epoch of"},
+		},
+		Response: &apitypes.ApiValidatorQueuesResponse{},
+	},
+	{
+		Path:        "/api/v1/broadcast",
+		Method:      http.MethodPost,
+		Handler:     ApiBroadcast,
+		Summary:     "Broadcast voluntary exits & bls changes",
+		Description: "Validates signed voluntary exits and bls to execution changes against the current state and broadcasts them to all online beacon nodes. The request body is a single json encoded operation or a list of operations. Invalid operations are not broadcasted. Only available if frontend.showSubmitBroadcast is enabled.",
+		Tag:         "validators",
+		Request:     &apitypes.ApiBroadcastOperation{},
+		Response:    &apitypes.ApiBroadcastResponse{},
+	},
+	{
+		Path:        "/api/v1/validate_object",
+		Method:      http.MethodPost,
+		Handler:     ApiValidateObject,
+		Summary:     "Validate blocks, attestations, exits & deposits",
+		Description: "Decodes json or hex encoded ssz signed blocks, attestations, voluntary exits, bls to execution changes or deposits with the spec of this network and checks their encoding, signatures & validity against the current state. The request body is a single json object, a json list or whitespace separated hex encoded ssz objects. Nothing is broadcasted.",
+		Tag:         "tools",
+		Params: []ApiRouteParam{
+			{Name: "type", In: "query", Type: "string", Description: "Object type (block, attestation, voluntary_exit, bls_change or deposit), auto detected for json objects if omitted. Required for ssz encoded objects."},
+		},
+		Response: &apitypes.ApiValidateObjectResponse{},
+	},
+	{
+		Path:        "/api/v1/events",
+		Method:      http.MethodGet,
+		Handler:     ApiEvents,
+		Summary:     "Subscribe to indexer events",
+		Description: "Streams the indexer events as server-sent-events. The events are deduplicated across all connected beacon clients, so tools can subscribe to a single stream instead of every beacon node. Each event is sent with the topic as event name and a json encoded data field.",
+		Tag:         "epochs",
+		Stream:      true,
+		Params: []ApiRouteParam{
+			{Name: "topics", In: "query", Type: "string", Description: "Comma separated list of topics (head, block, reorg, finalized_checkpoint)", Required: true},
+		},
+	},
+	{
+		Path:        "/api/v1/supply",
+		Method:      http.MethodGet,
+		Handler:     ApiSupply,
+		Summary:     "Get supply delta",
+		Description: "Returns the consensus layer issuance (net rewards) and the burned execution layer fees (base fees & blob fees) per epoch or day in gwei, newest first. Requires the rewards indexer.",
+		Tag:         "epochs",
+		Params: []ApiRouteParam{
+			{Name: "period", In: "query", Type: "string", Description: "Aggregation period (defaults to day)", Enum: []string{"day", "epoch"}},
+			{Name: "limit", In: "query", Type: "integer", Description: "Number of periods to return (max 100)"},
+			localeParam,
+		},
+		Response: &apitypes.ApiSupplyResponse{},
+	},
+	{
+		Path:        "/api/v1/network/stats",
+		Method:      http.MethodGet,
+		Handler:     ApiNetworkStats,
+		Summary:     "Get rolling network stats",
+		Description: "Returns rolling 1d, 7d & 31d aggregates of the finalized epochs: average participation, missed slot rate, reorg count (orphaned blocks), validator growth and average proposer block value in gwei (requires the rewards indexer). Refreshed once per epoch.",
+		Tag:         "epochs",
+		Response:    &apitypes.ApiNetworkStatsResponse{},
+	},
+	{
+		Path:        "/api/v1/admin/blockcache",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminBlockCache,
+		Summary:     "Get block cache settings",
+		Description: "Returns the block cache retention settings and the current cache usage.",
+		Tag:         "admin",
+		Admin:       true,
+		Response:    &apitypes.ApiAdminBlockCacheResponse{},
+	},
+	{
+		Path:        "/api/v1/admin/blockcache",
+		Method:      http.MethodPost,
+		Handler:     ApiAdminBlockCache,
+		Summary:     "Update block cache settings",
+		Description: "Updates the block cache retention settings. Omitted fields are left unchanged.",
+		Tag:         "admin",
+		Admin:       true,
+		Request:     &apitypes.ApiAdminBlockCacheUpdate{},
+		Response:    &apitypes.ApiAdminBlockCacheResponse{},
+	},
+	{
+		Path:        "/api/v1/admin/compare",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminCompare,
+		Summary:     "Compare with another dora instance",
+		Description: "Compares the finalized epoch aggregates against another dora instance on the same network and reports divergent values and epochs missing on either side. Defaults to the last 100 epochs finalized on both instances.",
+		Tag:         "admin",
+		Admin:       true,
+		Params: []ApiRouteParam{
+			{Name: "remote", In: "query", Type: "string", Description: "Base url of the other dora instance", Required: true},
+			{Name: "min_epoch", In: "query", Type: "integer", Description: "First epoch to compare"},
+			{Name: "max_epoch", In: "query", Type: "integer", Description: "Last epoch to compare (max 1000 epochs per request)"},
+		},
+		Response: &apitypes.ApiAdminCompareResponse{},
+	},
+	{
+		Path:        "/api/v1/admin/entities",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminEntities,
+		Summary:     "Get entities",
+		Description: "Returns all entities with their rosters. Entities are stored in the database and name the validators of their validator ranges and of the validators resolved via their withdrawal & deposit addresses, on top of the configured validator names.",
+		Tag:         "admin",
+		Admin:       true,
+		Response:    &apitypes.ApiEntitiesResponse{},
+	},
+	{
+		Path:        "/api/v1/admin/entities",
+		Method:      http.MethodPost,
+		Handler:     ApiAdminEntities,
+		Summary:     "Create entity",
+		Description: "Creates a new entity. The validator names are reloaded within 30 seconds.",
+		Tag:         "admin",
+		Admin:       true,
+		Request:     &apitypes.ApiEntityUpdate{},
+		Response:    &apitypes.ApiEntity{},
+	},
+	{
+		Path:        "/api/v1/admin/entities/{id}",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminEntity,
+		Summary:     "Get entity",
+		Description: "Returns a single entity with its roster.",
+		Tag:         "admin",
+		Admin:       true,
+		Params:      entityIdParams,
+		Response:    &apitypes.ApiEntity{},
+	},
+	{
+		Path:        "/api/v1/admin/entities/{id}",
+		Method:      http.MethodPut,
+		Handler:     ApiAdminEntity,
+		Summary:     "Update entity",
+		Description: "Replaces the fields and the roster of an entity. The validator names are reloaded within 30 seconds.",
+		Tag:         "admin",
+		Admin:       true,
+		Params:      entityIdParams,
+		Request:     &apitypes.ApiEntityUpdate{},
+		Response:    &apitypes.ApiEntity{},
+	},
+	{
+		Path:        "/api/v1/admin/entities/{id}",
+		Method:      http.MethodDelete,
+		Handler:     ApiAdminEntity,
+		Summary:     "Delete entity",
+		Description: "Deletes an entity with its roster and returns the deleted entity. The validator names are reloaded within 30 seconds.",
+		Tag:         "admin",
+		Admin:       true,
+		Params:      entityIdParams,
+		Response:    &apitypes.ApiEntity{},
+	},
+	{
+		Path:        "/api/v1/admin/epochcache",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminEpochCache,
+		Summary:     "Get epoch cache stats",
+		Description: "Returns the current epoch cache memory usage.",
+		Tag:         "admin",
+		Admin:       true,
+		Response:    &beacon.EpochCacheStats{},
+	},
+	{
+		Path:        "/api/v1/admin/heapdumps",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminHeapDumps,
+		Summary:     "List heap dumps",
+		Description: "Returns the heap dumps in the configured heap dump directory (newest first) and the current process RSS. Heap dumps are captured on demand or automatically when the process RSS crosses the configured threshold.",
+		Tag:         "admin",
+		Admin:       true,
+		Response:    &apitypes.ApiAdminHeapDumpsResponse{},
+	},
+	{
+		Path:        "/api/v1/admin/heapdumps",
+		Method:      http.MethodPost,
+		Handler:     ApiAdminHeapDumps,
+		Summary:     "Capture heap dump",
+		Description: "Runs a garbage collection and writes a heap profile to the heap dump directory. The oldest heap dumps beyond the configured limit are removed.",
+		Tag:         "admin",
+		Admin:       true,
+		Response:    &apitypes.ApiAdminHeapDump{},
+	},
+	{
+		Path:        "/api/v1/admin/heapdumps/{name}",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminHeapDumpDownload,
+		Summary:     "Download heap dump",
+		Description: "Downloads a captured heap dump, the file can be analyzed with `go tool pprof`.",
+		Tag:         "admin",
+		Admin:       true,
+		Download:    "application/octet-stream",
+		Params: []ApiRouteParam{
+			{Name: "name", In: "path", Type: "string", Description: "Heap dump file name", Required: true},
+		},
+	},
+	{
+		Path:        "/api/v1/admin/incident_bundle",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminIncidentBundle,
+		Summary:     "Download incident bundle",
+		Description: "Packages the data usually attached to devnet incident reports for a slot or epoch range into a zip archive: the fork tree of the range with all canonical, orphaned & missed slots, the current head & finality view of all clients, block propagation timings, voting participation and the buffered log lines around the range. Either start_slot or start_epoch is required.",
+		Tag:         "admin",
+		Admin:       true,
+		Download:    "application/zip",
+		Params: []ApiRouteParam{
+			{Name: "start_slot", In: "query", Type: "integer", Description: "First slot of the range"},
+			{Name: "end_slot", In: "query", Type: "integer", Description: "Last slot of the range (defaults to start_slot, max 1024 slots)"},
+			{Name: "start_epoch", In: "query", Type: "integer", Description: "First epoch of the range (alternative to start_slot)"},
+			{Name: "end_epoch", In: "query", Type: "integer", Description: "Last epoch of the range (defaults to start_epoch)"},
+		},
+	},
+	{
+		Path:        "/api/v1/admin/online_migrations",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminOnlineMigrations,
+		Summary:     "Get online migration progress",
+		Description: "Returns the background backfill progress of online schema migrations. Large tables are migrated by adding the new column without a table rewrite and backfilling the rows in small key range chunks after the upgrade.",
+		Tag:         "admin",
+		Admin:       true,
+		Response:    &apitypes.ApiOnlineMigrationsResponse{},
+	},
+	{
+		Path:        "/api/v1/admin/page_metrics",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminPageMetrics,
+		Summary:     "Get page rendering metrics",
+		Description: "Returns the frontend request timings per route, split into data building (time to first byte) and template rendering, and the page model build durations & cache outcomes per page type. Durations are in nanoseconds.",
+		Tag:         "admin",
+		Admin:       true,
+		Response:    &services.PageMetricsStats{},
+	},
+	{
+		Path:        "/api/v1/admin/pprof/{profile}",
+		Method:      http.MethodGet,
+		Handler:     ApiAdminPprof,
+		Summary:     "Get runtime profile",
+		Description: "Serves the go runtime profiles of net/http/pprof to admin requests. The downloaded profiles can be analyzed with `go tool pprof`. The duration of cpu profiles & traces is limited by the http write timeout.",
+		Tag:         "admin",
+		Admin:       true,
+		Download:    "application/octet-stream",
+		Params: []ApiRouteParam{
+			{Name: "profile", In: "path", Type: "string", Description: "Profile name", Required: true, Enum: adminPprofProfiles},
+			{Name: "seconds", In: "query", Type: "integer", Description: "Duration of cpu profiles & traces, or delta duration of the other profiles"},
+			{Name: "debug", In: "query", Type: "integer", Description: "Return the profile in text format (1 or 2) instead of the binary pprof format"},
+		},
+	},
+	{
+		Path:        "/api/v1/admin/replay",
+		Method:      http.MethodPost,
+		Handler:     ApiAdminReplay,
+		Summary:     "Replay finalized epochs",
+		Description: "Rewrites a range of already finalized epochs to the database, eg. after a partially failed finalization write. Existing rows are overwritten and stale rows are reconciled. The epochs are replayed asynchronously.",
+		Tag:         "admin",
+		Admin:       true,
+		Params: []ApiRouteParam{
+			{Name: "start_epoch", In: "query", Type: "integer", Description: "First epoch to replay", Required: true},
+			{Name: "end_epoch", In: "query", Type: "integer", Description: "Last epoch to replay (defaults to start_epoch)"},
+		},
+		Response: &apitypes.ApiAdminReplayResponse{},
+	},
+}
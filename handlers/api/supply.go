@@ -0,0 +1,128 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// ApiSupply returns the consensus layer issuance and execution layer fee burn per epoch or day, newest first.
+// the stats are indexed by the rewards indexer, so they are only available if it is enabled.
+func ApiSupply(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	period := urlArgs.Get("period")
+	if period == "" {
+		period = "day"
+	}
+	if period != "day" && period != "epoch" {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid period")
+		return
+	}
+
+	var limit uint64 = 30
+	if urlArgs.Has("limit") {
+		limit, _ = strconv.ParseUint(urlArgs.Get("limit"), 10, 64)
+	}
+	if limit > 100 || limit == 0 {
+		limit = 100
+	}
+
+	totals, err := db.GetSupplyTotals()
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load supply stats")
+		return
+	}
+
+	response := &apitypes.ApiSupplyResponse{
+		Period: period,
+		Total: &apitypes.ApiSupplyEntry{
+			Epochs: totals.Epochs,
+			Blocks: totals.Blocks,
+			Issued: totals.Issued,
+			Burned: totals.Burned,
+			Delta:  totals.Issued - totals.Burned,
+		},
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	genesisTime := time.Time{}
+	if genesis := chainState.GetGenesis(); genesis != nil {
+		genesisTime = genesis.GenesisTime
+	}
+
+	switch period {
+	case "epoch":
+		supplyEpochs, err := db.GetSupplyEpochs(uint32(limit))
+		if err != nil {
+			sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load supply stats")
+			return
+		}
+
+		response.History = make([]*apitypes.ApiSupplyEntry, 0, len(supplyEpochs))
+		for _, entry := range supplyEpochs {
+			epoch := entry.Epoch
+			epochTime := chainState.EpochToTime(phase0.Epoch(epoch))
+			response.History = append(response.History, &apitypes.ApiSupplyEntry{
+				Epoch:  &epoch,
+				Date:   &epochTime,
+				Epochs: 1,
+				Blocks: entry.Blocks,
+				Issued: entry.Issued,
+				Burned: entry.Burned,
+				Delta:  entry.Issued - entry.Burned,
+			})
+		}
+	case "day":
+		supplyDays, err := db.GetSupplyDays(uint32(limit))
+		if err != nil {
+			sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load supply stats")
+			return
+		}
+
+		response.History = make([]*apitypes.ApiSupplyEntry, 0, len(supplyDays))
+		for _, entry := range supplyDays {
+			day := entry.Day
+			dayTime := genesisTime.Add(time.Duration(day) * 24 * time.Hour)
+			response.History = append(response.History, &apitypes.ApiSupplyEntry{
+				Day:    &day,
+				Date:   &dayTime,
+				Epochs: entry.Epochs,
+				Blocks: entry.Blocks,
+				Issued: entry.Issued,
+				Burned: entry.Burned,
+				Delta:  entry.Issued - entry.Burned,
+			})
+		}
+	}
+
+	if formatter := getApiFormatter(r); formatter != nil {
+		formatApiSupplyEntry(response.Total, formatter)
+		for _, entry := range response.History {
+			formatApiSupplyEntry(entry, formatter)
+		}
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
+
+// formatApiSupplyEntry sets the human formatted fields of the supply entry
+func formatApiSupplyEntry(entry *apitypes.ApiSupplyEntry, formatter *utils.LocaleFormatter) {
+	if entry.Date != nil {
+		entry.DateFormatted = formatter.FormatRecentTime(*entry.Date)
+	}
+	entry.IssuedFormatted = formatter.FormatEthFromGweiSigned(entry.Issued)
+	entry.BurnedFormatted = formatter.FormatEthFromGweiSigned(entry.Burned)
+	entry.DeltaFormatted = formatter.FormatEthFromGweiSigned(entry.Delta)
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// maxValidateObjectBodySize is the maximum size of the request body for the object validation
+const maxValidateObjectBodySize = 16 * 1024 * 1024
+
+// ApiValidateObject decodes json or hex encoded ssz blocks, attestations, exits & deposits and checks their signatures against the current state.
+func ApiValidateObject(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 5)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	objectType, err := services.ParseValidationObjectType(r.URL.Query().Get("type"))
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxValidateObjectBodySize))
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	results, err := services.GlobalBeaconService.ValidateObjects(body, objectType)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := &apitypes.ApiValidateObjectResponse{
+		Results: make([]*apitypes.ApiValidateObjectResult, 0, len(results)),
+	}
+	for _, result := range results {
+		resultData := &apitypes.ApiValidateObjectResult{
+			Index:    uint64(result.Index),
+			Type:     result.Type.String(),
+			Encoding: result.Encoding,
+			Valid:    result.Error == nil,
+			Checks:   make([]*apitypes.ApiValidateObjectCheck, 0, len(result.Checks)),
+		}
+		if result.Type == services.ValidationObjectBlock || result.Type == services.ValidationObjectAttestation {
+			resultData.Fork = result.Version.String()
+		}
+		if result.Error != nil {
+			resultData.Error = result.Error.Error()
+		} else if result.RootName != "" {
+			resultData.RootName = result.RootName
+			resultData.Root = result.Root.String()
+		}
+
+		if len(result.Fields) > 0 {
+			resultData.Fields = make(map[string]string, len(result.Fields))
+			for _, field := range result.Fields {
+				resultData.Fields[field.Name] = field.Value
+			}
+		}
+
+		for _, check := range result.Checks {
+			resultData.Checks = append(resultData.Checks, &apitypes.ApiValidateObjectCheck{
+				Name:    check.Name,
+				Status:  check.Status.String(),
+				Message: check.Message,
+			})
+			if check.Status == services.ValidationCheckFailed {
+				resultData.Valid = false
+			}
+		}
+
+		response.Results = append(response.Results, resultData)
+	}
+	response.Count = uint64(len(response.Results))
+
+	sendOKResponse(w, r.URL.String(), response)
+}
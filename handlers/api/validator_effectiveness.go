@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/gorilla/mux"
+)
+
+// ApiValidatorEffectiveness returns the daily effectiveness scores & network-wide percentile rankings of a validator
+func ApiValidatorEffectiveness(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	var validatorIndex phase0.ValidatorIndex
+	validatorFound := false
+	validatorPubKey, err := hex.DecodeString(strings.Replace(vars["idxOrPubKey"], "0x", "", -1))
+	if err != nil || len(validatorPubKey) != 48 {
+		index, err := strconv.ParseUint(vars["idxOrPubKey"], 10, 64)
+		if err == nil {
+			validatorIndex = phase0.ValidatorIndex(index)
+			validatorFound = true
+		}
+	} else {
+		validatorIndex, validatorFound = services.GlobalBeaconService.GetValidatorIndexByPubkey(phase0.BLSPubKey(validatorPubKey))
+	}
+	if !validatorFound {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "validator not found")
+		return
+	}
+
+	var limit uint64 = 30
+	if r.URL.Query().Has("limit") {
+		limit, _ = strconv.ParseUint(r.URL.Query().Get("limit"), 10, 64)
+	}
+	if limit > 100 || limit == 0 {
+		limit = 100
+	}
+
+	history, err := db.GetValidatorEffectivenessHistory(uint64(validatorIndex), uint32(limit))
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusInternalServerError, "could not load effectiveness history")
+		return
+	}
+
+	genesisTime := time.Time{}
+	if genesis := services.GlobalBeaconService.GetChainState().GetGenesis(); genesis != nil {
+		genesisTime = genesis.GenesisTime
+	}
+
+	response := &apitypes.ApiValidatorEffectivenessResponse{
+		ValidatorIndex: uint64(validatorIndex),
+		History:        make([]*apitypes.ApiValidatorEffectivenessDay, 0, len(history)),
+	}
+	for _, entry := range history {
+		response.History = append(response.History, &apitypes.ApiValidatorEffectivenessDay{
+			Day:           entry.Day,
+			Date:          genesisTime.Add(time.Duration(entry.Day) * 24 * time.Hour),
+			Effectiveness: float64(entry.Effectiveness) / 100,
+			TopPercent:    float64(entry.TopPercent) / 100,
+			Epochs:        entry.Epochs,
+		})
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// maxValidatorMetadataLookups is the maximum number of validators that can be looked up with a single request
+const maxValidatorMetadataLookups = 2048
+
+// ApiValidatorMetadata returns the pubkey, entity & status of a batch of validators.
+// supported filters: indices (comma separated list of validator indices or index ranges like 100-200)
+func ApiValidatorMetadata(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	indices, err := parseValidatorIndices(r.URL.Query().Get("indices"))
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	metadata := services.GlobalBeaconService.GetValidatorMetadata(indices)
+	response := &apitypes.ApiValidatorMetadataResponse{
+		Validators: make([]*apitypes.ApiValidatorMetadata, 0, len(metadata)),
+	}
+	for _, index := range indices {
+		validatorMeta := metadata[index]
+		if validatorMeta == nil {
+			continue
+		}
+
+		response.Validators = append(response.Validators, &apitypes.ApiValidatorMetadata{
+			Index:  uint64(validatorMeta.Index),
+			Pubkey: validatorMeta.Pubkey.String(),
+			Entity: validatorMeta.Entity,
+			Status: validatorMeta.Status.String(),
+		})
+		delete(metadata, index) // skip duplicate indices
+	}
+	response.Count = uint64(len(response.Validators))
+
+	sendOKResponse(w, r.URL.String(), response)
+}
+
+// parseValidatorIndices parses a comma separated list of validator indices and index ranges
+func parseValidatorIndices(indicesArg string) ([]phase0.ValidatorIndex, error) {
+	indices := []phase0.ValidatorIndex{}
+	if indicesArg == "" {
+		return nil, fmt.Errorf("missing indices")
+	}
+
+	for _, entry := range strings.Split(indicesArg, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		rangeStart, rangeEnd, isRange := strings.Cut(entry, "-")
+		startIndex, err := strconv.ParseUint(strings.TrimSpace(rangeStart), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator index: %v", entry)
+		}
+
+		endIndex := startIndex
+		if isRange {
+			endIndex, err = strconv.ParseUint(strings.TrimSpace(rangeEnd), 10, 64)
+			if err != nil || endIndex < startIndex {
+				return nil, fmt.Errorf("invalid validator index range: %v", entry)
+			}
+		}
+
+		if endIndex-startIndex >= maxValidatorMetadataLookups || uint64(len(indices))+endIndex-startIndex+1 > maxValidatorMetadataLookups {
+			return nil, fmt.Errorf("too many validators, max %v per request", maxValidatorMetadataLookups)
+		}
+
+		for index := startIndex; index <= endIndex; index++ {
+			indices = append(indices, phase0.ValidatorIndex(index))
+		}
+	}
+
+	return indices, nil
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/gorilla/mux"
+)
+
+// ApiValidatorProposals returns the block proposals of a validator, newest first.
+// supported filters: status (canonical / orphaned / missing)
+func ApiValidatorProposals(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	var validatorIndex phase0.ValidatorIndex
+	validatorFound := false
+	validatorPubKey, err := hex.DecodeString(strings.Replace(vars["idxOrPubKey"], "0x", "", -1))
+	if err != nil || len(validatorPubKey) != 48 {
+		index, err := strconv.ParseUint(vars["idxOrPubKey"], 10, 64)
+		if err == nil {
+			validatorIndex = phase0.ValidatorIndex(index)
+			validatorFound = true
+		}
+	} else {
+		validatorIndex, validatorFound = services.GlobalBeaconService.GetValidatorIndexByPubkey(phase0.BLSPubKey(validatorPubKey))
+	}
+	if !validatorFound {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "validator not found")
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	paging, err := parseApiPaging(urlArgs, 50, 100)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, err.Error())
+		return
+	}
+
+	proposerIndex := uint64(validatorIndex)
+	blockFilter := &dbtypes.BlockFilter{
+		ProposerIndex: &proposerIndex,
+		WithOrphaned:  1,
+		WithMissing:   1,
+		Cursor:        paging.keyset,
+	}
+	switch urlArgs.Get("status") {
+	case "":
+	case "canonical":
+		blockFilter.WithOrphaned = 0
+		blockFilter.WithMissing = 0
+	case "orphaned":
+		blockFilter.WithOrphaned = 2
+		blockFilter.WithMissing = 0
+	case "missing":
+		blockFilter.WithOrphaned = 0
+		blockFilter.WithMissing = 2
+	default:
+		sendErrorResponse(w, r.URL.String(), http.StatusBadRequest, "invalid status filter (expected: canonical, orphaned or missing)")
+		return
+	}
+
+	dbBlocks := services.GlobalBeaconService.GetDbBlocksByFilter(blockFilter, paging.pageIdx, uint32(paging.limit), 0)
+	hasMore := false
+	if uint64(len(dbBlocks)) > paging.limit {
+		hasMore = true
+		dbBlocks = dbBlocks[:paging.limit]
+	}
+
+	slotKeys := make([]uint64, len(dbBlocks))
+	rewardSlots := make([]uint64, 0, len(dbBlocks))
+	for idx, dbBlock := range dbBlocks {
+		slotKeys[idx] = dbBlock.Slot
+		if dbBlock.Block != nil && dbBlock.Block.Status == dbtypes.Canonical {
+			rewardSlots = append(rewardSlots, dbBlock.Slot)
+		}
+	}
+
+	blockRewards := map[uint64]*dbtypes.BlockRewards{}
+	if rewards, err := db.GetBlockRewardsBySlots(rewardSlots); err == nil {
+		for _, reward := range rewards {
+			blockRewards[reward.Slot] = reward
+		}
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	currentSlot := uint64(chainState.CurrentSlot())
+	response := &apitypes.ApiValidatorProposalsResponse{
+		ValidatorIndex: proposerIndex,
+		Proposals:      make([]*apitypes.ApiValidatorProposal, 0, len(dbBlocks)),
+		Pagination:     paging.getKeysetPagination(nil, hasMore, db.GetNextListCursor(paging.keyset, slotKeys)),
+	}
+
+	for _, dbBlock := range dbBlocks {
+		proposal := &apitypes.ApiValidatorProposal{
+			Slot:  dbBlock.Slot,
+			Epoch: uint64(chainState.EpochOfSlot(phase0.Slot(dbBlock.Slot))),
+			Time:  chainState.SlotToTime(phase0.Slot(dbBlock.Slot)),
+		}
+
+		switch {
+		case dbBlock.Block == nil || dbBlock.Block.Status == dbtypes.Missing:
+			if dbBlock.Slot >= currentSlot {
+				proposal.Status = "scheduled"
+			} else {
+				proposal.Status = "missing"
+			}
+		case dbBlock.Block.Status == dbtypes.Orphaned:
+			proposal.Status = "orphaned"
+		default:
+			proposal.Status = "canonical"
+		}
+
+		if block := dbBlock.Block; block != nil && block.Status != dbtypes.Missing {
+			proposal.BlockRoot = fmt.Sprintf("0x%x", block.Root)
+			proposal.Graffiti = block.GraffitiText
+			proposal.EthBlockNumber = block.EthBlockNumber
+			if len(block.EthBlockHash) > 0 {
+				proposal.EthBlockHash = fmt.Sprintf("0x%x", block.EthBlockHash)
+			}
+			if len(block.EthFeeRecipient) > 0 {
+				proposal.FeeRecipient = fmt.Sprintf("0x%x", block.EthFeeRecipient)
+			}
+		}
+
+		if rewards := blockRewards[dbBlock.Slot]; rewards != nil {
+			proposal.Rewards = &apitypes.ApiValidatorProposalRewards{
+				Total:             rewards.Total,
+				Attestations:      rewards.Attestations,
+				SyncAggregate:     rewards.SyncAggregate,
+				ProposerSlashings: rewards.ProposerSlashings,
+				AttesterSlashings: rewards.AttesterSlashings,
+				ElFees:            rewards.ElFees,
+				MevPayment:        rewards.MevPayment,
+			}
+		}
+
+		response.Proposals = append(response.Proposals, proposal)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
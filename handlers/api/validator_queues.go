@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+)
+
+// ApiValidatorQueues returns the current activation & exit queues, the churn limits and the estimated waiting times for a new deposit & exit.
+// supported filters: validator (index or hex encoded pubkey) to include the queue position & estimate of a validator
+func ApiValidatorQueues(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	var queueStats *services.ValidatorQueueStats
+	var queueEstimate *services.ValidatorQueueEstimate
+
+	urlArgs := r.URL.Query()
+	if urlArgs.Has("validator") {
+		var validatorIndex phase0.ValidatorIndex
+		validatorFound := false
+		validatorArg := urlArgs.Get("validator")
+		validatorPubKey, err := hex.DecodeString(strings.Replace(validatorArg, "0x", "", -1))
+		if err != nil || len(validatorPubKey) != 48 {
+			index, err := strconv.ParseUint(validatorArg, 10, 64)
+			if err == nil {
+				validatorIndex = phase0.ValidatorIndex(index)
+				validatorFound = true
+			}
+		} else {
+			validatorIndex, validatorFound = services.GlobalBeaconService.GetValidatorIndexByPubkey(phase0.BLSPubKey(validatorPubKey))
+		}
+		if validatorFound {
+			queueEstimate, queueStats = services.GlobalBeaconService.GetValidatorQueueEstimate(validatorIndex)
+		}
+		if queueEstimate == nil {
+			sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "validator not found")
+			return
+		}
+	} else {
+		queueStats = services.GlobalBeaconService.GetValidatorQueueStats()
+	}
+
+	if queueStats == nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusServiceUnavailable, "queue state not available")
+		return
+	}
+
+	response := &apitypes.ApiValidatorQueuesResponse{
+		Epoch:                 uint64(queueStats.Epoch),
+		IsElectra:             queueStats.IsElectra,
+		ActiveValidators:      queueStats.ActiveValidators,
+		TotalActiveBalance:    uint64(queueStats.TotalActiveBalance),
+		ActivationChurn:       queueStats.ActivationChurn,
+		ExitChurn:             queueStats.ExitChurn,
+		BalanceChurn:          uint64(queueStats.BalanceChurn),
+		ActivationQueueLength: queueStats.ActivationQueueLength,
+		ExitQueueLength:       queueStats.ExitQueueLength,
+		ExitQueueBalance:      uint64(queueStats.ExitQueueBalance),
+		NewDeposit:            getApiValidatorQueueEstimate(queueStats.NewActivationEpoch),
+		NewExit:               getApiValidatorQueueEstimate(queueStats.NewExitEpoch),
+	}
+
+	if queueStats.HasStateQueues {
+		pendingDepositBalance := uint64(queueStats.PendingDepositBalance)
+		depositBalanceToConsume := uint64(queueStats.DepositBalanceToConsume)
+		exitBalanceToConsume := uint64(queueStats.ExitBalanceToConsume)
+		earliestExitEpoch := uint64(queueStats.EarliestExitEpoch)
+		response.PendingDeposits = &queueStats.PendingDeposits
+		response.PendingDepositBalance = &pendingDepositBalance
+		response.DepositBalanceToConsume = &depositBalanceToConsume
+		response.ExitBalanceToConsume = &exitBalanceToConsume
+		response.EarliestExitEpoch = &earliestExitEpoch
+	}
+
+	if queueEstimate != nil {
+		response.Validator = &apitypes.ApiValidatorQueueValidator{
+			ValidatorIndex: uint64(queueEstimate.ValidatorIndex),
+			Queue:          queueEstimate.Queue.String(),
+			Position:       queueEstimate.Position,
+			BalanceAhead:   uint64(queueEstimate.BalanceAhead),
+			Scheduled:      queueEstimate.Scheduled,
+		}
+		if queueEstimate.EstimatedEpoch != beacon.FarFutureEpoch {
+			response.Validator.Estimate = getApiValidatorQueueEstimate(queueEstimate.EstimatedEpoch)
+		}
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
+
+func getApiValidatorQueueEstimate(epoch phase0.Epoch) *apitypes.ApiValidatorQueueEstimate {
+	epochTime := services.GlobalBeaconService.GetChainState().EpochToTime(epoch)
+	estimate := &apitypes.ApiValidatorQueueEstimate{
+		Epoch: uint64(epoch),
+		Time:  epochTime,
+	}
+	if waitTime := time.Until(epochTime); waitTime > 0 {
+		estimate.WaitTime = uint64(waitTime.Seconds())
+	}
+
+	return estimate
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	apitypes "github.com/ethpandaops/dora/types/api"
+	"github.com/gorilla/mux"
+)
+
+// ApiValidatorTimeline returns the lifecycle events of a validator in chronological order
+func ApiValidatorTimeline(w http.ResponseWriter, r *http.Request) {
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if err != nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusTooManyRequests, err.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	var validatorIndex phase0.ValidatorIndex
+	validatorFound := false
+	validatorPubKey, err := hex.DecodeString(strings.Replace(vars["idxOrPubKey"], "0x", "", -1))
+	if err != nil || len(validatorPubKey) != 48 {
+		index, err := strconv.ParseUint(vars["idxOrPubKey"], 10, 64)
+		if err == nil {
+			validatorIndex = phase0.ValidatorIndex(index)
+			validatorFound = true
+		}
+	} else {
+		validatorIndex, validatorFound = services.GlobalBeaconService.GetValidatorIndexByPubkey(phase0.BLSPubKey(validatorPubKey))
+	}
+	if !validatorFound {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "validator not found")
+		return
+	}
+
+	timeline := services.GlobalBeaconService.GetValidatorTimeline(validatorIndex)
+	if timeline == nil {
+		sendErrorResponse(w, r.URL.String(), http.StatusNotFound, "validator not found")
+		return
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	response := &apitypes.ApiValidatorTimelineResponse{
+		ValidatorIndex: uint64(validatorIndex),
+		Entries:        make([]*apitypes.ApiValidatorTimelineEntry, 0, len(timeline)),
+	}
+	for _, entry := range timeline {
+		apiEntry := &apitypes.ApiValidatorTimelineEntry{
+			Type:           entry.Type.String(),
+			Pending:        entry.Pending,
+			Amount:         entry.Amount,
+			DepositIndex:   entry.DepositIndex,
+			BlockNumber:    entry.BlockNumber,
+			Validator:      entry.Validator,
+			IsSource:       entry.IsSource,
+			Result:         entry.Result,
+			SlashingReason: uint8(entry.SlashingReason),
+		}
+		if entry.HasSlot {
+			slot := entry.Slot
+			epoch := uint64(chainState.EpochOfSlot(phase0.Slot(slot)))
+			entryTime := entry.Time
+			apiEntry.Slot = &slot
+			apiEntry.Epoch = &epoch
+			apiEntry.Time = &entryTime
+		}
+		if len(entry.TxHash) > 0 {
+			apiEntry.TxHash = fmt.Sprintf("%#x", entry.TxHash)
+		}
+		if len(entry.Credentials) > 0 {
+			apiEntry.Credentials = fmt.Sprintf("%#x", entry.Credentials)
+		}
+
+		response.Entries = append(response.Entries, apiEntry)
+	}
+
+	sendOKResponse(w, r.URL.String(), response)
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Assertoor will return the test runs of the integrated assertoor instance using a go template
+func Assertoor(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"assertoor/assertoor.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "clients", "/assertoor", "Assertoor Tests", templateFiles)
+
+	if services.GlobalAssertoorService == nil {
+		handlePageError(w, r, fmt.Errorf("assertoor integration is not enabled"))
+		return
+	}
+
+	var runId uint64
+	if r.URL.Query().Has("run") {
+		runId, _ = strconv.ParseUint(r.URL.Query().Get("run"), 10, 64)
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		data.Data, pageError = getAssertoorPageData(runId)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "assertoor.go", "Assertoor", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getAssertoorPageData(runId uint64) (*models.AssertoorPageData, error) {
+	pageData := &models.AssertoorPageData{}
+	pageCacheKey := fmt.Sprintf("assertoor:%v", runId)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildAssertoorPageData(runId)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.AssertoorPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildAssertoorPageData(runId uint64) (*models.AssertoorPageData, time.Duration) {
+	logrus.Debugf("assertoor page called: %v", runId)
+
+	testRuns, lastUpdate, lastError := services.GlobalAssertoorService.GetTestRuns()
+
+	pageData := &models.AssertoorPageData{
+		AssertoorUrl: utils.Config.Integrations.Assertoor.PublicUrl,
+		LastUpdate:   lastUpdate,
+		TestRuns:     make([]*models.AssertoorPageDataRun, 0, len(testRuns)),
+	}
+	if lastError != nil {
+		pageData.LastError = lastError.Error()
+	}
+
+	for _, testRun := range testRuns {
+		runData := buildAssertoorPageDataRun(testRun, testRun.RunId == runId)
+		pageData.TestRuns = append(pageData.TestRuns, runData)
+
+		switch testRun.Status {
+		case "pending", "running":
+			pageData.RunningCount++
+		case "success":
+			pageData.SuccessCount++
+		case "failure":
+			pageData.FailureCount++
+		}
+
+		if testRun.RunId == runId {
+			pageData.SelectedRun = runData
+		}
+	}
+	pageData.RunCount = uint64(len(pageData.TestRuns))
+
+	return pageData, 10 * time.Second
+}
+
+func buildAssertoorPageDataRun(testRun *services.AssertoorTestRun, withTasks bool) *models.AssertoorPageDataRun {
+	runData := &models.AssertoorPageDataRun{
+		RunId:       testRun.RunId,
+		TestId:      testRun.TestId,
+		Name:        testRun.Name,
+		Status:      testRun.Status,
+		StartTime:   testRun.StartTime,
+		StopTime:    testRun.StopTime,
+		HasStopTime: !testRun.StopTime.IsZero(),
+		TaskCount:   uint64(len(testRun.Tasks)),
+		RunUrl:      services.GlobalAssertoorService.GetRunUrl(testRun.RunId),
+	}
+	if runData.HasStopTime && !testRun.StartTime.IsZero() {
+		runData.Duration = testRun.StopTime.Sub(testRun.StartTime)
+	} else if !testRun.StartTime.IsZero() {
+		runData.Duration = time.Since(testRun.StartTime).Truncate(time.Second)
+	}
+
+	taskMap := make(map[uint64]*services.AssertoorTask, len(testRun.Tasks))
+	for _, task := range testRun.Tasks {
+		taskMap[task.Index] = task
+		if task.Result == "failure" {
+			runData.FailedTasks++
+		}
+	}
+
+	if !withTasks {
+		return runData
+	}
+
+	runData.Tasks = make([]*models.AssertoorPageDataTask, 0, len(testRun.Tasks))
+	for _, task := range testRun.Tasks {
+		taskData := &models.AssertoorPageDataTask{
+			Index:       task.Index,
+			Name:        task.Name,
+			Title:       task.Title,
+			Status:      task.Status,
+			Result:      task.Result,
+			ResultError: task.ResultError,
+			Slots:       task.Slots,
+			Validators:  make([]types.NamedValidator, len(task.Validators)),
+		}
+		if !task.StartTime.IsZero() && !task.StopTime.IsZero() {
+			taskData.Duration = task.StopTime.Sub(task.StartTime)
+		}
+
+		// nesting depth for the task tree (limited to avoid loops on inconsistent data)
+		parentTask := taskMap[task.ParentIndex]
+		for parentTask != nil && parentTask != task && taskData.Depth < 10 {
+			taskData.Depth++
+			parentTask = taskMap[parentTask.ParentIndex]
+		}
+
+		for idx, validatorIndex := range task.Validators {
+			taskData.Validators[idx] = types.NamedValidator{
+				Index: validatorIndex,
+				Name:  services.GlobalBeaconService.GetValidatorName(validatorIndex),
+			}
+		}
+
+		runData.Tasks = append(runData.Tasks, taskData)
+	}
+
+	return runData
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// BlobAvailability will return the "blob availability" matrix page using a go template
+func BlobAvailability(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"blob_availability/blob_availability.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/blobs/availability", "Blob Availability", templateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		data.Data, pageError = getBlobAvailabilityPageData()
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "blob_availability.go", "BlobAvailability", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getBlobAvailabilityPageData() (*models.BlobAvailabilityPageData, error) {
+	pageData := &models.BlobAvailabilityPageData{}
+	pageCacheKey := "blobs/availability"
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildBlobAvailabilityPageData()
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.BlobAvailabilityPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildBlobAvailabilityPageData() *models.BlobAvailabilityPageData {
+	pageData := &models.BlobAvailabilityPageData{
+		Enabled:      utils.Config.BlobAvailability.Enabled,
+		HistorySlots: utils.Config.BlobAvailability.HistorySlots,
+		VerifyKzg:    utils.Config.BlobAvailability.VerifyKzg,
+		Clients:      []string{},
+		Blocks:       []*models.BlobAvailabilityPageDataBlock{},
+	}
+	logrus.Debugf("blob availability page called")
+
+	monitor := services.GlobalBeaconService.GetBlobSidecarMonitor()
+	if monitor == nil {
+		return pageData
+	}
+	pageData.Running = true
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	blocks := monitor.GetRecentBlocks()
+
+	// collect the clients that have been polled for any of the recent blocks
+	clientIndexes := map[string]int{}
+	for _, block := range blocks {
+		for _, client := range block.Clients {
+			if _, ok := clientIndexes[client.ClientName]; !ok {
+				clientIndexes[client.ClientName] = 0
+				pageData.Clients = append(pageData.Clients, client.ClientName)
+			}
+		}
+	}
+	sort.Strings(pageData.Clients)
+	for idx, clientName := range pageData.Clients {
+		clientIndexes[clientName] = idx
+	}
+
+	for _, block := range blocks {
+		blockData := &models.BlobAvailabilityPageDataBlock{
+			Slot:      uint64(block.Slot),
+			Time:      chainState.SlotToTime(block.Slot),
+			BlockRoot: block.BlockRoot[:],
+			BlobCount: block.BlobCount,
+			Diverging: block.Diverging,
+			Invalid:   block.Invalid,
+			Cells:     make([]*models.BlobAvailabilityPageDataCell, len(pageData.Clients)),
+		}
+
+		for idx := range blockData.Cells {
+			blockData.Cells[idx] = &models.BlobAvailabilityPageDataCell{}
+		}
+
+		for _, client := range block.Clients {
+			cell := blockData.Cells[clientIndexes[client.ClientName]]
+			cell.Polled = true
+			cell.Status = make([]uint8, len(client.Status))
+			for index, status := range client.Status {
+				cell.Status[index] = uint8(status)
+			}
+			cell.AvailableCount = client.AvailableCount
+			cell.InvalidCount = client.InvalidCount
+			cell.Complete = client.AvailableCount >= block.BlobCount
+			cell.Results = client.Results
+			cell.FetchError = client.FetchError
+
+			if !cell.Complete {
+				blockData.MissingCount++
+			}
+		}
+
+		if block.Diverging {
+			pageData.DivergingCount++
+		}
+		if block.Invalid {
+			pageData.InvalidCount++
+		}
+		pageData.Blocks = append(pageData.Blocks, blockData)
+	}
+	pageData.BlockCount = uint64(len(pageData.Blocks))
+
+	// client names are redacted after grouping, so clients with the same redacted name keep separate columns
+	for idx, clientName := range pageData.Clients {
+		pageData.Clients[idx] = services.RedactClientName(clientName)
+	}
+
+	return pageData
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/datacolumns"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// BlobColumns will return the "data column availability" page using a go template
+func BlobColumns(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"blob_columns/blob_columns.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/blobs/columns", "Data Column Availability", templateFiles)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	urlArgs := r.URL.Query()
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		if urlArgs.Has("slot") {
+			slot, err := strconv.ParseUint(urlArgs.Get("slot"), 10, 64)
+			if err != nil {
+				pageError = fmt.Errorf("invalid slot: %v", err)
+			} else {
+				data.Data, pageError = getBlobColumnsPageData(uint64(chainState.EpochOfSlot(phase0.Slot(slot))), &slot)
+			}
+		} else {
+			epoch := uint64(chainState.CurrentEpoch())
+			if urlArgs.Has("epoch") {
+				epoch, _ = strconv.ParseUint(urlArgs.Get("epoch"), 10, 64)
+			}
+			data.Data, pageError = getBlobColumnsPageData(epoch, nil)
+		}
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "blob_columns.go", "BlobColumns", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getBlobColumnsPageData(epoch uint64, slot *uint64) (*models.BlobColumnsPageData, error) {
+	pageData := &models.BlobColumnsPageData{}
+	pageCacheKey := fmt.Sprintf("blobs/columns:%v", epoch)
+	if slot != nil {
+		pageCacheKey = fmt.Sprintf("blobs/columns:slot:%v", *slot)
+	}
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildBlobColumnsPageData(epoch, slot)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.BlobColumnsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildBlobColumnsPageData(epoch uint64, slot *uint64) *models.BlobColumnsPageData {
+	chainState := services.GlobalBeaconService.GetChainState()
+	currentEpoch := uint64(chainState.CurrentEpoch())
+	if epoch > currentEpoch {
+		epoch = currentEpoch
+	}
+
+	pageData := &models.BlobColumnsPageData{
+		Enabled:         utils.Config.DataColumns.Enabled,
+		NumberOfColumns: datacolumns.GetNumberOfColumns(chainState.GetSpecs()),
+		Epoch:           epoch,
+		PrevEpoch:       epoch - 1,
+		NextEpoch:       epoch + 1,
+		HasPrev:         epoch > 0,
+		HasNext:         epoch < currentEpoch,
+	}
+	logrus.Debugf("blob columns page called: %v (slot: %v)", epoch, slot)
+
+	if peerDASEpoch := chainState.GetPeerDASForkEpoch(); peerDASEpoch != nil {
+		pageData.PeerDASEpoch = uint64(*peerDASEpoch)
+		pageData.PeerDASActive = currentEpoch >= pageData.PeerDASEpoch
+	}
+
+	firstSlot := uint64(chainState.EpochToSlot(phase0.Epoch(epoch)))
+	lastSlot := uint64(chainState.EpochToSlot(phase0.Epoch(epoch+1))) - 1
+	if slot != nil {
+		pageData.IsMatrix = true
+		pageData.Slot = *slot
+		firstSlot = *slot
+		lastSlot = *slot
+	}
+
+	entries, _ := db.GetDataColumnAvailabilityBySlots(firstSlot, lastSlot)
+
+	// entries are sorted by slot & block root, group them by block
+	var block *models.BlobColumnsPageDataBlock
+	for _, entry := range entries {
+		if block == nil || !bytes.Equal(block.BlockRoot, entry.BlockRoot) {
+			if block != nil {
+				finalizeBlobColumnsBlock(pageData, block)
+			}
+
+			block = &models.BlobColumnsPageDataBlock{
+				Slot:              entry.Slot,
+				Time:              chainState.SlotToTime(phase0.Slot(entry.Slot)),
+				BlockRoot:         entry.BlockRoot,
+				BlobCount:         entry.BlobCount,
+				Clients:           []*models.BlobColumnsPageDataClient{},
+				UnionColumns:      make([]bool, pageData.NumberOfColumns),
+				ReconstructionMin: (pageData.NumberOfColumns + 1) / 2,
+			}
+		}
+
+		block.Clients = append(block.Clients, getBlobColumnsClient(pageData.NumberOfColumns, entry, block.UnionColumns))
+	}
+	if block != nil {
+		finalizeBlobColumnsBlock(pageData, block)
+	}
+
+	pageData.BlockCount = uint64(len(pageData.Blocks))
+
+	return pageData
+}
+
+func getBlobColumnsClient(numberOfColumns uint64, entry *dbtypes.DataColumnAvailability, unionColumns []bool) *models.BlobColumnsPageDataClient {
+	client := &models.BlobColumnsPageDataClient{
+		Name:       services.RedactClientName(entry.ClientName),
+		NodeId:     entry.NodeId,
+		FetchError: entry.FetchError,
+		Columns:    make([]uint8, numberOfColumns),
+	}
+
+	hasBit := func(bitfield []byte, column uint64) bool {
+		return column/8 < uint64(len(bitfield)) && bitfield[column/8]&(1<<(column%8)) != 0
+	}
+
+	for column := uint64(0); column < numberOfColumns; column++ {
+		isCustody := hasBit(entry.CustodyColumns, column)
+		isAvailable := hasBit(entry.AvailableColumns, column)
+
+		if isCustody {
+			client.CustodyCount++
+		}
+		if isAvailable {
+			client.AvailableCount++
+			unionColumns[column] = true
+		}
+
+		switch {
+		case isCustody && isAvailable:
+			client.Columns[column] = 2
+		case isCustody:
+			client.Columns[column] = 3
+			client.MissingCount++
+		case isAvailable:
+			client.Columns[column] = 1
+		}
+	}
+
+	return client
+}
+
+func finalizeBlobColumnsBlock(pageData *models.BlobColumnsPageData, block *models.BlobColumnsPageDataBlock) {
+	block.ClientCount = uint64(len(block.Clients))
+	for _, client := range block.Clients {
+		if client.FetchError != "" {
+			block.FailedCount++
+		} else if client.MissingCount == 0 {
+			block.CustodyComplete++
+		}
+	}
+
+	for _, available := range block.UnionColumns {
+		if available {
+			block.AvailableColumns++
+		}
+	}
+	if pageData.NumberOfColumns > 0 {
+		block.AvailablePercent = float64(block.AvailableColumns) * 100 / float64(pageData.NumberOfColumns)
+	}
+
+	// any half of the columns is sufficient to reconstruct the full extended blob matrix
+	block.Reconstructable = block.AvailableColumns >= block.ReconstructionMin
+	block.Complete = block.AvailableColumns >= pageData.NumberOfColumns
+
+	if !pageData.IsMatrix {
+		// the per client columns are only shown in the slot matrix view
+		block.Clients = nil
+		block.UnionColumns = nil
+	}
+
+	pageData.Blocks = append(pageData.Blocks, block)
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/indexer/rollups"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// blobRollupsUnknown is the name used for blob transactions that could not be mapped to a rollup
+const blobRollupsUnknown = "Unknown"
+
+// blobRollupsMaxUnknownSenders is the max number of unclassified sender / target combinations shown on the dashboard
+const blobRollupsMaxUnknownSenders = 25
+
+// BlobRollups will return the "rollup blob usage" dashboard using a go template
+func BlobRollups(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"blob_rollups/blob_rollups.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/blobs/rollups", "Rollup Blob Usage", templateFiles)
+
+	urlArgs := r.URL.Query()
+	period := "7d"
+	if urlArgs.Has("f") && urlArgs.Has("f.period") {
+		period = urlArgs.Get("f.period")
+	}
+	if _, ok := clientDiversityPeriods[period]; !ok {
+		period = "7d"
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getBlobRollupsPageData(period)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "blob_rollups.go", "BlobRollups", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getBlobRollupsPageData(period string) (*models.BlobRollupsPageData, error) {
+	pageData := &models.BlobRollupsPageData{}
+	pageCacheKey := fmt.Sprintf("blobs/rollups:%v", period)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildBlobRollupsPageData(period)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.BlobRollupsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildBlobRollupsPageData(period string) *models.BlobRollupsPageData {
+	pageData := &models.BlobRollupsPageData{
+		FilterPeriod: period,
+		Enabled:      utils.Config.Rollups.Enabled,
+	}
+	logrus.Debugf("blob rollups page called: %v", period)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	periodConfig := clientDiversityPeriods[period]
+
+	if periodConfig.duration > 0 {
+		pageData.PeriodStartSlot = uint64(chainState.TimeToSlot(time.Now().Add(-periodConfig.duration)))
+	}
+	pageData.PeriodStartTime = chainState.SlotToTime(phase0.Slot(pageData.PeriodStartSlot))
+
+	pageData.BucketSlots = 1
+	if specs != nil && specs.SecondsPerSlot > 0 {
+		if bucketSlots := uint64(periodConfig.bucket / specs.SecondsPerSlot); bucketSlots > 1 {
+			pageData.BucketSlots = bucketSlots
+		}
+	}
+
+	// the rollup mappings are applied here, so changed mappings also apply to already indexed transactions
+	classifier := rollups.NewClassifier(utils.Config.Rollups.Mappings)
+	blobStats, _ := db.GetBlobTransactionStats(pageData.PeriodStartSlot, pageData.BucketSlots)
+
+	rollupMap := map[string]*models.BlobRollupsPageDataRollup{}
+	rollupSenders := map[string]map[common.Address]bool{}
+	unknownSenders := map[[2]common.Address]*models.BlobRollupsPageDataSender{}
+	bucketStats := map[uint64]*models.BlobRollupsPageDataHistory{}
+	bucketRollupBlobs := map[uint64]map[string]uint64{}
+	buckets := []uint64{}
+
+	for _, stats := range blobStats {
+		sender := common.BytesToAddress(stats.Sender)
+		toAddress := common.BytesToAddress(stats.ToAddress)
+
+		name := classifier.Classify(sender, toAddress)
+		if name == "" {
+			name = blobRollupsUnknown
+
+			senderKey := [2]common.Address{sender, toAddress}
+			unknownSender := unknownSenders[senderKey]
+			if unknownSender == nil {
+				unknownSender = &models.BlobRollupsPageDataSender{
+					Sender:    stats.Sender,
+					ToAddress: stats.ToAddress,
+				}
+				unknownSenders[senderKey] = unknownSender
+			}
+			unknownSender.Txs += stats.TxCount
+			unknownSender.Blobs += stats.BlobCount
+			if stats.LastSlot > unknownSender.LastSlot {
+				unknownSender.LastSlot = stats.LastSlot
+			}
+		} else {
+			pageData.ClassifiedBlobs += stats.BlobCount
+		}
+
+		rollup := rollupMap[name]
+		if rollup == nil {
+			rollup = &models.BlobRollupsPageDataRollup{
+				Name:  name,
+				Known: name != blobRollupsUnknown,
+			}
+			rollupMap[name] = rollup
+			rollupSenders[name] = map[common.Address]bool{}
+		}
+		rollup.Txs += stats.TxCount
+		rollup.Blobs += stats.BlobCount
+		if stats.LastSlot > rollup.LastSlot {
+			rollup.LastSlot = stats.LastSlot
+		}
+		rollupSenders[name][sender] = true
+
+		pageData.TotalTxs += stats.TxCount
+		pageData.TotalBlobs += stats.BlobCount
+
+		if _, exists := bucketStats[stats.Bucket]; !exists {
+			buckets = append(buckets, stats.Bucket)
+			bucketStats[stats.Bucket] = &models.BlobRollupsPageDataHistory{
+				FirstSlot: stats.Bucket * pageData.BucketSlots,
+				LastSlot:  (stats.Bucket+1)*pageData.BucketSlots - 1,
+				Time:      chainState.SlotToTime(phase0.Slot(stats.Bucket * pageData.BucketSlots)),
+			}
+			bucketRollupBlobs[stats.Bucket] = map[string]uint64{}
+		}
+		bucketStats[stats.Bucket].Txs += stats.TxCount
+		bucketStats[stats.Bucket].Blobs += stats.BlobCount
+		bucketRollupBlobs[stats.Bucket][name] += stats.BlobCount
+	}
+
+	// rollups are ordered by blob usage, unclassified transactions are always sorted last
+	for name, rollup := range rollupMap {
+		rollup.Senders = uint64(len(rollupSenders[name]))
+		rollup.Share = float64(rollup.Blobs) * 100 / float64(pageData.TotalBlobs)
+		rollup.BlobsPerTx = float64(rollup.Blobs) / float64(rollup.Txs)
+		pageData.Rollups = append(pageData.Rollups, rollup)
+	}
+	sort.Slice(pageData.Rollups, func(a, b int) bool {
+		if pageData.Rollups[a].Known != pageData.Rollups[b].Known {
+			return pageData.Rollups[a].Known
+		}
+		if pageData.Rollups[a].Blobs != pageData.Rollups[b].Blobs {
+			return pageData.Rollups[a].Blobs > pageData.Rollups[b].Blobs
+		}
+		return pageData.Rollups[a].Name < pageData.Rollups[b].Name
+	})
+	pageData.RollupCount = uint64(len(pageData.Rollups))
+	if pageData.TotalBlobs > 0 {
+		pageData.ClassifiedShare = float64(pageData.ClassifiedBlobs) * 100 / float64(pageData.TotalBlobs)
+	}
+
+	for _, unknownSender := range unknownSenders {
+		pageData.UnknownSenders = append(pageData.UnknownSenders, unknownSender)
+	}
+	sort.Slice(pageData.UnknownSenders, func(a, b int) bool {
+		return pageData.UnknownSenders[a].Blobs > pageData.UnknownSenders[b].Blobs
+	})
+	if len(pageData.UnknownSenders) > blobRollupsMaxUnknownSenders {
+		pageData.UnknownSenders = pageData.UnknownSenders[:blobRollupsMaxUnknownSenders]
+	}
+	pageData.UnknownCount = uint64(len(pageData.UnknownSenders))
+
+	for idx := len(buckets) - 1; idx >= 0; idx-- {
+		historyData := bucketStats[buckets[idx]]
+		historyData.Rollups = make([]uint64, len(pageData.Rollups))
+		for rollupIdx, rollup := range pageData.Rollups {
+			historyData.Rollups[rollupIdx] = bucketRollupBlobs[buckets[idx]][rollup.Name]
+		}
+
+		pageData.History = append(pageData.History, historyData)
+	}
+	pageData.HistoryCount = uint64(len(pageData.History))
+
+	return pageData
+}
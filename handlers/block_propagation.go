@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// BlockPropagation will return the "block propagation" dashboard using a go template
+func BlockPropagation(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"clients/block_propagation.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "clients/propagation", "/clients/propagation", "Block Propagation", templateFiles)
+
+	urlArgs := r.URL.Query()
+	period := "1d"
+	if urlArgs.Has("f") && urlArgs.Has("f.period") {
+		period = urlArgs.Get("f.period")
+	}
+	if _, ok := clientDiversityPeriods[period]; !ok {
+		period = "1d"
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getBlockPropagationPageData(period)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "block_propagation.go", "BlockPropagation", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getBlockPropagationPageData(period string) (*models.BlockPropagationPageData, error) {
+	pageData := &models.BlockPropagationPageData{}
+	pageCacheKey := fmt.Sprintf("clients/propagation:%v", period)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildBlockPropagationPageData(period)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.BlockPropagationPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildBlockPropagationPageData(period string) *models.BlockPropagationPageData {
+	pageData := &models.BlockPropagationPageData{
+		FilterPeriod: period,
+	}
+	logrus.Debugf("block propagation page called: %v", period)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	periodConfig := clientDiversityPeriods[period]
+
+	if periodConfig.duration > 0 {
+		pageData.PeriodStartSlot = uint64(chainState.TimeToSlot(time.Now().Add(-periodConfig.duration)))
+	}
+	pageData.PeriodStartTime = chainState.SlotToTime(phase0.Slot(pageData.PeriodStartSlot))
+
+	pageData.BucketEpochs = 1
+	pageData.SlotDuration = 12000
+	slotsPerEpoch := uint64(32)
+	if specs != nil && specs.SecondsPerSlot > 0 && specs.SlotsPerEpoch > 0 {
+		if bucketEpochs := uint64(periodConfig.bucket / (specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch))); bucketEpochs > 1 {
+			pageData.BucketEpochs = bucketEpochs
+		}
+		pageData.SlotDuration = uint64(specs.SecondsPerSlot.Milliseconds())
+		slotsPerEpoch = specs.SlotsPerEpoch
+	}
+
+	// delays are shown as share of the slot duration
+	getSlotPercent := func(delay float64) float64 {
+		percent := delay * 100 / float64(pageData.SlotDuration)
+		if percent < 0 {
+			return 0
+		}
+		if percent > 100 {
+			return 100
+		}
+		return percent
+	}
+
+	// only finalized blocks are tracked, the dashboard lags behind the chain head by the unfinalized epochs
+	history, _ := db.GetBlockArrivalHistory(pageData.PeriodStartSlot, pageData.BucketEpochs*slotsPerEpoch)
+	for _, stats := range history {
+		pageData.TotalBlocks += stats.Blocks
+		pageData.MinDelay += stats.MinDelay * float64(stats.Blocks)
+		pageData.MedianDelay += stats.MedianDelay * float64(stats.Blocks)
+		pageData.MaxDelay += stats.MaxDelay * float64(stats.Blocks)
+	}
+	if pageData.TotalBlocks > 0 {
+		pageData.MinDelay /= float64(pageData.TotalBlocks)
+		pageData.MedianDelay /= float64(pageData.TotalBlocks)
+		pageData.MaxDelay /= float64(pageData.TotalBlocks)
+	}
+
+	for idx := len(history) - 1; idx >= 0; idx-- {
+		stats := history[idx]
+		firstEpoch := stats.Bucket * pageData.BucketEpochs
+		pageData.History = append(pageData.History, &models.BlockPropagationPageDataHistory{
+			FirstEpoch:    firstEpoch,
+			LastEpoch:     firstEpoch + pageData.BucketEpochs - 1,
+			Time:          chainState.EpochToTime(phase0.Epoch(firstEpoch)),
+			Blocks:        stats.Blocks,
+			MinDelay:      stats.MinDelay,
+			MedianDelay:   stats.MedianDelay,
+			MedianPercent: getSlotPercent(stats.MedianDelay),
+			MaxDelay:      stats.MaxDelay,
+		})
+	}
+	pageData.HistoryCount = uint64(len(pageData.History))
+
+	clientStats, _ := db.GetBlockArrivalClientStats(pageData.PeriodStartSlot)
+	for _, stats := range clientStats {
+		pageData.Clients = append(pageData.Clients, &models.BlockPropagationPageDataClient{
+			Name:       services.RedactClientName(stats.Client),
+			Blocks:     stats.Blocks,
+			AvgDelay:   stats.AvgDelay,
+			AvgPercent: getSlotPercent(stats.AvgDelay),
+			MaxDelay:   stats.MaxDelay,
+		})
+	}
+	pageData.ClientCount = uint64(len(pageData.Clients))
+
+	clientTypeStats, _ := db.GetBlockArrivalClientTypeStats(pageData.PeriodStartSlot)
+	for _, stats := range clientTypeStats {
+		pageData.ClientTypes = append(pageData.ClientTypes, &models.BlockPropagationPageDataDelays{
+			Name:          getClientDiversityName(consensus.ClientType(stats.ClClient).String(), stats.ClClient),
+			Blocks:        stats.Blocks,
+			MinDelay:      stats.MinDelay,
+			MedianDelay:   stats.MedianDelay,
+			MedianPercent: getSlotPercent(stats.MedianDelay),
+			MaxDelay:      stats.MaxDelay,
+		})
+	}
+	pageData.ClientTypeCount = uint64(len(pageData.ClientTypes))
+
+	proposerStats, _ := db.GetBlockArrivalProposerStats(pageData.PeriodStartSlot, 25)
+	for _, stats := range proposerStats {
+		pageData.Proposers = append(pageData.Proposers, &models.BlockPropagationPageDataDelays{
+			Name:          services.GlobalBeaconService.GetValidatorName(stats.Proposer),
+			Index:         stats.Proposer,
+			Blocks:        stats.Blocks,
+			MinDelay:      stats.MinDelay,
+			MedianDelay:   stats.MedianDelay,
+			MedianPercent: getSlotPercent(stats.MedianDelay),
+			MaxDelay:      stats.MaxDelay,
+		})
+	}
+	pageData.ProposerCount = uint64(len(pageData.Proposers))
+
+	// missed & late attestations attributed to late blocks or the attesters
+	missStats, _ := db.GetAttestationMissStats(uint64(chainState.EpochOfSlot(phase0.Slot(pageData.PeriodStartSlot))), 25)
+	for _, stats := range missStats {
+		lateBlockMisses := stats.MissedLateBlock + stats.DelayedLateBlock
+		attesterMisses := stats.MissedAttester + stats.DelayedAttester
+		pageData.MissesLateBlock += lateBlockMisses
+		pageData.MissesAttester += attesterMisses
+
+		missData := &models.BlockPropagationPageDataMisses{
+			Entity:           stats.Entity,
+			Epochs:           stats.Epochs,
+			MissedLateBlock:  stats.MissedLateBlock,
+			MissedAttester:   stats.MissedAttester,
+			DelayedLateBlock: stats.DelayedLateBlock,
+			DelayedAttester:  stats.DelayedAttester,
+		}
+		if lateBlockMisses+attesterMisses > 0 {
+			missData.LateBlockPercent = float64(lateBlockMisses) * 100 / float64(lateBlockMisses+attesterMisses)
+		}
+		pageData.AttestationMisses = append(pageData.AttestationMisses, missData)
+	}
+	pageData.AttestationMissCount = uint64(len(pageData.AttestationMisses))
+	pageData.MissesTotal = pageData.MissesLateBlock + pageData.MissesAttester
+	if pageData.MissesTotal > 0 {
+		pageData.MissesLateBlockPercent = float64(pageData.MissesLateBlock) * 100 / float64(pageData.MissesTotal)
+	}
+
+	return pageData
+}
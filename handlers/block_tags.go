@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// BlockTags will return the "block tags" page using a go template
+func BlockTags(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"block_tags/block_tags.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/slots/tags", "Block Tags", templateFiles)
+
+	urlArgs := r.URL.Query()
+	period := "7d"
+	if urlArgs.Has("f") && urlArgs.Has("f.period") {
+		period = urlArgs.Get("f.period")
+	}
+	if _, ok := clientDiversityPeriods[period]; !ok {
+		period = "7d"
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getBlockTagsPageData(period)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "block_tags.go", "BlockTags", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getBlockTagsPageData(period string) (*models.BlockTagsPageData, error) {
+	pageData := &models.BlockTagsPageData{}
+	pageCacheKey := fmt.Sprintf("slots/tags:%v", period)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildBlockTagsPageData(period)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.BlockTagsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildBlockTagsPageData(period string) *models.BlockTagsPageData {
+	pageData := &models.BlockTagsPageData{
+		FilterPeriod: period,
+	}
+	logrus.Debugf("block tags page called: %v", period)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	periodConfig := clientDiversityPeriods[period]
+
+	if periodConfig.duration > 0 {
+		pageData.PeriodStartSlot = uint64(chainState.TimeToSlot(time.Now().Add(-periodConfig.duration)))
+	}
+	pageData.PeriodStartTime = chainState.SlotToTime(phase0.Slot(pageData.PeriodStartSlot))
+
+	pageData.BucketEpochs = 1
+	if specs != nil && specs.SecondsPerSlot > 0 && specs.SlotsPerEpoch > 0 {
+		if bucketEpochs := uint64(periodConfig.bucket / (specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch))); bucketEpochs > 1 {
+			pageData.BucketEpochs = bucketEpochs
+		}
+	}
+
+	slotsPerEpoch := uint64(32)
+	if specs != nil && specs.SlotsPerEpoch > 0 {
+		slotsPerEpoch = specs.SlotsPerEpoch
+	}
+
+	// tags are persisted with the finalized blocks, the page lags behind the chain head by the unfinalized epochs
+	tagStats, _ := db.GetSlotTagStats(pageData.PeriodStartSlot, pageData.BucketEpochs*slotsPerEpoch)
+
+	tagBlocks := map[string]uint64{}
+	bucketBlocks := map[uint64]uint64{}
+	bucketTagBlocks := map[uint64]map[string]uint64{}
+	buckets := []uint64{}
+
+	for _, stats := range tagStats {
+		if _, exists := bucketTagBlocks[stats.Bucket]; !exists {
+			buckets = append(buckets, stats.Bucket)
+			bucketTagBlocks[stats.Bucket] = map[string]uint64{}
+		}
+
+		if stats.Tag == "" {
+			// total canonical blocks of the bucket
+			pageData.TotalBlocks += stats.Blocks
+			bucketBlocks[stats.Bucket] += stats.Blocks
+			continue
+		}
+
+		pageData.TaggedBlocks += stats.Blocks
+		tagBlocks[stats.Tag] += stats.Blocks
+		bucketTagBlocks[stats.Bucket][stats.Tag] += stats.Blocks
+	}
+	sort.Slice(buckets, func(a, b int) bool {
+		return buckets[a] < buckets[b]
+	})
+
+	// configured tags are shown in config order, followed by tags of removed rules
+	tags := []string{}
+	tagRules := map[string]*models.BlockTagsPageDataTag{}
+	for _, blockTag := range utils.Config.Indexer.BlockTags {
+		tagData := tagRules[blockTag.Tag]
+		if tagData == nil {
+			tagData = &models.BlockTagsPageDataTag{
+				Tag:        blockTag.Tag,
+				Configured: true,
+			}
+			tagRules[blockTag.Tag] = tagData
+			tags = append(tags, blockTag.Tag)
+		}
+		tagData.Graffiti = joinBlockTagPattern(tagData.Graffiti, blockTag.Graffiti)
+		tagData.ExtraData = joinBlockTagPattern(tagData.ExtraData, blockTag.ExtraData)
+	}
+	removedTags := []string{}
+	for tag := range tagBlocks {
+		if tagRules[tag] == nil {
+			removedTags = append(removedTags, tag)
+		}
+	}
+	sort.Strings(removedTags)
+	tags = append(tags, removedTags...)
+
+	for _, tag := range tags {
+		tagData := tagRules[tag]
+		if tagData == nil {
+			tagData = &models.BlockTagsPageDataTag{
+				Tag: tag,
+			}
+		}
+		tagData.Blocks = tagBlocks[tag]
+		if pageData.TotalBlocks > 0 {
+			tagData.Share = float64(tagData.Blocks) * 100 / float64(pageData.TotalBlocks)
+		}
+		pageData.Tags = append(pageData.Tags, tagData)
+	}
+
+	for idx := len(buckets) - 1; idx >= 0; idx-- {
+		bucket := buckets[idx]
+		firstEpoch := bucket * pageData.BucketEpochs
+		historyData := &models.BlockTagsPageDataHistory{
+			FirstEpoch: firstEpoch,
+			LastEpoch:  firstEpoch + pageData.BucketEpochs - 1,
+			Time:       chainState.EpochToTime(phase0.Epoch(firstEpoch)),
+			Blocks:     bucketBlocks[bucket],
+			Shares:     make([]float64, len(tags)),
+		}
+
+		if historyData.Blocks > 0 {
+			for tagIdx, tag := range tags {
+				historyData.Shares[tagIdx] = float64(bucketTagBlocks[bucket][tag]) * 100 / float64(historyData.Blocks)
+			}
+		}
+
+		pageData.History = append(pageData.History, historyData)
+	}
+	pageData.HistoryCount = uint64(len(pageData.History))
+
+	return pageData
+}
+
+// joinBlockTagPattern combines the patterns of multiple rules with the same tag for display
+func joinBlockTagPattern(patterns string, pattern string) string {
+	if pattern == "" {
+		return patterns
+	}
+	if patterns == "" {
+		return pattern
+	}
+	return strings.Join([]string{patterns, pattern}, " | ")
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// clientDiversityPeriods maps the selectable dashboard periods to their duration (0 = all time) and the duration of a history row
+var clientDiversityPeriods = map[string]struct {
+	duration time.Duration
+	bucket   time.Duration
+}{
+	"1d":  {24 * time.Hour, 1 * time.Hour},
+	"7d":  {7 * 24 * time.Hour, 8 * time.Hour},
+	"30d": {30 * 24 * time.Hour, 24 * time.Hour},
+	"all": {0, 7 * 24 * time.Hour},
+}
+
+// ClientDiversity will return the "client diversity" dashboard using a go template
+func ClientDiversity(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"clients/client_diversity.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "clients/diversity", "/clients/diversity", "Client Diversity", templateFiles)
+
+	urlArgs := r.URL.Query()
+	period := "7d"
+	if urlArgs.Has("f") && urlArgs.Has("f.period") {
+		period = urlArgs.Get("f.period")
+	}
+	if _, ok := clientDiversityPeriods[period]; !ok {
+		period = "7d"
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getClientDiversityPageData(period)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "client_diversity.go", "ClientDiversity", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getClientDiversityPageData(period string) (*models.ClientDiversityPageData, error) {
+	pageData := &models.ClientDiversityPageData{}
+	pageCacheKey := fmt.Sprintf("clients/diversity:%v", period)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildClientDiversityPageData(period)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ClientDiversityPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildClientDiversityPageData(period string) *models.ClientDiversityPageData {
+	pageData := &models.ClientDiversityPageData{
+		FilterPeriod: period,
+	}
+	logrus.Debugf("client diversity page called: %v", period)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	periodConfig := clientDiversityPeriods[period]
+
+	if periodConfig.duration > 0 {
+		pageData.PeriodStartSlot = uint64(chainState.TimeToSlot(time.Now().Add(-periodConfig.duration)))
+	}
+	pageData.PeriodStartTime = chainState.SlotToTime(phase0.Slot(pageData.PeriodStartSlot))
+
+	pageData.BucketEpochs = 1
+	if specs != nil && specs.SecondsPerSlot > 0 && specs.SlotsPerEpoch > 0 {
+		if bucketEpochs := uint64(periodConfig.bucket / (specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch))); bucketEpochs > 1 {
+			pageData.BucketEpochs = bucketEpochs
+		}
+	}
+
+	slotsPerEpoch := uint64(32)
+	if specs != nil && specs.SlotsPerEpoch > 0 {
+		slotsPerEpoch = specs.SlotsPerEpoch
+	}
+
+	// only finalized blocks are classified, the dashboard lags behind the chain head by the unfinalized epochs
+	clientStats, _ := db.GetBlockClientStats(pageData.PeriodStartSlot, pageData.BucketEpochs*slotsPerEpoch)
+
+	clBlocks := map[int8]uint64{}
+	elBlocks := map[int8]uint64{}
+	bucketBlocks := map[uint64]uint64{}
+	bucketClBlocks := map[uint64]map[int8]uint64{}
+	bucketElBlocks := map[uint64]map[int8]uint64{}
+	buckets := []uint64{}
+
+	for _, stats := range clientStats {
+		pageData.TotalBlocks += stats.Blocks
+		clBlocks[stats.ClClient] += stats.Blocks
+		elBlocks[stats.ElClient] += stats.Blocks
+
+		if _, exists := bucketBlocks[stats.Bucket]; !exists {
+			buckets = append(buckets, stats.Bucket)
+			bucketClBlocks[stats.Bucket] = map[int8]uint64{}
+			bucketElBlocks[stats.Bucket] = map[int8]uint64{}
+		}
+		bucketBlocks[stats.Bucket] += stats.Blocks
+		bucketClBlocks[stats.Bucket][stats.ClClient] += stats.Blocks
+		bucketElBlocks[stats.Bucket][stats.ElClient] += stats.Blocks
+	}
+
+	clClients := getClientDiversityOrder(clBlocks)
+	for _, clClient := range clClients {
+		pageData.ClClients = append(pageData.ClClients, &models.ClientDiversityPageDataClient{
+			Name:   getClientDiversityName(consensus.ClientType(clClient).String(), clClient),
+			Blocks: clBlocks[clClient],
+			Share:  float64(clBlocks[clClient]) * 100 / float64(pageData.TotalBlocks),
+		})
+	}
+
+	elClients := getClientDiversityOrder(elBlocks)
+	for _, elClient := range elClients {
+		pageData.ElClients = append(pageData.ElClients, &models.ClientDiversityPageDataClient{
+			Name:   getClientDiversityName(execution.ClientType(elClient).String(), elClient),
+			Blocks: elBlocks[elClient],
+			Share:  float64(elBlocks[elClient]) * 100 / float64(pageData.TotalBlocks),
+		})
+	}
+
+	for idx := len(buckets) - 1; idx >= 0; idx-- {
+		bucket := buckets[idx]
+		firstEpoch := bucket * pageData.BucketEpochs
+		historyData := &models.ClientDiversityPageDataHistory{
+			FirstEpoch: firstEpoch,
+			LastEpoch:  firstEpoch + pageData.BucketEpochs - 1,
+			Time:       chainState.EpochToTime(phase0.Epoch(firstEpoch)),
+			Blocks:     bucketBlocks[bucket],
+			ClShares:   make([]float64, len(clClients)),
+			ElShares:   make([]float64, len(elClients)),
+		}
+
+		for clientIdx, clClient := range clClients {
+			historyData.ClShares[clientIdx] = float64(bucketClBlocks[bucket][clClient]) * 100 / float64(historyData.Blocks)
+		}
+		for clientIdx, elClient := range elClients {
+			historyData.ElShares[clientIdx] = float64(bucketElBlocks[bucket][elClient]) * 100 / float64(historyData.Blocks)
+		}
+
+		pageData.History = append(pageData.History, historyData)
+	}
+	pageData.HistoryCount = uint64(len(pageData.History))
+
+	if utils.Config.Blockprint.Url != "" && specs != nil && specs.SecondsPerSlot > 0 {
+		pageData.BlockprintEnabled = true
+		buildClientDiversityBlockprintData(pageData, uint64(24*time.Hour/specs.SecondsPerSlot))
+	}
+
+	return pageData
+}
+
+// buildClientDiversityBlockprintData adds the blockprint classifications aggregated per day to the page data.
+func buildClientDiversityBlockprintData(pageData *models.ClientDiversityPageData, slotsPerDay uint64) {
+	chainState := services.GlobalBeaconService.GetChainState()
+	dayStats, _ := db.GetBlockprintDayStats(pageData.PeriodStartSlot, slotsPerDay)
+
+	clientBlocks := map[int8]uint64{}
+	dayBlocks := map[uint64]uint64{}
+	dayClientBlocks := map[uint64]map[int8]uint64{}
+	days := []uint64{}
+
+	for _, stats := range dayStats {
+		pageData.BlockprintBlocks += stats.Blocks
+		clientBlocks[stats.Client] += stats.Blocks
+
+		if _, exists := dayBlocks[stats.Day]; !exists {
+			days = append(days, stats.Day)
+			dayClientBlocks[stats.Day] = map[int8]uint64{}
+		}
+		dayBlocks[stats.Day] += stats.Blocks
+		dayClientBlocks[stats.Day][stats.Client] += stats.Blocks
+	}
+
+	clients := getClientDiversityOrder(clientBlocks)
+	for _, client := range clients {
+		pageData.BlockprintClients = append(pageData.BlockprintClients, &models.ClientDiversityPageDataClient{
+			Name:   getClientDiversityName(consensus.ClientType(client).String(), client),
+			Blocks: clientBlocks[client],
+			Share:  float64(clientBlocks[client]) * 100 / float64(pageData.BlockprintBlocks),
+		})
+	}
+
+	for idx := len(days) - 1; idx >= 0; idx-- {
+		day := days[idx]
+		dayData := &models.ClientDiversityPageDataBlockprint{
+			Day:    day,
+			Time:   chainState.SlotToTime(phase0.Slot(day * slotsPerDay)),
+			Blocks: dayBlocks[day],
+			Shares: make([]float64, len(clients)),
+		}
+
+		for clientIdx, client := range clients {
+			dayData.Shares[clientIdx] = float64(dayClientBlocks[day][client]) * 100 / float64(dayData.Blocks)
+		}
+
+		pageData.BlockprintDays = append(pageData.BlockprintDays, dayData)
+	}
+	pageData.BlockprintCount = uint64(len(pageData.BlockprintDays))
+}
+
+// getClientDiversityOrder returns the client types ordered by block count, unknown clients are always sorted last.
+func getClientDiversityOrder(blocks map[int8]uint64) []int8 {
+	clients := make([]int8, 0, len(blocks))
+	for client := range blocks {
+		clients = append(clients, client)
+	}
+
+	sort.Slice(clients, func(a, b int) bool {
+		if (clients[a] < 0) != (clients[b] < 0) {
+			return clients[b] < 0
+		}
+		if blocks[clients[a]] != blocks[clients[b]] {
+			return blocks[clients[a]] > blocks[clients[b]]
+		}
+		return clients[a] < clients[b]
+	})
+
+	return clients
+}
+
+func getClientDiversityName(name string, client int8) string {
+	if client < 0 {
+		return "unknown"
+	}
+	return name
+}
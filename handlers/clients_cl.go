@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"sort"
@@ -9,7 +10,9 @@ import (
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/services"
 	"github.com/ethpandaops/dora/templates"
 	"github.com/ethpandaops/dora/types/models"
@@ -81,7 +84,7 @@ func buildCLPeerMapData() *models.ClientCLPageDataPeerMap {
 		if _, ok := nodes[peerId]; !ok {
 			node := models.ClientCLPageDataPeerMapNode{
 				ID:    peerId,
-				Label: client.GetName(),
+				Label: services.RedactClientName(client.GetName()),
 				Group: "internal",
 			}
 			nodes[peerId] = &node
@@ -176,7 +179,7 @@ func buildCLClientsPageData() (*models.ClientsCLPageData, time.Duration) {
 		if id == nil {
 			continue
 		}
-		aliases[id.PeerID] = client.GetName()
+		aliases[id.PeerID] = services.RedactClientName(client.GetName())
 	}
 
 	enrMap := map[string]*enr.Record{}
@@ -239,6 +242,9 @@ func buildCLClientsPageData() (*models.ClientsCLPageData, time.Duration) {
 		}
 	}
 
+	connectivityClients := []*models.ClientCLPageDataConnectivityClient{}
+	connectivityLinks := []map[string]string{}
+
 	for _, client := range services.GlobalBeaconService.GetConsensusClients() {
 		lastHeadSlot, lastHeadRoot := client.GetLastHead()
 
@@ -255,7 +261,7 @@ func buildCLClientsPageData() (*models.ClientsCLPageData, time.Duration) {
 		if !ok {
 			node = &models.ClientCLPageDataNode{
 				PeerID: peerId,
-				Alias:  client.GetName(),
+				Alias:  services.RedactClientName(client.GetName()),
 				Type:   "internal",
 			}
 			if id != nil {
@@ -275,12 +281,23 @@ func buildCLClientsPageData() (*models.ClientsCLPageData, time.Duration) {
 			pageData.Nodes[peerId] = node
 		}
 
+		if id != nil {
+			node.Attnets = getSubnetsFromBitvector(id.Metadata.Attnets)
+			node.Syncnets = getSubnetsFromBitvector(id.Metadata.Syncnets)
+			node.P2PAddresses = id.P2PAddresses
+		}
+
 		peers := client.GetNodePeers()
 		resPeers := []*models.ClientCLPageDataNodePeers{}
+		peerLinks := map[string]string{}
 
-		var inPeerCount, outPeerCount uint32
+		var inPeerCount, outPeerCount, internalPeerCount uint32
 		for _, peer := range peers {
 			addPeerNode(peer)
+			peerLinks[peer.PeerID] = peer.Direction
+			if _, isInternal := aliases[peer.PeerID]; isInternal {
+				internalPeerCount++
+			}
 
 			peerNode := &models.ClientCLPageDataNodePeers{
 				PeerID:             peer.PeerID,
@@ -326,7 +343,7 @@ func buildCLClientsPageData() (*models.ClientsCLPageData, time.Duration) {
 
 		resClient := &models.ClientsCLPageDataClient{
 			Index:                int(client.GetIndex()) + 1,
-			Name:                 client.GetName(),
+			Name:                 services.RedactClientName(client.GetName()),
 			Version:              client.GetVersion(),
 			PeerID:               peerId,
 			PeerCount:            inPeerCount + outPeerCount,
@@ -336,6 +353,27 @@ func buildCLClientsPageData() (*models.ClientsCLPageData, time.Duration) {
 			HeadRoot:             lastHeadRoot[:],
 			Status:               client.GetStatus().String(),
 			LastRefresh:          client.GetLastEventTime(),
+			EventTopics:          strings.Join(client.GetEventTopics(), ", "),
+		}
+
+		if streamStats := client.GetEventStreamStats(); streamStats != nil {
+			resClient.EventLag = uint64(streamStats.LastLag.Milliseconds())
+			resClient.EventMaxLag = uint64(streamStats.MaxLag.Milliseconds())
+			resClient.EventQueue = uint64(streamStats.QueueLength)
+			resClient.EventsReceived = streamStats.Received
+			resClient.EventsDropped = streamStats.Dropped
+		}
+
+		for _, indexerClient := range services.GlobalBeaconService.GetBeaconIndexer().GetAllClients() {
+			if indexerClient.GetClient() != client {
+				continue
+			}
+
+			fetchStats := indexerClient.GetFetchStats()
+			resClient.FetchLatency = uint64(fetchStats.Latency.Milliseconds())
+			resClient.FetchRaces = fetchStats.Races
+			resClient.FetchWins = fetchStats.Wins
+			resClient.FetchWinRate = fetchStats.WinRate() * 100
 		}
 
 		lastError := client.GetLastClientError()
@@ -345,8 +383,37 @@ func buildCLClientsPageData() (*models.ClientsCLPageData, time.Duration) {
 
 		pageData.Clients = append(pageData.Clients, resClient)
 
+		connectivityClients = append(connectivityClients, &models.ClientCLPageDataConnectivityClient{
+			Name:          resClient.Name,
+			PeerID:        peerId,
+			Version:       resClient.Version,
+			InternalPeers: internalPeerCount,
+			ExternalPeers: resClient.PeerCount - internalPeerCount,
+		})
+		connectivityLinks = append(connectivityLinks, peerLinks)
 	}
 	pageData.ClientCount = uint64(len(pageData.Clients))
+	pageData.Connectivity = buildCLConnectivityData(connectivityClients, connectivityLinks)
+
+	// recent divergence episodes of the clients from the head divergence monitor
+	divergences, _ := db.GetClientHeadDivergences(20)
+	for _, divergence := range divergences {
+		pageData.Divergences = append(pageData.Divergences, &models.ClientCLPageDataDivergence{
+			ClientName:  services.RedactClientName(divergence.ClientName),
+			Reason:      divergence.Reason,
+			StartSlot:   divergence.StartSlot,
+			StartTime:   chainState.SlotToTime(phase0.Slot(divergence.StartSlot)),
+			EndSlot:     divergence.EndSlot,
+			EndTime:     chainState.SlotToTime(phase0.Slot(divergence.EndSlot)),
+			HeadSlot:    divergence.HeadSlot,
+			HeadRoot:    divergence.HeadRoot,
+			MaxDistance: divergence.MaxDistance,
+			Resolved:    divergence.Resolved,
+		})
+		if !divergence.Resolved {
+			pageData.OpenDivergenceCount++
+		}
+	}
 
 	// Add peer in/out infos to global nodes map
 	for _, edge := range pageData.PeerMap.ClientDataMapEdges {
@@ -407,6 +474,14 @@ func buildCLClientsPageData() (*models.ClientsCLPageData, time.Duration) {
 			v.ENRKeyValues = getEnrValues(enrValues)
 		}
 
+		// Get subnet subscriptions from ENR if not reported by the node itself
+		if attnets, ok := enrValues["attnets"].(string); ok && v.Attnets == nil {
+			v.Attnets = getSubnetsFromBitvector(attnets)
+		}
+		if syncnets, ok := enrValues["syncnets"].(string); ok && v.Syncnets == nil {
+			v.Syncnets = getSubnetsFromBitvector(syncnets)
+		}
+
 		// Calculate node ID
 		nodeID, err := utils.ConvertPeerIDStringToEnodeID(v.PeerID)
 		if err != nil {
@@ -508,8 +583,66 @@ func buildCLClientsPageData() (*models.ClientsCLPageData, time.Duration) {
 		for _, node := range pageData.Nodes {
 			node.ENR = ""
 			node.ENRKeyValues = nil
+			node.P2PAddresses = nil
 		}
 	}
 
 	return pageData, cacheTime
 }
+
+// buildCLConnectivityData builds the peering matrix of the configured clients.
+// links are taken from the peer list of the row client, or from the peer list of the column client if the row client did not report the peering (yet).
+func buildCLConnectivityData(clients []*models.ClientCLPageDataConnectivityClient, peerLinks []map[string]string) *models.ClientCLPageDataConnectivity {
+	connectivity := &models.ClientCLPageDataConnectivity{
+		Clients: clients,
+	}
+
+	for i, client := range clients {
+		client.Links = make([]string, len(clients))
+		linkCount := 0
+
+		for j, peer := range clients {
+			if i == j || client.PeerID == peer.PeerID {
+				client.Links[j] = "self"
+				continue
+			}
+
+			if direction, ok := peerLinks[i][peer.PeerID]; ok {
+				client.Links[j] = direction
+			} else if direction, ok := peerLinks[j][client.PeerID]; ok {
+				if direction == "inbound" {
+					client.Links[j] = "outbound"
+				} else {
+					client.Links[j] = "inbound"
+				}
+			}
+
+			if client.Links[j] != "" {
+				linkCount++
+			}
+		}
+
+		if linkCount == 0 && len(clients) > 1 {
+			client.Isolated = true
+			connectivity.IsolatedCount++
+		}
+	}
+
+	return connectivity
+}
+
+// getSubnetsFromBitvector returns the indices of the set bits of a hex encoded ssz bitvector (attnets / syncnets)
+func getSubnetsFromBitvector(bitvector string) []uint64 {
+	bits, err := hex.DecodeString(strings.TrimPrefix(bitvector, "0x"))
+	if err != nil || len(bits) == 0 {
+		return nil
+	}
+
+	subnets := []uint64{}
+	for i := 0; i < len(bits)*8; i++ {
+		if utils.BitAtVector(bits, i) {
+			subnets = append(subnets, uint64(i))
+		}
+	}
+	return subnets
+}
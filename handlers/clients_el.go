@@ -81,7 +81,7 @@ func buildELPeerMapData(parseEnodeRecord func(enrStr string) *enode.Node) *model
 		if _, ok := nodes[peerID]; !ok {
 			node := models.ClientELPageDataPeerMapNode{
 				ID:    peerID,
-				Label: client.GetName(),
+				Label: services.RedactClientName(client.GetName()),
 				Group: "internal",
 			}
 			nodes[peerID] = &node
@@ -188,7 +188,7 @@ func buildELClientsPageData() (*models.ClientsELPageData, time.Duration) {
 				nodeID = en.ID().String()
 			}
 
-			aliases[nodeID] = client.GetName()
+			aliases[nodeID] = services.RedactClientName(client.GetName())
 		}
 	}
 
@@ -263,7 +263,7 @@ func buildELClientsPageData() (*models.ClientsELPageData, time.Duration) {
 
 		resClient := &models.ClientsELPageDataClient{
 			Index:                int(client.GetIndex()) + 1,
-			Name:                 client.GetName(),
+			Name:                 services.RedactClientName(client.GetName()),
 			Version:              client.GetVersion(),
 			DidFetchPeers:        client.DidFetchPeers(),
 			PeerCount:            uint32(len(peers)),
@@ -277,7 +277,7 @@ func buildELClientsPageData() (*models.ClientsELPageData, time.Duration) {
 		}
 
 		resNode := &models.ClientsELPageDataNode{
-			Name:          client.GetName(),
+			Name:          services.RedactClientName(client.GetName()),
 			Version:       client.GetVersion(),
 			Status:        client.GetStatus().String(),
 			Peers:         resPeers,
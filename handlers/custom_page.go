@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// CustomPage will return an operator defined custom page using a go template
+func CustomPage(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"custom_page/custom_page.html",
+		"_svg/professor.html",
+	)
+
+	vars := mux.Vars(r)
+	customPage := services.GetCustomPage(vars["name"])
+	if customPage == nil {
+		NotFound(w, r)
+		return
+	}
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/custom/"+customPage.Name, customPage.Title, templateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+		if pageIdx < 1 {
+			pageIdx = 1
+		}
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getCustomPageData(customPage, urlArgs, pageIdx)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "custom_page.go", "CustomPage", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getCustomPageData(customPage *types.CustomPageConfig, urlArgs url.Values, pageIdx uint64) (*models.CustomPageData, error) {
+	args, paramValues, err := services.GetCustomPageParams(customPage, urlArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	paramArgs := url.Values{}
+	for _, param := range customPage.Params {
+		paramArgs.Add(param.Name, paramValues[param.Name])
+	}
+
+	pageData := &models.CustomPageData{}
+	pageCacheKey := fmt.Sprintf("custom_page:%v:%v:%v", customPage.Name, paramArgs.Encode(), pageIdx)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildCustomPageData(customPage, args, paramValues, paramArgs, pageIdx)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.CustomPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildCustomPageData(customPage *types.CustomPageConfig, args []interface{}, paramValues map[string]string, paramArgs url.Values, pageIdx uint64) *models.CustomPageData {
+	pageData := &models.CustomPageData{
+		Name:             customPage.Name,
+		Title:            customPage.Title,
+		Description:      customPage.Description,
+		Icon:             customPage.Icon,
+		CurrentPageIndex: pageIdx,
+	}
+	logrus.Debugf("custom page called: %v:%v [%v]", customPage.Name, pageIdx, paramArgs.Encode())
+	if pageData.Icon == "" {
+		pageData.Icon = "fa-table"
+	}
+	if pageIdx > 1 {
+		pageData.PrevPageIndex = pageIdx - 1
+	}
+
+	for _, param := range customPage.Params {
+		pageData.Params = append(pageData.Params, &models.CustomPageDataParam{
+			Name:  param.Name,
+			Label: param.Label,
+			Type:  param.Type,
+			Value: paramValues[param.Name],
+		})
+	}
+
+	result, err := services.LoadCustomPageResult(customPage, args, pageIdx)
+	if err != nil {
+		logrus.Warnf("custom page %v query failed: %v", customPage.Name, err)
+		pageData.QueryError = "The query for this page failed. Please check the page parameters or contact the operator."
+		result = &services.CustomPageResult{}
+	}
+
+	// show all result columns as text if no columns are configured
+	columns := customPage.Columns
+	if len(columns) == 0 {
+		for _, column := range result.Columns {
+			columns = append(columns, types.CustomPageColumnConfig{
+				Field:  column,
+				Label:  column,
+				Format: "text",
+			})
+		}
+	}
+
+	for _, column := range columns {
+		pageData.Columns = append(pageData.Columns, &models.CustomPageDataColumn{
+			Label: column.Label,
+		})
+	}
+
+	for _, row := range result.Rows {
+		cells := make([]template.HTML, len(columns))
+		for idx, column := range columns {
+			cells[idx] = formatCustomPageValue(row[column.Field], column.Format)
+		}
+		pageData.Rows = append(pageData.Rows, cells)
+	}
+	pageData.RowCount = uint64(len(pageData.Rows))
+
+	if result.HasMore {
+		pageData.NextPageIndex = pageIdx + 1
+	}
+
+	baseLink := fmt.Sprintf("/custom/%v?%v", customPage.Name, paramArgs.Encode())
+	pageData.FirstPageLink = baseLink
+	pageData.PrevPageLink = fmt.Sprintf("%v&p=%v", baseLink, pageData.PrevPageIndex)
+	pageData.NextPageLink = fmt.Sprintf("%v&p=%v", baseLink, pageData.NextPageIndex)
+	pageData.ApiLink = fmt.Sprintf("/api/v1/custom/%v?%v&p=%v", customPage.Name, paramArgs.Encode(), pageIdx)
+
+	return pageData
+}
+
+// formatCustomPageValue formats a single custom page query result value with the configured column format
+func formatCustomPageValue(value interface{}, format string) template.HTML {
+	if value == nil {
+		return ""
+	}
+
+	numValue, isNum := getCustomPageNumber(value)
+	switch format {
+	case "number":
+		if isNum && numValue >= 0 {
+			return utils.FormatAddCommas(uint64(numValue))
+		}
+	case "float":
+		if floatValue, err := strconv.ParseFloat(getCustomPageString(value), 64); err == nil {
+			return template.HTML(utils.FormatFloat(floatValue, 4))
+		}
+	case "eth":
+		if isNum && numValue >= 0 {
+			return template.HTML(utils.FormatETHFromGwei(uint64(numValue)))
+		}
+	case "slot":
+		if isNum && numValue >= 0 {
+			return template.HTML(fmt.Sprintf(`<a href="/slot/%v">%v</a>`, numValue, utils.FormatAddCommas(uint64(numValue))))
+		}
+	case "epoch":
+		if isNum && numValue >= 0 {
+			return template.HTML(fmt.Sprintf(`<a href="/epoch/%v">%v</a>`, numValue, utils.FormatAddCommas(uint64(numValue))))
+		}
+	case "validator":
+		if isNum && numValue >= 0 {
+			return utils.FormatValidator(uint64(numValue), services.GlobalBeaconService.GetValidatorName(uint64(numValue)))
+		}
+	case "hex":
+		if bytesValue, ok := value.([]byte); ok {
+			return template.HTML(fmt.Sprintf("0x%x", bytesValue))
+		}
+	case "time":
+		if isNum && numValue >= 0 {
+			return utils.FormatRecentTimeShort(time.Unix(numValue, 0))
+		}
+	case "address":
+		if bytesValue, ok := value.([]byte); ok {
+			return utils.FormatEthAddress(bytesValue)
+		}
+	case "bool":
+		if boolValue, err := strconv.ParseBool(getCustomPageString(value)); err == nil {
+			if boolValue {
+				return `<i class="fas fa-check text-success"></i>`
+			}
+			return `<i class="fas fa-times text-danger"></i>`
+		}
+	}
+
+	return template.HTML(html.EscapeString(getCustomPageString(value)))
+}
+
+// getCustomPageString returns the string representation of a custom page query result value
+func getCustomPageString(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// getCustomPageNumber returns the integer value of a custom page query result value
+func getCustomPageNumber(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	default:
+		num, err := strconv.ParseInt(getCustomPageString(value), 10, 64)
+		return num, err == nil
+	}
+}
@@ -84,7 +84,7 @@ func buildDepositsPageData(firstEpoch uint64, pageSize uint64) (*models.Deposits
 	for _, depositTx := range dbDepositTxs {
 		depositTxData := &models.DepositsPageDataInitiatedDeposit{
 			Index:                 depositTx.Index,
-			Address:               depositTx.TxSender,
+			Address:               services.RedactDepositSender(depositTx.TxSender),
 			PublicKey:             depositTx.PublicKey,
 			Withdrawalcredentials: depositTx.WithdrawalCredentials,
 			Amount:                depositTx.Amount,
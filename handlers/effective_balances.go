@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// EffectiveBalances will return the "effective balances" page using a go template
+func EffectiveBalances(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"effective_balances/effective_balances.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/effective_balances", "Effective Balances", templateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		data.Data, pageError = getEffectiveBalancesPageData()
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "effective_balances.go", "EffectiveBalances", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getEffectiveBalancesPageData() (*models.EffectiveBalancesPageData, error) {
+	pageData := &models.EffectiveBalancesPageData{}
+	pageCacheKey := "validators/effective_balances"
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageCall.CacheTimeout = 5 * time.Minute
+		return buildEffectiveBalancesPageData()
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.EffectiveBalancesPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildEffectiveBalancesPageData() *models.EffectiveBalancesPageData {
+	pageData := &models.EffectiveBalancesPageData{}
+	logrus.Debugf("effective balances page called")
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
+	// current distribution
+	latestStats := db.GetLatestEffectiveBalanceStats()
+	for _, stats := range latestStats {
+		pageData.Epoch = stats.Epoch
+		pageData.Validators += stats.Validators
+		pageData.Compounding += stats.Compounding
+		pageData.EffectiveBalance += stats.EffectiveBalance
+	}
+	pageData.EpochTime = chainState.EpochToTime(phase0.Epoch(pageData.Epoch))
+	if pageData.Validators > 0 {
+		pageData.CompoundingShare = float64(pageData.Compounding) * 100 / float64(pageData.Validators)
+		pageData.AvgEffectiveBalance = pageData.EffectiveBalance / pageData.Validators
+	}
+
+	for _, stats := range latestStats {
+		bucketData := &models.EffectiveBalancesPageDataBucket{
+			Name:             getEffectiveBalanceBucketName(stats.Bucket),
+			Validators:       stats.Validators,
+			Compounding:      stats.Compounding,
+			EffectiveBalance: stats.EffectiveBalance,
+		}
+		if pageData.Validators > 0 {
+			bucketData.Share = float64(stats.Validators) * 100 / float64(pageData.Validators)
+		}
+		if pageData.EffectiveBalance > 0 {
+			bucketData.BalanceShare = float64(stats.EffectiveBalance) * 100 / float64(pageData.EffectiveBalance)
+		}
+		pageData.Buckets = append(pageData.Buckets, bucketData)
+	}
+	pageData.BucketCount = uint64(len(pageData.Buckets))
+
+	// daily history of the last 30 days
+	epochsPerDay := uint64(1)
+	if specs != nil && specs.SecondsPerSlot > 0 && specs.SlotsPerEpoch > 0 {
+		if dayEpochs := uint64(24 * time.Hour / (specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch))); dayEpochs > 1 {
+			epochsPerDay = dayEpochs
+		}
+	}
+	minEpoch := uint64(0)
+	if pageData.Epoch > epochsPerDay*30 {
+		minEpoch = pageData.Epoch - epochsPerDay*30
+	}
+
+	var historyEntry *models.EffectiveBalancesPageDataHistory
+	for _, stats := range db.GetEffectiveBalanceStatsHistory(minEpoch, epochsPerDay) {
+		if historyEntry == nil || historyEntry.Epoch != stats.Epoch {
+			historyEntry = &models.EffectiveBalancesPageDataHistory{
+				Epoch: stats.Epoch,
+				Time:  chainState.EpochToTime(phase0.Epoch(stats.Epoch)),
+			}
+			pageData.History = append(pageData.History, historyEntry)
+		}
+
+		historyEntry.Validators += stats.Validators
+		historyEntry.Compounding += stats.Compounding
+		historyEntry.AvgEffectiveBalance += stats.EffectiveBalance // sum, averaged below
+		if stats.Bucket > 32 {
+			historyEntry.Above32Eth += stats.Validators
+		}
+	}
+	for _, historyEntry := range pageData.History {
+		if historyEntry.Validators > 0 {
+			historyEntry.CompoundingShare = float64(historyEntry.Compounding) * 100 / float64(historyEntry.Validators)
+			historyEntry.AvgEffectiveBalance /= historyEntry.Validators
+		}
+	}
+	pageData.HistoryCount = uint64(len(pageData.History))
+
+	// recent effective balance changes
+	for _, change := range db.GetRecentEffectiveBalanceChanges(50) {
+		pageData.RecentChanges = append(pageData.RecentChanges, &models.EffectiveBalancesPageDataChange{
+			ValidatorIndex:      change.ValidatorIndex,
+			ValidatorName:       services.GlobalBeaconService.GetValidatorName(change.ValidatorIndex),
+			Epoch:               change.Epoch,
+			Time:                chainState.EpochToTime(phase0.Epoch(change.Epoch)),
+			OldEffectiveBalance: change.OldEffectiveBalance,
+			NewEffectiveBalance: change.NewEffectiveBalance,
+			Change:              int64(change.NewEffectiveBalance) - int64(change.OldEffectiveBalance),
+			Compounding:         change.Compounding,
+		})
+	}
+	pageData.RecentChangeCount = uint64(len(pageData.RecentChanges))
+
+	return pageData
+}
+
+// getEffectiveBalanceBucketName returns the effective balance range of a distribution bucket
+func getEffectiveBalanceBucketName(bucket uint64) string {
+	buckets := beacon.EffectiveBalanceBuckets
+	for idx, minBalance := range buckets {
+		if uint64(minBalance) != bucket {
+			continue
+		}
+
+		if idx == len(buckets)-1 || uint64(buckets[idx+1]) == bucket+1 {
+			return fmt.Sprintf("%v ETH", bucket)
+		}
+		return fmt.Sprintf("%v - %v ETH", bucket, buckets[idx+1]-1)
+	}
+
+	return fmt.Sprintf("%v+ ETH", bucket)
+}
@@ -68,7 +68,7 @@ func ElConsolidations(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.maxsi") {
 			maxSrcIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxsi"), 10, 64)
 		}
-		if urlArgs.Has("f.svname") {
+		if urlArgs.Has("f.svname") && services.IsValidatorNameSearchAllowed() {
 			srcVName = urlArgs.Get("f.svname")
 		}
 		if urlArgs.Has("f.minti") {
@@ -77,7 +77,7 @@ func ElConsolidations(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.maxti") {
 			maxTgtIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxti"), 10, 64)
 		}
-		if urlArgs.Has("f.tvname") {
+		if urlArgs.Has("f.tvname") && services.IsValidatorNameSearchAllowed() {
 			tgtVName = urlArgs.Get("f.tvname")
 		}
 		if urlArgs.Has("f.orphaned") {
@@ -168,19 +168,7 @@ func buildFilteredElConsolidationsPageData(pageIdx uint64, pageSize uint64, minS
 		FilterPublicKey:        pubkey,
 	}
 	logrus.Debugf("el_consolidations page called: %v:%v [%v,%v,%v,%v,%v,%v,%v,%v]", pageIdx, pageSize, minSlot, maxSlot, minSrcIndex, maxSrcIndex, srcVName, minTgtIndex, maxTgtIndex, tgtVName)
-	if pageIdx == 1 {
-		pageData.IsDefaultPage = true
-	}
-
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	pageData.PageSize = pageSize
-	pageData.TotalPages = pageIdx
-	pageData.CurrentPageIndex = pageIdx
-	if pageIdx > 1 {
-		pageData.PrevPageIndex = pageIdx - 1
-	}
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
 
 	// Update the filter to use CombinedConsolidationRequestFilter
 	consolidationRequestFilter := &services.CombinedConsolidationRequestFilter{
@@ -276,19 +264,7 @@ func buildFilteredElConsolidationsPageData(pageIdx uint64, pageSize uint64, minS
 	}
 
 	totalRows := totalPendingTxRows + totalRequests
-	pageData.TotalPages = totalRows / pageSize
-	if totalRows%pageSize > 0 {
-		pageData.TotalPages++
-	}
-	pageData.LastPageIndex = pageData.TotalPages
-	if pageIdx < pageData.TotalPages {
-		pageData.NextPageIndex = pageIdx + 1
-	}
-
-	pageData.FirstPageLink = fmt.Sprintf("/validators/el_consolidations?f&%v&c=%v", filterArgs.Encode(), pageData.PageSize)
-	pageData.PrevPageLink = fmt.Sprintf("/validators/el_consolidations?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.PrevPageIndex)
-	pageData.NextPageLink = fmt.Sprintf("/validators/el_consolidations?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.NextPageIndex)
-	pageData.LastPageLink = fmt.Sprintf("/validators/el_consolidations?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.LastPageIndex)
+	finalizePagingData(&pageData.PagingData, totalRows, fmt.Sprintf("/validators/el_consolidations?f&%v", filterArgs.Encode()))
 
 	return pageData
 }
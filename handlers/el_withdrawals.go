@@ -65,7 +65,7 @@ func ElWithdrawals(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.maxi") {
 			maxIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxi"), 10, 64)
 		}
-		if urlArgs.Has("f.vname") {
+		if urlArgs.Has("f.vname") && services.IsValidatorNameSearchAllowed() {
 			vname = urlArgs.Get("f.vname")
 		}
 		if urlArgs.Has("f.orphaned") {
@@ -153,19 +153,7 @@ func buildFilteredElWithdrawalsPageData(pageIdx uint64, pageSize uint64, minSlot
 		FilterPublicKey:     pubkey,
 	}
 	logrus.Debugf("el_withdrawals page called: %v:%v [%v,%v,%v,%v,%v]", pageIdx, pageSize, minSlot, maxSlot, minIndex, maxIndex, vname)
-	if pageIdx == 1 {
-		pageData.IsDefaultPage = true
-	}
-
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	pageData.PageSize = pageSize
-	pageData.TotalPages = pageIdx
-	pageData.CurrentPageIndex = pageIdx
-	if pageIdx > 1 {
-		pageData.PrevPageIndex = pageIdx - 1
-	}
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
 
 	// Create combined filter
 	withdrawalRequestFilter := &services.CombinedWithdrawalRequestFilter{
@@ -261,19 +249,7 @@ func buildFilteredElWithdrawalsPageData(pageIdx uint64, pageSize uint64, minSlot
 	}
 
 	totalRows := totalPendingTxRows + totalRequests
-	pageData.TotalPages = totalRows / pageSize
-	if totalRows%pageSize > 0 {
-		pageData.TotalPages++
-	}
-	pageData.LastPageIndex = pageData.TotalPages
-	if pageIdx < pageData.TotalPages {
-		pageData.NextPageIndex = pageIdx + 1
-	}
-
-	pageData.FirstPageLink = fmt.Sprintf("/validators/el_withdrawals?f&%v&c=%v", filterArgs.Encode(), pageData.PageSize)
-	pageData.PrevPageLink = fmt.Sprintf("/validators/el_withdrawals?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.PrevPageIndex)
-	pageData.NextPageLink = fmt.Sprintf("/validators/el_withdrawals?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.NextPageIndex)
-	pageData.LastPageLink = fmt.Sprintf("/validators/el_withdrawals?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.LastPageIndex)
+	finalizePagingData(&pageData.PagingData, totalRows, fmt.Sprintf("/validators/el_withdrawals?f&%v", filterArgs.Encode()))
 
 	return pageData
 }
@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+)
+
+// Entities will return the "entities" page using a go template
+func Entities(w http.ResponseWriter, r *http.Request) {
+	var entitiesTemplateFiles = append(layoutTemplateFiles,
+		"entities/entities.html",
+	)
+
+	if !services.IsValidatorNameSearchAllowed() {
+		// entities would reveal the redacted validator names
+		NotFound(w, r)
+		return
+	}
+
+	var pageTemplate = templates.GetTemplate(entitiesTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/entities", "Entities", entitiesTemplateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getEntitiesPageData()
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "entities.go", "Entities", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// Entity will return the "entity" details page using a go template
+func Entity(w http.ResponseWriter, r *http.Request) {
+	var entityTemplateFiles = append(layoutTemplateFiles,
+		"entities/entity.html",
+		"_svg/timeline.html",
+	)
+	var notfoundTemplateFiles = append(layoutTemplateFiles,
+		"entities/notfound.html",
+	)
+
+	if !services.IsValidatorNameSearchAllowed() {
+		// entities would reveal the redacted validator names
+		NotFound(w, r)
+		return
+	}
+
+	vars := mux.Vars(r)
+	entityId, err := strconv.ParseUint(vars["id"], 10, 64)
+
+	var pageData *models.EntityPageData
+	var pageError error
+	if err == nil {
+		pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+		if pageError == nil {
+			pageData, pageError = getEntityPageData(entityId)
+		}
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	if pageData == nil {
+		data := InitPageData(w, r, "validators", "/entities", "Entity not found", notfoundTemplateFiles)
+		w.Header().Set("Content-Type", "text/html")
+		if handleTemplateError(w, r, "entities.go", "Entity", "notFound", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+			return // an error has occurred and was processed
+		}
+		return
+	}
+
+	data := InitPageData(w, r, "validators", "/entities", fmt.Sprintf("Entity %v", pageData.Name), entityTemplateFiles)
+	data.Data = pageData
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "entities.go", "Entity", "", templates.GetTemplate(entityTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getEntitiesPageData() (*models.EntitiesPageData, error) {
+	pageData := &models.EntitiesPageData{}
+	pageCacheKey := "entities"
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildEntitiesPageData()
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.EntitiesPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildEntitiesPageData() (*models.EntitiesPageData, time.Duration) {
+	logrus.Debugf("entities page called")
+	pageData := &models.EntitiesPageData{
+		Entities: []*models.EntitiesPageDataEntity{},
+	}
+
+	entities, err := services.GetEntities()
+	if err != nil {
+		logrus.Errorf("error loading entities: %v", err)
+		return pageData, 10 * time.Second
+	}
+
+	entityStats := map[phase0.ValidatorIndex]*models.EntityValidatorStats{}
+	for _, details := range entities {
+		entityData := &models.EntitiesPageDataEntity{
+			Id:          details.Entity.EntityId,
+			Name:        details.Entity.Name,
+			Description: details.Entity.Description,
+		}
+		pageData.Entities = append(pageData.Entities, entityData)
+
+		for _, index := range services.GlobalBeaconService.GetEntityValidatorIndexes(details.Entity.EntityId) {
+			entityStats[phase0.ValidatorIndex(index)] = &entityData.EntityValidatorStats
+		}
+	}
+	pageData.EntityCount = uint64(len(pageData.Entities))
+
+	aggregateEntityValidatorStats(entityStats)
+
+	return pageData, 1 * time.Minute
+}
+
+func getEntityPageData(entityId uint64) (*models.EntityPageData, error) {
+	pageData := &models.EntityPageData{}
+	pageCacheKey := fmt.Sprintf("entity:%v", entityId)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildEntityPageData(entityId)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.EntityPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildEntityPageData(entityId uint64) (*models.EntityPageData, time.Duration) {
+	details, err := services.GetEntity(entityId)
+	if err != nil || details == nil {
+		return nil, 10 * time.Second
+	}
+	logrus.Debugf("entity page called: %v", entityId)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	pageData := &models.EntityPageData{
+		Id:              details.Entity.EntityId,
+		Name:            details.Entity.Name,
+		Description:     details.Entity.Description,
+		Links:           make([]*models.EntityPageDataLink, 0, len(details.Links)),
+		ValidatorRanges: make([]*models.EntityPageDataRange, 0, len(details.ValidatorRanges)),
+		Addresses:       make([]*models.EntityPageDataAddr, 0, len(details.Addresses)),
+		Created:         time.Unix(int64(details.Entity.Created), 0),
+		Updated:         time.Unix(int64(details.Entity.Updated), 0),
+	}
+
+	for _, link := range details.Links {
+		pageData.Links = append(pageData.Links, &models.EntityPageDataLink{
+			Name: link.Name,
+			Url:  link.Url,
+		})
+	}
+	for _, validatorRange := range details.ValidatorRanges {
+		pageData.ValidatorRanges = append(pageData.ValidatorRanges, &models.EntityPageDataRange{
+			MinIndex: validatorRange.MinIndex,
+			MaxIndex: validatorRange.MaxIndex,
+		})
+	}
+	for _, address := range details.Addresses {
+		pageData.Addresses = append(pageData.Addresses, &models.EntityPageDataAddr{
+			Type:    services.GetEntityAddressTypeKey(address.AddressType),
+			Address: address.Address,
+		})
+	}
+
+	// aggregate the validators that are currently named by the entity
+	entityStats := map[phase0.ValidatorIndex]*models.EntityValidatorStats{}
+	for _, index := range services.GlobalBeaconService.GetEntityValidatorIndexes(entityId) {
+		entityStats[phase0.ValidatorIndex(index)] = &pageData.EntityValidatorStats
+	}
+	aggregateEntityValidatorStats(entityStats)
+
+	// load recent block proposals of the entity validators
+	pageData.RecentBlocks = make([]*models.EntityPageDataBlock, 0)
+	if pageData.Validators > 0 {
+		blocksData := services.GlobalBeaconService.GetDbBlocksByFilter(&dbtypes.BlockFilter{
+			ProposerName: pageData.Name,
+			WithOrphaned: 1,
+			WithMissing:  1,
+		}, 0, 10, chainState.GetSpecs().SlotsPerEpoch)
+		for _, blockData := range blocksData {
+			var blockStatus dbtypes.SlotStatus
+			if blockData.Block == nil {
+				blockStatus = dbtypes.Missing
+			} else {
+				blockStatus = blockData.Block.Status
+			}
+			blockEntry := &models.EntityPageDataBlock{
+				Epoch:    uint64(chainState.EpochOfSlot(phase0.Slot(blockData.Slot))),
+				Slot:     blockData.Slot,
+				Ts:       chainState.SlotToTime(phase0.Slot(blockData.Slot)),
+				Status:   uint64(blockStatus),
+				Proposer: blockData.Proposer,
+			}
+			if blockData.Block != nil {
+				blockEntry.Graffiti = blockData.Block.Graffiti
+				blockEntry.BlockRoot = fmt.Sprintf("0x%x", blockData.Block.Root)
+				if blockData.Block.EthBlockNumber != nil {
+					blockEntry.WithEthBlock = true
+					blockEntry.EthBlock = *blockData.Block.EthBlockNumber
+				}
+			}
+			pageData.RecentBlocks = append(pageData.RecentBlocks, blockEntry)
+		}
+	}
+	pageData.RecentBlockCount = uint64(len(pageData.RecentBlocks))
+
+	return pageData, 1 * time.Minute
+}
+
+// aggregateEntityValidatorStats adds the status & effective balance of the validators in the map to their entity stats
+func aggregateEntityValidatorStats(entityStats map[phase0.ValidatorIndex]*models.EntityValidatorStats) {
+	if len(entityStats) == 0 {
+		return
+	}
+
+	currentEpoch := services.GlobalBeaconService.GetChainState().CurrentEpoch()
+	services.GlobalBeaconService.StreamActiveValidatorData(false, func(index phase0.ValidatorIndex, validatorFlags uint16, activeData *beacon.ValidatorData, validator *phase0.Validator) error {
+		stats := entityStats[index]
+		if stats == nil {
+			return nil
+		}
+
+		stats.Validators++
+		if validatorFlags&beacon.ValidatorStatusSlashed != 0 {
+			stats.Slashed++
+		}
+
+		if activeData != nil && activeData.ActivationEpoch <= currentEpoch {
+			if activeData.ExitEpoch > currentEpoch {
+				stats.Activated++
+				stats.EffectiveBalance += uint64(activeData.EffectiveBalanceEth)
+				if services.GlobalBeaconService.GetValidatorLiveness(index, 3) > 0 {
+					stats.Online++
+				} else {
+					stats.Offline++
+				}
+			} else {
+				stats.Exited++
+			}
+		} else if validatorFlags&beacon.ValidatorStatusExited != 0 {
+			stats.Exited++
+		} else {
+			stats.Pending++
+		}
+
+		return nil
+	})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -64,6 +65,39 @@ func Epoch(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// EpochStateSSZ will stream the raw ssz encoded beacon state at the start of the given epoch from a beacon node
+func EpochStateSSZ(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	epoch, err := strconv.ParseUint(vars["epoch"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid epoch", http.StatusBadRequest)
+		return
+	}
+
+	// states are large and need to be loaded from a beacon node, so this call is expensive
+	if err := services.GlobalCallRateLimiter.CheckCallLimit(r, 10); err != nil {
+		http.Error(w, "Call rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
+	stateStream, version, err := services.GlobalBeaconService.GetEpochStateSSZ(r.Context(), phase0.Epoch(epoch))
+	if err != nil {
+		logrus.WithError(err).Warnf("error loading state ssz for epoch %v", epoch)
+		http.Error(w, fmt.Sprintf("Error loading state: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	defer stateStream.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if version != "" {
+		w.Header().Set("Eth-Consensus-Version", version)
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=state-%d.ssz", epoch))
+	if _, err := io.Copy(w, stateStream); err != nil {
+		logrus.WithError(err).Debugf("error streaming state ssz for epoch %v", epoch)
+	}
+}
+
 func getEpochPageData(epoch uint64) (*models.EpochPageData, error) {
 	pageData := &models.EpochPageData{}
 	pageCacheKey := fmt.Sprintf("epoch:%v", epoch)
@@ -118,6 +152,12 @@ func buildEpochPageData(epoch uint64) (*models.EpochPageData, time.Duration) {
 		Finalized:     finalizedEpoch > phase0.Epoch(epoch),
 	}
 
+	if epochRewards := db.GetEpochRewards(epoch); epochRewards != nil {
+		pageData.ShowRewards = true
+		pageData.TotalRewards = epochRewards.AttestationHead + epochRewards.AttestationTarget + epochRewards.AttestationSource +
+			epochRewards.AttestationInclusionDelay + epochRewards.AttestationInactivity + epochRewards.SyncCommittee + epochRewards.Proposer
+	}
+
 	dbEpochs := services.GlobalBeaconService.GetDbEpochs(epoch, 1)
 	dbEpoch := dbEpochs[0]
 	if dbEpoch != nil {
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+)
+
+// EpochRewards will return the "epoch rewards" breakdown page using a go template
+func EpochRewards(w http.ResponseWriter, r *http.Request) {
+	var epochRewardsTemplateFiles = append(layoutTemplateFiles,
+		"epoch/rewards.html",
+		"_svg/professor.html",
+	)
+	var notfoundTemplateFiles = append(layoutTemplateFiles,
+		"epoch/notfound.html",
+	)
+	var pageTemplate = templates.GetTemplate(epochRewardsTemplateFiles...)
+
+	vars := mux.Vars(r)
+	epoch, err := strconv.ParseUint(vars["epoch"], 10, 64)
+	if err != nil {
+		data := InitPageData(w, r, "blockchain", "/epoch", "Epoch not found", notfoundTemplateFiles)
+		w.Header().Set("Content-Type", "text/html")
+		handleTemplateError(w, r, "epoch_rewards.go", "EpochRewards", "", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data))
+		return
+	}
+
+	var pageData *models.EpochRewardsPageData
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		pageData, pageError = getEpochRewardsPageData(epoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	data := InitPageData(w, r, "blockchain", "/epoch", fmt.Sprintf("Epoch %v Rewards", epoch), epochRewardsTemplateFiles)
+	data.Data = pageData
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "epoch_rewards.go", "EpochRewards", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getEpochRewardsPageData(epoch uint64) (*models.EpochRewardsPageData, error) {
+	pageData := &models.EpochRewardsPageData{}
+	pageCacheKey := fmt.Sprintf("epoch_rewards:%v", epoch)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildEpochRewardsPageData(epoch)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.EpochRewardsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildEpochRewardsPageData(epoch uint64) (*models.EpochRewardsPageData, time.Duration) {
+	logrus.Debugf("epoch rewards page called: %v", epoch)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	currentEpoch := uint64(chainState.CurrentEpoch())
+
+	nextEpoch := epoch + 1
+	if nextEpoch > currentEpoch {
+		nextEpoch = 0
+	}
+
+	pageData := &models.EpochRewardsPageData{
+		Epoch:         epoch,
+		PreviousEpoch: epoch - 1,
+		NextEpoch:     nextEpoch,
+		Ts:            chainState.EpochToTime(phase0.Epoch(epoch)),
+	}
+
+	epochRewards := db.GetEpochRewards(epoch)
+	if epochRewards == nil {
+		// rewards are indexed after finalization, check again later
+		return pageData, 1 * time.Minute
+	}
+
+	pageData.Indexed = true
+	pageData.Validators = epochRewards.Validators
+	pageData.AttestationHead = epochRewards.AttestationHead
+	pageData.AttestationTarget = epochRewards.AttestationTarget
+	pageData.AttestationSource = epochRewards.AttestationSource
+	pageData.AttestationInclusionDelay = epochRewards.AttestationInclusionDelay
+	pageData.AttestationInactivity = epochRewards.AttestationInactivity
+	pageData.AttestationTotal = epochRewards.AttestationHead + epochRewards.AttestationTarget + epochRewards.AttestationSource + epochRewards.AttestationInclusionDelay + epochRewards.AttestationInactivity
+	pageData.SyncCommittee = epochRewards.SyncCommittee
+	pageData.Proposer = epochRewards.Proposer
+	pageData.Total = pageData.AttestationTotal + pageData.SyncCommittee + pageData.Proposer
+
+	firstSlot := chainState.EpochToSlot(phase0.Epoch(epoch))
+	lastSlot := chainState.EpochToSlot(phase0.Epoch(epoch+1)) - 1
+	blockRewards, _ := db.GetBlockRewardsRange(uint64(firstSlot), uint64(lastSlot))
+	for _, blockReward := range blockRewards {
+		pageData.Blocks = append(pageData.Blocks, &models.EpochRewardsPageDataBlock{
+			Slot:              blockReward.Slot,
+			Ts:                chainState.SlotToTime(phase0.Slot(blockReward.Slot)),
+			Proposer:          blockReward.Proposer,
+			ProposerName:      services.GlobalBeaconService.GetValidatorName(blockReward.Proposer),
+			Total:             blockReward.Total,
+			Attestations:      blockReward.Attestations,
+			SyncAggregate:     blockReward.SyncAggregate,
+			ProposerSlashings: blockReward.ProposerSlashings,
+			AttesterSlashings: blockReward.AttesterSlashings,
+		})
+	}
+	pageData.BlockCount = uint64(len(pageData.Blocks))
+
+	return pageData, 30 * time.Minute
+}
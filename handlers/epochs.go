@@ -73,33 +73,8 @@ func buildEpochsPageData(firstEpoch uint64, pageSize uint64) (*models.EpochsPage
 
 	chainState := services.GlobalBeaconService.GetChainState()
 	currentEpoch := chainState.CurrentEpoch()
-	if firstEpoch > uint64(currentEpoch) {
-		pageData.IsDefaultPage = true
-		firstEpoch = uint64(currentEpoch)
-	}
-
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	pagesBefore := (firstEpoch + 1) / pageSize
-	if ((firstEpoch + 1) % pageSize) > 0 {
-		pagesBefore++
-	}
-	pagesAfter := (uint64(currentEpoch) - firstEpoch) / pageSize
-	if ((uint64(currentEpoch) - firstEpoch) % pageSize) > 0 {
-		pagesAfter++
-	}
-	pageData.PageSize = pageSize
-	pageData.TotalPages = pagesBefore + pagesAfter
-	pageData.CurrentPageIndex = pagesAfter + 1
+	pageSize, firstEpoch = initAnchoredPagingData(&pageData.PagingData, firstEpoch, uint64(currentEpoch), pageSize, "/epochs", "epoch", "count")
 	pageData.CurrentPageEpoch = firstEpoch
-	pageData.PrevPageIndex = pageData.CurrentPageIndex - 1
-	pageData.PrevPageEpoch = pageData.CurrentPageEpoch + pageSize
-	if pageData.CurrentPageEpoch >= pageSize {
-		pageData.NextPageIndex = pageData.CurrentPageIndex + 1
-		pageData.NextPageEpoch = pageData.CurrentPageEpoch - pageSize
-	}
-	pageData.LastPageEpoch = pageSize - 1
 
 	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
 	justifiedEpoch, _ := chainState.GetJustifiedCheckpoint()
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// ExecutionBlock will return the main "execution block" page using a go template
+func ExecutionBlock(w http.ResponseWriter, r *http.Request) {
+	var executionBlockTemplateFiles = append(layoutTemplateFiles,
+		"execution_block/execution_block.html",
+	)
+	var notfoundTemplateFiles = append(layoutTemplateFiles,
+		"execution_block/notfound.html",
+	)
+
+	vars := mux.Vars(r)
+	numberOrHash := vars["numberOrHash"]
+
+	var blockNumber *uint64
+	var blockHash []byte
+	if strings.HasPrefix(numberOrHash, "0x") {
+		hash, err := hex.DecodeString(strings.Replace(numberOrHash, "0x", "", -1))
+		if err == nil && len(hash) == 32 {
+			blockHash = hash
+		}
+	} else if number, err := strconv.ParseUint(numberOrHash, 10, 64); err == nil && number < math.MaxInt64 {
+		blockNumber = &number
+	}
+
+	urlArgs := r.URL.Query()
+	var beaconRoot []byte
+	if urlArgs.Has("root") {
+		root, err := hex.DecodeString(strings.Replace(urlArgs.Get("root"), "0x", "", -1))
+		if err == nil && len(root) == 32 {
+			beaconRoot = root
+		}
+	}
+	showReceipts := urlArgs.Get("receipts") == "1"
+
+	var pageData *models.ExecutionBlockPageData
+	var pageError error
+	if blockNumber != nil || blockHash != nil {
+		callCost := uint(1)
+		if showReceipts {
+			callCost = 2
+		}
+		pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, callCost)
+		if pageError == nil {
+			pageData, pageError = getExecutionBlockPageData(blockNumber, blockHash, beaconRoot, showReceipts)
+		}
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	if pageData == nil {
+		data := InitPageData(w, r, "blockchain", "/slots", fmt.Sprintf("Execution Block %v", numberOrHash), notfoundTemplateFiles)
+		w.Header().Set("Content-Type", "text/html")
+		if handleTemplateError(w, r, "execution_block.go", "ExecutionBlock", "notFound", templates.GetTemplate(notfoundTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+			return // an error has occurred and was processed
+		}
+		return
+	}
+
+	data := InitPageData(w, r, "blockchain", "/slots", fmt.Sprintf("Execution Block %v", pageData.BlockNumber), executionBlockTemplateFiles)
+	data.Data = pageData
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "execution_block.go", "ExecutionBlock", "", templates.GetTemplate(executionBlockTemplateFiles...).ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getExecutionBlockPageData(blockNumber *uint64, blockHash []byte, beaconRoot []byte, showReceipts bool) (*models.ExecutionBlockPageData, error) {
+	pageData := &models.ExecutionBlockPageData{}
+	numberKey := uint64(0)
+	if blockNumber != nil {
+		numberKey = *blockNumber
+	}
+	pageCacheKey := fmt.Sprintf("execution_block:%v:%x:%x:%v", numberKey, blockHash, beaconRoot, showReceipts)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildExecutionBlockPageData(pageCall.CallCtx, blockNumber, blockHash, beaconRoot, showReceipts)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ExecutionBlockPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildExecutionBlockPageData(ctx context.Context, blockNumber *uint64, blockHash []byte, beaconRoot []byte, showReceipts bool) (*models.ExecutionBlockPageData, time.Duration) {
+	dbBlocks := services.GlobalBeaconService.GetDbBlocksByExecutionBlock(blockNumber, blockHash)
+	if len(dbBlocks) == 0 {
+		return nil, 10 * time.Second
+	}
+	logrus.Debugf("execution block page called: %v", dbBlocks[0].EthBlockNumber)
+
+	// show the requested beacon block, or the canonical one by default
+	selectedBlock := dbBlocks[0]
+	if beaconRoot != nil {
+		for _, dbBlock := range dbBlocks {
+			if bytes.Equal(dbBlock.Root, beaconRoot) {
+				selectedBlock = dbBlock
+				break
+			}
+		}
+	}
+
+	blockData, err := services.GlobalBeaconService.GetSlotDetailsByBlockroot(ctx, phase0.Root(selectedBlock.Root))
+	if err != nil || blockData == nil || blockData.Block == nil {
+		return nil, 10 * time.Second
+	}
+
+	// reuse the slot page logic for decoding the execution payload & transactions
+	slotPageData := &models.SlotPageBlockData{
+		BlockRoot: selectedBlock.Root,
+	}
+	getSlotPageExecutionPayload(slotPageData, blockData.Block)
+	if slotPageData.ExecutionData == nil {
+		return nil, 10 * time.Second
+	}
+
+	executionData := slotPageData.ExecutionData
+	pageData := &models.ExecutionBlockPageData{
+		BlockNumber:      executionData.BlockNumber,
+		BlockHash:        executionData.BlockHash,
+		ParentHash:       executionData.ParentHash,
+		FeeRecipient:     executionData.FeeRecipient,
+		StateRoot:        executionData.StateRoot,
+		ReceiptsRoot:     executionData.ReceiptsRoot,
+		GasLimit:         executionData.GasLimit,
+		GasUsed:          executionData.GasUsed,
+		BaseFeePerGas:    executionData.BaseFeePerGas,
+		Time:             executionData.Time,
+		ExtraData:        executionData.ExtraData,
+		Slot:             uint64(blockData.Header.Message.Slot),
+		BlockRoot:        selectedBlock.Root,
+		Orphaned:         selectedBlock.Status == dbtypes.Orphaned,
+		TransactionCount: slotPageData.TransactionsCount,
+		ShowReceipts:     showReceipts,
+	}
+	if pageData.GasLimit > 0 {
+		pageData.GasUsedPercent = float64(pageData.GasUsed) * 100 / float64(pageData.GasLimit)
+	}
+
+	for _, dbBlock := range dbBlocks {
+		pageData.BeaconBlocks = append(pageData.BeaconBlocks, &models.ExecutionBlockPageBeaconBlock{
+			Slot:      dbBlock.Slot,
+			BlockRoot: dbBlock.Root,
+			Orphaned:  dbBlock.Status == dbtypes.Orphaned,
+			Selected:  dbBlock == selectedBlock,
+		})
+	}
+
+	pageData.Transactions = make([]*models.ExecutionBlockPageTransaction, 0, len(slotPageData.Transactions))
+	for _, tx := range slotPageData.Transactions {
+		txData := &models.ExecutionBlockPageTransaction{
+			Index:         tx.Index,
+			Hash:          tx.Hash,
+			From:          tx.From,
+			To:            tx.To,
+			Value:         tx.Value,
+			Type:          tx.Type,
+			FuncSigStatus: tx.FuncSigStatus,
+			FuncName:      tx.FuncName,
+			FuncSig:       tx.FuncSig,
+			GasLimit:      tx.GasLimit,
+		}
+		pageData.Transactions = append(pageData.Transactions, txData)
+	}
+
+	cacheTimeout := 5 * time.Minute
+	if showReceipts {
+		receipts, err := services.GlobalBeaconService.GetExecutionBlockReceipts(ctx, common.Hash(pageData.BlockHash))
+		if err != nil {
+			pageData.ReceiptsError = err.Error()
+			cacheTimeout = 10 * time.Second
+		} else {
+			getExecutionBlockReceipts(pageData, receipts)
+		}
+	}
+
+	return pageData, cacheTimeout
+}
+
+// getExecutionBlockReceipts adds the receipt fields to the transactions of the execution block page
+func getExecutionBlockReceipts(pageData *models.ExecutionBlockPageData, receipts []*ethtypes.Receipt) {
+	receiptMap := make(map[common.Hash]*ethtypes.Receipt, len(receipts))
+	for _, receipt := range receipts {
+		receiptMap[receipt.TxHash] = receipt
+	}
+
+	ethFloat, _ := utils.ETH.Float64()
+	totalFees := big.NewInt(0)
+	for _, txData := range pageData.Transactions {
+		receipt := receiptMap[common.Hash(txData.Hash)]
+		if receipt == nil {
+			continue
+		}
+
+		txData.HasReceipt = true
+		txData.Success = receipt.Status == ethtypes.ReceiptStatusSuccessful
+		txData.GasUsed = receipt.GasUsed
+		txData.LogCount = uint64(len(receipt.Logs))
+		if receipt.ContractAddress != (common.Address{}) {
+			txData.ContractAddress = receipt.ContractAddress[:]
+		}
+
+		if receipt.EffectiveGasPrice != nil {
+			txData.GasPrice = receipt.EffectiveGasPrice.Uint64()
+
+			txFee := new(big.Int).Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+			totalFees.Add(totalFees, txFee)
+
+			txFeeFloat, _ := txFee.Float64()
+			txData.Fee = txFeeFloat / ethFloat
+		}
+	}
+
+	totalFeesFloat, _ := totalFees.Float64()
+	pageData.TotalFees = totalFeesFloat / ethFloat
+}
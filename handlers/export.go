@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/sirupsen/logrus"
+)
+
+// exportBatchSize is the number of rows loaded per batch while streaming a table export
+const exportBatchSize = 1000
+
+// tableExport streams the rows of a table view as csv or json to the client.
+// rows are written & flushed batch by batch, so large exports don't need to be built in memory.
+// note: exports are still bound to the frontend write timeout (frontend.httpWriteTimeout).
+type tableExport struct {
+	w         http.ResponseWriter
+	flusher   http.Flusher
+	isJson    bool
+	columns   []string
+	csvWriter *csv.Writer
+	rowCount  uint64
+	err       error
+}
+
+// startTableExport checks the call limit, parses the requested format (?format=csv|json) and writes the response headers.
+// returns nil if the export can't be started, the error response has been sent in that case.
+func startTableExport(w http.ResponseWriter, r *http.Request, name string, columns []string) *tableExport {
+	if err := services.GlobalCallRateLimiter.CheckCallLimit(r, 10); err != nil {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return nil
+	}
+
+	export := &tableExport{
+		w:       w,
+		columns: columns,
+	}
+	export.flusher, _ = w.(http.Flusher)
+
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "", "csv":
+		format = "csv"
+		w.Header().Set("Content-Type", "text/csv")
+		export.csvWriter = csv.NewWriter(w)
+	case "json":
+		export.isJson = true
+		w.Header().Set("Content-Type", "application/json")
+	default:
+		http.Error(w, fmt.Sprintf("unsupported export format: %v", format), http.StatusBadRequest)
+		return nil
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%v-%v.%v\"", name, time.Now().Format("20060102-150405"), format))
+
+	if export.isJson {
+		_, export.err = w.Write([]byte("["))
+	} else {
+		export.err = export.csvWriter.Write(columns)
+	}
+
+	return export
+}
+
+// writeRow writes a single row, the values need to be in the same order as the columns.
+// returns false if the client connection failed and the export should be aborted.
+func (export *tableExport) writeRow(values ...interface{}) bool {
+	if export.err != nil {
+		return false
+	}
+
+	if export.isJson {
+		rowJson := make([]byte, 0, 256)
+		if export.rowCount > 0 {
+			rowJson = append(rowJson, ',')
+		}
+		rowJson = append(rowJson, "\n{"...)
+		for idx, column := range export.columns {
+			if idx > 0 {
+				rowJson = append(rowJson, ',')
+			}
+			keyJson, _ := json.Marshal(column)
+			valueJson, err := json.Marshal(getExportValue(values[idx], true))
+			if err != nil {
+				valueJson = []byte("null")
+			}
+			rowJson = append(rowJson, keyJson...)
+			rowJson = append(rowJson, ':')
+			rowJson = append(rowJson, valueJson...)
+		}
+		rowJson = append(rowJson, '}')
+		_, export.err = export.w.Write(rowJson)
+	} else {
+		record := make([]string, len(values))
+		for idx, value := range values {
+			record[idx] = fmt.Sprintf("%v", getExportValue(value, false))
+		}
+		export.err = export.csvWriter.Write(record)
+	}
+
+	export.rowCount++
+	return export.err == nil
+}
+
+// flush sends the buffered rows to the client, called after each batch
+func (export *tableExport) flush() bool {
+	if export.csvWriter != nil && export.err == nil {
+		export.csvWriter.Flush()
+		export.err = export.csvWriter.Error()
+	}
+	if export.flusher != nil && export.err == nil {
+		export.flusher.Flush()
+	}
+	return export.err == nil
+}
+
+// finish completes the export
+func (export *tableExport) finish(r *http.Request) {
+	if export.isJson && export.err == nil {
+		_, export.err = export.w.Write([]byte("\n]\n"))
+	}
+	export.flush()
+
+	if export.err != nil {
+		logrus.Warnf("table export %v aborted after %v rows: %v", r.URL.String(), export.rowCount, export.err)
+	}
+}
+
+// getExportValue converts a value to its export representation (hex encoded bytes, unix timestamps, nil for unset pointers)
+func getExportValue(value interface{}, isJson bool) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		if v == nil {
+			break
+		}
+		return fmt.Sprintf("0x%x", v)
+	case time.Time:
+		return v.Unix()
+	case *uint64:
+		if v != nil {
+			return *v
+		}
+	default:
+		return value
+	}
+
+	if isJson {
+		return nil
+	}
+	return ""
+}
@@ -0,0 +1,443 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// the export handlers accept the same filter arguments as the corresponding table pages,
+// so the export link of a page is the page url with the export path & an optional format argument.
+
+// SlotsExport streams the filtered slots as csv or json (filters of the "/slots/filtered" page)
+func SlotsExport(w http.ResponseWriter, r *http.Request) {
+	urlArgs := r.URL.Query()
+	blockFilter := &dbtypes.BlockFilter{
+		WithOrphaned: 1,
+		WithMissing:  1,
+	}
+	if urlArgs.Has("f") {
+		blockFilter.Graffiti = urlArgs.Get("f.graffiti")
+		blockFilter.ExtraData = urlArgs.Get("f.extra")
+		blockFilter.ProposerName = getExportFilterName(urlArgs, "f.pname")
+		blockFilter.Tag = urlArgs.Get("f.tag")
+		blockFilter.WithOrphaned = getExportFilterUint8(urlArgs, "f.orphaned")
+		blockFilter.WithMissing = getExportFilterUint8(urlArgs, "f.missing")
+		if proposer := urlArgs.Get("f.proposer"); proposer != "" {
+			pidx, _ := strconv.ParseUint(proposer, 10, 64)
+			blockFilter.ProposerIndex = &pidx
+		}
+	}
+
+	export := startTableExport(w, r, "slots", []string{
+		"slot", "epoch", "time", "status", "proposer", "proposer_name", "block_root", "attestations", "deposits", "exits",
+		"proposer_slashings", "attester_slashings", "sync_participation", "transactions", "el_block_number", "graffiti", "el_extra_data",
+	})
+	if export == nil {
+		return
+	}
+	defer export.finish(r)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	for pageIdx := uint64(0); ; pageIdx++ {
+		dbBlocks := services.GlobalBeaconService.GetDbBlocksByFilter(blockFilter, pageIdx, exportBatchSize, 0)
+		for idx, dbBlock := range dbBlocks {
+			if idx >= exportBatchSize {
+				break
+			}
+
+			slot := phase0.Slot(dbBlock.Slot)
+			status := "missing"
+			var blockRoot, graffiti, extraData []byte
+			var attestations, deposits, exits, proposerSlashings, attesterSlashings, transactions uint64
+			var syncParticipation float32
+			var blockNumber *uint64
+			if block := dbBlock.Block; block != nil {
+				switch block.Status {
+				case dbtypes.Canonical:
+					status = "canonical"
+				case dbtypes.Orphaned:
+					status = "orphaned"
+				}
+				blockRoot = block.Root
+				graffiti = block.Graffiti
+				extraData = block.EthBlockExtra
+				attestations = block.AttestationCount
+				deposits = block.DepositCount
+				exits = block.ExitCount
+				proposerSlashings = block.ProposerSlashingCount
+				attesterSlashings = block.AttesterSlashingCount
+				transactions = block.EthTransactionCount
+				syncParticipation = block.SyncParticipation
+				blockNumber = block.EthBlockNumber
+			}
+
+			if !export.writeRow(
+				dbBlock.Slot, uint64(chainState.EpochOfSlot(slot)), chainState.SlotToTime(slot), status, dbBlock.Proposer,
+				services.GlobalBeaconService.GetValidatorName(dbBlock.Proposer), blockRoot, attestations, deposits, exits,
+				proposerSlashings, attesterSlashings, syncParticipation, transactions, blockNumber, graffiti, extraData,
+			) {
+				return
+			}
+		}
+
+		if !export.flush() || len(dbBlocks) <= exportBatchSize {
+			return
+		}
+	}
+}
+
+// ValidatorsExport streams the filtered validator set as csv or json (filters & sorting of the "/validators" page)
+func ValidatorsExport(w http.ResponseWriter, r *http.Request) {
+	// each batch merges the cached validator changes, so larger batches are used to limit the number of passes
+	const validatorBatchSize = 50000
+
+	validatorFilter := getExportValidatorFilter(r.URL.Query(), validatorBatchSize)
+	export := startTableExport(w, r, "validators", []string{
+		"index", "pubkey", "name", "status", "balance", "effective_balance", "slashed",
+		"activation_eligibility_epoch", "activation_epoch", "exit_epoch", "withdrawable_epoch", "withdrawal_credentials",
+	})
+	if export == nil {
+		return
+	}
+	defer export.finish(r)
+
+	for {
+		validators, totalCount := services.GlobalBeaconService.GetFilteredValidatorSet(validatorFilter, true)
+		for _, validator := range validators {
+			if validator.Validator == nil {
+				continue
+			}
+
+			if !export.writeRow(
+				uint64(validator.Index), validator.Validator.PublicKey[:], services.GlobalBeaconService.GetValidatorName(uint64(validator.Index)),
+				validator.Status.String(), uint64(validator.Balance), uint64(validator.Validator.EffectiveBalance), validator.Validator.Slashed,
+				uint64(validator.Validator.ActivationEligibilityEpoch), uint64(validator.Validator.ActivationEpoch),
+				uint64(validator.Validator.ExitEpoch), uint64(validator.Validator.WithdrawableEpoch), validator.Validator.WithdrawalCredentials,
+			) {
+				return
+			}
+		}
+
+		validatorFilter.Offset += validatorBatchSize
+		if !export.flush() || len(validators) < validatorBatchSize || validatorFilter.Offset >= totalCount {
+			return
+		}
+	}
+}
+
+// getExportValidatorFilter parses the filters & sorting of the "/validators" page
+func getExportValidatorFilter(urlArgs url.Values, limit uint64) *dbtypes.ValidatorFilter {
+	validatorFilter := &dbtypes.ValidatorFilter{
+		Limit: limit,
+	}
+	if urlArgs.Has("f") {
+		if filterPubKey := urlArgs.Get("f.pubkey"); filterPubKey != "" {
+			validatorFilter.PubKey, _ = hex.DecodeString(strings.Replace(filterPubKey, "0x", "", -1))
+		}
+		if filterIndex := urlArgs.Get("f.index"); filterIndex != "" {
+			filterIndexVal, _ := strconv.ParseUint(filterIndex, 10, 64)
+			validatorFilter.MinIndex = &filterIndexVal
+			validatorFilter.MaxIndex = &filterIndexVal
+		}
+		validatorFilter.ValidatorName = getExportFilterName(urlArgs, "f.name")
+		if urlArgs.Has("f.status") {
+			for _, status := range strings.Split(strings.Join(urlArgs["f.status"], ","), ",") {
+				statusVal := v1.ValidatorState(0)
+				if err := statusVal.UnmarshalJSON([]byte(fmt.Sprintf("\"%v\"", status))); err == nil {
+					validatorFilter.Status = append(validatorFilter.Status, statusVal)
+				}
+			}
+		}
+		if urlArgs.Get("f.minbal") != "" {
+			minBalance := getExportFilterUint64(urlArgs, "f.minbal") * utils.GWEI.Uint64()
+			validatorFilter.MinBalance = &minBalance
+		}
+		if urlArgs.Get("f.maxbal") != "" {
+			maxBalance := getExportFilterUint64(urlArgs, "f.maxbal") * utils.GWEI.Uint64()
+			validatorFilter.MaxBalance = &maxBalance
+		}
+		if urlArgs.Get("f.minact") != "" {
+			minActivation := getExportFilterUint64(urlArgs, "f.minact")
+			validatorFilter.MinActivation = &minActivation
+		}
+		if urlArgs.Get("f.maxact") != "" {
+			maxActivation := getExportFilterUint64(urlArgs, "f.maxact")
+			validatorFilter.MaxActivation = &maxActivation
+		}
+		if filterCredentials := urlArgs.Get("f.creds"); filterCredentials != "" {
+			validatorFilter.CredentialsPrefix, _ = hex.DecodeString(strings.Replace(filterCredentials, "0x", "", -1))
+		}
+	}
+
+	switch urlArgs.Get("o") {
+	case "index-d":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderIndexDesc
+	case "pubkey":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderPubKeyAsc
+	case "pubkey-d":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderPubKeyDesc
+	case "balance":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderBalanceAsc
+	case "balance-d":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderBalanceDesc
+	case "activation":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderActivationEpochAsc
+	case "activation-d":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderActivationEpochDesc
+	case "exit":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderExitEpochAsc
+	case "exit-d":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderExitEpochDesc
+	default:
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderIndexAsc
+	}
+
+	return validatorFilter
+}
+
+// DepositsExport streams the filtered included deposits as csv or json (filters of the "/validators/included_deposits" page)
+func DepositsExport(w http.ResponseWriter, r *http.Request) {
+	urlArgs := r.URL.Query()
+	depositFilter := &dbtypes.DepositFilter{
+		WithOrphaned: 1,
+	}
+	if urlArgs.Has("f") {
+		depositFilter.MinIndex = getExportFilterUint64(urlArgs, "f.mini")
+		depositFilter.MaxIndex = getExportFilterUint64(urlArgs, "f.maxi")
+		depositFilter.PublicKey = common.FromHex(urlArgs.Get("f.pubkey"))
+		depositFilter.ValidatorName = getExportFilterName(urlArgs, "f.vname")
+		depositFilter.MinAmount = getExportFilterUint64(urlArgs, "f.mina")
+		depositFilter.MaxAmount = getExportFilterUint64(urlArgs, "f.maxa")
+		depositFilter.WithOrphaned = getExportFilterUint8(urlArgs, "f.orphaned")
+	}
+
+	export := startTableExport(w, r, "deposits", []string{
+		"index", "slot", "time", "slot_root", "orphaned", "pubkey", "validator_name", "withdrawal_credentials", "amount",
+	})
+	if export == nil {
+		return
+	}
+	defer export.finish(r)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	for pageIdx := uint64(0); ; pageIdx++ {
+		deposits, totalCount := services.GlobalBeaconService.GetIncludedDepositsByFilter(depositFilter, pageIdx, exportBatchSize)
+		for _, deposit := range deposits {
+			validatorName := ""
+			if validatorIdx, found := services.GlobalBeaconService.GetValidatorIndexByPubkey(phase0.BLSPubKey(deposit.PublicKey)); found {
+				validatorName = services.GlobalBeaconService.GetValidatorName(uint64(validatorIdx))
+			}
+
+			if !export.writeRow(
+				deposit.Index, deposit.SlotNumber, chainState.SlotToTime(phase0.Slot(deposit.SlotNumber)), deposit.SlotRoot, deposit.Orphaned,
+				deposit.PublicKey, validatorName, deposit.WithdrawalCredentials, deposit.Amount,
+			) {
+				return
+			}
+		}
+
+		if !export.flush() || len(deposits) < exportBatchSize || (pageIdx+1)*exportBatchSize >= totalCount {
+			return
+		}
+	}
+}
+
+// WithdrawalsExport streams the filtered withdrawal requests as csv or json (filters of the "/validators/el_withdrawals" page)
+func WithdrawalsExport(w http.ResponseWriter, r *http.Request) {
+	urlArgs := r.URL.Query()
+	withdrawalRequestFilter := &services.CombinedWithdrawalRequestFilter{
+		Filter: &dbtypes.WithdrawalRequestFilter{
+			WithOrphaned: 1,
+		},
+	}
+	if urlArgs.Has("f") {
+		withdrawalRequestFilter.Filter.MinSlot = getExportFilterUint64(urlArgs, "f.mins")
+		withdrawalRequestFilter.Filter.MaxSlot = getExportFilterUint64(urlArgs, "f.maxs")
+		withdrawalRequestFilter.Filter.SourceAddress = common.FromHex(urlArgs.Get("f.address"))
+		withdrawalRequestFilter.Filter.MinIndex = getExportFilterUint64(urlArgs, "f.mini")
+		withdrawalRequestFilter.Filter.MaxIndex = getExportFilterUint64(urlArgs, "f.maxi")
+		withdrawalRequestFilter.Filter.ValidatorName = getExportFilterName(urlArgs, "f.vname")
+		withdrawalRequestFilter.Filter.WithOrphaned = getExportFilterUint8(urlArgs, "f.orphaned")
+		withdrawalRequestFilter.Filter.PublicKey = common.FromHex(urlArgs.Get("f.pubkey"))
+
+		switch getExportFilterUint8(urlArgs, "f.type") {
+		case 1: // withdrawals
+			minAmount := uint64(1)
+			withdrawalRequestFilter.Filter.MinAmount = &minAmount
+		case 2: // exits
+			maxAmount := uint64(0)
+			withdrawalRequestFilter.Filter.MaxAmount = &maxAmount
+		}
+	}
+
+	export := startTableExport(w, r, "withdrawals", []string{
+		"slot", "slot_root", "orphaned", "source_address", "validator_index", "validator_name", "validator_pubkey", "amount",
+		"result", "tx_hash", "tx_block_number", "tx_orphaned",
+	})
+	if export == nil {
+		return
+	}
+	defer export.finish(r)
+
+	for offset := uint64(0); ; offset += exportBatchSize {
+		requests, _, _ := services.GlobalBeaconService.GetWithdrawalRequestsByFilter(withdrawalRequestFilter, offset, exportBatchSize)
+		for _, request := range requests {
+			var slot *uint64
+			var slotRoot, txHash []byte
+			var orphaned, txOrphaned bool
+			var result uint8
+			var txBlockNumber *uint64
+			if request.Request != nil {
+				slot = &request.Request.SlotNumber
+				slotRoot = request.Request.SlotRoot
+				orphaned = request.RequestOrphaned
+				result = request.Request.Result
+				txHash = request.Request.TxHash
+			}
+			if request.Transaction != nil {
+				txHash = request.Transaction.TxHash
+				txBlockNumber = &request.Transaction.BlockNumber
+				txOrphaned = request.TransactionOrphaned
+			}
+
+			validatorName := ""
+			validatorIndex := request.ValidatorIndex()
+			if validatorIndex != nil {
+				validatorName = services.GlobalBeaconService.GetValidatorName(*validatorIndex)
+			}
+
+			if !export.writeRow(
+				slot, slotRoot, orphaned, request.SourceAddress(), validatorIndex, validatorName, request.ValidatorPubkey(), request.Amount(),
+				result, txHash, txBlockNumber, txOrphaned,
+			) {
+				return
+			}
+		}
+
+		if !export.flush() || len(requests) < exportBatchSize {
+			return
+		}
+	}
+}
+
+// VoluntaryExitsExport streams the filtered voluntary exits as csv or json (filters of the "/validators/voluntary_exits" page)
+func VoluntaryExitsExport(w http.ResponseWriter, r *http.Request) {
+	urlArgs := r.URL.Query()
+	voluntaryExitFilter := &dbtypes.VoluntaryExitFilter{
+		WithOrphaned: 1,
+	}
+	if urlArgs.Has("f") {
+		voluntaryExitFilter.MinSlot = getExportFilterUint64(urlArgs, "f.mins")
+		voluntaryExitFilter.MaxSlot = getExportFilterUint64(urlArgs, "f.maxs")
+		voluntaryExitFilter.MinIndex = getExportFilterUint64(urlArgs, "f.mini")
+		voluntaryExitFilter.MaxIndex = getExportFilterUint64(urlArgs, "f.maxi")
+		voluntaryExitFilter.ValidatorName = getExportFilterName(urlArgs, "f.vname")
+		voluntaryExitFilter.WithOrphaned = getExportFilterUint8(urlArgs, "f.orphaned")
+	}
+
+	export := startTableExport(w, r, "voluntary_exits", []string{
+		"slot", "time", "slot_root", "orphaned", "validator_index", "validator_name",
+	})
+	if export == nil {
+		return
+	}
+	defer export.finish(r)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	for pageIdx := uint64(0); ; pageIdx++ {
+		voluntaryExits, totalCount := services.GlobalBeaconService.GetVoluntaryExitsByFilter(voluntaryExitFilter, pageIdx, exportBatchSize)
+		for _, voluntaryExit := range voluntaryExits {
+			if !export.writeRow(
+				voluntaryExit.SlotNumber, chainState.SlotToTime(phase0.Slot(voluntaryExit.SlotNumber)), voluntaryExit.SlotRoot, voluntaryExit.Orphaned,
+				voluntaryExit.ValidatorIndex, services.GlobalBeaconService.GetValidatorName(voluntaryExit.ValidatorIndex),
+			) {
+				return
+			}
+		}
+
+		if !export.flush() || len(voluntaryExits) < exportBatchSize || (pageIdx+1)*exportBatchSize >= totalCount {
+			return
+		}
+	}
+}
+
+// SlashingsExport streams the filtered slashings as csv or json (filters of the "/validators/slashings" page)
+func SlashingsExport(w http.ResponseWriter, r *http.Request) {
+	urlArgs := r.URL.Query()
+	slashingFilter := &dbtypes.SlashingFilter{
+		WithOrphaned: 1,
+	}
+	if urlArgs.Has("f") {
+		slashingFilter.MinSlot = getExportFilterUint64(urlArgs, "f.mins")
+		slashingFilter.MaxSlot = getExportFilterUint64(urlArgs, "f.maxs")
+		slashingFilter.MinIndex = getExportFilterUint64(urlArgs, "f.mini")
+		slashingFilter.MaxIndex = getExportFilterUint64(urlArgs, "f.maxi")
+		slashingFilter.ValidatorName = getExportFilterName(urlArgs, "f.vname")
+		slashingFilter.SlasherName = getExportFilterName(urlArgs, "f.sname")
+		slashingFilter.WithReason = dbtypes.SlashingReason(getExportFilterUint8(urlArgs, "f.reason"))
+		slashingFilter.WithOrphaned = getExportFilterUint8(urlArgs, "f.orphaned")
+	}
+
+	export := startTableExport(w, r, "slashings", []string{
+		"slot", "time", "slot_root", "orphaned", "reason", "validator_index", "validator_name", "slasher_index", "slasher_name",
+	})
+	if export == nil {
+		return
+	}
+	defer export.finish(r)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	for pageIdx := uint64(0); ; pageIdx++ {
+		slashings, totalCount := services.GlobalBeaconService.GetSlashingsByFilter(slashingFilter, pageIdx, exportBatchSize)
+		for _, slashing := range slashings {
+			reason := "unknown"
+			switch slashing.Reason {
+			case dbtypes.ProposerSlashing:
+				reason = "proposer"
+			case dbtypes.AttesterSlashing:
+				reason = "attester"
+			}
+
+			if !export.writeRow(
+				slashing.SlotNumber, chainState.SlotToTime(phase0.Slot(slashing.SlotNumber)), slashing.SlotRoot, slashing.Orphaned, reason,
+				slashing.ValidatorIndex, services.GlobalBeaconService.GetValidatorName(slashing.ValidatorIndex),
+				slashing.SlasherIndex, services.GlobalBeaconService.GetValidatorName(slashing.SlasherIndex),
+			) {
+				return
+			}
+		}
+
+		if !export.flush() || len(slashings) < exportBatchSize || (pageIdx+1)*exportBatchSize >= totalCount {
+			return
+		}
+	}
+}
+
+func getExportFilterUint64(urlArgs url.Values, name string) uint64 {
+	value, _ := strconv.ParseUint(urlArgs.Get(name), 10, 64)
+	return value
+}
+
+// getExportFilterName returns a validator name filter, name filters are ignored if validator names are redacted
+func getExportFilterName(urlArgs url.Values, name string) string {
+	if !services.IsValidatorNameSearchAllowed() {
+		return ""
+	}
+	return urlArgs.Get(name)
+}
+
+func getExportFilterUint8(urlArgs url.Values, name string) uint8 {
+	value, _ := strconv.ParseUint(urlArgs.Get(name), 10, 8)
+	return uint8(value)
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// exportEthdoOfflinePreparationVersion is the version of the ethdo offline preparation file format
+const exportEthdoOfflinePreparationVersion = "3"
+
+// signing domain types used by ethdo for offline exits & withdrawal credential changes
+var (
+	exportDomainVoluntaryExit        = phase0.DomainType{0x04, 0x00, 0x00, 0x00}
+	exportDomainBlsToExecutionChange = phase0.DomainType{0x0a, 0x00, 0x00, 0x00}
+)
+
+// exportBeaconApiValidator is a validator in the format of the beacon api validators endpoint.
+// the entity is an explorer extension, it's ignored by tools parsing the standard format.
+type exportBeaconApiValidator struct {
+	Index     string                          `json:"index"`
+	Balance   string                          `json:"balance"`
+	Status    string                          `json:"status"`
+	Validator exportBeaconApiValidatorDetails `json:"validator"`
+	Entity    string                          `json:"entity,omitempty"`
+}
+
+type exportBeaconApiValidatorDetails struct {
+	Pubkey                     string `json:"pubkey"`
+	WithdrawalCredentials      string `json:"withdrawal_credentials"`
+	EffectiveBalance           string `json:"effective_balance"`
+	Slashed                    bool   `json:"slashed"`
+	ActivationEligibilityEpoch string `json:"activation_eligibility_epoch"`
+	ActivationEpoch            string `json:"activation_epoch"`
+	ExitEpoch                  string `json:"exit_epoch"`
+	WithdrawableEpoch          string `json:"withdrawable_epoch"`
+}
+
+// exportEthdoValidator is a validator in the format of the ethdo offline preparation file
+type exportEthdoValidator struct {
+	Index                 string `json:"index"`
+	Pubkey                string `json:"pubkey"`
+	State                 string `json:"state"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+}
+
+// ValidatorsToolingExport streams the filtered validator set in the formats of common staking tools (filters & sorting of the "/validators" page):
+// - beaconapi: validator list of the beacon api (/eth/v1/beacon/states/{state_id}/validators), as consumed by eth2-val-tools & ethdo
+// - ethdo: ethdo offline preparation file (offline-preparation.json) for offline exits & withdrawal credential changes
+// - pubkeys: plain list of validator pubkeys, one per line (for dirk / ethdo account matchers & eth2-val-tools key lists)
+func ValidatorsToolingExport(w http.ResponseWriter, r *http.Request) {
+	const validatorBatchSize = 50000
+
+	if err := services.GlobalCallRateLimiter.CheckCallLimit(r, 10); err != nil {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+
+	format := mux.Vars(r)["format"]
+	var fileName, header, footer string
+	switch format {
+	case "beaconapi":
+		w.Header().Set("Content-Type", "application/json")
+		fileName = "validators.json"
+		header = `{"execution_optimistic":false,"finalized":false,"data":[`
+		footer = "\n]}\n"
+	case "ethdo":
+		chainInfo, err := getExportEthdoChainInfo()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fileName = "offline-preparation.json"
+		header = strings.TrimSuffix(chainInfo, "}") + `,"validators":[`
+		footer = "\n]}\n"
+	case "pubkeys":
+		w.Header().Set("Content-Type", "text/plain")
+		fileName = "pubkeys.txt"
+	default:
+		http.Error(w, fmt.Sprintf("unsupported export format: %v", format), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%v-%v\"", time.Now().Format("20060102-150405"), fileName))
+
+	flusher, _ := w.(http.Flusher)
+	validatorFilter := getExportValidatorFilter(r.URL.Query(), validatorBatchSize)
+	rowCount := uint64(0)
+
+	_, err := w.Write([]byte(header))
+	for err == nil {
+		validators, totalCount := services.GlobalBeaconService.GetFilteredValidatorSet(validatorFilter, true)
+
+		var batch strings.Builder
+		for _, validator := range validators {
+			if validator.Validator == nil {
+				continue
+			}
+
+			var row []byte
+			switch format {
+			case "beaconapi":
+				row, _ = json.Marshal(&exportBeaconApiValidator{
+					Index:   fmt.Sprintf("%v", validator.Index),
+					Balance: fmt.Sprintf("%v", validator.Balance),
+					Status:  validator.Status.String(),
+					Validator: exportBeaconApiValidatorDetails{
+						Pubkey:                     fmt.Sprintf("%#x", validator.Validator.PublicKey),
+						WithdrawalCredentials:      fmt.Sprintf("%#x", validator.Validator.WithdrawalCredentials),
+						EffectiveBalance:           fmt.Sprintf("%v", validator.Validator.EffectiveBalance),
+						Slashed:                    validator.Validator.Slashed,
+						ActivationEligibilityEpoch: fmt.Sprintf("%v", validator.Validator.ActivationEligibilityEpoch),
+						ActivationEpoch:            fmt.Sprintf("%v", validator.Validator.ActivationEpoch),
+						ExitEpoch:                  fmt.Sprintf("%v", validator.Validator.ExitEpoch),
+						WithdrawableEpoch:          fmt.Sprintf("%v", validator.Validator.WithdrawableEpoch),
+					},
+					Entity: services.GlobalBeaconService.GetValidatorName(uint64(validator.Index)),
+				})
+			case "ethdo":
+				row, _ = json.Marshal(&exportEthdoValidator{
+					Index:                 fmt.Sprintf("%v", validator.Index),
+					Pubkey:                fmt.Sprintf("%#x", validator.Validator.PublicKey),
+					State:                 validator.Status.String(),
+					WithdrawalCredentials: fmt.Sprintf("%#x", validator.Validator.WithdrawalCredentials),
+				})
+			case "pubkeys":
+				fmt.Fprintf(&batch, "%#x\n", validator.Validator.PublicKey)
+				rowCount++
+				continue
+			}
+
+			if rowCount > 0 {
+				batch.WriteString(",")
+			}
+			batch.WriteString("\n")
+			batch.Write(row)
+			rowCount++
+		}
+
+		if _, err = w.Write([]byte(batch.String())); err != nil {
+			break
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		validatorFilter.Offset += validatorBatchSize
+		if len(validators) < validatorBatchSize || validatorFilter.Offset >= totalCount {
+			_, err = w.Write([]byte(footer))
+			break
+		}
+	}
+
+	if err != nil {
+		logrus.Warnf("validator export %v aborted after %v rows: %v", r.URL.String(), rowCount, err)
+	}
+}
+
+// getExportEthdoChainInfo returns the chain information of the ethdo offline preparation file as json object
+func getExportEthdoChainInfo() (string, error) {
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	genesis := chainState.GetGenesis()
+	if specs == nil || genesis == nil {
+		return "", fmt.Errorf("chain specs not loaded")
+	}
+
+	currentEpoch := chainState.CurrentEpoch()
+	currentForkVersion := chainState.GetForkVersionAtEpoch(currentEpoch)
+
+	// exits are signed with the capella fork version since deneb (EIP-7044)
+	exitForkVersion := currentForkVersion
+	if specs.DenebForkEpoch != nil && currentEpoch >= phase0.Epoch(*specs.DenebForkEpoch) {
+		exitForkVersion = specs.CapellaForkVersion
+	}
+
+	chainInfo, err := json.Marshal(map[string]string{
+		"version":                             exportEthdoOfflinePreparationVersion,
+		"genesis_validators_root":             fmt.Sprintf("%#x", genesis.GenesisValidatorsRoot),
+		"epoch":                               fmt.Sprintf("%v", currentEpoch),
+		"genesis_fork_version":                fmt.Sprintf("%#x", specs.GenesisForkVersion),
+		"exit_fork_version":                   fmt.Sprintf("%#x", exitForkVersion),
+		"current_fork_version":                fmt.Sprintf("%#x", currentForkVersion),
+		"bls_to_execution_change_domain_type": fmt.Sprintf("%#x", exportDomainBlsToExecutionChange),
+		"voluntary_exit_domain_type":          fmt.Sprintf("%#x", exportDomainVoluntaryExit),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(chainInfo), nil
+}
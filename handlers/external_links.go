@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+)
+
+var externalLinkPlaceholderRE = regexp.MustCompile(`\{([a-zA-Z]+)\}`)
+
+// buildExternalLinks renders the configured external tool links for a page.
+// placeholders in the url templates are substituted with the given values, links referencing unavailable values are skipped.
+func buildExternalLinks(page string, values map[string]string) []*models.ExternalLink {
+	if len(utils.Config.Integrations.Links) == 0 {
+		return nil
+	}
+
+	if _, hasNetwork := values["network"]; !hasNetwork {
+		if specs := services.GlobalBeaconService.GetChainState().GetSpecs(); specs != nil {
+			values["network"] = specs.ConfigName
+		}
+	}
+
+	links := []*models.ExternalLink{}
+	for _, linkConfig := range utils.Config.Integrations.Links {
+		if linkConfig.Page != page {
+			continue
+		}
+
+		isComplete := true
+		linkUrl := externalLinkPlaceholderRE.ReplaceAllStringFunc(linkConfig.Url, func(placeholder string) string {
+			value := values[strings.Trim(placeholder, "{}")]
+			if value == "" {
+				isComplete = false
+			}
+			return url.QueryEscape(value)
+		})
+		if !isComplete {
+			continue
+		}
+
+		links = append(links, &models.ExternalLink{
+			Name: linkConfig.Name,
+			Icon: linkConfig.Icon,
+			Url:  linkUrl,
+		})
+	}
+
+	return links
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// feeRecipientPeriods maps the selectable monitoring periods to their duration (0 = all time)
+var feeRecipientPeriods = map[string]time.Duration{
+	"1d":  24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+	"all": 0,
+}
+
+// FeeRecipients will return the "fee recipients" monitoring page using a go template
+func FeeRecipients(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"fee_recipients/fee_recipients.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/fee_recipients", "Fee Recipients", templateFiles)
+
+	urlArgs := r.URL.Query()
+	period := "7d"
+	mismatchesOnly := false
+	if urlArgs.Has("f") {
+		if urlArgs.Has("f.period") {
+			period = urlArgs.Get("f.period")
+		}
+		mismatchesOnly = urlArgs.Get("f.mismatches") == "1"
+	}
+	if _, ok := feeRecipientPeriods[period]; !ok {
+		period = "7d"
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getFeeRecipientsPageData(period, mismatchesOnly)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "fee_recipients.go", "FeeRecipients", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getFeeRecipientsPageData(period string, mismatchesOnly bool) (*models.FeeRecipientsPageData, error) {
+	pageData := &models.FeeRecipientsPageData{}
+	pageCacheKey := fmt.Sprintf("validators/fee_recipients:%v:%v", period, mismatchesOnly)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildFeeRecipientsPageData(period, mismatchesOnly)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.FeeRecipientsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildFeeRecipientsPageData(period string, mismatchesOnly bool) *models.FeeRecipientsPageData {
+	pageData := &models.FeeRecipientsPageData{
+		FilterPeriod:     period,
+		FilterMismatches: mismatchesOnly,
+		HasExpectations:  services.GlobalBeaconService.HasFeeRecipientExpectations(),
+	}
+	logrus.Debugf("fee recipients page called: %v (mismatches: %v)", period, mismatchesOnly)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	if duration := feeRecipientPeriods[period]; duration > 0 {
+		pageData.PeriodStartSlot = uint64(chainState.TimeToSlot(time.Now().Add(-duration)))
+	}
+	pageData.PeriodStartTime = chainState.SlotToTime(phase0.Slot(pageData.PeriodStartSlot))
+
+	summaries, _ := services.GlobalBeaconService.GetFeeRecipientSummaries(pageData.PeriodStartSlot)
+
+	proposers := map[uint64]bool{}
+	mismatchProposers := map[uint64]bool{}
+	for _, summary := range summaries {
+		pageData.TotalBlocks += summary.Blocks
+		proposers[summary.Validator] = true
+		if summary.Mismatch {
+			pageData.MismatchBlocks += summary.Blocks
+			mismatchProposers[summary.Validator] = true
+		} else if mismatchesOnly {
+			continue
+		}
+
+		entry := &models.FeeRecipientsPageDataEntry{
+			Validator:     summary.Validator,
+			ValidatorName: summary.ValidatorName,
+			FeeRecipient:  summary.FeeRecipient[:],
+			Mismatch:      summary.Mismatch,
+			Blocks:        summary.Blocks,
+			FirstSlot:     summary.FirstSlot,
+			LastSlot:      summary.LastSlot,
+		}
+		if summary.Expected != nil {
+			entry.HasExpected = true
+			entry.Expected = summary.Expected[:]
+		}
+
+		pageData.Recipients = append(pageData.Recipients, entry)
+	}
+	pageData.Proposers = uint64(len(proposers))
+	pageData.MismatchProposers = uint64(len(mismatchProposers))
+	pageData.RecipientCount = uint64(len(pageData.Recipients))
+
+	return pageData
+}
@@ -105,6 +105,11 @@ func buildForksPageData() (*models.ForksPageData, time.Duration) {
 			HeadSlot: uint64(fork.Slot),
 			HeadRoot: fork.Root[:],
 			Clients:  []*models.ForksPageDataClient{},
+			ExternalLinks: buildExternalLinks("fork", map[string]string{
+				"slot":      fmt.Sprintf("%v", fork.Slot),
+				"epoch":     fmt.Sprintf("%v", chainState.EpochOfSlot(fork.Slot)),
+				"blockRoot": fork.Root.String(),
+			}),
 		}
 		pageData.Forks = append(pageData.Forks, forkData)
 
@@ -113,7 +118,7 @@ func buildForksPageData() (*models.ForksPageData, time.Duration) {
 			clientHeadSlot, _ := consensusClient.GetLastHead()
 			forkClient := &models.ForksPageDataClient{
 				Index:       int(client.GetIndex()) + 1,
-				Name:        consensusClient.GetName(),
+				Name:        services.RedactClientName(consensusClient.GetName()),
 				Version:     consensusClient.GetVersion(),
 				Status:      consensusClient.GetStatus().String(),
 				LastRefresh: consensusClient.GetLastEventTime(),
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// GenesisValidators will return the filtered "genesis_validators" page using a go template
+func GenesisValidators(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"genesis_validators/genesis_validators.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/genesis", "Genesis Validators", templateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+		if pageIdx < 1 {
+			pageIdx = 1
+		}
+	}
+
+	var entity string
+	var minIndex uint64
+	var maxIndex uint64
+
+	if urlArgs.Has("f") {
+		if urlArgs.Has("f.entity") {
+			entity = urlArgs.Get("f.entity")
+		}
+		if urlArgs.Has("f.mini") {
+			minIndex, _ = strconv.ParseUint(urlArgs.Get("f.mini"), 10, 64)
+		}
+		if urlArgs.Has("f.maxi") {
+			maxIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxi"), 10, 64)
+		}
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getGenesisValidatorsPageData(pageIdx, pageSize, entity, minIndex, maxIndex)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "genesis_validators.go", "GenesisValidators", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getGenesisValidatorsPageData(pageIdx uint64, pageSize uint64, entity string, minIndex uint64, maxIndex uint64) (*models.GenesisValidatorsPageData, error) {
+	pageData := &models.GenesisValidatorsPageData{}
+	pageCacheKey := fmt.Sprintf("genesis_validators:%v:%v:%v:%v:%v", pageIdx, pageSize, entity, minIndex, maxIndex)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildGenesisValidatorsPageData(pageIdx, pageSize, entity, minIndex, maxIndex)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.GenesisValidatorsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildGenesisValidatorsPageData(pageIdx uint64, pageSize uint64, entity string, minIndex uint64, maxIndex uint64) *models.GenesisValidatorsPageData {
+	filterArgs := url.Values{}
+	if entity != "" {
+		filterArgs.Add("f.entity", entity)
+	}
+	if minIndex != 0 {
+		filterArgs.Add("f.mini", fmt.Sprintf("%v", minIndex))
+	}
+	if maxIndex != 0 {
+		filterArgs.Add("f.maxi", fmt.Sprintf("%v", maxIndex))
+	}
+
+	pageData := &models.GenesisValidatorsPageData{
+		FilterEntity:   entity,
+		FilterMinIndex: minIndex,
+		FilterMaxIndex: maxIndex,
+	}
+	logrus.Debugf("genesis validators page called: %v:%v [%v,%v,%v]", pageIdx, pageSize, entity, minIndex, maxIndex)
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
+
+	genesisState := dbtypes.GenesisValidatorsState{}
+	db.GetExplorerState("indexer.genesisvalidators", &genesisState)
+	pageData.IsLoaded = genesisState.Loaded
+
+	// load entity summary
+	entityStats, _ := db.GetGenesisEntityStats()
+	for _, stats := range entityStats {
+		pageData.TotalValidators += stats.Validators
+		pageData.TotalBalance += stats.Balance
+	}
+	for _, stats := range entityStats {
+		entityData := &models.GenesisValidatorsPageDataEntity{
+			Entity:     stats.Entity,
+			Validators: stats.Validators,
+			Balance:    stats.Balance,
+		}
+		if pageData.TotalBalance > 0 {
+			entityData.Share = float64(stats.Balance) * 100 / float64(pageData.TotalBalance)
+		}
+		pageData.Entities = append(pageData.Entities, entityData)
+	}
+	pageData.EntityCount = uint64(len(pageData.Entities))
+
+	// load genesis validators
+	validatorFilter := &dbtypes.GenesisValidatorFilter{}
+	if entity == "-" {
+		noEntity := ""
+		validatorFilter.Entity = &noEntity
+	} else if entity != "" {
+		validatorFilter.Entity = &entity
+	}
+	if minIndex != 0 {
+		validatorFilter.MinIndex = &minIndex
+	}
+	if maxIndex != 0 {
+		validatorFilter.MaxIndex = &maxIndex
+	}
+
+	dbValidators, totalRows, _ := db.GetGenesisValidatorsFiltered((pageIdx-1)*pageSize, uint32(pageSize), validatorFilter)
+
+	for _, genesisValidator := range dbValidators {
+		validatorData := &models.GenesisValidatorsPageDataValidator{
+			Index:           genesisValidator.ValidatorIndex,
+			Name:            services.GlobalBeaconService.GetValidatorName(genesisValidator.ValidatorIndex),
+			PublicKey:       genesisValidator.Pubkey,
+			WithdrawalCreds: genesisValidator.WithdrawalCredentials,
+			GenesisBalance:  genesisValidator.Balance,
+			Entity:          genesisValidator.Entity,
+		}
+
+		validator := services.GlobalBeaconService.GetValidatorByIndex(phase0.ValidatorIndex(genesisValidator.ValidatorIndex), false)
+		if validator == nil {
+			validatorData.State = "Unknown"
+		} else {
+			validatorData.CurrentBalance = uint64(validator.Balance)
+			validatorData.CredsChanged = !bytes.Equal(validator.Validator.WithdrawalCredentials, genesisValidator.WithdrawalCredentials)
+
+			if strings.HasPrefix(validator.Status.String(), "pending") {
+				validatorData.State = "Pending"
+			} else if validator.Status == v1.ValidatorStateActiveOngoing {
+				validatorData.State = "Active"
+			} else if validator.Status == v1.ValidatorStateActiveExiting {
+				validatorData.State = "Exiting"
+			} else if validator.Status == v1.ValidatorStateActiveSlashed || validator.Status == v1.ValidatorStateExitedSlashed {
+				validatorData.State = "Slashed"
+			} else if validator.Status == v1.ValidatorStateExitedUnslashed {
+				validatorData.State = "Exited"
+			} else {
+				validatorData.State = validator.Status.String()
+			}
+		}
+
+		pageData.Validators = append(pageData.Validators, validatorData)
+	}
+	pageData.ValidatorCount = uint64(len(pageData.Validators))
+
+	if pageData.ValidatorCount > 0 {
+		pageData.FirstIndex = pageData.Validators[0].Index
+		pageData.LastIndex = pageData.Validators[pageData.ValidatorCount-1].Index
+	}
+
+	finalizePagingData(&pageData.PagingData, totalRows, fmt.Sprintf("/validators/genesis?f&%v", filterArgs.Encode()))
+
+	return pageData
+}
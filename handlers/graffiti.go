@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// graffitiPeriods maps the selectable leaderboard periods to their duration (0 = all time)
+var graffitiPeriods = map[string]time.Duration{
+	"1d":  24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+	"all": 0,
+}
+
+// Graffiti will return the "graffiti" leaderboard page using a go template
+func Graffiti(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"graffiti/graffiti.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/graffiti", "Graffiti Leaderboard", templateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+		if pageIdx < 1 {
+			pageIdx = 1
+		}
+	}
+
+	period := "7d"
+	var search string
+
+	if urlArgs.Has("f") {
+		if urlArgs.Has("f.period") {
+			period = urlArgs.Get("f.period")
+		}
+		if urlArgs.Has("f.search") {
+			search = urlArgs.Get("f.search")
+		}
+	}
+	if _, ok := graffitiPeriods[period]; !ok {
+		period = "7d"
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getGraffitiPageData(pageIdx, pageSize, period, search)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "graffiti.go", "Graffiti", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getGraffitiPageData(pageIdx uint64, pageSize uint64, period string, search string) (*models.GraffitiPageData, error) {
+	pageData := &models.GraffitiPageData{}
+	pageCacheKey := fmt.Sprintf("graffiti:%v:%v:%v:%v", pageIdx, pageSize, period, search)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildGraffitiPageData(pageIdx, pageSize, period, search)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.GraffitiPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildGraffitiPageData(pageIdx uint64, pageSize uint64, period string, search string) *models.GraffitiPageData {
+	filterArgs := url.Values{}
+	filterArgs.Add("f.period", period)
+	if search != "" {
+		filterArgs.Add("f.search", search)
+	}
+
+	pageData := &models.GraffitiPageData{
+		FilterPeriod: period,
+		FilterSearch: search,
+	}
+	logrus.Debugf("graffiti page called: %v:%v [%v,%v]", pageIdx, pageSize, period, search)
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	if duration := graffitiPeriods[period]; duration > 0 {
+		pageData.PeriodStartSlot = uint64(chainState.TimeToSlot(time.Now().Add(-duration)))
+	}
+	pageData.PeriodStartTime = chainState.SlotToTime(phase0.Slot(pageData.PeriodStartSlot))
+
+	// only finalized blocks are aggregated, the leaderboard lags behind the chain head by the unfinalized epochs
+	graffitiStats, totalGraffitis, totalBlocks, _ := db.GetGraffitiStats(pageData.PeriodStartSlot, search, (pageIdx-1)*pageSize, uint32(pageSize))
+	pageData.TotalGraffitis = totalGraffitis
+	pageData.TotalBlocks = totalBlocks
+
+	for idx, stats := range graffitiStats {
+		graffitiData := &models.GraffitiPageDataGraffiti{
+			Rank:      (pageIdx-1)*pageSize + uint64(idx) + 1,
+			Graffiti:  stats.GraffitiText,
+			Blocks:    stats.Blocks,
+			Proposers: stats.Proposers,
+			LastSlot:  stats.LastSlot,
+			LastTime:  chainState.SlotToTime(phase0.Slot(stats.LastSlot)),
+		}
+		if totalBlocks > 0 {
+			graffitiData.Share = float64(stats.Blocks) * 100 / float64(totalBlocks)
+		}
+
+		pageData.Graffitis = append(pageData.Graffitis, graffitiData)
+	}
+	pageData.GraffitiCount = uint64(len(pageData.Graffitis))
+
+	if pageData.GraffitiCount > 0 {
+		pageData.FirstRank = pageData.Graffitis[0].Rank
+		pageData.LastRank = pageData.Graffitis[pageData.GraffitiCount-1].Rank
+	}
+
+	finalizePagingData(&pageData.PagingData, totalGraffitis, fmt.Sprintf("/graffiti?f&%v", filterArgs.Encode()))
+
+	return pageData
+}
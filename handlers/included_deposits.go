@@ -10,6 +10,7 @@ import (
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/services"
 	"github.com/ethpandaops/dora/templates"
@@ -39,6 +40,7 @@ func IncludedDeposits(w http.ResponseWriter, r *http.Request) {
 			pageIdx = 1
 		}
 	}
+	cursor := parseListCursor(urlArgs.Get("k"))
 
 	var minIndex uint64
 	var maxIndex uint64
@@ -58,7 +60,7 @@ func IncludedDeposits(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.pubkey") {
 			publickey = urlArgs.Get("f.pubkey")
 		}
-		if urlArgs.Has("f.vname") {
+		if urlArgs.Has("f.vname") && services.IsValidatorNameSearchAllowed() {
 			vname = urlArgs.Get("f.vname")
 		}
 		if urlArgs.Has("f.mina") {
@@ -76,7 +78,7 @@ func IncludedDeposits(w http.ResponseWriter, r *http.Request) {
 	var pageError error
 	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
 	if pageError == nil {
-		data.Data, pageError = getFilteredIncludedDepositsPageData(pageIdx, pageSize, minIndex, maxIndex, publickey, vname, minAmount, maxAmount, uint8(withOrphaned))
+		data.Data, pageError = getFilteredIncludedDepositsPageData(pageIdx, pageSize, cursor, minIndex, maxIndex, publickey, vname, minAmount, maxAmount, uint8(withOrphaned))
 	}
 	if pageError != nil {
 		handlePageError(w, r, pageError)
@@ -88,11 +90,11 @@ func IncludedDeposits(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getFilteredIncludedDepositsPageData(pageIdx uint64, pageSize uint64, minIndex uint64, maxIndex uint64, publickey string, vname string, minAmount uint64, maxAmount uint64, withOrphaned uint8) (*models.IncludedDepositsPageData, error) {
+func getFilteredIncludedDepositsPageData(pageIdx uint64, pageSize uint64, cursor *dbtypes.ListCursor, minIndex uint64, maxIndex uint64, publickey string, vname string, minAmount uint64, maxAmount uint64, withOrphaned uint8) (*models.IncludedDepositsPageData, error) {
 	pageData := &models.IncludedDepositsPageData{}
-	pageCacheKey := fmt.Sprintf("included_deposits:%v:%v:%v:%v:%v:%v:%v:%v:%v", pageIdx, pageSize, minIndex, maxIndex, publickey, vname, minAmount, maxAmount, withOrphaned)
+	pageCacheKey := fmt.Sprintf("included_deposits:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v", pageIdx, pageSize, getListCursorKey(cursor), minIndex, maxIndex, publickey, vname, minAmount, maxAmount, withOrphaned)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
-		return buildFilteredIncludedDepositsPageData(pageIdx, pageSize, minIndex, maxIndex, publickey, vname, minAmount, maxAmount, withOrphaned)
+		return buildFilteredIncludedDepositsPageData(pageIdx, pageSize, cursor, minIndex, maxIndex, publickey, vname, minAmount, maxAmount, withOrphaned)
 	})
 	if pageErr == nil && pageRes != nil {
 		resData, resOk := pageRes.(*models.IncludedDepositsPageData)
@@ -104,7 +106,7 @@ func getFilteredIncludedDepositsPageData(pageIdx uint64, pageSize uint64, minInd
 	return pageData, pageErr
 }
 
-func buildFilteredIncludedDepositsPageData(pageIdx uint64, pageSize uint64, minIndex uint64, maxIndex uint64, publickey string, vname string, minAmount uint64, maxAmount uint64, withOrphaned uint8) *models.IncludedDepositsPageData {
+func buildFilteredIncludedDepositsPageData(pageIdx uint64, pageSize uint64, cursor *dbtypes.ListCursor, minIndex uint64, maxIndex uint64, publickey string, vname string, minAmount uint64, maxAmount uint64, withOrphaned uint8) *models.IncludedDepositsPageData {
 	filterArgs := url.Values{}
 	if minIndex != 0 {
 		filterArgs.Add("f.mini", fmt.Sprintf("%v", minIndex))
@@ -138,19 +140,7 @@ func buildFilteredIncludedDepositsPageData(pageIdx uint64, pageSize uint64, minI
 		FilterWithOrphaned:  withOrphaned,
 	}
 	logrus.Debugf("included_deposits page called: %v:%v [%v,%v,%v,%v,%v,%v]", pageIdx, pageSize, minIndex, maxIndex, publickey, vname, minAmount, maxAmount)
-	if pageIdx == 1 {
-		pageData.IsDefaultPage = true
-	}
-
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	pageData.PageSize = pageSize
-	pageData.TotalPages = pageIdx
-	pageData.CurrentPageIndex = pageIdx
-	if pageIdx > 1 {
-		pageData.PrevPageIndex = pageIdx - 1
-	}
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
 
 	// load included deposits
 	depositFilter := &dbtypes.DepositFilter{
@@ -161,12 +151,18 @@ func buildFilteredIncludedDepositsPageData(pageIdx uint64, pageSize uint64, minI
 		MinAmount:     minAmount,
 		MaxAmount:     maxAmount,
 		WithOrphaned:  withOrphaned,
+		Cursor:        cursor,
 	}
 
 	dbDeposits, totalRows := services.GlobalBeaconService.GetIncludedDepositsByFilter(depositFilter, pageIdx-1, uint32(pageSize))
 
 	chainState := services.GlobalBeaconService.GetChainState()
 
+	depositKeys := make([]uint64, len(dbDeposits))
+	for idx, deposit := range dbDeposits {
+		depositKeys[idx] = deposit.SlotNumber
+	}
+
 	for _, deposit := range dbDeposits {
 		depositData := &models.IncludedDepositsPageDataDeposit{
 			PublicKey:             deposit.PublicKey,
@@ -222,19 +218,9 @@ func buildFilteredIncludedDepositsPageData(pageIdx uint64, pageSize uint64, minI
 		pageData.LastIndex = pageData.Deposits[pageData.DepositCount-1].Index
 	}
 
-	pageData.TotalPages = totalRows / pageSize
-	if totalRows%pageSize > 0 {
-		pageData.TotalPages++
-	}
-	pageData.LastPageIndex = pageData.TotalPages
-	if pageIdx < pageData.TotalPages {
-		pageData.NextPageIndex = pageIdx + 1
-	}
-
-	pageData.FirstPageLink = fmt.Sprintf("/validators/included_deposits?f&%v&c=%v", filterArgs.Encode(), pageData.PageSize)
-	pageData.PrevPageLink = fmt.Sprintf("/validators/included_deposits?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.PrevPageIndex)
-	pageData.NextPageLink = fmt.Sprintf("/validators/included_deposits?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.NextPageIndex)
-	pageData.LastPageLink = fmt.Sprintf("/validators/included_deposits?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.LastPageIndex)
+	baseLink := fmt.Sprintf("/validators/included_deposits?f&%v", filterArgs.Encode())
+	finalizePagingData(&pageData.PagingData, totalRows, baseLink)
+	setNextPageCursor(&pageData.PagingData, baseLink, db.GetNextListCursor(cursor, depositKeys))
 
 	return pageData
 }
@@ -221,6 +221,14 @@ func buildIndexPageData() (*models.IndexPageData, time.Duration) {
 			Active:  uint64(currentEpoch) >= *specs.Eip7594ForkEpoch,
 		})
 	}
+	if specs.FuluForkEpoch != nil && *specs.FuluForkEpoch < uint64(18446744073709551615) {
+		pageData.NetworkForks = append(pageData.NetworkForks, &models.IndexPageDataForks{
+			Name:    "Fulu",
+			Epoch:   *specs.FuluForkEpoch,
+			Version: specs.FuluForkVersion[:],
+			Active:  uint64(currentEpoch) >= *specs.FuluForkEpoch,
+		})
+	}
 
 	// load recent epochs
 	buildIndexPageRecentEpochsData(pageData, currentEpoch, finalizedEpoch, justifiedEpoch, recentEpochCount)
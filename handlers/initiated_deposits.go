@@ -41,6 +41,7 @@ func InitiatedDeposits(w http.ResponseWriter, r *http.Request) {
 			pageIdx = 1
 		}
 	}
+	cursor := parseListCursor(urlArgs.Get("k"))
 
 	var address string
 	var publickey string
@@ -49,6 +50,7 @@ func InitiatedDeposits(w http.ResponseWriter, r *http.Request) {
 	var maxAmount uint64
 	var withOrphaned uint64
 	var withValid uint64
+	var withProblems uint64 = 1
 
 	if urlArgs.Has("f") {
 		if urlArgs.Has("f.address") {
@@ -57,7 +59,7 @@ func InitiatedDeposits(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.pubkey") {
 			publickey = urlArgs.Get("f.pubkey")
 		}
-		if urlArgs.Has("f.vname") {
+		if urlArgs.Has("f.vname") && services.IsValidatorNameSearchAllowed() {
 			vname = urlArgs.Get("f.vname")
 		}
 		if urlArgs.Has("f.mina") {
@@ -72,6 +74,9 @@ func InitiatedDeposits(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.valid") {
 			withValid, _ = strconv.ParseUint(urlArgs.Get("f.valid"), 10, 64)
 		}
+		if urlArgs.Has("f.problems") {
+			withProblems, _ = strconv.ParseUint(urlArgs.Get("f.problems"), 10, 64)
+		}
 	} else {
 		withOrphaned = 1
 		withValid = 1
@@ -79,7 +84,7 @@ func InitiatedDeposits(w http.ResponseWriter, r *http.Request) {
 	var pageError error
 	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
 	if pageError == nil {
-		data.Data, pageError = getFilteredInitiatedDepositsPageData(pageIdx, pageSize, address, publickey, vname, minAmount, maxAmount, uint8(withOrphaned), uint8(withValid))
+		data.Data, pageError = getFilteredInitiatedDepositsPageData(pageIdx, pageSize, cursor, address, publickey, vname, minAmount, maxAmount, uint8(withOrphaned), uint8(withValid), uint8(withProblems))
 	}
 	if pageError != nil {
 		handlePageError(w, r, pageError)
@@ -91,11 +96,11 @@ func InitiatedDeposits(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, address string, publickey string, vname string, minAmount uint64, maxAmount uint64, withOrphaned uint8, withValid uint8) (*models.InitiatedDepositsPageData, error) {
+func getFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, cursor *dbtypes.ListCursor, address string, publickey string, vname string, minAmount uint64, maxAmount uint64, withOrphaned uint8, withValid uint8, withProblems uint8) (*models.InitiatedDepositsPageData, error) {
 	pageData := &models.InitiatedDepositsPageData{}
-	pageCacheKey := fmt.Sprintf("initiated_deposits:%v:%v:%v:%v:%v:%v:%v:%v:%v", pageIdx, pageSize, address, publickey, vname, minAmount, maxAmount, withOrphaned, withValid)
+	pageCacheKey := fmt.Sprintf("initiated_deposits:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v", pageIdx, pageSize, getListCursorKey(cursor), address, publickey, vname, minAmount, maxAmount, withOrphaned, withValid, withProblems)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
-		return buildFilteredInitiatedDepositsPageData(pageIdx, pageSize, address, publickey, vname, minAmount, maxAmount, withOrphaned, withValid)
+		return buildFilteredInitiatedDepositsPageData(pageIdx, pageSize, cursor, address, publickey, vname, minAmount, maxAmount, withOrphaned, withValid, withProblems)
 	})
 	if pageErr == nil && pageRes != nil {
 		resData, resOk := pageRes.(*models.InitiatedDepositsPageData)
@@ -107,7 +112,7 @@ func getFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, addre
 	return pageData, pageErr
 }
 
-func buildFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, address string, publickey string, vname string, minAmount uint64, maxAmount uint64, withOrphaned uint8, withValid uint8) *models.InitiatedDepositsPageData {
+func buildFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, cursor *dbtypes.ListCursor, address string, publickey string, vname string, minAmount uint64, maxAmount uint64, withOrphaned uint8, withValid uint8, withProblems uint8) *models.InitiatedDepositsPageData {
 	filterArgs := url.Values{}
 	if address != "" {
 		filterArgs.Add("f.address", address)
@@ -130,6 +135,9 @@ func buildFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, add
 	if withValid != 0 {
 		filterArgs.Add("f.valid", fmt.Sprintf("%v", withValid))
 	}
+	if withProblems != 1 {
+		filterArgs.Add("f.problems", fmt.Sprintf("%v", withProblems))
+	}
 
 	pageData := &models.InitiatedDepositsPageData{
 		FilterAddress:       address,
@@ -139,21 +147,10 @@ func buildFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, add
 		FilterMaxAmount:     maxAmount,
 		FilterWithOrphaned:  withOrphaned,
 		FilterWithValid:     withValid,
+		FilterWithProblems:  withProblems,
 	}
 	logrus.Debugf("initiated_deposits page called: %v:%v [%v,%v,%v,%v,%v]", pageIdx, pageSize, address, publickey, vname, minAmount, maxAmount)
-	if pageIdx == 1 {
-		pageData.IsDefaultPage = true
-	}
-
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	pageData.PageSize = pageSize
-	pageData.TotalPages = pageIdx
-	pageData.CurrentPageIndex = pageIdx
-	if pageIdx > 1 {
-		pageData.PrevPageIndex = pageIdx - 1
-	}
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
 
 	// load initiated deposits
 	depositFilter := &dbtypes.DepositTxFilter{
@@ -164,9 +161,14 @@ func buildFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, add
 		MaxAmount:     maxAmount,
 		WithOrphaned:  withOrphaned,
 		WithValid:     withValid,
+		WithProblems:  withProblems,
+		Cursor:        cursor,
 	}
 
 	offset := (pageIdx - 1) * pageSize
+	if cursor != nil {
+		offset = 0
+	}
 	depositSyncState := dbtypes.DepositIndexerState{}
 	db.GetExplorerState("indexer.depositstate", &depositSyncState)
 
@@ -175,10 +177,15 @@ func buildFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, add
 		panic(err)
 	}
 
+	depositKeys := make([]uint64, len(dbDepositTxs))
+	for idx, depositTx := range dbDepositTxs {
+		depositKeys[idx] = depositTx.Index
+	}
+
 	for _, depositTx := range dbDepositTxs {
 		depositTxData := &models.InitiatedDepositsPageDataDeposit{
 			Index:                 depositTx.Index,
-			Address:               depositTx.TxSender,
+			Address:               services.RedactDepositSender(depositTx.TxSender),
 			PublicKey:             depositTx.PublicKey,
 			Withdrawalcredentials: depositTx.WithdrawalCredentials,
 			Amount:                depositTx.Amount,
@@ -190,6 +197,13 @@ func buildFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, add
 			ValidatorStatus:       "",
 		}
 
+		if depositTx.ProblemFlags&dbtypes.DepositTxProblemCredentialsMismatch != 0 {
+			depositTxData.Problems = append(depositTxData.Problems, "Pubkey already deposited with different withdrawal credentials")
+		}
+		if depositTx.ProblemFlags&dbtypes.DepositTxProblemInvalidSignature != 0 {
+			depositTxData.Problems = append(depositTxData.Problems, "Invalid signature, deposit will be ignored")
+		}
+
 		if validatorIdx, found := services.GlobalBeaconService.GetValidatorIndexByPubkey(phase0.BLSPubKey(depositTx.PublicKey)); !found {
 			depositTxData.ValidatorStatus = "Deposited"
 		} else {
@@ -228,19 +242,9 @@ func buildFilteredInitiatedDepositsPageData(pageIdx uint64, pageSize uint64, add
 		pageData.LastIndex = pageData.Deposits[pageData.DepositCount-1].Index
 	}
 
-	pageData.TotalPages = totalRows / pageSize
-	if totalRows%pageSize > 0 {
-		pageData.TotalPages++
-	}
-	pageData.LastPageIndex = pageData.TotalPages
-	if pageIdx < pageData.TotalPages {
-		pageData.NextPageIndex = pageIdx + 1
-	}
-
-	pageData.FirstPageLink = fmt.Sprintf("/validators/initiated_deposits?f&%v&c=%v", filterArgs.Encode(), pageData.PageSize)
-	pageData.PrevPageLink = fmt.Sprintf("/validators/initiated_deposits?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.PrevPageIndex)
-	pageData.NextPageLink = fmt.Sprintf("/validators/initiated_deposits?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.NextPageIndex)
-	pageData.LastPageLink = fmt.Sprintf("/validators/initiated_deposits?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.LastPageIndex)
+	baseLink := fmt.Sprintf("/validators/initiated_deposits?f&%v", filterArgs.Encode())
+	finalizePagingData(&pageData.PagingData, totalRows, baseLink)
+	setNextPageCursor(&pageData.PagingData, baseLink, db.GetNextListCursor(cursor, depositKeys))
 
 	return pageData
 }
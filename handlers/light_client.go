@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// lightClientPagePeriods is the number of sync committee periods shown per page
+const lightClientPagePeriods = 8
+
+// LightClient will return the "light client updates" page using a go template
+func LightClient(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"light_client/light_client.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/lightclient", "Light Client Updates", templateFiles)
+
+	urlArgs := r.URL.Query()
+	var period *uint64
+	if urlArgs.Has("period") {
+		periodArg, err := strconv.ParseUint(urlArgs.Get("period"), 10, 64)
+		if err == nil {
+			period = &periodArg
+		}
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		data.Data, pageError = getLightClientPageData(period)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "light_client.go", "LightClient", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getLightClientPageData(period *uint64) (*models.LightClientPageData, error) {
+	pageData := &models.LightClientPageData{}
+	pageCacheKey := "lightclient"
+	if period != nil {
+		pageCacheKey = fmt.Sprintf("lightclient:%v", *period)
+	}
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildLightClientPageData(period)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.LightClientPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildLightClientPageData(period *uint64) *models.LightClientPageData {
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	logrus.Debugf("light client page called: %v", period)
+
+	pageData := &models.LightClientPageData{
+		Enabled:           utils.Config.LightClient.Enabled,
+		SyncCommitteeSize: specs.SyncCommitteeSize,
+	}
+	if specs.EpochsPerSyncCommitteePeriod == 0 {
+		return pageData
+	}
+
+	pageData.CurrentPeriod = uint64(chainState.CurrentEpoch()) / specs.EpochsPerSyncCommitteePeriod
+	pageData.LastPeriod = pageData.CurrentPeriod
+	if period != nil && *period < pageData.LastPeriod {
+		pageData.LastPeriod = *period
+	}
+	if pageData.LastPeriod >= lightClientPagePeriods {
+		pageData.FirstPeriod = pageData.LastPeriod - lightClientPagePeriods + 1
+	}
+
+	pageData.HasPrev = pageData.FirstPeriod > 0
+	pageData.PrevPeriod = pageData.FirstPeriod - 1
+	pageData.HasNext = pageData.LastPeriod < pageData.CurrentPeriod
+	pageData.NextPeriod = pageData.LastPeriod + lightClientPagePeriods
+
+	updates, _ := db.GetLightClientUpdates(pageData.FirstPeriod, pageData.LastPeriod, false)
+	headUpdates, _ := db.GetLightClientHeadUpdates(pageData.FirstPeriod, pageData.LastPeriod, false)
+
+	periodMap := map[uint64]*models.LightClientPageDataPeriod{}
+	getPeriod := func(period uint64) *models.LightClientPageDataPeriod {
+		if pagePeriod := periodMap[period]; pagePeriod != nil {
+			return pagePeriod
+		}
+
+		firstEpoch := period * specs.EpochsPerSyncCommitteePeriod
+		pagePeriod := &models.LightClientPageDataPeriod{
+			Period:     period,
+			FirstEpoch: firstEpoch,
+			LastEpoch:  firstEpoch + specs.EpochsPerSyncCommitteePeriod - 1,
+			StartTime:  chainState.EpochToTime(phase0.Epoch(firstEpoch)),
+			IsCurrent:  period == pageData.CurrentPeriod,
+			Clients:    []*models.LightClientPageDataClient{},
+		}
+		periodMap[period] = pagePeriod
+		return pagePeriod
+	}
+	getClient := func(pagePeriod *models.LightClientPageDataPeriod, clientName string) *models.LightClientPageDataClient {
+		name := services.RedactClientName(clientName)
+		for _, client := range pagePeriod.Clients {
+			if client.Name == name {
+				return client
+			}
+		}
+
+		client := &models.LightClientPageDataClient{
+			Name: name,
+		}
+		pagePeriod.Clients = append(pagePeriod.Clients, client)
+		return client
+	}
+
+	for _, update := range updates {
+		pagePeriod := getPeriod(update.Period)
+		getClient(pagePeriod, update.ClientName).Update = &models.LightClientPageDataUpdate{
+			Version:           update.Version,
+			AttestedSlot:      update.AttestedSlot,
+			AttestedRoot:      update.AttestedRoot,
+			FinalizedSlot:     update.FinalizedSlot,
+			FinalizedRoot:     update.FinalizedRoot,
+			SignatureSlot:     update.SignatureSlot,
+			Participation:     update.Participation,
+			NextCommitteeRoot: update.NextCommitteeRoot,
+			Status:            uint8(update.Status),
+			Result:            update.Result,
+			UpdatedAt:         time.Unix(int64(update.UpdatedAt), 0),
+		}
+	}
+
+	for _, headUpdate := range headUpdates {
+		pageUpdate := &models.LightClientPageDataUpdate{
+			Version:       headUpdate.Version,
+			AttestedSlot:  headUpdate.AttestedSlot,
+			AttestedRoot:  headUpdate.AttestedRoot,
+			FinalizedSlot: headUpdate.FinalizedSlot,
+			FinalizedRoot: headUpdate.FinalizedRoot,
+			SignatureSlot: headUpdate.SignatureSlot,
+			Participation: headUpdate.Participation,
+			Status:        uint8(headUpdate.Status),
+			Result:        headUpdate.Result,
+			UpdatedAt:     time.Unix(int64(headUpdate.UpdatedAt), 0),
+		}
+
+		client := getClient(getPeriod(headUpdate.Period), headUpdate.ClientName)
+		switch headUpdate.UpdateType {
+		case dbtypes.LightClientFinalityUpdate:
+			client.Finality = pageUpdate
+		case dbtypes.LightClientOptimisticUpdate:
+			client.Optimistic = pageUpdate
+		}
+	}
+
+	for p := pageData.LastPeriod + 1; p > pageData.FirstPeriod; p-- {
+		pagePeriod := periodMap[p-1]
+		if pagePeriod == nil {
+			continue
+		}
+
+		var nextCommitteeRoot []byte
+		for _, client := range pagePeriod.Clients {
+			for _, update := range []*models.LightClientPageDataUpdate{client.Update, client.Finality, client.Optimistic} {
+				if update != nil && update.Status == uint8(dbtypes.LightClientUpdateInvalid) {
+					pagePeriod.Invalid = true
+				}
+			}
+
+			if client.Update == nil {
+				continue
+			}
+			if nextCommitteeRoot == nil {
+				nextCommitteeRoot = client.Update.NextCommitteeRoot
+			} else if !bytes.Equal(nextCommitteeRoot, client.Update.NextCommitteeRoot) {
+				pagePeriod.Diverging = true
+			}
+		}
+
+		if pagePeriod.Invalid {
+			pageData.InvalidCount++
+		}
+		pageData.Periods = append(pageData.Periods, pagePeriod)
+	}
+
+	return pageData
+}
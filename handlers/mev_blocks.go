@@ -61,7 +61,7 @@ func MevBlocks(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.maxi") {
 			maxIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxi"), 10, 64)
 		}
-		if urlArgs.Has("f.vname") {
+		if urlArgs.Has("f.vname") && services.IsValidatorNameSearchAllowed() {
 			vname = urlArgs.Get("f.vname")
 		}
 		if urlArgs.Has("f.relays") {
@@ -179,19 +179,7 @@ func buildFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uin
 	}
 
 	logrus.Debugf("mev_blocks page called: %v:%v [%v,%v,%v,%v,%v]", pageIdx, pageSize, minSlot, maxSlot, minIndex, maxIndex, vname)
-	if pageIdx == 1 {
-		pageData.IsDefaultPage = true
-	}
-
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	pageData.PageSize = pageSize
-	pageData.TotalPages = pageIdx
-	pageData.CurrentPageIndex = pageIdx
-	if pageIdx > 1 {
-		pageData.PrevPageIndex = pageIdx - 1
-	}
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
 
 	// load mev blocks
 	mevBlockFilter := &dbtypes.MevBlockFilter{
@@ -249,19 +237,7 @@ func buildFilteredMevBlocksPageData(pageIdx uint64, pageSize uint64, minSlot uin
 		pageData.LastIndex = pageData.MevBlocks[pageData.BlockCount-1].SlotNumber
 	}
 
-	pageData.TotalPages = totalRows / pageSize
-	if totalRows%pageSize > 0 {
-		pageData.TotalPages++
-	}
-	pageData.LastPageIndex = pageData.TotalPages
-	if pageIdx < pageData.TotalPages {
-		pageData.NextPageIndex = pageIdx + 1
-	}
-
-	pageData.FirstPageLink = fmt.Sprintf("/mev/blocks?f&%v&c=%v", filterArgs.Encode(), pageData.PageSize)
-	pageData.PrevPageLink = fmt.Sprintf("/mev/blocks?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.PrevPageIndex)
-	pageData.NextPageLink = fmt.Sprintf("/mev/blocks?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.NextPageIndex)
-	pageData.LastPageLink = fmt.Sprintf("/mev/blocks?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.LastPageIndex)
+	finalizePagingData(&pageData.PagingData, totalRows, fmt.Sprintf("/mev/blocks?f&%v", filterArgs.Encode()))
 
 	return pageData
 }
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// MissedSlots will return the filtered "missed slots" analysis page using a go template
+func MissedSlots(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"missed_slots/missed_slots.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/slots/missed", "Missed Slots", templateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+		if pageIdx < 1 {
+			pageIdx = 1
+		}
+	}
+
+	var minSlot uint64
+	var maxSlot uint64
+	var proposer string
+	var withReason uint64
+
+	if urlArgs.Has("f") {
+		if urlArgs.Has("f.mins") {
+			minSlot, _ = strconv.ParseUint(urlArgs.Get("f.mins"), 10, 64)
+		}
+		if urlArgs.Has("f.maxs") {
+			maxSlot, _ = strconv.ParseUint(urlArgs.Get("f.maxs"), 10, 64)
+		}
+		if urlArgs.Has("f.proposer") {
+			proposer = urlArgs.Get("f.proposer")
+		}
+		if urlArgs.Has("f.reason") {
+			withReason, _ = strconv.ParseUint(urlArgs.Get("f.reason"), 10, 64)
+		}
+	}
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getMissedSlotsPageData(pageIdx, pageSize, minSlot, maxSlot, proposer, uint8(withReason))
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "missed_slots.go", "MissedSlots", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getMissedSlotsPageData(pageIdx uint64, pageSize uint64, minSlot uint64, maxSlot uint64, proposer string, withReason uint8) (*models.MissedSlotsPageData, error) {
+	pageData := &models.MissedSlotsPageData{}
+	pageCacheKey := fmt.Sprintf("missed_slots:%v:%v:%v:%v:%v:%v", pageIdx, pageSize, minSlot, maxSlot, proposer, withReason)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildMissedSlotsPageData(pageIdx, pageSize, minSlot, maxSlot, proposer, withReason)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.MissedSlotsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildMissedSlotsPageData(pageIdx uint64, pageSize uint64, minSlot uint64, maxSlot uint64, proposer string, withReason uint8) *models.MissedSlotsPageData {
+	filterArgs := url.Values{}
+	if minSlot != 0 {
+		filterArgs.Add("f.mins", fmt.Sprintf("%v", minSlot))
+	}
+	if maxSlot != 0 {
+		filterArgs.Add("f.maxs", fmt.Sprintf("%v", maxSlot))
+	}
+	if proposer != "" {
+		filterArgs.Add("f.proposer", proposer)
+	}
+	if withReason != 0 {
+		filterArgs.Add("f.reason", fmt.Sprintf("%v", withReason))
+	}
+
+	pageData := &models.MissedSlotsPageData{
+		FilterMinSlot:    minSlot,
+		FilterMaxSlot:    maxSlot,
+		FilterProposer:   proposer,
+		FilterWithReason: withReason,
+	}
+	logrus.Debugf("missed slots page called: %v:%v [%v,%v,%v,%v]", pageIdx, pageSize, minSlot, maxSlot, proposer, withReason)
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
+
+	// load missed slots
+	missedSlotFilter := &dbtypes.MissedSlotFilter{
+		MinSlot:    minSlot,
+		MaxSlot:    maxSlot,
+		WithReason: dbtypes.MissedSlotReason(withReason),
+	}
+	if proposerIndex, err := strconv.ParseUint(proposer, 10, 64); err == nil {
+		missedSlotFilter.Proposer = &proposerIndex
+	}
+
+	reasonCounts, _ := db.GetMissedSlotReasonCounts(missedSlotFilter)
+	for _, reasonCount := range reasonCounts {
+		switch reasonCount.Reason {
+		case dbtypes.MissedSlotReasonOffline:
+			pageData.OfflineCount = reasonCount.Count
+		case dbtypes.MissedSlotReasonReorged:
+			pageData.ReorgedCount = reasonCount.Count
+		case dbtypes.MissedSlotReasonLate:
+			pageData.LateCount = reasonCount.Count
+		}
+		pageData.TotalCount += reasonCount.Count
+	}
+
+	dbMissedSlots, totalRows, _ := db.GetMissedSlotsFiltered((pageIdx-1)*pageSize, uint32(pageSize), missedSlotFilter)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+
+	for _, missedSlot := range dbMissedSlots {
+		missedSlotData := &models.MissedSlotsPageDataSlot{
+			Slot:         missedSlot.Slot,
+			Time:         chainState.SlotToTime(phase0.Slot(missedSlot.Slot)),
+			Proposer:     missedSlot.Proposer,
+			ProposerName: services.GlobalBeaconService.GetValidatorName(missedSlot.Proposer),
+			Reason:       uint8(missedSlot.Reason),
+			BlockRoot:    missedSlot.BlockRoot,
+			SeenBy:       missedSlot.SeenBy,
+		}
+		if missedSlot.SeenDelay != nil {
+			missedSlotData.HasSeenDelay = true
+			missedSlotData.SeenDelay = *missedSlot.SeenDelay
+		}
+
+		pageData.MissedSlots = append(pageData.MissedSlots, missedSlotData)
+	}
+	pageData.MissedSlotCount = uint64(len(pageData.MissedSlots))
+
+	if pageData.MissedSlotCount > 0 {
+		pageData.FirstIndex = pageData.MissedSlots[0].Slot
+		pageData.LastIndex = pageData.MissedSlots[pageData.MissedSlotCount-1].Slot
+	}
+
+	finalizePagingData(&pageData.PagingData, totalRows, fmt.Sprintf("/slots/missed?f&%v", filterArgs.Encode()))
+
+	return pageData
+}
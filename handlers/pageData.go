@@ -52,9 +52,11 @@ func InitPageData(w http.ResponseWriter, r *http.Request, active, path, title st
 		ExplorerSubtitle: utils.Config.Frontend.SiteSubtitle,
 		ExplorerLogo:     utils.Config.Frontend.SiteLogo,
 		Lang:             "en-US",
+		Locale:           utils.GetFrontendFormatter().Locale(),
 		Debug:            utils.Config.Frontend.Debug,
 		MainMenuItems:    createMenuItems(active),
 	}
+	data.LocaleTimeStrings = utils.GetFrontendFormatter().TimeStrings()
 
 	chainState := services.GlobalBeaconService.GetChainState()
 	if specs := chainState.GetSpecs(); specs != nil {
@@ -119,8 +121,29 @@ func createMenuItems(active string) []types.MainMenuItem {
 				Path:  "/slots",
 				Icon:  "fa-cube",
 			},
+			{
+				Label: "Missed Slots",
+				Path:  "/slots/missed",
+				Icon:  "fa-circle-xmark",
+			},
+			{
+				Label: "Graffiti",
+				Path:  "/graffiti",
+				Icon:  "fa-signature",
+			},
 		},
 	})
+	if len(utils.Config.Indexer.BlockTags) > 0 {
+		blockchainMenu = append(blockchainMenu, types.NavigationGroup{
+			Links: []types.NavigationLink{
+				{
+					Label: "Block Tags",
+					Path:  "/slots/tags",
+					Icon:  "fa-tags",
+				},
+			},
+		})
+	}
 	if len(utils.Config.MevIndexer.Relays) > 0 {
 		blockchainMenu = append(blockchainMenu, types.NavigationGroup{
 			Links: []types.NavigationLink{
@@ -133,6 +156,86 @@ func createMenuItems(active string) []types.MainMenuItem {
 		})
 	}
 
+	if utils.Config.Rollups.Enabled {
+		blockchainMenu = append(blockchainMenu, types.NavigationGroup{
+			Links: []types.NavigationLink{
+				{
+					Label: "Rollup Blob Usage",
+					Path:  "/blobs/rollups",
+					Icon:  "fa-layer-group",
+				},
+			},
+		})
+	}
+
+	if utils.Config.BlobAvailability.Enabled {
+		blockchainMenu = append(blockchainMenu, types.NavigationGroup{
+			Links: []types.NavigationLink{
+				{
+					Label: "Blob Availability",
+					Path:  "/blobs/availability",
+					Icon:  "fa-border-all",
+				},
+			},
+		})
+	}
+
+	if utils.Config.DataColumns.Enabled {
+		blockchainMenu = append(blockchainMenu, types.NavigationGroup{
+			Links: []types.NavigationLink{
+				{
+					Label: "Data Column Availability",
+					Path:  "/blobs/columns",
+					Icon:  "fa-table-cells",
+				},
+			},
+		})
+	}
+
+	if utils.Config.LightClient.Enabled {
+		blockchainMenu = append(blockchainMenu, types.NavigationGroup{
+			Links: []types.NavigationLink{
+				{
+					Label: "Light Client Updates",
+					Path:  "/lightclient",
+					Icon:  "fa-feather",
+				},
+			},
+		})
+	}
+
+	blockchainMenu = append(blockchainMenu, types.NavigationGroup{
+		Links: []types.NavigationLink{
+			{
+				Label: "Validate Object",
+				Path:  "/tools/validate_object",
+				Icon:  "fa-stethoscope",
+			},
+		},
+	})
+
+	customPageLinks := []types.NavigationLink{}
+	for _, customPage := range utils.Config.CustomPages {
+		if !customPage.ShowInMenu {
+			continue
+		}
+
+		icon := customPage.Icon
+		if icon == "" {
+			icon = "fa-table"
+		}
+		customPageLinks = append(customPageLinks, types.NavigationLink{
+			Label: customPage.Title,
+			Path:  "/custom/" + customPage.Name,
+			Icon:  icon,
+		})
+	}
+	if len(customPageLinks) > 0 {
+		blockchainMenu = append(blockchainMenu, types.NavigationGroup{
+			Links: customPageLinks,
+		})
+	}
+
 	clientLinks := []types.NavigationLink{
 		{
 			Label: "Consensus",
@@ -149,6 +252,18 @@ func createMenuItems(active string) []types.MainMenuItem {
 		})
 	}
 
+	clientLinks = append(clientLinks, types.NavigationLink{
+		Label: "Client Diversity",
+		Path:  "/clients/diversity",
+		Icon:  "fa-chart-pie",
+	})
+
+	clientLinks = append(clientLinks, types.NavigationLink{
+		Label: "Block Propagation",
+		Path:  "/clients/propagation",
+		Icon:  "fa-tower-broadcast",
+	})
+
 	clientLinks = append(clientLinks, types.NavigationLink{
 		Label: "Forks",
 		Path:  "/forks",
@@ -159,6 +274,18 @@ func createMenuItems(active string) []types.MainMenuItem {
 		Links: clientLinks,
 	})
 
+	if utils.Config.Integrations.Assertoor.Enabled {
+		clientsMenu = append(clientsMenu, types.NavigationGroup{
+			Links: []types.NavigationLink{
+				{
+					Label: "Assertoor Tests",
+					Path:  "/assertoor",
+					Icon:  "fa-vial",
+				},
+			},
+		})
+	}
+
 	validatorMenu = append(validatorMenu, types.NavigationGroup{
 		Links: []types.NavigationLink{
 			{
@@ -171,8 +298,42 @@ func createMenuItems(active string) []types.MainMenuItem {
 				Path:  "/validators/activity",
 				Icon:  "fa-tachometer",
 			},
+			{
+				Label: "Validator Watchlist",
+				Path:  "/validators/watchlist",
+				Icon:  "fa-eye",
+			},
+			{
+				Label: "Genesis Validators",
+				Path:  "/validators/genesis",
+				Icon:  "fa-seedling",
+			},
+			{
+				Label: "Validator Queues",
+				Path:  "/validators/queues",
+				Icon:  "fa-hourglass-half",
+			},
 		},
 	})
+	if services.IsValidatorNameSearchAllowed() {
+		entityLinks := []types.NavigationLink{
+			{
+				Label: "Entities",
+				Path:  "/entities",
+				Icon:  "fa-building",
+			},
+		}
+		if services.IsDepositSenderSearchAllowed() {
+			entityLinks = append(entityLinks, types.NavigationLink{
+				Label: "Staking Entities",
+				Path:  "/validators/staking_entities",
+				Icon:  "fa-piggy-bank",
+			})
+		}
+		validatorMenu = append(validatorMenu, types.NavigationGroup{
+			Links: entityLinks,
+		})
+	}
 	validatorMenu = append(validatorMenu, types.NavigationGroup{
 		Links: []types.NavigationLink{
 			{
@@ -192,6 +353,17 @@ func createMenuItems(active string) []types.MainMenuItem {
 			},
 		},
 	})
+	if services.GlobalBeaconService.HasFeeRecipientExpectations() {
+		validatorMenu = append(validatorMenu, types.NavigationGroup{
+			Links: []types.NavigationLink{
+				{
+					Label: "Fee Recipients",
+					Path:  "/validators/fee_recipients",
+					Icon:  "fa-hand-holding-dollar",
+				},
+			},
+		})
+	}
 
 	chainState := services.GlobalBeaconService.GetChainState()
 	specs := chainState.GetSpecs()
@@ -208,6 +380,16 @@ func createMenuItems(active string) []types.MainMenuItem {
 					Path:  "/validators/el_consolidations",
 					Icon:  "fa-square-plus",
 				},
+				{
+					Label: "Request Fees",
+					Path:  "/validators/request_fees",
+					Icon:  "fa-coins",
+				},
+				{
+					Label: "Effective Balances",
+					Path:  "/validators/effective_balances",
+					Icon:  "fa-scale-balanced",
+				},
 			},
 		})
 	}
@@ -234,6 +416,14 @@ func createMenuItems(active string) []types.MainMenuItem {
 		})
 	}
 
+	if utils.Config.Frontend.ShowSubmitBroadcast {
+		submitLinks = append(submitLinks, types.NavigationLink{
+			Label: "Broadcast Operations",
+			Path:  "/validators/broadcast",
+			Icon:  "fa-tower-broadcast",
+		})
+	}
+
 	if len(submitLinks) > 0 {
 		validatorMenu = append(validatorMenu, types.NavigationGroup{
 			Links: submitLinks,
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/gorilla/mux"
+)
+
+// PageMetricsMiddleware tracks the request timings of the frontend pages.
+// the time to the first response byte is attributed to data building, the remaining time to template rendering.
+// api routes & static files (prefix routes) are not tracked.
+func PageMetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := mux.CurrentRoute(r)
+		if route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		pathTemplate, err1 := route.GetPathTemplate()
+		pathRegexp, err2 := route.GetPathRegexp()
+		if err1 != nil || err2 != nil || strings.HasPrefix(pathTemplate, "/api/") || !strings.HasSuffix(pathRegexp, "$") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		metricsWriter := &pageMetricsWriter{
+			ResponseWriter: w,
+			status:         http.StatusOK,
+		}
+		startTime := time.Now()
+
+		next.ServeHTTP(metricsWriter, r)
+
+		endTime := time.Now()
+		if metricsWriter.firstWrite.IsZero() {
+			metricsWriter.firstWrite = endTime
+		}
+
+		services.GlobalPageMetrics.TrackRequest(pathTemplate, metricsWriter.status, metricsWriter.firstWrite.Sub(startTime), endTime.Sub(metricsWriter.firstWrite), metricsWriter.bytes)
+	})
+}
+
+// pageMetricsWriter is a response writer that tracks the status code, the response size & the time of the first write.
+type pageMetricsWriter struct {
+	http.ResponseWriter
+	firstWrite  time.Time
+	status      int
+	wroteHeader bool
+	bytes       uint64
+}
+
+func (w *pageMetricsWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *pageMetricsWriter) Write(data []byte) (int, error) {
+	if w.firstWrite.IsZero() {
+		w.firstWrite = time.Now()
+	}
+	w.wroteHeader = true
+
+	n, err := w.ResponseWriter.Write(data)
+	w.bytes += uint64(n)
+	return n, err
+}
+
+// Flush forwards flushes to the underlying writer, as used by the streamed exports
+func (w *pageMetricsWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
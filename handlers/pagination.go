@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types/models"
+)
+
+// initPagingData initializes the paging state of a list page for the requested page (1 based).
+// returns the effective page size, which is capped at 100 & defaults to 50.
+func initPagingData(paging *models.PagingData, pageIdx uint64, pageSize uint64) uint64 {
+	return initPagingDataWithMaxSize(paging, pageIdx, pageSize, 100)
+}
+
+// initPagingDataWithMaxSize initializes the paging state of a list page that allows page sizes up to maxPageSize.
+func initPagingDataWithMaxSize(paging *models.PagingData, pageIdx uint64, pageSize uint64, maxPageSize uint64) uint64 {
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	} else if pageSize == 0 {
+		pageSize = 50
+	}
+	if pageIdx == 0 {
+		pageIdx = 1
+	}
+
+	paging.IsDefaultPage = pageIdx == 1
+	paging.PageSize = pageSize
+	paging.TotalPages = pageIdx
+	paging.CurrentPageIndex = pageIdx
+	if pageIdx > 1 {
+		paging.PrevPageIndex = pageIdx - 1
+	}
+
+	return pageSize
+}
+
+// finalizePagingData sets the page count & navigation links of a list page based on the total number of rows.
+// baseLink is the page url including the filter arguments, the page size & index arguments are appended to it.
+func finalizePagingData(paging *models.PagingData, totalRows uint64, baseLink string) {
+	paging.TotalPages = totalRows / paging.PageSize
+	if totalRows%paging.PageSize > 0 {
+		paging.TotalPages++
+	}
+	paging.LastPageIndex = paging.TotalPages
+	if paging.CurrentPageIndex < paging.TotalPages {
+		paging.NextPageIndex = paging.CurrentPageIndex + 1
+	}
+
+	paging.FirstPageLink = fmt.Sprintf("%v&c=%v", baseLink, paging.PageSize)
+	paging.PrevPageLink = fmt.Sprintf("%v&c=%v&p=%v", baseLink, paging.PageSize, paging.PrevPageIndex)
+	paging.NextPageLink = fmt.Sprintf("%v&c=%v&p=%v", baseLink, paging.PageSize, paging.NextPageIndex)
+	paging.LastPageLink = fmt.Sprintf("%v&c=%v&p=%v", baseLink, paging.PageSize, paging.LastPageIndex)
+}
+
+// finalizePagingDataHasMore sets the navigation links of a list page without a known total number of rows.
+// the next page is linked if hasMore is set, the last page link stays unset.
+func finalizePagingDataHasMore(paging *models.PagingData, hasMore bool, baseLink string) {
+	paging.TotalPages = paging.CurrentPageIndex
+	if hasMore {
+		paging.NextPageIndex = paging.CurrentPageIndex + 1
+		paging.TotalPages++
+	}
+
+	paging.FirstPageLink = fmt.Sprintf("%v&c=%v", baseLink, paging.PageSize)
+	paging.PrevPageLink = fmt.Sprintf("%v&c=%v&p=%v", baseLink, paging.PageSize, paging.PrevPageIndex)
+	paging.NextPageLink = fmt.Sprintf("%v&c=%v&p=%v", baseLink, paging.PageSize, paging.NextPageIndex)
+}
+
+// initAnchoredPagingData initializes the paging state & navigation links of a descending list page that is addressed by
+// its first (highest) position instead of a page index, so page links stay stable while new slots / epochs are added.
+// posArg & sizeArg are the url arguments of the position & page size, firstPos is capped at maxPos.
+// returns the effective page size & first position.
+func initAnchoredPagingData(paging *models.PagingData, firstPos uint64, maxPos uint64, pageSize uint64, baseLink string, posArg string, sizeArg string) (uint64, uint64) {
+	if pageSize > 100 {
+		pageSize = 100
+	} else if pageSize == 0 {
+		pageSize = 50
+	}
+	if firstPos > maxPos {
+		paging.IsDefaultPage = true
+		firstPos = maxPos
+	}
+
+	pagesBefore := (firstPos + 1) / pageSize
+	if ((firstPos + 1) % pageSize) > 0 {
+		pagesBefore++
+	}
+	pagesAfter := (maxPos - firstPos) / pageSize
+	if ((maxPos - firstPos) % pageSize) > 0 {
+		pagesAfter++
+	}
+
+	paging.PageSize = pageSize
+	paging.TotalPages = pagesBefore + pagesAfter
+	paging.CurrentPageIndex = pagesAfter + 1
+	paging.PrevPageIndex = paging.CurrentPageIndex - 1
+	if firstPos >= pageSize {
+		paging.NextPageIndex = paging.CurrentPageIndex + 1
+	}
+	paging.LastPageIndex = paging.TotalPages
+
+	paging.FirstPageLink = fmt.Sprintf("%v?%v=%v", baseLink, sizeArg, pageSize)
+	paging.PrevPageLink = fmt.Sprintf("%v?%v=%v&%v=%v", baseLink, posArg, firstPos+pageSize, sizeArg, pageSize)
+	if paging.NextPageIndex > 0 {
+		paging.NextPageLink = fmt.Sprintf("%v?%v=%v&%v=%v", baseLink, posArg, firstPos-pageSize, sizeArg, pageSize)
+	}
+	paging.LastPageLink = fmt.Sprintf("%v?%v=%v&%v=%v", baseLink, posArg, pageSize-1, sizeArg, pageSize)
+
+	return pageSize, firstPos
+}
+
+// parseListCursor parses the keyset cursor argument (`k=<key>-<skip>`) of a list page.
+// returns nil if the argument is not set or invalid, the page is loaded by page index then.
+func parseListCursor(cursorArg string) *dbtypes.ListCursor {
+	if cursorArg == "" {
+		return nil
+	}
+
+	cursor := &dbtypes.ListCursor{}
+	if _, err := fmt.Sscanf(cursorArg, "%d-%d", &cursor.Key, &cursor.Skip); err != nil {
+		return nil
+	}
+
+	return cursor
+}
+
+// getListCursorKey returns the cache key part of a keyset cursor
+func getListCursorKey(cursor *dbtypes.ListCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v-%v", cursor.Key, cursor.Skip)
+}
+
+// setNextPageCursor links the next page via the keyset cursor of the last row on the current page,
+// so the next page can be loaded without skipping all previous rows in the database.
+func setNextPageCursor(paging *models.PagingData, baseLink string, cursor *dbtypes.ListCursor) {
+	if cursor == nil || paging.NextPageIndex == 0 {
+		return
+	}
+
+	paging.NextPageLink = fmt.Sprintf("%v&c=%v&p=%v&k=%v", baseLink, paging.PageSize, paging.NextPageIndex, getListCursorKey(cursor))
+}
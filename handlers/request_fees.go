@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// requestFeePeriods maps the selectable fee history periods to their duration (0 = all time) and the duration of a history row
+var requestFeePeriods = map[string]struct {
+	duration time.Duration
+	bucket   time.Duration
+}{
+	"1d":  {24 * time.Hour, 1 * time.Hour},
+	"7d":  {7 * 24 * time.Hour, 8 * time.Hour},
+	"30d": {30 * 24 * time.Hour, 24 * time.Hour},
+	"all": {0, 7 * 24 * time.Hour},
+}
+
+// RequestFees will return the "request fees" page using a go template
+func RequestFees(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"request_fees/request_fees.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/request_fees", "Request Fees", templateFiles)
+
+	urlArgs := r.URL.Query()
+	period := "7d"
+	if urlArgs.Has("f") && urlArgs.Has("f.period") {
+		period = urlArgs.Get("f.period")
+	}
+	if _, ok := requestFeePeriods[period]; !ok {
+		period = "7d"
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getRequestFeesPageData(period)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "request_fees.go", "RequestFees", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getRequestFeesPageData(period string) (*models.RequestFeesPageData, error) {
+	pageData := &models.RequestFeesPageData{}
+	pageCacheKey := fmt.Sprintf("validators/request_fees:%v", period)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildRequestFeesPageData(period)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.RequestFeesPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildRequestFeesPageData(period string) *models.RequestFeesPageData {
+	pageData := &models.RequestFeesPageData{
+		FilterPeriod: period,
+	}
+	logrus.Debugf("request fees page called: %v", period)
+
+	periodConfig := requestFeePeriods[period]
+	minTime := uint64(0)
+	if periodConfig.duration > 0 {
+		pageData.PeriodStartTime = time.Now().Add(-periodConfig.duration)
+		minTime = uint64(pageData.PeriodStartTime.Unix())
+	}
+	pageData.BucketHours = uint64(periodConfig.bucket / time.Hour)
+
+	bucketSize := uint64(periodConfig.bucket / time.Second)
+
+	pageData.Contracts = []*models.RequestFeesPageDataContract{
+		buildRequestFeesContractData("Withdrawal Requests", services.GlobalBeaconService.GetWithdrawalRequestFees(minTime, bucketSize), bucketSize),
+		buildRequestFeesContractData("Consolidation Requests", services.GlobalBeaconService.GetConsolidationRequestFees(minTime, bucketSize), bucketSize),
+	}
+
+	return pageData
+}
+
+func buildRequestFeesContractData(name string, requestFees *services.RequestFees, bucketSize uint64) *models.RequestFeesPageDataContract {
+	contractData := &models.RequestFeesPageDataContract{
+		Name:     name,
+		Contract: requestFees.ContractAddress[:],
+	}
+
+	if requestFees.CurrentFee != nil {
+		contractData.HasCurrentFee = true
+		if requestFees.CurrentFee.IsUint64() {
+			contractData.CurrentFee = requestFees.CurrentFee.Uint64()
+		} else {
+			contractData.CurrentFee = math.MaxUint64
+		}
+	}
+
+	maxAvgFee := uint64(0)
+	for _, stats := range requestFees.History {
+		if stats.AvgFee > maxAvgFee {
+			maxAvgFee = stats.AvgFee
+		}
+	}
+
+	for idx := len(requestFees.History) - 1; idx >= 0; idx-- {
+		stats := requestFees.History[idx]
+		bucketData := &models.RequestFeesPageDataBucket{
+			Time:     time.Unix(int64(stats.Bucket*bucketSize), 0),
+			Requests: stats.Requests,
+			MinFee:   stats.MinFee,
+			MaxFee:   stats.MaxFee,
+			AvgFee:   stats.AvgFee,
+		}
+		if maxAvgFee > 0 {
+			bucketData.AvgFeeShare = float64(stats.AvgFee) * 100 / float64(maxAvgFee)
+		}
+
+		contractData.Requests += stats.Requests
+		contractData.History = append(contractData.History, bucketData)
+	}
+	contractData.HistoryCount = uint64(len(contractData.History))
+
+	return contractData
+}
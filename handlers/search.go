@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
@@ -69,24 +71,45 @@ func Search(w http.ResponseWriter, r *http.Request) {
 				}
 				return
 			}
+
+			// search for execution block hash
+			if blockSlots := db.GetExecutionBlockSlotsByHash(blockHash); len(blockSlots) > 0 {
+				if blockSlots[0].Orphaned {
+					http.Redirect(w, r, fmt.Sprintf("/slot/0x%x", blockSlots[0].Root), http.StatusMovedPermanently)
+				} else {
+					http.Redirect(w, r, fmt.Sprintf("/slot/%v", blockSlots[0].Slot), http.StatusMovedPermanently)
+				}
+				return
+			}
 		}
+	} else if len(hashQuery) == 40 && common.IsHexAddress(hashQuery) {
+		// execution address
+		http.Redirect(w, r, fmt.Sprintf("/address/%v", common.HexToAddress(hashQuery).String()), http.StatusMovedPermanently)
+		return
 	}
 
-	names := &dbtypes.SearchNameResult{}
-	err = db.ReaderDb.Get(names, db.EngineQuery(map[dbtypes.DBEngineType]string{
-		dbtypes.DBEnginePgsql: `
-			SELECT name
-			FROM validator_names
-			WHERE name ILIKE LOWER($1)
-			LIMIT 1`,
-		dbtypes.DBEngineSqlite: `
-			SELECT name
-			FROM validator_names
-			WHERE name LIKE LOWER($1)
-			LIMIT 1`,
-	}), "%"+searchQuery+"%")
-	if err == nil {
-		http.Redirect(w, r, "/slots/filtered?f&f.missing=1&f.orphaned=1&f.pname="+searchQuery, http.StatusMovedPermanently)
+	if services.IsValidatorNameSearchAllowed() {
+		names := &dbtypes.SearchNameResult{}
+		err = db.ReaderDb.Get(names, db.EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql: `
+				SELECT name
+				FROM validator_names
+				WHERE name ILIKE LOWER($1)
+				LIMIT 1`,
+			dbtypes.DBEngineSqlite: `
+				SELECT name
+				FROM validator_names
+				WHERE name LIKE LOWER($1)
+				LIMIT 1`,
+		}), "%"+searchQuery+"%")
+		if err == nil {
+			http.Redirect(w, r, "/slots/filtered?f&f.missing=1&f.orphaned=1&f.pname="+searchQuery, http.StatusMovedPermanently)
+			return
+		}
+	}
+
+	if labels := services.GlobalBeaconService.SearchAddressLabels(searchQuery, 1); len(labels) > 0 {
+		http.Redirect(w, r, fmt.Sprintf("/address/%v", labels[0].Address.String()), http.StatusMovedPermanently)
 		return
 	}
 
@@ -103,7 +126,7 @@ func Search(w http.ResponseWriter, r *http.Request) {
 			WHERE graffiti_text LIKE LOWER($1)
 			LIMIT 1`,
 	}), "%"+searchQuery+"%")
-	if err == nil {
+	if err == nil || len(services.GlobalBeaconService.GetCachedGraffitiCounts(searchQuery)) > 0 {
 		http.Redirect(w, r, "/slots/filtered?f&f.missing=1&f.orphaned=1&f.graffiti="+searchQuery, http.StatusMovedPermanently)
 		return
 	}
@@ -265,30 +288,16 @@ func SearchAhead(w http.ResponseWriter, r *http.Request) {
 					}
 				}
 				result = res
-			} else {
-				dbres := &dbtypes.SearchAheadExecBlocksResult{}
-				err = db.ReaderDb.Select(dbres, `
-				SELECT slot, root, eth_block_hash, eth_block_number, status 
-				FROM slots 
-				WHERE slot < $1 AND eth_block_hash = $2
-				ORDER BY slot LIMIT 10`, minSlotIdx, blockHash)
-				if err != nil {
-					logger.Errorf("error reading block: %v", err)
-					http.Error(w, "Internal server error", http.StatusServiceUnavailable)
-					return
-				}
-				if len(*dbres) > 0 {
-					result = &[]models.SearchAheadExecBlocksResult{
-						{
-							Slot:       fmt.Sprintf("%v", (*dbres)[0].Slot),
-							Root:       phase0.Root((*dbres)[0].Root),
-							ExecHash:   phase0.Hash32((*dbres)[0].ExecHash),
-							ExecNumber: (*dbres)[0].ExecNumber,
-							Orphaned:   (*dbres)[0].Status == dbtypes.Orphaned,
-						},
-					}
+			} else if blockSlots := db.GetExecutionBlockSlotsByHash(blockHash); len(blockSlots) > 0 {
+				result = &[]models.SearchAheadExecBlocksResult{
+					{
+						Slot:       fmt.Sprintf("%v", blockSlots[0].Slot),
+						Root:       phase0.Root(blockSlots[0].Root),
+						ExecHash:   phase0.Hash32(blockSlots[0].BlockHash),
+						ExecNumber: blockSlots[0].BlockNumber,
+						Orphaned:   blockSlots[0].Orphaned,
+					},
 				}
-
 			}
 		} else if blockNumber, convertErr := strconv.ParseUint(search, 10, 32); convertErr == nil {
 			cachedBlocks := indexer.GetBlocksByExecutionBlockNumber(blockNumber)
@@ -307,56 +316,71 @@ func SearchAhead(w http.ResponseWriter, r *http.Request) {
 				}
 				result = res
 			} else {
-				dbres := &dbtypes.SearchAheadExecBlocksResult{}
-				err = db.ReaderDb.Select(dbres, `
-				SELECT slot, root, eth_block_hash, eth_block_number, status 
-				FROM slots 
-				WHERE slot < $1 AND eth_block_number = $2
-				ORDER BY slot LIMIT 10`, minSlotIdx, blockNumber)
-				if err == nil {
-					model := make([]models.SearchAheadExecBlocksResult, len(*dbres))
-					for idx, entry := range *dbres {
-						model[idx] = models.SearchAheadExecBlocksResult{
-							Slot:       fmt.Sprintf("%v", entry.Slot),
-							Root:       phase0.Root(entry.Root),
-							ExecHash:   phase0.Hash32(entry.ExecHash),
-							ExecNumber: entry.ExecNumber,
-							Orphaned:   entry.Status == dbtypes.Orphaned,
-						}
+				blockSlots := db.GetExecutionBlockSlotsByNumber(blockNumber, 10)
+				model := make([]models.SearchAheadExecBlocksResult, len(blockSlots))
+				for idx, entry := range blockSlots {
+					model[idx] = models.SearchAheadExecBlocksResult{
+						Slot:       fmt.Sprintf("%v", entry.Slot),
+						Root:       phase0.Root(entry.Root),
+						ExecHash:   phase0.Hash32(entry.BlockHash),
+						ExecNumber: entry.BlockNumber,
+						Orphaned:   entry.Orphaned,
 					}
-					result = model
 				}
+				result = model
 			}
 		}
 	case "graffiti":
 		graffiti := &dbtypes.SearchAheadGraffitiResult{}
 		err = db.ReaderDb.Select(graffiti, db.EngineQuery(map[dbtypes.DBEngineType]string{
 			dbtypes.DBEnginePgsql: `
-				SELECT graffiti, count(*) as count
+				SELECT graffiti_text as graffiti, count(*) as count
 				FROM slots
 				WHERE graffiti_text ILIKE LOWER($1)
-				GROUP BY graffiti
+				GROUP BY graffiti_text
 				ORDER BY count desc
 				LIMIT 10`,
 			dbtypes.DBEngineSqlite: `
-				SELECT graffiti, count(*) as count
+				SELECT graffiti_text as graffiti, count(*) as count
 				FROM slots
 				WHERE graffiti_text LIKE LOWER($1)
-				GROUP BY graffiti
+				GROUP BY graffiti_text
 				ORDER BY count desc
 				LIMIT 10`,
 		}), "%"+search+"%")
 		if err == nil {
-			model := make([]models.SearchAheadGraffitiResult, len(*graffiti))
-			for i, entry := range *graffiti {
+			// merge with unfinalized blocks from cache
+			graffitiCounts := services.GlobalBeaconService.GetCachedGraffitiCounts(search)
+			for _, entry := range *graffiti {
+				graffitiCounts[entry.Graffiti] += entry.Count
+			}
+
+			graffitiTexts := make([]string, 0, len(graffitiCounts))
+			for graffitiText := range graffitiCounts {
+				graffitiTexts = append(graffitiTexts, graffitiText)
+			}
+			sort.Slice(graffitiTexts, func(a, b int) bool {
+				return graffitiCounts[graffitiTexts[a]] > graffitiCounts[graffitiTexts[b]]
+			})
+			if len(graffitiTexts) > 10 {
+				graffitiTexts = graffitiTexts[:10]
+			}
+
+			model := make([]models.SearchAheadGraffitiResult, len(graffitiTexts))
+			for i, graffitiText := range graffitiTexts {
 				model[i] = models.SearchAheadGraffitiResult{
-					Graffiti: utils.FormatGraffitiString(entry.Graffiti),
-					Count:    fmt.Sprintf("%v", entry.Count),
+					Graffiti: utils.FormatGraffitiString(graffitiText),
+					Count:    fmt.Sprintf("%v", graffitiCounts[graffitiText]),
 				}
 			}
 			result = model
 		}
 	case "valname":
+		if !services.IsValidatorNameSearchAllowed() {
+			result = []models.SearchAheadValidatorNameResult{}
+			break
+		}
+
 		names := &dbtypes.SearchAheadValidatorNameResult{}
 		err = db.ReaderDb.Select(names, db.EngineQuery(map[dbtypes.DBEngineType]string{
 			dbtypes.DBEnginePgsql: `
@@ -386,6 +410,16 @@ func SearchAhead(w http.ResponseWriter, r *http.Request) {
 			}
 			result = model
 		}
+	case "addresses":
+		labels := services.GlobalBeaconService.SearchAddressLabels(search, 10)
+		model := make([]models.SearchAheadAddressLabelResult, len(labels))
+		for i, entry := range labels {
+			model[i] = models.SearchAheadAddressLabelResult{
+				Address: entry.Address.String(),
+				Label:   utils.FormatGraffitiString(entry.Label),
+			}
+		}
+		result = model
 
 	default:
 		http.Error(w, "Not found", 404)
@@ -61,10 +61,10 @@ func Slashings(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.maxi") {
 			maxIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxi"), 10, 64)
 		}
-		if urlArgs.Has("f.vname") {
+		if urlArgs.Has("f.vname") && services.IsValidatorNameSearchAllowed() {
 			vname = urlArgs.Get("f.vname")
 		}
-		if urlArgs.Has("f.sname") {
+		if urlArgs.Has("f.sname") && services.IsValidatorNameSearchAllowed() {
 			sname = urlArgs.Get("f.sname")
 		}
 		if urlArgs.Has("f.reason") {
@@ -145,19 +145,7 @@ func buildFilteredSlashingsPageData(pageIdx uint64, pageSize uint64, minSlot uin
 		FilterWithOrphaned:  withOrphaned,
 	}
 	logrus.Debugf("slashings page called: %v:%v [%v,%v,%v,%v,%v,%v]", pageIdx, pageSize, minSlot, maxSlot, minIndex, maxIndex, vname, sname)
-	if pageIdx == 1 {
-		pageData.IsDefaultPage = true
-	}
-
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	pageData.PageSize = pageSize
-	pageData.TotalPages = pageIdx
-	pageData.CurrentPageIndex = pageIdx
-	if pageIdx > 1 {
-		pageData.PrevPageIndex = pageIdx - 1
-	}
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
 
 	// load slashings
 	slashingFilter := &dbtypes.SlashingFilter{
@@ -229,19 +217,7 @@ func buildFilteredSlashingsPageData(pageIdx uint64, pageSize uint64, minSlot uin
 		pageData.LastIndex = pageData.Slashings[pageData.SlashingCount-1].SlotNumber
 	}
 
-	pageData.TotalPages = totalRows / pageSize
-	if totalRows%pageSize > 0 {
-		pageData.TotalPages++
-	}
-	pageData.LastPageIndex = pageData.TotalPages
-	if pageIdx < pageData.TotalPages {
-		pageData.NextPageIndex = pageIdx + 1
-	}
-
-	pageData.FirstPageLink = fmt.Sprintf("/validators/slashings?f&%v&c=%v", filterArgs.Encode(), pageData.PageSize)
-	pageData.PrevPageLink = fmt.Sprintf("/validators/slashings?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.PrevPageIndex)
-	pageData.NextPageLink = fmt.Sprintf("/validators/slashings?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.NextPageIndex)
-	pageData.LastPageLink = fmt.Sprintf("/validators/slashings?f&%v&c=%v&p=%v", filterArgs.Encode(), pageData.PageSize, pageData.LastPageIndex)
+	finalizePagingData(&pageData.PagingData, totalRows, fmt.Sprintf("/validators/slashings?f&%v", filterArgs.Encode()))
 
 	return pageData
 }
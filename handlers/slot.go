@@ -21,9 +21,12 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/gorilla/mux"
 	"github.com/juliangruber/go-intersect"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/indexer/beacon"
 	"github.com/ethpandaops/dora/services"
 	"github.com/ethpandaops/dora/templates"
@@ -32,6 +35,9 @@ import (
 	"github.com/ethpandaops/dora/utils"
 )
 
+// slotAttestationsPageSize is the number of attestations shown per page on the slot page
+const slotAttestationsPageSize = 20
+
 // Index will return the main "index" page using a go template
 func Slot(w http.ResponseWriter, r *http.Request) {
 	var slotTemplateFiles = append(layoutTemplateFiles,
@@ -83,7 +89,15 @@ func Slot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pageData, pageError := getSlotPageData(blockSlot, blockRootHash)
+	var attestationsPageIdx uint64 = 1
+	if urlArgs.Has("ap") {
+		attestationsPageIdx, _ = strconv.ParseUint(urlArgs.Get("ap"), 10, 64)
+		if attestationsPageIdx < 1 {
+			attestationsPageIdx = 1
+		}
+	}
+
+	pageData, pageError := getSlotPageData(blockSlot, blockRootHash, attestationsPageIdx)
 	if pageError != nil {
 		handlePageError(w, r, pageError)
 		return
@@ -167,11 +181,42 @@ func SlotBlob(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getSlotPageData(blockSlot int64, blockRoot []byte) (*models.SlotPageData, error) {
+// SlotDownloadSSZ will return the raw ssz encoded signed beacon block for the given block root
+func SlotDownloadSSZ(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	blockRoot, err := hex.DecodeString(strings.Replace(vars["root"], "0x", "", -1))
+	if err != nil || len(blockRoot) != 32 {
+		http.Error(w, "Invalid block root", http.StatusBadRequest)
+		return
+	}
+
+	if err := services.GlobalCallRateLimiter.CheckCallLimit(r, 2); err != nil {
+		http.Error(w, "Call rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
+	version, blockSSZ, err := services.GlobalBeaconService.GetBlockSSZByBlockroot(r.Context(), phase0.Root(blockRoot))
+	if err != nil {
+		logrus.WithError(err).Error("error loading block ssz")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+		return
+	}
+	if blockSSZ == nil {
+		http.Error(w, "Block not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Eth-Consensus-Version", version.String())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=block-%x.ssz", blockRoot))
+	w.Write(blockSSZ)
+}
+
+func getSlotPageData(blockSlot int64, blockRoot []byte, attestationsPageIdx uint64) (*models.SlotPageData, error) {
 	pageData := &models.SlotPageData{}
-	pageCacheKey := fmt.Sprintf("slot:%v:%x", blockSlot, blockRoot)
+	pageCacheKey := fmt.Sprintf("slot:%v:%x:%v", blockSlot, blockRoot, attestationsPageIdx)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildSlotPageData(pageCall.CallCtx, blockSlot, blockRoot)
+		pageData, cacheTimeout := buildSlotPageData(pageCall.CallCtx, blockSlot, blockRoot, attestationsPageIdx)
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -185,7 +230,7 @@ func getSlotPageData(blockSlot int64, blockRoot []byte) (*models.SlotPageData, e
 	return pageData, pageErr
 }
 
-func buildSlotPageData(ctx context.Context, blockSlot int64, blockRoot []byte) (*models.SlotPageData, time.Duration) {
+func buildSlotPageData(ctx context.Context, blockSlot int64, blockRoot []byte, attestationsPageIdx uint64) (*models.SlotPageData, time.Duration) {
 	chainState := services.GlobalBeaconService.GetChainState()
 	currentSlot := chainState.CurrentSlot()
 	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
@@ -270,11 +315,12 @@ func buildSlotPageData(ctx context.Context, blockSlot int64, blockRoot []byte) (
 		}
 		pageData.Proposer = uint64(blockData.Header.Message.ProposerIndex)
 		pageData.ProposerName = services.GlobalBeaconService.GetValidatorName(pageData.Proposer)
-		pageData.Block = getSlotPageBlockData(blockData, epochStatsValues)
+		pageData.Block = getSlotPageBlockData(blockData, epochStatsValues, attestationsPageIdx)
 
 		// check mev block
+		var mevBlock *dbtypes.MevBlock
 		if pageData.Block.ExecutionData != nil {
-			mevBlock := db.GetMevBlockByBlockHash(pageData.Block.ExecutionData.BlockHash)
+			mevBlock = db.GetMevBlockByBlockHash(pageData.Block.ExecutionData.BlockHash)
 			if mevBlock != nil {
 				relays := []string{}
 				for _, relay := range utils.Config.MevIndexer.Relays {
@@ -292,18 +338,203 @@ func buildSlotPageData(ctx context.Context, blockSlot int64, blockRoot []byte) (
 				})
 			}
 		}
+
+		// check double proposals
+		for _, equivocation := range db.GetProposerEquivocationsBySlot(pageData.Slot) {
+			if equivocation.Proposer != pageData.Proposer {
+				continue
+			}
+			if bytes.Equal(equivocation.BlockRoot1, pageData.Block.BlockRoot) {
+				pageData.EquivocationRoots = append(pageData.EquivocationRoots, equivocation.BlockRoot2)
+			} else if bytes.Equal(equivocation.BlockRoot2, pageData.Block.BlockRoot) {
+				pageData.EquivocationRoots = append(pageData.EquivocationRoots, equivocation.BlockRoot1)
+			}
+		}
+		if len(pageData.EquivocationRoots) > 0 {
+			pageData.Badges = append(pageData.Badges, &models.SlotPageBlockBadge{
+				Title:       "Equivocation",
+				Icon:        "fa-exclamation-triangle",
+				Description: fmt.Sprintf("The proposer published %v conflicting block(s) for this slot", len(pageData.EquivocationRoots)),
+				ClassName:   "text-bg-danger",
+			})
+		}
+
+		// load execution payload verdicts of the clients (from the block cache for unfinalized blocks)
+		if pageData.Block.ExecutionData != nil {
+			pageData.PayloadStatuses = getSlotPagePayloadStatuses(phase0.Root(pageData.Block.BlockRoot))
+			for _, payloadStatus := range pageData.PayloadStatuses {
+				if payloadStatus.Status == "SYNCING" && cacheTimeout > 30*time.Second {
+					// verdict pending, refresh soon
+					cacheTimeout = 30 * time.Second
+				}
+			}
+			for _, payloadStatus := range pageData.PayloadStatuses {
+				if payloadStatus.Status == "INVALID" {
+					pageData.Badges = append(pageData.Badges, &models.SlotPageBlockBadge{
+						Title:       "Invalid Payload",
+						Icon:        "fa-times-circle",
+						Description: "At least one client dropped this block after its execution engine rejected the payload",
+						ClassName:   "text-bg-danger",
+					})
+					break
+				}
+			}
+		}
+
+		// load proposer reward breakdown
+		if utils.Config.Rewards.Enabled && !blockData.Orphaned {
+			pageData.Rewards = getSlotPageRewards(pageData.Slot, mevBlock)
+		}
+
+		// check blockprint classification
+		if utils.Config.Blockprint.Url != "" {
+			blockprint := db.GetBlockprintSlot(pageData.Slot)
+			if blockprint != nil && blockprint.Client > 0 {
+				pageData.Badges = append(pageData.Badges, &models.SlotPageBlockBadge{
+					Title:       consensus.ClientType(blockprint.Client).String(),
+					Icon:        "fa-fingerprint",
+					Description: fmt.Sprintf("Proposer client classified by blockprint (%.1f%% probability)", blockprint.Probability*100),
+					ClassName:   "text-bg-secondary",
+				})
+			}
+		}
+
+		// check slot fingerprint
+		if utils.Config.Fingerprint.Enabled && !blockData.Orphaned {
+			if dbFingerprint := db.GetSlotFingerprint(pageData.Slot); dbFingerprint != nil {
+				fingerprint := getSlotFingerprintModel(dbFingerprint)
+				if dbFingerprint.ClClient > 0 || dbFingerprint.ElClient > 0 {
+					pageData.Badges = append(pageData.Badges, &models.SlotPageBlockBadge{
+						Title:       fmt.Sprintf("%v / %v", fingerprint.ClClient, fingerprint.ElClient),
+						Icon:        "fa-fingerprint",
+						Description: fmt.Sprintf("Likely proposed by %v (%v%% confidence) with %v (%v%% confidence), signals: %v", fingerprint.ClClient, fingerprint.ClConfidence, fingerprint.ElClient, fingerprint.ElConfidence, fingerprint.Sources),
+						ClassName:   "text-bg-secondary",
+					})
+				}
+				if dbFingerprint.BuilderType == uint8(dbtypes.BlockBuilderExternal) {
+					builderName := fingerprint.BuilderName
+					if builderName == "" {
+						builderName = "External Builder"
+					}
+					pageData.Badges = append(pageData.Badges, &models.SlotPageBlockBadge{
+						Title:       builderName,
+						Icon:        "fa-hammer",
+						Description: fmt.Sprintf("Payload likely built by an external block builder (%v%% confidence)", fingerprint.BuilderConfidence),
+						ClassName:   "text-bg-warning",
+					})
+				}
+			}
+		}
 	}
 
+	// check proposer duty changes caused by reorgs
+	if slot > 0 {
+		for _, reassignment := range db.GetProposerReassignmentsBySlot(uint64(slot)) {
+			pageData.Reassignments = append(pageData.Reassignments, &models.SlotPageReassignment{
+				SwitchSlot:       reassignment.SwitchSlot,
+				OldProposer:      reassignment.OldProposer,
+				OldProposerName:  services.GlobalBeaconService.GetValidatorName(reassignment.OldProposer),
+				NewProposer:      reassignment.NewProposer,
+				NewProposerName:  services.GlobalBeaconService.GetValidatorName(reassignment.NewProposer),
+				OldDependentRoot: reassignment.OldDependentRoot,
+				NewDependentRoot: reassignment.NewDependentRoot,
+			})
+		}
+		if len(pageData.Reassignments) > 0 {
+			pageData.Badges = append(pageData.Badges, &models.SlotPageBlockBadge{
+				Title:       "Reassigned",
+				Icon:        "fa-random",
+				Description: "The expected proposer of this slot changed because a reorg switched the dependent root of the epoch",
+				ClassName:   "text-bg-info",
+			})
+		}
+	}
+
+	// render links to external tools
+	linkValues := map[string]string{
+		"slot":  fmt.Sprintf("%v", pageData.Slot),
+		"epoch": fmt.Sprintf("%v", pageData.Epoch),
+	}
+	if pageData.Block != nil {
+		linkValues["blockRoot"] = fmt.Sprintf("0x%x", pageData.Block.BlockRoot)
+		linkValues["stateRoot"] = fmt.Sprintf("0x%x", pageData.Block.StateRoot)
+		linkValues["parentRoot"] = fmt.Sprintf("0x%x", pageData.Block.ParentRoot)
+	}
+	pageData.ExternalLinks = buildExternalLinks("slot", linkValues)
+
 	return pageData, cacheTimeout
 }
 
-func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsValues *beacon.EpochStatsValues) *models.SlotPageBlockData {
+func getSlotPagePayloadStatuses(blockRoot phase0.Root) []*models.SlotPagePayloadStatus {
+	statuses := []*models.SlotPagePayloadStatus{}
+
+	if block := services.GlobalBeaconService.GetBeaconIndexer().GetBlockByRoot(blockRoot); block != nil {
+		for _, status := range block.GetPayloadStatuses() {
+			statuses = append(statuses, &models.SlotPagePayloadStatus{
+				ClientName: services.RedactClientName(status.Client.GetClient().GetName()),
+				Status:     getPayloadStatusName(status.Status),
+			})
+		}
+	} else {
+		for _, status := range db.GetBlockPayloadStatuses(blockRoot[:]) {
+			statuses = append(statuses, &models.SlotPagePayloadStatus{
+				ClientName: services.RedactClientName(status.ClientName),
+				Status:     getPayloadStatusName(status.Status),
+			})
+		}
+	}
+
+	return statuses
+}
+
+func getPayloadStatusName(status dbtypes.PayloadStatus) string {
+	switch status {
+	case dbtypes.PayloadStatusValid:
+		return "VALID"
+	case dbtypes.PayloadStatusSyncing:
+		return "SYNCING"
+	case dbtypes.PayloadStatusInvalid:
+		return "INVALID"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func getSlotPageRewards(slot uint64, mevBlock *dbtypes.MevBlock) *models.SlotPageRewards {
+	blockRewards := db.GetBlockRewards(slot)
+	if blockRewards == nil {
+		return nil
+	}
+
+	rewards := &models.SlotPageRewards{
+		Consensus:     blockRewards.Total,
+		Attestations:  blockRewards.Attestations,
+		SyncAggregate: blockRewards.SyncAggregate,
+		Slashings:     blockRewards.ProposerSlashings + blockRewards.AttesterSlashings,
+		ElFees:        blockRewards.ElFees,
+		MevBlock:      mevBlock != nil,
+		MevPayment:    blockRewards.MevPayment,
+	}
+	if rewards.MevPayment == 0 && mevBlock != nil {
+		rewards.MevPayment = int64(mevBlock.BlockValueGwei)
+	}
+
+	// the priority fees of relayed blocks go to the builder, which pays the proposer via the mev payment
+	if rewards.MevBlock {
+		rewards.Total = rewards.Consensus + rewards.MevPayment
+	} else {
+		rewards.Total = rewards.Consensus + rewards.ElFees
+	}
+
+	return rewards
+}
+
+func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsValues *beacon.EpochStatsValues, attestationsPageIdx uint64) *models.SlotPageBlockData {
 	chainState := services.GlobalBeaconService.GetChainState()
 	specs := chainState.GetSpecs()
 	graffiti, _ := blockData.Block.Graffiti()
 	randaoReveal, _ := blockData.Block.RandaoReveal()
 	eth1Data, _ := blockData.Block.ETH1Data()
-	attestations, _ := blockData.Block.Attestations()
 	deposits, _ := blockData.Block.Deposits()
 	voluntaryExits, _ := blockData.Block.VoluntaryExits()
 	attesterSlashings, _ := blockData.Block.AttesterSlashings()
@@ -326,134 +557,18 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsV
 		Eth1dataBlockhash:      eth1Data.BlockHash,
 		ProposerSlashingsCount: uint64(len(proposerSlashings)),
 		AttesterSlashingsCount: uint64(len(attesterSlashings)),
-		AttestationsCount:      uint64(len(attestations)),
 		DepositsCount:          uint64(len(deposits)),
 		VoluntaryExitsCount:    uint64(len(voluntaryExits)),
 		SlashingsCount:         uint64(len(proposerSlashings)) + uint64(len(attesterSlashings)),
 	}
 
 	epoch := chainState.EpochOfSlot(blockData.Header.Message.Slot)
-	assignmentsMap := make(map[phase0.Epoch]*beacon.EpochStatsValues)
-	assignmentsLoaded := make(map[phase0.Epoch]bool)
-	assignmentsMap[epoch] = epochStatsValues
-	assignmentsLoaded[epoch] = true
-
-	pageData.Attestations = make([]*models.SlotPageAttestation, pageData.AttestationsCount)
-	for i, attVersioned := range attestations {
-		attData, _ := attVersioned.Data()
-		if attData == nil {
-			continue
-		}
-
-		attSignature, err := attVersioned.Signature()
-		if err != nil {
-			continue
-		}
-
-		attAggregationBits, err := attVersioned.AggregationBits()
-		if err != nil {
-			continue
-		}
-
-		attEpoch := chainState.EpochOfSlot(attData.Slot)
-		if !assignmentsLoaded[attEpoch] { // get epoch duties from cache
-			beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
-			if epochStats := beaconIndexer.GetEpochStats(epoch, nil); epochStats != nil {
-				epochStatsValues := epochStats.GetOrLoadValues(beaconIndexer, true, false)
-
-				assignmentsMap[attEpoch] = epochStatsValues
-				assignmentsLoaded[attEpoch] = true
-			}
-		}
-
-		attPageData := models.SlotPageAttestation{
-			Slot:            uint64(attData.Slot),
-			AggregationBits: attAggregationBits,
-			Signature:       attSignature[:],
-			BeaconBlockRoot: attData.BeaconBlockRoot[:],
-			SourceEpoch:     uint64(attData.Source.Epoch),
-			SourceRoot:      attData.Source.Root[:],
-			TargetEpoch:     uint64(attData.Target.Epoch),
-			TargetRoot:      attData.Target.Root[:],
-		}
-
-		var attAssignments []uint64
-		includedValidators := []uint64{}
-
-		if attVersioned.Version >= spec.DataVersionElectra {
-			// EIP-7549 attestation
-			attAssignments = []uint64{}
-			attPageData.CommitteeIndex = []uint64{}
-
-			committeeBits, err := attVersioned.CommitteeBits()
-			if err != nil {
-				continue
-			}
-
-			attBitsOffset := uint64(0)
-			for _, committee := range committeeBits.BitIndices() {
-				if uint64(committee) >= specs.MaxCommitteesPerSlot {
-					continue
-				}
-
-				attPageData.CommitteeIndex = append(attPageData.CommitteeIndex, uint64(committee))
-				if assignmentsMap[attEpoch] != nil {
-					slotIndex := int(chainState.SlotToSlotIndex(attData.Slot))
-					committeeAssignments := assignmentsMap[attEpoch].AttesterDuties[slotIndex][uint64(committee)]
-					if len(committeeAssignments) == 0 {
-						break
-					}
-
-					committeeAssignmentsInt := make([]uint64, 0)
-					for j := 0; j < len(committeeAssignments); j++ {
-						if attAggregationBits.BitAt(attBitsOffset + uint64(j)) {
-							includedValidators = append(includedValidators, uint64(committeeAssignments[j]))
-						}
-						committeeAssignmentsInt = append(committeeAssignmentsInt, uint64(committeeAssignments[j]))
-					}
-
-					attBitsOffset += uint64(len(committeeAssignments))
-					attAssignments = append(attAssignments, committeeAssignmentsInt...)
-				}
-			}
-		} else {
-			// pre-electra attestation
-			if assignmentsMap[attEpoch] != nil {
-				slotIndex := int(chainState.SlotToSlotIndex(attData.Slot))
-				committeeAssignments := assignmentsMap[attEpoch].AttesterDuties[slotIndex][uint64(attData.Index)]
-				committeeAssignmentsInt := make([]uint64, 0)
-				for j := 0; j < len(committeeAssignments); j++ {
-					if attAggregationBits.BitAt(uint64(j)) {
-						includedValidators = append(includedValidators, uint64(committeeAssignments[j]))
-					}
-					committeeAssignmentsInt = append(committeeAssignmentsInt, uint64(committeeAssignments[j]))
-				}
-
-				attAssignments = committeeAssignmentsInt
-			} else {
-				attAssignments = []uint64{}
-			}
-
-			attPageData.CommitteeIndex = []uint64{uint64(attData.Index)}
-		}
-
-		attPageData.Validators = make([]types.NamedValidator, len(attAssignments))
-		for j := 0; j < len(attAssignments); j++ {
-			attPageData.Validators[j] = types.NamedValidator{
-				Index: attAssignments[j],
-				Name:  services.GlobalBeaconService.GetValidatorName(attAssignments[j]),
-			}
-		}
-
-		attPageData.IncludedValidators = make([]types.NamedValidator, len(includedValidators))
-		for j := 0; j < len(includedValidators); j++ {
-			attPageData.IncludedValidators[j] = types.NamedValidator{
-				Index: includedValidators[j],
-				Name:  services.GlobalBeaconService.GetValidatorName(includedValidators[j]),
-			}
-		}
-
-		pageData.Attestations[i] = &attPageData
+	pageData.Attestations, pageData.AttestationsCount, pageData.AttestationsPageIndex, pageData.AttestationsTotalPages = getSlotPageAttestations(blockData, epochStatsValues, attestationsPageIdx)
+	if pageData.AttestationsPageIndex > 1 {
+		pageData.AttestationsPrevPageLink = fmt.Sprintf("/slot/0x%x?ap=%v#attestations", blockData.Root[:], pageData.AttestationsPageIndex-1)
+	}
+	if pageData.AttestationsPageIndex < pageData.AttestationsTotalPages {
+		pageData.AttestationsNextPageLink = fmt.Sprintf("/slot/0x%x?ap=%v#attestations", blockData.Root[:], pageData.AttestationsPageIndex+1)
 	}
 
 	pageData.Deposits = make([]*models.SlotPageDeposit, pageData.DepositsCount)
@@ -478,8 +593,7 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsV
 
 	pageData.AttesterSlashings = make([]*models.SlotPageAttesterSlashing, pageData.AttesterSlashingsCount)
 	for i, slashing := range attesterSlashings {
-		att1, _ := slashing.Attestation1()
-		att2, _ := slashing.Attestation2()
+		att1, att2 := beacon.GetAttesterSlashingAttestations(&slashing)
 		if att1 == nil || att2 == nil {
 			continue
 		}
@@ -579,9 +693,9 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsV
 			for idx, vidx := range syncAssignments {
 				pageData.SyncAggCommittee[idx] = types.NamedValidator{
 					Index: vidx,
-					Name:  services.GlobalBeaconService.GetValidatorName(vidx),
 				}
 			}
+			resolveNamedValidators(pageData.SyncAggCommittee)
 		} else {
 			pageData.SyncAggCommittee = []types.NamedValidator{}
 		}
@@ -589,106 +703,7 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsV
 	}
 
 	if specs.BellatrixForkEpoch != nil && uint64(epoch) >= *specs.BellatrixForkEpoch {
-		switch blockData.Block.Version {
-		case spec.DataVersionBellatrix:
-			if blockData.Block.Bellatrix == nil {
-				break
-			}
-			executionPayload := blockData.Block.Bellatrix.Message.Body.ExecutionPayload
-			var baseFeePerGasBEBytes [32]byte
-			for i := 0; i < 32; i++ {
-				baseFeePerGasBEBytes[i] = executionPayload.BaseFeePerGas[32-1-i]
-			}
-			baseFeePerGas := new(big.Int).SetBytes(baseFeePerGasBEBytes[:])
-			pageData.ExecutionData = &models.SlotPageExecutionData{
-				ParentHash:    executionPayload.ParentHash[:],
-				FeeRecipient:  executionPayload.FeeRecipient[:],
-				StateRoot:     executionPayload.StateRoot[:],
-				ReceiptsRoot:  executionPayload.ReceiptsRoot[:],
-				LogsBloom:     executionPayload.LogsBloom[:],
-				Random:        executionPayload.PrevRandao[:],
-				GasLimit:      uint64(executionPayload.GasLimit),
-				GasUsed:       uint64(executionPayload.GasUsed),
-				Timestamp:     uint64(executionPayload.Timestamp),
-				Time:          time.Unix(int64(executionPayload.Timestamp), 0),
-				ExtraData:     executionPayload.ExtraData,
-				BaseFeePerGas: baseFeePerGas.Uint64(),
-				BlockHash:     executionPayload.BlockHash[:],
-				BlockNumber:   uint64(executionPayload.BlockNumber),
-			}
-			getSlotPageTransactions(pageData, executionPayload.Transactions)
-		case spec.DataVersionCapella:
-			if blockData.Block.Capella == nil {
-				break
-			}
-			executionPayload := blockData.Block.Capella.Message.Body.ExecutionPayload
-			var baseFeePerGasBEBytes [32]byte
-			for i := 0; i < 32; i++ {
-				baseFeePerGasBEBytes[i] = executionPayload.BaseFeePerGas[32-1-i]
-			}
-			baseFeePerGas := new(big.Int).SetBytes(baseFeePerGasBEBytes[:])
-			pageData.ExecutionData = &models.SlotPageExecutionData{
-				ParentHash:    executionPayload.ParentHash[:],
-				FeeRecipient:  executionPayload.FeeRecipient[:],
-				StateRoot:     executionPayload.StateRoot[:],
-				ReceiptsRoot:  executionPayload.ReceiptsRoot[:],
-				LogsBloom:     executionPayload.LogsBloom[:],
-				Random:        executionPayload.PrevRandao[:],
-				GasLimit:      uint64(executionPayload.GasLimit),
-				GasUsed:       uint64(executionPayload.GasUsed),
-				Timestamp:     uint64(executionPayload.Timestamp),
-				Time:          time.Unix(int64(executionPayload.Timestamp), 0),
-				ExtraData:     executionPayload.ExtraData,
-				BaseFeePerGas: baseFeePerGas.Uint64(),
-				BlockHash:     executionPayload.BlockHash[:],
-				BlockNumber:   uint64(executionPayload.BlockNumber),
-			}
-			getSlotPageTransactions(pageData, executionPayload.Transactions)
-		case spec.DataVersionDeneb:
-			if blockData.Block.Deneb == nil {
-				break
-			}
-			executionPayload := blockData.Block.Deneb.Message.Body.ExecutionPayload
-			pageData.ExecutionData = &models.SlotPageExecutionData{
-				ParentHash:    executionPayload.ParentHash[:],
-				FeeRecipient:  executionPayload.FeeRecipient[:],
-				StateRoot:     executionPayload.StateRoot[:],
-				ReceiptsRoot:  executionPayload.ReceiptsRoot[:],
-				LogsBloom:     executionPayload.LogsBloom[:],
-				Random:        executionPayload.PrevRandao[:],
-				GasLimit:      uint64(executionPayload.GasLimit),
-				GasUsed:       uint64(executionPayload.GasUsed),
-				Timestamp:     uint64(executionPayload.Timestamp),
-				Time:          time.Unix(int64(executionPayload.Timestamp), 0),
-				ExtraData:     executionPayload.ExtraData,
-				BaseFeePerGas: executionPayload.BaseFeePerGas.Uint64(),
-				BlockHash:     executionPayload.BlockHash[:],
-				BlockNumber:   uint64(executionPayload.BlockNumber),
-			}
-			getSlotPageTransactions(pageData, executionPayload.Transactions)
-		case spec.DataVersionElectra:
-			if blockData.Block.Electra == nil {
-				break
-			}
-			executionPayload := blockData.Block.Electra.Message.Body.ExecutionPayload
-			pageData.ExecutionData = &models.SlotPageExecutionData{
-				ParentHash:    executionPayload.ParentHash[:],
-				FeeRecipient:  executionPayload.FeeRecipient[:],
-				StateRoot:     executionPayload.StateRoot[:],
-				ReceiptsRoot:  executionPayload.ReceiptsRoot[:],
-				LogsBloom:     executionPayload.LogsBloom[:],
-				Random:        executionPayload.PrevRandao[:],
-				GasLimit:      uint64(executionPayload.GasLimit),
-				GasUsed:       uint64(executionPayload.GasUsed),
-				Timestamp:     uint64(executionPayload.Timestamp),
-				Time:          time.Unix(int64(executionPayload.Timestamp), 0),
-				ExtraData:     executionPayload.ExtraData,
-				BaseFeePerGas: executionPayload.BaseFeePerGas.Uint64(),
-				BlockHash:     executionPayload.BlockHash[:],
-				BlockNumber:   uint64(executionPayload.BlockNumber),
-			}
-			getSlotPageTransactions(pageData, executionPayload.Transactions)
-		}
+		getSlotPageExecutionPayload(pageData, blockData.Block)
 	}
 
 	if specs.CapellaForkEpoch != nil && uint64(epoch) >= *specs.CapellaForkEpoch {
@@ -727,6 +742,20 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsV
 			}
 			pageData.Blobs[i] = blobData
 		}
+
+		if pageData.BlobsCount > 0 && utils.Config.BlobAvailability.VerifyKzg {
+			for _, verification := range db.GetBlobVerificationsByRoot(blockData.Root[:]) {
+				pageData.BlobVerifications = append(pageData.BlobVerifications, &models.SlotPageBlobVerification{
+					ClientName:   services.RedactClientName(verification.ClientName),
+					ValidCount:   uint64(verification.ValidCount),
+					InvalidCount: uint64(verification.InvalidCount),
+					Result:       verification.Result,
+				})
+				if verification.InvalidCount > 0 {
+					pageData.BlobVerificationFails++
+				}
+			}
+		}
 	}
 
 	if specs.ElectraForkEpoch != nil && uint64(epoch) >= *specs.ElectraForkEpoch {
@@ -741,6 +770,325 @@ func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsV
 	return pageData
 }
 
+// getSlotPageExecutionPayload fills the execution payload fields & transactions of a post-merge block.
+func getSlotPageExecutionPayload(pageData *models.SlotPageBlockData, block *spec.VersionedSignedBeaconBlock) {
+	switch block.Version {
+	case spec.DataVersionBellatrix:
+		if block.Bellatrix == nil {
+			break
+		}
+		executionPayload := block.Bellatrix.Message.Body.ExecutionPayload
+		var baseFeePerGasBEBytes [32]byte
+		for i := 0; i < 32; i++ {
+			baseFeePerGasBEBytes[i] = executionPayload.BaseFeePerGas[32-1-i]
+		}
+		baseFeePerGas := new(big.Int).SetBytes(baseFeePerGasBEBytes[:])
+		pageData.ExecutionData = &models.SlotPageExecutionData{
+			ParentHash:    executionPayload.ParentHash[:],
+			FeeRecipient:  executionPayload.FeeRecipient[:],
+			StateRoot:     executionPayload.StateRoot[:],
+			ReceiptsRoot:  executionPayload.ReceiptsRoot[:],
+			LogsBloom:     executionPayload.LogsBloom[:],
+			Random:        executionPayload.PrevRandao[:],
+			GasLimit:      uint64(executionPayload.GasLimit),
+			GasUsed:       uint64(executionPayload.GasUsed),
+			Timestamp:     uint64(executionPayload.Timestamp),
+			Time:          time.Unix(int64(executionPayload.Timestamp), 0),
+			ExtraData:     executionPayload.ExtraData,
+			BaseFeePerGas: baseFeePerGas.Uint64(),
+			BlockHash:     executionPayload.BlockHash[:],
+			BlockNumber:   uint64(executionPayload.BlockNumber),
+		}
+		getSlotPageTransactions(pageData, executionPayload.Transactions)
+	case spec.DataVersionCapella:
+		if block.Capella == nil {
+			break
+		}
+		executionPayload := block.Capella.Message.Body.ExecutionPayload
+		var baseFeePerGasBEBytes [32]byte
+		for i := 0; i < 32; i++ {
+			baseFeePerGasBEBytes[i] = executionPayload.BaseFeePerGas[32-1-i]
+		}
+		baseFeePerGas := new(big.Int).SetBytes(baseFeePerGasBEBytes[:])
+		pageData.ExecutionData = &models.SlotPageExecutionData{
+			ParentHash:    executionPayload.ParentHash[:],
+			FeeRecipient:  executionPayload.FeeRecipient[:],
+			StateRoot:     executionPayload.StateRoot[:],
+			ReceiptsRoot:  executionPayload.ReceiptsRoot[:],
+			LogsBloom:     executionPayload.LogsBloom[:],
+			Random:        executionPayload.PrevRandao[:],
+			GasLimit:      uint64(executionPayload.GasLimit),
+			GasUsed:       uint64(executionPayload.GasUsed),
+			Timestamp:     uint64(executionPayload.Timestamp),
+			Time:          time.Unix(int64(executionPayload.Timestamp), 0),
+			ExtraData:     executionPayload.ExtraData,
+			BaseFeePerGas: baseFeePerGas.Uint64(),
+			BlockHash:     executionPayload.BlockHash[:],
+			BlockNumber:   uint64(executionPayload.BlockNumber),
+		}
+		getSlotPageTransactions(pageData, executionPayload.Transactions)
+	case spec.DataVersionDeneb:
+		if block.Deneb == nil {
+			break
+		}
+		executionPayload := block.Deneb.Message.Body.ExecutionPayload
+		pageData.ExecutionData = &models.SlotPageExecutionData{
+			ParentHash:    executionPayload.ParentHash[:],
+			FeeRecipient:  executionPayload.FeeRecipient[:],
+			StateRoot:     executionPayload.StateRoot[:],
+			ReceiptsRoot:  executionPayload.ReceiptsRoot[:],
+			LogsBloom:     executionPayload.LogsBloom[:],
+			Random:        executionPayload.PrevRandao[:],
+			GasLimit:      uint64(executionPayload.GasLimit),
+			GasUsed:       uint64(executionPayload.GasUsed),
+			Timestamp:     uint64(executionPayload.Timestamp),
+			Time:          time.Unix(int64(executionPayload.Timestamp), 0),
+			ExtraData:     executionPayload.ExtraData,
+			BaseFeePerGas: executionPayload.BaseFeePerGas.Uint64(),
+			BlockHash:     executionPayload.BlockHash[:],
+			BlockNumber:   uint64(executionPayload.BlockNumber),
+		}
+		getSlotPageTransactions(pageData, executionPayload.Transactions)
+	case spec.DataVersionElectra:
+		if block.Electra == nil {
+			break
+		}
+		executionPayload := block.Electra.Message.Body.ExecutionPayload
+		pageData.ExecutionData = &models.SlotPageExecutionData{
+			ParentHash:    executionPayload.ParentHash[:],
+			FeeRecipient:  executionPayload.FeeRecipient[:],
+			StateRoot:     executionPayload.StateRoot[:],
+			ReceiptsRoot:  executionPayload.ReceiptsRoot[:],
+			LogsBloom:     executionPayload.LogsBloom[:],
+			Random:        executionPayload.PrevRandao[:],
+			GasLimit:      uint64(executionPayload.GasLimit),
+			GasUsed:       uint64(executionPayload.GasUsed),
+			Timestamp:     uint64(executionPayload.Timestamp),
+			Time:          time.Unix(int64(executionPayload.Timestamp), 0),
+			ExtraData:     executionPayload.ExtraData,
+			BaseFeePerGas: executionPayload.BaseFeePerGas.Uint64(),
+			BlockHash:     executionPayload.BlockHash[:],
+			BlockNumber:   uint64(executionPayload.BlockNumber),
+		}
+		getSlotPageTransactions(pageData, executionPayload.Transactions)
+	}
+}
+
+// getSlotPageAttestations builds the page models for a single page of the attestations included in a block.
+// attestations & their count are loaded from the slot_attestations table if the block has already been written to the db,
+// the block body is only decoded for blocks that are not persisted yet.
+// returns the page attestations, the total number of attestations in the block, the page index & the total number of pages.
+func getSlotPageAttestations(blockData *services.CombinedBlockResponse, epochStatsValues *beacon.EpochStatsValues, pageIdx uint64) ([]*models.SlotPageAttestation, uint64, uint64, uint64) {
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
+	if pageIdx < 1 {
+		pageIdx = 1
+	}
+	offset := (pageIdx - 1) * slotAttestationsPageSize
+
+	dbAttestations, totalCount, err := db.GetSlotAttestations(blockData.Root[:], offset, uint32(slotAttestationsPageSize))
+	if err == nil && totalCount > 0 && offset >= totalCount {
+		// page index out of range, show the last page
+		pageIdx = (totalCount + slotAttestationsPageSize - 1) / slotAttestationsPageSize
+		offset = (pageIdx - 1) * slotAttestationsPageSize
+		dbAttestations, totalCount, err = db.GetSlotAttestations(blockData.Root[:], offset, uint32(slotAttestationsPageSize))
+	}
+
+	if err != nil || totalCount == 0 {
+		// not persisted yet, build the page from the block body
+		attestations, _ := blockData.Block.Attestations()
+		totalCount = uint64(len(attestations))
+
+		if totalCount > 0 && offset >= totalCount {
+			pageIdx = (totalCount + slotAttestationsPageSize - 1) / slotAttestationsPageSize
+			offset = (pageIdx - 1) * slotAttestationsPageSize
+		}
+
+		dbAttestations = make([]*dbtypes.SlotAttestation, 0, slotAttestationsPageSize)
+		for idx := offset; idx < totalCount && idx < offset+slotAttestationsPageSize; idx++ {
+			attVersioned := attestations[idx]
+			attData, _ := attVersioned.Data()
+			if attData == nil {
+				continue
+			}
+
+			attSignature, err := attVersioned.Signature()
+			if err != nil {
+				continue
+			}
+
+			attAggregationBits, err := attVersioned.AggregationBits()
+			if err != nil {
+				continue
+			}
+
+			dbAttestation := &dbtypes.SlotAttestation{
+				SlotIndex:       idx,
+				AttSlot:         uint64(attData.Slot),
+				CommitteeIndex:  uint64(attData.Index),
+				AggregationBits: attAggregationBits,
+				BeaconBlockRoot: attData.BeaconBlockRoot[:],
+				SourceEpoch:     uint64(attData.Source.Epoch),
+				SourceRoot:      attData.Source.Root[:],
+				TargetEpoch:     uint64(attData.Target.Epoch),
+				TargetRoot:      attData.Target.Root[:],
+				Signature:       attSignature[:],
+			}
+
+			if attVersioned.Version >= spec.DataVersionElectra {
+				committeeBits, err := attVersioned.CommitteeBits()
+				if err != nil {
+					continue
+				}
+
+				dbAttestation.CommitteeIndex = 0
+				dbAttestation.CommitteeBits = committeeBits
+			}
+
+			dbAttestations = append(dbAttestations, dbAttestation)
+		}
+	}
+
+	totalPages := (totalCount + slotAttestationsPageSize - 1) / slotAttestationsPageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	epoch := chainState.EpochOfSlot(blockData.Header.Message.Slot)
+	assignmentsMap := make(map[phase0.Epoch]*beacon.EpochStatsValues)
+	assignmentsLoaded := make(map[phase0.Epoch]bool)
+	assignmentsMap[epoch] = epochStatsValues
+	assignmentsLoaded[epoch] = true
+
+	pageAttestations := make([]*models.SlotPageAttestation, 0, len(dbAttestations))
+	for _, attestation := range dbAttestations {
+		attSlot := phase0.Slot(attestation.AttSlot)
+		attEpoch := chainState.EpochOfSlot(attSlot)
+		if !assignmentsLoaded[attEpoch] { // get epoch duties from cache
+			beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
+			if epochStats := beaconIndexer.GetEpochStats(attEpoch, nil); epochStats != nil {
+				assignmentsMap[attEpoch] = epochStats.GetOrLoadValues(beaconIndexer, true, false)
+			}
+			assignmentsLoaded[attEpoch] = true
+		}
+
+		attAggregationBits := bitfield.Bitlist(attestation.AggregationBits)
+		attPageData := &models.SlotPageAttestation{
+			Index:           attestation.SlotIndex,
+			Slot:            attestation.AttSlot,
+			AggregationBits: attestation.AggregationBits,
+			Signature:       attestation.Signature,
+			BeaconBlockRoot: attestation.BeaconBlockRoot,
+			SourceEpoch:     attestation.SourceEpoch,
+			SourceRoot:      attestation.SourceRoot,
+			TargetEpoch:     attestation.TargetEpoch,
+			TargetRoot:      attestation.TargetRoot,
+		}
+
+		var attAssignments []uint64
+		includedValidators := []uint64{}
+
+		if len(attestation.CommitteeBits) > 0 {
+			// EIP-7549 attestation
+			attAssignments = []uint64{}
+			attPageData.CommitteeIndex = []uint64{}
+
+			attBitsOffset := uint64(0)
+			for _, committee := range bitfield.Bitvector64(attestation.CommitteeBits).BitIndices() {
+				if uint64(committee) >= specs.MaxCommitteesPerSlot {
+					continue
+				}
+
+				attPageData.CommitteeIndex = append(attPageData.CommitteeIndex, uint64(committee))
+				if assignmentsMap[attEpoch] != nil {
+					slotIndex := int(chainState.SlotToSlotIndex(attSlot))
+					committeeAssignments := assignmentsMap[attEpoch].AttesterDuties[slotIndex][uint64(committee)]
+					if len(committeeAssignments) == 0 {
+						break
+					}
+
+					committeeAssignmentsInt := make([]uint64, 0)
+					for j := 0; j < len(committeeAssignments); j++ {
+						if attAggregationBits.BitAt(attBitsOffset + uint64(j)) {
+							includedValidators = append(includedValidators, uint64(committeeAssignments[j]))
+						}
+						committeeAssignmentsInt = append(committeeAssignmentsInt, uint64(committeeAssignments[j]))
+					}
+
+					attBitsOffset += uint64(len(committeeAssignments))
+					attAssignments = append(attAssignments, committeeAssignmentsInt...)
+				}
+			}
+		} else {
+			// pre-electra attestation
+			if assignmentsMap[attEpoch] != nil {
+				slotIndex := int(chainState.SlotToSlotIndex(attSlot))
+				committeeAssignments := assignmentsMap[attEpoch].AttesterDuties[slotIndex][attestation.CommitteeIndex]
+				committeeAssignmentsInt := make([]uint64, 0)
+				for j := 0; j < len(committeeAssignments); j++ {
+					if attAggregationBits.BitAt(uint64(j)) {
+						includedValidators = append(includedValidators, uint64(committeeAssignments[j]))
+					}
+					committeeAssignmentsInt = append(committeeAssignmentsInt, uint64(committeeAssignments[j]))
+				}
+
+				attAssignments = committeeAssignmentsInt
+			} else {
+				attAssignments = []uint64{}
+			}
+
+			attPageData.CommitteeIndex = []uint64{attestation.CommitteeIndex}
+		}
+
+		attPageData.Validators = make([]types.NamedValidator, len(attAssignments))
+		for j := 0; j < len(attAssignments); j++ {
+			attPageData.Validators[j] = types.NamedValidator{
+				Index: attAssignments[j],
+			}
+		}
+
+		attPageData.IncludedValidators = make([]types.NamedValidator, len(includedValidators))
+		for j := 0; j < len(includedValidators); j++ {
+			attPageData.IncludedValidators[j] = types.NamedValidator{
+				Index: includedValidators[j],
+			}
+		}
+
+		pageAttestations = append(pageAttestations, attPageData)
+	}
+
+	// resolve the committee members of all attestations in a single batch
+	namedValidators := make([][]types.NamedValidator, 0, len(pageAttestations)*2)
+	for _, attPageData := range pageAttestations {
+		namedValidators = append(namedValidators, attPageData.Validators, attPageData.IncludedValidators)
+	}
+	resolveNamedValidators(namedValidators...)
+
+	return pageAttestations, totalCount, pageIdx, totalPages
+}
+
+// resolveNamedValidators fills the names of the given validators with a single batched metadata lookup
+func resolveNamedValidators(validatorLists ...[]types.NamedValidator) {
+	indices := []phase0.ValidatorIndex{}
+	for _, validators := range validatorLists {
+		for _, validator := range validators {
+			indices = append(indices, phase0.ValidatorIndex(validator.Index))
+		}
+	}
+
+	metadata := services.GlobalBeaconService.GetValidatorMetadata(indices)
+	for _, validators := range validatorLists {
+		for j := range validators {
+			if validatorMeta := metadata[phase0.ValidatorIndex(validators[j].Index)]; validatorMeta != nil {
+				validators[j].Name = validatorMeta.Entity
+			} else {
+				validators[j].Name = services.GlobalBeaconService.GetValidatorName(validators[j].Index)
+			}
+		}
+	}
+}
+
 func getSlotPageTransactions(pageData *models.SlotPageBlockData, transactions []bellatrix.Transaction) {
 	pageData.Transactions = make([]*models.SlotPageTransaction, 0)
 	sigLookupBytes := []types.TxSignatureBytes{}
@@ -761,11 +1109,12 @@ func getSlotPageTransactions(pageData *models.SlotPageBlockData, transactions []
 		txValue = txValue / ethFloat
 
 		txData := &models.SlotPageTransaction{
-			Index: uint64(idx),
-			Hash:  txHash[:],
-			Value: txValue,
-			Data:  tx.Data(),
-			Type:  uint64(tx.Type()),
+			Index:    uint64(idx),
+			Hash:     txHash[:],
+			Value:    txValue,
+			Data:     tx.Data(),
+			Type:     uint64(tx.Type()),
+			GasLimit: tx.Gas(),
 		}
 		txData.DataLen = uint64(len(txData.Data))
 		txFrom, err := ethtypes.Sender(ethtypes.NewPragueSigner(tx.ChainId()), &tx)
@@ -920,7 +1269,7 @@ func handleSlotDownload(ctx context.Context, w http.ResponseWriter, blockSlot in
 		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=block-%d-%x.ssz", blockData.Header.Message.Slot, blockData.Root[:]))
 
 		dynSsz := services.GlobalBeaconService.GetBeaconIndexer().GetDynSSZ()
-		_, blockSSZ, err := beacon.MarshalVersionedSignedBeaconBlockSSZ(dynSsz, blockData.Block, false, true)
+		_, blockSSZ, err := beacon.MarshalVersionedSignedBeaconBlockSSZ(dynSsz, blockData.Block, nil, true)
 		if err != nil {
 			return fmt.Errorf("error serializing block: %v", err)
 		}
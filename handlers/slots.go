@@ -80,33 +80,8 @@ func buildSlotsPageData(firstSlot uint64, pageSize uint64) (*models.SlotsPageDat
 	if maxSlot >= chainState.EpochToSlot(currentEpoch+1) {
 		maxSlot = chainState.EpochToSlot(currentEpoch+1) - 1
 	}
-	if firstSlot > uint64(maxSlot) {
-		pageData.IsDefaultPage = true
-		firstSlot = uint64(maxSlot)
-	}
-
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	pagesBefore := (firstSlot + 1) / pageSize
-	if ((firstSlot + 1) % pageSize) > 0 {
-		pagesBefore++
-	}
-	pagesAfter := (uint64(maxSlot) - firstSlot) / pageSize
-	if ((uint64(maxSlot) - firstSlot) % pageSize) > 0 {
-		pagesAfter++
-	}
-	pageData.PageSize = pageSize
-	pageData.TotalPages = pagesBefore + pagesAfter
-	pageData.CurrentPageIndex = pagesAfter + 1
+	pageSize, firstSlot = initAnchoredPagingData(&pageData.PagingData, firstSlot, uint64(maxSlot), pageSize, "/slots", "s", "c")
 	pageData.CurrentPageSlot = firstSlot
-	pageData.PrevPageIndex = pageData.CurrentPageIndex - 1
-	pageData.PrevPageSlot = pageData.CurrentPageSlot + pageSize
-	if pageData.CurrentPageSlot >= pageSize {
-		pageData.NextPageIndex = pageData.CurrentPageIndex + 1
-		pageData.NextPageSlot = pageData.CurrentPageSlot - pageSize
-	}
-	pageData.LastPageSlot = pageSize - 1
 
 	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
 	slotLimit := pageSize - 1
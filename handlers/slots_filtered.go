@@ -4,15 +4,21 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
 	"github.com/ethpandaops/dora/services"
 	"github.com/ethpandaops/dora/templates"
 	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -31,10 +37,18 @@ func SlotsFiltered(w http.ResponseWriter, r *http.Request) {
 	if urlArgs.Has("c") {
 		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
 	}
-	var pageIdx uint64 = 0
-	if urlArgs.Has("s") {
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+	} else if urlArgs.Has("s") {
+		// 0 based page index of older page links
 		pageIdx, _ = strconv.ParseUint(urlArgs.Get("s"), 10, 64)
+		pageIdx++
 	}
+	if pageIdx < 1 {
+		pageIdx = 1
+	}
+	cursor := parseListCursor(urlArgs.Get("k"))
 	var displayColumns string = ""
 	if urlArgs.Has("d") {
 		displayColumns = urlArgs.Get("d")
@@ -44,6 +58,11 @@ func SlotsFiltered(w http.ResponseWriter, r *http.Request) {
 	var extradata string
 	var proposer string
 	var pname string
+	var clClient int64
+	var elClient int64
+	var builderType uint64
+	var minConfidence uint64
+	var tag string
 	var withOrphaned uint64
 	var withMissing uint64
 
@@ -57,9 +76,24 @@ func SlotsFiltered(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.proposer") {
 			proposer = urlArgs.Get("f.proposer")
 		}
-		if urlArgs.Has("f.pname") {
+		if urlArgs.Has("f.pname") && services.IsValidatorNameSearchAllowed() {
 			pname = urlArgs.Get("f.pname")
 		}
+		if urlArgs.Has("f.cl") {
+			clClient, _ = strconv.ParseInt(urlArgs.Get("f.cl"), 10, 8)
+		}
+		if urlArgs.Has("f.el") {
+			elClient, _ = strconv.ParseInt(urlArgs.Get("f.el"), 10, 8)
+		}
+		if urlArgs.Has("f.builder") {
+			builderType, _ = strconv.ParseUint(urlArgs.Get("f.builder"), 10, 8)
+		}
+		if urlArgs.Has("f.conf") {
+			minConfidence, _ = strconv.ParseUint(urlArgs.Get("f.conf"), 10, 8)
+		}
+		if urlArgs.Has("f.tag") {
+			tag = urlArgs.Get("f.tag")
+		}
 		if urlArgs.Has("f.orphaned") {
 			withOrphaned, _ = strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 64)
 		}
@@ -73,7 +107,7 @@ func SlotsFiltered(w http.ResponseWriter, r *http.Request) {
 	var pageError error
 	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
 	if pageError == nil {
-		data.Data, pageError = getFilteredSlotsPageData(pageIdx, pageSize, graffiti, extradata, proposer, pname, uint8(withOrphaned), uint8(withMissing), displayColumns)
+		data.Data, pageError = getFilteredSlotsPageData(pageIdx, pageSize, cursor, graffiti, extradata, proposer, pname, int8(clClient), int8(elClient), uint8(builderType), uint8(minConfidence), tag, uint8(withOrphaned), uint8(withMissing), displayColumns)
 	}
 	if pageError != nil {
 		handlePageError(w, r, pageError)
@@ -85,11 +119,11 @@ func SlotsFiltered(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string, extradata string, proposer string, pname string, withOrphaned uint8, withMissing uint8, displayColumns string) (*models.SlotsFilteredPageData, error) {
+func getFilteredSlotsPageData(pageIdx uint64, pageSize uint64, cursor *dbtypes.ListCursor, graffiti string, extradata string, proposer string, pname string, clClient int8, elClient int8, builderType uint8, minConfidence uint8, tag string, withOrphaned uint8, withMissing uint8, displayColumns string) (*models.SlotsFilteredPageData, error) {
 	pageData := &models.SlotsFilteredPageData{}
-	pageCacheKey := fmt.Sprintf("slots_filtered:%v:%v:%v:%v:%v:%v:%v:%v:%v", pageIdx, pageSize, graffiti, extradata, proposer, pname, withOrphaned, withMissing, displayColumns)
+	pageCacheKey := fmt.Sprintf("slots_filtered:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v", pageIdx, pageSize, getListCursorKey(cursor), graffiti, extradata, proposer, pname, clClient, elClient, builderType, minConfidence, tag, withOrphaned, withMissing, displayColumns)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
-		return buildFilteredSlotsPageData(pageIdx, pageSize, graffiti, extradata, proposer, pname, withOrphaned, withMissing, displayColumns)
+		return buildFilteredSlotsPageData(pageIdx, pageSize, cursor, graffiti, extradata, proposer, pname, clClient, elClient, builderType, minConfidence, tag, withOrphaned, withMissing, displayColumns)
 	})
 	if pageErr == nil && pageRes != nil {
 		resData, resOk := pageRes.(*models.SlotsFilteredPageData)
@@ -101,7 +135,7 @@ func getFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string,
 	return pageData, pageErr
 }
 
-func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string, extradata string, proposer string, pname string, withOrphaned uint8, withMissing uint8, displayColumns string) *models.SlotsFilteredPageData {
+func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, cursor *dbtypes.ListCursor, graffiti string, extradata string, proposer string, pname string, clClient int8, elClient int8, builderType uint8, minConfidence uint8, tag string, withOrphaned uint8, withMissing uint8, displayColumns string) *models.SlotsFilteredPageData {
 	chainState := services.GlobalBeaconService.GetChainState()
 	filterArgs := url.Values{}
 	if graffiti != "" {
@@ -116,6 +150,21 @@ func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string
 	if pname != "" {
 		filterArgs.Add("f.pname", pname)
 	}
+	if clClient != 0 {
+		filterArgs.Add("f.cl", fmt.Sprintf("%v", clClient))
+	}
+	if elClient != 0 {
+		filterArgs.Add("f.el", fmt.Sprintf("%v", elClient))
+	}
+	if builderType != 0 {
+		filterArgs.Add("f.builder", fmt.Sprintf("%v", builderType))
+	}
+	if minConfidence != 0 {
+		filterArgs.Add("f.conf", fmt.Sprintf("%v", minConfidence))
+	}
+	if tag != "" {
+		filterArgs.Add("f.tag", tag)
+	}
 	if withOrphaned != 0 {
 		filterArgs.Add("f.orphaned", fmt.Sprintf("%v", withOrphaned))
 	}
@@ -169,8 +218,16 @@ func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string
 		FilterExtraData:    extradata,
 		FilterProposer:     proposer,
 		FilterProposerName: pname,
+		FilterClClient:     clClient,
+		FilterElClient:     elClient,
+		FilterBuilderType:  builderType,
+		FilterConfidence:   minConfidence,
+		FilterTag:          tag,
 		FilterWithOrphaned: withOrphaned,
 		FilterWithMissing:  withMissing,
+		ClClientOptions:    getFingerprintClientOptions(true),
+		ElClientOptions:    getFingerprintClientOptions(false),
+		TagOptions:         getBlockTagOptions(),
 
 		DisplayEpoch:        displayMap[1],
 		DisplaySlot:         displayMap[2],
@@ -184,25 +241,11 @@ func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string
 		DisplaySyncAgg:      displayMap[10],
 		DisplayGraffiti:     displayMap[11],
 		DisplayElExtraData:  displayMap[12],
+		DisplayFingerprint:  displayMap[13],
 		DisplayColCount:     uint64(len(displayMap)),
 	}
 	logrus.Debugf("slots_filtered page called: %v:%v [%v/%v]", pageIdx, pageSize, graffiti, extradata)
-	if pageIdx == 0 {
-		pageData.IsDefaultPage = true
-	}
-
-	if pageSize > 100 {
-		pageSize = 100
-	}
-	pageData.PageSize = pageSize
-	pageData.TotalPages = pageIdx + 1
-	pageData.CurrentPageIndex = pageIdx + 1
-	pageData.CurrentPageSlot = pageIdx
-	if pageIdx >= 1 {
-		pageData.PrevPageIndex = pageIdx
-		pageData.PrevPageSlot = pageIdx - 1
-	}
-	pageData.LastPageSlot = 0
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
 
 	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
 	currentSlot := chainState.CurrentSlot()
@@ -210,11 +253,17 @@ func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string
 	// load slots
 	pageData.Slots = make([]*models.SlotsFilteredPageDataSlot, 0)
 	blockFilter := &dbtypes.BlockFilter{
-		Graffiti:     graffiti,
-		ExtraData:    extradata,
-		ProposerName: pname,
-		WithOrphaned: withOrphaned,
-		WithMissing:  withMissing,
+		Graffiti:      graffiti,
+		ExtraData:     extradata,
+		ProposerName:  pname,
+		ClClient:      clClient,
+		ElClient:      elClient,
+		BuilderType:   builderType,
+		MinConfidence: minConfidence,
+		Tag:           tag,
+		WithOrphaned:  withOrphaned,
+		WithMissing:   withMissing,
+		Cursor:        cursor,
 	}
 	if proposer != "" {
 		pidx, _ := strconv.ParseUint(proposer, 10, 64)
@@ -226,14 +275,17 @@ func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string
 		withScheduledCount = 16
 	}
 
-	dbBlocks := services.GlobalBeaconService.GetDbBlocksByFilter(blockFilter, pageIdx, uint32(pageSize), withScheduledCount)
+	dbBlocks := services.GlobalBeaconService.GetDbBlocksByFilter(blockFilter, pageIdx-1, uint32(pageSize), withScheduledCount)
 	haveMore := false
+	slotKeys := make([]uint64, 0, len(dbBlocks))
+	tagRoots := [][]byte{}
 	for idx, dbBlock := range dbBlocks {
 		if idx >= int(pageSize) {
 			haveMore = true
 			break
 		}
 		slot := phase0.Slot(dbBlock.Slot)
+		slotKeys = append(slotKeys, dbBlock.Slot)
 
 		slotData := &models.SlotsFilteredPageDataSlot{
 			Slot:         uint64(slot),
@@ -265,24 +317,122 @@ func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string
 				slotData.WithEthBlock = true
 				slotData.EthBlockNumber = *dbBlock.Block.EthBlockNumber
 			}
+
+			// tags of finalized blocks are loaded from the db, tags of unfinalized blocks are evaluated on the fly
+			if slotData.Finalized {
+				tagRoots = append(tagRoots, dbBlock.Block.Root)
+			} else if dbBlock.Block.Status != dbtypes.Missing {
+				slotData.Tags = beacon.MatchBlockTags(dbBlock.Block.GraffitiText, dbBlock.Block.EthBlockExtraText)
+			}
 		}
 		pageData.Slots = append(pageData.Slots, slotData)
 	}
 	pageData.SlotCount = uint64(len(pageData.Slots))
+
+	if pageData.DisplayFingerprint {
+		fingerprints := db.GetSlotFingerprintsBySlots(slotKeys)
+		for _, slotData := range pageData.Slots {
+			if fingerprint := fingerprints[slotData.Slot]; fingerprint != nil && slotData.Status == uint8(dbtypes.Canonical) {
+				slotData.Fingerprint = getSlotFingerprintModel(fingerprint)
+			}
+		}
+	}
+
+	if len(tagRoots) > 0 && len(pageData.TagOptions) > 0 {
+		slotTags := map[string][]string{}
+		for _, slotTag := range db.GetSlotTagsByRoots(tagRoots) {
+			slotTags[string(slotTag.Root)] = append(slotTags[string(slotTag.Root)], slotTag.Tag)
+		}
+		for _, slotData := range pageData.Slots {
+			if tags := slotTags[string(slotData.BlockRoot)]; tags != nil {
+				slotData.Tags = tags
+			}
+		}
+	}
+
 	if pageData.SlotCount > 0 {
 		pageData.FirstSlot = pageData.Slots[0].Slot
 		pageData.LastSlot = pageData.Slots[pageData.SlotCount-1].Slot
 	}
-	if haveMore {
-		pageData.NextPageIndex = pageIdx + 1
-		pageData.NextPageSlot = pageIdx + 1
-		pageData.TotalPages++
+	baseLink := fmt.Sprintf("/slots/filtered?f&%v", filterArgs.Encode())
+	finalizePagingDataHasMore(&pageData.PagingData, haveMore, baseLink)
+	setNextPageCursor(&pageData.PagingData, baseLink, db.GetNextListCursor(cursor, slotKeys))
+
+	return pageData
+}
+
+// getFingerprintClientOptions returns the selectable client filter options of the filtered slots page
+func getFingerprintClientOptions(consensusClients bool) []*models.SlotsFilteredPageDataClientOption {
+	options := []*models.SlotsFilteredPageDataClientOption{}
+	for client := int8(1); ; client++ {
+		var name string
+		if consensusClients {
+			name = consensus.ClientType(client).String()
+		} else {
+			name = execution.ClientType(client).String()
+		}
+		if strings.HasPrefix(name, "unknown") {
+			break
+		}
+
+		options = append(options, &models.SlotsFilteredPageDataClientOption{
+			Value: client,
+			Name:  name,
+		})
+	}
+
+	options = append(options, &models.SlotsFilteredPageDataClientOption{
+		Value: int8(consensus.UnknownClient),
+		Name:  "unknown",
+	})
+	return options
+}
+
+// getBlockTagOptions returns the selectable block tags of the filtered slots page (configured tags & tags that have been assigned by previous rules)
+func getBlockTagOptions() []string {
+	options := []string{}
+	for _, blockTag := range utils.Config.Indexer.BlockTags {
+		if !slices.Contains(options, blockTag.Tag) {
+			options = append(options, blockTag.Tag)
+		}
+	}
+	for _, tag := range db.GetSlotTagNames() {
+		if !slices.Contains(options, tag) {
+			options = append(options, tag)
+		}
 	}
 
-	pageData.FirstPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v", filterArgs.Encode(), pageData.PageSize)
-	pageData.PrevPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v&s=%v", filterArgs.Encode(), pageData.PageSize, pageData.PrevPageSlot)
-	pageData.NextPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v&s=%v", filterArgs.Encode(), pageData.PageSize, pageData.NextPageSlot)
-	pageData.LastPageLink = fmt.Sprintf("/slots/filtered?f&%v&c=%v&s=%v", filterArgs.Encode(), pageData.PageSize, pageData.LastPageSlot)
+	return options
+}
 
-	return pageData
+func getSlotFingerprintModel(fingerprint *dbtypes.SlotFingerprint) *models.SlotFingerprint {
+	sourceNames := []string{}
+	sources := dbtypes.SlotFingerprintSource(fingerprint.Sources)
+	for _, source := range []struct {
+		flag dbtypes.SlotFingerprintSource
+		name string
+	}{
+		{dbtypes.FingerprintSourceGraffitiVersion, "graffiti version"},
+		{dbtypes.FingerprintSourceGraffitiName, "graffiti name"},
+		{dbtypes.FingerprintSourceExtraData, "extra data"},
+		{dbtypes.FingerprintSourceRelay, "relay"},
+		{dbtypes.FingerprintSourceFeeRecipient, "fee recipient"},
+		{dbtypes.FingerprintSourceEmptyBlock, "empty block"},
+	} {
+		if sources&source.flag != 0 {
+			sourceNames = append(sourceNames, source.name)
+		}
+	}
+
+	return &models.SlotFingerprint{
+		ClClient:          getClientDiversityName(consensus.ClientType(fingerprint.ClClient).String(), fingerprint.ClClient),
+		ClConfidence:      fingerprint.ClConfidence,
+		ElClient:          getClientDiversityName(execution.ClientType(fingerprint.ElClient).String(), fingerprint.ElClient),
+		ElConfidence:      fingerprint.ElConfidence,
+		BuilderType:       fingerprint.BuilderType,
+		BuilderConfidence: fingerprint.BuilderConfidence,
+		BuilderName:       fingerprint.BuilderName,
+		BuilderPubkey:     fingerprint.BuilderPubkey,
+		Sources:           strings.Join(sourceNames, ", "),
+	}
 }
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+)
+
+// stakingEntitiesMaxListed is the maximum number of entities listed on the staking entities page, the remaining senders are aggregated
+const stakingEntitiesMaxListed = 100
+
+// StakingEntities will return the "staking entities" page using a go template
+func StakingEntities(w http.ResponseWriter, r *http.Request) {
+	var stakingEntitiesTemplateFiles = append(layoutTemplateFiles,
+		"staking_entities/staking_entities.html",
+	)
+
+	if !services.IsValidatorNameSearchAllowed() || !services.IsDepositSenderSearchAllowed() {
+		// staking entities would reveal the redacted address labels & deposit senders
+		NotFound(w, r)
+		return
+	}
+
+	var pageTemplate = templates.GetTemplate(stakingEntitiesTemplateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/staking_entities", "Staking Entities", stakingEntitiesTemplateFiles)
+
+	showUnlabelled := r.URL.Query().Get("unlabelled") == "1"
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getStakingEntitiesPageData(showUnlabelled)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "staking_entities.go", "StakingEntities", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getStakingEntitiesPageData(showUnlabelled bool) (*models.StakingEntitiesPageData, error) {
+	pageData := &models.StakingEntitiesPageData{}
+	pageCacheKey := fmt.Sprintf("staking_entities:%v", showUnlabelled)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildStakingEntitiesPageData(showUnlabelled)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.StakingEntitiesPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildStakingEntitiesPageData(showUnlabelled bool) (*models.StakingEntitiesPageData, time.Duration) {
+	logrus.Debugf("staking entities page called: %v", showUnlabelled)
+	pageData := &models.StakingEntitiesPageData{
+		Entities:       []*models.StakingEntitiesPageDataEntity{},
+		Others:         &models.StakingEntitiesPageDataEntity{},
+		ShowUnlabelled: showUnlabelled,
+	}
+
+	senderStats, err := db.GetDepositSenderStats()
+	if err != nil {
+		logrus.Errorf("error loading deposit sender stats: %v", err)
+		return pageData, 10 * time.Second
+	}
+	depositSenders, err := db.GetDepositTxSenders()
+	if err != nil {
+		logrus.Errorf("error loading deposit tx senders: %v", err)
+		return pageData, 10 * time.Second
+	}
+
+	// group the deposit senders by their label, unlabelled senders are grouped by address
+	entityMap := map[string]*models.StakingEntitiesPageDataEntity{}
+	getEntity := func(sender []byte) *models.StakingEntitiesPageDataEntity {
+		entityKey := fmt.Sprintf("0x%x", sender)
+		label := services.GlobalBeaconService.GetAddressLabel(sender)
+		if label != "" {
+			entityKey = "label:" + label
+		}
+
+		entity := entityMap[entityKey]
+		if entity == nil {
+			entity = &models.StakingEntitiesPageDataEntity{
+				Name:     label,
+				Labelled: label != "",
+			}
+			if label == "" {
+				entity.Name = common.BytesToAddress(sender).String()
+				entity.Address = sender
+			}
+			entityMap[entityKey] = entity
+		}
+		return entity
+	}
+
+	for _, stats := range senderStats {
+		entity := getEntity(stats.TxSender)
+		entity.SenderCount++
+		entity.DepositCount += stats.DepositCount
+		entity.DepositAmount += stats.DepositAmount
+
+		firstDeposit := time.Unix(int64(stats.FirstBlockTime), 0)
+		if entity.FirstDeposit.IsZero() || firstDeposit.Before(entity.FirstDeposit) {
+			entity.FirstDeposit = firstDeposit
+		}
+		lastDeposit := time.Unix(int64(stats.LastBlockTime), 0)
+		if lastDeposit.After(entity.LastDeposit) {
+			entity.LastDeposit = lastDeposit
+		}
+	}
+
+	// validators are attributed to the sender of their first deposit
+	now := time.Now()
+	new7dTime := uint64(now.Add(-7 * 24 * time.Hour).Unix())
+	new30dTime := uint64(now.Add(-30 * 24 * time.Hour).Unix())
+	entityStats := map[phase0.ValidatorIndex]*models.EntityValidatorStats{}
+	for _, depositSender := range depositSenders {
+		entity := getEntity(depositSender.TxSender)
+		if depositSender.BlockTime >= new7dTime {
+			entity.New7d++
+		}
+		if depositSender.BlockTime >= new30dTime {
+			entity.New30d++
+		}
+
+		validatorIndex, found := services.GlobalBeaconService.GetValidatorIndexByPubkey(phase0.BLSPubKey(depositSender.PublicKey))
+		if found {
+			entityStats[validatorIndex] = &entity.EntityValidatorStats
+		}
+	}
+
+	aggregateEntityValidatorStats(entityStats)
+
+	entities := make([]*models.StakingEntitiesPageDataEntity, 0, len(entityMap))
+	for _, entity := range entityMap {
+		pageData.TotalValidators += entity.Validators
+		pageData.TotalStake += entity.EffectiveBalance
+		pageData.TotalNew7d += entity.New7d
+		pageData.TotalNew30d += entity.New30d
+		if entity.Labelled {
+			pageData.LabelledCount++
+		}
+		entities = append(entities, entity)
+	}
+
+	sort.Slice(entities, func(a, b int) bool {
+		if entities[a].EffectiveBalance != entities[b].EffectiveBalance {
+			return entities[a].EffectiveBalance > entities[b].EffectiveBalance
+		}
+		if entities[a].Validators != entities[b].Validators {
+			return entities[a].Validators > entities[b].Validators
+		}
+		return entities[a].Name < entities[b].Name
+	})
+
+	for _, entity := range entities {
+		if (entity.Labelled || showUnlabelled) && len(pageData.Entities) < stakingEntitiesMaxListed {
+			pageData.Entities = append(pageData.Entities, entity)
+		} else {
+			addStakingEntityStats(pageData.Others, entity)
+		}
+	}
+	pageData.EntityCount = uint64(len(pageData.Entities))
+
+	for _, entity := range append(pageData.Entities, pageData.Others) {
+		if pageData.TotalStake > 0 {
+			entity.StakeShare = float64(entity.EffectiveBalance) * 100 / float64(pageData.TotalStake)
+		}
+		if entity.Validators > entity.New30d {
+			entity.Growth30d = float64(entity.New30d) * 100 / float64(entity.Validators-entity.New30d)
+		}
+	}
+
+	return pageData, 10 * time.Minute
+}
+
+// addStakingEntityStats adds the deposit & validator stats of the entity to the aggregated target entity
+func addStakingEntityStats(target *models.StakingEntitiesPageDataEntity, entity *models.StakingEntitiesPageDataEntity) {
+	target.SenderCount += entity.SenderCount
+	target.DepositCount += entity.DepositCount
+	target.DepositAmount += entity.DepositAmount
+	target.New7d += entity.New7d
+	target.New30d += entity.New30d
+	if target.FirstDeposit.IsZero() || (!entity.FirstDeposit.IsZero() && entity.FirstDeposit.Before(target.FirstDeposit)) {
+		target.FirstDeposit = entity.FirstDeposit
+	}
+	if entity.LastDeposit.After(target.LastDeposit) {
+		target.LastDeposit = entity.LastDeposit
+	}
+
+	target.Validators += entity.Validators
+	target.Pending += entity.Pending
+	target.Activated += entity.Activated
+	target.Online += entity.Online
+	target.Offline += entity.Offline
+	target.Exited += entity.Exited
+	target.Slashed += entity.Slashed
+	target.EffectiveBalance += entity.EffectiveBalance
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// maxBroadcastFormSize is the maximum size of the submitted broadcast form
+const maxBroadcastFormSize = 1024 * 1024
+
+// SubmitBroadcast will validate and broadcast signed voluntary exits & bls to execution changes
+func SubmitBroadcast(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"submit_broadcast/submit_broadcast.html",
+	)
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+
+	if !utils.Config.Frontend.ShowSubmitBroadcast {
+		handlePageError(w, r, errors.New("broadcasting operations is not enabled"))
+		return
+	}
+
+	data := InitPageData(w, r, "validators", "/validators/broadcast", "Broadcast Operations", templateFiles)
+	pageData := &models.SubmitBroadcastPageData{}
+
+	if r.Method == http.MethodPost {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBroadcastFormSize)
+		if err := r.ParseForm(); err != nil {
+			handlePageError(w, r, err)
+			return
+		}
+
+		operationsJson := r.PostForm.Get("operations")
+		operations, parseErr := services.ParseBroadcastOperations([]byte(operationsJson))
+
+		err := services.GlobalCallRateLimiter.CheckCallLimit(r, services.GetBroadcastCallCost(len(operations)))
+		if err != nil {
+			handlePageError(w, r, err)
+			return
+		}
+
+		pageData = buildSubmitBroadcastPageData(r, operationsJson, operations, parseErr)
+	}
+
+	data.Data = pageData
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "submit_broadcast.go", "SubmitBroadcast", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func buildSubmitBroadcastPageData(r *http.Request, operationsJson string, operations []*services.BroadcastOperation, parseErr error) *models.SubmitBroadcastPageData {
+	pageData := &models.SubmitBroadcastPageData{
+		Operations: operationsJson,
+		Submitted:  true,
+	}
+	logrus.Debugf("broadcast operations submitted")
+
+	if parseErr != nil {
+		pageData.ParseError = parseErr.Error()
+		return pageData
+	}
+
+	for _, result := range services.GlobalBeaconService.BroadcastOperations(r.Context(), operations) {
+		operation := result.Operation
+		resultData := &models.SubmitBroadcastPageDataResult{
+			Type:           operation.Type.String(),
+			ValidatorIndex: uint64(operation.ValidatorIndex),
+			ValidatorName:  services.GlobalBeaconService.GetValidatorName(uint64(operation.ValidatorIndex)),
+		}
+
+		switch operation.Type {
+		case services.BroadcastOperationVoluntaryExit:
+			resultData.Epoch = uint64(operation.VoluntaryExit.Message.Epoch)
+		case services.BroadcastOperationBLSChange:
+			resultData.Address = operation.BLSChange.Message.ToExecutionAddress[:]
+		}
+
+		if result.ValidationError != nil {
+			resultData.ValidationError = result.ValidationError.Error()
+		}
+
+		for _, clientResult := range result.Clients {
+			resultData.Clients = append(resultData.Clients, &models.SubmitBroadcastPageDataClient{
+				Name:     clientResult.ClientName,
+				Accepted: clientResult.Accepted,
+				Error:    clientResult.Error,
+			})
+			if clientResult.Accepted {
+				resultData.AcceptedCount++
+			}
+		}
+		resultData.ClientCount = uint64(len(resultData.Clients))
+
+		pageData.Results = append(pageData.Results, resultData)
+	}
+	pageData.ResultCount = uint64(len(pageData.Results))
+
+	return pageData
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+)
+
+// maxValidateObjectFormSize is the maximum size of the submitted object validation form (hex encoded blocks can be large)
+const maxValidateObjectFormSize = 16 * 1024 * 1024
+
+// ValidateObject will decode & validate json or ssz encoded blocks, attestations, exits & deposits
+func ValidateObject(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"validate_object/validate_object.html",
+	)
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+
+	data := InitPageData(w, r, "blockchain", "/tools/validate_object", "Validate Object", templateFiles)
+	pageData := &models.ValidateObjectPageData{}
+
+	if r.Method == http.MethodPost {
+		err := services.GlobalCallRateLimiter.CheckCallLimit(r, 5)
+		if err != nil {
+			handlePageError(w, r, err)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxValidateObjectFormSize)
+		if err := r.ParseForm(); err != nil {
+			handlePageError(w, r, err)
+			return
+		}
+
+		pageData = buildValidateObjectPageData(r.PostForm.Get("objects"), r.PostForm.Get("type"))
+	}
+
+	data.Data = pageData
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validate_object.go", "ValidateObject", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func buildValidateObjectPageData(objects string, objectTypeName string) *models.ValidateObjectPageData {
+	pageData := &models.ValidateObjectPageData{
+		Objects:    objects,
+		ObjectType: objectTypeName,
+		Submitted:  true,
+	}
+	logrus.Debugf("validate object page called: %v", objectTypeName)
+
+	objectType, err := services.ParseValidationObjectType(objectTypeName)
+	if err != nil {
+		pageData.ParseError = err.Error()
+		return pageData
+	}
+
+	results, err := services.GlobalBeaconService.ValidateObjects([]byte(objects), objectType)
+	if err != nil {
+		pageData.ParseError = err.Error()
+		return pageData
+	}
+
+	for _, result := range results {
+		resultData := &models.ValidateObjectPageDataResult{
+			Index:    uint64(result.Index),
+			Type:     result.Type.String(),
+			Encoding: result.Encoding,
+			RootName: result.RootName,
+			Object:   result.Object,
+		}
+		if result.Type == services.ValidationObjectBlock || result.Type == services.ValidationObjectAttestation {
+			resultData.Fork = result.Version.String()
+		}
+		if result.Error != nil {
+			resultData.Error = result.Error.Error()
+		} else if result.RootName != "" {
+			resultData.Root = result.Root[:]
+		}
+
+		for _, field := range result.Fields {
+			resultData.Fields = append(resultData.Fields, &models.ValidateObjectPageDataField{
+				Name:  field.Name,
+				Value: field.Value,
+			})
+		}
+
+		for _, check := range result.Checks {
+			resultData.Checks = append(resultData.Checks, &models.ValidateObjectPageDataCheck{
+				Name:    check.Name,
+				Status:  check.Status.String(),
+				Message: check.Message,
+			})
+			if check.Status == services.ValidationCheckFailed {
+				resultData.FailedCount++
+			}
+		}
+
+		pageData.Results = append(pageData.Results, resultData)
+	}
+	pageData.ResultCount = uint64(len(pageData.Results))
+
+	return pageData
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"math"
@@ -21,6 +22,7 @@ import (
 	"github.com/ethpandaops/dora/services"
 	"github.com/ethpandaops/dora/templates"
 	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
 )
 
 // Validator will return the main "validator" page using a go template
@@ -32,6 +34,9 @@ func Validator(w http.ResponseWriter, r *http.Request) {
 		"validator/recentDeposits.html",
 		"validator/withdrawalRequests.html",
 		"validator/consolidationRequests.html",
+		"validator/rewards.html",
+		"validator/timeline.html",
+		"validator/effectiveBalances.html",
 		"validator/txDetails.html",
 		"_svg/timeline.html",
 	)
@@ -128,6 +133,7 @@ func buildValidatorPageData(validatorIndex uint64, tabView string) (*models.Vali
 		WithdrawCredentials: validator.Validator.WithdrawalCredentials,
 		TabView:             tabView,
 		ElectraIsActive:     specs.ElectraForkEpoch != nil && uint64(chainState.CurrentEpoch()) >= *specs.ElectraForkEpoch,
+		ShowRewards:         utils.Config.Rewards.Enabled,
 	}
 	if strings.HasPrefix(validator.Status.String(), "pending") {
 		pageData.State = "Pending"
@@ -154,6 +160,49 @@ func buildValidatorPageData(validatorIndex uint64, tabView string) (*models.Vali
 		pageData.UpcheckMaximum = uint8(3)
 	}
 
+	// load latest daily effectiveness ranking
+	if effectiveness, _ := db.GetValidatorEffectivenessHistory(validatorIndex, 1); len(effectiveness) > 0 {
+		pageData.ShowEffectiveness = true
+		pageData.Effectiveness = float64(effectiveness[0].Effectiveness) / 100
+		pageData.EffectivenessTopPercent = float64(effectiveness[0].TopPercent) / 100
+		pageData.EffectivenessEpochs = uint64(effectiveness[0].Epochs)
+		pageData.EffectivenessDate = chainState.GetGenesis().GenesisTime.Add(time.Duration(effectiveness[0].Day) * 24 * time.Hour)
+	}
+
+	// load latest relay registration
+	if registration := db.GetValidatorRegistration(validatorIndex); registration != nil {
+		pageData.ShowRegistration = true
+		pageData.RegistrationFeeRecipient = registration.FeeRecipient
+		pageData.RegistrationGasLimit = registration.GasLimit
+		pageData.RegistrationTs = time.Unix(int64(registration.Timestamp), 0)
+		for _, relay := range utils.Config.MevIndexer.Relays {
+			if registration.SeenbyRelays&(uint64(1)<<relay.Index) > 0 {
+				pageData.RegistrationRelays = append(pageData.RegistrationRelays, relay.Name)
+			}
+		}
+
+		// compare with the fee recipient observed in the last proposed block
+		// (the proposer fee recipient reported by the relay for mev blocks, as the block fee recipient is the builder)
+		if lastProposal := db.GetLastProposedSlot(validatorIndex, math.MaxInt64); lastProposal != nil && lastProposal.EthFeeRecipient != nil {
+			observedFeeRecipient := lastProposal.EthFeeRecipient
+			if mevBlock := db.GetMevBlockByBlockHash(lastProposal.EthBlockHash); mevBlock != nil && len(mevBlock.FeeRecipient) > 0 {
+				observedFeeRecipient = mevBlock.FeeRecipient
+			}
+
+			pageData.ObservedFeeRecipientSlot = lastProposal.Slot
+			pageData.ObservedFeeRecipient = observedFeeRecipient
+			pageData.FeeRecipientMismatch = !bytes.Equal(observedFeeRecipient, registration.FeeRecipient)
+		}
+	}
+
+	// load double proposals
+	for _, equivocation := range db.GetProposerEquivocationsByProposer(validatorIndex, 10) {
+		if len(pageData.EquivocationSlots) > 0 && pageData.EquivocationSlots[len(pageData.EquivocationSlots)-1] == equivocation.Slot {
+			continue
+		}
+		pageData.EquivocationSlots = append(pageData.EquivocationSlots, equivocation.Slot)
+	}
+
 	if validator.Validator.ActivationEligibilityEpoch < 18446744073709551615 {
 		pageData.ShowEligible = true
 		pageData.EligibleEpoch = uint64(validator.Validator.ActivationEligibilityEpoch)
@@ -309,10 +358,13 @@ func buildValidatorPageData(validatorIndex uint64, tabView string) (*models.Vali
 				BlockNumber: depositTx.BlockNumber,
 				BlockHash:   fmt.Sprintf("0x%x", depositTx.BlockRoot),
 				BlockTime:   depositTx.BlockTime,
-				TxOrigin:    common.Address(depositTx.TxSender).Hex(),
+				TxOrigin:    "redacted",
 				TxTarget:    common.Address(depositTx.TxTarget).Hex(),
 				TxHash:      fmt.Sprintf("0x%x", depositTx.TxHash),
 			}
+			if txSender := services.RedactDepositSender(depositTx.TxSender); txSender != nil {
+				txDetails.TxOrigin = common.Address(txSender).Hex()
+			}
 
 			return txDetails
 		}
@@ -430,6 +482,14 @@ func buildValidatorPageData(validatorIndex uint64, tabView string) (*models.Vali
 		}
 
 		pageData.RecentDepositCount = uint64(len(pageData.RecentDeposits))
+
+		// genesis validators have no deposit, show their genesis provenance instead
+		if genesisValidator := db.GetGenesisValidator(uint64(validator.Index)); genesisValidator != nil {
+			pageData.IsGenesis = true
+			pageData.GenesisEntity = genesisValidator.Entity
+			pageData.GenesisBalance = genesisValidator.Balance
+			pageData.GenesisWithdrawalCreds = genesisValidator.WithdrawalCredentials
+		}
 	}
 
 	// load recent withdrawal requests
@@ -597,6 +657,127 @@ func buildValidatorPageData(validatorIndex uint64, tabView string) (*models.Vali
 		pageData.ConsolidationRequestCount = uint64(len(pageData.ConsolidationRequests))
 	}
 
+	// load daily rewards history
+	if pageData.TabView == "rewards" && pageData.ShowRewards {
+		dbRewards, _ := db.GetValidatorRewardsHistory(validatorIndex, 30)
+		for _, dbReward := range dbRewards {
+			pageData.RecentRewards = append(pageData.RecentRewards, &models.ValidatorPageDataRewards{
+				Day:           dbReward.Day,
+				Date:          chainState.GetGenesis().GenesisTime.Add(time.Duration(dbReward.Day) * 24 * time.Hour),
+				Epochs:        uint64(dbReward.Epochs),
+				Attestation:   dbReward.Attestation,
+				SyncCommittee: dbReward.SyncCommittee,
+				Proposer:      dbReward.Proposer,
+				Total:         dbReward.Attestation + dbReward.SyncCommittee + dbReward.Proposer,
+			})
+		}
+		pageData.RecentRewardCount = uint64(len(pageData.RecentRewards))
+	}
+
+	// load lifecycle timeline
+	if pageData.TabView == "timeline" {
+		for _, entry := range services.GlobalBeaconService.GetValidatorTimeline(validator.Index) {
+			timelineEntry := &models.ValidatorPageDataTimelineEntry{
+				Type:        entry.Type.String(),
+				Slot:        entry.Slot,
+				HasSlot:     entry.HasSlot,
+				Epoch:       uint64(chainState.EpochOfSlot(phase0.Slot(entry.Slot))),
+				Time:        entry.Time,
+				Pending:     entry.Pending,
+				TxHash:      entry.TxHash,
+				BlockNumber: entry.BlockNumber,
+				Credentials: entry.Credentials,
+			}
+			if entry.Amount != nil {
+				timelineEntry.HasAmount = true
+				timelineEntry.Amount = *entry.Amount
+			}
+			if entry.Validator != nil {
+				timelineEntry.HasValidator = true
+				timelineEntry.Validator = *entry.Validator
+				timelineEntry.ValidatorName = services.GlobalBeaconService.GetValidatorName(*entry.Validator)
+			}
+
+			switch entry.Type {
+			case services.ValidatorTimelineGenesis:
+				timelineEntry.Title = "Genesis validator"
+			case services.ValidatorTimelineDepositTx:
+				timelineEntry.Title = "Deposit transaction"
+				if entry.DepositIndex != nil {
+					timelineEntry.Details = fmt.Sprintf("Deposit index %v", *entry.DepositIndex)
+				}
+			case services.ValidatorTimelineDeposit:
+				timelineEntry.Title = "Deposit included"
+				if entry.DepositIndex != nil {
+					timelineEntry.Details = fmt.Sprintf("Deposit index %v", *entry.DepositIndex)
+				}
+			case services.ValidatorTimelineEligible:
+				timelineEntry.Title = "Eligible for activation"
+			case services.ValidatorTimelineActivation:
+				timelineEntry.Title = "Activation"
+			case services.ValidatorTimelineCredentialChange:
+				timelineEntry.Title = "Withdrawal credentials changed"
+				if len(entry.Credentials) > 0 && entry.Credentials[0] == 0x02 {
+					timelineEntry.Details = "Switched to compounding credentials"
+				} else {
+					timelineEntry.Details = "BLS to execution change"
+				}
+			case services.ValidatorTimelineWithdrawalRequest:
+				if entry.Amount != nil && *entry.Amount == 0 {
+					timelineEntry.Title = "Full withdrawal request"
+				} else {
+					timelineEntry.Title = "Partial withdrawal request"
+				}
+				if !entry.Pending {
+					timelineEntry.Details = getWithdrawalResultMessage(entry.Result, specs)
+					timelineEntry.IsFailed = entry.Result != dbtypes.WithdrawalRequestResultSuccess
+				}
+			case services.ValidatorTimelineConsolidationRequest:
+				if entry.IsSource {
+					timelineEntry.Title = "Consolidation request (source)"
+				} else {
+					timelineEntry.Title = "Consolidation request (target)"
+				}
+				if !entry.Pending {
+					timelineEntry.Details = getConsolidationResultMessage(entry.Result, specs)
+					timelineEntry.IsFailed = entry.Result != dbtypes.ConsolidationRequestResultSuccess
+				}
+			case services.ValidatorTimelineSlashing:
+				timelineEntry.Title = "Slashed"
+				switch entry.SlashingReason {
+				case dbtypes.ProposerSlashing:
+					timelineEntry.Details = "Proposer slashing"
+				case dbtypes.AttesterSlashing:
+					timelineEntry.Details = "Attester slashing"
+				}
+			case services.ValidatorTimelineVoluntaryExit:
+				timelineEntry.Title = "Voluntary exit"
+			case services.ValidatorTimelineExit:
+				timelineEntry.Title = "Exit"
+			case services.ValidatorTimelineWithdrawable:
+				timelineEntry.Title = "Withdrawable"
+			}
+
+			pageData.Timeline = append(pageData.Timeline, timelineEntry)
+		}
+		pageData.TimelineCount = uint64(len(pageData.Timeline))
+	}
+
+	// load effective balance history
+	if pageData.TabView == "effectivebalance" {
+		for _, change := range db.GetEffectiveBalanceChangesByValidator(validatorIndex, 100) {
+			pageData.EffectiveBalanceChanges = append(pageData.EffectiveBalanceChanges, &models.ValidatorPageDataEffectiveBalanceChange{
+				Epoch:               change.Epoch,
+				Time:                chainState.EpochToTime(phase0.Epoch(change.Epoch)),
+				OldEffectiveBalance: change.OldEffectiveBalance,
+				NewEffectiveBalance: change.NewEffectiveBalance,
+				Change:              int64(change.NewEffectiveBalance) - int64(change.OldEffectiveBalance),
+				Compounding:         change.Compounding,
+			})
+		}
+		pageData.EffectiveBalanceChangeCount = uint64(len(pageData.EffectiveBalanceChanges))
+	}
+
 	// Check for exit reason if validator is exiting or has exited
 	if pageData.ShowExit {
 		zeroAmount := uint64(0)
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// ValidatorQueues will return the "activation & exit queues" page using a go template
+func ValidatorQueues(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"validator_queues/validator_queues.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/queues", "Validator Queues", templateFiles)
+
+	urlArgs := r.URL.Query()
+	filterValidator := strings.TrimSpace(urlArgs.Get("validator"))
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getValidatorQueuesPageData(filterValidator)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validator_queues.go", "ValidatorQueues", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getValidatorQueuesPageData(filterValidator string) (*models.ValidatorQueuesPageData, error) {
+	pageData := &models.ValidatorQueuesPageData{}
+	pageCacheKey := fmt.Sprintf("validators/queues:%v", filterValidator)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageCall.CacheTimeout = 1 * time.Minute
+		return buildValidatorQueuesPageData(filterValidator)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorQueuesPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorQueuesPageData(filterValidator string) *models.ValidatorQueuesPageData {
+	pageData := &models.ValidatorQueuesPageData{
+		FilterValidator: filterValidator,
+	}
+	logrus.Debugf("validator queues page called: %v", filterValidator)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+
+	var queueStats *services.ValidatorQueueStats
+	if filterValidator != "" {
+		validatorIndex, found := parseQueueValidator(filterValidator)
+		var queueEstimate *services.ValidatorQueueEstimate
+		if found {
+			queueEstimate, queueStats = services.GlobalBeaconService.GetValidatorQueueEstimate(validatorIndex)
+		}
+
+		if queueEstimate == nil {
+			pageData.ValidatorError = "validator not found"
+		} else {
+			validatorData := &models.ValidatorQueuesPageDataValidator{
+				Index:        uint64(validatorIndex),
+				Name:         services.GlobalBeaconService.GetValidatorName(uint64(validatorIndex)),
+				Queue:        queueEstimate.Queue.String(),
+				Position:     queueEstimate.Position,
+				BalanceAhead: uint64(queueEstimate.BalanceAhead),
+				Scheduled:    queueEstimate.Scheduled,
+			}
+			if queueEstimate.EstimatedEpoch != beacon.FarFutureEpoch {
+				validatorData.HasEstimate = true
+				validatorData.EstimatedEpoch = uint64(queueEstimate.EstimatedEpoch)
+				validatorData.EstimatedTime = chainState.EpochToTime(queueEstimate.EstimatedEpoch)
+				validatorData.EstimatedWait = formatQueueWaitTime(validatorData.EstimatedTime)
+			}
+			pageData.Validator = validatorData
+		}
+	}
+
+	if queueStats == nil {
+		queueStats = services.GlobalBeaconService.GetValidatorQueueStats()
+	}
+	if queueStats == nil {
+		return pageData
+	}
+
+	pageData.Epoch = uint64(queueStats.Epoch)
+	pageData.IsElectra = queueStats.IsElectra
+	pageData.HasStateQueues = queueStats.HasStateQueues
+	pageData.ActiveValidators = queueStats.ActiveValidators
+	pageData.TotalActiveBalance = uint64(queueStats.TotalActiveBalance)
+	pageData.ActivationChurn = queueStats.ActivationChurn
+	pageData.ExitChurn = queueStats.ExitChurn
+	pageData.BalanceChurn = uint64(queueStats.BalanceChurn)
+	pageData.ActivationQueueLength = queueStats.ActivationQueueLength
+	pageData.ExitQueueLength = queueStats.ExitQueueLength
+	pageData.ExitQueueBalance = uint64(queueStats.ExitQueueBalance)
+	pageData.PendingDeposits = queueStats.PendingDeposits
+	pageData.PendingDepositBalance = uint64(queueStats.PendingDepositBalance)
+	pageData.DepositBalanceToConsume = uint64(queueStats.DepositBalanceToConsume)
+	pageData.ExitBalanceToConsume = uint64(queueStats.ExitBalanceToConsume)
+	pageData.EarliestExitEpoch = uint64(queueStats.EarliestExitEpoch)
+	pageData.NewDepositEpoch = uint64(queueStats.NewDepositEpoch)
+	pageData.NewDepositTime = chainState.EpochToTime(queueStats.NewDepositEpoch)
+	pageData.NewActivationEpoch = uint64(queueStats.NewActivationEpoch)
+	pageData.NewActivationTime = chainState.EpochToTime(queueStats.NewActivationEpoch)
+	pageData.NewActivationWait = formatQueueWaitTime(pageData.NewActivationTime)
+	pageData.NewExitEpoch = uint64(queueStats.NewExitEpoch)
+	pageData.NewExitTime = chainState.EpochToTime(queueStats.NewExitEpoch)
+	pageData.NewExitWait = formatQueueWaitTime(pageData.NewExitTime)
+
+	return pageData
+}
+
+// parseQueueValidator resolves a validator index or hex encoded pubkey to a validator index
+func parseQueueValidator(validator string) (phase0.ValidatorIndex, bool) {
+	validatorPubKey, err := hex.DecodeString(strings.Replace(validator, "0x", "", -1))
+	if err == nil && len(validatorPubKey) == 48 {
+		return services.GlobalBeaconService.GetValidatorIndexByPubkey(phase0.BLSPubKey(validatorPubKey))
+	}
+
+	index, err := strconv.ParseUint(validator, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return phase0.ValidatorIndex(index), true
+}
+
+// formatQueueWaitTime returns the remaining time until the given time in days & hours
+func formatQueueWaitTime(until time.Time) string {
+	waitTime := time.Until(until)
+	if waitTime <= 0 {
+		return "0 days and 0 hours"
+	}
+
+	waitDays, waitFractionalDays := math.Modf(waitTime.Hours() / 24)
+	return fmt.Sprintf("%d days and %d hours", int(waitDays), int(waitFractionalDays*24))
+}
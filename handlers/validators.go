@@ -17,6 +17,7 @@ import (
 	"github.com/ethpandaops/dora/services"
 	"github.com/ethpandaops/dora/templates"
 	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -49,6 +50,11 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 	var filterIndex string
 	var filterName string
 	var filterStatus string
+	var filterMinBalance string
+	var filterMaxBalance string
+	var filterMinActivation string
+	var filterMaxActivation string
+	var filterCredentials string
 	if urlArgs.Has("f") {
 		if urlArgs.Has("f.pubkey") {
 			filterPubKey = urlArgs.Get("f.pubkey")
@@ -56,22 +62,38 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.index") {
 			filterIndex = urlArgs.Get("f.index")
 		}
-		if urlArgs.Has("f.name") {
+		if urlArgs.Has("f.name") && services.IsValidatorNameSearchAllowed() {
 			filterName = urlArgs.Get("f.name")
 		}
 		if urlArgs.Has("f.status") {
 			filterStatus = strings.Join(urlArgs["f.status"], ",")
 		}
+		if urlArgs.Has("f.minbal") {
+			filterMinBalance = urlArgs.Get("f.minbal")
+		}
+		if urlArgs.Has("f.maxbal") {
+			filterMaxBalance = urlArgs.Get("f.maxbal")
+		}
+		if urlArgs.Has("f.minact") {
+			filterMinActivation = urlArgs.Get("f.minact")
+		}
+		if urlArgs.Has("f.maxact") {
+			filterMaxActivation = urlArgs.Get("f.maxact")
+		}
+		if urlArgs.Has("f.creds") {
+			filterCredentials = urlArgs.Get("f.creds")
+		}
 	}
 	var sortOrder string
 	if urlArgs.Has("o") {
 		sortOrder = urlArgs.Get("o")
 	}
 
+	// large validator set exports are charged by page size
 	var pageError error
-	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1+uint(pageSize/1000))
 	if pageError == nil {
-		data.Data, pageError = getValidatorsPageData(pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+		data.Data, pageError = getValidatorsPageData(pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus, filterMinBalance, filterMaxBalance, filterMinActivation, filterMaxActivation, filterCredentials)
 	}
 	if pageError != nil {
 		handlePageError(w, r, pageError)
@@ -93,11 +115,11 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string) (*models.ValidatorsPageData, error) {
+func getValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string, filterMinBalance string, filterMaxBalance string, filterMinActivation string, filterMaxActivation string, filterCredentials string) (*models.ValidatorsPageData, error) {
 	pageData := &models.ValidatorsPageData{}
-	pageCacheKey := fmt.Sprintf("validators:%v:%v:%v:%v:%v:%v:%v", pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+	pageCacheKey := fmt.Sprintf("validators:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v", pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus, filterMinBalance, filterMaxBalance, filterMinActivation, filterMaxActivation, filterCredentials)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildValidatorsPageData(pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+		pageData, cacheTimeout := buildValidatorsPageData(pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus, filterMinBalance, filterMaxBalance, filterMinActivation, filterMaxActivation, filterCredentials)
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -111,20 +133,23 @@ func getValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder string,
 	return pageData, pageErr
 }
 
-func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string) (*models.ValidatorsPageData, time.Duration) {
-	logrus.Debugf("validators page called: %v:%v:%v:%v:%v:%v:%v", pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string, filterMinBalance string, filterMaxBalance string, filterMinActivation string, filterMaxActivation string, filterCredentials string) (*models.ValidatorsPageData, time.Duration) {
+	logrus.Debugf("validators page called: %v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v:%v", pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus, filterMinBalance, filterMaxBalance, filterMinActivation, filterMaxActivation, filterCredentials)
 	pageData := &models.ValidatorsPageData{}
 	cacheTime := 10 * time.Minute
 
 	chainState := services.GlobalBeaconService.GetChainState()
 
+	// the handler limits the page size to 1000 validators (10000 for json requests)
+	pageSize = initPagingDataWithMaxSize(&pageData.PagingData, pageNumber, pageSize, 10000)
+	pageOffset := (pageData.CurrentPageIndex - 1) * pageSize
 	validatorFilter := dbtypes.ValidatorFilter{
 		Limit:  pageSize,
-		Offset: (pageNumber - 1) * pageSize,
+		Offset: pageOffset,
 	}
 
 	filterArgs := url.Values{}
-	if filterPubKey != "" || filterIndex != "" || filterName != "" || filterStatus != "" {
+	if filterPubKey != "" || filterIndex != "" || filterName != "" || filterStatus != "" || filterMinBalance != "" || filterMaxBalance != "" || filterMinActivation != "" || filterMaxActivation != "" || filterCredentials != "" {
 		if filterPubKey != "" {
 			pageData.FilterPubKey = filterPubKey
 			filterArgs.Add("f.pubkey", filterPubKey)
@@ -156,6 +181,41 @@ func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder strin
 				}
 			}
 		}
+		if filterMinBalance != "" {
+			pageData.FilterMinBalance = filterMinBalance
+			filterArgs.Add("f.minbal", filterMinBalance)
+			filterMinBalanceVal, _ := strconv.ParseUint(filterMinBalance, 10, 64)
+			filterMinBalanceVal *= utils.GWEI.Uint64()
+			validatorFilter.MinBalance = &filterMinBalanceVal
+		}
+		if filterMaxBalance != "" {
+			pageData.FilterMaxBalance = filterMaxBalance
+			filterArgs.Add("f.maxbal", filterMaxBalance)
+			filterMaxBalanceVal, _ := strconv.ParseUint(filterMaxBalance, 10, 64)
+			filterMaxBalanceVal *= utils.GWEI.Uint64()
+			validatorFilter.MaxBalance = &filterMaxBalanceVal
+		}
+		if filterMinActivation != "" {
+			pageData.FilterMinActivation = filterMinActivation
+			filterArgs.Add("f.minact", filterMinActivation)
+			filterMinActivationVal, _ := strconv.ParseUint(filterMinActivation, 10, 64)
+			validatorFilter.MinActivation = &filterMinActivationVal
+		}
+		if filterMaxActivation != "" {
+			pageData.FilterMaxActivation = filterMaxActivation
+			filterArgs.Add("f.maxact", filterMaxActivation)
+			filterMaxActivationVal, _ := strconv.ParseUint(filterMaxActivation, 10, 64)
+			validatorFilter.MaxActivation = &filterMaxActivationVal
+		}
+		if filterCredentials != "" {
+			pageData.FilterCredentials = filterCredentials
+			filterArgs.Add("f.creds", filterCredentials)
+			filterCredentialsVal, _ := hex.DecodeString(strings.Replace(filterCredentials, "0x", "", -1))
+			if len(filterCredentialsVal) > 32 {
+				filterCredentialsVal = filterCredentialsVal[:32]
+			}
+			validatorFilter.CredentialsPrefix = filterCredentialsVal
+		}
 	}
 
 	// apply sort order
@@ -208,33 +268,6 @@ func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder strin
 		return strings.Compare(pageData.FilterStatusOpts[a].Status, pageData.FilterStatusOpts[b].Status) < 0
 	})
 
-	totalPages := validatorSetLen / pageSize
-	if (validatorSetLen % pageSize) > 0 {
-		totalPages++
-	}
-	if pageNumber == 0 {
-		pageData.IsDefaultPage = true
-	} else if pageNumber >= totalPages {
-		if totalPages == 0 {
-			pageNumber = 0
-		} else {
-			pageNumber = totalPages
-		}
-	}
-
-	pageData.PageSize = pageSize
-	pageData.TotalPages = totalPages
-	pageData.CurrentPageIndex = pageNumber
-	if pageNumber > 1 {
-		pageData.PrevPageIndex = pageNumber - 1
-	}
-	if pageNumber < totalPages {
-		pageData.NextPageIndex = pageNumber + 1
-	}
-	if totalPages > 1 {
-		pageData.LastPageIndex = totalPages
-	}
-
 	// get validators
 	pageData.Validators = make([]*models.ValidatorsPageDataValidator, 0)
 
@@ -292,9 +325,15 @@ func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder strin
 		pageData.Validators = append(pageData.Validators, validatorData)
 	}
 	pageData.ValidatorCount = validatorSetLen
-	pageData.FirstValidator = pageNumber * pageSize
+	pageData.FirstValidator = pageOffset
 	pageData.LastValidator = pageData.FirstValidator + uint64(len(pageData.Validators))
 	pageData.FilteredPageLink = fmt.Sprintf("/validators?f&%v&c=%v", filterArgs.Encode(), pageData.PageSize)
 
+	baseLink := fmt.Sprintf("/validators?f&%v", filterArgs.Encode())
+	if !pageData.IsDefaultSorting {
+		baseLink += fmt.Sprintf("&o=%v", pageData.Sorting)
+	}
+	finalizePagingData(&pageData.PagingData, validatorSetLen, baseLink)
+
 	return pageData, cacheTime
 }
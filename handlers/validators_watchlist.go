@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// name of the cookie that holds the personal watchlist (comma separated index ranges)
+const watchlistCookieName = "dora_watchlist"
+
+// maximum number of validators shown on the watchlist dashboard
+const maxWatchlistValidators = 10000
+
+// number of days the proposal stats on the watchlist dashboard cover
+const watchlistStatsDays = 7
+
+// ValidatorsWatchlist will return the dashboard for a configured or personal validator watchlist using a go template
+func ValidatorsWatchlist(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"validators_watchlist/validators_watchlist.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/watchlist", "Validator Watchlist", templateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+		if pageIdx < 1 {
+			pageIdx = 1
+		}
+	}
+
+	listName := urlArgs.Get("list")
+	personalList := ""
+	if listName == "" {
+		if urlArgs.Has("f") {
+			// update the personal watchlist cookie
+			personalList = strings.TrimSpace(urlArgs.Get("f.validators"))
+			cookie := &http.Cookie{
+				Name:     watchlistCookieName,
+				Value:    personalList,
+				Path:     "/",
+				MaxAge:   365 * 24 * 60 * 60,
+				SameSite: http.SameSiteLaxMode,
+			}
+			if personalList == "" {
+				cookie.MaxAge = -1
+			}
+			if _, err := services.ParseWatchlist("", personalList); err == nil {
+				http.SetCookie(w, cookie)
+			}
+		} else if cookie, err := r.Cookie(watchlistCookieName); err == nil {
+			personalList = cookie.Value
+		}
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getValidatorsWatchlistPageData(listName, personalList, pageIdx, pageSize)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validators_watchlist.go", "ValidatorsWatchlist", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getValidatorsWatchlistPageData(listName string, personalList string, pageIdx uint64, pageSize uint64) (*models.ValidatorsWatchlistPageData, error) {
+	pageData := &models.ValidatorsWatchlistPageData{}
+	pageCacheKey := fmt.Sprintf("validators_watchlist:%v:%v:%v:%v", listName, personalList, pageIdx, pageSize)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildValidatorsWatchlistPageData(listName, personalList, pageIdx, pageSize)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorsWatchlistPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorsWatchlistPageData(listName string, personalList string, pageIdx uint64, pageSize uint64) (*models.ValidatorsWatchlistPageData, time.Duration) {
+	logrus.Debugf("validators watchlist page called: %v:%v [%v,%v]", pageIdx, pageSize, listName, personalList)
+
+	pageData := &models.ValidatorsWatchlistPageData{
+		ListName:       listName,
+		IsPersonalList: listName == "",
+		MaxValidators:  maxWatchlistValidators,
+		StatsDays:      watchlistStatsDays,
+	}
+	for _, listConfig := range utils.Config.Watchlists.Lists {
+		pageData.ConfiguredLists = append(pageData.ConfiguredLists, listConfig.Name)
+	}
+	pageSize = initPagingData(&pageData.PagingData, pageIdx, pageSize)
+
+	// resolve watchlist
+	var watchlist *services.Watchlist
+	if listName != "" {
+		watchlist = services.GetConfiguredWatchlist(listName)
+	} else {
+		watchlist, _ = services.ParseWatchlist("", personalList)
+	}
+	if watchlist == nil {
+		pageData.InvalidList = true
+		return pageData, 1 * time.Minute
+	}
+	pageData.ListValidators = watchlist.String()
+
+	validators := watchlist.GetValidators(maxWatchlistValidators + 1)
+	if len(validators) > maxWatchlistValidators {
+		validators = validators[:maxWatchlistValidators]
+		pageData.IsTruncated = true
+	}
+	if len(validators) == 0 {
+		return pageData, 1 * time.Minute
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	currentSlot := chainState.CurrentSlot()
+	currentEpoch := chainState.CurrentEpoch()
+	beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
+
+	watchedMap := make(map[phase0.ValidatorIndex]bool, len(validators))
+	for _, validator := range validators {
+		watchedMap[validator.Index] = true
+	}
+
+	// current sync committee
+	syncCommitteeMap := map[phase0.ValidatorIndex]bool{}
+	if epochStatsValues := beaconIndexer.GetEpochStats(currentEpoch, nil).GetValues(false); epochStatsValues != nil {
+		for _, validatorIndex := range epochStatsValues.SyncCommitteeDuties {
+			syncCommitteeMap[validatorIndex] = true
+		}
+	}
+
+	// upcoming proposals in the current & next epoch
+	for epoch := currentEpoch; epoch <= currentEpoch+1; epoch++ {
+		epochStatsValues := beaconIndexer.GetEpochStats(epoch, nil).GetValues(false)
+		if epochStatsValues == nil {
+			continue
+		}
+
+		firstSlot := chainState.EpochToSlot(epoch)
+		for slotIdx, validatorIndex := range epochStatsValues.ProposerDuties {
+			slot := firstSlot + phase0.Slot(slotIdx)
+			if slot < currentSlot || !watchedMap[validatorIndex] {
+				continue
+			}
+
+			pageData.UpcomingProposals = append(pageData.UpcomingProposals, &models.ValidatorsWatchlistPageDataDuty{
+				Slot:          uint64(slot),
+				Time:          chainState.SlotToTime(slot),
+				Validator:     uint64(validatorIndex),
+				ValidatorName: services.GlobalBeaconService.GetValidatorName(uint64(validatorIndex)),
+			})
+		}
+	}
+
+	// validator summary
+	validatorDatas := make([]*models.ValidatorsWatchlistPageDataValidator, len(validators))
+	for idx, validator := range validators {
+		validatorData := &models.ValidatorsWatchlistPageDataValidator{
+			Index:            uint64(validator.Index),
+			Name:             services.GlobalBeaconService.GetValidatorName(uint64(validator.Index)),
+			Balance:          uint64(validator.Balance),
+			EffectiveBalance: uint64(validator.Validator.EffectiveBalance),
+			InSyncCommittee:  syncCommitteeMap[validator.Index],
+		}
+
+		if strings.HasPrefix(validator.Status.String(), "pending") {
+			validatorData.State = "Pending"
+		} else if validator.Status == v1.ValidatorStateActiveOngoing {
+			validatorData.State = "Active"
+			validatorData.ShowUpcheck = true
+		} else if validator.Status == v1.ValidatorStateActiveExiting {
+			validatorData.State = "Exiting"
+			validatorData.ShowUpcheck = true
+		} else if validator.Status == v1.ValidatorStateActiveSlashed {
+			validatorData.State = "Slashed"
+			validatorData.ShowUpcheck = true
+		} else if validator.Status == v1.ValidatorStateExitedUnslashed {
+			validatorData.State = "Exited"
+		} else if validator.Status == v1.ValidatorStateExitedSlashed {
+			validatorData.State = "Slashed"
+		} else {
+			validatorData.State = validator.Status.String()
+		}
+
+		if validatorData.ShowUpcheck {
+			validatorData.UpcheckActivity = uint8(services.GlobalBeaconService.GetValidatorLiveness(validator.Index, 3))
+			validatorData.UpcheckMaximum = uint8(3)
+
+			pageData.ActiveCount++
+			if validatorData.UpcheckActivity > 0 {
+				pageData.OnlineCount++
+			}
+		}
+		if validatorData.InSyncCommittee {
+			pageData.SyncCommittee++
+		}
+
+		pageData.TotalBalance += validatorData.Balance
+		pageData.TotalEffBalance += validatorData.EffectiveBalance
+		validatorDatas[idx] = validatorData
+	}
+	pageData.ValidatorCount = uint64(len(validatorDatas))
+
+	// current page
+	pageOffset := (pageIdx - 1) * pageSize
+	if pageOffset < uint64(len(validatorDatas)) {
+		pageEnd := pageOffset + pageSize
+		if pageEnd > uint64(len(validatorDatas)) {
+			pageEnd = uint64(len(validatorDatas))
+		}
+		pageData.Validators = validatorDatas[pageOffset:pageEnd]
+	}
+
+	// recent performance of the validators on the current page
+	pageIndices := make([]uint64, len(pageData.Validators))
+	pageValidatorMap := make(map[uint64]*models.ValidatorsWatchlistPageDataValidator, len(pageData.Validators))
+	for idx, validatorData := range pageData.Validators {
+		pageIndices[idx] = validatorData.Index
+		pageValidatorMap[validatorData.Index] = validatorData
+	}
+
+	statsMinSlot := uint64(0)
+	if statsSlot := chainState.TimeToSlot(time.Now().Add(-watchlistStatsDays * 24 * time.Hour)); statsSlot > 0 {
+		statsMinSlot = uint64(statsSlot)
+	}
+	proposalStats, _ := db.GetValidatorProposalStats(pageIndices, statsMinSlot)
+	for _, stats := range proposalStats {
+		if validatorData := pageValidatorMap[stats.Proposer]; validatorData != nil {
+			validatorData.Proposed = stats.Proposed
+			validatorData.Orphaned = stats.Orphaned
+			validatorData.Missed = stats.Missed
+		}
+	}
+
+	effectivenessScores, _ := db.GetLatestValidatorEffectiveness(pageIndices)
+	for _, score := range effectivenessScores {
+		if validatorData := pageValidatorMap[score.ValidatorIndex]; validatorData != nil {
+			validatorData.HasEffectiveness = true
+			validatorData.Effectiveness = float64(score.Effectiveness) / 100
+		}
+	}
+
+	listArgs := url.Values{}
+	if listName != "" {
+		listArgs.Add("list", listName)
+	}
+	finalizePagingData(&pageData.PagingData, pageData.ValidatorCount, fmt.Sprintf("/validators/watchlist?%v", listArgs.Encode()))
+
+	return pageData, 1 * time.Minute
+}
@@ -0,0 +1,271 @@
+package beacon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// AnomalyHook is called when an unexpected change of a watchlisted validator is detected.
+// hooks are called synchronously from the finalization routine, so they must not block.
+type AnomalyHook func(anomaly *dbtypes.ValidatorAnomaly)
+
+// WatchlistResolver returns true if the validator is watched for anomalies.
+type WatchlistResolver func(validatorIndex phase0.ValidatorIndex) bool
+
+// anomalyTracker detects fee recipient, withdrawal credential & graffiti pattern changes of watchlisted validators in finalized blocks.
+// fee recipient & graffiti changes are detected by comparing each proposal with the previous proposal of the validator,
+// withdrawal credential changes are detected from bls changes & switch to compounding requests included in the blocks.
+type anomalyTracker struct {
+	indexer   *Indexer
+	mutex     sync.Mutex
+	watchlist WatchlistResolver
+	hooks     []AnomalyHook
+	proposals map[phase0.ValidatorIndex]*anomalyProposal
+}
+
+// anomalyProposal holds the values of the last known proposal of a validator.
+type anomalyProposal struct {
+	feeRecipient    []byte // nil for pre-merge blocks
+	graffiti        []byte
+	graffitiPattern string
+}
+
+// newAnomalyTracker creates & returns a new instance of anomalyTracker.
+func newAnomalyTracker(indexer *Indexer) *anomalyTracker {
+	return &anomalyTracker{
+		indexer:   indexer,
+		proposals: map[phase0.ValidatorIndex]*anomalyProposal{},
+	}
+}
+
+// SetAnomalyWatchlist sets the function used to check whether a validator is watched for anomalies.
+func (indexer *Indexer) SetAnomalyWatchlist(watchlist WatchlistResolver) {
+	if indexer.anomalyTracker == nil {
+		return
+	}
+
+	indexer.anomalyTracker.mutex.Lock()
+	defer indexer.anomalyTracker.mutex.Unlock()
+
+	indexer.anomalyTracker.watchlist = watchlist
+}
+
+// AddAnomalyHook adds a hook that is called when an anomaly of a watchlisted validator is detected.
+func (indexer *Indexer) AddAnomalyHook(hook AnomalyHook) {
+	if indexer.anomalyTracker == nil {
+		return
+	}
+
+	indexer.anomalyTracker.mutex.Lock()
+	defer indexer.anomalyTracker.mutex.Unlock()
+
+	indexer.anomalyTracker.hooks = append(indexer.anomalyTracker.hooks, hook)
+}
+
+// processEpoch checks the canonical blocks of the finalized epoch for anomalies of watchlisted validators.
+func (tracker *anomalyTracker) processEpoch(epoch phase0.Epoch, canonicalBlocks []*Block) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if tracker.watchlist == nil {
+		return
+	}
+
+	anomalies := []*dbtypes.ValidatorAnomaly{}
+	for _, block := range canonicalBlocks {
+		anomalies = append(anomalies, tracker.getBlockAnomalies(block)...)
+	}
+
+	if len(anomalies) == 0 {
+		return
+	}
+
+	_, err := tracker.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertValidatorAnomalies(anomalies, tx)
+	})
+	if err != nil {
+		tracker.indexer.logger.Errorf("failed persisting validator anomalies for epoch %v: %v", epoch, err)
+		return
+	}
+
+	for _, anomaly := range anomalies {
+		tracker.indexer.logger.Warnf("validator anomaly detected: validator %v changed %v in slot %v", anomaly.ValidatorIndex, getAnomalyTypeName(anomaly.Type), anomaly.Slot)
+
+		for _, hook := range tracker.hooks {
+			anomalyCopy := *anomaly
+			hook(&anomalyCopy)
+		}
+	}
+}
+
+// getBlockAnomalies returns the anomalies of watchlisted validators in a single block.
+func (tracker *anomalyTracker) getBlockAnomalies(block *Block) []*dbtypes.ValidatorAnomaly {
+	header := block.GetHeader()
+	blockBody := block.GetBlock()
+	if header == nil || blockBody == nil {
+		return nil
+	}
+
+	anomalies := []*dbtypes.ValidatorAnomaly{}
+
+	// withdrawal credential changes via bls changes (0x00 -> 0x01)
+	blsChanges, _ := blockBody.BLSToExecutionChanges()
+	for _, blsChange := range blsChanges {
+		if !tracker.watchlist(blsChange.Message.ValidatorIndex) {
+			continue
+		}
+
+		pubkeyHash := sha256.Sum256(blsChange.Message.FromBLSPubkey[:])
+		beforeCredentials := append([]byte{0x00}, pubkeyHash[1:]...)
+		afterCredentials := make([]byte, 32)
+		afterCredentials[0] = 0x01
+		copy(afterCredentials[12:], blsChange.Message.ToExecutionAddress[:])
+
+		anomalies = append(anomalies, &dbtypes.ValidatorAnomaly{
+			ValidatorIndex: uint64(blsChange.Message.ValidatorIndex),
+			Slot:           uint64(block.Slot),
+			Type:           dbtypes.ValidatorAnomalyWithdrawalCredentials,
+			BeforeValue:    beforeCredentials,
+			AfterValue:     afterCredentials,
+		})
+	}
+
+	// withdrawal credential changes via switch to compounding requests (0x01 -> 0x02)
+	executionRequests, _ := blockBody.ExecutionRequests()
+	if executionRequests != nil {
+		for _, consolidation := range executionRequests.Consolidations {
+			if consolidation.SourcePubkey != consolidation.TargetPubkey {
+				continue
+			}
+
+			validatorIndex, found := tracker.indexer.GetValidatorIndexByPubkey(consolidation.SourcePubkey)
+			if !found || !tracker.watchlist(validatorIndex) {
+				continue
+			}
+
+			// skip requests of validators without execution credentials, these requests are invalid
+			validator := tracker.indexer.GetValidatorByIndex(validatorIndex, nil)
+			if validator == nil || len(validator.WithdrawalCredentials) != 32 || validator.WithdrawalCredentials[0] == 0x00 {
+				continue
+			}
+
+			beforeCredentials := append([]byte{0x01}, validator.WithdrawalCredentials[1:]...)
+			afterCredentials := append([]byte{0x02}, validator.WithdrawalCredentials[1:]...)
+			anomalies = append(anomalies, &dbtypes.ValidatorAnomaly{
+				ValidatorIndex: uint64(validatorIndex),
+				Slot:           uint64(block.Slot),
+				Type:           dbtypes.ValidatorAnomalyWithdrawalCredentials,
+				BeforeValue:    beforeCredentials,
+				AfterValue:     afterCredentials,
+			})
+		}
+	}
+
+	// fee recipient & graffiti changes of the proposer
+	proposer := header.Message.ProposerIndex
+	if !tracker.watchlist(proposer) {
+		return anomalies
+	}
+
+	graffiti, _ := blockBody.Graffiti()
+	proposal := &anomalyProposal{
+		graffiti:        graffiti[:],
+		graffitiPattern: getGraffitiPattern(utils.GraffitiToString(graffiti[:])),
+	}
+	if executionBlockNumber, _ := blockBody.ExecutionBlockNumber(); executionBlockNumber > 0 {
+		feeRecipient, _ := getBlockExecutionFeeRecipient(blockBody)
+		proposal.feeRecipient = feeRecipient[:]
+	}
+
+	lastProposal := tracker.getLastProposal(proposer, block.Slot)
+	tracker.proposals[proposer] = proposal
+	if lastProposal == nil {
+		return anomalies
+	}
+
+	if lastProposal.feeRecipient != nil && proposal.feeRecipient != nil && !bytes.Equal(lastProposal.feeRecipient, proposal.feeRecipient) {
+		anomalies = append(anomalies, &dbtypes.ValidatorAnomaly{
+			ValidatorIndex: uint64(proposer),
+			Slot:           uint64(block.Slot),
+			Type:           dbtypes.ValidatorAnomalyFeeRecipient,
+			BeforeValue:    lastProposal.feeRecipient,
+			AfterValue:     proposal.feeRecipient,
+		})
+	}
+
+	if lastProposal.graffitiPattern != proposal.graffitiPattern {
+		anomalies = append(anomalies, &dbtypes.ValidatorAnomaly{
+			ValidatorIndex: uint64(proposer),
+			Slot:           uint64(block.Slot),
+			Type:           dbtypes.ValidatorAnomalyGraffiti,
+			BeforeValue:    lastProposal.graffiti,
+			AfterValue:     proposal.graffiti,
+		})
+	}
+
+	return anomalies
+}
+
+// getLastProposal returns the last known proposal of a validator before the given slot.
+// proposals that are not tracked since startup are loaded from the db.
+func (tracker *anomalyTracker) getLastProposal(proposer phase0.ValidatorIndex, slot phase0.Slot) *anomalyProposal {
+	if proposal := tracker.proposals[proposer]; proposal != nil {
+		return proposal
+	}
+
+	dbSlot := db.GetLastProposedSlot(uint64(proposer), uint64(slot))
+	if dbSlot == nil {
+		return nil
+	}
+
+	proposal := &anomalyProposal{
+		graffiti:        dbSlot.Graffiti,
+		graffitiPattern: getGraffitiPattern(dbSlot.GraffitiText),
+	}
+	if dbSlot.EthBlockNumber != nil && len(dbSlot.EthFeeRecipient) > 0 {
+		proposal.feeRecipient = dbSlot.EthFeeRecipient
+	}
+
+	return proposal
+}
+
+// getGraffitiPattern returns the static part of a graffiti.
+// words containing digits (versions, commit hashes, counters) are ignored, so client updates are not reported as changes.
+func getGraffitiPattern(graffiti string) string {
+	words := strings.FieldsFunc(strings.ToLower(graffiti), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	patternWords := make([]string, 0, len(words))
+	for _, word := range words {
+		if strings.IndexFunc(word, unicode.IsDigit) >= 0 {
+			continue
+		}
+		patternWords = append(patternWords, word)
+	}
+
+	return strings.Join(patternWords, " ")
+}
+
+// getAnomalyTypeName returns a readable name of the anomaly type.
+func getAnomalyTypeName(anomalyType dbtypes.ValidatorAnomalyType) string {
+	switch anomalyType {
+	case dbtypes.ValidatorAnomalyFeeRecipient:
+		return "fee recipient"
+	case dbtypes.ValidatorAnomalyWithdrawalCredentials:
+		return "withdrawal credentials"
+	case dbtypes.ValidatorAnomalyGraffiti:
+		return "graffiti"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,139 @@
+package beacon
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// attestationMissTracker attributes the missed & late attestations of finalized epochs to their probable cause.
+// a miss is attributed to a late block if the block of the attestation slot is missing or has been seen by the
+// connected clients after the attestation deadline (1/3 of the slot), otherwise the attester is assumed to be offline / slow.
+// blocks without arrival times (e.g. loaded during backfill) can't be judged, so their misses are attributed to the attester.
+type attestationMissTracker struct {
+	indexer        *Indexer
+	mutex          sync.Mutex
+	entityResolver EntityResolver
+}
+
+// newAttestationMissTracker creates & returns a new instance of attestationMissTracker.
+func newAttestationMissTracker(indexer *Indexer) *attestationMissTracker {
+	return &attestationMissTracker{
+		indexer: indexer,
+	}
+}
+
+// processEpoch attributes the missed & late attestations of the finalized epoch and persists the counts per entity.
+func (tracker *attestationMissTracker) processEpoch(epoch phase0.Epoch, epochStatsValues *EpochStatsValues, canonicalBlocks []*Block) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if tracker.entityResolver == nil || len(epochStatsValues.AttesterDuties) == 0 {
+		return
+	}
+
+	chainState := tracker.indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	attestationDeadline := specs.SecondsPerSlot / 3
+	firstSlot := chainState.EpochToSlot(epoch)
+
+	canonicalMap := make(map[*Block]bool, len(canonicalBlocks))
+	lateSlots := make([]bool, len(epochStatsValues.AttesterDuties))
+	for idx := range lateSlots {
+		lateSlots[idx] = true
+	}
+	for _, block := range canonicalBlocks {
+		canonicalMap[block] = true
+
+		slotIdx := int(block.Slot - firstSlot)
+		if block.Slot < firstSlot || slotIdx >= len(lateSlots) {
+			continue
+		}
+
+		// the block is late if the majority of the clients have seen it after the attestation deadline
+		arrivals := block.GetArrivals()
+		if len(arrivals) == 0 {
+			lateSlots[slotIdx] = false
+			continue
+		}
+
+		medianDelay := arrivals[len(arrivals)/2].SeenTime.Sub(chainState.SlotToTime(block.Slot))
+		lateSlots[slotIdx] = medianDelay > attestationDeadline
+	}
+
+	// attestation slot of each active validator
+	dutySlots := make([]uint8, len(epochStatsValues.ActiveIndices))
+	for slotIdx, committees := range epochStatsValues.AttesterDuties {
+		for _, committee := range committees {
+			for _, activeIdx := range committee {
+				if int(activeIdx) < len(dutySlots) {
+					dutySlots[activeIdx] = uint8(slotIdx)
+				}
+			}
+		}
+	}
+
+	entityMisses := map[string]*dbtypes.AttestationMisses{}
+	for idx, validatorIndex := range epochStatsValues.ActiveIndices {
+		voted := false
+		inclusionDelay := uint16(0)
+		for _, activity := range tracker.indexer.validatorActivity.getValidatorActivity(validatorIndex) {
+			if !canonicalMap[activity.VoteBlock] || chainState.EpochOfSlot(activity.VoteBlock.Slot-phase0.Slot(activity.VoteDelay)) != epoch {
+				continue
+			}
+
+			if !voted || activity.VoteDelay < inclusionDelay {
+				inclusionDelay = activity.VoteDelay
+			}
+			voted = true
+		}
+		if voted && inclusionDelay <= 1 {
+			continue
+		}
+
+		entity := tracker.entityResolver(validatorIndex)
+		if entity == "" {
+			continue
+		}
+
+		misses := entityMisses[entity]
+		if misses == nil {
+			misses = &dbtypes.AttestationMisses{
+				Epoch:  uint64(epoch),
+				Entity: entity,
+			}
+			entityMisses[entity] = misses
+		}
+
+		lateBlock := lateSlots[dutySlots[idx]]
+		switch {
+		case !voted && lateBlock:
+			misses.MissedLateBlock++
+		case !voted:
+			misses.MissedAttester++
+		case lateBlock:
+			misses.DelayedLateBlock++
+		default:
+			misses.DelayedAttester++
+		}
+	}
+
+	if len(entityMisses) == 0 {
+		return
+	}
+
+	misses := make([]*dbtypes.AttestationMisses, 0, len(entityMisses))
+	for _, entityMiss := range entityMisses {
+		misses = append(misses, entityMiss)
+	}
+
+	_, err := tracker.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertAttestationMisses(misses, tx)
+	})
+	if err != nil {
+		tracker.indexer.logger.Errorf("failed persisting attestation misses for epoch %v: %v", epoch, err)
+	}
+}
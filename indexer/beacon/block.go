@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"math/rand/v2"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	dynssz "github.com/pk910/dynamic-ssz"
@@ -36,6 +39,8 @@ type Block struct {
 	processingStatus  dbtypes.UnfinalizedBlockStatus
 	seenMutex         sync.RWMutex
 	seenMap           map[uint16]*Client
+	seenTimes         map[uint16]time.Time // time each client first announced the block via the event stream
+	payloadStatuses   map[uint16]dbtypes.PayloadStatus
 	processedActivity uint8
 	blockResults      [][]uint8
 	blockResultsMutex sync.Mutex
@@ -44,10 +49,11 @@ type Block struct {
 // BlockBodyIndex holds important block properties that are used as index for cache lookups.
 // this structure should be preserved after pruning, so the block is still identifiable.
 type BlockBodyIndex struct {
-	Graffiti           [32]byte
-	ExecutionExtraData []byte
-	ExecutionHash      phase0.Hash32
-	ExecutionNumber    uint64
+	Graffiti              [32]byte
+	ExecutionExtraData    []byte
+	ExecutionHash         phase0.Hash32
+	ExecutionNumber       uint64
+	ExecutionFeeRecipient bellatrix.ExecutionAddress
 }
 
 // newBlock creates a new Block instance.
@@ -57,6 +63,7 @@ func newBlock(dynSsz *dynssz.DynSsz, root phase0.Root, slot phase0.Slot) *Block
 		Slot:       slot,
 		dynSsz:     dynSsz,
 		seenMap:    make(map[uint16]*Client),
+		seenTimes:  make(map[uint16]time.Time),
 		headerChan: make(chan bool),
 		blockChan:  make(chan bool),
 	}
@@ -70,6 +77,8 @@ func (block *Block) Dispose() {
 	block.block = nil
 	block.blockIndex = nil
 	block.seenMap = nil
+	block.seenTimes = nil
+	block.payloadStatuses = nil
 }
 
 // GetSeenBy returns a list of clients that have seen this block.
@@ -95,7 +104,8 @@ func (block *Block) GetSeenBy() []*Client {
 }
 
 // SetSeenBy sets the client that has seen this block.
-func (block *Block) SetSeenBy(client *Client) {
+// the seen time is the time the client announced the block, only the first announcement of each client is kept (zero if not announced via stream).
+func (block *Block) SetSeenBy(client *Client, seenTime time.Time) {
 	if block.isDisposed {
 		return
 	}
@@ -103,6 +113,173 @@ func (block *Block) SetSeenBy(client *Client) {
 	block.seenMutex.Lock()
 	defer block.seenMutex.Unlock()
 	block.seenMap[client.index] = client
+
+	if seenTime.IsZero() {
+		return
+	}
+	if lastSeenTime, exists := block.seenTimes[client.index]; !exists || seenTime.Before(lastSeenTime) {
+		block.seenTimes[client.index] = seenTime
+	}
+}
+
+// BlockArrival holds the time a client first announced a block.
+type BlockArrival struct {
+	Client   *Client
+	SeenTime time.Time
+}
+
+// GetArrivals returns the announcement times of all clients that have announced this block via stream, sorted by seen time.
+func (block *Block) GetArrivals() []*BlockArrival {
+	if block.isDisposed {
+		return nil
+	}
+
+	block.seenMutex.RLock()
+	defer block.seenMutex.RUnlock()
+
+	arrivals := make([]*BlockArrival, 0, len(block.seenTimes))
+	for clientIndex, seenTime := range block.seenTimes {
+		arrivals = append(arrivals, &BlockArrival{
+			Client:   block.seenMap[clientIndex],
+			SeenTime: seenTime,
+		})
+	}
+
+	sort.Slice(arrivals, func(i, j int) bool {
+		return arrivals[i].SeenTime.Before(arrivals[j].SeenTime)
+	})
+
+	return arrivals
+}
+
+// GetDbArrivals returns the arrival delays of this block relative to the slot start, as announced by the connected clients via event stream.
+// returns nil if the block has not been announced by any client (e.g. loaded during backfill).
+func (block *Block) GetDbArrivals(chainState *consensus.ChainState) (*dbtypes.BlockArrival, []*dbtypes.BlockArrivalClient) {
+	blockArrivals := block.GetArrivals()
+	header := block.GetHeader()
+	if len(blockArrivals) == 0 || header == nil {
+		return nil, nil
+	}
+
+	slotTime := chainState.SlotToTime(block.Slot)
+	delays := make([]int64, len(blockArrivals))
+	clientArrivals := make([]*dbtypes.BlockArrivalClient, len(blockArrivals))
+	for i, arrival := range blockArrivals {
+		delays[i] = arrival.SeenTime.Sub(slotTime).Milliseconds()
+
+		clientArrivals[i] = &dbtypes.BlockArrivalClient{
+			Slot:     uint64(block.Slot),
+			Client:   arrival.Client.client.GetName(),
+			ClientId: arrival.Client.client.GetClientId(),
+			Delay:    delays[i],
+		}
+	}
+
+	// arrivals are sorted by seen time, so the delays are sorted too
+	medianDelay := delays[len(delays)/2]
+	if len(delays)%2 == 0 {
+		medianDelay = (delays[len(delays)/2-1] + delays[len(delays)/2]) / 2
+	}
+
+	return &dbtypes.BlockArrival{
+		Slot:        uint64(block.Slot),
+		Proposer:    uint64(header.Message.ProposerIndex),
+		SeenBy:      uint32(len(delays)),
+		MinDelay:    delays[0],
+		MedianDelay: medianDelay,
+		MaxDelay:    delays[len(delays)-1],
+	}, clientArrivals
+}
+
+// BlockPayloadStatus holds the execution payload verdict of a client for a block.
+type BlockPayloadStatus struct {
+	Client *Client
+	Status dbtypes.PayloadStatus
+}
+
+// SetPayloadStatus sets the execution payload verdict of a client for this block.
+// verdicts only progress from syncing to valid or invalid, a final verdict is only overridden by invalid.
+// returns true if the verdict has changed.
+func (block *Block) SetPayloadStatus(client *Client, status dbtypes.PayloadStatus) bool {
+	if block.isDisposed {
+		return false
+	}
+
+	block.seenMutex.Lock()
+	defer block.seenMutex.Unlock()
+
+	currentStatus := block.payloadStatuses[client.index]
+	if currentStatus == status {
+		return false
+	}
+
+	switch status {
+	case dbtypes.PayloadStatusSyncing:
+		if currentStatus != dbtypes.PayloadStatusUnknown {
+			return false
+		}
+	case dbtypes.PayloadStatusValid:
+		if currentStatus == dbtypes.PayloadStatusInvalid {
+			return false
+		}
+	}
+
+	if block.payloadStatuses == nil {
+		block.payloadStatuses = make(map[uint16]dbtypes.PayloadStatus)
+	}
+	block.payloadStatuses[client.index] = status
+	return true
+}
+
+// GetPayloadStatus returns the execution payload verdict of a client for this block.
+func (block *Block) GetPayloadStatus(client *Client) dbtypes.PayloadStatus {
+	if block.isDisposed {
+		return dbtypes.PayloadStatusUnknown
+	}
+
+	block.seenMutex.RLock()
+	defer block.seenMutex.RUnlock()
+
+	return block.payloadStatuses[client.index]
+}
+
+// GetPayloadStatuses returns the execution payload verdicts of all clients for this block, sorted by client index.
+func (block *Block) GetPayloadStatuses() []*BlockPayloadStatus {
+	if block.isDisposed {
+		return nil
+	}
+
+	block.seenMutex.RLock()
+	defer block.seenMutex.RUnlock()
+
+	statuses := make([]*BlockPayloadStatus, 0, len(block.payloadStatuses))
+	for clientIndex, status := range block.payloadStatuses {
+		client := block.seenMap[clientIndex]
+		if client == nil {
+			continue
+		}
+
+		statuses = append(statuses, &BlockPayloadStatus{
+			Client: client,
+			Status: status,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Client.index < statuses[j].Client.index
+	})
+
+	return statuses
+}
+
+// GetFirstSeen returns the time the block was first announced via the event stream (zero if never announced via stream).
+func (block *Block) GetFirstSeen() time.Time {
+	arrivals := block.GetArrivals()
+	if len(arrivals) == 0 {
+		return time.Time{}
+	}
+
+	return arrivals[0].SeenTime
 }
 
 // GetHeader returns the signed beacon block header of this block.
@@ -139,8 +316,8 @@ func (block *Block) GetBlock() *spec.VersionedSignedBeaconBlock {
 		return nil
 	}
 
-	if block.block != nil {
-		return block.block
+	if blockBody := block.getBlockBody(); blockBody != nil {
+		return blockBody
 	}
 
 	if block.isInUnfinalizedDb {
@@ -156,6 +333,28 @@ func (block *Block) GetBlock() *spec.VersionedSignedBeaconBlock {
 	return nil
 }
 
+// GetBlockSSZ returns the ssz encoded versioned signed beacon block of this block.
+// bodies that are only stored in the unfinalized blocks table are returned as stored, without decoding them.
+func (block *Block) GetBlockSSZ() (spec.DataVersion, []byte, error) {
+	if block.isDisposed {
+		return 0, nil, nil
+	}
+
+	if blockBody := block.getBlockBody(); blockBody != nil {
+		_, blockSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(block.dynSsz, blockBody, nil, true)
+		return blockBody.Version, blockSSZ, err
+	}
+
+	if block.isInUnfinalizedDb {
+		dbBlock := db.GetUnfinalizedBlock(block.Root[:])
+		if dbBlock != nil {
+			return unmarshalRawBlockSSZ(block.dynSsz, dbBlock.BlockVer, dbBlock.BlockSSZ)
+		}
+	}
+
+	return 0, nil, nil
+}
+
 // AwaitBlock waits for the versioned signed beacon block of this block to be available.
 func (block *Block) AwaitBlock(ctx context.Context, timeout time.Duration) *spec.VersionedSignedBeaconBlock {
 	if block.isDisposed {
@@ -238,6 +437,9 @@ func (block *Block) SetBlock(body *spec.VersionedSignedBeaconBlock) {
 		return
 	}
 
+	block.blockMutex.Lock()
+	defer block.blockMutex.Unlock()
+
 	block.setBlockIndex(body)
 	block.block = body
 
@@ -279,15 +481,72 @@ func (block *Block) EnsureBlock(loadBlock func() (*spec.VersionedSignedBeaconBlo
 	return true, nil
 }
 
+// pruneBlockBody drops the block body from memory, the block index is preserved so the block is still identifiable.
+// returns true if a body has been dropped.
+func (block *Block) pruneBlockBody() bool {
+	block.blockMutex.Lock()
+	defer block.blockMutex.Unlock()
+
+	if block.block == nil {
+		return false
+	}
+
+	block.setBlockIndex(block.block)
+	block.block = nil
+	return true
+}
+
+// hasBlockBody checks whether the block body is held in memory.
+func (block *Block) hasBlockBody() bool {
+	return block.getBlockBody() != nil
+}
+
+// getBlockBody returns the block body if it's held in memory.
+func (block *Block) getBlockBody() *spec.VersionedSignedBeaconBlock {
+	block.blockMutex.Lock()
+	defer block.blockMutex.Unlock()
+
+	return block.block
+}
+
 // setBlockIndex sets the block index of this block.
 func (block *Block) setBlockIndex(body *spec.VersionedSignedBeaconBlock) {
-	blockIndex := &BlockBodyIndex{}
-	blockIndex.Graffiti, _ = body.Graffiti()
-	blockIndex.ExecutionExtraData, _ = getBlockExecutionExtraData(body)
-	blockIndex.ExecutionHash, _ = body.ExecutionBlockHash()
-	blockIndex.ExecutionNumber, _ = body.ExecutionBlockNumber()
+	block.setBlockIndexFromFields(getBlockBodyFields(body))
+}
 
-	block.blockIndex = blockIndex
+// setBlockIndexFromFields sets the block index of this block from the extracted block body fields.
+func (block *Block) setBlockIndexFromFields(fields *blockBodyFields) {
+	block.blockIndex = &BlockBodyIndex{
+		Graffiti:              fields.Graffiti,
+		ExecutionExtraData:    fields.ExecutionExtraData,
+		ExecutionHash:         fields.ExecutionHash,
+		ExecutionNumber:       fields.ExecutionNumber,
+		ExecutionFeeRecipient: fields.ExecutionFeeRecipient,
+	}
+}
+
+// getBodyFields returns the block body fields of this block.
+// if the block body has been pruned, the fields are read from the ssz encoded body in the unfinalized db without decoding the whole body.
+func (block *Block) getBodyFields() *blockBodyFields {
+	if block.isDisposed {
+		return nil
+	}
+
+	if blockBody := block.block; blockBody != nil {
+		return getBlockBodyFields(blockBody)
+	}
+
+	if block.isInUnfinalizedDb {
+		dbBlock := db.GetUnfinalizedBlock(block.Root[:])
+		if dbBlock != nil {
+			fields, err := unmarshalBlockBodyFields(dbBlock.BlockVer, dbBlock.BlockSSZ)
+			if err == nil {
+				return fields
+			}
+		}
+	}
+
+	return nil
 }
 
 // GetBlockIndex returns the block index of this block.
@@ -300,26 +559,25 @@ func (block *Block) GetBlockIndex() *BlockBodyIndex {
 		return block.blockIndex
 	}
 
-	blockBody := block.GetBlock()
-	if blockBody != nil {
-		block.setBlockIndex(blockBody)
+	if fields := block.getBodyFields(); fields != nil {
+		block.setBlockIndexFromFields(fields)
 	}
 
 	return block.blockIndex
 }
 
 // buildUnfinalizedBlock builds an unfinalized block from the block data.
-func (block *Block) buildUnfinalizedBlock(compress bool) (*dbtypes.UnfinalizedBlock, error) {
+func (block *Block) buildUnfinalizedBlock(codec *CompressionCodec) (*dbtypes.UnfinalizedBlock, error) {
 	if block.isDisposed {
 		return nil, fmt.Errorf("block is disposed")
 	}
 
-	headerSSZ, err := block.header.MarshalSSZ()
+	headerVer, headerSSZ, err := marshalBlockHeaderSSZ(block.header, codec)
 	if err != nil {
 		return nil, fmt.Errorf("marshal header ssz failed: %v", err)
 	}
 
-	blockVer, blockSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(block.dynSsz, block.GetBlock(), compress, false)
+	blockVer, blockSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(block.dynSsz, block.GetBlock(), codec, false)
 	if err != nil {
 		return nil, fmt.Errorf("marshal block ssz failed: %v", err)
 	}
@@ -327,7 +585,7 @@ func (block *Block) buildUnfinalizedBlock(compress bool) (*dbtypes.UnfinalizedBl
 	return &dbtypes.UnfinalizedBlock{
 		Root:      block.Root[:],
 		Slot:      uint64(block.Slot),
-		HeaderVer: 1,
+		HeaderVer: headerVer,
 		HeaderSSZ: headerSSZ,
 		BlockVer:  blockVer,
 		BlockSSZ:  blockSSZ,
@@ -337,24 +595,24 @@ func (block *Block) buildUnfinalizedBlock(compress bool) (*dbtypes.UnfinalizedBl
 }
 
 // buildOrphanedBlock builds an orphaned block from the block data.
-func (block *Block) buildOrphanedBlock(compress bool) (*dbtypes.OrphanedBlock, error) {
+func (block *Block) buildOrphanedBlock(codec *CompressionCodec) (*dbtypes.OrphanedBlock, error) {
 	if block.isDisposed {
 		return nil, fmt.Errorf("block is disposed")
 	}
 
-	headerSSZ, err := block.header.MarshalSSZ()
+	headerVer, headerSSZ, err := marshalBlockHeaderSSZ(block.header, codec)
 	if err != nil {
 		return nil, fmt.Errorf("marshal header ssz failed: %v", err)
 	}
 
-	blockVer, blockSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(block.dynSsz, block.GetBlock(), compress, false)
+	blockVer, blockSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(block.dynSsz, block.GetBlock(), codec, false)
 	if err != nil {
 		return nil, fmt.Errorf("marshal block ssz failed: %v", err)
 	}
 
 	return &dbtypes.OrphanedBlock{
 		Root:      block.Root[:],
-		HeaderVer: 1,
+		HeaderVer: headerVer,
 		HeaderSSZ: headerSSZ,
 		BlockVer:  blockVer,
 		BlockSSZ:  blockSSZ,
@@ -367,6 +625,13 @@ func (block *Block) unpruneBlockBody() {
 		return
 	}
 
+	block.blockMutex.Lock()
+	defer block.blockMutex.Unlock()
+
+	if block.block != nil {
+		return
+	}
+
 	dbBlock := db.GetUnfinalizedBlock(block.Root[:])
 	if dbBlock != nil {
 		block.block, _ = unmarshalVersionedSignedBeaconBlockSSZ(block.dynSsz, dbBlock.BlockVer, dbBlock.BlockSSZ)
@@ -427,6 +692,15 @@ func (block *Block) GetDbSlashings(indexer *Indexer, isCanonical bool) []*dbtype
 	return indexer.dbWriter.buildDbSlashings(block, !isCanonical, nil)
 }
 
+// GetDbAttestations returns the database representation of the attestations in this block.
+func (block *Block) GetDbAttestations(indexer *Indexer, isCanonical bool) []*dbtypes.SlotAttestation {
+	if block.isDisposed {
+		return nil
+	}
+
+	return indexer.dbWriter.buildDbAttestations(block, !isCanonical)
+}
+
 // GetDbWithdrawalRequests returns the database representation of the withdrawal requests in this block.
 func (block *Block) GetDbWithdrawalRequests(indexer *Indexer, isCanonical bool) []*dbtypes.WithdrawalRequest {
 	if block.isDisposed {
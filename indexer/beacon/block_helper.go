@@ -3,6 +3,7 @@ package beacon
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -12,14 +13,15 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/utils"
+	"github.com/holiman/uint256"
 	dynssz "github.com/pk910/dynamic-ssz"
 )
 
 var jsonVersionFlag uint64 = 0x40000000
-var compressionFlag uint64 = 0x20000000
 
 // MarshalVersionedSignedBeaconBlockSSZ marshals a versioned signed beacon block using SSZ encoding.
-func MarshalVersionedSignedBeaconBlockSSZ(dynSsz *dynssz.DynSsz, block *spec.VersionedSignedBeaconBlock, compress bool, forceSSZ bool) (version uint64, ssz []byte, err error) {
+// The result is compressed with the given codec (nil = no compression).
+func MarshalVersionedSignedBeaconBlockSSZ(dynSsz *dynssz.DynSsz, block *spec.VersionedSignedBeaconBlock, codec *CompressionCodec, forceSSZ bool) (version uint64, ssz []byte, err error) {
 	if utils.Config.KillSwitch.DisableSSZEncoding && !forceSSZ {
 		// SSZ encoding disabled, use json instead
 		version, ssz, err = MarshalVersionedSignedBeaconBlockJson(block)
@@ -49,23 +51,66 @@ func MarshalVersionedSignedBeaconBlockSSZ(dynSsz *dynssz.DynSsz, block *spec.Ver
 		}
 	}
 
-	if compress {
-		ssz = compressBytes(ssz)
-		version |= compressionFlag
+	if err == nil && codec != nil {
+		var codecFlag uint64
+		codecFlag, ssz = codec.compress(ssz)
+		version |= codecFlag
 	}
 
 	return
 }
 
+// marshalBlockHeaderSSZ marshals a signed beacon block header using SSZ encoding.
+// The result is compressed with the given codec (nil = no compression).
+func marshalBlockHeaderSSZ(header *phase0.SignedBeaconBlockHeader, codec *CompressionCodec) (uint64, []byte, error) {
+	ssz, err := header.MarshalSSZ()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	version := uint64(1)
+	if codec != nil {
+		var codecFlag uint64
+		codecFlag, ssz = codec.compress(ssz)
+		version |= codecFlag
+	}
+
+	return version, ssz, nil
+}
+
+// unmarshalBlockHeaderSSZ unmarshals a signed beacon block header using SSZ encoding.
+func unmarshalBlockHeaderSSZ(version uint64, ssz []byte) (*phase0.SignedBeaconBlockHeader, error) {
+	if (version & compressionFlagMask) != 0 {
+		if v, d, err := decompressVersioned(version, ssz); err != nil {
+			return nil, fmt.Errorf("failed to decompress: %v", err)
+		} else {
+			ssz = d
+			version = v
+		}
+	}
+
+	if version != 1 {
+		return nil, fmt.Errorf("unsupported header version")
+	}
+
+	header := &phase0.SignedBeaconBlockHeader{}
+	err := header.UnmarshalSSZ(ssz)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
 // unmarshalVersionedSignedBeaconBlockSSZ unmarshals a versioned signed beacon block using SSZ encoding.
 func unmarshalVersionedSignedBeaconBlockSSZ(dynSsz *dynssz.DynSsz, version uint64, ssz []byte) (*spec.VersionedSignedBeaconBlock, error) {
-	if (version & compressionFlag) != 0 {
+	if (version & compressionFlagMask) != 0 {
 		// decompress
-		if d, err := decompressBytes(ssz); err != nil {
+		if v, d, err := decompressVersioned(version, ssz); err != nil {
 			return nil, fmt.Errorf("failed to decompress: %v", err)
 		} else {
 			ssz = d
-			version &= ^compressionFlag
+			version = v
 		}
 	}
 
@@ -116,6 +161,35 @@ func unmarshalVersionedSignedBeaconBlockSSZ(dynSsz *dynssz.DynSsz, version uint6
 	return block, nil
 }
 
+// unmarshalRawBlockSSZ returns the plain ssz encoding of a stored block body without decoding it.
+// json encoded bodies (ssz encoding disabled via kill switch) are decoded & re-encoded with ssz.
+func unmarshalRawBlockSSZ(dynSsz *dynssz.DynSsz, version uint64, ssz []byte) (spec.DataVersion, []byte, error) {
+	if (version & compressionFlagMask) != 0 {
+		// decompress
+		if v, d, err := decompressVersioned(version, ssz); err != nil {
+			return 0, nil, fmt.Errorf("failed to decompress: %v", err)
+		} else {
+			ssz = d
+			version = v
+		}
+	}
+
+	if (version & jsonVersionFlag) != 0 {
+		block, err := unmarshalVersionedSignedBeaconBlockJson(version, ssz)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		_, ssz, err = MarshalVersionedSignedBeaconBlockSSZ(dynSsz, block, nil, true)
+		if err != nil {
+			return 0, nil, err
+		}
+		return block.Version, ssz, nil
+	}
+
+	return spec.DataVersion(version), ssz, nil
+}
+
 // MarshalVersionedSignedBeaconBlockJson marshals a versioned signed beacon block using JSON encoding.
 func MarshalVersionedSignedBeaconBlockJson(block *spec.VersionedSignedBeaconBlock) (version uint64, jsonRes []byte, err error) {
 	switch block.Version {
@@ -191,6 +265,22 @@ func unmarshalVersionedSignedBeaconBlockJson(version uint64, ssz []byte) (*spec.
 	return block, nil
 }
 
+// GetAttesterSlashingAttestations returns both indexed attestations of an attester slashing.
+// go-eth2-client returns the altair & bellatrix indexed attestations with the phase0 version, which breaks all getters on them,
+// so the version of the attester slashing is applied to the returned attestations.
+func GetAttesterSlashingAttestations(slashing *spec.VersionedAttesterSlashing) (*spec.VersionedIndexedAttestation, *spec.VersionedIndexedAttestation) {
+	att1, err1 := slashing.Attestation1()
+	att2, err2 := slashing.Attestation2()
+	if err1 != nil || err2 != nil || att1 == nil || att2 == nil {
+		return nil, nil
+	}
+
+	att1.Version = slashing.Version
+	att2.Version = slashing.Version
+
+	return att1, att2
+}
+
 // getBlockExecutionExtraData returns the extra data from the execution payload of a versioned signed beacon block.
 func getBlockExecutionExtraData(v *spec.VersionedSignedBeaconBlock) ([]byte, error) {
 	switch v.Version {
@@ -223,6 +313,162 @@ func getBlockExecutionExtraData(v *spec.VersionedSignedBeaconBlock) ([]byte, err
 	}
 }
 
+// getBlockExecutionFeeRecipient returns the fee recipient from the execution payload of a versioned signed beacon block.
+func getBlockExecutionFeeRecipient(v *spec.VersionedSignedBeaconBlock) (bellatrix.ExecutionAddress, error) {
+	switch v.Version {
+	case spec.DataVersionBellatrix:
+		if v.Bellatrix == nil || v.Bellatrix.Message == nil || v.Bellatrix.Message.Body == nil || v.Bellatrix.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, errors.New("no bellatrix block")
+		}
+
+		return v.Bellatrix.Message.Body.ExecutionPayload.FeeRecipient, nil
+	case spec.DataVersionCapella:
+		if v.Capella == nil || v.Capella.Message == nil || v.Capella.Message.Body == nil || v.Capella.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, errors.New("no capella block")
+		}
+
+		return v.Capella.Message.Body.ExecutionPayload.FeeRecipient, nil
+	case spec.DataVersionDeneb:
+		if v.Deneb == nil || v.Deneb.Message == nil || v.Deneb.Message.Body == nil || v.Deneb.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, errors.New("no deneb block")
+		}
+
+		return v.Deneb.Message.Body.ExecutionPayload.FeeRecipient, nil
+	case spec.DataVersionElectra:
+		if v.Electra == nil || v.Electra.Message == nil || v.Electra.Message.Body == nil || v.Electra.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, errors.New("no electra block")
+		}
+
+		return v.Electra.Message.Body.ExecutionPayload.FeeRecipient, nil
+	default:
+		return bellatrix.ExecutionAddress{}, errors.New("unknown version")
+	}
+}
+
+// getBlockExecutionGasFields returns the gas used, gas limit & base fee per gas from the execution payload of a versioned signed beacon block.
+// the base fee is capped to max int64.
+func getBlockExecutionGasFields(v *spec.VersionedSignedBeaconBlock) (uint64, uint64, uint64, error) {
+	switch v.Version {
+	case spec.DataVersionBellatrix:
+		if v.Bellatrix == nil || v.Bellatrix.Message == nil || v.Bellatrix.Message.Body == nil || v.Bellatrix.Message.Body.ExecutionPayload == nil {
+			return 0, 0, 0, errors.New("no bellatrix block")
+		}
+
+		payload := v.Bellatrix.Message.Body.ExecutionPayload
+		return payload.GasUsed, payload.GasLimit, uint256LEToUint64(payload.BaseFeePerGas[:]), nil
+	case spec.DataVersionCapella:
+		if v.Capella == nil || v.Capella.Message == nil || v.Capella.Message.Body == nil || v.Capella.Message.Body.ExecutionPayload == nil {
+			return 0, 0, 0, errors.New("no capella block")
+		}
+
+		payload := v.Capella.Message.Body.ExecutionPayload
+		return payload.GasUsed, payload.GasLimit, uint256LEToUint64(payload.BaseFeePerGas[:]), nil
+	case spec.DataVersionDeneb:
+		if v.Deneb == nil || v.Deneb.Message == nil || v.Deneb.Message.Body == nil || v.Deneb.Message.Body.ExecutionPayload == nil {
+			return 0, 0, 0, errors.New("no deneb block")
+		}
+
+		payload := v.Deneb.Message.Body.ExecutionPayload
+		return payload.GasUsed, payload.GasLimit, capUint256(payload.BaseFeePerGas), nil
+	case spec.DataVersionElectra:
+		if v.Electra == nil || v.Electra.Message == nil || v.Electra.Message.Body == nil || v.Electra.Message.Body.ExecutionPayload == nil {
+			return 0, 0, 0, errors.New("no electra block")
+		}
+
+		payload := v.Electra.Message.Body.ExecutionPayload
+		return payload.GasUsed, payload.GasLimit, capUint256(payload.BaseFeePerGas), nil
+	default:
+		return 0, 0, 0, errors.New("unknown version")
+	}
+}
+
+// capUint256 converts a uint256 to uint64, values are capped to the int64 range to fit into the db.
+func capUint256(value *uint256.Int) uint64 {
+	if value == nil {
+		return 0
+	}
+	if !value.IsUint64() || value.Uint64() > math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return value.Uint64()
+}
+
+// getBlockHeader builds the signed block header and block root for a versioned signed beacon block.
+func getBlockHeader(dynSsz *dynssz.DynSsz, v *spec.VersionedSignedBeaconBlock) (*phase0.SignedBeaconBlockHeader, phase0.Root, error) {
+	var body any
+	var signature phase0.BLSSignature
+
+	switch v.Version {
+	case spec.DataVersionPhase0:
+		if v.Phase0 == nil || v.Phase0.Message == nil {
+			return nil, phase0.Root{}, errors.New("no phase0 block")
+		}
+		body = v.Phase0.Message.Body
+		signature = v.Phase0.Signature
+	case spec.DataVersionAltair:
+		if v.Altair == nil || v.Altair.Message == nil {
+			return nil, phase0.Root{}, errors.New("no altair block")
+		}
+		body = v.Altair.Message.Body
+		signature = v.Altair.Signature
+	case spec.DataVersionBellatrix:
+		if v.Bellatrix == nil || v.Bellatrix.Message == nil {
+			return nil, phase0.Root{}, errors.New("no bellatrix block")
+		}
+		body = v.Bellatrix.Message.Body
+		signature = v.Bellatrix.Signature
+	case spec.DataVersionCapella:
+		if v.Capella == nil || v.Capella.Message == nil {
+			return nil, phase0.Root{}, errors.New("no capella block")
+		}
+		body = v.Capella.Message.Body
+		signature = v.Capella.Signature
+	case spec.DataVersionDeneb:
+		if v.Deneb == nil || v.Deneb.Message == nil {
+			return nil, phase0.Root{}, errors.New("no deneb block")
+		}
+		body = v.Deneb.Message.Body
+		signature = v.Deneb.Signature
+	case spec.DataVersionElectra:
+		if v.Electra == nil || v.Electra.Message == nil {
+			return nil, phase0.Root{}, errors.New("no electra block")
+		}
+		body = v.Electra.Message.Body
+		signature = v.Electra.Signature
+	default:
+		return nil, phase0.Root{}, errors.New("unknown version")
+	}
+
+	bodyRoot, err := dynSsz.HashTreeRoot(body)
+	if err != nil {
+		return nil, phase0.Root{}, fmt.Errorf("failed to compute body root: %v", err)
+	}
+
+	slot, _ := v.Slot()
+	proposerIndex, _ := v.ProposerIndex()
+	parentRoot, _ := v.ParentRoot()
+	stateRoot, _ := v.StateRoot()
+
+	header := &phase0.SignedBeaconBlockHeader{
+		Message: &phase0.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: proposerIndex,
+			ParentRoot:    parentRoot,
+			StateRoot:     stateRoot,
+			BodyRoot:      bodyRoot,
+		},
+		Signature: signature,
+	}
+
+	blockRoot, err := dynSsz.HashTreeRoot(header.Message)
+	if err != nil {
+		return nil, phase0.Root{}, fmt.Errorf("failed to compute block root: %v", err)
+	}
+
+	return header, blockRoot, nil
+}
+
 // getStateRandaoMixes returns the RANDAO mixes from a versioned beacon state.
 func getStateRandaoMixes(v *spec.VersionedBeaconState) ([]phase0.Root, error) {
 	switch v.Version {
@@ -373,3 +619,43 @@ func getStatePendingConsolidations(v *spec.VersionedBeaconState) ([]*electra.Pen
 		return nil, errors.New("unknown version")
 	}
 }
+
+// getStatePendingDeposits returns the pending deposits from a versioned beacon state.
+func getStatePendingDeposits(v *spec.VersionedBeaconState) ([]*electra.PendingDeposit, error) {
+	switch v.Version {
+	case spec.DataVersionPhase0:
+		return nil, errors.New("no pending deposits in phase0")
+	case spec.DataVersionAltair:
+		return nil, errors.New("no pending deposits in altair")
+	case spec.DataVersionBellatrix:
+		return nil, errors.New("no pending deposits in bellatrix")
+	case spec.DataVersionCapella:
+		return nil, errors.New("no pending deposits in capella")
+	case spec.DataVersionDeneb:
+		return nil, errors.New("no pending deposits in deneb")
+	case spec.DataVersionElectra:
+		if v.Electra == nil || v.Electra.PendingDeposits == nil {
+			return nil, errors.New("no electra block")
+		}
+
+		return v.Electra.PendingDeposits, nil
+	default:
+		return nil, errors.New("unknown version")
+	}
+}
+
+// getStateChurnBalances returns the deposit & exit balance to consume and the earliest exit epoch from a versioned beacon state.
+func getStateChurnBalances(v *spec.VersionedBeaconState) (phase0.Gwei, phase0.Gwei, phase0.Epoch, error) {
+	switch v.Version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair, spec.DataVersionBellatrix, spec.DataVersionCapella, spec.DataVersionDeneb:
+		return 0, 0, 0, errors.New("no balance churn before electra")
+	case spec.DataVersionElectra:
+		if v.Electra == nil {
+			return 0, 0, 0, errors.New("no electra block")
+		}
+
+		return v.Electra.DepositBalanceToConsume, v.Electra.ExitBalanceToConsume, v.Electra.EarliestExitEpoch, nil
+	default:
+		return 0, 0, 0, errors.New("unknown version")
+	}
+}
@@ -0,0 +1,247 @@
+package beacon
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/utils"
+	dynssz "github.com/pk910/dynamic-ssz"
+)
+
+func newTestCodecs(t *testing.T) []*CompressionCodec {
+	t.Helper()
+
+	codecs := []*CompressionCodec{nil}
+	for _, name := range []string{"zlib", "snappy", "zstd"} {
+		codec, err := NewCompressionCodec(name, 0)
+		if err != nil {
+			t.Fatalf("failed creating %v codec: %v", name, err)
+		}
+		codecs = append(codecs, codec)
+	}
+
+	return codecs
+}
+
+func TestBlockSSZRoundTrip(t *testing.T) {
+	dynSsz := newCorpusDynSsz()
+	codecs := newTestCodecs(t)
+
+	for _, fixture := range loadCorpus(t) {
+		for _, codec := range codecs {
+			t.Run(fixture.version.String()+"/"+codec.String(), func(t *testing.T) {
+				version, data, err := MarshalVersionedSignedBeaconBlockSSZ(dynSsz, fixture.block, codec, false)
+				if err != nil {
+					t.Fatalf("marshal failed: %v", err)
+				}
+				if version&^compressionFlagMask != uint64(fixture.version) {
+					t.Fatalf("unexpected version %x", version)
+				}
+				if codec == nil && !bytes.Equal(data, fixture.blockSSZ) {
+					t.Fatalf("uncompressed encoding differs from fixture")
+				}
+
+				block, err := unmarshalVersionedSignedBeaconBlockSSZ(dynSsz, version, data)
+				if err != nil {
+					t.Fatalf("unmarshal failed: %v", err)
+				}
+				if block.Version != fixture.version {
+					t.Fatalf("unexpected block version %v", block.Version)
+				}
+
+				_, blockSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(dynSsz, block, nil, true)
+				if err != nil {
+					t.Fatalf("re-marshal failed: %v", err)
+				}
+				if !bytes.Equal(blockSSZ, fixture.blockSSZ) {
+					t.Fatalf("round trip encoding differs from fixture")
+				}
+
+				rawVersion, rawSSZ, err := unmarshalRawBlockSSZ(dynSsz, version, data)
+				if err != nil {
+					t.Fatalf("raw unmarshal failed: %v", err)
+				}
+				if rawVersion != fixture.version || !bytes.Equal(rawSSZ, fixture.blockSSZ) {
+					t.Fatalf("raw ssz differs from fixture")
+				}
+			})
+		}
+	}
+}
+
+func TestBlockJSONRoundTrip(t *testing.T) {
+	dynSsz := newCorpusDynSsz()
+
+	utils.Config.KillSwitch.DisableSSZEncoding = true
+	defer func() {
+		utils.Config.KillSwitch.DisableSSZEncoding = false
+	}()
+
+	for _, fixture := range loadCorpus(t) {
+		t.Run(fixture.version.String(), func(t *testing.T) {
+			version, data, err := MarshalVersionedSignedBeaconBlockSSZ(dynSsz, fixture.block, nil, false)
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+			if version&jsonVersionFlag == 0 {
+				t.Fatalf("expected json encoding with disabled ssz encoding")
+			}
+
+			block, err := unmarshalVersionedSignedBeaconBlockSSZ(dynSsz, version, data)
+			if err != nil {
+				t.Fatalf("unmarshal failed: %v", err)
+			}
+
+			_, blockSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(dynSsz, block, nil, true)
+			if err != nil {
+				t.Fatalf("re-marshal failed: %v", err)
+			}
+			if !bytes.Equal(blockSSZ, fixture.blockSSZ) {
+				t.Fatalf("json round trip differs from fixture")
+			}
+
+			rawVersion, rawSSZ, err := unmarshalRawBlockSSZ(dynSsz, version, data)
+			if err != nil {
+				t.Fatalf("raw unmarshal failed: %v", err)
+			}
+			if rawVersion != fixture.version || !bytes.Equal(rawSSZ, fixture.blockSSZ) {
+				t.Fatalf("raw ssz of json encoded block differs from fixture")
+			}
+		})
+	}
+}
+
+func TestBlockHeaderSSZRoundTrip(t *testing.T) {
+	dynSsz := newCorpusDynSsz()
+	codecs := newTestCodecs(t)
+
+	for _, fixture := range loadCorpus(t) {
+		header, root, err := getBlockHeader(dynSsz, fixture.block)
+		if err != nil {
+			t.Fatalf("%v: failed building header: %v", fixture.version, err)
+		}
+
+		slot, _ := fixture.block.Slot()
+		if header.Message.Slot != slot {
+			t.Errorf("%v: header slot %v does not match block slot %v", fixture.version, header.Message.Slot, slot)
+		}
+
+		for _, codec := range codecs {
+			version, data, err := marshalBlockHeaderSSZ(header, codec)
+			if err != nil {
+				t.Fatalf("%v/%v: marshal failed: %v", fixture.version, codec, err)
+			}
+
+			decoded, err := unmarshalBlockHeaderSSZ(version, data)
+			if err != nil {
+				t.Fatalf("%v/%v: unmarshal failed: %v", fixture.version, codec, err)
+			}
+
+			decodedRoot, err := decoded.Message.HashTreeRoot()
+			if err != nil {
+				t.Fatalf("%v/%v: hashing failed: %v", fixture.version, codec, err)
+			}
+			if phase0.Root(decodedRoot) != root {
+				t.Errorf("%v/%v: header root mismatch", fixture.version, codec)
+			}
+		}
+	}
+}
+
+// TestBlockMainnetPresetEncoding checks that the dynamic ssz encoding with the mainnet preset matches the static fastssz encoding.
+func TestBlockMainnetPresetEncoding(t *testing.T) {
+	dynSsz := dynssz.NewDynSsz(map[string]any{})
+
+	for _, version := range corpusForks {
+		block := buildCorpusBlock(version, 512)
+
+		_, dynSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(dynSsz, block, nil, true)
+		if err != nil {
+			t.Fatalf("%v: dynamic ssz encoding failed: %v", version, err)
+		}
+
+		var staticSSZ []byte
+		switch version {
+		case spec.DataVersionPhase0:
+			staticSSZ, err = block.Phase0.MarshalSSZ()
+		case spec.DataVersionAltair:
+			staticSSZ, err = block.Altair.MarshalSSZ()
+		case spec.DataVersionBellatrix:
+			staticSSZ, err = block.Bellatrix.MarshalSSZ()
+		case spec.DataVersionCapella:
+			staticSSZ, err = block.Capella.MarshalSSZ()
+		case spec.DataVersionDeneb:
+			staticSSZ, err = block.Deneb.MarshalSSZ()
+		case spec.DataVersionElectra:
+			staticSSZ, err = block.Electra.MarshalSSZ()
+		}
+		if err != nil {
+			t.Fatalf("%v: static ssz encoding failed: %v", version, err)
+		}
+
+		if !bytes.Equal(dynSSZ, staticSSZ) {
+			t.Errorf("%v: dynamic ssz encoding differs from static encoding", version)
+		}
+	}
+}
+
+func TestStateGetters(t *testing.T) {
+	for _, fixture := range loadCorpus(t) {
+		t.Run(fixture.version.String(), func(t *testing.T) {
+			state := fixture.state
+
+			randaoMixes, err := getStateRandaoMixes(state)
+			if err != nil {
+				t.Fatalf("failed getting randao mixes: %v", err)
+			}
+			if len(randaoMixes) != corpusHistoricalVectorSize {
+				t.Errorf("unexpected randao mix count %v", len(randaoMixes))
+			}
+
+			if depositIndex := getStateDepositIndex(state); depositIndex != 1024 {
+				t.Errorf("unexpected deposit index %v", depositIndex)
+			}
+
+			syncCommittee, err := getStateCurrentSyncCommittee(state)
+			if fixture.version == spec.DataVersionPhase0 {
+				if err == nil {
+					t.Errorf("expected error for phase0 sync committee")
+				}
+			} else if err != nil {
+				t.Errorf("failed getting sync committee: %v", err)
+			} else if len(syncCommittee) != corpusSyncCommitteeSize {
+				t.Errorf("unexpected sync committee size %v", len(syncCommittee))
+			}
+
+			pendingWithdrawals, withdrawalsErr := getStatePendingWithdrawals(state)
+			pendingConsolidations, consolidationsErr := getStatePendingConsolidations(state)
+			pendingDeposits, depositsErr := getStatePendingDeposits(state)
+			depositChurn, exitChurn, earliestExitEpoch, churnErr := getStateChurnBalances(state)
+
+			if fixture.version < spec.DataVersionElectra {
+				if withdrawalsErr == nil || consolidationsErr == nil || depositsErr == nil || churnErr == nil {
+					t.Errorf("expected errors for electra fields before electra")
+				}
+				return
+			}
+
+			if withdrawalsErr != nil || consolidationsErr != nil || depositsErr != nil || churnErr != nil {
+				t.Fatalf("failed getting electra fields: %v %v %v %v", withdrawalsErr, consolidationsErr, depositsErr, churnErr)
+			}
+			if len(pendingWithdrawals) != 1 || pendingWithdrawals[0].ValidatorIndex != 21 {
+				t.Errorf("unexpected pending withdrawals")
+			}
+			if len(pendingConsolidations) != 1 || pendingConsolidations[0].SourceIndex != 30 || pendingConsolidations[0].TargetIndex != 31 {
+				t.Errorf("unexpected pending consolidations")
+			}
+			if len(pendingDeposits) != 2 || pendingDeposits[0].Amount != 32000000000 {
+				t.Errorf("unexpected pending deposits")
+			}
+			if depositChurn != 3000000000 || exitChurn != 64000000000 || earliestExitEpoch != state.Electra.EarliestExitEpoch {
+				t.Errorf("unexpected churn balances %v %v %v", depositChurn, exitChurn, earliestExitEpoch)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 var zeroHash = phase0.Hash32{}
 
 // blockCache is a cache for storing blocks.
+// the cache mutex is the innermost lock of the cache hierarchy (see forkCache), it must not be held while calling into other caches.
 type blockCache struct {
 	indexer      *Indexer
 	cacheMutex   sync.RWMutex
@@ -87,9 +88,7 @@ func (cache *blockCache) addBlockToParentMap(block *Block) {
 
 // addBlockToExecBlockMap adds the given block to the execution block map.
 func (cache *blockCache) addBlockToExecBlockMap(block *Block) {
-	cache.cacheMutex.Lock()
-	defer cache.cacheMutex.Unlock()
-
+	// resolve the block index before locking, it might need to load the block body from db
 	blockIndex := block.GetBlockIndex()
 	if blockIndex == nil {
 		return
@@ -99,6 +98,9 @@ func (cache *blockCache) addBlockToExecBlockMap(block *Block) {
 		return
 	}
 
+	cache.cacheMutex.Lock()
+	defer cache.cacheMutex.Unlock()
+
 	for _, entry := range cache.execBlockMap[blockIndex.ExecutionHash] {
 		if entry == block {
 			return
@@ -108,6 +110,22 @@ func (cache *blockCache) addBlockToExecBlockMap(block *Block) {
 	cache.execBlockMap[blockIndex.ExecutionHash] = append(cache.execBlockMap[blockIndex.ExecutionHash], block)
 }
 
+// setLatestBlock sets the latest added block, which is used as a marker for cache changes.
+func (cache *blockCache) setLatestBlock(block *Block) {
+	cache.cacheMutex.Lock()
+	defer cache.cacheMutex.Unlock()
+
+	cache.latestBlock = block
+}
+
+// getLatestBlock returns the latest added block.
+func (cache *blockCache) getLatestBlock() *Block {
+	cache.cacheMutex.RLock()
+	defer cache.cacheMutex.RUnlock()
+
+	return cache.latestBlock
+}
+
 // getBlockByRoot returns the cached block with the given root.
 func (cache *blockCache) getBlockByRoot(root phase0.Root) *Block {
 	cache.cacheMutex.RLock()
@@ -176,22 +194,28 @@ func (cache *blockCache) getBlocksByExecutionBlockHash(blockHash phase0.Hash32)
 }
 
 func (cache *blockCache) getBlocksByExecutionBlockNumber(blockNumber uint64) []*Block {
-	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
 	resBlocks := []*Block{}
+	bodyBlocks := []*Block{}
+
+	cache.cacheMutex.RLock()
 	for _, block := range cache.rootMap {
 		if block.blockIndex != nil {
 			if block.blockIndex.ExecutionNumber == blockNumber {
 				resBlocks = append(resBlocks, block)
-				continue
 			}
+			continue
 		}
 
-		if block.block == nil {
+		bodyBlocks = append(bodyBlocks, block)
+	}
+	cache.cacheMutex.RUnlock()
+
+	// the bodies are checked outside of the cache lock, the block locks might be held during body loading
+	for _, block := range bodyBlocks {
+		blockBody := block.getBlockBody()
+		if blockBody == nil {
 			continue
 		}
-		blockBody := block.block
 
 		executionNumber, err := blockBody.ExecutionBlockNumber()
 		if err == nil && executionNumber == blockNumber {
@@ -204,23 +228,58 @@ func (cache *blockCache) getBlocksByExecutionBlockNumber(blockNumber uint64) []*
 
 // getPruningBlocks returns the blocks that can be pruned based on the given finalized slot.
 func (cache *blockCache) getPruningBlocks(minInMemorySlot phase0.Slot) []*Block {
-	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
 	blocks := []*Block{}
+	bodyBlocks := []*Block{}
+
+	cache.cacheMutex.RLock()
 	for slot, slotBlocks := range cache.slotMap {
 		if slot >= minInMemorySlot {
 			continue
 		}
 
 		for _, block := range slotBlocks {
-			if block.block == nil {
+			if block.isInFinalizedDb || !block.isInUnfinalizedDb {
+				bodyBlocks = append(bodyBlocks, block)
 				continue
 			}
 
 			blocks = append(blocks, block)
 		}
 	}
+	cache.cacheMutex.RUnlock()
+
+	// blocks without a persisted copy are only prunable with a body in memory
+	for _, block := range bodyBlocks {
+		if block.hasBlockBody() {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+// getBodyPruningCandidates returns all blocks with a body in cache, that can be reloaded from the unfinalized blocks table.
+func (cache *blockCache) getBodyPruningCandidates() []*Block {
+	candidates := []*Block{}
+
+	cache.cacheMutex.RLock()
+	for _, slotBlocks := range cache.slotMap {
+		for _, block := range slotBlocks {
+			if !block.isInUnfinalizedDb || block.isInFinalizedDb {
+				continue
+			}
+
+			candidates = append(candidates, block)
+		}
+	}
+	cache.cacheMutex.RUnlock()
+
+	blocks := []*Block{}
+	for _, block := range candidates {
+		if block.hasBlockBody() {
+			blocks = append(blocks, block)
+		}
+	}
 
 	return blocks
 }
@@ -242,6 +301,27 @@ func (cache *blockCache) getCleanupBlocks(finalizedSlot phase0.Slot) []*Block {
 	return blocks
 }
 
+// getUnfinalizedBlocks returns all blocks from the given slot on, ordered by slot.
+func (cache *blockCache) getUnfinalizedBlocks(finalizedSlot phase0.Slot) []*Block {
+	cache.cacheMutex.RLock()
+	defer cache.cacheMutex.RUnlock()
+
+	blocks := []*Block{}
+	for slot, slotBlocks := range cache.slotMap {
+		if slot < finalizedSlot {
+			continue
+		}
+
+		blocks = append(blocks, slotBlocks...)
+	}
+
+	sort.Slice(blocks, func(a, b int) bool {
+		return blocks[a].Slot < blocks[b].Slot
+	})
+
+	return blocks
+}
+
 // getForkBlocks returns a slice of blocks that belong to the specified forkId.
 func (cache *blockCache) getForkBlocks(forkId ForkKey) []*Block {
 	cache.cacheMutex.RLock()
@@ -351,6 +431,43 @@ func (cache *blockCache) removeBlock(block *Block) {
 	block.Dispose()
 }
 
+// replaceBlock replaces a cached block with a new instance of the same block.
+// used to update blocks that are read without locks, readers holding the old instance keep a consistent view of it.
+func (cache *blockCache) replaceBlock(oldBlock *Block, newBlock *Block) {
+	replaceInSlice := func(blocks []*Block) {
+		for i, block := range blocks {
+			if block == oldBlock {
+				blocks[i] = newBlock
+			}
+		}
+	}
+
+	// resolve the block index before locking, it might need to load the block body from db
+	blockIndex := oldBlock.GetBlockIndex()
+
+	cache.cacheMutex.Lock()
+	defer cache.cacheMutex.Unlock()
+
+	if cache.rootMap[oldBlock.Root] != oldBlock {
+		return
+	}
+
+	cache.rootMap[oldBlock.Root] = newBlock
+	replaceInSlice(cache.slotMap[oldBlock.Slot])
+
+	if parentRoot := oldBlock.GetParentRoot(); parentRoot != nil {
+		replaceInSlice(cache.parentMap[*parentRoot])
+	}
+
+	if blockIndex != nil && !bytes.Equal(blockIndex.ExecutionHash[:], zeroHash[:]) {
+		replaceInSlice(cache.execBlockMap[blockIndex.ExecutionHash])
+	}
+
+	if cache.latestBlock == oldBlock {
+		cache.latestBlock = newBlock
+	}
+}
+
 // getEpochBlocks returns the blocks that belong to the specified epoch.
 func (cache *blockCache) getEpochBlocks(epoch phase0.Epoch) []*Block {
 	cache.cacheMutex.RLock()
@@ -0,0 +1,407 @@
+package beacon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// ssz layout of the signed beacon block, block body & execution payload containers.
+// all operation sizes are preset independent, the size of the sync committee bits is derived from the body offsets.
+const (
+	sszBlockBodyOffsetPos        = 80  // slot, proposer_index, parent_root, state_root
+	sszBodyGraffitiPos           = 168 // randao_reveal, eth1_data
+	sszBodyOperationsOffsetPos   = 200 // proposer_slashings, attester_slashings, attestations, deposits, voluntary_exits
+	sszBodySyncAggregatePos      = 220
+	sszPayloadFeeRecipientPos    = 32
+	sszPayloadBlockNumberPos     = 404
+	sszPayloadGasLimitPos        = 412
+	sszPayloadGasUsedPos         = 420
+	sszPayloadExtraDataOffsetPos = 436
+	sszPayloadBaseFeePos         = 440
+	sszPayloadBlockHashPos       = 472
+	sszPayloadTxsOffsetPos       = 504 // transactions, withdrawals (capella)
+	sszProposerSlashingSize      = 416
+	sszDepositSize               = 1240
+	sszVoluntaryExitSize         = 112
+	sszBLSChangeSize             = 172
+	sszWithdrawalSize            = 44
+	sszDepositRequestSize        = 192
+)
+
+// blockBodyFields holds the block body fields that are needed to build the block index and the db representation of a block.
+type blockBodyFields struct {
+	Graffiti              [32]byte
+	AttestationCount      uint64
+	DepositCount          uint64 // deposits & deposit requests
+	ExitCount             uint64
+	AttesterSlashingCount uint64
+	ProposerSlashingCount uint64
+	BLSChangeCount        uint64
+	SyncCommitteeBits     []byte
+	ExecutionNumber       uint64
+	ExecutionHash         phase0.Hash32
+	ExecutionExtraData    []byte
+	ExecutionFeeRecipient bellatrix.ExecutionAddress
+	ExecutionGasUsed      uint64
+	ExecutionGasLimit     uint64
+	ExecutionBaseFee      uint64 // capped to max int64
+	TransactionCount      uint64
+	WithdrawalCount       uint64
+	WithdrawalAmount      uint64
+}
+
+// getBlockBodyFields extracts the block body fields from a decoded block body.
+func getBlockBodyFields(blockBody *spec.VersionedSignedBeaconBlock) *blockBodyFields {
+	fields := &blockBodyFields{}
+
+	fields.Graffiti, _ = blockBody.Graffiti()
+	attestations, _ := blockBody.Attestations()
+	deposits, _ := blockBody.Deposits()
+	voluntaryExits, _ := blockBody.VoluntaryExits()
+	attesterSlashings, _ := blockBody.AttesterSlashings()
+	proposerSlashings, _ := blockBody.ProposerSlashings()
+	blsToExecChanges, _ := blockBody.BLSToExecutionChanges()
+	executionTransactions, _ := blockBody.ExecutionTransactions()
+	executionWithdrawals, _ := blockBody.Withdrawals()
+
+	fields.AttestationCount = uint64(len(attestations))
+	fields.DepositCount = uint64(len(deposits))
+	fields.ExitCount = uint64(len(voluntaryExits))
+	fields.AttesterSlashingCount = uint64(len(attesterSlashings))
+	fields.ProposerSlashingCount = uint64(len(proposerSlashings))
+	fields.BLSChangeCount = uint64(len(blsToExecChanges))
+	fields.TransactionCount = uint64(len(executionTransactions))
+	fields.WithdrawalCount = uint64(len(executionWithdrawals))
+	for _, withdrawal := range executionWithdrawals {
+		fields.WithdrawalAmount += uint64(withdrawal.Amount)
+	}
+
+	if syncAggregate, _ := blockBody.SyncAggregate(); syncAggregate != nil {
+		fields.SyncCommitteeBits = syncAggregate.SyncCommitteeBits
+	}
+
+	if executionRequests, _ := blockBody.ExecutionRequests(); executionRequests != nil {
+		fields.DepositCount += uint64(len(executionRequests.Deposits))
+	}
+
+	fields.ExecutionNumber, _ = blockBody.ExecutionBlockNumber()
+	fields.ExecutionHash, _ = blockBody.ExecutionBlockHash()
+	fields.ExecutionExtraData, _ = getBlockExecutionExtraData(blockBody)
+	fields.ExecutionFeeRecipient, _ = getBlockExecutionFeeRecipient(blockBody)
+	fields.ExecutionGasUsed, fields.ExecutionGasLimit, fields.ExecutionBaseFee, _ = getBlockExecutionGasFields(blockBody)
+
+	return fields
+}
+
+// unmarshalBlockBodyFields extracts the block body fields from an encoded block body.
+// ssz encoded bodies are read lazily without materializing the whole block, json encoded bodies are fully decoded.
+func unmarshalBlockBodyFields(version uint64, ssz []byte) (*blockBodyFields, error) {
+	if (version & compressionFlagMask) != 0 {
+		if v, d, err := decompressVersioned(version, ssz); err != nil {
+			return nil, fmt.Errorf("failed to decompress: %v", err)
+		} else {
+			ssz = d
+			version = v
+		}
+	}
+
+	if (version & jsonVersionFlag) != 0 {
+		blockBody, err := unmarshalVersionedSignedBeaconBlockJson(version, ssz)
+		if err != nil {
+			return nil, err
+		}
+
+		return getBlockBodyFields(blockBody), nil
+	}
+
+	reader, err := newBlockSSZReader(spec.DataVersion(version), ssz)
+	if err != nil {
+		return nil, err
+	}
+
+	return reader.getBlockBodyFields()
+}
+
+// blockSSZReader is a lazy reader for ssz encoded signed beacon blocks.
+// it only resolves the offsets of the block body & execution payload, so single fields can be extracted without decoding the whole block.
+type blockSSZReader struct {
+	version      spec.DataVersion
+	body         []byte
+	bodyOffsets  []uint32 // offsets of the variable size body fields
+	payload      []byte
+	syncBitsSize uint32
+}
+
+// newBlockSSZReader creates a lazy reader for a ssz encoded signed beacon block.
+func newBlockSSZReader(version spec.DataVersion, ssz []byte) (*blockSSZReader, error) {
+	reader := &blockSSZReader{
+		version: version,
+	}
+
+	// trailing offsets after the sync aggregate: execution_payload, bls_to_execution_changes, blob_kzg_commitments, execution_requests
+	trailingOffsets := uint32(0)
+	switch version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair:
+	case spec.DataVersionBellatrix:
+		trailingOffsets = 1
+	case spec.DataVersionCapella:
+		trailingOffsets = 2
+	case spec.DataVersionDeneb:
+		trailingOffsets = 3
+	case spec.DataVersionElectra:
+		trailingOffsets = 4
+	default:
+		return nil, fmt.Errorf("unknown block version")
+	}
+
+	block, err := readSSZOffsetField(ssz, 0, 100)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signed block: %v", err)
+	}
+
+	body, err := readSSZOffsetField(block, sszBlockBodyOffsetPos, sszBlockBodyOffsetPos+4)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block: %v", err)
+	}
+	reader.body = body
+
+	if len(body) < sszBodySyncAggregatePos {
+		return nil, fmt.Errorf("invalid block body: too short")
+	}
+
+	// the first offset points to the end of the fixed size part
+	fixedSize := binary.LittleEndian.Uint32(body[sszBodyOperationsOffsetPos:])
+	if fixedSize > uint32(len(body)) {
+		return nil, fmt.Errorf("invalid block body: offset out of range")
+	}
+
+	offsetPositions := []uint32{}
+	for i := uint32(0); i < 5; i++ {
+		offsetPositions = append(offsetPositions, sszBodyOperationsOffsetPos+i*4)
+	}
+
+	if version == spec.DataVersionPhase0 {
+		if fixedSize != sszBodySyncAggregatePos {
+			return nil, fmt.Errorf("invalid block body: unexpected fixed size")
+		}
+	} else {
+		if fixedSize < sszBodySyncAggregatePos+96+trailingOffsets*4 {
+			return nil, fmt.Errorf("invalid block body: unexpected fixed size")
+		}
+
+		reader.syncBitsSize = fixedSize - sszBodySyncAggregatePos - 96 - trailingOffsets*4
+		for i := uint32(0); i < trailingOffsets; i++ {
+			offsetPositions = append(offsetPositions, fixedSize-trailingOffsets*4+i*4)
+		}
+	}
+
+	reader.bodyOffsets = make([]uint32, len(offsetPositions))
+	lastOffset := fixedSize
+	for i, pos := range offsetPositions {
+		offset := binary.LittleEndian.Uint32(body[pos:])
+		if offset < lastOffset || offset > uint32(len(body)) {
+			return nil, fmt.Errorf("invalid block body: offset %v out of range", i)
+		}
+
+		reader.bodyOffsets[i] = offset
+		lastOffset = offset
+	}
+
+	if trailingOffsets > 0 {
+		reader.payload = reader.getBodyField(5)
+		if len(reader.payload) < sszPayloadTxsOffsetPos+4 {
+			return nil, fmt.Errorf("invalid execution payload: too short")
+		}
+	}
+
+	return reader, nil
+}
+
+// readSSZOffsetField returns the variable size field referenced by the offset at the given position, reaching to the end of the data.
+func readSSZOffsetField(data []byte, offsetPos uint32, minOffset uint32) ([]byte, error) {
+	if uint32(len(data)) < offsetPos+4 {
+		return nil, fmt.Errorf("too short")
+	}
+
+	offset := binary.LittleEndian.Uint32(data[offsetPos:])
+	if offset < minOffset || offset > uint32(len(data)) {
+		return nil, fmt.Errorf("offset out of range")
+	}
+
+	return data[offset:], nil
+}
+
+// getBodyField returns the variable size body field with the given index.
+func (reader *blockSSZReader) getBodyField(index int) []byte {
+	if index >= len(reader.bodyOffsets) {
+		return nil
+	}
+
+	end := uint32(len(reader.body))
+	if index+1 < len(reader.bodyOffsets) {
+		end = reader.bodyOffsets[index+1]
+	}
+
+	return reader.body[reader.bodyOffsets[index]:end]
+}
+
+// getVariableField returns the variable size field with the given index of a container.
+// offsetPositions are the positions of all variable size field offsets within the container.
+func getVariableField(data []byte, offsetPositions []uint32, index int) ([]byte, error) {
+	if index >= len(offsetPositions) || uint32(len(data)) < offsetPositions[len(offsetPositions)-1]+4 {
+		return nil, fmt.Errorf("too short")
+	}
+
+	start := binary.LittleEndian.Uint32(data[offsetPositions[index]:])
+	end := uint32(len(data))
+	if index+1 < len(offsetPositions) {
+		end = binary.LittleEndian.Uint32(data[offsetPositions[index+1]:])
+	}
+
+	if start > end || end > uint32(len(data)) {
+		return nil, fmt.Errorf("offset out of range")
+	}
+
+	return data[start:end], nil
+}
+
+// getFixedListCount returns the number of items in a ssz list of fixed size items.
+func getFixedListCount(data []byte, itemSize uint32) (uint64, error) {
+	if uint32(len(data))%itemSize != 0 {
+		return 0, fmt.Errorf("unexpected list size")
+	}
+
+	return uint64(uint32(len(data)) / itemSize), nil
+}
+
+// getVariableListCount returns the number of items in a ssz list of variable size items.
+func getVariableListCount(data []byte) (uint64, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+	if len(data) < 4 {
+		return 0, fmt.Errorf("unexpected list size")
+	}
+
+	firstOffset := binary.LittleEndian.Uint32(data)
+	if firstOffset%4 != 0 || firstOffset > uint32(len(data)) {
+		return 0, fmt.Errorf("unexpected list offset")
+	}
+
+	return uint64(firstOffset / 4), nil
+}
+
+// getBlockBodyFields extracts the block body fields without decoding the whole block.
+func (reader *blockSSZReader) getBlockBodyFields() (*blockBodyFields, error) {
+	var err error
+	fields := &blockBodyFields{}
+
+	copy(fields.Graffiti[:], reader.body[sszBodyGraffitiPos:sszBodyGraffitiPos+32])
+
+	if fields.ProposerSlashingCount, err = getFixedListCount(reader.getBodyField(0), sszProposerSlashingSize); err != nil {
+		return nil, fmt.Errorf("invalid proposer slashings: %v", err)
+	}
+	if fields.AttesterSlashingCount, err = getVariableListCount(reader.getBodyField(1)); err != nil {
+		return nil, fmt.Errorf("invalid attester slashings: %v", err)
+	}
+	if fields.AttestationCount, err = getVariableListCount(reader.getBodyField(2)); err != nil {
+		return nil, fmt.Errorf("invalid attestations: %v", err)
+	}
+	if fields.DepositCount, err = getFixedListCount(reader.getBodyField(3), sszDepositSize); err != nil {
+		return nil, fmt.Errorf("invalid deposits: %v", err)
+	}
+	if fields.ExitCount, err = getFixedListCount(reader.getBodyField(4), sszVoluntaryExitSize); err != nil {
+		return nil, fmt.Errorf("invalid voluntary exits: %v", err)
+	}
+
+	if reader.version >= spec.DataVersionAltair {
+		fields.SyncCommitteeBits = make([]byte, reader.syncBitsSize)
+		copy(fields.SyncCommitteeBits, reader.body[sszBodySyncAggregatePos:sszBodySyncAggregatePos+reader.syncBitsSize])
+	}
+
+	if reader.version >= spec.DataVersionCapella {
+		if fields.BLSChangeCount, err = getFixedListCount(reader.getBodyField(6), sszBLSChangeSize); err != nil {
+			return nil, fmt.Errorf("invalid bls changes: %v", err)
+		}
+	}
+
+	if reader.version >= spec.DataVersionElectra {
+		depositRequests, err := getVariableField(reader.getBodyField(8), []uint32{0, 4, 8}, 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid execution requests: %v", err)
+		}
+
+		depositRequestCount, err := getFixedListCount(depositRequests, sszDepositRequestSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deposit requests: %v", err)
+		}
+		fields.DepositCount += depositRequestCount
+	}
+
+	if reader.payload != nil {
+		payload := reader.payload
+		copy(fields.ExecutionFeeRecipient[:], payload[sszPayloadFeeRecipientPos:sszPayloadFeeRecipientPos+20])
+		copy(fields.ExecutionHash[:], payload[sszPayloadBlockHashPos:sszPayloadBlockHashPos+32])
+		fields.ExecutionNumber = binary.LittleEndian.Uint64(payload[sszPayloadBlockNumberPos:])
+		fields.ExecutionGasLimit = binary.LittleEndian.Uint64(payload[sszPayloadGasLimitPos:])
+		fields.ExecutionGasUsed = binary.LittleEndian.Uint64(payload[sszPayloadGasUsedPos:])
+		fields.ExecutionBaseFee = uint256LEToUint64(payload[sszPayloadBaseFeePos : sszPayloadBaseFeePos+32])
+
+		payloadOffsets := []uint32{sszPayloadExtraDataOffsetPos, sszPayloadTxsOffsetPos}
+		if reader.version >= spec.DataVersionCapella {
+			payloadOffsets = append(payloadOffsets, sszPayloadTxsOffsetPos+4)
+		}
+
+		extraData, err := getVariableField(payload, payloadOffsets, 0)
+		if err != nil {
+			return nil, fmt.Errorf("invalid extra data: %v", err)
+		}
+		fields.ExecutionExtraData = make([]byte, len(extraData)) // copy to avoid referencing the whole block
+		copy(fields.ExecutionExtraData, extraData)
+
+		transactions, err := getVariableField(payload, payloadOffsets, 1)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transactions: %v", err)
+		}
+		if fields.TransactionCount, err = getVariableListCount(transactions); err != nil {
+			return nil, fmt.Errorf("invalid transactions: %v", err)
+		}
+
+		if reader.version >= spec.DataVersionCapella {
+			withdrawals, err := getVariableField(payload, payloadOffsets, 2)
+			if err != nil {
+				return nil, fmt.Errorf("invalid withdrawals: %v", err)
+			}
+			if fields.WithdrawalCount, err = getFixedListCount(withdrawals, sszWithdrawalSize); err != nil {
+				return nil, fmt.Errorf("invalid withdrawals: %v", err)
+			}
+
+			for i := uint64(0); i < fields.WithdrawalCount; i++ {
+				fields.WithdrawalAmount += binary.LittleEndian.Uint64(withdrawals[i*sszWithdrawalSize+36:])
+			}
+		}
+	}
+
+	return fields, nil
+}
+
+// uint256LEToUint64 converts a little endian encoded uint256 to uint64, values are capped to the int64 range to fit into the db.
+func uint256LEToUint64(data []byte) uint64 {
+	beBytes := make([]byte, len(data))
+	for i := range data {
+		beBytes[len(data)-1-i] = data[i]
+	}
+
+	value := new(big.Int).SetBytes(beBytes)
+	if !value.IsInt64() {
+		return math.MaxInt64
+	}
+
+	return value.Uint64()
+}
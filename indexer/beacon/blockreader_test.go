@@ -0,0 +1,110 @@
+package beacon
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// expectedCorpusBodyFields returns the body fields of the corpus block of the given fork version, as built by buildCorpusBlock.
+func expectedCorpusBodyFields(version spec.DataVersion) *blockBodyFields {
+	fields := &blockBodyFields{
+		AttestationCount:      3,
+		DepositCount:          2,
+		ExitCount:             2,
+		AttesterSlashingCount: 1,
+		ProposerSlashingCount: 1,
+	}
+
+	if version >= spec.DataVersionAltair {
+		fields.SyncCommitteeBits = bytes.Repeat([]byte{0xb7}, corpusSyncCommitteeSize/8)
+	}
+	if version >= spec.DataVersionBellatrix {
+		fields.ExecutionNumber = uint64(version)*1000 + 37 + 1000
+		fields.ExecutionExtraData = []byte("Geth/v1.14.0/linux")
+		fields.ExecutionGasLimit = 30000000
+		fields.ExecutionGasUsed = 12345678
+		fields.ExecutionBaseFee = 7
+		fields.TransactionCount = 3
+	}
+	if version >= spec.DataVersionCapella {
+		fields.BLSChangeCount = 1
+		fields.WithdrawalCount = 4
+		fields.WithdrawalAmount = 10000000
+	}
+	if version >= spec.DataVersionElectra {
+		fields.AttestationCount = 2
+		fields.DepositCount = 4 // 2 deposits & 2 deposit requests
+	}
+
+	return fields
+}
+
+func TestBlockBodyFields(t *testing.T) {
+	dynSsz := newCorpusDynSsz()
+	codecs := newTestCodecs(t)
+
+	for _, fixture := range loadCorpus(t) {
+		t.Run(fixture.version.String(), func(t *testing.T) {
+			decodedFields := getBlockBodyFields(fixture.block)
+
+			expected := expectedCorpusBodyFields(fixture.version)
+			expected.Graffiti = decodedFields.Graffiti
+			expected.ExecutionHash = decodedFields.ExecutionHash
+			expected.ExecutionFeeRecipient = decodedFields.ExecutionFeeRecipient
+			if !reflect.DeepEqual(decodedFields, expected) {
+				t.Errorf("unexpected body fields:\n got: %+v\nwant: %+v", decodedFields, expected)
+			}
+			if !strings.HasPrefix(string(decodedFields.Graffiti[:]), "Lighthouse/") {
+				t.Errorf("unexpected graffiti %q", decodedFields.Graffiti[:])
+			}
+
+			// the lazy ssz reader must extract the same fields as the full decoding, for all storage encodings
+			for _, codec := range codecs {
+				version, data, err := MarshalVersionedSignedBeaconBlockSSZ(dynSsz, fixture.block, codec, false)
+				if err != nil {
+					t.Fatalf("%v: marshal failed: %v", codec, err)
+				}
+
+				readerFields, err := unmarshalBlockBodyFields(version, data)
+				if err != nil {
+					t.Fatalf("%v: reading body fields failed: %v", codec, err)
+				}
+				if !reflect.DeepEqual(readerFields, decodedFields) {
+					t.Errorf("%v: ssz reader fields differ from decoded fields:\n got: %+v\nwant: %+v", codec, readerFields, decodedFields)
+				}
+			}
+		})
+	}
+}
+
+func TestSetBlockIndex(t *testing.T) {
+	dynSsz := newCorpusDynSsz()
+
+	for _, fixture := range loadCorpus(t) {
+		header, root, err := getBlockHeader(dynSsz, fixture.block)
+		if err != nil {
+			t.Fatalf("%v: failed building header: %v", fixture.version, err)
+		}
+
+		block := newBlock(dynSsz, root, header.Message.Slot)
+		block.SetHeader(header)
+		block.SetBlock(fixture.block)
+
+		index := block.GetBlockIndex()
+		fields := getBlockBodyFields(fixture.block)
+		if index == nil {
+			t.Fatalf("%v: missing block index", fixture.version)
+		}
+		if index.Graffiti != fields.Graffiti || index.ExecutionNumber != fields.ExecutionNumber || index.ExecutionHash != fields.ExecutionHash || index.ExecutionFeeRecipient != fields.ExecutionFeeRecipient || !bytes.Equal(index.ExecutionExtraData, fields.ExecutionExtraData) {
+			t.Errorf("%v: block index does not match body fields", fixture.version)
+		}
+		if fixture.version >= spec.DataVersionBellatrix && index.ExecutionHash == (phase0.Hash32{}) {
+			t.Errorf("%v: missing execution hash in block index", fixture.version)
+		}
+	}
+}
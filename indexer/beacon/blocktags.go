@@ -0,0 +1,75 @@
+package beacon
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/ethpandaops/dora/utils"
+)
+
+// blockTagRule is a compiled block tag rule from the indexer config
+type blockTagRule struct {
+	tag       string
+	graffiti  *regexp.Regexp
+	extraData *regexp.Regexp
+}
+
+var blockTagRules []*blockTagRule
+var blockTagRulesOnce sync.Once
+
+// getBlockTagRules compiles the block tag rules from the config (patterns have already been validated when loading the config)
+func getBlockTagRules() []*blockTagRule {
+	blockTagRulesOnce.Do(func() {
+		if utils.Config == nil {
+			return
+		}
+
+		for _, ruleConfig := range utils.Config.Indexer.BlockTags {
+			rule := &blockTagRule{
+				tag: ruleConfig.Tag,
+			}
+			if ruleConfig.Graffiti != "" {
+				rule.graffiti = regexp.MustCompile(ruleConfig.Graffiti)
+			}
+			if ruleConfig.ExtraData != "" {
+				rule.extraData = regexp.MustCompile(ruleConfig.ExtraData)
+			}
+			blockTagRules = append(blockTagRules, rule)
+		}
+	})
+
+	return blockTagRules
+}
+
+// HasBlockTagRules returns true if there are block tag rules configured
+func HasBlockTagRules() bool {
+	return len(getBlockTagRules()) > 0
+}
+
+// MatchBlockTags returns the config defined tags that apply to a block with the given graffiti and execution extra data.
+// a rule applies if all of its patterns match, tags are returned in config order without duplicates.
+func MatchBlockTags(graffiti string, extraData string) []string {
+	var tags []string
+
+	for _, rule := range getBlockTagRules() {
+		if rule.graffiti != nil && !rule.graffiti.MatchString(graffiti) {
+			continue
+		}
+		if rule.extraData != nil && !rule.extraData.MatchString(extraData) {
+			continue
+		}
+
+		duplicate := false
+		for _, tag := range tags {
+			if tag == rule.tag {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			tags = append(tags, rule.tag)
+		}
+	}
+
+	return tags
+}
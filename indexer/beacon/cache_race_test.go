@@ -0,0 +1,199 @@
+package beacon
+
+import (
+	"encoding/binary"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+const (
+	raceTestWriters       = 4
+	raceTestReaders       = 4
+	raceTestSlots         = 512
+	raceTestSlotsPerEpoch = 8
+	raceTestForkInterval  = 16
+	raceTestMaxLag        = 32  // max slot distance between the fastest & slowest writer
+	raceTestPruneDistance = 256 // pruned blocks stay far behind all reader walks
+)
+
+// raceTestRoot returns a deterministic block root for the given writer chain & slot.
+func raceTestRoot(writer int, slot phase0.Slot) phase0.Root {
+	root := phase0.Root{}
+	root[0] = byte(writer + 1)
+	binary.BigEndian.PutUint64(root[8:], uint64(slot))
+	return root
+}
+
+// TestCacheConcurrentAccess drives the block, fork & epoch caches from concurrent writers, readers & a pruner.
+// it does not assert much on its own, but is meant to be run with the race detector (go test -race) to catch
+// unsynchronized map access & lock hierarchy violations (deadlocks) in the cache implementations.
+//
+// block properties (header, disposed flag) are not synchronized by the caches, so the test only publishes blocks
+// after their header is set and prunes far enough behind the readers to never dispose a block that is still walked.
+func TestCacheConcurrentAccess(t *testing.T) {
+	indexer := newTestIndexer(t, newCorpusDynSsz())
+
+	progress := make([]atomic.Uint64, raceTestWriters)
+	minProgress := func() phase0.Slot {
+		min := progress[0].Load()
+		for i := 1; i < raceTestWriters; i++ {
+			if p := progress[i].Load(); p < min {
+				min = p
+			}
+		}
+		return phase0.Slot(min)
+	}
+
+	writersDone := make(chan struct{})
+	writerWg := sync.WaitGroup{}
+	workerWg := sync.WaitGroup{}
+
+	for w := 0; w < raceTestWriters; w++ {
+		writerWg.Add(1)
+		go func(writer int) {
+			defer writerWg.Done()
+
+			parentFork := ForkKey(0)
+			for slot := phase0.Slot(1); slot <= raceTestSlots; slot++ {
+				for slot > minProgress()+raceTestMaxLag {
+					runtime.Gosched()
+				}
+
+				root := raceTestRoot(writer, slot)
+				block, isNew := indexer.blockCache.createOrGetBlock(root, slot)
+				if !isNew {
+					t.Errorf("block %v of writer %v already exists", slot, writer)
+					return
+				}
+
+				block.SetHeader(&phase0.SignedBeaconBlockHeader{
+					Message: &phase0.BeaconBlockHeader{
+						Slot:       slot,
+						ParentRoot: raceTestRoot(writer, slot-1),
+					},
+				})
+				indexer.blockCache.addBlockToParentMap(block)
+				indexer.blockCache.setLatestBlock(block)
+
+				if slot%raceTestSlotsPerEpoch == 0 {
+					epoch := phase0.Epoch(slot / raceTestSlotsPerEpoch)
+					indexer.epochCache.createOrGetEpochStats(epoch, raceTestRoot(writer, slot-1), false)
+				}
+
+				if slot%raceTestForkInterval == 0 {
+					forkId := ForkKey(uint64(slot)*raceTestWriters + uint64(writer))
+					baseSlot := slot - raceTestForkInterval
+					indexer.forkCache.addFork(newFork(forkId, baseSlot, raceTestRoot(writer, baseSlot), block, parentFork))
+					parentFork = forkId
+				}
+
+				progress[writer].Store(uint64(slot))
+			}
+		}(w)
+	}
+
+	for r := 0; r < raceTestReaders; r++ {
+		workerWg.Add(1)
+		go func() {
+			defer workerWg.Done()
+
+			for {
+				select {
+				case <-writersDone:
+					return
+				default:
+				}
+
+				latest := indexer.blockCache.getLatestBlock()
+				if latest == nil || latest.Slot <= raceTestMaxLag+raceTestSlotsPerEpoch {
+					runtime.Gosched()
+					continue
+				}
+
+				writer := int(latest.Root[0]) - 1
+				ancestor := raceTestRoot(writer, latest.Slot-raceTestSlotsPerEpoch)
+				if isCanonical, distance := indexer.blockCache.getCanonicalDistance(ancestor, latest.Root, 2*raceTestSlotsPerEpoch); !isCanonical || distance != raceTestSlotsPerEpoch {
+					t.Errorf("unexpected canonical distance %v (%v) for slot %v", distance, isCanonical, latest.Slot)
+				}
+
+				indexer.blockCache.getBlockByRoot(ancestor)
+				for _, block := range indexer.blockCache.getBlocksBySlot(latest.Slot - 1) {
+					_ = block.Root
+				}
+				indexer.blockCache.getBlocksByParentRoot(ancestor)
+
+				indexer.forkCache.getForkByLeaf(latest.Root)
+				indexer.forkCache.getForkByBase(ancestor)
+				indexer.forkCache.getForks()
+				indexer.forkCache.getForkHeads()
+
+				// all writers passed the dependent block of this epoch, so the canonical walk stops within the kept range
+				epoch := phase0.Epoch(latest.Slot/raceTestSlotsPerEpoch) - raceTestMaxLag/raceTestSlotsPerEpoch
+				indexer.epochCache.getEpochStatsByEpoch(epoch)
+				indexer.epochCache.getEpochStatsBeforeEpoch(epoch)
+				indexer.epochCache.getEpochStats(epoch, raceTestRoot(writer, phase0.Slot(epoch)*raceTestSlotsPerEpoch-1))
+				if stats := indexer.epochCache.getEpochStatsByEpochAndRoot(epoch, latest.Root); stats != nil && stats.dependentRoot[0] != latest.Root[0] {
+					t.Errorf("epoch %v stats of another chain returned for slot %v", epoch, latest.Slot)
+				}
+			}
+		}()
+	}
+
+	workerWg.Add(1)
+	go func() {
+		defer workerWg.Done()
+
+		prunedSlot := phase0.Slot(0)
+		for {
+			select {
+			case <-writersDone:
+				return
+			default:
+			}
+
+			minSlot := minProgress()
+			if minSlot <= raceTestPruneDistance+prunedSlot {
+				runtime.Gosched()
+				continue
+			}
+
+			cutoff := minSlot - raceTestPruneDistance
+			for ; prunedSlot < cutoff; prunedSlot++ {
+				for _, block := range indexer.blockCache.getBlocksBySlot(prunedSlot) {
+					indexer.blockCache.removeBlock(block)
+				}
+			}
+
+			for _, stats := range indexer.epochCache.getEpochStatsBeforeEpoch(phase0.Epoch(cutoff / raceTestSlotsPerEpoch)) {
+				indexer.epochCache.removeEpochStats(stats)
+			}
+
+			for _, fork := range indexer.forkCache.getForks() {
+				if fork.leafSlot < cutoff {
+					indexer.forkCache.removeFork(fork.forkId)
+				}
+			}
+		}
+	}()
+
+	writerWg.Wait()
+	close(writersDone)
+	workerWg.Wait()
+
+	latest := indexer.blockCache.getLatestBlock()
+	if latest == nil || latest.Slot != raceTestSlots {
+		t.Fatalf("unexpected latest block after all writers completed")
+	}
+	for w := 0; w < raceTestWriters; w++ {
+		if block := indexer.blockCache.getBlockByRoot(raceTestRoot(w, raceTestSlots)); block == nil {
+			t.Errorf("head block of writer %v missing", w)
+		}
+		if len(indexer.forkCache.getForkByBase(raceTestRoot(w, raceTestSlots-raceTestForkInterval))) != 1 {
+			t.Errorf("head fork of writer %v missing", w)
+		}
+	}
+}
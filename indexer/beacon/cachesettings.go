@@ -0,0 +1,186 @@
+package beacon
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// BlockCacheSettings holds the runtime tunable retention parameters of the block cache.
+type BlockCacheSettings struct {
+	InMemoryEpochs     uint16 `json:"in_memory_epochs"`      // number of epochs to keep in memory before pruning
+	BodyEpochs         uint16 `json:"body_epochs"`           // number of recent epochs to keep block bodies for (0 = all in-memory epochs), older blocks are kept as headers only
+	MaxForkBlockBodies uint64 `json:"max_fork_block_bodies"` // max number of block bodies to keep per non-canonical fork (0 = unlimited)
+}
+
+// BlockCacheStats holds statistics about the current block cache usage.
+type BlockCacheStats struct {
+	Blocks       uint64 `json:"blocks"`
+	Bodies       uint64 `json:"bodies"`
+	HeadersOnly  uint64 `json:"headers_only"`
+	Forks        uint64 `json:"forks"`
+	LowestSlot   uint64 `json:"lowest_slot"`
+	HighestSlot  uint64 `json:"highest_slot"`
+	PrunedEpoch  uint64 `json:"pruned_epoch"`
+	CurrentEpoch uint64 `json:"current_epoch"`
+}
+
+// GetBlockCacheSettings returns the current block cache retention settings.
+func (indexer *Indexer) GetBlockCacheSettings() BlockCacheSettings {
+	indexer.cacheSettingsMutex.Lock()
+	defer indexer.cacheSettingsMutex.Unlock()
+
+	return BlockCacheSettings{
+		InMemoryEpochs:     indexer.inMemoryEpochs,
+		BodyEpochs:         indexer.bodyEpochs,
+		MaxForkBlockBodies: indexer.maxForkBlockBodies,
+	}
+}
+
+// getInMemoryEpochs returns the number of epochs to keep in memory, the setting may be updated at runtime.
+func (indexer *Indexer) getInMemoryEpochs() phase0.Epoch {
+	indexer.cacheSettingsMutex.Lock()
+	defer indexer.cacheSettingsMutex.Unlock()
+
+	return phase0.Epoch(indexer.inMemoryEpochs)
+}
+
+// SetBlockCacheSettings updates the block cache retention settings.
+// The new settings are applied by the indexer loop right away, which prunes the cache accordingly.
+func (indexer *Indexer) SetBlockCacheSettings(settings BlockCacheSettings) error {
+	if settings.InMemoryEpochs < 2 {
+		return fmt.Errorf("in_memory_epochs must be at least 2")
+	}
+	if settings.BodyEpochs > settings.InMemoryEpochs {
+		return fmt.Errorf("body_epochs must not exceed in_memory_epochs")
+	}
+
+	select {
+	case indexer.cacheSettingsChan <- &settings:
+	default:
+		return fmt.Errorf("another cache settings update is pending")
+	}
+
+	return nil
+}
+
+// applyBlockCacheSettings applies updated cache settings, called from the indexer loop only.
+func (indexer *Indexer) applyBlockCacheSettings(settings *BlockCacheSettings) {
+	indexer.cacheSettingsMutex.Lock()
+	indexer.inMemoryEpochs = settings.InMemoryEpochs
+	indexer.bodyEpochs = settings.BodyEpochs
+	indexer.maxForkBlockBodies = settings.MaxForkBlockBodies
+	indexer.cacheSettingsMutex.Unlock()
+
+	indexer.logger.Infof("updated block cache settings (in memory epochs: %v, body epochs: %v, max fork bodies: %v)", settings.InMemoryEpochs, settings.BodyEpochs, settings.MaxForkBlockBodies)
+
+	if indexer.running {
+		err := indexer.runCachePruning()
+		if err != nil {
+			indexer.logger.WithError(err).Errorf("failed pruning cache")
+		}
+	}
+}
+
+// GetBlockCacheStats returns statistics about the current block cache usage.
+func (indexer *Indexer) GetBlockCacheStats() BlockCacheStats {
+	stats := BlockCacheStats{
+		PrunedEpoch:  uint64(indexer.lastPrunedEpoch),
+		CurrentEpoch: uint64(indexer.consensusPool.GetChainState().CurrentEpoch()),
+	}
+
+	forkIds := map[ForkKey]bool{}
+	lowestSlot := int64(-1)
+
+	// collect the blocks first, checking the bodies requires the block locks which might be held during body loading
+	cachedBlocks := []*Block{}
+	indexer.blockCache.cacheMutex.RLock()
+	for slot, blocks := range indexer.blockCache.slotMap {
+		for _, block := range blocks {
+			cachedBlocks = append(cachedBlocks, block)
+			forkIds[block.forkId] = true
+		}
+
+		if lowestSlot == -1 || int64(slot) < lowestSlot {
+			lowestSlot = int64(slot)
+		}
+		if uint64(slot) > stats.HighestSlot {
+			stats.HighestSlot = uint64(slot)
+		}
+	}
+	indexer.blockCache.cacheMutex.RUnlock()
+
+	for _, block := range cachedBlocks {
+		stats.Blocks++
+		if block.hasBlockBody() {
+			stats.Bodies++
+		} else {
+			stats.HeadersOnly++
+		}
+	}
+
+	if lowestSlot > 0 {
+		stats.LowestSlot = uint64(lowestSlot)
+	}
+	stats.Forks = uint64(len(forkIds))
+
+	return stats
+}
+
+// pruneBlockBodies drops block bodies that are outside the configured body retention range.
+// only bodies that are persisted in the unfinalized blocks table are dropped, so they can be reloaded on demand.
+func (indexer *Indexer) pruneBlockBodies() uint64 {
+	settings := indexer.GetBlockCacheSettings()
+	chainState := indexer.consensusPool.GetChainState()
+
+	minBodySlot := phase0.Slot(0)
+	if settings.BodyEpochs > 0 {
+		currentEpoch := chainState.CurrentEpoch()
+		if currentEpoch > phase0.Epoch(settings.BodyEpochs) {
+			minBodySlot = chainState.EpochToSlot(currentEpoch - phase0.Epoch(settings.BodyEpochs))
+		}
+	}
+
+	canonicalHead := indexer.GetCanonicalHead(nil)
+	prunableBlocks := indexer.blockCache.getBodyPruningCandidates()
+	forkBlocks := map[ForkKey][]*Block{}
+	prunedBodies := uint64(0)
+
+	for _, block := range prunableBlocks {
+		if block.Slot < minBodySlot {
+			if block.pruneBlockBody() {
+				prunedBodies++
+			}
+			continue
+		}
+
+		if settings.MaxForkBlockBodies > 0 && !indexer.IsCanonicalBlockByHead(block, canonicalHead) {
+			forkBlocks[block.forkId] = append(forkBlocks[block.forkId], block)
+		}
+	}
+
+	for _, blocks := range forkBlocks {
+		if uint64(len(blocks)) <= settings.MaxForkBlockBodies {
+			continue
+		}
+
+		sort.Slice(blocks, func(i, j int) bool {
+			return blocks[i].Slot > blocks[j].Slot
+		})
+
+		for _, block := range blocks[settings.MaxForkBlockBodies:] {
+			if block.pruneBlockBody() {
+				prunedBodies++
+			}
+		}
+	}
+
+	if prunedBodies > 0 {
+		runtime.GC()
+		indexer.logger.Infof("pruned %v block bodies from cache", prunedBodies)
+	}
+
+	return prunedBodies
+}
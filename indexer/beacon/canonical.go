@@ -16,7 +16,8 @@ const FarFutureEpoch = phase0.Epoch(math.MaxUint64)
 // ChainHead represents a head block of the chain.
 type ChainHead struct {
 	HeadBlock             *Block      // The head block of the chain.
-	AggregatedHeadVotes   phase0.Gwei // The aggregated votes of the last 2 epochs for the head block.
+	AggregatedHeadVotes   phase0.Gwei // The aggregated votes of the last epochs for the head block.
+	ProposerBoost         phase0.Gwei // The proposer boost weight of the head block (included in AggregatedHeadVotes).
 	PerEpochVotingPercent []float64   // The voting percentage in the last epochs (ascendeing order).
 }
 
@@ -73,23 +74,30 @@ func (indexer *Indexer) computeCanonicalChain() bool {
 	indexer.canonicalHeadMutex.Lock()
 	defer indexer.canonicalHeadMutex.Unlock()
 
-	if indexer.blockCache.latestBlock == nil {
+	latestBlock := indexer.blockCache.getLatestBlock()
+	if latestBlock == nil {
 		return false
 	}
 
-	latestBlockRoot := indexer.blockCache.latestBlock.Root
-	if bytes.Equal(latestBlockRoot[:], indexer.canonicalComputation[:]) {
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	currentSlot := chainState.CurrentSlot()
+
+	// the proposer boost expires with the slot, so the head needs to be recomputed on each slot when enabled
+	latestBlockRoot := latestBlock.Root
+	if bytes.Equal(latestBlockRoot[:], indexer.canonicalComputation[:]) && (indexer.proposerBoost == 0 || indexer.canonicalSlot == currentSlot) {
 		return false
 	}
 
 	var headBlock *Block = nil
 	var chainHeads []*ChainHead = nil
 
-	chainState := indexer.consensusPool.GetChainState()
-	specs := chainState.GetSpecs()
-	aggregateEpochs := (32 / specs.SlotsPerEpoch) + 1 // aggregate votes of last 48 slots (2 epochs for mainnet, 5 epochs for minimal config)
-	if aggregateEpochs < 2 {
-		aggregateEpochs = 2
+	aggregateEpochs := indexer.forkChoiceEpochs
+	if aggregateEpochs == 0 {
+		aggregateEpochs = (32 / specs.SlotsPerEpoch) + 1 // aggregate votes of last 48 slots (2 epochs for mainnet, 5 epochs for minimal config)
+		if aggregateEpochs < 2 {
+			aggregateEpochs = 2
+		}
 	}
 
 	t1 := time.Now()
@@ -98,6 +106,7 @@ func (indexer *Indexer) computeCanonicalChain() bool {
 		indexer.canonicalHead = headBlock
 		indexer.cachedChainHeads = chainHeads
 		indexer.canonicalComputation = latestBlockRoot
+		indexer.canonicalSlot = currentSlot
 
 		if headBlock == nil {
 			indexer.logger.Warnf("canonical head computation failed. forks: %v, latest block: %v, time: %v ms", len(chainHeads), latestBlockRoot.String(), time.Since(t1).Milliseconds())
@@ -119,10 +128,13 @@ func (indexer *Indexer) computeCanonicalChain() bool {
 		}
 
 		forkVotes, epochParticipation := indexer.aggregateForkVotes(fork.ForkId, aggregateEpochs)
+		proposerBoost := indexer.getProposerBoost(fork.Block, currentSlot)
+		forkVotes += proposerBoost
 		headForkVotes[fork.ForkId] = forkVotes
 		chainHeads = append(chainHeads, &ChainHead{
 			HeadBlock:             fork.Block,
 			AggregatedHeadVotes:   forkVotes,
+			ProposerBoost:         proposerBoost,
 			PerEpochVotingPercent: epochParticipation,
 		})
 
@@ -133,10 +145,12 @@ func (indexer *Indexer) computeCanonicalChain() bool {
 			}
 
 			indexer.logger.Infof(
-				"fork %v: votes in last 2 epochs: %v ETH (%v), head: %v (%v)",
+				"fork %v: votes in last %v epochs: %v ETH (%v, boost: %v ETH), head: %v (%v)",
 				fork.ForkId,
+				aggregateEpochs,
 				forkVotes/EtherGweiFactor,
 				strings.Join(participationStr, ", "),
+				proposerBoost/EtherGweiFactor,
 				fork.Block.Slot,
 				fork.Block.Root.String(),
 			)
@@ -204,6 +218,35 @@ func (indexer *Indexer) computeCanonicalChain() bool {
 	return true
 }
 
+// getProposerBoost returns the proposer boost weight of a fork head block.
+// like in the fork choice, only blocks of the current slot that have been received before the attestation deadline (first third of the slot) are boosted.
+func (indexer *Indexer) getProposerBoost(block *Block, currentSlot phase0.Slot) phase0.Gwei {
+	if indexer.proposerBoost == 0 || block.Slot != currentSlot {
+		return 0
+	}
+
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	firstSeen := block.GetFirstSeen()
+	if firstSeen.IsZero() || firstSeen.Sub(chainState.SlotToTime(block.Slot)) > specs.SecondsPerSlot/3 {
+		return 0
+	}
+
+	epochStats := indexer.epochCache.getEpochStatsByEpochAndRoot(chainState.EpochOfSlot(block.Slot), block.Root)
+	if epochStats == nil {
+		return 0
+	}
+
+	epochStatsValues := epochStats.GetValues(false)
+	if epochStatsValues == nil {
+		return 0
+	}
+
+	committeeWeight := epochStatsValues.EffectiveBalance / phase0.Gwei(specs.SlotsPerEpoch)
+	return committeeWeight * phase0.Gwei(indexer.proposerBoost) / 100
+}
+
 // aggregateForkVotes aggregates the votes for a given fork.
 func (indexer *Indexer) aggregateForkVotes(forkId ForkKey, epochLimit uint64) (totalVotes phase0.Gwei, epochPercent []float64) {
 	chainState := indexer.consensusPool.GetChainState()
@@ -12,6 +12,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/selection"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/jmoiron/sqlx"
@@ -26,18 +27,18 @@ type Client struct {
 	logger   logrus.FieldLogger
 	indexing bool
 
-	priority       int
-	archive        bool
 	skipValidators bool
 
 	blockSubscription *consensus.Subscription[*v1.BlockEvent]
 	headSubscription  *consensus.Subscription[*v1.HeadEvent]
 
 	headRoot phase0.Root
+
+	fetchStats clientFetchStats
 }
 
 // newClient creates a new indexer client for a given consensus pool client.
-func newClient(index uint16, client *consensus.Client, priority int, archive bool, skipValidators bool, indexer *Indexer, logger logrus.FieldLogger) *Client {
+func newClient(index uint16, client *consensus.Client, skipValidators bool, indexer *Indexer, logger logrus.FieldLogger) *Client {
 	return &Client{
 		indexer:  indexer,
 		index:    index,
@@ -45,8 +46,6 @@ func newClient(index uint16, client *consensus.Client, priority int, archive boo
 		logger:   logger,
 		indexing: false,
 
-		priority:       priority,
-		archive:        archive,
 		skipValidators: skipValidators,
 	}
 }
@@ -60,7 +59,11 @@ func (c *Client) GetClient() *consensus.Client {
 }
 
 func (c *Client) GetPriority() int {
-	return c.priority
+	return c.client.GetSelection().Priority
+}
+
+func (c *Client) getSelection() *selection.Settings {
+	return c.client.GetSelection()
 }
 
 func (c *Client) GetIndex() uint16 {
@@ -189,8 +192,19 @@ func (c *Client) processBlockEvent(blockEvent *v1.BlockEvent) error {
 		return nil
 	}
 
-	_, err := c.processStreamBlock(blockEvent.Slot, blockEvent.Block)
-	return err
+	block, err := c.processStreamBlock(blockEvent.Slot, blockEvent.Block)
+	if err != nil {
+		return err
+	}
+
+	// the execution_optimistic flag tells whether the payload has been verified by the execution engine at import
+	if blockEvent.ExecutionOptimistic {
+		block.SetPayloadStatus(c, dbtypes.PayloadStatusSyncing)
+	} else {
+		block.SetPayloadStatus(c, dbtypes.PayloadStatusValid)
+	}
+
+	return nil
 }
 
 // processHeadEvent processes a head event from the event stream.
@@ -232,6 +246,11 @@ func (c *Client) processHeadEvent(headEvent *v1.HeadEvent) error {
 		}
 	}
 
+	if c.client.GetStatus() == consensus.ClientStatusOnline {
+		// the head of a non-optimistic client has a verified payload, so have all its ancestors
+		c.setPayloadsValid(block)
+	}
+
 	chainState := c.client.GetPool().GetChainState()
 	dependentRoot := headEvent.CurrentDutyDependentRoot
 
@@ -281,16 +300,27 @@ func (c *Client) processHeadEvent(headEvent *v1.HeadEvent) error {
 	}
 
 	c.headRoot = block.Root
+
+	if c.indexer.eventDispatcher != nil {
+		c.indexer.eventDispatcher.emitEvent(&IndexerEvent{
+			Type:  IndexerEventHead,
+			Block: block,
+		})
+	}
+
 	return nil
 }
 
 // processStreamBlock processes a block received from the stream (either via block or head events).
 func (c *Client) processStreamBlock(slot phase0.Slot, root phase0.Root) (*Block, error) {
+	seenTime := time.Now()
 	block, isNew, processingTimes, err := c.processBlock(slot, root, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	block.SetSeenBy(c, seenTime)
+
 	c.emitBlockLogEntry(slot, root, "stream", isNew, block.forkId, processingTimes)
 
 	return block, nil
@@ -323,6 +353,10 @@ func (c *Client) processReorg(oldHead *Block, newHead *Block) error {
 		rewindDistance++
 	}
 
+	if rewindDistance > 0 && reorgBase != nil && c.client.GetStatus() == consensus.ClientStatusOnline {
+		c.setPayloadsInvalid(oldHead, reorgBase)
+	}
+
 	if rewindDistance == 0 {
 		c.logger.Debugf("chain fast forward! +%v slots (old: %v, new: %v)", forwardDistance, oldHead.Root.String(), newHead.Root.String())
 		return nil // just a fast forward
@@ -334,11 +368,59 @@ func (c *Client) processReorg(oldHead *Block, newHead *Block) error {
 
 	c.logger.Infof("chain reorg! depth: -%v / +%v (old: %v, new: %v)", rewindDistance, forwardDistance, oldHead.Root.String(), newHead.Root.String())
 
-	// TODO: do something with reorgs?
+	if c.indexer.reassignmentTracker != nil {
+		c.indexer.reassignmentTracker.checkAssignments()
+	}
+
+	if c.indexer.eventDispatcher != nil {
+		c.indexer.eventDispatcher.emitEvent(&IndexerEvent{
+			Type:            IndexerEventReorg,
+			Block:           newHead,
+			OldHead:         oldHead,
+			RewindDistance:  rewindDistance,
+			ForwardDistance: forwardDistance,
+		})
+	}
 
 	return nil
 }
 
+// setPayloadsValid marks the payloads of the block and its optimistically imported ancestors as valid for this client.
+func (c *Client) setPayloadsValid(block *Block) {
+	for block != nil {
+		if block.GetPayloadStatus(c) == dbtypes.PayloadStatusValid {
+			break
+		}
+
+		block.SetSeenBy(c, time.Time{})
+		block.SetPayloadStatus(c, dbtypes.PayloadStatusValid)
+
+		parentRoot := block.GetParentRoot()
+		if parentRoot == nil {
+			break
+		}
+		block = c.indexer.blockCache.getBlockByRoot(*parentRoot)
+	}
+}
+
+// setPayloadsInvalid marks the optimistically imported blocks that have been dropped by a non-optimistic client in a reorg as invalid.
+// a synced client only drops optimistically imported blocks when its execution engine rejects the payload.
+func (c *Client) setPayloadsInvalid(oldHead *Block, reorgBase *Block) {
+	block := oldHead
+	for block != nil && block.Slot > reorgBase.Slot {
+		if block.GetPayloadStatus(c) == dbtypes.PayloadStatusSyncing {
+			block.SetPayloadStatus(c, dbtypes.PayloadStatusInvalid)
+			c.logger.Warnf("optimistic block %v (%v) dropped, payload considered invalid", block.Slot, block.Root.String())
+		}
+
+		parentRoot := block.GetParentRoot()
+		if parentRoot == nil {
+			break
+		}
+		block = c.indexer.blockCache.getBlockByRoot(*parentRoot)
+	}
+}
+
 // processBlock processes a block (from stream & polling).
 func (c *Client) processBlock(slot phase0.Slot, root phase0.Root, header *phase0.SignedBeaconBlockHeader) (block *Block, isNew bool, processingTimes []time.Duration, err error) {
 	chainState := c.client.GetPool().GetChainState()
@@ -372,6 +454,10 @@ func (c *Client) processBlock(slot phase0.Slot, root phase0.Root, header *phase0
 			processingTimes[0] += time.Since(t1)
 		}()
 
+		if slot >= finalizedSlot {
+			return c.indexer.loadHeaderRace(c, root)
+		}
+
 		return LoadBeaconHeader(c.getContext(), c, root)
 	})
 	if err != nil {
@@ -385,6 +471,10 @@ func (c *Client) processBlock(slot phase0.Slot, root phase0.Root, header *phase0
 			processingTimes[0] += time.Since(t1)
 		}()
 
+		if slot >= finalizedSlot {
+			return c.indexer.loadBlockRace(c, root)
+		}
+
 		return LoadBeaconBlock(c.getContext(), c, root)
 	})
 	if err != nil {
@@ -402,6 +492,16 @@ func (c *Client) processBlock(slot phase0.Slot, root phase0.Root, header *phase0
 			c.logger.Warnf("failed processing new fork: %v", err2)
 		}
 
+		// double proposal detection
+		c.indexer.checkProposerEquivocation(block)
+
+		if c.indexer.eventDispatcher != nil {
+			c.indexer.eventDispatcher.emitEvent(&IndexerEvent{
+				Type:  IndexerEventNewBlock,
+				Block: block,
+			})
+		}
+
 		// insert into unfinalized blocks
 		var dbBlock *dbtypes.UnfinalizedBlock
 		dbBlock, err = block.buildUnfinalizedBlock(c.indexer.blockCompression)
@@ -413,13 +513,14 @@ func (c *Client) processBlock(slot phase0.Slot, root phase0.Root, header *phase0
 		t1 = time.Now()
 
 		// write to db
-		err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		var persisted bool
+		persisted, err = c.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 			err := db.InsertUnfinalizedBlock(dbBlock, tx)
 			if err != nil {
 				return err
 			}
 
-			return nil
+			return c.indexer.dbWriter.persistExecutionBlockSlot(tx, block, false, nil)
 		})
 		if err != nil {
 			return
@@ -427,8 +528,9 @@ func (c *Client) processBlock(slot phase0.Slot, root phase0.Root, header *phase0
 
 		processingTimes[2] = time.Since(t1)
 
-		block.isInUnfinalizedDb = true
-		c.indexer.blockCache.latestBlock = block
+		// read-only indexers keep the body in memory, it is persisted on promotion or dropped once the writer stored it
+		block.isInUnfinalizedDb = persisted
+		c.indexer.blockCache.setLatestBlock(block)
 	}
 
 	if slot < finalizedSlot && !block.isInFinalizedDb {
@@ -0,0 +1,136 @@
+package beacon
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/execution"
+)
+
+// graffitiClientVersionPattern matches the client version suffix appended to the graffiti by most clients (see engine_getClientVersionV1).
+// format: <el code><el commit><cl code><cl commit>, where the commit prefix is 4, 2 or 0 hex chars long (eg. "GEab12LHcd34", "GEabLHcd", "GELH").
+var graffitiClientVersionPattern = regexp.MustCompile(`(BU|EJ|EG|GE|NM|RH)([0-9a-f]{4}|[0-9a-f]{2}|)(GR|LH|LS|NB|PM|TK)([0-9a-f]{4}|[0-9a-f]{2}|)$`)
+
+var graffitiClientCodesCL = map[string]consensus.ClientType{
+	"GR": consensus.GrandineClient,
+	"LH": consensus.LighthouseClient,
+	"LS": consensus.LodestarClient,
+	"NB": consensus.NimbusClient,
+	"PM": consensus.PrysmClient,
+	"TK": consensus.TekuClient,
+}
+
+var graffitiClientCodesEL = map[string]execution.ClientType{
+	"BU": execution.BesuClient,
+	"EJ": execution.EthjsClient,
+	"EG": execution.ErigonClient,
+	"GE": execution.GethClient,
+	"NM": execution.NethermindClient,
+	"RH": execution.RethClient,
+}
+
+// clientNamePatternsCL & clientNamePatternsEL are used as fallback when no client version suffix is found.
+// the patterns are checked in order, the first match wins.
+var clientNamePatternsCL = []struct {
+	client  consensus.ClientType
+	pattern *regexp.Regexp
+}{
+	{consensus.LighthouseClient, regexp.MustCompile(`(?i)lighthouse`)},
+	{consensus.LodestarClient, regexp.MustCompile(`(?i)lodestar`)},
+	{consensus.NimbusClient, regexp.MustCompile(`(?i)nimbus`)},
+	{consensus.PrysmClient, regexp.MustCompile(`(?i)prysm`)},
+	{consensus.TekuClient, regexp.MustCompile(`(?i)\bteku\b`)},
+	{consensus.GrandineClient, regexp.MustCompile(`(?i)grandine`)},
+	{consensus.CaplinClient, regexp.MustCompile(`(?i)caplin`)},
+}
+
+var clientNamePatternsEL = []struct {
+	client  execution.ClientType
+	pattern *regexp.Regexp
+}{
+	{execution.BesuClient, regexp.MustCompile(`(?i)\bbesu\b`)},
+	{execution.ErigonClient, regexp.MustCompile(`(?i)erigon`)},
+	{execution.EthjsClient, regexp.MustCompile(`(?i)ethereumjs`)},
+	{execution.NethermindClient, regexp.MustCompile(`(?i)nethermind`)},
+	{execution.RethClient, regexp.MustCompile(`(?i)\breth\b`)},
+	{execution.GethClient, regexp.MustCompile(`(?i)\bgeth\b|go-ethereum`)},
+}
+
+// ClientClassificationSource is the block field a client classification has been derived from.
+type ClientClassificationSource uint8
+
+const (
+	ClientSourceNone            ClientClassificationSource = iota
+	ClientSourceGraffitiVersion                            // client version suffix in the graffiti
+	ClientSourceGraffitiName                               // client name in the graffiti
+	ClientSourceExtraData                                  // client name in the execution extra data
+)
+
+// BlockClientClassification is the guessed consensus & execution client of a block with the source of each guess.
+type BlockClientClassification struct {
+	ClClient consensus.ClientType
+	ClSource ClientClassificationSource
+	ElClient execution.ClientType
+	ElSource ClientClassificationSource
+}
+
+// ClassifyBlockClients guesses the consensus & execution client that produced a block from its graffiti and execution extra data.
+// the client version suffix in the graffiti is the most reliable source, so it is checked first.
+// execution clients are additionally identified from the extra data, consensus clients from client names in the graffiti.
+// clients that could not be identified are returned as UnknownClient.
+func ClassifyBlockClients(graffiti string, extraData string) *BlockClientClassification {
+	result := &BlockClientClassification{
+		ClClient: consensus.UnknownClient,
+		ElClient: execution.UnknownClient,
+	}
+
+	graffiti = strings.TrimSpace(graffiti)
+	if match := graffitiClientVersionPattern.FindStringSubmatch(graffiti); match != nil && len(match[2]) == len(match[4]) {
+		result.ElClient = graffitiClientCodesEL[match[1]]
+		result.ElSource = ClientSourceGraffitiVersion
+		result.ClClient = graffitiClientCodesCL[match[3]]
+		result.ClSource = ClientSourceGraffitiVersion
+	}
+
+	if result.ClClient == consensus.UnknownClient {
+		for _, entry := range clientNamePatternsCL {
+			if entry.pattern.MatchString(graffiti) {
+				result.ClClient = entry.client
+				result.ClSource = ClientSourceGraffitiName
+				break
+			}
+		}
+	}
+
+	if result.ElClient == execution.UnknownClient {
+		if elClient := ClassifyExtraDataClient(extraData); elClient != execution.UnknownClient {
+			result.ElClient = elClient
+			result.ElSource = ClientSourceExtraData
+		}
+	}
+
+	if result.ElClient == execution.UnknownClient {
+		for _, entry := range clientNamePatternsEL {
+			if entry.pattern.MatchString(graffiti) {
+				result.ElClient = entry.client
+				result.ElSource = ClientSourceGraffitiName
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// ClassifyExtraDataClient returns the execution client that is named in the execution extra data, or UnknownClient.
+// most execution clients put their name into the extra data of locally built payloads by default.
+func ClassifyExtraDataClient(extraData string) execution.ClientType {
+	for _, entry := range clientNamePatternsEL {
+		if entry.pattern.MatchString(extraData) {
+			return entry.client
+		}
+	}
+
+	return execution.UnknownClient
+}
@@ -3,8 +3,123 @@ package beacon
 import (
 	"bytes"
 	"compress/zlib"
+	"fmt"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
 )
 
+// version flags for compressed block & header data, stored alongside the fork version in the *_ver columns.
+// only one flag is set at a time, rows without a compression flag are stored uncompressed.
+var compressionFlag uint64 = 0x20000000       // zlib
+var snappyCompressionFlag uint64 = 0x10000000 // snappy (block format)
+var zstdCompressionFlag uint64 = 0x08000000   // zstd
+var compressionFlagMask = compressionFlag | snappyCompressionFlag | zstdCompressionFlag
+
+// CompressionCodec describes the compression algorithm & level used for stored block data.
+type CompressionCodec struct {
+	name        string
+	flag        uint64
+	level       int
+	zstdEncoder *zstd.Encoder
+}
+
+var zstdDecoder *zstd.Decoder
+var zstdDecoderMutex sync.Mutex
+
+// NewCompressionCodec creates a compression codec by name (none, zlib, snappy or zstd).
+// The level is optional and only used for zlib & zstd (0 = default level).
+// Returns nil for codec "none", which disables compression.
+func NewCompressionCodec(name string, level int) (*CompressionCodec, error) {
+	switch name {
+	case "none":
+		return nil, nil
+	case "", "zlib":
+		if level == 0 {
+			level = zlib.DefaultCompression
+		}
+		if level < zlib.HuffmanOnly || level > zlib.BestCompression {
+			return nil, fmt.Errorf("invalid zlib compression level: %v", level)
+		}
+		return &CompressionCodec{name: "zlib", flag: compressionFlag, level: level}, nil
+	case "snappy":
+		return &CompressionCodec{name: "snappy", flag: snappyCompressionFlag}, nil
+	case "zstd":
+		encoderLevel := zstd.SpeedDefault
+		if level != 0 {
+			encoderLevel = zstd.EncoderLevelFromZstd(level)
+		}
+		encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(encoderLevel))
+		if err != nil {
+			return nil, fmt.Errorf("failed creating zstd encoder: %v", err)
+		}
+		return &CompressionCodec{name: "zstd", flag: zstdCompressionFlag, level: level, zstdEncoder: encoder}, nil
+	default:
+		return nil, fmt.Errorf("unknown compression codec: %v", name)
+	}
+}
+
+// String returns the name of the compression codec.
+func (codec *CompressionCodec) String() string {
+	if codec == nil {
+		return "none"
+	}
+	return codec.name
+}
+
+// compress compresses the given data and returns the version flag of the used codec.
+// A nil codec returns the data as is.
+func (codec *CompressionCodec) compress(data []byte) (uint64, []byte) {
+	if codec == nil {
+		return 0, data
+	}
+
+	switch codec.flag {
+	case snappyCompressionFlag:
+		return codec.flag, snappy.Encode(nil, data)
+	case zstdCompressionFlag:
+		return codec.flag, codec.zstdEncoder.EncodeAll(data, nil)
+	default:
+		var b bytes.Buffer
+		w, err := zlib.NewWriterLevel(&b, codec.level)
+		if err != nil {
+			return 0, data
+		}
+		w.Write(data)
+		w.Close()
+		return codec.flag, b.Bytes()
+	}
+}
+
+// decompressVersioned decompresses the given data according to the compression flag in the version
+// and returns the version without compression flags.
+func decompressVersioned(version uint64, data []byte) (uint64, []byte, error) {
+	var err error
+
+	switch {
+	case version&compressionFlag != 0:
+		data, err = decompressBytes(data)
+	case version&snappyCompressionFlag != 0:
+		data, err = snappy.Decode(nil, data)
+	case version&zstdCompressionFlag != 0:
+		zstdDecoderMutex.Lock()
+		if zstdDecoder == nil {
+			zstdDecoder, err = zstd.NewReader(nil)
+		}
+		zstdDecoderMutex.Unlock()
+		if err == nil {
+			data, err = zstdDecoder.DecodeAll(data, nil)
+		}
+	}
+
+	if err != nil {
+		return version, nil, err
+	}
+
+	return version & ^compressionFlagMask, data, nil
+}
+
 // compressBytes compresses the given byte slice using zlib compression algorithm.
 // It returns the compressed byte slice.
 func compressBytes(data []byte) []byte {
@@ -20,7 +135,6 @@ func compressBytes(data []byte) []byte {
 func decompressBytes(data []byte) ([]byte, error) {
 	r, err := zlib.NewReader(bytes.NewReader(data))
 	if err != nil {
-		r.Close()
 		return nil, err
 	}
 
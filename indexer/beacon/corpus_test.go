@@ -0,0 +1,1060 @@
+package beacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	dynssz "github.com/pk910/dynamic-ssz"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// the fixture corpus contains one signed block & one beacon state per fork version, encoded with the minimal preset.
+// the fixtures are generated deterministically with every list populated, so all code paths of the block & state helpers are covered.
+// regenerate after changing the generator with: go test ./indexer/beacon/ -run TestCorpus -update-corpus
+var updateCorpus = flag.Bool("update-corpus", false, "regenerate the ssz fixture corpus in testdata/corpus")
+
+const corpusPath = "testdata/corpus"
+
+// corpusForks are the fork versions covered by the fixture corpus.
+var corpusForks = []spec.DataVersion{
+	spec.DataVersionPhase0,
+	spec.DataVersionAltair,
+	spec.DataVersionBellatrix,
+	spec.DataVersionCapella,
+	spec.DataVersionDeneb,
+	spec.DataVersionElectra,
+}
+
+// corpusSpec are the preset values used to encode the fixture corpus (minimal preset).
+var corpusSpec = map[string]any{
+	"SLOTS_PER_HISTORICAL_ROOT":    uint64(64),
+	"EPOCHS_PER_HISTORICAL_VECTOR": uint64(64),
+	"EPOCHS_PER_SLASHINGS_VECTOR":  uint64(64),
+	"SYNC_COMMITTEE_SIZE":          uint64(32),
+	"SYNC_COMMITTEE_SUBNET_COUNT":  uint64(4),
+	"DEPOSIT_CONTRACT_TREE_DEPTH":  uint64(32),
+	"MAX_VALIDATORS_PER_COMMITTEE": uint64(2048),
+	"MAX_COMMITTEES_PER_SLOT":      uint64(4),
+}
+
+const corpusSyncCommitteeSize = 32
+const corpusHistoricalVectorSize = 64
+
+// corpusManifest holds the expected roots of the fixtures, so encoder regressions are detected even if the fixtures still decode.
+type corpusManifest map[string]*corpusManifestEntry
+
+type corpusManifestEntry struct {
+	BlockRoot string `json:"block_root"`
+	StateRoot string `json:"state_root"`
+}
+
+// corpusGen generates deterministic pseudo random fixture data.
+type corpusGen struct {
+	counter uint64
+}
+
+func (g *corpusGen) bytes(size int) []byte {
+	res := make([]byte, 0, size+32)
+	for len(res) < size {
+		g.counter++
+		seed := make([]byte, 8)
+		binary.LittleEndian.PutUint64(seed, g.counter)
+		hash := sha256.Sum256(seed)
+		res = append(res, hash[:]...)
+	}
+	return res[:size]
+}
+
+func (g *corpusGen) root() (r phase0.Root) {
+	copy(r[:], g.bytes(32))
+	return
+}
+
+func (g *corpusGen) hash() (h phase0.Hash32) {
+	copy(h[:], g.bytes(32))
+	return
+}
+
+func (g *corpusGen) pubkey() (p phase0.BLSPubKey) {
+	copy(p[:], g.bytes(48))
+	return
+}
+
+func (g *corpusGen) signature() (s phase0.BLSSignature) {
+	copy(s[:], g.bytes(96))
+	return
+}
+
+func (g *corpusGen) address() (a bellatrix.ExecutionAddress) {
+	copy(a[:], g.bytes(20))
+	return
+}
+
+func (g *corpusGen) commitment() (c deneb.KZGCommitment) {
+	copy(c[:], g.bytes(48))
+	return
+}
+
+func (g *corpusGen) bitlist(size uint64) bitfield.Bitlist {
+	bits := bitfield.NewBitlist(size)
+	for i := uint64(0); i < size; i += 3 {
+		bits.SetBitAt(i, true)
+	}
+	return bits
+}
+
+func (g *corpusGen) checkpoint(epoch phase0.Epoch) *phase0.Checkpoint {
+	return &phase0.Checkpoint{Epoch: epoch, Root: g.root()}
+}
+
+func (g *corpusGen) attestationData(slot phase0.Slot, index phase0.CommitteeIndex) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Slot:            slot,
+		Index:           index,
+		BeaconBlockRoot: g.root(),
+		Source:          g.checkpoint(phase0.Epoch(slot/8) - 1),
+		Target:          g.checkpoint(phase0.Epoch(slot / 8)),
+	}
+}
+
+func (g *corpusGen) signedHeader(slot phase0.Slot, proposer phase0.ValidatorIndex) *phase0.SignedBeaconBlockHeader {
+	return &phase0.SignedBeaconBlockHeader{
+		Message: &phase0.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: proposer,
+			ParentRoot:    g.root(),
+			StateRoot:     g.root(),
+			BodyRoot:      g.root(),
+		},
+		Signature: g.signature(),
+	}
+}
+
+func (g *corpusGen) eth1Data() *phase0.ETH1Data {
+	return &phase0.ETH1Data{
+		DepositRoot:  g.root(),
+		DepositCount: 1024,
+		BlockHash:    g.bytes(32),
+	}
+}
+
+func (g *corpusGen) graffiti(text string) (graffiti [32]byte) {
+	copy(graffiti[:], text)
+	return
+}
+
+func (g *corpusGen) proposerSlashings(slot phase0.Slot) []*phase0.ProposerSlashing {
+	return []*phase0.ProposerSlashing{
+		{SignedHeader1: g.signedHeader(slot-5, 17), SignedHeader2: g.signedHeader(slot-5, 17)},
+	}
+}
+
+func (g *corpusGen) phase0AttesterSlashings(slot phase0.Slot) []*phase0.AttesterSlashing {
+	data := g.attestationData(slot-3, 1)
+	return []*phase0.AttesterSlashing{
+		{
+			Attestation1: &phase0.IndexedAttestation{AttestingIndices: []uint64{3, 5, 8, 13}, Data: data, Signature: g.signature()},
+			Attestation2: &phase0.IndexedAttestation{AttestingIndices: []uint64{5, 13, 21}, Data: g.attestationData(slot-3, 1), Signature: g.signature()},
+		},
+	}
+}
+
+func (g *corpusGen) phase0Attestations(slot phase0.Slot) []*phase0.Attestation {
+	attestations := []*phase0.Attestation{}
+	for i := 0; i < 3; i++ {
+		attestations = append(attestations, &phase0.Attestation{
+			AggregationBits: g.bitlist(16),
+			Data:            g.attestationData(slot-1, phase0.CommitteeIndex(i)),
+			Signature:       g.signature(),
+		})
+	}
+	return attestations
+}
+
+func (g *corpusGen) deposits() []*phase0.Deposit {
+	deposits := []*phase0.Deposit{}
+	for i := 0; i < 2; i++ {
+		proof := make([][]byte, 33)
+		for j := range proof {
+			proof[j] = g.bytes(32)
+		}
+		deposits = append(deposits, &phase0.Deposit{
+			Proof: proof,
+			Data: &phase0.DepositData{
+				PublicKey:             g.pubkey(),
+				WithdrawalCredentials: g.bytes(32),
+				Amount:                phase0.Gwei(32000000000 + i),
+				Signature:             g.signature(),
+			},
+		})
+	}
+	return deposits
+}
+
+func (g *corpusGen) voluntaryExits() []*phase0.SignedVoluntaryExit {
+	return []*phase0.SignedVoluntaryExit{
+		{Message: &phase0.VoluntaryExit{Epoch: 3, ValidatorIndex: 42}, Signature: g.signature()},
+		{Message: &phase0.VoluntaryExit{Epoch: 3, ValidatorIndex: 43}, Signature: g.signature()},
+	}
+}
+
+func (g *corpusGen) syncAggregate(syncCommitteeSize int) *altair.SyncAggregate {
+	bits := make([]byte, syncCommitteeSize/8)
+	for i := range bits {
+		bits[i] = 0xb7
+	}
+	return &altair.SyncAggregate{
+		SyncCommitteeBits:      bits,
+		SyncCommitteeSignature: g.signature(),
+	}
+}
+
+func (g *corpusGen) transactions() []bellatrix.Transaction {
+	return []bellatrix.Transaction{g.bytes(110), g.bytes(48), g.bytes(270)}
+}
+
+func (g *corpusGen) withdrawals() []*capella.Withdrawal {
+	withdrawals := []*capella.Withdrawal{}
+	for i := 0; i < 4; i++ {
+		withdrawals = append(withdrawals, &capella.Withdrawal{
+			Index:          capella.WithdrawalIndex(1000 + i),
+			ValidatorIndex: phase0.ValidatorIndex(10 + i),
+			Address:        g.address(),
+			Amount:         phase0.Gwei(1000000 * (i + 1)),
+		})
+	}
+	return withdrawals
+}
+
+func (g *corpusGen) blsChanges() []*capella.SignedBLSToExecutionChange {
+	return []*capella.SignedBLSToExecutionChange{
+		{
+			Message: &capella.BLSToExecutionChange{
+				ValidatorIndex:     7,
+				FromBLSPubkey:      g.pubkey(),
+				ToExecutionAddress: g.address(),
+			},
+			Signature: g.signature(),
+		},
+	}
+}
+
+func (g *corpusGen) bellatrixPayload(slot phase0.Slot) *bellatrix.ExecutionPayload {
+	payload := &bellatrix.ExecutionPayload{
+		ParentHash:   g.hash(),
+		FeeRecipient: g.address(),
+		StateRoot:    g.root(),
+		ReceiptsRoot: g.root(),
+		BlockNumber:  uint64(slot) + 1000,
+		GasLimit:     30000000,
+		GasUsed:      12345678,
+		Timestamp:    1700000000 + uint64(slot)*12,
+		ExtraData:    []byte("Geth/v1.14.0/linux"),
+		BlockHash:    g.hash(),
+		Transactions: g.transactions(),
+	}
+	copy(payload.LogsBloom[:], g.bytes(256))
+	copy(payload.PrevRandao[:], g.bytes(32))
+	payload.BaseFeePerGas[0] = 0x07 // little endian base fee
+	return payload
+}
+
+func (g *corpusGen) capellaPayload(slot phase0.Slot) *capella.ExecutionPayload {
+	base := g.bellatrixPayload(slot)
+	return &capella.ExecutionPayload{
+		ParentHash:    base.ParentHash,
+		FeeRecipient:  base.FeeRecipient,
+		StateRoot:     base.StateRoot,
+		ReceiptsRoot:  base.ReceiptsRoot,
+		LogsBloom:     base.LogsBloom,
+		PrevRandao:    base.PrevRandao,
+		BlockNumber:   base.BlockNumber,
+		GasLimit:      base.GasLimit,
+		GasUsed:       base.GasUsed,
+		Timestamp:     base.Timestamp,
+		ExtraData:     base.ExtraData,
+		BaseFeePerGas: base.BaseFeePerGas,
+		BlockHash:     base.BlockHash,
+		Transactions:  base.Transactions,
+		Withdrawals:   g.withdrawals(),
+	}
+}
+
+func (g *corpusGen) denebPayload(slot phase0.Slot) *deneb.ExecutionPayload {
+	base := g.capellaPayload(slot)
+	return &deneb.ExecutionPayload{
+		ParentHash:    base.ParentHash,
+		FeeRecipient:  base.FeeRecipient,
+		StateRoot:     base.StateRoot,
+		ReceiptsRoot:  base.ReceiptsRoot,
+		LogsBloom:     base.LogsBloom,
+		PrevRandao:    base.PrevRandao,
+		BlockNumber:   base.BlockNumber,
+		GasLimit:      base.GasLimit,
+		GasUsed:       base.GasUsed,
+		Timestamp:     base.Timestamp,
+		ExtraData:     base.ExtraData,
+		BaseFeePerGas: uint256.NewInt(7),
+		BlockHash:     base.BlockHash,
+		Transactions:  base.Transactions,
+		Withdrawals:   base.Withdrawals,
+		BlobGasUsed:   262144,
+		ExcessBlobGas: 131072,
+	}
+}
+
+func (g *corpusGen) electraAttestations(slot phase0.Slot) []*electra.Attestation {
+	attestations := []*electra.Attestation{}
+	for i := 0; i < 2; i++ {
+		committeeBits := bitfield.NewBitvector64()
+		committeeBits.SetBitAt(uint64(i), true)
+		committeeBits.SetBitAt(uint64(i+2), true)
+		attestations = append(attestations, &electra.Attestation{
+			AggregationBits: g.bitlist(32),
+			Data:            g.attestationData(slot-1, 0),
+			Signature:       g.signature(),
+			CommitteeBits:   committeeBits,
+		})
+	}
+	return attestations
+}
+
+func (g *corpusGen) electraAttesterSlashings(slot phase0.Slot) []*electra.AttesterSlashing {
+	return []*electra.AttesterSlashing{
+		{
+			Attestation1: &electra.IndexedAttestation{AttestingIndices: []uint64{3, 5, 8, 13}, Data: g.attestationData(slot-3, 0), Signature: g.signature()},
+			Attestation2: &electra.IndexedAttestation{AttestingIndices: []uint64{5, 13, 21}, Data: g.attestationData(slot-3, 0), Signature: g.signature()},
+		},
+	}
+}
+
+func (g *corpusGen) executionRequests() *electra.ExecutionRequests {
+	return &electra.ExecutionRequests{
+		Deposits: []*electra.DepositRequest{
+			{Pubkey: g.pubkey(), WithdrawalCredentials: g.bytes(32), Amount: 32000000000, Signature: g.signature(), Index: 1024},
+			{Pubkey: g.pubkey(), WithdrawalCredentials: g.bytes(32), Amount: 1000000000, Signature: g.signature(), Index: 1025},
+		},
+		Withdrawals: []*electra.WithdrawalRequest{
+			{SourceAddress: g.address(), ValidatorPubkey: g.pubkey(), Amount: 0},
+			{SourceAddress: g.address(), ValidatorPubkey: g.pubkey(), Amount: 1000000000},
+		},
+		Consolidations: []*electra.ConsolidationRequest{
+			{SourceAddress: g.address(), SourcePubkey: g.pubkey(), TargetPubkey: g.pubkey()},
+		},
+	}
+}
+
+// buildCorpusBlock builds the deterministic fixture block for the given fork version.
+func buildCorpusBlock(version spec.DataVersion, syncCommitteeSize int) *spec.VersionedSignedBeaconBlock {
+	g := &corpusGen{counter: uint64(version) << 32}
+	slot := phase0.Slot(uint64(version)*1000 + 37)
+	proposer := phase0.ValidatorIndex(11)
+	graffiti := g.graffiti(fmt.Sprintf("Lighthouse/v5.3.0 %v", version.String()))
+	parentRoot := g.root()
+	stateRoot := g.root()
+
+	block := &spec.VersionedSignedBeaconBlock{Version: version}
+
+	switch version {
+	case spec.DataVersionPhase0:
+		block.Phase0 = &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot:          slot,
+				ProposerIndex: proposer,
+				ParentRoot:    parentRoot,
+				StateRoot:     stateRoot,
+				Body: &phase0.BeaconBlockBody{
+					RANDAOReveal:      g.signature(),
+					ETH1Data:          g.eth1Data(),
+					Graffiti:          graffiti,
+					ProposerSlashings: g.proposerSlashings(slot),
+					AttesterSlashings: g.phase0AttesterSlashings(slot),
+					Attestations:      g.phase0Attestations(slot),
+					Deposits:          g.deposits(),
+					VoluntaryExits:    g.voluntaryExits(),
+				},
+			},
+			Signature: g.signature(),
+		}
+	case spec.DataVersionAltair:
+		block.Altair = &altair.SignedBeaconBlock{
+			Message: &altair.BeaconBlock{
+				Slot:          slot,
+				ProposerIndex: proposer,
+				ParentRoot:    parentRoot,
+				StateRoot:     stateRoot,
+				Body: &altair.BeaconBlockBody{
+					RANDAOReveal:      g.signature(),
+					ETH1Data:          g.eth1Data(),
+					Graffiti:          graffiti,
+					ProposerSlashings: g.proposerSlashings(slot),
+					AttesterSlashings: g.phase0AttesterSlashings(slot),
+					Attestations:      g.phase0Attestations(slot),
+					Deposits:          g.deposits(),
+					VoluntaryExits:    g.voluntaryExits(),
+					SyncAggregate:     g.syncAggregate(syncCommitteeSize),
+				},
+			},
+			Signature: g.signature(),
+		}
+	case spec.DataVersionBellatrix:
+		block.Bellatrix = &bellatrix.SignedBeaconBlock{
+			Message: &bellatrix.BeaconBlock{
+				Slot:          slot,
+				ProposerIndex: proposer,
+				ParentRoot:    parentRoot,
+				StateRoot:     stateRoot,
+				Body: &bellatrix.BeaconBlockBody{
+					RANDAOReveal:      g.signature(),
+					ETH1Data:          g.eth1Data(),
+					Graffiti:          graffiti,
+					ProposerSlashings: g.proposerSlashings(slot),
+					AttesterSlashings: g.phase0AttesterSlashings(slot),
+					Attestations:      g.phase0Attestations(slot),
+					Deposits:          g.deposits(),
+					VoluntaryExits:    g.voluntaryExits(),
+					SyncAggregate:     g.syncAggregate(syncCommitteeSize),
+					ExecutionPayload:  g.bellatrixPayload(slot),
+				},
+			},
+			Signature: g.signature(),
+		}
+	case spec.DataVersionCapella:
+		block.Capella = &capella.SignedBeaconBlock{
+			Message: &capella.BeaconBlock{
+				Slot:          slot,
+				ProposerIndex: proposer,
+				ParentRoot:    parentRoot,
+				StateRoot:     stateRoot,
+				Body: &capella.BeaconBlockBody{
+					RANDAOReveal:          g.signature(),
+					ETH1Data:              g.eth1Data(),
+					Graffiti:              graffiti,
+					ProposerSlashings:     g.proposerSlashings(slot),
+					AttesterSlashings:     g.phase0AttesterSlashings(slot),
+					Attestations:          g.phase0Attestations(slot),
+					Deposits:              g.deposits(),
+					VoluntaryExits:        g.voluntaryExits(),
+					SyncAggregate:         g.syncAggregate(syncCommitteeSize),
+					ExecutionPayload:      g.capellaPayload(slot),
+					BLSToExecutionChanges: g.blsChanges(),
+				},
+			},
+			Signature: g.signature(),
+		}
+	case spec.DataVersionDeneb:
+		block.Deneb = &deneb.SignedBeaconBlock{
+			Message: &deneb.BeaconBlock{
+				Slot:          slot,
+				ProposerIndex: proposer,
+				ParentRoot:    parentRoot,
+				StateRoot:     stateRoot,
+				Body: &deneb.BeaconBlockBody{
+					RANDAOReveal:          g.signature(),
+					ETH1Data:              g.eth1Data(),
+					Graffiti:              graffiti,
+					ProposerSlashings:     g.proposerSlashings(slot),
+					AttesterSlashings:     g.phase0AttesterSlashings(slot),
+					Attestations:          g.phase0Attestations(slot),
+					Deposits:              g.deposits(),
+					VoluntaryExits:        g.voluntaryExits(),
+					SyncAggregate:         g.syncAggregate(syncCommitteeSize),
+					ExecutionPayload:      g.denebPayload(slot),
+					BLSToExecutionChanges: g.blsChanges(),
+					BlobKZGCommitments:    []deneb.KZGCommitment{g.commitment(), g.commitment()},
+				},
+			},
+			Signature: g.signature(),
+		}
+	case spec.DataVersionElectra:
+		block.Electra = &electra.SignedBeaconBlock{
+			Message: &electra.BeaconBlock{
+				Slot:          slot,
+				ProposerIndex: proposer,
+				ParentRoot:    parentRoot,
+				StateRoot:     stateRoot,
+				Body: &electra.BeaconBlockBody{
+					RANDAOReveal:          g.signature(),
+					ETH1Data:              g.eth1Data(),
+					Graffiti:              graffiti,
+					ProposerSlashings:     g.proposerSlashings(slot),
+					AttesterSlashings:     g.electraAttesterSlashings(slot),
+					Attestations:          g.electraAttestations(slot),
+					Deposits:              g.deposits(),
+					VoluntaryExits:        g.voluntaryExits(),
+					SyncAggregate:         g.syncAggregate(syncCommitteeSize),
+					ExecutionPayload:      g.denebPayload(slot),
+					BLSToExecutionChanges: g.blsChanges(),
+					BlobKZGCommitments:    []deneb.KZGCommitment{g.commitment(), g.commitment(), g.commitment()},
+					ExecutionRequests:     g.executionRequests(),
+				},
+			},
+			Signature: g.signature(),
+		}
+	}
+
+	return block
+}
+
+func (g *corpusGen) roots(count int) []phase0.Root {
+	roots := make([]phase0.Root, count)
+	for i := range roots {
+		roots[i] = g.root()
+	}
+	return roots
+}
+
+func (g *corpusGen) validators(count int) ([]*phase0.Validator, []phase0.Gwei) {
+	validators := make([]*phase0.Validator, count)
+	balances := make([]phase0.Gwei, count)
+	for i := range validators {
+		validators[i] = &phase0.Validator{
+			PublicKey:                  g.pubkey(),
+			WithdrawalCredentials:      g.bytes(32),
+			EffectiveBalance:           32000000000,
+			Slashed:                    i == 5,
+			ActivationEligibilityEpoch: 0,
+			ActivationEpoch:            0,
+			ExitEpoch:                  phase0.Epoch(0xffffffffffffffff),
+			WithdrawableEpoch:          phase0.Epoch(0xffffffffffffffff),
+		}
+		balances[i] = phase0.Gwei(32000000000 + i*1000)
+	}
+	return validators, balances
+}
+
+func (g *corpusGen) syncCommittee(size int) *altair.SyncCommittee {
+	pubkeys := make([]phase0.BLSPubKey, size)
+	for i := range pubkeys {
+		pubkeys[i] = g.pubkey()
+	}
+	return &altair.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: g.pubkey()}
+}
+
+func corpusParticipation(count int) []altair.ParticipationFlags {
+	flags := make([]altair.ParticipationFlags, count)
+	for i := range flags {
+		flags[i] = altair.ParticipationFlags(i % 8)
+	}
+	return flags
+}
+
+// corpusStateFields are the state fields shared by all fork versions.
+type corpusStateFields struct {
+	slot              phase0.Slot
+	fork              *phase0.Fork
+	latestBlockHeader *phase0.BeaconBlockHeader
+	blockRoots        []phase0.Root
+	stateRoots        []phase0.Root
+	eth1Data          *phase0.ETH1Data
+	eth1DataVotes     []*phase0.ETH1Data
+	validators        []*phase0.Validator
+	balances          []phase0.Gwei
+	randaoMixes       []phase0.Root
+	slashings         []phase0.Gwei
+	justified         *phase0.Checkpoint
+	previousJustified *phase0.Checkpoint
+	finalized         *phase0.Checkpoint
+}
+
+// buildCorpusState builds the deterministic fixture state for the given fork version.
+func buildCorpusState(version spec.DataVersion) *spec.VersionedBeaconState {
+	g := &corpusGen{counter: (uint64(version) << 32) | 0x80000000}
+	validatorCount := 64
+	f := &corpusStateFields{
+		slot:              phase0.Slot(uint64(version)*1000 + 40),
+		fork:              &phase0.Fork{PreviousVersion: phase0.Version{0, 0, 0, byte(version)}, CurrentVersion: phase0.Version{byte(version), 0, 0, 1}, Epoch: phase0.Epoch(uint64(version) * 100)},
+		latestBlockHeader: g.signedHeader(phase0.Slot(uint64(version)*1000+39), 11).Message,
+		blockRoots:        g.roots(corpusHistoricalVectorSize),
+		stateRoots:        g.roots(corpusHistoricalVectorSize),
+		eth1Data:          g.eth1Data(),
+		eth1DataVotes:     []*phase0.ETH1Data{g.eth1Data(), g.eth1Data()},
+		randaoMixes:       g.roots(corpusHistoricalVectorSize),
+		slashings:         make([]phase0.Gwei, corpusHistoricalVectorSize),
+		justified:         g.checkpoint(phase0.Epoch(uint64(version)*125 + 3)),
+		previousJustified: g.checkpoint(phase0.Epoch(uint64(version)*125 + 2)),
+		finalized:         g.checkpoint(phase0.Epoch(uint64(version)*125 + 2)),
+	}
+	f.validators, f.balances = g.validators(validatorCount)
+	f.slashings[3] = 1000000000
+	justificationBits := bitfield.Bitvector4{0x0e}
+	genesisValidatorsRoot := g.root()
+
+	state := &spec.VersionedBeaconState{Version: version}
+
+	switch version {
+	case spec.DataVersionPhase0:
+		state.Phase0 = &phase0.BeaconState{
+			GenesisTime:           1700000000,
+			GenesisValidatorsRoot: genesisValidatorsRoot,
+			Slot:                  f.slot,
+			Fork:                  f.fork,
+			LatestBlockHeader:     f.latestBlockHeader,
+			BlockRoots:            f.blockRoots,
+			StateRoots:            f.stateRoots,
+			HistoricalRoots:       g.roots(2),
+			ETH1Data:              f.eth1Data,
+			ETH1DataVotes:         f.eth1DataVotes,
+			ETH1DepositIndex:      1024,
+			Validators:            f.validators,
+			Balances:              f.balances,
+			RANDAOMixes:           f.randaoMixes,
+			Slashings:             f.slashings,
+			PreviousEpochAttestations: []*phase0.PendingAttestation{
+				{AggregationBits: g.bitlist(16), Data: g.attestationData(f.slot-9, 0), InclusionDelay: 1, ProposerIndex: 3},
+			},
+			CurrentEpochAttestations: []*phase0.PendingAttestation{
+				{AggregationBits: g.bitlist(16), Data: g.attestationData(f.slot-1, 1), InclusionDelay: 2, ProposerIndex: 4},
+			},
+			JustificationBits:           justificationBits,
+			PreviousJustifiedCheckpoint: f.previousJustified,
+			CurrentJustifiedCheckpoint:  f.justified,
+			FinalizedCheckpoint:         f.finalized,
+		}
+	case spec.DataVersionAltair:
+		state.Altair = &altair.BeaconState{
+			GenesisTime:                 1700000000,
+			GenesisValidatorsRoot:       genesisValidatorsRoot,
+			Slot:                        f.slot,
+			Fork:                        f.fork,
+			LatestBlockHeader:           f.latestBlockHeader,
+			BlockRoots:                  f.blockRoots,
+			StateRoots:                  f.stateRoots,
+			HistoricalRoots:             g.roots(2),
+			ETH1Data:                    f.eth1Data,
+			ETH1DataVotes:               f.eth1DataVotes,
+			ETH1DepositIndex:            1024,
+			Validators:                  f.validators,
+			Balances:                    f.balances,
+			RANDAOMixes:                 f.randaoMixes,
+			Slashings:                   f.slashings,
+			PreviousEpochParticipation:  corpusParticipation(validatorCount),
+			CurrentEpochParticipation:   corpusParticipation(validatorCount),
+			JustificationBits:           justificationBits,
+			PreviousJustifiedCheckpoint: f.previousJustified,
+			CurrentJustifiedCheckpoint:  f.justified,
+			FinalizedCheckpoint:         f.finalized,
+			InactivityScores:            make([]uint64, validatorCount),
+			CurrentSyncCommittee:        g.syncCommittee(corpusSyncCommitteeSize),
+			NextSyncCommittee:           g.syncCommittee(corpusSyncCommitteeSize),
+		}
+	case spec.DataVersionBellatrix:
+		payload := g.bellatrixPayload(f.slot - 1)
+		state.Bellatrix = &bellatrix.BeaconState{
+			GenesisTime:                 1700000000,
+			GenesisValidatorsRoot:       genesisValidatorsRoot,
+			Slot:                        f.slot,
+			Fork:                        f.fork,
+			LatestBlockHeader:           f.latestBlockHeader,
+			BlockRoots:                  f.blockRoots,
+			StateRoots:                  f.stateRoots,
+			HistoricalRoots:             g.roots(2),
+			ETH1Data:                    f.eth1Data,
+			ETH1DataVotes:               f.eth1DataVotes,
+			ETH1DepositIndex:            1024,
+			Validators:                  f.validators,
+			Balances:                    f.balances,
+			RANDAOMixes:                 f.randaoMixes,
+			Slashings:                   f.slashings,
+			PreviousEpochParticipation:  corpusParticipation(validatorCount),
+			CurrentEpochParticipation:   corpusParticipation(validatorCount),
+			JustificationBits:           justificationBits,
+			PreviousJustifiedCheckpoint: f.previousJustified,
+			CurrentJustifiedCheckpoint:  f.justified,
+			FinalizedCheckpoint:         f.finalized,
+			InactivityScores:            make([]uint64, validatorCount),
+			CurrentSyncCommittee:        g.syncCommittee(corpusSyncCommitteeSize),
+			NextSyncCommittee:           g.syncCommittee(corpusSyncCommitteeSize),
+			LatestExecutionPayloadHeader: &bellatrix.ExecutionPayloadHeader{
+				ParentHash:       payload.ParentHash,
+				FeeRecipient:     payload.FeeRecipient,
+				StateRoot:        payload.StateRoot,
+				ReceiptsRoot:     payload.ReceiptsRoot,
+				LogsBloom:        payload.LogsBloom,
+				PrevRandao:       payload.PrevRandao,
+				BlockNumber:      payload.BlockNumber,
+				GasLimit:         payload.GasLimit,
+				GasUsed:          payload.GasUsed,
+				Timestamp:        payload.Timestamp,
+				ExtraData:        payload.ExtraData,
+				BaseFeePerGas:    payload.BaseFeePerGas,
+				BlockHash:        payload.BlockHash,
+				TransactionsRoot: g.root(),
+			},
+		}
+	case spec.DataVersionCapella:
+		payload := g.bellatrixPayload(f.slot - 1)
+		state.Capella = &capella.BeaconState{
+			GenesisTime:                 1700000000,
+			GenesisValidatorsRoot:       genesisValidatorsRoot,
+			Slot:                        f.slot,
+			Fork:                        f.fork,
+			LatestBlockHeader:           f.latestBlockHeader,
+			BlockRoots:                  f.blockRoots,
+			StateRoots:                  f.stateRoots,
+			HistoricalRoots:             g.roots(2),
+			ETH1Data:                    f.eth1Data,
+			ETH1DataVotes:               f.eth1DataVotes,
+			ETH1DepositIndex:            1024,
+			Validators:                  f.validators,
+			Balances:                    f.balances,
+			RANDAOMixes:                 f.randaoMixes,
+			Slashings:                   f.slashings,
+			PreviousEpochParticipation:  corpusParticipation(validatorCount),
+			CurrentEpochParticipation:   corpusParticipation(validatorCount),
+			JustificationBits:           justificationBits,
+			PreviousJustifiedCheckpoint: f.previousJustified,
+			CurrentJustifiedCheckpoint:  f.justified,
+			FinalizedCheckpoint:         f.finalized,
+			InactivityScores:            make([]uint64, validatorCount),
+			CurrentSyncCommittee:        g.syncCommittee(corpusSyncCommitteeSize),
+			NextSyncCommittee:           g.syncCommittee(corpusSyncCommitteeSize),
+			LatestExecutionPayloadHeader: &capella.ExecutionPayloadHeader{
+				ParentHash:       payload.ParentHash,
+				FeeRecipient:     payload.FeeRecipient,
+				StateRoot:        payload.StateRoot,
+				ReceiptsRoot:     payload.ReceiptsRoot,
+				LogsBloom:        payload.LogsBloom,
+				PrevRandao:       payload.PrevRandao,
+				BlockNumber:      payload.BlockNumber,
+				GasLimit:         payload.GasLimit,
+				GasUsed:          payload.GasUsed,
+				Timestamp:        payload.Timestamp,
+				ExtraData:        payload.ExtraData,
+				BaseFeePerGas:    payload.BaseFeePerGas,
+				BlockHash:        payload.BlockHash,
+				TransactionsRoot: g.root(),
+				WithdrawalsRoot:  g.root(),
+			},
+			NextWithdrawalIndex:          1004,
+			NextWithdrawalValidatorIndex: 14,
+			HistoricalSummaries: []*capella.HistoricalSummary{
+				{BlockSummaryRoot: g.root(), StateSummaryRoot: g.root()},
+			},
+		}
+	case spec.DataVersionDeneb:
+		payload := g.bellatrixPayload(f.slot - 1)
+		state.Deneb = &deneb.BeaconState{
+			GenesisTime:                  1700000000,
+			GenesisValidatorsRoot:        genesisValidatorsRoot,
+			Slot:                         f.slot,
+			Fork:                         f.fork,
+			LatestBlockHeader:            f.latestBlockHeader,
+			BlockRoots:                   f.blockRoots,
+			StateRoots:                   f.stateRoots,
+			HistoricalRoots:              g.roots(2),
+			ETH1Data:                     f.eth1Data,
+			ETH1DataVotes:                f.eth1DataVotes,
+			ETH1DepositIndex:             1024,
+			Validators:                   f.validators,
+			Balances:                     f.balances,
+			RANDAOMixes:                  f.randaoMixes,
+			Slashings:                    f.slashings,
+			PreviousEpochParticipation:   corpusParticipation(validatorCount),
+			CurrentEpochParticipation:    corpusParticipation(validatorCount),
+			JustificationBits:            justificationBits,
+			PreviousJustifiedCheckpoint:  f.previousJustified,
+			CurrentJustifiedCheckpoint:   f.justified,
+			FinalizedCheckpoint:          f.finalized,
+			InactivityScores:             make([]uint64, validatorCount),
+			CurrentSyncCommittee:         g.syncCommittee(corpusSyncCommitteeSize),
+			NextSyncCommittee:            g.syncCommittee(corpusSyncCommitteeSize),
+			LatestExecutionPayloadHeader: corpusDenebPayloadHeader(g, payload),
+			NextWithdrawalIndex:          1004,
+			NextWithdrawalValidatorIndex: 14,
+			HistoricalSummaries: []*capella.HistoricalSummary{
+				{BlockSummaryRoot: g.root(), StateSummaryRoot: g.root()},
+			},
+		}
+	case spec.DataVersionElectra:
+		payload := g.bellatrixPayload(f.slot - 1)
+		state.Electra = &electra.BeaconState{
+			GenesisTime:                  1700000000,
+			GenesisValidatorsRoot:        genesisValidatorsRoot,
+			Slot:                         f.slot,
+			Fork:                         f.fork,
+			LatestBlockHeader:            f.latestBlockHeader,
+			BlockRoots:                   f.blockRoots,
+			StateRoots:                   f.stateRoots,
+			HistoricalRoots:              g.roots(2),
+			ETH1Data:                     f.eth1Data,
+			ETH1DataVotes:                f.eth1DataVotes,
+			ETH1DepositIndex:             1024,
+			Validators:                   f.validators,
+			Balances:                     f.balances,
+			RANDAOMixes:                  f.randaoMixes,
+			Slashings:                    f.slashings,
+			PreviousEpochParticipation:   corpusParticipation(validatorCount),
+			CurrentEpochParticipation:    corpusParticipation(validatorCount),
+			JustificationBits:            justificationBits,
+			PreviousJustifiedCheckpoint:  f.previousJustified,
+			CurrentJustifiedCheckpoint:   f.justified,
+			FinalizedCheckpoint:          f.finalized,
+			InactivityScores:             make([]uint64, validatorCount),
+			CurrentSyncCommittee:         g.syncCommittee(corpusSyncCommitteeSize),
+			NextSyncCommittee:            g.syncCommittee(corpusSyncCommitteeSize),
+			LatestExecutionPayloadHeader: corpusDenebPayloadHeader(g, payload),
+			NextWithdrawalIndex:          1004,
+			NextWithdrawalValidatorIndex: 14,
+			HistoricalSummaries: []*capella.HistoricalSummary{
+				{BlockSummaryRoot: g.root(), StateSummaryRoot: g.root()},
+			},
+			DepositRequestsStartIndex:     1024,
+			DepositBalanceToConsume:       3000000000,
+			ExitBalanceToConsume:          64000000000,
+			EarliestExitEpoch:             phase0.Epoch(uint64(version)*125 + 9),
+			ConsolidationBalanceToConsume: 128000000000,
+			EarliestConsolidationEpoch:    phase0.Epoch(uint64(version)*125 + 10),
+			PendingDeposits: []*electra.PendingDeposit{
+				{Pubkey: g.pubkey(), WithdrawalCredentials: g.bytes(32), Amount: 32000000000, Signature: g.signature(), Slot: f.slot - 2},
+				{Pubkey: g.pubkey(), WithdrawalCredentials: g.bytes(32), Amount: 1000000000, Signature: g.signature(), Slot: f.slot - 1},
+			},
+			PendingPartialWithdrawals: []*electra.PendingPartialWithdrawal{
+				{ValidatorIndex: 21, Amount: 1000000000, WithdrawableEpoch: phase0.Epoch(uint64(version)*125 + 11)},
+			},
+			PendingConsolidations: []*electra.PendingConsolidation{
+				{SourceIndex: 30, TargetIndex: 31},
+			},
+		}
+	}
+
+	return state
+}
+
+func corpusDenebPayloadHeader(g *corpusGen, payload *bellatrix.ExecutionPayload) *deneb.ExecutionPayloadHeader {
+	return &deneb.ExecutionPayloadHeader{
+		ParentHash:       payload.ParentHash,
+		FeeRecipient:     payload.FeeRecipient,
+		StateRoot:        payload.StateRoot,
+		ReceiptsRoot:     payload.ReceiptsRoot,
+		LogsBloom:        payload.LogsBloom,
+		PrevRandao:       payload.PrevRandao,
+		BlockNumber:      payload.BlockNumber,
+		GasLimit:         payload.GasLimit,
+		GasUsed:          payload.GasUsed,
+		Timestamp:        payload.Timestamp,
+		ExtraData:        payload.ExtraData,
+		BaseFeePerGas:    uint256.NewInt(7),
+		BlockHash:        payload.BlockHash,
+		TransactionsRoot: g.root(),
+		WithdrawalsRoot:  g.root(),
+		BlobGasUsed:      131072,
+		ExcessBlobGas:    0,
+	}
+}
+
+// getStateObject returns the fork specific state object of a versioned beacon state.
+func getStateObject(state *spec.VersionedBeaconState) any {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0
+	case spec.DataVersionAltair:
+		return state.Altair
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix
+	case spec.DataVersionCapella:
+		return state.Capella
+	case spec.DataVersionDeneb:
+		return state.Deneb
+	case spec.DataVersionElectra:
+		return state.Electra
+	}
+	return nil
+}
+
+// unmarshalCorpusState decodes a ssz encoded beacon state of the given fork version.
+func unmarshalCorpusState(dynSsz *dynssz.DynSsz, version spec.DataVersion, ssz []byte) (*spec.VersionedBeaconState, error) {
+	state := &spec.VersionedBeaconState{Version: version}
+	switch version {
+	case spec.DataVersionPhase0:
+		state.Phase0 = &phase0.BeaconState{}
+	case spec.DataVersionAltair:
+		state.Altair = &altair.BeaconState{}
+	case spec.DataVersionBellatrix:
+		state.Bellatrix = &bellatrix.BeaconState{}
+	case spec.DataVersionCapella:
+		state.Capella = &capella.BeaconState{}
+	case spec.DataVersionDeneb:
+		state.Deneb = &deneb.BeaconState{}
+	case spec.DataVersionElectra:
+		state.Electra = &electra.BeaconState{}
+	default:
+		return nil, fmt.Errorf("unknown state version")
+	}
+
+	if err := dynSsz.UnmarshalSSZ(getStateObject(state), ssz); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// corpusFixture is a decoded fixture of the corpus.
+type corpusFixture struct {
+	version  spec.DataVersion
+	blockSSZ []byte
+	block    *spec.VersionedSignedBeaconBlock
+	stateSSZ []byte
+	state    *spec.VersionedBeaconState
+	manifest *corpusManifestEntry
+}
+
+func newCorpusDynSsz() *dynssz.DynSsz {
+	return dynssz.NewDynSsz(corpusSpec)
+}
+
+// loadCorpus loads & decodes all fixtures of the corpus.
+// with -update-corpus the fixtures & manifest are regenerated from the generator first.
+func loadCorpus(t *testing.T) []*corpusFixture {
+	t.Helper()
+
+	dynSsz := newCorpusDynSsz()
+	manifestFile := filepath.Join(corpusPath, "manifest.json")
+
+	if *updateCorpus {
+		if err := os.MkdirAll(corpusPath, 0o755); err != nil {
+			t.Fatalf("failed creating corpus directory: %v", err)
+		}
+
+		manifest := corpusManifest{}
+		for _, version := range corpusForks {
+			block := buildCorpusBlock(version, corpusSyncCommitteeSize)
+			_, blockSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(dynSsz, block, nil, true)
+			if err != nil {
+				t.Fatalf("failed encoding %v block: %v", version, err)
+			}
+			_, blockRoot, err := getBlockHeader(dynSsz, block)
+			if err != nil {
+				t.Fatalf("failed building %v block header: %v", version, err)
+			}
+
+			state := buildCorpusState(version)
+			stateSSZ, err := dynSsz.MarshalSSZ(getStateObject(state))
+			if err != nil {
+				t.Fatalf("failed encoding %v state: %v", version, err)
+			}
+			stateRoot, err := dynSsz.HashTreeRoot(getStateObject(state))
+			if err != nil {
+				t.Fatalf("failed hashing %v state: %v", version, err)
+			}
+
+			if err := os.WriteFile(filepath.Join(corpusPath, version.String()+"_block.ssz"), blockSSZ, 0o644); err != nil {
+				t.Fatalf("failed writing %v block: %v", version, err)
+			}
+			if err := os.WriteFile(filepath.Join(corpusPath, version.String()+"_state.ssz"), stateSSZ, 0o644); err != nil {
+				t.Fatalf("failed writing %v state: %v", version, err)
+			}
+
+			manifest[version.String()] = &corpusManifestEntry{
+				BlockRoot: blockRoot.String(),
+				StateRoot: phase0.Root(stateRoot).String(),
+			}
+		}
+
+		manifestJson, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			t.Fatalf("failed encoding corpus manifest: %v", err)
+		}
+		if err := os.WriteFile(manifestFile, append(manifestJson, '\n'), 0o644); err != nil {
+			t.Fatalf("failed writing corpus manifest: %v", err)
+		}
+	}
+
+	manifestJson, err := os.ReadFile(manifestFile)
+	if err != nil {
+		t.Fatalf("failed reading corpus manifest: %v", err)
+	}
+	manifest := corpusManifest{}
+	if err := json.Unmarshal(manifestJson, &manifest); err != nil {
+		t.Fatalf("failed decoding corpus manifest: %v", err)
+	}
+
+	fixtures := make([]*corpusFixture, 0, len(corpusForks))
+	for _, version := range corpusForks {
+		fixture := &corpusFixture{
+			version:  version,
+			manifest: manifest[version.String()],
+		}
+		if fixture.manifest == nil {
+			t.Fatalf("missing %v entry in corpus manifest", version)
+		}
+
+		if fixture.blockSSZ, err = os.ReadFile(filepath.Join(corpusPath, version.String()+"_block.ssz")); err != nil {
+			t.Fatalf("failed reading %v block: %v", version, err)
+		}
+		if fixture.block, err = unmarshalVersionedSignedBeaconBlockSSZ(dynSsz, uint64(version), fixture.blockSSZ); err != nil {
+			t.Fatalf("failed decoding %v block: %v", version, err)
+		}
+
+		if fixture.stateSSZ, err = os.ReadFile(filepath.Join(corpusPath, version.String()+"_state.ssz")); err != nil {
+			t.Fatalf("failed reading %v state: %v", version, err)
+		}
+		if fixture.state, err = unmarshalCorpusState(dynSsz, version, fixture.stateSSZ); err != nil {
+			t.Fatalf("failed decoding %v state: %v", version, err)
+		}
+
+		fixtures = append(fixtures, fixture)
+	}
+
+	return fixtures
+}
+
+func TestCorpusManifest(t *testing.T) {
+	dynSsz := newCorpusDynSsz()
+
+	for _, fixture := range loadCorpus(t) {
+		t.Run(fixture.version.String(), func(t *testing.T) {
+			_, blockRoot, err := getBlockHeader(dynSsz, fixture.block)
+			if err != nil {
+				t.Fatalf("failed building block header: %v", err)
+			}
+			if blockRoot.String() != fixture.manifest.BlockRoot {
+				t.Errorf("block root mismatch: got %v, expected %v", blockRoot.String(), fixture.manifest.BlockRoot)
+			}
+
+			stateRoot, err := dynSsz.HashTreeRoot(getStateObject(fixture.state))
+			if err != nil {
+				t.Fatalf("failed hashing state: %v", err)
+			}
+			if phase0.Root(stateRoot).String() != fixture.manifest.StateRoot {
+				t.Errorf("state root mismatch: got %v, expected %v", phase0.Root(stateRoot).String(), fixture.manifest.StateRoot)
+			}
+		})
+	}
+}
+
+func TestCorpusMatchesGenerator(t *testing.T) {
+	dynSsz := newCorpusDynSsz()
+
+	// the generator must reproduce the committed fixtures, otherwise the corpus is stale
+	for _, fixture := range loadCorpus(t) {
+		_, blockSSZ, err := MarshalVersionedSignedBeaconBlockSSZ(dynSsz, buildCorpusBlock(fixture.version, corpusSyncCommitteeSize), nil, true)
+		if err != nil {
+			t.Fatalf("failed encoding %v block: %v", fixture.version, err)
+		}
+		if string(blockSSZ) != string(fixture.blockSSZ) {
+			t.Errorf("%v block fixture is stale, regenerate with -update-corpus", fixture.version)
+		}
+
+		stateSSZ, err := dynSsz.MarshalSSZ(getStateObject(buildCorpusState(fixture.version)))
+		if err != nil {
+			t.Fatalf("failed encoding %v state: %v", fixture.version, err)
+		}
+		if string(stateSSZ) != string(fixture.stateSSZ) {
+			t.Errorf("%v state fixture is stale, regenerate with -update-corpus", fixture.version)
+		}
+	}
+}
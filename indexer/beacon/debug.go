@@ -63,7 +63,6 @@ func (indexer *Indexer) GetCacheDebugStats() *CacheDebugStats {
 
 func (indexer *Indexer) getBlockCacheDebugStats(cacheStats *CacheDebugStats) {
 	indexer.blockCache.cacheMutex.RLock()
-	defer indexer.blockCache.cacheMutex.RUnlock()
 
 	cacheStats.BlockCache.SlotMap = CacheDebugMapSize{
 		Length: len(indexer.blockCache.slotMap),
@@ -82,11 +81,18 @@ func (indexer *Indexer) getBlockCacheDebugStats(cacheStats *CacheDebugStats) {
 		Size:   mapsize.Size(indexer.blockCache.execBlockMap),
 	}
 
+	cachedBlocks := make([]*Block, 0, len(indexer.blockCache.rootMap))
 	for _, block := range indexer.blockCache.rootMap {
+		cachedBlocks = append(cachedBlocks, block)
+	}
+	indexer.blockCache.cacheMutex.RUnlock()
+
+	// the bodies are checked outside of the cache lock, the block locks might be held during body loading
+	for _, block := range cachedBlocks {
 		if block.header != nil {
 			cacheStats.BlockCache.BlockHeader++
 		}
-		if block.block != nil {
+		if block.hasBlockBody() {
 			cacheStats.BlockCache.BlockBodies++
 		}
 		if block.blockIndex != nil {
@@ -129,8 +135,8 @@ func (indexer *Indexer) getEpochCacheDebugStats(cacheStats *CacheDebugStats) {
 	}
 
 	cacheStats.EpochCache.VotesCacheLen = uint64(indexer.epochCache.votesCache.Len())
-	cacheStats.EpochCache.VotesCacheHit = indexer.epochCache.votesCacheHit
-	cacheStats.EpochCache.VotesCacheMiss = indexer.epochCache.votesCacheMiss
+	cacheStats.EpochCache.VotesCacheHit = indexer.epochCache.votesCacheHit.Load()
+	cacheStats.EpochCache.VotesCacheMiss = indexer.epochCache.votesCacheMiss.Load()
 }
 
 func (indexer *Indexer) getForkCacheDebugStats(cacheStats *CacheDebugStats) {
@@ -143,12 +149,12 @@ func (indexer *Indexer) getForkCacheDebugStats(cacheStats *CacheDebugStats) {
 	}
 
 	cacheStats.ForkCache.ParentIdCacheLen = uint64(indexer.forkCache.parentIdCache.Len())
-	cacheStats.ForkCache.ParentIdCacheHit = indexer.forkCache.parentIdCacheHit
-	cacheStats.ForkCache.ParentIdCacheMiss = indexer.forkCache.parentIdCacheMiss
+	cacheStats.ForkCache.ParentIdCacheHit = indexer.forkCache.parentIdCacheHit.Load()
+	cacheStats.ForkCache.ParentIdCacheMiss = indexer.forkCache.parentIdCacheMiss.Load()
 
 	cacheStats.ForkCache.ParentIdsCacheLen = uint64(indexer.forkCache.parentIdsCache.Len())
-	cacheStats.ForkCache.ParentIdsCacheHit = indexer.forkCache.parentIdsCacheHit
-	cacheStats.ForkCache.ParentIdsCacheMiss = indexer.forkCache.parentIdsCacheMiss
+	cacheStats.ForkCache.ParentIdsCacheHit = indexer.forkCache.parentIdsCacheHit.Load()
+	cacheStats.ForkCache.ParentIdsCacheMiss = indexer.forkCache.parentIdsCacheMiss.Load()
 }
 
 func (indexer *Indexer) getValidatorCacheDebugStats(cacheStats *CacheDebugStats) {
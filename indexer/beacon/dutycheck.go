@@ -0,0 +1,208 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// dutyCheckTimeout is the timeout for fetching the duties of an epoch from a beacon node
+const dutyCheckTimeout = 30 * time.Second
+
+// dutyCheckTracker cross-checks the proposer & attester duties reported by the connected beacon nodes
+// with the duties computed by the explorer from the dependent state.
+// mismatches point to shuffling bugs in either the client or the explorer and are persisted for inspection.
+type dutyCheckTracker struct {
+	indexer          *Indexer
+	mutex            sync.Mutex
+	running          bool
+	lastCheckedEpoch phase0.Epoch
+	checkedClients   map[uint16]bool
+}
+
+// newDutyCheckTracker creates & returns a new instance of dutyCheckTracker.
+func newDutyCheckTracker(indexer *Indexer) *dutyCheckTracker {
+	return &dutyCheckTracker{
+		indexer:        indexer,
+		checkedClients: map[uint16]bool{},
+	}
+}
+
+// scheduleCheck starts the duty check for the epoch in background, unless a check is already running.
+// clients that follow a chain whose duties are not computed yet are checked on the next call.
+func (tracker *dutyCheckTracker) scheduleCheck(epoch phase0.Epoch) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if tracker.running || epoch < tracker.lastCheckedEpoch {
+		return
+	}
+
+	if epoch > tracker.lastCheckedEpoch {
+		tracker.lastCheckedEpoch = epoch
+		tracker.checkedClients = map[uint16]bool{}
+	}
+
+	tracker.running = true
+	go func() {
+		defer utils.HandleSubroutinePanic("dutyCheckTracker.checkEpoch", nil)
+		defer func() {
+			tracker.mutex.Lock()
+			tracker.running = false
+			tracker.mutex.Unlock()
+		}()
+
+		tracker.checkEpoch(epoch)
+	}()
+}
+
+// checkEpoch compares the duties of the epoch for all clients that have not been checked yet.
+func (tracker *dutyCheckTracker) checkEpoch(epoch phase0.Epoch) {
+	mismatches := []*dbtypes.DutyMismatch{}
+
+	for _, epochStats := range tracker.indexer.epochCache.getEpochStatsByEpoch(epoch) {
+		epochStatsValues := epochStats.GetValues(false)
+		if epochStatsValues == nil || len(epochStatsValues.ProposerDuties) == 0 || len(epochStatsValues.AttesterDuties) == 0 {
+			continue
+		}
+
+		for _, client := range tracker.indexer.GetReadyClientsByBlockRoot(epochStats.dependentRoot, false) {
+			tracker.mutex.Lock()
+			checked := tracker.checkedClients[client.index]
+			tracker.checkedClients[client.index] = true
+			tracker.mutex.Unlock()
+
+			if checked {
+				continue
+			}
+
+			clientMismatches, err := tracker.checkClient(client, epochStats, epochStatsValues)
+			if err != nil {
+				tracker.indexer.logger.Warnf("duty check for epoch %v failed on client %v: %v", epoch, client.client.GetName(), err)
+				continue
+			}
+
+			if len(clientMismatches) > 0 {
+				tracker.indexer.logger.Warnf("duty check for epoch %v: client %v reported %v mismatching duties (dependent root: %v)", epoch, client.client.GetName(), len(clientMismatches), epochStats.dependentRoot.String())
+			}
+
+			mismatches = append(mismatches, clientMismatches...)
+		}
+	}
+
+	if len(mismatches) == 0 {
+		return
+	}
+
+	_, err := tracker.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertDutyMismatches(mismatches, tx)
+	})
+	if err != nil {
+		tracker.indexer.logger.Errorf("failed persisting duty mismatches for epoch %v: %v", epoch, err)
+	}
+}
+
+// checkClient fetches the proposer duties & beacon committees of the epoch from the client and returns the duties that differ from the computed ones.
+func (tracker *dutyCheckTracker) checkClient(client *Client, epochStats *EpochStats, epochStatsValues *EpochStatsValues) ([]*dbtypes.DutyMismatch, error) {
+	chainState := tracker.indexer.consensusPool.GetChainState()
+	epoch := epochStats.epoch
+	firstSlot := chainState.EpochToSlot(epoch)
+
+	ctx, cancel := context.WithTimeout(client.client.GetContext(), dutyCheckTimeout)
+	defer cancel()
+
+	proposerDuties, err := client.client.GetRPCClient().GetProposerDuties(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching proposer duties: %v", err)
+	}
+
+	committees, err := client.client.GetRPCClient().GetBeaconCommittees(ctx, "head", &epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching beacon committees: %v", err)
+	}
+
+	mismatches := []*dbtypes.DutyMismatch{}
+	addMismatch := func(dutyType dbtypes.DutyMismatchType, slot phase0.Slot, committeeIndex uint64, expected, reported []phase0.ValidatorIndex) {
+		mismatches = append(mismatches, &dbtypes.DutyMismatch{
+			Epoch:          uint64(epoch),
+			ClientId:       client.client.GetClientId(),
+			DutyType:       dutyType,
+			Slot:           uint64(slot),
+			CommitteeIndex: committeeIndex,
+			ClientName:     client.client.GetName(),
+			DependentRoot:  epochStats.dependentRoot[:],
+			Expected:       formatDutyIndices(expected),
+			Reported:       formatDutyIndices(reported),
+			DetectedAt:     uint64(time.Now().Unix()),
+		})
+	}
+
+	// proposer duties
+	reportedProposers := map[phase0.Slot][]phase0.ValidatorIndex{}
+	for _, duty := range proposerDuties {
+		reportedProposers[duty.Slot] = append(reportedProposers[duty.Slot], duty.ValidatorIndex)
+	}
+
+	for slotIndex, proposer := range epochStatsValues.ProposerDuties {
+		slot := firstSlot + phase0.Slot(slotIndex)
+		expected := []phase0.ValidatorIndex{proposer}
+		if reported := reportedProposers[slot]; !slices.Equal(expected, reported) {
+			addMismatch(dbtypes.DutyMismatchProposer, slot, 0, expected, reported)
+		}
+	}
+
+	// attester duties
+	type committeeKey struct {
+		slot  phase0.Slot
+		index uint64
+	}
+
+	reportedCommittees := map[committeeKey][]phase0.ValidatorIndex{}
+	for _, committee := range committees {
+		if chainState.EpochOfSlot(committee.Slot) != epoch {
+			continue
+		}
+		reportedCommittees[committeeKey{committee.Slot, uint64(committee.Index)}] = committee.Validators
+	}
+
+	for slotIndex, slotCommittees := range epochStatsValues.AttesterDuties {
+		slot := firstSlot + phase0.Slot(slotIndex)
+		for committeeIndex, committee := range slotCommittees {
+			key := committeeKey{slot, uint64(committeeIndex)}
+			expected := make([]phase0.ValidatorIndex, len(committee))
+			for idx, activeIdx := range committee {
+				expected[idx] = epochStatsValues.ActiveIndices[activeIdx]
+			}
+
+			if reported := reportedCommittees[key]; !slices.Equal(expected, reported) {
+				addMismatch(dbtypes.DutyMismatchAttester, slot, key.index, expected, reported)
+			}
+			delete(reportedCommittees, key)
+		}
+	}
+
+	// committees reported by the client that do not exist in the computed duties
+	for key, reported := range reportedCommittees {
+		addMismatch(dbtypes.DutyMismatchAttester, key.slot, key.index, nil, reported)
+	}
+
+	return mismatches, nil
+}
+
+// formatDutyIndices returns the validator indices as comma separated string.
+func formatDutyIndices(indices []phase0.ValidatorIndex) string {
+	indiceStrs := make([]string, len(indices))
+	for idx, index := range indices {
+		indiceStrs[idx] = fmt.Sprintf("%v", index)
+	}
+	return strings.Join(indiceStrs, ",")
+}
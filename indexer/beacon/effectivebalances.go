@@ -0,0 +1,63 @@
+package beacon
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/jmoiron/sqlx"
+)
+
+// EffectiveBalanceBuckets are the lower bounds (in ETH) of the effective balance distribution buckets.
+// validators with 0x00/0x01 credentials are capped at 32 ETH, compounding (0x02) validators can grow up to the MaxEB of 2048 ETH.
+var EffectiveBalanceBuckets = []uint16{0, 32, 33, 64, 128, 256, 512, 1024, 2048}
+
+// effectiveBalanceChangeBatchSize is the max number of effective balance changes inserted per statement
+const effectiveBalanceChangeBatchSize = 1000
+
+// effectiveBalanceTracker persists the effective balance changes of the validators and the effective balance distribution per finalized epoch.
+// the changes are collected by the validator cache when a validator set update gets finalized.
+type effectiveBalanceTracker struct {
+	indexer *Indexer
+	mutex   sync.Mutex
+}
+
+// newEffectiveBalanceTracker creates & returns a new instance of effectiveBalanceTracker.
+func newEffectiveBalanceTracker(indexer *Indexer) *effectiveBalanceTracker {
+	return &effectiveBalanceTracker{
+		indexer: indexer,
+	}
+}
+
+// processEpoch persists the collected effective balance changes and the effective balance distribution after the finalization of an epoch.
+// the distribution reflects the validator set after the epoch transition, so it's recorded for the next epoch.
+func (tracker *effectiveBalanceTracker) processEpoch(epoch phase0.Epoch) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	changes := tracker.indexer.validatorCache.takeEffectiveBalanceChanges()
+	distribution := tracker.indexer.validatorCache.getEffectiveBalanceDistribution(EffectiveBalanceBuckets)
+
+	_, err := tracker.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		for start := 0; start < len(changes); start += effectiveBalanceChangeBatchSize {
+			end := start + effectiveBalanceChangeBatchSize
+			if end > len(changes) {
+				end = len(changes)
+			}
+
+			if err := db.InsertEffectiveBalanceChanges(changes[start:end], tx); err != nil {
+				return err
+			}
+		}
+
+		return db.InsertEffectiveBalanceStats(uint64(epoch+1), distribution, tx)
+	})
+	if err != nil {
+		tracker.indexer.logger.Errorf("failed persisting effective balances for epoch %v: %v", epoch, err)
+		return
+	}
+
+	if len(changes) > 0 {
+		tracker.indexer.logger.Debugf("persisted %v effective balance changes for epoch %v", len(changes), epoch)
+	}
+}
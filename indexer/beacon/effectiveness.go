@@ -0,0 +1,166 @@
+package beacon
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+const effectivenessBatchSize = 1000
+
+// effectivenessTracker accumulates the attestation effectiveness of all active validators over a day.
+// the effectiveness of a validator in an epoch is 1/inclusion delay of its canonical vote (0 if missed).
+// at the end of each day, all validators are ranked by their average effectiveness and the scores are persisted to the db.
+// the accumulated scores are kept in memory only, so the first day after a restart covers less epochs.
+type effectivenessTracker struct {
+	indexer     *Indexer
+	mutex       sync.Mutex
+	historyDays uint64
+	day         uint64
+	firstEpoch  phase0.Epoch
+	scores      map[phase0.ValidatorIndex]effectivenessEntry
+}
+
+// effectivenessEntry holds the accumulated effectiveness of a validator.
+// entry size: 8 bytes (+ 8 bytes map key)
+type effectivenessEntry struct {
+	score  float32
+	epochs uint16
+}
+
+// newEffectivenessTracker creates & returns a new instance of effectivenessTracker.
+func newEffectivenessTracker(indexer *Indexer, historyDays uint64) *effectivenessTracker {
+	return &effectivenessTracker{
+		indexer:     indexer,
+		historyDays: historyDays,
+		scores:      map[phase0.ValidatorIndex]effectivenessEntry{},
+	}
+}
+
+// getDayOfEpoch returns the day number (since genesis) of the given epoch.
+func (tracker *effectivenessTracker) getDayOfEpoch(epoch phase0.Epoch) uint64 {
+	chainState := tracker.indexer.consensusPool.GetChainState()
+	genesis := chainState.GetGenesis()
+	if genesis == nil {
+		return 0
+	}
+
+	return uint64(chainState.EpochToTime(epoch).Sub(genesis.GenesisTime) / (24 * time.Hour))
+}
+
+// processEpoch adds the effectiveness of all active validators in the finalized epoch to the daily scores.
+// flushes the scores of the previous day to the db when the epoch belongs to a new day.
+func (tracker *effectivenessTracker) processEpoch(epoch phase0.Epoch, epochStatsValues *EpochStatsValues, canonicalBlocks []*Block) {
+	chainState := tracker.indexer.consensusPool.GetChainState()
+	day := tracker.getDayOfEpoch(epoch)
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if day != tracker.day && len(tracker.scores) > 0 {
+		if err := tracker.flushDay(); err != nil {
+			tracker.indexer.logger.Errorf("failed persisting validator effectiveness for day %v: %v", tracker.day, err)
+		}
+
+		tracker.scores = map[phase0.ValidatorIndex]effectivenessEntry{}
+	}
+	if len(tracker.scores) == 0 {
+		tracker.day = day
+		tracker.firstEpoch = epoch
+	}
+
+	canonicalMap := make(map[*Block]bool, len(canonicalBlocks))
+	for _, block := range canonicalBlocks {
+		canonicalMap[block] = true
+	}
+
+	for _, validatorIndex := range epochStatsValues.ActiveIndices {
+		score := float32(0)
+		for _, activity := range tracker.indexer.validatorActivity.getValidatorActivity(validatorIndex) {
+			if !canonicalMap[activity.VoteBlock] {
+				continue
+			}
+
+			if chainState.EpochOfSlot(activity.VoteBlock.Slot-phase0.Slot(activity.VoteDelay)) != epoch {
+				continue
+			}
+
+			delay := activity.VoteDelay
+			if delay < 1 {
+				delay = 1
+			}
+
+			if entryScore := 1 / float32(delay); entryScore > score {
+				score = entryScore
+			}
+		}
+
+		entry := tracker.scores[validatorIndex]
+		entry.score += score
+		entry.epochs++
+		tracker.scores[validatorIndex] = entry
+	}
+}
+
+// flushDay ranks all validators by their average effectiveness of the tracked day and persists the scores to the db.
+func (tracker *effectivenessTracker) flushDay() error {
+	t1 := time.Now()
+	scores := make([]*dbtypes.ValidatorEffectiveness, 0, len(tracker.scores))
+	for validatorIndex, entry := range tracker.scores {
+		scores = append(scores, &dbtypes.ValidatorEffectiveness{
+			Day:            tracker.day,
+			ValidatorIndex: uint64(validatorIndex),
+			Effectiveness:  uint32(entry.score * 10000 / float32(entry.epochs)),
+			Epochs:         uint32(entry.epochs),
+		})
+	}
+
+	sort.Slice(scores, func(a, b int) bool {
+		return scores[a].Effectiveness > scores[b].Effectiveness
+	})
+
+	// validators with equal effectiveness share the best rank
+	totalCount := uint64(len(scores))
+	rank := uint64(0)
+	for idx, score := range scores {
+		if idx == 0 || score.Effectiveness != scores[idx-1].Effectiveness {
+			rank = uint64(idx) + 1
+		}
+
+		score.TopPercent = uint32((rank*10000 + totalCount - 1) / totalCount)
+	}
+
+	_, err := tracker.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		for start := 0; start < len(scores); start += effectivenessBatchSize {
+			end := start + effectivenessBatchSize
+			if end > len(scores) {
+				end = len(scores)
+			}
+
+			if err := db.InsertValidatorEffectivenessBatch(scores[start:end], tx); err != nil {
+				return err
+			}
+		}
+
+		if tracker.historyDays > 0 && tracker.day >= tracker.historyDays {
+			if err := db.DeleteValidatorEffectivenessBefore(tracker.day-tracker.historyDays+1, tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error persisting validator effectiveness: %v", err)
+	}
+
+	tracker.indexer.logger.Infof("persisted effectiveness of %v validators for day %v (first epoch: %v, %v ms)", len(scores), tracker.day, tracker.firstEpoch, time.Since(t1).Milliseconds())
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"runtime/debug"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -31,6 +32,7 @@ func getEpochStatsKey(epoch phase0.Epoch, dependentRoot phase0.Root) epochStatsK
 }
 
 // epochCache is the cache for EpochStats (epoch status) and epochState (beacon state) structures.
+// the cache mutex is independent of the fork & block cache locks (see forkCache), it must not be held while calling into these caches.
 type epochCache struct {
 	indexer        *Indexer
 	cacheMutex     sync.RWMutex                  // mutex to protect statsMap & stateMap for concurrent read/write
@@ -42,8 +44,9 @@ type epochCache struct {
 	precomputeLock sync.Mutex                    // mutex to prevent concurrent precomputing of epoch stats
 
 	votesCache     *lru.Cache[epochVotesKey, *EpochVotes] // cache for epoch vote aggregations
-	votesCacheHit  uint64
-	votesCacheMiss uint64
+	votesCacheHit  atomic.Uint64
+	votesCacheMiss atomic.Uint64
+	evictedValues  uint64 // total number of epoch stats values evicted due to the memory limit
 }
 
 // newEpochCache creates & returns a new instance of epochCache.
@@ -58,8 +61,10 @@ func newEpochCache(indexer *Indexer) *epochCache {
 		votesCache: lru.NewCache[epochVotesKey, *EpochVotes](500),
 	}
 
-	// start beacon state loader subroutine
-	go cache.startLoaderLoop()
+	// start beacon state loader subroutine (frontends don't load states, the epoch stats are restored from the db)
+	if !indexer.frontendOnly {
+		go cache.startLoaderLoop()
+	}
 
 	return cache
 }
@@ -99,13 +104,14 @@ func (cache *epochCache) createOrGetEpochStats(epoch phase0.Epoch, dependentRoot
 }
 
 func (cache *epochCache) addEpochStateRequest(epochStats *EpochStats) {
+	cache.cacheMutex.Lock()
+	defer cache.cacheMutex.Unlock()
+
+	// dependentState is assigned under the cache lock, so check it after locking
 	if epochStats.dependentState != nil {
 		return
 	}
 
-	cache.cacheMutex.Lock()
-	defer cache.cacheMutex.Unlock()
-
 	epochState := cache.stateMap[epochStats.dependentRoot]
 	if epochState == nil {
 		epochState = newEpochState(epochStats.dependentRoot)
@@ -127,8 +133,8 @@ func (cache *epochCache) getEpochStats(epoch phase0.Epoch, dependentRoot phase0.
 
 // getPendingEpochStats gets all EpochStats with unloaded epochStates.
 func (cache *epochCache) getPendingEpochStats() []*EpochStats {
-	cache.cacheMutex.Lock()
-	defer cache.cacheMutex.Unlock()
+	cache.cacheMutex.RLock()
+	defer cache.cacheMutex.RUnlock()
 
 	pendingStats := make([]*EpochStats, 0)
 	for _, stats := range cache.statsMap {
@@ -155,11 +161,9 @@ func (cache *epochCache) getEpochStatsByEpoch(epoch phase0.Epoch) []*EpochStats
 }
 
 func (cache *epochCache) getEpochStatsByEpochAndRoot(epoch phase0.Epoch, blockRoot phase0.Root) *EpochStats {
-	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
-	for _, stats := range cache.statsMap {
-		if stats.epoch == epoch && cache.indexer.blockCache.isCanonicalBlock(stats.dependentRoot, blockRoot) {
+	// the canonical check walks the block cache, so it must not run while holding the epoch cache lock
+	for _, stats := range cache.getEpochStatsByEpoch(epoch) {
+		if cache.indexer.blockCache.isCanonicalBlock(stats.dependentRoot, blockRoot) {
 			return stats
 		}
 	}
@@ -353,6 +357,9 @@ func (cache *epochCache) runLoaderLoop() {
 
 		for _, pendingStats := range pendingStats {
 			if cache.loadEpochStats(pendingStats) {
+				if cache.indexer.reassignmentTracker != nil {
+					cache.indexer.reassignmentTracker.checkAssignments()
+				}
 				break
 			}
 		}
@@ -410,17 +417,19 @@ func (cache *epochCache) loadEpochStats(epochStats *EpochStats) bool {
 	sort.Slice(clients, func(a, b int) bool {
 		cliA := clients[a]
 		cliB := clients[b]
+		selA := cliA.getSelection()
+		selB := cliB.getSelection()
 
-		if cliA.archive != cliB.archive {
-			if cliA.archive {
+		if selA.Archive != selB.Archive {
+			if selA.Archive {
 				return false
 			} else {
 				return true
 			}
 		}
 
-		if cliA.priority != cliB.priority {
-			if cliA.priority > cliB.priority {
+		if selA.Priority != selB.Priority {
+			if selA.Priority > selB.Priority {
 				return false
 			} else {
 				return true
@@ -0,0 +1,168 @@
+package beacon
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// epochCacheProtectedEpochs is the number of epochs before the current epoch that are never evicted from the epoch cache.
+const epochCacheProtectedEpochs = 2
+
+// EpochCacheStats holds statistics about the current epoch cache memory usage.
+type EpochCacheStats struct {
+	EpochStats     uint64 `json:"epoch_stats"`      // number of epoch stats in cache
+	EpochStates    uint64 `json:"epoch_states"`     // number of epoch states in cache
+	FullValues     uint64 `json:"full_values"`      // number of epoch stats with full duties in memory
+	PrunedValues   uint64 `json:"pruned_values"`    // number of epoch stats with pruned duties in memory
+	PrecalcValues  uint64 `json:"precalc_values"`   // number of epoch stats with precalculated duties in memory
+	LowestEpoch    uint64 `json:"lowest_epoch"`     // lowest epoch in cache
+	HighestEpoch   uint64 `json:"highest_epoch"`    // highest epoch in cache
+	MemoryUsage    uint64 `json:"memory_usage"`     // estimated memory usage of all cached epoch stats values (bytes)
+	MemoryLimit    uint64 `json:"memory_limit"`     // configured memory limit (bytes, 0 = unlimited)
+	EvictedValues  uint64 `json:"evicted_values"`   // total number of evicted epoch stats values since startup
+	VotesCacheSize uint64 `json:"votes_cache_size"` // number of cached epoch vote aggregations
+}
+
+// getMemorySize returns the estimated memory usage of the EpochStats values in bytes.
+func (v *EpochStatsValues) getMemorySize() uint64 {
+	if v == nil {
+		return 0
+	}
+
+	size := uint64(256) // struct & slice headers
+	size += uint64(len(v.ActiveIndices)) * 8
+	size += uint64(len(v.EffectiveBalances)) * 2
+	size += uint64(len(v.ProposerDuties)) * 8
+	size += uint64(len(v.SyncCommitteeDuties)) * 8
+	size += uint64(len(v.PendingWithdrawals)) * 16
+	size += uint64(len(v.PendingConsolidations)) * 16
+	size += uint64(len(v.PendingDeposits)) * 16
+
+	for _, slotDuties := range v.AttesterDuties {
+		size += 24
+		for _, committeeDuties := range slotDuties {
+			size += 24 + uint64(len(committeeDuties))*4
+		}
+	}
+
+	return size
+}
+
+// getMemorySize returns the estimated memory usage of all values held by the EpochStats in bytes.
+func (es *EpochStats) getMemorySize() uint64 {
+	return es.values.getMemorySize() + es.prunedValues.getMemorySize() + es.precalcValues.getMemorySize()
+}
+
+// GetEpochCacheStats returns statistics about the current epoch cache memory usage.
+func (indexer *Indexer) GetEpochCacheStats() EpochCacheStats {
+	cache := indexer.epochCache
+	cache.cacheMutex.RLock()
+	defer cache.cacheMutex.RUnlock()
+
+	stats := EpochCacheStats{
+		EpochStats:     uint64(len(cache.statsMap)),
+		EpochStates:    uint64(len(cache.stateMap)),
+		MemoryLimit:    indexer.epochCacheMemoryLimit,
+		EvictedValues:  cache.evictedValues,
+		VotesCacheSize: uint64(cache.votesCache.Len()),
+	}
+
+	first := true
+	for _, epochStats := range cache.statsMap {
+		if epochStats.values != nil {
+			stats.FullValues++
+		}
+		if epochStats.prunedValues != nil {
+			stats.PrunedValues++
+		}
+		if epochStats.precalcValues != nil {
+			stats.PrecalcValues++
+		}
+
+		stats.MemoryUsage += epochStats.getMemorySize()
+
+		if first || uint64(epochStats.epoch) < stats.LowestEpoch {
+			stats.LowestEpoch = uint64(epochStats.epoch)
+		}
+		if first || uint64(epochStats.epoch) > stats.HighestEpoch {
+			stats.HighestEpoch = uint64(epochStats.epoch)
+		}
+		first = false
+	}
+
+	return stats
+}
+
+// enforceMemoryLimit evicts epoch stats values from the cache until the estimated memory usage is below the configured limit.
+// only already pruned epochs are considered, epochs farthest from the head are evicted first.
+// full duties are reduced to pruned values, the full duties can be restored from the unfinalized duties table on demand (GetOrLoadValues).
+// the pruned values (proposer & sync committee duties) are small and kept, as most callers read them via GetValues without db fallback.
+func (cache *epochCache) enforceMemoryLimit() uint64 {
+	memoryLimit := cache.indexer.epochCacheMemoryLimit
+	if memoryLimit == 0 {
+		return 0
+	}
+
+	currentEpoch := cache.indexer.consensusPool.GetChainState().CurrentEpoch()
+	protectedEpoch := phase0.Epoch(0)
+	if currentEpoch > epochCacheProtectedEpochs {
+		protectedEpoch = currentEpoch - epochCacheProtectedEpochs
+	}
+	if protectedEpoch > cache.indexer.lastPrunedEpoch {
+		protectedEpoch = cache.indexer.lastPrunedEpoch
+	}
+
+	cache.cacheMutex.Lock()
+	defer cache.cacheMutex.Unlock()
+
+	memoryUsage := uint64(0)
+	candidates := make([]*EpochStats, 0, len(cache.statsMap))
+	for _, epochStats := range cache.statsMap {
+		memoryUsage += epochStats.getMemorySize()
+
+		if epochStats.epoch < protectedEpoch && epochStats.ready && epochStats.isInDb {
+			candidates = append(candidates, epochStats)
+		}
+	}
+
+	if memoryUsage <= memoryLimit {
+		return 0
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		return candidates[a].epoch < candidates[b].epoch
+	})
+
+	evicted := uint64(0)
+
+	// reduce full & precalculated values to pruned values
+	for _, epochStats := range candidates {
+		if memoryUsage <= memoryLimit {
+			break
+		}
+
+		if epochStats.values == nil && epochStats.precalcValues == nil {
+			continue
+		}
+
+		oldSize := epochStats.getMemorySize()
+		if epochStats.values != nil {
+			epochStats.pruneValues()
+		}
+		epochStats.precalcValues = nil
+
+		memoryUsage -= oldSize - epochStats.getMemorySize()
+		evicted++
+	}
+
+	cache.evictedValues += evicted
+
+	if memoryUsage > memoryLimit {
+		cache.indexer.logger.Warnf("epoch cache memory usage (%v MB) exceeds the limit (%v MB) after evicting %v epoch stats", memoryUsage/1024/1024, memoryLimit/1024/1024, evicted)
+	} else if evicted > 0 {
+		cache.indexer.logger.Infof("evicted %v epoch stats from cache (memory usage: %v MB)", evicted, memoryUsage/1024/1024)
+	}
+
+	return evicted
+}
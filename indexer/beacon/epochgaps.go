@@ -0,0 +1,66 @@
+package beacon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/jmoiron/sqlx"
+)
+
+// fillMissingEpochs recomputes the aggregates of finalized epochs that have slots in the db, but no entry in the epochs table.
+// such gaps are left behind by crashes between writing the slots & epoch aggregates and would otherwise show up as holes in the epoch charts.
+// the restored aggregates are built from the stored slots only: vote stats are not available anymore and the validator set size is taken from the previous epoch.
+func (indexer *Indexer) fillMissingEpochs(finalizedEpoch phase0.Epoch) {
+	if finalizedEpoch == 0 {
+		return
+	}
+
+	specs := indexer.consensusPool.GetChainState().GetSpecs()
+	if specs == nil {
+		return
+	}
+
+	t1 := time.Now()
+	filledCount := 0
+
+	for {
+		epochs, err := db.GetMissingEpochAggregates(specs.SlotsPerEpoch, uint64(finalizedEpoch-1), 100)
+		if err != nil {
+			indexer.logger.WithError(err).Errorf("failed loading missing epoch aggregates")
+			return
+		}
+		if len(epochs) == 0 {
+			break
+		}
+
+		for _, dbEpoch := range epochs {
+			if dbEpoch.Epoch > 0 {
+				if prevEpochs := db.GetEpochs(dbEpoch.Epoch-1, 1); len(prevEpochs) > 0 {
+					dbEpoch.ValidatorCount = prevEpochs[0].ValidatorCount
+					dbEpoch.ValidatorBalance = prevEpochs[0].ValidatorBalance
+				}
+			}
+		}
+
+		_, err = indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+			for _, dbEpoch := range epochs {
+				if err := db.InsertEpoch(dbEpoch, tx); err != nil {
+					return fmt.Errorf("error while saving epoch %v to db: %w", dbEpoch.Epoch, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			indexer.logger.WithError(err).Errorf("failed persisting restored epoch aggregates")
+			return
+		}
+
+		filledCount += len(epochs)
+	}
+
+	if filledCount > 0 {
+		indexer.logger.Infof("restored %v missing epoch aggregates from stored slots (%.3f sec)", filledCount, time.Since(t1).Seconds())
+	}
+}
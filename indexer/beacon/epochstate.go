@@ -29,6 +29,10 @@ type epochState struct {
 	syncCommittee             []phase0.ValidatorIndex
 	pendingPartialWithdrawals []*electra.PendingPartialWithdrawal
 	pendingConsolidations     []*electra.PendingConsolidation
+	pendingDeposits           []EpochStatsPendingDeposit
+	depositBalanceToConsume   phase0.Gwei
+	exitBalanceToConsume      phase0.Gwei
+	earliestExitEpoch         phase0.Epoch
 }
 
 // newEpochState creates a new epochState instance with the root of the state to be loaded.
@@ -207,6 +211,30 @@ func (s *epochState) processState(state *spec.VersionedBeaconState, cache *epoch
 
 		// apply epoch transition to get remaining pending consolidations
 		s.pendingConsolidations = pendingConsolidations
+
+		pendingDeposits, err := getStatePendingDeposits(state)
+		if err != nil {
+			return fmt.Errorf("error getting pending deposits from state %v: %v", s.slotRoot.String(), err)
+		}
+
+		// keep amounts & validator indices only, the pubkeys & signatures are not needed for queue estimations
+		s.pendingDeposits = make([]EpochStatsPendingDeposit, len(pendingDeposits))
+		for i, pendingDeposit := range pendingDeposits {
+			validatorIndex, found := validatorPubkeyMap[pendingDeposit.Pubkey]
+			if !found {
+				validatorIndex = UnknownValidatorIndex
+			}
+
+			s.pendingDeposits[i] = EpochStatsPendingDeposit{
+				ValidatorIndex: validatorIndex,
+				Amount:         pendingDeposit.Amount,
+			}
+		}
+
+		s.depositBalanceToConsume, s.exitBalanceToConsume, s.earliestExitEpoch, err = getStateChurnBalances(state)
+		if err != nil {
+			return fmt.Errorf("error getting churn balances from state %v: %v", s.slotRoot.String(), err)
+		}
 	}
 
 	return nil
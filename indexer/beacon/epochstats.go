@@ -59,6 +59,12 @@ type EpochStatsValues struct {
 	FirstDepositIndex     uint64
 	PendingWithdrawals    []EpochStatsPendingWithdrawals
 	PendingConsolidations []electra.PendingConsolidation
+
+	// electra activation & exit queue state, not persisted in the packed format (empty if restored from db)
+	PendingDeposits         []EpochStatsPendingDeposit
+	DepositBalanceToConsume phase0.Gwei
+	ExitBalanceToConsume    phase0.Gwei
+	EarliestExitEpoch       phase0.Epoch
 }
 
 // EpochStatsPacked holds the packed values for the epoch-specific information.
@@ -86,6 +92,15 @@ type EpochStatsPendingWithdrawals struct {
 	Epoch          phase0.Epoch
 }
 
+// UnknownValidatorIndex is used for pending deposits of validators that are not part of the validator set yet.
+const UnknownValidatorIndex = phase0.ValidatorIndex(math.MaxUint64)
+
+// EpochStatsPendingDeposit holds the queue relevant values of a pending deposit.
+type EpochStatsPendingDeposit struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Amount         phase0.Gwei
+}
+
 // newEpochStats creates a new EpochStats instance.
 func newEpochStats(epoch phase0.Epoch, dependentRoot phase0.Root) *EpochStats {
 	stats := &EpochStats{
@@ -305,6 +320,7 @@ func (es *EpochStats) pruneValues() {
 		FirstDepositIndex:     es.values.FirstDepositIndex,
 		PendingWithdrawals:    nil, // prune
 		PendingConsolidations: nil, // prune
+		PendingDeposits:       nil, // prune
 	}
 
 	es.values = nil
@@ -360,6 +376,11 @@ func (es *EpochStats) processState(indexer *Indexer, validatorSet []*phase0.Vali
 		FirstDepositIndex:     es.dependentState.depositIndex,
 		PendingWithdrawals:    make([]EpochStatsPendingWithdrawals, len(es.dependentState.pendingPartialWithdrawals)),
 		PendingConsolidations: make([]electra.PendingConsolidation, len(es.dependentState.pendingConsolidations)),
+
+		PendingDeposits:         es.dependentState.pendingDeposits,
+		DepositBalanceToConsume: es.dependentState.depositBalanceToConsume,
+		ExitBalanceToConsume:    es.dependentState.exitBalanceToConsume,
+		EarliestExitEpoch:       es.dependentState.earliestExitEpoch,
 	}
 
 	for i, pendingPartialWithdrawal := range es.dependentState.pendingPartialWithdrawals {
@@ -454,14 +475,14 @@ func (es *EpochStats) processState(indexer *Indexer, validatorSet []*phase0.Vali
 		DutiesSSZ:     packedSsz,
 	}
 
-	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	persisted, err := indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 		return db.InsertUnfinalizedDuty(dbDuty, tx)
 	})
 	if err != nil {
 		indexer.logger.WithError(err).Errorf("failed storing epoch %v stats (%v / %v) to unfinalized duties", es.epoch, es.dependentRoot.String(), es.dependentState.stateRoot.String())
 	}
 
-	es.isInDb = true
+	es.isInDb = persisted
 
 	indexer.logger.Infof(
 		"processed epoch %v stats (root: %v / state: %v, validators: %v/%v, %v ms), %v bytes",
@@ -69,12 +69,12 @@ func (indexer *Indexer) aggregateEpochVotes(epoch phase0.Epoch, chainState *cons
 
 	votesKey := getEpochVotesKey(epoch, targetRoot, blocks[len(blocks)-1].Root, uint8(len(blocks)), votesWithValues, votesWithPrecalc)
 	if cachedVotes, isOk := indexer.epochCache.votesCache.Get(votesKey); isOk {
-		indexer.epochCache.votesCacheHit++
+		indexer.epochCache.votesCacheHit.Add(1)
 		return cachedVotes
 	}
 
 	votes := indexer.aggregateEpochVotesAndActivity(epoch, chainState, blocks, epochStats)
-	indexer.epochCache.votesCacheMiss++
+	indexer.epochCache.votesCacheMiss.Add(1)
 
 	return votes
 }
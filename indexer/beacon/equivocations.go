@@ -0,0 +1,54 @@
+package beacon
+
+import (
+	"bytes"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// checkProposerEquivocation checks the block cache for other blocks of the same slot & proposer (double proposals).
+// detected equivocations are persisted for the slot & validator pages, the proposer may be slashed for them.
+func (indexer *Indexer) checkProposerEquivocation(block *Block) {
+	header := block.GetHeader()
+	if header == nil {
+		return
+	}
+
+	equivocations := []*dbtypes.ProposerEquivocation{}
+	for _, otherBlock := range indexer.blockCache.getBlocksBySlot(block.Slot) {
+		if otherBlock == block || otherBlock.Root == block.Root {
+			continue
+		}
+
+		otherHeader := otherBlock.GetHeader()
+		if otherHeader == nil || otherHeader.Message.ProposerIndex != header.Message.ProposerIndex {
+			continue
+		}
+
+		equivocation := &dbtypes.ProposerEquivocation{
+			Slot:       uint64(block.Slot),
+			Proposer:   uint64(header.Message.ProposerIndex),
+			BlockRoot1: block.Root[:],
+			BlockRoot2: otherBlock.Root[:],
+		}
+		if bytes.Compare(equivocation.BlockRoot1, equivocation.BlockRoot2) > 0 {
+			equivocation.BlockRoot1, equivocation.BlockRoot2 = equivocation.BlockRoot2, equivocation.BlockRoot1
+		}
+
+		indexer.logger.Warnf("proposer equivocation detected: validator %v proposed conflicting blocks for slot %v (%v, %v)", equivocation.Proposer, block.Slot, block.Root.String(), otherBlock.Root.String())
+		equivocations = append(equivocations, equivocation)
+	}
+
+	if len(equivocations) == 0 {
+		return
+	}
+
+	_, err := indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertProposerEquivocations(equivocations, tx)
+	})
+	if err != nil {
+		indexer.logger.Errorf("error persisting proposer equivocations: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package beacon
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type IndexerEventType uint8
+
+const (
+	IndexerEventNewBlock       IndexerEventType = 1
+	IndexerEventReorg          IndexerEventType = 2
+	IndexerEventFinalizedEpoch IndexerEventType = 3
+	IndexerEventHead           IndexerEventType = 4
+)
+
+// IndexerEvent is an event of the indexing process that is passed to the event hooks.
+type IndexerEvent struct {
+	Type            IndexerEventType
+	Block           *Block       // new block (IndexerEventNewBlock) or new head (IndexerEventReorg, IndexerEventHead)
+	OldHead         *Block       // previous head (IndexerEventReorg)
+	RewindDistance  uint64       // number of reorged slots (IndexerEventReorg)
+	ForwardDistance uint64       // number of new slots (IndexerEventReorg)
+	Epoch           phase0.Epoch // finalized epoch (IndexerEventFinalizedEpoch)
+	CanonicalBlocks []*Block     // canonical blocks of the finalized epoch (IndexerEventFinalizedEpoch)
+}
+
+// IndexerEventHook is called for every indexer event.
+// hooks are called synchronously from the block processing & finalization routines, so they must not block.
+type IndexerEventHook func(event *IndexerEvent)
+
+// eventDispatcher passes the events of the indexing process to the registered hooks.
+type eventDispatcher struct {
+	mutex         sync.RWMutex
+	hooks         []IndexerEventHook
+	lastReorgHead phase0.Root
+	seenHeads     map[phase0.Root]phase0.Slot
+	maxHeadSlot   phase0.Slot
+}
+
+// newEventDispatcher creates & returns a new instance of eventDispatcher.
+func newEventDispatcher() *eventDispatcher {
+	return &eventDispatcher{
+		seenHeads: map[phase0.Root]phase0.Slot{},
+	}
+}
+
+// AddEventHook adds a hook that is called for new blocks, reorgs & finalized epochs.
+func (indexer *Indexer) AddEventHook(hook IndexerEventHook) {
+	if indexer.eventDispatcher == nil {
+		return
+	}
+
+	indexer.eventDispatcher.mutex.Lock()
+	defer indexer.eventDispatcher.mutex.Unlock()
+
+	indexer.eventDispatcher.hooks = append(indexer.eventDispatcher.hooks, hook)
+}
+
+// emitEvent passes an event to the registered hooks.
+// reorgs & head updates are reported by every client that follows the new head, so only the first report of a new head is passed on.
+func (dispatcher *eventDispatcher) emitEvent(event *IndexerEvent) {
+	switch event.Type {
+	case IndexerEventReorg:
+		dispatcher.mutex.Lock()
+		isDuplicate := dispatcher.lastReorgHead == event.Block.Root
+		dispatcher.lastReorgHead = event.Block.Root
+		dispatcher.mutex.Unlock()
+
+		if isDuplicate {
+			return
+		}
+
+	case IndexerEventHead:
+		if !dispatcher.checkNewHead(event.Block) {
+			return
+		}
+	}
+
+	dispatcher.mutex.RLock()
+	defer dispatcher.mutex.RUnlock()
+
+	for _, hook := range dispatcher.hooks {
+		hook(event)
+	}
+}
+
+// checkNewHead checks if a head has not been reported before.
+// clients on different forks may switch between the same heads, so the reported heads of the last 64 slots are remembered.
+func (dispatcher *eventDispatcher) checkNewHead(block *Block) bool {
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+
+	if _, seen := dispatcher.seenHeads[block.Root]; seen {
+		return false
+	}
+
+	dispatcher.seenHeads[block.Root] = block.Slot
+	if block.Slot > dispatcher.maxHeadSlot {
+		dispatcher.maxHeadSlot = block.Slot
+
+		for root, slot := range dispatcher.seenHeads {
+			if slot+64 < dispatcher.maxHeadSlot {
+				delete(dispatcher.seenHeads, root)
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,215 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus"
+)
+
+// fetchLatencyWeight is the weight of a new sample in the exponentially weighted request latency average.
+const fetchLatencyWeight = 0.2
+
+// clientFetchStats holds the request latency & fetch race statistics of a client.
+type clientFetchStats struct {
+	mutex    sync.Mutex
+	latency  time.Duration
+	requests uint64
+	races    uint64
+	wins     uint64
+}
+
+// ClientFetchStats is a snapshot of the request latency & fetch race statistics of a client.
+type ClientFetchStats struct {
+	Latency  time.Duration // exponentially weighted average latency of successful header/body requests
+	Requests uint64        // number of successful header/body requests
+	Races    uint64        // number of fetch races the client participated in
+	Wins     uint64        // number of fetch races won by the client
+}
+
+// WinRate returns the share of fetch races won by the client (0-1).
+func (stats *ClientFetchStats) WinRate() float64 {
+	if stats.Races == 0 {
+		return 0
+	}
+	return float64(stats.Wins) / float64(stats.Races)
+}
+
+// GetFetchStats returns the request latency & fetch race statistics of the client.
+func (c *Client) GetFetchStats() ClientFetchStats {
+	c.fetchStats.mutex.Lock()
+	defer c.fetchStats.mutex.Unlock()
+
+	return ClientFetchStats{
+		Latency:  c.fetchStats.latency,
+		Requests: c.fetchStats.requests,
+		Races:    c.fetchStats.races,
+		Wins:     c.fetchStats.wins,
+	}
+}
+
+// recordFetchLatency adds a successful request latency sample to the clients latency average.
+func (c *Client) recordFetchLatency(latency time.Duration) {
+	c.fetchStats.mutex.Lock()
+	defer c.fetchStats.mutex.Unlock()
+
+	if c.fetchStats.requests == 0 {
+		c.fetchStats.latency = latency
+	} else {
+		c.fetchStats.latency = time.Duration(float64(c.fetchStats.latency)*(1-fetchLatencyWeight) + float64(latency)*fetchLatencyWeight)
+	}
+	c.fetchStats.requests++
+}
+
+// recordFetchRace records the participation (and win) of the client in a fetch race.
+func (c *Client) recordFetchRace(won bool) {
+	c.fetchStats.mutex.Lock()
+	defer c.fetchStats.mutex.Unlock()
+
+	c.fetchStats.races++
+	if won {
+		c.fetchStats.wins++
+	}
+}
+
+// getFetchRaceClients returns the clients to race a time-critical request against.
+// the preferred client is always included, the remaining slots are filled with the online clients with the lowest request latency.
+func (indexer *Indexer) getFetchRaceClients(preferred *Client) []*Client {
+	raceClients := []*Client{preferred}
+	if indexer.fetchRaceClients <= 1 {
+		return raceClients
+	}
+
+	candidates := make([]*Client, 0, len(indexer.clients))
+	candidateLatency := map[*Client]time.Duration{}
+	for _, client := range indexer.clients {
+		if client == preferred || client.client.GetStatus() != consensus.ClientStatusOnline {
+			continue
+		}
+
+		stats := client.GetFetchStats()
+		if stats.Requests == 0 {
+			// no latency samples yet, give the client a chance to participate
+			stats.Latency = 0
+		}
+
+		candidates = append(candidates, client)
+		candidateLatency[client] = stats.Latency
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidateLatency[candidates[i]] != candidateLatency[candidates[j]] {
+			return candidateLatency[candidates[i]] < candidateLatency[candidates[j]]
+		}
+
+		return candidates[i].GetPriority() > candidates[j].GetPriority()
+	})
+
+	for _, client := range candidates {
+		if len(raceClients) >= int(indexer.fetchRaceClients) {
+			break
+		}
+		raceClients = append(raceClients, client)
+	}
+
+	return raceClients
+}
+
+// raceFetch runs the fetch function against all given clients in parallel and returns the first successful result.
+// the remaining requests are cancelled as soon as one client succeeds.
+func raceFetch[T any](clients []*Client, timeout time.Duration, fetch func(ctx context.Context, client *Client) (T, error)) (T, *Client, error) {
+	type raceResult struct {
+		client *Client
+		result T
+		err    error
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resultChan := make(chan *raceResult, len(clients))
+	for _, client := range clients {
+		go func(client *Client) {
+			t1 := time.Now()
+			clientCtx, clientCancel := context.WithCancel(ctx)
+			defer clientCancel()
+
+			// stop the request when the client shuts down
+			go func() {
+				select {
+				case <-client.getContext().Done():
+					clientCancel()
+				case <-clientCtx.Done():
+				}
+			}()
+
+			result, err := fetch(clientCtx, client)
+			if err == nil {
+				client.recordFetchLatency(time.Since(t1))
+			}
+
+			resultChan <- &raceResult{
+				client: client,
+				result: result,
+				err:    err,
+			}
+		}(client)
+	}
+
+	var winner *raceResult
+	var lastErr error
+	for range clients {
+		result := <-resultChan
+		if result.err != nil {
+			lastErr = result.err
+			continue
+		}
+
+		winner = result
+		break
+	}
+
+	if len(clients) > 1 {
+		for _, client := range clients {
+			client.recordFetchRace(winner != nil && winner.client == client)
+		}
+	}
+
+	if winner == nil {
+		var empty T
+		return empty, nil, lastErr
+	}
+
+	return winner.result, winner.client, nil
+}
+
+// loadHeaderRace loads a block header by racing the request against the preferred and the fastest other clients.
+func (indexer *Indexer) loadHeaderRace(preferred *Client, root phase0.Root) (*phase0.SignedBeaconBlockHeader, error) {
+	header, _, err := raceFetch(indexer.getFetchRaceClients(preferred), beaconHeaderRequestTimeout, func(ctx context.Context, client *Client) (*phase0.SignedBeaconBlockHeader, error) {
+		header, err := LoadBeaconHeader(ctx, client, root)
+		if err == nil && header == nil {
+			err = fmt.Errorf("header %v not found on %v", root.String(), client.client.GetName())
+		}
+		return header, err
+	})
+
+	return header, err
+}
+
+// loadBlockRace loads a block body by racing the request against the preferred and the fastest other clients.
+func (indexer *Indexer) loadBlockRace(preferred *Client, root phase0.Root) (*spec.VersionedSignedBeaconBlock, error) {
+	block, _, err := raceFetch(indexer.getFetchRaceClients(preferred), beaconBodyRequestTimeout, func(ctx context.Context, client *Client) (*spec.VersionedSignedBeaconBlock, error) {
+		block, err := LoadBeaconBlock(ctx, client, root)
+		if err == nil && block == nil {
+			err = fmt.Errorf("block %v not found on %v", root.String(), client.client.GetName())
+		}
+		return block, err
+	})
+
+	return block, err
+}
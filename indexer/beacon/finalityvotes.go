@@ -0,0 +1,204 @@
+package beacon
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// finalityVoteTracker records which active validators had a correct target vote included in the canonical chain for each finalized epoch.
+// the target votes are the votes counted towards justification, so the map allows to analyse delayed justification incidents per validator.
+type finalityVoteTracker struct {
+	indexer *Indexer
+	mutex   sync.Mutex
+}
+
+// FinalityVoteMap is the target vote map of a finalized epoch.
+// the bitfields are indexed by validator index (bit i%8 of byte i/8).
+type FinalityVoteMap struct {
+	Epoch         phase0.Epoch
+	TargetRoot    phase0.Root
+	ActiveCount   uint64
+	VotedCount    uint64
+	ActiveBalance phase0.Gwei
+	VotedBalance  phase0.Gwei
+	ActiveBits    []byte
+	VotedBits     []byte
+}
+
+// newFinalityVoteTracker creates & returns a new instance of finalityVoteTracker.
+func newFinalityVoteTracker(indexer *Indexer) *finalityVoteTracker {
+	return &finalityVoteTracker{
+		indexer: indexer,
+	}
+}
+
+// processEpoch builds the target vote map of the finalized epoch and persists it.
+// blocks contains the canonical blocks of the epoch and the next epoch, as votes can be included until the end of the next epoch.
+func (tracker *finalityVoteTracker) processEpoch(epoch phase0.Epoch, epochStatsValues *EpochStatsValues, blocks []*Block) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if len(blocks) == 0 || len(epochStatsValues.AttesterDuties) == 0 {
+		return
+	}
+
+	chainState := tracker.indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	// the target is the epoch boundary block: the block at the first slot of the epoch or the latest block before
+	var targetRoot phase0.Root
+	if blocks[0].Slot == chainState.EpochToSlot(epoch) {
+		targetRoot = blocks[0].Root
+	} else if parentRoot := blocks[0].GetParentRoot(); parentRoot != nil {
+		targetRoot = *parentRoot
+	} else {
+		return
+	}
+
+	votedActiveBits := bitfield.NewBitlist(uint64(len(epochStatsValues.ActiveIndices)))
+	for _, block := range blocks {
+		blockBody := block.GetBlock()
+		if blockBody == nil {
+			continue
+		}
+
+		attestations, err := blockBody.Attestations()
+		if err != nil {
+			continue
+		}
+
+		for _, attVersioned := range attestations {
+			attData, err := attVersioned.Data()
+			if err != nil || chainState.EpochOfSlot(attData.Slot) != epoch || !bytes.Equal(attData.Target.Root[:], targetRoot[:]) {
+				continue
+			}
+
+			aggregationBits, err := attVersioned.AggregationBits()
+			if err != nil {
+				continue
+			}
+
+			slotIndex := chainState.SlotToSlotIndex(attData.Slot)
+			if attVersioned.Version >= spec.DataVersionElectra {
+				// EIP-7549: the aggregation bits of all included committees are concatenated
+				committeeBits, err := attVersioned.CommitteeBits()
+				if err != nil {
+					continue
+				}
+
+				aggregationBitsOffset := uint64(0)
+				for _, committee := range committeeBits.BitIndices() {
+					if uint64(committee) >= specs.MaxCommitteesPerSlot {
+						continue
+					}
+					aggregationBitsOffset += tracker.setVotedBits(epochStatsValues, slotIndex, uint64(committee), aggregationBits, aggregationBitsOffset, votedActiveBits)
+				}
+			} else {
+				tracker.setVotedBits(epochStatsValues, slotIndex, uint64(attData.Index), aggregationBits, 0, votedActiveBits)
+			}
+		}
+	}
+
+	// convert the bits indexed by active indice to bitfields indexed by validator index
+	validatorCount := uint64(0)
+	for _, validatorIndex := range epochStatsValues.ActiveIndices {
+		if uint64(validatorIndex) >= validatorCount {
+			validatorCount = uint64(validatorIndex) + 1
+		}
+	}
+
+	voteMap := &dbtypes.EpochFinalityVotes{
+		Epoch:         uint64(epoch),
+		TargetRoot:    targetRoot[:],
+		ActiveCount:   uint64(len(epochStatsValues.ActiveIndices)),
+		ActiveBalance: uint64(epochStatsValues.EffectiveBalance),
+	}
+	activeValidatorBits := make([]byte, (validatorCount+7)/8)
+	votedValidatorBits := make([]byte, (validatorCount+7)/8)
+	for activeIdx, validatorIndex := range epochStatsValues.ActiveIndices {
+		activeValidatorBits[validatorIndex/8] |= 1 << (validatorIndex % 8)
+		if votedActiveBits.BitAt(uint64(activeIdx)) {
+			votedValidatorBits[validatorIndex/8] |= 1 << (validatorIndex % 8)
+			voteMap.VotedCount++
+			voteMap.VotedBalance += uint64(epochStatsValues.EffectiveBalances[activeIdx]) * uint64(EtherGweiFactor)
+		}
+	}
+	voteMap.ActiveBits = compressBytes(activeValidatorBits)
+	voteMap.VotedBits = compressBytes(votedValidatorBits)
+
+	_, err := tracker.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertEpochFinalityVotes(voteMap, tx)
+	})
+	if err != nil {
+		tracker.indexer.logger.Errorf("failed persisting finality votes for epoch %v: %v", epoch, err)
+	}
+}
+
+// setVotedBits marks the validators of a committee that are set in the aggregation bits as voted and returns the committee size.
+func (tracker *finalityVoteTracker) setVotedBits(epochStatsValues *EpochStatsValues, slotIndex phase0.Slot, committee uint64, aggregationBits bitfield.Bitfield, aggregationBitsOffset uint64, votedBits bitfield.Bitlist) uint64 {
+	if uint64(slotIndex) >= uint64(len(epochStatsValues.AttesterDuties)) || committee >= uint64(len(epochStatsValues.AttesterDuties[slotIndex])) {
+		return 0
+	}
+
+	voteDuties := epochStatsValues.AttesterDuties[slotIndex][committee]
+	for bitIdx, activeIdx := range voteDuties {
+		if aggregationBits.BitAt(uint64(bitIdx) + aggregationBitsOffset) {
+			votedBits.SetBitAt(uint64(activeIdx), true)
+		}
+	}
+
+	return uint64(len(voteDuties))
+}
+
+// GetFinalityVoteMap returns the recorded target vote map of a finalized epoch or nil if not recorded.
+func (indexer *Indexer) GetFinalityVoteMap(epoch phase0.Epoch) (*FinalityVoteMap, error) {
+	dbVotes, err := db.GetEpochFinalityVotes(uint64(epoch))
+	if err != nil || dbVotes == nil {
+		return nil, err
+	}
+
+	voteMap := &FinalityVoteMap{
+		Epoch:         epoch,
+		TargetRoot:    phase0.Root(dbVotes.TargetRoot),
+		ActiveCount:   dbVotes.ActiveCount,
+		VotedCount:    dbVotes.VotedCount,
+		ActiveBalance: phase0.Gwei(dbVotes.ActiveBalance),
+		VotedBalance:  phase0.Gwei(dbVotes.VotedBalance),
+	}
+
+	voteMap.ActiveBits, err = decompressBytes(dbVotes.ActiveBits)
+	if err != nil {
+		return nil, fmt.Errorf("failed decompressing active bits: %v", err)
+	}
+	voteMap.VotedBits, err = decompressBytes(dbVotes.VotedBits)
+	if err != nil {
+		return nil, fmt.Errorf("failed decompressing voted bits: %v", err)
+	}
+
+	return voteMap, nil
+}
+
+// IsActive returns true if the validator has been active in the epoch
+func (voteMap *FinalityVoteMap) IsActive(validatorIndex phase0.ValidatorIndex) bool {
+	return getValidatorBit(voteMap.ActiveBits, validatorIndex)
+}
+
+// HasVoted returns true if a correct target vote of the validator has been included in the canonical chain
+func (voteMap *FinalityVoteMap) HasVoted(validatorIndex phase0.ValidatorIndex) bool {
+	return getValidatorBit(voteMap.VotedBits, validatorIndex)
+}
+
+func getValidatorBit(bits []byte, validatorIndex phase0.ValidatorIndex) bool {
+	if uint64(validatorIndex/8) >= uint64(len(bits)) {
+		return false
+	}
+	return bits[validatorIndex/8]&(1<<(validatorIndex%8)) != 0
+}
@@ -101,8 +101,7 @@ func (indexer *Indexer) processFinalityEvent(finalityEvent *v1.Finality) error {
 	if startSynchronizer {
 		indexer.startSynchronizer(synchronizeFromEpoch)
 	} else if !indexer.synchronizer.running && indexer.synchronizer.currentEpoch >= oldLastFinalizedEpoch && indexer.lastFinalizedEpoch > oldLastFinalizedEpoch {
-		indexer.synchronizer.currentEpoch = indexer.lastFinalizedEpoch
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		persisted, err := indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 			return db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
 				Epoch: uint64(indexer.lastFinalizedEpoch),
 			}, tx)
@@ -110,6 +109,10 @@ func (indexer *Indexer) processFinalityEvent(finalityEvent *v1.Finality) error {
 		if err != nil {
 			indexer.logger.WithError(err).Errorf("failed updating sync state")
 		}
+		if persisted {
+			// read-only indexers keep the sync state from the db, it is reloaded on promotion
+			indexer.synchronizer.currentEpoch = indexer.lastFinalizedEpoch
+		}
 	}
 
 	return nil
@@ -301,8 +304,13 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 	t1 = time.Now()
 
 	// persist to db
+	// all writes are upserts, so a partially written epoch (eg. from a previous failed attempt) is overwritten & reconciled
+	if db.IsEpochSynchronized(uint64(epoch)) {
+		indexer.logger.Infof("epoch %v has already been written, rewriting", epoch)
+	}
 	deleteBeforeSlot := chainState.EpochToSlot(epoch + 1)
-	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	deletedPayloadRoots := [][]byte{}
+	_, err := indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 		// persist canonical epoch data
 		if err := indexer.dbWriter.persistEpochData(tx, epoch, canonicalBlocks, epochStats, epochVotes, nil); err != nil {
 			return fmt.Errorf("failed persisting epoch data for epoch %v: %v", epoch, err)
@@ -337,6 +345,21 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 			}
 		}
 
+		// persist root cause analysis of missed slots
+		if err := indexer.dbWriter.persistMissedSlotAnalysis(tx, epoch, canonicalBlocks, epochBlocks, epochStats); err != nil {
+			return fmt.Errorf("failed persisting missed slot analysis for epoch %v: %v", epoch, err)
+		}
+
+		// persist block arrival delays
+		if err := indexer.dbWriter.persistBlockArrivals(tx, canonicalBlocks); err != nil {
+			return fmt.Errorf("failed persisting block arrivals for epoch %v: %v", epoch, err)
+		}
+
+		// persist non-valid execution payload verdicts
+		if err := indexer.dbWriter.persistBlockPayloadStatuses(tx, append(canonicalBlocks, orphanedBlocks...)); err != nil {
+			return fmt.Errorf("failed persisting block payload statuses for epoch %v: %v", epoch, err)
+		}
+
 		// persist sync committee assignments
 		if err := indexer.dbWriter.persistSyncAssignments(tx, epoch, epochStats); err != nil {
 			return fmt.Errorf("error persisting sync committee assignments to db: %v", err)
@@ -352,9 +375,11 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 		}
 
 		// delete unfinalized blocks before epoch
-		if err := db.DeleteUnfinalizedBlocksBefore(uint64(deleteBeforeSlot), tx); err != nil {
+		payloadRoots, err := db.DeleteUnfinalizedBlocksBefore(uint64(deleteBeforeSlot), tx)
+		if err != nil {
 			return fmt.Errorf("failed deleting unfinalized duties < slot %v: %v", deleteBeforeSlot, err)
 		}
+		deletedPayloadRoots = payloadRoots
 
 		// delete unfinalized epoch aggregations in epoch
 		if err := db.DeleteUnfinalizedEpochsBefore(uint64(epoch+1), tx); err != nil {
@@ -377,6 +402,9 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 		return false, fmt.Errorf("failed persisting epoch %v data: %v", epoch, err)
 	}
 
+	// the external payloads of the deleted unfinalized blocks can only be dropped once the deletion is committed
+	db.DeleteUnfinalizedBlockPayloads(deletedPayloadRoots)
+
 	t2dur := time.Since(t1)
 
 	indexer.lastFinalizedEpoch = epoch + 1
@@ -391,6 +419,65 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 		indexer.validatorCache.setFinalizedEpoch(epoch, canonicalBlocks[len(canonicalBlocks)-1].Root)
 	}
 
+	// record effective balance changes & distribution
+	if indexer.effectiveBalances != nil {
+		indexer.effectiveBalances.processEpoch(epoch)
+	}
+
+	// track validator effectiveness
+	if epochStatsValues != nil && indexer.effectivenessTracker != nil {
+		effectivenessBlocks := make([]*Block, len(canonicalBlocks)+len(nextEpochCanonicalBlocks))
+		copy(effectivenessBlocks, canonicalBlocks)
+		copy(effectivenessBlocks[len(canonicalBlocks):], nextEpochCanonicalBlocks)
+		indexer.effectivenessTracker.processEpoch(epoch, epochStatsValues, effectivenessBlocks)
+	}
+
+	// track missed duty incidents
+	if epochStatsValues != nil && indexer.incidentTracker != nil {
+		incidentBlocks := make([]*Block, len(canonicalBlocks)+len(nextEpochCanonicalBlocks))
+		copy(incidentBlocks, canonicalBlocks)
+		copy(incidentBlocks[len(canonicalBlocks):], nextEpochCanonicalBlocks)
+		indexer.incidentTracker.processEpoch(epoch, epochStatsValues, incidentBlocks)
+	}
+
+	// attribute missed & late attestations to late blocks or offline attesters
+	if epochStatsValues != nil && indexer.attestationMisses != nil {
+		attestationBlocks := make([]*Block, len(canonicalBlocks)+len(nextEpochCanonicalBlocks))
+		copy(attestationBlocks, canonicalBlocks)
+		copy(attestationBlocks[len(canonicalBlocks):], nextEpochCanonicalBlocks)
+		indexer.attestationMisses.processEpoch(epoch, epochStatsValues, attestationBlocks)
+	}
+
+	// record the target vote map for finality analysis
+	if epochStatsValues != nil && indexer.finalityVotes != nil {
+		finalityVoteBlocks := make([]*Block, len(canonicalBlocks)+len(nextEpochCanonicalBlocks))
+		copy(finalityVoteBlocks, canonicalBlocks)
+		copy(finalityVoteBlocks[len(canonicalBlocks):], nextEpochCanonicalBlocks)
+		indexer.finalityVotes.processEpoch(epoch, epochStatsValues, finalityVoteBlocks)
+	}
+
+	// report missed duties of watchlisted validators
+	if epochStatsValues != nil && indexer.missedDutyTracker != nil {
+		missedDutyBlocks := make([]*Block, len(canonicalBlocks)+len(nextEpochCanonicalBlocks))
+		copy(missedDutyBlocks, canonicalBlocks)
+		copy(missedDutyBlocks[len(canonicalBlocks):], nextEpochCanonicalBlocks)
+		indexer.missedDutyTracker.processEpoch(epoch, epochStatsValues, missedDutyBlocks)
+	}
+
+	// detect anomalies of watchlisted validators
+	if indexer.anomalyTracker != nil {
+		indexer.anomalyTracker.processEpoch(epoch, canonicalBlocks)
+	}
+
+	// export finalized epoch
+	if indexer.eventDispatcher != nil {
+		indexer.eventDispatcher.emitEvent(&IndexerEvent{
+			Type:            IndexerEventFinalizedEpoch,
+			Epoch:           epoch,
+			CanonicalBlocks: canonicalBlocks,
+		})
+	}
+
 	// clean fork cache
 	indexer.forkCache.setFinalizedEpoch(deleteBeforeSlot, justifiedRoot)
 	for _, fork := range indexer.forkCache.getForksBefore(deleteBeforeSlot) {
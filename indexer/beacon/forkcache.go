@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/common/lru"
@@ -14,6 +15,13 @@ import (
 )
 
 // forkCache is a struct that represents the fork cache in the indexer.
+//
+// lock hierarchy of the indexer caches (locks must only be acquired in this order to avoid deadlocks):
+//  1. forkCache.forkProcessLock - serializes fork detection & finalization (protects lastForkId & fork parent updates)
+//  2. forkCache.cacheMutex      - protects forkMap & finalizedForkId
+//  3. blockCache.cacheMutex     - protects the block maps, never calls into other caches while held
+//
+// epochCache.cacheMutex is independent and must not be held while calling into the fork or block cache.
 type forkCache struct {
 	indexer            *Indexer
 	cacheMutex         sync.RWMutex
@@ -21,11 +29,11 @@ type forkCache struct {
 	finalizedForkId    ForkKey
 	lastForkId         ForkKey
 	parentIdCache      *lru.Cache[ForkKey, ForkKey]
-	parentIdCacheHit   uint64
-	parentIdCacheMiss  uint64
+	parentIdCacheHit   atomic.Uint64
+	parentIdCacheMiss  atomic.Uint64
 	parentIdsCache     *lru.Cache[ForkKey, []ForkKey]
-	parentIdsCacheHit  uint64
-	parentIdsCacheMiss uint64
+	parentIdsCacheHit  atomic.Uint64
+	parentIdsCacheMiss atomic.Uint64
 	forkProcessLock    sync.Mutex
 }
 
@@ -51,8 +59,14 @@ func (cache *forkCache) loadForkState() error {
 		forkState.Finalized = 1
 	}
 
+	// frontends reload the fork state periodically, so hold the locks of both fields
+	cache.forkProcessLock.Lock()
+	defer cache.forkProcessLock.Unlock()
+
+	cache.cacheMutex.Lock()
 	cache.lastForkId = ForkKey(forkState.ForkId)
 	cache.finalizedForkId = ForkKey(forkState.Finalized)
+	cache.cacheMutex.Unlock()
 
 	return nil
 }
@@ -87,8 +101,8 @@ func (cache *forkCache) addFork(fork *Fork) {
 
 // getForkByLeaf retrieves a fork from the cache by its leaf root.
 func (cache *forkCache) getForkByLeaf(leafRoot phase0.Root) *Fork {
-	cache.cacheMutex.Lock()
-	defer cache.cacheMutex.Unlock()
+	cache.cacheMutex.RLock()
+	defer cache.cacheMutex.RUnlock()
 
 	for _, fork := range cache.forkMap {
 		if bytes.Equal(fork.leafRoot[:], leafRoot[:]) {
@@ -101,8 +115,8 @@ func (cache *forkCache) getForkByLeaf(leafRoot phase0.Root) *Fork {
 
 // getForkByBase retrieves forks from the cache by their base root.
 func (cache *forkCache) getForkByBase(baseRoot phase0.Root) []*Fork {
-	cache.cacheMutex.Lock()
-	defer cache.cacheMutex.Unlock()
+	cache.cacheMutex.RLock()
+	defer cache.cacheMutex.RUnlock()
 
 	forks := []*Fork{}
 	for _, fork := range cache.forkMap {
@@ -126,7 +140,7 @@ func (cache *forkCache) removeFork(forkId ForkKey) {
 func (cache *forkCache) getParentForkIds(forkId ForkKey) []ForkKey {
 	parentForks, isCached := cache.parentIdsCache.Get(forkId)
 	if isCached {
-		cache.parentIdsCacheHit++
+		cache.parentIdsCacheHit.Add(1)
 		return parentForks
 	}
 
@@ -135,25 +149,25 @@ func (cache *forkCache) getParentForkIds(forkId ForkKey) []ForkKey {
 
 	for parentForkId > 1 {
 		if cachedParent, isCached := cache.parentIdCache.Get(parentForkId); isCached {
-			cache.parentIdCacheHit++
+			cache.parentIdCacheHit.Add(1)
 			parentForkId = cachedParent
 		} else if parentFork := cache.getForkById(parentForkId); parentFork != nil {
 			parentForkId = parentFork.parentFork
 		} else if dbFork := db.GetForkById(uint64(parentForkId)); dbFork != nil {
 			cache.parentIdCache.Add(ForkKey(parentForkId), ForkKey(dbFork.ParentFork))
 			parentForkId = ForkKey(dbFork.ParentFork)
-			cache.parentIdCacheMiss++
+			cache.parentIdCacheMiss.Add(1)
 		} else {
 			cache.parentIdCache.Add(ForkKey(parentForkId), ForkKey(0))
 			parentForkId = 0
-			cache.parentIdCacheMiss++
+			cache.parentIdCacheMiss.Add(1)
 		}
 
 		parentForks = append(parentForks, parentForkId)
 	}
 
 	cache.parentIdsCache.Add(forkId, parentForks)
-	cache.parentIdsCacheMiss++
+	cache.parentIdsCacheMiss.Add(1)
 
 	return parentForks
 }
@@ -205,6 +219,19 @@ func (cache *forkCache) getForkHeads() []*ForkHead {
 	return forkHeads
 }
 
+// getForks retrieves all forks in the cache.
+func (cache *forkCache) getForks() []*Fork {
+	cache.cacheMutex.RLock()
+	defer cache.cacheMutex.RUnlock()
+
+	forks := make([]*Fork, 0, len(cache.forkMap))
+	for _, fork := range cache.forkMap {
+		forks = append(forks, fork)
+	}
+
+	return forks
+}
+
 // getForksBefore retrieves all forks that happened before the given slot.
 func (cache *forkCache) getForksBefore(slot phase0.Slot) []*Fork {
 	cache.cacheMutex.RLock()
@@ -223,8 +250,11 @@ func (cache *forkCache) getForksBefore(slot phase0.Slot) []*Fork {
 // setFinalizedEpoch sets the finalized epoch in the fork cache.
 // It removes all forks that happened before the finalized epoch and updates the finalized fork ID.
 func (cache *forkCache) setFinalizedEpoch(finalizedSlot phase0.Slot, justifiedRoot phase0.Root) {
+	// hold the process lock, so the fork state is not modified by a concurrent fork detection
+	cache.forkProcessLock.Lock()
+	defer cache.forkProcessLock.Unlock()
+
 	cache.cacheMutex.Lock()
-	defer cache.cacheMutex.Unlock()
 
 	for _, fork := range cache.forkMap {
 		if fork.leafSlot >= finalizedSlot {
@@ -260,7 +290,10 @@ func (cache *forkCache) setFinalizedEpoch(finalizedSlot phase0.Slot, justifiedRo
 	cache.finalizedForkId = finalizedForkId
 	cache.parentIdsCache.Purge()
 
-	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	// release the cache lock before writing to the db, readers should not wait for the db transaction
+	cache.cacheMutex.Unlock()
+
+	_, err := cache.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 		return cache.updateForkState(tx)
 	})
 	if err != nil {
@@ -70,7 +70,10 @@ func (cache *forkCache) processBlock(block *Block) error {
 		parentSlot = 0
 		parentIsProcessed = false
 		parentIsFinalized = true
+
+		cache.cacheMutex.Lock()
 		cache.finalizedForkId = parentForkId
+		cache.cacheMutex.Unlock()
 	}
 
 	// check if this block (c) introduces a new fork, it does so if:
@@ -252,7 +255,7 @@ func (cache *forkCache) processBlock(block *Block) error {
 		// purge parent ids cache as the fork id tree has changed
 		cache.parentIdsCache.Purge()
 
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		_, err := cache.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 			// helper function to update unfinalized block fork ids in batches
 			updateUnfinalizedBlockForkIds := func(updateRoots [][]byte, forkId ForkKey) error {
 				batchSize := 1000
@@ -271,6 +274,11 @@ func (cache *forkCache) processBlock(block *Block) error {
 					if err != nil {
 						return err
 					}
+
+					err = db.UpdateExecutionBlockSlotForkId(batchRoots, uint64(forkId), tx)
+					if err != nil {
+						return err
+					}
 				}
 
 				return nil
@@ -0,0 +1,271 @@
+package beacon
+
+import (
+	"math"
+	"runtime/debug"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// frontendRefreshState tracks which parts of the writer's unfinalized state have already been mirrored into the caches.
+// it's only accessed by the frontend loop.
+type frontendRefreshState struct {
+	forkState      dbtypes.IndexerForkState
+	pruneState     dbtypes.IndexerPruneState
+	finalizedEpoch phase0.Epoch
+	fullEpoch      phase0.Epoch // epoch of the last full refresh
+	hasFullRefresh bool
+	lastSlot       phase0.Slot  // highest slot of the loaded blocks
+	lastEpoch      phase0.Epoch // highest epoch of the loaded duties
+}
+
+// runFrontendLoop keeps the caches of a frontend-only indexer in sync with the unfinalized state persisted by the writer instance.
+// frontends don't process blocks or load states from the clients, so all cached blocks, forks & epoch stats are loaded from the db.
+func (indexer *Indexer) runFrontendLoop() {
+	defer func() {
+		if err := recover(); err != nil {
+			indexer.logger.Errorf("uncaught panic in indexer.beacon.Indexer.runFrontendLoop subroutine: %v, stack: %v", err, string(debug.Stack()))
+			time.Sleep(10 * time.Second)
+
+			go indexer.runFrontendLoop()
+		}
+	}()
+
+	// refresh 3 times per slot, the writer persists new blocks right after processing them
+	refreshInterval := indexer.consensusPool.GetChainState().GetSpecs().SecondsPerSlot / 3
+	if refreshInterval < time.Second {
+		refreshInterval = time.Second
+	}
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	refreshState := &frontendRefreshState{}
+	for range ticker.C {
+		indexer.refreshFrontendCache(refreshState)
+	}
+}
+
+// refreshFrontendCache loads the forks, epoch stats & blocks added by the writer since the last refresh.
+// fork ids & processing states of the cached blocks are only compared and removed entries only dropped on a full refresh,
+// which is done when the writer's fork, prune or finality state changed and once per epoch.
+func (indexer *Indexer) refreshFrontendCache(refreshState *frontendRefreshState) {
+	t1 := time.Now()
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	forkState := dbtypes.IndexerForkState{}
+	if _, err := db.GetExplorerState("indexer.forkstate", &forkState); err != nil {
+		indexer.logger.WithError(err).Debugf("failed loading fork state")
+	}
+	pruneState := dbtypes.IndexerPruneState{}
+	if _, err := db.GetExplorerState("indexer.prunestate", &pruneState); err != nil {
+		indexer.logger.WithError(err).Debugf("failed loading prune state")
+	}
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+	currentEpoch := chainState.CurrentEpoch()
+
+	fullRefresh := !refreshState.hasFullRefresh ||
+		forkState != refreshState.forkState ||
+		pruneState != refreshState.pruneState ||
+		finalizedEpoch != refreshState.finalizedEpoch ||
+		currentEpoch != refreshState.fullEpoch
+
+	// forks
+	forksChanged := false
+	if fullRefresh {
+		if err := indexer.forkCache.loadForkState(); err != nil {
+			indexer.logger.WithError(err).Errorf("failed loading fork state")
+		}
+
+		dbForks := db.GetUnfinalizedForks(0)
+		if dbForks == nil {
+			return
+		}
+
+		dbForkIds := make(map[ForkKey]bool, len(dbForks))
+		for _, dbFork := range dbForks {
+			forkId := ForkKey(dbFork.ForkId)
+			dbForkIds[forkId] = true
+
+			if indexer.forkCache.getForkById(forkId) == nil {
+				indexer.forkCache.addFork(newForkFromDb(dbFork))
+				forksChanged = true
+			}
+		}
+
+		for _, fork := range indexer.forkCache.getForks() {
+			if !dbForkIds[fork.forkId] {
+				indexer.forkCache.removeFork(fork.forkId)
+				forksChanged = true
+			}
+		}
+
+		if forksChanged {
+			indexer.forkCache.parentIdsCache.Purge()
+		}
+
+		indexer.lastPrunedEpoch = phase0.Epoch(pruneState.Epoch)
+	}
+
+	// epoch stats
+	minDutyEpoch := refreshState.lastEpoch
+	if fullRefresh {
+		minDutyEpoch = 0
+	}
+
+	dbDutyKeys := db.GetUnfinalizedDutyKeys(uint64(minDutyEpoch))
+	if dbDutyKeys == nil {
+		return
+	}
+
+	lastEpoch := refreshState.lastEpoch
+	dbStatsKeys := make(map[epochStatsKey]bool, len(dbDutyKeys))
+	addedStats := 0
+	for _, dbDutyKey := range dbDutyKeys {
+		epoch := phase0.Epoch(dbDutyKey.Epoch)
+		dependentRoot := phase0.Root(dbDutyKey.DependentRoot)
+		dbStatsKeys[getEpochStatsKey(epoch, dependentRoot)] = true
+
+		if epoch > lastEpoch {
+			lastEpoch = epoch
+		}
+
+		if epochStats := indexer.epochCache.getEpochStats(epoch, dependentRoot); epochStats != nil && epochStats.ready {
+			continue
+		}
+
+		dbDuty := db.GetUnfinalizedDuty(dbDutyKey.Epoch, dbDutyKey.DependentRoot)
+		if dbDuty == nil {
+			continue
+		}
+
+		if err := indexer.restoreEpochStatsFromDb(dbDuty); err != nil {
+			indexer.logger.WithError(err).Errorf("failed loading epoch stats for epoch %v (%x) from db", dbDuty.Epoch, dbDuty.DependentRoot)
+			continue
+		}
+
+		addedStats++
+	}
+
+	removedStats := 0
+	if fullRefresh {
+		for _, epochStats := range indexer.epochCache.getEpochStatsBeforeEpoch(FarFutureEpoch) {
+			if !dbStatsKeys[getEpochStatsKey(epochStats.epoch, epochStats.dependentRoot)] {
+				indexer.epochCache.removeEpochStats(epochStats)
+				removedStats++
+			}
+		}
+	}
+
+	// blocks (headers only, the bodies are only loaded for new & updated blocks)
+	// blocks might be persisted a bit late (eg. late orphaned blocks), so recheck the last epoch on incremental refreshes
+	blockFilter := &dbtypes.UnfinalizedBlockFilter{}
+	if !fullRefresh && refreshState.lastSlot > phase0.Slot(specs.SlotsPerEpoch) {
+		blockFilter.MinSlot = uint64(refreshState.lastSlot) - specs.SlotsPerEpoch
+	}
+
+	dbBlockHeaders := db.GetUnfinalizedBlocks(blockFilter)
+	if dbBlockHeaders == nil {
+		return
+	}
+
+	lastSlot := refreshState.lastSlot
+	dbBlockRoots := make(map[phase0.Root]bool, len(dbBlockHeaders))
+	addedBlocks := 0
+	updatedBlocks := 0
+	for _, dbBlockHeader := range dbBlockHeaders {
+		blockRoot := phase0.Root(dbBlockHeader.Root)
+		dbBlockRoots[blockRoot] = true
+
+		if slot := phase0.Slot(dbBlockHeader.Slot); slot > lastSlot {
+			lastSlot = slot
+		}
+
+		if block := indexer.blockCache.getBlockByRoot(blockRoot); block != nil {
+			// the writer updates fork ids & processing status of unfinalized blocks.
+			// cached blocks are read without locks, so changed blocks are replaced with a new instance instead of updating them in place.
+			if block.forkId != ForkKey(dbBlockHeader.ForkId) || block.processingStatus != dbBlockHeader.Status {
+				if indexer.replaceFrontendBlock(block) {
+					updatedBlocks++
+				}
+			} else if forksChanged {
+				indexer.updateForkHead(block)
+			}
+			continue
+		}
+
+		dbBlock := db.GetUnfinalizedBlock(dbBlockHeader.Root)
+		if dbBlock == nil {
+			continue
+		}
+
+		if block, _ := indexer.restoreBlockFromDb(dbBlock); block != nil {
+			addedBlocks++
+		}
+	}
+
+	removedBlocks := 0
+	if fullRefresh {
+		for _, block := range indexer.blockCache.getCleanupBlocks(phase0.Slot(math.MaxUint64)) {
+			if !dbBlockRoots[block.Root] {
+				indexer.blockCache.removeBlock(block)
+				removedBlocks++
+			}
+		}
+	}
+
+	if removedBlocks > 0 {
+		// blocks are removed from the unfinalized table by the writer's finalization, reload the finalized validator set
+		indexer.lastFinalizedEpoch = finalizedEpoch
+
+		if _, err := indexer.validatorCache.prepopulateFromDB(); err != nil {
+			indexer.logger.WithError(err).Errorf("failed reloading validator set")
+		}
+	}
+
+	refreshState.lastSlot = lastSlot
+	refreshState.lastEpoch = lastEpoch
+	if fullRefresh {
+		refreshState.forkState = forkState
+		refreshState.pruneState = pruneState
+		refreshState.finalizedEpoch = finalizedEpoch
+		refreshState.fullEpoch = currentEpoch
+		refreshState.hasFullRefresh = true
+	}
+
+	if addedStats+removedStats+addedBlocks+updatedBlocks+removedBlocks > 0 {
+		indexer.logger.Debugf("refreshed frontend cache (full: %v): %v/%v epoch stats, %v/%v/%v blocks added/updated/removed (%v ms)", fullRefresh, addedStats, removedStats, addedBlocks, updatedBlocks, removedBlocks, time.Since(t1).Milliseconds())
+	}
+}
+
+// replaceFrontendBlock reloads a cached block from the db and swaps it into the block cache & fork heads.
+func (indexer *Indexer) replaceFrontendBlock(block *Block) bool {
+	dbBlock := db.GetUnfinalizedBlock(block.Root[:])
+	if dbBlock == nil {
+		return false
+	}
+
+	newBlock := newBlock(indexer.dynSsz, block.Root, block.Slot)
+	if _, err := indexer.populateBlockFromDb(newBlock, dbBlock); err != nil {
+		indexer.logger.Warnf("%v", err)
+		return false
+	}
+
+	indexer.blockCache.replaceBlock(block, newBlock)
+
+	for _, fork := range indexer.forkCache.getForks() {
+		if fork.headBlock == block {
+			fork.headBlock = nil
+			if fork.forkId == newBlock.forkId {
+				fork.headBlock = newBlock
+			}
+		}
+	}
+	indexer.updateForkHead(newBlock)
+
+	return true
+}
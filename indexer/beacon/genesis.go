@@ -0,0 +1,136 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const genesisValidatorBatchSize = 1000
+
+// runGenesisValidatorIndexer loads the genesis validator set once and applies the configured entity attributions.
+// genesis validators have no deposit transactions, so this is the only source of their provenance.
+func (indexer *Indexer) runGenesisValidatorIndexer() {
+	defer func() {
+		if err := recover(); err != nil {
+			indexer.logger.WithError(err.(error)).Errorf("uncaught panic in indexer.beacon.Indexer.runGenesisValidatorIndexer subroutine: %v, stack: %v", err, string(debug.Stack()))
+		}
+	}()
+
+	genesisState := dbtypes.GenesisValidatorsState{}
+	db.GetExplorerState("indexer.genesisvalidators", &genesisState)
+
+	for retry := 0; !genesisState.Loaded; retry++ {
+		count, err := indexer.loadGenesisValidators()
+		if err == nil {
+			genesisState.Loaded = true
+			genesisState.Validators = count
+			indexer.logger.Infof("indexed %v genesis validators", count)
+			break
+		}
+
+		indexer.logger.Warnf("failed loading genesis validators: %v", err)
+		if retry >= 10 {
+			return
+		}
+		time.Sleep(time.Duration(retry+1) * 30 * time.Second)
+	}
+
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		if err := indexer.applyGenesisEntities(tx); err != nil {
+			return err
+		}
+
+		return db.SetExplorerState("indexer.genesisvalidators", &genesisState, tx)
+	})
+	if err != nil {
+		indexer.logger.Errorf("failed applying genesis entities: %v", err)
+	}
+}
+
+// loadGenesisValidators fetches the genesis state and persists the genesis validator set.
+func (indexer *Indexer) loadGenesisValidators() (uint64, error) {
+	client := indexer.GetReadyClient(true)
+	if client == nil {
+		return 0, fmt.Errorf("no ready client")
+	}
+
+	ctx, cancel := context.WithTimeout(client.getContext(), beaconStateRequestTimeout)
+	defer cancel()
+
+	genesisState, err := client.client.GetRPCClient().GetState(ctx, "genesis")
+	if err != nil {
+		return 0, fmt.Errorf("error loading genesis state from %v: %v", client.client.GetName(), err)
+	}
+
+	validators, err := genesisState.Validators()
+	if err != nil {
+		return 0, fmt.Errorf("error getting genesis validators: %v", err)
+	}
+
+	balances, err := genesisState.ValidatorBalances()
+	if err != nil {
+		return 0, fmt.Errorf("error getting genesis balances: %v", err)
+	}
+
+	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		batch := make([]*dbtypes.GenesisValidator, 0, genesisValidatorBatchSize)
+		for index, validator := range validators {
+			dbValidator := &dbtypes.GenesisValidator{
+				ValidatorIndex:        uint64(index),
+				Pubkey:                validator.PublicKey[:],
+				WithdrawalCredentials: validator.WithdrawalCredentials,
+				Balance:               uint64(validator.EffectiveBalance),
+			}
+			if index < len(balances) {
+				dbValidator.Balance = uint64(balances[index])
+			}
+
+			batch = append(batch, dbValidator)
+			if len(batch) >= genesisValidatorBatchSize {
+				if err := db.InsertGenesisValidatorBatch(batch, tx); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+		}
+
+		return db.InsertGenesisValidatorBatch(batch, tx)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(len(validators)), nil
+}
+
+// applyGenesisEntities (re-)applies the configured entity attributions to the genesis validator set.
+func (indexer *Indexer) applyGenesisEntities(tx *sqlx.Tx) error {
+	if err := db.ResetGenesisValidatorEntities(tx); err != nil {
+		return err
+	}
+
+	for _, entity := range utils.Config.Indexer.GenesisEntities {
+		for _, indexRange := range strings.Split(entity.Validators, ",") {
+			minIndex, maxIndex, err := utils.ParseValidatorIndexRange(indexRange)
+			if err != nil {
+				indexer.logger.Warnf("invalid validator range for genesis entity %v: %v", entity.Name, err)
+				continue
+			}
+
+			if err := db.SetGenesisValidatorEntity(minIndex, maxIndex, entity.Name, tx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package beacon
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+)
+
+// UnmarshalBlockSSZ decodes a SSZ encoded signed beacon block of the given fork version.
+func (indexer *Indexer) UnmarshalBlockSSZ(version spec.DataVersion, ssz []byte) (*spec.VersionedSignedBeaconBlock, error) {
+	return unmarshalVersionedSignedBeaconBlockSSZ(indexer.dynSsz, uint64(version), ssz)
+}
+
+// ImportFinalizedEpoch persists the canonical blocks of a finalized epoch from an external source (eg. era files) to the database.
+// as there is no beacon state available for imported epochs, duties and vote aggregations are not computed.
+// the epoch is flagged as imported, so the synchronizer revisits it once a client is able to serve the epoch state.
+func (indexer *Indexer) ImportFinalizedEpoch(epoch phase0.Epoch, blockBodies []*spec.VersionedSignedBeaconBlock) error {
+	chainState := indexer.consensusPool.GetChainState()
+
+	blocks := make([]*Block, 0, len(blockBodies))
+	for _, blockBody := range blockBodies {
+		header, blockRoot, err := getBlockHeader(indexer.dynSsz, blockBody)
+		if err != nil {
+			return fmt.Errorf("failed building block header: %v", err)
+		}
+
+		if chainState.EpochOfSlot(header.Message.Slot) != epoch {
+			return fmt.Errorf("block %v (slot %v) is not part of epoch %v", blockRoot.String(), header.Message.Slot, epoch)
+		}
+
+		block := newBlock(indexer.dynSsz, blockRoot, header.Message.Slot)
+		block.SetHeader(header)
+		block.SetBlock(blockBody)
+		blocks = append(blocks, block)
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Slot < blocks[j].Slot
+	})
+
+	// ensure the blocks form a chain
+	for i := 1; i < len(blocks); i++ {
+		parentRoot := blocks[i].GetParentRoot()
+		if parentRoot == nil || *parentRoot != blocks[i-1].Root {
+			return fmt.Errorf("block %v (slot %v) does not build on block %v (slot %v)", blocks[i].Root.String(), blocks[i].Slot, blocks[i-1].Root.String(), blocks[i-1].Slot)
+		}
+	}
+
+	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		err := indexer.dbWriter.persistEpochData(tx, epoch, blocks, nil, nil, nil)
+		if err != nil {
+			return fmt.Errorf("error persisting epoch data to db: %v", err)
+		}
+
+		if err := db.SetEpochImported(uint64(epoch), tx); err != nil {
+			return fmt.Errorf("error flagging epoch as imported: %v", err)
+		}
+
+		return nil
+	})
+}
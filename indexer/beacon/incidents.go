@@ -0,0 +1,284 @@
+package beacon
+
+import (
+	"math"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// IncidentHook is called when a validator incident is opened or resolved.
+// hooks are called synchronously from the finalization routine, so they must not block.
+type IncidentHook func(incident *dbtypes.ValidatorIncident)
+
+// EntityResolver returns the entity (operator name) a validator belongs to, or an empty string if unknown.
+type EntityResolver func(validatorIndex phase0.ValidatorIndex) string
+
+// incidentTracker groups consecutive missed duties of validators with the same entity into incidents.
+// an incident is opened when an entity misses at least minMissed duties in a finalized epoch,
+// extended as long as the following epochs exceed the threshold, and resolved with the first epoch below the threshold.
+type incidentTracker struct {
+	indexer        *Indexer
+	mutex          sync.Mutex
+	minMissed      uint64
+	entityResolver EntityResolver
+	hooks          []IncidentHook
+	loaded         bool
+	incidents      map[string]*trackedIncident
+}
+
+// trackedIncident holds an unresolved incident along with the validators affected since startup.
+type trackedIncident struct {
+	incident       *dbtypes.ValidatorIncident
+	baseValidators uint64 // affected validators loaded from db (before restart)
+	validators     map[phase0.ValidatorIndex]bool
+}
+
+// incidentEpochStats holds the missed duties of an entity in a single epoch.
+type incidentEpochStats struct {
+	validators         map[phase0.ValidatorIndex]bool
+	missedAttestations uint64
+	missedProposals    uint64
+	estimatedLoss      float64
+}
+
+// newIncidentTracker creates & returns a new instance of incidentTracker.
+func newIncidentTracker(indexer *Indexer, minMissed uint64) *incidentTracker {
+	return &incidentTracker{
+		indexer:   indexer,
+		minMissed: minMissed,
+		incidents: map[string]*trackedIncident{},
+	}
+}
+
+// SetEntityResolver sets the function used to group validators into entities for incident tracking & attestation miss attribution.
+func (indexer *Indexer) SetEntityResolver(resolver EntityResolver) {
+	if indexer.attestationMisses != nil {
+		indexer.attestationMisses.mutex.Lock()
+		indexer.attestationMisses.entityResolver = resolver
+		indexer.attestationMisses.mutex.Unlock()
+	}
+
+	if indexer.incidentTracker == nil {
+		return
+	}
+
+	indexer.incidentTracker.mutex.Lock()
+	defer indexer.incidentTracker.mutex.Unlock()
+
+	indexer.incidentTracker.entityResolver = resolver
+}
+
+// AddIncidentHook adds a hook that is called when a validator incident is opened or resolved.
+func (indexer *Indexer) AddIncidentHook(hook IncidentHook) {
+	if indexer.incidentTracker == nil {
+		return
+	}
+
+	indexer.incidentTracker.mutex.Lock()
+	defer indexer.incidentTracker.mutex.Unlock()
+
+	indexer.incidentTracker.hooks = append(indexer.incidentTracker.hooks, hook)
+}
+
+// loadIncidents restores the unresolved incidents from the db.
+// the affected validators of restored incidents are only known as count, so validators affected before and after a restart might be counted twice.
+func (tracker *incidentTracker) loadIncidents() error {
+	incidents, err := db.GetUnresolvedValidatorIncidents()
+	if err != nil {
+		return err
+	}
+
+	for _, incident := range incidents {
+		tracker.incidents[incident.Entity] = &trackedIncident{
+			incident:       incident,
+			baseValidators: incident.Validators,
+			validators:     map[phase0.ValidatorIndex]bool{},
+		}
+	}
+
+	tracker.loaded = true
+	return nil
+}
+
+// processEpoch collects the missed duties of the finalized epoch per entity and updates the incidents accordingly.
+func (tracker *incidentTracker) processEpoch(epoch phase0.Epoch, epochStatsValues *EpochStatsValues, canonicalBlocks []*Block) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if tracker.entityResolver == nil {
+		return
+	}
+
+	if !tracker.loaded {
+		if err := tracker.loadIncidents(); err != nil {
+			tracker.indexer.logger.Errorf("failed loading unresolved validator incidents: %v", err)
+			return
+		}
+	}
+
+	entityStats := tracker.getEpochStats(epoch, epochStatsValues, canonicalBlocks)
+
+	updatedIncidents := []*dbtypes.ValidatorIncident{}
+	hookIncidents := []*dbtypes.ValidatorIncident{}
+
+	// resolve incidents of entities without missed duties in this epoch
+	for entity, tracked := range tracker.incidents {
+		stats := entityStats[entity]
+		if stats != nil && tracked.incident.EndEpoch+1 == uint64(epoch) {
+			continue
+		}
+
+		tracked.incident.Resolved = true
+		updatedIncidents = append(updatedIncidents, tracked.incident)
+		hookIncidents = append(hookIncidents, tracked.incident)
+		delete(tracker.incidents, entity)
+	}
+
+	// open or extend incidents of entities with missed duties in this epoch
+	for entity, stats := range entityStats {
+		tracked := tracker.incidents[entity]
+		if tracked == nil {
+			tracked = &trackedIncident{
+				incident: &dbtypes.ValidatorIncident{
+					Entity:     entity,
+					StartEpoch: uint64(epoch),
+				},
+				validators: map[phase0.ValidatorIndex]bool{},
+			}
+			tracker.incidents[entity] = tracked
+			hookIncidents = append(hookIncidents, tracked.incident)
+		}
+
+		for validatorIndex := range stats.validators {
+			tracked.validators[validatorIndex] = true
+		}
+
+		tracked.incident.EndEpoch = uint64(epoch)
+		tracked.incident.Validators = tracked.baseValidators + uint64(len(tracked.validators))
+		tracked.incident.MissedAttestations += stats.missedAttestations
+		tracked.incident.MissedProposals += stats.missedProposals
+		tracked.incident.EstimatedLoss += uint64(stats.estimatedLoss)
+		updatedIncidents = append(updatedIncidents, tracked.incident)
+	}
+
+	if len(updatedIncidents) == 0 {
+		return
+	}
+
+	_, err := tracker.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertValidatorIncidents(updatedIncidents, tx)
+	})
+	if err != nil {
+		tracker.indexer.logger.Errorf("failed persisting validator incidents for epoch %v: %v", epoch, err)
+		return
+	}
+
+	for _, incident := range hookIncidents {
+		if incident.Resolved {
+			tracker.indexer.logger.Infof("validator incident resolved: %v (epochs %v-%v, %v validators)", incident.Entity, incident.StartEpoch, incident.EndEpoch, incident.Validators)
+		} else {
+			tracker.indexer.logger.Infof("validator incident opened: %v (epoch %v, %v validators)", incident.Entity, incident.StartEpoch, incident.Validators)
+		}
+
+		for _, hook := range tracker.hooks {
+			incidentCopy := *incident
+			hook(&incidentCopy)
+		}
+	}
+}
+
+// getEpochStats returns the missed duties per entity of all entities that exceed the incident threshold in the given epoch.
+// the estimated loss covers the missed consensus rewards & penalties, execution layer fees of missed blocks are not included.
+func (tracker *incidentTracker) getEpochStats(epoch phase0.Epoch, epochStatsValues *EpochStatsValues, canonicalBlocks []*Block) map[string]*incidentEpochStats {
+	chainState := tracker.indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	// base reward = effective balance * BASE_REWARD_FACTOR / sqrt(total active balance)
+	sqrtTotalBalance := math.Sqrt(float64(epochStatsValues.EffectiveBalance))
+	if sqrtTotalBalance == 0 {
+		sqrtTotalBalance = 1
+	}
+
+	canonicalMap := make(map[*Block]bool, len(canonicalBlocks))
+	proposedSlots := make(map[phase0.Slot]bool, len(canonicalBlocks))
+	for _, block := range canonicalBlocks {
+		canonicalMap[block] = true
+		proposedSlots[block.Slot] = true
+	}
+
+	entityStats := map[string]*incidentEpochStats{}
+	getEntityStats := func(validatorIndex phase0.ValidatorIndex) *incidentEpochStats {
+		entity := tracker.entityResolver(validatorIndex)
+		if entity == "" {
+			return nil
+		}
+
+		stats := entityStats[entity]
+		if stats == nil {
+			stats = &incidentEpochStats{
+				validators: map[phase0.ValidatorIndex]bool{},
+			}
+			entityStats[entity] = stats
+		}
+		return stats
+	}
+
+	// missed attestations
+	for idx, validatorIndex := range epochStatsValues.ActiveIndices {
+		voted := false
+		for _, activity := range tracker.indexer.validatorActivity.getValidatorActivity(validatorIndex) {
+			if canonicalMap[activity.VoteBlock] && chainState.EpochOfSlot(activity.VoteBlock.Slot-phase0.Slot(activity.VoteDelay)) == epoch {
+				voted = true
+				break
+			}
+		}
+		if voted {
+			continue
+		}
+
+		stats := getEntityStats(validatorIndex)
+		if stats == nil {
+			continue
+		}
+
+		stats.validators[validatorIndex] = true
+		stats.missedAttestations++
+
+		// missed source, target & head rewards (54/64) + source & target penalties (40/64)
+		effectiveBalance := float64(epochStatsValues.EffectiveBalances[idx]) * EtherGweiFactor
+		stats.estimatedLoss += effectiveBalance * 64 / sqrtTotalBalance * 94 / 64
+	}
+
+	// missed proposals
+	firstSlot := chainState.EpochToSlot(epoch)
+	for slotIdx, validatorIndex := range epochStatsValues.ProposerDuties {
+		if proposedSlots[firstSlot+phase0.Slot(slotIdx)] {
+			continue
+		}
+
+		stats := getEntityStats(validatorIndex)
+		if stats == nil {
+			continue
+		}
+
+		stats.validators[validatorIndex] = true
+		stats.missedProposals++
+
+		// proposer reward for the included attestations of a slot (8/56 of the attestation rewards)
+		if specs != nil && specs.SlotsPerEpoch > 0 {
+			stats.estimatedLoss += 54 * sqrtTotalBalance / float64(specs.SlotsPerEpoch) * 8 / 56
+		}
+	}
+
+	for entity, stats := range entityStats {
+		if stats.missedAttestations+stats.missedProposals < tracker.minMissed {
+			delete(entityStats, entity)
+		}
+	}
+
+	return entityStats
+}
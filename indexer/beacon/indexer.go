@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
@@ -17,6 +18,7 @@ import (
 	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
 	"github.com/ethpandaops/dora/utils"
 	"github.com/ethpandaops/ethwallclock"
 )
@@ -31,11 +33,18 @@ type Indexer struct {
 	synchronizer  *synchronizer
 
 	// configuration
+	frontendOnly          bool
 	disableSync           bool
-	blockCompression      bool
+	blockCompression      *CompressionCodec
 	inMemoryEpochs        uint16
+	bodyEpochs            uint16
+	maxForkBlockBodies    uint64
 	activityHistoryLength uint16
 	maxParallelStateCalls uint16
+	fetchRaceClients      uint16
+	epochCacheMemoryLimit uint64
+	proposerBoost         uint64
+	forkChoiceEpochs      uint64
 
 	// caches
 	blockCache        *blockCache
@@ -45,10 +54,28 @@ type Indexer struct {
 	validatorCache    *validatorCache
 	validatorActivity *validatorActivityCache
 
+	effectivenessTracker *effectivenessTracker
+	incidentTracker      *incidentTracker
+	anomalyTracker       *anomalyTracker
+	missedDutyTracker    *missedDutyTracker
+	attestationMisses    *attestationMissTracker
+	finalityVotes        *finalityVoteTracker
+	effectiveBalances    *effectiveBalanceTracker
+	slasher              *slasher
+	dutyCheck            *dutyCheckTracker
+	lightClient          *lightClientTracker
+	eventDispatcher      *eventDispatcher
+	reassignmentTracker  *proposerReassignmentTracker
+
 	// indexer state
 	clients               []*Client
 	dbWriter              *dbWriter
 	running               bool
+	readOnly              atomic.Bool
+	writerMutex           sync.Mutex
+	processingStarted     bool
+	promoted              bool // switched from read-only (standby) to writer mode
+	writerStarted         bool
 	backfillCompleteMutex sync.Mutex
 	backfillingCount      int
 	backfillComplete      bool
@@ -59,11 +86,14 @@ type Indexer struct {
 	lastPrecalcRunEpoch   phase0.Epoch
 	finalitySubscription  *consensus.Subscription[*v1.Finality]
 	wallclockSubscription *consensus.Subscription[*ethwallclock.Slot]
+	cacheSettingsMutex    sync.Mutex
+	cacheSettingsChan     chan *BlockCacheSettings
 
 	// canonical head state
 	canonicalHeadMutex   sync.Mutex
 	canonicalHead        *Block
 	canonicalComputation phase0.Root
+	canonicalSlot        phase0.Slot
 	cachedChainHeads     []*ChainHead
 }
 
@@ -74,6 +104,10 @@ func NewIndexer(logger logrus.FieldLogger, consensusPool *consensus.Pool) *Index
 	if inMemoryEpochs < 2 {
 		inMemoryEpochs = 2
 	}
+	bodyEpochs := utils.Config.Indexer.InMemoryBodyEpochs
+	if bodyEpochs > inMemoryEpochs {
+		bodyEpochs = inMemoryEpochs
+	}
 	activityHistoryLength := utils.Config.Indexer.ActivityHistoryLength
 	if activityHistoryLength == 0 {
 		activityHistoryLength = 6
@@ -82,23 +116,36 @@ func NewIndexer(logger logrus.FieldLogger, consensusPool *consensus.Pool) *Index
 	if maxParallelStateCalls < 2 {
 		maxParallelStateCalls = 2
 	}
-	blockCompression := true
-	if utils.Config.KillSwitch.DisableBlockCompression {
-		blockCompression = false
+	var blockCompression *CompressionCodec
+	if !utils.Config.KillSwitch.DisableBlockCompression {
+		codec, err := NewCompressionCodec(utils.Config.Indexer.BlockCompression, utils.Config.Indexer.BlockCompressionLevel)
+		if err != nil {
+			logger.Warnf("invalid block compression setting, falling back to zlib: %v", err)
+			codec, _ = NewCompressionCodec("zlib", 0)
+		}
+		blockCompression = codec
 	}
 
 	// Create the indexer instance.
 	indexer := &Indexer{
 		logger:                logger,
 		consensusPool:         consensusPool,
-		disableSync:           utils.Config.Indexer.DisableSynchronizer,
+		frontendOnly:          utils.Config.Indexer.Mode == types.IndexerModeFrontend,
+		disableSync:           utils.Config.Indexer.DisableSynchronizer || utils.Config.Indexer.Mode == types.IndexerModeFrontend,
 		blockCompression:      blockCompression,
 		inMemoryEpochs:        inMemoryEpochs,
+		bodyEpochs:            bodyEpochs,
+		maxForkBlockBodies:    utils.Config.Indexer.MaxForkBlockBodies,
 		activityHistoryLength: activityHistoryLength,
 		maxParallelStateCalls: maxParallelStateCalls,
+		fetchRaceClients:      utils.Config.Indexer.FetchRaceClients,
+		epochCacheMemoryLimit: utils.Config.Indexer.EpochCacheMemoryLimit * 1024 * 1024,
+		proposerBoost:         utils.Config.Indexer.ProposerBoost,
+		forkChoiceEpochs:      utils.Config.Indexer.ForkChoiceEpochs,
 
 		clients:              make([]*Client, 0),
 		backfillCompleteChan: make(chan bool),
+		cacheSettingsChan:    make(chan *BlockCacheSettings, 1),
 	}
 
 	indexer.blockCache = newBlockCache(indexer)
@@ -107,7 +154,42 @@ func NewIndexer(logger logrus.FieldLogger, consensusPool *consensus.Pool) *Index
 	indexer.pubkeyCache = newPubkeyCache(indexer, utils.Config.Indexer.PubkeyCachePath)
 	indexer.validatorCache = newValidatorCache(indexer)
 	indexer.validatorActivity = newValidatorActivityCache(indexer)
+	if !utils.Config.Indexer.DisableEffectivenessRanking && !indexer.frontendOnly {
+		historyDays := utils.Config.Indexer.EffectivenessHistoryDays
+		if historyDays == 0 {
+			historyDays = 30
+		}
+		indexer.effectivenessTracker = newEffectivenessTracker(indexer, historyDays)
+	}
+	if utils.Config.Incidents.Enabled && !indexer.frontendOnly {
+		indexer.incidentTracker = newIncidentTracker(indexer, utils.Config.Incidents.MinMissedDuties)
+	}
+	if utils.Config.Anomalies.Enabled && !indexer.frontendOnly {
+		indexer.anomalyTracker = newAnomalyTracker(indexer)
+	}
+	if len(utils.Config.Watchlists.Lists) > 0 && !indexer.frontendOnly {
+		indexer.missedDutyTracker = newMissedDutyTracker(indexer)
+	}
+	if utils.Config.Slasher.Enabled && !indexer.frontendOnly {
+		indexer.slasher = newSlasher(indexer, utils.Config.Slasher.Broadcast)
+	}
+	if utils.Config.DutyCheck.Enabled && !indexer.frontendOnly {
+		indexer.dutyCheck = newDutyCheckTracker(indexer)
+	}
+	if utils.Config.LightClient.Enabled && !indexer.frontendOnly {
+		indexer.lightClient = newLightClientTracker(indexer)
+	}
+	if (utils.Config.EventExport.Enabled || utils.Config.Api.EventStream.Enabled) && !indexer.frontendOnly {
+		indexer.eventDispatcher = newEventDispatcher()
+	}
+	if !indexer.frontendOnly {
+		indexer.reassignmentTracker = newProposerReassignmentTracker(indexer)
+		indexer.attestationMisses = newAttestationMissTracker(indexer)
+		indexer.finalityVotes = newFinalityVoteTracker(indexer)
+		indexer.effectiveBalances = newEffectiveBalanceTracker(indexer)
+	}
 	indexer.dbWriter = newDbWriter(indexer)
+	indexer.readOnly.Store(indexer.frontendOnly)
 
 	return indexer
 }
@@ -131,8 +213,8 @@ func (indexer *Indexer) getMinInMemoryEpoch() phase0.Epoch {
 func (indexer *Indexer) getAbsoluteMinInMemoryEpoch() phase0.Epoch {
 	minInMemoryEpoch := phase0.Epoch(0)
 	currentEpoch := indexer.consensusPool.GetChainState().CurrentEpoch()
-	if currentEpoch > phase0.Epoch(indexer.inMemoryEpochs) {
-		minInMemoryEpoch = currentEpoch - phase0.Epoch(indexer.inMemoryEpochs)
+	if inMemoryEpochs := indexer.getInMemoryEpochs(); currentEpoch > inMemoryEpochs {
+		minInMemoryEpoch = currentEpoch - inMemoryEpochs
 	} else {
 		minInMemoryEpoch = 0
 	}
@@ -149,6 +231,76 @@ func (indexer *Indexer) getMinInMemorySlot() phase0.Slot {
 	return chainState.EpochToSlot(indexer.getMinInMemoryEpoch() + 1)
 }
 
+// runDbTransaction runs the given db transaction unless the indexer runs in read-only (frontend/standby) mode.
+// read-only indexers keep the same in-memory state as the writer instance, but leave persisting it to the writer.
+// returns false if the transaction was skipped, callers must not mark objects as persisted in that case.
+func (indexer *Indexer) runDbTransaction(handler func(tx *sqlx.Tx) error) (bool, error) {
+	if indexer.readOnly.Load() {
+		return false, nil
+	}
+
+	return true, db.RunDBTransaction(handler)
+}
+
+// IsReadOnly returns true if the indexer runs in read-only (frontend/standby) mode.
+func (indexer *Indexer) IsReadOnly() bool {
+	return indexer.readOnly.Load()
+}
+
+// SetReadOnly switches the indexer between read-only (standby) and writer mode.
+// the writer-only background processes (synchronizer & genesis validator indexer) are started when the indexer becomes a writer.
+// a promoted standby indexer persists the in-memory state that has been skipped in read-only mode before.
+// switching a writer back to read-only mode does not stop these processes, so this is expected to be followed by a shutdown.
+func (indexer *Indexer) SetReadOnly(readOnly bool) {
+	if indexer.frontendOnly {
+		return
+	}
+
+	wasReadOnly := indexer.readOnly.Swap(readOnly)
+	if !readOnly {
+		if wasReadOnly {
+			indexer.writerMutex.Lock()
+			indexer.promoted = true
+			indexer.writerMutex.Unlock()
+		}
+		indexer.startWriterProcesses()
+	}
+}
+
+// startWriterProcesses starts the background processes that write to the db, once the indexer processing has been started in writer mode.
+func (indexer *Indexer) startWriterProcesses() {
+	indexer.writerMutex.Lock()
+	defer indexer.writerMutex.Unlock()
+
+	if !indexer.processingStarted || indexer.writerStarted || indexer.readOnly.Load() {
+		return
+	}
+	indexer.writerStarted = true
+
+	if indexer.promoted {
+		// catch up with the db state: persist the in-memory state that has been skipped in read-only mode
+		// and reload the sync state, which has been advanced by the previous writer meanwhile.
+		if err := indexer.persistStandbyState(); err != nil {
+			indexer.logger.Errorf("failed persisting standby state: %v", err)
+		}
+		indexer.synchronizer.loadSyncState()
+	}
+
+	if !utils.Config.Indexer.DisableGenesisValidators {
+		go indexer.runGenesisValidatorIndexer()
+	}
+
+	// restore epoch aggregates that went missing after a crash
+	go indexer.fillMissingEpochs(indexer.lastFinalizedEpoch)
+
+	// start synchronizer, epochs imported from era files are revisited to fill in their duties & vote aggregations
+	syncStartEpoch := indexer.lastFinalizedEpoch
+	if importedEpoch, found := db.GetFirstImportedEpoch(); found && phase0.Epoch(importedEpoch) < syncStartEpoch {
+		syncStartEpoch = phase0.Epoch(importedEpoch)
+	}
+	indexer.startSynchronizer(syncStartEpoch)
+}
+
 func (indexer *Indexer) withBackfillTracker(backfillCb func() error) error {
 	indexer.backfillCompleteMutex.Lock()
 	indexer.backfillingCount++
@@ -183,9 +335,9 @@ func (indexer *Indexer) awaitBackfillComplete(ctx context.Context, timeout time.
 }
 
 // AddClient adds a new consensus pool client to the indexer.
-func (indexer *Indexer) AddClient(index uint16, client *consensus.Client, priority int, archive bool, skipValidators bool) *Client {
+func (indexer *Indexer) AddClient(index uint16, client *consensus.Client, skipValidators bool) *Client {
 	logger := indexer.logger.WithField("client", client.GetName())
-	indexerClient := newClient(index, client, priority, archive, skipValidators, indexer, logger)
+	indexerClient := newClient(index, client, skipValidators, indexer, logger)
 	indexer.clients = append(indexer.clients, indexerClient)
 
 	return indexerClient
@@ -221,7 +373,7 @@ func (indexer *Indexer) StartIndexer() {
 
 	if indexer.lastPrunedEpoch < finalizedEpoch {
 		indexer.lastPrunedEpoch = finalizedEpoch
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		_, err := indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 			return indexer.updatePruningState(tx, indexer.lastPrunedEpoch)
 		})
 		if err != nil {
@@ -266,21 +418,12 @@ func (indexer *Indexer) StartIndexer() {
 				processingWaitGroup.Done()
 			}()
 
-			epochStats := indexer.epochCache.createOrGetEpochStats(phase0.Epoch(dbDuty.Epoch), phase0.Root(dbDuty.DependentRoot), false)
-			pruneStats := dbDuty.Epoch < uint64(indexer.lastPrunedEpoch)
-
-			err := epochStats.restoreFromDb(dbDuty, indexer.dynSsz, chainState, !pruneStats)
-			if err != nil {
+			if err := indexer.restoreEpochStatsFromDb(dbDuty); err != nil {
 				indexer.logger.WithError(err).Errorf("failed restoring epoch stats for epoch %v (%x) from db", dbDuty.Epoch, dbDuty.DependentRoot)
 				return
 			}
 
-			epochStats.isInDb = true
-
 			restoredEpochStats++
-			if pruneStats {
-				epochStats.pruneValues()
-			}
 		}()
 	})
 	processingWaitGroup.Wait()
@@ -316,49 +459,14 @@ func (indexer *Indexer) StartIndexer() {
 	restoredBodyCount := 0
 	t1 = time.Now()
 	err = db.StreamUnfinalizedBlocks(uint64(finalizedSlot), func(dbBlock *dbtypes.UnfinalizedBlock) {
-
-		block, _ := indexer.blockCache.createOrGetBlock(phase0.Root(dbBlock.Root), phase0.Slot(dbBlock.Slot))
-		block.forkId = ForkKey(dbBlock.ForkId)
-		block.forkChecked = true
-		block.processingStatus = dbBlock.Status
-		block.isInUnfinalizedDb = true
-
-		if dbBlock.HeaderVer != 1 {
-			indexer.logger.Warnf("failed unmarshal unfinalized block header %v [%x] from db: unsupported header version", dbBlock.Slot, dbBlock.Root)
+		block, hasBody := indexer.restoreBlockFromDb(dbBlock)
+		if block == nil {
 			return
 		}
 
-		header := &phase0.SignedBeaconBlockHeader{}
-		err := header.UnmarshalSSZ(dbBlock.HeaderSSZ)
-		if err != nil {
-			indexer.logger.Warnf("failed unmarshal unfinalized block header %v [%x] from db: %v", dbBlock.Slot, dbBlock.Root, err)
-			return
-		}
-
-		block.SetHeader(header)
-		indexer.blockCache.addBlockToParentMap(block)
-
-		blockBody, err := unmarshalVersionedSignedBeaconBlockSSZ(indexer.dynSsz, dbBlock.BlockVer, dbBlock.BlockSSZ)
-		if err != nil {
-			indexer.logger.Warnf("could not restore unfinalized block body %v [%x] from db: %v", dbBlock.Slot, dbBlock.Root, err)
-		} else if block.processingStatus == 0 {
-			block.SetBlock(blockBody)
+		if hasBody {
 			restoredBodyCount++
-		} else {
-			block.setBlockIndex(blockBody)
-			block.isInFinalizedDb = true
 		}
-
-		indexer.blockCache.addBlockToExecBlockMap(block)
-
-		blockFork := indexer.forkCache.getForkById(block.forkId)
-		if blockFork != nil {
-			if blockFork.headBlock == nil || blockFork.headBlock.Slot < block.Slot {
-				blockFork.headBlock = block
-			}
-		}
-
-		indexer.blockCache.latestBlock = block
 		restoredBlockCount++
 
 		if time.Since(t1) > 5*time.Second {
@@ -372,6 +480,17 @@ func (indexer *Indexer) StartIndexer() {
 		indexer.logger.Infof("restored %v unfinalized blocks from DB (%v with bodies, %.3f sec)", restoredBlockCount, restoredBodyCount, time.Since(t1).Seconds())
 	}
 
+	// verify the restored block graph, unclean shutdowns might leave inconsistent fork ids behind
+	t1 = time.Now()
+	indexer.logBlockGraphCheck(indexer.checkBlockGraph(finalizedSlot), time.Since(t1))
+
+	if indexer.frontendOnly {
+		// frontends don't process blocks or load states from the clients, the caches mirror the unfinalized state persisted by the writer
+		indexer.logger.Infof("running in frontend-only mode, block & state processing is disabled")
+		go indexer.runFrontendLoop()
+		return
+	}
+
 	// start indexing for all clients
 	for _, client := range indexer.clients {
 		client.startIndexing()
@@ -400,8 +519,12 @@ func (indexer *Indexer) StartIndexer() {
 
 		go indexer.runIndexerLoop()
 
-		// start synchronizer
-		indexer.startSynchronizer(indexer.lastFinalizedEpoch)
+		indexer.writerMutex.Lock()
+		indexer.processingStarted = true
+		indexer.writerMutex.Unlock()
+
+		// start synchronizer & genesis validator indexer (writer only)
+		indexer.startWriterProcesses()
 	}()
 }
 
@@ -431,7 +554,7 @@ func (indexer *Indexer) runIndexerLoop() {
 
 			if indexer.lastFinalizedEpoch > indexer.lastPrunedEpoch {
 				indexer.lastPrunedEpoch = indexer.lastFinalizedEpoch
-				err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+				_, err := indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 					return indexer.updatePruningState(tx, indexer.lastPrunedEpoch)
 				})
 				if err != nil {
@@ -471,6 +594,23 @@ func (indexer *Indexer) runIndexerLoop() {
 				indexer.lastPruneRunEpoch = epoch
 			}
 
+			// scan the attestations of new blocks for slashable offences
+			if indexer.slasher != nil {
+				indexer.slasher.scanBlocks()
+			}
+
+			// cross-check the duties of the current epoch with the connected clients (after the epoch transition has been processed)
+			if indexer.dutyCheck != nil && slotIndex > 0 {
+				indexer.dutyCheck.scheduleCheck(epoch)
+			}
+
+			// poll & verify the light client updates of the connected clients once per epoch (writer only, results are persisted)
+			if indexer.lightClient != nil && slotIndex > 0 && !indexer.readOnly.Load() {
+				indexer.lightClient.scheduleCheck(epoch)
+			}
+
+		case cacheSettings := <-indexer.cacheSettingsChan:
+			indexer.applyBlockCacheSettings(cacheSettings)
 		}
 	}
 }
@@ -3,13 +3,13 @@ package beacon
 import (
 	"bytes"
 	"fmt"
-	"math/rand/v2"
 	"slices"
-	"sort"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/selection"
 	"github.com/ethpandaops/dora/db"
 	dynssz "github.com/pk910/dynamic-ssz"
 )
@@ -43,17 +43,7 @@ func (indexer *Indexer) GetReadyClientsByCheckpoint(finalizedRoot phase0.Root, p
 		clients = append(clients, client)
 	}
 
-	sort.Slice(clients, func(i, j int) bool {
-		if preferArchive && clients[i].archive != clients[j].archive {
-			return clients[i].archive
-		}
-
-		if clients[i].priority != clients[j].priority {
-			return clients[i].priority > clients[j].priority
-		}
-
-		return rand.IntN(2) == 0
-	})
+	selection.SortClients(clients, (*Client).getSelection, preferArchive)
 
 	return clients
 }
@@ -73,17 +63,7 @@ func (indexer *Indexer) GetReadyClientsByBlockRoot(blockRoot phase0.Root, prefer
 		}
 	}
 
-	sort.Slice(clients, func(i, j int) bool {
-		if preferArchive && clients[i].archive != clients[j].archive {
-			return clients[i].archive
-		}
-
-		if clients[i].priority != clients[j].priority {
-			return clients[i].priority > clients[j].priority
-		}
-
-		return rand.IntN(2) == 0
-	})
+	selection.SortClients(clients, (*Client).getSelection, preferArchive)
 
 	return clients
 }
@@ -180,12 +160,7 @@ func (indexer *Indexer) GetOrphanedBlockByRoot(blockRoot phase0.Root) (*Block, e
 		return nil, nil
 	}
 
-	if orphanedBlock.HeaderVer != 1 {
-		return nil, fmt.Errorf("failed unmarshal orphaned block header [%x] from db: unsupported header version", orphanedBlock.Root)
-	}
-
-	header := &phase0.SignedBeaconBlockHeader{}
-	err := header.UnmarshalSSZ(orphanedBlock.HeaderSSZ)
+	header, err := unmarshalBlockHeaderSSZ(orphanedBlock.HeaderVer, orphanedBlock.HeaderSSZ)
 	if err != nil {
 		return nil, fmt.Errorf("failed unmarshal orphaned block header [%x] from db: %v", orphanedBlock.Root, err)
 	}
@@ -202,6 +177,21 @@ func (indexer *Indexer) GetOrphanedBlockByRoot(blockRoot phase0.Root) (*Block, e
 	return block, nil
 }
 
+// GetOrphanedBlockSSZByRoot returns the ssz encoded block body of an orphaned block from the db, without decoding it.
+func (indexer *Indexer) GetOrphanedBlockSSZByRoot(blockRoot phase0.Root) (spec.DataVersion, []byte, error) {
+	orphanedBlock := db.GetOrphanedBlock(blockRoot[:])
+	if orphanedBlock == nil {
+		return 0, nil, nil
+	}
+
+	version, blockSSZ, err := unmarshalRawBlockSSZ(indexer.dynSsz, orphanedBlock.BlockVer, orphanedBlock.BlockSSZ)
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not restore orphaned block body [%x] from db: %v", orphanedBlock.Root, err)
+	}
+
+	return version, blockSSZ, nil
+}
+
 // GetEpochStats returns the epoch stats for the given epoch and optional fork ID override.
 func (indexer *Indexer) GetEpochStats(epoch phase0.Epoch, overrideForkId *ForkKey) *EpochStats {
 	epochStats := indexer.epochCache.getEpochStatsByEpoch(epoch)
@@ -316,6 +306,12 @@ func (indexer *Indexer) GetValidatorByIndex(index phase0.ValidatorIndex, overrid
 	return indexer.validatorCache.getValidatorByIndex(index, overrideForkId)
 }
 
+// GetValidatorsByIndices returns the validators for a list of indices for a given forkId.
+// Validators that are not held in memory are loaded from the db with a single query.
+func (indexer *Indexer) GetValidatorsByIndices(indices []phase0.ValidatorIndex, overrideForkId *ForkKey) map[phase0.ValidatorIndex]*phase0.Validator {
+	return indexer.validatorCache.getValidatorsByIndices(indices, overrideForkId)
+}
+
 // GetValidatorActivity returns the validator activity for a given validator index.
 func (indexer *Indexer) GetValidatorActivity(validatorIndex phase0.ValidatorIndex) ([]ValidatorActivity, phase0.Epoch) {
 	activity := indexer.validatorActivity.getValidatorActivity(validatorIndex)
@@ -0,0 +1,184 @@
+package beacon
+
+import (
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+)
+
+// blockGraphCheckResult holds the findings of the block graph integrity check.
+type blockGraphCheckResult struct {
+	checkedBlocks     int
+	checkedForks      int
+	unresolvedParents int
+	unknownForkIds    int
+	repairedBlocks    int
+	orphanedForkRefs  int
+	repairedForks     int
+}
+
+// checkBlockGraph verifies the unfinalized block graph that has been restored from the db.
+// it checks that the parents of all unfinalized blocks can be resolved, that the fork ids of the blocks are consistent
+// with the forks table and that the forks don't reference unknown parent forks.
+// fork ids are repaired by deriving them from the forks table (the first block of a fork carries its id) and the parent blocks,
+// fork parents are repaired from the fork id of their base block. unresolvable parents are only reported,
+// as the missing blocks get backfilled by the clients.
+func (indexer *Indexer) checkBlockGraph(finalizedSlot phase0.Slot) *blockGraphCheckResult {
+	cache := indexer.forkCache
+	cache.forkProcessLock.Lock()
+	defer cache.forkProcessLock.Unlock()
+
+	result := &blockGraphCheckResult{}
+
+	// collect the known forks, forks based before the finalized slot are only in the db
+	cache.cacheMutex.RLock()
+	finalizedForkId := cache.finalizedForkId
+	forks := make(map[ForkKey]*Fork, len(cache.forkMap))
+	forksByLeaf := make(map[phase0.Root]*Fork, len(cache.forkMap))
+	for forkId, fork := range cache.forkMap {
+		forks[forkId] = fork
+		forksByLeaf[fork.leafRoot] = fork
+	}
+	cache.cacheMutex.RUnlock()
+
+	dbForks := map[ForkKey]bool{}
+	isKnownFork := func(forkId ForkKey) bool {
+		if forkId == 0 || forkId == finalizedForkId || forks[forkId] != nil {
+			return true
+		}
+		if known, checked := dbForks[forkId]; checked {
+			return known
+		}
+
+		dbForks[forkId] = db.GetForkById(uint64(forkId)) != nil
+		return dbForks[forkId]
+	}
+
+	// check the blocks in slot order, so the fork ids of the parents are repaired first
+	blocks := indexer.blockCache.getUnfinalizedBlocks(finalizedSlot)
+	repairedForkIds := map[ForkKey][][]byte{}
+
+	for _, block := range blocks {
+		if !block.forkChecked {
+			continue
+		}
+
+		result.checkedBlocks++
+		parentRoot := block.GetParentRoot()
+		if parentRoot == nil || block.Slot == 0 {
+			continue
+		}
+
+		expectedForkId := block.forkId
+		parentBlock := indexer.blockCache.getBlockByRoot(*parentRoot)
+
+		switch {
+		case forksByLeaf[block.Root] != nil:
+			// first block of a fork
+			expectedForkId = forksByLeaf[block.Root].forkId
+		case parentBlock != nil && parentBlock.forkChecked:
+			expectedForkId = parentBlock.forkId
+		case parentBlock == nil && db.GetBlockHeadByRoot((*parentRoot)[:]) == nil:
+			result.unresolvedParents++
+			indexer.logger.Warnf("block graph check: parent %v of block %v [%v] not found", parentRoot.String(), block.Slot, block.Root.String())
+		}
+
+		if expectedForkId != block.forkId {
+			indexer.logger.Warnf("block graph check: block %v [%v] has fork id %v, expected %v", block.Slot, block.Root.String(), block.forkId, expectedForkId)
+			block.forkId = expectedForkId
+			repairedForkIds[expectedForkId] = append(repairedForkIds[expectedForkId], block.Root[:])
+			result.repairedBlocks++
+
+			if fork := forks[expectedForkId]; fork != nil && (fork.headBlock == nil || fork.headBlock.Slot < block.Slot) {
+				fork.headBlock = block
+			}
+		} else if !isKnownFork(block.forkId) {
+			result.unknownForkIds++
+			indexer.logger.Warnf("block graph check: block %v [%v] references unknown fork %v", block.Slot, block.Root.String(), block.forkId)
+		}
+	}
+
+	// check the parent references of the forks
+	repairedForks := []*Fork{}
+	for _, fork := range forks {
+		result.checkedForks++
+
+		expectedParent := fork.parentFork
+		if !isKnownFork(fork.parentFork) {
+			result.orphanedForkRefs++
+			indexer.logger.Warnf("block graph check: fork %v (base %v [%v]) references unknown parent fork %v", fork.forkId, fork.baseSlot, fork.baseRoot.String(), fork.parentFork)
+			expectedParent = 0
+			if blockHead := db.GetBlockHeadByRoot(fork.baseRoot[:]); blockHead != nil {
+				expectedParent = ForkKey(blockHead.ForkId)
+			}
+		}
+		if baseBlock := indexer.blockCache.getBlockByRoot(fork.baseRoot); baseBlock != nil && baseBlock.forkChecked {
+			expectedParent = baseBlock.forkId
+		}
+
+		if expectedParent != fork.parentFork {
+			indexer.logger.Warnf("block graph check: fork %v (base %v [%v]) has parent fork %v, expected %v", fork.forkId, fork.baseSlot, fork.baseRoot.String(), fork.parentFork, expectedParent)
+			cache.cacheMutex.Lock()
+			fork.parentFork = expectedParent
+			cache.cacheMutex.Unlock()
+			repairedForks = append(repairedForks, fork)
+			result.repairedForks++
+		}
+	}
+
+	if len(repairedForkIds) == 0 && len(repairedForks) == 0 {
+		return result
+	}
+
+	// fork ids have changed, drop the cached fork trees & persist the repaired graph
+	cache.parentIdCache.Purge()
+	cache.parentIdsCache.Purge()
+
+	_, err := indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		for forkId, roots := range repairedForkIds {
+			for start := 0; start < len(roots); start += 1000 {
+				end := start + 1000
+				if end > len(roots) {
+					end = len(roots)
+				}
+
+				if err := db.UpdateUnfinalizedBlockForkId(roots[start:end], uint64(forkId), tx); err != nil {
+					return err
+				}
+				if err := db.UpdateExecutionBlockSlotForkId(roots[start:end], uint64(forkId), tx); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, fork := range repairedForks {
+			if err := db.InsertFork(fork.toDbFork(), tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		indexer.logger.WithError(err).Errorf("block graph check: failed persisting repaired block graph")
+	}
+
+	return result
+}
+
+// logBlockGraphCheck logs the summary of a block graph integrity check.
+func (indexer *Indexer) logBlockGraphCheck(result *blockGraphCheckResult, duration time.Duration) {
+	issues := result.unresolvedParents + result.unknownForkIds + result.repairedBlocks + result.orphanedForkRefs + result.repairedForks
+	if issues == 0 {
+		indexer.logger.Infof("block graph check passed (%v blocks, %v forks, %.3f sec)", result.checkedBlocks, result.checkedForks, duration.Seconds())
+		return
+	}
+
+	indexer.logger.Warnf(
+		"block graph check found inconsistencies (%v blocks, %v forks): %v unresolved parents, %v unknown fork ids, %v repaired block fork ids, %v orphaned fork references, %v repaired fork parents",
+		result.checkedBlocks, result.checkedForks, result.unresolvedParents, result.unknownForkIds, result.repairedBlocks, result.orphanedForkRefs, result.repairedForks,
+	)
+}
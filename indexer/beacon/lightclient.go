@@ -0,0 +1,463 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus/rpc"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/jmoiron/sqlx"
+	blsu "github.com/protolambda/bls12-381-util"
+	zrnt_common "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// lightClientMinParticipants is the minimum number of sync committee participants of a valid light client update (MIN_SYNC_COMMITTEE_PARTICIPANTS)
+const lightClientMinParticipants = 1
+
+// generalized indices of the next sync committee & the finalized checkpoint root in the beacon state (the state got additional fields with electra)
+const (
+	lightClientNextSyncCommitteeGindex        = 55
+	lightClientFinalizedRootGindex            = 105
+	lightClientNextSyncCommitteeGindexElectra = 87
+	lightClientFinalizedRootGindexElectra     = 169
+)
+
+// lightClientTracker polls the light client updates served by the connected beacon nodes once per epoch.
+// each update is verified against the canonical chain (merkle branches, sync committee signature & next sync committee),
+// so broken update chains of individual clients can be spotted before light clients fail to sync.
+type lightClientTracker struct {
+	indexer          *Indexer
+	mutex            sync.Mutex
+	running          bool
+	backfilled       bool
+	lastCheckedEpoch phase0.Epoch
+	committees       map[uint64]*lightClientCommittee
+}
+
+// lightClientCommittee is the canonical sync committee of a sync committee period
+type lightClientCommittee struct {
+	pubkeys    []phase0.BLSPubKey
+	blsPubkeys []*blsu.Pubkey
+}
+
+// lightClientCheck holds the verification result of a light client update
+type lightClientCheck struct {
+	period            uint64
+	attestedRoot      phase0.Root
+	finalizedSlot     phase0.Slot
+	finalizedRoot     phase0.Root
+	participation     uint32
+	nextCommitteeRoot phase0.Root
+	failed            []string
+	unverified        []string
+}
+
+// newLightClientTracker creates & returns a new instance of lightClientTracker.
+func newLightClientTracker(indexer *Indexer) *lightClientTracker {
+	return &lightClientTracker{
+		indexer:    indexer,
+		committees: map[uint64]*lightClientCommittee{},
+	}
+}
+
+// scheduleCheck starts polling the light client updates for the epoch in background, unless the epoch has already been checked.
+func (tracker *lightClientTracker) scheduleCheck(epoch phase0.Epoch) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if tracker.running || (tracker.backfilled && epoch <= tracker.lastCheckedEpoch) {
+		return
+	}
+
+	tracker.running = true
+	tracker.lastCheckedEpoch = epoch
+	go func() {
+		defer utils.HandleSubroutinePanic("lightClientTracker.checkEpoch", nil)
+		defer func() {
+			tracker.mutex.Lock()
+			tracker.running = false
+			tracker.mutex.Unlock()
+		}()
+
+		tracker.checkEpoch(epoch)
+	}()
+}
+
+// checkEpoch polls & verifies the light client updates of the recent sync committee periods from all ready clients.
+// the best update of a period may still change until the next period started, so the previous period is refreshed too.
+func (tracker *lightClientTracker) checkEpoch(epoch phase0.Epoch) {
+	specs := tracker.indexer.consensusPool.GetChainState().GetSpecs()
+	if specs.AltairForkEpoch == nil || uint64(epoch) < *specs.AltairForkEpoch || specs.EpochsPerSyncCommitteePeriod == 0 {
+		return
+	}
+
+	currentPeriod := uint64(epoch) / specs.EpochsPerSyncCommitteePeriod
+	altairPeriod := *specs.AltairForkEpoch / specs.EpochsPerSyncCommitteePeriod
+
+	backfillPeriods := uint64(1)
+	if !tracker.backfilled {
+		backfillPeriods = utils.Config.LightClient.BackfillPeriods
+	}
+
+	startPeriod := altairPeriod
+	if currentPeriod > altairPeriod+backfillPeriods {
+		startPeriod = currentPeriod - backfillPeriods
+	}
+
+	updates := []*dbtypes.LightClientUpdate{}
+	headUpdates := []*dbtypes.LightClientHeadUpdate{}
+	for _, client := range tracker.indexer.GetReadyClients(false) {
+		clientUpdates, clientHeadUpdates := tracker.checkClient(client, startPeriod, currentPeriod)
+		updates = append(updates, clientUpdates...)
+		headUpdates = append(headUpdates, clientHeadUpdates...)
+	}
+
+	// the committees of older periods are not needed anymore
+	for period := range tracker.committees {
+		if period+1 < startPeriod {
+			delete(tracker.committees, period)
+		}
+	}
+
+	if len(updates) == 0 && len(headUpdates) == 0 {
+		return
+	}
+
+	_, err := tracker.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		if err := db.InsertLightClientUpdates(updates, tx); err != nil {
+			return err
+		}
+		return db.InsertLightClientHeadUpdates(headUpdates, tx)
+	})
+	if err != nil {
+		tracker.indexer.logger.Errorf("failed persisting light client updates for epoch %v: %v", epoch, err)
+		return
+	}
+
+	tracker.mutex.Lock()
+	tracker.backfilled = true
+	tracker.mutex.Unlock()
+}
+
+// checkClient fetches the sync committee updates of the period range and the latest finality & optimistic update from the client and verifies them.
+func (tracker *lightClientTracker) checkClient(client *Client, startPeriod uint64, endPeriod uint64) ([]*dbtypes.LightClientUpdate, []*dbtypes.LightClientHeadUpdate) {
+	ctx, cancel := context.WithTimeout(client.client.GetContext(), utils.Config.LightClient.RequestTimeout)
+	defer cancel()
+
+	clientName := client.client.GetName()
+	rpcClient := client.client.GetRPCClient()
+	now := uint64(time.Now().Unix())
+
+	updates := []*dbtypes.LightClientUpdate{}
+	lcUpdates, err := rpcClient.GetLightClientUpdates(ctx, startPeriod, endPeriod-startPeriod+1)
+	if err != nil {
+		tracker.indexer.logger.Warnf("failed fetching light client updates (period %v-%v) from client %v: %v", startPeriod, endPeriod, clientName, err)
+	}
+
+	for _, lcUpdate := range lcUpdates {
+		if lcUpdate.Update.AttestedHeader == nil || lcUpdate.Update.AttestedHeader.Beacon == nil {
+			tracker.indexer.logger.Warnf("light client update without attested header from client %v", clientName)
+			continue
+		}
+
+		check := tracker.verifyUpdate(lcUpdate.Update, true)
+		status, result := check.getStatus()
+		if status == dbtypes.LightClientUpdateInvalid {
+			tracker.indexer.logger.Warnf("invalid light client update for period %v from client %v: %v", check.period, clientName, result)
+		}
+
+		updates = append(updates, &dbtypes.LightClientUpdate{
+			Period:            check.period,
+			ClientName:        clientName,
+			Version:           lcUpdate.Version,
+			AttestedSlot:      uint64(lcUpdate.Update.AttestedHeader.Beacon.Slot),
+			AttestedRoot:      check.attestedRoot[:],
+			FinalizedSlot:     uint64(check.finalizedSlot),
+			FinalizedRoot:     check.finalizedRoot[:],
+			SignatureSlot:     uint64(lcUpdate.Update.SignatureSlot),
+			Participation:     check.participation,
+			NextCommitteeRoot: check.nextCommitteeRoot[:],
+			Status:            status,
+			Result:            result,
+			Data:              lcUpdate.Data,
+			UpdatedAt:         now,
+		})
+	}
+
+	headUpdates := []*dbtypes.LightClientHeadUpdate{}
+	addHeadUpdate := func(updateType dbtypes.LightClientHeadUpdateType, lcUpdate *rpc.VersionedLightClientUpdate) {
+		check := tracker.verifyUpdate(lcUpdate.Update, false)
+		status, result := check.getStatus()
+		if status == dbtypes.LightClientUpdateInvalid {
+			tracker.indexer.logger.Warnf("invalid light client %v update (attested slot %v) from client %v: %v", getLightClientHeadUpdateTypeName(updateType), lcUpdate.Update.AttestedHeader.Beacon.Slot, clientName, result)
+		}
+
+		headUpdates = append(headUpdates, &dbtypes.LightClientHeadUpdate{
+			Period:        check.period,
+			ClientName:    clientName,
+			UpdateType:    updateType,
+			Version:       lcUpdate.Version,
+			AttestedSlot:  uint64(lcUpdate.Update.AttestedHeader.Beacon.Slot),
+			AttestedRoot:  check.attestedRoot[:],
+			FinalizedSlot: uint64(check.finalizedSlot),
+			FinalizedRoot: check.finalizedRoot[:],
+			SignatureSlot: uint64(lcUpdate.Update.SignatureSlot),
+			Participation: check.participation,
+			Status:        status,
+			Result:        result,
+			Data:          lcUpdate.Data,
+			UpdatedAt:     now,
+		})
+	}
+
+	finalityUpdate, err := rpcClient.GetLightClientFinalityUpdate(ctx)
+	if err != nil {
+		tracker.indexer.logger.Warnf("failed fetching light client finality update from client %v: %v", clientName, err)
+	} else if finalityUpdate.Update.AttestedHeader != nil && finalityUpdate.Update.AttestedHeader.Beacon != nil {
+		addHeadUpdate(dbtypes.LightClientFinalityUpdate, finalityUpdate)
+	}
+
+	optimisticUpdate, err := rpcClient.GetLightClientOptimisticUpdate(ctx)
+	if err != nil {
+		tracker.indexer.logger.Warnf("failed fetching light client optimistic update from client %v: %v", clientName, err)
+	} else if optimisticUpdate.Update.AttestedHeader != nil && optimisticUpdate.Update.AttestedHeader.Beacon != nil {
+		addHeadUpdate(dbtypes.LightClientOptimisticUpdate, optimisticUpdate)
+	}
+
+	return updates, headUpdates
+}
+
+// verifyUpdate checks a light client update against the canonical chain, the update is expected to have an attested header.
+// the next sync committee is only checked for sync committee updates (isCommitteeUpdate), finality & optimistic updates don't carry it.
+func (tracker *lightClientTracker) verifyUpdate(update *rpc.LightClientUpdate, isCommitteeUpdate bool) *lightClientCheck {
+	chainState := tracker.indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	check := &lightClientCheck{}
+
+	attestedHeader := update.AttestedHeader.Beacon
+	attestedEpoch := chainState.EpochOfSlot(attestedHeader.Slot)
+	check.period = uint64(attestedEpoch) / specs.EpochsPerSyncCommitteePeriod
+	if attestedRoot, err := attestedHeader.HashTreeRoot(); err == nil {
+		check.attestedRoot = attestedRoot
+	}
+
+	isElectra := specs.ElectraForkEpoch != nil && uint64(attestedEpoch) >= *specs.ElectraForkEpoch
+	nextSyncCommitteeGindex := uint64(lightClientNextSyncCommitteeGindex)
+	finalizedRootGindex := uint64(lightClientFinalizedRootGindex)
+	if isElectra {
+		nextSyncCommitteeGindex = lightClientNextSyncCommitteeGindexElectra
+		finalizedRootGindex = lightClientFinalizedRootGindexElectra
+	}
+
+	if update.SignatureSlot <= attestedHeader.Slot {
+		check.failed = append(check.failed, fmt.Sprintf("signature slot %v not after attested slot %v", update.SignatureSlot, attestedHeader.Slot))
+	}
+
+	// finality branch (the finalized header is empty if the update does not prove finality)
+	if update.FinalizedHeader != nil && update.FinalizedHeader.Beacon != nil && !isZeroBranch(update.FinalityBranch) {
+		check.finalizedSlot = update.FinalizedHeader.Beacon.Slot
+		if check.finalizedSlot > 0 {
+			if finalizedRoot, err := update.FinalizedHeader.Beacon.HashTreeRoot(); err == nil {
+				check.finalizedRoot = finalizedRoot
+			}
+		}
+
+		if check.finalizedSlot > attestedHeader.Slot {
+			check.failed = append(check.failed, fmt.Sprintf("finalized slot %v after attested slot %v", check.finalizedSlot, attestedHeader.Slot))
+		}
+		if !isValidMerkleBranch(check.finalizedRoot, update.FinalityBranch, finalizedRootGindex, attestedHeader.StateRoot) {
+			check.failed = append(check.failed, "invalid finality branch")
+		}
+	}
+
+	// next sync committee & branch, compared with the canonical committee of the next period
+	if isCommitteeUpdate {
+		if update.NextSyncCommittee == nil {
+			check.failed = append(check.failed, "missing next sync committee")
+		} else {
+			if nextCommitteeRoot, err := tracker.indexer.dynSsz.HashTreeRoot(update.NextSyncCommittee); err == nil {
+				check.nextCommitteeRoot = nextCommitteeRoot
+			}
+			if !isValidMerkleBranch(check.nextCommitteeRoot, update.NextSyncCommitteeBranch, nextSyncCommitteeGindex, attestedHeader.StateRoot) {
+				check.failed = append(check.failed, "invalid next sync committee branch")
+			}
+
+			if nextCommittee := tracker.getSyncCommittee(check.period + 1); nextCommittee == nil {
+				check.unverified = append(check.unverified, fmt.Sprintf("sync committee of period %v not known yet", check.period+1))
+			} else if !slices.Equal(nextCommittee.pubkeys, update.NextSyncCommittee.Pubkeys) {
+				check.failed = append(check.failed, fmt.Sprintf("next sync committee does not match the canonical sync committee of period %v", check.period+1))
+			}
+		}
+	}
+
+	// sync committee signature
+	if update.SyncAggregate == nil {
+		check.failed = append(check.failed, "missing sync aggregate")
+		return check
+	}
+
+	check.participation = uint32(update.SyncAggregate.SyncCommitteeBits.Count())
+	if check.participation < lightClientMinParticipants {
+		check.failed = append(check.failed, "insufficient sync committee participation")
+		return check
+	}
+
+	signaturePeriod := uint64(chainState.EpochOfSlot(update.SignatureSlot)) / specs.EpochsPerSyncCommitteePeriod
+	committee := tracker.getSyncCommittee(signaturePeriod)
+	if committee == nil {
+		check.unverified = append(check.unverified, fmt.Sprintf("sync committee of period %v not known yet", signaturePeriod))
+		return check
+	}
+
+	if len(update.SyncAggregate.SyncCommitteeBits)*8 != len(committee.blsPubkeys) {
+		check.failed = append(check.failed, fmt.Sprintf("sync committee bits length %v does not match committee size %v", len(update.SyncAggregate.SyncCommitteeBits)*8, len(committee.blsPubkeys)))
+		return check
+	}
+
+	participants := make([]*blsu.Pubkey, 0, check.participation)
+	for idx, pubkey := range committee.blsPubkeys {
+		if utils.BitAtVector(update.SyncAggregate.SyncCommitteeBits, idx) {
+			participants = append(participants, pubkey)
+		}
+	}
+
+	forkVersionSlot := update.SignatureSlot
+	if forkVersionSlot > 0 {
+		forkVersionSlot--
+	}
+	forkVersion := chainState.GetForkVersionAtEpoch(chainState.EpochOfSlot(forkVersionSlot))
+	domain := zrnt_common.ComputeDomain(zrnt_common.DOMAIN_SYNC_COMMITTEE, zrnt_common.Version(forkVersion), zrnt_common.Root(chainState.GetGenesis().GenesisValidatorsRoot))
+	signingRoot := zrnt_common.ComputeSigningRoot(zrnt_common.Root(check.attestedRoot), domain)
+
+	signatureData := zrnt_common.BLSSignature(update.SyncAggregate.SyncCommitteeSignature)
+	signature, err := signatureData.Signature()
+	if err != nil || !blsu.FastAggregateVerify(participants, signingRoot[:], signature) {
+		check.failed = append(check.failed, "invalid sync committee signature")
+	}
+
+	return check
+}
+
+// getStatus returns the verification status & the failed or skipped checks
+func (check *lightClientCheck) getStatus() (dbtypes.LightClientUpdateStatus, string) {
+	if len(check.failed) > 0 {
+		return dbtypes.LightClientUpdateInvalid, strings.Join(check.failed, ", ")
+	}
+	if len(check.unverified) > 0 {
+		return dbtypes.LightClientUpdateUnverified, strings.Join(check.unverified, ", ")
+	}
+	return dbtypes.LightClientUpdateValid, ""
+}
+
+// getSyncCommittee returns the canonical sync committee of the period or nil if not known yet.
+// the assignments are persisted when the first epoch of the period is finalized, the in-memory epoch stats are used for the current period before.
+func (tracker *lightClientTracker) getSyncCommittee(period uint64) *lightClientCommittee {
+	if committee := tracker.committees[period]; committee != nil {
+		return committee
+	}
+
+	chainState := tracker.indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	validatorIndices := db.GetSyncAssignmentsForPeriod(period)
+	isPersisted := len(validatorIndices) > 0
+	if !isPersisted {
+		currentEpoch := chainState.CurrentEpoch()
+		if uint64(currentEpoch)/specs.EpochsPerSyncCommitteePeriod != period {
+			return nil
+		}
+
+		epochStats := tracker.indexer.GetEpochStats(currentEpoch, nil)
+		if epochStats == nil {
+			return nil
+		}
+
+		epochStatsValues := epochStats.GetValues(false)
+		if epochStatsValues == nil {
+			return nil
+		}
+
+		for _, validatorIndex := range epochStatsValues.SyncCommitteeDuties {
+			validatorIndices = append(validatorIndices, uint64(validatorIndex))
+		}
+	}
+
+	if len(validatorIndices) == 0 {
+		return nil
+	}
+
+	committee := &lightClientCommittee{
+		pubkeys:    make([]phase0.BLSPubKey, len(validatorIndices)),
+		blsPubkeys: make([]*blsu.Pubkey, len(validatorIndices)),
+	}
+	for idx, validatorIndex := range validatorIndices {
+		validator := tracker.indexer.GetValidatorByIndex(phase0.ValidatorIndex(validatorIndex), nil)
+		if validator == nil {
+			return nil
+		}
+
+		pubkeyData := zrnt_common.BLSPubkey(validator.PublicKey)
+		pubkey, err := pubkeyData.Pubkey()
+		if err != nil {
+			return nil
+		}
+
+		committee.pubkeys[idx] = validator.PublicKey
+		committee.blsPubkeys[idx] = pubkey
+	}
+
+	if isPersisted {
+		tracker.committees[period] = committee
+	}
+
+	return committee
+}
+
+// isValidMerkleBranch checks the merkle proof of the leaf at the generalized index against the root
+func isValidMerkleBranch(leaf phase0.Root, branch []phase0.Root, gindex uint64, root phase0.Root) bool {
+	depth := bits.Len64(gindex) - 1
+	if len(branch) != depth {
+		return false
+	}
+
+	value := leaf
+	for i := 0; i < depth; i++ {
+		if (gindex>>i)&1 == 1 {
+			value = phase0.Root(sha256.Sum256(append(branch[i][:], value[:]...)))
+		} else {
+			value = phase0.Root(sha256.Sum256(append(value[:], branch[i][:]...)))
+		}
+	}
+
+	return bytes.Equal(value[:], root[:])
+}
+
+func isZeroBranch(branch []phase0.Root) bool {
+	for _, node := range branch {
+		if node != (phase0.Root{}) {
+			return false
+		}
+	}
+	return true
+}
+
+func getLightClientHeadUpdateTypeName(updateType dbtypes.LightClientHeadUpdateType) string {
+	switch updateType {
+	case dbtypes.LightClientFinalityUpdate:
+		return "finality"
+	case dbtypes.LightClientOptimisticUpdate:
+		return "optimistic"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,51 @@
+package beacon
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+	dynssz "github.com/pk910/dynamic-ssz"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMain(m *testing.M) {
+	// the block & state helpers read kill switches from the global config
+	utils.Config = &types.Config{}
+
+	os.Exit(m.Run())
+}
+
+// newTestIndexer creates an indexer with in-memory caches only.
+// no clients, db or background routines are attached, so the caches can be driven directly from tests.
+func newTestIndexer(t *testing.T, dynSsz *dynssz.DynSsz) *Indexer {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	indexer := &Indexer{
+		logger:                logger,
+		consensusPool:         consensus.NewPool(context.Background(), logger),
+		dynSsz:                dynSsz,
+		inMemoryEpochs:        2,
+		maxParallelStateCalls: 2,
+	}
+
+	indexer.blockCache = newBlockCache(indexer)
+	indexer.forkCache = newForkCache(indexer)
+	indexer.epochCache = &epochCache{
+		indexer:  indexer,
+		statsMap: map[epochStatsKey]*EpochStats{},
+		stateMap: map[phase0.Root]*epochState{},
+	}
+	indexer.pubkeyCache = newPubkeyCache(indexer, "")
+	indexer.dbWriter = newDbWriter(indexer)
+
+	return indexer
+}
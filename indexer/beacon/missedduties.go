@@ -0,0 +1,134 @@
+package beacon
+
+import (
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+type MissedDutyType uint8
+
+const (
+	MissedDutyAttestation MissedDutyType = 1
+	MissedDutyProposal    MissedDutyType = 2
+)
+
+// MissedDuty is a missed attestation or block proposal of a watched validator in a finalized epoch.
+type MissedDuty struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Epoch          phase0.Epoch
+	Slot           phase0.Slot // proposal slot (first slot of the epoch for attestations)
+	Type           MissedDutyType
+}
+
+// MissedDutyHook is called with the missed duties of the watched validators once per finalized epoch.
+// hooks are called synchronously from the finalization routine, so they must not block.
+type MissedDutyHook func(epoch phase0.Epoch, duties []*MissedDuty)
+
+// missedDutyTracker reports the missed attestations & proposals of watched validators in finalized epochs.
+type missedDutyTracker struct {
+	indexer *Indexer
+	mutex   sync.Mutex
+	watches []*missedDutyWatch
+}
+
+// missedDutyWatch is a watchlist along with the hook that is called for its missed duties.
+type missedDutyWatch struct {
+	watchlist WatchlistResolver
+	hook      MissedDutyHook
+}
+
+// newMissedDutyTracker creates & returns a new instance of missedDutyTracker.
+func newMissedDutyTracker(indexer *Indexer) *missedDutyTracker {
+	return &missedDutyTracker{
+		indexer: indexer,
+	}
+}
+
+// AddMissedDutyHook adds a hook that is called with the missed duties of the validators matched by the watchlist.
+func (indexer *Indexer) AddMissedDutyHook(watchlist WatchlistResolver, hook MissedDutyHook) {
+	if indexer.missedDutyTracker == nil {
+		return
+	}
+
+	indexer.missedDutyTracker.mutex.Lock()
+	defer indexer.missedDutyTracker.mutex.Unlock()
+
+	indexer.missedDutyTracker.watches = append(indexer.missedDutyTracker.watches, &missedDutyWatch{
+		watchlist: watchlist,
+		hook:      hook,
+	})
+}
+
+// processEpoch collects the missed duties of the finalized epoch and calls the hooks of the matching watchlists.
+func (tracker *missedDutyTracker) processEpoch(epoch phase0.Epoch, epochStatsValues *EpochStatsValues, canonicalBlocks []*Block) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	if len(tracker.watches) == 0 {
+		return
+	}
+
+	chainState := tracker.indexer.consensusPool.GetChainState()
+	firstSlot := chainState.EpochToSlot(epoch)
+
+	canonicalMap := make(map[*Block]bool, len(canonicalBlocks))
+	proposedSlots := make(map[phase0.Slot]bool, len(canonicalBlocks))
+	for _, block := range canonicalBlocks {
+		canonicalMap[block] = true
+		proposedSlots[block.Slot] = true
+	}
+
+	watchDuties := make([][]*MissedDuty, len(tracker.watches))
+	addMissedDuty := func(duty *MissedDuty) {
+		for idx, watch := range tracker.watches {
+			if watch.watchlist(duty.ValidatorIndex) {
+				watchDuties[idx] = append(watchDuties[idx], duty)
+			}
+		}
+	}
+
+	// missed attestations
+	for _, validatorIndex := range epochStatsValues.ActiveIndices {
+		voted := false
+		for _, activity := range tracker.indexer.validatorActivity.getValidatorActivity(validatorIndex) {
+			if canonicalMap[activity.VoteBlock] && chainState.EpochOfSlot(activity.VoteBlock.Slot-phase0.Slot(activity.VoteDelay)) == epoch {
+				voted = true
+				break
+			}
+		}
+		if voted {
+			continue
+		}
+
+		addMissedDuty(&MissedDuty{
+			ValidatorIndex: validatorIndex,
+			Epoch:          epoch,
+			Slot:           firstSlot,
+			Type:           MissedDutyAttestation,
+		})
+	}
+
+	// missed proposals
+	for slotIdx, validatorIndex := range epochStatsValues.ProposerDuties {
+		slot := firstSlot + phase0.Slot(slotIdx)
+		if proposedSlots[slot] {
+			continue
+		}
+
+		addMissedDuty(&MissedDuty{
+			ValidatorIndex: validatorIndex,
+			Epoch:          epoch,
+			Slot:           slot,
+			Type:           MissedDutyProposal,
+		})
+	}
+
+	for idx, watch := range tracker.watches {
+		if len(watchDuties[idx]) == 0 {
+			continue
+		}
+
+		watch.hook(epoch, watchDuties[idx])
+	}
+}
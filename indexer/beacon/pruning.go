@@ -19,8 +19,8 @@ func (indexer *Indexer) runCachePruning() error {
 	chainState := indexer.consensusPool.GetChainState()
 
 	pruneToEpoch := chainState.CurrentEpoch()
-	if pruneToEpoch >= phase0.Epoch(indexer.inMemoryEpochs) {
-		pruneToEpoch -= phase0.Epoch(indexer.inMemoryEpochs)
+	if inMemoryEpochs := indexer.getInMemoryEpochs(); pruneToEpoch >= inMemoryEpochs {
+		pruneToEpoch -= inMemoryEpochs
 	} else {
 		pruneToEpoch = 0
 	}
@@ -46,6 +46,15 @@ func (indexer *Indexer) runCachePruning() error {
 		return fmt.Errorf("failed pruning cache: %v", err)
 	}
 
+	// mark blocks & epoch stats stored by the writer instance as persisted (read-only mode)
+	indexer.syncStandbyDbState()
+
+	// drop block bodies outside of the configured body retention range
+	indexer.pruneBlockBodies()
+
+	// evict epoch stats values if the epoch cache exceeds its memory limit
+	indexer.epochCache.enforceMemoryLimit()
+
 	return nil
 }
 
@@ -190,7 +199,7 @@ func (indexer *Indexer) processEpochPruning(pruneEpoch phase0.Epoch) (uint64, ui
 	t1 = time.Now()
 
 	// persist data in db
-	db.RunDBTransaction(func(tx *sqlx.Tx) error {
+	persisted, _ := indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 		persistedBlocks := map[phase0.Root]bool{}
 
 		for _, epochData := range epochData {
@@ -255,11 +264,10 @@ func (indexer *Indexer) processEpochPruning(pruneEpoch phase0.Epoch) (uint64, ui
 
 	t2 := time.Now()
 	// remove bodies from all pruned blocks in cache
-	for _, block := range pruningBlocks {
+	for _, block := range indexer.getStoredPruningBlocks(pruningBlocks, persisted) {
 		block.isInFinalizedDb = true
 		block.processingStatus = dbtypes.UnfinalizedBlockStatusPruned
-		block.setBlockIndex(block.block)
-		block.block = nil
+		block.pruneBlockBody()
 		block.blockResults = nil
 	}
 
@@ -296,6 +304,30 @@ func (indexer *Indexer) processEpochPruning(pruneEpoch phase0.Epoch) (uint64, ui
 	return prunedEpochStats, prunedEpochStates, nil
 }
 
+// getStoredPruningBlocks returns the pruned blocks that can be dropped from memory.
+// if the pruning has not been persisted (read-only mode), only blocks that have already been stored by the writer instance are returned.
+// the other blocks keep their bodies, so they can be persisted when the instance gets promoted to writer.
+func (indexer *Indexer) getStoredPruningBlocks(blocks []*Block, persisted bool) []*Block {
+	if persisted || len(blocks) == 0 {
+		return blocks
+	}
+
+	roots := make([][]byte, len(blocks))
+	for i, block := range blocks {
+		roots[i] = block.Root[:]
+	}
+
+	dbSlots := db.GetSlotsByRoots(roots)
+	storedBlocks := make([]*Block, 0, len(blocks))
+	for _, block := range blocks {
+		if dbSlots[block.Root] != nil {
+			storedBlocks = append(storedBlocks, block)
+		}
+	}
+
+	return storedBlocks
+}
+
 type pruningBlockData struct {
 	block      *Block
 	epochStats *EpochStats
@@ -341,8 +373,10 @@ func (indexer *Indexer) processCachePruning(prunedEpochStats, prunedEpochStates
 		pruningBlockRoots = append(pruningBlockRoots, block.Root[:])
 	}
 
+	persisted := true
 	if len(pruningData) > 0 {
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		var err error
+		persisted, err = indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 			for _, pruneBlock := range pruningData {
 				sim := newStateSimulator(indexer, pruneBlock.epochStats)
 				_, err := indexer.dbWriter.persistBlockData(tx, pruneBlock.block, pruneBlock.epochStats, nil, true, nil, sim)
@@ -367,10 +401,14 @@ func (indexer *Indexer) processCachePruning(prunedEpochStats, prunedEpochStates
 	}
 
 	// remove bodies from all pruned blocks in cache
-	for _, pruneBlock := range pruningData {
-		pruneBlock.block.isInFinalizedDb = true
-		pruneBlock.block.processingStatus = dbtypes.UnfinalizedBlockStatusPruned
-		pruneBlock.block.block = nil
+	prunedBlocks := make([]*Block, len(pruningData))
+	for i, pruneBlock := range pruningData {
+		prunedBlocks[i] = pruneBlock.block
+	}
+	for _, block := range indexer.getStoredPruningBlocks(prunedBlocks, persisted) {
+		block.isInFinalizedDb = true
+		block.processingStatus = dbtypes.UnfinalizedBlockStatusPruned
+		block.pruneBlockBody()
 	}
 
 	// remove all blocks in the finalized block range from the cache
@@ -0,0 +1,109 @@
+package beacon
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// proposerReassignmentTracker tracks the canonical proposer duties of the unfinalized epochs.
+// a reorg that switches the dependent root of an epoch may change its proposer duties, the changed duties are persisted
+// with the slot the switch has been noticed at, so the frontend can explain why the expected proposer of a slot changed.
+type proposerReassignmentTracker struct {
+	indexer     *Indexer
+	mutex       sync.Mutex
+	assignments map[phase0.Epoch]*trackedProposerDuties
+}
+
+// trackedProposerDuties holds the proposer duties of the canonical epoch stats of an epoch.
+type trackedProposerDuties struct {
+	dependentRoot phase0.Root
+	proposers     []phase0.ValidatorIndex
+}
+
+// newProposerReassignmentTracker creates a new proposer reassignment tracker.
+func newProposerReassignmentTracker(indexer *Indexer) *proposerReassignmentTracker {
+	return &proposerReassignmentTracker{
+		indexer:     indexer,
+		assignments: map[phase0.Epoch]*trackedProposerDuties{},
+	}
+}
+
+// checkAssignments compares the canonical proposer duties of the unfinalized epochs with the previously seen duties.
+// called after reorgs and after new epoch stats have been loaded, as the stats of a new dependent root may not be ready at reorg time.
+func (tracker *proposerReassignmentTracker) checkAssignments() {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	chainState := tracker.indexer.consensusPool.GetChainState()
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+	currentEpoch := chainState.CurrentEpoch()
+	currentSlot := chainState.CurrentSlot()
+
+	for epoch := range tracker.assignments {
+		if epoch < finalizedEpoch {
+			delete(tracker.assignments, epoch)
+		}
+	}
+
+	reassignments := []*dbtypes.ProposerReassignment{}
+	for epoch := finalizedEpoch; epoch <= currentEpoch+1; epoch++ {
+		epochStats := tracker.indexer.GetEpochStats(epoch, nil)
+		if epochStats == nil || !epochStats.ready {
+			continue
+		}
+
+		epochStatsValues := epochStats.GetValues(false)
+		if epochStatsValues == nil || len(epochStatsValues.ProposerDuties) == 0 {
+			continue
+		}
+
+		lastAssignment := tracker.assignments[epoch]
+		if lastAssignment != nil && lastAssignment.dependentRoot == epochStats.dependentRoot {
+			continue
+		}
+
+		tracker.assignments[epoch] = &trackedProposerDuties{
+			dependentRoot: epochStats.dependentRoot,
+			proposers:     slices.Clone(epochStatsValues.ProposerDuties),
+		}
+		if lastAssignment == nil {
+			continue
+		}
+
+		firstSlot := chainState.EpochToSlot(epoch)
+		for slotIdx, newProposer := range epochStatsValues.ProposerDuties {
+			if slotIdx >= len(lastAssignment.proposers) || lastAssignment.proposers[slotIdx] == newProposer {
+				continue
+			}
+
+			reassignments = append(reassignments, &dbtypes.ProposerReassignment{
+				Slot:             uint64(firstSlot) + uint64(slotIdx),
+				Epoch:            uint64(epoch),
+				SwitchSlot:       uint64(currentSlot),
+				OldProposer:      uint64(lastAssignment.proposers[slotIdx]),
+				NewProposer:      uint64(newProposer),
+				OldDependentRoot: lastAssignment.dependentRoot[:],
+				NewDependentRoot: epochStats.dependentRoot[:],
+			})
+		}
+
+		tracker.indexer.logger.Infof("proposer duties of epoch %v changed (dependent root %v -> %v)", epoch, lastAssignment.dependentRoot.String(), epochStats.dependentRoot.String())
+	}
+
+	if len(reassignments) == 0 {
+		return
+	}
+
+	_, err := tracker.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertProposerReassignments(reassignments, tx)
+	})
+	if err != nil {
+		tracker.indexer.logger.Errorf("error persisting proposer reassignments: %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package beacon
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// restoreEpochStatsFromDb restores the epoch stats of a persisted unfinalized duty into the epoch cache.
+func (indexer *Indexer) restoreEpochStatsFromDb(dbDuty *dbtypes.UnfinalizedDuty) error {
+	chainState := indexer.consensusPool.GetChainState()
+	epochStats := indexer.epochCache.createOrGetEpochStats(phase0.Epoch(dbDuty.Epoch), phase0.Root(dbDuty.DependentRoot), false)
+	pruneStats := dbDuty.Epoch < uint64(indexer.lastPrunedEpoch)
+
+	err := epochStats.restoreFromDb(dbDuty, indexer.dynSsz, chainState, !pruneStats)
+	if err != nil {
+		return err
+	}
+
+	epochStats.isInDb = true
+
+	if pruneStats {
+		epochStats.pruneValues()
+	}
+
+	return nil
+}
+
+// restoreBlockFromDb restores a persisted unfinalized block into the block cache.
+// returns the restored block (nil if the header could not be restored) and whether the full block body has been restored.
+func (indexer *Indexer) restoreBlockFromDb(dbBlock *dbtypes.UnfinalizedBlock) (*Block, bool) {
+	block, _ := indexer.blockCache.createOrGetBlock(phase0.Root(dbBlock.Root), phase0.Slot(dbBlock.Slot))
+
+	hasBody, err := indexer.populateBlockFromDb(block, dbBlock)
+	if err != nil {
+		indexer.logger.Warnf("%v", err)
+		return nil, false
+	}
+
+	indexer.blockCache.addBlockToParentMap(block)
+	indexer.blockCache.addBlockToExecBlockMap(block)
+	indexer.updateForkHead(block)
+	indexer.blockCache.setLatestBlock(block)
+
+	return block, hasBody
+}
+
+// populateBlockFromDb sets the header, body & processing state of a block from its persisted unfinalized block.
+// returns whether the full block body has been restored, an error is only returned if the header could not be restored.
+func (indexer *Indexer) populateBlockFromDb(block *Block, dbBlock *dbtypes.UnfinalizedBlock) (bool, error) {
+	block.forkId = ForkKey(dbBlock.ForkId)
+	block.forkChecked = true
+	block.processingStatus = dbBlock.Status
+	block.isInUnfinalizedDb = true
+
+	header, err := unmarshalBlockHeaderSSZ(dbBlock.HeaderVer, dbBlock.HeaderSSZ)
+	if err != nil {
+		return false, fmt.Errorf("failed unmarshal unfinalized block header %v [%x] from db: %v", dbBlock.Slot, dbBlock.Root, err)
+	}
+
+	block.SetHeader(header)
+
+	hasBody := false
+	if block.processingStatus == 0 {
+		blockBody, err := unmarshalVersionedSignedBeaconBlockSSZ(indexer.dynSsz, dbBlock.BlockVer, dbBlock.BlockSSZ)
+		if err != nil {
+			indexer.logger.Warnf("could not restore unfinalized block body %v [%x] from db: %v", dbBlock.Slot, dbBlock.Root, err)
+		} else {
+			block.SetBlock(blockBody)
+			hasBody = true
+		}
+	} else {
+		// processed blocks only need the block index, so avoid decoding the whole body
+		blockFields, err := unmarshalBlockBodyFields(dbBlock.BlockVer, dbBlock.BlockSSZ)
+		if err != nil {
+			indexer.logger.Warnf("could not restore unfinalized block index %v [%x] from db: %v", dbBlock.Slot, dbBlock.Root, err)
+		} else {
+			block.setBlockIndexFromFields(blockFields)
+			block.isInFinalizedDb = true
+		}
+	}
+
+	return hasBody, nil
+}
+
+// updateForkHead sets the block as head block of its fork if it is the highest known block of the fork.
+func (indexer *Indexer) updateForkHead(block *Block) {
+	blockFork := indexer.forkCache.getForkById(block.forkId)
+	if blockFork != nil {
+		if blockFork.headBlock == nil || blockFork.headBlock.Slot < block.Slot {
+			blockFork.headBlock = block
+		}
+	}
+}
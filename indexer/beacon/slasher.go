@@ -0,0 +1,400 @@
+package beacon
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// slasherSubmitTimeout is the timeout for submitting a detected attester slashing to a beacon node
+const slasherSubmitTimeout = 10 * time.Second
+
+// slasher scans the attestations of unfinalized blocks for double & surround votes.
+// the votes of all validators are tracked for the unfinalized target epochs, so offences are detected
+// as soon as the conflicting votes are seen in any block (canonical or not) - usually before the slashing is included on chain.
+type slasher struct {
+	indexer         *Indexer
+	mutex           sync.Mutex
+	broadcast       bool
+	scannedBlocks   map[phase0.Root]phase0.Slot
+	votes           map[phase0.ValidatorIndex][]*slasherAttestation
+	offences        map[slasherOffenceKey]phase0.Epoch
+	lastPruneEpoch  phase0.Epoch
+	pendingOffences []*slasherOffence
+}
+
+// slasherAttestation is an attestation aggregate resolved to the attesting validator indices.
+// it is shared by the votes of all attesting validators.
+type slasherAttestation struct {
+	slot      phase0.Slot // inclusion slot
+	dataRoot  phase0.Root
+	data      *phase0.AttestationData
+	signature phase0.BLSSignature
+	indices   []uint64 // sorted
+	electra   bool
+}
+
+// slasherOffence is a detected double or surround vote of a single validator.
+// att1 is the earlier seen vote, att2 the conflicting vote.
+type slasherOffence struct {
+	validator   phase0.ValidatorIndex
+	offenceType dbtypes.DetectedSlashingType
+	att1        *slasherAttestation
+	att2        *slasherAttestation
+}
+
+// slasherOffenceKey is the primary key for detected offences.
+// consists of validator index (8 byte) and both attestation data roots (32 byte each, sorted).
+type slasherOffenceKey [8 + 32 + 32]byte
+
+// newSlasher creates & returns a new instance of slasher.
+func newSlasher(indexer *Indexer, broadcast bool) *slasher {
+	return &slasher{
+		indexer:       indexer,
+		broadcast:     broadcast,
+		scannedBlocks: map[phase0.Root]phase0.Slot{},
+		votes:         map[phase0.ValidatorIndex][]*slasherAttestation{},
+		offences:      map[slasherOffenceKey]phase0.Epoch{},
+	}
+}
+
+func getSlasherOffenceKey(validator phase0.ValidatorIndex, root1 phase0.Root, root2 phase0.Root) slasherOffenceKey {
+	var key slasherOffenceKey
+
+	if slices.Compare(root1[:], root2[:]) > 0 {
+		root1, root2 = root2, root1
+	}
+
+	binary.LittleEndian.PutUint64(key[0:], uint64(validator))
+	copy(key[8:], root1[:])
+	copy(key[40:], root2[:])
+
+	return key
+}
+
+// scanBlocks scans the attestations of all unfinalized blocks that have not been scanned yet.
+// blocks whose attester duties are not available yet are skipped and scanned again on the next run.
+func (slasher *slasher) scanBlocks() {
+	slasher.mutex.Lock()
+	defer slasher.mutex.Unlock()
+
+	chainState := slasher.indexer.consensusPool.GetChainState()
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+	finalizedSlot := chainState.GetFinalizedSlot()
+	currentSlot := chainState.CurrentSlot()
+
+	if finalizedEpoch > slasher.lastPruneEpoch {
+		slasher.pruneVotes(finalizedEpoch, finalizedSlot)
+		slasher.lastPruneEpoch = finalizedEpoch
+	}
+
+	for slot := finalizedSlot; slot <= currentSlot; slot++ {
+		for _, block := range slasher.indexer.blockCache.getBlocksBySlot(slot) {
+			if _, scanned := slasher.scannedBlocks[block.Root]; scanned {
+				continue
+			}
+
+			if slasher.scanBlock(chainState, block) {
+				slasher.scannedBlocks[block.Root] = block.Slot
+			}
+		}
+	}
+
+	// broadcasting might take a while, don't block the indexer loop
+	if len(slasher.pendingOffences) > 0 {
+		go slasher.processOffences(slasher.pendingOffences)
+		slasher.pendingOffences = nil
+	}
+}
+
+// pruneVotes removes the votes & scanned blocks that are finalized.
+func (slasher *slasher) pruneVotes(finalizedEpoch phase0.Epoch, finalizedSlot phase0.Slot) {
+	for root, slot := range slasher.scannedBlocks {
+		if slot < finalizedSlot {
+			delete(slasher.scannedBlocks, root)
+		}
+	}
+
+	for validator, votes := range slasher.votes {
+		votes = slices.DeleteFunc(votes, func(vote *slasherAttestation) bool {
+			return vote.data.Target.Epoch < finalizedEpoch
+		})
+		if len(votes) == 0 {
+			delete(slasher.votes, validator)
+		} else {
+			slasher.votes[validator] = votes
+		}
+	}
+
+	for key, epoch := range slasher.offences {
+		if epoch < finalizedEpoch {
+			delete(slasher.offences, key)
+		}
+	}
+}
+
+// scanBlock checks the attestations of a block against the tracked votes.
+// returns false if the attester duties for the block are not available yet.
+func (slasher *slasher) scanBlock(chainState *consensus.ChainState, block *Block) bool {
+	blockBody := block.GetBlock()
+	if blockBody == nil {
+		return false
+	}
+
+	attestations, err := blockBody.Attestations()
+	if err != nil {
+		return true
+	}
+
+	specs := chainState.GetSpecs()
+	minInMemorySlot := slasher.indexer.getMinInMemorySlot()
+	slasherAttestations := make([]*slasherAttestation, 0, len(attestations))
+	epochStatsValues := map[phase0.Epoch]*EpochStatsValues{}
+
+	for _, attVersioned := range attestations {
+		attData, err := attVersioned.Data()
+		if err != nil {
+			continue
+		}
+
+		attAggregationBits, err := attVersioned.AggregationBits()
+		if err != nil {
+			continue
+		}
+
+		attSignature, err := attVersioned.Signature()
+		if err != nil {
+			continue
+		}
+
+		attEpoch := chainState.EpochOfSlot(attData.Slot)
+		values, loaded := epochStatsValues[attEpoch]
+		if !loaded {
+			if epochStats := slasher.indexer.epochCache.getEpochStatsByEpochAndRoot(attEpoch, block.Root); epochStats != nil {
+				values = epochStats.GetOrLoadValues(slasher.indexer, false, false)
+			}
+			epochStatsValues[attEpoch] = values
+		}
+		if values == nil {
+			if block.Slot >= minInMemorySlot {
+				return false
+			}
+
+			// duties of old epochs might not be available anymore, skip the attestation instead of retrying forever
+			continue
+		}
+
+		dataRoot, err := attData.HashTreeRoot()
+		if err != nil {
+			continue
+		}
+
+		attestation := &slasherAttestation{
+			slot:      block.Slot,
+			dataRoot:  dataRoot,
+			data:      attData,
+			signature: attSignature,
+			indices:   []uint64{},
+			electra:   attVersioned.Version >= spec.DataVersionElectra,
+		}
+
+		slotIndex := chainState.SlotToSlotIndex(attData.Slot)
+		if attestation.electra {
+			committeeBits, err := attVersioned.CommitteeBits()
+			if err != nil {
+				continue
+			}
+
+			aggregationBitsOffset := uint64(0)
+			for _, committee := range committeeBits.BitIndices() {
+				if uint64(committee) >= specs.MaxCommitteesPerSlot {
+					continue
+				}
+
+				committeeSize := slasher.addAttestingIndices(attestation, values, slotIndex, uint64(committee), attAggregationBits, aggregationBitsOffset)
+				aggregationBitsOffset += committeeSize
+			}
+		} else {
+			slasher.addAttestingIndices(attestation, values, slotIndex, uint64(attData.Index), attAggregationBits, 0)
+		}
+
+		slices.Sort(attestation.indices)
+		slasherAttestations = append(slasherAttestations, attestation)
+	}
+
+	for _, attestation := range slasherAttestations {
+		slasher.checkAttestation(attestation)
+	}
+
+	return true
+}
+
+// addAttestingIndices adds the validator indices of a committee that are set in the aggregation bits to the attestation.
+// returns the committee size.
+func (slasher *slasher) addAttestingIndices(attestation *slasherAttestation, values *EpochStatsValues, slotIndex phase0.Slot, committee uint64, aggregationBits bitfield.Bitfield, aggregationBitsOffset uint64) uint64 {
+	if int(slotIndex) >= len(values.AttesterDuties) || int(committee) >= len(values.AttesterDuties[slotIndex]) {
+		return 0
+	}
+
+	duties := values.AttesterDuties[slotIndex][committee]
+	for bitIdx, validatorIndice := range duties {
+		if aggregationBits.BitAt(uint64(bitIdx) + aggregationBitsOffset) {
+			attestation.indices = append(attestation.indices, uint64(values.ActiveIndices[validatorIndice]))
+		}
+	}
+
+	return uint64(len(duties))
+}
+
+// checkAttestation checks the votes of all attesting validators against their tracked votes & tracks the new votes.
+func (slasher *slasher) checkAttestation(attestation *slasherAttestation) {
+	source := attestation.data.Source.Epoch
+	target := attestation.data.Target.Epoch
+
+	for _, index := range attestation.indices {
+		validator := phase0.ValidatorIndex(index)
+		votes := slasher.votes[validator]
+		isKnownVote := false
+
+		for _, vote := range votes {
+			if vote.dataRoot == attestation.dataRoot {
+				// same vote included multiple times
+				isKnownVote = true
+				continue
+			}
+
+			voteSource := vote.data.Source.Epoch
+			voteTarget := vote.data.Target.Epoch
+
+			var offenceType dbtypes.DetectedSlashingType
+			if voteTarget == target {
+				offenceType = dbtypes.DetectedSlashingDoubleVote
+			} else if (source < voteSource && voteTarget < target) || (voteSource < source && target < voteTarget) {
+				offenceType = dbtypes.DetectedSlashingSurroundVote
+			} else {
+				continue
+			}
+
+			offenceKey := getSlasherOffenceKey(validator, vote.dataRoot, attestation.dataRoot)
+			if _, reported := slasher.offences[offenceKey]; reported {
+				continue
+			}
+			slasher.offences[offenceKey] = max(voteTarget, target)
+
+			slasher.pendingOffences = append(slasher.pendingOffences, &slasherOffence{
+				validator:   validator,
+				offenceType: offenceType,
+				att1:        vote,
+				att2:        attestation,
+			})
+		}
+
+		if !isKnownVote {
+			slasher.votes[validator] = append(votes, attestation)
+		}
+	}
+}
+
+// processOffences broadcasts (if enabled) & persists the detected offences.
+func (slasher *slasher) processOffences(offences []*slasherOffence) {
+	defer utils.HandleSubroutinePanic("slasher.processOffences", nil)
+
+	broadcasted := map[[2]*slasherAttestation]bool{}
+
+	if slasher.broadcast && !slasher.indexer.readOnly.Load() {
+		// a single attester slashing covers all validators that are part of both attestations
+		for _, offence := range offences {
+			attestationPair := [2]*slasherAttestation{offence.att1, offence.att2}
+			if _, processed := broadcasted[attestationPair]; processed {
+				continue
+			}
+
+			err := slasher.submitAttesterSlashing(offence.att1, offence.att2)
+			if err != nil {
+				slasher.indexer.logger.Warnf("failed broadcasting attester slashing for validator %v: %v", offence.validator, err)
+			}
+			broadcasted[attestationPair] = err == nil
+		}
+	}
+
+	dbSlashings := make([]*dbtypes.DetectedSlashing, len(offences))
+	for i, offence := range offences {
+		slasher.indexer.logger.Warnf("slashable offence detected: validator %v %v in slot %v (target epochs %v / %v)", offence.validator, getDetectedSlashingTypeName(offence.offenceType), offence.att2.slot, offence.att1.data.Target.Epoch, offence.att2.data.Target.Epoch)
+
+		dbSlashings[i] = &dbtypes.DetectedSlashing{
+			Validator:   uint64(offence.validator),
+			Slot:        uint64(offence.att2.slot),
+			Type:        offence.offenceType,
+			Att1Root:    offence.att1.dataRoot[:],
+			Att1Source:  uint64(offence.att1.data.Source.Epoch),
+			Att1Target:  uint64(offence.att1.data.Target.Epoch),
+			Att2Root:    offence.att2.dataRoot[:],
+			Att2Source:  uint64(offence.att2.data.Source.Epoch),
+			Att2Target:  uint64(offence.att2.data.Target.Epoch),
+			Broadcasted: broadcasted[[2]*slasherAttestation{offence.att1, offence.att2}],
+		}
+	}
+
+	_, err := slasher.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertDetectedSlashings(dbSlashings, tx)
+	})
+	if err != nil {
+		slasher.indexer.logger.Errorf("failed persisting detected slashings: %v", err)
+	}
+}
+
+// submitAttesterSlashing submits the attester slashing for two conflicting attestations to the first online beacon node that accepts it.
+func (slasher *slasher) submitAttesterSlashing(att1 *slasherAttestation, att2 *slasherAttestation) error {
+	var lastErr error = fmt.Errorf("no online client")
+
+	for _, client := range slasher.indexer.clients {
+		if client.client.GetStatus() != consensus.ClientStatusOnline {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(client.client.GetContext(), slasherSubmitTimeout)
+		if att2.electra {
+			lastErr = client.client.GetRPCClient().SubmitElectraAttesterSlashing(ctx, &electra.AttesterSlashing{
+				Attestation1: &electra.IndexedAttestation{AttestingIndices: att1.indices, Data: att1.data, Signature: att1.signature},
+				Attestation2: &electra.IndexedAttestation{AttestingIndices: att2.indices, Data: att2.data, Signature: att2.signature},
+			})
+		} else {
+			lastErr = client.client.GetRPCClient().SubmitAttesterSlashing(ctx, &phase0.AttesterSlashing{
+				Attestation1: &phase0.IndexedAttestation{AttestingIndices: att1.indices, Data: att1.data, Signature: att1.signature},
+				Attestation2: &phase0.IndexedAttestation{AttestingIndices: att2.indices, Data: att2.data, Signature: att2.signature},
+			})
+		}
+		cancel()
+
+		if lastErr == nil {
+			return nil
+		}
+	}
+
+	return lastErr
+}
+
+// getDetectedSlashingTypeName returns a readable name of the offence type.
+func getDetectedSlashingTypeName(offenceType dbtypes.DetectedSlashingType) string {
+	switch offenceType {
+	case dbtypes.DetectedSlashingDoubleVote:
+		return "double vote"
+	case dbtypes.DetectedSlashingSurroundVote:
+		return "surround vote"
+	default:
+		return "unknown"
+	}
+}
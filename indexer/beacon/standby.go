@@ -0,0 +1,177 @@
+package beacon
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+// standbyRootBatchSize is the max number of block roots checked per query (stays well below the bind parameter limits)
+const standbyRootBatchSize = 1000
+
+// syncStandbyDbState marks the in-memory blocks & epoch stats that have been stored by the writer instance as persisted.
+// read-only indexers skip all db writes, so objects are only marked as persisted once they are found in the db.
+// this allows the body & epoch stats pruning to drop data that can be reloaded from the db.
+func (indexer *Indexer) syncStandbyDbState() {
+	if !indexer.readOnly.Load() {
+		return
+	}
+
+	chainState := indexer.consensusPool.GetChainState()
+	finalizedSlot := chainState.EpochToSlot(indexer.lastFinalizedEpoch)
+
+	unpersistedBlocks := map[phase0.Root]*Block{}
+	unpersistedRoots := [][]byte{}
+	for _, block := range indexer.blockCache.getUnfinalizedBlocks(finalizedSlot) {
+		if block.isInUnfinalizedDb || block.isInFinalizedDb || block.block == nil {
+			continue
+		}
+
+		unpersistedBlocks[block.Root] = block
+		unpersistedRoots = append(unpersistedRoots, block.Root[:])
+	}
+
+	for start := 0; start < len(unpersistedRoots); start += standbyRootBatchSize {
+		end := start + standbyRootBatchSize
+		if end > len(unpersistedRoots) {
+			end = len(unpersistedRoots)
+		}
+
+		for _, root := range db.GetUnfinalizedBlockRoots(unpersistedRoots[start:end]) {
+			if block := unpersistedBlocks[phase0.Root(root)]; block != nil {
+				block.isInUnfinalizedDb = true
+			}
+		}
+	}
+
+	for _, epochStats := range indexer.epochCache.getEpochStatsBeforeEpoch(chainState.CurrentEpoch() + 2) {
+		if epochStats.isInDb || !epochStats.ready {
+			continue
+		}
+
+		if db.HasUnfinalizedDuty(uint64(epochStats.epoch), epochStats.dependentRoot[:]) {
+			epochStats.isInDb = true
+		}
+	}
+}
+
+// persistStandbyState writes the in-memory unfinalized state that has not been persisted while the indexer was running in read-only mode.
+// called when a standby instance gets promoted to writer, before the writer processes are started.
+func (indexer *Indexer) persistStandbyState() error {
+	chainState := indexer.consensusPool.GetChainState()
+	finalizedSlot := chainState.EpochToSlot(indexer.lastFinalizedEpoch)
+
+	unfinalizedBlocks := indexer.blockCache.getUnfinalizedBlocks(finalizedSlot)
+	persistBlocks := []*Block{}
+	dbBlocks := []*dbtypes.UnfinalizedBlock{}
+	for _, block := range unfinalizedBlocks {
+		if block.isInUnfinalizedDb || block.isInFinalizedDb || block.block == nil {
+			continue
+		}
+
+		dbBlock, err := block.buildUnfinalizedBlock(indexer.blockCompression)
+		if err != nil {
+			indexer.logger.Warnf("failed building unfinalized block %v [%v]: %v", block.Slot, block.Root.String(), err)
+			continue
+		}
+
+		persistBlocks = append(persistBlocks, block)
+		dbBlocks = append(dbBlocks, dbBlock)
+	}
+
+	persistStats := []*EpochStats{}
+	dbDuties := []*dbtypes.UnfinalizedDuty{}
+	dbEpochs := []*dbtypes.UnfinalizedEpoch{}
+	for _, epochStats := range indexer.epochCache.getEpochStatsBeforeEpoch(chainState.CurrentEpoch() + 2) {
+		dbEpochs = append(dbEpochs, epochStats.prunedEpochAggregations...)
+
+		if epochStats.isInDb || !epochStats.ready || epochStats.values == nil {
+			continue
+		}
+
+		packedSsz, err := epochStats.buildPackedSSZ(indexer.dynSsz)
+		if err != nil {
+			indexer.logger.Warnf("failed building epoch %v stats (%v): %v", epochStats.epoch, epochStats.dependentRoot.String(), err)
+			continue
+		}
+
+		persistStats = append(persistStats, epochStats)
+		dbDuties = append(dbDuties, &dbtypes.UnfinalizedDuty{
+			Epoch:         uint64(epochStats.epoch),
+			DependentRoot: epochStats.dependentRoot[:],
+			DutiesSSZ:     packedSsz,
+		})
+	}
+
+	// the fork ids of the in-memory blocks may differ from the ones assigned by the previous writer
+	forkRoots := map[ForkKey][][]byte{}
+	for _, block := range unfinalizedBlocks {
+		forkRoots[block.forkId] = append(forkRoots[block.forkId], block.Root[:])
+	}
+
+	_, err := indexer.runDbTransaction(func(tx *sqlx.Tx) error {
+		for i, dbBlock := range dbBlocks {
+			if err := db.InsertUnfinalizedBlock(dbBlock, tx); err != nil {
+				return fmt.Errorf("error inserting unfinalized block %v: %v", dbBlock.Slot, err)
+			}
+
+			if err := indexer.dbWriter.persistExecutionBlockSlot(tx, persistBlocks[i], false, nil); err != nil {
+				return err
+			}
+		}
+
+		for _, dbDuty := range dbDuties {
+			if err := db.InsertUnfinalizedDuty(dbDuty, tx); err != nil {
+				return fmt.Errorf("error inserting unfinalized duty %v: %v", dbDuty.Epoch, err)
+			}
+		}
+
+		for _, dbEpoch := range dbEpochs {
+			if err := db.InsertUnfinalizedEpoch(dbEpoch, tx); err != nil {
+				return fmt.Errorf("error inserting unfinalized epoch %v: %v", dbEpoch.Epoch, err)
+			}
+		}
+
+		for _, fork := range indexer.forkCache.getForks() {
+			if err := db.InsertFork(fork.toDbFork(), tx); err != nil {
+				return fmt.Errorf("error inserting fork %v: %v", fork.forkId, err)
+			}
+		}
+
+		for forkId, roots := range forkRoots {
+			for start := 0; start < len(roots); start += standbyRootBatchSize {
+				end := start + standbyRootBatchSize
+				if end > len(roots) {
+					end = len(roots)
+				}
+
+				if err := db.UpdateUnfinalizedBlockForkId(roots[start:end], uint64(forkId), tx); err != nil {
+					return fmt.Errorf("error updating unfinalized block fork ids: %v", err)
+				}
+			}
+		}
+
+		if err := indexer.forkCache.updateForkState(tx); err != nil {
+			return err
+		}
+
+		return indexer.updatePruningState(tx, indexer.lastPrunedEpoch)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, block := range persistBlocks {
+		block.isInUnfinalizedDb = true
+	}
+	for _, epochStats := range persistStats {
+		epochStats.isInDb = true
+	}
+
+	indexer.logger.Infof("persisted standby state: %v blocks, %v epoch stats, %v epoch aggregations", len(persistBlocks), len(persistStats), len(dbEpochs))
+
+	return nil
+}
@@ -398,8 +398,7 @@ func (sim *stateSimulator) applyBlock(block *Block) [][]uint8 {
 		return nil
 	}
 	for _, attesterSlashing := range attesterSlashing {
-		att1, _ := attesterSlashing.Attestation1()
-		att2, _ := attesterSlashing.Attestation2()
+		att1, att2 := GetAttesterSlashingAttestations(&attesterSlashing)
 		if att1 == nil || att2 == nil {
 			continue
 		}
@@ -3,14 +3,13 @@ package beacon
 import (
 	"context"
 	"fmt"
-	"math/rand/v2"
-	"sort"
 	"sync"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/selection"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/utils"
@@ -29,13 +28,16 @@ type synchronizer struct {
 	stateMutex   sync.Mutex
 	running      bool
 	currentEpoch phase0.Epoch
+	replayFirst  phase0.Epoch
+	replayLast   phase0.Epoch
+	replaying    bool
 
 	cachedSlot   phase0.Slot
 	cachedBlocks map[phase0.Slot]*Block
 }
 
 func (indexer *Indexer) startSynchronizer(startEpoch phase0.Epoch) {
-	if indexer.disableSync {
+	if indexer.disableSync || indexer.readOnly.Load() {
 		return
 	}
 	if !indexer.synchronizer.isEpochAhead(startEpoch) || !indexer.synchronizer.running {
@@ -43,19 +45,54 @@ func (indexer *Indexer) startSynchronizer(startEpoch phase0.Epoch) {
 	}
 }
 
+// ReplayEpochs rewrites the already finalized epochs firstEpoch..lastEpoch, even if they have been written before.
+// the synchronizer restarts at firstEpoch and continues with all following epochs that have not been written completely.
+func (indexer *Indexer) ReplayEpochs(firstEpoch phase0.Epoch, lastEpoch phase0.Epoch) error {
+	if indexer.disableSync || indexer.readOnly.Load() {
+		return fmt.Errorf("synchronizer is disabled")
+	}
+	if firstEpoch > lastEpoch {
+		return fmt.Errorf("invalid epoch range %v - %v", firstEpoch, lastEpoch)
+	}
+	if lastEpoch >= indexer.lastFinalizedEpoch {
+		return fmt.Errorf("epoch %v is not finalized yet", lastEpoch)
+	}
+
+	indexer.synchronizer.stopSync()
+
+	indexer.synchronizer.stateMutex.Lock()
+	indexer.synchronizer.replayFirst = firstEpoch
+	indexer.synchronizer.replayLast = lastEpoch
+	indexer.synchronizer.replaying = true
+	indexer.synchronizer.stateMutex.Unlock()
+
+	indexer.logger.Infof("replaying finalized epochs %v - %v", firstEpoch, lastEpoch)
+	indexer.synchronizer.startSync(firstEpoch)
+	return nil
+}
+
 func newSynchronizer(indexer *Indexer, logger logrus.FieldLogger) *synchronizer {
 	sync := &synchronizer{
 		indexer: indexer,
 		logger:  logger,
 	}
 
-	// restore sync state
+	sync.loadSyncState()
+
+	return sync
+}
+
+// loadSyncState restores the sync state from the db.
+// called on startup and when a standby instance gets promoted, as the sync state has been advanced by the previous writer meanwhile.
+func (sync *synchronizer) loadSyncState() {
 	syncState := &dbtypes.IndexerSyncState{}
-	if _, err := db.GetExplorerState("indexer.syncstate", syncState); err == nil {
-		sync.currentEpoch = phase0.Epoch(syncState.Epoch)
+	if _, err := db.GetExplorerState("indexer.syncstate", syncState); err != nil {
+		return
 	}
 
-	return sync
+	sync.stateMutex.Lock()
+	sync.currentEpoch = phase0.Epoch(syncState.Epoch)
+	sync.stateMutex.Unlock()
 }
 
 func (sync *synchronizer) isEpochAhead(epoch phase0.Epoch) bool {
@@ -195,6 +232,9 @@ func (sync *synchronizer) runSync() {
 
 	if isComplete {
 		sync.logger.Infof("synchronization complete. Head epoch: %v", sync.currentEpoch)
+		sync.stateMutex.Lock()
+		sync.replaying = false
+		sync.stateMutex.Unlock()
 		db.RunDBTransaction(func(tx *sqlx.Tx) error {
 			return db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
 				Epoch: uint64(sync.currentEpoch),
@@ -207,9 +247,46 @@ func (sync *synchronizer) runSync() {
 	sync.running = false
 }
 
+func (sync *synchronizer) isReplayEpoch(epoch phase0.Epoch) bool {
+	sync.stateMutex.Lock()
+	defer sync.stateMutex.Unlock()
+	return sync.replaying && epoch >= sync.replayFirst && epoch <= sync.replayLast
+}
+
+// isEpochComplete checks whether the epoch has already been written completely.
+// epochs with missing or duplicate canonical slot rows (eg. from a partially failed write) are rewritten.
+func (sync *synchronizer) isEpochComplete(chainState *consensus.ChainState, epoch phase0.Epoch) bool {
+	dbEpochs := db.GetEpochs(uint64(epoch), 1)
+	if len(dbEpochs) == 0 || dbEpochs[0].Epoch != uint64(epoch) {
+		return false
+	}
+
+	if db.IsEpochImported(uint64(epoch)) {
+		sync.logger.Infof("epoch %v has been imported without duties, rewriting", epoch)
+		return false
+	}
+
+	firstSlot := chainState.EpochStartSlot(epoch)
+	lastSlot := chainState.EpochStartSlot(epoch+1) - 1
+	statusCounts, err := db.GetSlotStatusCounts(uint64(firstSlot), uint64(lastSlot), nil)
+	if err != nil {
+		return false
+	}
+
+	canonicalRows, err := verifySlotStatusCounts(statusCounts, firstSlot, lastSlot)
+	if err == nil && canonicalRows != uint64(dbEpochs[0].BlockCount) {
+		err = fmt.Errorf("%v canonical slot rows, %v blocks in epoch", canonicalRows, dbEpochs[0].BlockCount)
+	}
+	if err != nil {
+		sync.logger.Warnf("epoch %v already written but incomplete (%v), rewriting", epoch, err)
+		return false
+	}
+
+	return true
+}
+
 func (sync *synchronizer) getSyncClients(epoch phase0.Epoch) []*Client {
-	archiveClients := make([]*Client, 0)
-	normalClients := make([]*Client, 0)
+	clients := make([]*Client, 0)
 
 	for _, client := range sync.indexer.clients {
 		if client.client.GetStatus() != consensus.ClientStatusOnline {
@@ -225,28 +302,13 @@ func (sync *synchronizer) getSyncClients(epoch phase0.Epoch) []*Client {
 			continue
 		}
 
-		if client.archive {
-			archiveClients = append(archiveClients, client)
-		} else {
-			normalClients = append(normalClients, client)
-		}
+		clients = append(clients, client)
 	}
 
-	sort.Slice(archiveClients, func(i, j int) bool {
-		if archiveClients[i].priority == archiveClients[j].priority {
-			return rand.UintN(1) == 0
-		}
-		return archiveClients[i].priority > archiveClients[j].priority
-	})
+	// synchronizing historic epochs requires state access, so prefer archive clients
+	selection.SortClients(clients, (*Client).getSelection, true)
 
-	sort.Slice(normalClients, func(i, j int) bool {
-		if normalClients[i].priority == normalClients[j].priority {
-			return rand.UintN(1) == 0
-		}
-		return normalClients[i].priority > normalClients[j].priority
-	})
-
-	return append(archiveClients, normalClients...)
+	return clients
 }
 
 func (sync *synchronizer) loadBlockHeader(client *Client, slot phase0.Slot) (*phase0.SignedBeaconBlockHeader, phase0.Root, error) {
@@ -268,16 +330,35 @@ func (sync *synchronizer) loadBlockBody(client *Client, root phase0.Root) (*spec
 }
 
 func (sync *synchronizer) syncEpoch(syncEpoch phase0.Epoch, client *Client, lastTry bool) (bool, error) {
-	if !utils.Config.Indexer.ResyncForceUpdate && db.IsEpochSynchronized(uint64(syncEpoch)) {
+	chainState := sync.indexer.consensusPool.GetChainState()
+
+	if !utils.Config.Indexer.ResyncForceUpdate && !sync.isReplayEpoch(syncEpoch) && sync.isEpochComplete(chainState, syncEpoch) {
 		return true, nil
 	}
 
-	chainState := sync.indexer.consensusPool.GetChainState()
 	specs := chainState.GetSpecs()
 
 	// load headers & blocks from this & next epoch
 	firstSlot := chainState.EpochStartSlot(syncEpoch)
 	lastSlot := chainState.EpochStartSlot(syncEpoch+2) - 1
+
+	// imported epochs are revisited to fill in the duties & vote aggregations, which requires the epoch state.
+	// check the state availability first, so the blocks of epochs that can't be completed anyway are not fetched again.
+	var importedState *epochState
+	var importedBeaconState *spec.VersionedBeaconState
+	if db.IsEpochImported(uint64(syncEpoch)) {
+		dependentSlot := firstSlot
+		if dependentSlot == 0 {
+			dependentSlot = 1 // epoch 0 dependent root is the genesis block
+		}
+
+		importedState = newEpochState(phase0.Root(db.GetHighestRootBeforeSlot(uint64(dependentSlot), false)))
+		beaconState, err := importedState.loadState(sync.syncCtx, client, nil)
+		if err != nil || importedState.loadingStatus != 2 {
+			return true, fmt.Errorf("epoch %v state not available (%v), keeping imported epoch", syncEpoch, err)
+		}
+		importedBeaconState = beaconState
+	}
 	canonicalBlocks := []*Block{}
 	canonicalBlockRoots := [][]byte{}
 	canonicalBlockHashes := [][]byte{}
@@ -349,11 +430,22 @@ func (sync *synchronizer) syncEpoch(syncEpoch phase0.Epoch, client *Client, last
 		dependentRoot = phase0.Root(depRoot)
 	}
 
-	epochState := newEpochState(dependentRoot)
-	state, err := epochState.loadState(sync.syncCtx, client, nil)
+	var state *spec.VersionedBeaconState
+	var err error
+	epochState := importedState
+	if epochState != nil && epochState.slotRoot == dependentRoot {
+		state = importedBeaconState
+	} else {
+		epochState = newEpochState(dependentRoot)
+		state, err = epochState.loadState(sync.syncCtx, client, nil)
+	}
 	if (err != nil || epochState.loadingStatus != 2) && !lastTry {
 		return false, fmt.Errorf("error fetching epoch %v state: %v", syncEpoch, err)
 	}
+	if epochState.loadingStatus != 2 && importedState != nil {
+		// rewriting an imported epoch without state doesn't add anything, keep the imported data
+		return true, fmt.Errorf("epoch %v state not available, keeping imported epoch", syncEpoch)
+	}
 
 	var validatorSet []*phase0.Validator
 	if state == nil {
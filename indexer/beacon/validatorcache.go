@@ -37,6 +37,8 @@ type validatorCache struct {
 	lastFinalized            phase0.Epoch      // last finalized epoch
 	lastFinalizedActiveCount uint64
 	triggerDbUpdate          chan bool
+
+	effectiveBalanceChanges []*dbtypes.EffectiveBalanceChange // finalized effective balance changes, collected until persisted by the effectiveBalanceTracker
 }
 
 // validatorEntry represents a single validator's state in the cache
@@ -46,6 +48,7 @@ type validatorEntry struct {
 	finalValidator *phase0.Validator
 	activeData     *ValidatorData
 	statusFlags    uint16
+	finalBalance   uint16 // finalized effective balance in ETH
 }
 
 // ValidatorData contains the essential validator state information for active validators
@@ -90,9 +93,10 @@ func (cache *validatorCache) updateValidatorSet(slot phase0.Slot, dependentRoot
 	epoch := chainState.EpochOfSlot(slot)
 	currentEpoch := chainState.CurrentEpoch()
 	finalizedEpoch, finalizedRoot := chainState.GetFinalizedCheckpoint()
+	inMemoryEpochs := cache.indexer.getInMemoryEpochs()
 	cutOffEpoch := phase0.Epoch(0)
-	if currentEpoch > phase0.Epoch(cache.indexer.inMemoryEpochs) {
-		cutOffEpoch = currentEpoch - phase0.Epoch(cache.indexer.inMemoryEpochs)
+	if currentEpoch > inMemoryEpochs {
+		cutOffEpoch = currentEpoch - inMemoryEpochs
 	}
 	if cutOffEpoch > finalizedEpoch {
 		cutOffEpoch = finalizedEpoch
@@ -121,6 +125,7 @@ func (cache *validatorCache) updateValidatorSet(slot phase0.Slot, dependentRoot
 	defer cache.cacheMutex.Unlock()
 
 	t1 := time.Now()
+	isInitialSet := len(cache.valsetCache) == 0
 
 	if len(cache.valsetCache) < len(validators) {
 		if len(validators) > cap(cache.valsetCache) {
@@ -209,6 +214,7 @@ func (cache *validatorCache) updateValidatorSet(slot phase0.Slot, dependentRoot
 			cachedValidator.finalValidator = validators[i]
 			cachedValidator.finalChecksum = checksum
 			cachedValidator.statusFlags = GetValidatorStatusFlags(validators[i])
+			cache.trackEffectiveBalance(phase0.ValidatorIndex(i), cachedValidator, epoch, !isInitialSet)
 			updatedCount++
 		}
 
@@ -270,6 +276,71 @@ func (cache *validatorCache) updateValidatorSet(slot phase0.Slot, dependentRoot
 	cache.indexer.logger.Infof("processed %vvalidator set update for epoch %d in %v", isFinalizedStr, epoch, time.Since(t1))
 }
 
+// trackEffectiveBalance updates the finalized effective balance of a validator entry and records the change for the effectiveBalanceTracker.
+// changes are not recorded if record is false (initial validator set) or if effective balances are not tracked.
+func (cache *validatorCache) trackEffectiveBalance(index phase0.ValidatorIndex, entry *validatorEntry, epoch phase0.Epoch, record bool) {
+	effectiveBalance := uint16(entry.finalValidator.EffectiveBalance / EtherGweiFactor)
+	if effectiveBalance == entry.finalBalance {
+		return
+	}
+
+	if record && cache.indexer.effectiveBalances != nil {
+		cache.effectiveBalanceChanges = append(cache.effectiveBalanceChanges, &dbtypes.EffectiveBalanceChange{
+			ValidatorIndex:      uint64(index),
+			Epoch:               uint64(epoch),
+			OldEffectiveBalance: uint64(entry.finalBalance) * uint64(EtherGweiFactor),
+			NewEffectiveBalance: uint64(entry.finalValidator.EffectiveBalance),
+			Compounding:         entry.statusFlags&ValidatorStatusCompounding != 0,
+		})
+	}
+
+	entry.finalBalance = effectiveBalance
+}
+
+// takeEffectiveBalanceChanges returns the collected effective balance changes and resets the collection
+func (cache *validatorCache) takeEffectiveBalanceChanges() []*dbtypes.EffectiveBalanceChange {
+	cache.cacheMutex.Lock()
+	defer cache.cacheMutex.Unlock()
+
+	changes := cache.effectiveBalanceChanges
+	cache.effectiveBalanceChanges = nil
+	return changes
+}
+
+// getEffectiveBalanceDistribution returns the number of finalized active validators & their effective balance sum per effective balance bucket
+func (cache *validatorCache) getEffectiveBalanceDistribution(buckets []uint16) []*dbtypes.EffectiveBalanceStats {
+	cache.cacheMutex.RLock()
+	defer cache.cacheMutex.RUnlock()
+
+	stats := make([]*dbtypes.EffectiveBalanceStats, len(buckets))
+	for idx, bucket := range buckets {
+		stats[idx] = &dbtypes.EffectiveBalanceStats{
+			Bucket: uint64(bucket),
+		}
+	}
+
+	for _, cachedValidator := range cache.valsetCache {
+		if cachedValidator == nil || cachedValidator.activeData == nil {
+			continue
+		}
+
+		effectiveBalance := cachedValidator.activeData.EffectiveBalanceEth
+		bucketIdx := len(buckets) - 1
+		for bucketIdx > 0 && buckets[bucketIdx] > effectiveBalance {
+			bucketIdx--
+		}
+
+		bucketStats := stats[bucketIdx]
+		bucketStats.Validators++
+		bucketStats.EffectiveBalance += uint64(effectiveBalance) * uint64(EtherGweiFactor)
+		if cachedValidator.statusFlags&ValidatorStatusCompounding != 0 {
+			bucketStats.Compounding++
+		}
+	}
+
+	return stats
+}
+
 // checkValidatorEqual compares two validator states for equality
 // Returns true if both validators are nil or if all fields match
 func (cache *validatorCache) checkValidatorEqual(validator1 *phase0.Validator, validator2 *phase0.Validator) bool {
@@ -344,7 +415,7 @@ func (cache *validatorCache) setFinalizedEpoch(epoch phase0.Epoch, nextEpochDepe
 	activeCount := uint64(0)
 	updatedCount := uint64(0)
 
-	for _, cachedValidator := range cache.valsetCache {
+	for index, cachedValidator := range cache.valsetCache {
 		if cachedValidator == nil {
 			continue
 		}
@@ -355,6 +426,7 @@ func (cache *validatorCache) setFinalizedEpoch(epoch phase0.Epoch, nextEpochDepe
 				cachedValidator.finalValidator = diff.validator
 				cachedValidator.finalChecksum = calculateValidatorChecksum(diff.validator)
 				cachedValidator.statusFlags = GetValidatorStatusFlags(diff.validator)
+				cache.trackEffectiveBalance(phase0.ValidatorIndex(index), cachedValidator, diff.epoch, true)
 				updatedCount++
 
 				cachedValidator.activeData = &ValidatorData{
@@ -629,6 +701,90 @@ func (cache *validatorCache) getValidatorByIndexAndRoot(index phase0.ValidatorIn
 	return validator
 }
 
+// getValidatorsByIndices returns the validators for a list of indices for a given forkId.
+func (cache *validatorCache) getValidatorsByIndices(indices []phase0.ValidatorIndex, overrideForkId *ForkKey) map[phase0.ValidatorIndex]*phase0.Validator {
+	canonicalHead := cache.indexer.GetCanonicalHead(overrideForkId)
+	if canonicalHead == nil {
+		return nil
+	}
+
+	return cache.getValidatorsByIndicesAndRoot(indices, canonicalHead.Root)
+}
+
+// getValidatorsByIndicesAndRoot returns the validators for a list of indices for a given blockRoot.
+// Validators that are not held in memory are loaded from the db in a single batch.
+func (cache *validatorCache) getValidatorsByIndicesAndRoot(indices []phase0.ValidatorIndex, blockRoot phase0.Root) map[phase0.ValidatorIndex]*phase0.Validator {
+	validators := make(map[phase0.ValidatorIndex]*phase0.Validator, len(indices))
+	missingIndices := []uint64{}
+
+	cache.cacheMutex.RLock()
+	canonicalMap := map[phase0.Root]bool{}
+	for _, index := range indices {
+		if _, loaded := validators[index]; loaded || index >= phase0.ValidatorIndex(len(cache.valsetCache)) {
+			continue
+		}
+
+		cachedValidator := cache.valsetCache[index]
+		if cachedValidator == nil {
+			continue
+		}
+
+		validator := cachedValidator.finalValidator
+		validatorEpoch := cache.lastFinalized
+
+		// Find the latest valid diff
+		for _, diff := range cachedValidator.validatorDiffs {
+			isCanonical, checked := canonicalMap[diff.dependentRoot]
+			if !checked {
+				isCanonical = cache.indexer.blockCache.isCanonicalBlock(diff.dependentRoot, blockRoot)
+				canonicalMap[diff.dependentRoot] = isCanonical
+			}
+
+			if isCanonical && diff.epoch >= validatorEpoch {
+				validator = diff.validator
+				validatorEpoch = diff.epoch
+			}
+		}
+
+		if validator == nil {
+			missingIndices = append(missingIndices, uint64(index))
+			validators[index] = nil
+			continue
+		}
+
+		validators[index] = &phase0.Validator{
+			PublicKey:                  validator.PublicKey,
+			WithdrawalCredentials:      validator.WithdrawalCredentials,
+			EffectiveBalance:           validator.EffectiveBalance,
+			Slashed:                    validator.Slashed,
+			ActivationEligibilityEpoch: validator.ActivationEligibilityEpoch,
+			ActivationEpoch:            validator.ActivationEpoch,
+			ExitEpoch:                  validator.ExitEpoch,
+			WithdrawableEpoch:          validator.WithdrawableEpoch,
+		}
+	}
+	cache.cacheMutex.RUnlock()
+
+	// fallback to db for all validators that are not found in cache
+	if len(missingIndices) > 0 {
+		err := db.StreamValidatorsByIndexes(missingIndices, func(dbValidator *dbtypes.Validator) bool {
+			validators[phase0.ValidatorIndex(dbValidator.ValidatorIndex)] = UnwrapDbValidator(dbValidator)
+			return true
+		})
+		if err != nil {
+			cache.indexer.logger.WithError(err).Errorf("error loading %v validators from db", len(missingIndices))
+		}
+	}
+
+	for index, validator := range validators {
+		if validator == nil {
+			delete(validators, index)
+		}
+	}
+
+	return validators
+}
+
 // calculateValidatorChecksum generates a CRC64 checksum of all validator fields
 // Used to efficiently detect changes in validator state
 func calculateValidatorChecksum(v *phase0.Validator) uint64 {
@@ -692,6 +848,7 @@ func (cache *validatorCache) prepopulateFromDB() (uint64, error) {
 			val := UnwrapDbValidator(dbVal)
 			valEntry := &validatorEntry{
 				finalChecksum: calculateValidatorChecksum(val),
+				finalBalance:  uint16(val.EffectiveBalance / EtherGweiFactor),
 			}
 			valData := &ValidatorData{
 				ActivationEligibilityEpoch: phase0.Epoch(db.ConvertInt64ToUint64(dbVal.ActivationEligibilityEpoch)),
@@ -734,7 +891,7 @@ func (cache *validatorCache) runPersistLoop() {
 
 	for range cache.triggerDbUpdate {
 		time.Sleep(2 * time.Second)
-		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		_, err := cache.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 			hasMore, err := cache.persistValidators(tx)
 			if hasMore {
 				select {
@@ -3,7 +3,9 @@ package beacon
 import (
 	"fmt"
 	"math"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/clients/consensus"
@@ -58,6 +60,148 @@ func (dbw *dbWriter) persistMissedSlots(tx *sqlx.Tx, epoch phase0.Epoch, blocks
 	return nil
 }
 
+// persistMissedSlotAnalysis persists the root cause of all missed slots in the epoch.
+// a missed slot is classified by the non-canonical blocks seen for the slot:
+// no block seen (offline proposer), block seen in time but reorged out, or block seen after the attestation deadline (late block).
+func (dbw *dbWriter) persistMissedSlotAnalysis(tx *sqlx.Tx, epoch phase0.Epoch, canonicalBlocks []*Block, epochBlocks []*Block, epochStats *EpochStats) error {
+	chainState := dbw.indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	epochStatsValues := epochStats.GetValues(true)
+	lateThreshold := specs.SecondsPerSlot / 3
+
+	canonicalSlots := map[phase0.Slot]bool{}
+	for _, block := range canonicalBlocks {
+		canonicalSlots[block.Slot] = true
+	}
+
+	slotBlocks := map[phase0.Slot][]*Block{}
+	for _, block := range epochBlocks {
+		if !canonicalSlots[block.Slot] {
+			slotBlocks[block.Slot] = append(slotBlocks[block.Slot], block)
+		}
+	}
+
+	firstSlot := chainState.EpochStartSlot(epoch)
+	lastSlot := firstSlot + phase0.Slot(specs.SlotsPerEpoch)
+	missedSlots := []*dbtypes.MissedSlot{}
+
+	for slot := firstSlot; slot < lastSlot; slot++ {
+		if canonicalSlots[slot] || slot == 0 {
+			continue
+		}
+
+		proposer := phase0.ValidatorIndex(math.MaxInt64)
+		if epochStatsValues != nil {
+			proposer = epochStatsValues.ProposerDuties[int(slot-firstSlot)]
+		}
+
+		missedSlot := &dbtypes.MissedSlot{
+			Slot:     uint64(slot),
+			Proposer: uint64(proposer),
+			Reason:   dbtypes.MissedSlotReasonOffline,
+		}
+
+		// use the earliest seen block if multiple blocks have been seen for the slot
+		var seenBlock *Block
+		var seenTime time.Time
+		for _, block := range slotBlocks[slot] {
+			blockSeenTime := block.GetFirstSeen()
+			if seenBlock == nil || (!blockSeenTime.IsZero() && (seenTime.IsZero() || blockSeenTime.Before(seenTime))) {
+				seenBlock = block
+				seenTime = blockSeenTime
+			}
+
+			if seenBy := uint32(len(block.GetSeenBy())); seenBy > missedSlot.SeenBy {
+				missedSlot.SeenBy = seenBy
+			}
+		}
+
+		if seenBlock != nil {
+			missedSlot.Reason = dbtypes.MissedSlotReasonReorged
+			missedSlot.BlockRoot = seenBlock.Root[:]
+
+			if !seenTime.IsZero() {
+				seenDelay := seenTime.Sub(chainState.SlotToTime(slot))
+				seenDelayMs := seenDelay.Milliseconds()
+				missedSlot.SeenDelay = &seenDelayMs
+
+				if seenDelay > lateThreshold {
+					missedSlot.Reason = dbtypes.MissedSlotReasonLate
+				}
+			}
+		}
+
+		missedSlots = append(missedSlots, missedSlot)
+	}
+
+	err := db.InsertMissedSlots(missedSlots, tx)
+	if err != nil {
+		return fmt.Errorf("error while adding missed slot analysis to db: %w", err)
+	}
+	return nil
+}
+
+// persistBlockArrivals persists the arrival delays of the canonical blocks, as announced by the connected clients via event stream.
+// blocks that have not been announced by any client (e.g. loaded during backfill) are skipped.
+func (dbw *dbWriter) persistBlockArrivals(tx *sqlx.Tx, canonicalBlocks []*Block) error {
+	chainState := dbw.indexer.consensusPool.GetChainState()
+
+	arrivals := []*dbtypes.BlockArrival{}
+	clientArrivals := []*dbtypes.BlockArrivalClient{}
+
+	for _, block := range canonicalBlocks {
+		arrival, blockClientArrivals := block.GetDbArrivals(chainState)
+		if arrival == nil {
+			continue
+		}
+
+		arrivals = append(arrivals, arrival)
+		clientArrivals = append(clientArrivals, blockClientArrivals...)
+	}
+
+	err := db.InsertBlockArrivals(arrivals, clientArrivals, tx)
+	if err != nil {
+		return fmt.Errorf("error while adding block arrivals to db: %w", err)
+	}
+	return nil
+}
+
+// persistBlockPayloadStatuses persists the execution payload verdicts of the connected clients.
+// only blocks with at least one non-valid verdict are persisted, as these are the interesting ones for incident analysis.
+func (dbw *dbWriter) persistBlockPayloadStatuses(tx *sqlx.Tx, blocks []*Block) error {
+	statuses := []*dbtypes.BlockPayloadStatus{}
+
+	for _, block := range blocks {
+		blockStatuses := block.GetPayloadStatuses()
+		allValid := true
+		for _, status := range blockStatuses {
+			if status.Status != dbtypes.PayloadStatusValid {
+				allValid = false
+				break
+			}
+		}
+		if allValid {
+			continue
+		}
+
+		for _, status := range blockStatuses {
+			statuses = append(statuses, &dbtypes.BlockPayloadStatus{
+				Root:       block.Root[:],
+				Slot:       uint64(block.Slot),
+				ClientId:   status.Client.client.GetClientId(),
+				ClientName: status.Client.client.GetName(),
+				Status:     status.Status,
+			})
+		}
+	}
+
+	err := db.InsertBlockPayloadStatuses(statuses, tx)
+	if err != nil {
+		return fmt.Errorf("error while adding block payload statuses to db: %w", err)
+	}
+	return nil
+}
+
 func (dbw *dbWriter) persistBlockData(tx *sqlx.Tx, block *Block, epochStats *EpochStats, depositIndex *uint64, orphaned bool, overrideForkId *ForkKey, sim *stateSimulator) (*dbtypes.Slot, error) {
 	// insert block
 	dbBlock := dbw.buildDbBlock(block, epochStats, overrideForkId)
@@ -76,6 +220,18 @@ func (dbw *dbWriter) persistBlockData(tx *sqlx.Tx, block *Block, epochStats *Epo
 
 	block.isInFinalizedDb = true
 
+	// insert config defined block tags
+	err = dbw.persistBlockTags(tx, dbBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	// update execution block mapping
+	err = dbw.persistExecutionBlockSlot(tx, block, orphaned, overrideForkId)
+	if err != nil {
+		return nil, err
+	}
+
 	// insert child objects
 	if block.Slot > 0 {
 		err = dbw.persistBlockChildObjects(tx, block, depositIndex, orphaned, overrideForkId, sim)
@@ -102,6 +258,12 @@ func (dbw *dbWriter) persistBlockChildObjects(tx *sqlx.Tx, block *Block, deposit
 		return err
 	}
 
+	// insert attestations
+	err = dbw.persistBlockAttestations(tx, block, orphaned)
+	if err != nil {
+		return err
+	}
+
 	// insert voluntary exits
 	err = dbw.persistBlockVoluntaryExits(tx, block, orphaned, overrideForkId)
 	if err != nil {
@@ -129,11 +291,62 @@ func (dbw *dbWriter) persistBlockChildObjects(tx *sqlx.Tx, block *Block, deposit
 	return nil
 }
 
+func (dbw *dbWriter) persistBlockTags(tx *sqlx.Tx, dbBlock *dbtypes.Slot) error {
+	tags := MatchBlockTags(dbBlock.GraffitiText, dbBlock.EthBlockExtraText)
+	if len(tags) == 0 {
+		return nil
+	}
+
+	slotTags := make([]*dbtypes.SlotTag, len(tags))
+	for i, tag := range tags {
+		slotTags[i] = &dbtypes.SlotTag{
+			Root: dbBlock.Root,
+			Slot: dbBlock.Slot,
+			Tag:  tag,
+		}
+	}
+
+	err := db.InsertSlotTags(slotTags, tx)
+	if err != nil {
+		return fmt.Errorf("error inserting slot tags: %v", err)
+	}
+
+	return nil
+}
+
+func (dbw *dbWriter) persistExecutionBlockSlot(tx *sqlx.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
+	blockIndex := block.GetBlockIndex()
+	if blockIndex == nil || blockIndex.ExecutionHash == (phase0.Hash32{}) {
+		// pre-merge block without execution payload
+		return nil
+	}
+
+	blockSlot := &dbtypes.ExecutionBlockSlot{
+		Root:        block.Root[:],
+		Slot:        uint64(block.Slot),
+		BlockHash:   blockIndex.ExecutionHash[:],
+		BlockNumber: blockIndex.ExecutionNumber,
+		ForkId:      uint64(block.forkId),
+		Orphaned:    orphaned,
+	}
+	if overrideForkId != nil {
+		blockSlot.ForkId = uint64(*overrideForkId)
+	}
+
+	err := db.InsertExecutionBlockSlot(blockSlot, tx)
+	if err != nil {
+		return fmt.Errorf("error inserting execution block mapping: %v", err)
+	}
+
+	return nil
+}
+
 func (dbw *dbWriter) persistEpochData(tx *sqlx.Tx, epoch phase0.Epoch, blocks []*Block, epochStats *EpochStats, epochVotes *EpochVotes, sim *stateSimulator) error {
 	if tx == nil {
-		return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		_, err := dbw.indexer.runDbTransaction(func(tx *sqlx.Tx) error {
 			return dbw.persistEpochData(tx, epoch, blocks, epochStats, epochVotes, sim)
 		})
+		return err
 	}
 	canonicalForkId := ForkKey(0)
 
@@ -160,9 +373,69 @@ func (dbw *dbWriter) persistEpochData(tx *sqlx.Tx, epoch phase0.Epoch, blocks []
 		return fmt.Errorf("error while saving epoch to db: %w", err)
 	}
 
+	// cleanup leftovers of previous writes & verify the written slots, so epochs can safely be written again
+	err = dbw.reconcileEpochSlots(tx, epoch, blocks)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// reconcileEpochSlots cleans up leftovers of a previous (partial) write of the epoch and verifies the slot rows afterwards.
+// each slot of the epoch must have at least one row, canonical rows must match the canonical blocks.
+func (dbw *dbWriter) reconcileEpochSlots(tx *sqlx.Tx, epoch phase0.Epoch, blocks []*Block) error {
+	chainState := dbw.indexer.consensusPool.GetChainState()
+	firstSlot := chainState.EpochStartSlot(epoch)
+	lastSlot := firstSlot + phase0.Slot(chainState.GetSpecs().SlotsPerEpoch) - 1
+
+	canonicalRoots := make([][]byte, len(blocks))
+	for i, block := range blocks {
+		canonicalRoots[i] = block.Root[:]
+	}
+
+	reconciled, err := db.ReconcileSlots(uint64(firstSlot), uint64(lastSlot), canonicalRoots, tx)
+	if err != nil {
+		return fmt.Errorf("error while reconciling epoch %v slots: %w", epoch, err)
+	}
+	if reconciled > 0 {
+		dbw.indexer.logger.Infof("reconciled %v stale slot rows in epoch %v", reconciled, epoch)
+	}
+
+	statusCounts, err := db.GetSlotStatusCounts(uint64(firstSlot), uint64(lastSlot), tx)
+	if err != nil {
+		return fmt.Errorf("error while verifying epoch %v slots: %w", epoch, err)
+	}
+
+	canonicalRows, err := verifySlotStatusCounts(statusCounts, firstSlot, lastSlot)
+	if err != nil {
+		return fmt.Errorf("error while verifying epoch %v slots: %w", epoch, err)
+	}
+	if canonicalRows != uint64(len(blocks)) {
+		return fmt.Errorf("error while verifying epoch %v slots: %v canonical slot rows, %v canonical blocks", epoch, canonicalRows, len(blocks))
+	}
+
 	return nil
 }
 
+// verifySlotStatusCounts checks that each slot of the slot range has been written and has at most one canonical row.
+// returns the number of canonical rows.
+func verifySlotStatusCounts(statusCounts []*dbtypes.SlotStatusCount, firstSlot phase0.Slot, lastSlot phase0.Slot) (uint64, error) {
+	if len(statusCounts) != int(lastSlot-firstSlot+1) {
+		return 0, fmt.Errorf("%v of %v slots written", len(statusCounts), lastSlot-firstSlot+1)
+	}
+
+	canonicalRows := uint64(0)
+	for _, statusCount := range statusCounts {
+		if statusCount.Canonical > 1 {
+			return 0, fmt.Errorf("slot %v has %v canonical rows", statusCount.Slot, statusCount.Canonical)
+		}
+		canonicalRows += statusCount.Canonical
+	}
+
+	return canonicalRows, nil
+}
+
 func (dbw *dbWriter) persistSyncAssignments(tx *sqlx.Tx, epoch phase0.Epoch, epochStats *EpochStats) error {
 	chainState := dbw.indexer.consensusPool.GetChainState()
 	specs := chainState.GetSpecs()
@@ -224,8 +497,8 @@ func (dbw *dbWriter) buildDbBlock(block *Block, epochStats *EpochStats, override
 		}
 	}
 
-	blockBody := block.GetBlock()
-	if blockBody == nil {
+	bodyFields := block.getBodyFields()
+	if bodyFields == nil {
 		dbw.indexer.logger.Warnf("error while building db blocks: block body not found: %v", block.Slot)
 		return nil
 	}
@@ -235,27 +508,6 @@ func (dbw *dbWriter) buildDbBlock(block *Block, epochStats *EpochStats, override
 		epochStatsValues = epochStats.GetValues(true)
 	}
 
-	graffiti, _ := blockBody.Graffiti()
-	attestations, _ := blockBody.Attestations()
-	deposits, _ := blockBody.Deposits()
-	voluntaryExits, _ := blockBody.VoluntaryExits()
-	attesterSlashings, _ := blockBody.AttesterSlashings()
-	proposerSlashings, _ := blockBody.ProposerSlashings()
-	blsToExecChanges, _ := blockBody.BLSToExecutionChanges()
-	syncAggregate, _ := blockBody.SyncAggregate()
-	executionBlockNumber, _ := blockBody.ExecutionBlockNumber()
-	executionBlockHash, _ := blockBody.ExecutionBlockHash()
-	executionExtraData, _ := getBlockExecutionExtraData(blockBody)
-	executionTransactions, _ := blockBody.ExecutionTransactions()
-	executionWithdrawals, _ := blockBody.Withdrawals()
-
-	var depositRequests []*electra.DepositRequest
-
-	executionRequests, _ := blockBody.ExecutionRequests()
-	if executionRequests != nil {
-		depositRequests = executionRequests.Deposits
-	}
-
 	dbBlock := dbtypes.Slot{
 		Slot:                  uint64(block.header.Message.Slot),
 		Proposer:              uint64(block.header.Message.ProposerIndex),
@@ -264,50 +516,56 @@ func (dbw *dbWriter) buildDbBlock(block *Block, epochStats *EpochStats, override
 		Root:                  block.Root[:],
 		ParentRoot:            block.header.Message.ParentRoot[:],
 		StateRoot:             block.header.Message.StateRoot[:],
-		Graffiti:              graffiti[:],
-		GraffitiText:          utils.GraffitiToString(graffiti[:]),
-		AttestationCount:      uint64(len(attestations)),
-		DepositCount:          uint64(len(deposits) + len(depositRequests)),
-		ExitCount:             uint64(len(voluntaryExits)),
-		AttesterSlashingCount: uint64(len(attesterSlashings)),
-		ProposerSlashingCount: uint64(len(proposerSlashings)),
-		BLSChangeCount:        uint64(len(blsToExecChanges)),
+		Graffiti:              bodyFields.Graffiti[:],
+		GraffitiText:          utils.GraffitiToString(bodyFields.Graffiti[:]),
+		AttestationCount:      bodyFields.AttestationCount,
+		DepositCount:          bodyFields.DepositCount,
+		ExitCount:             bodyFields.ExitCount,
+		AttesterSlashingCount: bodyFields.AttesterSlashingCount,
+		ProposerSlashingCount: bodyFields.ProposerSlashingCount,
+		BLSChangeCount:        bodyFields.BLSChangeCount,
 	}
 
 	if overrideForkId != nil {
 		dbBlock.ForkId = uint64(*overrideForkId)
 	}
 
-	if syncAggregate != nil {
+	if bodyFields.SyncCommitteeBits != nil {
 		var assignedCount int
 		if epochStatsValues != nil {
 			assignedCount = len(epochStatsValues.SyncCommitteeDuties)
 		} else {
 			// this is not accurate, but best we can get without epoch assignments
-			assignedCount = len(syncAggregate.SyncCommitteeBits) * 8
+			assignedCount = len(bodyFields.SyncCommitteeBits) * 8
 		}
 
 		votedCount := 0
 		for i := 0; i < assignedCount; i++ {
-			if utils.BitAtVector(syncAggregate.SyncCommitteeBits, i) {
+			if utils.BitAtVector(bodyFields.SyncCommitteeBits, i) {
 				votedCount++
 			}
 		}
 		dbBlock.SyncParticipation = float32(votedCount) / float32(assignedCount)
 	}
 
-	if executionBlockNumber > 0 {
-		dbBlock.EthTransactionCount = uint64(len(executionTransactions))
-		dbBlock.EthBlockNumber = &executionBlockNumber
-		dbBlock.EthBlockHash = executionBlockHash[:]
-		dbBlock.EthBlockExtra = executionExtraData
-		dbBlock.EthBlockExtraText = utils.GraffitiToString(executionExtraData[:])
-		dbBlock.WithdrawCount = uint64(len(executionWithdrawals))
-		for _, withdrawal := range executionWithdrawals {
-			dbBlock.WithdrawAmount += uint64(withdrawal.Amount)
-		}
+	if bodyFields.ExecutionNumber > 0 {
+		dbBlock.EthTransactionCount = bodyFields.TransactionCount
+		dbBlock.EthBlockNumber = &bodyFields.ExecutionNumber
+		dbBlock.EthBlockHash = bodyFields.ExecutionHash[:]
+		dbBlock.EthBlockExtra = bodyFields.ExecutionExtraData
+		dbBlock.EthBlockExtraText = utils.GraffitiToString(bodyFields.ExecutionExtraData[:])
+		dbBlock.EthFeeRecipient = bodyFields.ExecutionFeeRecipient[:]
+		dbBlock.EthGasUsed = bodyFields.ExecutionGasUsed
+		dbBlock.EthGasLimit = bodyFields.ExecutionGasLimit
+		dbBlock.EthBaseFee = bodyFields.ExecutionBaseFee
+		dbBlock.WithdrawCount = bodyFields.WithdrawalCount
+		dbBlock.WithdrawAmount = bodyFields.WithdrawalAmount
 	}
 
+	blockClients := ClassifyBlockClients(dbBlock.GraffitiText, dbBlock.EthBlockExtraText)
+	dbBlock.ClClient = int8(blockClients.ClClient)
+	dbBlock.ElClient = int8(blockClients.ElClient)
+
 	return &dbBlock
 }
 
@@ -534,6 +792,79 @@ func (dbw *dbWriter) buildDbDepositRequests(block *Block, orphaned bool, overrid
 	return dbDeposits
 }
 
+func (dbw *dbWriter) persistBlockAttestations(tx *sqlx.Tx, block *Block, orphaned bool) error {
+	// insert attestations
+	dbAttestations := dbw.buildDbAttestations(block, orphaned)
+	if len(dbAttestations) > 0 {
+		err := db.InsertSlotAttestations(dbAttestations, tx)
+		if err != nil {
+			return fmt.Errorf("error inserting attestations: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (dbw *dbWriter) buildDbAttestations(block *Block, orphaned bool) []*dbtypes.SlotAttestation {
+	blockBody := block.GetBlock()
+	if blockBody == nil {
+		return nil
+	}
+
+	attestations, err := blockBody.Attestations()
+	if err != nil {
+		return nil
+	}
+
+	dbAttestations := make([]*dbtypes.SlotAttestation, 0, len(attestations))
+	for idx, attVersioned := range attestations {
+		attData, err := attVersioned.Data()
+		if err != nil || attData == nil {
+			continue
+		}
+
+		aggregationBits, err := attVersioned.AggregationBits()
+		if err != nil {
+			continue
+		}
+
+		signature, err := attVersioned.Signature()
+		if err != nil {
+			continue
+		}
+
+		dbAttestation := &dbtypes.SlotAttestation{
+			SlotRoot:        block.Root[:],
+			SlotIndex:       uint64(idx),
+			SlotNumber:      uint64(block.Slot),
+			Orphaned:        orphaned,
+			AttSlot:         uint64(attData.Slot),
+			CommitteeIndex:  uint64(attData.Index),
+			AggregationBits: aggregationBits,
+			BeaconBlockRoot: attData.BeaconBlockRoot[:],
+			SourceEpoch:     uint64(attData.Source.Epoch),
+			SourceRoot:      attData.Source.Root[:],
+			TargetEpoch:     uint64(attData.Target.Epoch),
+			TargetRoot:      attData.Target.Root[:],
+			Signature:       signature[:],
+		}
+
+		if attVersioned.Version >= spec.DataVersionElectra {
+			committeeBits, err := attVersioned.CommitteeBits()
+			if err != nil {
+				continue
+			}
+
+			dbAttestation.CommitteeIndex = 0
+			dbAttestation.CommitteeBits = committeeBits
+		}
+
+		dbAttestations = append(dbAttestations, dbAttestation)
+	}
+
+	return dbAttestations
+}
+
 func (dbw *dbWriter) persistBlockVoluntaryExits(tx *sqlx.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
 	// insert voluntary exits
 	dbVoluntaryExits := dbw.buildDbVoluntaryExits(block, orphaned, overrideForkId)
@@ -635,8 +966,7 @@ func (dbw *dbWriter) buildDbSlashings(block *Block, orphaned bool, overrideForkI
 	}
 
 	for _, attesterSlashing := range attesterSlashings {
-		att1, _ := attesterSlashing.Attestation1()
-		att2, _ := attesterSlashing.Attestation2()
+		att1, att2 := GetAttesterSlashingAttestations(&attesterSlashing)
 		if att1 == nil || att2 == nil {
 			continue
 		}
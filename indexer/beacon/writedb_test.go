@@ -0,0 +1,194 @@
+package beacon
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// newCorpusBlock creates a cached block with header & body of the corpus fixture.
+func newCorpusBlock(t *testing.T, indexer *Indexer, fixture *corpusFixture, forkId ForkKey) *Block {
+	t.Helper()
+
+	header, root, err := getBlockHeader(indexer.dynSsz, fixture.block)
+	if err != nil {
+		t.Fatalf("%v: failed building header: %v", fixture.version, err)
+	}
+
+	block, _ := indexer.blockCache.createOrGetBlock(root, header.Message.Slot)
+	block.forkId = forkId
+	block.SetHeader(header)
+	block.SetBlock(fixture.block)
+
+	return block
+}
+
+func TestBuildDbBlock(t *testing.T) {
+	indexer := newTestIndexer(t, newCorpusDynSsz())
+
+	for _, fixture := range loadCorpus(t) {
+		t.Run(fixture.version.String(), func(t *testing.T) {
+			block := newCorpusBlock(t, indexer, fixture, 3)
+			fields := expectedCorpusBodyFields(fixture.version)
+
+			dbBlock := indexer.dbWriter.buildDbBlock(block, nil, nil)
+			if dbBlock == nil {
+				t.Fatalf("failed building db block")
+			}
+
+			if dbBlock.Slot != uint64(block.Slot) || dbBlock.Proposer != 11 || dbBlock.Status != dbtypes.Canonical || dbBlock.ForkId != 3 {
+				t.Errorf("unexpected slot properties: %+v", dbBlock)
+			}
+			if !bytes.Equal(dbBlock.Root, block.Root[:]) || !bytes.Equal(dbBlock.ParentRoot, block.header.Message.ParentRoot[:]) || !bytes.Equal(dbBlock.StateRoot, block.header.Message.StateRoot[:]) {
+				t.Errorf("unexpected slot roots")
+			}
+			if dbBlock.AttestationCount != fields.AttestationCount || dbBlock.DepositCount != fields.DepositCount || dbBlock.ExitCount != fields.ExitCount ||
+				dbBlock.AttesterSlashingCount != fields.AttesterSlashingCount || dbBlock.ProposerSlashingCount != fields.ProposerSlashingCount || dbBlock.BLSChangeCount != fields.BLSChangeCount {
+				t.Errorf("unexpected operation counts: %+v", dbBlock)
+			}
+			if dbBlock.ClClient != int8(consensus.LighthouseClient) {
+				t.Errorf("unexpected cl client %v", dbBlock.ClClient)
+			}
+
+			if fixture.version >= spec.DataVersionAltair && dbBlock.SyncParticipation != 0.75 {
+				// 0xb7 = 6 of 8 bits set
+				t.Errorf("unexpected sync participation %v", dbBlock.SyncParticipation)
+			}
+
+			if fixture.version < spec.DataVersionBellatrix {
+				if dbBlock.EthBlockNumber != nil {
+					t.Errorf("unexpected execution block number before bellatrix")
+				}
+				return
+			}
+
+			if dbBlock.EthBlockNumber == nil || *dbBlock.EthBlockNumber != fields.ExecutionNumber {
+				t.Errorf("unexpected execution block number")
+			}
+			if dbBlock.EthTransactionCount != fields.TransactionCount || dbBlock.EthGasUsed != fields.ExecutionGasUsed || dbBlock.EthGasLimit != fields.ExecutionGasLimit || dbBlock.EthBaseFee != fields.ExecutionBaseFee {
+				t.Errorf("unexpected execution properties: %+v", dbBlock)
+			}
+			if dbBlock.EthBlockExtraText != "Geth/v1.14.0/linux" {
+				t.Errorf("unexpected extra data %q", dbBlock.EthBlockExtraText)
+			}
+			if dbBlock.WithdrawCount != fields.WithdrawalCount || dbBlock.WithdrawAmount != fields.WithdrawalAmount {
+				t.Errorf("unexpected withdrawals %v / %v", dbBlock.WithdrawCount, dbBlock.WithdrawAmount)
+			}
+		})
+	}
+}
+
+func TestBuildDbBlockFromPrunedBody(t *testing.T) {
+	indexer := newTestIndexer(t, newCorpusDynSsz())
+
+	// a block without body & db entry can't be built
+	for _, fixture := range loadCorpus(t) {
+		block := newCorpusBlock(t, indexer, fixture, 0)
+		block.block = nil
+
+		if dbBlock := indexer.dbWriter.buildDbBlock(block, nil, nil); dbBlock != nil {
+			t.Errorf("%v: expected no db block for pruned body", fixture.version)
+		}
+	}
+}
+
+func TestBuildDbOperations(t *testing.T) {
+	indexer := newTestIndexer(t, newCorpusDynSsz())
+
+	for _, fixture := range loadCorpus(t) {
+		t.Run(fixture.version.String(), func(t *testing.T) {
+			block := newCorpusBlock(t, indexer, fixture, 5)
+			overrideForkId := ForkKey(7)
+
+			depositIndex := uint64(100)
+			deposits := indexer.dbWriter.buildDbDeposits(block, &depositIndex, false, nil)
+			if len(deposits) != 2 || depositIndex != 102 {
+				t.Fatalf("unexpected deposits: %v (index %v)", len(deposits), depositIndex)
+			}
+			if *deposits[0].Index != 100 || *deposits[1].Index != 101 || deposits[1].SlotIndex != 1 || deposits[0].ForkId != 5 {
+				t.Errorf("unexpected deposit properties")
+			}
+
+			attestations := indexer.dbWriter.buildDbAttestations(block, true)
+			if uint64(len(attestations)) != expectedCorpusBodyFields(fixture.version).AttestationCount {
+				t.Fatalf("unexpected attestation count %v", len(attestations))
+			}
+			for _, attestation := range attestations {
+				if !attestation.Orphaned || attestation.SlotNumber != uint64(block.Slot) || len(attestation.AggregationBits) == 0 {
+					t.Errorf("unexpected attestation properties")
+				}
+				if fixture.version >= spec.DataVersionElectra && len(attestation.CommitteeBits) == 0 {
+					t.Errorf("missing committee bits for electra attestation")
+				}
+			}
+
+			exits := indexer.dbWriter.buildDbVoluntaryExits(block, false, &overrideForkId)
+			if len(exits) != 2 || exits[0].ValidatorIndex != 42 || exits[1].ValidatorIndex != 43 || exits[0].ForkId != 7 {
+				t.Errorf("unexpected voluntary exits")
+			}
+
+			// 1 proposer slashing & the 2 validators in both attestations of the attester slashing
+			slashings := indexer.dbWriter.buildDbSlashings(block, false, nil)
+			if len(slashings) != 3 {
+				t.Fatalf("unexpected slashing count %v", len(slashings))
+			}
+			if slashings[0].Reason != dbtypes.ProposerSlashing || slashings[0].ValidatorIndex != 17 || slashings[0].SlasherIndex != 11 {
+				t.Errorf("unexpected proposer slashing")
+			}
+			if slashings[1].Reason != dbtypes.AttesterSlashing || slashings[1].ValidatorIndex != 5 || slashings[2].ValidatorIndex != 13 {
+				t.Errorf("unexpected attester slashings")
+			}
+
+			depositRequests := indexer.dbWriter.buildDbDepositRequests(block, false, nil)
+			withdrawalRequests := indexer.dbWriter.buildDbWithdrawalRequests(block, false, nil, nil)
+			consolidationRequests := indexer.dbWriter.buildDbConsolidationRequests(block, false, nil, nil)
+			if fixture.version < spec.DataVersionElectra {
+				if len(depositRequests) != 0 || len(withdrawalRequests) != 0 || len(consolidationRequests) != 0 {
+					t.Errorf("unexpected execution requests before electra")
+				}
+				return
+			}
+
+			if len(depositRequests) != 2 || *depositRequests[0].Index != 1024 || *depositRequests[1].Index != 1025 {
+				t.Errorf("unexpected deposit requests")
+			}
+			if len(withdrawalRequests) != 2 || db.ConvertInt64ToUint64(withdrawalRequests[1].Amount) != 1000000000 {
+				t.Errorf("unexpected withdrawal requests")
+			}
+			if len(consolidationRequests) != 1 || consolidationRequests[0].SourceIndex != nil {
+				t.Errorf("unexpected consolidation requests")
+			}
+		})
+	}
+}
+
+func TestBuildDbRequestsResolvesIndexes(t *testing.T) {
+	indexer := newTestIndexer(t, newCorpusDynSsz())
+
+	for _, fixture := range loadCorpus(t) {
+		if fixture.version < spec.DataVersionElectra {
+			continue
+		}
+
+		requests := fixture.block.Electra.Message.Body.ExecutionRequests
+		indexer.pubkeyCache.Add(requests.Withdrawals[0].ValidatorPubkey, 55)
+		indexer.pubkeyCache.Add(requests.Consolidations[0].SourcePubkey, 56)
+		indexer.pubkeyCache.Add(requests.Consolidations[0].TargetPubkey, 57)
+
+		block := newCorpusBlock(t, indexer, fixture, 0)
+
+		withdrawalRequests := indexer.dbWriter.buildDbWithdrawalRequests(block, false, nil, nil)
+		if withdrawalRequests[0].ValidatorIndex == nil || *withdrawalRequests[0].ValidatorIndex != 55 || withdrawalRequests[1].ValidatorIndex != nil {
+			t.Errorf("unexpected withdrawal request validator indexes")
+		}
+
+		consolidationRequests := indexer.dbWriter.buildDbConsolidationRequests(block, false, nil, nil)
+		if consolidationRequests[0].SourceIndex == nil || *consolidationRequests[0].SourceIndex != 56 || consolidationRequests[0].TargetIndex == nil || *consolidationRequests[0].TargetIndex != 57 {
+			t.Errorf("unexpected consolidation request validator indexes")
+		}
+	}
+}
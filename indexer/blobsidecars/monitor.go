@@ -0,0 +1,349 @@
+package blobsidecars
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// BlockBlobAvailability holds the blob sidecar availability of a block on all online consensus clients
+type BlockBlobAvailability struct {
+	Slot      phase0.Slot
+	BlockRoot phase0.Root
+	BlobCount uint64
+	Clients   []*ClientBlobAvailability
+	Diverging bool // at least one client is missing blobs
+	Invalid   bool // at least one client served sidecars that failed verification
+}
+
+// ClientBlobAvailability holds the blob sidecars a consensus client had available for a block
+type ClientBlobAvailability struct {
+	ClientName     string
+	Available      []bool       // by blob index
+	Status         []BlobStatus // by blob index
+	AvailableCount uint64
+	InvalidCount   uint64
+	Results        []string // descriptions of the failed sidecar verifications
+	FetchError     string
+}
+
+// DivergenceHook is called for each polled block where at least one client is missing blobs or served invalid sidecars.
+// hooks are called synchronously from the monitor routine, so they must not block.
+type DivergenceHook func(availability *BlockBlobAvailability)
+
+// BlobSidecarMonitor polls the blob sidecars of new blocks from all consensus clients to detect blob propagation issues.
+// the availability of the recent blocks is kept in memory only.
+type BlobSidecarMonitor struct {
+	logger         logrus.FieldLogger
+	beaconIndexer  *beacon.Indexer
+	chainState     *consensus.ChainState
+	updaterRunning bool
+	mutex          sync.RWMutex
+	blocks         []*BlockBlobAvailability
+	hooks          []DivergenceHook
+	verifier       *KzgVerifier
+}
+
+// NewBlobSidecarMonitor creates a new blob sidecar availability monitor.
+func NewBlobSidecarMonitor(logger logrus.FieldLogger, beaconIndexer *beacon.Indexer, chainState *consensus.ChainState) *BlobSidecarMonitor {
+	return &BlobSidecarMonitor{
+		logger:        logger,
+		beaconIndexer: beaconIndexer,
+		chainState:    chainState,
+		blocks:        []*BlockBlobAvailability{},
+	}
+}
+
+// AddHook adds a hook that is called for each block with diverging blob availability
+func (bsm *BlobSidecarMonitor) AddHook(hook DivergenceHook) {
+	bsm.mutex.Lock()
+	defer bsm.mutex.Unlock()
+
+	bsm.hooks = append(bsm.hooks, hook)
+}
+
+// SetKzgVerifier enables the kzg verification of the polled sidecars.
+// the full sidecars are loaded from the clients and the verification results are persisted to the db.
+func (bsm *BlobSidecarMonitor) SetKzgVerifier(verifier *KzgVerifier) {
+	bsm.verifier = verifier
+}
+
+// GetRecentBlocks returns the blob availability of the recent blocks, newest first
+func (bsm *BlobSidecarMonitor) GetRecentBlocks() []*BlockBlobAvailability {
+	bsm.mutex.RLock()
+	defer bsm.mutex.RUnlock()
+
+	blocks := make([]*BlockBlobAvailability, len(bsm.blocks))
+	for idx, block := range bsm.blocks {
+		blocks[len(bsm.blocks)-idx-1] = block
+	}
+
+	return blocks
+}
+
+func (bsm *BlobSidecarMonitor) StartUpdater() {
+	if bsm.updaterRunning {
+		return
+	}
+
+	bsm.updaterRunning = true
+	go bsm.runUpdaterLoop()
+}
+
+func (bsm *BlobSidecarMonitor) runUpdaterLoop() {
+	defer utils.HandleSubroutinePanic("BlobSidecarMonitor.runUpdaterLoop", bsm.runUpdaterLoop)
+
+	nextSlot := bsm.chainState.CurrentSlot()
+	for {
+		// wait for the sidecars of the slot to propagate
+		pollTime := bsm.chainState.SlotToTime(nextSlot).Add(utils.Config.BlobAvailability.PollDelay)
+		if waitTime := time.Until(pollTime); waitTime > 0 {
+			time.Sleep(waitTime)
+		}
+
+		slot := nextSlot
+		nextSlot = bsm.chainState.TimeToSlot(time.Now().Add(-utils.Config.BlobAvailability.PollDelay)) + 1
+
+		specs := bsm.chainState.GetSpecs()
+		epoch := bsm.chainState.EpochOfSlot(slot)
+		if specs.DenebForkEpoch == nil || epoch < phase0.Epoch(*specs.DenebForkEpoch) {
+			continue
+		}
+		if peerDASEpoch := bsm.chainState.GetPeerDASForkEpoch(); peerDASEpoch != nil && epoch >= *peerDASEpoch {
+			// blobs are distributed as data columns with PeerDAS
+			continue
+		}
+
+		bsm.processSlot(slot)
+	}
+}
+
+// processSlot polls the blob sidecars of all blob carrying blocks of the slot
+func (bsm *BlobSidecarMonitor) processSlot(slot phase0.Slot) {
+	for _, block := range bsm.beaconIndexer.GetBlocksBySlot(slot) {
+		blockBody := block.GetBlock()
+		if blockBody == nil {
+			continue
+		}
+
+		commitments, err := blockBody.BlobKZGCommitments()
+		if err != nil || len(commitments) == 0 {
+			continue
+		}
+
+		availability := bsm.loadBlockAvailability(block.Root, slot, commitments)
+		if len(availability.Clients) == 0 {
+			continue
+		}
+
+		if bsm.verifier != nil {
+			err := bsm.persistVerifications(availability)
+			if err != nil {
+				bsm.logger.Errorf("failed persisting blob verifications for block %v [%v]: %v", slot, block.Root.String(), err)
+			}
+		}
+
+		bsm.mutex.Lock()
+		bsm.blocks = append(bsm.blocks, availability)
+		sort.Slice(bsm.blocks, func(a, b int) bool {
+			return bsm.blocks[a].Slot < bsm.blocks[b].Slot
+		})
+
+		// prune blocks beyond the history limit
+		minSlot := phase0.Slot(0)
+		if uint64(slot) > utils.Config.BlobAvailability.HistorySlots {
+			minSlot = slot - phase0.Slot(utils.Config.BlobAvailability.HistorySlots)
+		}
+		pruneCount := 0
+		for pruneCount < len(bsm.blocks) && bsm.blocks[pruneCount].Slot < minSlot {
+			pruneCount++
+		}
+		bsm.blocks = bsm.blocks[pruneCount:]
+
+		hooks := bsm.hooks
+		bsm.mutex.Unlock()
+
+		if availability.Invalid {
+			bsm.logger.Errorf("blob verification failed for block %v [%v]: %v", slot, block.Root.String(), getInvalidSummary(availability))
+		}
+		if availability.Diverging {
+			bsm.logger.Warnf("blob availability diverges for block %v [%v]: %v", slot, block.Root.String(), getMissingSummary(availability))
+		}
+		if availability.Diverging || availability.Invalid {
+			for _, hook := range hooks {
+				hook(availability)
+			}
+		}
+	}
+}
+
+// loadBlockAvailability requests the available blob sidecars of a block from all online clients
+func (bsm *BlobSidecarMonitor) loadBlockAvailability(blockRoot phase0.Root, slot phase0.Slot, commitments []deneb.KZGCommitment) *BlockBlobAvailability {
+	blobCount := uint64(len(commitments))
+	clients := bsm.beaconIndexer.GetAllClients()
+	results := make([]*ClientBlobAvailability, len(clients))
+
+	wg := &sync.WaitGroup{}
+	for idx, client := range clients {
+		if client.GetClient().GetStatus() != consensus.ClientStatusOnline {
+			continue
+		}
+
+		wg.Add(1)
+		go func(idx int, client *beacon.Client) {
+			defer wg.Done()
+
+			result := &ClientBlobAvailability{
+				ClientName: client.GetClient().GetName(),
+				Available:  make([]bool, blobCount),
+				Status:     make([]BlobStatus, blobCount),
+			}
+
+			ctx, cancel := context.WithTimeout(client.GetClient().GetContext(), utils.Config.BlobAvailability.RequestTimeout)
+			defer cancel()
+
+			if bsm.verifier != nil {
+				bsm.loadVerifiedSidecars(ctx, client, blockRoot, commitments, result)
+			} else {
+				indexes, err := client.GetClient().GetRPCClient().GetBlobSidecarIndexes(ctx, blockRoot)
+				if err != nil {
+					result.FetchError = err.Error()
+				}
+
+				for _, index := range indexes {
+					if index < blobCount && !result.Available[index] {
+						result.Available[index] = true
+						result.Status[index] = BlobStatusAvailable
+						result.AvailableCount++
+					}
+				}
+			}
+
+			results[idx] = result
+		}(idx, client)
+	}
+	wg.Wait()
+
+	availability := &BlockBlobAvailability{
+		Slot:      slot,
+		BlockRoot: blockRoot,
+		BlobCount: blobCount,
+		Clients:   make([]*ClientBlobAvailability, 0, len(results)),
+	}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+
+		availability.Clients = append(availability.Clients, result)
+		if result.AvailableCount < blobCount {
+			availability.Diverging = true
+		}
+		if result.InvalidCount > 0 {
+			availability.Invalid = true
+		}
+	}
+
+	return availability
+}
+
+// loadVerifiedSidecars loads the full blob sidecars of a block from a client and verifies them against the block's commitments.
+// sidecars that fail verification are not counted as available.
+func (bsm *BlobSidecarMonitor) loadVerifiedSidecars(ctx context.Context, client *beacon.Client, blockRoot phase0.Root, commitments []deneb.KZGCommitment, result *ClientBlobAvailability) {
+	sidecars, err := client.GetClient().GetRPCClient().GetBlobSidecarsByBlockroot(ctx, blockRoot[:])
+	if err != nil {
+		result.FetchError = err.Error()
+		return
+	}
+
+	for _, sidecar := range sidecars {
+		status, err := bsm.verifier.VerifySidecar(blockRoot, commitments, sidecar)
+		if err != nil {
+			result.Results = append(result.Results, fmt.Sprintf("blob %v: %v", sidecar.Index, err))
+		}
+
+		index := uint64(sidecar.Index)
+		if index >= uint64(len(commitments)) {
+			// out of range sidecar, can't be assigned to a blob of the block
+			result.InvalidCount++
+			continue
+		}
+		if result.Status[index] != BlobStatusMissing {
+			// duplicate sidecar, keep the first one
+			continue
+		}
+
+		result.Status[index] = status
+		if status.IsInvalid() {
+			result.InvalidCount++
+		} else {
+			result.Available[index] = true
+			result.AvailableCount++
+		}
+	}
+}
+
+// persistVerifications persists the kzg verification results of all clients for a block
+func (bsm *BlobSidecarMonitor) persistVerifications(availability *BlockBlobAvailability) error {
+	verifications := make([]*dbtypes.BlobVerification, 0, len(availability.Clients))
+	for _, client := range availability.Clients {
+		if client.FetchError != "" {
+			continue
+		}
+
+		verifications = append(verifications, &dbtypes.BlobVerification{
+			BlockRoot:    availability.BlockRoot[:],
+			ClientName:   client.ClientName,
+			Slot:         uint64(availability.Slot),
+			BlobCount:    uint16(availability.BlobCount),
+			ValidCount:   uint16(client.AvailableCount),
+			InvalidCount: uint16(client.InvalidCount),
+			Result:       strings.Join(client.Results, "; "),
+		})
+	}
+
+	if len(verifications) == 0 {
+		return nil
+	}
+
+	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertBlobVerifications(verifications, tx)
+	})
+}
+
+// getInvalidSummary returns the failed sidecar verifications of all clients
+func getInvalidSummary(availability *BlockBlobAvailability) string {
+	invalid := []string{}
+	for _, client := range availability.Clients {
+		if client.InvalidCount > 0 {
+			invalid = append(invalid, fmt.Sprintf("%v (%v)", client.ClientName, strings.Join(client.Results, "; ")))
+		}
+	}
+
+	return strings.Join(invalid, ", ")
+}
+
+func getMissingSummary(availability *BlockBlobAvailability) string {
+	missing := []string{}
+	for _, client := range availability.Clients {
+		if client.AvailableCount < availability.BlobCount {
+			missing = append(missing, fmt.Sprintf("%v (%v/%v)", client.ClientName, client.AvailableCount, availability.BlobCount))
+		}
+	}
+
+	return strings.Join(missing, ", ")
+}
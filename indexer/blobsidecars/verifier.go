@@ -0,0 +1,118 @@
+package blobsidecars
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+)
+
+// BlobStatus is the availability & verification status of a blob sidecar served by a client
+type BlobStatus uint8
+
+const (
+	BlobStatusMissing            BlobStatus = iota
+	BlobStatusAvailable                     // available, not verified
+	BlobStatusValid                         // commitment & kzg proof verified
+	BlobStatusHeaderMismatch                // sidecar belongs to a different block
+	BlobStatusCommitmentMismatch            // commitment differs from the block's commitment at the sidecar index
+	BlobStatusInvalidProof                  // kzg proof does not match the blob & commitment
+)
+
+// IsInvalid returns true if the sidecar failed verification
+func (status BlobStatus) IsInvalid() bool {
+	return status >= BlobStatusHeaderMismatch
+}
+
+// String returns a readable name of the blob status
+func (status BlobStatus) String() string {
+	switch status {
+	case BlobStatusMissing:
+		return "missing"
+	case BlobStatusAvailable:
+		return "available"
+	case BlobStatusValid:
+		return "valid"
+	case BlobStatusHeaderMismatch:
+		return "header mismatch"
+	case BlobStatusCommitmentMismatch:
+		return "commitment mismatch"
+	case BlobStatusInvalidProof:
+		return "invalid proof"
+	default:
+		return "unknown"
+	}
+}
+
+// KzgVerifier verifies blob sidecars against the kzg commitments of their block
+type KzgVerifier struct {
+	kzgContext *gokzg4844.Context
+}
+
+// NewKzgVerifier creates a new kzg verifier with the given trusted setup file (json with g1_lagrange & g2_monomial points).
+// the trusted setup of the ethereum kzg ceremony is used if no file is given.
+func NewKzgVerifier(trustedSetupFile string) (*KzgVerifier, error) {
+	var kzgContext *gokzg4844.Context
+	var err error
+
+	if trustedSetupFile == "" {
+		kzgContext, err = gokzg4844.NewContext4096Secure()
+	} else {
+		var setupJson []byte
+		setupJson, err = os.ReadFile(trustedSetupFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading trusted setup: %v", err)
+		}
+
+		trustedSetup := &gokzg4844.JSONTrustedSetup{}
+		err = json.Unmarshal(setupJson, trustedSetup)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing trusted setup: %v", err)
+		}
+
+		err = gokzg4844.CheckTrustedSetupIsWellFormed(trustedSetup)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted setup: %v", err)
+		}
+
+		kzgContext, err = gokzg4844.NewContext4096(trustedSetup)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed initializing kzg context: %v", err)
+	}
+
+	return &KzgVerifier{
+		kzgContext: kzgContext,
+	}, nil
+}
+
+// VerifySidecar verifies a blob sidecar against the block root & kzg commitments of its block
+func (verifier *KzgVerifier) VerifySidecar(blockRoot phase0.Root, commitments []deneb.KZGCommitment, sidecar *deneb.BlobSidecar) (BlobStatus, error) {
+	if sidecar.SignedBlockHeader != nil && sidecar.SignedBlockHeader.Message != nil {
+		headerRoot, err := sidecar.SignedBlockHeader.Message.HashTreeRoot()
+		if err != nil {
+			return BlobStatusHeaderMismatch, fmt.Errorf("failed hashing sidecar block header: %v", err)
+		}
+		if !bytes.Equal(headerRoot[:], blockRoot[:]) {
+			return BlobStatusHeaderMismatch, fmt.Errorf("sidecar block header root 0x%x does not match block", headerRoot)
+		}
+	}
+
+	if uint64(sidecar.Index) >= uint64(len(commitments)) {
+		return BlobStatusCommitmentMismatch, fmt.Errorf("sidecar index %v out of range (%v commitments)", sidecar.Index, len(commitments))
+	}
+	if !bytes.Equal(sidecar.KZGCommitment[:], commitments[sidecar.Index][:]) {
+		return BlobStatusCommitmentMismatch, fmt.Errorf("sidecar commitment 0x%x does not match block commitment 0x%x", sidecar.KZGCommitment[:], commitments[sidecar.Index][:])
+	}
+
+	err := verifier.kzgContext.VerifyBlobKZGProof((*gokzg4844.Blob)(&sidecar.Blob), gokzg4844.KZGCommitment(sidecar.KZGCommitment), gokzg4844.KZGProof(sidecar.KZGProof))
+	if err != nil {
+		return BlobStatusInvalidProof, fmt.Errorf("kzg proof verification failed: %v", err)
+	}
+
+	return BlobStatusValid, nil
+}
@@ -0,0 +1,113 @@
+package blockprint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const blockprintInsertBatchSize = 1000
+
+// Blockprint is an enricher that loads the proposer client classification of blocks from a blockprint api.
+type Blockprint struct {
+	logger logrus.FieldLogger
+	apiUrl string
+	client *http.Client
+}
+
+type blockprintBlockResponse struct {
+	Slot            uint64             `json:"slot"`
+	ProposerIndex   uint64             `json:"proposer_index"`
+	BestGuessSingle string             `json:"best_guess_single"`
+	ProbabilityMap  map[string]float64 `json:"probability_map"`
+}
+
+// NewBlockprint creates a new blockprint enricher for the given api url.
+func NewBlockprint(logger logrus.FieldLogger, apiUrl string) *Blockprint {
+	return &Blockprint{
+		logger: logger,
+		apiUrl: apiUrl,
+		client: &http.Client{Timeout: time.Second * 60},
+	}
+}
+
+// GetName returns the name of the enricher.
+func (bp *Blockprint) GetName() string {
+	return "blockprint"
+}
+
+// LoadSlots loads the client classifications for all blocks in the given slot range from the blockprint api.
+func (bp *Blockprint) LoadSlots(ctx context.Context, firstSlot phase0.Slot, lastSlot phase0.Slot) (func(tx *sqlx.Tx) error, error) {
+	apiUrl, err := url.Parse(bp.apiUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blockprint url: %v", err)
+	}
+
+	apiUrl.Path = path.Join(apiUrl.Path, fmt.Sprintf("/blocks/%v/%v", firstSlot, lastSlot))
+	bp.logger.Debugf("loading blockprint classifications: %v", utils.GetRedactedUrl(apiUrl.String()))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := bp.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch blockprint classifications (%v): %v", utils.GetRedactedUrl(apiUrl.String()), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(apiUrl.String()), data)
+	}
+
+	blocksResponse := []*blockprintBlockResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&blocksResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing blockprint response: %v", err)
+	}
+
+	blockprints := make([]*dbtypes.BlockprintSlot, 0, len(blocksResponse))
+	for _, block := range blocksResponse {
+		if block.Slot < uint64(firstSlot) || block.Slot > uint64(lastSlot) {
+			continue
+		}
+
+		blockprints = append(blockprints, &dbtypes.BlockprintSlot{
+			Slot:        block.Slot,
+			Proposer:    block.ProposerIndex,
+			Client:      int8(consensus.ParseClientType(strings.ToLower(block.BestGuessSingle))),
+			Probability: float32(block.ProbabilityMap[block.BestGuessSingle]),
+		})
+	}
+
+	return func(tx *sqlx.Tx) error {
+		for start := 0; start < len(blockprints); start += blockprintInsertBatchSize {
+			end := start + blockprintInsertBatchSize
+			if end > len(blockprints) {
+				end = len(blockprints)
+			}
+
+			if err := db.InsertBlockprintSlots(blockprints[start:end], tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
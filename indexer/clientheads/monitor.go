@@ -0,0 +1,281 @@
+package clientheads
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const (
+	DivergenceReasonFork     = "fork"     // the client head is not part of the canonical chain
+	DivergenceReasonLagging  = "lagging"  // the client head is an ancestor of the canonical head
+	DivergenceReasonFinality = "finality" // the client reports a conflicting finalized checkpoint
+)
+
+// DivergenceHook is called when a divergence episode is opened or resolved (divergence.Resolved).
+// hooks are called synchronously from the monitor routine, so they must not block.
+type DivergenceHook func(divergence *dbtypes.ClientHeadDivergence)
+
+// HeadDivergenceMonitor compares the head & finalized checkpoint of all consensus clients against the canonical chain once per slot.
+// clients that diverge for more than the configured number of slots are tracked as divergence episodes in the db.
+type HeadDivergenceMonitor struct {
+	logger         logrus.FieldLogger
+	beaconIndexer  *beacon.Indexer
+	chainState     *consensus.ChainState
+	updaterRunning bool
+	loaded         bool
+	mutex          sync.Mutex
+	clients        map[string]*clientDivergence
+	hooks          []DivergenceHook
+}
+
+// clientDivergence holds the divergence state of a client that is currently off the canonical chain
+type clientDivergence struct {
+	since      phase0.Slot
+	divergence *dbtypes.ClientHeadDivergence // nil until the episode is opened
+}
+
+// NewHeadDivergenceMonitor creates a new client head divergence monitor.
+func NewHeadDivergenceMonitor(logger logrus.FieldLogger, beaconIndexer *beacon.Indexer, chainState *consensus.ChainState) *HeadDivergenceMonitor {
+	return &HeadDivergenceMonitor{
+		logger:        logger,
+		beaconIndexer: beaconIndexer,
+		chainState:    chainState,
+		clients:       map[string]*clientDivergence{},
+	}
+}
+
+// AddHook adds a hook that is called when a divergence episode is opened or resolved
+func (hdm *HeadDivergenceMonitor) AddHook(hook DivergenceHook) {
+	hdm.mutex.Lock()
+	defer hdm.mutex.Unlock()
+
+	hdm.hooks = append(hdm.hooks, hook)
+}
+
+func (hdm *HeadDivergenceMonitor) StartUpdater() {
+	if hdm.updaterRunning {
+		return
+	}
+
+	hdm.updaterRunning = true
+	go hdm.runUpdaterLoop()
+}
+
+func (hdm *HeadDivergenceMonitor) runUpdaterLoop() {
+	defer utils.HandleSubroutinePanic("HeadDivergenceMonitor.runUpdaterLoop", hdm.runUpdaterLoop)
+
+	nextSlot := hdm.chainState.CurrentSlot()
+	for {
+		// compare the heads late in the slot, when the clients had time to process the slot's block
+		checkDelay := hdm.chainState.GetSpecs().SecondsPerSlot * 2 / 3
+		checkTime := hdm.chainState.SlotToTime(nextSlot).Add(checkDelay)
+		if waitTime := time.Until(checkTime); waitTime > 0 {
+			time.Sleep(waitTime)
+		}
+
+		slot := nextSlot
+		nextSlot = hdm.chainState.TimeToSlot(time.Now().Add(-checkDelay)) + 1
+
+		if !hdm.loaded {
+			err := hdm.loadUnresolvedDivergences(slot)
+			if err != nil {
+				hdm.logger.Errorf("failed loading unresolved client head divergences: %v", err)
+				continue
+			}
+			hdm.loaded = true
+		}
+
+		hdm.checkClientHeads(slot)
+	}
+}
+
+// loadUnresolvedDivergences restores the open divergence episodes from the db.
+// episodes of clients that are not configured anymore are resolved.
+func (hdm *HeadDivergenceMonitor) loadUnresolvedDivergences(slot phase0.Slot) error {
+	divergences, err := db.GetUnresolvedClientHeadDivergences()
+	if err != nil {
+		return err
+	}
+
+	clientNames := map[string]bool{}
+	for _, client := range hdm.beaconIndexer.GetAllClients() {
+		clientNames[client.GetClient().GetName()] = true
+	}
+
+	hdm.mutex.Lock()
+	defer hdm.mutex.Unlock()
+
+	removed := []*dbtypes.ClientHeadDivergence{}
+	for _, divergence := range divergences {
+		if !clientNames[divergence.ClientName] {
+			divergence.EndSlot = uint64(slot)
+			divergence.Resolved = true
+			removed = append(removed, divergence)
+			continue
+		}
+
+		hdm.clients[divergence.ClientName] = &clientDivergence{
+			since:      phase0.Slot(divergence.StartSlot),
+			divergence: divergence,
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertClientHeadDivergences(removed, tx)
+	})
+}
+
+// checkClientHeads compares the heads of all online clients against the canonical head and updates the divergence episodes
+func (hdm *HeadDivergenceMonitor) checkClientHeads(slot phase0.Slot) {
+	canonicalHead := hdm.beaconIndexer.GetCanonicalHead(nil)
+	if canonicalHead == nil {
+		return
+	}
+
+	minSlots := phase0.Slot(utils.Config.HeadDivergence.MinSlots)
+	updated := []*dbtypes.ClientHeadDivergence{}
+	opened := []*dbtypes.ClientHeadDivergence{}
+	resolved := []*dbtypes.ClientHeadDivergence{}
+
+	hdm.mutex.Lock()
+	for _, client := range hdm.beaconIndexer.GetAllClients() {
+		consensusClient := client.GetClient()
+		switch consensusClient.GetStatus() {
+		case consensus.ClientStatusOffline, consensus.ClientStatusSynchronizing:
+			// the head of offline or syncing clients is not meaningful, keep the current state
+			continue
+		}
+
+		clientName := consensusClient.GetName()
+		headSlot, headRoot := consensusClient.GetLastHead()
+		finalizedEpoch, _, _, _ := consensusClient.GetFinalityCheckpoint()
+		reason := hdm.getDivergenceReason(consensusClient, canonicalHead)
+
+		state := hdm.clients[clientName]
+		if reason == "" {
+			if state != nil {
+				if state.divergence != nil {
+					state.divergence.EndSlot = uint64(slot)
+					state.divergence.Resolved = true
+					updated = append(updated, state.divergence)
+					resolved = append(resolved, state.divergence)
+				}
+				delete(hdm.clients, clientName)
+			}
+			continue
+		}
+
+		since := slot
+		if reason == DivergenceReasonLagging && headSlot < slot {
+			// the client is off since the first slot it did not follow
+			since = headSlot + 1
+		}
+		if state == nil {
+			state = &clientDivergence{
+				since: since,
+			}
+			hdm.clients[clientName] = state
+		} else if since < state.since {
+			state.since = since
+		}
+
+		if state.divergence == nil {
+			if slot < state.since+minSlots {
+				continue
+			}
+
+			state.divergence = &dbtypes.ClientHeadDivergence{
+				ClientName: clientName,
+				StartSlot:  uint64(state.since),
+			}
+			opened = append(opened, state.divergence)
+		}
+
+		distance := uint64(0)
+		if canonicalHead.Slot > headSlot {
+			distance = uint64(canonicalHead.Slot - headSlot)
+		}
+
+		divergence := state.divergence
+		divergence.EndSlot = uint64(slot)
+		divergence.Reason = reason
+		divergence.HeadSlot = uint64(headSlot)
+		divergence.HeadRoot = headRoot[:]
+		divergence.CanonicalSlot = uint64(canonicalHead.Slot)
+		divergence.CanonicalRoot = canonicalHead.Root[:]
+		divergence.FinalizedEpoch = uint64(finalizedEpoch)
+		if distance > divergence.MaxDistance {
+			divergence.MaxDistance = distance
+		}
+		updated = append(updated, divergence)
+	}
+	hooks := hdm.hooks
+	hdm.mutex.Unlock()
+
+	if len(updated) > 0 {
+		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+			return db.InsertClientHeadDivergences(updated, tx)
+		})
+		if err != nil {
+			hdm.logger.Errorf("failed persisting client head divergences: %v", err)
+		}
+	}
+
+	for _, divergence := range opened {
+		hdm.logger.Warnf("client %v diverges from the canonical chain since slot %v (%v, head: %v [0x%x])", divergence.ClientName, divergence.StartSlot, divergence.Reason, divergence.HeadSlot, divergence.HeadRoot)
+		for _, hook := range hooks {
+			hook(divergence)
+		}
+	}
+	for _, divergence := range resolved {
+		hdm.logger.Infof("client %v is back on the canonical chain (diverged from slot %v to %v)", divergence.ClientName, divergence.StartSlot, divergence.EndSlot)
+		for _, hook := range hooks {
+			hook(divergence)
+		}
+	}
+}
+
+// getDivergenceReason returns why the client diverges from the canonical chain, or an empty string if it follows the canonical head
+func (hdm *HeadDivergenceMonitor) getDivergenceReason(client *consensus.Client, canonicalHead *beacon.Block) string {
+	finalizedEpoch, finalizedRoot := hdm.chainState.GetFinalizedCheckpoint()
+	clientFinalizedEpoch, clientFinalizedRoot, _, _ := client.GetFinalityCheckpoint()
+	if finalizedEpoch > 0 && clientFinalizedEpoch == finalizedEpoch && !bytes.Equal(clientFinalizedRoot[:], finalizedRoot[:]) {
+		return DivergenceReasonFinality
+	}
+
+	headSlot, headRoot := client.GetLastHead()
+	if bytes.Equal(headRoot[:], canonicalHead.Root[:]) {
+		return ""
+	}
+
+	if isInChain, _ := hdm.beaconIndexer.GetBlockDistance(canonicalHead.Root, headRoot); isInChain {
+		// the client is ahead of the canonical head on the same chain
+		return ""
+	}
+
+	if isInChain, _ := hdm.beaconIndexer.GetBlockDistance(headRoot, canonicalHead.Root); isInChain {
+		return DivergenceReasonLagging
+	}
+
+	if headSlot <= hdm.chainState.GetFinalizedSlot() {
+		// the head is below finality and not in the block cache anymore, so the client is stuck behind the chain
+		return DivergenceReasonLagging
+	}
+
+	return DivergenceReasonFork
+}
@@ -0,0 +1,139 @@
+package datacolumns
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/holiman/uint256"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/consensus/rpc"
+)
+
+// columnConfig holds the PeerDAS parameters used to derive the custody columns of a node
+type columnConfig struct {
+	numberOfColumns       uint64
+	numberOfCustodyGroups uint64
+	custodyRequirement    uint64
+}
+
+// GetNumberOfColumns returns the number of data columns per block (NUMBER_OF_COLUMNS)
+func GetNumberOfColumns(specs *consensus.ChainSpec) uint64 {
+	if specs != nil && specs.NumberOfColumns != nil && *specs.NumberOfColumns > 0 {
+		return *specs.NumberOfColumns
+	}
+
+	return 128
+}
+
+func getColumnConfig(specs *consensus.ChainSpec) *columnConfig {
+	config := &columnConfig{
+		numberOfColumns:    GetNumberOfColumns(specs),
+		custodyRequirement: 4,
+	}
+
+	if specs.CustodyRequirement != nil {
+		config.custodyRequirement = *specs.CustodyRequirement
+	}
+
+	switch {
+	case specs.NumberOfCustodyGroups != nil && *specs.NumberOfCustodyGroups > 0:
+		config.numberOfCustodyGroups = *specs.NumberOfCustodyGroups
+	case specs.DataColumnSidecarSubnetCount != nil && *specs.DataColumnSidecarSubnetCount > 0:
+		// pre custody group specs (eip7594 devnets) assign the custody by subnet
+		config.numberOfCustodyGroups = *specs.DataColumnSidecarSubnetCount
+	default:
+		config.numberOfCustodyGroups = config.numberOfColumns
+	}
+
+	return config
+}
+
+// getNodeId returns the node id from the ENR of a node identity
+func getNodeId(identity *rpc.NodeIdentity) ([]byte, error) {
+	if identity == nil || identity.Enr == "" {
+		return nil, fmt.Errorf("no enr")
+	}
+
+	node, err := enode.Parse(enode.ValidSchemes, identity.Enr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid enr: %v", err)
+	}
+
+	nodeId := node.ID()
+	return nodeId[:], nil
+}
+
+// getCustodyGroupCount returns the custody group count advertised by a node, falls back to the custody requirement
+func (config *columnConfig) getCustodyGroupCount(identity *rpc.NodeIdentity) uint64 {
+	if identity != nil && identity.Metadata.CustodyGroupCount != "" {
+		count, err := strconv.ParseUint(identity.Metadata.CustodyGroupCount, 10, 64)
+		if err == nil && count > 0 {
+			return min(count, config.numberOfCustodyGroups)
+		}
+	}
+
+	return config.custodyRequirement
+}
+
+// getCustodyColumns returns the sorted column indexes a node needs to custody (get_custody_groups & compute_columns_for_custody_group)
+func (config *columnConfig) getCustodyColumns(nodeId []byte, custodyGroupCount uint64) []uint64 {
+	groups := make([]uint64, 0, custodyGroupCount)
+	if custodyGroupCount >= config.numberOfCustodyGroups {
+		for group := uint64(0); group < config.numberOfCustodyGroups; group++ {
+			groups = append(groups, group)
+		}
+	} else {
+		groupMap := make(map[uint64]bool, custodyGroupCount)
+		currentId := new(uint256.Int).SetBytes(nodeId)
+		one := uint256.NewInt(1)
+
+		for uint64(len(groups)) < custodyGroupCount {
+			// hash the little endian representation of the node id
+			idBytes := currentId.Bytes32()
+			for i, j := 0, len(idBytes)-1; i < j; i, j = i+1, j-1 {
+				idBytes[i], idBytes[j] = idBytes[j], idBytes[i]
+			}
+
+			hash := sha256.Sum256(idBytes[:])
+			group := binary.LittleEndian.Uint64(hash[0:8]) % config.numberOfCustodyGroups
+			if !groupMap[group] {
+				groupMap[group] = true
+				groups = append(groups, group)
+			}
+
+			// overflows to 0 for UINT256_MAX
+			currentId.Add(currentId, one)
+		}
+	}
+
+	columnsPerGroup := config.numberOfColumns / config.numberOfCustodyGroups
+	columns := make([]uint64, 0, uint64(len(groups))*columnsPerGroup)
+	for _, group := range groups {
+		for i := uint64(0); i < columnsPerGroup; i++ {
+			columns = append(columns, config.numberOfCustodyGroups*i+group)
+		}
+	}
+
+	sort.Slice(columns, func(a, b int) bool {
+		return columns[a] < columns[b]
+	})
+
+	return columns
+}
+
+// getColumnBitfield returns a bitfield with one bit per column index
+func (config *columnConfig) getColumnBitfield(columns []uint64) []byte {
+	bitfield := make([]byte, (config.numberOfColumns+7)/8)
+	for _, column := range columns {
+		if column < config.numberOfColumns {
+			bitfield[column/8] |= 1 << (column % 8)
+		}
+	}
+
+	return bitfield
+}
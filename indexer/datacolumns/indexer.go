@@ -0,0 +1,154 @@
+package datacolumns
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// DataColumnIndexer polls the data column sidecars (PeerDAS) of new blocks from all consensus clients.
+// for each block & client it persists the columns the client had available and the columns it's expected to custody.
+type DataColumnIndexer struct {
+	logger         logrus.FieldLogger
+	beaconIndexer  *beacon.Indexer
+	chainState     *consensus.ChainState
+	updaterRunning bool
+}
+
+// NewDataColumnIndexer creates a new data column availability indexer.
+func NewDataColumnIndexer(logger logrus.FieldLogger, beaconIndexer *beacon.Indexer, chainState *consensus.ChainState) *DataColumnIndexer {
+	return &DataColumnIndexer{
+		logger:        logger,
+		beaconIndexer: beaconIndexer,
+		chainState:    chainState,
+	}
+}
+
+func (dci *DataColumnIndexer) StartUpdater() {
+	if dci.updaterRunning {
+		return
+	}
+
+	dci.updaterRunning = true
+	go dci.runUpdaterLoop()
+}
+
+func (dci *DataColumnIndexer) runUpdaterLoop() {
+	defer utils.HandleSubroutinePanic("DataColumnIndexer.runUpdaterLoop", dci.runUpdaterLoop)
+
+	nextSlot := dci.chainState.CurrentSlot()
+	for {
+		// wait for the sidecars of the slot to propagate
+		pollTime := dci.chainState.SlotToTime(nextSlot).Add(utils.Config.DataColumns.PollDelay)
+		if waitTime := time.Until(pollTime); waitTime > 0 {
+			time.Sleep(waitTime)
+		}
+
+		slot := nextSlot
+		nextSlot = dci.chainState.TimeToSlot(time.Now().Add(-utils.Config.DataColumns.PollDelay)) + 1
+
+		peerDASEpoch := dci.chainState.GetPeerDASForkEpoch()
+		if peerDASEpoch == nil || dci.chainState.EpochOfSlot(slot) < *peerDASEpoch {
+			continue
+		}
+
+		err := dci.processSlot(slot)
+		if err != nil {
+			dci.logger.Errorf("error processing data column availability for slot %v: %v", slot, err)
+		}
+	}
+}
+
+// processSlot polls the data column sidecars of all blob carrying blocks of the slot
+func (dci *DataColumnIndexer) processSlot(slot phase0.Slot) error {
+	config := getColumnConfig(dci.chainState.GetSpecs())
+	entries := []*dbtypes.DataColumnAvailability{}
+
+	for _, block := range dci.beaconIndexer.GetBlocksBySlot(slot) {
+		blockBody := block.GetBlock()
+		if blockBody == nil {
+			continue
+		}
+
+		commitments, err := blockBody.BlobKZGCommitments()
+		if err != nil || len(commitments) == 0 {
+			continue
+		}
+
+		entries = append(entries, dci.loadBlockAvailability(config, block.Root, slot, uint16(len(commitments)))...)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertDataColumnAvailability(entries, tx)
+	})
+}
+
+// loadBlockAvailability requests the available data column sidecars of a block from all online clients
+func (dci *DataColumnIndexer) loadBlockAvailability(config *columnConfig, blockRoot phase0.Root, slot phase0.Slot, blobCount uint16) []*dbtypes.DataColumnAvailability {
+	clients := dci.beaconIndexer.GetAllClients()
+	entries := make([]*dbtypes.DataColumnAvailability, len(clients))
+
+	wg := &sync.WaitGroup{}
+	for idx, client := range clients {
+		if client.GetClient().GetStatus() != consensus.ClientStatusOnline {
+			continue
+		}
+
+		wg.Add(1)
+		go func(idx int, client *beacon.Client) {
+			defer wg.Done()
+
+			entry := &dbtypes.DataColumnAvailability{
+				BlockRoot:  blockRoot[:],
+				ClientName: client.GetClient().GetName(),
+				Slot:       uint64(slot),
+				BlobCount:  blobCount,
+			}
+
+			identity := client.GetClient().GetNodeIdentity()
+			nodeId, err := getNodeId(identity)
+			if err != nil {
+				dci.logger.Debugf("could not get node id of client %v: %v", entry.ClientName, err)
+				entry.CustodyColumns = config.getColumnBitfield(nil)
+			} else {
+				entry.NodeId = nodeId
+				entry.CustodyColumns = config.getColumnBitfield(config.getCustodyColumns(nodeId, config.getCustodyGroupCount(identity)))
+			}
+
+			ctx, cancel := context.WithTimeout(client.GetClient().GetContext(), utils.Config.DataColumns.RequestTimeout)
+			defer cancel()
+
+			columns, err := client.GetClient().GetRPCClient().GetDataColumnSidecarIndexes(ctx, blockRoot)
+			if err != nil {
+				entry.FetchError = err.Error()
+			}
+			entry.AvailableColumns = config.getColumnBitfield(columns)
+
+			entries[idx] = entry
+		}(idx, client)
+	}
+	wg.Wait()
+
+	result := make([]*dbtypes.DataColumnAvailability, 0, len(entries))
+	for _, entry := range entries {
+		if entry != nil {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,143 @@
+package enricher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// Enricher is a pluggable source of additional per-slot data from an external service (eg. blockprint).
+// enrichers are fed with ranges of finalized slots by the Runner, which takes care of rate limiting and progress tracking.
+type Enricher interface {
+	// GetName returns the unique name of the enricher, used as key for the persisted progress.
+	GetName() string
+	// LoadSlots loads the enrichment data for all blocks in the given slot range (inclusive).
+	// returns a function that persists the loaded data, called within the db transaction that also persists the progress.
+	LoadSlots(ctx context.Context, firstSlot phase0.Slot, lastSlot phase0.Slot) (func(tx *sqlx.Tx) error, error)
+}
+
+// RunnerConfig holds the scheduling settings of an enricher runner.
+type RunnerConfig struct {
+	RefreshInterval time.Duration // interval to check for newly finalized slots
+	RateLimit       float64       // max number of LoadSlots calls per second
+	BatchSize       uint64        // max number of slots per LoadSlots call
+	StartSlot       phase0.Slot   // first slot to process if no progress has been persisted yet
+}
+
+// Runner feeds finalized slot ranges to an enricher.
+type Runner struct {
+	logger        logrus.FieldLogger
+	beaconIndexer *beacon.Indexer
+	chainState    *consensus.ChainState
+	enricher      Enricher
+	config        *RunnerConfig
+	limiter       *rate.Limiter
+	running       bool
+}
+
+// runnerState is the persisted progress of an enricher.
+type runnerState struct {
+	NextSlot uint64 `json:"next"`
+}
+
+// NewRunner creates a new runner for the given enricher.
+func NewRunner(logger logrus.FieldLogger, beaconIndexer *beacon.Indexer, chainState *consensus.ChainState, enricher Enricher, config *RunnerConfig) *Runner {
+	if config.BatchSize == 0 {
+		config.BatchSize = 1
+	}
+
+	return &Runner{
+		logger:        logger,
+		beaconIndexer: beaconIndexer,
+		chainState:    chainState,
+		enricher:      enricher,
+		config:        config,
+		limiter:       rate.NewLimiter(rate.Limit(config.RateLimit), 1),
+	}
+}
+
+// Start starts the runner loop (once).
+func (runner *Runner) Start() {
+	if runner.running {
+		return
+	}
+
+	runner.running = true
+	go runner.runLoop()
+}
+
+func (runner *Runner) runLoop() {
+	defer utils.HandleSubroutinePanic("Enricher.runLoop", runner.runLoop)
+
+	for {
+		err := runner.processFinalizedSlots()
+		if err != nil {
+			runner.logger.Errorf("%v enricher error: %v, retrying in %v...", runner.enricher.GetName(), err, runner.config.RefreshInterval)
+		}
+
+		time.Sleep(runner.config.RefreshInterval)
+	}
+}
+
+// processFinalizedSlots feeds all finalized slots that have not been processed yet to the enricher.
+func (runner *Runner) processFinalizedSlots() error {
+	stateKey := fmt.Sprintf("enricher.%v", runner.enricher.GetName())
+	state := runnerState{}
+	if _, err := db.GetExplorerState(stateKey, &state); err != nil || state.NextSlot == 0 {
+		state.NextSlot = uint64(runner.config.StartSlot)
+	}
+
+	finalizedEpoch, _ := runner.beaconIndexer.GetBlockCacheState()
+	if finalizedEpoch == 0 {
+		return nil
+	}
+	finalizedSlot := runner.chainState.EpochToSlot(finalizedEpoch)
+
+	for phase0.Slot(state.NextSlot) < finalizedSlot {
+		firstSlot := phase0.Slot(state.NextSlot)
+		lastSlot := firstSlot + phase0.Slot(runner.config.BatchSize) - 1
+		if lastSlot >= finalizedSlot {
+			lastSlot = finalizedSlot - 1
+		}
+
+		ctx := context.Background()
+		if err := runner.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		t1 := time.Now()
+		persistFn, err := runner.enricher.LoadSlots(ctx, firstSlot, lastSlot)
+		if err != nil {
+			return fmt.Errorf("failed loading slots %v-%v: %v", firstSlot, lastSlot, err)
+		}
+
+		newState := runnerState{
+			NextSlot: uint64(lastSlot) + 1,
+		}
+		err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+			if err := persistFn(tx); err != nil {
+				return err
+			}
+
+			return db.SetExplorerState(stateKey, &newState, tx)
+		})
+		if err != nil {
+			return fmt.Errorf("failed persisting slots %v-%v: %v", firstSlot, lastSlot, err)
+		}
+		state = newState
+
+		runner.logger.Debugf("%v enricher processed slots %v-%v (%v ms)", runner.enricher.GetName(), firstSlot, lastSlot, time.Since(t1).Milliseconds())
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package execution
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// AddressWatchHook is called for each new deposit or system request contract interaction of a watched address.
+type AddressWatchHook func(event *dbtypes.AddressWatchEvent)
+
+// AddressWatcher checks the indexed deposit & system request contract interactions for activity of the watched addresses
+type AddressWatcher struct {
+	indexerCtx *IndexerCtx
+	logger     logrus.FieldLogger
+	addresses  [][]byte
+	state      *addressWatcherState
+	hooks      []AddressWatchHook
+	hooksMutex sync.Mutex
+}
+
+// addressWatcherState represents the last checked block number per event type
+type addressWatcherState struct {
+	Blocks map[dbtypes.AddressWatchEventType]uint64 `json:"blocks"`
+}
+
+var addressWatchEventTypes = []dbtypes.AddressWatchEventType{
+	dbtypes.AddressWatchEventDeposit,
+	dbtypes.AddressWatchEventWithdrawalRequest,
+	dbtypes.AddressWatchEventConsolidationRequest,
+}
+
+// NewAddressWatcher creates a new address watcher for the given addresses
+func NewAddressWatcher(indexer *IndexerCtx, addresses []common.Address) *AddressWatcher {
+	aw := &AddressWatcher{
+		indexerCtx: indexer,
+		logger:     indexer.logger.WithField("indexer", "addresswatch"),
+		addresses:  make([][]byte, len(addresses)),
+	}
+
+	for i, address := range addresses {
+		aw.addresses[i] = address.Bytes()
+	}
+
+	go aw.runAddressWatcherLoop()
+
+	return aw
+}
+
+// AddHook adds a hook that is called for each new interaction of a watched address
+func (aw *AddressWatcher) AddHook(hook AddressWatchHook) {
+	aw.hooksMutex.Lock()
+	defer aw.hooksMutex.Unlock()
+
+	aw.hooks = append(aw.hooks, hook)
+}
+
+// runAddressWatcherLoop is the main loop for the address watcher
+func (aw *AddressWatcher) runAddressWatcherLoop() {
+	defer utils.HandleSubroutinePanic("AddressWatcher.runAddressWatcherLoop", aw.runAddressWatcherLoop)
+
+	for {
+		time.Sleep(60 * time.Second)
+		aw.logger.Debugf("run address watcher logic")
+
+		err := aw.runAddressWatcher()
+		if err != nil {
+			aw.logger.Errorf("address watcher error: %v", err)
+		}
+	}
+}
+
+// loadState loads the address watcher state from the database.
+// on first run the checked block numbers are initialized with the latest known interactions, so historic activity does not trigger alerts.
+func (aw *AddressWatcher) loadState() error {
+	syncState := addressWatcherState{}
+	db.GetExplorerState("indexer.addresswatch", &syncState)
+
+	if syncState.Blocks == nil {
+		syncState.Blocks = make(map[dbtypes.AddressWatchEventType]uint64)
+
+		for _, eventType := range addressWatchEventTypes {
+			events, _, err := db.GetAddressWatchEvents(0, 1, &dbtypes.AddressWatchFilter{
+				Addresses: aw.addresses,
+				Type:      eventType,
+			})
+			if err != nil {
+				return err
+			}
+
+			if len(events) > 0 {
+				syncState.Blocks[eventType] = events[0].BlockNumber
+			}
+		}
+	}
+
+	aw.state = &syncState
+	return nil
+}
+
+// runAddressWatcher checks for new interactions of the watched addresses since the last run
+func (aw *AddressWatcher) runAddressWatcher() error {
+	if aw.state == nil {
+		err := aw.loadState()
+		if err != nil {
+			return fmt.Errorf("error while loading address watcher state: %v", err)
+		}
+	}
+
+	aw.hooksMutex.Lock()
+	hooks := aw.hooks
+	aw.hooksMutex.Unlock()
+
+	for _, eventType := range addressWatchEventTypes {
+		filter := &dbtypes.AddressWatchFilter{
+			Addresses: aw.addresses,
+			Type:      eventType,
+			MinBlock:  aw.state.Blocks[eventType] + 1,
+		}
+
+		events := []*dbtypes.AddressWatchEvent{}
+		for {
+			pageEvents, totalCount, err := db.GetAddressWatchEvents(uint64(len(events)), 1000, filter)
+			if err != nil {
+				return err
+			}
+
+			events = append(events, pageEvents...)
+			if len(pageEvents) == 0 || uint64(len(events)) >= totalCount {
+				break
+			}
+		}
+
+		// events are returned newest first
+		for idx := len(events) - 1; idx >= 0; idx-- {
+			event := events[idx]
+			aw.logger.Infof("address activity: type %v, block %v, tx 0x%x (sender 0x%x)", event.Type, event.BlockNumber, event.TxHash, event.TxSender)
+
+			for _, hook := range hooks {
+				hook(event)
+			}
+
+			if event.BlockNumber > aw.state.Blocks[eventType] {
+				aw.state.Blocks[eventType] = event.BlockNumber
+			}
+		}
+	}
+
+	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.SetExplorerState("indexer.addresswatch", aw.state, tx)
+	})
+}
@@ -121,6 +121,7 @@ func (ci *ConsolidationIndexer) processFinalTx(log *types.Log, tx *types.Transac
 	requestTx.TxSender = txFrom[:]
 	requestTx.TxTarget = txTo[:]
 	requestTx.DequeueBlock = dequeueBlock
+	requestTx.Fee = getRequestTxFee(tx, common.HexToAddress(ConsolidationContractAddr))
 
 	return requestTx, nil
 }
@@ -139,6 +140,7 @@ func (ci *ConsolidationIndexer) processRecentTx(log *types.Log, tx *types.Transa
 	requestTx.TxSender = txFrom[:]
 	requestTx.TxTarget = txTo[:]
 	requestTx.DequeueBlock = dequeueBlock
+	requestTx.Fee = getRequestTxFee(tx, common.HexToAddress(ConsolidationContractAddr))
 
 	clBlock := ci.indexerCtx.beaconIndexer.GetBlocksByExecutionBlockHash(phase0.Hash32(log.BlockHash))
 	if len(clBlock) > 0 {
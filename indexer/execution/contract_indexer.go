@@ -594,3 +594,19 @@ func (ci *contractIndexer[TxType]) persistRecentRequestTxs(forkId beacon.ForkKey
 		return ci.persistState(tx)
 	})
 }
+
+// getRequestTxFee returns the fee (in wei) paid to the system contract by a request transaction.
+// the fee is only known for transactions calling the system contract directly, as the value of internal calls is not visible in the transaction.
+// returns 0 if the fee is unknown, fees above the int64 range of the database are capped.
+func getRequestTxFee(tx *types.Transaction, contractAddress common.Address) uint64 {
+	if tx.To() == nil || *tx.To() != contractAddress {
+		return 0
+	}
+
+	fee := tx.Value()
+	if !fee.IsInt64() {
+		return math.MaxInt64
+	}
+
+	return fee.Uint64()
+}
@@ -5,25 +5,32 @@ import (
 	"encoding/binary"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
 	blsu "github.com/protolambda/bls12-381-util"
 	zrnt_common "github.com/protolambda/zrnt/eth2/beacon/common"
 	"github.com/protolambda/ztyp/tree"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/utils"
 )
 
+// depositIndexerFallbackInterval is the interval for running the deposit indexer when no finalization event arrives (eg. during non-finality)
+const depositIndexerFallbackInterval = 5 * time.Minute
+
 const depositContractAbi = `[{"inputs":[],"stateMutability":"nonpayable","type":"constructor"},{"anonymous":false,"inputs":[{"indexed":false,"internalType":"bytes","name":"pubkey","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"withdrawal_credentials","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"amount","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"signature","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"index","type":"bytes"}],"name":"DepositEvent","type":"event"},{"inputs":[{"internalType":"bytes","name":"pubkey","type":"bytes"},{"internalType":"bytes","name":"withdrawal_credentials","type":"bytes"},{"internalType":"bytes","name":"signature","type":"bytes"},{"internalType":"bytes32","name":"deposit_data_root","type":"bytes32"}],"name":"deposit","outputs":[],"stateMutability":"payable","type":"function"},{"inputs":[],"name":"get_deposit_count","outputs":[{"internalType":"bytes","name":"","type":"bytes"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"get_deposit_root","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"bytes4","name":"interfaceId","type":"bytes4"}],"name":"supportsInterface","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"pure","type":"function"}]`
 
 // DepositIndexer is the indexer for the deposit contract
@@ -35,6 +42,10 @@ type DepositIndexer struct {
 	depositContractAbi *abi.ABI
 	depositEventTopic  []byte
 	depositSigDomain   zrnt_common.BLSDomain
+
+	finalitySubscription *consensus.Subscription[*v1.Finality]
+	finalBlockGauge      prometheus.Gauge
+	finalizedLagGauge    prometheus.Gauge
 }
 
 // NewDepositIndexer creates a new deposit contract indexer
@@ -61,8 +72,23 @@ func NewDepositIndexer(indexer *IndexerCtx) *DepositIndexer {
 		depositContractAbi: &contractAbi,
 		depositEventTopic:  depositEventTopic[:],
 		depositSigDomain:   depositSigDomain,
+
+		finalitySubscription: indexer.consensusPool.SubscribeFinalizedEvent(10),
+		finalBlockGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "dora_deposit_indexer_final_block",
+			Help: "Last finalized execution block number processed by the deposit indexer.",
+		}),
+		finalizedLagGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "dora_deposit_indexer_finalized_lag_blocks",
+			Help: "Number of finalized execution blocks not yet processed by the deposit indexer.",
+		}),
 	}
 
+	prometheus.MustRegister(
+		ds.finalBlockGauge,
+		ds.finalizedLagGauge,
+	)
+
 	// create contract indexer for the deposit contract
 	ds.indexer = newContractIndexer(
 		indexer,
@@ -89,14 +115,59 @@ func NewDepositIndexer(indexer *IndexerCtx) *DepositIndexer {
 func (ds *DepositIndexer) runDepositIndexerLoop() {
 	defer utils.HandleSubroutinePanic("DepositIndexer.runDepositIndexerLoop", ds.runDepositIndexerLoop)
 
+	// initial run is delayed a bit to allow the beacon indexer to load the finalized block
+	fallbackTimer := time.NewTimer(60 * time.Second)
+	defer fallbackTimer.Stop()
+
 	for {
-		time.Sleep(60 * time.Second)
-		ds.logger.Debugf("run deposit indexer logic")
+		select {
+		case finalityEvent := <-ds.finalitySubscription.Channel():
+			ds.logger.Debugf("run deposit indexer logic (finalized epoch %v)", finalityEvent.Finalized.Epoch)
+		case <-fallbackTimer.C:
+			ds.logger.Debugf("run deposit indexer logic (fallback)")
+		}
+
+		// skip queued finality events, the indexer always runs up to the latest finalized block
+	drainLoop:
+		for {
+			select {
+			case <-ds.finalitySubscription.Channel():
+			default:
+				break drainLoop
+			}
+		}
 
 		err := ds.indexer.runContractIndexer()
 		if err != nil {
 			ds.logger.Errorf("deposit indexer error: %v", err)
 		}
+
+		ds.updateLagMetrics()
+
+		if !fallbackTimer.Stop() {
+			select {
+			case <-fallbackTimer.C:
+			default:
+			}
+		}
+		fallbackTimer.Reset(depositIndexerFallbackInterval)
+	}
+}
+
+// updateLagMetrics updates the metrics for the indexer progress relative to the finalized el block
+func (ds *DepositIndexer) updateLagMetrics() {
+	if ds.indexer.state == nil {
+		return
+	}
+
+	indexedBlock := ds.indexer.state.FinalBlock
+	ds.finalBlockGauge.Set(float64(indexedBlock))
+
+	finalizedBlock := ds.indexer.getFinalizedBlockNumber()
+	if finalizedBlock > indexedBlock {
+		ds.finalizedLagGauge.Set(float64(finalizedBlock - indexedBlock))
+	} else {
+		ds.finalizedLagGauge.Set(0)
 	}
 }
 
@@ -170,6 +241,11 @@ func (ci *DepositIndexer) parseDepositLog(log *types.Log) *dbtypes.DepositTx {
 
 // persistDepositTxs is the callback for the contract indexer to persist deposit transactions to the database
 func (ci *DepositIndexer) persistDepositTxs(tx *sqlx.Tx, requests []*dbtypes.DepositTx) error {
+	err := ci.checkDepositProblems(tx, requests)
+	if err != nil {
+		return fmt.Errorf("error while checking deposit txs: %v", err)
+	}
+
 	requestCount := len(requests)
 	for requestIdx := 0; requestIdx < requestCount; requestIdx += 500 {
 		endIdx := requestIdx + 500
@@ -177,7 +253,7 @@ func (ci *DepositIndexer) persistDepositTxs(tx *sqlx.Tx, requests []*dbtypes.Dep
 			endIdx = requestCount
 		}
 
-		err := db.InsertDepositTxs(requests[requestIdx:endIdx], tx)
+		err = db.InsertDepositTxs(requests[requestIdx:endIdx], tx)
 		if err != nil {
 			return fmt.Errorf("error while inserting deposit txs: %v", err)
 		}
@@ -206,3 +282,82 @@ func (ds *DepositIndexer) checkDepositValidity(depositTx *dbtypes.DepositTx) {
 		depositTx.ValidSignature = true
 	}
 }
+
+// checkDepositProblems flags deposits that probably result in lost funds.
+// a deposit is flagged if it reuses an already deposited pubkey with different withdrawal credentials,
+// or if it has an invalid signature while there is no prior valid deposit for the same pubkey.
+func (ds *DepositIndexer) checkDepositProblems(tx *sqlx.Tx, requests []*dbtypes.DepositTx) error {
+	pubkeys := [][]byte{}
+	pubkeyMap := map[string]bool{}
+	for _, request := range requests {
+		if pubkeyMap[string(request.PublicKey)] {
+			continue
+		}
+		pubkeyMap[string(request.PublicKey)] = true
+		pubkeys = append(pubkeys, request.PublicKey)
+	}
+
+	knownDeposits, err := db.GetDepositTxsByPublicKeys(pubkeys, tx)
+	if err != nil {
+		return err
+	}
+
+	// merge known deposits with the new requests (new requests replace known deposits with the same index)
+	requestMap := map[uint64]*dbtypes.DepositTx{}
+	for _, request := range requests {
+		if !request.Orphaned {
+			requestMap[request.Index] = request
+		}
+	}
+
+	deposits := make([]*dbtypes.DepositTx, 0, len(knownDeposits)+len(requestMap))
+	for _, deposit := range knownDeposits {
+		if requestMap[deposit.Index] == nil {
+			deposits = append(deposits, deposit)
+		}
+	}
+	for _, request := range requestMap {
+		deposits = append(deposits, request)
+	}
+
+	sort.Slice(deposits, func(a, b int) bool {
+		return deposits[a].Index < deposits[b].Index
+	})
+
+	// walk through all deposits in order and track the accepted withdrawal credentials for each pubkey
+	acceptedCredentials := map[string][]byte{}
+	for _, deposit := range deposits {
+		pubkey := string(deposit.PublicKey)
+		credentials, accepted := acceptedCredentials[pubkey]
+
+		if requestMap[deposit.Index] != deposit {
+			// known deposit, just track state
+			if !accepted && deposit.ValidSignature {
+				acceptedCredentials[pubkey] = deposit.WithdrawalCredentials
+			}
+			continue
+		}
+
+		deposit.ProblemFlags = 0
+
+		if !accepted {
+			if deposit.ValidSignature {
+				acceptedCredentials[pubkey] = deposit.WithdrawalCredentials
+			} else if _, found := ds.indexerCtx.beaconIndexer.GetValidatorIndexByPubkey(phase0.BLSPubKey(deposit.PublicKey)); found {
+				// validator has been created by a deposit we don't know about (eg. genesis validator)
+				// top-up deposits do not require a valid signature, but we can't check the credentials either
+				acceptedCredentials[pubkey] = nil
+			} else {
+				deposit.ProblemFlags |= dbtypes.DepositTxProblemInvalidSignature
+			}
+		} else if credentials != nil && !bytes.Equal(credentials, deposit.WithdrawalCredentials) {
+			deposit.ProblemFlags |= dbtypes.DepositTxProblemCredentialsMismatch
+		}
+
+		if deposit.ProblemFlags != 0 {
+			ds.logger.Infof("problematic deposit %v detected (pubkey: 0x%x, flags: %v)", deposit.Index, deposit.PublicKey, deposit.ProblemFlags)
+		}
+	}
+
+	return nil
+}
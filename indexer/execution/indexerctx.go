@@ -1,49 +1,33 @@
 package execution
 
 import (
-	"math/rand/v2"
 	"sort"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/clients/selection"
 	"github.com/ethpandaops/dora/indexer/beacon"
 	"github.com/sirupsen/logrus"
 )
 
 // IndexerCtx is the context for the execution indexer
 type IndexerCtx struct {
-	logger           logrus.FieldLogger
-	beaconIndexer    *beacon.Indexer
-	executionPool    *execution.Pool
-	consensusPool    *consensus.Pool
-	chainState       *consensus.ChainState
-	executionClients map[*execution.Client]*indexerElClientInfo
-}
-
-// indexerElClientInfo holds information about a client and its priority
-type indexerElClientInfo struct {
-	priority int
-	archive  bool
+	logger        logrus.FieldLogger
+	beaconIndexer *beacon.Indexer
+	executionPool *execution.Pool
+	consensusPool *consensus.Pool
+	chainState    *consensus.ChainState
 }
 
 // NewIndexerCtx creates a new IndexerCtx
 func NewIndexerCtx(logger logrus.FieldLogger, executionPool *execution.Pool, consensusPool *consensus.Pool, beaconIndexer *beacon.Indexer) *IndexerCtx {
 	return &IndexerCtx{
-		logger:           logger,
-		executionPool:    executionPool,
-		consensusPool:    consensusPool,
-		beaconIndexer:    beaconIndexer,
-		chainState:       consensusPool.GetChainState(),
-		executionClients: map[*execution.Client]*indexerElClientInfo{},
-	}
-}
-
-// AddClientInfo adds client info to the indexer context
-func (ictx *IndexerCtx) AddClientInfo(client *execution.Client, priority int, archive bool) {
-	ictx.executionClients[client] = &indexerElClientInfo{
-		priority: priority,
-		archive:  archive,
+		logger:        logger,
+		executionPool: executionPool,
+		consensusPool: consensusPool,
+		beaconIndexer: beaconIndexer,
+		chainState:    consensusPool.GetChainState(),
 	}
 }
 
@@ -66,20 +50,10 @@ func (ictx *IndexerCtx) getFinalizedClients(clientType execution.ClientType) []*
 	return finalizedClients
 }
 
-// sortClients sorts clients by priority, but randomizes the order for equal priority
-func (ictx *IndexerCtx) sortClients(clientA *execution.Client, clientB *execution.Client, preferArchive bool) bool {
-	clientAInfo := ictx.executionClients[clientA]
-	clientBInfo := ictx.executionClients[clientB]
-
-	if preferArchive && clientAInfo.archive != clientBInfo.archive {
-		return clientAInfo.archive
-	}
-
-	if clientAInfo.priority != clientBInfo.priority {
-		return clientAInfo.priority > clientBInfo.priority
-	}
-
-	return rand.IntN(2) == 0
+// sortClients orders the clients by their selection settings.
+// heavy requests (historic log crawls) prefer archive clients, light requests prefer the remaining clients.
+func (ictx *IndexerCtx) sortClients(clients []*execution.Client, heavy bool) {
+	selection.SortClients(clients, (*execution.Client).GetSelection, heavy)
 }
 
 // forkWithClients holds information about a fork and the clients following it
@@ -128,9 +102,7 @@ func (ictx *IndexerCtx) getForksWithClients(clientType execution.ClientType) []*
 	for _, forkWithClients := range forksWithClients {
 		forkWithClients.canonical = canonicalHead != nil && canonicalHead.GetForkId() == forkWithClients.forkId
 
-		sort.Slice(forkWithClients.clients, func(i, j int) bool {
-			return ictx.sortClients(forkWithClients.clients[i], forkWithClients.clients[j], true)
-		})
+		ictx.sortClients(forkWithClients.clients, true)
 	}
 
 	sort.Slice(forksWithClients, func(i, j int) bool {
@@ -0,0 +1,116 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/execution"
+)
+
+// ReceiptFetcher loads the transaction receipts of finalized blocks from the execution client pool
+type ReceiptFetcher struct {
+	indexerCtx *IndexerCtx
+	logger     logrus.FieldLogger
+}
+
+// BlockReceipts holds the receipts & base fee of an execution block
+type BlockReceipts struct {
+	BlockHash common.Hash
+	BaseFee   *big.Int
+	Receipts  []*types.Receipt
+}
+
+// NewReceiptFetcher creates a new receipt fetcher
+func NewReceiptFetcher(indexer *IndexerCtx) *ReceiptFetcher {
+	return &ReceiptFetcher{
+		indexerCtx: indexer,
+		logger:     indexer.logger.WithField("indexer", "receipts"),
+	}
+}
+
+// GetBlockReceipts loads the receipts of a finalized execution block.
+// the clients are tried in priority order until one of them returns the receipts.
+func (rf *ReceiptFetcher) GetBlockReceipts(ctx context.Context, blockHash common.Hash) (*BlockReceipts, error) {
+	clients := rf.indexerCtx.getFinalizedClients(execution.AnyClient)
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("no ready execution client found")
+	}
+
+	rf.indexerCtx.sortClients(clients, false)
+
+	var lastErr error
+	for _, client := range clients {
+		blockReceipts, err := rf.loadBlockReceipts(ctx, client, blockHash)
+		if err == nil {
+			return blockReceipts, nil
+		}
+
+		rf.logger.Debugf("could not load receipts for block %v from %v: %v", blockHash.String(), client.GetName(), err)
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("could not load receipts for block %v: %v", blockHash.String(), lastErr)
+}
+
+func (rf *ReceiptFetcher) loadBlockReceipts(ctx context.Context, client *execution.Client, blockHash common.Hash) (*BlockReceipts, error) {
+	header, err := client.GetRPCClient().GetHeaderByHash(ctx, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not load block header: %v", err)
+	}
+
+	receipts, err := client.GetRPCClient().GetBlockReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not load block receipts: %v", err)
+	}
+
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+
+	return &BlockReceipts{
+		BlockHash: blockHash,
+		BaseFee:   baseFee,
+		Receipts:  receipts,
+	}, nil
+}
+
+// GetPriorityFees returns the sum of the priority fees (in wei) paid to the fee recipient of the block
+func (br *BlockReceipts) GetPriorityFees() *big.Int {
+	priorityFees := big.NewInt(0)
+	for _, receipt := range br.Receipts {
+		if receipt.EffectiveGasPrice == nil {
+			continue
+		}
+
+		tip := new(big.Int).Sub(receipt.EffectiveGasPrice, br.BaseFee)
+		if tip.Sign() <= 0 {
+			continue
+		}
+
+		priorityFees.Add(priorityFees, tip.Mul(tip, new(big.Int).SetUint64(receipt.GasUsed)))
+	}
+
+	return priorityFees
+}
+
+// GetBurnedFees returns the sum of the base fees & blob fees (in wei) burned by the transactions of the block
+func (br *BlockReceipts) GetBurnedFees() *big.Int {
+	var gasUsed uint64
+	blobFees := big.NewInt(0)
+	for _, receipt := range br.Receipts {
+		gasUsed += receipt.GasUsed
+
+		if receipt.BlobGasUsed > 0 && receipt.BlobGasPrice != nil {
+			blobFees.Add(blobFees, new(big.Int).Mul(receipt.BlobGasPrice, new(big.Int).SetUint64(receipt.BlobGasUsed)))
+		}
+	}
+
+	burnedFees := new(big.Int).Mul(br.BaseFee, new(big.Int).SetUint64(gasUsed))
+	return burnedFees.Add(burnedFees, blobFees)
+}
@@ -122,6 +122,7 @@ func (wi *WithdrawalIndexer) processFinalTx(log *types.Log, tx *types.Transactio
 	requestTx.TxSender = txFrom[:]
 	requestTx.TxTarget = txTo[:]
 	requestTx.DequeueBlock = dequeueBlock
+	requestTx.Fee = getRequestTxFee(tx, common.HexToAddress(WithdrawalContractAddr))
 
 	return requestTx, nil
 }
@@ -140,6 +141,7 @@ func (wi *WithdrawalIndexer) processRecentTx(log *types.Log, tx *types.Transacti
 	requestTx.TxSender = txFrom[:]
 	requestTx.TxTarget = txTo[:]
 	requestTx.DequeueBlock = dequeueBlock
+	requestTx.Fee = getRequestTxFee(tx, common.HexToAddress(WithdrawalContractAddr))
 
 	clBlock := wi.indexerCtx.beaconIndexer.GetBlocksByExecutionBlockHash(phase0.Hash32(log.BlockHash))
 	if len(clBlock) > 0 {
@@ -0,0 +1,209 @@
+package fingerprint
+
+import (
+	"context"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+const fingerprintInsertBatchSize = 1000
+
+// sharedFeeRecipientProposers is the number of distinct proposers within a batch that need to use the same fee recipient,
+// so the fee recipient is considered to belong to an external block builder.
+const sharedFeeRecipientProposers = 3
+
+// confidence levels (0-100) of the single fingerprint signals
+const (
+	confidenceGraffitiVersion     = 95 // client version suffix, appended by the clients themselves
+	confidenceGraffitiName        = 60 // client name in the graffiti, set by the node operator
+	confidenceExtraData           = 80 // default extra data of the execution client
+	confidenceExtraDataExternal   = 40 // client name in the extra data of an externally built payload (refers to the builder node)
+	confidenceAgreement           = 98 // graffiti & extra data point to the same execution client
+	confidenceBuilderRelay        = 99 // payload delivered by a mev relay
+	confidenceBuilderFeeRecipient = 80 // fee recipient shared by several proposers
+	confidenceBuilderExtraData    = 70 // extra data names an execution client
+	confidenceBuilderEmptyBlock   = 60 // external builders do not deliver empty payloads
+)
+
+// Fingerprint is an enricher that guesses the proposing clients & block builder of finalized blocks.
+// it combines the graffiti & extra data client classification with mev relay data and block structure heuristics.
+type Fingerprint struct {
+	logger logrus.FieldLogger
+}
+
+// NewFingerprint creates a new slot fingerprint enricher.
+func NewFingerprint(logger logrus.FieldLogger) *Fingerprint {
+	return &Fingerprint{
+		logger: logger,
+	}
+}
+
+// GetName returns the name of the enricher.
+func (fp *Fingerprint) GetName() string {
+	return "fingerprint"
+}
+
+// LoadSlots builds the fingerprints for all canonical blocks in the given slot range.
+func (fp *Fingerprint) LoadSlots(ctx context.Context, firstSlot phase0.Slot, lastSlot phase0.Slot) (func(tx *sqlx.Tx) error, error) {
+	slots := db.GetSlotsRange(uint64(lastSlot), uint64(firstSlot), false, false)
+
+	// payloads delivered by the mev relays
+	relayBlocks := map[common.Hash]*dbtypes.MevBlock{}
+	for offset := uint64(0); ; {
+		mevBlocks, totalCount, err := db.GetMevBlocksFiltered(offset, 1000, &dbtypes.MevBlockFilter{
+			MinSlot:  uint64(firstSlot),
+			MaxSlot:  uint64(lastSlot),
+			Proposed: []uint8{1},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mevBlock := range mevBlocks {
+			relayBlocks[common.Hash(mevBlock.BlockHash)] = mevBlock
+		}
+
+		offset += uint64(len(mevBlocks))
+		if offset >= totalCount || len(mevBlocks) == 0 {
+			break
+		}
+	}
+
+	// distinct proposers per fee recipient
+	feeRecipientProposers := map[common.Address]map[uint64]bool{}
+	for _, slot := range slots {
+		if slot.Block == nil || slot.Block.EthBlockNumber == nil || len(slot.Block.EthFeeRecipient) != 20 {
+			continue
+		}
+
+		feeRecipient := common.Address(slot.Block.EthFeeRecipient)
+		if feeRecipientProposers[feeRecipient] == nil {
+			feeRecipientProposers[feeRecipient] = map[uint64]bool{}
+		}
+		feeRecipientProposers[feeRecipient][slot.Block.Proposer] = true
+	}
+
+	fingerprints := make([]*dbtypes.SlotFingerprint, 0, len(slots))
+	for _, slot := range slots {
+		if slot.Slot == 0 || slot.Block == nil || slot.Block.Status != dbtypes.Canonical {
+			continue
+		}
+
+		var relayBlock *dbtypes.MevBlock
+		sharedFeeRecipient := false
+		if slot.Block.EthBlockNumber != nil {
+			relayBlock = relayBlocks[common.Hash(slot.Block.EthBlockHash)]
+			if len(slot.Block.EthFeeRecipient) == 20 {
+				sharedFeeRecipient = len(feeRecipientProposers[common.Address(slot.Block.EthFeeRecipient)]) >= sharedFeeRecipientProposers
+			}
+		}
+
+		fingerprints = append(fingerprints, buildSlotFingerprint(slot.Block, relayBlock, sharedFeeRecipient))
+	}
+
+	return func(tx *sqlx.Tx) error {
+		for start := 0; start < len(fingerprints); start += fingerprintInsertBatchSize {
+			end := start + fingerprintInsertBatchSize
+			if end > len(fingerprints) {
+				end = len(fingerprints)
+			}
+
+			if err := db.InsertSlotFingerprints(fingerprints[start:end], tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// buildSlotFingerprint combines the single signals of a block into its fingerprint.
+func buildSlotFingerprint(block *dbtypes.Slot, relayBlock *dbtypes.MevBlock, sharedFeeRecipient bool) *dbtypes.SlotFingerprint {
+	fingerprint := &dbtypes.SlotFingerprint{
+		Slot:     block.Slot,
+		Proposer: block.Proposer,
+	}
+	sources := dbtypes.SlotFingerprintSource(0)
+	clients := beacon.ClassifyBlockClients(block.GraffitiText, block.EthBlockExtraText)
+
+	// block builder
+	if block.EthBlockNumber != nil {
+		extraDataClient := beacon.ClassifyExtraDataClient(block.EthBlockExtraText)
+		switch {
+		case relayBlock != nil:
+			fingerprint.BuilderType = uint8(dbtypes.BlockBuilderExternal)
+			fingerprint.BuilderConfidence = confidenceBuilderRelay
+			fingerprint.BuilderPubkey = relayBlock.BuilderPubkey
+			sources |= dbtypes.FingerprintSourceRelay
+			if sharedFeeRecipient {
+				fingerprint.BuilderConfidence = 100
+				sources |= dbtypes.FingerprintSourceFeeRecipient
+			}
+		case sharedFeeRecipient:
+			fingerprint.BuilderType = uint8(dbtypes.BlockBuilderExternal)
+			fingerprint.BuilderConfidence = confidenceBuilderFeeRecipient
+			sources |= dbtypes.FingerprintSourceFeeRecipient
+		case extraDataClient != execution.UnknownClient:
+			fingerprint.BuilderType = uint8(dbtypes.BlockBuilderLocal)
+			fingerprint.BuilderConfidence = confidenceBuilderExtraData
+			sources |= dbtypes.FingerprintSourceExtraData
+		case block.EthTransactionCount == 0:
+			fingerprint.BuilderType = uint8(dbtypes.BlockBuilderLocal)
+			fingerprint.BuilderConfidence = confidenceBuilderEmptyBlock
+			sources |= dbtypes.FingerprintSourceEmptyBlock
+		}
+
+		if fingerprint.BuilderType == uint8(dbtypes.BlockBuilderExternal) {
+			// external builders usually tag their payloads via the extra data
+			builderName := []rune(strings.TrimSpace(block.EthBlockExtraText))
+			if len(builderName) > 100 {
+				builderName = builderName[:100]
+			}
+			fingerprint.BuilderName = string(builderName)
+		}
+	}
+
+	// proposing consensus client
+	fingerprint.ClClient = int8(clients.ClClient)
+	switch clients.ClSource {
+	case beacon.ClientSourceGraffitiVersion:
+		fingerprint.ClConfidence = confidenceGraffitiVersion
+		sources |= dbtypes.FingerprintSourceGraffitiVersion
+	case beacon.ClientSourceGraffitiName:
+		fingerprint.ClConfidence = confidenceGraffitiName
+		sources |= dbtypes.FingerprintSourceGraffitiName
+	}
+
+	// execution client
+	fingerprint.ElClient = int8(clients.ElClient)
+	switch clients.ElSource {
+	case beacon.ClientSourceGraffitiVersion:
+		fingerprint.ElConfidence = confidenceGraffitiVersion
+		sources |= dbtypes.FingerprintSourceGraffitiVersion
+		if fingerprint.BuilderType != uint8(dbtypes.BlockBuilderExternal) && beacon.ClassifyExtraDataClient(block.EthBlockExtraText) == clients.ElClient {
+			fingerprint.ElConfidence = confidenceAgreement
+			sources |= dbtypes.FingerprintSourceExtraData
+		}
+	case beacon.ClientSourceExtraData:
+		fingerprint.ElConfidence = confidenceExtraData
+		if fingerprint.BuilderType == uint8(dbtypes.BlockBuilderExternal) {
+			fingerprint.ElConfidence = confidenceExtraDataExternal
+		}
+		sources |= dbtypes.FingerprintSourceExtraData
+	case beacon.ClientSourceGraffitiName:
+		fingerprint.ElConfidence = confidenceGraffitiName
+		sources |= dbtypes.FingerprintSourceGraffitiName
+	}
+
+	fingerprint.Sources = uint16(sources)
+	return fingerprint
+}
@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+)
+
+// e2store entry types used in era & era1 files.
+// see https://github.com/status-im/nimbus-eth2/blob/stable/docs/e2store.md
+var (
+	e2sTypeVersion                     = [2]byte{0x65, 0x32}
+	e2sTypeCompressedSignedBeaconBlock = [2]byte{0x01, 0x00}
+	e2sTypeCompressedBeaconState       = [2]byte{0x02, 0x00}
+	e2sTypeSlotIndex                   = [2]byte{0x69, 0x32}
+	e2sTypeCompressedHeader            = [2]byte{0x03, 0x00} // era1 only
+)
+
+// e2storeHeaderSize is the size of the header preceding each e2store entry (type, length, reserved).
+const e2storeHeaderSize = 8
+
+// e2storeEntry represents a single type-length-value entry of an e2store file.
+type e2storeEntry struct {
+	Type   [2]byte
+	Offset int64
+	Value  []byte
+}
+
+// e2storeReader reads e2store entries sequentially from an underlying reader.
+type e2storeReader struct {
+	reader io.Reader
+	offset int64
+}
+
+// newE2storeReader creates a new e2storeReader for the given reader.
+func newE2storeReader(reader io.Reader) *e2storeReader {
+	return &e2storeReader{
+		reader: reader,
+	}
+}
+
+// readEntry reads the next entry from the e2store file.
+// returns io.EOF if there are no more entries.
+func (r *e2storeReader) readEntry() (*e2storeEntry, error) {
+	header := make([]byte, e2storeHeaderSize)
+	n, err := io.ReadFull(r.reader, header)
+	if err != nil {
+		if err == io.EOF && n == 0 {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed reading entry header at offset %v: %w", r.offset, err)
+	}
+
+	entry := &e2storeEntry{
+		Offset: r.offset,
+	}
+	copy(entry.Type[:], header[0:2])
+	length := binary.LittleEndian.Uint32(header[2:6])
+	if reserved := binary.LittleEndian.Uint16(header[6:8]); reserved != 0 {
+		return nil, fmt.Errorf("invalid entry header at offset %v: reserved bytes not zero", r.offset)
+	}
+
+	entry.Value = make([]byte, length)
+	if _, err := io.ReadFull(r.reader, entry.Value); err != nil {
+		return nil, fmt.Errorf("failed reading entry value at offset %v: %w", r.offset, err)
+	}
+
+	r.offset += e2storeHeaderSize + int64(length)
+
+	return entry, nil
+}
+
+// decompressSnappyFramed decompresses a snappy framed entry value.
+func decompressSnappyFramed(data []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// EraFileType is the type of an e2store history file.
+type EraFileType uint8
+
+const (
+	EraFileTypeUnknown EraFileType = iota
+	EraFileTypeEra                 // consensus layer history (signed beacon blocks & beacon state)
+	EraFileTypeEra1                // pre-merge execution layer history (headers, bodies & receipts)
+)
+
+// ErrNotEraFile is returned if a file does not start with an e2store version entry.
+var ErrNotEraFile = errors.New("not an e2store file")
+
+// EraBlock is a single signed beacon block read from an era file.
+type EraBlock struct {
+	Slot phase0.Slot
+	SSZ  []byte
+}
+
+// EraReader reads signed beacon blocks from era files.
+type EraReader struct {
+	path     string
+	file     *os.File
+	reader   *e2storeReader
+	fileType EraFileType
+	peeked   *e2storeEntry
+}
+
+// OpenEraFile opens an era or era1 file and detects its type.
+func OpenEraFile(path string) (*EraReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	era := &EraReader{
+		path:   path,
+		file:   file,
+		reader: newE2storeReader(bufio.NewReaderSize(file, 1024*1024)),
+	}
+
+	versionEntry, err := era.reader.readEntry()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed reading version entry: %w", err)
+	}
+	if versionEntry.Type != e2sTypeVersion || len(versionEntry.Value) != 0 {
+		file.Close()
+		return nil, ErrNotEraFile
+	}
+
+	// the type of the first data entry tells us whether it is a consensus or execution history file
+	firstEntry, err := era.reader.readEntry()
+	if err != nil && err != io.EOF {
+		file.Close()
+		return nil, err
+	}
+
+	if firstEntry != nil {
+		switch firstEntry.Type {
+		case e2sTypeCompressedSignedBeaconBlock, e2sTypeCompressedBeaconState:
+			era.fileType = EraFileTypeEra
+		case e2sTypeCompressedHeader:
+			era.fileType = EraFileTypeEra1
+		}
+		era.peeked = firstEntry
+	}
+
+	return era, nil
+}
+
+// GetFileType returns the detected type of the era file.
+func (era *EraReader) GetFileType() EraFileType {
+	return era.fileType
+}
+
+// Close closes the underlying file.
+func (era *EraReader) Close() error {
+	return era.file.Close()
+}
+
+// nextEntry returns the next entry of the file, including the entry that was read ahead during type detection.
+func (era *EraReader) nextEntry() (*e2storeEntry, error) {
+	if era.peeked != nil {
+		entry := era.peeked
+		era.peeked = nil
+		return entry, nil
+	}
+
+	return era.reader.readEntry()
+}
+
+// ReadBlocks reads all signed beacon blocks from the era file in order and calls cb for each of them.
+// the beacon state and index entries are skipped.
+func (era *EraReader) ReadBlocks(cb func(block *EraBlock) error) error {
+	if era.fileType != EraFileTypeEra {
+		return fmt.Errorf("%v is not a consensus layer era file", era.path)
+	}
+
+	for {
+		entry, err := era.nextEntry()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch entry.Type {
+		case e2sTypeCompressedSignedBeaconBlock:
+			blockSSZ, err := decompressSnappyFramed(entry.Value)
+			if err != nil {
+				return fmt.Errorf("failed decompressing block at offset %v: %v", entry.Offset, err)
+			}
+
+			// all signed beacon block versions start with the message offset (4 bytes) & signature (96 bytes),
+			// followed by the message which starts with the slot number (8 bytes).
+			if len(blockSSZ) < 108 {
+				return fmt.Errorf("invalid block at offset %v: too short", entry.Offset)
+			}
+
+			err = cb(&EraBlock{
+				Slot: phase0.Slot(binary.LittleEndian.Uint64(blockSSZ[100:108])),
+				SSZ:  blockSSZ,
+			})
+			if err != nil {
+				return err
+			}
+		case e2sTypeCompressedBeaconState, e2sTypeSlotIndex, e2sTypeVersion:
+			continue
+		default:
+			return fmt.Errorf("unexpected entry type 0x%x at offset %v", entry.Type, entry.Offset)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+// EraImporter imports finalized chain history from era files into the database.
+type EraImporter struct {
+	logger        logrus.FieldLogger
+	beaconIndexer *beacon.Indexer
+	chainState    *consensus.ChainState
+	forceUpdate   bool
+}
+
+// NewEraImporter creates a new era file importer.
+// forceUpdate re-imports epochs that are already present in the database.
+func NewEraImporter(logger logrus.FieldLogger, beaconIndexer *beacon.Indexer, chainState *consensus.ChainState, forceUpdate bool) *EraImporter {
+	return &EraImporter{
+		logger:        logger,
+		beaconIndexer: beaconIndexer,
+		chainState:    chainState,
+		forceUpdate:   forceUpdate,
+	}
+}
+
+// ImportFile imports all blocks from the given era file.
+// blocks are grouped by epoch and each epoch is persisted in a separate db transaction.
+func (ei *EraImporter) ImportFile(path string) error {
+	era, err := OpenEraFile(path)
+	if err != nil {
+		return fmt.Errorf("failed opening %v: %v", path, err)
+	}
+	defer era.Close()
+
+	if era.GetFileType() == EraFileTypeEra1 {
+		return fmt.Errorf("%v is an era1 file: execution layer history is not stored by the explorer and can't be imported", path)
+	}
+
+	finalizedEpoch, _ := ei.chainState.GetFinalizedCheckpoint()
+
+	importEpoch := phase0.Epoch(0)
+	epochBlocks := []*spec.VersionedSignedBeaconBlock{}
+	importedEpochs := 0
+	skippedEpochs := 0
+
+	flushEpoch := func() error {
+		if len(epochBlocks) == 0 {
+			return nil
+		}
+
+		defer func() {
+			epochBlocks = []*spec.VersionedSignedBeaconBlock{}
+		}()
+
+		if importEpoch >= finalizedEpoch {
+			return fmt.Errorf("epoch %v is not finalized yet (finalized epoch: %v)", importEpoch, finalizedEpoch)
+		}
+
+		if !ei.forceUpdate && db.IsEpochSynchronized(uint64(importEpoch)) {
+			skippedEpochs++
+			return nil
+		}
+
+		if err := ei.beaconIndexer.ImportFinalizedEpoch(importEpoch, epochBlocks); err != nil {
+			return fmt.Errorf("failed importing epoch %v: %v", importEpoch, err)
+		}
+
+		importedEpochs++
+		ei.logger.Debugf("imported epoch %v with %v blocks", importEpoch, len(epochBlocks))
+		return nil
+	}
+
+	err = era.ReadBlocks(func(eraBlock *EraBlock) error {
+		epoch := ei.chainState.EpochOfSlot(eraBlock.Slot)
+		if epoch != importEpoch {
+			if err := flushEpoch(); err != nil {
+				return err
+			}
+			importEpoch = epoch
+		}
+
+		blockVersion := getForkVersionAtEpoch(ei.chainState.GetSpecs(), epoch)
+		block, err := ei.beaconIndexer.UnmarshalBlockSSZ(blockVersion, eraBlock.SSZ)
+		if err != nil {
+			return fmt.Errorf("failed decoding block at slot %v: %v", eraBlock.Slot, err)
+		}
+
+		epochBlocks = append(epochBlocks, block)
+		return nil
+	})
+	if err == nil {
+		err = flushEpoch()
+	}
+	if err != nil {
+		return err
+	}
+
+	ei.logger.Infof("imported %v: %v epochs imported, %v epochs skipped (already synchronized)", path, importedEpochs, skippedEpochs)
+	return nil
+}
+
+// getForkVersionAtEpoch returns the block version that is active at the given epoch.
+func getForkVersionAtEpoch(specs *consensus.ChainSpec, epoch phase0.Epoch) spec.DataVersion {
+	switch {
+	case specs.ElectraForkEpoch != nil && epoch >= phase0.Epoch(*specs.ElectraForkEpoch):
+		return spec.DataVersionElectra
+	case specs.DenebForkEpoch != nil && epoch >= phase0.Epoch(*specs.DenebForkEpoch):
+		return spec.DataVersionDeneb
+	case specs.CapellaForkEpoch != nil && epoch >= phase0.Epoch(*specs.CapellaForkEpoch):
+		return spec.DataVersionCapella
+	case specs.BellatrixForkEpoch != nil && epoch >= phase0.Epoch(*specs.BellatrixForkEpoch):
+		return spec.DataVersionBellatrix
+	case specs.AltairForkEpoch != nil && epoch >= phase0.Epoch(*specs.AltairForkEpoch):
+		return spec.DataVersionAltair
+	default:
+		return spec.DataVersionPhase0
+	}
+}
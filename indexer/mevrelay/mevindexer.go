@@ -171,6 +171,14 @@ func (mev *MevIndexer) runUpdater() error {
 		}
 	}
 
+	// load validator registrations of the upcoming proposers
+	if utils.Config.MevIndexer.ValidatorRegistrations {
+		err := mev.loadValidatorRegistrations()
+		if err != nil {
+			mev.logger.Errorf("error loading validator registrations: %v", err)
+		}
+	}
+
 	mev.lastRefresh = time.Now()
 	return nil
 }
@@ -364,8 +372,8 @@ func (mev *MevIndexer) getMevBlockProposedStatus(mevBlock *dbtypes.MevBlock, fin
 			}
 		}
 	} else {
-		for _, block := range db.GetSlotsByBlockHash(mevBlock.BlockHash) {
-			if block.Status == dbtypes.Canonical {
+		for _, block := range db.GetExecutionBlockSlotsByHash(mevBlock.BlockHash) {
+			if !block.Orphaned {
 				proposed = 1
 			} else if proposed != 1 {
 				proposed = 2
@@ -0,0 +1,173 @@
+package mevrelay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+type mevIndexerRelayRegistrationResponse struct {
+	Message struct {
+		FeeRecipient string `json:"fee_recipient"`
+		GasLimit     string `json:"gas_limit"`
+		Timestamp    string `json:"timestamp"`
+		Pubkey       string `json:"pubkey"`
+	} `json:"message"`
+	Signature string `json:"signature"`
+}
+
+// loadValidatorRegistrations polls the latest validator registrations of the upcoming proposers (current & next epoch) from all relays.
+// the registration with the newest timestamp is stored per validator, along with the relays that returned it.
+func (mev *MevIndexer) loadValidatorRegistrations() error {
+	currentEpoch := mev.chainState.CurrentEpoch()
+	proposers := []phase0.ValidatorIndex{}
+	proposerMap := map[phase0.ValidatorIndex]bool{}
+	for _, epoch := range []phase0.Epoch{currentEpoch, currentEpoch + 1} {
+		epochStats := mev.beaconIndexer.GetEpochStats(epoch, nil)
+		if epochStats == nil {
+			continue
+		}
+
+		epochStatsValues := epochStats.GetValues(false)
+		if epochStatsValues == nil {
+			continue
+		}
+
+		for _, proposer := range epochStatsValues.ProposerDuties {
+			if !proposerMap[proposer] {
+				proposerMap[proposer] = true
+				proposers = append(proposers, proposer)
+			}
+		}
+	}
+
+	if len(proposers) == 0 {
+		return nil
+	}
+
+	registrations := map[phase0.ValidatorIndex]*dbtypes.ValidatorRegistration{}
+	registrationsMutex := sync.Mutex{}
+
+	wg := &sync.WaitGroup{}
+	for idx := range utils.Config.MevIndexer.Relays {
+		wg.Add(1)
+
+		go func(idx int, relay *types.MevRelayConfig) {
+			defer func() {
+				wg.Done()
+			}()
+
+			relayFlag := uint64(1) << relay.Index
+			for _, proposer := range proposers {
+				validator := mev.beaconIndexer.GetValidatorByIndex(proposer, nil)
+				if validator == nil {
+					continue
+				}
+
+				registration, err := mev.loadValidatorRegistrationFromRelay(relay, validator.PublicKey)
+				if err != nil {
+					mev.logger.Debugf("error loading validator registration of %v from relay %v (%v): %v", proposer, idx, relay.Name, err)
+					continue
+				}
+				if registration == nil {
+					continue
+				}
+
+				registrationsMutex.Lock()
+				if latest := registrations[proposer]; latest == nil || registration.Timestamp > latest.Timestamp {
+					registration.ValidatorIndex = uint64(proposer)
+					registration.SeenbyRelays = relayFlag
+					registrations[proposer] = registration
+				} else if registration.Timestamp == latest.Timestamp {
+					latest.SeenbyRelays |= relayFlag
+				}
+				registrationsMutex.Unlock()
+			}
+		}(idx, &utils.Config.MevIndexer.Relays[idx])
+	}
+	wg.Wait()
+
+	if len(registrations) == 0 {
+		return nil
+	}
+
+	dbRegistrations := make([]*dbtypes.ValidatorRegistration, 0, len(registrations))
+	for _, registration := range registrations {
+		dbRegistrations = append(dbRegistrations, registration)
+	}
+
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertValidatorRegistrations(dbRegistrations, tx)
+	})
+	if err != nil {
+		return fmt.Errorf("error saving validator registrations to db: %v", err)
+	}
+
+	mev.logger.Debugf("loaded validator registrations for %v/%v upcoming proposers", len(dbRegistrations), len(proposers))
+	return nil
+}
+
+// loadValidatorRegistrationFromRelay loads the latest registration of a validator from the relay data api.
+// returns nil if the relay does not know a registration for the validator.
+func (mev *MevIndexer) loadValidatorRegistrationFromRelay(relay *types.MevRelayConfig, pubkey phase0.BLSPubKey) (*dbtypes.ValidatorRegistration, error) {
+	relayUrl, err := url.Parse(relay.Url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid relay url: %v", err)
+	}
+
+	relayUrl.Path = path.Join(relayUrl.Path, "/relay/v1/data/validator_registration")
+	apiUrl := fmt.Sprintf("%v?pubkey=%v", relayUrl.String(), pubkey.String())
+
+	client := &http.Client{Timeout: time.Second * 30}
+	resp, err := client.Get(apiUrl)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch validator registration (%v): %v", utils.GetRedactedUrl(apiUrl), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
+			// relays respond with 400 / 404 if no registration is known for the validator
+			return nil, nil
+		}
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(apiUrl), data)
+	}
+
+	registrationResponse := &mevIndexerRelayRegistrationResponse{}
+	dec := json.NewDecoder(resp.Body)
+	err = dec.Decode(registrationResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing validator registration response: %v", err)
+	}
+
+	gasLimit, err := strconv.ParseUint(registrationResponse.Message.GasLimit, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing validator registration GasLimit: %v", err)
+	}
+
+	timestamp, err := strconv.ParseUint(registrationResponse.Message.Timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing validator registration Timestamp: %v", err)
+	}
+
+	return &dbtypes.ValidatorRegistration{
+		FeeRecipient: common.FromHex(registrationResponse.Message.FeeRecipient),
+		GasLimit:     gasLimit,
+		Timestamp:    timestamp,
+	}, nil
+}
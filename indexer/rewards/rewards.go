@@ -0,0 +1,288 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/indexer/execution"
+)
+
+const rewardsInsertBatchSize = 1000
+
+// Rewards is an enricher that indexes the attestation, sync committee & block proposal rewards of finalized epochs from the beacon rewards apis.
+// the enricher must be fed with exactly one epoch per LoadSlots call.
+// the block rewards include the priority fees paid to the fee recipient (loaded via the receipt fetcher) and the mev payment for relayed blocks.
+// the net consensus layer rewards and the burned execution layer fees of each epoch are tracked as supply stats with a daily rollup.
+// the epoch totals & block rewards are persisted per epoch, the rewards per validator are accumulated in memory and persisted per day,
+// so the first day after a restart covers less epochs.
+type Rewards struct {
+	logger         logrus.FieldLogger
+	beaconIndexer  *beacon.Indexer
+	chainState     *consensus.ChainState
+	receiptFetcher *execution.ReceiptFetcher
+	historyDays    uint64
+	day            uint64
+	firstEpoch     phase0.Epoch
+	nextEpoch      phase0.Epoch
+	validators     map[phase0.ValidatorIndex]rewardsEntry
+}
+
+// rewardsEntry holds the accumulated rewards of a validator.
+type rewardsEntry struct {
+	attestation   int64
+	syncCommittee int64
+	proposer      int64
+	epochs        uint32
+}
+
+// NewRewards creates a new rewards enricher.
+// the receipt fetcher is optional, execution layer fees are not indexed if it is nil.
+func NewRewards(logger logrus.FieldLogger, beaconIndexer *beacon.Indexer, chainState *consensus.ChainState, receiptFetcher *execution.ReceiptFetcher, historyDays uint64) *Rewards {
+	return &Rewards{
+		logger:         logger,
+		beaconIndexer:  beaconIndexer,
+		chainState:     chainState,
+		receiptFetcher: receiptFetcher,
+		historyDays:    historyDays,
+		validators:     map[phase0.ValidatorIndex]rewardsEntry{},
+	}
+}
+
+// GetName returns the name of the enricher.
+func (rewards *Rewards) GetName() string {
+	return "rewards"
+}
+
+// getDayOfEpoch returns the day number (since genesis) of the given epoch.
+func (rewards *Rewards) getDayOfEpoch(epoch phase0.Epoch) uint64 {
+	genesis := rewards.chainState.GetGenesis()
+	if genesis == nil {
+		return 0
+	}
+
+	return uint64(rewards.chainState.EpochToTime(epoch).Sub(genesis.GenesisTime) / (24 * time.Hour))
+}
+
+// LoadSlots loads the rewards of the epoch starting at firstSlot from a ready beacon node.
+func (rewards *Rewards) LoadSlots(ctx context.Context, firstSlot phase0.Slot, lastSlot phase0.Slot) (func(tx *sqlx.Tx) error, error) {
+	epoch := rewards.chainState.EpochOfSlot(firstSlot)
+	if rewards.chainState.EpochOfSlot(lastSlot) != epoch {
+		return nil, fmt.Errorf("slot range %v-%v spans multiple epochs", firstSlot, lastSlot)
+	}
+
+	client := rewards.beaconIndexer.GetReadyClient(true)
+	if client == nil {
+		return nil, fmt.Errorf("no ready beacon client")
+	}
+	rpcClient := client.GetClient().GetRPCClient()
+
+	attestationRewards, err := rpcClient.GetAttestationRewards(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading attestation rewards for epoch %v: %v", epoch, err)
+	}
+
+	epochRewards := &dbtypes.EpochRewards{
+		Epoch: uint64(epoch),
+	}
+	validatorRewards := map[phase0.ValidatorIndex]rewardsEntry{}
+
+	if attestationRewards != nil {
+		epochRewards.Validators = uint64(len(attestationRewards.TotalRewards))
+		for _, reward := range attestationRewards.TotalRewards {
+			epochRewards.AttestationHead += reward.Head
+			epochRewards.AttestationTarget += reward.Target
+			epochRewards.AttestationSource += reward.Source
+			epochRewards.AttestationInclusionDelay += reward.InclusionDelay
+			epochRewards.AttestationInactivity += reward.Inactivity
+
+			entry := validatorRewards[phase0.ValidatorIndex(reward.ValidatorIndex)]
+			entry.attestation += reward.Head + reward.Target + reward.Source + reward.InclusionDelay + reward.Inactivity
+			entry.epochs++
+			validatorRewards[phase0.ValidatorIndex(reward.ValidatorIndex)] = entry
+		}
+	}
+
+	specs := rewards.chainState.GetSpecs()
+	isAltair := specs.AltairForkEpoch != nil && uint64(epoch) >= *specs.AltairForkEpoch
+
+	supplyStats := &dbtypes.SupplyEpoch{
+		Epoch: uint64(epoch),
+		Day:   rewards.getDayOfEpoch(epoch),
+	}
+
+	blockRewards := []*dbtypes.BlockRewards{}
+	for _, slot := range db.GetSlotsRange(uint64(lastSlot), uint64(firstSlot), false, false) {
+		if slot.Block == nil || slot.Block.Status != dbtypes.Canonical || slot.Slot == 0 {
+			continue
+		}
+
+		blockRoot := phase0.Root(slot.Block.Root)
+		blockReward, err := rpcClient.GetBlockRewards(ctx, blockRoot)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading block rewards for slot %v: %v", slot.Slot, err)
+		}
+
+		blockRewardEntry := &dbtypes.BlockRewards{
+			Slot:              slot.Slot,
+			Proposer:          uint64(blockReward.ProposerIndex),
+			Total:             int64(blockReward.Total),
+			Attestations:      int64(blockReward.Attestations),
+			SyncAggregate:     int64(blockReward.SyncAggregate),
+			ProposerSlashings: int64(blockReward.ProposerSlashings),
+			AttesterSlashings: int64(blockReward.AttesterSlashings),
+		}
+		burnedFees, err := rewards.loadExecutionRewards(ctx, slot.Block, blockRewardEntry)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading execution rewards for slot %v: %v", slot.Slot, err)
+		}
+		blockRewards = append(blockRewards, blockRewardEntry)
+		supplyStats.Blocks++
+		supplyStats.Burned += burnedFees
+		epochRewards.Proposer += int64(blockReward.Total)
+
+		entry := validatorRewards[blockReward.ProposerIndex]
+		entry.proposer += int64(blockReward.Total)
+		validatorRewards[blockReward.ProposerIndex] = entry
+
+		if !isAltair {
+			continue
+		}
+
+		syncRewards, err := rpcClient.GetSyncCommitteeRewards(ctx, blockRoot)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading sync committee rewards for slot %v: %v", slot.Slot, err)
+		}
+
+		for _, syncReward := range syncRewards {
+			epochRewards.SyncCommittee += syncReward.Reward
+
+			entry := validatorRewards[syncReward.ValidatorIndex]
+			entry.syncCommittee += syncReward.Reward
+			validatorRewards[syncReward.ValidatorIndex] = entry
+		}
+	}
+
+	supplyStats.Issued = epochRewards.AttestationHead + epochRewards.AttestationTarget + epochRewards.AttestationSource +
+		epochRewards.AttestationInclusionDelay + epochRewards.AttestationInactivity + epochRewards.SyncCommittee + epochRewards.Proposer
+
+	// persist the rewards of the previous day before accumulating the first epoch of a new day
+	day := supplyStats.Day
+	if day != rewards.day && len(rewards.validators) > 0 {
+		if err := rewards.flushDay(); err != nil {
+			return nil, fmt.Errorf("failed persisting validator rewards for day %v: %v", rewards.day, err)
+		}
+
+		rewards.validators = map[phase0.ValidatorIndex]rewardsEntry{}
+	}
+	if len(rewards.validators) == 0 {
+		rewards.day = day
+		rewards.firstEpoch = epoch
+	}
+
+	// epochs are retried if persisting fails, so skip accumulating epochs that have already been added
+	if epoch >= rewards.nextEpoch {
+		for validatorIndex, reward := range validatorRewards {
+			entry := rewards.validators[validatorIndex]
+			entry.attestation += reward.attestation
+			entry.syncCommittee += reward.syncCommittee
+			entry.proposer += reward.proposer
+			entry.epochs += reward.epochs
+			rewards.validators[validatorIndex] = entry
+		}
+		rewards.nextEpoch = epoch + 1
+	}
+
+	return func(tx *sqlx.Tx) error {
+		if err := db.InsertEpochRewards(epochRewards, tx); err != nil {
+			return err
+		}
+
+		if err := db.InsertBlockRewards(blockRewards, tx); err != nil {
+			return err
+		}
+
+		return db.InsertSupplyEpoch(supplyStats, tx)
+	}, nil
+}
+
+// loadExecutionRewards loads the priority fees & mev payment of the execution payload of a block.
+// returns the fees burned by the execution payload (in gwei).
+func (rewards *Rewards) loadExecutionRewards(ctx context.Context, slot *dbtypes.Slot, blockReward *dbtypes.BlockRewards) (int64, error) {
+	if slot.EthBlockNumber == nil || *slot.EthBlockNumber == 0 || len(slot.EthBlockHash) == 0 {
+		return 0, nil
+	}
+
+	if mevBlock := db.GetMevBlockByBlockHash(slot.EthBlockHash); mevBlock != nil {
+		blockReward.MevPayment = int64(mevBlock.BlockValueGwei)
+	}
+
+	if rewards.receiptFetcher == nil {
+		return 0, nil
+	}
+
+	blockReceipts, err := rewards.receiptFetcher.GetBlockReceipts(ctx, common.BytesToHash(slot.EthBlockHash))
+	if err != nil {
+		return 0, err
+	}
+
+	priorityFees := blockReceipts.GetPriorityFees()
+	blockReward.ElFees = priorityFees.Div(priorityFees, big.NewInt(1000000000)).Int64()
+
+	burnedFees := blockReceipts.GetBurnedFees()
+	return burnedFees.Div(burnedFees, big.NewInt(1000000000)).Int64(), nil
+}
+
+// flushDay persists the accumulated validator rewards of the tracked day.
+func (rewards *Rewards) flushDay() error {
+	t1 := time.Now()
+	dayRewards := make([]*dbtypes.ValidatorRewards, 0, len(rewards.validators))
+	for validatorIndex, entry := range rewards.validators {
+		dayRewards = append(dayRewards, &dbtypes.ValidatorRewards{
+			ValidatorIndex: uint64(validatorIndex),
+			Day:            rewards.day,
+			Epochs:         entry.epochs,
+			Attestation:    entry.attestation,
+			SyncCommittee:  entry.syncCommittee,
+			Proposer:       entry.proposer,
+		})
+	}
+
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		for start := 0; start < len(dayRewards); start += rewardsInsertBatchSize {
+			end := start + rewardsInsertBatchSize
+			if end > len(dayRewards) {
+				end = len(dayRewards)
+			}
+
+			if err := db.InsertValidatorRewardsBatch(dayRewards[start:end], tx); err != nil {
+				return err
+			}
+		}
+
+		if rewards.historyDays > 0 && rewards.day >= rewards.historyDays {
+			if err := db.DeleteValidatorRewardsBefore(rewards.day-rewards.historyDays+1, tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rewards.logger.Infof("persisted rewards of %v validators for day %v (first epoch: %v, %v ms)", len(dayRewards), rewards.day, rewards.firstEpoch, time.Since(t1).Milliseconds())
+
+	return nil
+}
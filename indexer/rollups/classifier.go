@@ -0,0 +1,46 @@
+package rollups
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethpandaops/dora/types"
+)
+
+// Classifier maps blob transactions to rollups by their sender (batch poster) or target address (inbox contract).
+type Classifier struct {
+	senders map[common.Address]string
+	targets map[common.Address]string
+}
+
+// NewClassifier creates a classifier from the configured rollup mappings, invalid addresses are ignored.
+func NewClassifier(mappings []types.RollupConfig) *Classifier {
+	classifier := &Classifier{
+		senders: map[common.Address]string{},
+		targets: map[common.Address]string{},
+	}
+
+	for _, mapping := range mappings {
+		for _, sender := range mapping.Senders {
+			if common.IsHexAddress(sender) {
+				classifier.senders[common.HexToAddress(sender)] = mapping.Name
+			}
+		}
+		for _, target := range mapping.ToAddresses {
+			if common.IsHexAddress(target) {
+				classifier.targets[common.HexToAddress(target)] = mapping.Name
+			}
+		}
+	}
+
+	return classifier
+}
+
+// Classify returns the rollup name of a blob transaction, or an empty string if the transaction could not be classified.
+// sender mappings take precedence, as inbox contracts may be shared by several rollups.
+func (classifier *Classifier) Classify(sender common.Address, to common.Address) string {
+	if name, found := classifier.senders[sender]; found {
+		return name
+	}
+
+	return classifier.targets[to]
+}
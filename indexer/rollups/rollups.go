@@ -0,0 +1,146 @@
+package rollups
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+const blobTxInsertBatchSize = 1000
+
+// Rollups is an enricher that indexes the blob transactions of finalized blocks.
+// only the sender, target address & blob count are persisted, the classification by rollup is done when the stats are loaded,
+// so changes to the rollup mappings also apply to already indexed transactions.
+type Rollups struct {
+	logger        logrus.FieldLogger
+	beaconIndexer *beacon.Indexer
+	chainState    *consensus.ChainState
+}
+
+// NewRollups creates a new rollup blob transaction enricher.
+func NewRollups(logger logrus.FieldLogger, beaconIndexer *beacon.Indexer, chainState *consensus.ChainState) *Rollups {
+	return &Rollups{
+		logger:        logger,
+		beaconIndexer: beaconIndexer,
+		chainState:    chainState,
+	}
+}
+
+// GetName returns the name of the enricher.
+func (rollups *Rollups) GetName() string {
+	return "rollups"
+}
+
+// LoadSlots loads the blob transactions of all canonical blocks in the given slot range.
+func (rollups *Rollups) LoadSlots(ctx context.Context, firstSlot phase0.Slot, lastSlot phase0.Slot) (func(tx *sqlx.Tx) error, error) {
+	specs := rollups.chainState.GetSpecs()
+	if specs == nil || specs.DenebForkEpoch == nil || rollups.chainState.EpochOfSlot(lastSlot) < phase0.Epoch(*specs.DenebForkEpoch) {
+		// no blob transactions before deneb
+		return func(tx *sqlx.Tx) error { return nil }, nil
+	}
+
+	blobTxs := []*dbtypes.BlobTransaction{}
+	for _, slot := range db.GetSlotsRange(uint64(lastSlot), uint64(firstSlot), false, false) {
+		if slot.Block == nil || slot.Block.Status != dbtypes.Canonical || slot.Block.EthTransactionCount == 0 {
+			continue
+		}
+
+		blockTxs, err := rollups.loadBlobTransactions(ctx, slot.Block)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading blob transactions for slot %v: %v", slot.Slot, err)
+		}
+
+		blobTxs = append(blobTxs, blockTxs...)
+	}
+
+	return func(tx *sqlx.Tx) error {
+		for start := 0; start < len(blobTxs); start += blobTxInsertBatchSize {
+			end := start + blobTxInsertBatchSize
+			if end > len(blobTxs) {
+				end = len(blobTxs)
+			}
+
+			if err := db.InsertBlobTransactions(blobTxs[start:end], tx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// loadBlobTransactions decodes the blob transactions of a block, the block body is taken from the block cache or loaded from a ready beacon node.
+func (rollups *Rollups) loadBlobTransactions(ctx context.Context, block *dbtypes.Slot) ([]*dbtypes.BlobTransaction, error) {
+	blockRoot := phase0.Root(block.Root)
+
+	var blockBody *spec.VersionedSignedBeaconBlock
+	if cachedBlock := rollups.beaconIndexer.GetBlockByRoot(blockRoot); cachedBlock != nil {
+		blockBody = cachedBlock.GetBlock()
+	}
+	if blockBody == nil {
+		client := rollups.beaconIndexer.GetReadyClientByBlockRoot(blockRoot, true)
+		if client == nil {
+			return nil, fmt.Errorf("no ready beacon client")
+		}
+
+		var err error
+		blockBody, err = beacon.LoadBeaconBlock(ctx, client, blockRoot)
+		if err != nil {
+			return nil, err
+		}
+		if blockBody == nil {
+			return nil, fmt.Errorf("block %v not found", blockRoot.String())
+		}
+	}
+
+	blobCommitments, _ := blockBody.BlobKZGCommitments()
+	if len(blobCommitments) == 0 {
+		return nil, nil
+	}
+
+	transactions, err := blockBody.ExecutionTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	blobTxs := []*dbtypes.BlobTransaction{}
+	for idx, txBytes := range transactions {
+		var tx ethtypes.Transaction
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
+			rollups.logger.Warnf("error decoding transaction 0x%x.%v: %v", block.Root, idx, err)
+			continue
+		}
+
+		if tx.Type() != ethtypes.BlobTxType || tx.To() == nil {
+			continue
+		}
+
+		sender, err := ethtypes.Sender(ethtypes.NewPragueSigner(tx.ChainId()), &tx)
+		if err != nil {
+			rollups.logger.Warnf("error decoding transaction sender 0x%x.%v: %v", block.Root, idx, err)
+			continue
+		}
+
+		txHash := tx.Hash()
+		blobTxs = append(blobTxs, &dbtypes.BlobTransaction{
+			Slot:      block.Slot,
+			TxIndex:   uint32(idx),
+			TxHash:    txHash[:],
+			Sender:    sender[:],
+			ToAddress: tx.To().Bytes(),
+			BlobCount: uint16(len(tx.BlobHashes())),
+		})
+	}
+
+	return blobTxs, nil
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/dbtypes"
+	execindexer "github.com/ethpandaops/dora/indexer/execution"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// AddressWatchWebhookPayload is the body POSTed to the configured address watch webhooks
+type AddressWatchWebhookPayload struct {
+	Event           string `json:"event"` // always "address_activity"
+	Network         string `json:"network"`
+	Type            string `json:"type"` // "deposit", "withdrawal_request" or "consolidation_request"
+	BlockNumber     uint64 `json:"block_number"`
+	BlockTime       uint64 `json:"block_time"`
+	TxHash          string `json:"tx_hash"`
+	TxSender        string `json:"tx_sender"`
+	SourceAddress   string `json:"source_address,omitempty"`
+	ValidatorPubkey string `json:"validator_pubkey,omitempty"`
+	TargetPubkey    string `json:"target_pubkey,omitempty"`
+	Amount          int64  `json:"amount"`
+}
+
+// GetWatchedAddresses returns the valid addresses of the address watch configuration
+func GetWatchedAddresses(logger logrus.FieldLogger) []common.Address {
+	addresses := []common.Address{}
+	for _, addressStr := range utils.Config.AddressWatch.Addresses {
+		if !common.IsHexAddress(addressStr) {
+			if logger != nil {
+				logger.Warnf("invalid address in address watch: %v", addressStr)
+			}
+			continue
+		}
+
+		addresses = append(addresses, common.HexToAddress(addressStr))
+	}
+
+	return addresses
+}
+
+// newAddressWatchWebhookHook returns an address watch hook that sends new interactions to the configured webhooks.
+func newAddressWatchWebhookHook(logger logrus.FieldLogger, chainState *consensus.ChainState) execindexer.AddressWatchHook {
+	sender := newWebhookSender(logger, "address watch", utils.Config.Webhooks.AddressWatch)
+
+	return func(event *dbtypes.AddressWatchEvent) {
+		payload := &AddressWatchWebhookPayload{
+			Event:       "address_activity",
+			Network:     getNetworkName(chainState),
+			Type:        GetAddressWatchEventTypeKey(event.Type),
+			BlockNumber: event.BlockNumber,
+			BlockTime:   event.BlockTime,
+			TxHash:      hexutil.Encode(event.TxHash),
+			TxSender:    common.BytesToAddress(event.TxSender).Hex(),
+			Amount:      event.Amount,
+		}
+		if len(event.SourceAddress) > 0 {
+			payload.SourceAddress = common.BytesToAddress(event.SourceAddress).Hex()
+		}
+		if len(event.ValidatorPubkey) > 0 {
+			payload.ValidatorPubkey = hexutil.Encode(event.ValidatorPubkey)
+		}
+		if len(event.TargetPubkey) > 0 {
+			payload.TargetPubkey = hexutil.Encode(event.TargetPubkey)
+		}
+
+		sender.send(payload)
+	}
+}
+
+// GetAddressWatchEventTypeKey returns the api key of an address watch event type
+func GetAddressWatchEventTypeKey(eventType dbtypes.AddressWatchEventType) string {
+	switch eventType {
+	case dbtypes.AddressWatchEventDeposit:
+		return "deposit"
+	case dbtypes.AddressWatchEventWithdrawalRequest:
+		return "withdrawal_request"
+	case dbtypes.AddressWatchEventConsolidationRequest:
+		return "consolidation_request"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// AnomalyWebhookPayload is the body POSTed to the configured anomaly webhooks
+type AnomalyWebhookPayload struct {
+	Event         string `json:"event"` // always "anomaly"
+	Network       string `json:"network"`
+	Validator     uint64 `json:"validator"`
+	ValidatorName string `json:"validator_name"`
+	Slot          uint64 `json:"slot"`
+	Epoch         uint64 `json:"epoch"`
+	Type          string `json:"type"` // "fee_recipient", "withdrawal_credentials" or "graffiti"
+	Before        string `json:"before"`
+	After         string `json:"after"`
+}
+
+// newAnomalyWatchlist returns a watchlist resolver that matches validators by the configured index ranges & names
+func newAnomalyWatchlist(logger logrus.FieldLogger, validatorNames *ValidatorNames) beacon.WatchlistResolver {
+	type indexRange struct {
+		minIndex uint64
+		maxIndex uint64
+	}
+
+	indexRanges := []indexRange{}
+	if utils.Config.Anomalies.Watchlist != "" {
+		for _, rangeStr := range strings.Split(utils.Config.Anomalies.Watchlist, ",") {
+			minIndex, maxIndex, err := utils.ParseValidatorIndexRange(rangeStr)
+			if err != nil {
+				logger.Warnf("invalid validator range in anomaly watchlist: %v", err)
+				continue
+			}
+
+			indexRanges = append(indexRanges, indexRange{minIndex, maxIndex})
+		}
+	}
+
+	watchNames := map[string]bool{}
+	for _, name := range utils.Config.Anomalies.WatchNames {
+		watchNames[name] = true
+	}
+
+	return func(validatorIndex phase0.ValidatorIndex) bool {
+		for _, indexRange := range indexRanges {
+			if uint64(validatorIndex) >= indexRange.minIndex && uint64(validatorIndex) <= indexRange.maxIndex {
+				return true
+			}
+		}
+
+		if len(watchNames) > 0 {
+			return watchNames[validatorNames.GetValidatorName(uint64(validatorIndex))]
+		}
+
+		return false
+	}
+}
+
+// newAnomalyWebhookHook returns an anomaly hook that sends detected anomalies to the configured webhooks.
+func newAnomalyWebhookHook(logger logrus.FieldLogger, chainState *consensus.ChainState, validatorNames *ValidatorNames) beacon.AnomalyHook {
+	sender := newWebhookSender(logger, "anomaly", utils.Config.Webhooks.Anomalies)
+
+	return func(anomaly *dbtypes.ValidatorAnomaly) {
+		payload := &AnomalyWebhookPayload{
+			Event:         "anomaly",
+			Network:       getNetworkName(chainState),
+			Validator:     anomaly.ValidatorIndex,
+			ValidatorName: validatorNames.GetValidatorName(anomaly.ValidatorIndex),
+			Slot:          anomaly.Slot,
+			Epoch:         uint64(chainState.EpochOfSlot(phase0.Slot(anomaly.Slot))),
+			Type:          GetAnomalyTypeKey(anomaly.Type),
+			Before:        FormatAnomalyValue(anomaly.Type, anomaly.BeforeValue),
+			After:         FormatAnomalyValue(anomaly.Type, anomaly.AfterValue),
+		}
+
+		sender.send(payload)
+	}
+}
+
+// GetAnomalyTypeKey returns the api key of an anomaly type
+func GetAnomalyTypeKey(anomalyType dbtypes.ValidatorAnomalyType) string {
+	switch anomalyType {
+	case dbtypes.ValidatorAnomalyFeeRecipient:
+		return "fee_recipient"
+	case dbtypes.ValidatorAnomalyWithdrawalCredentials:
+		return "withdrawal_credentials"
+	case dbtypes.ValidatorAnomalyGraffiti:
+		return "graffiti"
+	default:
+		return "unknown"
+	}
+}
+
+// FormatAnomalyValue returns a readable representation of an anomaly value (graffiti as text, everything else as hex)
+func FormatAnomalyValue(anomalyType dbtypes.ValidatorAnomalyType, value []byte) string {
+	if len(value) == 0 {
+		return ""
+	}
+	if anomalyType == dbtypes.ValidatorAnomalyGraffiti {
+		return utils.GraffitiToString(value)
+	}
+	return hexutil.Encode(value)
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// number of buckets the rolling quota window is split into
+const apiQuotaBuckets = 60
+
+var ErrInvalidApiKey = errors.New("invalid api key")
+var ErrApiKeyRequired = errors.New("api key required")
+var ErrApiQuotaExceeded = errors.New("api quota exceeded")
+
+// ApiQuotaManager tracks the api calls per api key / ip in a rolling window and enforces the configured quota tiers
+type ApiQuotaManager struct {
+	window     time.Duration
+	bucketSize time.Duration
+	proxyCount uint
+	keys       map[string]*apiQuotaKey
+
+	mutex   sync.Mutex
+	callers map[string]*apiQuotaCaller
+}
+
+// apiQuotaKey holds the resolved quota settings of a configured api key
+type apiQuotaKey struct {
+	name  string
+	tier  string
+	limit uint64
+	rate  float64 // token bucket rate of the key (0 = ip based rate limit)
+	burst uint
+}
+
+// apiQuotaCaller holds the call counts of an api key / ip in the rolling window
+type apiQuotaCaller struct {
+	buckets    [apiQuotaBuckets]uint64 // calls per bucket (ring buffer)
+	lastBucket int64                   // number of the most recent bucket (unix time / bucket size)
+	lastSeen   time.Time
+}
+
+// ApiQuotaUsage is the usage of an api key / ip within the current rolling window
+type ApiQuotaUsage struct {
+	Identity     string        // key owner or "anonymous" for ip based quotas
+	Tier         string        // quota tier of the key, empty for ip based quotas
+	Limit        uint64        // calls per window (0 = unlimited)
+	Used         uint64        // calls within the current window
+	Window       time.Duration // length of the rolling window
+	ResetTime    time.Time     // time when the oldest counted calls leave the window
+	BucketSize   time.Duration
+	HistoryStart time.Time // start time of the first history bucket
+	History      []uint64  // calls per bucket within the current window, oldest first
+}
+
+var GlobalApiQuotaManager *ApiQuotaManager
+
+// StartApiQuotaManager is used to start the global api quota manager
+func StartApiQuotaManager(logger logrus.FieldLogger) error {
+	if GlobalApiQuotaManager != nil {
+		return nil
+	}
+
+	quotaConfig := &utils.Config.Api.Quotas
+	tiers := map[string]*types.ApiQuotaTierConfig{}
+	for idx := range quotaConfig.Tiers {
+		tiers[quotaConfig.Tiers[idx].Name] = &quotaConfig.Tiers[idx]
+	}
+
+	keys := map[string]*apiQuotaKey{}
+	for _, key := range quotaConfig.Keys {
+		if key.Key == "" {
+			continue
+		}
+
+		quotaKey := &apiQuotaKey{
+			name:  key.Name,
+			tier:  key.Tier,
+			limit: quotaConfig.AnonymousLimit,
+		}
+
+		if tier := tiers[key.Tier]; tier != nil {
+			quotaKey.limit = tier.Limit
+			quotaKey.rate = tier.Rate
+			quotaKey.burst = tier.Burst
+			if quotaKey.burst == 0 {
+				quotaKey.burst = uint(math.Ceil(tier.Rate))
+			}
+		} else {
+			logger.Warnf("unknown quota tier '%v' for api key '%v', falling back to anonymous limit", key.Tier, key.Name)
+		}
+
+		keys[key.Key] = quotaKey
+	}
+
+	GlobalApiQuotaManager = &ApiQuotaManager{
+		window:     quotaConfig.Window,
+		bucketSize: quotaConfig.Window / apiQuotaBuckets,
+		proxyCount: quotaConfig.ProxyCount,
+		keys:       keys,
+		callers:    map[string]*apiQuotaCaller{},
+	}
+	if GlobalApiQuotaManager.bucketSize < time.Second {
+		GlobalApiQuotaManager.bucketSize = time.Second
+	}
+	go GlobalApiQuotaManager.cleanupCallers()
+
+	return nil
+}
+
+// CheckQuota counts an api call of the calling key / ip and checks it against the quota.
+// returns ErrApiQuotaExceeded if the quota is used up (the call is not counted then), or ErrInvalidApiKey for unknown keys.
+func (aqm *ApiQuotaManager) CheckQuota(r *http.Request) (*ApiQuotaUsage, error) {
+	return aqm.processCall(r, true)
+}
+
+// GetUsage returns the usage of the calling key / ip without counting the call
+func (aqm *ApiQuotaManager) GetUsage(r *http.Request) (*ApiQuotaUsage, error) {
+	return aqm.processCall(r, false)
+}
+
+func (aqm *ApiQuotaManager) processCall(r *http.Request, countCall bool) (*ApiQuotaUsage, error) {
+	if aqm == nil {
+		return nil, nil
+	}
+
+	callerId, key, err := aqm.resolveCaller(r)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &ApiQuotaUsage{
+		Identity:   "anonymous",
+		Limit:      utils.Config.Api.Quotas.AnonymousLimit,
+		Window:     aqm.window,
+		BucketSize: aqm.bucketSize,
+	}
+	if key != nil {
+		usage.Identity = key.name
+		usage.Tier = key.tier
+		usage.Limit = key.limit
+	}
+
+	now := time.Now()
+	currentBucket := now.UnixNano() / int64(aqm.bucketSize)
+
+	aqm.mutex.Lock()
+	defer aqm.mutex.Unlock()
+
+	caller := aqm.callers[callerId]
+	if caller == nil {
+		caller = &apiQuotaCaller{
+			lastBucket: currentBucket,
+		}
+		aqm.callers[callerId] = caller
+	}
+	caller.advance(currentBucket)
+	caller.lastSeen = now
+
+	for _, count := range caller.buckets {
+		usage.Used += count
+	}
+
+	if countCall {
+		if usage.Limit > 0 && usage.Used >= usage.Limit {
+			err = ErrApiQuotaExceeded
+		} else {
+			caller.buckets[currentBucket%apiQuotaBuckets]++
+			usage.Used++
+		}
+	}
+
+	usage.HistoryStart = time.Unix(0, (currentBucket-apiQuotaBuckets+1)*int64(aqm.bucketSize))
+	usage.History = make([]uint64, apiQuotaBuckets)
+	oldestBucket := int64(-1)
+	for i := int64(0); i < apiQuotaBuckets; i++ {
+		bucket := currentBucket - apiQuotaBuckets + 1 + i
+		usage.History[i] = caller.buckets[bucket%apiQuotaBuckets]
+		if oldestBucket == -1 && usage.History[i] > 0 {
+			oldestBucket = bucket
+		}
+	}
+	if oldestBucket == -1 {
+		oldestBucket = currentBucket
+	}
+	usage.ResetTime = time.Unix(0, (oldestBucket+apiQuotaBuckets)*int64(aqm.bucketSize))
+
+	return usage, err
+}
+
+// getRequestApiKey returns the api key of the request (X-Api-Key header or apikey query parameter)
+func getRequestApiKey(r *http.Request) string {
+	apiKey := r.Header.Get("X-Api-Key")
+	if apiKey == "" {
+		apiKey = r.URL.Query().Get("apikey")
+	}
+	return apiKey
+}
+
+// resolveCaller returns the identifier of the calling key / ip and the api key settings (nil for ip based quotas)
+func (aqm *ApiQuotaManager) resolveCaller(r *http.Request) (string, *apiQuotaKey, error) {
+	apiKey := getRequestApiKey(r)
+	if apiKey == "" && utils.Config.Api.Quotas.RequireKey {
+		return "", nil, ErrApiKeyRequired
+	}
+
+	if apiKey != "" {
+		key := aqm.keys[apiKey]
+		if key == nil {
+			return "", nil, ErrInvalidApiKey
+		}
+		return "key:" + apiKey, key, nil
+	}
+
+	ip := getCallerIP(r, aqm.proxyCount)
+	if ip == "" {
+		return "", nil, errors.New("could not resolve caller ip")
+	}
+	return "ip:" + ip, nil, nil
+}
+
+// getKeyRateLimit returns the token bucket settings of the api key used for the request.
+// returns ok = false for requests without (valid) api key or keys without own rate limit, these are rate limited by ip.
+func (aqm *ApiQuotaManager) getKeyRateLimit(r *http.Request) (apiKey string, rate float64, burst uint, ok bool) {
+	if aqm == nil {
+		return "", 0, 0, false
+	}
+
+	apiKey = getRequestApiKey(r)
+	if apiKey == "" {
+		return "", 0, 0, false
+	}
+
+	key := aqm.keys[apiKey]
+	if key == nil || key.rate <= 0 {
+		return "", 0, 0, false
+	}
+
+	return apiKey, key.rate, key.burst, true
+}
+
+// advance moves the ring buffer to the given bucket, clearing the buckets that left the window
+func (caller *apiQuotaCaller) advance(currentBucket int64) {
+	if currentBucket <= caller.lastBucket {
+		return
+	}
+
+	if currentBucket-caller.lastBucket >= apiQuotaBuckets {
+		caller.buckets = [apiQuotaBuckets]uint64{}
+	} else {
+		for bucket := caller.lastBucket + 1; bucket <= currentBucket; bucket++ {
+			caller.buckets[bucket%apiQuotaBuckets] = 0
+		}
+	}
+	caller.lastBucket = currentBucket
+}
+
+func (aqm *ApiQuotaManager) cleanupCallers() {
+	for {
+		time.Sleep(aqm.bucketSize)
+
+		aqm.mutex.Lock()
+		for callerId, caller := range aqm.callers {
+			if time.Since(caller.lastSeen) > aqm.window {
+				delete(aqm.callers, callerId)
+			}
+		}
+		aqm.mutex.Unlock()
+	}
+}
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/utils"
+)
+
+// AssertoorService polls the test runs of an assertoor instance
+type AssertoorService struct {
+	logger logrus.FieldLogger
+	client *http.Client
+	apiUrl string
+
+	mutex      sync.RWMutex
+	testRuns   []*AssertoorTestRun
+	lastUpdate time.Time
+	lastError  error
+}
+
+// AssertoorTestRun is a test run of the assertoor instance
+type AssertoorTestRun struct {
+	RunId     uint64
+	TestId    string
+	Name      string
+	Status    string // pending, running, success, failure, skipped or aborted
+	StartTime time.Time
+	StopTime  time.Time
+	Tasks     []*AssertoorTask
+}
+
+// AssertoorTask is a task of an assertoor test run
+type AssertoorTask struct {
+	Index       uint64
+	ParentIndex uint64
+	Name        string
+	Title       string
+	Status      string // pending, running or complete
+	Result      string // none, success or failure
+	ResultError string
+	StartTime   time.Time
+	StopTime    time.Time
+	Slots       []uint64 // slots referenced in the task title or error
+	Validators  []uint64 // validators referenced in the task title or error
+}
+
+// assertoorApiResponse is the envelope of all assertoor api responses
+type assertoorApiResponse struct {
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type assertoorApiTestRun struct {
+	RunId     uint64                  `json:"run_id"`
+	TestId    string                  `json:"test_id"`
+	Name      string                  `json:"name"`
+	Status    string                  `json:"status"`
+	StartTime int64                   `json:"start_time"`
+	StopTime  int64                   `json:"stop_time"`
+	Tasks     []*assertoorApiTestTask `json:"tasks"`
+}
+
+type assertoorApiTestTask struct {
+	Index       uint64 `json:"index"`
+	ParentIndex uint64 `json:"parent_index"`
+	Name        string `json:"name"`
+	Title       string `json:"title"`
+	StartTime   int64  `json:"start_time"`
+	StopTime    int64  `json:"stop_time"`
+	Status      string `json:"status"`
+	Result      string `json:"result"`
+	ResultError string `json:"result_error"`
+}
+
+var assertoorSlotPattern = regexp.MustCompile(`(?i)\bslots?\s*#?(\d+)`)
+var assertoorValidatorPattern = regexp.MustCompile(`(?i)\bvalidators?\s*(?:index\s*)?#?(\d+)`)
+
+var GlobalAssertoorService *AssertoorService
+
+// StartAssertoorService is used to start the global assertoor polling service
+func StartAssertoorService(logger logrus.FieldLogger) error {
+	if GlobalAssertoorService != nil {
+		return nil
+	}
+
+	if utils.Config.Integrations.Assertoor.Url == "" {
+		return fmt.Errorf("missing assertoor url")
+	}
+
+	GlobalAssertoorService = &AssertoorService{
+		logger: logger,
+		client: &http.Client{Timeout: 30 * time.Second},
+		apiUrl: strings.TrimSuffix(utils.Config.Integrations.Assertoor.Url, "/"),
+	}
+
+	go GlobalAssertoorService.runPollLoop()
+
+	return nil
+}
+
+// GetTestRuns returns the most recent test runs (newest first) along with the time of the last successful update and the last polling error
+func (as *AssertoorService) GetTestRuns() ([]*AssertoorTestRun, time.Time, error) {
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+
+	return as.testRuns, as.lastUpdate, as.lastError
+}
+
+// GetTestRun returns a cached test run by its id or nil if unknown
+func (as *AssertoorService) GetTestRun(runId uint64) *AssertoorTestRun {
+	as.mutex.RLock()
+	defer as.mutex.RUnlock()
+
+	for _, testRun := range as.testRuns {
+		if testRun.RunId == runId {
+			return testRun
+		}
+	}
+	return nil
+}
+
+// GetRunUrl returns the link to a test run in the assertoor web ui
+func (as *AssertoorService) GetRunUrl(runId uint64) string {
+	return fmt.Sprintf("%v/run/%v", strings.TrimSuffix(utils.Config.Integrations.Assertoor.PublicUrl, "/"), runId)
+}
+
+func (as *AssertoorService) runPollLoop() {
+	defer utils.HandleSubroutinePanic("AssertoorService.runPollLoop", as.runPollLoop)
+
+	for {
+		err := as.updateTestRuns()
+		if err != nil {
+			as.logger.Warnf("failed polling assertoor test runs: %v", err)
+		}
+
+		as.mutex.Lock()
+		as.lastError = err
+		if err == nil {
+			as.lastUpdate = time.Now()
+		}
+		as.mutex.Unlock()
+
+		time.Sleep(utils.Config.Integrations.Assertoor.PollInterval)
+	}
+}
+
+// updateTestRuns loads the most recent test runs. the task details are reloaded for runs that are not finished yet or changed their status.
+func (as *AssertoorService) updateTestRuns() error {
+	apiRuns := []*assertoorApiTestRun{}
+	if err := as.getApi("/api/v1/test_runs", &apiRuns); err != nil {
+		return err
+	}
+
+	sort.Slice(apiRuns, func(a, b int) bool {
+		return apiRuns[a].RunId > apiRuns[b].RunId
+	})
+	if uint64(len(apiRuns)) > utils.Config.Integrations.Assertoor.MaxRuns {
+		apiRuns = apiRuns[:utils.Config.Integrations.Assertoor.MaxRuns]
+	}
+
+	as.mutex.RLock()
+	cachedRuns := make(map[uint64]*AssertoorTestRun, len(as.testRuns))
+	for _, testRun := range as.testRuns {
+		cachedRuns[testRun.RunId] = testRun
+	}
+	as.mutex.RUnlock()
+
+	testRuns := make([]*AssertoorTestRun, 0, len(apiRuns))
+	for _, apiRun := range apiRuns {
+		cachedRun := cachedRuns[apiRun.RunId]
+		if cachedRun != nil && cachedRun.Status == apiRun.Status && !isAssertoorRunActive(apiRun.Status) {
+			testRuns = append(testRuns, cachedRun)
+			continue
+		}
+
+		apiRunDetails := &assertoorApiTestRun{}
+		if err := as.getApi(fmt.Sprintf("/api/v1/test_run/%v", apiRun.RunId), apiRunDetails); err != nil {
+			as.logger.Debugf("failed loading assertoor test run %v: %v", apiRun.RunId, err)
+			apiRunDetails = apiRun
+		}
+
+		testRuns = append(testRuns, buildAssertoorTestRun(apiRunDetails))
+	}
+
+	as.mutex.Lock()
+	as.testRuns = testRuns
+	as.mutex.Unlock()
+
+	return nil
+}
+
+func (as *AssertoorService) getApi(path string, result interface{}) error {
+	resp, err := as.client.Get(as.apiUrl + path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("assertoor api returned %v: %v", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	apiResponse := &assertoorApiResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(apiResponse); err != nil {
+		return fmt.Errorf("failed decoding assertoor api response: %v", err)
+	}
+	if apiResponse.Status != "OK" {
+		return fmt.Errorf("assertoor api error: %v", apiResponse.Status)
+	}
+
+	return json.Unmarshal(apiResponse.Data, result)
+}
+
+func buildAssertoorTestRun(apiRun *assertoorApiTestRun) *AssertoorTestRun {
+	testRun := &AssertoorTestRun{
+		RunId:     apiRun.RunId,
+		TestId:    apiRun.TestId,
+		Name:      apiRun.Name,
+		Status:    apiRun.Status,
+		StartTime: parseAssertoorTime(apiRun.StartTime),
+		StopTime:  parseAssertoorTime(apiRun.StopTime),
+		Tasks:     make([]*AssertoorTask, len(apiRun.Tasks)),
+	}
+
+	for idx, apiTask := range apiRun.Tasks {
+		task := &AssertoorTask{
+			Index:       apiTask.Index,
+			ParentIndex: apiTask.ParentIndex,
+			Name:        apiTask.Name,
+			Title:       apiTask.Title,
+			Status:      apiTask.Status,
+			Result:      apiTask.Result,
+			ResultError: apiTask.ResultError,
+			StartTime:   parseAssertoorTime(apiTask.StartTime),
+			StopTime:    parseAssertoorTime(apiTask.StopTime),
+		}
+
+		taskText := task.Title + " " + task.ResultError
+		task.Slots = findAssertoorReferences(assertoorSlotPattern, taskText)
+		task.Validators = findAssertoorReferences(assertoorValidatorPattern, taskText)
+
+		testRun.Tasks[idx] = task
+	}
+
+	return testRun
+}
+
+// findAssertoorReferences returns the distinct numbers captured by the pattern in the text
+func findAssertoorReferences(pattern *regexp.Regexp, text string) []uint64 {
+	references := []uint64{}
+	seen := map[uint64]bool{}
+	for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+		number, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || seen[number] {
+			continue
+		}
+
+		seen[number] = true
+		references = append(references, number)
+	}
+	return references
+}
+
+func parseAssertoorTime(unixTime int64) time.Time {
+	if unixTime <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(unixTime, 0)
+}
+
+func isAssertoorRunActive(status string) bool {
+	return status == "pending" || status == "running"
+}
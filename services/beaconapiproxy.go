@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/lru"
+
+	"github.com/ethpandaops/dora/utils"
+)
+
+var GlobalBeaconApiProxy *BeaconApiProxy
+
+// ErrInvalidBlockId is returned for block ids that are not a valid slot number, block root or named block
+var ErrInvalidBlockId = errors.New("invalid block id")
+
+// BeaconApiProxy serves selected beacon api endpoints from the indexer cache & database, falling back to the upstream beacon nodes.
+// blocks & blob sidecars of finalized slots are immutable, so they are kept in a lru cache after being loaded from upstream.
+type BeaconApiProxy struct {
+	slotCache  *lru.Cache[phase0.Slot, phase0.Root]
+	blockCache *lru.Cache[phase0.Root, *CombinedBlockResponse]
+	blobCache  *lru.Cache[phase0.Root, []*deneb.BlobSidecar]
+}
+
+// BeaconApiProxyBlock is a block resolved by the beacon api proxy
+type BeaconApiProxyBlock struct {
+	*CombinedBlockResponse
+	Finalized bool
+}
+
+// StartBeaconApiProxy initializes the global beacon api proxy
+func StartBeaconApiProxy() {
+	cacheSize := utils.Config.BeaconApiProxy.CacheSize
+	GlobalBeaconApiProxy = &BeaconApiProxy{
+		slotCache:  lru.NewCache[phase0.Slot, phase0.Root](cacheSize),
+		blockCache: lru.NewCache[phase0.Root, *CombinedBlockResponse](cacheSize),
+		blobCache:  lru.NewCache[phase0.Root, []*deneb.BlobSidecar](cacheSize),
+	}
+}
+
+// GetBlock resolves a beacon api block id (head, genesis, finalized, slot number or 0x prefixed block root) to a block.
+// returns nil if the block could not be found.
+func (proxy *BeaconApiProxy) GetBlock(ctx context.Context, blockId string) (*BeaconApiProxyBlock, error) {
+	chainState := GlobalBeaconService.GetChainState()
+	finalizedSlot := chainState.GetFinalizedSlot()
+
+	var blockRoot phase0.Root
+	var slot phase0.Slot
+	var bySlot bool
+
+	switch {
+	case blockId == "head":
+		headBlock := GlobalBeaconService.GetBeaconIndexer().GetCanonicalHead(nil)
+		if headBlock == nil {
+			return nil, fmt.Errorf("no canonical head")
+		}
+		blockRoot = headBlock.Root
+	case blockId == "finalized":
+		_, blockRoot = chainState.GetFinalizedCheckpoint()
+	case blockId == "genesis":
+		bySlot = true
+	case strings.HasPrefix(blockId, "0x"):
+		rootBytes, err := hex.DecodeString(blockId[2:])
+		if err != nil || len(rootBytes) != 32 {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBlockId, blockId)
+		}
+		blockRoot = phase0.Root(rootBytes)
+	default:
+		slotNum, err := strconv.ParseUint(blockId, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBlockId, blockId)
+		}
+		slot = phase0.Slot(slotNum)
+		bySlot = true
+	}
+
+	if bySlot && slot < finalizedSlot {
+		if cachedRoot, found := proxy.slotCache.Get(slot); found {
+			blockRoot = cachedRoot
+			bySlot = false
+		}
+	}
+
+	if !bySlot {
+		if cachedBlock, found := proxy.blockCache.Get(blockRoot); found {
+			return &BeaconApiProxyBlock{
+				CombinedBlockResponse: cachedBlock,
+				Finalized:             true,
+			}, nil
+		}
+	}
+
+	var block *CombinedBlockResponse
+	var err error
+	if bySlot {
+		block, err = GlobalBeaconService.GetSlotDetailsBySlot(ctx, slot)
+	} else {
+		block, err = GlobalBeaconService.GetSlotDetailsByBlockroot(ctx, blockRoot)
+	}
+	if err != nil || block == nil || block.Header == nil {
+		return nil, err
+	}
+
+	isFinalized := block.Header.Message.Slot < finalizedSlot && !block.Orphaned
+	if isFinalized && block.Block != nil && GlobalBeaconService.GetBeaconIndexer().GetBlockByRoot(block.Root) == nil {
+		// loaded from db or upstream, keep it for further requests
+		proxy.blockCache.Add(block.Root, block)
+		proxy.slotCache.Add(block.Header.Message.Slot, block.Root)
+	}
+
+	return &BeaconApiProxyBlock{
+		CombinedBlockResponse: block,
+		Finalized:             isFinalized,
+	}, nil
+}
+
+// GetBlobSidecars returns the blob sidecars of a block, the sidecars of finalized blocks are cached.
+func (proxy *BeaconApiProxy) GetBlobSidecars(ctx context.Context, block *BeaconApiProxyBlock) ([]*deneb.BlobSidecar, error) {
+	if blobs, found := proxy.blobCache.Get(block.Root); found {
+		return blobs, nil
+	}
+
+	beaconIndexer := GlobalBeaconService.GetBeaconIndexer()
+	client := beaconIndexer.GetReadyClientByBlockRoot(block.Root, true)
+	if client == nil {
+		client = beaconIndexer.GetReadyClient(true)
+	}
+	if client == nil {
+		return nil, fmt.Errorf("no clients available")
+	}
+
+	blobs, err := client.GetClient().GetRPCClient().GetBlobSidecarsByBlockroot(ctx, block.Root[:])
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Finalized {
+		proxy.blobCache.Add(block.Root, blobs)
+	}
+
+	return blobs, nil
+}
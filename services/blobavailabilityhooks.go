@@ -0,0 +1,71 @@
+package services
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/indexer/blobsidecars"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// BlobAvailabilityWebhookPayload is the body POSTed to the configured blob availability webhooks
+type BlobAvailabilityWebhookPayload struct {
+	Event     string                                  `json:"event"` // "blob_divergence" or "blob_invalid"
+	Network   string                                  `json:"network"`
+	Slot      uint64                                  `json:"slot"`
+	BlockRoot string                                  `json:"block_root"`
+	BlobCount uint64                                  `json:"blob_count"`
+	Clients   []*BlobAvailabilityWebhookPayloadClient `json:"clients"`
+}
+
+// BlobAvailabilityWebhookPayloadClient holds the blobs a client had available for the block
+type BlobAvailabilityWebhookPayloadClient struct {
+	Name      string   `json:"name"`
+	Available uint64   `json:"available"`
+	Missing   []int    `json:"missing"` // missing blob indexes
+	Invalid   []int    `json:"invalid"` // blob indexes that failed kzg verification
+	Results   []string `json:"results,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// newBlobAvailabilityWebhookHook returns a divergence hook that sends blocks with missing blobs to the configured webhooks.
+func newBlobAvailabilityWebhookHook(logger logrus.FieldLogger, chainState *consensus.ChainState) blobsidecars.DivergenceHook {
+	sender := newWebhookSender(logger, "blob availability", utils.Config.Webhooks.BlobAvailability)
+
+	return func(availability *blobsidecars.BlockBlobAvailability) {
+		payload := &BlobAvailabilityWebhookPayload{
+			Event:     "blob_divergence",
+			Network:   getNetworkName(chainState),
+			Slot:      uint64(availability.Slot),
+			BlockRoot: availability.BlockRoot.String(),
+			BlobCount: availability.BlobCount,
+			Clients:   make([]*BlobAvailabilityWebhookPayloadClient, 0, len(availability.Clients)),
+		}
+		if availability.Invalid {
+			payload.Event = "blob_invalid"
+		}
+
+		for _, clientAvailability := range availability.Clients {
+			payloadClient := &BlobAvailabilityWebhookPayloadClient{
+				Name:      RedactClientName(clientAvailability.ClientName),
+				Available: clientAvailability.AvailableCount,
+				Missing:   []int{},
+				Invalid:   []int{},
+				Results:   clientAvailability.Results,
+				Error:     clientAvailability.FetchError,
+			}
+			for index, status := range clientAvailability.Status {
+				if status.IsInvalid() {
+					payloadClient.Invalid = append(payloadClient.Invalid, index)
+				}
+				if !clientAvailability.Available[index] {
+					payloadClient.Missing = append(payloadClient.Missing, index)
+				}
+			}
+
+			payload.Clients = append(payload.Clients, payloadClient)
+		}
+
+		sender.send(payload)
+	}
+}
@@ -22,6 +22,7 @@ type CallRateLimiter struct {
 
 type callRateVisitor struct {
 	limiter  *rate.Limiter
+	burst    uint
 	lastSeen time.Time
 }
 
@@ -53,40 +54,46 @@ func (crl *CallRateLimiter) CheckCallLimit(r *http.Request, callCost uint) error
 	if visitor == nil {
 		return fmt.Errorf("could not get visitor")
 	}
+	if callCost > visitor.burst {
+		// expensive calls would never fit into a small bucket
+		callCost = visitor.burst
+	}
 	if !visitor.limiter.AllowN(time.Now(), int(callCost)) {
 		return fmt.Errorf("call rate limit exceeded")
 	}
 	return nil
 }
 
+// getVisitor returns the token bucket of the caller.
+// callers with an api key of a quota tier with own rate limit share the bucket of the key, all other callers are limited by ip.
 func (crl *CallRateLimiter) getVisitor(r *http.Request) *callRateVisitor {
-	var ip string
-
-	if crl.proxyCount > 0 {
-		forwardIps := strings.Split(r.Header.Get("X-Forwarded-For"), ", ")
-		forwardIdx := len(forwardIps) - int(crl.proxyCount)
-		if forwardIdx >= 0 {
-			ip = forwardIps[forwardIdx]
-		}
-	}
-	if ip == "" {
-		var err error
-		ip, _, err = net.SplitHostPort(r.RemoteAddr)
-		if err != nil {
+	var visitorId string
+	limit := rate.Limit(crl.rateLimit)
+	burst := crl.burstLimit
+
+	if apiKey, keyRate, keyBurst, ok := GlobalApiQuotaManager.getKeyRateLimit(r); ok {
+		visitorId = "key:" + apiKey
+		limit = rate.Limit(keyRate)
+		burst = keyBurst
+	} else {
+		ip := getCallerIP(r, crl.proxyCount)
+		if ip == "" {
 			return nil
 		}
+		visitorId = "ip:" + ip
 	}
 
 	crl.mutex.Lock()
 	defer crl.mutex.Unlock()
 
-	visitor := crl.visitors[ip]
+	visitor := crl.visitors[visitorId]
 	if visitor == nil {
 		visitor = &callRateVisitor{
-			limiter:  rate.NewLimiter(rate.Limit(crl.rateLimit), int(crl.burstLimit)),
+			limiter:  rate.NewLimiter(limit, int(burst)),
+			burst:    burst,
 			lastSeen: time.Now(),
 		}
-		crl.visitors[ip] = visitor
+		crl.visitors[visitorId] = visitor
 	} else {
 		visitor.lastSeen = time.Now()
 	}
@@ -98,11 +105,33 @@ func (crl *CallRateLimiter) cleanupVisitors() {
 		time.Sleep(time.Minute)
 
 		crl.mutex.Lock()
-		for ip, v := range crl.visitors {
+		for visitorId, v := range crl.visitors {
 			if time.Since(v.lastSeen) > 3*time.Minute {
-				delete(crl.visitors, ip)
+				delete(crl.visitors, visitorId)
 			}
 		}
 		crl.mutex.Unlock()
 	}
 }
+
+// getCallerIP returns the ip of the caller, resolved from the X-Forwarded-For header if dora runs behind proxyCount proxies
+func getCallerIP(r *http.Request, proxyCount uint) string {
+	var ip string
+
+	if proxyCount > 0 {
+		forwardIps := strings.Split(r.Header.Get("X-Forwarded-For"), ", ")
+		forwardIdx := len(forwardIps) - int(proxyCount)
+		if forwardIdx >= 0 {
+			ip = forwardIps[forwardIdx]
+		}
+	}
+	if ip == "" {
+		var err error
+		ip, _, err = net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			return ""
+		}
+	}
+
+	return ip
+}
@@ -4,21 +4,37 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/consensus/rpc"
 	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/clients/selection"
 	"github.com/ethpandaops/dora/clients/sshtunnel"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/indexer/blobsidecars"
+	"github.com/ethpandaops/dora/indexer/blockprint"
+	"github.com/ethpandaops/dora/indexer/clientheads"
+	"github.com/ethpandaops/dora/indexer/datacolumns"
+	"github.com/ethpandaops/dora/indexer/enricher"
 	execindexer "github.com/ethpandaops/dora/indexer/execution"
+	"github.com/ethpandaops/dora/indexer/fingerprint"
 	"github.com/ethpandaops/dora/indexer/mevrelay"
+	"github.com/ethpandaops/dora/indexer/rewards"
+	"github.com/ethpandaops/dora/indexer/rollups"
+	"github.com/ethpandaops/dora/types"
 	"github.com/ethpandaops/dora/utils"
 	"github.com/sirupsen/logrus"
 )
@@ -32,7 +48,23 @@ type ChainService struct {
 	depositIndexer       *execindexer.DepositIndexer
 	consolidationIndexer *execindexer.ConsolidationIndexer
 	withdrawalIndexer    *execindexer.WithdrawalIndexer
+	addressWatcher       *execindexer.AddressWatcher
 	mevRelayIndexer      *mevrelay.MevIndexer
+	blockprintRunner     *enricher.Runner
+	fingerprintRunner    *enricher.Runner
+	rollupsRunner        *enricher.Runner
+	dataColumnIndexer    *datacolumns.DataColumnIndexer
+	blobSidecarMonitor   *blobsidecars.BlobSidecarMonitor
+	headDivergence       *clientheads.HeadDivergenceMonitor
+	rewardsRunner        *enricher.Runner
+	executionIndexerCtx  *execindexer.IndexerCtx
+	poolClients          *poolClientRegistry
+	leaderElection       *LeaderElection
+	validatorMetaCache   validatorMetadataCache
+	networkStatsCache    networkStatsCache
+	proposerSlotsCache   *lru.Cache[proposerSlotsCacheKey, []*dbtypes.AssignedSlot]
+	writerMutex          sync.Mutex
+	writerStarted        bool
 	started              bool
 }
 
@@ -52,6 +84,41 @@ func InitChainService(ctx context.Context, logger logrus.FieldLogger) {
 	validatorNames := NewValidatorNames(beaconIndexer, chainState)
 	mevRelayIndexer := mevrelay.NewMevIndexer(logger.WithField("service", "mev-relay"), beaconIndexer, chainState)
 
+	// group validators by name for missed duty incidents & attestation miss attribution
+	beaconIndexer.SetEntityResolver(func(validatorIndex phase0.ValidatorIndex) string {
+		return validatorNames.GetValidatorName(uint64(validatorIndex))
+	})
+	utils.EthAddressLabelResolver = func(address []byte) string {
+		return RedactValidatorName(validatorNames.GetAddressLabel(address))
+	}
+	if len(utils.Config.Webhooks.Incidents) > 0 {
+		beaconIndexer.AddIncidentHook(newIncidentWebhookHook(logger.WithField("service", "incident-hooks"), chainState))
+	}
+
+	// export indexer events to nats / kafka
+	if utils.Config.EventExport.Enabled {
+		err := registerEventExporter(logger.WithField("service", "event-export"), beaconIndexer, chainState)
+		if err != nil {
+			logger.Errorf("failed initializing event export: %v", err)
+		}
+	}
+
+	// serve indexer events via server-sent-events (/api/v1/events)
+	if utils.Config.Api.EventStream.Enabled && utils.Config.Indexer.Mode != types.IndexerModeFrontend {
+		registerEventStream(logger.WithField("service", "event-stream"), beaconIndexer, chainState)
+	}
+
+	// alert missed duties of the validators in the configured watchlists
+	registerWatchlistHooks(logger.WithField("service", "watchlist-hooks"), beaconIndexer, chainState, validatorNames)
+
+	// watch configured validators for unexpected credential changes
+	if utils.Config.Anomalies.Enabled {
+		beaconIndexer.SetAnomalyWatchlist(newAnomalyWatchlist(logger, validatorNames))
+		if len(utils.Config.Webhooks.Anomalies) > 0 {
+			beaconIndexer.AddAnomalyHook(newAnomalyWebhookHook(logger.WithField("service", "anomaly-hooks"), chainState, validatorNames))
+		}
+	}
+
 	GlobalBeaconService = &ChainService{
 		logger:          logger,
 		consensusPool:   consensusPool,
@@ -59,6 +126,9 @@ func InitChainService(ctx context.Context, logger logrus.FieldLogger) {
 		beaconIndexer:   beaconIndexer,
 		validatorNames:  validatorNames,
 		mevRelayIndexer: mevRelayIndexer,
+		poolClients:     newPoolClientRegistry(logger.WithField("service", "pool-clients"), consensusPool, executionPool),
+
+		proposerSlotsCache: lru.NewCache[proposerSlotsCacheKey, []*dbtypes.AssignedSlot](proposerSlotsCacheSize),
 	}
 }
 
@@ -69,34 +139,17 @@ func (cs *ChainService) StartService() error {
 	}
 	cs.started = true
 
-	executionIndexerCtx := execindexer.NewIndexerCtx(cs.logger.WithField("service", "el-indexer"), cs.executionPool, cs.consensusPool, cs.beaconIndexer)
+	cs.executionIndexerCtx = execindexer.NewIndexerCtx(cs.logger.WithField("service", "el-indexer"), cs.executionPool, cs.consensusPool, cs.beaconIndexer)
 
 	// add consensus clients
 	for index, endpoint := range utils.Config.BeaconApi.Endpoints {
-		endpointConfig := &consensus.ClientConfig{
-			URL:        endpoint.Url,
-			Name:       endpoint.Name,
-			Headers:    endpoint.Headers,
-			DisableSSZ: utils.Config.KillSwitch.DisableSSZRequests,
-		}
-
-		if endpoint.Ssh != nil {
-			endpointConfig.SshConfig = &sshtunnel.SshConfig{
-				Host:     endpoint.Ssh.Host,
-				Port:     endpoint.Ssh.Port,
-				User:     endpoint.Ssh.User,
-				Password: endpoint.Ssh.Password,
-				Keyfile:  endpoint.Ssh.Keyfile,
-			}
-		}
-
-		client, err := cs.consensusPool.AddEndpoint(endpointConfig)
+		client, err := cs.consensusPool.AddEndpoint(getConsensusClientConfig(&endpoint))
 		if err != nil {
 			cs.logger.Errorf("could not add beacon client '%v' to pool: %v", endpoint.Name, err)
 			continue
 		}
 
-		cs.beaconIndexer.AddClient(uint16(index), client, endpoint.Priority, endpoint.Archive, endpoint.SkipValidators)
+		cs.beaconIndexer.AddClient(uint16(index), client, endpoint.SkipValidators)
 	}
 
 	if len(cs.consensusPool.GetAllEndpoints()) == 0 {
@@ -106,9 +159,10 @@ func (cs *ChainService) StartService() error {
 	// add execution clients
 	for _, endpoint := range utils.Config.ExecutionApi.Endpoints {
 		endpointConfig := &execution.ClientConfig{
-			URL:     endpoint.Url,
-			Name:    endpoint.Name,
-			Headers: endpoint.Headers,
+			URL:       endpoint.Url,
+			Name:      endpoint.Name,
+			Headers:   endpoint.Headers,
+			Selection: getClientSelection(&endpoint),
 		}
 
 		if endpoint.Ssh != nil {
@@ -121,17 +175,28 @@ func (cs *ChainService) StartService() error {
 			}
 		}
 
-		client, err := cs.executionPool.AddEndpoint(endpointConfig)
+		_, err := cs.executionPool.AddEndpoint(endpointConfig)
 		if err != nil {
 			cs.logger.Errorf("could not add execution client '%v' to pool: %v", endpoint.Name, err)
 			continue
 		}
+	}
+
+	frontendOnly := utils.Config.Indexer.Mode == types.IndexerModeFrontend
 
-		executionIndexerCtx.AddClientInfo(client, endpoint.Priority, endpoint.Archive)
+	// elect a single writer instance if configured, all other instances run as hot-standby
+	isWriter := !frontendOnly
+	if isWriter && utils.Config.Indexer.LeaderElection {
+		cs.leaderElection = NewLeaderElection(cs.logger.WithField("service", "leader-election"), utils.Config.Indexer.LeaderLeaseTimeout)
+		isWriter = cs.leaderElection.Start(func() {
+			cs.beaconIndexer.SetReadOnly(false)
+			cs.startWriterServices()
+		})
+		cs.beaconIndexer.SetReadOnly(!isWriter)
 	}
 
 	// reset sync state if configured
-	if utils.Config.Indexer.ResyncFromEpoch != nil {
+	if utils.Config.Indexer.ResyncFromEpoch != nil && isWriter {
 		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
 			syncState := &dbtypes.IndexerSyncState{
 				Epoch: *utils.Config.Indexer.ResyncFromEpoch,
@@ -169,27 +234,229 @@ func (cs *ChainService) StartService() error {
 		"genesis_fork": fmt.Sprintf("%x", genesis.GenesisForkVersion),
 	}).Infof("beacon client pool ready")
 
+	// detect network resets before restoring any state from the db
+	if err := cs.checkNetworkState(isWriter); err != nil {
+		return err
+	}
+
+	// assign stable ids to the pool clients before any client keyed data gets persisted
+	if isWriter {
+		if err := cs.poolClients.updateClients(); err != nil {
+			cs.logger.Warnf("failed assigning pool client ids: %v", err)
+		}
+	}
+
 	// start validator names updater
 	validatorNamesLoading := cs.validatorNames.LoadValidatorNames()
 	<-validatorNamesLoading
 
-	go func() {
-		cs.validatorNames.UpdateDb()
-		cs.validatorNames.StartUpdater()
-	}()
+	go cs.validatorNames.StartUpdater()
 
 	// start chain indexer
 	cs.beaconIndexer.StartIndexer()
 
+	if frontendOnly {
+		// execution & mev indexers only write to the db, these are run by the writer instance
+		cs.logger.Infof("running in frontend-only mode, execution & mev relay indexers are disabled")
+		return nil
+	}
+
+	if isWriter {
+		cs.startWriterServices()
+	} else {
+		cs.logger.Infof("running as hot-standby, execution & mev relay indexers are started on promotion")
+	}
+
+	return nil
+}
+
+// startWriterServices starts the services that write to the db (once).
+// called on startup for writer instances or when a hot-standby instance gets promoted to leader.
+func (cs *ChainService) startWriterServices() {
+	cs.writerMutex.Lock()
+	defer cs.writerMutex.Unlock()
+
+	if cs.writerStarted {
+		return
+	}
+	cs.writerStarted = true
+
+	go cs.validatorNames.UpdateDb()
+
+	// track pool client metadata & version history
+	cs.poolClients.StartUpdater()
+
 	// add execution indexers
-	cs.depositIndexer = execindexer.NewDepositIndexer(executionIndexerCtx)
-	cs.consolidationIndexer = execindexer.NewConsolidationIndexer(executionIndexerCtx)
-	cs.withdrawalIndexer = execindexer.NewWithdrawalIndexer(executionIndexerCtx)
+	cs.depositIndexer = execindexer.NewDepositIndexer(cs.executionIndexerCtx)
+	cs.consolidationIndexer = execindexer.NewConsolidationIndexer(cs.executionIndexerCtx)
+	cs.withdrawalIndexer = execindexer.NewWithdrawalIndexer(cs.executionIndexerCtx)
+
+	// watch configured addresses for deposit & system request contract interactions
+	if utils.Config.AddressWatch.Enabled {
+		addresses := GetWatchedAddresses(cs.logger)
+		if len(addresses) > 0 {
+			cs.addressWatcher = execindexer.NewAddressWatcher(cs.executionIndexerCtx, addresses)
+			if len(utils.Config.Webhooks.AddressWatch) > 0 {
+				cs.addressWatcher.AddHook(newAddressWatchWebhookHook(cs.logger.WithField("service", "addresswatch-hooks"), cs.consensusPool.GetChainState()))
+			}
+		}
+	}
 
 	// start MEV relay indexer
 	cs.mevRelayIndexer.StartUpdater()
 
-	return nil
+	// start blockprint enricher
+	if utils.Config.Blockprint.Url != "" {
+		chainState := cs.consensusPool.GetChainState()
+		cs.blockprintRunner = enricher.NewRunner(cs.logger.WithField("service", "blockprint"), cs.beaconIndexer, chainState, blockprint.NewBlockprint(cs.logger.WithField("service", "blockprint"), utils.Config.Blockprint.Url), &enricher.RunnerConfig{
+			RefreshInterval: utils.Config.Blockprint.RefreshInterval,
+			RateLimit:       utils.Config.Blockprint.RateLimit,
+			BatchSize:       utils.Config.Blockprint.BatchSize,
+			StartSlot:       chainState.EpochToSlot(phase0.Epoch(utils.Config.Blockprint.StartEpoch)),
+		})
+		cs.blockprintRunner.Start()
+	}
+
+	// start slot fingerprint enricher
+	if utils.Config.Fingerprint.Enabled {
+		chainState := cs.consensusPool.GetChainState()
+		cs.fingerprintRunner = enricher.NewRunner(cs.logger.WithField("service", "fingerprint"), cs.beaconIndexer, chainState, fingerprint.NewFingerprint(cs.logger.WithField("service", "fingerprint")), &enricher.RunnerConfig{
+			RefreshInterval: utils.Config.Fingerprint.RefreshInterval,
+			RateLimit:       10,
+			BatchSize:       utils.Config.Fingerprint.BatchSize,
+			StartSlot:       chainState.EpochToSlot(phase0.Epoch(utils.Config.Fingerprint.StartEpoch)),
+		})
+		cs.fingerprintRunner.Start()
+	}
+
+	// start rollup blob transaction enricher
+	if utils.Config.Rollups.Enabled {
+		chainState := cs.consensusPool.GetChainState()
+		cs.rollupsRunner = enricher.NewRunner(cs.logger.WithField("service", "rollups"), cs.beaconIndexer, chainState, rollups.NewRollups(cs.logger.WithField("service", "rollups"), cs.beaconIndexer, chainState), &enricher.RunnerConfig{
+			RefreshInterval: utils.Config.Rollups.RefreshInterval,
+			RateLimit:       utils.Config.Rollups.RateLimit,
+			BatchSize:       utils.Config.Rollups.BatchSize,
+			StartSlot:       chainState.EpochToSlot(phase0.Epoch(utils.Config.Rollups.StartEpoch)),
+		})
+		cs.rollupsRunner.Start()
+	}
+
+	// start data column availability indexer
+	if utils.Config.DataColumns.Enabled {
+		chainState := cs.consensusPool.GetChainState()
+		cs.dataColumnIndexer = datacolumns.NewDataColumnIndexer(cs.logger.WithField("service", "datacolumns"), cs.beaconIndexer, chainState)
+		cs.dataColumnIndexer.StartUpdater()
+	}
+
+	// start blob sidecar availability monitor
+	if utils.Config.BlobAvailability.Enabled {
+		chainState := cs.consensusPool.GetChainState()
+		cs.blobSidecarMonitor = blobsidecars.NewBlobSidecarMonitor(cs.logger.WithField("service", "blob-availability"), cs.beaconIndexer, chainState)
+		if utils.Config.BlobAvailability.VerifyKzg {
+			verifier, err := blobsidecars.NewKzgVerifier(utils.Config.BlobAvailability.TrustedSetup)
+			if err != nil {
+				cs.logger.Errorf("failed initializing kzg verifier, blob sidecars are not verified: %v", err)
+			} else {
+				cs.blobSidecarMonitor.SetKzgVerifier(verifier)
+			}
+		}
+		if len(utils.Config.Webhooks.BlobAvailability) > 0 {
+			cs.blobSidecarMonitor.AddHook(newBlobAvailabilityWebhookHook(cs.logger.WithField("service", "blob-availability-hooks"), chainState))
+		}
+		cs.blobSidecarMonitor.StartUpdater()
+	}
+
+	// start client head divergence monitor
+	if utils.Config.HeadDivergence.Enabled {
+		chainState := cs.consensusPool.GetChainState()
+		cs.headDivergence = clientheads.NewHeadDivergenceMonitor(cs.logger.WithField("service", "head-divergence"), cs.beaconIndexer, chainState)
+		if len(utils.Config.Webhooks.HeadDivergence) > 0 {
+			cs.headDivergence.AddHook(newHeadDivergenceWebhookHook(cs.logger.WithField("service", "head-divergence-hooks"), chainState))
+		}
+		cs.headDivergence.StartUpdater()
+	}
+
+	// start rewards indexer
+	if utils.Config.Rewards.Enabled {
+		chainState := cs.consensusPool.GetChainState()
+		startEpoch := phase0.Epoch(utils.Config.Rewards.StartEpoch)
+		if startEpoch == 0 {
+			startEpoch, _ = chainState.GetFinalizedCheckpoint()
+		}
+
+		var receiptFetcher *execindexer.ReceiptFetcher
+		if len(cs.executionPool.GetAllEndpoints()) > 0 {
+			receiptFetcher = execindexer.NewReceiptFetcher(cs.executionIndexerCtx)
+		}
+
+		cs.rewardsRunner = enricher.NewRunner(cs.logger.WithField("service", "rewards"), cs.beaconIndexer, chainState, rewards.NewRewards(cs.logger.WithField("service", "rewards"), cs.beaconIndexer, chainState, receiptFetcher, utils.Config.Rewards.HistoryDays), &enricher.RunnerConfig{
+			RefreshInterval: utils.Config.Rewards.RefreshInterval,
+			RateLimit:       utils.Config.Rewards.RateLimit,
+			BatchSize:       chainState.GetSpecs().SlotsPerEpoch,
+			StartSlot:       chainState.EpochToSlot(startEpoch),
+		})
+		cs.rewardsRunner.Start()
+	}
+}
+
+// getClientSelection returns the client selection settings for a configured endpoint
+func getClientSelection(endpoint *types.EndpointConfig) selection.Settings {
+	return selection.Settings{
+		Priority: endpoint.Priority,
+		Weight:   endpoint.Weight,
+		Archive:  endpoint.Archive,
+		Full:     slices.Contains(endpoint.Tags, "full"),
+	}
+}
+
+// getConsensusClientConfig builds the consensus pool client config for a configured beacon endpoint
+func getConsensusClientConfig(endpoint *types.EndpointConfig) *consensus.ClientConfig {
+	endpointConfig := &consensus.ClientConfig{
+		URL:               endpoint.Url,
+		Name:              endpoint.Name,
+		Headers:           endpoint.Headers,
+		DisableSSZ:        utils.Config.KillSwitch.DisableSSZRequests,
+		ExperimentalForks: getExperimentalForks(),
+		Selection:         getClientSelection(endpoint),
+	}
+
+	for _, topic := range rpc.StreamEventTopics {
+		if slices.Contains(endpoint.EventTopics, topic.Topic) {
+			endpointConfig.EventTopics |= topic.Event
+		}
+	}
+
+	if endpoint.Ssh != nil {
+		endpointConfig.SshConfig = &sshtunnel.SshConfig{
+			Host:     endpoint.Ssh.Host,
+			Port:     endpoint.Ssh.Port,
+			User:     endpoint.Ssh.User,
+			Password: endpoint.Ssh.Password,
+			Keyfile:  endpoint.Ssh.Keyfile,
+		}
+	}
+
+	return endpointConfig
+}
+
+// getExperimentalForks returns the configured mapping of experimental fork versions to the base fork their blocks are decoded as
+func getExperimentalForks() map[string]spec.DataVersion {
+	if len(utils.Config.Chain.ExperimentalForks) == 0 {
+		return nil
+	}
+
+	experimentalForks := map[string]spec.DataVersion{}
+	for _, fork := range utils.Config.Chain.ExperimentalForks {
+		var baseFork spec.DataVersion
+		if err := baseFork.UnmarshalJSON([]byte(fmt.Sprintf("%q", fork.BaseFork))); err != nil {
+			logrus.Warnf("ignoring experimental fork %v: unknown base fork %v", fork.Version, fork.BaseFork)
+			continue
+		}
+
+		experimentalForks[strings.ToLower(fork.Version)] = baseFork
+	}
+
+	return experimentalForks
 }
 
 func (bs *ChainService) StopService() {
@@ -201,6 +468,10 @@ func (bs *ChainService) StopService() {
 		bs.beaconIndexer.StopIndexer()
 		bs.beaconIndexer = nil
 	}
+
+	if bs.leaderElection != nil {
+		bs.leaderElection.Stop()
+	}
 }
 
 func (bs *ChainService) GetBeaconIndexer() *beacon.Indexer {
@@ -215,6 +486,10 @@ func (bs *ChainService) GetWithdrawalIndexer() *execindexer.WithdrawalIndexer {
 	return bs.withdrawalIndexer
 }
 
+func (bs *ChainService) GetBlobSidecarMonitor() *blobsidecars.BlobSidecarMonitor {
+	return bs.blobSidecarMonitor
+}
+
 func (bs *ChainService) GetConsensusClients() []*consensus.Client {
 	if bs == nil || bs.consensusPool == nil {
 		return nil
@@ -266,10 +541,29 @@ func (bs *ChainService) isCanonicalForkId(forkId uint64, canonicalForkIds []uint
 	return false
 }
 
+// GetValidatorName returns the name of the validator, or an empty string if validator names are redacted.
 func (bs *ChainService) GetValidatorName(index uint64) string {
+	return RedactValidatorName(bs.validatorNames.GetValidatorName(index))
+}
+
+// GetUnredactedValidatorName returns the name of the validator regardless of the redaction settings (for admin access only).
+func (bs *ChainService) GetUnredactedValidatorName(index uint64) string {
 	return bs.validatorNames.GetValidatorName(index)
 }
 
+// GetAddressLabel returns the label of an execution address, or an empty string if validator names are redacted.
+func (bs *ChainService) GetAddressLabel(address []byte) string {
+	return RedactValidatorName(bs.validatorNames.GetAddressLabel(address))
+}
+
+// SearchAddressLabels returns the execution addresses with a matching label, or nil if validator names are redacted.
+func (bs *ChainService) SearchAddressLabels(search string, limit int) []*AddressLabel {
+	if !IsValidatorNameSearchAllowed() {
+		return nil
+	}
+	return bs.validatorNames.SearchAddressLabels(search, limit)
+}
+
 func (bs *ChainService) GetValidatorNamesCount() uint64 {
 	return bs.validatorNames.GetValidatorNamesCount()
 }
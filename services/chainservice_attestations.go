@@ -0,0 +1,176 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// AttestationDutyInclusions is the attestation duty of a validator in an epoch along with all aggregates that include its vote
+type AttestationDutyInclusions struct {
+	ValidatorIndex    uint64
+	Epoch             uint64
+	Slot              uint64
+	CommitteeIndex    uint64
+	CommitteePosition uint64 // position of the validator in the committee (aggregation bit index)
+	CommitteeSize     uint64
+	Inclusions        []*AttestationInclusion
+}
+
+// AttestationInclusion is an aggregate in a block that includes the vote of a validator
+type AttestationInclusion struct {
+	Slot             uint64 // inclusion slot
+	BlockRoot        []byte
+	Orphaned         bool
+	AttestationIndex uint64 // index of the aggregate in the block body
+	InclusionDelay   uint64
+	AggregateSize    uint64 // number of votes in the aggregate
+	BeaconBlockRoot  []byte
+	SourceEpoch      uint64
+	SourceRoot       []byte
+	TargetEpoch      uint64
+	TargetRoot       []byte
+}
+
+// GetAttestationDutyInclusions reconstructs where the vote of a validator for its attestation duty in an epoch has been included.
+// the aggregates are loaded from the stored attestations of finalized & orphaned blocks and from the unfinalized blocks in the block cache.
+// returns nil if the validator had no attestation duty in the epoch.
+func (bs *ChainService) GetAttestationDutyInclusions(validatorIndex phase0.ValidatorIndex, epoch phase0.Epoch) (*AttestationDutyInclusions, error) {
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	if specs == nil {
+		return nil, fmt.Errorf("chain specs not loaded")
+	}
+
+	epochStats := bs.beaconIndexer.GetEpochStats(epoch, nil)
+	if epochStats == nil {
+		return nil, fmt.Errorf("attester duties for epoch %v not available", epoch)
+	}
+	epochStatsValues := epochStats.GetOrLoadValues(bs.beaconIndexer, true, false)
+	if epochStatsValues == nil || epochStatsValues.AttesterDuties == nil {
+		return nil, fmt.Errorf("attester duties for epoch %v not available", epoch)
+	}
+
+	// find the duty of the validator
+	var dutyInclusions *AttestationDutyInclusions
+	var slotCommitteeSizes []uint64
+dutiesLoop:
+	for slotIndex, slotCommittees := range epochStatsValues.AttesterDuties {
+		for committee, committeeDuties := range slotCommittees {
+			for position, validatorIndice := range committeeDuties {
+				if epochStatsValues.ActiveIndices[validatorIndice] != validatorIndex {
+					continue
+				}
+
+				dutyInclusions = &AttestationDutyInclusions{
+					ValidatorIndex:    uint64(validatorIndex),
+					Epoch:             uint64(epoch),
+					Slot:              uint64(chainState.EpochToSlot(epoch)) + uint64(slotIndex),
+					CommitteeIndex:    uint64(committee),
+					CommitteePosition: uint64(position),
+					CommitteeSize:     uint64(len(committeeDuties)),
+					Inclusions:        []*AttestationInclusion{},
+				}
+
+				slotCommitteeSizes = make([]uint64, len(slotCommittees))
+				for idx, duties := range slotCommittees {
+					slotCommitteeSizes[idx] = uint64(len(duties))
+				}
+				break dutiesLoop
+			}
+		}
+	}
+	if dutyInclusions == nil {
+		return nil, nil
+	}
+
+	// attestations can be included until the end of the next epoch (EIP-7045)
+	maxInclusionSlot := uint64(chainState.EpochToSlot(epoch+2)) - 1
+
+	attestations, err := db.GetSlotAttestationsByAttSlot(dutyInclusions.Slot, maxInclusionSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	knownBlocks := map[phase0.Root]bool{}
+	for _, attestation := range attestations {
+		knownBlocks[phase0.Root(attestation.SlotRoot)] = true
+	}
+
+	canonicalHead := bs.beaconIndexer.GetCanonicalHead(nil)
+	for slot := dutyInclusions.Slot + 1; slot <= maxInclusionSlot; slot++ {
+		for _, block := range bs.beaconIndexer.GetBlocksBySlot(phase0.Slot(slot)) {
+			if knownBlocks[block.Root] {
+				continue
+			}
+
+			isCanonical := false
+			if canonicalHead != nil {
+				isCanonical, _ = bs.beaconIndexer.GetBlockDistance(block.Root, canonicalHead.Root)
+			}
+
+			for _, attestation := range block.GetDbAttestations(bs.beaconIndexer, isCanonical) {
+				if attestation.AttSlot == dutyInclusions.Slot {
+					attestations = append(attestations, attestation)
+				}
+			}
+		}
+	}
+
+	// check the aggregation bits of all attestations for the vote of the validator
+	for _, attestation := range attestations {
+		if attestation.AttSlot != dutyInclusions.Slot {
+			continue
+		}
+
+		aggregationBits := bitfield.Bitlist(attestation.AggregationBits)
+		bitsOffset := uint64(0)
+		hasCommittee := false
+		if len(attestation.CommitteeBits) > 0 {
+			// EIP-7549 attestation, the aggregation bits of all included committees are concatenated
+			for _, committee := range bitfield.Bitvector64(attestation.CommitteeBits).BitIndices() {
+				if uint64(committee) == dutyInclusions.CommitteeIndex {
+					hasCommittee = true
+					break
+				}
+				if committee < len(slotCommitteeSizes) {
+					bitsOffset += slotCommitteeSizes[committee]
+				}
+			}
+		} else {
+			hasCommittee = attestation.CommitteeIndex == dutyInclusions.CommitteeIndex
+		}
+
+		if !hasCommittee || !aggregationBits.BitAt(bitsOffset+dutyInclusions.CommitteePosition) {
+			continue
+		}
+
+		dutyInclusions.Inclusions = append(dutyInclusions.Inclusions, &AttestationInclusion{
+			Slot:             attestation.SlotNumber,
+			BlockRoot:        attestation.SlotRoot,
+			Orphaned:         attestation.Orphaned,
+			AttestationIndex: attestation.SlotIndex,
+			InclusionDelay:   attestation.SlotNumber - attestation.AttSlot,
+			AggregateSize:    aggregationBits.Count(),
+			BeaconBlockRoot:  attestation.BeaconBlockRoot,
+			SourceEpoch:      attestation.SourceEpoch,
+			SourceRoot:       attestation.SourceRoot,
+			TargetEpoch:      attestation.TargetEpoch,
+			TargetRoot:       attestation.TargetRoot,
+		})
+	}
+
+	sort.Slice(dutyInclusions.Inclusions, func(a, b int) bool {
+		inclusionA := dutyInclusions.Inclusions[a]
+		inclusionB := dutyInclusions.Inclusions[b]
+		if inclusionA.Slot != inclusionB.Slot {
+			return inclusionA.Slot < inclusionB.Slot
+		}
+		return inclusionA.AttestationIndex < inclusionB.AttestationIndex
+	})
+
+	return dutyInclusions, nil
+}
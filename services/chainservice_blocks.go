@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec"
@@ -14,6 +15,7 @@ import (
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -25,13 +27,43 @@ type CombinedBlockResponse struct {
 }
 
 // GetBlockBlob retrieves the blob sidecar for a given block root and commitment.
-// It first tries to find a client that has the block root in its cache, and if not found,
-// it falls back to a random ready client. It then retrieves the blob sidecars for the block root
+// It loads the blob sidecars of the block from the blob store or a ready client (see getBlobSidecars)
 // and checks if any of them match the given commitment. If a match is found, it returns the blob sidecar,
 // otherwise it returns nil.
 func (bs *ChainService) GetBlockBlob(ctx context.Context, blockroot phase0.Root, commitment deneb.KZGCommitment) (*deneb.BlobSidecar, error) {
+	blobs, err := bs.getBlobSidecars(ctx, blockroot, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blob := range blobs {
+		if bytes.Equal(blob.KZGCommitment[:], commitment[:]) {
+			return blob, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getBlobSidecars retrieves the blob sidecars for a given block root.
+// The sidecars are loaded from the blob store first. If not stored yet, it tries to find a client that has the
+// block root in its cache (or falls back to a random ready client if allowed) and writes the received sidecars
+// to the blob store, so they remain available after the clients pruned them.
+func (bs *ChainService) getBlobSidecars(ctx context.Context, blockroot phase0.Root, anyClient bool) ([]*deneb.BlobSidecar, error) {
+	storedSSZ, err := db.GetBlobSidecars(blockroot[:])
+	if err != nil {
+		bs.logger.Warnf("error loading blob sidecars for block %v from blob store: %v", blockroot.String(), err)
+	} else if storedSSZ != nil {
+		blobs, err := unmarshalBlobSidecarsSSZ(storedSSZ)
+		if err == nil {
+			return blobs, nil
+		}
+
+		bs.logger.Warnf("error decoding stored blob sidecars for block %v: %v", blockroot.String(), err)
+	}
+
 	client := bs.beaconIndexer.GetReadyClientByBlockRoot(blockroot, true)
-	if client == nil {
+	if client == nil && anyClient {
 		client = bs.beaconIndexer.GetReadyClient(true)
 	}
 
@@ -44,13 +76,51 @@ func (bs *ChainService) GetBlockBlob(ctx context.Context, blockroot phase0.Root,
 		return nil, err
 	}
 
+	if len(blobs) > 0 {
+		blobsSSZ, err := marshalBlobSidecarsSSZ(blobs)
+		if err == nil {
+			err = db.PutBlobSidecars(blockroot[:], blobsSSZ)
+		}
+		if err != nil {
+			bs.logger.Warnf("error storing blob sidecars for block %v: %v", blockroot.String(), err)
+		}
+	}
+
+	return blobs, nil
+}
+
+// marshalBlobSidecarsSSZ encodes a list of blob sidecars by concatenating their fixed size ssz encodings.
+func marshalBlobSidecarsSSZ(blobs []*deneb.BlobSidecar) ([]byte, error) {
+	data := []byte{}
 	for _, blob := range blobs {
-		if bytes.Equal(blob.KZGCommitment[:], commitment[:]) {
-			return blob, nil
+		var err error
+		data, err = blob.MarshalSSZTo(data)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	return nil, nil
+	return data, nil
+}
+
+// unmarshalBlobSidecarsSSZ decodes a list of blob sidecars encoded by marshalBlobSidecarsSSZ.
+func unmarshalBlobSidecarsSSZ(data []byte) ([]*deneb.BlobSidecar, error) {
+	sidecarSize := (&deneb.BlobSidecar{}).SizeSSZ()
+	if len(data)%sidecarSize != 0 {
+		return nil, fmt.Errorf("invalid blob sidecars length %v", len(data))
+	}
+
+	blobs := make([]*deneb.BlobSidecar, 0, len(data)/sidecarSize)
+	for offset := 0; offset < len(data); offset += sidecarSize {
+		blob := &deneb.BlobSidecar{}
+		if err := blob.UnmarshalSSZ(data[offset : offset+sidecarSize]); err != nil {
+			return nil, err
+		}
+
+		blobs = append(blobs, blob)
+	}
+
+	return blobs, nil
 }
 
 // GetSlotDetailsByBlockroot retrieves the combined block details for a given block root.
@@ -136,6 +206,37 @@ func (bs *ChainService) GetSlotDetailsByBlockroot(ctx context.Context, blockroot
 	return result, nil
 }
 
+// GetBlockSSZByBlockroot returns the ssz encoded signed beacon block for a given block root.
+// Blocks that are stored in the unfinalized or orphaned blocks table are returned as stored,
+// other blocks are loaded from a ready client & re-encoded.
+func (bs *ChainService) GetBlockSSZByBlockroot(ctx context.Context, blockroot phase0.Root) (spec.DataVersion, []byte, error) {
+	if blockInfo := bs.beaconIndexer.GetBlockByRoot(blockroot); blockInfo != nil {
+		version, blockSSZ, err := blockInfo.GetBlockSSZ()
+		if blockSSZ != nil || err != nil {
+			return version, blockSSZ, err
+		}
+	}
+
+	if version, blockSSZ, err := bs.beaconIndexer.GetOrphanedBlockSSZByRoot(blockroot); blockSSZ != nil || err != nil {
+		return version, blockSSZ, err
+	}
+
+	blockData, err := bs.GetSlotDetailsByBlockroot(ctx, blockroot)
+	if err != nil {
+		return 0, nil, err
+	}
+	if blockData == nil || blockData.Block == nil {
+		return 0, nil, nil
+	}
+
+	_, blockSSZ, err := beacon.MarshalVersionedSignedBeaconBlockSSZ(bs.beaconIndexer.GetDynSSZ(), blockData.Block, nil, true)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error serializing block: %v", err)
+	}
+
+	return blockData.Block.Version, blockSSZ, nil
+}
+
 // GetSlotDetailsBySlot retrieves the combined block details for a given slot.
 // It first checks if there are any blocks in the beacon indexer's block cache for the given slot.
 // If found, it constructs a CombinedBlockResponse using the block information from the cache.
@@ -222,16 +323,9 @@ func (bs *ChainService) GetSlotDetailsBySlot(ctx context.Context, slot phase0.Sl
 }
 
 // GetBlobSidecarsByBlockRoot retrieves the blob sidecars for a given block root.
-// It first tries to find a client that has the block root in its cache, and if not found,
-// it falls back to a random ready client. It then retrieves the blob sidecars for the block root
-// and returns them.
+// The sidecars are loaded from the blob store or a client that has the block root in its cache (see getBlobSidecars).
 func (bs *ChainService) GetBlobSidecarsByBlockRoot(ctx context.Context, blockroot []byte) ([]*deneb.BlobSidecar, error) {
-	client := bs.beaconIndexer.GetReadyClientByBlockRoot(phase0.Root(blockroot), true)
-	if client == nil {
-		return nil, fmt.Errorf("no clients available")
-	}
-
-	return client.GetClient().GetRPCClient().GetBlobSidecarsByBlockroot(ctx, blockroot)
+	return bs.getBlobSidecars(ctx, phase0.Root(blockroot), false)
 }
 
 // GetDbBlocksForSlots retrieves blocks for a range of slots from cache & database.
@@ -494,6 +588,18 @@ func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx
 	prunedSlot := chainState.EpochToSlot(prunedEpoch)
 	finalizedSlot := chainState.EpochToSlot(finalizedEpoch)
 
+	if filter.Cursor != nil {
+		if filter.Cursor.Key < uint64(finalizedSlot) {
+			// keyset pagination beyond the cached range, load the page from db only
+			return bs.getFilteredDbSlots(filter, uint64(finalizedSlot), 0, pageSize+1)
+		}
+
+		// keyset cursor points into the cached range, fall back to page index based paging
+		dbFilter := *filter
+		dbFilter.Cursor = nil
+		filter = &dbFilter
+	}
+
 	currentSlot := chainState.CurrentSlot()
 	startSlot := currentSlot
 	if withScheduledCount > 0 {
@@ -526,11 +632,14 @@ func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx
 		return proposer
 	}
 
+	// fingerprints are only built for finalized blocks, so cached blocks never match fingerprint filters
+	withFingerprintFilter := filter.ClClient != 0 || filter.ElClient != 0 || filter.BuilderType != 0
+
 	// get blocks from cache
 	// iterate from current slot to finalized slot
 	lastCanonicalBlock := bs.beaconIndexer.GetCanonicalHead(nil)
 
-	for slotIdx := int64(startSlot); slotIdx >= int64(finalizedSlot); slotIdx-- {
+	for slotIdx := int64(startSlot); slotIdx >= int64(finalizedSlot) && !withFingerprintFilter; slotIdx-- {
 		slot := phase0.Slot(slotIdx)
 		blocks := bs.beaconIndexer.GetBlocksBySlot(slot)
 		for _, block := range blocks {
@@ -566,8 +675,9 @@ func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx
 
 			// filter by graffiti
 			if filter.Graffiti != "" {
-				blockGraffiti := string(blockIndex.Graffiti[:])
-				if !strings.Contains(blockGraffiti, filter.Graffiti) {
+				// case-insensitive, same as the db search path
+				blockGraffiti := strings.ToLower(string(blockIndex.Graffiti[:]))
+				if !strings.Contains(blockGraffiti, strings.ToLower(filter.Graffiti)) {
 					continue
 				}
 			}
@@ -580,6 +690,21 @@ func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx
 				}
 			}
 
+			// filter by fee recipient
+			if len(filter.FeeRecipient) > 0 {
+				if !bytes.Equal(blockIndex.ExecutionFeeRecipient[:], filter.FeeRecipient) {
+					continue
+				}
+			}
+
+			// filter by block tag (tags of unfinalized blocks are evaluated on the fly)
+			if filter.Tag != "" {
+				blockTags := beacon.MatchBlockTags(utils.GraffitiToString(blockIndex.Graffiti[:]), utils.GraffitiToString(blockIndex.ExecutionExtraData))
+				if !slices.Contains(blockTags, filter.Tag) {
+					continue
+				}
+			}
+
 			// filter by proposer
 			proposer := uint64(blockHeader.Message.ProposerIndex)
 			if filter.ProposerIndex != nil {
@@ -603,7 +728,7 @@ func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx
 		}
 
 		// reconstruct missing blocks from epoch duties
-		if filter.WithMissing != 0 && filter.Graffiti == "" && filter.ExtraData == "" && filter.WithOrphaned != 2 {
+		if filter.WithMissing != 0 && filter.Graffiti == "" && filter.ExtraData == "" && len(filter.FeeRecipient) == 0 && filter.Tag == "" && filter.WithOrphaned != 2 {
 			hasCanonicalProposer := false
 			canonicalProposer := getCanonicalProposer(slot)
 
@@ -731,9 +856,9 @@ func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx
 	dbCacheOffset := uint64(pageSize) - (cachedMatchesLen % uint64(pageSize))
 	var dbBlocks []*dbtypes.AssignedSlot
 	if dbPage == 0 {
-		dbBlocks = db.GetFilteredSlots(filter, uint64(finalizedSlot), 0, uint32(dbCacheOffset)+1)
+		dbBlocks = bs.getFilteredDbSlots(filter, uint64(finalizedSlot), 0, uint32(dbCacheOffset)+1)
 	} else {
-		dbBlocks = db.GetFilteredSlots(filter, uint64(finalizedSlot), (dbPage-1)*uint64(pageSize)+dbCacheOffset, pageSize+1)
+		dbBlocks = bs.getFilteredDbSlots(filter, uint64(finalizedSlot), (dbPage-1)*uint64(pageSize)+dbCacheOffset, pageSize+1)
 	}
 	resBlocks = append(resBlocks, dbBlocks...)
 
@@ -794,3 +919,32 @@ func (bs *ChainService) GetHighestElBlockNumber(overrideForkId *beacon.ForkKey)
 
 	return 0
 }
+
+// GetCachedGraffitiCounts returns the number of unfinalized canonical blocks per graffiti text that contain the search string (case-insensitive).
+// finalized blocks are not included, these are aggregated from the database.
+func (bs *ChainService) GetCachedGraffitiCounts(search string) map[string]uint64 {
+	chainState := bs.consensusPool.GetChainState()
+	finalizedEpoch, _ := bs.beaconIndexer.GetBlockCacheState()
+	finalizedSlot := chainState.EpochToSlot(finalizedEpoch)
+	search = strings.ToLower(search)
+
+	graffitiCounts := map[string]uint64{}
+	canonicalBlock := bs.beaconIndexer.GetCanonicalHead(nil)
+	for canonicalBlock != nil && canonicalBlock.Slot >= finalizedSlot {
+		if blockIndex := canonicalBlock.GetBlockIndex(); blockIndex != nil {
+			graffitiText := utils.GraffitiToString(blockIndex.Graffiti[:])
+			if graffitiText != "" && strings.Contains(strings.ToLower(graffitiText), search) {
+				graffitiCounts[graffitiText]++
+			}
+		}
+
+		parentRoot := canonicalBlock.GetParentRoot()
+		if parentRoot == nil {
+			break
+		}
+
+		canonicalBlock = bs.beaconIndexer.GetBlockByRoot(*parentRoot)
+	}
+
+	return graffitiCounts
+}
@@ -0,0 +1,368 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	blsu "github.com/protolambda/bls12-381-util"
+	zrnt_common "github.com/protolambda/zrnt/eth2/beacon/common"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+// maxBroadcastOperations is the maximum number of operations that can be broadcasted with a single submission.
+const maxBroadcastOperations = 16
+
+// broadcastOperationCallCost is the call rate limit cost of a single broadcasted operation.
+const broadcastOperationCallCost = 5
+
+// broadcastTimeout is the timeout for submitting all operations of a submission to a single client.
+const broadcastTimeout = 10 * time.Second
+
+// BroadcastOperationType is the type of a broadcasted operation.
+type BroadcastOperationType uint8
+
+const (
+	BroadcastOperationVoluntaryExit BroadcastOperationType = iota + 1
+	BroadcastOperationBLSChange
+)
+
+var broadcastOperationTypeNames = map[BroadcastOperationType]string{
+	BroadcastOperationVoluntaryExit: "voluntary_exit",
+	BroadcastOperationBLSChange:     "bls_change",
+}
+
+func (t BroadcastOperationType) String() string {
+	return broadcastOperationTypeNames[t]
+}
+
+// BroadcastOperation is a signed voluntary exit or bls to execution change submitted for broadcasting.
+type BroadcastOperation struct {
+	Type           BroadcastOperationType
+	ValidatorIndex phase0.ValidatorIndex
+	VoluntaryExit  *phase0.SignedVoluntaryExit
+	BLSChange      *capella.SignedBLSToExecutionChange
+}
+
+// BroadcastResult holds the validation & per-client submission results of a broadcasted operation.
+type BroadcastResult struct {
+	Operation       *BroadcastOperation
+	ValidationError error
+	Clients         []*BroadcastClientResult
+}
+
+// BroadcastClientResult holds the submission result of a single client.
+type BroadcastClientResult struct {
+	ClientName string
+	Accepted   bool
+	Error      string
+}
+
+// ParseBroadcastOperations parses a json encoded signed voluntary exit or bls to execution change, or a list of them.
+// the operation type is detected from the message fields, additional fields (eg. the metadata of the staking-deposit-cli) are ignored.
+func ParseBroadcastOperations(data []byte) ([]*BroadcastOperation, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no operation provided")
+	}
+
+	rawOperations := []json.RawMessage{}
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &rawOperations); err != nil {
+			return nil, fmt.Errorf("invalid operation list: %v", err)
+		}
+	} else {
+		rawOperations = append(rawOperations, json.RawMessage(data))
+	}
+
+	if len(rawOperations) == 0 {
+		return nil, fmt.Errorf("no operation provided")
+	}
+	if len(rawOperations) > maxBroadcastOperations {
+		return nil, fmt.Errorf("too many operations, max %v per submission", maxBroadcastOperations)
+	}
+
+	operations := make([]*BroadcastOperation, 0, len(rawOperations))
+	for idx, rawOperation := range rawOperations {
+		operationProbe := struct {
+			Message map[string]json.RawMessage `json:"message"`
+		}{}
+		if err := json.Unmarshal(rawOperation, &operationProbe); err != nil || operationProbe.Message == nil {
+			return nil, fmt.Errorf("operation %v: invalid operation, expected an object with message & signature", idx)
+		}
+
+		if _, isBLSChange := operationProbe.Message["from_bls_pubkey"]; isBLSChange {
+			blsChange := &capella.SignedBLSToExecutionChange{}
+			if err := json.Unmarshal(rawOperation, blsChange); err != nil {
+				return nil, fmt.Errorf("operation %v: invalid bls to execution change: %v", idx, err)
+			}
+
+			operations = append(operations, &BroadcastOperation{
+				Type:           BroadcastOperationBLSChange,
+				ValidatorIndex: blsChange.Message.ValidatorIndex,
+				BLSChange:      blsChange,
+			})
+		} else {
+			voluntaryExit := &phase0.SignedVoluntaryExit{}
+			if err := json.Unmarshal(rawOperation, voluntaryExit); err != nil {
+				return nil, fmt.Errorf("operation %v: invalid voluntary exit: %v", idx, err)
+			}
+
+			operations = append(operations, &BroadcastOperation{
+				Type:           BroadcastOperationVoluntaryExit,
+				ValidatorIndex: voluntaryExit.Message.ValidatorIndex,
+				VoluntaryExit:  voluntaryExit,
+			})
+		}
+	}
+
+	return operations, nil
+}
+
+// GetBroadcastCallCost returns the call rate limit cost for broadcasting the given number of operations.
+// every operation is verified and submitted to all clients, so the cost scales with the number of operations.
+func GetBroadcastCallCost(operationCount int) uint {
+	if operationCount < 1 {
+		operationCount = 1
+	}
+	return broadcastOperationCallCost * uint(operationCount)
+}
+
+// BroadcastOperations validates the operations against the current state and submits the valid ones to all online clients.
+// all operations are validated before broadcasting, the submissions to each client share a single deadline.
+func (bs *ChainService) BroadcastOperations(ctx context.Context, operations []*BroadcastOperation) []*BroadcastResult {
+	results := make([]*BroadcastResult, len(operations))
+	validExits := []int{}
+	validBLSChanges := []int{}
+
+	for idx, operation := range operations {
+		result := &BroadcastResult{
+			Operation: operation,
+		}
+		results[idx] = result
+
+		switch operation.Type {
+		case BroadcastOperationVoluntaryExit:
+			result.ValidationError = bs.validateVoluntaryExit(operation.VoluntaryExit)
+			if result.ValidationError == nil {
+				validExits = append(validExits, idx)
+			}
+		case BroadcastOperationBLSChange:
+			result.ValidationError = bs.validateBLSChange(operation.BLSChange)
+			if result.ValidationError == nil {
+				validBLSChanges = append(validBLSChanges, idx)
+			}
+		default:
+			result.ValidationError = fmt.Errorf("unknown operation type")
+		}
+	}
+
+	if len(validExits) == 0 && len(validBLSChanges) == 0 {
+		return results
+	}
+
+	clients := []*consensus.Client{}
+	for _, client := range bs.beaconIndexer.GetAllClients() {
+		if client.GetClient().GetStatus() == consensus.ClientStatusOnline {
+			clients = append(clients, client.GetClient())
+		}
+	}
+
+	for _, result := range results {
+		if result.ValidationError == nil {
+			result.Clients = make([]*BroadcastClientResult, len(clients))
+		}
+	}
+
+	setClientResult := func(opIdx int, clientIdx int, client *consensus.Client, err error) {
+		clientResult := &BroadcastClientResult{
+			ClientName: RedactClientName(client.GetName()),
+			Accepted:   err == nil,
+		}
+		if err != nil {
+			clientResult.Error = err.Error()
+		}
+		results[opIdx].Clients[clientIdx] = clientResult
+	}
+
+	submitCtx, cancel := context.WithTimeout(ctx, broadcastTimeout)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	for clientIdx, client := range clients {
+		wg.Add(1)
+		go func(clientIdx int, client *consensus.Client) {
+			defer wg.Done()
+
+			// the beacon api accepts a single voluntary exit per call, bls changes are submitted as one batch
+			for _, opIdx := range validExits {
+				err := client.GetRPCClient().SubmitVoluntaryExits(submitCtx, operations[opIdx].VoluntaryExit)
+				setClientResult(opIdx, clientIdx, client, err)
+			}
+
+			if len(validBLSChanges) > 0 {
+				blsChanges := make([]*capella.SignedBLSToExecutionChange, len(validBLSChanges))
+				for i, opIdx := range validBLSChanges {
+					blsChanges[i] = operations[opIdx].BLSChange
+				}
+
+				err := client.GetRPCClient().SubmitBLSToExecutionChanges(submitCtx, blsChanges)
+				for _, opIdx := range validBLSChanges {
+					setClientResult(opIdx, clientIdx, client, err)
+				}
+			}
+		}(clientIdx, client)
+	}
+	wg.Wait()
+
+	bs.logger.Infof("broadcasted %v voluntary exits & %v bls changes to %v clients", len(validExits), len(validBLSChanges), len(clients))
+
+	return results
+}
+
+// validateVoluntaryExit checks a signed voluntary exit against the current state (process_voluntary_exit).
+// pending partial withdrawals (electra) are not checked as they are not available from the validator set.
+func (bs *ChainService) validateVoluntaryExit(exit *phase0.SignedVoluntaryExit) error {
+	if exit == nil || exit.Message == nil {
+		return fmt.Errorf("missing voluntary exit message")
+	}
+
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	genesis := chainState.GetGenesis()
+	if specs == nil || genesis == nil {
+		return fmt.Errorf("chain state not ready")
+	}
+
+	validator := bs.GetValidatorByIndex(exit.Message.ValidatorIndex, false)
+	if validator == nil || validator.Validator == nil {
+		return fmt.Errorf("validator %v not found", exit.Message.ValidatorIndex)
+	}
+
+	currentEpoch := chainState.CurrentEpoch()
+	if validator.Validator.ActivationEpoch > currentEpoch || validator.Validator.ExitEpoch <= currentEpoch {
+		return fmt.Errorf("validator is not active")
+	}
+	if validator.Validator.ExitEpoch != beacon.FarFutureEpoch {
+		return fmt.Errorf("validator is already exiting (exit epoch %v)", validator.Validator.ExitEpoch)
+	}
+	if exit.Message.Epoch > currentEpoch {
+		return fmt.Errorf("exit epoch %v is in the future", exit.Message.Epoch)
+	}
+	if validator.Validator.ActivationEpoch+phase0.Epoch(specs.ShardCommitteePeriod) > currentEpoch {
+		return fmt.Errorf("validator is not old enough (min. %v epochs)", specs.ShardCommitteePeriod)
+	}
+
+	return bs.verifyVoluntaryExitSignature(exit, validator.Validator.PublicKey)
+}
+
+// verifyVoluntaryExitSignature checks the signature of a signed voluntary exit for the given validator pubkey.
+func (bs *ChainService) verifyVoluntaryExitSignature(exit *phase0.SignedVoluntaryExit, pubkey phase0.BLSPubKey) error {
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	genesis := chainState.GetGenesis()
+	if specs == nil || genesis == nil {
+		return fmt.Errorf("chain state not ready")
+	}
+
+	// voluntary exits are locked to the capella fork version since deneb (EIP-7044)
+	forkVersion := chainState.GetForkVersionAtEpoch(exit.Message.Epoch)
+	if specs.DenebForkEpoch != nil && uint64(chainState.CurrentEpoch()) >= *specs.DenebForkEpoch {
+		forkVersion = specs.CapellaForkVersion
+	}
+
+	msgRoot, err := exit.Message.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("failed hashing voluntary exit: %v", err)
+	}
+
+	domain := zrnt_common.ComputeDomain(zrnt_common.DOMAIN_VOLUNTARY_EXIT, zrnt_common.Version(forkVersion), zrnt_common.Root(genesis.GenesisValidatorsRoot))
+	if !verifyBroadcastSignature(msgRoot, domain, pubkey, exit.Signature) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
+
+// validateBLSChange checks a signed bls to execution change against the current state (process_bls_to_execution_change).
+func (bs *ChainService) validateBLSChange(blsChange *capella.SignedBLSToExecutionChange) error {
+	if blsChange == nil || blsChange.Message == nil {
+		return fmt.Errorf("missing bls to execution change message")
+	}
+
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	genesis := chainState.GetGenesis()
+	if specs == nil || genesis == nil {
+		return fmt.Errorf("chain state not ready")
+	}
+	if specs.CapellaForkEpoch == nil || uint64(chainState.CurrentEpoch()) < *specs.CapellaForkEpoch {
+		return fmt.Errorf("bls to execution changes are not supported before capella")
+	}
+
+	validator := bs.GetValidatorByIndex(blsChange.Message.ValidatorIndex, false)
+	if validator == nil || validator.Validator == nil {
+		return fmt.Errorf("validator %v not found", blsChange.Message.ValidatorIndex)
+	}
+
+	withdrawalCredentials := validator.Validator.WithdrawalCredentials
+	if len(withdrawalCredentials) != 32 || withdrawalCredentials[0] != 0x00 {
+		return fmt.Errorf("validator does not have bls withdrawal credentials")
+	}
+
+	pubkeyHash := sha256.Sum256(blsChange.Message.FromBLSPubkey[:])
+	if !bytes.Equal(withdrawalCredentials[1:], pubkeyHash[1:]) {
+		return fmt.Errorf("from_bls_pubkey does not match the withdrawal credentials of the validator")
+	}
+
+	return bs.verifyBLSChangeSignature(blsChange)
+}
+
+// verifyBLSChangeSignature checks the signature of a signed bls to execution change against its from_bls_pubkey.
+func (bs *ChainService) verifyBLSChangeSignature(blsChange *capella.SignedBLSToExecutionChange) error {
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	genesis := chainState.GetGenesis()
+	if specs == nil || genesis == nil {
+		return fmt.Errorf("chain state not ready")
+	}
+
+	msgRoot, err := blsChange.Message.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("failed hashing bls to execution change: %v", err)
+	}
+
+	domain := zrnt_common.ComputeDomain(zrnt_common.DOMAIN_BLS_TO_EXECUTION_CHANGE, zrnt_common.Version(specs.GenesisForkVersion), zrnt_common.Root(genesis.GenesisValidatorsRoot))
+	if !verifyBroadcastSignature(msgRoot, domain, blsChange.Message.FromBLSPubkey, blsChange.Signature) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
+
+// verifyBroadcastSignature verifies the bls signature of a message root for the given signing domain.
+func verifyBroadcastSignature(msgRoot [32]byte, domain zrnt_common.BLSDomain, pubkey phase0.BLSPubKey, signature phase0.BLSSignature) bool {
+	signingRoot := zrnt_common.ComputeSigningRoot(zrnt_common.Root(msgRoot), domain)
+
+	pubkeyData := zrnt_common.BLSPubkey(pubkey)
+	blsPubkey, err := pubkeyData.Pubkey()
+	if err != nil {
+		return false
+	}
+
+	signatureData := zrnt_common.BLSSignature(signature)
+	blsSignature, err := signatureData.Signature()
+	if err != nil {
+		return false
+	}
+
+	return blsu.Verify(blsPubkey, signingRoot[:], blsSignature)
+}
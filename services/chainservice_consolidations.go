@@ -128,7 +128,7 @@ func (bs *ChainService) GetConsolidationRequestsByFilter(filter *CombinedConsoli
 
 			if len(dbOperation.TxHash) > 0 {
 				requestTxDetailsFor = append(requestTxDetailsFor, dbOperation.TxHash)
-			} else if matcherHeight := bs.GetConsolidationIndexer().GetMatcherHeight(); dbOperation.BlockNumber > matcherHeight {
+			} else if consolidationIndexer := bs.GetConsolidationIndexer(); consolidationIndexer == nil || dbOperation.BlockNumber > consolidationIndexer.GetMatcherHeight() {
 				// consolidation request has not been matched with a tx yet, try to find the tx on the fly
 				requestTxs := db.GetConsolidationRequestTxsByDequeueRange(dbOperation.BlockNumber, dbOperation.BlockNumber)
 				if len(requestTxs) > 1 {
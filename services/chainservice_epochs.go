@@ -1,11 +1,24 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"io"
+
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon/duties"
+	"github.com/prysmaticlabs/go-bitfield"
 )
 
+// CommitteeParticipation holds the attestation participation of a committee index across all slots of an epoch
+type CommitteeParticipation struct {
+	CommitteeIndex uint64
+	Assigned       uint64 // validators assigned to the committee index
+	Attested       uint64 // assigned validators with an included canonical attestation
+}
+
 func (bs *ChainService) GetDbEpochs(firstEpoch uint64, limit uint32) []*dbtypes.Epoch {
 	resEpochs := make([]*dbtypes.Epoch, limit)
 	resIdx := 0
@@ -46,3 +59,141 @@ func (bs *ChainService) GetDbEpochs(firstEpoch uint64, limit uint32) []*dbtypes.
 
 	return resEpochs
 }
+
+// GetEpochCommitteeParticipation aggregates the participation per committee index across all slots of an epoch.
+// the participation is computed from the aggregation bits of the stored canonical attestations.
+// committee sizes are taken from the epoch duties if available, otherwise they are derived from the active validator count of the epoch.
+// GetEpochStateSSZ opens a stream of the ssz encoded beacon state at the first slot of the given epoch from a ready client.
+// dora does not store beacon states, so the state is always proxied from a (preferably archive) node.
+// returns the state stream along with its consensus version, the caller needs to close the stream.
+func (bs *ChainService) GetEpochStateSSZ(ctx context.Context, epoch phase0.Epoch) (io.ReadCloser, string, error) {
+	chainState := bs.consensusPool.GetChainState()
+	if epoch > chainState.CurrentEpoch() {
+		return nil, "", fmt.Errorf("epoch %v has not started yet", epoch)
+	}
+
+	client := bs.beaconIndexer.GetReadyClient(true)
+	if client == nil {
+		return nil, "", fmt.Errorf("no clients available")
+	}
+
+	stateSlot := chainState.EpochStartSlot(epoch)
+	return client.GetClient().GetRPCClient().GetStateSSZ(ctx, fmt.Sprintf("%v", stateSlot))
+}
+
+func (bs *ChainService) GetEpochCommitteeParticipation(epoch phase0.Epoch) ([]*CommitteeParticipation, error) {
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	if specs == nil {
+		return nil, fmt.Errorf("chain specs not loaded")
+	}
+
+	committeeSizes := bs.getEpochCommitteeSizes(epoch)
+	if committeeSizes == nil {
+		return nil, fmt.Errorf("committee assignments for epoch %v not available", epoch)
+	}
+
+	// attestations can be included until the end of the next epoch (EIP-7045)
+	firstSlot := chainState.EpochToSlot(epoch)
+	lastSlot := firstSlot + phase0.Slot(specs.SlotsPerEpoch) - 1
+	attestations, err := db.GetCanonicalAttestationsByAttSlot(uint64(firstSlot), uint64(lastSlot), uint64(lastSlot)+specs.SlotsPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	// merge the aggregation bits of all attestations per slot & committee to avoid counting validators twice
+	participationBits := make([][]bitfield.Bitlist, len(committeeSizes))
+	for slotIndex, slotCommittees := range committeeSizes {
+		participationBits[slotIndex] = make([]bitfield.Bitlist, len(slotCommittees))
+		for committee, size := range slotCommittees {
+			participationBits[slotIndex][committee] = bitfield.NewBitlist(size)
+		}
+	}
+
+	mergeBits := func(slotIndex uint64, committee uint64, aggregationBits bitfield.Bitlist, offset uint64) uint64 {
+		if slotIndex >= uint64(len(participationBits)) || committee >= uint64(len(participationBits[slotIndex])) {
+			return 0
+		}
+
+		committeeBits := participationBits[slotIndex][committee]
+		for i := uint64(0); i < committeeBits.Len(); i++ {
+			if offset+i < aggregationBits.Len() && aggregationBits.BitAt(offset+i) {
+				committeeBits.SetBitAt(i, true)
+			}
+		}
+
+		return committeeBits.Len()
+	}
+
+	for _, attestation := range attestations {
+		slotIndex := uint64(chainState.SlotToSlotIndex(phase0.Slot(attestation.AttSlot)))
+		aggregationBits := bitfield.Bitlist(attestation.AggregationBits)
+
+		if len(attestation.CommitteeBits) > 0 {
+			// EIP-7549 attestation, the aggregation bits of all included committees are concatenated
+			offset := uint64(0)
+			for _, committee := range bitfield.Bitvector64(attestation.CommitteeBits).BitIndices() {
+				offset += mergeBits(slotIndex, uint64(committee), aggregationBits, offset)
+			}
+		} else {
+			mergeBits(slotIndex, attestation.CommitteeIndex, aggregationBits, 0)
+		}
+	}
+
+	participation := []*CommitteeParticipation{}
+	for _, slotCommittees := range participationBits {
+		for committee, committeeBits := range slotCommittees {
+			for len(participation) <= committee {
+				participation = append(participation, &CommitteeParticipation{
+					CommitteeIndex: uint64(len(participation)),
+				})
+			}
+
+			participation[committee].Assigned += committeeBits.Len()
+			participation[committee].Attested += committeeBits.Count()
+		}
+	}
+
+	return participation, nil
+}
+
+// getEpochCommitteeSizes returns the number of validators per slot & committee of an epoch or nil if unknown
+func (bs *ChainService) getEpochCommitteeSizes(epoch phase0.Epoch) [][]uint64 {
+	if epochStats := bs.beaconIndexer.GetEpochStats(epoch, nil); epochStats != nil {
+		if epochStatsValues := epochStats.GetOrLoadValues(bs.beaconIndexer, true, false); epochStatsValues != nil && epochStatsValues.AttesterDuties != nil {
+			committeeSizes := make([][]uint64, len(epochStatsValues.AttesterDuties))
+			for slotIndex, slotCommittees := range epochStatsValues.AttesterDuties {
+				committeeSizes[slotIndex] = make([]uint64, len(slotCommittees))
+				for committee, committeeDuties := range slotCommittees {
+					committeeSizes[slotIndex][committee] = uint64(len(committeeDuties))
+				}
+			}
+
+			return committeeSizes
+		}
+	}
+
+	// the committee sizes only depend on the number of active validators
+	dbEpochs := db.GetEpochs(uint64(epoch), 1)
+	if len(dbEpochs) == 0 || dbEpochs[0].Epoch != uint64(epoch) || dbEpochs[0].ValidatorCount == 0 {
+		return nil
+	}
+
+	specs := bs.consensusPool.GetChainState().GetSpecs()
+	validatorCount := dbEpochs[0].ValidatorCount
+	committeesPerSlot := duties.SlotCommitteeCount(specs, validatorCount)
+	committeesCount := committeesPerSlot * specs.SlotsPerEpoch
+
+	committeeSizes := make([][]uint64, specs.SlotsPerEpoch)
+	for slotIndex := uint64(0); slotIndex < specs.SlotsPerEpoch; slotIndex++ {
+		committeeSizes[slotIndex] = make([]uint64, committeesPerSlot)
+		for committee := uint64(0); committee < committeesPerSlot; committee++ {
+			indexOffset := committee + (slotIndex * committeesPerSlot)
+			start := duties.SplitOffset(validatorCount, committeesCount, indexOffset)
+			end := duties.SplitOffset(validatorCount, committeesCount, indexOffset+1)
+			committeeSizes[slotIndex][committee] = end - start
+		}
+	}
+
+	return committeeSizes
+}
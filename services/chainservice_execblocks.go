@@ -0,0 +1,88 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+// GetDbBlocksByExecutionBlock returns all blocks that include the execution payload with the given block hash, or block number if no hash is given.
+// canonical blocks are returned first, unfinalized blocks are taken from the cache.
+func (bs *ChainService) GetDbBlocksByExecutionBlock(blockNumber *uint64, blockHash []byte) []*dbtypes.Slot {
+	resBlocks := []*dbtypes.Slot{}
+	cachedRoots := map[phase0.Root]bool{}
+
+	var cachedBlocks []*beacon.Block
+	if blockHash != nil {
+		cachedBlocks = bs.beaconIndexer.GetBlocksByExecutionBlockHash(phase0.Hash32(blockHash))
+	} else if blockNumber != nil {
+		cachedBlocks = bs.beaconIndexer.GetBlocksByExecutionBlockNumber(*blockNumber)
+	}
+
+	for _, block := range cachedBlocks {
+		isCanonical := bs.beaconIndexer.IsCanonicalBlock(block, nil)
+		dbBlock := block.GetDbBlock(bs.beaconIndexer, isCanonical)
+		if dbBlock == nil {
+			continue
+		}
+
+		cachedRoots[block.Root] = true
+		resBlocks = append(resBlocks, dbBlock)
+	}
+
+	for _, dbBlock := range db.GetSlotsByExecutionBlock(blockNumber, blockHash) {
+		if cachedRoots[phase0.Root(dbBlock.Root)] {
+			continue
+		}
+
+		resBlocks = append(resBlocks, dbBlock)
+	}
+
+	// canonical blocks first, then by slot descending
+	sort.Slice(resBlocks, func(i, j int) bool {
+		if resBlocks[i].Status != resBlocks[j].Status {
+			return resBlocks[i].Status == dbtypes.Canonical
+		}
+		if resBlocks[i].Slot != resBlocks[j].Slot {
+			return resBlocks[i].Slot > resBlocks[j].Slot
+		}
+		return bytes.Compare(resBlocks[i].Root, resBlocks[j].Root) < 0
+	})
+
+	return resBlocks
+}
+
+// GetExecutionBlockReceipts loads the transaction receipts of an execution block from the ready execution clients.
+// the clients are tried in order until one of them returns the receipts.
+func (bs *ChainService) GetExecutionBlockReceipts(ctx context.Context, blockHash common.Hash) ([]*types.Receipt, error) {
+	clients := bs.executionPool.GetReadyEndpoints(execution.AnyClient)
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("no ready execution client found")
+	}
+
+	var lastErr error
+	for _, client := range clients {
+		reqCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		receipts, err := client.GetRPCClient().GetBlockReceipts(reqCtx, blockHash)
+		cancel()
+
+		if err == nil {
+			return receipts, nil
+		}
+
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("could not load receipts for block %v: %v", blockHash.String(), lastErr)
+}
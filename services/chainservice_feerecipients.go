@@ -0,0 +1,132 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// FeeRecipientSummary holds the blocks of a proposer with the same fee recipient
+type FeeRecipientSummary struct {
+	Validator     uint64
+	ValidatorName string
+	FeeRecipient  common.Address
+	Expected      *common.Address // nil if no expected fee recipient is configured for the validator
+	Mismatch      bool
+	Blocks        uint64
+	FirstSlot     uint64
+	LastSlot      uint64
+}
+
+type feeRecipientExpectation struct {
+	minIndex uint64
+	maxIndex uint64
+	name     string
+	address  common.Address
+}
+
+var feeRecipientExpectationsOnce sync.Once
+var feeRecipientExpectations []*feeRecipientExpectation
+
+// getFeeRecipientExpectations parses the expected fee recipients from the config
+func (bs *ChainService) getFeeRecipientExpectations() []*feeRecipientExpectation {
+	feeRecipientExpectationsOnce.Do(func() {
+		for _, expected := range utils.Config.FeeRecipients.Expected {
+			if !common.IsHexAddress(expected.Address) {
+				bs.logger.Warnf("invalid expected fee recipient address: %v", expected.Address)
+				continue
+			}
+			address := common.HexToAddress(expected.Address)
+
+			if expected.Validators != "" {
+				for _, indexRange := range strings.Split(expected.Validators, ",") {
+					minIndex, maxIndex, err := utils.ParseValidatorIndexRange(indexRange)
+					if err != nil {
+						bs.logger.Warnf("invalid validator range for expected fee recipient %v: %v", expected.Address, err)
+						continue
+					}
+
+					feeRecipientExpectations = append(feeRecipientExpectations, &feeRecipientExpectation{
+						minIndex: minIndex,
+						maxIndex: maxIndex,
+						address:  address,
+					})
+				}
+			}
+
+			if expected.Name != "" {
+				feeRecipientExpectations = append(feeRecipientExpectations, &feeRecipientExpectation{
+					name:    expected.Name,
+					address: address,
+				})
+			}
+		}
+
+		// index ranges take precedence over validator names
+		sort.SliceStable(feeRecipientExpectations, func(i, j int) bool {
+			return feeRecipientExpectations[i].name == "" && feeRecipientExpectations[j].name != ""
+		})
+	})
+
+	return feeRecipientExpectations
+}
+
+// HasFeeRecipientExpectations returns true if expected fee recipients are configured
+func (bs *ChainService) HasFeeRecipientExpectations() bool {
+	return len(bs.getFeeRecipientExpectations()) > 0
+}
+
+// GetExpectedFeeRecipient returns the configured fee recipient for a validator or nil if none is configured
+func (bs *ChainService) GetExpectedFeeRecipient(validatorIndex uint64, validatorName string) *common.Address {
+	for _, expectation := range bs.getFeeRecipientExpectations() {
+		if expectation.name != "" {
+			if validatorName == expectation.name {
+				return &expectation.address
+			}
+		} else if validatorIndex >= expectation.minIndex && validatorIndex <= expectation.maxIndex {
+			return &expectation.address
+		}
+	}
+
+	return nil
+}
+
+// GetFeeRecipientSummaries returns the fee recipients used by each proposer in canonical blocks since minSlot.
+// the summaries are matched against the expected fee recipients and sorted by mismatch & last slot (newest first).
+func (bs *ChainService) GetFeeRecipientSummaries(minSlot uint64) ([]*FeeRecipientSummary, error) {
+	stats, err := db.GetFeeRecipientStats(minSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*FeeRecipientSummary, 0, len(stats))
+	for _, stat := range stats {
+		summary := &FeeRecipientSummary{
+			Validator:     stat.Proposer,
+			ValidatorName: bs.GetValidatorName(stat.Proposer),
+			FeeRecipient:  common.BytesToAddress(stat.FeeRecipient),
+			Blocks:        stat.Blocks,
+			FirstSlot:     stat.FirstSlot,
+			LastSlot:      stat.LastSlot,
+		}
+
+		summary.Expected = bs.GetExpectedFeeRecipient(summary.Validator, summary.ValidatorName)
+		summary.Mismatch = summary.Expected != nil && *summary.Expected != summary.FeeRecipient
+
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Mismatch != summaries[j].Mismatch {
+			return summaries[i].Mismatch
+		}
+		return summaries[i].LastSlot > summaries[j].LastSlot
+	})
+
+	return summaries, nil
+}
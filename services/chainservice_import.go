@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	importer "github.com/ethpandaops/dora/indexer/import"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// ImportEraFiles imports finalized chain history from the given era files into the database.
+// only the consensus client pool is started to load the chain specs & finality checkpoint, the indexer itself is not started.
+func (cs *ChainService) ImportEraFiles(ctx context.Context, files []string, forceUpdate bool) error {
+	for _, endpoint := range utils.Config.BeaconApi.Endpoints {
+		_, err := cs.consensusPool.AddEndpoint(getConsensusClientConfig(&endpoint))
+		if err != nil {
+			cs.logger.Errorf("could not add beacon client '%v' to pool: %v", endpoint.Name, err)
+			continue
+		}
+	}
+
+	if len(cs.consensusPool.GetAllEndpoints()) == 0 {
+		return fmt.Errorf("no beacon clients configured")
+	}
+
+	// await chain specs & finality checkpoint
+	lastLog := time.Now()
+	chainState := cs.consensusPool.GetChainState()
+	for {
+		finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+		if chainState.GetSpecs() != nil && finalizedEpoch > 0 {
+			break
+		}
+
+		if time.Since(lastLog) > 10*time.Second {
+			cs.logger.Warnf("still waiting for chain specs & finality checkpoint... need at least 1 consensus client to load them from.")
+			lastLog = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(1 * time.Second):
+		}
+	}
+
+	eraImporter := importer.NewEraImporter(cs.logger.WithField("service", "era-import"), cs.beaconIndexer, chainState, forceUpdate)
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		cs.logger.Infof("importing era file %v", file)
+		err := eraImporter.ImportFile(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// checkNetworkState compares the genesis of the connected network with the network the database has been indexed for.
+// a changed genesis indicates a network reset (devnet respin). in that case the database is archived if configured,
+// otherwise an error is returned to avoid mixing the data of both networks.
+func (cs *ChainService) checkNetworkState(isWriter bool) error {
+	chainState := cs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	genesis := chainState.GetGenesis()
+
+	currentState := &dbtypes.IndexerNetworkState{
+		ConfigName:            specs.ConfigName,
+		GenesisTime:           uint64(genesis.GenesisTime.Unix()),
+		GenesisValidatorsRoot: genesis.GenesisValidatorsRoot.String(),
+		GenesisForkVersion:    fmt.Sprintf("0x%x", genesis.GenesisForkVersion),
+	}
+
+	storedState := &dbtypes.IndexerNetworkState{}
+	if _, err := db.GetExplorerState("indexer.network", storedState); err != nil || storedState.GenesisTime == 0 {
+		// no network state stored yet (new database or upgraded from a version without reset detection)
+		if !isWriter {
+			return nil
+		}
+
+		return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+			return db.SetExplorerState("indexer.network", currentState, tx)
+		})
+	}
+
+	if storedState.GenesisTime == currentState.GenesisTime && storedState.GenesisValidatorsRoot == currentState.GenesisValidatorsRoot {
+		return nil
+	}
+
+	cs.logger.Warnf(
+		"network reset detected: database has been indexed for genesis %v (%v, validators root %v), connected network has genesis %v (%v, validators root %v)",
+		storedState.GenesisTime, storedState.ConfigName, storedState.GenesisValidatorsRoot,
+		currentState.GenesisTime, currentState.ConfigName, currentState.GenesisValidatorsRoot,
+	)
+
+	if !utils.Config.Indexer.ArchiveOnNetworkReset {
+		return fmt.Errorf("network genesis changed, refusing to mix data of both networks. enable indexer.archiveOnNetworkReset or reset the database")
+	}
+	if !isWriter {
+		return fmt.Errorf("network genesis changed, the database needs to be archived by the writer instance first")
+	}
+
+	archiveName := fmt.Sprintf("archive_%v", storedState.GenesisTime)
+	cs.logger.Warnf("archiving database of the previous network to %v", archiveName)
+
+	if err := db.ArchiveDatabase(archiveName); err != nil {
+		return fmt.Errorf("failed archiving database: %v", err)
+	}
+	if err := db.ApplyEmbeddedDbSchema(-2); err != nil {
+		return fmt.Errorf("failed initializing db schema: %v", err)
+	}
+
+	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.SetExplorerState("indexer.network", currentState, tx)
+	})
+}
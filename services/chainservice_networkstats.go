@@ -0,0 +1,155 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/db"
+)
+
+// NetworkStatsWindow holds the rolling aggregates of the finalized epochs within a time window
+type NetworkStatsWindow struct {
+	Name                string
+	Duration            time.Duration
+	FirstEpoch          uint64
+	LastEpoch           uint64
+	Epochs              uint64 // number of indexed epochs within the window
+	Slots               uint64
+	Blocks              uint64
+	MissedSlots         uint64
+	MissedSlotRate      float64 // percent
+	ReorgCount          uint64  // number of orphaned blocks
+	Participation       float64 // percent of eligible balance that voted for the correct target
+	HeadParticipation   float64 // percent of eligible balance that voted for the correct head
+	SyncParticipation   float64 // percent
+	ValidatorCountStart uint64
+	ValidatorCountEnd   uint64
+	ValidatorGrowth     int64
+	BlockValueBlocks    uint64 // number of blocks with indexed rewards
+	AvgBlockValue       int64  // gwei, only set if rewards have been indexed
+	HasBlockValue       bool
+}
+
+// NetworkStats holds the rolling network stats of all windows
+type NetworkStats struct {
+	CurrentEpoch   uint64
+	FinalizedEpoch uint64
+	UpdatedAt      time.Time
+	Windows        []*NetworkStatsWindow
+}
+
+// networkStatsWindows are the windows the rolling network stats are aggregated for
+var networkStatsWindows = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"1d", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"31d", 31 * 24 * time.Hour},
+}
+
+// networkStatsCache holds the rolling network stats, they are aggregated from the db once per epoch
+type networkStatsCache struct {
+	mutex sync.Mutex
+	epoch phase0.Epoch
+	stats *NetworkStats
+}
+
+// GetNetworkStats returns the rolling 1d / 7d / 31d network stats.
+// the stats are aggregated from the finalized epochs in the db and refreshed with every new epoch.
+func (bs *ChainService) GetNetworkStats() (*NetworkStats, error) {
+	chainState := bs.consensusPool.GetChainState()
+	currentEpoch := chainState.CurrentEpoch()
+	cache := &bs.networkStatsCache
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if cache.stats != nil && cache.epoch == currentEpoch {
+		return cache.stats, nil
+	}
+
+	stats, err := bs.buildNetworkStats(currentEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.epoch = currentEpoch
+	cache.stats = stats
+	return stats, nil
+}
+
+func (bs *ChainService) buildNetworkStats(currentEpoch phase0.Epoch) (*NetworkStats, error) {
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+
+	stats := &NetworkStats{
+		CurrentEpoch:   uint64(currentEpoch),
+		FinalizedEpoch: uint64(finalizedEpoch),
+		UpdatedAt:      time.Now(),
+		Windows:        make([]*NetworkStatsWindow, 0, len(networkStatsWindows)),
+	}
+
+	epochDuration := specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch)
+	if epochDuration == 0 || finalizedEpoch == 0 {
+		return stats, nil
+	}
+
+	// epochs before the finalized checkpoint are persisted, so each window ends with the last finalized epoch
+	lastEpoch := uint64(finalizedEpoch) - 1
+
+	for _, windowConfig := range networkStatsWindows {
+		window := &NetworkStatsWindow{
+			Name:      windowConfig.name,
+			Duration:  windowConfig.duration,
+			LastEpoch: lastEpoch,
+		}
+
+		windowEpochs := uint64(windowConfig.duration / epochDuration)
+		if windowEpochs <= lastEpoch {
+			window.FirstEpoch = lastEpoch - windowEpochs + 1
+		}
+
+		epochStats, err := db.GetEpochRangeStats(window.FirstEpoch, window.LastEpoch)
+		if err != nil {
+			return nil, err
+		}
+
+		window.Epochs = epochStats.Epochs
+		window.Slots = epochStats.Epochs * specs.SlotsPerEpoch
+		window.Blocks = epochStats.BlockCount
+		if window.Slots > window.Blocks {
+			window.MissedSlots = window.Slots - window.Blocks
+		}
+		if window.Slots > 0 {
+			window.MissedSlotRate = float64(window.MissedSlots) * 100 / float64(window.Slots)
+		}
+		window.ReorgCount = epochStats.OrphanedCount
+		if epochStats.Eligible > 0 {
+			window.Participation = float64(epochStats.VotedTarget) * 100 / float64(epochStats.Eligible)
+			window.HeadParticipation = float64(epochStats.VotedHead) * 100 / float64(epochStats.Eligible)
+		}
+		window.SyncParticipation = epochStats.SyncParticipation * 100
+		window.ValidatorCountStart = epochStats.FirstValidatorCount
+		window.ValidatorCountEnd = epochStats.LastValidatorCount
+		window.ValidatorGrowth = int64(epochStats.LastValidatorCount) - int64(epochStats.FirstValidatorCount)
+
+		// block values are only available if the rewards indexer is enabled
+		valueStats, err := db.GetBlockValueStats(window.FirstEpoch*specs.SlotsPerEpoch, (window.LastEpoch+1)*specs.SlotsPerEpoch-1)
+		if err != nil {
+			return nil, err
+		}
+		if valueStats.Blocks > 0 {
+			window.BlockValueBlocks = valueStats.Blocks
+			window.AvgBlockValue = valueStats.TotalValue / int64(valueStats.Blocks)
+			window.HasBlockValue = true
+		}
+
+		stats.Windows = append(stats.Windows, window)
+	}
+
+	return stats, nil
+}
@@ -20,6 +20,13 @@ func (bs *ChainService) GetIncludedDepositsByFilter(filter *dbtypes.DepositFilte
 	idxMinSlot := chainState.EpochToSlot(prunedEpoch)
 	currentSlot := chainState.CurrentSlot()
 
+	if filter.Cursor != nil && filter.Cursor.Key >= uint64(idxMinSlot) {
+		// keyset cursor points into the cached range, fall back to page index based paging
+		dbFilter := *filter
+		dbFilter.Cursor = nil
+		filter = &dbFilter
+	}
+
 	// load most recent objects from indexer cache
 	cachedMatches := make([]*dbtypes.Deposit, 0)
 	canonicalForkIds := bs.GetCanonicalForkKeys()
@@ -97,12 +104,15 @@ func (bs *ChainService) GetIncludedDepositsByFilter(filter *dbtypes.DepositFilte
 	cachedStart := pageIdx * uint64(pageSize)
 	cachedEnd := cachedStart + uint64(pageSize)
 
-	if cachedPages > 0 && pageIdx < cachedPages {
-		resObjs = append(resObjs, cachedMatches[cachedStart:cachedEnd]...)
-		resIdx += int(cachedEnd - cachedStart)
-	} else if pageIdx == cachedPages {
-		resObjs = append(resObjs, cachedMatches[cachedStart:]...)
-		resIdx += len(cachedMatches) - int(cachedStart)
+	// with a keyset cursor beyond the cached range, the page is loaded from db only
+	if filter.Cursor == nil {
+		if cachedPages > 0 && pageIdx < cachedPages {
+			resObjs = append(resObjs, cachedMatches[cachedStart:cachedEnd]...)
+			resIdx += int(cachedEnd - cachedStart)
+		} else if pageIdx == cachedPages {
+			resObjs = append(resObjs, cachedMatches[cachedStart:]...)
+			resIdx += len(cachedMatches) - int(cachedStart)
+		}
 	}
 
 	// load older objects from db
@@ -113,7 +123,9 @@ func (bs *ChainService) GetIncludedDepositsByFilter(filter *dbtypes.DepositFilte
 	var dbCount uint64
 	var err error
 
-	if resIdx > int(pageSize) {
+	if filter.Cursor != nil {
+		dbObjects, dbCount, err = db.GetDepositsFiltered(0, pageSize, uint64(finalizedBlock), filter)
+	} else if resIdx > int(pageSize) {
 		// all results from cache, just get result count from db
 		_, dbCount, err = db.GetDepositsFiltered(0, 1, uint64(finalizedBlock), filter)
 	} else if dbPage == 0 {
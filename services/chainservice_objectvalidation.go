@@ -0,0 +1,866 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	blsu "github.com/protolambda/bls12-381-util"
+	zrnt_common "github.com/protolambda/zrnt/eth2/beacon/common"
+)
+
+// maxValidationObjects is the maximum number of objects that can be validated with a single submission.
+const maxValidationObjects = 100
+
+// ValidationObjectType is the type of an object submitted for validation.
+type ValidationObjectType uint8
+
+const (
+	ValidationObjectUnknown ValidationObjectType = iota
+	ValidationObjectBlock
+	ValidationObjectAttestation
+	ValidationObjectVoluntaryExit
+	ValidationObjectBLSChange
+	ValidationObjectDeposit
+)
+
+var validationObjectTypeNames = map[ValidationObjectType]string{
+	ValidationObjectBlock:         "block",
+	ValidationObjectAttestation:   "attestation",
+	ValidationObjectVoluntaryExit: "voluntary_exit",
+	ValidationObjectBLSChange:     "bls_change",
+	ValidationObjectDeposit:       "deposit",
+}
+
+func (t ValidationObjectType) String() string {
+	if name, found := validationObjectTypeNames[t]; found {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseValidationObjectType returns the object type with the given name (ValidationObjectUnknown for auto detection).
+func ParseValidationObjectType(name string) (ValidationObjectType, error) {
+	if name == "" || name == "auto" {
+		return ValidationObjectUnknown, nil
+	}
+
+	for objectType, typeName := range validationObjectTypeNames {
+		if typeName == name {
+			return objectType, nil
+		}
+	}
+
+	return ValidationObjectUnknown, fmt.Errorf("unknown object type: %v", name)
+}
+
+// ValidationCheckStatus is the outcome of a single validation check.
+type ValidationCheckStatus uint8
+
+const (
+	ValidationCheckOk ValidationCheckStatus = iota + 1
+	ValidationCheckFailed
+	ValidationCheckSkipped
+)
+
+var validationCheckStatusNames = map[ValidationCheckStatus]string{
+	ValidationCheckOk:      "ok",
+	ValidationCheckFailed:  "failed",
+	ValidationCheckSkipped: "skipped",
+}
+
+func (s ValidationCheckStatus) String() string {
+	return validationCheckStatusNames[s]
+}
+
+// ObjectValidationResult holds the decoded breakdown & check results of a validated object.
+type ObjectValidationResult struct {
+	Index    int
+	Type     ValidationObjectType
+	Encoding string
+	Version  spec.DataVersion
+	RootName string
+	Root     phase0.Root
+	Fields   []*ObjectValidationField
+	Checks   []*ObjectValidationCheck
+	Object   string
+	Error    error
+}
+
+// ObjectValidationField is a single decoded field of a validated object.
+type ObjectValidationField struct {
+	Name  string
+	Value string
+}
+
+// ObjectValidationCheck is the result of a single check on a validated object.
+type ObjectValidationCheck struct {
+	Name    string
+	Status  ValidationCheckStatus
+	Message string
+}
+
+func (result *ObjectValidationResult) addField(name string, value string) {
+	result.Fields = append(result.Fields, &ObjectValidationField{
+		Name:  name,
+		Value: value,
+	})
+}
+
+func (result *ObjectValidationResult) addCheck(name string, err error, message string) {
+	check := &ObjectValidationCheck{
+		Name:    name,
+		Status:  ValidationCheckOk,
+		Message: message,
+	}
+	if err != nil {
+		check.Status = ValidationCheckFailed
+		check.Message = err.Error()
+	}
+	result.Checks = append(result.Checks, check)
+}
+
+func (result *ObjectValidationResult) addSkippedCheck(name string, reason string) {
+	result.Checks = append(result.Checks, &ObjectValidationCheck{
+		Name:    name,
+		Status:  ValidationCheckSkipped,
+		Message: reason,
+	})
+}
+
+// validationDeposit is a decoded deposit with the optional metadata of the staking-deposit-cli.
+type validationDeposit struct {
+	data               *phase0.DepositData
+	depositMessageRoot string
+	depositDataRoot    string
+	forkVersion        string
+}
+
+// ValidateObjects decodes json or hex encoded ssz objects with the dynamic ssz definitions of the active spec and checks their signatures & validity against the current state.
+// json input may be a single object or a list of objects, ssz input may contain multiple whitespace separated objects.
+// the object type is detected from the json fields if not given, ssz input requires an explicit object type.
+func (bs *ChainService) ValidateObjects(input []byte, objectType ValidationObjectType) ([]*ObjectValidationResult, error) {
+	input = bytes.TrimSpace(input)
+	if len(input) == 0 {
+		return nil, fmt.Errorf("no object provided")
+	}
+
+	isJson := input[0] == '{' || input[0] == '['
+	rawObjects := [][]byte{}
+	if isJson {
+		if input[0] == '[' {
+			jsonObjects := []json.RawMessage{}
+			if err := json.Unmarshal(input, &jsonObjects); err != nil {
+				return nil, fmt.Errorf("invalid object list: %v", err)
+			}
+			for _, jsonObject := range jsonObjects {
+				rawObjects = append(rawObjects, jsonObject)
+			}
+		} else {
+			rawObjects = append(rawObjects, input)
+		}
+	} else {
+		if objectType == ValidationObjectUnknown {
+			return nil, fmt.Errorf("object type is required for ssz encoded objects")
+		}
+
+		for idx, hexObject := range strings.Fields(string(input)) {
+			sszObject, err := hex.DecodeString(strings.TrimPrefix(hexObject, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("object %v: invalid hex encoding: %v", idx, err)
+			}
+			rawObjects = append(rawObjects, sszObject)
+		}
+	}
+
+	if len(rawObjects) == 0 {
+		return nil, fmt.Errorf("no object provided")
+	}
+	if len(rawObjects) > maxValidationObjects {
+		return nil, fmt.Errorf("too many objects, max %v per submission", maxValidationObjects)
+	}
+
+	results := make([]*ObjectValidationResult, 0, len(rawObjects))
+	for idx, rawObject := range rawObjects {
+		result := &ObjectValidationResult{
+			Index: idx,
+			Type:  objectType,
+		}
+
+		var object any
+		var sszInput []byte
+		var err error
+		if isJson {
+			result.Encoding = "json"
+			object, err = bs.decodeJsonValidationObject(result, rawObject)
+		} else {
+			result.Encoding = "ssz"
+			sszInput = rawObject
+			object, err = bs.decodeSszValidationObject(result, rawObject)
+		}
+
+		if err != nil {
+			result.Error = err
+		} else {
+			bs.validateObject(result, object, sszInput)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// detectValidationObjectType detects the object type from the fields of a json encoded object.
+func detectValidationObjectType(fields map[string]json.RawMessage) ValidationObjectType {
+	if fields["message"] != nil {
+		messageFields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(fields["message"], &messageFields); err != nil {
+			return ValidationObjectUnknown
+		}
+
+		switch {
+		case messageFields["body"] != nil:
+			return ValidationObjectBlock
+		case messageFields["from_bls_pubkey"] != nil:
+			return ValidationObjectBLSChange
+		case messageFields["epoch"] != nil && messageFields["validator_index"] != nil:
+			return ValidationObjectVoluntaryExit
+		}
+		return ValidationObjectUnknown
+	}
+
+	switch {
+	case fields["aggregation_bits"] != nil && fields["data"] != nil:
+		return ValidationObjectAttestation
+	case fields["pubkey"] != nil && fields["withdrawal_credentials"] != nil && fields["amount"] != nil:
+		return ValidationObjectDeposit
+	}
+
+	return ValidationObjectUnknown
+}
+
+func (bs *ChainService) decodeJsonValidationObject(result *ObjectValidationResult, data []byte) (any, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("invalid json object: %v", err)
+	}
+
+	// unwrap beacon api responses ({"version": ..., "data": {...}}) & deposits with proof ({"proof": [...], "data": {...}})
+	if fields["data"] != nil && fields["message"] == nil && fields["aggregation_bits"] == nil {
+		data = fields["data"]
+		fields = map[string]json.RawMessage{}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("invalid json object: %v", err)
+		}
+	}
+
+	if result.Type == ValidationObjectUnknown {
+		result.Type = detectValidationObjectType(fields)
+	}
+
+	chainState := bs.consensusPool.GetChainState()
+
+	switch result.Type {
+	case ValidationObjectBlock:
+		blockProbe := struct {
+			Message struct {
+				Slot string `json:"slot"`
+			} `json:"message"`
+		}{}
+		if err := json.Unmarshal(data, &blockProbe); err != nil {
+			return nil, fmt.Errorf("invalid block: %v", err)
+		}
+		slot, err := strconv.ParseUint(blockProbe.Message.Slot, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block slot: %v", err)
+		}
+
+		result.Version = chainState.GetDataVersionAtEpoch(chainState.EpochOfSlot(phase0.Slot(slot)))
+		return unmarshalValidationBlockJson(result.Version, data)
+
+	case ValidationObjectAttestation:
+		if fields["committee_bits"] != nil {
+			result.Version = spec.DataVersionElectra
+			attestation := &electra.Attestation{}
+			if err := json.Unmarshal(data, attestation); err != nil {
+				return nil, fmt.Errorf("invalid attestation: %v", err)
+			}
+			return &spec.VersionedAttestation{Version: result.Version, Electra: attestation}, nil
+		}
+
+		result.Version = spec.DataVersionPhase0
+		attestation := &phase0.Attestation{}
+		if err := json.Unmarshal(data, attestation); err != nil {
+			return nil, fmt.Errorf("invalid attestation: %v", err)
+		}
+		return &spec.VersionedAttestation{Version: result.Version, Phase0: attestation}, nil
+
+	case ValidationObjectVoluntaryExit:
+		voluntaryExit := &phase0.SignedVoluntaryExit{}
+		if err := json.Unmarshal(data, voluntaryExit); err != nil {
+			return nil, fmt.Errorf("invalid voluntary exit: %v", err)
+		}
+		return voluntaryExit, nil
+
+	case ValidationObjectBLSChange:
+		blsChange := &capella.SignedBLSToExecutionChange{}
+		if err := json.Unmarshal(data, blsChange); err != nil {
+			return nil, fmt.Errorf("invalid bls to execution change: %v", err)
+		}
+		return blsChange, nil
+
+	case ValidationObjectDeposit:
+		// the staking-deposit-cli encodes the amount as number
+		if amount := fields["amount"]; len(amount) > 0 && amount[0] != '"' {
+			fields["amount"] = json.RawMessage(strconv.Quote(string(amount)))
+		}
+		normalizedData, err := json.Marshal(fields)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deposit: %v", err)
+		}
+
+		deposit := &validationDeposit{
+			data: &phase0.DepositData{},
+		}
+		if err := json.Unmarshal(normalizedData, deposit.data); err != nil {
+			return nil, fmt.Errorf("invalid deposit: %v", err)
+		}
+
+		depositMeta := struct {
+			DepositMessageRoot string `json:"deposit_message_root"`
+			DepositDataRoot    string `json:"deposit_data_root"`
+			ForkVersion        string `json:"fork_version"`
+		}{}
+		if err := json.Unmarshal(normalizedData, &depositMeta); err == nil {
+			deposit.depositMessageRoot = depositMeta.DepositMessageRoot
+			deposit.depositDataRoot = depositMeta.DepositDataRoot
+			deposit.forkVersion = depositMeta.ForkVersion
+		}
+		return deposit, nil
+	}
+
+	return nil, fmt.Errorf("could not detect object type")
+}
+
+func (bs *ChainService) decodeSszValidationObject(result *ObjectValidationResult, ssz []byte) (any, error) {
+	chainState := bs.consensusPool.GetChainState()
+	dynSsz := bs.beaconIndexer.GetDynSSZ()
+
+	switch result.Type {
+	case ValidationObjectBlock:
+		// signed block: message offset (4) + signature (96), the slot is the first field of the message
+		if len(ssz) < 100 {
+			return nil, fmt.Errorf("invalid block: ssz too short")
+		}
+		msgOffset := uint64(binary.LittleEndian.Uint32(ssz[0:4]))
+		if msgOffset+8 > uint64(len(ssz)) {
+			return nil, fmt.Errorf("invalid block: message offset out of range")
+		}
+		slot := phase0.Slot(binary.LittleEndian.Uint64(ssz[msgOffset : msgOffset+8]))
+
+		result.Version = chainState.GetDataVersionAtEpoch(chainState.EpochOfSlot(slot))
+		block, err := bs.beaconIndexer.UnmarshalBlockSSZ(result.Version, ssz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v block: %v", result.Version.String(), err)
+		}
+		return block, nil
+
+	case ValidationObjectAttestation:
+		// attestation: aggregation bits offset (4), the slot is the first field of the attestation data
+		if len(ssz) < 12 {
+			return nil, fmt.Errorf("invalid attestation: ssz too short")
+		}
+		slot := phase0.Slot(binary.LittleEndian.Uint64(ssz[4:12]))
+
+		if chainState.GetDataVersionAtEpoch(chainState.EpochOfSlot(slot)) >= spec.DataVersionElectra {
+			result.Version = spec.DataVersionElectra
+			attestation := &electra.Attestation{}
+			if err := dynSsz.UnmarshalSSZ(attestation, ssz); err != nil {
+				return nil, fmt.Errorf("invalid electra attestation: %v", err)
+			}
+			return &spec.VersionedAttestation{Version: result.Version, Electra: attestation}, nil
+		}
+
+		result.Version = spec.DataVersionPhase0
+		attestation := &phase0.Attestation{}
+		if err := dynSsz.UnmarshalSSZ(attestation, ssz); err != nil {
+			return nil, fmt.Errorf("invalid attestation: %v", err)
+		}
+		return &spec.VersionedAttestation{Version: result.Version, Phase0: attestation}, nil
+
+	case ValidationObjectVoluntaryExit:
+		voluntaryExit := &phase0.SignedVoluntaryExit{}
+		if err := dynSsz.UnmarshalSSZ(voluntaryExit, ssz); err != nil {
+			return nil, fmt.Errorf("invalid voluntary exit: %v", err)
+		}
+		return voluntaryExit, nil
+
+	case ValidationObjectBLSChange:
+		blsChange := &capella.SignedBLSToExecutionChange{}
+		if err := dynSsz.UnmarshalSSZ(blsChange, ssz); err != nil {
+			return nil, fmt.Errorf("invalid bls to execution change: %v", err)
+		}
+		return blsChange, nil
+
+	case ValidationObjectDeposit:
+		// accept plain deposit data (184 bytes) & deposits with merkle proof
+		depositData := &phase0.DepositData{}
+		if len(ssz) == 184 {
+			if err := dynSsz.UnmarshalSSZ(depositData, ssz); err != nil {
+				return nil, fmt.Errorf("invalid deposit data: %v", err)
+			}
+		} else {
+			deposit := &phase0.Deposit{}
+			if err := dynSsz.UnmarshalSSZ(deposit, ssz); err != nil {
+				return nil, fmt.Errorf("invalid deposit: %v", err)
+			}
+			depositData = deposit.Data
+		}
+		return &validationDeposit{data: depositData}, nil
+	}
+
+	return nil, fmt.Errorf("unknown object type")
+}
+
+func unmarshalValidationBlockJson(version spec.DataVersion, data []byte) (*spec.VersionedSignedBeaconBlock, error) {
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: version,
+	}
+
+	var err error
+	switch version {
+	case spec.DataVersionPhase0:
+		block.Phase0 = &phase0.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Phase0)
+	case spec.DataVersionAltair:
+		block.Altair = &altair.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Altair)
+	case spec.DataVersionBellatrix:
+		block.Bellatrix = &bellatrix.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Bellatrix)
+	case spec.DataVersionCapella:
+		block.Capella = &capella.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Capella)
+	case spec.DataVersionDeneb:
+		block.Deneb = &deneb.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Deneb)
+	case spec.DataVersionElectra:
+		block.Electra = &electra.SignedBeaconBlock{}
+		err = json.Unmarshal(data, block.Electra)
+	default:
+		return nil, fmt.Errorf("unknown block version")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v block: %v", version.String(), err)
+	}
+
+	return block, nil
+}
+
+// getValidationBlockParts returns the fork specific signed block, block message & signature of a versioned block.
+func getValidationBlockParts(block *spec.VersionedSignedBeaconBlock) (any, any, phase0.BLSSignature, error) {
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		if block.Phase0 != nil && block.Phase0.Message != nil {
+			return block.Phase0, block.Phase0.Message, block.Phase0.Signature, nil
+		}
+	case spec.DataVersionAltair:
+		if block.Altair != nil && block.Altair.Message != nil {
+			return block.Altair, block.Altair.Message, block.Altair.Signature, nil
+		}
+	case spec.DataVersionBellatrix:
+		if block.Bellatrix != nil && block.Bellatrix.Message != nil {
+			return block.Bellatrix, block.Bellatrix.Message, block.Bellatrix.Signature, nil
+		}
+	case spec.DataVersionCapella:
+		if block.Capella != nil && block.Capella.Message != nil {
+			return block.Capella, block.Capella.Message, block.Capella.Signature, nil
+		}
+	case spec.DataVersionDeneb:
+		if block.Deneb != nil && block.Deneb.Message != nil {
+			return block.Deneb, block.Deneb.Message, block.Deneb.Signature, nil
+		}
+	case spec.DataVersionElectra:
+		if block.Electra != nil && block.Electra.Message != nil {
+			return block.Electra, block.Electra.Message, block.Electra.Signature, nil
+		}
+	}
+
+	return nil, nil, phase0.BLSSignature{}, fmt.Errorf("no block message")
+}
+
+// validateObject runs the encoding, signature & state checks for a decoded object.
+func (bs *ChainService) validateObject(result *ObjectValidationResult, object any, sszInput []byte) {
+	var sszObject, rootObject any
+	switch obj := object.(type) {
+	case *spec.VersionedSignedBeaconBlock:
+		signedBlock, message, _, err := getValidationBlockParts(obj)
+		if err != nil {
+			result.Error = err
+			return
+		}
+		sszObject = signedBlock
+		rootObject = message
+		result.RootName = "Block Root"
+	case *spec.VersionedAttestation:
+		if obj.Version == spec.DataVersionElectra {
+			sszObject = obj.Electra
+		} else {
+			sszObject = obj.Phase0
+		}
+		rootObject = sszObject
+		result.RootName = "Attestation Root"
+	case *phase0.SignedVoluntaryExit:
+		sszObject = obj
+		rootObject = obj.Message
+		result.RootName = "Message Root"
+	case *capella.SignedBLSToExecutionChange:
+		sszObject = obj
+		rootObject = obj.Message
+		result.RootName = "Message Root"
+	case *validationDeposit:
+		sszObject = obj.data
+		rootObject = obj.data
+		result.RootName = "Deposit Data Root"
+	}
+
+	if objectJson, err := json.MarshalIndent(sszObject, "", "  "); err == nil {
+		result.Object = string(objectJson)
+	}
+
+	dynSsz := bs.beaconIndexer.GetDynSSZ()
+
+	// encoding checks
+	sszData, err := dynSsz.MarshalSSZ(sszObject)
+	switch {
+	case err != nil:
+		result.addCheck("SSZ Encoding", fmt.Errorf("failed encoding object: %v", err), "")
+	case sszInput != nil && !bytes.Equal(sszData, sszInput):
+		result.addCheck("SSZ Encoding", fmt.Errorf("re-encoded object (%v bytes) differs from input (%v bytes)", len(sszData), len(sszInput)), "")
+	default:
+		result.addCheck("SSZ Encoding", nil, fmt.Sprintf("%v bytes", len(sszData)))
+	}
+
+	root, err := dynSsz.HashTreeRoot(rootObject)
+	if err != nil {
+		result.addCheck("Hash Tree Root", fmt.Errorf("failed hashing object: %v", err), "")
+	} else {
+		result.Root = root
+	}
+
+	switch obj := object.(type) {
+	case *spec.VersionedSignedBeaconBlock:
+		bs.validateBlockObject(result, obj, root)
+	case *spec.VersionedAttestation:
+		bs.validateAttestationObject(result, obj)
+	case *phase0.SignedVoluntaryExit:
+		bs.validateVoluntaryExitObject(result, obj)
+	case *capella.SignedBLSToExecutionChange:
+		bs.validateBLSChangeObject(result, obj)
+	case *validationDeposit:
+		bs.validateDepositObject(result, obj)
+	}
+}
+
+func (bs *ChainService) validateBlockObject(result *ObjectValidationResult, block *spec.VersionedSignedBeaconBlock, blockRoot phase0.Root) {
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	genesis := chainState.GetGenesis()
+
+	slot, _ := block.Slot()
+	epoch := chainState.EpochOfSlot(slot)
+	proposerIndex, _ := block.ProposerIndex()
+	parentRoot, _ := block.ParentRoot()
+	stateRoot, _ := block.StateRoot()
+	graffiti, _ := block.Graffiti()
+	attestations, _ := block.Attestations()
+	deposits, _ := block.Deposits()
+	voluntaryExits, _ := block.VoluntaryExits()
+
+	result.addField("Slot", fmt.Sprintf("%v (epoch %v)", slot, epoch))
+	result.addField("Proposer", fmt.Sprintf("%v (%v)", proposerIndex, bs.GetValidatorName(uint64(proposerIndex))))
+	result.addField("Parent Root", parentRoot.String())
+	result.addField("State Root", stateRoot.String())
+	result.addField("Graffiti", string(bytes.TrimRight(graffiti[:], "\x00")))
+	result.addField("Attestations", fmt.Sprintf("%v", len(attestations)))
+	result.addField("Deposits", fmt.Sprintf("%v", len(deposits)))
+	result.addField("Voluntary Exits", fmt.Sprintf("%v", len(voluntaryExits)))
+	if blockNumber, err := block.ExecutionBlockNumber(); err == nil {
+		blockHash, _ := block.ExecutionBlockHash()
+		result.addField("Execution Block", fmt.Sprintf("%v (%v)", blockNumber, blockHash.String()))
+	}
+
+	if specs == nil || genesis == nil {
+		result.addSkippedCheck("Proposer Signature", "chain state not ready")
+		return
+	}
+
+	// proposer duty
+	epochStats := bs.beaconIndexer.GetEpochStats(epoch, nil)
+	epochStatsValues := epochStats.GetOrLoadValues(bs.beaconIndexer, true, false)
+	slotIndex := chainState.SlotToSlotIndex(slot)
+	if epochStatsValues == nil || uint64(slotIndex) >= uint64(len(epochStatsValues.ProposerDuties)) {
+		result.addSkippedCheck("Proposer Duty", fmt.Sprintf("no proposer duties available for epoch %v", epoch))
+	} else if dutyProposer := epochStatsValues.ProposerDuties[slotIndex]; dutyProposer != proposerIndex {
+		result.addCheck("Proposer Duty", fmt.Errorf("proposer %v is not the scheduled proposer %v", proposerIndex, dutyProposer), "")
+	} else {
+		result.addCheck("Proposer Duty", nil, fmt.Sprintf("validator %v is the scheduled proposer", proposerIndex))
+	}
+
+	// proposer & randao signatures
+	validator := bs.GetValidatorByIndex(proposerIndex, false)
+	if validator == nil || validator.Validator == nil {
+		result.addCheck("Proposer Signature", fmt.Errorf("proposer %v not found", proposerIndex), "")
+		return
+	}
+
+	_, _, signature, _ := getValidationBlockParts(block)
+	forkVersion := chainState.GetForkVersionAtEpoch(epoch)
+	genesisRoot := zrnt_common.Root(genesis.GenesisValidatorsRoot)
+
+	domain := zrnt_common.ComputeDomain(zrnt_common.DOMAIN_BEACON_PROPOSER, zrnt_common.Version(forkVersion), genesisRoot)
+	if verifyBroadcastSignature(blockRoot, domain, validator.Validator.PublicKey, signature) {
+		result.addCheck("Proposer Signature", nil, "")
+	} else {
+		result.addCheck("Proposer Signature", fmt.Errorf("invalid signature"), "")
+	}
+
+	randaoReveal, _ := block.RandaoReveal()
+	epochRoot := [32]byte{}
+	binary.LittleEndian.PutUint64(epochRoot[:], uint64(epoch))
+	domain = zrnt_common.ComputeDomain(zrnt_common.DOMAIN_RANDAO, zrnt_common.Version(forkVersion), genesisRoot)
+	if verifyBroadcastSignature(epochRoot, domain, validator.Validator.PublicKey, randaoReveal) {
+		result.addCheck("RANDAO Reveal", nil, "")
+	} else {
+		result.addCheck("RANDAO Reveal", fmt.Errorf("invalid randao reveal"), "")
+	}
+}
+
+func (bs *ChainService) validateAttestationObject(result *ObjectValidationResult, attestation *spec.VersionedAttestation) {
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	genesis := chainState.GetGenesis()
+
+	attData, err := attestation.Data()
+	if err != nil || attData == nil || attData.Source == nil || attData.Target == nil {
+		result.Error = fmt.Errorf("missing attestation data")
+		return
+	}
+	aggregationBits, _ := attestation.AggregationBits()
+	signature, _ := attestation.Signature()
+
+	committees := []uint64{}
+	if attestation.Version == spec.DataVersionElectra {
+		committeeBits, _ := attestation.CommitteeBits()
+		for _, committee := range committeeBits.BitIndices() {
+			committees = append(committees, uint64(committee))
+		}
+	} else {
+		committees = append(committees, uint64(attData.Index))
+	}
+
+	epoch := chainState.EpochOfSlot(attData.Slot)
+	result.addField("Slot", fmt.Sprintf("%v (epoch %v)", attData.Slot, epoch))
+	result.addField("Committees", fmt.Sprintf("%v", committees))
+	result.addField("Beacon Block Root", attData.BeaconBlockRoot.String())
+	result.addField("Source", fmt.Sprintf("epoch %v (%v)", attData.Source.Epoch, attData.Source.Root.String()))
+	result.addField("Target", fmt.Sprintf("epoch %v (%v)", attData.Target.Epoch, attData.Target.Root.String()))
+	result.addField("Aggregation Bits", fmt.Sprintf("%v of %v bits set", aggregationBits.Count(), aggregationBits.Len()))
+
+	if attData.Target.Epoch != epoch {
+		result.addCheck("Target Epoch", fmt.Errorf("target epoch %v does not match slot epoch %v", attData.Target.Epoch, epoch), "")
+	} else {
+		result.addCheck("Target Epoch", nil, "")
+	}
+
+	if specs == nil || genesis == nil {
+		result.addSkippedCheck("Committee", "chain state not ready")
+		return
+	}
+
+	if attestation.Version == spec.DataVersionElectra && attData.Index != 0 {
+		result.addCheck("Committee Index", fmt.Errorf("attestation data index must be 0 since electra (EIP-7549)"), "")
+	}
+
+	// resolve attesting validators from the committee duties
+	epochStats := bs.beaconIndexer.GetEpochStats(epoch, nil)
+	epochStatsValues := epochStats.GetOrLoadValues(bs.beaconIndexer, true, false)
+	slotIndex := chainState.SlotToSlotIndex(attData.Slot)
+	if epochStatsValues == nil || uint64(slotIndex) >= uint64(len(epochStatsValues.AttesterDuties)) {
+		result.addSkippedCheck("Committee", fmt.Sprintf("no attester duties available for epoch %v", epoch))
+		result.addSkippedCheck("Aggregate Signature", "committee unknown")
+		return
+	}
+
+	attestingIndices := []phase0.ValidatorIndex{}
+	committeeSize := uint64(0)
+	for _, committee := range committees {
+		if committee >= specs.MaxCommitteesPerSlot || committee >= uint64(len(epochStatsValues.AttesterDuties[slotIndex])) {
+			result.addCheck("Committee", fmt.Errorf("committee %v does not exist in slot %v", committee, attData.Slot), "")
+			return
+		}
+
+		duties := epochStatsValues.AttesterDuties[slotIndex][committee]
+		for bitIdx, validatorIndice := range duties {
+			if aggregationBits.BitAt(uint64(bitIdx) + committeeSize) {
+				attestingIndices = append(attestingIndices, epochStatsValues.ActiveIndices[validatorIndice])
+			}
+		}
+		committeeSize += uint64(len(duties))
+	}
+
+	if aggregationBits.Len() != committeeSize {
+		result.addCheck("Committee", fmt.Errorf("aggregation bits length %v does not match committee size %v", aggregationBits.Len(), committeeSize), "")
+		return
+	}
+	result.addCheck("Committee", nil, fmt.Sprintf("%v of %v validators attested", len(attestingIndices), committeeSize))
+
+	if len(attestingIndices) == 0 {
+		result.addCheck("Aggregate Signature", fmt.Errorf("no attesting validators"), "")
+		return
+	}
+
+	validators := bs.beaconIndexer.GetValidatorsByIndices(attestingIndices, nil)
+	pubkeys := make([]*blsu.Pubkey, 0, len(attestingIndices))
+	for _, index := range attestingIndices {
+		validator := validators[index]
+		if validator == nil {
+			result.addCheck("Aggregate Signature", fmt.Errorf("validator %v not found", index), "")
+			return
+		}
+
+		pubkeyData := zrnt_common.BLSPubkey(validator.PublicKey)
+		pubkey, err := pubkeyData.Pubkey()
+		if err != nil {
+			result.addCheck("Aggregate Signature", fmt.Errorf("invalid pubkey of validator %v", index), "")
+			return
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	dataRoot, err := attData.HashTreeRoot()
+	if err != nil {
+		result.addCheck("Aggregate Signature", fmt.Errorf("failed hashing attestation data: %v", err), "")
+		return
+	}
+
+	forkVersion := chainState.GetForkVersionAtEpoch(attData.Target.Epoch)
+	domain := zrnt_common.ComputeDomain(zrnt_common.DOMAIN_BEACON_ATTESTER, zrnt_common.Version(forkVersion), zrnt_common.Root(genesis.GenesisValidatorsRoot))
+	signingRoot := zrnt_common.ComputeSigningRoot(zrnt_common.Root(dataRoot), domain)
+
+	signatureData := zrnt_common.BLSSignature(signature)
+	blsSignature, err := signatureData.Signature()
+	if err != nil || !blsu.FastAggregateVerify(pubkeys, signingRoot[:], blsSignature) {
+		result.addCheck("Aggregate Signature", fmt.Errorf("invalid signature"), "")
+	} else {
+		result.addCheck("Aggregate Signature", nil, fmt.Sprintf("verified with %v pubkeys", len(pubkeys)))
+	}
+}
+
+func (bs *ChainService) validateVoluntaryExitObject(result *ObjectValidationResult, exit *phase0.SignedVoluntaryExit) {
+	if exit.Message == nil {
+		result.Error = fmt.Errorf("missing voluntary exit message")
+		return
+	}
+
+	result.addField("Validator", fmt.Sprintf("%v (%v)", exit.Message.ValidatorIndex, bs.GetValidatorName(uint64(exit.Message.ValidatorIndex))))
+	result.addField("Epoch", fmt.Sprintf("%v", exit.Message.Epoch))
+
+	validator := bs.GetValidatorByIndex(exit.Message.ValidatorIndex, false)
+	if validator == nil || validator.Validator == nil {
+		result.addCheck("Signature", fmt.Errorf("validator %v not found", exit.Message.ValidatorIndex), "")
+		return
+	}
+
+	result.addCheck("Signature", bs.verifyVoluntaryExitSignature(exit, validator.Validator.PublicKey), "")
+	result.addCheck("Processable", bs.validateVoluntaryExit(exit), "exit can be included in the next block")
+}
+
+func (bs *ChainService) validateBLSChangeObject(result *ObjectValidationResult, blsChange *capella.SignedBLSToExecutionChange) {
+	if blsChange.Message == nil {
+		result.Error = fmt.Errorf("missing bls to execution change message")
+		return
+	}
+
+	result.addField("Validator", fmt.Sprintf("%v (%v)", blsChange.Message.ValidatorIndex, bs.GetValidatorName(uint64(blsChange.Message.ValidatorIndex))))
+	result.addField("From BLS Pubkey", blsChange.Message.FromBLSPubkey.String())
+	result.addField("To Execution Address", blsChange.Message.ToExecutionAddress.String())
+
+	result.addCheck("Signature", bs.verifyBLSChangeSignature(blsChange), "")
+	result.addCheck("Processable", bs.validateBLSChange(blsChange), "change can be included in the next block")
+}
+
+func (bs *ChainService) validateDepositObject(result *ObjectValidationResult, deposit *validationDeposit) {
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	depositData := deposit.data
+	result.addField("Pubkey", depositData.PublicKey.String())
+	result.addField("Withdrawal Credentials", fmt.Sprintf("0x%x", depositData.WithdrawalCredentials))
+	result.addField("Amount", fmt.Sprintf("%v Gwei", depositData.Amount))
+	if validatorIndex, found := bs.GetValidatorIndexByPubkey(depositData.PublicKey); found {
+		result.addField("Validator", fmt.Sprintf("%v (%v, top-up deposit)", validatorIndex, bs.GetValidatorName(uint64(validatorIndex))))
+	} else {
+		result.addField("Validator", "new validator")
+	}
+
+	depositMessage := &phase0.DepositMessage{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+	}
+	msgRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		result.addCheck("Signature", fmt.Errorf("failed hashing deposit message: %v", err), "")
+		return
+	}
+
+	// metadata provided by the staking-deposit-cli
+	if deposit.depositMessageRoot != "" {
+		if strings.TrimPrefix(deposit.depositMessageRoot, "0x") != hex.EncodeToString(msgRoot[:]) {
+			result.addCheck("Deposit Message Root", fmt.Errorf("deposit_message_root does not match (expected 0x%x)", msgRoot[:]), "")
+		} else {
+			result.addCheck("Deposit Message Root", nil, "")
+		}
+	}
+	if deposit.depositDataRoot != "" {
+		if strings.TrimPrefix(deposit.depositDataRoot, "0x") != hex.EncodeToString(result.Root[:]) {
+			result.addCheck("Deposit Data Root", fmt.Errorf("deposit_data_root does not match (expected %v)", result.Root.String()), "")
+		} else {
+			result.addCheck("Deposit Data Root", nil, "")
+		}
+	}
+
+	if specs == nil {
+		result.addSkippedCheck("Signature", "chain state not ready")
+		return
+	}
+
+	if deposit.forkVersion != "" {
+		if strings.TrimPrefix(deposit.forkVersion, "0x") != hex.EncodeToString(specs.GenesisForkVersion[:]) {
+			result.addCheck("Fork Version", fmt.Errorf("fork_version does not match the genesis fork version (0x%x)", specs.GenesisForkVersion[:]), "")
+		} else {
+			result.addCheck("Fork Version", nil, "")
+		}
+	}
+
+	// deposits are signed with the genesis fork version & an empty genesis validators root
+	domain := zrnt_common.ComputeDomain(zrnt_common.DOMAIN_DEPOSIT, zrnt_common.Version(specs.GenesisForkVersion), zrnt_common.Root{})
+	if verifyBroadcastSignature(msgRoot, domain, depositData.PublicKey, depositData.Signature) {
+		result.addCheck("Signature", nil, "")
+	} else {
+		result.addCheck("Signature", fmt.Errorf("invalid signature (only accepted as top-up deposit for existing validators)"), "")
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// proposerSlotsCacheSize is the max number of finalized proposer pages kept in the proposer slots cache
+const proposerSlotsCacheSize = 2000
+
+// proposerSlotsCacheKey identifies a page of finalized slots of a proposer.
+// the finalized slot is part of the key, so cached pages are invalidated on finalization.
+type proposerSlotsCacheKey struct {
+	proposer      uint64
+	finalizedSlot uint64
+	withOrphaned  uint8
+	withMissing   uint8
+	cursorKey     uint64
+	cursorSkip    uint64
+	offset        uint64
+	limit         uint32
+}
+
+// isProposerOnlyFilter returns true if the filter only selects slots by proposer index (and status)
+func isProposerOnlyFilter(filter *dbtypes.BlockFilter) bool {
+	return filter.ProposerIndex != nil &&
+		filter.Graffiti == "" &&
+		filter.ExtraData == "" &&
+		filter.ProposerName == "" &&
+		len(filter.FeeRecipient) == 0 &&
+		filter.ClClient == 0 &&
+		filter.ElClient == 0 &&
+		filter.BuilderType == 0 &&
+		filter.Tag == ""
+}
+
+// getFilteredDbSlots loads a page of finalized slots from the db.
+// pages of proposer lookups are cached until the next finalization, as the validator pages & proposals api request the same pages repeatedly.
+func (bs *ChainService) getFilteredDbSlots(filter *dbtypes.BlockFilter, finalizedSlot uint64, offset uint64, limit uint32) []*dbtypes.AssignedSlot {
+	if !isProposerOnlyFilter(filter) {
+		return db.GetFilteredSlots(filter, finalizedSlot, offset, limit)
+	}
+
+	cacheKey := proposerSlotsCacheKey{
+		proposer:      *filter.ProposerIndex,
+		finalizedSlot: finalizedSlot,
+		withOrphaned:  filter.WithOrphaned,
+		withMissing:   filter.WithMissing,
+		offset:        offset,
+		limit:         limit,
+	}
+	if filter.Cursor != nil {
+		cacheKey.cursorKey = filter.Cursor.Key
+		cacheKey.cursorSkip = filter.Cursor.Skip
+	}
+
+	if cachedSlots, found := bs.proposerSlotsCache.Get(cacheKey); found {
+		return cachedSlots
+	}
+
+	slots := db.GetFilteredSlots(filter, finalizedSlot, offset, limit)
+	if slots != nil {
+		bs.proposerSlotsCache.Add(cacheKey, slots)
+	}
+
+	return slots
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+// queueFinalizationDelay is the number of epochs it usually takes until an epoch gets finalized.
+// validators are only activated after their activation eligibility epoch has been finalized.
+const queueFinalizationDelay = 2
+
+// ValidatorQueueType is the queue a validator is waiting in.
+type ValidatorQueueType uint8
+
+const (
+	ValidatorQueueNone ValidatorQueueType = iota
+	ValidatorQueueDeposit
+	ValidatorQueueActivation
+	ValidatorQueueExit
+	ValidatorQueueActive
+	ValidatorQueueExited
+)
+
+var validatorQueueTypeNames = map[ValidatorQueueType]string{
+	ValidatorQueueNone:       "none",
+	ValidatorQueueDeposit:    "deposit",
+	ValidatorQueueActivation: "activation",
+	ValidatorQueueExit:       "exit",
+	ValidatorQueueActive:     "active",
+	ValidatorQueueExited:     "exited",
+}
+
+func (t ValidatorQueueType) String() string {
+	return validatorQueueTypeNames[t]
+}
+
+// ValidatorQueueStats holds the current activation & exit queues of the network.
+// before electra the queues are limited by a validator count churn, with electra deposits & exits are limited by a balance churn.
+type ValidatorQueueStats struct {
+	Epoch                   phase0.Epoch
+	IsElectra               bool
+	ActiveValidators        uint64
+	TotalActiveBalance      phase0.Gwei
+	ActivationChurn         uint64      // validators activated per epoch (pre-electra)
+	ExitChurn               uint64      // validators exited per epoch (pre-electra)
+	BalanceChurn            phase0.Gwei // deposit & exit balance churn per epoch (electra)
+	ActivationQueueLength   uint64      // validators that are eligible for activation, but not active yet
+	ExitQueueLength         uint64      // validators with an upcoming exit epoch
+	ExitQueueBalance        phase0.Gwei
+	HasStateQueues          bool // the electra queue state is available from the beacon state
+	PendingDeposits         uint64
+	PendingDepositBalance   phase0.Gwei
+	DepositBalanceToConsume phase0.Gwei
+	ExitBalanceToConsume    phase0.Gwei
+	EarliestExitEpoch       phase0.Epoch
+	NewDepositEpoch         phase0.Epoch // estimated epoch a new deposit gets credited (electra)
+	NewActivationEpoch      phase0.Epoch // estimated activation epoch of a new validator
+	NewExitEpoch            phase0.Epoch // estimated exit epoch of a 32 ETH validator exiting now
+}
+
+// ValidatorQueueEstimate holds the queue position & estimated activation or exit epoch of a validator.
+type ValidatorQueueEstimate struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Queue          ValidatorQueueType
+	Position       uint64       // validators ahead in the activation queue (pre-electra)
+	BalanceAhead   phase0.Gwei  // pending deposit balance ahead in the deposit queue (electra)
+	EstimatedEpoch phase0.Epoch // activation epoch for queued deposits & activations, exit epoch for exits (active validators: exit epoch of an exit initiated now)
+	Scheduled      bool         // the epoch is already set in the beacon state
+}
+
+type validatorQueueState struct {
+	stats              *ValidatorQueueStats
+	pendingDeposits    []beacon.EpochStatsPendingDeposit
+	activationQueue    []validatorQueueEntry
+	lastExitEpoch      phase0.Epoch
+	lastExitEpochCount uint64
+}
+
+type validatorQueueEntry struct {
+	index            phase0.ValidatorIndex
+	eligibilityEpoch phase0.Epoch
+}
+
+// GetValidatorQueueStats returns the current activation & exit queue lengths, the churn limits and the estimated waiting times for new deposits & exits.
+func (bs *ChainService) GetValidatorQueueStats() *ValidatorQueueStats {
+	queueState := bs.getValidatorQueueState()
+	if queueState == nil {
+		return nil
+	}
+
+	return queueState.stats
+}
+
+// GetValidatorQueueEstimate returns the queue position & estimated activation or exit epoch of a validator.
+func (bs *ChainService) GetValidatorQueueEstimate(validatorIndex phase0.ValidatorIndex) (*ValidatorQueueEstimate, *ValidatorQueueStats) {
+	validator := bs.GetValidatorByIndex(validatorIndex, false)
+	if validator == nil || validator.Validator == nil {
+		return nil, nil
+	}
+
+	queueState := bs.getValidatorQueueState()
+	if queueState == nil {
+		return nil, nil
+	}
+
+	chainState := bs.consensusPool.GetChainState()
+	stats := queueState.stats
+	estimate := &ValidatorQueueEstimate{
+		ValidatorIndex: validatorIndex,
+		EstimatedEpoch: beacon.FarFutureEpoch,
+	}
+
+	validatorData := validator.Validator
+	switch {
+	case validatorData.ExitEpoch != beacon.FarFutureEpoch && validatorData.ExitEpoch <= stats.Epoch:
+		estimate.Queue = ValidatorQueueExited
+		estimate.EstimatedEpoch = validatorData.ExitEpoch
+		estimate.Scheduled = true
+
+	case validatorData.ExitEpoch != beacon.FarFutureEpoch:
+		estimate.Queue = ValidatorQueueExit
+		estimate.EstimatedEpoch = validatorData.ExitEpoch
+		estimate.Scheduled = true
+
+	case validatorData.ActivationEpoch != beacon.FarFutureEpoch && validatorData.ActivationEpoch > stats.Epoch:
+		estimate.Queue = ValidatorQueueActivation
+		estimate.EstimatedEpoch = validatorData.ActivationEpoch
+		estimate.Scheduled = true
+
+	case validatorData.ActivationEpoch != beacon.FarFutureEpoch:
+		estimate.Queue = ValidatorQueueActive
+		if stats.IsElectra {
+			estimate.EstimatedEpoch = queueState.estimateExitEpoch(bs, validatorData.EffectiveBalance)
+		} else {
+			estimate.EstimatedEpoch = stats.NewExitEpoch
+		}
+
+	case validatorData.ActivationEligibilityEpoch != beacon.FarFutureEpoch:
+		estimate.Queue = ValidatorQueueActivation
+		activationStartEpoch := validatorData.ActivationEligibilityEpoch + queueFinalizationDelay
+		if !stats.IsElectra && stats.ActivationChurn > 0 {
+			// pre-electra activations are limited by the churn & processed in order of eligibility
+			estimate.Position = uint64(sort.Search(len(queueState.activationQueue), func(i int) bool {
+				entry := queueState.activationQueue[i]
+				return entry.eligibilityEpoch > validatorData.ActivationEligibilityEpoch || (entry.eligibilityEpoch == validatorData.ActivationEligibilityEpoch && entry.index >= validatorIndex)
+			}))
+
+			if churnEpoch := stats.Epoch + phase0.Epoch(estimate.Position/stats.ActivationChurn); churnEpoch > activationStartEpoch {
+				activationStartEpoch = churnEpoch
+			}
+		}
+		if activationStartEpoch < stats.Epoch {
+			activationStartEpoch = stats.Epoch
+		}
+		estimate.EstimatedEpoch = chainState.ComputeActivationExitEpoch(activationStartEpoch)
+
+	case stats.IsElectra:
+		// validator is waiting for its pending deposits to get processed
+		balanceAhead := phase0.Gwei(0)
+		for _, pendingDeposit := range queueState.pendingDeposits {
+			if pendingDeposit.ValidatorIndex == validatorIndex {
+				estimate.Queue = ValidatorQueueDeposit
+				estimate.BalanceAhead = balanceAhead
+				estimate.EstimatedEpoch = queueState.estimateDepositActivationEpoch(bs, balanceAhead)
+				break
+			}
+
+			balanceAhead += pendingDeposit.Amount
+		}
+	}
+
+	return estimate, stats
+}
+
+// getValidatorQueueState collects the queue state from the latest epoch stats & the current validator set.
+func (bs *ChainService) getValidatorQueueState() *validatorQueueState {
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	if specs == nil {
+		return nil
+	}
+
+	currentEpoch := chainState.CurrentEpoch()
+	canonicalHead := bs.beaconIndexer.GetCanonicalHead(nil)
+	if canonicalHead == nil {
+		return nil
+	}
+
+	queueState := &validatorQueueState{
+		stats: &ValidatorQueueStats{
+			Epoch:     currentEpoch,
+			IsElectra: specs.ElectraForkEpoch != nil && uint64(currentEpoch) >= *specs.ElectraForkEpoch,
+		},
+	}
+	stats := queueState.stats
+
+	var epochStatsValues *beacon.EpochStatsValues
+	epochStatsEpoch := currentEpoch
+	for epochStatsEpoch+3 > currentEpoch {
+		epochStats := bs.beaconIndexer.GetEpochStats(epochStatsEpoch, nil)
+		if epochStats != nil {
+			epochStatsValues = epochStats.GetValues(false)
+			if epochStatsValues != nil {
+				break
+			}
+		}
+		if epochStatsEpoch == 0 {
+			break
+		}
+		epochStatsEpoch--
+	}
+
+	if epochStatsValues != nil {
+		stats.ActiveValidators = epochStatsValues.ActiveValidators
+		stats.TotalActiveBalance = epochStatsValues.EffectiveBalance
+
+		if stats.IsElectra && epochStatsValues.EarliestExitEpoch > 0 {
+			stats.HasStateQueues = true
+			stats.PendingDeposits = uint64(len(epochStatsValues.PendingDeposits))
+			stats.DepositBalanceToConsume = epochStatsValues.DepositBalanceToConsume
+			stats.ExitBalanceToConsume = epochStatsValues.ExitBalanceToConsume
+			stats.EarliestExitEpoch = epochStatsValues.EarliestExitEpoch
+			queueState.pendingDeposits = epochStatsValues.PendingDeposits
+
+			for _, pendingDeposit := range epochStatsValues.PendingDeposits {
+				stats.PendingDepositBalance += pendingDeposit.Amount
+			}
+		}
+	}
+
+	bs.beaconIndexer.StreamActiveValidatorDataForRoot(canonicalHead.Root, false, &currentEpoch, func(index phase0.ValidatorIndex, flags uint16, activeData *beacon.ValidatorData, validator *phase0.Validator) error {
+		if activeData == nil {
+			return nil
+		}
+
+		if activeData.ActivationEligibilityEpoch != beacon.FarFutureEpoch && (activeData.ActivationEpoch == beacon.FarFutureEpoch || activeData.ActivationEpoch > currentEpoch) {
+			stats.ActivationQueueLength++
+			if activeData.ActivationEpoch == beacon.FarFutureEpoch {
+				queueState.activationQueue = append(queueState.activationQueue, validatorQueueEntry{
+					index:            index,
+					eligibilityEpoch: activeData.ActivationEligibilityEpoch,
+				})
+			}
+		}
+
+		if activeData.ExitEpoch != beacon.FarFutureEpoch && activeData.ExitEpoch > currentEpoch {
+			stats.ExitQueueLength++
+			stats.ExitQueueBalance += phase0.Gwei(activeData.EffectiveBalanceEth) * beacon.EtherGweiFactor
+
+			if activeData.ExitEpoch > queueState.lastExitEpoch {
+				queueState.lastExitEpoch = activeData.ExitEpoch
+				queueState.lastExitEpochCount = 0
+			}
+			if activeData.ExitEpoch == queueState.lastExitEpoch {
+				queueState.lastExitEpochCount++
+			}
+		}
+
+		return nil
+	})
+
+	sort.Slice(queueState.activationQueue, func(a, b int) bool {
+		entryA := queueState.activationQueue[a]
+		entryB := queueState.activationQueue[b]
+		if entryA.eligibilityEpoch != entryB.eligibilityEpoch {
+			return entryA.eligibilityEpoch < entryB.eligibilityEpoch
+		}
+		return entryA.index < entryB.index
+	})
+
+	if stats.IsElectra {
+		stats.BalanceChurn = chainState.GetActivationExitChurnLimit(stats.TotalActiveBalance)
+		stats.NewDepositEpoch = queueState.estimateDepositEpoch(stats.PendingDepositBalance)
+		stats.NewActivationEpoch = queueState.estimateDepositActivationEpoch(bs, stats.PendingDepositBalance)
+		stats.NewExitEpoch = queueState.estimateExitEpoch(bs, phase0.Gwei(specs.MinActivationBalance))
+	} else {
+		stats.ActivationChurn = chainState.GetActivationChurnLimit(stats.ActiveValidators, currentEpoch)
+		stats.ExitChurn = chainState.GetValidatorChurnLimit(stats.ActiveValidators)
+
+		activationStartEpoch := currentEpoch + 1 + queueFinalizationDelay
+		if stats.ActivationChurn > 0 {
+			if churnEpoch := currentEpoch + phase0.Epoch(uint64(len(queueState.activationQueue))/stats.ActivationChurn); churnEpoch > activationStartEpoch {
+				activationStartEpoch = churnEpoch
+			}
+		}
+		stats.NewActivationEpoch = chainState.ComputeActivationExitEpoch(activationStartEpoch)
+
+		// mirrors the pre-electra exit queue: exits are assigned to the latest exit epoch until the churn limit is reached
+		exitEpoch := chainState.ComputeActivationExitEpoch(currentEpoch)
+		exitEpochCount := uint64(0)
+		if queueState.lastExitEpoch >= exitEpoch {
+			exitEpoch = queueState.lastExitEpoch
+			exitEpochCount = queueState.lastExitEpochCount
+		}
+		if stats.ExitChurn > 0 && exitEpochCount >= stats.ExitChurn {
+			exitEpoch++
+		}
+		stats.NewExitEpoch = exitEpoch
+	}
+
+	return queueState
+}
+
+// estimateDepositEpoch returns the estimated epoch a deposit gets credited with the given pending deposit balance ahead (electra).
+func (queueState *validatorQueueState) estimateDepositEpoch(balanceAhead phase0.Gwei) phase0.Epoch {
+	stats := queueState.stats
+	queueEpochs := phase0.Epoch(0)
+	if stats.BalanceChurn > 0 && balanceAhead > stats.DepositBalanceToConsume {
+		queueEpochs = phase0.Epoch((balanceAhead - stats.DepositBalanceToConsume) / stats.BalanceChurn)
+	}
+
+	return stats.Epoch + queueEpochs + 1
+}
+
+// estimateDepositActivationEpoch returns the estimated activation epoch of a validator deposit with the given pending deposit balance ahead (electra).
+// the validator becomes eligible in the epoch after the deposit got credited and gets activated once that epoch is finalized.
+func (queueState *validatorQueueState) estimateDepositActivationEpoch(bs *ChainService, balanceAhead phase0.Gwei) phase0.Epoch {
+	depositEpoch := queueState.estimateDepositEpoch(balanceAhead)
+	return bs.consensusPool.GetChainState().ComputeActivationExitEpoch(depositEpoch + 1 + queueFinalizationDelay)
+}
+
+// estimateExitEpoch returns the estimated exit epoch of a validator with the given effective balance exiting now (electra).
+// mirrors compute_exit_epoch_and_update_churn from the consensus specs.
+func (queueState *validatorQueueState) estimateExitEpoch(bs *ChainService, exitBalance phase0.Gwei) phase0.Epoch {
+	stats := queueState.stats
+	exitEpoch := bs.consensusPool.GetChainState().ComputeActivationExitEpoch(stats.Epoch)
+	balanceToConsume := stats.BalanceChurn
+	if stats.EarliestExitEpoch >= exitEpoch {
+		exitEpoch = stats.EarliestExitEpoch
+		balanceToConsume = stats.ExitBalanceToConsume
+	}
+
+	if exitBalance > balanceToConsume && stats.BalanceChurn > 0 {
+		balanceToProcess := exitBalance - balanceToConsume
+		exitEpoch += phase0.Epoch((balanceToProcess-1)/stats.BalanceChurn + 1)
+	}
+
+	return exitEpoch
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	execindexer "github.com/ethpandaops/dora/indexer/execution"
+)
+
+// RequestFees holds the current fee & the fee history of an eip-7002 / eip-7251 system contract
+type RequestFees struct {
+	ContractAddress common.Address
+	CurrentFee      *big.Int // nil if the current fee could not be loaded
+	History         []*dbtypes.RequestFeeStats
+}
+
+// GetWithdrawalRequestFees returns the current fee & the fee history (since minTime, in buckets of bucketSize seconds) of the withdrawal request contract.
+func (bs *ChainService) GetWithdrawalRequestFees(minTime uint64, bucketSize uint64) *RequestFees {
+	return bs.getRequestFees(common.HexToAddress(execindexer.WithdrawalContractAddr), db.GetWithdrawalRequestFeeStats, minTime, bucketSize)
+}
+
+// GetConsolidationRequestFees returns the current fee & the fee history (since minTime, in buckets of bucketSize seconds) of the consolidation request contract.
+func (bs *ChainService) GetConsolidationRequestFees(minTime uint64, bucketSize uint64) *RequestFees {
+	return bs.getRequestFees(common.HexToAddress(execindexer.ConsolidationContractAddr), db.GetConsolidationRequestFeeStats, minTime, bucketSize)
+}
+
+func (bs *ChainService) getRequestFees(contractAddress common.Address, loadStats func(minTime uint64, bucketSize uint64) ([]*dbtypes.RequestFeeStats, error), minTime uint64, bucketSize uint64) *RequestFees {
+	requestFees := &RequestFees{
+		ContractAddress: contractAddress,
+	}
+
+	currentFee, err := bs.GetCurrentRequestFee(contractAddress)
+	if err != nil {
+		logrus.Debugf("failed loading current request fee of %v: %v", contractAddress.String(), err)
+	} else {
+		requestFees.CurrentFee = currentFee
+	}
+
+	requestFees.History, _ = loadStats(minTime, bucketSize)
+
+	return requestFees
+}
+
+// GetCurrentRequestFee returns the current fee (in wei) for adding a request to an eip-7002 / eip-7251 system contract.
+// the system contracts return the current fee when called without input data.
+func (bs *ChainService) GetCurrentRequestFee(contractAddress common.Address) (*big.Int, error) {
+	client := bs.executionPool.GetReadyEndpoint(execution.AnyClient)
+	if client == nil {
+		return nil, fmt.Errorf("no ready execution client")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := client.GetRPCClient().CallContract(ctx, ethereum.CallMsg{
+		To: &contractAddress,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed calling system contract: %v", err)
+	}
+	if len(result) != 32 {
+		return nil, fmt.Errorf("unexpected fee response length: %v", len(result))
+	}
+
+	return new(big.Int).SetBytes(result), nil
+}
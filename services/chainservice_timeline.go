@@ -0,0 +1,303 @@
+package services
+
+import (
+	"bytes"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+// validatorTimelineSourceLimit is the max number of entries loaded per source table
+const validatorTimelineSourceLimit = 100
+
+// ValidatorTimelineEntryType is the type of a validator lifecycle event.
+// the order of the constants is used to order events that happened in the same slot.
+type ValidatorTimelineEntryType uint8
+
+const (
+	ValidatorTimelineGenesis ValidatorTimelineEntryType = iota + 1
+	ValidatorTimelineDepositTx
+	ValidatorTimelineDeposit
+	ValidatorTimelineEligible
+	ValidatorTimelineActivation
+	ValidatorTimelineCredentialChange
+	ValidatorTimelineWithdrawalRequest
+	ValidatorTimelineConsolidationRequest
+	ValidatorTimelineSlashing
+	ValidatorTimelineVoluntaryExit
+	ValidatorTimelineExit
+	ValidatorTimelineWithdrawable
+)
+
+var validatorTimelineTypeNames = map[ValidatorTimelineEntryType]string{
+	ValidatorTimelineGenesis:              "genesis",
+	ValidatorTimelineDepositTx:            "deposit_tx",
+	ValidatorTimelineDeposit:              "deposit",
+	ValidatorTimelineEligible:             "eligible",
+	ValidatorTimelineActivation:           "activation",
+	ValidatorTimelineCredentialChange:     "credential_change",
+	ValidatorTimelineWithdrawalRequest:    "withdrawal_request",
+	ValidatorTimelineConsolidationRequest: "consolidation_request",
+	ValidatorTimelineSlashing:             "slashing",
+	ValidatorTimelineVoluntaryExit:        "voluntary_exit",
+	ValidatorTimelineExit:                 "exit",
+	ValidatorTimelineWithdrawable:         "withdrawable",
+}
+
+func (t ValidatorTimelineEntryType) String() string {
+	return validatorTimelineTypeNames[t]
+}
+
+// ValidatorTimelineEntry is a single event in the lifecycle of a validator.
+type ValidatorTimelineEntry struct {
+	Type           ValidatorTimelineEntryType
+	Slot           uint64
+	HasSlot        bool // false for events with unknown time (bls credential changes are not indexed)
+	Time           time.Time
+	Pending        bool // scheduled for a future epoch or still queued on the execution layer
+	Amount         *uint64
+	DepositIndex   *uint64
+	TxHash         []byte
+	BlockNumber    uint64
+	Validator      *uint64 // counterpart validator (slasher, consolidation source / target)
+	IsSource       bool    // validator is the source of the consolidation
+	Credentials    []byte
+	Result         uint8
+	SlashingReason dbtypes.SlashingReason
+}
+
+// GetValidatorTimeline returns the lifecycle events of a validator in chronological order.
+// the events are aggregated from the deposit, slashing, exit, withdrawal & consolidation request tables and the validator state.
+// payload withdrawals (balance sweeps) are not indexed and thus not part of the timeline.
+func (bs *ChainService) GetValidatorTimeline(validatorIndex phase0.ValidatorIndex) []*ValidatorTimelineEntry {
+	validator := bs.GetValidatorByIndex(validatorIndex, false)
+	if validator == nil {
+		return nil
+	}
+
+	chainState := bs.consensusPool.GetChainState()
+	currentEpoch := chainState.CurrentEpoch()
+	pubkey := validator.Validator.PublicKey[:]
+	index := uint64(validatorIndex)
+	entries := []*ValidatorTimelineEntry{}
+
+	addSlotEntry := func(entry *ValidatorTimelineEntry, slot uint64) {
+		entry.Slot = slot
+		entry.HasSlot = true
+		entry.Time = chainState.SlotToTime(phase0.Slot(slot))
+		entries = append(entries, entry)
+	}
+	addEpochEntry := func(entryType ValidatorTimelineEntryType, epoch phase0.Epoch) {
+		if epoch == beacon.FarFutureEpoch {
+			return
+		}
+		addSlotEntry(&ValidatorTimelineEntry{
+			Type:    entryType,
+			Pending: epoch > currentEpoch,
+		}, uint64(chainState.EpochToSlot(epoch)))
+	}
+
+	// initial withdrawal credentials, used to detect credential changes
+	var initialCredentials []byte
+
+	if genesisValidator := db.GetGenesisValidator(index); genesisValidator != nil {
+		initialCredentials = genesisValidator.WithdrawalCredentials
+		addSlotEntry(&ValidatorTimelineEntry{
+			Type:        ValidatorTimelineGenesis,
+			Amount:      &genesisValidator.Balance,
+			Credentials: genesisValidator.WithdrawalCredentials,
+		}, 0)
+	}
+
+	// deposit transactions & included deposits
+	depositSyncState := dbtypes.DepositIndexerState{}
+	db.GetExplorerState("indexer.depositstate", &depositSyncState)
+
+	depositTxs, _, _ := db.GetDepositTxsFiltered(0, validatorTimelineSourceLimit, depositSyncState.FinalBlock, &dbtypes.DepositTxFilter{
+		PublicKey: pubkey,
+	})
+	for _, depositTx := range depositTxs {
+		if initialCredentials == nil && depositTx.ValidSignature {
+			initialCredentials = depositTx.WithdrawalCredentials
+		}
+
+		depositIndex := depositTx.Index
+		amount := depositTx.Amount
+		entries = append(entries, &ValidatorTimelineEntry{
+			Type:         ValidatorTimelineDepositTx,
+			Slot:         uint64(chainState.TimeToSlot(time.Unix(int64(depositTx.BlockTime), 0))),
+			HasSlot:      true,
+			Time:         time.Unix(int64(depositTx.BlockTime), 0),
+			Amount:       &amount,
+			DepositIndex: &depositIndex,
+			TxHash:       depositTx.TxHash,
+			BlockNumber:  depositTx.BlockNumber,
+			Credentials:  depositTx.WithdrawalCredentials,
+		})
+	}
+
+	deposits, _ := bs.GetIncludedDepositsByFilter(&dbtypes.DepositFilter{
+		PublicKey: pubkey,
+	}, 0, validatorTimelineSourceLimit)
+	for _, deposit := range deposits {
+		if initialCredentials == nil {
+			initialCredentials = deposit.WithdrawalCredentials
+		}
+
+		amount := deposit.Amount
+		addSlotEntry(&ValidatorTimelineEntry{
+			Type:         ValidatorTimelineDeposit,
+			Amount:       &amount,
+			DepositIndex: deposit.Index,
+			Credentials:  deposit.WithdrawalCredentials,
+		}, deposit.SlotNumber)
+	}
+
+	// lifecycle epochs from the validator state
+	addEpochEntry(ValidatorTimelineEligible, validator.Validator.ActivationEligibilityEpoch)
+	addEpochEntry(ValidatorTimelineActivation, validator.Validator.ActivationEpoch)
+	addEpochEntry(ValidatorTimelineExit, validator.Validator.ExitEpoch)
+	addEpochEntry(ValidatorTimelineWithdrawable, validator.Validator.WithdrawableEpoch)
+
+	// slashings & voluntary exits
+	slashings, _ := bs.GetSlashingsByFilter(&dbtypes.SlashingFilter{
+		MinIndex: index,
+		MaxIndex: index,
+	}, 0, validatorTimelineSourceLimit)
+	for _, slashing := range slashings {
+		if slashing.ValidatorIndex != index {
+			continue
+		}
+
+		slasherIndex := slashing.SlasherIndex
+		addSlotEntry(&ValidatorTimelineEntry{
+			Type:           ValidatorTimelineSlashing,
+			Validator:      &slasherIndex,
+			SlashingReason: slashing.Reason,
+		}, slashing.SlotNumber)
+	}
+
+	voluntaryExits, _ := bs.GetVoluntaryExitsByFilter(&dbtypes.VoluntaryExitFilter{
+		MinIndex: index,
+		MaxIndex: index,
+	}, 0, validatorTimelineSourceLimit)
+	for _, voluntaryExit := range voluntaryExits {
+		if voluntaryExit.ValidatorIndex != index {
+			continue
+		}
+
+		addSlotEntry(&ValidatorTimelineEntry{
+			Type: ValidatorTimelineVoluntaryExit,
+		}, voluntaryExit.SlotNumber)
+	}
+
+	// withdrawal & consolidation requests, pending requests are placed at the block of the request transaction
+	withdrawalRequests, _, _ := bs.GetWithdrawalRequestsByFilter(&CombinedWithdrawalRequestFilter{
+		Filter: &dbtypes.WithdrawalRequestFilter{
+			PublicKey: pubkey,
+		},
+	}, 0, validatorTimelineSourceLimit)
+	for _, withdrawalRequest := range withdrawalRequests {
+		amount := withdrawalRequest.Amount()
+		entry := &ValidatorTimelineEntry{
+			Type:   ValidatorTimelineWithdrawalRequest,
+			Amount: &amount,
+		}
+		if withdrawalRequest.Transaction != nil {
+			entry.TxHash = withdrawalRequest.Transaction.TxHash
+			entry.BlockNumber = withdrawalRequest.Transaction.BlockNumber
+		}
+
+		if request := withdrawalRequest.Request; request != nil {
+			entry.Result = request.Result
+			addSlotEntry(entry, request.SlotNumber)
+		} else if withdrawalRequest.Transaction != nil {
+			entry.Pending = true
+			addSlotEntry(entry, uint64(chainState.TimeToSlot(time.Unix(int64(withdrawalRequest.Transaction.BlockTime), 0))))
+		}
+	}
+
+	consolidationRequests, _, _ := bs.GetConsolidationRequestsByFilter(&CombinedConsolidationRequestFilter{
+		Filter: &dbtypes.ConsolidationRequestFilter{
+			PublicKey: pubkey,
+		},
+	}, 0, validatorTimelineSourceLimit)
+	switchedToCompounding := false
+	for _, consolidationRequest := range consolidationRequests {
+		isSource := bytes.Equal(consolidationRequest.SourcePubkey(), pubkey)
+		isSwitch := isSource && bytes.Equal(consolidationRequest.TargetPubkey(), pubkey)
+
+		entry := &ValidatorTimelineEntry{
+			Type:     ValidatorTimelineConsolidationRequest,
+			IsSource: isSource,
+		}
+		if isSource {
+			entry.Validator = consolidationRequest.TargetIndex()
+		} else {
+			entry.Validator = consolidationRequest.SourceIndex()
+		}
+		if consolidationRequest.Transaction != nil {
+			entry.TxHash = consolidationRequest.Transaction.TxHash
+			entry.BlockNumber = consolidationRequest.Transaction.BlockNumber
+		}
+
+		if request := consolidationRequest.Request; request != nil {
+			entry.Result = request.Result
+			addSlotEntry(entry, request.SlotNumber)
+
+			// a successful self consolidation switches the credentials to compounding (0x02)
+			if isSwitch && request.Result == dbtypes.ConsolidationRequestResultSuccess && !consolidationRequest.RequestOrphaned {
+				switchedToCompounding = true
+				credentials := slices.Clone(validator.Validator.WithdrawalCredentials)
+				credentials[0] = 0x02
+				addSlotEntry(&ValidatorTimelineEntry{
+					Type:        ValidatorTimelineCredentialChange,
+					TxHash:      entry.TxHash,
+					BlockNumber: entry.BlockNumber,
+					Credentials: credentials,
+				}, request.SlotNumber)
+			}
+		} else if consolidationRequest.Transaction != nil {
+			entry.Pending = true
+			addSlotEntry(entry, uint64(chainState.TimeToSlot(time.Unix(int64(consolidationRequest.Transaction.BlockTime), 0))))
+		}
+	}
+
+	// bls to execution changes are not indexed per validator, so the change is derived from the credential prefixes
+	currentCredentials := validator.Validator.WithdrawalCredentials
+	if len(initialCredentials) > 0 && len(currentCredentials) > 0 {
+		if initialCredentials[0] == 0x00 && currentCredentials[0] != 0x00 {
+			credentials := slices.Clone(currentCredentials)
+			credentials[0] = 0x01
+			entries = append(entries, &ValidatorTimelineEntry{
+				Type:        ValidatorTimelineCredentialChange,
+				Credentials: credentials,
+			})
+		}
+		if initialCredentials[0] != 0x02 && currentCredentials[0] == 0x02 && !switchedToCompounding {
+			entries = append(entries, &ValidatorTimelineEntry{
+				Type:        ValidatorTimelineCredentialChange,
+				Credentials: slices.Clone(currentCredentials),
+			})
+		}
+	}
+
+	// order by slot, events with unknown time go last
+	sort.SliceStable(entries, func(a, b int) bool {
+		if entries[a].HasSlot != entries[b].HasSlot {
+			return entries[a].HasSlot
+		}
+		if entries[a].Slot != entries[b].Slot {
+			return entries[a].Slot < entries[b].Slot
+		}
+		return entries[a].Type < entries[b].Type
+	})
+
+	return entries
+}
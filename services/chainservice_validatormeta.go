@@ -0,0 +1,119 @@
+package services
+
+import (
+	"sync"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+// validatorMetadataCacheLimit is the maximum number of validators held in the metadata cache.
+// the cache is reset when the limit is exceeded, which is fine as it gets reset every epoch anyway.
+const validatorMetadataCacheLimit = 500000
+
+// ValidatorMetadata holds the basic metadata of a validator as needed to render committees & attestations.
+type ValidatorMetadata struct {
+	Index  phase0.ValidatorIndex
+	Pubkey phase0.BLSPubKey
+	Entity string
+	Status v1.ValidatorState
+}
+
+// validatorMetadataCache is a compact per-epoch cache of validator pubkeys & states.
+// entity names are not cached as they are resolved from the in-memory validator names on each lookup.
+type validatorMetadataCache struct {
+	mutex   sync.Mutex
+	epoch   phase0.Epoch
+	entries map[phase0.ValidatorIndex]validatorMetadataCacheEntry
+}
+
+type validatorMetadataCacheEntry struct {
+	pubkey phase0.BLSPubKey
+	status v1.ValidatorState
+}
+
+// GetValidatorMetadata returns the pubkey, entity & status of the validators with the given indices.
+// Validators that are not cached yet are resolved with a single validator cache lookup, falling back to a single db query.
+// Unknown validators are not included in the result.
+func (bs *ChainService) GetValidatorMetadata(indices []phase0.ValidatorIndex) map[phase0.ValidatorIndex]*ValidatorMetadata {
+	result := make(map[phase0.ValidatorIndex]*ValidatorMetadata, len(indices))
+	if len(indices) == 0 {
+		return result
+	}
+
+	chainState := bs.consensusPool.GetChainState()
+	currentEpoch := chainState.CurrentEpoch()
+	cache := &bs.validatorMetaCache
+
+	// lookup cached entries, validator states only change on epoch transitions so the cache is reset with every new epoch
+	missingIndices := []phase0.ValidatorIndex{}
+	cache.mutex.Lock()
+	if cache.entries == nil || cache.epoch != currentEpoch {
+		cache.entries = map[phase0.ValidatorIndex]validatorMetadataCacheEntry{}
+		cache.epoch = currentEpoch
+	}
+
+	for _, index := range indices {
+		if _, found := result[index]; found {
+			continue
+		}
+
+		entry, found := cache.entries[index]
+		if !found {
+			missingIndices = append(missingIndices, index)
+			continue
+		}
+
+		result[index] = &ValidatorMetadata{
+			Index:  index,
+			Pubkey: entry.pubkey,
+			Status: entry.status,
+		}
+	}
+	cache.mutex.Unlock()
+
+	// resolve missing entries in a single batch
+	if len(missingIndices) > 0 {
+		validators := bs.beaconIndexer.GetValidatorsByIndices(missingIndices, nil)
+		balances := bs.beaconIndexer.GetRecentValidatorBalances(nil)
+		newEntries := make(map[phase0.ValidatorIndex]validatorMetadataCacheEntry, len(validators))
+
+		for index, validator := range validators {
+			var balancePtr *phase0.Gwei
+			if balances != nil && uint64(index) < uint64(len(balances)) {
+				balance := balances[index]
+				balancePtr = &balance
+			}
+
+			entry := validatorMetadataCacheEntry{
+				pubkey: validator.PublicKey,
+				status: v1.ValidatorToState(validator, balancePtr, currentEpoch, beacon.FarFutureEpoch),
+			}
+			newEntries[index] = entry
+			result[index] = &ValidatorMetadata{
+				Index:  index,
+				Pubkey: entry.pubkey,
+				Status: entry.status,
+			}
+		}
+
+		cache.mutex.Lock()
+		if cache.epoch == currentEpoch {
+			if len(cache.entries)+len(newEntries) > validatorMetadataCacheLimit {
+				cache.entries = map[phase0.ValidatorIndex]validatorMetadataCacheEntry{}
+			}
+			for index, entry := range newEntries {
+				cache.entries[index] = entry
+			}
+		}
+		cache.mutex.Unlock()
+	}
+
+	for index, metadata := range result {
+		metadata.Entity = bs.GetValidatorName(uint64(index))
+	}
+
+	return result
+}
@@ -67,7 +67,10 @@ type ValidatorWithIndex struct {
 	Validator *phase0.Validator
 }
 
-// getValidatorsByWithdrawalAddressForRoot returns validators with a specific withdrawal address for a given blockRoot
+// GetFilteredValidatorSet returns the page of validators matching the filter and the total number of matching validators.
+// filtering, sorting & pagination is done by the database, only the unfinalized validator changes from the cache are merged in memory.
+// balance filters & sorting refer to the actual balance if withBalance is set and to the effective balance otherwise.
+// the db only holds the effective balances, so queries with actual balance filters or sorting load all matching indexes.
 func (bs *ChainService) GetFilteredValidatorSet(filter *dbtypes.ValidatorFilter, withBalance bool) ([]v1.Validator, uint64) {
 	var overrideForkId *beacon.ForkKey
 
@@ -84,61 +87,90 @@ func (bs *ChainService) GetFilteredValidatorSet(filter *dbtypes.ValidatorFilter,
 
 	cachedResults := make([]ValidatorWithIndex, 0, 1000)
 	cachedIndexes := map[uint64]bool{}
+	cachedIndexList := []uint64{}
 
 	// get matching entries from cached validators
+	// all cached validators overwrite the db entries, so their indexes are tracked even if they don't match the filter
 	bs.beaconIndexer.StreamActiveValidatorDataForRoot(canonicalHead.Root, false, &currentEpoch, func(index phase0.ValidatorIndex, flags uint16, activeData *beacon.ValidatorData, validator *phase0.Validator) error {
 		if validator == nil {
 			return nil
 		}
-		if filter.MinIndex != nil && index < phase0.ValidatorIndex(*filter.MinIndex) {
-			return nil
-		}
-		if filter.MaxIndex != nil && index > phase0.ValidatorIndex(*filter.MaxIndex) {
-			return nil
-		}
-		if filter.WithdrawalAddress != nil {
-			if validator.WithdrawalCredentials[0] != 0x01 && validator.WithdrawalCredentials[0] != 0x02 {
-				return nil
-			}
-			if !bytes.Equal(validator.WithdrawalCredentials[12:], filter.WithdrawalAddress[:]) {
-				return nil
-			}
-		}
-		if filter.ValidatorName != "" {
-			vname := bs.validatorNames.GetValidatorName(uint64(index))
-			if !strings.Contains(vname, filter.ValidatorName) {
-				return nil
-			}
-		}
 
-		if len(filter.Status) > 0 {
-			var balancePtr *phase0.Gwei
-			if balances != nil {
-				balancePtr = &balances[index]
-			}
-			validatorState := v1.ValidatorToState(validator, balancePtr, currentEpoch, beacon.FarFutureEpoch)
-			if !slices.Contains(filter.Status, validatorState) {
-				return nil
-			}
+		cachedIndexes[uint64(index)] = true
+		cachedIndexList = append(cachedIndexList, uint64(index))
+
+		if !bs.matchValidatorFilter(filter, index, validator, balances, currentEpoch) {
+			return nil
 		}
 
 		cachedResults = append(cachedResults, ValidatorWithIndex{
 			Index:     index,
 			Validator: validator,
 		})
-		cachedIndexes[uint64(index)] = true
 
 		return nil
 	})
 
+	// the db only holds the effective balances, so balance filters & sorting by actual balance are applied in memory
+	balanceFilter := balances != nil && (filter.MinBalance != nil || filter.MaxBalance != nil)
+	balanceSort := balances != nil && (filter.OrderBy == dbtypes.ValidatorOrderBalanceAsc || filter.OrderBy == dbtypes.ValidatorOrderBalanceDesc)
+
+	dbFilter := *filter
+	if balanceFilter {
+		dbFilter.MinBalance = nil
+		dbFilter.MaxBalance = nil
+	}
+
 	// get matching entries from DB
-	dbIndexes, err := db.GetValidatorIndexesByFilter(*filter, uint64(currentEpoch))
-	if err != nil {
-		bs.logger.Warnf("error getting validator indexes by filter: %v", err)
-		return nil, 0
+	// the db query is only limited if the overwritten entries can be counted with a single query, otherwise all
+	// matching indexes are loaded and the overwritten entries are skipped in memory.
+	const maxOverwrittenCountIndexes = 1000
+
+	dbLimit := uint64(0)
+	if filter.Limit > 0 && !balanceFilter && !balanceSort && len(cachedIndexList) <= maxOverwrittenCountIndexes {
+		dbLimit = filter.Offset + filter.Limit
+	}
+
+	var dbIndexes []uint64
+	dbOverwrittenCount := uint64(0)
+	for {
+		var err error
+		dbIndexes, err = db.GetValidatorIndexesByFilter(dbFilter, uint64(currentEpoch), dbLimit)
+		if err != nil {
+			bs.logger.Warnf("error getting validator indexes by filter: %v", err)
+			return nil, 0
+		}
+
+		dbOverwrittenCount = 0
+		for _, index := range dbIndexes {
+			if cachedIndexes[index] {
+				dbOverwrittenCount++
+			}
+		}
+
+		// the merged page needs offset+limit db entries that are not overwritten by cached validators
+		if dbLimit == 0 || uint64(len(dbIndexes)) < dbLimit || uint64(len(dbIndexes))-dbOverwrittenCount >= filter.Offset+filter.Limit {
+			break
+		}
+
+		dbLimit = filter.Offset + filter.Limit + dbOverwrittenCount
 	}
 
-	// sort results
+	// count all matching entries in the DB if the result has been limited
+	dbTotalCount := uint64(len(dbIndexes))
+	if dbLimit > 0 && dbTotalCount >= dbLimit {
+		var err error
+		dbTotalCount, err = db.GetValidatorCountByFilter(dbFilter, uint64(currentEpoch), nil)
+		if err == nil && len(cachedIndexList) > 0 {
+			dbOverwrittenCount, err = db.GetValidatorCountByFilter(dbFilter, uint64(currentEpoch), cachedIndexList)
+		}
+		if err != nil {
+			bs.logger.Warnf("error counting validators by filter: %v", err)
+			return nil, 0
+		}
+	}
+
+	// sort results (same order as the db query, ties are sorted by index)
 	var sortFn func(valA, valB ValidatorWithIndex) bool
 	switch filter.OrderBy {
 	case dbtypes.ValidatorOrderIndexAsc:
@@ -157,56 +189,24 @@ func (bs *ChainService) GetFilteredValidatorSet(filter *dbtypes.ValidatorFilter,
 		sortFn = func(valA, valB ValidatorWithIndex) bool {
 			return bytes.Compare(valA.Validator.PublicKey[:], valB.Validator.PublicKey[:]) > 0
 		}
-	case dbtypes.ValidatorOrderBalanceAsc:
-		if balances == nil {
-			sortFn = func(valA, valB ValidatorWithIndex) bool {
-				return valA.Validator.EffectiveBalance < valB.Validator.EffectiveBalance
-			}
-		} else {
-			sortFn = func(valA, valB ValidatorWithIndex) bool {
-				return balances[valA.Index] < balances[valB.Index]
-			}
-			sort.Slice(dbIndexes, func(i, j int) bool {
-				return balances[dbIndexes[i]] < balances[dbIndexes[j]]
-			})
-		}
-	case dbtypes.ValidatorOrderBalanceDesc:
-		if balances == nil {
-			sortFn = func(valA, valB ValidatorWithIndex) bool {
-				return valA.Validator.EffectiveBalance > valB.Validator.EffectiveBalance
-			}
-		} else {
-			sortFn = func(valA, valB ValidatorWithIndex) bool {
-				return balances[valA.Index] > balances[valB.Index]
-			}
-			sort.Slice(dbIndexes, func(i, j int) bool {
-				return balances[dbIndexes[i]] > balances[dbIndexes[j]]
-			})
+	case dbtypes.ValidatorOrderBalanceAsc, dbtypes.ValidatorOrderBalanceDesc:
+		balanceFn := func(val ValidatorWithIndex) uint64 { return uint64(val.Validator.EffectiveBalance) }
+		if balanceSort {
+			balanceFn = func(val ValidatorWithIndex) uint64 { return getValidatorBalance(balances, val.Index) }
 		}
+		sortFn = getValidatorSortFn(balanceFn, filter.OrderBy == dbtypes.ValidatorOrderBalanceDesc)
 	case dbtypes.ValidatorOrderActivationEpochAsc:
-		sortFn = func(valA, valB ValidatorWithIndex) bool {
-			return valA.Validator.ActivationEpoch < valB.Validator.ActivationEpoch
-		}
+		sortFn = getValidatorSortFn(func(val ValidatorWithIndex) uint64 { return uint64(val.Validator.ActivationEpoch) }, false)
 	case dbtypes.ValidatorOrderActivationEpochDesc:
-		sortFn = func(valA, valB ValidatorWithIndex) bool {
-			return valA.Validator.ActivationEpoch > valB.Validator.ActivationEpoch
-		}
+		sortFn = getValidatorSortFn(func(val ValidatorWithIndex) uint64 { return uint64(val.Validator.ActivationEpoch) }, true)
 	case dbtypes.ValidatorOrderExitEpochAsc:
-		sortFn = func(valA, valB ValidatorWithIndex) bool {
-			return valA.Validator.ExitEpoch < valB.Validator.ExitEpoch
-		}
+		sortFn = getValidatorSortFn(func(val ValidatorWithIndex) uint64 { return uint64(val.Validator.ExitEpoch) }, false)
 	case dbtypes.ValidatorOrderExitEpochDesc:
-		sortFn = func(valA, valB ValidatorWithIndex) bool {
-			return valA.Validator.ExitEpoch > valB.Validator.ExitEpoch
-		}
+		sortFn = getValidatorSortFn(func(val ValidatorWithIndex) uint64 { return uint64(val.Validator.ExitEpoch) }, true)
 	case dbtypes.ValidatorOrderWithdrawableEpochAsc:
-		sortFn = func(valA, valB ValidatorWithIndex) bool {
-			return valA.Validator.WithdrawableEpoch < valB.Validator.WithdrawableEpoch
-		}
+		sortFn = getValidatorSortFn(func(val ValidatorWithIndex) uint64 { return uint64(val.Validator.WithdrawableEpoch) }, false)
 	case dbtypes.ValidatorOrderWithdrawableEpochDesc:
-		sortFn = func(valA, valB ValidatorWithIndex) bool {
-			return valA.Validator.WithdrawableEpoch > valB.Validator.WithdrawableEpoch
-		}
+		sortFn = getValidatorSortFn(func(val ValidatorWithIndex) uint64 { return uint64(val.Validator.WithdrawableEpoch) }, true)
 	}
 
 	sort.Slice(cachedResults, func(i, j int) bool {
@@ -222,30 +222,56 @@ func (bs *ChainService) GetFilteredValidatorSet(filter *dbtypes.ValidatorFilter,
 	cachedIndex := 0
 	matchingCount := uint64(0)
 	resultCount := uint64(0)
-	dbEntryCount := uint64(0)
 
-	db.StreamValidatorsByIndexes(dbIndexes, func(validator *dbtypes.Validator) bool {
-		dbEntryCount++
+	addResult := func(index phase0.ValidatorIndex, validator *phase0.Validator) {
+		balance := phase0.Gwei(0)
+		var balancePtr *phase0.Gwei
+		if balances != nil && uint64(index) < uint64(len(balances)) {
+			balance = balances[index]
+			balancePtr = &balance
+		}
+		result = append(result, v1.Validator{
+			Index:     index,
+			Balance:   balance,
+			Status:    v1.ValidatorToState(validator, balancePtr, currentEpoch, beacon.FarFutureEpoch),
+			Validator: validator,
+		})
+		resultCount++
+	}
+
+	// only the db entries from the requested page onwards need to be loaded
+	dbStreamIndexes := make([]uint64, 0, len(dbIndexes))
+	for _, index := range dbIndexes {
+		if cachedIndexes[index] {
+			continue
+		}
+		if balanceFilter && !matchValidatorBalance(filter, getValidatorBalance(balances, phase0.ValidatorIndex(index))) {
+			continue
+		}
+		dbStreamIndexes = append(dbStreamIndexes, index)
+	}
+
+	if balanceFilter {
+		// all matching indexes have been loaded, the db count includes entries that don't match the actual balance
+		dbTotalCount = uint64(len(dbStreamIndexes))
+		dbOverwrittenCount = 0
+	}
+	if balanceSort {
+		// the actual balance sort only needs the validator index, so the db entries can be sorted before loading them
+		sort.Slice(dbStreamIndexes, func(i, j int) bool {
+			return sortFn(ValidatorWithIndex{Index: phase0.ValidatorIndex(dbStreamIndexes[i])}, ValidatorWithIndex{Index: phase0.ValidatorIndex(dbStreamIndexes[j])})
+		})
+	}
+
+	db.StreamValidatorsByIndexes(dbStreamIndexes, func(validator *dbtypes.Validator) bool {
 		validatorWithIndex := ValidatorWithIndex{
 			Index:     phase0.ValidatorIndex(validator.ValidatorIndex),
 			Validator: beacon.UnwrapDbValidator(validator),
 		}
 
-		for cachedIndex < len(cachedResults) && (cachedResults[cachedIndex].Index == validatorWithIndex.Index || sortFn(cachedResults[cachedIndex], validatorWithIndex)) {
+		for cachedIndex < len(cachedResults) && sortFn(cachedResults[cachedIndex], validatorWithIndex) {
 			if matchingCount >= filter.Offset {
-				balance := phase0.Gwei(0)
-				var balancePtr *phase0.Gwei
-				if balances != nil {
-					balance = balances[cachedResults[cachedIndex].Index]
-					balancePtr = &balance
-				}
-				result = append(result, v1.Validator{
-					Index:     cachedResults[cachedIndex].Index,
-					Balance:   balance,
-					Status:    v1.ValidatorToState(cachedResults[cachedIndex].Validator, balancePtr, currentEpoch, beacon.FarFutureEpoch),
-					Validator: cachedResults[cachedIndex].Validator,
-				})
-				resultCount++
+				addResult(cachedResults[cachedIndex].Index, cachedResults[cachedIndex].Validator)
 			}
 			matchingCount++
 			cachedIndex++
@@ -255,25 +281,8 @@ func (bs *ChainService) GetFilteredValidatorSet(filter *dbtypes.ValidatorFilter,
 			}
 		}
 
-		if cachedIndexes[validator.ValidatorIndex] {
-			return true // skip this index, cache entry is newer
-		}
-
 		if matchingCount >= filter.Offset {
-			balance := phase0.Gwei(0)
-			var balancePtr *phase0.Gwei
-			if balances != nil {
-				balance = balances[validator.ValidatorIndex]
-				balancePtr = &balance
-			}
-			validatorData := beacon.UnwrapDbValidator(validator)
-			result = append(result, v1.Validator{
-				Index:     phase0.ValidatorIndex(validator.ValidatorIndex),
-				Balance:   balance,
-				Status:    v1.ValidatorToState(validatorData, balancePtr, currentEpoch, beacon.FarFutureEpoch),
-				Validator: validatorData,
-			})
-			resultCount++
+			addResult(validatorWithIndex.Index, validatorWithIndex.Validator)
 		}
 		matchingCount++
 
@@ -286,36 +295,101 @@ func (bs *ChainService) GetFilteredValidatorSet(filter *dbtypes.ValidatorFilter,
 
 	for cachedIndex < len(cachedResults) && (filter.Limit == 0 || resultCount < filter.Limit) {
 		if matchingCount >= filter.Offset {
-			balance := phase0.Gwei(0)
-			var balancePtr *phase0.Gwei
-			if balances != nil {
-				balance = balances[cachedResults[cachedIndex].Index]
-				balancePtr = &balance
-			}
-			result = append(result, v1.Validator{
-				Index:     phase0.ValidatorIndex(cachedResults[cachedIndex].Index),
-				Balance:   balance,
-				Status:    v1.ValidatorToState(cachedResults[cachedIndex].Validator, balancePtr, currentEpoch, beacon.FarFutureEpoch),
-				Validator: cachedResults[cachedIndex].Validator,
-			})
-			resultCount++
+			addResult(cachedResults[cachedIndex].Index, cachedResults[cachedIndex].Validator)
 		}
 		matchingCount++
 		cachedIndex++
 	}
 
-	// add remaining cached results
-	matchingCount += uint64(len(cachedResults) - cachedIndex)
+	totalCount := dbTotalCount - dbOverwrittenCount + uint64(len(cachedResults))
 
-	// add remaining db results
-	remainingDbCount := uint64(0)
-	for i := dbEntryCount; i < uint64(len(dbIndexes)); i++ {
-		if cachedIndexes[dbIndexes[i]] {
-			continue
+	return result, totalCount
+}
+
+// getValidatorSortFn returns a sort function for the validator field, ties are sorted by index.
+func getValidatorSortFn(field func(val ValidatorWithIndex) uint64, desc bool) func(valA, valB ValidatorWithIndex) bool {
+	return func(valA, valB ValidatorWithIndex) bool {
+		fieldA, fieldB := field(valA), field(valB)
+		if fieldA != fieldB {
+			return (fieldA < fieldB) != desc
+		}
+		return valA.Index < valB.Index
+	}
+}
+
+// matchValidatorFilter checks if a cached validator matches the filter (same conditions as the db filter)
+func (bs *ChainService) matchValidatorFilter(filter *dbtypes.ValidatorFilter, index phase0.ValidatorIndex, validator *phase0.Validator, balances []phase0.Gwei, currentEpoch phase0.Epoch) bool {
+	if filter.MinIndex != nil && index < phase0.ValidatorIndex(*filter.MinIndex) {
+		return false
+	}
+	if filter.MaxIndex != nil && index > phase0.ValidatorIndex(*filter.MaxIndex) {
+		return false
+	}
+	if filter.PubKey != nil && !bytes.Equal(validator.PublicKey[:], filter.PubKey) {
+		return false
+	}
+	if filter.WithdrawalAddress != nil {
+		if validator.WithdrawalCredentials[0] != 0x01 && validator.WithdrawalCredentials[0] != 0x02 {
+			return false
+		}
+		if !bytes.Equal(validator.WithdrawalCredentials[12:], filter.WithdrawalAddress[:]) {
+			return false
 		}
-		remainingDbCount++
 	}
-	matchingCount += remainingDbCount
+	if filter.ValidatorName != "" {
+		vname := bs.validatorNames.GetValidatorName(uint64(index))
+		if !strings.Contains(strings.ToLower(vname), strings.ToLower(filter.ValidatorName)) {
+			return false
+		}
+	}
+	if filter.MinBalance != nil || filter.MaxBalance != nil {
+		balance := uint64(validator.EffectiveBalance)
+		if balances != nil {
+			balance = getValidatorBalance(balances, index)
+		}
+		if !matchValidatorBalance(filter, balance) {
+			return false
+		}
+	}
+	if filter.MinActivation != nil && uint64(validator.ActivationEpoch) < *filter.MinActivation {
+		return false
+	}
+	if filter.MaxActivation != nil && uint64(validator.ActivationEpoch) > *filter.MaxActivation {
+		return false
+	}
+	if len(filter.CredentialsPrefix) > 0 && !bytes.HasPrefix(validator.WithdrawalCredentials, filter.CredentialsPrefix) {
+		return false
+	}
+
+	if len(filter.Status) > 0 {
+		var balancePtr *phase0.Gwei
+		if balances != nil && uint64(index) < uint64(len(balances)) {
+			balancePtr = &balances[index]
+		}
+		validatorState := v1.ValidatorToState(validator, balancePtr, currentEpoch, beacon.FarFutureEpoch)
+		if !slices.Contains(filter.Status, validatorState) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getValidatorBalance returns the actual balance of the validator (0 if not in the balances list)
+func getValidatorBalance(balances []phase0.Gwei, index phase0.ValidatorIndex) uint64 {
+	if uint64(index) < uint64(len(balances)) {
+		return uint64(balances[index])
+	}
+	return 0
+}
 
-	return result, matchingCount
+// matchValidatorBalance checks if the balance is within the balance range of the filter
+func matchValidatorBalance(filter *dbtypes.ValidatorFilter, balance uint64) bool {
+	if filter.MinBalance != nil && balance < *filter.MinBalance {
+		return false
+	}
+	if filter.MaxBalance != nil && balance > *filter.MaxBalance {
+		return false
+	}
+	return true
 }
@@ -114,7 +114,7 @@ func (bs *ChainService) GetWithdrawalRequestsByFilter(filter *CombinedWithdrawal
 
 			if len(dbOperation.TxHash) > 0 {
 				requestTxDetailsFor = append(requestTxDetailsFor, dbOperation.TxHash)
-			} else if matcherHeight := bs.GetWithdrawalIndexer().GetMatcherHeight(); dbOperation.BlockNumber > matcherHeight {
+			} else if withdrawalIndexer := bs.GetWithdrawalIndexer(); withdrawalIndexer == nil || dbOperation.BlockNumber > withdrawalIndexer.GetMatcherHeight() {
 				// withdrawal request has not been matched with a tx yet, try to find the tx on the fly
 				requestTxs := db.GetWithdrawalRequestTxsByDequeueRange(dbOperation.BlockNumber, dbOperation.BlockNumber)
 				if len(requestTxs) > 1 {
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// CustomPageResult holds the result of an operator defined custom page query.
+type CustomPageResult struct {
+	Columns []string
+	Rows    []map[string]interface{}
+	HasMore bool
+}
+
+// GetCustomPage returns the configuration of the custom page with the given name or nil if there is no such page.
+func GetCustomPage(name string) *types.CustomPageConfig {
+	for idx := range utils.Config.CustomPages {
+		if utils.Config.CustomPages[idx].Name == name {
+			return &utils.Config.CustomPages[idx]
+		}
+	}
+	return nil
+}
+
+// GetCustomPageParams parses the custom page parameters from the url query.
+// returns the query arguments (in configured order) and the effective parameter values.
+func GetCustomPageParams(page *types.CustomPageConfig, urlArgs url.Values) ([]interface{}, map[string]string, error) {
+	args := make([]interface{}, len(page.Params))
+	values := make(map[string]string, len(page.Params))
+
+	for idx, param := range page.Params {
+		value := param.Default
+		if urlArgs.Has(param.Name) {
+			value = urlArgs.Get(param.Name)
+		}
+		values[param.Name] = value
+
+		switch param.Type {
+		case "int":
+			intValue, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid value for parameter '%v': %v", param.Label, err)
+			}
+			args[idx] = intValue
+		case "bool":
+			boolValue, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid value for parameter '%v': %v", param.Label, err)
+			}
+			args[idx] = boolValue
+		default:
+			args[idx] = value
+		}
+	}
+
+	return args, values, nil
+}
+
+// LoadCustomPageResult runs the custom page query with the given arguments and returns the requested page of result rows.
+func LoadCustomPageResult(page *types.CustomPageConfig, args []interface{}, pageIdx uint64) (*CustomPageResult, error) {
+	if pageIdx < 1 {
+		pageIdx = 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), page.Timeout)
+	defer cancel()
+
+	columns, rows, err := db.GetCustomPageRows(ctx, page.Query, args, (pageIdx-1)*page.PageSize, page.PageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CustomPageResult{
+		Columns: columns,
+		Rows:    rows,
+	}
+	if uint64(len(rows)) > page.PageSize {
+		result.Rows = rows[:page.PageSize]
+		result.HasMore = true
+	}
+
+	return result, nil
+}
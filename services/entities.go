@@ -0,0 +1,251 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// entityMaxRangeSize is the maximum number of validators per entity validator range
+const entityMaxRangeSize = 1 << 22
+
+// EntityDetails is an entity with its roster (validator ranges & addresses)
+type EntityDetails struct {
+	Entity          *dbtypes.Entity
+	Links           []*dbtypes.EntityLink
+	ValidatorRanges []*dbtypes.EntityValidatorRange
+	Addresses       []*dbtypes.EntityAddress
+}
+
+// GetEntityAddressTypeKey returns the api key of an entity address type
+func GetEntityAddressTypeKey(addressType dbtypes.EntityAddressType) string {
+	switch addressType {
+	case dbtypes.EntityAddressWithdrawal:
+		return "withdrawal"
+	case dbtypes.EntityAddressDepositOrigin:
+		return "deposit_origin"
+	case dbtypes.EntityAddressDepositTarget:
+		return "deposit_target"
+	}
+	return ""
+}
+
+// ParseEntityAddressType returns the entity address type for an api key
+func ParseEntityAddressType(key string) (dbtypes.EntityAddressType, bool) {
+	switch key {
+	case "withdrawal":
+		return dbtypes.EntityAddressWithdrawal, true
+	case "deposit_origin", "depositor":
+		return dbtypes.EntityAddressDepositOrigin, true
+	case "deposit_target":
+		return dbtypes.EntityAddressDepositTarget, true
+	}
+	return 0, false
+}
+
+// GetEntities returns all entities with their rosters
+func GetEntities() ([]*EntityDetails, error) {
+	entities, err := db.GetEntities()
+	if err != nil {
+		return nil, err
+	}
+	validatorRanges, err := db.GetEntityValidatorRanges(nil)
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := db.GetEntityAddresses(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	entityMap := make(map[uint64]*EntityDetails, len(entities))
+	result := make([]*EntityDetails, 0, len(entities))
+	for _, entity := range entities {
+		details := &EntityDetails{
+			Entity:          entity,
+			Links:           parseEntityLinks(entity.Links),
+			ValidatorRanges: []*dbtypes.EntityValidatorRange{},
+			Addresses:       []*dbtypes.EntityAddress{},
+		}
+		entityMap[entity.EntityId] = details
+		result = append(result, details)
+	}
+	for _, validatorRange := range validatorRanges {
+		if details := entityMap[validatorRange.EntityId]; details != nil {
+			details.ValidatorRanges = append(details.ValidatorRanges, validatorRange)
+		}
+	}
+	for _, address := range addresses {
+		if details := entityMap[address.EntityId]; details != nil {
+			details.Addresses = append(details.Addresses, address)
+		}
+	}
+
+	return result, nil
+}
+
+// GetEntity returns the entity with the given id with its roster, or nil if it does not exist
+func GetEntity(entityId uint64) (*EntityDetails, error) {
+	entity := db.GetEntity(entityId)
+	if entity == nil {
+		return nil, nil
+	}
+
+	validatorRanges, err := db.GetEntityValidatorRanges(&entityId)
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := db.GetEntityAddresses(&entityId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EntityDetails{
+		Entity:          entity,
+		Links:           parseEntityLinks(entity.Links),
+		ValidatorRanges: validatorRanges,
+		Addresses:       addresses,
+	}, nil
+}
+
+func parseEntityLinks(linksJson string) []*dbtypes.EntityLink {
+	links := []*dbtypes.EntityLink{}
+	if linksJson == "" {
+		return links
+	}
+	if err := json.Unmarshal([]byte(linksJson), &links); err != nil {
+		logger_vn.Warnf("invalid entity links: %v", err)
+	}
+	return links
+}
+
+// ValidateEntity checks the entity fields & roster before it gets written to the db
+func ValidateEntity(details *EntityDetails) error {
+	entity := details.Entity
+	entity.Name = strings.TrimSpace(entity.Name)
+	if entity.Name == "" {
+		return fmt.Errorf("entity name must not be empty")
+	}
+	if len(entity.Name) > 100 {
+		return fmt.Errorf("entity name must not be longer than 100 characters")
+	}
+
+	for _, link := range details.Links {
+		linkUrl, err := url.Parse(link.Url)
+		if err != nil || (linkUrl.Scheme != "http" && linkUrl.Scheme != "https") || linkUrl.Host == "" {
+			return fmt.Errorf("invalid link url: %v", link.Url)
+		}
+		if link.Name == "" {
+			link.Name = linkUrl.Host
+		}
+	}
+
+	rangeStarts := map[uint64]bool{}
+	for _, validatorRange := range details.ValidatorRanges {
+		if rangeStarts[validatorRange.MinIndex] {
+			return fmt.Errorf("duplicate validator range starting at %v", validatorRange.MinIndex)
+		}
+		rangeStarts[validatorRange.MinIndex] = true
+		if validatorRange.MinIndex > validatorRange.MaxIndex {
+			return fmt.Errorf("invalid validator range %v-%v", validatorRange.MinIndex, validatorRange.MaxIndex)
+		}
+		if validatorRange.MaxIndex-validatorRange.MinIndex >= entityMaxRangeSize {
+			return fmt.Errorf("validator range %v-%v exceeds the maximum range size of %v", validatorRange.MinIndex, validatorRange.MaxIndex, entityMaxRangeSize)
+		}
+	}
+
+	addressKeys := map[string]bool{}
+	for _, address := range details.Addresses {
+		if len(address.Address) != 20 {
+			return fmt.Errorf("invalid address 0x%x", address.Address)
+		}
+		if GetEntityAddressTypeKey(address.AddressType) == "" {
+			return fmt.Errorf("invalid address type %v", address.AddressType)
+		}
+
+		addressKey := fmt.Sprintf("%v:%x", address.AddressType, address.Address)
+		if addressKeys[addressKey] {
+			return fmt.Errorf("duplicate %v address 0x%x", GetEntityAddressTypeKey(address.AddressType), address.Address)
+		}
+		addressKeys[addressKey] = true
+	}
+
+	return nil
+}
+
+// SaveEntity creates (entity id 0) or updates an entity and replaces its roster.
+// the validator names are reloaded asynchronously afterwards.
+func SaveEntity(details *EntityDetails) error {
+	if err := ValidateEntity(details); err != nil {
+		return err
+	}
+
+	linksJson := ""
+	if len(details.Links) > 0 {
+		linksBytes, err := json.Marshal(details.Links)
+		if err != nil {
+			return fmt.Errorf("error encoding entity links: %v", err)
+		}
+		linksJson = string(linksBytes)
+	}
+
+	entity := details.Entity
+	entity.Links = linksJson
+	entity.Updated = uint64(time.Now().Unix())
+
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		if entity.EntityId == 0 {
+			entityId, err := db.GetNextEntityId(tx)
+			if err != nil {
+				return err
+			}
+			entity.EntityId = entityId
+			entity.Created = entity.Updated
+		}
+
+		if err := db.InsertEntity(entity, tx); err != nil {
+			return err
+		}
+
+		for _, validatorRange := range details.ValidatorRanges {
+			validatorRange.EntityId = entity.EntityId
+		}
+		for _, address := range details.Addresses {
+			address.EntityId = entity.EntityId
+		}
+
+		return db.SetEntityMembers(entity.EntityId, details.ValidatorRanges, details.Addresses, tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	GlobalBeaconService.validatorNames.ReloadEntities()
+	return nil
+}
+
+// DeleteEntity deletes an entity with its roster.
+// the validator names are reloaded asynchronously afterwards.
+func DeleteEntity(entityId uint64) error {
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.DeleteEntity(entityId, tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	GlobalBeaconService.validatorNames.ReloadEntities()
+	return nil
+}
+
+// GetEntityValidatorIndexes returns the sorted indexes of all validators that are currently named by the entity
+func (bs *ChainService) GetEntityValidatorIndexes(entityId uint64) []uint64 {
+	return bs.validatorNames.GetEntityValidatorIndexes(entityId)
+}
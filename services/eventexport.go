@@ -0,0 +1,274 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// EventExportMessage is the envelope of all messages published by the event exporter
+type EventExportMessage struct {
+	Event   string      `json:"event"` // block, reorg, finalized_epoch, deposit, voluntary_exit or slashing
+	Network string      `json:"network"`
+	Time    time.Time   `json:"time"`
+	Data    interface{} `json:"data"`
+}
+
+type EventExportBlock struct {
+	Slot            uint64 `json:"slot"`
+	Epoch           uint64 `json:"epoch"`
+	BlockRoot       string `json:"block_root"`
+	ParentRoot      string `json:"parent_root"`
+	Proposer        uint64 `json:"proposer"`
+	Graffiti        string `json:"graffiti"`
+	ExecutionNumber uint64 `json:"execution_number"`
+	ExecutionHash   string `json:"execution_hash"`
+}
+
+type EventExportReorg struct {
+	OldHeadSlot     uint64 `json:"old_head_slot"`
+	OldHeadRoot     string `json:"old_head_root"`
+	NewHeadSlot     uint64 `json:"new_head_slot"`
+	NewHeadRoot     string `json:"new_head_root"`
+	RewindDistance  uint64 `json:"rewind_distance"`
+	ForwardDistance uint64 `json:"forward_distance"`
+}
+
+type EventExportFinalizedEpoch struct {
+	Epoch           uint64   `json:"epoch"`
+	CanonicalBlocks []string `json:"canonical_blocks"`
+}
+
+type EventExportDeposit struct {
+	Slot                  uint64 `json:"slot"`
+	BlockRoot             string `json:"block_root"`
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"` // gwei
+}
+
+type EventExportVoluntaryExit struct {
+	Slot      uint64 `json:"slot"`
+	BlockRoot string `json:"block_root"`
+	Validator uint64 `json:"validator"`
+}
+
+type EventExportSlashing struct {
+	Slot      uint64 `json:"slot"`
+	BlockRoot string `json:"block_root"`
+	Validator uint64 `json:"validator"`
+	Slasher   uint64 `json:"slasher"`
+	Reason    string `json:"reason"` // proposer or attester
+}
+
+// eventExportBackend publishes messages to a message broker
+type eventExportBackend interface {
+	Publish(topic string, key string, payload []byte) error
+}
+
+// eventExportQueueItem is a message waiting to be published
+type eventExportQueueItem struct {
+	topic   string
+	key     string
+	payload []byte
+}
+
+// EventExporter publishes the indexer events to nats or kafka.
+// messages are queued and published asynchronously, so a slow or unavailable broker does not delay the indexer.
+type EventExporter struct {
+	logger      logrus.FieldLogger
+	chainState  *consensus.ChainState
+	indexer     *beacon.Indexer
+	backend     eventExportBackend
+	topicPrefix string
+	queue       chan *eventExportQueueItem
+	dropCount   atomic.Uint64
+}
+
+// registerEventExporter creates the configured event export backend and adds the event hook to the beacon indexer
+func registerEventExporter(logger logrus.FieldLogger, beaconIndexer *beacon.Indexer, chainState *consensus.ChainState) error {
+	var backend eventExportBackend
+	switch strings.ToLower(utils.Config.EventExport.Backend) {
+	case "nats":
+		if utils.Config.EventExport.NatsUrl == "" {
+			return fmt.Errorf("missing nats url")
+		}
+		backend = newNatsEventBackend(utils.Config.EventExport.NatsUrl, utils.Config.EventExport.Timeout)
+	case "kafka":
+		if utils.Config.EventExport.KafkaRestUrl == "" {
+			return fmt.Errorf("missing kafka rest proxy url")
+		}
+		backend = newKafkaEventBackend(utils.Config.EventExport.KafkaRestUrl, utils.Config.EventExport.Timeout)
+	default:
+		return fmt.Errorf("unknown event export backend: %v", utils.Config.EventExport.Backend)
+	}
+
+	exporter := &EventExporter{
+		logger:      logger,
+		chainState:  chainState,
+		indexer:     beaconIndexer,
+		backend:     backend,
+		topicPrefix: utils.Config.EventExport.TopicPrefix,
+		queue:       make(chan *eventExportQueueItem, utils.Config.EventExport.QueueSize),
+	}
+
+	go exporter.runPublisher()
+	beaconIndexer.AddEventHook(exporter.processEvent)
+
+	return nil
+}
+
+// processEvent converts an indexer event to messages and adds them to the publishing queue
+func (exporter *EventExporter) processEvent(event *beacon.IndexerEvent) {
+	switch event.Type {
+	case beacon.IndexerEventNewBlock:
+		if blockData := buildEventExportBlock(exporter.chainState, event.Block); blockData != nil {
+			exporter.enqueue("block", event.Block.Root.String(), blockData)
+		}
+
+	case beacon.IndexerEventReorg:
+		exporter.enqueue("reorg", event.Block.Root.String(), &EventExportReorg{
+			OldHeadSlot:     uint64(event.OldHead.Slot),
+			OldHeadRoot:     event.OldHead.Root.String(),
+			NewHeadSlot:     uint64(event.Block.Slot),
+			NewHeadRoot:     event.Block.Root.String(),
+			RewindDistance:  event.RewindDistance,
+			ForwardDistance: event.ForwardDistance,
+		})
+
+	case beacon.IndexerEventFinalizedEpoch:
+		epochData := &EventExportFinalizedEpoch{
+			Epoch:           uint64(event.Epoch),
+			CanonicalBlocks: make([]string, len(event.CanonicalBlocks)),
+		}
+		for idx, block := range event.CanonicalBlocks {
+			epochData.CanonicalBlocks[idx] = block.Root.String()
+		}
+		exporter.enqueue("finalized_epoch", fmt.Sprintf("%v", event.Epoch), epochData)
+
+		for _, block := range event.CanonicalBlocks {
+			exporter.processFinalizedBlock(block)
+		}
+	}
+}
+
+// processFinalizedBlock adds the deposits, voluntary exits & slashings of a finalized canonical block to the publishing queue
+func (exporter *EventExporter) processFinalizedBlock(block *beacon.Block) {
+	for _, deposit := range block.GetDbDeposits(exporter.indexer, nil, true) {
+		exporter.enqueue("deposit", fmt.Sprintf("0x%x", deposit.PublicKey), &EventExportDeposit{
+			Slot:                  deposit.SlotNumber,
+			BlockRoot:             block.Root.String(),
+			PublicKey:             fmt.Sprintf("0x%x", deposit.PublicKey),
+			WithdrawalCredentials: fmt.Sprintf("0x%x", deposit.WithdrawalCredentials),
+			Amount:                deposit.Amount,
+		})
+	}
+
+	for _, voluntaryExit := range block.GetDbVoluntaryExits(exporter.indexer, true) {
+		exporter.enqueue("voluntary_exit", fmt.Sprintf("%v", voluntaryExit.ValidatorIndex), &EventExportVoluntaryExit{
+			Slot:      voluntaryExit.SlotNumber,
+			BlockRoot: block.Root.String(),
+			Validator: voluntaryExit.ValidatorIndex,
+		})
+	}
+
+	for _, slashing := range block.GetDbSlashings(exporter.indexer, true) {
+		slashingData := &EventExportSlashing{
+			Slot:      slashing.SlotNumber,
+			BlockRoot: block.Root.String(),
+			Validator: slashing.ValidatorIndex,
+			Slasher:   slashing.SlasherIndex,
+		}
+		switch slashing.Reason {
+		case dbtypes.ProposerSlashing:
+			slashingData.Reason = "proposer"
+		case dbtypes.AttesterSlashing:
+			slashingData.Reason = "attester"
+		}
+		exporter.enqueue("slashing", fmt.Sprintf("%v", slashing.ValidatorIndex), slashingData)
+	}
+}
+
+// buildEventExportBlock builds the event data of a block, returns nil if the block header is not available
+func buildEventExportBlock(chainState *consensus.ChainState, block *beacon.Block) *EventExportBlock {
+	header := block.GetHeader()
+	if header == nil {
+		return nil
+	}
+
+	blockData := &EventExportBlock{
+		Slot:       uint64(block.Slot),
+		Epoch:      uint64(chainState.EpochOfSlot(block.Slot)),
+		BlockRoot:  block.Root.String(),
+		ParentRoot: header.Message.ParentRoot.String(),
+		Proposer:   uint64(header.Message.ProposerIndex),
+	}
+
+	if blockIndex := block.GetBlockIndex(); blockIndex != nil {
+		blockData.Graffiti = utils.GraffitiToString(blockIndex.Graffiti[:])
+		blockData.ExecutionNumber = blockIndex.ExecutionNumber
+		if blockIndex.ExecutionHash != (phase0.Hash32{}) {
+			blockData.ExecutionHash = blockIndex.ExecutionHash.String()
+		}
+	}
+
+	return blockData
+}
+
+// enqueue encodes a message and adds it to the publishing queue. messages are dropped if the queue is full.
+func (exporter *EventExporter) enqueue(event string, key string, data interface{}) {
+	payload, err := json.Marshal(&EventExportMessage{
+		Event:   event,
+		Network: getNetworkName(exporter.chainState),
+		Time:    time.Now(),
+		Data:    data,
+	})
+	if err != nil {
+		exporter.logger.Errorf("failed encoding %v event: %v", event, err)
+		return
+	}
+
+	select {
+	case exporter.queue <- &eventExportQueueItem{
+		topic:   exporter.topicPrefix + event,
+		key:     key,
+		payload: payload,
+	}:
+	default:
+		dropCount := exporter.dropCount.Add(1)
+		if dropCount%100 == 1 {
+			exporter.logger.Warnf("event export queue full, dropped %v messages so far", dropCount)
+		}
+	}
+}
+
+// runPublisher publishes the queued messages. failed messages are retried until the backend is available again.
+func (exporter *EventExporter) runPublisher() {
+	defer utils.HandleSubroutinePanic("EventExporter.runPublisher", exporter.runPublisher)
+
+	for item := range exporter.queue {
+		retryDelay := 1 * time.Second
+		for {
+			err := exporter.backend.Publish(item.topic, item.key, item.payload)
+			if err == nil {
+				break
+			}
+
+			exporter.logger.Warnf("failed publishing event to %v (retrying in %v): %v", item.topic, retryDelay, err)
+			time.Sleep(retryDelay)
+			if retryDelay < 60*time.Second {
+				retryDelay *= 2
+			}
+		}
+	}
+}
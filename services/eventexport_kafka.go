@@ -0,0 +1,70 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// kafkaEventBackend publishes messages to kafka via a kafka rest proxy (v2 api)
+type kafkaEventBackend struct {
+	proxyUrl string
+	client   *http.Client
+}
+
+// kafkaProduceRequest is the body of a produce request to the rest proxy
+type kafkaProduceRequest struct {
+	Records []*kafkaProduceRecord `json:"records"`
+}
+
+type kafkaProduceRecord struct {
+	Key   string          `json:"key,omitempty"`
+	Value json.RawMessage `json:"value"`
+}
+
+func newKafkaEventBackend(proxyUrl string, timeout time.Duration) *kafkaEventBackend {
+	return &kafkaEventBackend{
+		proxyUrl: strings.TrimSuffix(proxyUrl, "/"),
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Publish produces a message to the topic. messages with the same key end up in the same partition.
+func (backend *kafkaEventBackend) Publish(topic string, key string, payload []byte) error {
+	body, err := json.Marshal(&kafkaProduceRequest{
+		Records: []*kafkaProduceRecord{
+			{
+				Key:   key,
+				Value: payload,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%v/topics/%v", backend.proxyUrl, url.PathEscape(topic)), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := backend.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("kafka rest proxy returned %v: %v", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// natsEventBackend publishes messages to a nats server using the plain text client protocol.
+// the connection is established lazily and re-established after errors.
+type natsEventBackend struct {
+	serverUrl *url.URL
+	urlErr    error
+	timeout   time.Duration
+
+	mutex sync.Mutex
+	conn  net.Conn
+	err   error // async error reported by the server
+}
+
+// natsConnectOptions is the payload of the CONNECT command
+type natsConnectOptions struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	Version   string `json:"version"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+func newNatsEventBackend(serverUrl string, timeout time.Duration) *natsEventBackend {
+	backend := &natsEventBackend{
+		timeout: timeout,
+	}
+	backend.serverUrl, backend.urlErr = url.Parse(serverUrl)
+	return backend
+}
+
+// Publish sends a message to the subject. the key is not used, as nats subjects are not partitioned.
+func (backend *natsEventBackend) Publish(topic string, key string, payload []byte) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+
+	if backend.err != nil {
+		backend.closeConn()
+	}
+	if backend.conn == nil {
+		if err := backend.connect(); err != nil {
+			return err
+		}
+	}
+
+	backend.conn.SetWriteDeadline(time.Now().Add(backend.timeout))
+	_, err := fmt.Fprintf(backend.conn, "PUB %v %v\r\n%s\r\n", topic, len(payload), payload)
+	if err != nil {
+		backend.closeConn()
+		return fmt.Errorf("failed publishing message: %v", err)
+	}
+
+	return nil
+}
+
+func (backend *natsEventBackend) connect() error {
+	if backend.urlErr != nil {
+		return fmt.Errorf("invalid nats url: %v", backend.urlErr)
+	}
+
+	host := backend.serverUrl.Host
+	if backend.serverUrl.Port() == "" {
+		host = net.JoinHostPort(backend.serverUrl.Hostname(), "4222")
+	}
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: backend.timeout}
+	if backend.serverUrl.Scheme == "tls" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{ServerName: backend.serverUrl.Hostname()})
+	} else {
+		conn, err = dialer.Dial("tcp", host)
+	}
+	if err != nil {
+		return fmt.Errorf("failed connecting to nats server: %v", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	conn.SetDeadline(time.Now().Add(backend.timeout))
+
+	// the server greets with its INFO
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO") {
+		conn.Close()
+		return fmt.Errorf("unexpected nats greeting: %v %v", strings.TrimSpace(line), err)
+	}
+
+	options := &natsConnectOptions{
+		Name:    "dora",
+		Lang:    "go",
+		Version: "1.0.0",
+	}
+	if user := backend.serverUrl.User; user != nil {
+		if pass, hasPass := user.Password(); hasPass {
+			options.User = user.Username()
+			options.Pass = pass
+		} else {
+			options.AuthToken = user.Username()
+		}
+	}
+	optionsJson, _ := json.Marshal(options)
+
+	// PING after CONNECT to get a PONG (or -ERR) back once the connection is accepted
+	_, err = fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", optionsJson)
+	if err == nil {
+		line, err = reader.ReadString('\n')
+	}
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed connecting to nats server: %v", err)
+	}
+	if !strings.HasPrefix(line, "PONG") {
+		conn.Close()
+		return fmt.Errorf("nats server rejected connection: %v", strings.TrimSpace(line))
+	}
+
+	conn.SetDeadline(time.Time{})
+	backend.conn = conn
+	backend.err = nil
+	go backend.readLoop(conn, reader)
+
+	return nil
+}
+
+// readLoop answers the keepalive pings of the server and records errors reported by the server
+func (backend *natsEventBackend) readLoop(conn net.Conn, reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err == nil && strings.HasPrefix(line, "PING") {
+			backend.mutex.Lock()
+			if backend.conn == conn {
+				conn.SetWriteDeadline(time.Now().Add(backend.timeout))
+				_, err = conn.Write([]byte("PONG\r\n"))
+			}
+			backend.mutex.Unlock()
+		} else if err == nil && strings.HasPrefix(line, "-ERR") {
+			err = fmt.Errorf("nats server error: %v", strings.TrimSpace(line))
+		}
+
+		if err != nil {
+			backend.mutex.Lock()
+			if backend.conn == conn {
+				backend.err = err
+			}
+			backend.mutex.Unlock()
+			return
+		}
+	}
+}
+
+func (backend *natsEventBackend) closeConn() {
+	if backend.conn != nil {
+		backend.conn.Close()
+	}
+	backend.conn = nil
+	backend.err = nil
+}
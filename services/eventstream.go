@@ -0,0 +1,168 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// EventStreamTopics are the topics that can be subscribed via the event stream
+var EventStreamTopics = []string{"head", "block", "reorg", "finalized_checkpoint"}
+
+// ErrEventStreamFull is returned when the max number of event stream clients is reached
+var ErrEventStreamFull = fmt.Errorf("too many event stream clients")
+
+var GlobalEventStream *EventStream
+
+// EventStream passes the deduplicated indexer events to the connected server-sent-events clients.
+// events are encoded once and fanned out to all subscribers of the topic, slow subscribers miss events instead of delaying the indexer.
+type EventStream struct {
+	logger      logrus.FieldLogger
+	chainState  *consensus.ChainState
+	mutex       sync.RWMutex
+	subscribers map[*EventStreamSubscriber]bool
+}
+
+// EventStreamSubscriber is a client connected to the event stream
+type EventStreamSubscriber struct {
+	topics    map[string]bool
+	Messages  chan *EventStreamMessage
+	dropCount atomic.Uint64
+}
+
+// EventStreamMessage is an encoded event of the event stream
+type EventStreamMessage struct {
+	Event string
+	Data  []byte
+}
+
+type EventStreamHead struct {
+	Slot      uint64 `json:"slot"`
+	Epoch     uint64 `json:"epoch"`
+	BlockRoot string `json:"block"`
+}
+
+type EventStreamFinalizedCheckpoint struct {
+	Epoch     uint64 `json:"epoch"`
+	BlockRoot string `json:"block"` // root of the last canonical block of the finalized epoch
+}
+
+// registerEventStream creates the global event stream and adds the event hook to the beacon indexer
+func registerEventStream(logger logrus.FieldLogger, beaconIndexer *beacon.Indexer, chainState *consensus.ChainState) {
+	GlobalEventStream = &EventStream{
+		logger:      logger,
+		chainState:  chainState,
+		subscribers: map[*EventStreamSubscriber]bool{},
+	}
+
+	beaconIndexer.AddEventHook(GlobalEventStream.processEvent)
+}
+
+// Subscribe adds a new subscriber for the given topics
+func (stream *EventStream) Subscribe(topics []string) (*EventStreamSubscriber, error) {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+
+	if uint64(len(stream.subscribers)) >= utils.Config.Api.EventStream.MaxClients {
+		return nil, ErrEventStreamFull
+	}
+
+	subscriber := &EventStreamSubscriber{
+		topics:   map[string]bool{},
+		Messages: make(chan *EventStreamMessage, utils.Config.Api.EventStream.BufferSize),
+	}
+	for _, topic := range topics {
+		subscriber.topics[topic] = true
+	}
+
+	stream.subscribers[subscriber] = true
+	return subscriber, nil
+}
+
+// Unsubscribe removes a subscriber
+func (stream *EventStream) Unsubscribe(subscriber *EventStreamSubscriber) {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+
+	delete(stream.subscribers, subscriber)
+
+	if dropCount := subscriber.dropCount.Load(); dropCount > 0 {
+		stream.logger.Debugf("event stream client disconnected, %v events have been dropped", dropCount)
+	}
+}
+
+// processEvent converts an indexer event to a stream message and passes it to the subscribers of its topic
+func (stream *EventStream) processEvent(event *beacon.IndexerEvent) {
+	switch event.Type {
+	case beacon.IndexerEventHead:
+		stream.publish("head", &EventStreamHead{
+			Slot:      uint64(event.Block.Slot),
+			Epoch:     uint64(stream.chainState.EpochOfSlot(event.Block.Slot)),
+			BlockRoot: event.Block.Root.String(),
+		})
+
+	case beacon.IndexerEventNewBlock:
+		if blockData := buildEventExportBlock(stream.chainState, event.Block); blockData != nil {
+			stream.publish("block", blockData)
+		}
+
+	case beacon.IndexerEventReorg:
+		stream.publish("reorg", &EventExportReorg{
+			OldHeadSlot:     uint64(event.OldHead.Slot),
+			OldHeadRoot:     event.OldHead.Root.String(),
+			NewHeadSlot:     uint64(event.Block.Slot),
+			NewHeadRoot:     event.Block.Root.String(),
+			RewindDistance:  event.RewindDistance,
+			ForwardDistance: event.ForwardDistance,
+		})
+
+	case beacon.IndexerEventFinalizedEpoch:
+		checkpointData := &EventStreamFinalizedCheckpoint{
+			Epoch: uint64(event.Epoch),
+		}
+		if len(event.CanonicalBlocks) > 0 {
+			checkpointData.BlockRoot = event.CanonicalBlocks[len(event.CanonicalBlocks)-1].Root.String()
+		}
+		stream.publish("finalized_checkpoint", checkpointData)
+	}
+}
+
+// publish encodes an event and passes it to all subscribers of the topic. events are dropped for subscribers with a full buffer.
+func (stream *EventStream) publish(topic string, data interface{}) {
+	stream.mutex.RLock()
+	defer stream.mutex.RUnlock()
+
+	if len(stream.subscribers) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		stream.logger.Errorf("failed encoding %v event: %v", topic, err)
+		return
+	}
+
+	message := &EventStreamMessage{
+		Event: topic,
+		Data:  payload,
+	}
+
+	for subscriber := range stream.subscribers {
+		if !subscriber.topics[topic] {
+			continue
+		}
+
+		select {
+		case subscriber.Messages <- message:
+		default:
+			subscriber.dropCount.Add(1)
+		}
+	}
+}
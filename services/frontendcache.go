@@ -62,8 +62,35 @@ func StartFrontendCache() error {
 		return nil
 	}
 
-	cachePrefix := fmt.Sprintf("%sgui-", utils.Config.BeaconApi.RedisCachePrefix)
-	tieredCache, err := cache.NewTieredCache(utils.Config.BeaconApi.LocalCacheSize, utils.Config.BeaconApi.RedisCacheAddr, cachePrefix)
+	localCacheSize := utils.Config.BeaconApi.LocalCacheSize
+	redisAddr := utils.Config.BeaconApi.RedisCacheAddr
+	if utils.Config.Frontend.PageCacheRedisAddr != "" {
+		redisAddr = utils.Config.Frontend.PageCacheRedisAddr
+	}
+	redisPrefix := utils.Config.BeaconApi.RedisCachePrefix
+	if utils.Config.Frontend.PageCacheRedisPrefix != "" {
+		redisPrefix = utils.Config.Frontend.PageCacheRedisPrefix
+	}
+
+	switch utils.Config.Frontend.PageCacheBackend {
+	case "", "tiered":
+	case "memory":
+		redisAddr = ""
+	case "redis":
+		if redisAddr == "" {
+			return fmt.Errorf("redis page cache backend requires a redis address")
+		}
+		localCacheSize = 0
+	default:
+		return fmt.Errorf("unknown page cache backend: %v", utils.Config.Frontend.PageCacheBackend)
+	}
+
+	if localCacheSize <= 0 && redisAddr == "" {
+		localCacheSize = 100
+	}
+
+	cachePrefix := fmt.Sprintf("%sgui-", redisPrefix)
+	tieredCache, err := cache.NewTieredCache(localCacheSize, redisAddr, cachePrefix)
 	if err != nil {
 		return err
 	}
@@ -84,6 +111,7 @@ func (fc *FrontendCacheService) ProcessCachedPage(pageKey string, caching bool,
 	if processingPage != nil {
 		fc.processingMutex.Unlock()
 		logrus.Debugf("page already processing: %v", pageKey)
+		GlobalPageMetrics.trackModelCall(pageKey, PageModelShared, 0)
 
 		processingPage.modelMutex.RLock()
 		defer processingPage.modelMutex.RUnlock()
@@ -125,6 +153,7 @@ func (fc *FrontendCacheService) processPageCall(pageKey string, caching bool, pa
 	go func(callIdx uint64) {
 		defer func() {
 			if err := recover(); err != nil {
+				GlobalPageMetrics.trackModelCall(pageKey, PageModelError, 0)
 				errorChan <- &FrontendCachePageError{
 					name:  "page panic",
 					err:   fmt.Errorf("page call %v panic: %v", callIdx, err),
@@ -138,6 +167,7 @@ func (fc *FrontendCacheService) processPageCall(pageKey string, caching bool, pa
 		// check cache
 		if !utils.Config.Frontend.Debug && caching && fc.getFrontendCache(pageKey, pageData) == nil {
 			logrus.Debugf("page served from cache: %v", pageKey)
+			GlobalPageMetrics.trackModelCall(pageKey, PageModelCached, 0)
 			if !isTimedOut {
 				returnChan <- pageData
 			}
@@ -145,11 +175,17 @@ func (fc *FrontendCacheService) processPageCall(pageKey string, caching bool, pa
 		}
 
 		// process page call
+		buildStart := time.Now()
 		pageData = buildFn(pageCall)
 
 		if isTimedOut {
 			return
 		}
+		if caching {
+			GlobalPageMetrics.trackModelCall(pageKey, PageModelBuilt, time.Since(buildStart))
+		} else {
+			GlobalPageMetrics.trackModelCall(pageKey, PageModelUncached, time.Since(buildStart))
+		}
 		if !utils.Config.Frontend.Debug && caching && pageCall.CacheTimeout >= 0 {
 			fc.setFrontendCache(pageKey, pageData, pageCall.CacheTimeout)
 		}
@@ -171,6 +207,7 @@ func (fc *FrontendCacheService) processPageCall(pageKey string, caching bool, pa
 	case <-time.After(callTimeout):
 		isTimedOut = true
 		callCtxCancel()
+		GlobalPageMetrics.trackModelCall(pageKey, PageModelTimeout, callTimeout)
 		return nil, &FrontendCachePageError{
 			name:  "page timeout",
 			err:   fmt.Errorf("page call %v timeout", callIdx),
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/clientheads"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// HeadDivergenceWebhookPayload is the body POSTed to the configured head divergence webhooks
+type HeadDivergenceWebhookPayload struct {
+	Event          string `json:"event"` // "opened" or "resolved"
+	Network        string `json:"network"`
+	Client         string `json:"client"`
+	Reason         string `json:"reason"` // "fork", "lagging" or "finality"
+	StartSlot      uint64 `json:"start_slot"`
+	EndSlot        uint64 `json:"end_slot"`
+	HeadSlot       uint64 `json:"head_slot"`
+	HeadRoot       string `json:"head_root"`
+	CanonicalSlot  uint64 `json:"canonical_slot"`
+	CanonicalRoot  string `json:"canonical_root"`
+	FinalizedEpoch uint64 `json:"finalized_epoch"`
+	MaxDistance    uint64 `json:"max_distance"` // max slots the client head was behind the canonical head
+}
+
+// newHeadDivergenceWebhookHook returns a divergence hook that sends client head divergences to the configured webhooks.
+func newHeadDivergenceWebhookHook(logger logrus.FieldLogger, chainState *consensus.ChainState) clientheads.DivergenceHook {
+	sender := newWebhookSender(logger, "head divergence", utils.Config.Webhooks.HeadDivergence)
+
+	return func(divergence *dbtypes.ClientHeadDivergence) {
+		payload := &HeadDivergenceWebhookPayload{
+			Event:          "opened",
+			Network:        getNetworkName(chainState),
+			Client:         divergence.ClientName,
+			Reason:         divergence.Reason,
+			StartSlot:      divergence.StartSlot,
+			EndSlot:        divergence.EndSlot,
+			HeadSlot:       divergence.HeadSlot,
+			HeadRoot:       fmt.Sprintf("0x%x", divergence.HeadRoot),
+			CanonicalSlot:  divergence.CanonicalSlot,
+			CanonicalRoot:  fmt.Sprintf("0x%x", divergence.CanonicalRoot),
+			FinalizedEpoch: divergence.FinalizedEpoch,
+			MaxDistance:    divergence.MaxDistance,
+		}
+		if divergence.Resolved {
+			payload.Event = "resolved"
+		}
+
+		sender.send(payload)
+	}
+}
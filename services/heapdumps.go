@@ -0,0 +1,208 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/utils"
+)
+
+// HeapDumper captures heap profiles to the configured directory, either on demand or automatically when the process RSS crosses the configured threshold.
+type HeapDumper struct {
+	logger       logrus.FieldLogger
+	dumpMutex    sync.Mutex
+	lastAutoDump time.Time
+}
+
+// HeapDump describes a captured heap profile
+type HeapDump struct {
+	Name   string
+	Size   int64
+	Time   time.Time
+	Reason string
+	Rss    uint64 // process RSS in bytes at capture time
+}
+
+var GlobalHeapDumper *HeapDumper
+
+// StartHeapDumper is used to start the global heap dumper
+func StartHeapDumper(logger logrus.FieldLogger) error {
+	if GlobalHeapDumper != nil {
+		return nil
+	}
+
+	err := os.MkdirAll(utils.Config.Diagnostics.HeapDumpDir, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed creating heap dump directory: %w", err)
+	}
+
+	GlobalHeapDumper = &HeapDumper{
+		logger: logger,
+	}
+
+	if utils.Config.Diagnostics.HeapDumpRssThreshold > 0 {
+		go GlobalHeapDumper.runRssWatcher()
+	}
+
+	return nil
+}
+
+func (hd *HeapDumper) runRssWatcher() {
+	defer utils.HandleSubroutinePanic("HeapDumper.runRssWatcher", hd.runRssWatcher)
+
+	threshold := utils.Config.Diagnostics.HeapDumpRssThreshold * 1024 * 1024
+	for {
+		time.Sleep(utils.Config.Diagnostics.HeapDumpInterval)
+
+		rss := GetProcessRss()
+		if rss < threshold || time.Since(hd.lastAutoDump) < utils.Config.Diagnostics.HeapDumpCooldown {
+			continue
+		}
+
+		hd.lastAutoDump = time.Now()
+		hd.logger.Warnf("process RSS (%v MB) crossed heap dump threshold (%v MB)", rss/1024/1024, utils.Config.Diagnostics.HeapDumpRssThreshold)
+		_, err := hd.CaptureHeapDump("rss")
+		if err != nil {
+			hd.logger.WithError(err).Errorf("failed capturing heap dump")
+		}
+	}
+}
+
+// CaptureHeapDump writes a heap profile to the heap dump directory and prunes the oldest dumps beyond the configured limit.
+func (hd *HeapDumper) CaptureHeapDump(reason string) (*HeapDump, error) {
+	hd.dumpMutex.Lock()
+	defer hd.dumpMutex.Unlock()
+
+	// run a gc first, so the profile reflects the current live heap
+	runtime.GC()
+
+	dump := &HeapDump{
+		Time:   time.Now(),
+		Reason: reason,
+		Rss:    GetProcessRss(),
+	}
+	dump.Name = fmt.Sprintf("heap-%v-%v-%v.pprof", dump.Time.UTC().Format("20060102-150405.000"), reason, dump.Rss/1024/1024)
+
+	var buf bytes.Buffer
+	err := pprof.Lookup("heap").WriteTo(&buf, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed writing heap profile: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(utils.Config.Diagnostics.HeapDumpDir, dump.Name), buf.Bytes(), 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed writing heap dump file: %w", err)
+	}
+	dump.Size = int64(buf.Len())
+
+	hd.logger.Infof("captured heap dump %v (%v bytes)", dump.Name, dump.Size)
+	hd.pruneHeapDumps()
+
+	return dump, nil
+}
+
+// pruneHeapDumps removes the oldest heap dumps beyond the configured limit
+func (hd *HeapDumper) pruneHeapDumps() {
+	dumps, err := hd.GetHeapDumps()
+	if err != nil {
+		hd.logger.WithError(err).Warnf("failed listing heap dumps")
+		return
+	}
+
+	for idx := utils.Config.Diagnostics.HeapDumpMaxFiles; idx < len(dumps); idx++ {
+		err := os.Remove(filepath.Join(utils.Config.Diagnostics.HeapDumpDir, dumps[idx].Name))
+		if err != nil {
+			hd.logger.WithError(err).Warnf("failed removing heap dump %v", dumps[idx].Name)
+		}
+	}
+}
+
+// GetHeapDumps returns the heap dumps in the heap dump directory, newest first
+func (hd *HeapDumper) GetHeapDumps() ([]*HeapDump, error) {
+	entries, err := os.ReadDir(utils.Config.Diagnostics.HeapDumpDir)
+	if err != nil {
+		return nil, err
+	}
+
+	dumps := make([]*HeapDump, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !isHeapDumpName(entry.Name()) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		dump := &HeapDump{
+			Name: entry.Name(),
+			Size: info.Size(),
+			Time: info.ModTime(),
+		}
+
+		// heap-<date>-<time>-<reason>-<rss mb>.pprof
+		nameParts := strings.Split(strings.TrimSuffix(entry.Name(), ".pprof"), "-")
+		if len(nameParts) == 5 {
+			dump.Reason = nameParts[3]
+			rss, _ := strconv.ParseUint(nameParts[4], 10, 64)
+			dump.Rss = rss * 1024 * 1024
+		}
+
+		dumps = append(dumps, dump)
+	}
+
+	sort.Slice(dumps, func(a, b int) bool {
+		return dumps[a].Time.After(dumps[b].Time)
+	})
+
+	return dumps, nil
+}
+
+// GetHeapDumpPath returns the file path of a heap dump, or an error if there is no heap dump with the given name
+func (hd *HeapDumper) GetHeapDumpPath(name string) (string, error) {
+	if !isHeapDumpName(name) || filepath.Base(name) != name {
+		return "", fmt.Errorf("invalid heap dump name")
+	}
+
+	path := filepath.Join(utils.Config.Diagnostics.HeapDumpDir, name)
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("heap dump not found")
+	}
+
+	return path, nil
+}
+
+func isHeapDumpName(name string) bool {
+	return strings.HasPrefix(name, "heap-") && strings.HasSuffix(name, ".pprof")
+}
+
+// GetProcessRss returns the resident set size of the process in bytes.
+// falls back to the memory obtained from the os by the go runtime if /proc is not available.
+func GetProcessRss() uint64 {
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err == nil {
+		fields := strings.Fields(string(statm))
+		if len(fields) > 1 {
+			rssPages, err := strconv.ParseUint(fields[1], 10, 64)
+			if err == nil {
+				return rssPages * uint64(os.Getpagesize())
+			}
+		}
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	return memStats.Sys
+}
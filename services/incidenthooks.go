@@ -0,0 +1,47 @@
+package services
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// IncidentWebhookPayload is the body POSTed to the configured incident webhooks
+type IncidentWebhookPayload struct {
+	Event              string `json:"event"` // "opened" or "resolved"
+	Network            string `json:"network"`
+	Entity             string `json:"entity"`
+	StartEpoch         uint64 `json:"start_epoch"`
+	EndEpoch           uint64 `json:"end_epoch"`
+	Validators         uint64 `json:"validators"`
+	MissedAttestations uint64 `json:"missed_attestations"`
+	MissedProposals    uint64 `json:"missed_proposals"`
+	EstimatedLoss      uint64 `json:"estimated_loss"` // gwei
+}
+
+// newIncidentWebhookHook returns an incident hook that sends incident updates to the configured webhooks.
+func newIncidentWebhookHook(logger logrus.FieldLogger, chainState *consensus.ChainState) beacon.IncidentHook {
+	sender := newWebhookSender(logger, "incident", utils.Config.Webhooks.Incidents)
+
+	return func(incident *dbtypes.ValidatorIncident) {
+		payload := &IncidentWebhookPayload{
+			Event:              "opened",
+			Network:            getNetworkName(chainState),
+			Entity:             incident.Entity,
+			StartEpoch:         incident.StartEpoch,
+			EndEpoch:           incident.EndEpoch,
+			Validators:         incident.Validators,
+			MissedAttestations: incident.MissedAttestations,
+			MissedProposals:    incident.MissedProposals,
+			EstimatedLoss:      incident.EstimatedLoss,
+		}
+		if incident.Resolved {
+			payload.Event = "resolved"
+		}
+
+		sender.send(payload)
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+const leaderLeaseName = "indexer.leader"
+
+// LeaderElection holds a db lease to elect a single writer instance in a multi-replica setup.
+// all replicas run the indexer, but only the lease holder writes to the db. the others run as hot-standby
+// and take over as soon as the lease of the leader expires.
+// every write transaction of the leader checks the lease fencing token, so a stalled leader cannot write after its lease has been taken over.
+type LeaderElection struct {
+	logger      logrus.FieldLogger
+	holderId    string
+	timeout     time.Duration
+	mutex       sync.Mutex
+	isLeader    bool
+	token       uint64
+	onPromotion func()
+}
+
+// NewLeaderElection creates a new leader election instance with a unique holder id.
+func NewLeaderElection(logger logrus.FieldLogger, timeout time.Duration) *LeaderElection {
+	return &LeaderElection{
+		logger:   logger,
+		holderId: newLeaseHolderId(),
+		timeout:  timeout,
+	}
+}
+
+// newLeaseHolderId returns a unique db lease holder id for this instance.
+func newLeaseHolderId() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+
+	return fmt.Sprintf("%v-%v-%x", hostname, os.Getpid(), rand.Uint32())
+}
+
+// IsLeader returns true if this instance currently holds the leader lease.
+func (le *LeaderElection) IsLeader() bool {
+	le.mutex.Lock()
+	defer le.mutex.Unlock()
+	return le.isLeader
+}
+
+// Start tries to acquire the leader lease and starts the background loop to renew / acquire the lease.
+// the onPromotion callback is called when a standby instance becomes the leader.
+// returns true if this instance is the leader right away.
+func (le *LeaderElection) Start(onPromotion func()) bool {
+	le.onPromotion = onPromotion
+
+	token, isLeader, err := le.tryAcquire()
+	if err != nil {
+		le.logger.Errorf("error acquiring leader lease: %v", err)
+	}
+
+	le.mutex.Lock()
+	le.isLeader = isLeader
+	le.mutex.Unlock()
+
+	if isLeader {
+		le.setWriteFence(token)
+	}
+
+	if isLeader {
+		le.logger.Infof("acquired leader lease (holder: %v), running as writer", le.holderId)
+	} else {
+		holder, _, _ := db.GetIndexerLeaseHolder(leaderLeaseName)
+		le.logger.Infof("leader lease held by %v, running as hot-standby (holder: %v)", holder, le.holderId)
+	}
+
+	go le.runLeaseLoop()
+
+	return isLeader
+}
+
+// Stop releases the leader lease if it is held by this instance.
+func (le *LeaderElection) Stop() {
+	le.mutex.Lock()
+	defer le.mutex.Unlock()
+
+	if !le.isLeader {
+		return
+	}
+
+	err := db.RunUnfencedDBTransaction(func(tx *sqlx.Tx) error {
+		return db.ReleaseIndexerLease(leaderLeaseName, le.holderId, tx)
+	})
+	if err != nil {
+		le.logger.Errorf("error releasing leader lease: %v", err)
+		return
+	}
+
+	le.isLeader = false
+	db.SetWriteFence(nil)
+	le.logger.Infof("released leader lease")
+}
+
+func (le *LeaderElection) tryAcquire() (uint64, bool, error) {
+	token := uint64(0)
+	acquired := false
+	err := db.RunUnfencedDBTransaction(func(tx *sqlx.Tx) error {
+		var err error
+		token, acquired, err = db.AcquireIndexerLease(leaderLeaseName, le.holderId, le.timeout, tx)
+		return err
+	})
+	return token, acquired, err
+}
+
+// setWriteFence fences all following db writes to the lease with the given fencing token.
+func (le *LeaderElection) setWriteFence(token uint64) {
+	le.mutex.Lock()
+	le.token = token
+	le.mutex.Unlock()
+
+	db.SetWriteFence(func(tx *sqlx.Tx) error {
+		return db.CheckIndexerLease(leaderLeaseName, le.holderId, token, tx)
+	})
+}
+
+func (le *LeaderElection) runLeaseLoop() {
+	lastRenewal := time.Now()
+
+	for {
+		time.Sleep(le.timeout / 3)
+
+		token, acquired, err := le.tryAcquire()
+		if err != nil {
+			le.logger.Warnf("error renewing leader lease: %v", err)
+		}
+
+		le.mutex.Lock()
+		wasLeader := le.isLeader
+		leaderToken := le.token
+		if err == nil {
+			le.isLeader = acquired
+		}
+		le.mutex.Unlock()
+
+		if wasLeader {
+			if err == nil && acquired && token == leaderToken {
+				lastRenewal = time.Now()
+				continue
+			}
+
+			if err == nil || time.Since(lastRenewal) >= le.timeout {
+				// the lease is lost and another instance may already be writing to the db.
+				// the in-memory writer state cannot be safely handed back, so restart as hot-standby.
+				le.logger.Fatalf("lost leader lease, shutting down to restart as hot-standby")
+			}
+		} else if acquired {
+			lastRenewal = time.Now()
+			le.setWriteFence(token)
+			le.logger.Infof("acquired leader lease (holder: %v, token: %v), promoting to writer", le.holderId, token)
+			if le.onPromotion != nil {
+				le.onPromotion()
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+const onlineMigrationLeaseName = "schema.online-migrations"
+const onlineMigrationLeaseTimeout = 1 * time.Minute
+const onlineMigrationProgressInterval = 1 * time.Minute
+
+// OnlineMigrationRunner processes the backfills of online schema migrations in background.
+// schema migrations on large tables add the new column without rewriting the table and register a backfill in the online_migrations table,
+// the runner backfills the rows in small key range chunks, so the table is never locked for longer than a chunk.
+// the progress is persisted after each chunk, so the backfill continues where it stopped after a restart.
+// a db lease ensures only one instance processes the backfills in multi-replica setups.
+type OnlineMigrationRunner struct {
+	logger   logrus.FieldLogger
+	holderId string
+}
+
+// StartOnlineMigrations starts the background runner for unfinished online migrations.
+func StartOnlineMigrations(logger logrus.FieldLogger) {
+	runner := &OnlineMigrationRunner{
+		logger:   logger,
+		holderId: newLeaseHolderId(),
+	}
+
+	go runner.run()
+}
+
+func (runner *OnlineMigrationRunner) run() {
+	defer utils.HandleSubroutinePanic("OnlineMigrationRunner.run", nil)
+
+	for {
+		migrations, err := db.GetOnlineMigrations()
+		if err != nil {
+			runner.logger.Errorf("error loading online migrations: %v", err)
+			return
+		}
+
+		interrupted := false
+		for _, migration := range migrations {
+			if migration.FinishedAt > 0 {
+				continue
+			}
+
+			if !runner.processMigration(migration) {
+				interrupted = true
+				break
+			}
+		}
+
+		if !interrupted {
+			return
+		}
+
+		// lease held by another instance or migration failed, retry later
+		time.Sleep(onlineMigrationLeaseTimeout)
+	}
+}
+
+// processMigration runs the backfill of a migration to completion.
+// returns false if the backfill has been interrupted (lease held by another instance or error).
+func (runner *OnlineMigrationRunner) processMigration(migration *dbtypes.OnlineMigration) bool {
+	chunkDelay := utils.Config.Database.OnlineMigrationDelay
+	lastProgress := time.Now()
+
+	if migration.NextKey == nil {
+		runner.logger.Infof("starting online migration %v (table: %v)", migration.Name, migration.TableName)
+	} else {
+		runner.logger.Infof("resuming online migration %v at key %v/%v", migration.Name, *migration.NextKey, *migration.MaxKey)
+	}
+
+	for {
+		acquired := false
+		completed := false
+		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+			var err error
+			_, acquired, err = db.AcquireIndexerLease(onlineMigrationLeaseName, runner.holderId, onlineMigrationLeaseTimeout, tx)
+			if err != nil || !acquired {
+				return err
+			}
+
+			if migration.NextKey == nil {
+				return db.StartOnlineMigration(migration, tx)
+			}
+
+			completed, err = db.RunOnlineMigrationChunk(migration, tx)
+			return err
+		})
+		if err != nil {
+			runner.logger.Errorf("online migration %v failed at key %v: %v", migration.Name, migration.NextKey, err)
+			if err := db.SetOnlineMigrationError(migration.Name, err.Error()); err != nil {
+				runner.logger.Warnf("failed persisting online migration error: %v", err)
+			}
+			return false
+		}
+
+		if !acquired {
+			runner.logger.Infof("online migration %v is processed by another instance", migration.Name)
+			return false
+		}
+
+		if completed {
+			break
+		}
+
+		if time.Since(lastProgress) >= onlineMigrationProgressInterval {
+			lastProgress = time.Now()
+			runner.logger.Infof("online migration %v: key %v/%v, %v rows updated", migration.Name, *migration.NextKey, *migration.MaxKey, migration.RowsDone)
+		}
+
+		if chunkDelay > 0 {
+			time.Sleep(chunkDelay)
+		}
+	}
+
+	err := db.FinishOnlineMigration(migration)
+	if err != nil {
+		runner.logger.Errorf("online migration %v failed: %v", migration.Name, err)
+		if err := db.SetOnlineMigrationError(migration.Name, err.Error()); err != nil {
+			runner.logger.Warnf("failed persisting online migration error: %v", err)
+		}
+		return false
+	}
+
+	runner.logger.Infof("online migration %v completed (%v rows updated)", migration.Name, migration.RowsDone)
+
+	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.ReleaseIndexerLease(onlineMigrationLeaseName, runner.holderId, tx)
+	})
+	if err != nil {
+		runner.logger.Warnf("failed releasing online migration lease: %v", err)
+	}
+
+	return true
+}
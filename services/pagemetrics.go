@@ -0,0 +1,222 @@
+package services
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// page model build outcomes
+const (
+	PageModelBuilt    = "built"    // model built, not cached
+	PageModelCached   = "cached"   // model served from the page cache
+	PageModelShared   = "shared"   // joined a concurrent build of the same page
+	PageModelTimeout  = "timeout"  // page call timed out
+	PageModelError    = "error"    // page call panicked
+	PageModelUncached = "uncached" // model built, caching disabled for the call
+)
+
+// PageMetrics collects timing statistics of the frontend pages.
+// requests are tracked per route with the time spent on data building & template rendering,
+// page models are tracked per page type (prefix of the page cache key) with the build duration & cache outcome.
+type PageMetrics struct {
+	mutex  sync.Mutex
+	since  time.Time
+	routes map[string]*PageRouteStats
+	models map[string]*PageModelStats
+
+	requestsTotal     *prometheus.CounterVec
+	buildSeconds      *prometheus.HistogramVec
+	renderSeconds     *prometheus.HistogramVec
+	responseBytes     *prometheus.CounterVec
+	modelBuildsTotal  *prometheus.CounterVec
+	modelBuildSeconds *prometheus.HistogramVec
+}
+
+// PageMetricsStats is the breakdown of the collected page metrics.
+type PageMetricsStats struct {
+	Since  time.Time         `json:"since"`
+	Routes []*PageRouteStats `json:"routes"`
+	Models []*PageModelStats `json:"models"`
+}
+
+// PageRouteStats holds the request statistics of a frontend route.
+type PageRouteStats struct {
+	Route         string        `json:"route"`
+	Requests      uint64        `json:"requests"`
+	Errors        uint64        `json:"errors"` // responses with status >= 500
+	BuildTime     time.Duration `json:"build_time"`
+	MaxBuildTime  time.Duration `json:"max_build_time"`
+	RenderTime    time.Duration `json:"render_time"`
+	MaxRenderTime time.Duration `json:"max_render_time"`
+	ResponseBytes uint64        `json:"response_bytes"`
+}
+
+// PageModelStats holds the page model build statistics of a page type.
+type PageModelStats struct {
+	Page         string            `json:"page"`
+	Calls        uint64            `json:"calls"`
+	Builds       uint64            `json:"builds"`
+	BuildTime    time.Duration     `json:"build_time"`
+	MaxBuildTime time.Duration     `json:"max_build_time"`
+	Outcomes     map[string]uint64 `json:"outcomes"`
+}
+
+var GlobalPageMetrics = newPageMetrics()
+
+func newPageMetrics() *PageMetrics {
+	metrics := &PageMetrics{
+		since:  time.Now(),
+		routes: map[string]*PageRouteStats{},
+		models: map[string]*PageModelStats{},
+
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dora_page_requests_total",
+			Help: "Number of frontend page requests by route and status code.",
+		}, []string{"route", "status"}),
+		buildSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "dora_page_build_seconds",
+			Help:    "Time until the first response byte of a frontend page (data building).",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+		}, []string{"route"}),
+		renderSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "dora_page_render_seconds",
+			Help:    "Time from the first to the last response byte of a frontend page (template rendering).",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{"route"}),
+		responseBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dora_page_response_bytes_total",
+			Help: "Number of response bytes sent for frontend pages.",
+		}, []string{"route"}),
+		modelBuildsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "dora_page_model_calls_total",
+			Help: "Number of page model calls by page type and cache outcome.",
+		}, []string{"page", "outcome"}),
+		modelBuildSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "dora_page_model_build_seconds",
+			Help:    "Duration of page model builds (excluding cache hits).",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+		}, []string{"page"}),
+	}
+
+	prometheus.MustRegister(
+		metrics.requestsTotal,
+		metrics.buildSeconds,
+		metrics.renderSeconds,
+		metrics.responseBytes,
+		metrics.modelBuildsTotal,
+		metrics.modelBuildSeconds,
+	)
+
+	return metrics
+}
+
+// getPageType returns the page type of a page cache key (the part before the first colon)
+func getPageType(pageKey string) string {
+	pageType, _, _ := strings.Cut(pageKey, ":")
+	return pageType
+}
+
+// trackModelCall records a page model call with its outcome, buildTime is 0 if the model has not been built.
+func (metrics *PageMetrics) trackModelCall(pageKey string, outcome string, buildTime time.Duration) {
+	pageType := getPageType(pageKey)
+
+	metrics.modelBuildsTotal.WithLabelValues(pageType, outcome).Inc()
+	if buildTime > 0 {
+		metrics.modelBuildSeconds.WithLabelValues(pageType).Observe(buildTime.Seconds())
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	stats := metrics.models[pageType]
+	if stats == nil {
+		stats = &PageModelStats{
+			Page:     pageType,
+			Outcomes: map[string]uint64{},
+		}
+		metrics.models[pageType] = stats
+	}
+
+	stats.Calls++
+	stats.Outcomes[outcome]++
+	if buildTime > 0 {
+		stats.Builds++
+		stats.BuildTime += buildTime
+		if buildTime > stats.MaxBuildTime {
+			stats.MaxBuildTime = buildTime
+		}
+	}
+}
+
+// TrackRequest records a completed page request.
+// buildTime is the time to the first response byte (data building), renderTime the remaining response time (template rendering).
+func (metrics *PageMetrics) TrackRequest(route string, status int, buildTime time.Duration, renderTime time.Duration, responseBytes uint64) {
+	metrics.requestsTotal.WithLabelValues(route, strconv.Itoa(status)).Inc()
+	metrics.buildSeconds.WithLabelValues(route).Observe(buildTime.Seconds())
+	metrics.renderSeconds.WithLabelValues(route).Observe(renderTime.Seconds())
+	metrics.responseBytes.WithLabelValues(route).Add(float64(responseBytes))
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	stats := metrics.routes[route]
+	if stats == nil {
+		stats = &PageRouteStats{
+			Route: route,
+		}
+		metrics.routes[route] = stats
+	}
+
+	stats.Requests++
+	if status >= 500 {
+		stats.Errors++
+	}
+	stats.BuildTime += buildTime
+	if buildTime > stats.MaxBuildTime {
+		stats.MaxBuildTime = buildTime
+	}
+	stats.RenderTime += renderTime
+	if renderTime > stats.MaxRenderTime {
+		stats.MaxRenderTime = renderTime
+	}
+	stats.ResponseBytes += responseBytes
+}
+
+// GetStats returns a copy of the collected page metrics, routes & page types are sorted by total time spent.
+func (metrics *PageMetrics) GetStats() *PageMetricsStats {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	stats := &PageMetricsStats{
+		Since:  metrics.since,
+		Routes: make([]*PageRouteStats, 0, len(metrics.routes)),
+		Models: make([]*PageModelStats, 0, len(metrics.models)),
+	}
+
+	for _, routeStats := range metrics.routes {
+		routeCopy := *routeStats
+		stats.Routes = append(stats.Routes, &routeCopy)
+	}
+	sort.Slice(stats.Routes, func(a, b int) bool {
+		return stats.Routes[a].BuildTime+stats.Routes[a].RenderTime > stats.Routes[b].BuildTime+stats.Routes[b].RenderTime
+	})
+
+	for _, modelStats := range metrics.models {
+		modelCopy := *modelStats
+		modelCopy.Outcomes = make(map[string]uint64, len(modelStats.Outcomes))
+		for outcome, count := range modelStats.Outcomes {
+			modelCopy.Outcomes[outcome] = count
+		}
+		stats.Models = append(stats.Models, &modelCopy)
+	}
+	sort.Slice(stats.Models, func(a, b int) bool {
+		return stats.Models[a].BuildTime > stats.Models[b].BuildTime
+	})
+
+	return stats
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const (
+	poolClientLayerConsensus uint8 = 1
+	poolClientLayerExecution uint8 = 2
+)
+
+// poolClientRegistry assigns stable ids to the configured consensus & execution clients and persists their metadata & version history.
+// clients are matched by their endpoint fingerprint (hash of the redacted endpoint url) or by name if the endpoint changed,
+// so data keyed by the stable client id survives restarts, renames & endpoint changes.
+// the registry is only run by the writer instance.
+type poolClientRegistry struct {
+	logger        logrus.FieldLogger
+	consensusPool *consensus.Pool
+	executionPool *execution.Pool
+	mutex         sync.Mutex
+	loaded        bool
+	running       bool
+	lastId        uint64
+	clients       map[uint64]*dbtypes.PoolClient
+	versions      map[uint64]map[string]*dbtypes.PoolClientVersion
+}
+
+// poolClientRef is a configured client of the consensus or execution pool
+type poolClientRef struct {
+	layer      uint8
+	url        string
+	clientType int8
+	online     bool
+	client     interface {
+		GetName() string
+		GetVersion() string
+		GetClientId() uint64
+		SetClientId(clientId uint64)
+	}
+}
+
+func newPoolClientRegistry(logger logrus.FieldLogger, consensusPool *consensus.Pool, executionPool *execution.Pool) *poolClientRegistry {
+	return &poolClientRegistry{
+		logger:        logger,
+		consensusPool: consensusPool,
+		executionPool: executionPool,
+		clients:       map[uint64]*dbtypes.PoolClient{},
+		versions:      map[uint64]map[string]*dbtypes.PoolClientVersion{},
+	}
+}
+
+// StartUpdater starts the loop that refreshes the metadata & version history of the pool clients every 5 minutes.
+func (registry *poolClientRegistry) StartUpdater() {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if registry.running {
+		return
+	}
+
+	registry.running = true
+	go registry.runUpdaterLoop()
+}
+
+func (registry *poolClientRegistry) runUpdaterLoop() {
+	defer utils.HandleSubroutinePanic("poolClientRegistry.runUpdaterLoop", registry.runUpdaterLoop)
+
+	for {
+		if err := registry.updateClients(); err != nil {
+			registry.logger.Errorf("pool client update error: %v", err)
+		}
+
+		time.Sleep(5 * time.Minute)
+	}
+}
+
+// getPoolClientFingerprint returns the fingerprint of a client endpoint.
+// credentials are redacted before hashing, so rotating them does not change the fingerprint.
+func getPoolClientFingerprint(url string) []byte {
+	hash := sha256.Sum256([]byte(utils.GetRedactedUrl(url)))
+	return hash[:]
+}
+
+func (registry *poolClientRegistry) getClientRefs() []*poolClientRef {
+	refs := []*poolClientRef{}
+
+	for _, client := range registry.consensusPool.GetAllEndpoints() {
+		refs = append(refs, &poolClientRef{
+			layer:      poolClientLayerConsensus,
+			url:        client.GetEndpointConfig().URL,
+			clientType: int8(client.GetClientType()),
+			online:     client.GetStatus() == consensus.ClientStatusOnline,
+			client:     client,
+		})
+	}
+
+	for _, client := range registry.executionPool.GetAllEndpoints() {
+		refs = append(refs, &poolClientRef{
+			layer:      poolClientLayerExecution,
+			url:        client.GetEndpointConfig().URL,
+			clientType: int8(client.GetClientType()),
+			online:     client.GetStatus() == execution.ClientStatusOnline,
+			client:     client,
+		})
+	}
+
+	return refs
+}
+
+func (registry *poolClientRegistry) loadClients() error {
+	clients, err := db.GetPoolClients()
+	if err != nil {
+		return err
+	}
+
+	versions, err := db.GetPoolClientVersions()
+	if err != nil {
+		return err
+	}
+
+	for _, client := range clients {
+		registry.clients[client.ClientId] = client
+		if client.ClientId > registry.lastId {
+			registry.lastId = client.ClientId
+		}
+	}
+
+	for _, version := range versions {
+		if registry.versions[version.ClientId] == nil {
+			registry.versions[version.ClientId] = map[string]*dbtypes.PoolClientVersion{}
+		}
+		registry.versions[version.ClientId][version.Version] = version
+	}
+
+	registry.loaded = true
+	return nil
+}
+
+// findClient returns the known pool client that is not assigned yet and matches the fingerprint, or the name as fallback.
+func (registry *poolClientRegistry) findClient(layer uint8, fingerprint []byte, name string, assigned map[uint64]bool) *dbtypes.PoolClient {
+	var nameMatch *dbtypes.PoolClient
+	for _, client := range registry.clients {
+		if client.Layer != layer || assigned[client.ClientId] {
+			continue
+		}
+
+		if bytes.Equal(client.Fingerprint, fingerprint) {
+			return client
+		}
+		if nameMatch == nil && client.Name == name {
+			nameMatch = client
+		}
+	}
+
+	return nameMatch
+}
+
+// updateClients assigns stable ids to all pool clients and persists their metadata & version history.
+func (registry *poolClientRegistry) updateClients() error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if !registry.loaded {
+		if err := registry.loadClients(); err != nil {
+			return fmt.Errorf("failed loading pool clients: %v", err)
+		}
+	}
+
+	now := uint64(time.Now().Unix())
+	refs := registry.getClientRefs()
+
+	assigned := map[uint64]bool{}
+	for _, ref := range refs {
+		if clientId := ref.client.GetClientId(); clientId > 0 {
+			assigned[clientId] = true
+		}
+	}
+
+	updatedClients := []*dbtypes.PoolClient{}
+	updatedVersions := []*dbtypes.PoolClientVersion{}
+
+	for _, ref := range refs {
+		name := ref.client.GetName()
+		fingerprint := getPoolClientFingerprint(ref.url)
+
+		poolClient := registry.clients[ref.client.GetClientId()]
+		if poolClient == nil {
+			poolClient = registry.findClient(ref.layer, fingerprint, name, assigned)
+		}
+
+		updated := false
+		if poolClient == nil {
+			registry.lastId++
+			poolClient = &dbtypes.PoolClient{
+				ClientId:    registry.lastId,
+				Layer:       ref.layer,
+				Name:        name,
+				ClientType:  ref.clientType,
+				Fingerprint: fingerprint,
+				FirstSeen:   now,
+				LastSeen:    now,
+			}
+			registry.clients[poolClient.ClientId] = poolClient
+			updated = true
+			registry.logger.Infof("assigned client id %v to new client %v", poolClient.ClientId, name)
+		}
+
+		if ref.client.GetClientId() != poolClient.ClientId {
+			ref.client.SetClientId(poolClient.ClientId)
+			assigned[poolClient.ClientId] = true
+		}
+
+		if poolClient.Name != name {
+			registry.logger.Infof("client %v (id %v) has been renamed to %v", poolClient.Name, poolClient.ClientId, name)
+			poolClient.Name = name
+			updated = true
+		}
+		if !bytes.Equal(poolClient.Fingerprint, fingerprint) {
+			registry.logger.Infof("endpoint of client %v (id %v) has changed", name, poolClient.ClientId)
+			poolClient.Fingerprint = fingerprint
+			updated = true
+		}
+
+		if ref.online {
+			poolClient.LastSeen = now
+			updated = true
+
+			if ref.clientType > 0 {
+				poolClient.ClientType = ref.clientType
+			}
+
+			if version := ref.client.GetVersion(); version != "" {
+				if len(version) > 200 {
+					version = version[:200]
+				}
+
+				clientVersions := registry.versions[poolClient.ClientId]
+				if clientVersions == nil {
+					clientVersions = map[string]*dbtypes.PoolClientVersion{}
+					registry.versions[poolClient.ClientId] = clientVersions
+				}
+
+				clientVersion := clientVersions[version]
+				if clientVersion == nil {
+					clientVersion = &dbtypes.PoolClientVersion{
+						ClientId:  poolClient.ClientId,
+						Version:   version,
+						FirstSeen: now,
+					}
+					clientVersions[version] = clientVersion
+				}
+				clientVersion.LastSeen = now
+				updatedVersions = append(updatedVersions, clientVersion)
+			}
+		}
+
+		if updated {
+			updatedClients = append(updatedClients, poolClient)
+		}
+	}
+
+	if len(updatedClients) == 0 && len(updatedVersions) == 0 {
+		return nil
+	}
+
+	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		if err := db.InsertPoolClients(updatedClients, tx); err != nil {
+			return err
+		}
+
+		return db.InsertPoolClientVersions(updatedVersions, tx)
+	})
+}
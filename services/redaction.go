@@ -0,0 +1,48 @@
+package services
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/ethpandaops/dora/utils"
+)
+
+// the redaction helpers hide the configured fields on public instances.
+// redaction is applied when the data is presented, so the data is still stored in the db & available to admin api requests.
+
+// RedactClientName returns a stable pseudonym for the client name if client names are redacted.
+// the pseudonym is derived from the name, so it's the same for live & persisted references to the client.
+func RedactClientName(name string) string {
+	if !utils.Config.Redaction.ClientNames || name == "" {
+		return name
+	}
+
+	nameHash := sha256.Sum256([]byte(name))
+	return fmt.Sprintf("client-%x", nameHash[:3])
+}
+
+// RedactValidatorName returns an empty name if validator names are redacted.
+func RedactValidatorName(name string) string {
+	if utils.Config.Redaction.ValidatorNames {
+		return ""
+	}
+	return name
+}
+
+// RedactDepositSender returns nil if deposit tx senders are redacted.
+func RedactDepositSender(sender []byte) []byte {
+	if utils.Config.Redaction.DepositSenders {
+		return nil
+	}
+	return sender
+}
+
+// IsValidatorNameSearchAllowed returns false if validator names are redacted, so they can't be probed via search or filters.
+func IsValidatorNameSearchAllowed() bool {
+	return !utils.Config.Redaction.ValidatorNames
+}
+
+// IsDepositSenderSearchAllowed returns false if deposit tx senders are redacted, so deposits can't be looked up by their sender address.
+func IsDepositSenderSearchAllowed() bool {
+	return !utils.Config.Redaction.DepositSenders
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -36,16 +38,26 @@ type ValidatorNames struct {
 	lastResolvedMapUpdate time.Time
 	lastInventoryRefresh  time.Time
 	updaterRunning        bool
+	reloadRequested       atomic.Bool
 	namesMutex            sync.RWMutex
 	namesByIndex          map[uint64]*validatorNameEntry
 	namesByWithdrawal     map[common.Address]*validatorNameEntry
 	namesByDepositOrigin  map[common.Address]*validatorNameEntry
 	namesByDepositTarget  map[common.Address]*validatorNameEntry
+	namesByCredentials    map[phase0.Root]*validatorNameEntry
+	labelsByAddress       map[common.Address]*validatorNameEntry
 	resolvedNamesByIndex  map[uint64]*validatorNameEntry
 }
 
+// AddressLabel is a labelled execution address
+type AddressLabel struct {
+	Address common.Address
+	Label   string
+}
+
 type validatorNameEntry struct {
-	name string
+	name     string
+	entityId uint64 // id of the db entity, 0 for names from the config
 }
 
 func NewValidatorNames(beaconIndexer *beacon.Indexer, chainState *consensus.ChainState) *ValidatorNames {
@@ -87,7 +99,14 @@ func (vn *ValidatorNames) runUpdaterLoop() {
 func (vn *ValidatorNames) runUpdater() error {
 	needUpdate := false
 
-	if utils.Config.Frontend.ValidatorNamesRefreshInterval > 0 && time.Since(vn.lastInventoryRefresh) > utils.Config.Frontend.ValidatorNamesRefreshInterval {
+	if vn.reloadRequested.Swap(false) {
+		// entities have been changed, reload the names & resolve the entity addresses immediately
+		logger_vn.Infof("reloading validator names after entity update")
+		loadingChan := vn.LoadValidatorNames()
+		<-loadingChan
+		vn.lastResolvedMapUpdate = time.Time{}
+		needUpdate = true
+	} else if utils.Config.Frontend.ValidatorNamesRefreshInterval > 0 && time.Since(vn.lastInventoryRefresh) > utils.Config.Frontend.ValidatorNamesRefreshInterval {
 		logger_vn.Infof("refreshing validator inventory")
 		loadingChan := vn.LoadValidatorNames()
 		<-loadingChan
@@ -106,7 +125,7 @@ func (vn *ValidatorNames) runUpdater() error {
 		}
 	}
 
-	if needUpdate {
+	if needUpdate && !vn.beaconIndexer.IsReadOnly() {
 		err := vn.UpdateDb()
 		if err != nil {
 			return err
@@ -160,6 +179,20 @@ func (vn *ValidatorNames) resolveNames() (bool, error) {
 		}
 	}
 
+	// resolve names by withdrawal credentials
+	for credentials, name := range vn.namesByCredentials {
+		if name == nil {
+			continue
+		}
+
+		validators, _ := GlobalBeaconService.GetFilteredValidatorSet(&dbtypes.ValidatorFilter{
+			CredentialsPrefix: credentials[:],
+		}, false)
+		for _, validator := range validators {
+			addResolved(uint64(validator.Index), name)
+		}
+	}
+
 	// resolve names by depositor address
 	for address := range vn.namesByDepositOrigin {
 		offset := uint64(0)
@@ -261,7 +294,69 @@ func (vn *ValidatorNames) GetValidatorNamesCount() uint64 {
 	if vn.namesByIndex == nil {
 		return 0
 	}
-	return uint64(len(maps.Keys(vn.namesByIndex)) + len(maps.Keys(vn.namesByWithdrawal)))
+	return uint64(len(maps.Keys(vn.namesByIndex)) + len(maps.Keys(vn.namesByWithdrawal)) + len(maps.Keys(vn.namesByCredentials)) + len(maps.Keys(vn.labelsByAddress)))
+}
+
+// GetAddressLabel returns the label of an execution address.
+// explicitly labelled addresses take precedence over the names of withdrawal & deposit address rules.
+func (vn *ValidatorNames) GetAddressLabel(address []byte) string {
+	if len(address) != common.AddressLength || !vn.namesMutex.TryRLock() {
+		return ""
+	}
+	defer vn.namesMutex.RUnlock()
+
+	addr := common.Address(address)
+	for _, labelMap := range []map[common.Address]*validatorNameEntry{
+		vn.labelsByAddress,
+		vn.namesByWithdrawal,
+		vn.namesByDepositOrigin,
+		vn.namesByDepositTarget,
+	} {
+		if label := labelMap[addr]; label != nil {
+			return label.name
+		}
+	}
+
+	return ""
+}
+
+// SearchAddressLabels returns the labelled execution addresses with a label containing the search string (case insensitive), sorted by label.
+func (vn *ValidatorNames) SearchAddressLabels(search string, limit int) []*AddressLabel {
+	vn.namesMutex.RLock()
+	defer vn.namesMutex.RUnlock()
+
+	search = strings.ToLower(search)
+	matches := map[common.Address]*AddressLabel{}
+	for _, labelMap := range []map[common.Address]*validatorNameEntry{
+		vn.labelsByAddress,
+		vn.namesByWithdrawal,
+		vn.namesByDepositOrigin,
+		vn.namesByDepositTarget,
+	} {
+		for address, label := range labelMap {
+			if matches[address] != nil || label == nil || !strings.Contains(strings.ToLower(label.name), search) {
+				continue
+			}
+
+			matches[address] = &AddressLabel{
+				Address: address,
+				Label:   label.name,
+			}
+		}
+	}
+
+	labels := maps.Values(matches)
+	sort.Slice(labels, func(a, b int) bool {
+		if labels[a].Label != labels[b].Label {
+			return labels[a].Label < labels[b].Label
+		}
+		return bytes.Compare(labels[a].Address[:], labels[b].Address[:]) < 0
+	})
+	if limit > 0 && len(labels) > limit {
+		labels = labels[:limit]
+	}
+
+	return labels
 }
 
 func (vn *ValidatorNames) LoadValidatorNames() chan bool {
@@ -286,6 +381,8 @@ func (vn *ValidatorNames) LoadValidatorNames() chan bool {
 		vn.namesByWithdrawal = make(map[common.Address]*validatorNameEntry)
 		vn.namesByDepositOrigin = make(map[common.Address]*validatorNameEntry)
 		vn.namesByDepositTarget = make(map[common.Address]*validatorNameEntry)
+		vn.namesByCredentials = make(map[phase0.Root]*validatorNameEntry)
+		vn.labelsByAddress = make(map[common.Address]*validatorNameEntry)
 		vn.namesMutex.Unlock()
 
 		validatorNamesYaml := utils.Config.Frontend.ValidatorNamesYaml
@@ -293,16 +390,19 @@ func (vn *ValidatorNames) LoadValidatorNames() chan bool {
 			validatorNamesYaml = vn.getDefaultValidatorNames()
 		}
 
-		// load names
-		if strings.HasPrefix(validatorNamesYaml, "~internal/") {
-			err := vn.loadFromInternalYaml(validatorNamesYaml[10:])
-			if err != nil {
-				logger_vn.WithError(err).Errorf("error while loading validator names from internal yaml")
-			}
-		} else if validatorNamesYaml != "" {
-			err := vn.loadFromYaml(validatorNamesYaml)
-			if err != nil {
-				logger_vn.WithError(err).Errorf("error while loading validator names from yaml")
+		// load names, multiple files are comma separated (later files override earlier ones)
+		for _, namesFile := range strings.Split(validatorNamesYaml, ",") {
+			namesFile = strings.TrimSpace(namesFile)
+			if strings.HasPrefix(namesFile, "~internal/") {
+				err := vn.loadFromInternalYaml(namesFile[10:])
+				if err != nil {
+					logger_vn.WithError(err).Errorf("error while loading validator names from internal yaml")
+				}
+			} else if namesFile != "" {
+				err := vn.loadFromYaml(namesFile)
+				if err != nil {
+					logger_vn.WithError(err).Errorf("error while loading validator names from yaml")
+				}
 			}
 		}
 		if utils.Config.Frontend.ValidatorNamesInventory != "" {
@@ -311,6 +411,12 @@ func (vn *ValidatorNames) LoadValidatorNames() chan bool {
 				logger_vn.WithError(err).Errorf("error while loading validator names inventory")
 			}
 		}
+
+		// entities from the db take precedence over the configured names
+		err := vn.loadFromDbEntities()
+		if err != nil {
+			logger_vn.WithError(err).Errorf("error while loading validator names from db entities")
+		}
 	}()
 
 	return vn.loading
@@ -379,6 +485,17 @@ func (vn *ValidatorNames) parseNamesMap(names map[string]string) int {
 				target := common.HexToAddress(rangeParts[1])
 				vn.namesByDepositTarget[target] = nameEntry
 				nameCount++
+			case "withdrawal_credentials", "credentials":
+				credentials := common.FromHex(rangeParts[1])
+				if len(credentials) != 32 {
+					continue
+				}
+				vn.namesByCredentials[phase0.Root(credentials)] = nameEntry
+				nameCount++
+			case "address":
+				address := common.HexToAddress(rangeParts[1])
+				vn.labelsByAddress[address] = nameEntry
+				nameCount++
 			}
 
 		} else {
@@ -403,6 +520,98 @@ func (vn *ValidatorNames) parseNamesMap(names map[string]string) int {
 	return nameCount
 }
 
+func (vn *ValidatorNames) loadFromDbEntities() error {
+	entities, err := db.GetEntities()
+	if err != nil {
+		return err
+	}
+	if len(entities) == 0 {
+		return nil
+	}
+
+	validatorRanges, err := db.GetEntityValidatorRanges(nil)
+	if err != nil {
+		return err
+	}
+	addresses, err := db.GetEntityAddresses(nil)
+	if err != nil {
+		return err
+	}
+
+	entityEntries := make(map[uint64]*validatorNameEntry, len(entities))
+	for _, entity := range entities {
+		entityEntries[entity.EntityId] = &validatorNameEntry{
+			name:     entity.Name,
+			entityId: entity.EntityId,
+		}
+	}
+
+	vn.namesMutex.Lock()
+	defer vn.namesMutex.Unlock()
+	nameCount := 0
+	for _, validatorRange := range validatorRanges {
+		nameEntry := entityEntries[validatorRange.EntityId]
+		if nameEntry == nil {
+			continue
+		}
+
+		for idx := validatorRange.MinIndex; idx <= validatorRange.MaxIndex; idx++ {
+			vn.namesByIndex[idx] = nameEntry
+			nameCount++
+		}
+	}
+	for _, address := range addresses {
+		nameEntry := entityEntries[address.EntityId]
+		if nameEntry == nil {
+			continue
+		}
+
+		switch address.AddressType {
+		case dbtypes.EntityAddressWithdrawal:
+			vn.namesByWithdrawal[common.BytesToAddress(address.Address)] = nameEntry
+		case dbtypes.EntityAddressDepositOrigin:
+			vn.namesByDepositOrigin[common.BytesToAddress(address.Address)] = nameEntry
+		case dbtypes.EntityAddressDepositTarget:
+			vn.namesByDepositTarget[common.BytesToAddress(address.Address)] = nameEntry
+		default:
+			continue
+		}
+		nameCount++
+	}
+
+	logger_vn.Infof("loaded %v validator names from %v db entities", nameCount, len(entities))
+	return nil
+}
+
+// ReloadEntities schedules a reload of the validator names after the db entities have been changed.
+// the names are reloaded & the entity addresses are resolved by the updater loop within the next 30 seconds.
+func (vn *ValidatorNames) ReloadEntities() {
+	vn.reloadRequested.Store(true)
+}
+
+// GetEntityValidatorIndexes returns the sorted indexes of all validators that are named by the given db entity
+func (vn *ValidatorNames) GetEntityValidatorIndexes(entityId uint64) []uint64 {
+	vn.namesMutex.RLock()
+	defer vn.namesMutex.RUnlock()
+
+	indexes := []uint64{}
+	for index, name := range vn.namesByIndex {
+		if name.entityId == entityId {
+			indexes = append(indexes, index)
+		}
+	}
+	for index, name := range vn.resolvedNamesByIndex {
+		if vn.namesByIndex[index] == nil && name.entityId == entityId {
+			indexes = append(indexes, index)
+		}
+	}
+
+	sort.Slice(indexes, func(a, b int) bool {
+		return indexes[a] < indexes[b]
+	})
+	return indexes
+}
+
 type validatorNamesRangesResponse struct {
 	Ranges map[string]string `json:"ranges"`
 }
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// Watchlist is a set of validators defined by index ranges
+type Watchlist struct {
+	Name   string
+	ranges []watchlistRange
+}
+
+type watchlistRange struct {
+	minIndex uint64
+	maxIndex uint64
+}
+
+// WatchlistWebhookPayload is the body POSTed to the webhooks of a watchlist
+type WatchlistWebhookPayload struct {
+	Event     string                  `json:"event"` // always "missed_duties"
+	Network   string                  `json:"network"`
+	Watchlist string                  `json:"watchlist"`
+	Epoch     uint64                  `json:"epoch"`
+	Duties    []*WatchlistWebhookDuty `json:"duties"`
+}
+
+// WatchlistWebhookDuty is a single missed duty of a watched validator
+type WatchlistWebhookDuty struct {
+	Validator     uint64 `json:"validator"`
+	ValidatorName string `json:"validator_name"`
+	Type          string `json:"type"` // "attestation" or "proposal"
+	Slot          uint64 `json:"slot"`
+}
+
+// ParseWatchlist parses a comma separated list of validator index ranges, e.g. "0-999,2000-2499"
+func ParseWatchlist(name string, validators string) (*Watchlist, error) {
+	watchlist := &Watchlist{
+		Name: name,
+	}
+
+	for _, rangeStr := range strings.Split(validators, ",") {
+		if strings.TrimSpace(rangeStr) == "" {
+			continue
+		}
+
+		minIndex, maxIndex, err := utils.ParseValidatorIndexRange(rangeStr)
+		if err != nil {
+			return nil, err
+		}
+
+		watchlist.ranges = append(watchlist.ranges, watchlistRange{minIndex, maxIndex})
+	}
+
+	return watchlist, nil
+}
+
+// GetConfiguredWatchlist returns the configured watchlist with the given name or nil if there is none
+func GetConfiguredWatchlist(name string) *Watchlist {
+	for _, listConfig := range utils.Config.Watchlists.Lists {
+		if listConfig.Name != name {
+			continue
+		}
+
+		watchlist, err := ParseWatchlist(listConfig.Name, listConfig.Validators)
+		if err != nil {
+			return nil
+		}
+		return watchlist
+	}
+
+	return nil
+}
+
+// Contains returns true if the validator is part of the watchlist
+func (watchlist *Watchlist) Contains(validatorIndex uint64) bool {
+	for _, indexRange := range watchlist.ranges {
+		if validatorIndex >= indexRange.minIndex && validatorIndex <= indexRange.maxIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// GetIndices returns the validator indices of the watchlist in ascending range order, limited to maxCount indices below the validator set size
+func (watchlist *Watchlist) GetIndices(validatorSetSize uint64, maxCount uint64) []uint64 {
+	indices := []uint64{}
+	seen := map[uint64]bool{}
+	for _, indexRange := range watchlist.ranges {
+		for index := indexRange.minIndex; index <= indexRange.maxIndex && index < validatorSetSize; index++ {
+			if seen[index] {
+				continue
+			}
+			if uint64(len(indices)) >= maxCount {
+				return indices
+			}
+
+			seen[index] = true
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// GetValidators returns the current state of the watchlist validators ordered by index, limited to maxCount validators
+func (watchlist *Watchlist) GetValidators(maxCount uint64) []v1.Validator {
+	validators := []v1.Validator{}
+	seen := map[phase0.ValidatorIndex]bool{}
+	for _, indexRange := range watchlist.ranges {
+		rangeValidators, _ := GlobalBeaconService.GetFilteredValidatorSet(&dbtypes.ValidatorFilter{
+			MinIndex: &indexRange.minIndex,
+			MaxIndex: &indexRange.maxIndex,
+			Limit:    maxCount,
+		}, true)
+
+		for _, validator := range rangeValidators {
+			if seen[validator.Index] {
+				continue
+			}
+
+			seen[validator.Index] = true
+			validators = append(validators, validator)
+		}
+	}
+
+	sort.Slice(validators, func(a, b int) bool {
+		return validators[a].Index < validators[b].Index
+	})
+	if uint64(len(validators)) > maxCount {
+		validators = validators[:maxCount]
+	}
+	return validators
+}
+
+// String returns the watchlist in the comma separated index range format
+func (watchlist *Watchlist) String() string {
+	rangeStrs := make([]string, len(watchlist.ranges))
+	for i, indexRange := range watchlist.ranges {
+		if indexRange.minIndex == indexRange.maxIndex {
+			rangeStrs[i] = fmt.Sprintf("%v", indexRange.minIndex)
+		} else {
+			rangeStrs[i] = fmt.Sprintf("%v-%v", indexRange.minIndex, indexRange.maxIndex)
+		}
+	}
+	return strings.Join(rangeStrs, ",")
+}
+
+// registerWatchlistHooks adds the missed duty hooks for the configured watchlists with webhooks
+func registerWatchlistHooks(logger logrus.FieldLogger, beaconIndexer *beacon.Indexer, chainState *consensus.ChainState, validatorNames *ValidatorNames) {
+	for idx := range utils.Config.Watchlists.Lists {
+		listConfig := &utils.Config.Watchlists.Lists[idx]
+		watchlist, err := ParseWatchlist(listConfig.Name, listConfig.Validators)
+		if err != nil {
+			logger.Warnf("invalid validator range in watchlist '%v': %v", listConfig.Name, err)
+			continue
+		}
+
+		if len(listConfig.Webhooks) == 0 {
+			continue
+		}
+
+		beaconIndexer.AddMissedDutyHook(func(validatorIndex phase0.ValidatorIndex) bool {
+			return watchlist.Contains(uint64(validatorIndex))
+		}, newWatchlistWebhookHook(logger, chainState, validatorNames, listConfig))
+	}
+}
+
+// newWatchlistWebhookHook returns a missed duty hook that sends the missed duties of a watchlist to its webhooks.
+func newWatchlistWebhookHook(logger logrus.FieldLogger, chainState *consensus.ChainState, validatorNames *ValidatorNames, listConfig *types.WatchlistConfig) beacon.MissedDutyHook {
+	sender := newWebhookSender(logger, "watchlist", listConfig.Webhooks)
+
+	return func(epoch phase0.Epoch, duties []*beacon.MissedDuty) {
+		payload := &WatchlistWebhookPayload{
+			Event:     "missed_duties",
+			Network:   getNetworkName(chainState),
+			Watchlist: listConfig.Name,
+			Epoch:     uint64(epoch),
+			Duties:    make([]*WatchlistWebhookDuty, len(duties)),
+		}
+		for i, duty := range duties {
+			payload.Duties[i] = &WatchlistWebhookDuty{
+				Validator:     uint64(duty.ValidatorIndex),
+				ValidatorName: validatorNames.GetValidatorName(uint64(duty.ValidatorIndex)),
+				Type:          "attestation",
+				Slot:          uint64(duty.Slot),
+			}
+			if duty.Type == beacon.MissedDutyProposal {
+				payload.Duties[i].Type = "proposal"
+			}
+		}
+
+		logger.Infof("watchlist '%v': %v missed duties in epoch %v", listConfig.Name, len(duties), epoch)
+
+		sender.send(payload)
+	}
+}
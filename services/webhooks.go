@@ -0,0 +1,76 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// webhookSender POSTs json encoded payloads to a set of webhook urls.
+// requests are sent asynchronously, failed requests are logged and not retried.
+type webhookSender struct {
+	logger logrus.FieldLogger
+	client *http.Client
+	name   string
+	urls   []string
+}
+
+// newWebhookSender creates a webhook sender for the given urls, name is used to identify the webhook type in log messages
+func newWebhookSender(logger logrus.FieldLogger, name string, urls []string) *webhookSender {
+	return &webhookSender{
+		logger: logger,
+		client: &http.Client{Timeout: utils.Config.Webhooks.Timeout},
+		name:   name,
+		urls:   urls,
+	}
+}
+
+// send encodes the payload and POSTs it to all webhook urls
+func (sender *webhookSender) send(payload any) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		sender.logger.Errorf("failed encoding %v webhook payload: %v", sender.name, err)
+		return
+	}
+
+	for _, webhookUrl := range sender.urls {
+		go func(webhookUrl string) {
+			err := sender.post(webhookUrl, payloadBytes)
+			if err != nil {
+				sender.logger.Warnf("failed sending %v webhook (%v): %v", sender.name, utils.GetRedactedUrl(webhookUrl), err)
+			}
+		}(webhookUrl)
+	}
+}
+
+func (sender *webhookSender) post(webhookUrl string, payload []byte) error {
+	resp, err := sender.client.Post(webhookUrl, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("error-response (%v): %s", resp.StatusCode, data)
+	}
+
+	return nil
+}
+
+// getNetworkName returns the network name sent with webhooks & exported events (configured display name or spec config name)
+func getNetworkName(chainState *consensus.ChainState) string {
+	network := utils.Config.Chain.DisplayName
+	if specs := chainState.GetSpecs(); network == "" && specs != nil {
+		network = specs.ConfigName
+	}
+
+	return network
+}
@@ -100,22 +100,24 @@
     var duration = time - Math.floor(new Date().getTime() / 1000);
     var timeStr= "";
     var absDuration = Math.abs(duration);
+    // relative time strings of the configured frontend locale, "%v" is replaced with the amount
+    var strings = window.explorerLocaleTimeStrings || { now: "now", sec: "%v sec.", min: "%v min.", hr: "%v hr.", day: "%v day.", ago: "%v ago", in: "in %v" };
 
     if (absDuration < 1) {
-      return "now";
+      return strings.now;
     } else if (absDuration < 60) {
-      timeStr = absDuration + " sec."
+      timeStr = strings.sec.replace("%v", absDuration)
     } else if (absDuration < 60*60) {
-      timeStr = (Math.floor(absDuration / 60)) + " min."
+      timeStr = strings.min.replace("%v", Math.floor(absDuration / 60))
     } else if (absDuration < 24*60*60) {
-      timeStr = (Math.floor(absDuration / (60 * 60))) + " hr."
+      timeStr = strings.hr.replace("%v", Math.floor(absDuration / (60 * 60)))
     } else {
-      timeStr = (Math.floor(absDuration / (60 * 60 * 24))) + " day."
+      timeStr = strings.day.replace("%v", Math.floor(absDuration / (60 * 60 * 24)))
     }
     if (duration < 0) {
-      return timeStr + " ago";
+      return strings.ago.replace("%v", timeStr);
     } else {
-      return "in " + timeStr;
+      return strings.in.replace("%v", timeStr);
     }
   }
 
@@ -188,6 +190,18 @@
         maxPendingRequests: requestNum,
       },
     });
+    var bhAddresses = new Bloodhound({
+      datumTokenizer: Bloodhound.tokenizers.whitespace,
+      queryTokenizer: Bloodhound.tokenizers.whitespace,
+      identify: function (obj) {
+        return obj.address
+      },
+      remote: {
+        url: "/search/addresses?q=",
+        prepare: prepareQueryFn,
+        maxPendingRequests: requestNum,
+      },
+    });
 
 
     searchEl.typeahead(
@@ -241,6 +255,18 @@
           },
         },
       },
+      {
+        limit: 5,
+        name: "address",
+        source: bhAddresses,
+        display: "address",
+        templates: {
+          header: '<h3 class="h5">Addresses (by label):</h3>',
+          suggestion: function (data) {
+            return `<div class="text-monospace"><div class="search-table"><span class="search-cell">${data.label}:</span><span class="search-cell search-truncate">${data.address}</span></div></div>`;
+          },
+        },
+      },
       {
         limit: 5,
         name: "epoch",
@@ -286,6 +312,8 @@
         } else {
           window.location = "/slot/" + sug.slot
         }
+      } else if (sug.address !== undefined) {
+        window.location = "/address/" + sug.address
       } else if (sug.epoch !== undefined) {
         window.location = "/epoch/" + sug.epoch
       } else if (sug.graffiti !== undefined) {
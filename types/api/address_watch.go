@@ -0,0 +1,23 @@
+package api
+
+import "time"
+
+// ApiAddressWatchResponse is the response for the address watch activity feed
+type ApiAddressWatchResponse struct {
+	Events     []*ApiAddressWatchEvent `json:"events"`
+	Pagination *ApiPagination          `json:"pagination"`
+}
+
+// ApiAddressWatchEvent is a single deposit or system request contract interaction of a watched address
+type ApiAddressWatchEvent struct {
+	Type            string    `json:"type"`
+	BlockNumber     uint64    `json:"block_number"`
+	BlockTime       time.Time `json:"block_time"`
+	TxHash          string    `json:"tx_hash"`
+	TxSender        string    `json:"tx_sender"`
+	SourceAddress   string    `json:"source_address,omitempty"`
+	ValidatorPubkey string    `json:"validator_pubkey,omitempty"`
+	TargetPubkey    string    `json:"target_pubkey,omitempty"`
+	Amount          int64     `json:"amount"`
+	Orphaned        bool      `json:"orphaned"`
+}
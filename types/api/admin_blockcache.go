@@ -0,0 +1,16 @@
+package api
+
+import "github.com/ethpandaops/dora/indexer/beacon"
+
+// ApiAdminBlockCacheResponse is the response for the block cache settings & stats
+type ApiAdminBlockCacheResponse struct {
+	Settings beacon.BlockCacheSettings `json:"settings"`
+	Stats    beacon.BlockCacheStats    `json:"stats"`
+}
+
+// ApiAdminBlockCacheUpdate is the request body for block cache settings updates, unset fields are left unchanged
+type ApiAdminBlockCacheUpdate struct {
+	InMemoryEpochs     *uint16 `json:"in_memory_epochs"`
+	BodyEpochs         *uint16 `json:"body_epochs"`
+	MaxForkBlockBodies *uint64 `json:"max_fork_block_bodies"`
+}
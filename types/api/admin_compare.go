@@ -0,0 +1,21 @@
+package api
+
+// ApiAdminCompareResponse is the result of a finalized epoch aggregates comparison against another dora instance
+type ApiAdminCompareResponse struct {
+	Remote          string                       `json:"remote"`
+	MinEpoch        uint64                       `json:"min_epoch"`
+	MaxEpoch        uint64                       `json:"max_epoch"`
+	ComparedEpochs  uint64                       `json:"compared_epochs"`
+	MissingLocal    []uint64                     `json:"missing_local"`  // epochs only indexed by the remote instance
+	MissingRemote   []uint64                     `json:"missing_remote"` // epochs only indexed by this instance
+	Divergences     []*ApiAdminCompareDivergence `json:"divergences"`
+	DivergentEpochs uint64                       `json:"divergent_epochs"`
+}
+
+// ApiAdminCompareDivergence is a single epoch aggregate that differs between both instances
+type ApiAdminCompareDivergence struct {
+	Epoch  uint64 `json:"epoch"`
+	Field  string `json:"field"`
+	Local  uint64 `json:"local"`
+	Remote uint64 `json:"remote"`
+}
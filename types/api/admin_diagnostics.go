@@ -0,0 +1,19 @@
+package api
+
+import "time"
+
+// ApiAdminHeapDumpsResponse lists the captured heap dumps, newest first
+type ApiAdminHeapDumpsResponse struct {
+	ProcessRss   uint64              `json:"process_rss"`   // bytes
+	RssThreshold uint64              `json:"rss_threshold"` // bytes, 0 = automatic capture disabled
+	Dumps        []*ApiAdminHeapDump `json:"dumps"`
+}
+
+// ApiAdminHeapDump describes a captured heap profile
+type ApiAdminHeapDump struct {
+	Name   string    `json:"name"`
+	Size   int64     `json:"size"`
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"` // manual or rss
+	Rss    uint64    `json:"rss"`    // process RSS in bytes at capture time
+}
@@ -0,0 +1,129 @@
+package api
+
+import "time"
+
+// ApiIncidentBundleSummary is the summary.json of an incident bundle
+type ApiIncidentBundleSummary struct {
+	Network         string    `json:"network"`
+	ExplorerVersion string    `json:"explorer_version"`
+	GeneratedAt     time.Time `json:"generated_at"`
+	StartSlot       uint64    `json:"start_slot"`
+	EndSlot         uint64    `json:"end_slot"`
+	StartEpoch      uint64    `json:"start_epoch"`
+	EndEpoch        uint64    `json:"end_epoch"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	HeadSlot        uint64    `json:"head_slot"`
+	HeadRoot        string    `json:"head_root"`
+	FinalizedEpoch  uint64    `json:"finalized_epoch"`
+	FinalizedRoot   string    `json:"finalized_root"`
+	Files           []string  `json:"files"`
+}
+
+// ApiIncidentBundleForkTree is the fork_tree.json of an incident bundle
+type ApiIncidentBundleForkTree struct {
+	Blocks    []*ApiIncidentBundleBlock    `json:"blocks"`
+	HeadForks []*ApiIncidentBundleHeadFork `json:"head_forks"`
+}
+
+// ApiIncidentBundleBlock is a slot of the bundled range, missed slots have no block root
+type ApiIncidentBundleBlock struct {
+	Slot              uint64  `json:"slot"`
+	Epoch             uint64  `json:"epoch"`
+	Status            string  `json:"status"`
+	Root              string  `json:"root,omitempty"`
+	ParentRoot        string  `json:"parent_root,omitempty"`
+	ForkId            uint64  `json:"fork_id"`
+	Proposer          uint64  `json:"proposer"`
+	ProposerName      string  `json:"proposer_name,omitempty"`
+	Graffiti          string  `json:"graffiti,omitempty"`
+	ElBlockNumber     *uint64 `json:"el_block_number,omitempty"`
+	ElBlockHash       string  `json:"el_block_hash,omitempty"`
+	ElExtraData       string  `json:"el_extra_data,omitempty"`
+	AttestationCount  uint64  `json:"attestation_count"`
+	SyncParticipation float32 `json:"sync_participation"`
+}
+
+// ApiIncidentBundleHeadFork is a chain head followed by a group of consensus clients at bundle time
+type ApiIncidentBundleHeadFork struct {
+	HeadSlot uint64   `json:"head_slot"`
+	HeadRoot string   `json:"head_root"`
+	Clients  []string `json:"clients"`
+}
+
+// ApiIncidentBundleClientHeads is the client_heads.json of an incident bundle
+type ApiIncidentBundleClientHeads struct {
+	Consensus []*ApiIncidentBundleConsensusClient `json:"consensus"`
+	Execution []*ApiIncidentBundleExecutionClient `json:"execution"`
+}
+
+// ApiIncidentBundleConsensusClient is the head & finality view of a consensus client at bundle time
+type ApiIncidentBundleConsensusClient struct {
+	Name           string    `json:"name"`
+	Version        string    `json:"version"`
+	Status         string    `json:"status"`
+	HeadSlot       uint64    `json:"head_slot"`
+	HeadRoot       string    `json:"head_root"`
+	JustifiedEpoch uint64    `json:"justified_epoch"`
+	JustifiedRoot  string    `json:"justified_root"`
+	FinalizedEpoch uint64    `json:"finalized_epoch"`
+	FinalizedRoot  string    `json:"finalized_root"`
+	LastRefresh    time.Time `json:"last_refresh"`
+	LastError      string    `json:"last_error,omitempty"`
+}
+
+// ApiIncidentBundleExecutionClient is the head of an execution client at bundle time
+type ApiIncidentBundleExecutionClient struct {
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Status      string    `json:"status"`
+	HeadNumber  uint64    `json:"head_number"`
+	HeadHash    string    `json:"head_hash"`
+	LastRefresh time.Time `json:"last_refresh"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// ApiIncidentBundlePropagation is the propagation.json of an incident bundle
+type ApiIncidentBundlePropagation struct {
+	Blocks []*ApiIncidentBundleBlockArrival `json:"blocks"`
+}
+
+// ApiIncidentBundleBlockArrival holds the arrival delays (ms after slot start) of a block.
+// the block root is only set for unfinalized blocks, finalized arrivals are only kept for canonical blocks.
+type ApiIncidentBundleBlockArrival struct {
+	Slot        uint64                                 `json:"slot"`
+	Root        string                                 `json:"root,omitempty"`
+	Proposer    uint64                                 `json:"proposer"`
+	SeenBy      uint32                                 `json:"seen_by"`
+	MinDelay    int64                                  `json:"min_delay"`
+	MedianDelay int64                                  `json:"median_delay"`
+	MaxDelay    int64                                  `json:"max_delay"`
+	Clients     []*ApiIncidentBundleClientBlockArrival `json:"clients"`
+}
+
+// ApiIncidentBundleClientBlockArrival holds the arrival delay (ms after slot start) of a block at a single client
+type ApiIncidentBundleClientBlockArrival struct {
+	Client string `json:"client"`
+	Delay  int64  `json:"delay"`
+}
+
+// ApiIncidentBundleParticipation is the participation.json of an incident bundle
+type ApiIncidentBundleParticipation struct {
+	Epochs []*ApiIncidentBundleEpoch `json:"epochs"`
+}
+
+// ApiIncidentBundleEpoch holds the voting participation of an epoch (in gwei of effective balance)
+type ApiIncidentBundleEpoch struct {
+	Epoch             uint64  `json:"epoch"`
+	Finalized         bool    `json:"finalized"`
+	Eligible          uint64  `json:"eligible"`
+	VotedTarget       uint64  `json:"voted_target"`
+	VotedHead         uint64  `json:"voted_head"`
+	VotedTotal        uint64  `json:"voted_total"`
+	TargetPercent     float64 `json:"target_percent"`
+	HeadPercent       float64 `json:"head_percent"`
+	TotalPercent      float64 `json:"total_percent"`
+	BlockCount        uint16  `json:"block_count"`
+	OrphanedCount     uint16  `json:"orphaned_count"`
+	SyncParticipation float32 `json:"sync_participation"`
+}
@@ -0,0 +1,7 @@
+package api
+
+// ApiAdminReplayResponse is the accepted range of finalized epochs that will be rewritten
+type ApiAdminReplayResponse struct {
+	StartEpoch uint64 `json:"start_epoch"`
+	EndEpoch   uint64 `json:"end_epoch"`
+}
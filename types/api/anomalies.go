@@ -0,0 +1,21 @@
+package api
+
+import "time"
+
+// ApiValidatorAnomaliesResponse is the response for the validator anomaly list
+type ApiValidatorAnomaliesResponse struct {
+	Anomalies  []*ApiValidatorAnomaly `json:"anomalies"`
+	Pagination *ApiPagination         `json:"pagination"`
+}
+
+// ApiValidatorAnomaly is a single unexpected fee recipient, withdrawal credential or graffiti change of a watchlisted validator
+type ApiValidatorAnomaly struct {
+	Validator     uint64    `json:"validator"`
+	ValidatorName string    `json:"validator_name"`
+	Slot          uint64    `json:"slot"`
+	Epoch         uint64    `json:"epoch"`
+	Time          time.Time `json:"time"`
+	Type          string    `json:"type"`
+	Before        string    `json:"before"`
+	After         string    `json:"after"`
+}
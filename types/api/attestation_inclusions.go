@@ -0,0 +1,30 @@
+package api
+
+// ApiAttestationInclusionsResponse is the response for the inclusion path of a validators attestation duty
+type ApiAttestationInclusionsResponse struct {
+	ValidatorIndex    uint64                     `json:"validator_index"`
+	Epoch             uint64                     `json:"epoch"`
+	HasDuty           bool                       `json:"has_duty"`
+	Slot              uint64                     `json:"slot"`
+	CommitteeIndex    uint64                     `json:"committee_index"`
+	CommitteePosition uint64                     `json:"committee_position"`
+	CommitteeSize     uint64                     `json:"committee_size"`
+	Included          bool                       `json:"included"` // included in at least one canonical block
+	InclusionDelay    uint64                     `json:"inclusion_delay,omitempty"`
+	Inclusions        []*ApiAttestationInclusion `json:"inclusions"`
+}
+
+// ApiAttestationInclusion is an aggregate in a block that includes the vote of the validator
+type ApiAttestationInclusion struct {
+	Slot             uint64 `json:"slot"`
+	BlockRoot        string `json:"block_root"`
+	Orphaned         bool   `json:"orphaned"`
+	AttestationIndex uint64 `json:"attestation_index"`
+	InclusionDelay   uint64 `json:"inclusion_delay"`
+	AggregateSize    uint64 `json:"aggregate_size"`
+	BeaconBlockRoot  string `json:"beacon_block_root"`
+	SourceEpoch      uint64 `json:"source_epoch"`
+	SourceRoot       string `json:"source_root"`
+	TargetEpoch      uint64 `json:"target_epoch"`
+	TargetRoot       string `json:"target_root"`
+}
@@ -0,0 +1,31 @@
+package api
+
+// ApiBroadcastOperation is a signed voluntary exit or bls to execution change (request body documentation only).
+// the request body can be a single operation or a list of operations.
+type ApiBroadcastOperation struct {
+	Message   map[string]string `json:"message"`
+	Signature string            `json:"signature"`
+}
+
+// ApiBroadcastResponse is the response for the operation broadcast
+type ApiBroadcastResponse struct {
+	Results []*ApiBroadcastResult `json:"results"`
+	Count   uint64                `json:"count"`
+}
+
+// ApiBroadcastResult holds the validation & per-client submission results of an operation
+type ApiBroadcastResult struct {
+	Type            string                      `json:"type"` // voluntary_exit or bls_change
+	ValidatorIndex  uint64                      `json:"validator_index"`
+	Valid           bool                        `json:"valid"`
+	ValidationError string                      `json:"validation_error,omitempty"`
+	AcceptedCount   uint64                      `json:"accepted_count"`
+	Clients         []*ApiBroadcastClientResult `json:"clients"`
+}
+
+// ApiBroadcastClientResult holds the submission result of a single client
+type ApiBroadcastClientResult struct {
+	Client   string `json:"client"`
+	Accepted bool   `json:"accepted"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,16 @@
+package api
+
+// ApiEpochCommitteesResponse is the response for the participation per committee index of an epoch
+type ApiEpochCommitteesResponse struct {
+	Epoch      uint64                            `json:"epoch"`
+	Finalized  bool                              `json:"finalized"`
+	Committees []*ApiEpochCommitteeParticipation `json:"committees"`
+}
+
+// ApiEpochCommitteeParticipation is the participation of a single committee index across all slots of an epoch
+type ApiEpochCommitteeParticipation struct {
+	CommitteeIndex uint64  `json:"committee_index"`
+	Assigned       uint64  `json:"assigned"`
+	Attested       uint64  `json:"attested"`
+	Participation  float64 `json:"participation"` // percent
+}
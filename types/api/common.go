@@ -0,0 +1,9 @@
+// Package api holds the request & response models of the json api.
+// the openapi specification (/api/swagger.json) is generated from these types, so clients can be generated from it.
+package api
+
+// ApiResponse is the common envelope for all api responses
+type ApiResponse struct {
+	Status string      `json:"status"`
+	Data   interface{} `json:"data,omitempty"`
+}
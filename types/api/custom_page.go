@@ -0,0 +1,9 @@
+package api
+
+// ApiCustomPageResponse is the response for an operator defined custom page query
+type ApiCustomPageResponse struct {
+	Name       string                   `json:"name"`
+	Columns    []string                 `json:"columns"`
+	Rows       []map[string]interface{} `json:"rows"`
+	Pagination *ApiPagination           `json:"pagination"`
+}
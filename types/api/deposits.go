@@ -0,0 +1,24 @@
+package api
+
+import "time"
+
+// ApiProblematicDepositsResponse is the response for the problematic deposits report
+type ApiProblematicDepositsResponse struct {
+	Deposits   []*ApiProblematicDeposit `json:"deposits"`
+	Pagination *ApiPagination           `json:"pagination"`
+}
+
+// ApiProblematicDeposit is a single deposit entry in the problematic deposits report
+type ApiProblematicDeposit struct {
+	Index                 uint64    `json:"index"`
+	PublicKey             string    `json:"pubkey"`
+	WithdrawalCredentials string    `json:"withdrawal_credentials"`
+	Amount                uint64    `json:"amount"`
+	ValidSignature        bool      `json:"valid_signature"`
+	CredentialsMismatch   bool      `json:"credentials_mismatch"`
+	InvalidSignature      bool      `json:"invalid_signature"`
+	BlockNumber           uint64    `json:"block_number"`
+	BlockTime             time.Time `json:"block_time"`
+	TxHash                string    `json:"tx_hash"`
+	TxSender              string    `json:"tx_sender"` // empty if deposit senders are redacted
+}
@@ -0,0 +1,31 @@
+package api
+
+import "time"
+
+// ApiDetectedSlashingsResponse is the response for the detected slashable offence list
+type ApiDetectedSlashingsResponse struct {
+	Slashings  []*ApiDetectedSlashing `json:"slashings"`
+	Pagination *ApiPagination         `json:"pagination"`
+}
+
+// ApiDetectedSlashing is a single double or surround vote detected by the slasher
+type ApiDetectedSlashing struct {
+	Validator     uint64                   `json:"validator"`
+	ValidatorName string                   `json:"validator_name"`
+	Slot          uint64                   `json:"slot"`
+	Epoch         uint64                   `json:"epoch"`
+	Time          time.Time                `json:"time"`
+	Type          string                   `json:"type"`
+	Attestation1  *ApiDetectedSlashingVote `json:"attestation_1"`
+	Attestation2  *ApiDetectedSlashingVote `json:"attestation_2"`
+	Broadcasted   bool                     `json:"broadcasted"`
+	Included      bool                     `json:"included"`
+	IncludedSlot  *uint64                  `json:"included_slot,omitempty"`
+}
+
+// ApiDetectedSlashingVote is one of the conflicting votes of a detected offence
+type ApiDetectedSlashingVote struct {
+	DataRoot    string `json:"data_root"`
+	SourceEpoch uint64 `json:"source_epoch"`
+	TargetEpoch uint64 `json:"target_epoch"`
+}
@@ -0,0 +1,23 @@
+package api
+
+import "time"
+
+// ApiDutyMismatchesResponse is the response for the duty mismatch list
+type ApiDutyMismatchesResponse struct {
+	Mismatches []*ApiDutyMismatch `json:"mismatches"`
+	Pagination *ApiPagination     `json:"pagination"`
+}
+
+// ApiDutyMismatch is a proposer or attester duty reported by a beacon node that differs from the duty computed by the explorer
+type ApiDutyMismatch struct {
+	Epoch          uint64    `json:"epoch"`
+	ClientId       uint64    `json:"client_id"`
+	ClientName     string    `json:"client_name"`
+	Type           string    `json:"type"`
+	Slot           uint64    `json:"slot"`
+	CommitteeIndex *uint64   `json:"committee_index,omitempty"`
+	DependentRoot  string    `json:"dependent_root"`
+	Expected       []uint64  `json:"expected"`
+	Reported       []uint64  `json:"reported"`
+	DetectedAt     time.Time `json:"detected_at"`
+}
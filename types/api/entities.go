@@ -0,0 +1,47 @@
+package api
+
+import "time"
+
+// ApiEntitiesResponse is the response for the entity list
+type ApiEntitiesResponse struct {
+	Entities []*ApiEntity `json:"entities"`
+}
+
+// ApiEntity is an entity with its roster
+type ApiEntity struct {
+	Id          uint64                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Links       []*ApiEntityLink       `json:"links"`
+	Validators  []*ApiEntityValidators `json:"validators"`
+	Addresses   []*ApiEntityAddress    `json:"addresses"`
+	Created     time.Time              `json:"created"`
+	Updated     time.Time              `json:"updated"`
+}
+
+// ApiEntityUpdate is the request body for creating or replacing an entity
+type ApiEntityUpdate struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Links       []*ApiEntityLink       `json:"links"`
+	Validators  []*ApiEntityValidators `json:"validators"`
+	Addresses   []*ApiEntityAddress    `json:"addresses"`
+}
+
+// ApiEntityLink is an external link of an entity
+type ApiEntityLink struct {
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+// ApiEntityValidators is a range of validator indexes (inclusive) of an entity
+type ApiEntityValidators struct {
+	MinIndex uint64  `json:"min_index"`
+	MaxIndex *uint64 `json:"max_index,omitempty"` // defaults to min_index
+}
+
+// ApiEntityAddress is a withdrawal or deposit address of an entity, the validators are resolved via these addresses
+type ApiEntityAddress struct {
+	Type    string `json:"type"` // withdrawal, deposit_origin or deposit_target
+	Address string `json:"address"`
+}
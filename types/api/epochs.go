@@ -0,0 +1,36 @@
+package api
+
+// ApiEpochAggregatesResponse is the response for the finalized epoch aggregates
+type ApiEpochAggregatesResponse struct {
+	FinalizedEpoch uint64               `json:"finalized_epoch"`
+	Epochs         []*ApiEpochAggregate `json:"epochs"`
+	Pagination     *ApiPagination       `json:"pagination"`
+}
+
+// ApiEpochAggregate holds the indexed aggregates of a finalized epoch
+type ApiEpochAggregate struct {
+	Epoch                 uint64 `json:"epoch"`
+	ValidatorCount        uint64 `json:"validator_count"`
+	ValidatorBalance      uint64 `json:"validator_balance"`
+	Eligible              uint64 `json:"eligible"`
+	VotedTarget           uint64 `json:"voted_target"`
+	VotedHead             uint64 `json:"voted_head"`
+	VotedTotal            uint64 `json:"voted_total"`
+	BlockCount            uint64 `json:"block_count"`
+	OrphanedCount         uint64 `json:"orphaned_count"`
+	AttestationCount      uint64 `json:"attestation_count"`
+	DepositCount          uint64 `json:"deposit_count"`
+	ExitCount             uint64 `json:"exit_count"`
+	WithdrawCount         uint64 `json:"withdraw_count"`
+	WithdrawAmount        uint64 `json:"withdraw_amount"`
+	AttesterSlashingCount uint64 `json:"attester_slashing_count"`
+	ProposerSlashingCount uint64 `json:"proposer_slashing_count"`
+	BLSChangeCount        uint64 `json:"bls_change_count"`
+	EthTransactionCount   uint64 `json:"eth_transaction_count"`
+
+	// human formatted values, only set if a locale is requested
+	ValidatorCountFormatted   string `json:"validator_count_formatted,omitempty"`
+	ValidatorBalanceFormatted string `json:"validator_balance_formatted,omitempty"`
+	EligibleFormatted         string `json:"eligible_formatted,omitempty"`
+	WithdrawAmountFormatted   string `json:"withdraw_amount_formatted,omitempty"`
+}
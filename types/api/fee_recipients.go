@@ -0,0 +1,20 @@
+package api
+
+// ApiFeeRecipientsResponse is the response for the fee recipient monitoring
+type ApiFeeRecipientsResponse struct {
+	Recipients []*ApiFeeRecipient `json:"recipients"`
+	Mismatches uint64             `json:"mismatches"` // number of blocks with an unexpected fee recipient
+	Pagination *ApiPagination     `json:"pagination"`
+}
+
+// ApiFeeRecipient holds the blocks of a proposer with the same fee recipient
+type ApiFeeRecipient struct {
+	Validator     uint64 `json:"validator"`
+	ValidatorName string `json:"validator_name"`
+	FeeRecipient  string `json:"fee_recipient"`
+	Expected      string `json:"expected,omitempty"`
+	Mismatch      bool   `json:"mismatch"`
+	Blocks        uint64 `json:"blocks"`
+	FirstSlot     uint64 `json:"first_slot"`
+	LastSlot      uint64 `json:"last_slot"`
+}
@@ -0,0 +1,16 @@
+package api
+
+// ApiEpochFinalityVotesResponse is the response for the target vote map of a finalized epoch
+type ApiEpochFinalityVotesResponse struct {
+	Epoch             uint64   `json:"epoch"`
+	TargetRoot        string   `json:"target_root"`
+	ActiveCount       uint64   `json:"active_count"`
+	VotedCount        uint64   `json:"voted_count"`
+	MissingCount      uint64   `json:"missing_count"`
+	ActiveBalance     uint64   `json:"active_balance"`            // gwei
+	VotedBalance      uint64   `json:"voted_balance"`             // gwei
+	VotedPercent      float64  `json:"voted_percent"`             // percent of the active balance
+	MissingValidators []uint64 `json:"missing_validators"`        // active validators without an included target vote
+	ActiveBitfield    string   `json:"active_bitfield,omitempty"` // hex, bit i%8 of byte i/8 is set for active validator i
+	VotedBitfield     string   `json:"voted_bitfield,omitempty"`  // hex, bit i%8 of byte i/8 is set if validator i voted for the target
+}
@@ -0,0 +1,23 @@
+package api
+
+import "time"
+
+// ApiValidatorIncidentsResponse is the response for the validator incident list
+type ApiValidatorIncidentsResponse struct {
+	Incidents  []*ApiValidatorIncident `json:"incidents"`
+	Pagination *ApiPagination          `json:"pagination"`
+}
+
+// ApiValidatorIncident is a single incident of consecutive missed duties by validators of the same entity
+type ApiValidatorIncident struct {
+	Entity             string    `json:"entity"`
+	StartEpoch         uint64    `json:"start_epoch"`
+	StartTime          time.Time `json:"start_time"`
+	EndEpoch           uint64    `json:"end_epoch"`
+	EndTime            time.Time `json:"end_time"`
+	Validators         uint64    `json:"validators"`
+	MissedAttestations uint64    `json:"missed_attestations"`
+	MissedProposals    uint64    `json:"missed_proposals"`
+	EstimatedLoss      uint64    `json:"estimated_loss"`
+	Resolved           bool      `json:"resolved"`
+}
@@ -0,0 +1,35 @@
+package api
+
+import "time"
+
+// ApiLightClientUpdatesResponse is the response for the light client updates of a sync committee period range
+type ApiLightClientUpdatesResponse struct {
+	StartPeriod uint64                  `json:"start_period"`
+	EndPeriod   uint64                  `json:"end_period"`
+	Updates     []*ApiLightClientUpdate `json:"updates"`
+}
+
+// ApiLightClientHeadUpdatesResponse is the response for the latest light client finality & optimistic updates of a sync committee period
+type ApiLightClientHeadUpdatesResponse struct {
+	Period  uint64                  `json:"period"`
+	Updates []*ApiLightClientUpdate `json:"updates"`
+}
+
+// ApiLightClientUpdate is a light client update served by a consensus client and its verification result
+type ApiLightClientUpdate struct {
+	Period            uint64      `json:"period"`
+	ClientName        string      `json:"client_name"`
+	Type              string      `json:"type"` // update, finality or optimistic
+	Version           string      `json:"version"`
+	AttestedSlot      uint64      `json:"attested_slot"`
+	AttestedRoot      string      `json:"attested_root"`
+	FinalizedSlot     uint64      `json:"finalized_slot"`
+	FinalizedRoot     string      `json:"finalized_root"`
+	SignatureSlot     uint64      `json:"signature_slot"`
+	Participation     uint32      `json:"participation"`
+	NextCommitteeRoot string      `json:"next_committee_root,omitempty"`
+	Status            string      `json:"status"`           // valid, invalid or unverified
+	Result            string      `json:"result,omitempty"` // failed checks or the reason the update could not be verified
+	UpdatedAt         time.Time   `json:"updated_at"`
+	Data              interface{} `json:"data,omitempty"` // the update as served by the client (beacon api json)
+}
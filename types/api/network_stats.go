@@ -0,0 +1,32 @@
+package api
+
+import "time"
+
+// ApiNetworkStatsResponse is the response for the rolling network stats
+type ApiNetworkStatsResponse struct {
+	CurrentEpoch   uint64                   `json:"current_epoch"`
+	FinalizedEpoch uint64                   `json:"finalized_epoch"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+	Windows        []*ApiNetworkStatsWindow `json:"windows"`
+}
+
+// ApiNetworkStatsWindow holds the aggregates of the finalized epochs within a rolling window (1d, 7d or 31d)
+type ApiNetworkStatsWindow struct {
+	Window              string  `json:"window"`
+	FirstEpoch          uint64  `json:"first_epoch"`
+	LastEpoch           uint64  `json:"last_epoch"`
+	Epochs              uint64  `json:"epochs"` // number of indexed epochs within the window
+	Slots               uint64  `json:"slots"`
+	Blocks              uint64  `json:"blocks"`
+	MissedSlots         uint64  `json:"missed_slots"`
+	MissedSlotRate      float64 `json:"missed_slot_rate"` // percent
+	ReorgCount          uint64  `json:"reorg_count"`      // number of orphaned blocks
+	Participation       float64 `json:"participation"`    // percent, target votes
+	HeadParticipation   float64 `json:"head_participation"`
+	SyncParticipation   float64 `json:"sync_participation"`
+	ValidatorCountStart uint64  `json:"validator_count_start"`
+	ValidatorCountEnd   uint64  `json:"validator_count_end"`
+	ValidatorGrowth     int64   `json:"validator_growth"`
+	BlockValueBlocks    uint64  `json:"block_value_blocks"`        // number of blocks with indexed rewards
+	AvgBlockValue       *int64  `json:"avg_block_value,omitempty"` // gwei, requires the rewards indexer
+}
@@ -0,0 +1,22 @@
+package api
+
+import "time"
+
+// ApiOnlineMigrationsResponse is the response for the online migration progress
+type ApiOnlineMigrationsResponse struct {
+	Migrations []*ApiOnlineMigration `json:"migrations"`
+}
+
+// ApiOnlineMigration is the backfill progress of an online schema migration
+type ApiOnlineMigration struct {
+	Name       string     `json:"name"`
+	Table      string     `json:"table"`
+	Status     string     `json:"status"` // pending, running, finished
+	NextKey    *int64     `json:"next_key"`
+	MaxKey     *int64     `json:"max_key"`
+	Progress   float64    `json:"progress"` // percentage of the key range processed
+	RowsDone   uint64     `json:"rows_done"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	LastError  string     `json:"last_error,omitempty"`
+}
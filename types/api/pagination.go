@@ -0,0 +1,11 @@
+package api
+
+// ApiPagination is the shared pagination envelope of all list endpoints.
+// the cursors are opaque and can be passed via the "cursor" query parameter to fetch the adjacent pages.
+type ApiPagination struct {
+	Limit      uint64  `json:"limit"`
+	PageIndex  uint64  `json:"page_index"`            // 1 based
+	TotalCount *uint64 `json:"total_count,omitempty"` // total number of items (estimate for large tables), unset if unknown
+	NextCursor string  `json:"next_cursor,omitempty"` // unset on the last page
+	PrevCursor string  `json:"prev_cursor,omitempty"` // unset on the first page
+}
@@ -0,0 +1,22 @@
+package api
+
+import "time"
+
+// ApiUsageResponse is the response for the api usage dashboard of the calling key / ip
+type ApiUsageResponse struct {
+	Identity   string            `json:"identity"` // key owner or "anonymous"
+	Tier       string            `json:"tier,omitempty"`
+	Limit      uint64            `json:"limit"` // calls per window (0 = unlimited)
+	Used       uint64            `json:"used"`
+	Remaining  uint64            `json:"remaining"`
+	Window     uint64            `json:"window"` // window length in seconds
+	ResetTime  time.Time         `json:"reset_time"`
+	BucketSize uint64            `json:"bucket_size"` // bucket length in seconds
+	Usage      []*ApiUsageBucket `json:"usage"`
+}
+
+// ApiUsageBucket holds the number of calls within a part of the rolling window
+type ApiUsageBucket struct {
+	Time  time.Time `json:"time"`
+	Calls uint64    `json:"calls"`
+}
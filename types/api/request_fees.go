@@ -0,0 +1,25 @@
+package api
+
+import "time"
+
+// ApiRequestFeesResponse is the response for the el triggered request fees
+type ApiRequestFeesResponse struct {
+	Withdrawals    *ApiRequestFees `json:"withdrawals"`
+	Consolidations *ApiRequestFees `json:"consolidations"`
+}
+
+// ApiRequestFees holds the current fee & fee history of a system contract, all fees are in wei
+type ApiRequestFees struct {
+	Contract   string                  `json:"contract"`
+	CurrentFee string                  `json:"current_fee,omitempty"` // decimal string, empty if unknown
+	History    []*ApiRequestFeesBucket `json:"history"`
+}
+
+// ApiRequestFeesBucket holds the fees paid by the requests of a history bucket
+type ApiRequestFeesBucket struct {
+	Time     time.Time `json:"time"`
+	Requests uint64    `json:"requests"`
+	MinFee   uint64    `json:"min_fee"`
+	MaxFee   uint64    `json:"max_fee"`
+	AvgFee   uint64    `json:"avg_fee"`
+}
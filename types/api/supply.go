@@ -0,0 +1,28 @@
+package api
+
+import "time"
+
+// ApiSupplyResponse is the response for the supply delta (consensus layer issuance vs. execution layer fee burn)
+type ApiSupplyResponse struct {
+	Period  string            `json:"period"`
+	Total   *ApiSupplyEntry   `json:"total"`
+	History []*ApiSupplyEntry `json:"history"`
+}
+
+// ApiSupplyEntry holds the issuance & fee burn of an epoch, a day or the whole indexed range (in gwei)
+type ApiSupplyEntry struct {
+	Epoch  *uint64    `json:"epoch,omitempty"`
+	Day    *uint64    `json:"day,omitempty"`
+	Date   *time.Time `json:"date,omitempty"`
+	Epochs uint32     `json:"epochs"`
+	Blocks uint32     `json:"blocks"`
+	Issued int64      `json:"issued"`
+	Burned int64      `json:"burned"`
+	Delta  int64      `json:"delta"` // issued - burned
+
+	// human formatted values, only set if a locale is requested
+	DateFormatted   string `json:"date_formatted,omitempty"`
+	IssuedFormatted string `json:"issued_formatted,omitempty"`
+	BurnedFormatted string `json:"burned_formatted,omitempty"`
+	DeltaFormatted  string `json:"delta_formatted,omitempty"`
+}
@@ -0,0 +1,28 @@
+package api
+
+// ApiValidateObjectResponse is the response for the object validation
+type ApiValidateObjectResponse struct {
+	Results []*ApiValidateObjectResult `json:"results"`
+	Count   uint64                     `json:"count"`
+}
+
+// ApiValidateObjectResult holds the decoded breakdown & check results of a validated object
+type ApiValidateObjectResult struct {
+	Index    uint64                    `json:"index"`
+	Type     string                    `json:"type"`
+	Encoding string                    `json:"encoding"` // json or ssz
+	Fork     string                    `json:"fork,omitempty"`
+	Valid    bool                      `json:"valid"`
+	Error    string                    `json:"error,omitempty"`
+	RootName string                    `json:"root_name,omitempty"`
+	Root     string                    `json:"root,omitempty"`
+	Fields   map[string]string         `json:"fields,omitempty"`
+	Checks   []*ApiValidateObjectCheck `json:"checks"`
+}
+
+// ApiValidateObjectCheck holds the result of a single validation check
+type ApiValidateObjectCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"` // ok, failed or skipped
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,18 @@
+package api
+
+import "time"
+
+// ApiValidatorEffectivenessResponse is the response for the validator effectiveness history
+type ApiValidatorEffectivenessResponse struct {
+	ValidatorIndex uint64                          `json:"validator_index"`
+	History        []*ApiValidatorEffectivenessDay `json:"history"`
+}
+
+// ApiValidatorEffectivenessDay is the effectiveness score & percentile ranking of a validator for a single day
+type ApiValidatorEffectivenessDay struct {
+	Day           uint64    `json:"day"`
+	Date          time.Time `json:"date"`
+	Effectiveness float64   `json:"effectiveness"`
+	TopPercent    float64   `json:"top_percent"`
+	Epochs        uint32    `json:"epochs"`
+}
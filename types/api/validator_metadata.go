@@ -0,0 +1,15 @@
+package api
+
+// ApiValidatorMetadataResponse is the response for the batched validator metadata lookup
+type ApiValidatorMetadataResponse struct {
+	Validators []*ApiValidatorMetadata `json:"validators"`
+	Count      uint64                  `json:"count"`
+}
+
+// ApiValidatorMetadata holds the pubkey, entity & status of a validator
+type ApiValidatorMetadata struct {
+	Index  uint64 `json:"index"`
+	Pubkey string `json:"pubkey"`
+	Entity string `json:"entity,omitempty"`
+	Status string `json:"status"`
+}
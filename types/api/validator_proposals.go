@@ -0,0 +1,35 @@
+package api
+
+import "time"
+
+// ApiValidatorProposalsResponse is the response for the block proposals of a validator
+type ApiValidatorProposalsResponse struct {
+	ValidatorIndex uint64                  `json:"validator_index"`
+	Proposals      []*ApiValidatorProposal `json:"proposals"`
+	Pagination     *ApiPagination          `json:"pagination"`
+}
+
+// ApiValidatorProposal is a single proposal duty of a validator
+type ApiValidatorProposal struct {
+	Slot           uint64                       `json:"slot"`
+	Epoch          uint64                       `json:"epoch"`
+	Time           time.Time                    `json:"time"`
+	Status         string                       `json:"status"` // "canonical", "orphaned", "missing" or "scheduled"
+	BlockRoot      string                       `json:"block_root,omitempty"`
+	Graffiti       string                       `json:"graffiti,omitempty"`
+	EthBlockNumber *uint64                      `json:"eth_block_number,omitempty"`
+	EthBlockHash   string                       `json:"eth_block_hash,omitempty"`
+	FeeRecipient   string                       `json:"fee_recipient,omitempty"`
+	Rewards        *ApiValidatorProposalRewards `json:"rewards"` // null if the block rewards have not been indexed
+}
+
+// ApiValidatorProposalRewards holds the proposer rewards of a canonical block (in gwei)
+type ApiValidatorProposalRewards struct {
+	Total             int64 `json:"total"` // consensus layer rewards
+	Attestations      int64 `json:"attestations"`
+	SyncAggregate     int64 `json:"sync_aggregate"`
+	ProposerSlashings int64 `json:"proposer_slashings"`
+	AttesterSlashings int64 `json:"attester_slashings"`
+	ElFees            int64 `json:"el_fees"`     // execution layer priority fees
+	MevPayment        int64 `json:"mev_payment"` // builder payment of relayed blocks
+}
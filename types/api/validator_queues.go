@@ -0,0 +1,42 @@
+package api
+
+import "time"
+
+// ApiValidatorQueuesResponse is the response for the activation & exit queue estimator, all balances are in gwei
+type ApiValidatorQueuesResponse struct {
+	Epoch                   uint64                      `json:"epoch"`
+	IsElectra               bool                        `json:"is_electra"`
+	ActiveValidators        uint64                      `json:"active_validators"`
+	TotalActiveBalance      uint64                      `json:"total_active_balance"`
+	ActivationChurn         uint64                      `json:"activation_churn,omitempty"` // validators per epoch (pre-electra)
+	ExitChurn               uint64                      `json:"exit_churn,omitempty"`       // validators per epoch (pre-electra)
+	BalanceChurn            uint64                      `json:"balance_churn,omitempty"`    // gwei per epoch (electra)
+	ActivationQueueLength   uint64                      `json:"activation_queue_length"`
+	ExitQueueLength         uint64                      `json:"exit_queue_length"`
+	ExitQueueBalance        uint64                      `json:"exit_queue_balance"`
+	PendingDeposits         *uint64                     `json:"pending_deposits,omitempty"` // null if the electra queue state is unavailable
+	PendingDepositBalance   *uint64                     `json:"pending_deposit_balance,omitempty"`
+	DepositBalanceToConsume *uint64                     `json:"deposit_balance_to_consume,omitempty"`
+	ExitBalanceToConsume    *uint64                     `json:"exit_balance_to_consume,omitempty"`
+	EarliestExitEpoch       *uint64                     `json:"earliest_exit_epoch,omitempty"`
+	NewDeposit              *ApiValidatorQueueEstimate  `json:"new_deposit"`
+	NewExit                 *ApiValidatorQueueEstimate  `json:"new_exit"`
+	Validator               *ApiValidatorQueueValidator `json:"validator,omitempty"`
+}
+
+// ApiValidatorQueueEstimate holds the estimated epoch & time a queue is passed
+type ApiValidatorQueueEstimate struct {
+	Epoch    uint64    `json:"epoch"`
+	Time     time.Time `json:"time"`
+	WaitTime uint64    `json:"wait_time"` // seconds
+}
+
+// ApiValidatorQueueValidator holds the queue position & estimate of a single validator
+type ApiValidatorQueueValidator struct {
+	ValidatorIndex uint64                     `json:"validator_index"`
+	Queue          string                     `json:"queue"`                   // none, deposit, activation, exit, active or exited
+	Position       uint64                     `json:"position,omitempty"`      // validators ahead in the activation queue (pre-electra)
+	BalanceAhead   uint64                     `json:"balance_ahead,omitempty"` // pending deposit balance ahead (electra)
+	Scheduled      bool                       `json:"scheduled"`               // the epoch is already set in the beacon state
+	Estimate       *ApiValidatorQueueEstimate `json:"estimate"`                // activation or exit epoch, null if unknown
+}
@@ -0,0 +1,27 @@
+package api
+
+import "time"
+
+// ApiValidatorTimelineResponse is the response for the validator lifecycle timeline
+type ApiValidatorTimelineResponse struct {
+	ValidatorIndex uint64                       `json:"validator_index"`
+	Entries        []*ApiValidatorTimelineEntry `json:"entries"`
+}
+
+// ApiValidatorTimelineEntry is a single lifecycle event of a validator
+type ApiValidatorTimelineEntry struct {
+	Type           string     `json:"type"`
+	Slot           *uint64    `json:"slot"` // null for events with unknown time
+	Epoch          *uint64    `json:"epoch"`
+	Time           *time.Time `json:"time"`
+	Pending        bool       `json:"pending"`
+	Amount         *uint64    `json:"amount,omitempty"`
+	DepositIndex   *uint64    `json:"deposit_index,omitempty"`
+	TxHash         string     `json:"tx_hash,omitempty"`
+	BlockNumber    uint64     `json:"block_number,omitempty"`
+	Validator      *uint64    `json:"validator,omitempty"`
+	IsSource       bool       `json:"is_source,omitempty"`
+	Credentials    string     `json:"withdrawal_credentials,omitempty"`
+	Result         uint8      `json:"result,omitempty"`
+	SlashingReason uint8      `json:"slashing_reason,omitempty"`
+}
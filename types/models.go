@@ -26,6 +26,8 @@ type PageData struct {
 	InfoBanner            *template.HTML
 	ClientsUpdated        bool
 	Lang                  string
+	Locale                string
+	LocaleTimeStrings     interface{} // relative time strings for the frontend scripts
 	NoAds                 bool
 	Debug                 bool
 	DebugTemplates        []string
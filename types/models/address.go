@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// AddressPageData is a struct to hold info for the execution address page
+type AddressPageData struct {
+	Address []byte `json:"address"`
+	Label   string `json:"label"`
+
+	WithdrawalValidators     []*AddressPageDataValidator `json:"withdrawal_validators"`
+	WithdrawalValidatorCount uint64                      `json:"withdrawal_validator_count"`
+	WithdrawalValidatorTotal uint64                      `json:"withdrawal_validator_total"`
+
+	FeeRecipientBlocks    uint64                  `json:"fee_recipient_blocks"`
+	FeeRecipientProposers uint64                  `json:"fee_recipient_proposers"`
+	FeeRecipientFirstSlot uint64                  `json:"fee_recipient_first_slot"`
+	FeeRecipientLastSlot  uint64                  `json:"fee_recipient_last_slot"`
+	FeeRecipientFirstTime time.Time               `json:"fee_recipient_first_time"`
+	FeeRecipientLastTime  time.Time               `json:"fee_recipient_last_time"`
+	RecentBlocks          []*AddressPageDataBlock `json:"recent_blocks"`
+	RecentBlockCount      uint64                  `json:"recent_block_count"`
+
+	ShowDeposits              bool                      `json:"show_deposits"`
+	Deposits                  []*AddressPageDataRequest `json:"deposits"`
+	DepositCount              uint64                    `json:"deposit_count"`
+	DepositTotal              uint64                    `json:"deposit_total"`
+	WithdrawalRequests        []*AddressPageDataRequest `json:"withdrawal_requests"`
+	WithdrawalRequestCount    uint64                    `json:"withdrawal_request_count"`
+	WithdrawalRequestTotal    uint64                    `json:"withdrawal_request_total"`
+	ConsolidationRequests     []*AddressPageDataRequest `json:"consolidation_requests"`
+	ConsolidationRequestCount uint64                    `json:"consolidation_request_count"`
+	ConsolidationRequestTotal uint64                    `json:"consolidation_request_total"`
+}
+
+// AddressPageDataValidator is a validator that withdraws to the address
+type AddressPageDataValidator struct {
+	Index   uint64 `json:"index"`
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Balance uint64 `json:"balance"`
+}
+
+// AddressPageDataBlock is a recent block that used the address as fee recipient
+type AddressPageDataBlock struct {
+	Epoch        uint64    `json:"epoch"`
+	Slot         uint64    `json:"slot"`
+	WithEthBlock bool      `json:"with_eth_block"`
+	EthBlock     uint64    `json:"eth_block"`
+	Ts           time.Time `json:"ts"`
+	Orphaned     bool      `json:"orphaned"`
+	Proposer     uint64    `json:"proposer"`
+	ProposerName string    `json:"proposer_name"`
+	BlockRoot    []byte    `json:"block_root"`
+}
+
+// AddressPageDataRequest is a deposit or system request contract interaction sent by the address
+type AddressPageDataRequest struct {
+	BlockNumber     uint64    `json:"block_number"`
+	Time            time.Time `json:"time"`
+	Orphaned        bool      `json:"orphaned"`
+	TxHash          []byte    `json:"tx_hash"`
+	TxSender        []byte    `json:"tx_sender"`
+	SourceAddress   []byte    `json:"source_address"`
+	ValidatorPubkey []byte    `json:"validator_pubkey"`
+	TargetPubkey    []byte    `json:"target_pubkey"`
+	Amount          uint64    `json:"amount"`
+}
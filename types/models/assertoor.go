@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/ethpandaops/dora/types"
+)
+
+// AssertoorPageData is a struct to hold info for the assertoor test runs page
+type AssertoorPageData struct {
+	AssertoorUrl string    `json:"assertoor_url"`
+	LastUpdate   time.Time `json:"last_update"`
+	LastError    string    `json:"last_error"`
+
+	TestRuns     []*AssertoorPageDataRun `json:"test_runs"`
+	RunCount     uint64                  `json:"run_count"`
+	RunningCount uint64                  `json:"running_count"`
+	SuccessCount uint64                  `json:"success_count"`
+	FailureCount uint64                  `json:"failure_count"`
+
+	SelectedRun *AssertoorPageDataRun `json:"selected_run"`
+}
+
+type AssertoorPageDataRun struct {
+	RunId       uint64                   `json:"run_id"`
+	TestId      string                   `json:"test_id"`
+	Name        string                   `json:"name"`
+	Status      string                   `json:"status"`
+	StartTime   time.Time                `json:"start_time"`
+	StopTime    time.Time                `json:"stop_time"`
+	HasStopTime bool                     `json:"has_stop_time"`
+	Duration    time.Duration            `json:"duration"`
+	TaskCount   uint64                   `json:"task_count"`
+	FailedTasks uint64                   `json:"failed_tasks"`
+	RunUrl      string                   `json:"run_url"`
+	Tasks       []*AssertoorPageDataTask `json:"tasks"`
+}
+
+type AssertoorPageDataTask struct {
+	Index       uint64                 `json:"index"`
+	Depth       uint64                 `json:"depth"`
+	Name        string                 `json:"name"`
+	Title       string                 `json:"title"`
+	Status      string                 `json:"status"`
+	Result      string                 `json:"result"`
+	ResultError string                 `json:"result_error"`
+	Duration    time.Duration          `json:"duration"`
+	Slots       []uint64               `json:"slots"`
+	Validators  []types.NamedValidator `json:"validators"`
+}
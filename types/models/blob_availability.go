@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// BlobAvailabilityPageData is a struct to hold info for the blob availability matrix page
+type BlobAvailabilityPageData struct {
+	Enabled        bool                             `json:"enabled"`
+	Running        bool                             `json:"running"`
+	VerifyKzg      bool                             `json:"verify_kzg"`
+	HistorySlots   uint64                           `json:"history_slots"`
+	Clients        []string                         `json:"clients"`
+	Blocks         []*BlobAvailabilityPageDataBlock `json:"blocks"`
+	BlockCount     uint64                           `json:"block_count"`
+	DivergingCount uint64                           `json:"diverging_count"`
+	InvalidCount   uint64                           `json:"invalid_count"`
+}
+
+type BlobAvailabilityPageDataBlock struct {
+	Slot         uint64                          `json:"slot"`
+	Time         time.Time                       `json:"time"`
+	BlockRoot    []byte                          `json:"block_root"`
+	BlobCount    uint64                          `json:"blob_count"`
+	Diverging    bool                            `json:"diverging"`
+	Invalid      bool                            `json:"invalid"`
+	MissingCount uint64                          `json:"missing_count"` // clients missing blobs
+	Cells        []*BlobAvailabilityPageDataCell `json:"cells"`         // in the order of the clients list
+}
+
+type BlobAvailabilityPageDataCell struct {
+	Polled         bool     `json:"polled"`
+	Status         []uint8  `json:"status"` // by blob index, 0: missing, 1: available, 2: verified, 3+: verification failed
+	AvailableCount uint64   `json:"available_count"`
+	InvalidCount   uint64   `json:"invalid_count"`
+	Complete       bool     `json:"complete"`
+	Results        []string `json:"results"`
+	FetchError     string   `json:"fetch_error"`
+}
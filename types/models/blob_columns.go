@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// BlobColumnsPageData is a struct to hold info for the data column availability page
+type BlobColumnsPageData struct {
+	Enabled         bool   `json:"enabled"`
+	PeerDASEpoch    uint64 `json:"peerdas_epoch"`
+	PeerDASActive   bool   `json:"peerdas_active"`
+	NumberOfColumns uint64 `json:"number_of_columns"`
+
+	Epoch      uint64                      `json:"epoch"`
+	PrevEpoch  uint64                      `json:"prev_epoch"`
+	NextEpoch  uint64                      `json:"next_epoch"`
+	HasPrev    bool                        `json:"has_prev"`
+	HasNext    bool                        `json:"has_next"`
+	Blocks     []*BlobColumnsPageDataBlock `json:"blocks"`
+	BlockCount uint64                      `json:"block_count"`
+
+	IsMatrix bool   `json:"is_matrix"`
+	Slot     uint64 `json:"slot"`
+}
+
+type BlobColumnsPageDataBlock struct {
+	Slot              uint64                       `json:"slot"`
+	Time              time.Time                    `json:"time"`
+	BlockRoot         []byte                       `json:"block_root"`
+	BlobCount         uint16                       `json:"blob_count"`
+	ClientCount       uint64                       `json:"client_count"`
+	FailedCount       uint64                       `json:"failed_count"`
+	CustodyComplete   uint64                       `json:"custody_complete"` // clients that had all their custody columns
+	AvailableColumns  uint64                       `json:"available_columns"`
+	AvailablePercent  float64                      `json:"available_percent"`
+	Reconstructable   bool                         `json:"reconstructable"`
+	Complete          bool                         `json:"complete"`
+	Clients           []*BlobColumnsPageDataClient `json:"clients"`
+	UnionColumns      []bool                       `json:"union_columns"`
+	ReconstructionMin uint64                       `json:"reconstruction_min"`
+}
+
+type BlobColumnsPageDataClient struct {
+	Name           string  `json:"name"`
+	NodeId         []byte  `json:"node_id"`
+	CustodyCount   uint64  `json:"custody_count"`
+	AvailableCount uint64  `json:"available_count"`
+	MissingCount   uint64  `json:"missing_count"` // custody columns the client didn't have
+	FetchError     string  `json:"fetch_error"`
+	Columns        []uint8 `json:"columns"` // 0: not available, 1: available, 2: custody column available, 3: custody column missing
+}
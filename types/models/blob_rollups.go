@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// BlobRollupsPageData is a struct to hold info for the rollup blob usage page
+type BlobRollupsPageData struct {
+	FilterPeriod string `json:"filter_period"`
+	Enabled      bool   `json:"enabled"`
+
+	PeriodStartSlot uint64                        `json:"period_start_slot"`
+	PeriodStartTime time.Time                     `json:"period_start_time"`
+	BucketSlots     uint64                        `json:"bucket_slots"`
+	TotalTxs        uint64                        `json:"total_txs"`
+	TotalBlobs      uint64                        `json:"total_blobs"`
+	ClassifiedBlobs uint64                        `json:"classified_blobs"`
+	ClassifiedShare float64                       `json:"classified_share"`
+	Rollups         []*BlobRollupsPageDataRollup  `json:"rollups"`
+	RollupCount     uint64                        `json:"rollup_count"`
+	UnknownSenders  []*BlobRollupsPageDataSender  `json:"unknown_senders"`
+	UnknownCount    uint64                        `json:"unknown_count"`
+	History         []*BlobRollupsPageDataHistory `json:"history"`
+	HistoryCount    uint64                        `json:"history_count"`
+}
+
+type BlobRollupsPageDataRollup struct {
+	Name       string  `json:"name"`
+	Known      bool    `json:"known"`
+	Senders    uint64  `json:"senders"`
+	Txs        uint64  `json:"txs"`
+	Blobs      uint64  `json:"blobs"`
+	Share      float64 `json:"share"`
+	BlobsPerTx float64 `json:"blobs_per_tx"`
+	LastSlot   uint64  `json:"last_slot"`
+}
+
+type BlobRollupsPageDataSender struct {
+	Sender    []byte `json:"sender"`
+	ToAddress []byte `json:"to_address"`
+	Txs       uint64 `json:"txs"`
+	Blobs     uint64 `json:"blobs"`
+	LastSlot  uint64 `json:"last_slot"`
+}
+
+type BlobRollupsPageDataHistory struct {
+	FirstSlot uint64    `json:"first_slot"`
+	LastSlot  uint64    `json:"last_slot"`
+	Time      time.Time `json:"time"`
+	Txs       uint64    `json:"txs"`
+	Blobs     uint64    `json:"blobs"`
+	Rollups   []uint64  `json:"rollups"` // blobs per rollup, in the order of the rollups list
+}
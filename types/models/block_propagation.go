@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// BlockPropagationPageData is a struct to hold info for the block propagation page
+type BlockPropagationPageData struct {
+	FilterPeriod string `json:"filter_period"`
+
+	PeriodStartSlot uint64    `json:"period_start_slot"`
+	PeriodStartTime time.Time `json:"period_start_time"`
+	BucketEpochs    uint64    `json:"bucket_epochs"`
+	SlotDuration    uint64    `json:"slot_duration"`
+	TotalBlocks     uint64    `json:"total_blocks"`
+	MinDelay        float64   `json:"min_delay"`
+	MedianDelay     float64   `json:"median_delay"`
+	MaxDelay        float64   `json:"max_delay"`
+
+	Clients         []*BlockPropagationPageDataClient  `json:"clients"`
+	ClientCount     uint64                             `json:"client_count"`
+	ClientTypes     []*BlockPropagationPageDataDelays  `json:"client_types"`
+	ClientTypeCount uint64                             `json:"client_type_count"`
+	Proposers       []*BlockPropagationPageDataDelays  `json:"proposers"`
+	ProposerCount   uint64                             `json:"proposer_count"`
+	History         []*BlockPropagationPageDataHistory `json:"history"`
+	HistoryCount    uint64                             `json:"history_count"`
+
+	AttestationMisses      []*BlockPropagationPageDataMisses `json:"attestation_misses"`
+	AttestationMissCount   uint64                            `json:"attestation_miss_count"`
+	MissesTotal            uint64                            `json:"misses_total"`
+	MissesLateBlock        uint64                            `json:"misses_late_block"`
+	MissesAttester         uint64                            `json:"misses_attester"`
+	MissesLateBlockPercent float64                           `json:"misses_late_block_percent"`
+}
+
+type BlockPropagationPageDataClient struct {
+	Name       string  `json:"name"`
+	Blocks     uint64  `json:"blocks"`
+	AvgDelay   float64 `json:"avg_delay"`
+	AvgPercent float64 `json:"avg_percent"`
+	MaxDelay   int64   `json:"max_delay"`
+}
+
+type BlockPropagationPageDataDelays struct {
+	Name          string  `json:"name"`
+	Index         uint64  `json:"index"`
+	Blocks        uint64  `json:"blocks"`
+	MinDelay      float64 `json:"min_delay"`
+	MedianDelay   float64 `json:"median_delay"`
+	MedianPercent float64 `json:"median_percent"`
+	MaxDelay      float64 `json:"max_delay"`
+}
+
+type BlockPropagationPageDataHistory struct {
+	FirstEpoch    uint64    `json:"first_epoch"`
+	LastEpoch     uint64    `json:"last_epoch"`
+	Time          time.Time `json:"time"`
+	Blocks        uint64    `json:"blocks"`
+	MinDelay      float64   `json:"min_delay"`
+	MedianDelay   float64   `json:"median_delay"`
+	MedianPercent float64   `json:"median_percent"`
+	MaxDelay      float64   `json:"max_delay"`
+}
+
+type BlockPropagationPageDataMisses struct {
+	Entity           string  `json:"entity"`
+	Epochs           uint64  `json:"epochs"`
+	MissedLateBlock  uint64  `json:"missed_late_block"`
+	MissedAttester   uint64  `json:"missed_attester"`
+	DelayedLateBlock uint64  `json:"delayed_late_block"`
+	DelayedAttester  uint64  `json:"delayed_attester"`
+	LateBlockPercent float64 `json:"late_block_percent"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// BlockTagsPageData is a struct to hold info for the block tags page
+type BlockTagsPageData struct {
+	FilterPeriod string `json:"filter_period"`
+
+	PeriodStartSlot uint64                      `json:"period_start_slot"`
+	PeriodStartTime time.Time                   `json:"period_start_time"`
+	BucketEpochs    uint64                      `json:"bucket_epochs"`
+	TotalBlocks     uint64                      `json:"total_blocks"`
+	TaggedBlocks    uint64                      `json:"tagged_blocks"`
+	Tags            []*BlockTagsPageDataTag     `json:"tags"`
+	History         []*BlockTagsPageDataHistory `json:"history"`
+	HistoryCount    uint64                      `json:"history_count"`
+}
+
+type BlockTagsPageDataTag struct {
+	Tag        string  `json:"tag"`
+	Configured bool    `json:"configured"` // false if the tag has been assigned by a rule that has been removed from the config
+	Graffiti   string  `json:"graffiti"`
+	ExtraData  string  `json:"extra_data"`
+	Blocks     uint64  `json:"blocks"`
+	Share      float64 `json:"share"`
+}
+
+type BlockTagsPageDataHistory struct {
+	FirstEpoch uint64    `json:"first_epoch"`
+	LastEpoch  uint64    `json:"last_epoch"`
+	Time       time.Time `json:"time"`
+	Blocks     uint64    `json:"blocks"`
+	Shares     []float64 `json:"shares"`
+}
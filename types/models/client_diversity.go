@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ClientDiversityPageData is a struct to hold info for the client diversity page
+type ClientDiversityPageData struct {
+	FilterPeriod string `json:"filter_period"`
+
+	PeriodStartSlot uint64                            `json:"period_start_slot"`
+	PeriodStartTime time.Time                         `json:"period_start_time"`
+	BucketEpochs    uint64                            `json:"bucket_epochs"`
+	TotalBlocks     uint64                            `json:"total_blocks"`
+	ClClients       []*ClientDiversityPageDataClient  `json:"cl_clients"`
+	ElClients       []*ClientDiversityPageDataClient  `json:"el_clients"`
+	History         []*ClientDiversityPageDataHistory `json:"history"`
+	HistoryCount    uint64                            `json:"history_count"`
+
+	BlockprintEnabled bool                                 `json:"blockprint_enabled"`
+	BlockprintBlocks  uint64                               `json:"blockprint_blocks"`
+	BlockprintClients []*ClientDiversityPageDataClient     `json:"blockprint_clients"`
+	BlockprintDays    []*ClientDiversityPageDataBlockprint `json:"blockprint_days"`
+	BlockprintCount   uint64                               `json:"blockprint_count"`
+}
+
+type ClientDiversityPageDataClient struct {
+	Name   string  `json:"name"`
+	Blocks uint64  `json:"blocks"`
+	Share  float64 `json:"share"`
+}
+
+type ClientDiversityPageDataHistory struct {
+	FirstEpoch uint64    `json:"first_epoch"`
+	LastEpoch  uint64    `json:"last_epoch"`
+	Time       time.Time `json:"time"`
+	Blocks     uint64    `json:"blocks"`
+	ClShares   []float64 `json:"cl_shares"`
+	ElShares   []float64 `json:"el_shares"`
+}
+
+type ClientDiversityPageDataBlockprint struct {
+	Day    uint64    `json:"day"`
+	Time   time.Time `json:"time"`
+	Blocks uint64    `json:"blocks"`
+	Shares []float64 `json:"shares"`
+}
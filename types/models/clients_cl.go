@@ -13,6 +13,9 @@ type ClientsCLPageData struct {
 	ShowPeerDASInfos       bool                             `json:"show_peer_das_infos"`
 	PeerDASInfos           *ClientCLPagePeerDAS             `json:"peer_das"`
 	Nodes                  map[string]*ClientCLPageDataNode `json:"nodes"`
+	Connectivity           *ClientCLPageDataConnectivity    `json:"connectivity"`
+	Divergences            []*ClientCLPageDataDivergence    `json:"divergences"`
+	OpenDivergenceCount    uint64                           `json:"open_divergence_count"`
 }
 
 // ## Peer graph data
@@ -39,6 +42,41 @@ type ClientCLDataMapPeerMapEdge struct {
 	Interaction string `json:"interaction"`
 }
 
+// ## Client connectivity data
+
+// ClientCLPageDataConnectivity represents the peerings between the configured CL clients
+type ClientCLPageDataConnectivity struct {
+	Clients       []*ClientCLPageDataConnectivityClient `json:"clients"`
+	IsolatedCount uint64                                `json:"isolated_count"` // clients that do not peer with any other configured client
+}
+
+// ClientCLPageDataConnectivityClient represents a row of the client connectivity matrix
+type ClientCLPageDataConnectivityClient struct {
+	Name          string   `json:"name"`
+	PeerID        string   `json:"peer_id"`
+	Version       string   `json:"version"`
+	InternalPeers uint32   `json:"internal_peers"`
+	ExternalPeers uint32   `json:"external_peers"`
+	Isolated      bool     `json:"isolated"`
+	Links         []string `json:"links"` // per client column: "" (not connected), "self", "inbound", "outbound" or "both"
+}
+
+// ## Client head divergences
+
+// ClientCLPageDataDivergence represents an episode where a client was off the canonical chain
+type ClientCLPageDataDivergence struct {
+	ClientName  string    `json:"client_name"`
+	Reason      string    `json:"reason"`
+	StartSlot   uint64    `json:"start_slot"`
+	StartTime   time.Time `json:"start_time"`
+	EndSlot     uint64    `json:"end_slot"`
+	EndTime     time.Time `json:"end_time"`
+	HeadSlot    uint64    `json:"head_slot"`
+	HeadRoot    []byte    `json:"head_root"`
+	MaxDistance uint64    `json:"max_distance"`
+	Resolved    bool      `json:"resolved"`
+}
+
 // ## PeerDAS data
 
 // ClientCLPagePeerDAS represents the DAS information from all clients and peers.
@@ -82,6 +120,16 @@ type ClientsCLPageDataClient struct {
 	PeerCount            uint32    `json:"peer_count"`
 	PeersInboundCounter  uint32    `json:"peers_inbound_counter"`
 	PeersOutboundCounter uint32    `json:"peers_outbound_counter"`
+	FetchLatency         uint64    `json:"fetch_latency"`
+	FetchRaces           uint64    `json:"fetch_races"`
+	FetchWins            uint64    `json:"fetch_wins"`
+	FetchWinRate         float64   `json:"fetch_win_rate"`
+	EventLag             uint64    `json:"event_lag"`
+	EventMaxLag          uint64    `json:"event_max_lag"`
+	EventQueue           uint64    `json:"event_queue"`
+	EventsReceived       uint64    `json:"events_received"`
+	EventsDropped        uint64    `json:"events_dropped"`
+	EventTopics          string    `json:"event_topics"`
 }
 
 // ClientCLPageDataNode represents a generic node on the CL network. Can be a client or a peer of a client
@@ -97,6 +145,9 @@ type ClientCLPageDataNode struct {
 	PeerDAS      *ClientCLPageDataNodePeerDAS    `json:"peer_das"`
 	PeersIn      []string                        `json:"peers_in"`
 	PeersOut     []string                        `json:"peers_out"`
+	Attnets      []uint64                        `json:"attnets"`       // subscribed attestation subnets (from node metadata or enr)
+	Syncnets     []uint64                        `json:"syncnets"`      // subscribed sync committee subnets (from node metadata or enr)
+	P2PAddresses []string                        `json:"p2p_addresses"` // only relevant for internal peers
 }
 
 // ClientCLPageDataNodePeers represents the peers of a client
@@ -0,0 +1,36 @@
+package models
+
+import "html/template"
+
+// CustomPageData is a struct to hold info for an operator defined custom page
+type CustomPageData struct {
+	Name        string                  `json:"name"`
+	Title       string                  `json:"title"`
+	Description string                  `json:"description"`
+	Icon        string                  `json:"icon"`
+	Params      []*CustomPageDataParam  `json:"params"`
+	Columns     []*CustomPageDataColumn `json:"columns"`
+	Rows        [][]template.HTML       `json:"rows"`
+	RowCount    uint64                  `json:"row_count"`
+	QueryError  string                  `json:"query_error"`
+
+	CurrentPageIndex uint64 `json:"page_index"`
+	PrevPageIndex    uint64 `json:"prev_page_index"`
+	NextPageIndex    uint64 `json:"next_page_index"`
+
+	FirstPageLink string `json:"first_page_link"`
+	PrevPageLink  string `json:"prev_page_link"`
+	NextPageLink  string `json:"next_page_link"`
+	ApiLink       string `json:"api_link"`
+}
+
+type CustomPageDataParam struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type CustomPageDataColumn struct {
+	Label string `json:"label"`
+}
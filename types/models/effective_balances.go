@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// EffectiveBalancesPageData is a struct to hold info for the effective balance distribution page
+type EffectiveBalancesPageData struct {
+	Epoch               uint64                              `json:"epoch"`
+	EpochTime           time.Time                           `json:"epoch_time"`
+	Validators          uint64                              `json:"validators"`
+	Compounding         uint64                              `json:"compounding"`
+	CompoundingShare    float64                             `json:"compounding_share"`
+	EffectiveBalance    uint64                              `json:"effective_balance"`
+	AvgEffectiveBalance uint64                              `json:"avg_effective_balance"`
+	Buckets             []*EffectiveBalancesPageDataBucket  `json:"buckets"`
+	BucketCount         uint64                              `json:"bucket_count"`
+	History             []*EffectiveBalancesPageDataHistory `json:"history"`
+	HistoryCount        uint64                              `json:"history_count"`
+	RecentChanges       []*EffectiveBalancesPageDataChange  `json:"recent_changes"`
+	RecentChangeCount   uint64                              `json:"recent_change_count"`
+}
+
+type EffectiveBalancesPageDataBucket struct {
+	Name             string  `json:"name"`
+	Validators       uint64  `json:"validators"`
+	Compounding      uint64  `json:"compounding"`
+	Share            float64 `json:"share"` // share of the active validators (in percent)
+	EffectiveBalance uint64  `json:"effective_balance"`
+	BalanceShare     float64 `json:"balance_share"` // share of the total effective balance (in percent)
+}
+
+type EffectiveBalancesPageDataHistory struct {
+	Epoch               uint64    `json:"epoch"`
+	Time                time.Time `json:"time"`
+	Validators          uint64    `json:"validators"`
+	Compounding         uint64    `json:"compounding"`
+	CompoundingShare    float64   `json:"compounding_share"`
+	Above32Eth          uint64    `json:"above_32_eth"` // validators with an effective balance above 32 ETH
+	AvgEffectiveBalance uint64    `json:"avg_effective_balance"`
+}
+
+type EffectiveBalancesPageDataChange struct {
+	ValidatorIndex      uint64    `json:"validator_index"`
+	ValidatorName       string    `json:"validator_name"`
+	Epoch               uint64    `json:"epoch"`
+	Time                time.Time `json:"time"`
+	OldEffectiveBalance uint64    `json:"old_effective_balance"`
+	NewEffectiveBalance uint64    `json:"new_effective_balance"`
+	Change              int64     `json:"change"`
+	Compounding         bool      `json:"compounding"`
+}
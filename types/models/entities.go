@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+)
+
+// EntitiesPageData is a struct to hold info for the entities page
+type EntitiesPageData struct {
+	Entities    []*EntitiesPageDataEntity `json:"entities"`
+	EntityCount uint64                    `json:"entity_count"`
+}
+
+// EntitiesPageDataEntity is a single entity of the entities page
+type EntitiesPageDataEntity struct {
+	Id          uint64 `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	EntityValidatorStats
+}
+
+// EntityValidatorStats aggregates the validators of an entity
+type EntityValidatorStats struct {
+	Validators       uint64 `json:"validators"`
+	Pending          uint64 `json:"pending"`
+	Activated        uint64 `json:"activated"`
+	Online           uint64 `json:"online"`
+	Offline          uint64 `json:"offline"`
+	Exited           uint64 `json:"exited"`
+	Slashed          uint64 `json:"slashed"`
+	EffectiveBalance uint64 `json:"effective_balance"` // ETH
+}
+
+// EntityPageData is a struct to hold info for the entity page
+type EntityPageData struct {
+	Id               uint64                 `json:"id"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	Links            []*EntityPageDataLink  `json:"links"`
+	ValidatorRanges  []*EntityPageDataRange `json:"validator_ranges"`
+	Addresses        []*EntityPageDataAddr  `json:"addresses"`
+	Created          time.Time              `json:"created"`
+	Updated          time.Time              `json:"updated"`
+	RecentBlocks     []*EntityPageDataBlock `json:"recent_blocks"`
+	RecentBlockCount uint64                 `json:"recent_block_count"`
+	EntityValidatorStats
+}
+
+// EntityPageDataLink is an external link of the entity
+type EntityPageDataLink struct {
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+// EntityPageDataRange is a validator index range of the entity
+type EntityPageDataRange struct {
+	MinIndex uint64 `json:"min_index"`
+	MaxIndex uint64 `json:"max_index"`
+}
+
+// EntityPageDataAddr is a withdrawal or deposit address of the entity
+type EntityPageDataAddr struct {
+	Type    string `json:"type"`
+	Address []byte `json:"address"`
+}
+
+// EntityPageDataBlock is a recent block proposal of the entity validators
+type EntityPageDataBlock struct {
+	Epoch        uint64    `json:"epoch"`
+	Slot         uint64    `json:"slot"`
+	WithEthBlock bool      `json:"with_eth_block"`
+	EthBlock     uint64    `json:"eth_block"`
+	Ts           time.Time `json:"ts"`
+	Status       uint64    `json:"status"`
+	Proposer     uint64    `json:"proposer"`
+	BlockRoot    string    `json:"block_root"`
+	Graffiti     []byte    `json:"graffiti"`
+}
@@ -35,6 +35,8 @@ type EpochPageData struct {
 	ScheduledCount          uint64               `json:"scheduled_count"`
 	OrphanedCount           uint64               `json:"orphaned_count"`
 	EthTransactionCount     uint64               `json:"eth_transaction_count"`
+	ShowRewards             bool                 `json:"show_rewards"`
+	TotalRewards            int64                `json:"total_rewards"`
 	Slots                   []*EpochPageDataSlot `json:"slots"`
 }
 
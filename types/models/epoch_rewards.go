@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// EpochRewardsPageData is a struct to hold info for the epoch rewards page
+type EpochRewardsPageData struct {
+	Epoch                     uint64                       `json:"epoch"`
+	PreviousEpoch             uint64                       `json:"prev_epoch"`
+	NextEpoch                 uint64                       `json:"next_epoch"`
+	Ts                        time.Time                    `json:"ts"`
+	Indexed                   bool                         `json:"indexed"`
+	Validators                uint64                       `json:"validators"`
+	AttestationHead           int64                        `json:"attestation_head"`
+	AttestationTarget         int64                        `json:"attestation_target"`
+	AttestationSource         int64                        `json:"attestation_source"`
+	AttestationInclusionDelay int64                        `json:"attestation_inclusion_delay"`
+	AttestationInactivity     int64                        `json:"attestation_inactivity"`
+	AttestationTotal          int64                        `json:"attestation_total"`
+	SyncCommittee             int64                        `json:"sync_committee"`
+	Proposer                  int64                        `json:"proposer"`
+	Total                     int64                        `json:"total"`
+	Blocks                    []*EpochRewardsPageDataBlock `json:"blocks"`
+	BlockCount                uint64                       `json:"block_count"`
+}
+
+type EpochRewardsPageDataBlock struct {
+	Slot              uint64    `json:"slot"`
+	Ts                time.Time `json:"ts"`
+	Proposer          uint64    `json:"proposer"`
+	ProposerName      string    `json:"proposer_name"`
+	Total             int64     `json:"total"`
+	Attestations      int64     `json:"attestations"`
+	SyncAggregate     int64     `json:"sync_aggregate"`
+	ProposerSlashings int64     `json:"proposer_slashings"`
+	AttesterSlashings int64     `json:"attester_slashings"`
+}
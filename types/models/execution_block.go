@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+)
+
+// ExecutionBlockPageData is a struct to hold info for the execution block page
+type ExecutionBlockPageData struct {
+	BlockNumber      uint64                           `json:"block_number"`
+	BlockHash        []byte                           `json:"block_hash"`
+	ParentHash       []byte                           `json:"parent_hash"`
+	FeeRecipient     []byte                           `json:"fee_recipient"`
+	StateRoot        []byte                           `json:"state_root"`
+	ReceiptsRoot     []byte                           `json:"receipts_root"`
+	GasLimit         uint64                           `json:"gas_limit"`
+	GasUsed          uint64                           `json:"gas_used"`
+	GasUsedPercent   float64                          `json:"gas_used_percent"`
+	BaseFeePerGas    uint64                           `json:"base_fee_per_gas"`
+	Time             time.Time                        `json:"time"`
+	ExtraData        []byte                           `json:"extra_data"`
+	Slot             uint64                           `json:"slot"`
+	BlockRoot        []byte                           `json:"block_root"`
+	Orphaned         bool                             `json:"orphaned"`
+	BeaconBlocks     []*ExecutionBlockPageBeaconBlock `json:"beacon_blocks"`
+	TransactionCount uint64                           `json:"transaction_count"`
+	Transactions     []*ExecutionBlockPageTransaction `json:"transactions"`
+	ShowReceipts     bool                             `json:"show_receipts"`
+	ReceiptsError    string                           `json:"receipts_error"`
+	TotalFees        float64                          `json:"total_fees"`
+}
+
+// ExecutionBlockPageBeaconBlock is a beacon block that includes the execution block
+type ExecutionBlockPageBeaconBlock struct {
+	Slot      uint64 `json:"slot"`
+	BlockRoot []byte `json:"block_root"`
+	Orphaned  bool   `json:"orphaned"`
+	Selected  bool   `json:"selected"`
+}
+
+// ExecutionBlockPageTransaction is a transaction of the execution block, receipt fields are only set if receipts were requested
+type ExecutionBlockPageTransaction struct {
+	Index           uint64  `json:"index"`
+	Hash            []byte  `json:"hash"`
+	From            string  `json:"from"`
+	To              string  `json:"to"`
+	Value           float64 `json:"value"`
+	Type            uint64  `json:"type"`
+	FuncSigStatus   uint64  `json:"func_sig_status"`
+	FuncName        string  `json:"func_name"`
+	FuncSig         string  `json:"func_sig"`
+	GasLimit        uint64  `json:"gas_limit"`
+	HasReceipt      bool    `json:"has_receipt"`
+	Success         bool    `json:"success"`
+	GasUsed         uint64  `json:"gas_used"`
+	GasPrice        uint64  `json:"gas_price"`
+	Fee             float64 `json:"fee"`
+	LogCount        uint64  `json:"log_count"`
+	ContractAddress []byte  `json:"contract_address"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// ExternalLink is a link to an external tool (e.g. tracoor or forky) built from a configured url template
+type ExternalLink struct {
+	Name string `json:"name"`
+	Icon string `json:"icon"`
+	Url  string `json:"url"`
+}
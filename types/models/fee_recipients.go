@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// FeeRecipientsPageData is a struct to hold info for the fee recipient monitoring page
+type FeeRecipientsPageData struct {
+	FilterPeriod     string `json:"filter_period"`
+	FilterMismatches bool   `json:"filter_mismatches"`
+
+	PeriodStartSlot   uint64                        `json:"period_start_slot"`
+	PeriodStartTime   time.Time                     `json:"period_start_time"`
+	HasExpectations   bool                          `json:"has_expectations"`
+	TotalBlocks       uint64                        `json:"total_blocks"`
+	MismatchBlocks    uint64                        `json:"mismatch_blocks"`
+	Proposers         uint64                        `json:"proposers"`
+	MismatchProposers uint64                        `json:"mismatch_proposers"`
+	Recipients        []*FeeRecipientsPageDataEntry `json:"recipients"`
+	RecipientCount    uint64                        `json:"recipient_count"`
+}
+
+type FeeRecipientsPageDataEntry struct {
+	Validator     uint64 `json:"validator"`
+	ValidatorName string `json:"validator_name"`
+	FeeRecipient  []byte `json:"fee_recipient"`
+	HasExpected   bool   `json:"has_expected"`
+	Expected      []byte `json:"expected"`
+	Mismatch      bool   `json:"mismatch"`
+	Blocks        uint64 `json:"blocks"`
+	FirstSlot     uint64 `json:"first_slot"`
+	LastSlot      uint64 `json:"last_slot"`
+}
@@ -9,10 +9,11 @@ type ForksPageData struct {
 }
 
 type ForksPageDataFork struct {
-	HeadSlot    uint64                 `json:"head_slot"`
-	HeadRoot    []byte                 `json:"head_root"`
-	Clients     []*ForksPageDataClient `json:"clients"`
-	ClientCount uint64                 `json:"client_count"`
+	HeadSlot      uint64                 `json:"head_slot"`
+	HeadRoot      []byte                 `json:"head_root"`
+	Clients       []*ForksPageDataClient `json:"clients"`
+	ClientCount   uint64                 `json:"client_count"`
+	ExternalLinks []*ExternalLink        `json:"external_links"`
 }
 
 type ForksPageDataClient struct {
@@ -0,0 +1,39 @@
+package models
+
+// GenesisValidatorsPageData is a struct to hold info for the genesis validators page
+type GenesisValidatorsPageData struct {
+	FilterEntity   string `json:"filter_entity"`
+	FilterMinIndex uint64 `json:"filter_mini"`
+	FilterMaxIndex uint64 `json:"filter_maxi"`
+
+	IsLoaded        bool                                  `json:"is_loaded"`
+	TotalValidators uint64                                `json:"total_validators"`
+	TotalBalance    uint64                                `json:"total_balance"`
+	Entities        []*GenesisValidatorsPageDataEntity    `json:"entities"`
+	EntityCount     uint64                                `json:"entity_count"`
+	Validators      []*GenesisValidatorsPageDataValidator `json:"validators"`
+	ValidatorCount  uint64                                `json:"validator_count"`
+	FirstIndex      uint64                                `json:"first_index"`
+	LastIndex       uint64                                `json:"last_index"`
+
+	PagingData
+}
+
+type GenesisValidatorsPageDataEntity struct {
+	Entity     string  `json:"entity"`
+	Validators uint64  `json:"validators"`
+	Balance    uint64  `json:"balance"`
+	Share      float64 `json:"share"`
+}
+
+type GenesisValidatorsPageDataValidator struct {
+	Index           uint64 `json:"index"`
+	Name            string `json:"name"`
+	PublicKey       []byte `json:"pubkey"`
+	WithdrawalCreds []byte `json:"withdrawal_creds"`
+	GenesisBalance  uint64 `json:"genesis_balance"`
+	Entity          string `json:"entity"`
+	State           string `json:"state"`
+	CurrentBalance  uint64 `json:"current_balance"`
+	CredsChanged    bool   `json:"creds_changed"`
+}
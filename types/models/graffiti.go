@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// GraffitiPageData is a struct to hold info for the graffiti leaderboard page
+type GraffitiPageData struct {
+	FilterPeriod string `json:"filter_period"`
+	FilterSearch string `json:"filter_search"`
+
+	PeriodStartSlot uint64                      `json:"period_start_slot"`
+	PeriodStartTime time.Time                   `json:"period_start_time"`
+	TotalGraffitis  uint64                      `json:"total_graffitis"`
+	TotalBlocks     uint64                      `json:"total_blocks"`
+	Graffitis       []*GraffitiPageDataGraffiti `json:"graffitis"`
+	GraffitiCount   uint64                      `json:"graffiti_count"`
+	FirstRank       uint64                      `json:"first_rank"`
+	LastRank        uint64                      `json:"last_rank"`
+
+	PagingData
+}
+
+type GraffitiPageDataGraffiti struct {
+	Rank      uint64    `json:"rank"`
+	Graffiti  string    `json:"graffiti"`
+	Blocks    uint64    `json:"blocks"`
+	Share     float64   `json:"share"`
+	Proposers uint64    `json:"proposers"`
+	LastSlot  uint64    `json:"last_slot"`
+	LastTime  time.Time `json:"last_time"`
+}
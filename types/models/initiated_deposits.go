@@ -13,24 +13,14 @@ type InitiatedDepositsPageData struct {
 	FilterMaxAmount     uint64 `json:"filter_maxa"`
 	FilterWithOrphaned  uint8  `json:"filter_orphaned"`
 	FilterWithValid     uint8  `json:"filter_valid"`
+	FilterWithProblems  uint8  `json:"filter_problems"`
 
 	Deposits     []*InitiatedDepositsPageDataDeposit `json:"deposits"`
 	DepositCount uint64                              `json:"deposit_count"`
 	FirstIndex   uint64                              `json:"first_index"`
 	LastIndex    uint64                              `json:"last_index"`
 
-	IsDefaultPage    bool   `json:"default_page"`
-	TotalPages       uint64 `json:"total_pages"`
-	PageSize         uint64 `json:"page_size"`
-	CurrentPageIndex uint64 `json:"page_index"`
-	PrevPageIndex    uint64 `json:"prev_page_index"`
-	NextPageIndex    uint64 `json:"next_page_index"`
-	LastPageIndex    uint64 `json:"last_page_index"`
-
-	FirstPageLink string `json:"first_page_link"`
-	PrevPageLink  string `json:"prev_page_link"`
-	NextPageLink  string `json:"next_page_link"`
-	LastPageLink  string `json:"last_page_link"`
+	PagingData
 }
 
 type InitiatedDepositsPageDataDeposit struct {
@@ -44,6 +34,7 @@ type InitiatedDepositsPageDataDeposit struct {
 	Block                 uint64    `json:"block"`
 	Orphaned              bool      `json:"orphaned"`
 	Valid                 bool      `json:"valid"`
+	Problems              []string  `json:"problems"`
 	ValidatorStatus       string    `json:"vstatus"`
 	ShowUpcheck           bool      `json:"show_upcheck"`
 	UpcheckActivity       uint8     `json:"upcheck_act"`
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// LightClientPageData is a struct to hold info for the light client updates page
+type LightClientPageData struct {
+	Enabled           bool                         `json:"enabled"`
+	SyncCommitteeSize uint64                       `json:"sync_committee_size"`
+	CurrentPeriod     uint64                       `json:"current_period"`
+	FirstPeriod       uint64                       `json:"first_period"`
+	LastPeriod        uint64                       `json:"last_period"`
+	PrevPeriod        uint64                       `json:"prev_period"`
+	NextPeriod        uint64                       `json:"next_period"`
+	HasPrev           bool                         `json:"has_prev"`
+	HasNext           bool                         `json:"has_next"`
+	InvalidCount      uint64                       `json:"invalid_count"`
+	Periods           []*LightClientPageDataPeriod `json:"periods"`
+}
+
+type LightClientPageDataPeriod struct {
+	Period     uint64                       `json:"period"`
+	FirstEpoch uint64                       `json:"first_epoch"`
+	LastEpoch  uint64                       `json:"last_epoch"`
+	StartTime  time.Time                    `json:"start_time"`
+	IsCurrent  bool                         `json:"is_current"`
+	Diverging  bool                         `json:"diverging"` // clients served different next sync committees
+	Invalid    bool                         `json:"invalid"`
+	Clients    []*LightClientPageDataClient `json:"clients"`
+}
+
+type LightClientPageDataClient struct {
+	Name       string                     `json:"name"`
+	Update     *LightClientPageDataUpdate `json:"update"`
+	Finality   *LightClientPageDataUpdate `json:"finality"`
+	Optimistic *LightClientPageDataUpdate `json:"optimistic"`
+}
+
+type LightClientPageDataUpdate struct {
+	Version           string    `json:"version"`
+	AttestedSlot      uint64    `json:"attested_slot"`
+	AttestedRoot      []byte    `json:"attested_root"`
+	FinalizedSlot     uint64    `json:"finalized_slot"`
+	FinalizedRoot     []byte    `json:"finalized_root"`
+	SignatureSlot     uint64    `json:"signature_slot"`
+	Participation     uint32    `json:"participation"`
+	NextCommitteeRoot []byte    `json:"next_committee_root"`
+	Status            uint8     `json:"status"` // 0: unverified, 1: valid, 2: invalid
+	Result            string    `json:"result"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
@@ -20,18 +20,7 @@ type MevBlocksPageData struct {
 	FirstIndex uint64                    `json:"first_index"`
 	LastIndex  uint64                    `json:"last_index"`
 
-	IsDefaultPage    bool   `json:"default_page"`
-	TotalPages       uint64 `json:"total_pages"`
-	PageSize         uint64 `json:"page_size"`
-	CurrentPageIndex uint64 `json:"page_index"`
-	PrevPageIndex    uint64 `json:"prev_page_index"`
-	NextPageIndex    uint64 `json:"next_page_index"`
-	LastPageIndex    uint64 `json:"last_page_index"`
-
-	FirstPageLink string `json:"first_page_link"`
-	PrevPageLink  string `json:"prev_page_link"`
-	NextPageLink  string `json:"next_page_link"`
-	LastPageLink  string `json:"last_page_link"`
+	PagingData
 }
 
 type MevBlocksPageDataBlock struct {
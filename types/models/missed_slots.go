@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// MissedSlotsPageData is a struct to hold info for the missed slots page
+type MissedSlotsPageData struct {
+	FilterMinSlot    uint64 `json:"filter_mins"`
+	FilterMaxSlot    uint64 `json:"filter_maxs"`
+	FilterProposer   string `json:"filter_proposer"`
+	FilterWithReason uint8  `json:"filter_reason"`
+
+	OfflineCount uint64 `json:"offline_count"`
+	ReorgedCount uint64 `json:"reorged_count"`
+	LateCount    uint64 `json:"late_count"`
+	TotalCount   uint64 `json:"total_count"`
+
+	MissedSlots     []*MissedSlotsPageDataSlot `json:"missed_slots"`
+	MissedSlotCount uint64                     `json:"missed_slot_count"`
+	FirstIndex      uint64                     `json:"first_index"`
+	LastIndex       uint64                     `json:"last_index"`
+
+	PagingData
+}
+
+type MissedSlotsPageDataSlot struct {
+	Slot         uint64    `json:"slot"`
+	Time         time.Time `json:"time"`
+	Proposer     uint64    `json:"proposer"`
+	ProposerName string    `json:"proposer_name"`
+	Reason       uint8     `json:"reason"`
+	BlockRoot    []byte    `json:"block_root"`
+	HasSeenDelay bool      `json:"has_seen_delay"`
+	SeenDelay    int64     `json:"seen_delay"`
+	SeenBy       uint32    `json:"seen_by"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// RequestFeesPageData is a struct to hold info for the el triggered request fees page
+type RequestFeesPageData struct {
+	FilterPeriod string `json:"filter_period"`
+
+	PeriodStartTime time.Time                      `json:"period_start_time"`
+	BucketHours     uint64                         `json:"bucket_hours"`
+	Contracts       []*RequestFeesPageDataContract `json:"contracts"`
+}
+
+type RequestFeesPageDataContract struct {
+	Name          string                       `json:"name"`
+	Contract      []byte                       `json:"contract"`
+	HasCurrentFee bool                         `json:"has_current_fee"`
+	CurrentFee    uint64                       `json:"current_fee"`
+	Requests      uint64                       `json:"requests"`
+	History       []*RequestFeesPageDataBucket `json:"history"`
+	HistoryCount  uint64                       `json:"history_count"`
+}
+
+type RequestFeesPageDataBucket struct {
+	Time        time.Time `json:"time"`
+	Requests    uint64    `json:"requests"`
+	MinFee      uint64    `json:"min_fee"`
+	MaxFee      uint64    `json:"max_fee"`
+	AvgFee      uint64    `json:"avg_fee"`
+	AvgFeeShare float64   `json:"avg_fee_share"` // average fee relative to the highest average fee in the period (in percent)
+}
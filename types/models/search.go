@@ -46,3 +46,9 @@ type SearchAheadValidatorNameResult struct {
 	Name  string `json:"name,omitempty"`
 	Count string `json:"count,omitempty"`
 }
+
+// SearchAheadAddressLabelResult is a struct to hold the search ahead execution address results with a given label
+type SearchAheadAddressLabelResult struct {
+	Address string `json:"address,omitempty"`
+	Label   string `json:"label,omitempty"`
+}
@@ -8,19 +8,41 @@ import (
 
 // SlotPageData is a struct to hold info for the slot details page
 type SlotPageData struct {
-	Slot                   uint64                `json:"slot"`
-	Epoch                  uint64                `json:"epoch"`
-	EpochFinalized         bool                  `json:"epoch_finalized"`
-	EpochParticipationRate float64               `json:"epoch_participation_rate"`
-	Ts                     time.Time             `json:"time"`
-	NextSlot               uint64                `json:"next_slot"`
-	PreviousSlot           uint64                `json:"prev_slot"`
-	Status                 uint16                `json:"status"`
-	Future                 bool                  `json:"future"`
-	Proposer               uint64                `json:"proposer"`
-	ProposerName           string                `json:"proposer_name"`
-	Block                  *SlotPageBlockData    `json:"block"`
-	Badges                 []*SlotPageBlockBadge `json:"badges"`
+	Slot                   uint64                   `json:"slot"`
+	Epoch                  uint64                   `json:"epoch"`
+	EpochFinalized         bool                     `json:"epoch_finalized"`
+	EpochParticipationRate float64                  `json:"epoch_participation_rate"`
+	Ts                     time.Time                `json:"time"`
+	NextSlot               uint64                   `json:"next_slot"`
+	PreviousSlot           uint64                   `json:"prev_slot"`
+	Status                 uint16                   `json:"status"`
+	Future                 bool                     `json:"future"`
+	Proposer               uint64                   `json:"proposer"`
+	ProposerName           string                   `json:"proposer_name"`
+	Block                  *SlotPageBlockData       `json:"block"`
+	Badges                 []*SlotPageBlockBadge    `json:"badges"`
+	Rewards                *SlotPageRewards         `json:"rewards"`
+	EquivocationRoots      [][]byte                 `json:"equivocation_roots"` // conflicting blocks of the same proposer
+	Reassignments          []*SlotPageReassignment  `json:"reassignments"`      // proposer duty changes caused by reorgs
+	PayloadStatuses        []*SlotPagePayloadStatus `json:"payload_statuses"`   // execution payload verdicts of the connected clients
+	ExternalLinks          []*ExternalLink          `json:"external_links"`
+}
+
+// SlotPageReassignment is a change of the expected proposer caused by a reorg that switched the dependent root of the epoch
+type SlotPageReassignment struct {
+	SwitchSlot       uint64 `json:"switch_slot"`
+	OldProposer      uint64 `json:"old_proposer"`
+	OldProposerName  string `json:"old_proposer_name"`
+	NewProposer      uint64 `json:"new_proposer"`
+	NewProposerName  string `json:"new_proposer_name"`
+	OldDependentRoot []byte `json:"old_dependent_root"`
+	NewDependentRoot []byte `json:"new_dependent_root"`
+}
+
+// SlotPagePayloadStatus is the execution payload verdict of a connected client for the block
+type SlotPagePayloadStatus struct {
+	ClientName string `json:"client_name"`
+	Status     string `json:"status"` // VALID, SYNCING or INVALID
 }
 
 type SlotPageBlockBadge struct {
@@ -30,6 +52,18 @@ type SlotPageBlockBadge struct {
 	ClassName   string `json:"class"`
 }
 
+// SlotPageRewards holds the proposer reward breakdown of a block (all amounts in gwei)
+type SlotPageRewards struct {
+	Consensus     int64 `json:"consensus"`
+	Attestations  int64 `json:"attestations"`
+	SyncAggregate int64 `json:"sync_aggregate"`
+	Slashings     int64 `json:"slashings"`
+	ElFees        int64 `json:"el_fees"`
+	MevBlock      bool  `json:"mev_block"`
+	MevPayment    int64 `json:"mev_payment"`
+	Total         int64 `json:"total"`
+}
+
 type SlotStatus uint16
 
 const (
@@ -66,19 +100,26 @@ type SlotPageBlockData struct {
 	WithdrawalRequestsCount    uint64                 `json:"withdrawal_requests_count"`
 	ConsolidationRequestsCount uint64                 `json:"consolidation_requests_count"`
 
+	AttestationsPageIndex    uint64 `json:"attestations_page_index"`
+	AttestationsTotalPages   uint64 `json:"attestations_total_pages"`
+	AttestationsPrevPageLink string `json:"attestations_prev_page_link"`
+	AttestationsNextPageLink string `json:"attestations_next_page_link"`
+
 	ExecutionData         *SlotPageExecutionData          `json:"execution_data"`
-	Attestations          []*SlotPageAttestation          `json:"attestations"`           // Attestations included in this block
-	Deposits              []*SlotPageDeposit              `json:"deposits"`               // Deposits included in this block
-	VoluntaryExits        []*SlotPageVoluntaryExit        `json:"voluntary_exits"`        // Voluntary Exits included in this block
-	AttesterSlashings     []*SlotPageAttesterSlashing     `json:"attester_slashings"`     // Attester Slashings included in this block
-	ProposerSlashings     []*SlotPageProposerSlashing     `json:"proposer_slashings"`     // Proposer Slashings included in this block
-	BLSChanges            []*SlotPageBLSChange            `json:"bls_changes"`            // BLSChanges included in this block
-	Withdrawals           []*SlotPageWithdrawal           `json:"withdrawals"`            // Withdrawals included in this block
-	Blobs                 []*SlotPageBlob                 `json:"blobs"`                  // Blob sidecars included in this block
-	Transactions          []*SlotPageTransaction          `json:"transactions"`           // Transactions included in this block
-	DepositRequests       []*SlotPageDepositRequest       `json:"deposit_receipts"`       // DepositRequests included in this block
-	WithdrawalRequests    []*SlotPageWithdrawalRequest    `json:"withdrawal_requests"`    // WithdrawalRequests included in this block
-	ConsolidationRequests []*SlotPageConsolidationRequest `json:"consolidation_requests"` // ConsolidationRequests included in this block
+	Attestations          []*SlotPageAttestation          `json:"attestations"`            // Attestations included in this block (current page only)
+	Deposits              []*SlotPageDeposit              `json:"deposits"`                // Deposits included in this block
+	VoluntaryExits        []*SlotPageVoluntaryExit        `json:"voluntary_exits"`         // Voluntary Exits included in this block
+	AttesterSlashings     []*SlotPageAttesterSlashing     `json:"attester_slashings"`      // Attester Slashings included in this block
+	ProposerSlashings     []*SlotPageProposerSlashing     `json:"proposer_slashings"`      // Proposer Slashings included in this block
+	BLSChanges            []*SlotPageBLSChange            `json:"bls_changes"`             // BLSChanges included in this block
+	Withdrawals           []*SlotPageWithdrawal           `json:"withdrawals"`             // Withdrawals included in this block
+	Blobs                 []*SlotPageBlob                 `json:"blobs"`                   // Blob sidecars included in this block
+	BlobVerifications     []*SlotPageBlobVerification     `json:"blob_verifications"`      // KZG verification results of the served blob sidecars by client
+	BlobVerificationFails uint64                          `json:"blob_verification_fails"` // Number of clients that served invalid blob sidecars
+	Transactions          []*SlotPageTransaction          `json:"transactions"`            // Transactions included in this block
+	DepositRequests       []*SlotPageDepositRequest       `json:"deposit_receipts"`        // DepositRequests included in this block
+	WithdrawalRequests    []*SlotPageWithdrawalRequest    `json:"withdrawal_requests"`     // WithdrawalRequests included in this block
+	ConsolidationRequests []*SlotPageConsolidationRequest `json:"consolidation_requests"`  // ConsolidationRequests included in this block
 }
 
 type SlotPageExecutionData struct {
@@ -99,6 +140,7 @@ type SlotPageExecutionData struct {
 }
 
 type SlotPageAttestation struct {
+	Index          uint64   `json:"index"`
 	Slot           uint64   `json:"slot"`
 	CommitteeIndex []uint64 `json:"committeeindex"`
 
@@ -195,6 +237,13 @@ type SlotPageBlob struct {
 	KzgProof      []byte `json:"kzg_proof"`
 }
 
+type SlotPageBlobVerification struct {
+	ClientName   string `json:"client_name"`
+	ValidCount   uint64 `json:"valid_count"`
+	InvalidCount uint64 `json:"invalid_count"`
+	Result       string `json:"result"`
+}
+
 type SlotPageBlobDetails struct {
 	Index         uint64 `json:"index"`
 	Blob          string `json:"blob"`
@@ -215,6 +264,7 @@ type SlotPageTransaction struct {
 	FuncName      string  `json:"func_name"`
 	FuncSig       string  `json:"func_sig"`
 	Type          uint64  `json:"type"`
+	GasLimit      uint64  `json:"gas_limit"`
 }
 
 type SlotPageDepositRequest struct {
@@ -12,16 +12,8 @@ type SlotsPageData struct {
 	LastSlot      uint64               `json:"last_slot"`
 	ForkTreeWidth int                  `json:"forktree_width"`
 
-	IsDefaultPage    bool   `json:"default_page"`
-	TotalPages       uint64 `json:"total_pages"`
-	PageSize         uint64 `json:"page_size"`
-	CurrentPageIndex uint64 `json:"page_index"`
-	CurrentPageSlot  uint64 `json:"page_slot"`
-	PrevPageIndex    uint64 `json:"prev_page_index"`
-	PrevPageSlot     uint64 `json:"prev_page_slot"`
-	NextPageIndex    uint64 `json:"next_page_index"`
-	NextPageSlot     uint64 `json:"next_page_slot"`
-	LastPageSlot     uint64 `json:"last_page_slot"`
+	PagingData
+	CurrentPageSlot uint64 `json:"page_slot"`
 }
 
 type SlotsPageDataSlot struct {
@@ -10,9 +10,18 @@ type SlotsFilteredPageData struct {
 	FilterExtraData    string `json:"filter_extra_data"`
 	FilterProposer     string `json:"filter_proposer"`
 	FilterProposerName string `json:"filter_pname"`
+	FilterClClient     int8   `json:"filter_cl"`
+	FilterElClient     int8   `json:"filter_el"`
+	FilterBuilderType  uint8  `json:"filter_builder"`
+	FilterConfidence   uint8  `json:"filter_conf"`
+	FilterTag          string `json:"filter_tag"`
 	FilterWithOrphaned uint8  `json:"filter_orphaned"`
 	FilterWithMissing  uint8  `json:"filter_missing"`
 
+	ClClientOptions []*SlotsFilteredPageDataClientOption `json:"cl_client_options"`
+	ElClientOptions []*SlotsFilteredPageDataClientOption `json:"el_client_options"`
+	TagOptions      []string                             `json:"tag_options"`
+
 	DisplayEpoch        bool   `json:"dp_epoch"`
 	DisplaySlot         bool   `json:"dp_slot"`
 	DisplayStatus       bool   `json:"dp_status"`
@@ -25,6 +34,7 @@ type SlotsFilteredPageData struct {
 	DisplaySyncAgg      bool   `json:"dp_syncagg"`
 	DisplayGraffiti     bool   `json:"dp_graffiti"`
 	DisplayElExtraData  bool   `json:"dp_elextra"`
+	DisplayFingerprint  bool   `json:"dp_fingerprint"`
 	DisplayColCount     uint64 `json:"display_col_count"`
 
 	Slots     []*SlotsFilteredPageDataSlot `json:"slots"`
@@ -32,44 +42,50 @@ type SlotsFilteredPageData struct {
 	FirstSlot uint64                       `json:"first_slot"`
 	LastSlot  uint64                       `json:"last_slot"`
 
-	IsDefaultPage    bool   `json:"default_page"`
-	TotalPages       uint64 `json:"total_pages"`
-	PageSize         uint64 `json:"page_size"`
-	CurrentPageIndex uint64 `json:"page_index"`
-	CurrentPageSlot  uint64 `json:"page_slot"`
-	PrevPageIndex    uint64 `json:"prev_page_index"`
-	PrevPageSlot     uint64 `json:"prev_page_slot"`
-	NextPageIndex    uint64 `json:"next_page_index"`
-	NextPageSlot     uint64 `json:"next_page_slot"`
-	LastPageSlot     uint64 `json:"last_page_slot"`
-
-	FirstPageLink string `json:"first_page_link"`
-	PrevPageLink  string `json:"prev_page_link"`
-	NextPageLink  string `json:"next_page_link"`
-	LastPageLink  string `json:"last_page_link"`
+	PagingData
 }
 
 type SlotsFilteredPageDataSlot struct {
-	Slot                  uint64    `json:"slot"`
-	Epoch                 uint64    `json:"epoch"`
-	Ts                    time.Time `json:"ts"`
-	Finalized             bool      `json:"scheduled"`
-	Scheduled             bool      `json:"finalized"`
-	Status                uint8     `json:"status"`
-	Synchronized          bool      `json:"synchronized"`
-	Proposer              uint64    `json:"proposer"`
-	ProposerName          string    `json:"proposer_name"`
-	AttestationCount      uint64    `json:"attestation_count"`
-	DepositCount          uint64    `json:"deposit_count"`
-	ExitCount             uint64    `json:"exit_count"`
-	ProposerSlashingCount uint64    `json:"proposer_slashing_count"`
-	AttesterSlashingCount uint64    `json:"attester_slashing_count"`
-	SyncParticipation     float64   `json:"sync_participation"`
-	EthTransactionCount   uint64    `json:"eth_transaction_count"`
-	WithEthBlock          bool      `json:"with_eth_block"`
-	EthBlockNumber        uint64    `json:"eth_block_number"`
-	Graffiti              []byte    `json:"graffiti"`
-	ElExtraData           []byte    `json:"el_extra_data"`
-	BlockRoot             []byte    `json:"block_root"`
-	ParentRoot            []byte    `json:"parent_root"`
+	Slot                  uint64           `json:"slot"`
+	Epoch                 uint64           `json:"epoch"`
+	Ts                    time.Time        `json:"ts"`
+	Finalized             bool             `json:"scheduled"`
+	Scheduled             bool             `json:"finalized"`
+	Status                uint8            `json:"status"`
+	Synchronized          bool             `json:"synchronized"`
+	Proposer              uint64           `json:"proposer"`
+	ProposerName          string           `json:"proposer_name"`
+	AttestationCount      uint64           `json:"attestation_count"`
+	DepositCount          uint64           `json:"deposit_count"`
+	ExitCount             uint64           `json:"exit_count"`
+	ProposerSlashingCount uint64           `json:"proposer_slashing_count"`
+	AttesterSlashingCount uint64           `json:"attester_slashing_count"`
+	SyncParticipation     float64          `json:"sync_participation"`
+	EthTransactionCount   uint64           `json:"eth_transaction_count"`
+	WithEthBlock          bool             `json:"with_eth_block"`
+	EthBlockNumber        uint64           `json:"eth_block_number"`
+	Graffiti              []byte           `json:"graffiti"`
+	ElExtraData           []byte           `json:"el_extra_data"`
+	BlockRoot             []byte           `json:"block_root"`
+	ParentRoot            []byte           `json:"parent_root"`
+	Fingerprint           *SlotFingerprint `json:"fingerprint,omitempty"`
+	Tags                  []string         `json:"tags,omitempty"`
+}
+
+type SlotsFilteredPageDataClientOption struct {
+	Value int8   `json:"value"`
+	Name  string `json:"name"`
+}
+
+// SlotFingerprint is the guessed proposing clients & block builder of a block
+type SlotFingerprint struct {
+	ClClient          string `json:"cl_client"`
+	ClConfidence      uint8  `json:"cl_confidence"`
+	ElClient          string `json:"el_client"`
+	ElConfidence      uint8  `json:"el_confidence"`
+	BuilderType       uint8  `json:"builder_type"`
+	BuilderConfidence uint8  `json:"builder_confidence"`
+	BuilderName       string `json:"builder_name"`
+	BuilderPubkey     []byte `json:"builder_pubkey"`
+	Sources           string `json:"sources"`
 }
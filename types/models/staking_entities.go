@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// StakingEntitiesPageData is a struct to hold info for the staking entities page
+type StakingEntitiesPageData struct {
+	Entities        []*StakingEntitiesPageDataEntity `json:"entities"`
+	EntityCount     uint64                           `json:"entity_count"`
+	LabelledCount   uint64                           `json:"labelled_count"`
+	Others          *StakingEntitiesPageDataEntity   `json:"others"` // aggregated senders that are not listed
+	TotalValidators uint64                           `json:"total_validators"`
+	TotalStake      uint64                           `json:"total_stake"` // ETH
+	TotalNew7d      uint64                           `json:"total_new_7d"`
+	TotalNew30d     uint64                           `json:"total_new_30d"`
+	ShowUnlabelled  bool                             `json:"show_unlabelled"`
+}
+
+// StakingEntitiesPageDataEntity aggregates the deposits of a labelled entity or a single unlabelled deposit sender
+type StakingEntitiesPageDataEntity struct {
+	Name          string    `json:"name"`
+	Labelled      bool      `json:"labelled"`
+	Address       []byte    `json:"address"` // sender address of unlabelled entities
+	SenderCount   uint64    `json:"sender_count"`
+	DepositCount  uint64    `json:"deposit_count"`
+	DepositAmount uint64    `json:"deposit_amount"` // gwei
+	FirstDeposit  time.Time `json:"first_deposit"`
+	LastDeposit   time.Time `json:"last_deposit"`
+	New7d         uint64    `json:"new_7d"`
+	New30d        uint64    `json:"new_30d"`
+	Growth30d     float64   `json:"growth_30d"`  // percent
+	StakeShare    float64   `json:"stake_share"` // percent
+	EntityValidatorStats
+}
@@ -0,0 +1,28 @@
+package models
+
+// SubmitBroadcastPageData is a struct to hold info for the broadcast operations page
+type SubmitBroadcastPageData struct {
+	Operations  string                           `json:"operations"`
+	Submitted   bool                             `json:"submitted"`
+	ParseError  string                           `json:"parse_error"`
+	Results     []*SubmitBroadcastPageDataResult `json:"results"`
+	ResultCount uint64                           `json:"result_count"`
+}
+
+type SubmitBroadcastPageDataResult struct {
+	Type            string                           `json:"type"`
+	ValidatorIndex  uint64                           `json:"validator_index"`
+	ValidatorName   string                           `json:"validator_name"`
+	Epoch           uint64                           `json:"epoch"`
+	Address         []byte                           `json:"address"`
+	ValidationError string                           `json:"validation_error"`
+	Clients         []*SubmitBroadcastPageDataClient `json:"clients"`
+	AcceptedCount   uint64                           `json:"accepted_count"`
+	ClientCount     uint64                           `json:"client_count"`
+}
+
+type SubmitBroadcastPageDataClient struct {
+	Name     string `json:"name"`
+	Accepted bool   `json:"accepted"`
+	Error    string `json:"error"`
+}
@@ -0,0 +1,36 @@
+package models
+
+// ValidateObjectPageData is a struct to hold info for the object validation page
+type ValidateObjectPageData struct {
+	Objects     string                          `json:"objects"`
+	ObjectType  string                          `json:"object_type"`
+	Submitted   bool                            `json:"submitted"`
+	ParseError  string                          `json:"parse_error"`
+	Results     []*ValidateObjectPageDataResult `json:"results"`
+	ResultCount uint64                          `json:"result_count"`
+}
+
+type ValidateObjectPageDataResult struct {
+	Index       uint64                         `json:"index"`
+	Type        string                         `json:"type"`
+	Encoding    string                         `json:"encoding"`
+	Fork        string                         `json:"fork"`
+	RootName    string                         `json:"root_name"`
+	Root        []byte                         `json:"root"`
+	Error       string                         `json:"error"`
+	Fields      []*ValidateObjectPageDataField `json:"fields"`
+	Checks      []*ValidateObjectPageDataCheck `json:"checks"`
+	FailedCount uint64                         `json:"failed_count"`
+	Object      string                         `json:"object"`
+}
+
+type ValidateObjectPageDataField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ValidateObjectPageDataCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
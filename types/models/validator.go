@@ -24,6 +24,20 @@ type ValidatorPageData struct {
 	WasActive                bool                                  `json:"was_active"`
 	UpcheckActivity          uint8                                 `json:"upcheck_act"`
 	UpcheckMaximum           uint8                                 `json:"upcheck_max"`
+	ShowEffectiveness        bool                                  `json:"show_effectiveness"`
+	Effectiveness            float64                               `json:"effectiveness"`
+	EffectivenessTopPercent  float64                               `json:"effectiveness_top_percent"`
+	EffectivenessEpochs      uint64                                `json:"effectiveness_epochs"`
+	EffectivenessDate        time.Time                             `json:"effectiveness_date"`
+	ShowRegistration         bool                                  `json:"show_registration"`
+	RegistrationFeeRecipient []byte                                `json:"registration_fee_recipient"`
+	RegistrationGasLimit     uint64                                `json:"registration_gas_limit"`
+	RegistrationTs           time.Time                             `json:"registration_ts"`
+	RegistrationRelays       []string                              `json:"registration_relays"`
+	ObservedFeeRecipient     []byte                                `json:"observed_fee_recipient"`
+	ObservedFeeRecipientSlot uint64                                `json:"observed_fee_recipient_slot"`
+	FeeRecipientMismatch     bool                                  `json:"fee_recipient_mismatch"`
+	EquivocationSlots        []uint64                              `json:"equivocation_slots"`
 	ShowExit                 bool                                  `json:"show_exit"`
 	ExitTs                   time.Time                             `json:"exit_ts"`
 	ExitEpoch                uint64                                `json:"exit_epoch"`
@@ -44,21 +58,32 @@ type ValidatorPageData struct {
 
 	TabView         string `json:"tab_view"`
 	ElectraIsActive bool   `json:"electra_is_active"`
+	ShowRewards     bool   `json:"show_rewards"`
 
-	RecentBlocks                        []*ValidatorPageDataBlock         `json:"recent_blocks"`
-	RecentBlockCount                    uint64                            `json:"recent_block_count"`
-	RecentAttestations                  []*ValidatorPageDataAttestation   `json:"recent_attestations"`
-	RecentAttestationCount              uint64                            `json:"recent_attestation_count"`
-	RecentDeposits                      []*ValidatorPageDataDeposit       `json:"recent_deposits"`
-	RecentDepositCount                  uint64                            `json:"recent_deposit_count"`
-	AdditionalInitiatedDepositCount     uint64                            `json:"additional_initiated_deposit_count"`
-	AdditionalIncludedDepositCount      uint64                            `json:"additional_included_deposit_count"`
-	ConsolidationRequests               []*ValidatorPageDataConsolidation `json:"consolidation_requests"`
-	ConsolidationRequestCount           uint64                            `json:"consolidation_request_count"`
-	AdditionalConsolidationRequestCount uint64                            `json:"additional_consolidation_request_count"`
-	WithdrawalRequests                  []*ValidatorPageDataWithdrawal    `json:"withdrawal_requests"`
-	WithdrawalRequestCount              uint64                            `json:"withdrawal_request_count"`
-	AdditionalWithdrawalRequestCount    uint64                            `json:"additional_withdrawal_request_count"`
+	RecentBlocks                        []*ValidatorPageDataBlock                  `json:"recent_blocks"`
+	RecentBlockCount                    uint64                                     `json:"recent_block_count"`
+	RecentAttestations                  []*ValidatorPageDataAttestation            `json:"recent_attestations"`
+	RecentAttestationCount              uint64                                     `json:"recent_attestation_count"`
+	RecentDeposits                      []*ValidatorPageDataDeposit                `json:"recent_deposits"`
+	RecentDepositCount                  uint64                                     `json:"recent_deposit_count"`
+	AdditionalInitiatedDepositCount     uint64                                     `json:"additional_initiated_deposit_count"`
+	AdditionalIncludedDepositCount      uint64                                     `json:"additional_included_deposit_count"`
+	IsGenesis                           bool                                       `json:"is_genesis"`
+	GenesisEntity                       string                                     `json:"genesis_entity"`
+	GenesisBalance                      uint64                                     `json:"genesis_balance"`
+	GenesisWithdrawalCreds              []byte                                     `json:"genesis_withdrawal_creds"`
+	ConsolidationRequests               []*ValidatorPageDataConsolidation          `json:"consolidation_requests"`
+	ConsolidationRequestCount           uint64                                     `json:"consolidation_request_count"`
+	AdditionalConsolidationRequestCount uint64                                     `json:"additional_consolidation_request_count"`
+	WithdrawalRequests                  []*ValidatorPageDataWithdrawal             `json:"withdrawal_requests"`
+	WithdrawalRequestCount              uint64                                     `json:"withdrawal_request_count"`
+	AdditionalWithdrawalRequestCount    uint64                                     `json:"additional_withdrawal_request_count"`
+	RecentRewards                       []*ValidatorPageDataRewards                `json:"recent_rewards"`
+	RecentRewardCount                   uint64                                     `json:"recent_reward_count"`
+	Timeline                            []*ValidatorPageDataTimelineEntry          `json:"timeline"`
+	TimelineCount                       uint64                                     `json:"timeline_count"`
+	EffectiveBalanceChanges             []*ValidatorPageDataEffectiveBalanceChange `json:"effective_balance_changes"`
+	EffectiveBalanceChangeCount         uint64                                     `json:"effective_balance_change_count"`
 }
 
 type ValidatorPageDataBlock struct {
@@ -167,3 +192,42 @@ type ValidatorPageDataWithdrawalTxDetails struct {
 	TxTarget    string `json:"tx_target"`
 	TxHash      string `json:"tx_hash"`
 }
+
+type ValidatorPageDataRewards struct {
+	Day           uint64    `json:"day"`
+	Date          time.Time `json:"date"`
+	Epochs        uint64    `json:"epochs"`
+	Attestation   int64     `json:"attestation"`
+	SyncCommittee int64     `json:"sync_committee"`
+	Proposer      int64     `json:"proposer"`
+	Total         int64     `json:"total"`
+}
+
+type ValidatorPageDataTimelineEntry struct {
+	Type          string    `json:"type"`
+	Title         string    `json:"title"`
+	Slot          uint64    `json:"slot"`
+	HasSlot       bool      `json:"has_slot"`
+	Epoch         uint64    `json:"epoch"`
+	Time          time.Time `json:"time"`
+	Pending       bool      `json:"pending"`
+	Amount        uint64    `json:"amount"`
+	HasAmount     bool      `json:"has_amount"`
+	TxHash        []byte    `json:"tx_hash"`
+	BlockNumber   uint64    `json:"block_number"`
+	Validator     uint64    `json:"validator"`
+	ValidatorName string    `json:"validator_name"`
+	HasValidator  bool      `json:"has_validator"`
+	Credentials   []byte    `json:"credentials"`
+	Details       string    `json:"details"`
+	IsFailed      bool      `json:"is_failed"`
+}
+
+type ValidatorPageDataEffectiveBalanceChange struct {
+	Epoch               uint64    `json:"epoch"`
+	Time                time.Time `json:"time"`
+	OldEffectiveBalance uint64    `json:"old_effective_balance"`
+	NewEffectiveBalance uint64    `json:"new_effective_balance"`
+	Change              int64     `json:"change"`
+	Compounding         bool      `json:"compounding"`
+}
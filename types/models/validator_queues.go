@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// ValidatorQueuesPageData is a struct to hold info for the activation & exit queue page
+type ValidatorQueuesPageData struct {
+	Epoch                   uint64    `json:"epoch"`
+	IsElectra               bool      `json:"is_electra"`
+	HasStateQueues          bool      `json:"has_state_queues"`
+	ActiveValidators        uint64    `json:"active_validators"`
+	TotalActiveBalance      uint64    `json:"total_active_balance"`
+	ActivationChurn         uint64    `json:"activation_churn"`
+	ExitChurn               uint64    `json:"exit_churn"`
+	BalanceChurn            uint64    `json:"balance_churn"`
+	ActivationQueueLength   uint64    `json:"activation_queue_length"`
+	ExitQueueLength         uint64    `json:"exit_queue_length"`
+	ExitQueueBalance        uint64    `json:"exit_queue_balance"`
+	PendingDeposits         uint64    `json:"pending_deposits"`
+	PendingDepositBalance   uint64    `json:"pending_deposit_balance"`
+	DepositBalanceToConsume uint64    `json:"deposit_balance_to_consume"`
+	ExitBalanceToConsume    uint64    `json:"exit_balance_to_consume"`
+	EarliestExitEpoch       uint64    `json:"earliest_exit_epoch"`
+	NewDepositEpoch         uint64    `json:"new_deposit_epoch"`
+	NewDepositTime          time.Time `json:"new_deposit_time"`
+	NewActivationEpoch      uint64    `json:"new_activation_epoch"`
+	NewActivationTime       time.Time `json:"new_activation_time"`
+	NewActivationWait       string    `json:"new_activation_wait"`
+	NewExitEpoch            uint64    `json:"new_exit_epoch"`
+	NewExitTime             time.Time `json:"new_exit_time"`
+	NewExitWait             string    `json:"new_exit_wait"`
+
+	FilterValidator string                            `json:"filter_validator"`
+	ValidatorError  string                            `json:"validator_error"`
+	Validator       *ValidatorQueuesPageDataValidator `json:"validator"`
+}
+
+type ValidatorQueuesPageDataValidator struct {
+	Index          uint64    `json:"index"`
+	Name           string    `json:"name"`
+	Queue          string    `json:"queue"`
+	Position       uint64    `json:"position"`
+	BalanceAhead   uint64    `json:"balance_ahead"`
+	HasEstimate    bool      `json:"has_estimate"`
+	EstimatedEpoch uint64    `json:"estimated_epoch"`
+	EstimatedTime  time.Time `json:"estimated_time"`
+	EstimatedWait  string    `json:"estimated_wait"`
+	Scheduled      bool      `json:"scheduled"`
+}
@@ -6,11 +6,16 @@ import (
 
 // ValidatorsPageData is a struct to hold info for the validators page
 type ValidatorsPageData struct {
-	FilterPubKey     string                           `json:"filter_pubkey"`
-	FilterIndex      string                           `json:"filter_index"`
-	FilterName       string                           `json:"filter_name"`
-	FilterStatus     string                           `json:"filter_status"`
-	FilterStatusOpts []ValidatorsPageDataStatusOption `json:"filter_status_opts"`
+	FilterPubKey        string                           `json:"filter_pubkey"`
+	FilterIndex         string                           `json:"filter_index"`
+	FilterName          string                           `json:"filter_name"`
+	FilterStatus        string                           `json:"filter_status"`
+	FilterMinBalance    string                           `json:"filter_minbal"`
+	FilterMaxBalance    string                           `json:"filter_maxbal"`
+	FilterMinActivation string                           `json:"filter_minact"`
+	FilterMaxActivation string                           `json:"filter_maxact"`
+	FilterCredentials   string                           `json:"filter_creds"`
+	FilterStatusOpts    []ValidatorsPageDataStatusOption `json:"filter_status_opts"`
 
 	Validators       []*ValidatorsPageDataValidator `json:"validators"`
 	ValidatorCount   uint64                         `json:"validator_count"`
@@ -19,14 +24,9 @@ type ValidatorsPageData struct {
 	StateFilter      string                         `json:"state_filter"`
 	Sorting          string                         `json:"sorting"`
 	IsDefaultSorting bool                           `json:"default_sorting"`
-	IsDefaultPage    bool                           `json:"default_page"`
-	TotalPages       uint64                         `json:"total_pages"`
-	PageSize         uint64                         `json:"page_size"`
-	CurrentPageIndex uint64                         `json:"page_index"`
-	PrevPageIndex    uint64                         `json:"prev_page_index"`
-	NextPageIndex    uint64                         `json:"next_page_index"`
-	LastPageIndex    uint64                         `json:"last_page_index"`
 	FilteredPageLink string                         `json:"filtered_page_link"`
+
+	PagingData
 }
 
 type ValidatorsPageDataStatusOption struct {
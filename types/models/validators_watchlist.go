@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// ValidatorsWatchlistPageData is a struct to hold info for the validator watchlist dashboard
+type ValidatorsWatchlistPageData struct {
+	ListName        string   `json:"list_name"` // configured watchlist name, empty for the personal watchlist
+	ListValidators  string   `json:"list_validators"`
+	ConfiguredLists []string `json:"configured_lists"`
+	IsPersonalList  bool     `json:"personal_list"`
+	InvalidList     bool     `json:"invalid_list"`
+	IsTruncated     bool     `json:"truncated"`
+	MaxValidators   uint64   `json:"max_validators"`
+
+	ValidatorCount  uint64 `json:"validator_count"`
+	ActiveCount     uint64 `json:"active_count"`
+	OnlineCount     uint64 `json:"online_count"`
+	TotalBalance    uint64 `json:"total_balance"`
+	TotalEffBalance uint64 `json:"total_eff_balance"`
+	SyncCommittee   uint64 `json:"sync_committee"` // watched validators in the current sync committee
+	StatsDays       uint64 `json:"stats_days"`
+
+	UpcomingProposals []*ValidatorsWatchlistPageDataDuty `json:"upcoming_proposals"`
+
+	Validators []*ValidatorsWatchlistPageDataValidator `json:"validators"`
+
+	PagingData
+}
+
+type ValidatorsWatchlistPageDataDuty struct {
+	Slot          uint64    `json:"slot"`
+	Time          time.Time `json:"time"`
+	Validator     uint64    `json:"validator"`
+	ValidatorName string    `json:"validator_name"`
+}
+
+type ValidatorsWatchlistPageDataValidator struct {
+	Index            uint64  `json:"index"`
+	Name             string  `json:"name"`
+	Balance          uint64  `json:"balance"`
+	EffectiveBalance uint64  `json:"eff_balance"`
+	State            string  `json:"state"`
+	ShowUpcheck      bool    `json:"show_upcheck"`
+	UpcheckActivity  uint8   `json:"upcheck_act"`
+	UpcheckMaximum   uint8   `json:"upcheck_max"`
+	InSyncCommittee  bool    `json:"sync_committee"`
+	Proposed         uint64  `json:"proposed"`
+	Orphaned         uint64  `json:"orphaned"`
+	Missed           uint64  `json:"missed"`
+	HasEffectiveness bool    `json:"has_effectiveness"`
+	Effectiveness    float64 `json:"effectiveness"` // percent
+}
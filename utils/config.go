@@ -4,6 +4,11 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/yaml.v3"
@@ -15,6 +20,8 @@ import (
 // Config is the globally accessible configuration
 var Config *types.Config
 
+var customPageNameRE = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
 // ReadConfig will process a configuration
 func ReadConfig(cfg *types.Config, path string) error {
 	err := readConfigFile(cfg, path)
@@ -24,6 +31,42 @@ func ReadConfig(cfg *types.Config, path string) error {
 
 	readConfigEnv(cfg)
 
+	// in-memory log buffer
+	if cfg.Logging.BufferSize == 0 {
+		cfg.Logging.BufferSize = 2000
+	}
+
+	// indexer mode
+	switch cfg.Indexer.Mode {
+	case "", types.IndexerModeFull:
+		cfg.Indexer.Mode = types.IndexerModeFull
+	case types.IndexerModeWriter:
+		cfg.Frontend.Enabled = false
+	case types.IndexerModeFrontend:
+	default:
+		return fmt.Errorf("invalid indexer mode '%v' (expected: full, writer or frontend)", cfg.Indexer.Mode)
+	}
+	if cfg.Indexer.LeaderElection && cfg.Indexer.LeaderLeaseTimeout == 0 {
+		cfg.Indexer.LeaderLeaseTimeout = 30 * time.Second
+	}
+	for _, blockTag := range cfg.Indexer.BlockTags {
+		if blockTag.Tag == "" {
+			return fmt.Errorf("missing tag for block tag rule")
+		}
+		if len(blockTag.Tag) > 100 {
+			return fmt.Errorf("invalid block tag '%v' (max 100 chars)", blockTag.Tag)
+		}
+		if blockTag.Graffiti == "" && blockTag.ExtraData == "" {
+			return fmt.Errorf("missing graffiti or extraData pattern for block tag '%v'", blockTag.Tag)
+		}
+		if _, err := regexp.Compile(blockTag.Graffiti); err != nil {
+			return fmt.Errorf("invalid graffiti pattern for block tag '%v': %v", blockTag.Tag, err)
+		}
+		if _, err := regexp.Compile(blockTag.ExtraData); err != nil {
+			return fmt.Errorf("invalid extraData pattern for block tag '%v': %v", blockTag.Tag, err)
+		}
+	}
+
 	// endpoints
 	if cfg.BeaconApi.Endpoints == nil && cfg.BeaconApi.Endpoint != "" {
 		cfg.BeaconApi.Endpoints = []types.EndpointConfig{
@@ -42,6 +85,16 @@ func ReadConfig(cfg *types.Config, path string) error {
 				cfg.BeaconApi.Endpoints[idx].Name = fmt.Sprintf("endpoint-%v", idx+1)
 			}
 		}
+		for _, topic := range endpoint.EventTopics {
+			switch topic {
+			case "block", "head", "finalized_checkpoint":
+			default:
+				return fmt.Errorf("invalid event topic '%v' for beacon endpoint %v (expected: block, head or finalized_checkpoint)", topic, cfg.BeaconApi.Endpoints[idx].Name)
+			}
+		}
+		if err := checkEndpointSelection(&cfg.BeaconApi.Endpoints[idx]); err != nil {
+			return fmt.Errorf("invalid beacon endpoint %v: %v", cfg.BeaconApi.Endpoints[idx].Name, err)
+		}
 	}
 	if len(cfg.BeaconApi.Endpoints) == 0 {
 		return fmt.Errorf("missing beacon node endpoints (need at least 1 endpoint to run the explorer)")
@@ -65,6 +118,242 @@ func ReadConfig(cfg *types.Config, path string) error {
 				cfg.ExecutionApi.Endpoints[idx].Name = fmt.Sprintf("endpoint-%v", idx+1)
 			}
 		}
+		if err := checkEndpointSelection(&cfg.ExecutionApi.Endpoints[idx]); err != nil {
+			return fmt.Errorf("invalid execution endpoint %v: %v", cfg.ExecutionApi.Endpoints[idx].Name, err)
+		}
+	}
+
+	// blockprint enricher
+	if cfg.Blockprint.Url != "" {
+		if cfg.Blockprint.RefreshInterval == 0 {
+			cfg.Blockprint.RefreshInterval = 5 * time.Minute
+		}
+		if cfg.Blockprint.RateLimit <= 0 {
+			cfg.Blockprint.RateLimit = 1
+		}
+		if cfg.Blockprint.BatchSize == 0 {
+			cfg.Blockprint.BatchSize = 320
+		}
+	}
+
+	// slot fingerprint enricher
+	if cfg.Fingerprint.RefreshInterval == 0 {
+		cfg.Fingerprint.RefreshInterval = 1 * time.Minute
+	}
+	if cfg.Fingerprint.BatchSize == 0 {
+		cfg.Fingerprint.BatchSize = 320
+	}
+
+	// rollup blob indexer
+	if cfg.Rollups.RefreshInterval == 0 {
+		cfg.Rollups.RefreshInterval = 1 * time.Minute
+	}
+	if cfg.Rollups.RateLimit <= 0 {
+		cfg.Rollups.RateLimit = 1
+	}
+	if cfg.Rollups.BatchSize == 0 {
+		cfg.Rollups.BatchSize = 32
+	}
+
+	// data column availability indexer
+	if cfg.DataColumns.PollDelay == 0 {
+		cfg.DataColumns.PollDelay = 8 * time.Second
+	}
+	if cfg.DataColumns.RequestTimeout == 0 {
+		cfg.DataColumns.RequestTimeout = 10 * time.Second
+	}
+
+	// blob availability monitor
+	if cfg.BlobAvailability.PollDelay == 0 {
+		cfg.BlobAvailability.PollDelay = 6 * time.Second
+	}
+	if cfg.BlobAvailability.RequestTimeout == 0 {
+		cfg.BlobAvailability.RequestTimeout = 10 * time.Second
+	}
+	if cfg.BlobAvailability.HistorySlots == 0 {
+		cfg.BlobAvailability.HistorySlots = 64
+	}
+
+	// client head divergence monitor
+	if cfg.HeadDivergence.MinSlots == 0 {
+		cfg.HeadDivergence.MinSlots = 4
+	}
+
+	// light client data indexer
+	if cfg.LightClient.RequestTimeout == 0 {
+		cfg.LightClient.RequestTimeout = 10 * time.Second
+	}
+	if cfg.LightClient.BackfillPeriods == 0 {
+		cfg.LightClient.BackfillPeriods = 4
+	}
+
+	// rewards indexer
+	if cfg.Rewards.RefreshInterval == 0 {
+		cfg.Rewards.RefreshInterval = 1 * time.Minute
+	}
+	if cfg.Rewards.RateLimit <= 0 {
+		cfg.Rewards.RateLimit = 1
+	}
+	if cfg.Rewards.HistoryDays == 0 {
+		cfg.Rewards.HistoryDays = 90
+	}
+
+	// validator incidents
+	if cfg.Incidents.MinMissedDuties == 0 {
+		cfg.Incidents.MinMissedDuties = 2
+	}
+
+	// api quotas
+	if cfg.Api.Quotas.Window == 0 {
+		cfg.Api.Quotas.Window = 1 * time.Hour
+	}
+
+	// beacon api proxy
+	if cfg.BeaconApiProxy.CacheSize == 0 {
+		cfg.BeaconApiProxy.CacheSize = 200
+	}
+
+	// api event stream
+	if cfg.Api.EventStream.MaxClients == 0 {
+		cfg.Api.EventStream.MaxClients = 100
+	}
+	if cfg.Api.EventStream.BufferSize == 0 {
+		cfg.Api.EventStream.BufferSize = 100
+	}
+
+	// webhooks
+	if cfg.Webhooks.Timeout == 0 {
+		cfg.Webhooks.Timeout = 10 * time.Second
+	}
+
+	// event export
+	if cfg.EventExport.TopicPrefix == "" {
+		cfg.EventExport.TopicPrefix = "dora."
+	}
+	if cfg.EventExport.QueueSize == 0 {
+		cfg.EventExport.QueueSize = 1000
+	}
+	if cfg.EventExport.Timeout == 0 {
+		cfg.EventExport.Timeout = 10 * time.Second
+	}
+
+	// integrations
+	if cfg.Integrations.Assertoor.PublicUrl == "" {
+		cfg.Integrations.Assertoor.PublicUrl = cfg.Integrations.Assertoor.Url
+	}
+	if cfg.Integrations.Assertoor.PollInterval == 0 {
+		cfg.Integrations.Assertoor.PollInterval = 30 * time.Second
+	}
+	if cfg.Integrations.Assertoor.MaxRuns == 0 {
+		cfg.Integrations.Assertoor.MaxRuns = 25
+	}
+	for idx := range cfg.Integrations.Links {
+		link := &cfg.Integrations.Links[idx]
+		if link.Page != "slot" && link.Page != "fork" {
+			return fmt.Errorf("invalid page '%v' for external link '%v' (expected: slot or fork)", link.Page, link.Name)
+		}
+		if link.Icon == "" {
+			link.Icon = "fa-external-link-alt"
+		}
+	}
+
+	// diagnostics
+	if cfg.Diagnostics.HeapDumpDir == "" {
+		cfg.Diagnostics.HeapDumpDir = filepath.Join(os.TempDir(), "dora-heapdumps")
+	}
+	if cfg.Diagnostics.HeapDumpMaxFiles == 0 {
+		cfg.Diagnostics.HeapDumpMaxFiles = 5
+	}
+	if cfg.Diagnostics.HeapDumpInterval == 0 {
+		cfg.Diagnostics.HeapDumpInterval = 30 * time.Second
+	}
+	if cfg.Diagnostics.HeapDumpCooldown == 0 {
+		cfg.Diagnostics.HeapDumpCooldown = 30 * time.Minute
+	}
+
+	// custom pages
+	customPageNames := map[string]bool{}
+	for idx := range cfg.CustomPages {
+		customPage := &cfg.CustomPages[idx]
+		if !customPageNameRE.MatchString(customPage.Name) {
+			return fmt.Errorf("invalid custom page name '%v' (expected: lowercase letters, digits, '-' and '_')", customPage.Name)
+		}
+		if customPageNames[customPage.Name] {
+			return fmt.Errorf("duplicate custom page name '%v'", customPage.Name)
+		}
+		customPageNames[customPage.Name] = true
+
+		// custom page queries are always run in a rolled back (and on pgsql read-only) transaction,
+		// but only single select statements are accepted to avoid surprises.
+		customPage.Query = strings.TrimSuffix(strings.TrimSpace(customPage.Query), ";")
+		if customPage.Query == "" {
+			return fmt.Errorf("missing query for custom page '%v'", customPage.Name)
+		}
+		queryStart := strings.ToLower(strings.Fields(customPage.Query)[0])
+		if (queryStart != "select" && queryStart != "with") || strings.Contains(customPage.Query, ";") {
+			return fmt.Errorf("invalid query for custom page '%v' (expected: single SELECT statement)", customPage.Name)
+		}
+		if customPage.Title == "" {
+			customPage.Title = customPage.Name
+		}
+		if customPage.PageSize == 0 {
+			customPage.PageSize = 50
+		}
+		if customPage.Timeout == 0 {
+			customPage.Timeout = 10 * time.Second
+		}
+
+		for pidx := range customPage.Params {
+			param := &customPage.Params[pidx]
+			switch param.Type {
+			case "":
+				param.Type = "string"
+			case "string", "int", "bool":
+			default:
+				return fmt.Errorf("invalid type '%v' for param '%v' of custom page '%v' (expected: string, int or bool)", param.Type, param.Name, customPage.Name)
+			}
+			if param.Name == "" || param.Name == "p" || param.Name == "c" {
+				return fmt.Errorf("invalid param name '%v' for custom page '%v'", param.Name, customPage.Name)
+			}
+			if param.Label == "" {
+				param.Label = param.Name
+			}
+		}
+
+		for cidx := range customPage.Columns {
+			column := &customPage.Columns[cidx]
+			switch column.Format {
+			case "":
+				column.Format = "text"
+			case "text", "number", "float", "eth", "slot", "epoch", "validator", "hex", "time", "address", "bool":
+			default:
+				return fmt.Errorf("invalid format '%v' for column '%v' of custom page '%v'", column.Format, column.Field, customPage.Name)
+			}
+			if column.Label == "" {
+				column.Label = column.Field
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkEndpointSelection validates the client selection settings of an endpoint and applies the archive tag
+func checkEndpointSelection(endpoint *types.EndpointConfig) error {
+	if endpoint.Weight < 0 {
+		return fmt.Errorf("weight must not be negative")
+	}
+	for _, tag := range endpoint.Tags {
+		switch tag {
+		case "archive":
+			endpoint.Archive = true
+		case "full":
+		default:
+			return fmt.Errorf("invalid tag '%v' (expected: archive or full)", tag)
+		}
+	}
+	if endpoint.Archive && slices.Contains(endpoint.Tags, "full") {
+		return fmt.Errorf("endpoint cannot be tagged as archive and full")
 	}
 
 	return nil
@@ -25,11 +25,15 @@ func FormatETH(num string) string {
 }
 
 func FormatETHFromGwei(gwei uint64) string {
-	return fmt.Sprintf("%.4f", float64(gwei)/math.Pow10(9)) + " ETH"
+	return GetFrontendFormatter().FormatEthFromGwei(gwei)
 }
 
 func FormatETHFromGweiShort(gwei uint64) string {
-	return fmt.Sprintf("%.4f", float64(gwei)/math.Pow10(9))
+	return GetFrontendFormatter().FormatEthFromGweiShort(gwei)
+}
+
+func FormatETHFromGweiSigned(gwei int64) string {
+	return GetFrontendFormatter().FormatEthFromGweiSigned(gwei)
 }
 
 func FormatFullETHFromGwei(gwei uint64) string {
@@ -49,12 +53,8 @@ func FormatFloat(num float64, precision int) string {
 }
 
 func FormatAddCommasFormatted(num float64, precision uint) template.HTML {
-	p := message.NewPrinter(language.English)
-	s := p.Sprintf(fmt.Sprintf("%%.%vf", precision), num)
-	if precision > 0 {
-		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
-	}
-	return template.HTML(strings.ReplaceAll(string([]rune(p.Sprintf(s, num))), ",", `<span class="thousands-separator"></span>`))
+	formatter := GetFrontendFormatter()
+	return formatThousandsSeparators(formatter, formatter.FormatFloat(num, int(precision)))
 }
 
 func FormatBigNumberAddCommasFormatted(val hexutil.Big, precision uint) template.HTML {
@@ -62,9 +62,15 @@ func FormatBigNumberAddCommasFormatted(val hexutil.Big, precision uint) template
 }
 
 func FormatAddCommas(n uint64) template.HTML {
-	number := FormatFloat(float64(n), 2)
+	formatter := GetFrontendFormatter()
+	return formatThousandsSeparators(formatter, formatter.FormatNumber(n))
+}
 
-	number = strings.ReplaceAll(number, ",", `<span class="thousands-separator"></span>`)
+func formatThousandsSeparators(formatter *LocaleFormatter, number string) template.HTML {
+	number = html.EscapeString(number)
+	if formatter.GroupSeparator() != "" {
+		number = strings.ReplaceAll(number, formatter.GroupSeparator(), `<span class="thousands-separator"></span>`)
+	}
 	return template.HTML(number)
 }
 
@@ -297,15 +303,25 @@ func FormatEthBlockHashLink(blockHash []byte) template.HTML {
 	return template.HTML(caption)
 }
 
+// EthAddressLabelResolver resolves the label of an execution address.
+// it's set by the chain service, as the address labels are loaded with the validator names.
+var EthAddressLabelResolver func(address []byte) string
+
 func FormatEthAddressLink(address []byte) template.HTML {
 	caption := common.BytesToAddress(address).String()
+	result := caption
 	if Config.Frontend.EthExplorerLink != "" {
 		link, err := url.JoinPath(Config.Frontend.EthExplorerLink, "address", caption)
 		if err == nil {
-			return template.HTML(fmt.Sprintf(`<a href="%v">%v</a>`, link, caption))
+			result = fmt.Sprintf(`<a href="%v">%v</a>`, link, caption)
 		}
 	}
-	return template.HTML(caption)
+	if EthAddressLabelResolver != nil {
+		if label := EthAddressLabelResolver(address); label != "" {
+			result += fmt.Sprintf(` <span class="badge rounded-pill text-bg-secondary" data-bs-toggle="tooltip" title="Address label"><i class="fas fa-tag"></i> %v</span>`, html.EscapeString(label))
+		}
+	}
+	return template.HTML(result)
 }
 
 func FormatEthTransactionLink(hash []byte, width uint64) template.HTML {
@@ -364,25 +380,7 @@ func FormatValidatorNameWithIndex(index uint64, name string) template.HTML {
 }
 
 func FormatRecentTimeShort(ts time.Time) template.HTML {
-	duration := time.Until(ts)
-	var timeStr string
-	absDuraction := duration.Abs()
-	if absDuraction < 1*time.Second {
-		return template.HTML("now")
-	} else if absDuraction < 60*time.Second {
-		timeStr = fmt.Sprintf("%v sec.", uint(absDuraction.Seconds()))
-	} else if absDuraction < 60*time.Minute {
-		timeStr = fmt.Sprintf("%v min.", uint(absDuraction.Minutes()))
-	} else if absDuraction < 24*time.Hour {
-		timeStr = fmt.Sprintf("%v hr.", uint(absDuraction.Hours()))
-	} else {
-		timeStr = fmt.Sprintf("%v day.", uint(absDuraction.Hours()/24))
-	}
-	if duration < 0 {
-		return template.HTML(fmt.Sprintf("%v ago", timeStr))
-	} else {
-		return template.HTML(fmt.Sprintf("in %v", timeStr))
-	}
+	return template.HTML(html.EscapeString(GetFrontendFormatter().FormatRecentTime(ts)))
 }
 
 func FormatGraffiti(graffiti []byte) template.HTML {
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// LocaleTimeStrings holds the translated relative time strings of a locale, the %v placeholders are replaced with the amount / time string.
+// the strings are also passed to the frontend scripts that keep the relative times up to date.
+type LocaleTimeStrings struct {
+	Now     string `json:"now"`
+	Seconds string `json:"sec"`
+	Minutes string `json:"min"`
+	Hours   string `json:"hr"`
+	Days    string `json:"day"`
+	Ago     string `json:"ago"`
+	In      string `json:"in"`
+}
+
+// localeTimeStrings are the relative time translations by base language, other languages fall back to english
+var localeTimeStrings = map[string]*LocaleTimeStrings{
+	"en": {Now: "now", Seconds: "%v sec.", Minutes: "%v min.", Hours: "%v hr.", Days: "%v day.", Ago: "%v ago", In: "in %v"},
+	"de": {Now: "jetzt", Seconds: "%v Sek.", Minutes: "%v Min.", Hours: "%v Std.", Days: "%v Tg.", Ago: "vor %v", In: "in %v"},
+	"es": {Now: "ahora", Seconds: "%v s", Minutes: "%v min", Hours: "%v h", Days: "%v d", Ago: "hace %v", In: "en %v"},
+	"fr": {Now: "maintenant", Seconds: "%v s", Minutes: "%v min", Hours: "%v h", Days: "%v j", Ago: "il y a %v", In: "dans %v"},
+	"ru": {Now: "сейчас", Seconds: "%v сек.", Minutes: "%v мин.", Hours: "%v ч.", Days: "%v дн.", Ago: "%v назад", In: "через %v"},
+}
+
+// LocaleFormatter formats numbers, ETH amounts & relative times for a locale.
+// it's used by the template functions (with the configured frontend locale) and the json api (with the requested locale).
+type LocaleFormatter struct {
+	locale      string
+	printer     *message.Printer
+	groupSep    string
+	decimalSep  string
+	timeStrings *LocaleTimeStrings
+}
+
+var localeFormatters sync.Map
+
+// GetLocaleFormatter returns the formatter for the given locale (e.g. "en-US" or "de"), invalid locales fall back to english.
+func GetLocaleFormatter(locale string) *LocaleFormatter {
+	if formatter, ok := localeFormatters.Load(locale); ok {
+		return formatter.(*LocaleFormatter)
+	}
+
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.English
+	}
+	base, _ := tag.Base()
+
+	formatter := &LocaleFormatter{
+		locale:      tag.String(),
+		printer:     message.NewPrinter(tag),
+		timeStrings: localeTimeStrings[base.String()],
+	}
+	if formatter.timeStrings == nil {
+		formatter.timeStrings = localeTimeStrings["en"]
+	}
+
+	// determine the separators of the locale from a sample number
+	sample := []rune(formatter.printer.Sprintf("%.1f", 1234.5))
+	if len(sample) == 7 {
+		formatter.groupSep = string(sample[1])
+		formatter.decimalSep = string(sample[5])
+	} else {
+		formatter.decimalSep = string(sample[len(sample)-2])
+	}
+
+	localeFormatters.Store(locale, formatter)
+	return formatter
+}
+
+// GetFrontendFormatter returns the formatter for the configured frontend locale
+func GetFrontendFormatter() *LocaleFormatter {
+	locale := "en"
+	if Config != nil && Config.Frontend.Locale != "" {
+		locale = Config.Frontend.Locale
+	}
+	return GetLocaleFormatter(locale)
+}
+
+// Locale returns the normalized locale of the formatter
+func (f *LocaleFormatter) Locale() string {
+	return f.locale
+}
+
+// GroupSeparator returns the thousands separator of the locale
+func (f *LocaleFormatter) GroupSeparator() string {
+	return f.groupSep
+}
+
+// TimeStrings returns the relative time strings of the locale
+func (f *LocaleFormatter) TimeStrings() *LocaleTimeStrings {
+	return f.timeStrings
+}
+
+// FormatNumber formats an integer with the thousands separators of the locale
+func (f *LocaleFormatter) FormatNumber(num uint64) string {
+	return f.printer.Sprintf("%d", num)
+}
+
+// FormatFloat formats a float with up to precision decimals and the thousands separators of the locale, trailing zeros are trimmed
+func (f *LocaleFormatter) FormatFloat(num float64, precision int) string {
+	s := f.printer.Sprintf(fmt.Sprintf("%%.%vf", precision), num)
+	if precision > 0 {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), f.decimalSep)
+	}
+	return s
+}
+
+// FormatEthFromGwei formats a gwei amount as ETH with 4 decimals
+func (f *LocaleFormatter) FormatEthFromGwei(gwei uint64) string {
+	return f.FormatEthFromGweiShort(gwei) + " ETH"
+}
+
+// FormatEthFromGweiShort formats a gwei amount as ETH with 4 decimals, without unit.
+// ETH amounts are not grouped, only the decimal separator is localized.
+func (f *LocaleFormatter) FormatEthFromGweiShort(gwei uint64) string {
+	return f.localizeDecimal(fmt.Sprintf("%.4f", float64(gwei)/math.Pow10(9)))
+}
+
+// FormatEthFromGweiSigned formats a signed gwei amount as ETH with 6 decimals and sign
+func (f *LocaleFormatter) FormatEthFromGweiSigned(gwei int64) string {
+	return f.localizeDecimal(fmt.Sprintf("%+.6f", float64(gwei)/math.Pow10(9))) + " ETH"
+}
+
+func (f *LocaleFormatter) localizeDecimal(num string) string {
+	if f.decimalSep == "." {
+		return num
+	}
+	return strings.Replace(num, ".", f.decimalSep, 1)
+}
+
+// FormatRecentTime formats the distance of the time to now, e.g. "5 min. ago" or "in 2 hr."
+func (f *LocaleFormatter) FormatRecentTime(ts time.Time) string {
+	duration := time.Until(ts)
+	absDuration := duration.Abs()
+
+	var timeStr string
+	if absDuration < 1*time.Second {
+		return f.timeStrings.Now
+	} else if absDuration < 60*time.Second {
+		timeStr = fmt.Sprintf(f.timeStrings.Seconds, uint(absDuration.Seconds()))
+	} else if absDuration < 60*time.Minute {
+		timeStr = fmt.Sprintf(f.timeStrings.Minutes, uint(absDuration.Minutes()))
+	} else if absDuration < 24*time.Hour {
+		timeStr = fmt.Sprintf(f.timeStrings.Hours, uint(absDuration.Hours()))
+	} else {
+		timeStr = fmt.Sprintf(f.timeStrings.Days, uint(absDuration.Hours()/24))
+	}
+
+	if duration < 0 {
+		return fmt.Sprintf(f.timeStrings.Ago, timeStr)
+	}
+	return fmt.Sprintf(f.timeStrings.In, timeStr)
+}
@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -92,6 +94,14 @@ func InitLogger() (*LogWriter, logrus.FieldLogger) {
 		})
 	}
 
+	if Config.Logging.BufferSize > 0 {
+		logBuffer = &LogBufferHook{
+			entries:   make([]*BufferedLogEntry, Config.Logging.BufferSize),
+			LogLevels: getLogLevels(logrus.InfoLevel),
+		}
+		logger.AddHook(logBuffer)
+	}
+
 	return logWriter, logger
 }
 
@@ -200,6 +210,71 @@ func (hook *LogWriterHook) Levels() []logrus.Level {
 	return hook.LogLevels
 }
 
+// BufferedLogEntry is a log line kept in the in-memory log buffer
+type BufferedLogEntry struct {
+	Time  time.Time
+	Level logrus.Level
+	Line  string
+}
+
+// LogBufferHook is a hook that keeps the most recent log lines in a ring buffer
+type LogBufferHook struct {
+	LogLevels []logrus.Level
+
+	mutex   sync.Mutex
+	entries []*BufferedLogEntry
+	next    int
+}
+
+var logBuffer *LogBufferHook
+
+// Fire will be called when some logging function is called with current hook
+// It will format the log entry and put it into the ring buffer, replacing the oldest entry
+func (hook *LogBufferHook) Fire(entry *logrus.Entry) error {
+	line, err := entry.String()
+	if err != nil {
+		return err
+	}
+
+	hook.mutex.Lock()
+	defer hook.mutex.Unlock()
+
+	hook.entries[hook.next] = &BufferedLogEntry{
+		Time:  entry.Time,
+		Level: entry.Level,
+		Line:  line,
+	}
+	hook.next = (hook.next + 1) % len(hook.entries)
+	return nil
+}
+
+func (hook *LogBufferHook) Levels() []logrus.Level {
+	return hook.LogLevels
+}
+
+// GetBufferedLogs returns the buffered log lines that were logged within the given time range, oldest first.
+// returns nil if the log buffer is disabled.
+func GetBufferedLogs(fromTime time.Time, toTime time.Time) []*BufferedLogEntry {
+	if logBuffer == nil {
+		return nil
+	}
+
+	logBuffer.mutex.Lock()
+	defer logBuffer.mutex.Unlock()
+
+	entries := []*BufferedLogEntry{}
+	bufferSize := len(logBuffer.entries)
+	for i := 0; i < bufferSize; i++ {
+		entry := logBuffer.entries[(logBuffer.next+i)%bufferSize]
+		if entry == nil || entry.Time.Before(fromTime) || entry.Time.After(toTime) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
 // LogFatal logs a fatal error with callstack info that skips callerSkip many levels with arbitrarily many additional infos.
 // callerSkip equal to 0 gives you info directly where LogFatal is called.
 func LogFatal(err error, errorMsg interface{}, callerSkip int, additionalInfos ...map[string]interface{}) {
@@ -55,6 +55,7 @@ func GetTemplateFuncs() template.FuncMap {
 		"formatEthFromGwei":            FormatETHFromGwei,
 		"formatEthFromGweiShort":       FormatETHFromGweiShort,
 		"formatFullEthFromGwei":        FormatFullETHFromGwei,
+		"formatEthFromGweiSigned":      FormatETHFromGweiSigned,
 		"formatEthAddCommasFromGwei":   FormatETHAddCommasFromGwei,
 		"formatAmount":                 FormatAmount,
 		"ethBlockLink":                 FormatEthBlockLink,
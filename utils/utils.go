@@ -2,6 +2,8 @@ package utils
 
 import (
 	"encoding/hex"
+	"fmt"
+	"strconv"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -45,3 +47,24 @@ func SyncCommitteeParticipation(bits []byte, syncCommitteeSize uint64) float64 {
 	}
 	return float64(participating) / float64(syncCommitteeSize)
 }
+
+// ParseValidatorIndexRange parses a single validator index ("5") or an index range ("0-999").
+func ParseValidatorIndexRange(indexRange string) (uint64, uint64, error) {
+	rangeParts := strings.Split(strings.TrimSpace(indexRange), "-")
+	minIndex, err := strconv.ParseUint(strings.TrimSpace(rangeParts[0]), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range %v", indexRange)
+	}
+
+	maxIndex := minIndex
+	if len(rangeParts) == 2 {
+		maxIndex, err = strconv.ParseUint(strings.TrimSpace(rangeParts[1]), 10, 64)
+		if err != nil || maxIndex < minIndex {
+			return 0, 0, fmt.Errorf("invalid range %v", indexRange)
+		}
+	} else if len(rangeParts) > 2 {
+		return 0, 0, fmt.Errorf("invalid range %v", indexRange)
+	}
+
+	return minIndex, maxIndex, nil
+}